@@ -0,0 +1,77 @@
+// file: cmd/devgen_test.go
+// version: 1.0.0
+// guid: e2a8c4d6-1f37-4b95-8c0a-6d3b9e7f2a14
+
+package cmd
+
+import (
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+)
+
+func TestGenerateDevgenLibrary(t *testing.T) {
+	books := generateDevgenLibrary(rand.New(rand.NewPCG(1, 2)), 2000, 10, 30)
+	if len(books) != 2000 {
+		t.Fatalf("expected 2000 books, got %d", len(books))
+	}
+	dups, multi, series := 0, 0, 0
+	for i, b := range books {
+		if b.DuplicateOf >= 0 {
+			dups++
+			orig := books[b.DuplicateOf]
+			if b.DuplicateOf >= i || orig.DuplicateOf >= 0 || orig.Title != b.Title || orig.Author != b.Author {
+				t.Fatalf("book %d is not a copy of an earlier original: %+v", i, b)
+			}
+			continue
+		}
+		if b.Parts > 1 {
+			multi++
+		}
+		if b.Series != "" {
+			series++
+		}
+	}
+	// Loose bounds: the exact split depends on the random stream.
+	if dups < 100 || dups > 300 {
+		t.Errorf("expected about 10%% duplicates, got %d", dups)
+	}
+	if multi < 400 || multi > 800 {
+		t.Errorf("expected about 30%% multi-file books, got %d", multi)
+	}
+	if series == 0 {
+		t.Error("expected some books in series")
+	}
+}
+
+func TestWriteDevgenBookTags(t *testing.T) {
+	b := devgenBook{
+		Title: "Crown of the Hollow Tide", Author: "Ada Falk", Narrator: "Wen Ridley",
+		Series: "The Forge Saga", SeriesIndex: 3, Year: 2001, Parts: 3, DuplicateOf: -1,
+	}
+	paths, err := writeDevgenBook(t.TempDir(), b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 files, got %d", len(paths))
+	}
+	if _, err := os.Stat(paths[2]); err != nil {
+		t.Fatal(err)
+	}
+	md, err := metadata.ExtractMetadata(paths[1], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.UsedFilenameFallback {
+		t.Fatal("tags were not readable")
+	}
+	if md.Artist != "Ada Falk" || md.Album != b.Title || md.Series != "The Forge Saga" || md.SeriesIndex != 3 {
+		t.Errorf("unexpected tags: artist=%q album=%q series=%q index=%d", md.Artist, md.Album, md.Series, md.SeriesIndex)
+	}
+	if md.Narrator != "Wen Ridley" {
+		t.Errorf("expected narrator Wen Ridley, got %q", md.Narrator)
+	}
+}
@@ -0,0 +1,92 @@
+// file: cmd/doctor.go
+// version: 1.0.0
+// guid: c2bf90ef-6805-4dcd-9659-d1decad62620
+//
+// `doctor` runs the startup self-check (internal/doctor) and prints an
+// actionable report. The same report is served at GET /api/v1/system/doctor.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/doctor"
+	"github.com/spf13/cobra"
+)
+
+var doctorJSON bool
+var doctorSkipNetwork bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check database, config, paths, tools, providers and disk space",
+	Long: `Run a self-check of the installation and print an actionable report.
+
+Checks: database readability, configuration validity, root/database/import
+path permissions, ffmpeg/ffprobe availability, metadata provider
+reachability, and free disk space. Exits non-zero if any check fails.`,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "print the report as JSON")
+	doctorCmd.Flags().BoolVar(&doctorSkipNetwork, "skip-network", false, "skip metadata provider reachability probes")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	opts := doctor.Options{
+		Config:      config.Snapshot(),
+		SkipNetwork: doctorSkipNetwork,
+	}
+	// A store that fails to open is itself a finding, so report it via
+	// the database check rather than aborting the whole run.
+	store, err := initializeStore(config.AppConfig.DatabaseType, config.AppConfig.DatabasePath, config.AppConfig.EnableSQLite)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open database: %v\n", err)
+	} else {
+		defer closeStore()
+		opts.Store = store
+		if loadErr := loadConfigFromDB(store); loadErr == nil {
+			syncConfigFromEnv()
+			opts.Config = config.Snapshot()
+		}
+	}
+
+	report := doctor.Run(context.Background(), opts)
+	if doctorJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(cmd.OutOrStdout(), report)
+	}
+	if report.Overall == doctor.StatusFail {
+		return fmt.Errorf("doctor found %d failing check(s)", report.Counts()[doctor.StatusFail])
+	}
+	return nil
+}
+
+func printDoctorReport(w io.Writer, report *doctor.Report) {
+	category := ""
+	for _, c := range report.Checks {
+		if c.Category != category {
+			category = c.Category
+			fmt.Fprintf(w, "\n%s\n", strings.ToUpper(category))
+		}
+		fmt.Fprintf(w, "  [%-4s] %s: %s\n", c.Status, c.Name, c.Message)
+		if c.Remedy != "" && c.Status != doctor.StatusOK {
+			fmt.Fprintf(w, "         -> %s\n", c.Remedy)
+		}
+	}
+	counts := report.Counts()
+	fmt.Fprintf(w, "\nOverall: %s (%d ok, %d warn, %d fail, %d skipped)\n",
+		report.Overall, counts[doctor.StatusOK], counts[doctor.StatusWarn], counts[doctor.StatusFail], counts[doctor.StatusSkip])
+}
@@ -0,0 +1,178 @@
+// file: cmd/layout.go
+// version: 1.1.0
+// guid: 1a9d4e73-b5c2-4f08-8e6a-c3f7b2d05a91
+//
+// `layout export` writes the organized library's author/series/book
+// layout as a portable structure file; `layout apply` re-creates it from
+// the same files on another machine, without needing a database there.
+// The server serves the same at GET /api/v1/library/layout and
+// POST /api/v1/library/layout/apply.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/layout"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var layoutFormat string
+var layoutOut string
+var layoutSource string
+var layoutTarget string
+var layoutMode string
+var layoutDryRun bool
+
+var layoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Export or re-create the organized library layout",
+}
+
+var layoutExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the library's author/series/book layout as JSON or YAML",
+	Long: `Write every organized book (those under ROOT_DIR) as an author → series →
+book hierarchy, with each file's path relative to ROOT_DIR and its hash.
+
+Example:
+  audiobook-organizer layout export --out library.yaml`,
+	RunE: runLayoutExport,
+}
+
+var layoutApplyCmd = &cobra.Command{
+	Use:   "apply FILE",
+	Short: "Re-create an exported layout from files in a source directory",
+	Long: `Re-create the layout in FILE under --target (default ROOT_DIR), moving or
+symlinking files found under --source. Each file is looked for at its own
+relative path first, then anywhere under --source with the same size and
+hash. Nothing at the target is overwritten. Run a scan afterwards to
+import the result.
+
+Example:
+  audiobook-organizer layout apply library.yaml --source /mnt/old --mode symlink --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLayoutApply,
+}
+
+func init() {
+	layoutExportCmd.Flags().StringVar(&layoutFormat, "format", "", "json or yaml (default: from --out extension, else json)")
+	layoutExportCmd.Flags().StringVar(&layoutOut, "out", "", "output file (default: stdout)")
+	layoutApplyCmd.Flags().StringVar(&layoutSource, "source", "", "directory holding the files (required)")
+	layoutApplyCmd.Flags().StringVar(&layoutTarget, "target", "", "directory to re-create the layout in (default: ROOT_DIR)")
+	layoutApplyCmd.Flags().StringVar(&layoutMode, "mode", layout.ModeMove, "move or symlink")
+	layoutApplyCmd.Flags().BoolVar(&layoutDryRun, "dry-run", false, "report what would happen without changing anything")
+	layoutCmd.AddCommand(layoutExportCmd)
+	layoutCmd.AddCommand(layoutApplyCmd)
+}
+
+func runLayoutExport(cmd *cobra.Command, _ []string) error {
+	if config.AppConfig.RootDir == "" {
+		return fmt.Errorf("root directory not specified — pass --dir or set ROOT_DIR")
+	}
+	format := layoutFormat
+	if format == "" {
+		format = "json"
+		if ext := strings.ToLower(filepath.Ext(layoutOut)); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+	if format != "json" && format != "yaml" {
+		return fmt.Errorf("--format must be json or yaml")
+	}
+
+	store, err := initializeStore(config.AppConfig.DatabaseType, config.AppConfig.DatabasePath, config.AppConfig.EnableSQLite)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer closeStore()
+
+	l, skipped, err := layout.Export(store, config.AppConfig.RootDir)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if format == "yaml" {
+		data, err = yaml.Marshal(l)
+	} else {
+		data, err = json.MarshalIndent(l, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	if layoutOut == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+	} else {
+		err = os.WriteFile(layoutOut, data, 0o644)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d files; skipped %d books outside %s\n", len(l.Files()), skipped, config.AppConfig.RootDir)
+	return nil
+}
+
+func runLayoutApply(cmd *cobra.Command, args []string) error {
+	if layoutSource == "" {
+		return fmt.Errorf("--source is required")
+	}
+	target := layoutTarget
+	if target == "" {
+		target = config.AppConfig.RootDir
+	}
+	if target == "" {
+		return fmt.Errorf("target directory not specified — pass --target, --dir or set ROOT_DIR")
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	l, err := layout.Parse(data)
+	if err != nil {
+		return fmt.Errorf("read layout %s: %w", args[0], err)
+	}
+
+	result, err := layout.Apply(context.Background(), l, layout.ApplyOptions{
+		SourceDir:  layoutSource,
+		TargetRoot: target,
+		Mode:       layoutMode,
+		DryRun:     layoutDryRun,
+	})
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	for _, f := range result.Files {
+		switch f.Status {
+		case layout.StatusPresent:
+			continue
+		case layout.StatusFailed, layout.StatusConflict:
+			fmt.Fprintf(out, "%-11s %s: %s\n", f.Status, f.Path, f.Error)
+		default:
+			fmt.Fprintf(out, "%-11s %s\n", f.Status, f.Path)
+		}
+	}
+	statuses := make([]string, 0, len(result.Counts))
+	for s := range result.Counts {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	parts := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		parts = append(parts, fmt.Sprintf("%d %s", result.Counts[s], s))
+	}
+	fmt.Fprintf(out, "%d files: %s\n", len(result.Files), strings.Join(parts, ", "))
+	if result.Counts[layout.StatusFailed] > 0 {
+		return fmt.Errorf("%d files failed", result.Counts[layout.StatusFailed])
+	}
+	return nil
+}
@@ -1,5 +1,5 @@
 // file: cmd/root.go
-// version: 1.12.0
+// version: 1.19.0
 // guid: 6a7b8c9d-0e1f-2a3b-4c5d-6e7f8a9b0c1d
 
 package cmd
@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -221,6 +222,10 @@ var serveCmd = &cobra.Command{
 	Short: "Start the web server",
 	Long:  `Start the web server to provide a web interface for audiobook management.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Everything the server stamps is UTC; schedules use config.Location.
+		config.UseUTCClock()
+		database.SetDigestLocation(config.Location)
+
 		// Setup file logging (logs to both file and stdout)
 		logFile, err := setupFileLogging()
 		if err != nil {
@@ -286,6 +291,19 @@ var serveCmd = &cobra.Command{
 
 		fmt.Println("Starting audiobook organizer web server...")
 
+		// Time every database call for the slow-query log. The wrapper
+		// becomes the global store too, so calls made outside the server
+		// are measured as well.
+		if config.AppConfig.SlowQueryLogEnabled {
+			if ps, ok := store.(*database.PebbleStore); ok {
+				timed := database.NewTimedStore(ps, func() time.Duration {
+					return time.Duration(config.AppConfig.SlowQueryThresholdMs) * time.Millisecond
+				})
+				store = timed
+				database.SetGlobalStore(timed)
+			}
+		}
+
 		// Create and start server. Store is passed explicitly per the 4.4 DI
 		// migration; database.GlobalStore remains assigned for call sites that
 		// haven't yet been migrated to use s.Store().
@@ -297,6 +315,14 @@ var serveCmd = &cobra.Command{
 		// Apply command line flags (use defaults or user-provided values)
 		cfg.Port = cmd.Flag("port").Value.String()
 		cfg.Host = cmd.Flag("host").Value.String()
+		// Saved listen_host / listen_port settings replace the flag
+		// defaults, but not flags given on the command line.
+		if h := config.AppConfig.ListenHost; h != "" && !cmd.Flags().Changed("host") {
+			cfg.Host = h
+		}
+		if p := config.AppConfig.ListenPort; p > 0 && !cmd.Flags().Changed("port") {
+			cfg.Port = strconv.Itoa(p)
+		}
 
 		if rt := cmd.Flag("read-timeout").Value.String(); rt != "" {
 			if d, err := time.ParseDuration(rt); err == nil {
@@ -362,6 +388,10 @@ func init() {
 	rootCmd.AddCommand(diagnosticsCmd)
 	rootCmd.AddCommand(metadataInspectCmd)
 	rootCmd.AddCommand(seedCmd)
+	rootCmd.AddCommand(devgenCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(topCmd)
+	rootCmd.AddCommand(layoutCmd)
 
 	// Add serve command specific flags
 	serveCmd.Flags().String("port", "8484", "port to run the web server on")
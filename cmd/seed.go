@@ -1,5 +1,5 @@
 // file: cmd/seed.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: 7d2e9a4f-1b85-4c63-9f0a-3e8d7b2c1f56
 //
 // `seed` populates a fresh database with synthetic books for local
@@ -171,7 +171,6 @@ func runSeed(cmd *cobra.Command, _ []string) error {
 		filePath := filepath.Join(config.AppConfig.RootDir, "seed", safeAuthor, safeSeries, fmt.Sprintf("%s.%s", safeTitle, format))
 
 		seriesID := series.ID
-		seriesSeq := seq
 		quantity := 1
 		book := &database.Book{
 			ID:             fmt.Sprintf("seed_%s", ulid.Make().String()),
@@ -180,7 +179,7 @@ func runSeed(cmd *cobra.Command, _ []string) error {
 			Format:         format,
 			AuthorID:       authorID,
 			SeriesID:       &seriesID,
-			SeriesSequence: &seriesSeq,
+			SeriesSequence: database.NewSeriesSeq(seq),
 			Duration:       &duration,
 			LibraryState:   &state,
 			Quantity:       &quantity,
@@ -0,0 +1,72 @@
+// file: cmd/top.go
+// version: 1.0.0
+// guid: e51a7c09-3b6d-4f82-a4c1-8d29f6e0b357
+//
+// `top` is a terminal dashboard for a running server: live operation
+// progress, queue depth, recent events and system stats, for headless
+// installs where opening the web UI is inconvenient.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/top"
+	"github.com/spf13/cobra"
+)
+
+var (
+	topServer   string
+	topToken    string
+	topInsecure bool
+	topInterval time.Duration
+	topSince    time.Duration
+	topOnce     bool
+)
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live terminal dashboard of operations and system stats",
+	Long: `Connect to a running server and show running and queued operations with
+progress, recent operation events, and system stats, refreshed live.
+
+The server is reached over its HTTP API, so top works from any machine that
+can reach it. When authentication is enabled pass an API key with --token
+(or AUDIOBOOK_ORGANIZER_TOKEN); system stats need the settings permission
+and are omitted otherwise. Press Ctrl-C to exit.`,
+	RunE: runTop,
+}
+
+func init() {
+	topCmd.Flags().StringVar(&topServer, "server", envOr("AUDIOBOOK_ORGANIZER_URL", "http://localhost:8484"), "server URL (env AUDIOBOOK_ORGANIZER_URL)")
+	topCmd.Flags().StringVar(&topToken, "token", os.Getenv("AUDIOBOOK_ORGANIZER_TOKEN"), "API key or session token (env AUDIOBOOK_ORGANIZER_TOKEN)")
+	topCmd.Flags().BoolVar(&topInsecure, "insecure", false, "skip TLS certificate verification")
+	topCmd.Flags().DurationVar(&topInterval, "interval", 2*time.Second, "refresh interval")
+	topCmd.Flags().DurationVar(&topSince, "since", 24*time.Hour, "how far back to list operations")
+	topCmd.Flags().BoolVar(&topOnce, "once", false, "print one frame and exit")
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func runTop(cmd *cobra.Command, args []string) error {
+	client, err := top.NewClient(topServer, topToken, topInsecure)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return top.Run(ctx, client, topServer, top.Options{
+		Interval: topInterval,
+		Since:    topSince,
+		Once:     topOnce,
+	}, cmd.OutOrStdout())
+}
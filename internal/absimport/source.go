@@ -0,0 +1,350 @@
+// file: internal/absimport/source.go
+// version: 1.0.0
+// guid: 3f8a1d62-b5c7-4e90-9a24-6d0e7c3b1f58
+// last-edited: 2026-10-17
+
+// Package absimport reads what a migration from Audiobookshelf needs —
+// its book libraries, users and their listening progress — either live
+// from an Audiobookshelf server's REST API or from a JSON snapshot of
+// the same responses.
+package absimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+)
+
+// Snapshot is everything read from a server. A snapshot file holds the
+// same object as JSON.
+type Snapshot struct {
+	Libraries []Library `json:"libraries"`
+	Items     []Item    `json:"items"`
+	Users     []User    `json:"users"`
+}
+
+// Library is an Audiobookshelf library. Only "book" libraries are read;
+// podcasts have nothing to import.
+type Library struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	MediaType string `json:"mediaType"`
+}
+
+// Item is a library item: one book, stored as a folder of audio files or
+// a single file.
+type Item struct {
+	ID        string `json:"id"`
+	LibraryID string `json:"libraryId"`
+	Path      string `json:"path"`
+	IsFile    bool   `json:"isFile"`
+	IsMissing bool   `json:"isMissing"`
+	MediaType string `json:"mediaType"`
+	Media     Media  `json:"media"`
+}
+
+// Media is an item's book media.
+type Media struct {
+	Metadata ItemMetadata `json:"metadata"`
+	Tags     []string     `json:"tags"`
+	Duration float64      `json:"duration"`
+}
+
+// ItemMetadata is a book's metadata. Full items list authors, narrators
+// and series; minified ones flatten them into the *Name strings, so both
+// are read.
+type ItemMetadata struct {
+	Title         string      `json:"title"`
+	Subtitle      string      `json:"subtitle"`
+	Authors       []Named     `json:"authors"`
+	AuthorName    string      `json:"authorName"`
+	Narrators     []string    `json:"narrators"`
+	NarratorName  string      `json:"narratorName"`
+	Series        []SeriesRef `json:"series"`
+	SeriesName    string      `json:"seriesName"`
+	Genres        []string    `json:"genres"`
+	PublishedYear string      `json:"publishedYear"`
+	Publisher     string      `json:"publisher"`
+	Description   string      `json:"description"`
+	ISBN          string      `json:"isbn"`
+	ASIN          string      `json:"asin"`
+	Language      string      `json:"language"`
+}
+
+// Named is an author reference.
+type Named struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SeriesRef is a book's place in a series.
+type SeriesRef struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Sequence string `json:"sequence"`
+}
+
+// User is an Audiobookshelf account. Type is root, admin, user or guest.
+type User struct {
+	ID            string          `json:"id"`
+	Username      string          `json:"username"`
+	Type          string          `json:"type"`
+	IsActive      bool            `json:"isActive"`
+	MediaProgress []MediaProgress `json:"mediaProgress"`
+}
+
+// MediaProgress is a user's progress in one item. Progress is 0-1,
+// CurrentTime and Duration are seconds, LastUpdate is Unix milliseconds.
+type MediaProgress struct {
+	LibraryItemID string  `json:"libraryItemId"`
+	EpisodeID     string  `json:"episodeId"`
+	Duration      float64 `json:"duration"`
+	Progress      float64 `json:"progress"`
+	CurrentTime   float64 `json:"currentTime"`
+	IsFinished    bool    `json:"isFinished"`
+	LastUpdate    int64   `json:"lastUpdate"`
+}
+
+// UpdatedAt is when the progress last changed.
+func (p MediaProgress) UpdatedAt() time.Time {
+	if p.LastUpdate <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(p.LastUpdate)
+}
+
+// Authors returns the item's author names.
+func (i Item) Authors() []string {
+	var out []string
+	for _, a := range i.Media.Metadata.Authors {
+		if a.Name != "" {
+			out = append(out, a.Name)
+		}
+	}
+	if len(out) == 0 {
+		out = splitNames(i.Media.Metadata.AuthorName)
+	}
+	return out
+}
+
+// Narrators returns the item's narrator names.
+func (i Item) Narrators() []string {
+	if len(i.Media.Metadata.Narrators) > 0 {
+		return i.Media.Metadata.Narrators
+	}
+	return splitNames(i.Media.Metadata.NarratorName)
+}
+
+// Series returns the item's first series and its sequence; a minified
+// seriesName reads "Name #3, Other #1".
+func (i Item) Series() (name, sequence string) {
+	if s := i.Media.Metadata.Series; len(s) > 0 {
+		return s[0].Name, s[0].Sequence
+	}
+	first, _, _ := strings.Cut(i.Media.Metadata.SeriesName, ", ")
+	first = strings.TrimSpace(first)
+	if n, seq, ok := strings.Cut(first, " #"); ok {
+		return strings.TrimSpace(n), strings.TrimSpace(seq)
+	}
+	return first, ""
+}
+
+// Metadata converts the item's metadata for metafetch to apply.
+func (i Item) Metadata() metadata.BookMetadata {
+	md := i.Media.Metadata
+	m := metadata.BookMetadata{
+		Title:       md.Title,
+		Narrator:    strings.Join(i.Narrators(), ", "),
+		Description: md.Description,
+		Publisher:   md.Publisher,
+		ISBN:        md.ISBN,
+		ASIN:        md.ASIN,
+		Language:    md.Language,
+		DurationSec: int(i.Media.Duration),
+	}
+	if authors := i.Authors(); len(authors) > 0 {
+		m.Author = authors[0]
+	}
+	if len(md.Genres) > 0 {
+		m.Genre = md.Genres[0]
+	}
+	m.Series, m.SeriesPosition = i.Series()
+	if y, err := strconv.Atoi(strings.TrimSpace(md.PublishedYear)); err == nil {
+		m.PublishYear = y
+	}
+	return m
+}
+
+// splitNames splits a flattened "A, B & C" name list.
+func splitNames(s string) []string {
+	var out []string
+	for _, part := range strings.Split(strings.ReplaceAll(s, " & ", ", "), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// ReadSnapshot reads a snapshot file.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("absimport: read snapshot: %w", err)
+	}
+	return &s, nil
+}
+
+// PathMapping rewrites paths under From, as the Audiobookshelf server
+// sees them, to To, as this server sees them.
+type PathMapping struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MapPath applies the longest mapping whose From is p or one of its
+// parent directories; p is returned unchanged when none is.
+func MapPath(p string, mappings []PathMapping) string {
+	best := -1
+	for i, m := range mappings {
+		from := strings.TrimRight(m.From, "/")
+		if from == "" || (p != from && !strings.HasPrefix(p, from+"/")) {
+			continue
+		}
+		if best < 0 || len(from) > len(strings.TrimRight(mappings[best].From, "/")) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return p
+	}
+	rest := strings.TrimPrefix(p, strings.TrimRight(mappings[best].From, "/"))
+	return filepath.Join(mappings[best].To, filepath.FromSlash(rest))
+}
+
+// ErrUsersForbidden is returned by Client.Users when the API token may
+// not list users; only admin tokens can.
+var ErrUsersForbidden = errors.New("absimport: token may not list users")
+
+// Client reads from an Audiobookshelf server's REST API.
+type Client struct {
+	BaseURL string
+	Token   string
+	HTTP    *http.Client
+}
+
+// pageSize is how many items are requested per page.
+const pageSize = 200
+
+// Libraries lists the server's book libraries, limited to ids when given.
+func (c *Client) Libraries(ctx context.Context, ids []string) ([]Library, error) {
+	var resp struct {
+		Libraries []Library `json:"libraries"`
+	}
+	if err := c.get(ctx, "/api/libraries", nil, &resp); err != nil {
+		return nil, err
+	}
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	var out []Library
+	for _, l := range resp.Libraries {
+		if l.MediaType != "" && l.MediaType != "book" {
+			continue
+		}
+		if len(want) > 0 && !want[l.ID] {
+			continue
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+// Items lists every item in a library, a page at a time.
+func (c *Client) Items(ctx context.Context, libraryID string) ([]Item, error) {
+	var items []Item
+	for page := 0; ; page++ {
+		var resp struct {
+			Results []Item `json:"results"`
+			Total   int    `json:"total"`
+		}
+		q := url.Values{"limit": {strconv.Itoa(pageSize)}, "page": {strconv.Itoa(page)}}
+		if err := c.get(ctx, "/api/libraries/"+url.PathEscape(libraryID)+"/items", q, &resp); err != nil {
+			return nil, err
+		}
+		for _, it := range resp.Results {
+			if it.LibraryID == "" {
+				it.LibraryID = libraryID
+			}
+			items = append(items, it)
+		}
+		if len(resp.Results) == 0 || len(items) >= resp.Total {
+			return items, nil
+		}
+	}
+}
+
+// Users lists the server's users with their progress. Tokens that may
+// not list users get ErrUsersForbidden.
+func (c *Client) Users(ctx context.Context) ([]User, error) {
+	var resp struct {
+		Users []User `json:"users"`
+	}
+	if err := c.get(ctx, "/api/users", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Users, nil
+}
+
+// Me returns the token's own user with its progress.
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var u User
+	if err := c.get(ctx, "/api/me", nil, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, q url.Values, out any) error {
+	u := strings.TrimRight(c.BaseURL, "/") + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("absimport: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/json")
+	hc := c.HTTP
+	if hc == nil {
+		hc = &http.Client{Timeout: 2 * time.Minute}
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("absimport: GET %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusForbidden && path == "/api/users":
+		return ErrUsersForbidden
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("absimport: GET %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("absimport: decode %s: %w", path, err)
+	}
+	return nil
+}
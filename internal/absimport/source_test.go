@@ -0,0 +1,98 @@
+// file: internal/absimport/source_test.go
+// version: 1.0.0
+// guid: 9b2e6c41-7d3a-4f15-8e09-c4a5f1d7b362
+
+package absimport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/libraries":
+			_, _ = w.Write([]byte(`{"libraries":[{"id":"lib1","name":"Books","mediaType":"book"},{"id":"pod","name":"Podcasts","mediaType":"podcast"}]}`))
+		case "/api/libraries/lib1/items":
+			if r.URL.Query().Get("page") == "0" {
+				_, _ = w.Write([]byte(`{"total":2,"results":[{"id":"li1","path":"/audiobooks/Frank Herbert/Dune","media":{"metadata":{"title":"Dune","authors":[{"id":"a1","name":"Frank Herbert"}],"narrators":["Scott Brick"],"series":[{"name":"Dune Chronicles","sequence":"1"}],"publishedYear":"1965"},"duration":75600}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"total":2,"results":[{"id":"li2","path":"/audiobooks/Good Omens.m4b","isFile":true,"media":{"metadata":{"title":"Good Omens","authorName":"Terry Pratchett & Neil Gaiman","seriesName":"Omens #2, Other #1"}}}]}`))
+		case "/api/users":
+			w.WriteHeader(http.StatusForbidden)
+		case "/api/me":
+			_, _ = w.Write([]byte(`{"id":"u1","username":"alice","type":"user","mediaProgress":[{"libraryItemId":"li1","currentTime":600,"progress":0.25,"lastUpdate":1700000000000}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	c := &Client{BaseURL: srv.URL + "/", Token: "tok"}
+
+	libs, err := c.Libraries(ctx, nil)
+	if err != nil || len(libs) != 1 || libs[0].ID != "lib1" {
+		t.Fatalf("libraries = %+v, %v", libs, err)
+	}
+	items, err := c.Items(ctx, "lib1")
+	if err != nil || len(items) != 2 {
+		t.Fatalf("items = %+v, %v", items, err)
+	}
+	dune, omens := items[0].Metadata(), items[1]
+	if dune.Author != "Frank Herbert" || dune.Narrator != "Scott Brick" || dune.Series != "Dune Chronicles" ||
+		dune.SeriesPosition != "1" || dune.PublishYear != 1965 || dune.DurationSec != 75600 {
+		t.Errorf("dune metadata = %+v", dune)
+	}
+	if got := omens.Authors(); !reflect.DeepEqual(got, []string{"Terry Pratchett", "Neil Gaiman"}) {
+		t.Errorf("minified authors = %v", got)
+	}
+	if name, seq := omens.Series(); name != "Omens" || seq != "2" {
+		t.Errorf("minified series = %q %q", name, seq)
+	}
+	if omens.LibraryID != "lib1" {
+		t.Errorf("library id not filled in: %q", omens.LibraryID)
+	}
+
+	if _, err := c.Users(ctx); !errors.Is(err, ErrUsersForbidden) {
+		t.Errorf("users: expected ErrUsersForbidden, got %v", err)
+	}
+	me, err := c.Me(ctx)
+	if err != nil || me.Username != "alice" || len(me.MediaProgress) != 1 || me.MediaProgress[0].UpdatedAt().IsZero() {
+		t.Errorf("me = %+v, %v", me, err)
+	}
+
+	bad := &Client{BaseURL: srv.URL, Token: "wrong"}
+	if _, err := bad.Libraries(ctx, nil); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected a 401 error, got %v", err)
+	}
+}
+
+func TestMapPath(t *testing.T) {
+	maps := []PathMapping{
+		{From: "/audiobooks", To: "/srv/books"},
+		{From: "/audiobooks/podcasts/", To: "/srv/pods"},
+	}
+	for in, want := range map[string]string{
+		"/audiobooks/Dune":          filepath.Join("/srv/books", "Dune"),
+		"/audiobooks/podcasts/x":    filepath.Join("/srv/pods", "x"),
+		"/audiobooks":               "/srv/books",
+		"/audiobooks-old/Dune":      "/audiobooks-old/Dune",
+		"/elsewhere/Good Omens.m4b": "/elsewhere/Good Omens.m4b",
+	} {
+		if got := MapPath(in, maps); got != want {
+			t.Errorf("MapPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/activity/register.go
-// version: 1.2.0
+// version: 1.2.1
 // guid: c4d5e6f7-a8b9-0009-2345-000000000009
 
 // Package activity — service registry wiring for the activity log.
@@ -33,7 +33,7 @@ func init() {
 		Groups: []string{"activity"},
 		Build: func(c *serviceregistry.Container) (any, error) {
 			store := serviceregistry.Get[database.Store](c, "store")
-			ps, ok := store.(*database.PebbleStore)
+			ps, ok := database.AsPebbleStore(store)
 			if !ok {
 				// Non-Pebble backend (test double, SQLite) — return nil pointer;
 				// the activitystore Build checks for nil and falls back to NutsDB-only.
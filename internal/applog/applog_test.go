@@ -0,0 +1,101 @@
+// file: internal/applog/applog_test.go
+// version: 1.0.0
+// guid: 0d023cf1-d7b9-41cd-937f-bd9bfa1f5fe2
+
+package applog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+type memStore struct {
+	mu      sync.Mutex
+	entries []database.AppLogEntry
+	pruned  []string
+}
+
+func (m *memStore) AppendAppLogs(entries []database.AppLogEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func (m *memStore) QueryAppLogs(database.AppLogFilter) ([]database.AppLogEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]database.AppLogEntry(nil), m.entries...), nil
+}
+
+func (m *memStore) PruneAppLogs(_ time.Time, level string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruned = append(m.pruned, level)
+	return 0, nil
+}
+
+func TestHandler(t *testing.T) {
+	svc := New(&memStore{}, func() config.Config { return config.Config{AppLogLevel: "debug"} })
+	svc.refreshLevel()
+	var out bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelInfo}), svc))
+	ch, unsubscribe, err := svc.Subscribe()
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	logger.Debug("captured, not printed")
+	logger.With("book_id", "b1").WithGroup("req").Warn("[Dedup] merged", "n", 2, "err", errors.New("boom"))
+	logger.Info("plain line")
+
+	assert.NotContains(t, out.String(), "captured, not printed", "the wrapped handler keeps its own level")
+	assert.Contains(t, out.String(), "plain line")
+
+	debug, warn, info := <-ch, <-ch, <-ch
+	assert.Equal(t, "debug", debug.Level)
+	assert.Equal(t, "applog", debug.Module, "module falls back to the logging package")
+	assert.Equal(t, "warn", warn.Level)
+	assert.Equal(t, "dedup", warn.Module, "a [name] prefix names the module")
+	assert.Equal(t, map[string]any{"book_id": "b1", "req.n": int64(2), "req.err": "boom"}, warn.Attrs)
+	assert.Equal(t, "info", info.Level)
+	assert.Less(t, debug.ID, info.ID, "IDs sort by time")
+
+	assert.Equal(t, "scanner", packageOf("github.com/falkcorp/audiobook-organizer/internal/scanner.(*Service).Scan"))
+	assert.Equal(t, "main", packageOf("main.main"))
+}
+
+func TestServiceStartStop(t *testing.T) {
+	store := &memStore{}
+	cfg := config.Config{AppLogLevel: "warn", AppLogRetentionDays: 14, AppLogDebugRetentionDays: 1}
+	svc := New(store, func() config.Config { return cfg })
+	before := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	t.Cleanup(func() { slog.SetDefault(before) })
+	replaced := slog.Default()
+
+	require.NoError(t, svc.Start(context.Background()))
+	slog.Info("below the capture level")
+	slog.Error("kept")
+	require.NoError(t, svc.Stop(context.Background()))
+	assert.Same(t, replaced, slog.Default(), "Stop restores the previous default")
+
+	got, err := svc.Query(database.AppLogFilter{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "kept", got[0].Message)
+	assert.Equal(t, []string{"debug", ""}, store.pruned, "retention is applied on start")
+
+	_, err = New(nil, func() config.Config { return cfg }).Query(database.AppLogFilter{})
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
@@ -0,0 +1,188 @@
+// file: internal/applog/handler.go
+// version: 1.0.0
+// guid: d0ced455-9d39-44f1-9641-1569f9fa9dbb
+// last-edited: 2026-10-17
+
+package applog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Handler is a slog.Handler that passes every record on to next and hands
+// a copy of those the service captures to it.
+type Handler struct {
+	next slog.Handler
+	svc  *Service
+	// attrs are the WithAttrs attributes, keys already qualified by the
+	// groups open when they were added.
+	attrs  map[string]any
+	prefix string
+}
+
+// NewHandler wraps next so that svc sees every record logged through it.
+func NewHandler(next slog.Handler, svc *Service) *Handler {
+	return &Handler{next: next, svc: svc}
+}
+
+// Enabled reports whether either the wrapped handler or the capture wants
+// records at level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level) || h.svc.captures(level)
+}
+
+// Handle forwards r and captures it. A capture never blocks the caller.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	if h.next.Enabled(ctx, r.Level) {
+		err = h.next.Handle(ctx, r)
+	}
+	if h.svc.captures(r.Level) {
+		h.svc.record(h.entry(r))
+	}
+	return err
+}
+
+// WithAttrs returns a handler whose records carry attrs.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	m := make(map[string]any, len(h.attrs)+len(attrs))
+	for k, v := range h.attrs {
+		m[k] = v
+	}
+	for _, a := range attrs {
+		flatten(m, h.prefix, a)
+	}
+	return &Handler{next: h.next.WithAttrs(attrs), svc: h.svc, attrs: m, prefix: h.prefix}
+}
+
+// WithGroup returns a handler that qualifies later attribute keys with
+// name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{next: h.next.WithGroup(name), svc: h.svc, attrs: h.attrs, prefix: h.prefix + name + "."}
+}
+
+func (h *Handler) entry(r slog.Record) database.AppLogEntry {
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	t = t.UTC()
+	e := database.AppLogEntry{
+		ID:      database.NewAppLogID(t),
+		Time:    t,
+		Level:   levelName(r.Level),
+		Message: strings.TrimRight(r.Message, "\n"),
+	}
+	if len(h.attrs) > 0 || r.NumAttrs() > 0 {
+		e.Attrs = make(map[string]any, len(h.attrs)+r.NumAttrs())
+		for k, v := range h.attrs {
+			e.Attrs[k] = v
+		}
+		r.Attrs(func(a slog.Attr) bool {
+			flatten(e.Attrs, h.prefix, a)
+			return true
+		})
+	}
+	e.Module = moduleOf(e, r.PC)
+	return e
+}
+
+// flatten adds a to m under prefix, expanding groups into dotted keys and
+// reducing values to ones that survive a JSON round trip.
+func flatten(m map[string]any, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, g := range a.Value.Group() {
+			flatten(m, prefix, g)
+		}
+		return
+	}
+	m[prefix+a.Key] = plain(a.Value)
+}
+
+func plain(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		return v.Uint64()
+	case slog.KindFloat64:
+		if f := v.Float64(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return f
+		}
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindTime:
+		return v.Time().UTC()
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		return fmt.Sprint(v.Any())
+	}
+	return v.String()
+}
+
+func levelName(l slog.Level) string {
+	switch {
+	case l >= slog.LevelError:
+		return "error"
+	case l >= slog.LevelWarn:
+		return "warn"
+	case l >= slog.LevelInfo:
+		return "info"
+	}
+	return "debug"
+}
+
+// moduleOf names the subsystem a line came from: a module or component
+// attribute, else a "[name]" message prefix, else the logging package.
+func moduleOf(e database.AppLogEntry, pc uintptr) string {
+	for _, key := range []string{"module", "component"} {
+		if s, ok := e.Attrs[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	if strings.HasPrefix(e.Message, "[") {
+		if end := strings.IndexByte(e.Message, ']'); end > 1 && !strings.ContainsAny(e.Message[1:end], " \t") {
+			return strings.ToLower(e.Message[1:end])
+		}
+	}
+	if pc == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return packageOf(frame.Function)
+}
+
+// packageOf returns the last element of a function's package path:
+// ".../internal/scanner.(*Service).Scan" gives "scanner".
+func packageOf(function string) string {
+	name := function
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,29 @@
+// file: internal/applog/register.go
+// version: 1.0.0
+
+package applog
+
+import (
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
+)
+
+func init() {
+	// applog: the application log shares the main PebbleDB instance. On
+	// other backends the service is built without a store and captures
+	// nothing; GET /system/logs then falls back to operation logs.
+	serviceregistry.Register(serviceregistry.ServiceDef{
+		Name:   "applog",
+		Needs:  []string{"store"},
+		Groups: []string{"core"},
+		Build: func(c *serviceregistry.Container) (any, error) {
+			store := serviceregistry.Get[database.Store](c, "store")
+			ps, ok := database.AsPebbleStore(store)
+			if !ok {
+				return New(nil, config.Snapshot), nil
+			}
+			return New(database.NewPebbleAppLogStore(ps.DB()), config.Snapshot), nil
+		},
+	})
+}
@@ -0,0 +1,251 @@
+// file: internal/applog/service.go
+// version: 1.0.0
+// guid: 43a22259-a313-4d4e-8e3a-b94daabddeb6
+// last-edited: 2026-10-17
+
+// Package applog keeps the server's own log. It wraps the default slog
+// handler so every line logged through slog or the standard log package
+// is also written, with its level, time and module, to the application
+// log store, where GET /system/logs pages through it and
+// GET /system/logs/stream tails it.
+package applog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+const (
+	// flushInterval is how often captured lines are written out.
+	flushInterval = 250 * time.Millisecond
+	// pruneInterval is how often retention is applied.
+	pruneInterval = time.Hour
+	// bufferSize bounds the lines waiting for a flush; past it lines are
+	// dropped rather than blocking the code that logged them.
+	bufferSize = 4096
+	// subscriberBuffer is each tail's backlog before it drops lines.
+	subscriberBuffer = 256
+)
+
+// levelOff disables capture.
+const levelOff = slog.Level(1 << 20)
+
+// ErrUnavailable is returned when no application log store is running,
+// as on non-Pebble backends.
+var ErrUnavailable = errors.New("application log is not available")
+
+// Store is the application log store surface.
+type Store interface {
+	AppendAppLogs(entries []database.AppLogEntry) error
+	QueryAppLogs(f database.AppLogFilter) ([]database.AppLogEntry, error)
+	PruneAppLogs(olderThan time.Time, level string) (int, error)
+}
+
+// Service captures log lines into a Store and fans them out to tails.
+type Service struct {
+	store  Store
+	config func() config.Config
+	level  atomic.Int64
+
+	pending chan database.AppLogEntry
+	dropped atomic.Int64
+
+	mu   sync.Mutex
+	subs map[chan database.AppLogEntry]struct{}
+
+	// prev is the default logger Start replaced, restored by Stop and
+	// used for the service's own errors so they are not captured.
+	prev *slog.Logger
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns a service writing to store. A nil store gives a service
+// that captures nothing and reports ErrUnavailable.
+func New(store Store, cfg func() config.Config) *Service {
+	s := &Service{
+		store:   store,
+		config:  cfg,
+		pending: make(chan database.AppLogEntry, bufferSize),
+		subs:    map[chan database.AppLogEntry]struct{}{},
+	}
+	s.level.Store(int64(levelOff))
+	return s
+}
+
+// Start installs the capturing handler as the slog default and starts the
+// flush and retention loop.
+func (s *Service) Start(context.Context) error {
+	if s.store == nil || s.stop != nil {
+		return nil
+	}
+	s.refreshLevel()
+	s.prev = slog.Default()
+	next := s.prev.Handler()
+	// slog's built-in handler writes through the log package, which a
+	// replaced default routes back into slog; wrapping it would recurse.
+	if fmt.Sprintf("%T", next) == "*slog.defaultHandler" {
+		next = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo})
+	}
+	slog.SetDefault(slog.New(NewHandler(next, s)))
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run()
+	return nil
+}
+
+// Stop restores the previous default logger and flushes what is pending.
+func (s *Service) Stop(context.Context) error {
+	if s.stop == nil {
+		return nil
+	}
+	slog.SetDefault(s.prev)
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+	return nil
+}
+
+// Query returns stored lines matching f, newest first.
+func (s *Service) Query(f database.AppLogFilter) ([]database.AppLogEntry, error) {
+	if s == nil || s.store == nil {
+		return nil, ErrUnavailable
+	}
+	return s.store.QueryAppLogs(f)
+}
+
+// Subscribe returns a channel of lines as they are captured and a func
+// that ends the subscription. A subscriber that falls behind misses lines.
+func (s *Service) Subscribe() (<-chan database.AppLogEntry, func(), error) {
+	if s == nil || s.store == nil {
+		return nil, nil, ErrUnavailable
+	}
+	ch := make(chan database.AppLogEntry, subscriberBuffer)
+	s.mu.Lock()
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subs, ch)
+			s.mu.Unlock()
+		})
+	}, nil
+}
+
+// Dropped is how many lines were not stored because the buffer was full.
+func (s *Service) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+func (s *Service) captures(l slog.Level) bool {
+	return int64(l) >= s.level.Load()
+}
+
+// record queues e for the store and hands it to every tail.
+func (s *Service) record(e database.AppLogEntry) {
+	select {
+	case s.pending <- e:
+	default:
+		s.dropped.Add(1)
+	}
+	s.mu.Lock()
+	for ch := range s.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+	s.mu.Unlock()
+}
+
+func (s *Service) refreshLevel() {
+	level := levelOff
+	switch strings.ToLower(s.config().AppLogLevel) {
+	case "debug":
+		level = slog.LevelDebug
+	case "", "info":
+		level = slog.LevelInfo
+	case "warn":
+		level = slog.LevelWarn
+	case "error":
+		level = slog.LevelError
+	}
+	s.level.Store(int64(level))
+}
+
+func (s *Service) run() {
+	defer close(s.done)
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+	s.prune()
+	for {
+		select {
+		case <-s.stop:
+			s.flush()
+			return
+		case <-flush.C:
+			s.refreshLevel()
+			s.flush()
+		case <-prune.C:
+			s.prune()
+		}
+	}
+}
+
+// flush writes everything pending in one batch.
+func (s *Service) flush() {
+	var batch []database.AppLogEntry
+drain:
+	for len(batch) < bufferSize {
+		select {
+		case e := <-s.pending:
+			batch = append(batch, e)
+		default:
+			break drain
+		}
+	}
+	if len(batch) == 0 {
+		return
+	}
+	// WHY recover: a write racing the store's Close at shutdown panics
+	// inside Pebble; losing those lines is fine, crashing is not.
+	defer func() {
+		if r := recover(); r != nil {
+			s.prev.Warn("applog: write panicked", "panic", r)
+		}
+	}()
+	if err := s.store.AppendAppLogs(batch); err != nil {
+		s.prev.Warn("applog: write failed", "lines", len(batch), "err", err)
+	}
+}
+
+// prune applies the retention settings.
+func (s *Service) prune() {
+	cfg := s.config()
+	now := time.Now()
+	if days := cfg.AppLogDebugRetentionDays; days > 0 {
+		if _, err := s.store.PruneAppLogs(now.AddDate(0, 0, -days), "debug"); err != nil {
+			s.prev.Warn("applog: prune debug lines failed", "err", err)
+		}
+	}
+	if days := cfg.AppLogRetentionDays; days > 0 {
+		if _, err := s.store.PruneAppLogs(now.AddDate(0, 0, -days), ""); err != nil {
+			s.prev.Warn("applog: prune failed", "err", err)
+		}
+	}
+}
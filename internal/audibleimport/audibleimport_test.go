@@ -0,0 +1,129 @@
+// file: internal/audibleimport/audibleimport_test.go
+// version: 1.0.0
+// guid: 0b6e2f97-41a8-4d3c-9f75-e8c1a3d20b64
+// last-edited: 2026-10-16
+
+package audibleimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exportTSV = "\ufeffasin\ttitle\tsubtitle\tauthors\tnarrators\tseries_title\tseries_sequence\tgenres\truntime_length_min\trelease_date\tpurchase_date\n" +
+	"B002V0QK4C\tThe Way of Kings\tThe Stormlight Archive, Book 1\tBrandon Sanderson\tMichael Kramer, Kate Reading\tThe Stormlight Archive\t1\tScience Fiction & Fantasy, Fantasy\t2743\t2010-08-31\t2019-04-12T10:11:12Z\n" +
+	"b00ejlzbyq\tDune\t\tFrank Herbert\tScott Brick\t\t\t\t1270\t2007-04-26\t2020-01-02\n" +
+	"B00EJLZBYQ\tDune (duplicate)\t\t\t\t\t\t\t\t\t\n" +
+	"\tNo ASIN\t\t\t\t\t\t\t\t\t\n"
+
+func TestParseExport(t *testing.T) {
+	titles, err := ParseExport(strings.NewReader(exportTSV))
+	require.NoError(t, err)
+	require.Len(t, titles, 2)
+
+	wok := titles[0]
+	assert.Equal(t, "B002V0QK4C", wok.ASIN)
+	assert.Equal(t, []string{"Michael Kramer", "Kate Reading"}, wok.Narrators)
+	assert.Equal(t, "1", wok.SeriesSequence)
+	assert.Equal(t, 2743, wok.RuntimeMin)
+	require.NotNil(t, wok.PurchaseDate)
+	assert.Equal(t, 2019, wok.PurchaseDate.Year())
+
+	meta := wok.Metadata()
+	assert.Equal(t, "Brandon Sanderson", meta.Author)
+	assert.Equal(t, "Michael Kramer, Kate Reading", meta.Narrator)
+	assert.Equal(t, "Science Fiction & Fantasy", meta.Genre)
+	assert.Equal(t, 2010, meta.PublishYear)
+	assert.Equal(t, 2743*60, meta.DurationSec)
+
+	assert.Equal(t, "B00EJLZBYQ", titles[1].ASIN)
+	assert.Equal(t, "Dune", titles[1].Title)
+
+	csv := "ASIN,Title,Author,Date_Added\nB00EJLZBYQ,\"Dune, Deluxe\",Frank Herbert,2020-01-02\n"
+	titles, err = ParseExport(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, titles, 1)
+	assert.Equal(t, "Dune, Deluxe", titles[0].Title)
+	assert.NotNil(t, titles[0].PurchaseDate)
+
+	_, err = ParseExport(strings.NewReader("title\tauthors\nDune\tFrank Herbert\n"))
+	assert.ErrorIs(t, err, ErrNoASINColumn)
+}
+
+func TestMatchFiles(t *testing.T) {
+	titles := []Title{
+		{ASIN: "B002V0QK4C", Title: "The Way of Kings", Subtitle: "The Stormlight Archive, Book 1"},
+		{ASIN: "B00EJLZBYQ", Title: "Dune"},
+		{ASIN: "B07KX1XW5R", Title: "Project Hail Mary"},
+		{ASIN: "B0000000AA", Title: "Echo"},
+		{ASIN: "B0000000BB", Title: "Echo"},
+	}
+	files := []string{
+		"/dl/B002V0QK4C_something.aax",
+		"/dl/Dune-AAX_44_128.aax",
+		"/dl/Project_Hail_Mary-AAXC_44_128.aaxc",
+		"/dl/Echo-AAX_44_64.aax",
+		"/dl/Unknown Book.aax",
+	}
+	res := MatchFiles(titles, files)
+	got := map[string]string{}
+	for _, m := range res.Matched {
+		got[m.Title.ASIN] = m.By + ":" + filepath.Base(m.File)
+	}
+	assert.Equal(t, map[string]string{
+		"B002V0QK4C": "asin:B002V0QK4C_something.aax",
+		"B00EJLZBYQ": "title:Dune-AAX_44_128.aax",
+		"B07KX1XW5R": "title:Project_Hail_Mary-AAXC_44_128.aaxc",
+	}, got)
+	// Two titles named "Echo" make the title match ambiguous.
+	assert.Len(t, res.UnmatchedTitles, 2)
+	assert.Equal(t, []string{"/dl/Echo-AAX_44_64.aax", "/dl/Unknown Book.aax"}, res.UnmatchedFiles)
+}
+
+func TestFindFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a/One.aax", "b/Two.AAXC", "b/Two.voucher", "c/Three.m4b", ".hidden/Four.aax"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+	files, err := FindFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a/One.aax"), filepath.Join(dir, "b/Two.AAXC")}, files)
+}
+
+func TestConvertInputs(t *testing.T) {
+	assert.NoError(t, ValidateActivationBytes("1a2B3c4D"))
+	assert.Error(t, ValidateActivationBytes("1a2b3c"))
+	assert.Error(t, ValidateActivationBytes("zzzzzzzz"))
+
+	m := Match{Title: Title{ASIN: "B00EJLZBYQ", Title: "Dune: Part 1/2"}, File: "/dl/Dune.aax"}
+	assert.Equal(t, "/out/Dune Part 1 2 [B00EJLZBYQ].m4b", OutputPath(m, "/out"))
+	assert.Equal(t, "/dl/Dune Part 1 2 [B00EJLZBYQ].m4b", OutputPath(m, ""))
+
+	args := strings.Join(ConvertArgs("in.aax", "out.m4b", "1a2b3c4d", nil), " ")
+	assert.Contains(t, args, "-activation_bytes 1a2b3c4d -i in.aax")
+	assert.Contains(t, args, "-c copy")
+	args = strings.Join(ConvertArgs("in.aaxc", "out.m4b", "", &Voucher{Key: "k", IV: "v"}), " ")
+	assert.Contains(t, args, "-audible_key k -audible_iv v -i in.aaxc")
+	assert.NotContains(t, args, "activation_bytes")
+
+	dir := t.TempDir()
+	aaxc := filepath.Join(dir, "Book-AAXC_44_128.aaxc")
+	_, err := ReadVoucher(aaxc)
+	assert.Error(t, err)
+	require.NoError(t, os.WriteFile(strings.TrimSuffix(aaxc, ".aaxc")+".voucher",
+		[]byte(`{"content_license":{"license_response":{"key":"abc","iv":"def"}}}`), 0o644))
+	v, err := ReadVoucher(aaxc)
+	require.NoError(t, err)
+	assert.Equal(t, Voucher{Key: "abc", IV: "def"}, *v)
+
+	_, err = Convert(context.Background(), m, ConvertOptions{FFmpeg: "ffmpeg"})
+	assert.ErrorIs(t, err, ErrNoActivationBytes)
+}
@@ -0,0 +1,155 @@
+// file: internal/audibleimport/convert.go
+// version: 1.0.0
+// guid: d51b7e04-92a3-4c6f-8e0d-6a3f2c91b857
+// last-edited: 2026-10-16
+
+package audibleimport
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrNoActivationBytes is returned when an AAX file needs converting and
+// no activation bytes are configured.
+var ErrNoActivationBytes = errors.New("audibleimport: activation bytes are required to convert AAX files")
+
+// ValidateActivationBytes checks that s is the 8 hex digits (4 bytes)
+// Audible uses to decrypt an account's AAX files.
+func ValidateActivationBytes(s string) error {
+	if b, err := hex.DecodeString(s); err != nil || len(b) != 4 {
+		return fmt.Errorf("audibleimport: activation bytes must be 8 hex digits")
+	}
+	return nil
+}
+
+// Voucher holds the per-file key and IV that decrypt an AAXC file.
+// audible-cli saves it next to the download as "<name>.voucher".
+type Voucher struct {
+	Key string
+	IV  string
+}
+
+// ReadVoucher loads the voucher belonging to an AAXC file.
+func ReadVoucher(aaxcPath string) (*Voucher, error) {
+	path := strings.TrimSuffix(aaxcPath, filepath.Ext(aaxcPath)) + ".voucher"
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("audibleimport: AAXC file needs its voucher: %w", err)
+	}
+	var v struct {
+		ContentLicense struct {
+			LicenseResponse struct {
+				Key string `json:"key"`
+				IV  string `json:"iv"`
+			} `json:"license_response"`
+		} `json:"content_license"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("audibleimport: parse voucher %s: %w", path, err)
+	}
+	lr := v.ContentLicense.LicenseResponse
+	if lr.Key == "" || lr.IV == "" {
+		return nil, fmt.Errorf("audibleimport: voucher %s has no key/iv", path)
+	}
+	return &Voucher{Key: lr.Key, IV: lr.IV}, nil
+}
+
+// ConvertOptions controls Convert.
+type ConvertOptions struct {
+	// FFmpeg is the ffmpeg binary.
+	FFmpeg string
+	// ActivationBytes decrypt AAX files; AAXC files use their voucher.
+	ActivationBytes string
+	// OutputDir receives the M4B. Empty writes next to the source file.
+	OutputDir string
+}
+
+// OutputPath is where Convert writes m's M4B: the title and ASIN, so two
+// editions with the same title don't collide.
+func OutputPath(m Match, outputDir string) string {
+	if outputDir == "" {
+		outputDir = filepath.Dir(m.File)
+	}
+	name := safeName(m.Title.Title)
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(m.File), filepath.Ext(m.File))
+	}
+	return filepath.Join(outputDir, fmt.Sprintf("%s [%s].m4b", name, m.Title.ASIN))
+}
+
+var unsafeChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]+`)
+
+func safeName(s string) string {
+	s = strings.TrimSpace(unsafeChars.ReplaceAllString(s, " "))
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 150 {
+		s = strings.TrimSpace(string(r[:150]))
+	}
+	return strings.Trim(s, ".")
+}
+
+// ConvertArgs returns the ffmpeg arguments that decrypt in to out. The
+// audio, chapters, tags and embedded cover are copied as-is, so the
+// conversion is lossless and fast.
+func ConvertArgs(in, out, activationBytes string, v *Voucher) []string {
+	args := []string{"-y", "-nostdin", "-loglevel", "error"}
+	if v != nil {
+		args = append(args, "-audible_key", v.Key, "-audible_iv", v.IV)
+	} else {
+		args = append(args, "-activation_bytes", activationBytes)
+	}
+	return append(args,
+		"-i", in,
+		"-map", "0:a", "-map", "0:v?",
+		"-map_metadata", "0", "-map_chapters", "0",
+		"-c", "copy", "-disposition:v", "attached_pic",
+		"-movflags", "+faststart",
+		"-f", "mp4", out,
+	)
+}
+
+// Convert decrypts m.File into an M4B and returns its path. The output is
+// written to a temporary name and renamed into place, so an interrupted
+// run never leaves a truncated file at the final path.
+func Convert(ctx context.Context, m Match, opts ConvertOptions) (string, error) {
+	var voucher *Voucher
+	if strings.EqualFold(filepath.Ext(m.File), ".aaxc") {
+		v, err := ReadVoucher(m.File)
+		if err != nil {
+			return "", err
+		}
+		voucher = v
+	} else {
+		if opts.ActivationBytes == "" {
+			return "", ErrNoActivationBytes
+		}
+		if err := ValidateActivationBytes(opts.ActivationBytes); err != nil {
+			return "", err
+		}
+	}
+
+	out := OutputPath(m, opts.OutputDir)
+	if err := os.MkdirAll(filepath.Dir(out), 0o775); err != nil {
+		return "", fmt.Errorf("audibleimport: create output dir: %w", err)
+	}
+	tmp := strings.TrimSuffix(out, ".m4b") + ".converting.m4b"
+	cmd := exec.CommandContext(ctx, opts.FFmpeg, ConvertArgs(m.File, tmp, opts.ActivationBytes, voucher)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("audibleimport: ffmpeg %s: %w: %s", filepath.Base(m.File), err, strings.TrimSpace(string(output)))
+	}
+	if err := os.Rename(tmp, out); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("audibleimport: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,181 @@
+// file: internal/audibleimport/export.go
+// version: 1.0.0
+// guid: 8c3e51a9-4f2d-4b70-9e16-a05d7c2b48f3
+// last-edited: 2026-10-16
+
+// Package audibleimport imports a user's Audible purchases: it reads a
+// library export (the TSV written by `audible library export`, or the CSV
+// variant), matches its titles to downloaded AAX/AAXC files, and decrypts
+// those into M4B files the importer can take.
+package audibleimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+)
+
+// Title is one row of a library export.
+type Title struct {
+	ASIN           string     `json:"asin"`
+	Title          string     `json:"title"`
+	Subtitle       string     `json:"subtitle,omitempty"`
+	Authors        []string   `json:"authors,omitempty"`
+	Narrators      []string   `json:"narrators,omitempty"`
+	Series         string     `json:"series,omitempty"`
+	SeriesSequence string     `json:"series_sequence,omitempty"`
+	Genres         []string   `json:"genres,omitempty"`
+	RuntimeMin     int        `json:"runtime_min,omitempty"`
+	ReleaseDate    *time.Time `json:"release_date,omitempty"`
+	PurchaseDate   *time.Time `json:"purchase_date,omitempty"`
+}
+
+// columns maps each Title field to the header names it is read from.
+// The first name is the audible-cli column; the rest are aliases seen in
+// hand-edited or third-party exports.
+var columns = map[string][]string{
+	"asin":            {"asin"},
+	"title":           {"title"},
+	"subtitle":        {"subtitle"},
+	"authors":         {"authors", "author"},
+	"narrators":       {"narrators", "narrator"},
+	"series":          {"series_title", "series"},
+	"series_sequence": {"series_sequence", "series_position", "book_number"},
+	"genres":          {"genres", "genre"},
+	"runtime":         {"runtime_length_min", "runtime_min", "length_min"},
+	"release_date":    {"release_date"},
+	"purchase_date":   {"purchase_date", "date_added", "date_purchased"},
+}
+
+// ErrNoASINColumn is returned when the export has no ASIN column; ASINs
+// are what tie a row to its file and to Audible metadata.
+var ErrNoASINColumn = errors.New("audibleimport: export has no asin column")
+
+// ParseExport reads a library export. The delimiter is a tab when the
+// header line contains one, otherwise a comma. Rows without an ASIN are
+// skipped, as are repeats of an ASIN already read.
+func ParseExport(r io.Reader) ([]Title, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("audibleimport: read export: %w", err)
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	header, _, _ := strings.Cut(text, "\n")
+
+	cr := csv.NewReader(strings.NewReader(text))
+	cr.LazyQuotes = true
+	cr.FieldsPerRecord = -1
+	if strings.Contains(header, "\t") {
+		cr.Comma = '\t'
+	}
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("audibleimport: parse export: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrNoASINColumn
+	}
+
+	index := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	col := make(map[string]int, len(columns))
+	for field, names := range columns {
+		col[field] = -1
+		for _, name := range names {
+			if i, ok := index[name]; ok {
+				col[field] = i
+				break
+			}
+		}
+	}
+	if col["asin"] < 0 {
+		return nil, ErrNoASINColumn
+	}
+
+	seen := make(map[string]bool)
+	var titles []Title
+	for _, row := range rows[1:] {
+		get := func(field string) string {
+			if i := col[field]; i >= 0 && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		asin := strings.ToUpper(get("asin"))
+		if asin == "" || seen[asin] {
+			continue
+		}
+		seen[asin] = true
+		t := Title{
+			ASIN:           asin,
+			Title:          get("title"),
+			Subtitle:       get("subtitle"),
+			Authors:        splitList(get("authors")),
+			Narrators:      splitList(get("narrators")),
+			Series:         get("series"),
+			SeriesSequence: get("series_sequence"),
+			Genres:         splitList(get("genres")),
+			ReleaseDate:    parseDate(get("release_date")),
+			PurchaseDate:   parseDate(get("purchase_date")),
+		}
+		if n, err := strconv.ParseFloat(get("runtime"), 64); err == nil && n > 0 {
+			t.RuntimeMin = int(n)
+		}
+		titles = append(titles, t)
+	}
+	return titles, nil
+}
+
+// Metadata converts t to the form metadata sources return, so it can be
+// applied to a book like any fetched result. Only the first genre is
+// kept; books carry a single genre.
+func (t Title) Metadata() metadata.BookMetadata {
+	m := metadata.BookMetadata{
+		Title:          t.Title,
+		Narrator:       strings.Join(t.Narrators, ", "),
+		ASIN:           t.ASIN,
+		Series:         t.Series,
+		SeriesPosition: t.SeriesSequence,
+		DurationSec:    t.RuntimeMin * 60,
+	}
+	if len(t.Authors) > 0 {
+		m.Author = t.Authors[0]
+	}
+	if len(t.Genres) > 0 {
+		m.Genre = t.Genres[0]
+	}
+	if t.ReleaseDate != nil {
+		m.PublishYear = t.ReleaseDate.Year()
+	}
+	return m
+}
+
+// splitList splits the comma-separated people and genre columns.
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02", "01/02/2006", "01-02-06"}
+
+func parseDate(s string) *time.Time {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
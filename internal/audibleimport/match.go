@@ -0,0 +1,162 @@
+// file: internal/audibleimport/match.go
+// version: 1.0.0
+// guid: 3f7a0d82-6c1e-4e95-b2d8-91c4e6f05a7b
+// last-edited: 2026-10-16
+
+package audibleimport
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match pairs an export title with its downloaded file.
+type Match struct {
+	Title Title  `json:"title"`
+	File  string `json:"file"`
+	// By is how the file was matched: "asin" or "title".
+	By string `json:"by"`
+}
+
+// MatchResult is the outcome of MatchFiles.
+type MatchResult struct {
+	Matched []Match `json:"matched"`
+	// UnmatchedTitles have no downloaded file (not downloaded yet, or
+	// named so that neither the ASIN nor the title can be recognized).
+	UnmatchedTitles []Title `json:"unmatched_titles,omitempty"`
+	// UnmatchedFiles are AAX/AAXC files no export row claims.
+	UnmatchedFiles []string `json:"unmatched_files,omitempty"`
+}
+
+// FindFiles returns the .aax and .aaxc files under dir, sorted.
+func FindFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".aax", ".aaxc":
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// asinPattern finds an ASIN in a file name: "B" plus nine letters or
+// digits for Audible originals, ten digits for titles that share their
+// ISBN-10.
+var asinPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(b[0-9a-z]{9}|[0-9]{9}[0-9x])(?:[^a-z0-9]|$)`)
+
+// downloadSuffix is the codec suffix audible-cli appends to file names,
+// e.g. "Title-AAX_44_128.aax" or "Title-AAXC_44_128.aaxc".
+var downloadSuffix = regexp.MustCompile(`(?i)[-_ ]aaxc?(_\d+)*$`)
+
+// MatchFiles pairs titles with files. A file naming a title's ASIN wins;
+// otherwise a file whose name, minus the download suffix, is the title
+// (with or without its subtitle) is taken when exactly one file fits. A
+// file is never matched to two titles.
+func MatchFiles(titles []Title, files []string) MatchResult {
+	byASIN := make(map[string]int, len(titles))
+	for i, t := range titles {
+		byASIN[t.ASIN] = i
+	}
+	fileTitle := make(map[string]int, len(files))
+	how := make(map[string]string, len(files))
+	claimed := make(map[int]bool, len(titles))
+
+	var loose []string
+	for _, f := range files {
+		stem := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		if i, ok := asinIn(stem, byASIN); ok && !claimed[i] {
+			fileTitle[f], how[f] = i, "asin"
+			claimed[i] = true
+			continue
+		}
+		loose = append(loose, f)
+	}
+
+	// Title matching only considers titles and file names that are
+	// unambiguous among what is left.
+	byName := make(map[string][]int)
+	for i, t := range titles {
+		if claimed[i] {
+			continue
+		}
+		names := []string{normalize(t.Title)}
+		if t.Subtitle != "" {
+			names = append(names, normalize(t.Title+" "+t.Subtitle))
+		}
+		for _, n := range names {
+			if n != "" {
+				byName[n] = append(byName[n], i)
+			}
+		}
+	}
+	fileNames := make(map[string][]string)
+	for _, f := range loose {
+		stem := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		n := normalize(downloadSuffix.ReplaceAllString(stem, ""))
+		fileNames[n] = append(fileNames[n], f)
+	}
+	var unmatchedFiles []string
+	for _, f := range loose {
+		stem := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		n := normalize(downloadSuffix.ReplaceAllString(stem, ""))
+		if cands := byName[n]; len(cands) == 1 && len(fileNames[n]) == 1 && !claimed[cands[0]] {
+			fileTitle[f], how[f] = cands[0], "title"
+			claimed[cands[0]] = true
+			continue
+		}
+		unmatchedFiles = append(unmatchedFiles, f)
+	}
+
+	var res MatchResult
+	for _, f := range files {
+		if i, ok := fileTitle[f]; ok {
+			res.Matched = append(res.Matched, Match{Title: titles[i], File: f, By: how[f]})
+		}
+	}
+	for i, t := range titles {
+		if !claimed[i] {
+			res.UnmatchedTitles = append(res.UnmatchedTitles, t)
+		}
+	}
+	res.UnmatchedFiles = unmatchedFiles
+	return res
+}
+
+// asinIn returns the title index of the first known ASIN in stem.
+func asinIn(stem string, byASIN map[string]int) (int, bool) {
+	for _, m := range asinPattern.FindAllStringSubmatch(stem, -1) {
+		if i, ok := byASIN[strings.ToUpper(m[1])]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// normalize lowercases s and keeps only letters and digits, so
+// punctuation and separator differences between an export title and a
+// file name don't matter.
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
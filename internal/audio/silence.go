@@ -0,0 +1,166 @@
+// file: internal/audio/silence.go
+// version: 1.0.0
+// guid: 2c7e9a14-6b3f-4d58-a0e1-f94d3b8c6a27
+
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Silence is one quiet stretch found by DetectSilences, in seconds.
+type Silence struct {
+	StartSec float64 `json:"start_sec"`
+	EndSec   float64 `json:"end_sec"`
+}
+
+// Mid is the point chapter boundaries are placed at.
+func (s Silence) Mid() float64 { return (s.StartSec + s.EndSec) / 2 }
+
+// SilenceOptions tunes silence detection and chapter proposal.
+type SilenceOptions struct {
+	// NoiseDB is the level below which audio counts as silence.
+	NoiseDB float64 `json:"noise_db"`
+	// MinSilenceSec is the shortest silence considered a break.
+	// Narrators pause for a second or so mid-chapter; chapter breaks in
+	// produced audiobooks are usually two seconds or longer.
+	MinSilenceSec float64 `json:"min_silence_sec"`
+	// MinChapterSec is the shortest chapter ProposeChapters creates.
+	MinChapterSec float64 `json:"min_chapter_sec"`
+}
+
+// DefaultSilenceOptions are tuned for commercially produced audiobooks.
+var DefaultSilenceOptions = SilenceOptions{NoiseDB: -30, MinSilenceSec: 2, MinChapterSec: 300}
+
+// withDefaults fills zero fields from DefaultSilenceOptions.
+func (o SilenceOptions) withDefaults() SilenceOptions {
+	if o.NoiseDB == 0 {
+		o.NoiseDB = DefaultSilenceOptions.NoiseDB
+	}
+	if o.MinSilenceSec <= 0 {
+		o.MinSilenceSec = DefaultSilenceOptions.MinSilenceSec
+	}
+	if o.MinChapterSec <= 0 {
+		o.MinChapterSec = DefaultSilenceOptions.MinChapterSec
+	}
+	return o
+}
+
+// DetectSilences runs ffmpeg's silencedetect filter over path. The whole
+// file is decoded, so this takes a while on long books; progress, when
+// non-nil, is called with the position reached in seconds.
+func DetectSilences(ctx context.Context, ffmpegPath, path string, opts SilenceOptions, progress func(posSec float64)) ([]Silence, error) {
+	opts = opts.withDefaults()
+	filter := fmt.Sprintf("silencedetect=noise=%gdB:d=%g", opts.NoiseDB, opts.MinSilenceSec)
+	cmd := exec.CommandContext(ctx, ffmpegPath,
+		"-nostdin", "-hide_banner", "-nostats", "-progress", "pipe:1",
+		"-i", path, "-vn", "-sn", "-af", filter, "-f", "null", "-")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("silence detect: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("silence detect: start ffmpeg: %w", err)
+	}
+	sc := bufio.NewScanner(stdout)
+	for sc.Scan() {
+		// -progress writes key=value blocks; out_time_us is the position.
+		if v, ok := strings.CutPrefix(sc.Text(), "out_time_us="); ok && progress != nil {
+			if us, err := strconv.ParseInt(v, 10, 64); err == nil && us > 0 {
+				progress(float64(us) / 1e6)
+			}
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("silence detect: ffmpeg: %w: %s", err, lastLine(stderr.String()))
+	}
+	return ParseSilenceDetect(&stderr), nil
+}
+
+var (
+	silenceStartRe = regexp.MustCompile(`silence_start: (-?[0-9.]+)`)
+	silenceEndRe   = regexp.MustCompile(`silence_end: (-?[0-9.]+)`)
+)
+
+// ParseSilenceDetect reads silencedetect's log lines. A silence still
+// open at the end of the file (trailing quiet) is dropped; it can't be a
+// boundary between chapters.
+func ParseSilenceDetect(r io.Reader) []Silence {
+	var out []Silence
+	start := -1.0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if m := silenceStartRe.FindStringSubmatch(line); m != nil {
+			start, _ = strconv.ParseFloat(m[1], 64)
+			start = max(start, 0)
+			continue
+		}
+		if m := silenceEndRe.FindStringSubmatch(line); m != nil && start >= 0 {
+			end, _ := strconv.ParseFloat(m[1], 64)
+			if end > start {
+				out = append(out, Silence{StartSec: start, EndSec: end})
+			}
+			start = -1
+		}
+	}
+	return out
+}
+
+// ProposeChapters turns silences into chapter markers covering
+// [0, durationSec]. Boundaries go in the middle of a silence, taken in
+// order whenever both the chapter it closes and the rest of the book are
+// at least MinChapterSec long, so short pauses near a break don't split
+// it. Chapters are titled "Chapter N" and marked as silence proposals.
+func ProposeChapters(silences []Silence, durationSec float64, opts SilenceOptions) []database.BookChapter {
+	opts = opts.withDefaults()
+	if durationSec <= 0 {
+		return nil
+	}
+	bounds := []float64{0}
+	for _, s := range silences {
+		mid := s.Mid()
+		if mid-bounds[len(bounds)-1] >= opts.MinChapterSec && durationSec-mid >= opts.MinChapterSec {
+			bounds = append(bounds, mid)
+		}
+	}
+	bounds = append(bounds, durationSec)
+	chapters := make([]database.BookChapter, 0, len(bounds)-1)
+	for i := 0; i+1 < len(bounds); i++ {
+		chapters = append(chapters, database.BookChapter{
+			Index:    i + 1,
+			Title:    fmt.Sprintf("Chapter %d", i+1),
+			StartSec: round3(bounds[i]),
+			EndSec:   round3(bounds[i+1]),
+			Source:   database.ChapterSourceSilence,
+		})
+	}
+	return chapters
+}
+
+func round3(v float64) float64 {
+	return float64(int64(v*1000+0.5)) / 1000
+}
+
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
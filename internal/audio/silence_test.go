@@ -0,0 +1,63 @@
+// file: internal/audio/silence_test.go
+// version: 1.0.0
+// guid: 7f2b8d41-3c6e-4a95-b0d7-e518a9c2f364
+
+package audio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestParseSilenceDetect(t *testing.T) {
+	log := `[silencedetect @ 0x55d] silence_start: -0.0120
+[silencedetect @ 0x55d] silence_end: 1.504 | silence_duration: 1.516
+size=N/A time=00:10:00.00 bitrate=N/A speed= 512x
+[silencedetect @ 0x55d] silence_start: 612.25
+[silencedetect @ 0x55d] silence_end: 615.75 | silence_duration: 3.5
+[silencedetect @ 0x55d] silence_start: 1799.5
+`
+	got := ParseSilenceDetect(strings.NewReader(log))
+	want := []Silence{{StartSec: 0, EndSec: 1.504}, {StartSec: 612.25, EndSec: 615.75}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d silences %v, want %v", len(got), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("silence %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestProposeChapters(t *testing.T) {
+	opts := SilenceOptions{MinChapterSec: 300}
+	silences := []Silence{
+		{StartSec: 10, EndSec: 12},     // too close to the start
+		{StartSec: 600, EndSec: 604},   // break at 602
+		{StartSec: 700, EndSec: 702},   // too close to the previous break
+		{StartSec: 1200, EndSec: 1202}, // break at 1201
+		{StartSec: 1400, EndSec: 1402}, // too close to the end
+	}
+	got := ProposeChapters(silences, 1600, opts)
+	want := [][2]float64{{0, 602}, {602, 1201}, {1201, 1600}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d chapters %v, want %d", len(got), got, len(want))
+	}
+	for i, w := range want {
+		ch := got[i]
+		if ch.StartSec != w[0] || ch.EndSec != w[1] {
+			t.Errorf("chapter %d = [%g, %g], want [%g, %g]", i+1, ch.StartSec, ch.EndSec, w[0], w[1])
+		}
+		if ch.Index != i+1 || ch.Title == "" || ch.Source != database.ChapterSourceSilence {
+			t.Errorf("chapter %d = %+v", i+1, ch)
+		}
+	}
+}
+
+func TestProposeChapters_NoDuration(t *testing.T) {
+	if got := ProposeChapters([]Silence{{StartSec: 600, EndSec: 602}}, 0, SilenceOptions{}); got != nil {
+		t.Errorf("expected nil for unknown duration, got %v", got)
+	}
+}
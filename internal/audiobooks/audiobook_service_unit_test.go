@@ -1,13 +1,15 @@
 // file: internal/audiobooks/audiobook_service_unit_test.go
-// version: 1.5.0
+// version: 1.11.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
-// last-edited: 2026-05-01
+// last-edited: 2026-10-17
 
 package audiobooks
 
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/database/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // --- iTunes enqueuer wiring on delete paths ---
@@ -75,15 +78,12 @@ func TestAudiobookService_GetAudiobooks_StoreError(t *testing.T) {
 	mockStore := mocks.NewMockStore(t)
 	svc := NewAudiobookService(mockStore)
 
-	// Errors from GetAllBookSummaries are swallowed: the := inside the if-block
-	// creates a new err variable scoped to that block, so the outer err check
-	// is not reached. The function returns an empty list gracefully.
+	// A failed listing is an error, not an empty library.
 	mockStore.EXPECT().GetAllBookSummaries(50, 0).Return(nil, fmt.Errorf("db connection lost"))
 
 	books, err := svc.GetAudiobooks(context.Background(), 0, 0, "", nil, nil)
-	assert.NoError(t, err)
-	assert.NotNil(t, books)
-	assert.Empty(t, books)
+	assert.ErrorContains(t, err, "db connection lost")
+	assert.Nil(t, books)
 }
 
 func TestAudiobookService_GetAudiobooks_EmptyResult(t *testing.T) {
@@ -139,8 +139,8 @@ func TestAudiobookService_GetAudiobooks_SearchDelegatesToStore(t *testing.T) {
 	svc := NewAudiobookService(mockStore)
 
 	expected := []database.Book{{ID: "b1", Title: "Search Result"}}
-	// No Bleve index wired → falls back to store.SearchBooks
-	mockStore.EXPECT().SearchBooks("test query", 50, 0).Return(expected, nil)
+	// No Bleve index wired → falls back to store.SearchBooksContext
+	mockStore.EXPECT().SearchBooksContext(mock.Anything, "test query", 50, 0).Return(expected, nil)
 
 	books, err := svc.GetAudiobooks(context.Background(), 0, 0, "test query", nil, nil)
 	assert.NoError(t, err)
@@ -148,11 +148,32 @@ func TestAudiobookService_GetAudiobooks_SearchDelegatesToStore(t *testing.T) {
 	assert.Equal(t, "b1", books[0].ID)
 }
 
+func TestAudiobookService_SearchAudiobooksAfter_StoreFallback(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	svc := NewAudiobookService(mockStore)
+
+	matches := []database.Book{{ID: "b3"}, {ID: "b1"}, {ID: "b2"}}
+	mockStore.EXPECT().SearchBooksContext(mock.Anything, "dune", math.MaxInt32, 0).Return(matches, nil).Twice()
+
+	page, next, err := svc.SearchAudiobooksAfter(context.Background(), "dune", nil, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "b1", page[0].ID)
+	assert.Equal(t, "b2", page[1].ID)
+	require.NotNil(t, next)
+
+	page, next, err = svc.SearchAudiobooksAfter(context.Background(), "dune", next, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "b3", page[0].ID)
+	assert.Nil(t, next)
+}
+
 func TestAudiobookService_GetAudiobooks_SearchError(t *testing.T) {
 	mockStore := mocks.NewMockStore(t)
 	svc := NewAudiobookService(mockStore)
 
-	mockStore.EXPECT().SearchBooks("bad", 50, 0).Return(nil, fmt.Errorf("search index corrupt"))
+	mockStore.EXPECT().SearchBooksContext(mock.Anything, "bad", 50, 0).Return(nil, fmt.Errorf("search index corrupt"))
 
 	books, err := svc.GetAudiobooks(context.Background(), 0, 0, "bad", nil, nil)
 	assert.Error(t, err)
@@ -232,7 +253,7 @@ func TestAudiobookService_CountAudiobooks_Success(t *testing.T) {
 	mockStore := mocks.NewMockStore(t)
 	svc := NewAudiobookService(mockStore)
 
-	mockStore.EXPECT().CountBooks().Return(42, nil)
+	mockStore.EXPECT().CountBooksContext(mock.Anything).Return(42, nil)
 
 	count, err := svc.CountAudiobooks(context.Background())
 	assert.NoError(t, err)
@@ -243,7 +264,7 @@ func TestAudiobookService_CountAudiobooks_StoreError(t *testing.T) {
 	mockStore := mocks.NewMockStore(t)
 	svc := NewAudiobookService(mockStore)
 
-	mockStore.EXPECT().CountBooks().Return(0, fmt.Errorf("count failed"))
+	mockStore.EXPECT().CountBooksContext(mock.Anything).Return(0, fmt.Errorf("count failed"))
 
 	count, err := svc.CountAudiobooks(context.Background())
 	assert.Error(t, err)
@@ -821,6 +842,69 @@ func TestGetAudiobooks_UserRatingFilter_LessThan(t *testing.T) {
 	assert.Equal(t, "b1", got[0].ID)
 }
 
+// TestGetAudiobooks_PathFilter verifies library isolation's path filter
+// runs through the list pipeline before pagination.
+func TestGetAudiobooks_PathFilter(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	svc := NewAudiobookService(mockStore)
+
+	summaries := []database.BookSummary{
+		{ID: "a1", FilePath: "/lib/adults/a1.m4b"},
+		{ID: "k1", FilePath: "/lib/kids/k1.m4b"},
+		{ID: "k2", FilePath: "/lib/kids/k2.m4b"},
+	}
+	mockStore.EXPECT().GetAllBookSummaries(0, 0).Return(summaries, nil)
+
+	got, err := svc.GetAudiobooks(context.Background(), 1, 1, "", nil, nil, ListFilters{
+		PathFilter: func(p string) bool { return strings.HasPrefix(p, "/lib/kids/") },
+	})
+	assert.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "k2", got[0].ID)
+}
+
+// TestGetAudiobooks_ContentFilter verifies the content filter judges the
+// stored book, since list projections carry neither genre nor the
+// explicit flag.
+func TestGetAudiobooks_ContentFilter(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	svc := NewAudiobookService(mockStore)
+
+	explicit := true
+	mockStore.EXPECT().GetAllBookSummaries(0, 0).Return([]database.BookSummary{{ID: "kid"}, {ID: "adult"}}, nil)
+	mockStore.EXPECT().GetBookByID("kid").Return(&database.Book{ID: "kid"}, nil)
+	mockStore.EXPECT().GetBookByID("adult").Return(&database.Book{ID: "adult", Explicit: &explicit}, nil)
+
+	got, err := svc.GetAudiobooks(context.Background(), 0, 0, "", nil, nil, ListFilters{
+		ContentFilter: func(b *database.Book) bool { return b.Explicit == nil || !*b.Explicit },
+	})
+	assert.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "kid", got[0].ID)
+}
+
+// TestGetAudiobooks_ContentFilterStoredBooks verifies author, series and
+// search results, which are already stored books, are judged as loaded
+// rather than re-fetched one by one.
+func TestGetAudiobooks_ContentFilterStoredBooks(t *testing.T) {
+	mockStore := mocks.NewMockStore(t)
+	svc := NewAudiobookService(mockStore)
+
+	explicit := true
+	authorID := 7
+	mockStore.EXPECT().GetBooksByAuthorID(authorID).Return([]database.Book{
+		{ID: "kid"}, {ID: "adult", Explicit: &explicit},
+	}, nil)
+
+	got, err := svc.GetAudiobooks(context.Background(), 0, 0, "", &authorID, nil, ListFilters{
+		ContentFilter: func(b *database.Book) bool { return b.Explicit == nil || !*b.Explicit },
+	})
+	assert.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "kid", got[0].ID)
+	mockStore.AssertNotCalled(t, "GetBookByID", mock.Anything)
+}
+
 // ---------------------------------------------------------------------------
 // UpdateAudiobook
 // ---------------------------------------------------------------------------
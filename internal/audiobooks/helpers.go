@@ -1,7 +1,7 @@
 // file: internal/audiobooks/helpers.go
-// version: 1.0.1
+// version: 1.3.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234560010
-// last-edited: 2026-05-05
+// last-edited: 2026-10-17
 //
 // Private utilities needed by the audiobooks service package. These mirror
 // equivalent helpers from internal/server/ but are standalone so that the
@@ -235,6 +235,12 @@ type importPathLister interface {
 	GetAllImportPaths() ([]database.ImportPath, error)
 }
 
+// IsProtectedPath reports whether filePath is somewhere purges never
+// delete files from (see isProtectedPath).
+func IsProtectedPath(store importPathLister, filePath string) bool {
+	return isProtectedPath(store, filePath)
+}
+
 // isProtectedPath returns true if filePath is under a configured import
 // path, an iTunes library path, or another protected location. Takes an
 // explicit importPathLister so callers thread their own database
@@ -282,12 +288,18 @@ func isProtectedPath(store importPathLister, filePath string) bool {
 	return false
 }
 
-// resolveAuthorAndSeriesNames returns the author name and series name
+// AuthorSeriesNameStore is the lookup ResolveAuthorAndSeriesNames needs.
+type AuthorSeriesNameStore interface {
+	GetAuthorByID(id int) (*database.Author, error)
+	GetSeriesByID(id int) (*database.Series, error)
+}
+
+// ResolveAuthorAndSeriesNames returns the author name and series name
 // for book, falling back to a database lookup when the join is not
 // pre-loaded. Takes an explicit store (SERVER-GLOBAL-STORE-AUDIT phase 6).
 // Nil store skips the lookups; inline Book.Author / Book.Series still
 // resolve.
-func resolveAuthorAndSeriesNames(store authorSeriesStore, book *database.Book) (string, string) {
+func ResolveAuthorAndSeriesNames(store AuthorSeriesNameStore, book *database.Book) (string, string) {
 	authorName := ""
 	if book.Author != nil {
 		authorName = book.Author.Name
@@ -348,6 +360,13 @@ func intVal(p *int) any {
 	return *p
 }
 
+func seriesSeqVal(p *database.SeriesSeq) any {
+	if p == nil {
+		return nil
+	}
+	return p.JSONValue()
+}
+
 func nonEmpty(s string) any {
 	if s == "" {
 		return nil
@@ -426,7 +445,7 @@ func buildMetadataProvenance(book *database.Book, state map[string]metadataField
 	if meta.SeriesIndex > 0 {
 		seriesIdx = meta.SeriesIndex
 	}
-	addEntry("series_index", seriesIdx, intVal(book.SeriesSequence))
+	addEntry("series_index", seriesIdx, seriesSeqVal(book.SeriesSequence))
 	addEntry("print_year", nonEmpty(meta.PrintYear), intVal(book.PrintYear))
 	addEntry("edition", nonEmpty(meta.Edition), stringVal(book.Edition))
 	addEntry("description", nonEmpty(meta.Comments), stringVal(book.Description))
@@ -497,8 +516,8 @@ func buildComparisonValuesFromBook(book *database.Book, authorName, seriesName s
 	if book.AudiobookReleaseYear != nil && *book.AudiobookReleaseYear > 0 {
 		compMap["audiobook_release_year"] = *book.AudiobookReleaseYear
 	}
-	if book.SeriesSequence != nil && *book.SeriesSequence > 0 {
-		compMap["series_index"] = *book.SeriesSequence
+	if book.SeriesSequence != nil && *book.SeriesSequence != "" && *book.SeriesSequence != "0" {
+		compMap["series_index"] = book.SeriesSequence.JSONValue()
 	}
 	if book.PrintYear != nil && *book.PrintYear > 0 {
 		compMap["print_year"] = *book.PrintYear
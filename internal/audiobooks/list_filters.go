@@ -0,0 +1,203 @@
+// file: internal/audiobooks/list_filters.go
+// version: 1.0.0
+// guid: 7d3f9b25-c8e1-4a64-9f02-5b6e1a8c3d47
+// last-edited: 2026-10-17
+
+package audiobooks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// MissingMetadataFields are the names accepted by ListFilters.MissingMetadata,
+// each reporting whether a book lacks that field. Only fields memdb keeps
+// are listed, so the check runs inside the list walker.
+var MissingMetadataFields = map[string]func(b *database.Book) bool{
+	"author":    func(b *database.Book) bool { return b.AuthorID == nil && b.Author == nil },
+	"narrator":  func(b *database.Book) bool { return strings.TrimSpace(derefStr(b.Narrator)) == "" },
+	"series":    func(b *database.Book) bool { return b.SeriesID == nil && b.Series == nil },
+	"year":      func(b *database.Book) bool { return bookYear(b) == 0 },
+	"language":  func(b *database.Book) bool { return strings.TrimSpace(derefStr(b.Language)) == "" },
+	"genre":     func(b *database.Book) bool { return strings.TrimSpace(derefStr(b.Genre)) == "" },
+	"publisher": func(b *database.Book) bool { return strings.TrimSpace(derefStr(b.Publisher)) == "" },
+	"cover":     func(b *database.Book) bool { return strings.TrimSpace(derefStr(b.CoverURL)) == "" },
+	"isbn": func(b *database.Book) bool {
+		return strings.TrimSpace(derefStr(b.ISBN10)) == "" && strings.TrimSpace(derefStr(b.ISBN13)) == ""
+	},
+}
+
+// bookYear is the audiobook release year, else the print year, else 0 —
+// the same year the "year" sort uses.
+func bookYear(b *database.Book) int {
+	if y := derefInt(b.AudiobookReleaseYear); y != 0 {
+		return y
+	}
+	return derefInt(b.PrintYear)
+}
+
+// HasAttributeFilters reports whether any per-book attribute filter
+// (format, codec, quality, language, narrator, year range, has_series,
+// missing_metadata) is set.
+func (f ListFilters) HasAttributeFilters() bool {
+	return len(f.Formats) > 0 || len(f.Codecs) > 0 || len(f.Qualities) > 0 || len(f.Languages) > 0 ||
+		f.Narrator != "" || f.YearMin != nil || f.YearMax != nil || f.HasSeries != nil || len(f.MissingMetadata) > 0
+}
+
+// Filtered reports whether f narrows the list at all, so list totals
+// must be counted against the filter instead of the whole library.
+func (f ListFilters) Filtered() bool {
+	return f.IsPrimaryVersion != nil || f.LibraryState != "" || f.Tag != "" || len(f.Tags) > 0 ||
+		len(f.FieldFilters) > 0 || (len(f.PerUserFilters) > 0 && f.UserID != "") ||
+		f.FingerprintStatus != "" || f.CoveragePercentMin != nil || f.CoveragePercentMax != nil ||
+		f.PathFilter != nil || f.ContentFilter != nil || f.HasAttributeFilters()
+}
+
+// MatchesAttributeFilters reports whether b passes every attribute filter
+// in f. b must be the stored book (or its memdb copy); list projections
+// lack most of these fields.
+func MatchesAttributeFilters(b *database.Book, f ListFilters) bool {
+	if len(f.Formats) > 0 && !matchesAnyFold(b.Format, f.Formats) {
+		return false
+	}
+	if len(f.Codecs) > 0 && !matchesAnyFold(derefStr(b.Codec), f.Codecs) {
+		return false
+	}
+	if len(f.Qualities) > 0 && !matchesAnyFold(derefStr(b.Quality), f.Qualities) {
+		return false
+	}
+	if len(f.Languages) > 0 && !matchesAnyFold(derefStr(b.Language), f.Languages) {
+		return false
+	}
+	if f.Narrator != "" && !strings.Contains(strings.ToLower(derefStr(b.Narrator)), strings.ToLower(f.Narrator)) {
+		return false
+	}
+	if f.YearMin != nil || f.YearMax != nil {
+		y := bookYear(b)
+		if y == 0 || (f.YearMin != nil && y < *f.YearMin) || (f.YearMax != nil && y > *f.YearMax) {
+			return false
+		}
+	}
+	if f.HasSeries != nil && (b.SeriesID != nil || b.Series != nil) != *f.HasSeries {
+		return false
+	}
+	if len(f.MissingMetadata) > 0 {
+		missing := false
+		for _, field := range f.MissingMetadata {
+			if isMissing, ok := MissingMetadataFields[field]; ok && isMissing(b) {
+				missing = true
+				break
+			}
+		}
+		if !missing {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAnyFold reports whether value equals one of want, ignoring case.
+func matchesAnyFold(value string, want []string) bool {
+	for _, w := range want {
+		if strings.EqualFold(value, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureSortKeys wraps a list predicate so every row it keeps is recorded
+// in keys by ID. List rows are summary projections without most sort
+// fields; the walker hands the predicate the stored book, which carries
+// them. The recorded pointers are read-only.
+func captureSortKeys(pred func(*database.Book) bool, keys map[string]*database.Book) func(*database.Book) bool {
+	return func(b *database.Book) bool {
+		if pred != nil && !pred(b) {
+			return false
+		}
+		keys[b.ID] = b
+		return true
+	}
+}
+
+// sortListBooks orders books in place by f's sort key. keys, when set,
+// holds the stored book behind each row; rows without one sort by their
+// own fields. Author sorts resolve names from AuthorID, since list rows
+// don't carry the author.
+func (svc *AudiobookService) sortListBooks(books []database.Book, keys map[string]*database.Book, f ListFilters) {
+	cmpFn, ok := sortFieldMap[f.SortBy]
+	if !ok || len(books) < 2 {
+		return
+	}
+	if keys == nil && f.SortBy != "author" {
+		applySorting(books, f)
+		return
+	}
+	key := func(i int) *database.Book {
+		if k, ok := keys[books[i].ID]; ok {
+			return k
+		}
+		return &books[i]
+	}
+	compare := cmpFn
+	if f.SortBy == "author" {
+		names := map[int]string{}
+		authorName := func(b *database.Book) string {
+			if b.Author != nil {
+				return b.Author.Name
+			}
+			if b.AuthorID == nil {
+				return ""
+			}
+			name, ok := names[*b.AuthorID]
+			if !ok {
+				if a, err := svc.store.GetAuthorByID(*b.AuthorID); err == nil && a != nil {
+					name = a.Name
+				}
+				names[*b.AuthorID] = name
+			}
+			return name
+		}
+		compare = func(a, b *database.Book) int {
+			return strings.Compare(strings.ToLower(authorName(a)), strings.ToLower(authorName(b)))
+		}
+	}
+
+	order := make([]int, len(books))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		result := compare(key(a), key(b))
+		if result == 0 {
+			result = strings.Compare(books[a].ID, books[b].ID)
+		}
+		if f.SortOrder == "desc" {
+			return result > 0
+		}
+		return result < 0
+	})
+	sorted := make([]database.Book, len(books))
+	for i, idx := range order {
+		sorted[i] = books[idx]
+	}
+	copy(books, sorted)
+}
+
+// paginateBooks returns the page of books at offset, at most limit long
+// (no limit when limit <= 0).
+func paginateBooks(books []database.Book, limit, offset int) []database.Book {
+	if offset >= len(books) {
+		return []database.Book{}
+	}
+	if offset > 0 {
+		books = books[offset:]
+	}
+	if limit > 0 && limit < len(books) {
+		books = books[:limit]
+	}
+	return books
+}
@@ -0,0 +1,153 @@
+// file: internal/audiobooks/list_filters_test.go
+// version: 1.1.0
+// guid: 3b8e6d14-f2a9-4c57-a0d3-9e1c7f5b2a68
+
+package audiobooks
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedListFilterStore creates a Pebble store holding five books that
+// differ in format, codec, language, narrator, year, series and size.
+func seedListFilterStore(t *testing.T) *database.PebbleStore {
+	t.Helper()
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	require.Eventually(t, store.IsMemReady, 5*time.Second, 10*time.Millisecond)
+
+	zed, err := store.CreateAuthor("Zed Writer")
+	require.NoError(t, err)
+	amy, err := store.CreateAuthor("Amy Author")
+	require.NoError(t, err)
+	bob, err := store.CreateAuthor("Bob Builder")
+	require.NoError(t, err)
+	series, err := store.CreateSeries("Saga", &amy.ID)
+	require.NoError(t, err)
+
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+	size := func(n int64) *int64 { return &n }
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	books := []database.Book{
+		{Title: "Alpha", FilePath: "/lib/alpha.m4b", Format: "m4b", Codec: str("aac"), Language: str("en"),
+			Narrator: str("Jane Doe"), AudiobookReleaseYear: num(2001), AuthorID: &zed.ID, SeriesID: &series.ID, FileSize: size(300)},
+		{Title: "Bravo", FilePath: "/lib/bravo.mp3", Format: "mp3", Codec: str("mp3"), Language: str("en"),
+			Narrator: str("John Roe"), PrintYear: num(1999), AuthorID: &amy.ID, FileSize: size(100)},
+		{Title: "Charlie", FilePath: "/lib/charlie.m4b", Format: "M4B", Codec: str("AAC"), Language: str("de"),
+			Narrator: str("Jane Smith"), AudiobookReleaseYear: num(2015), AuthorID: &bob.ID, FileSize: size(500)},
+		{Title: "Delta", FilePath: "/lib/delta.m4b", Format: "m4b", Codec: str("opus"), Language: str("en"),
+			AudiobookReleaseYear: num(2020), SeriesID: &series.ID, FileSize: size(200)},
+		{Title: "Echo", FilePath: "/lib/echo.mp3", Format: "mp3", Language: str("fr"), Narrator: str("jane doe")},
+	}
+	for i := range books {
+		created := base.Add(time.Duration(i) * time.Hour)
+		books[i].CreatedAt = &created
+		_, err := store.CreateBook(&books[i])
+		require.NoError(t, err)
+	}
+	return store
+}
+
+func listTitles(books []database.Book) []string {
+	titles := make([]string, len(books))
+	for i, b := range books {
+		titles[i] = b.Title
+	}
+	return titles
+}
+
+func TestGetAudiobooks_AttributeFiltersAndSorts(t *testing.T) {
+	store := seedListFilterStore(t)
+	svc := NewAudiobookService(store)
+	ctx := context.Background()
+	yes, no := true, false
+	year := func(n int) *int { return &n }
+
+	for _, memdb := range []bool{true, false} {
+		store.UseMemDB = memdb
+		run := func(limit, offset int, f ListFilters) []string {
+			t.Helper()
+			books, err := svc.GetAudiobooks(ctx, limit, offset, "", nil, nil, f)
+			require.NoError(t, err)
+			return listTitles(books)
+		}
+		count := func(f ListFilters) int {
+			t.Helper()
+			n, err := svc.CountAudiobooksFiltered(ctx, f)
+			require.NoError(t, err)
+			return n
+		}
+
+		byTitle := ListFilters{SortBy: "title"}
+		f := byTitle
+		f.Formats = []string{"m4b"}
+		f.Codecs = []string{"aac"}
+		assert.Equal(t, []string{"Alpha", "Charlie"}, run(0, 0, f), "memdb=%v: format and codec ignore case", memdb)
+		assert.Equal(t, 2, count(f))
+
+		f = byTitle
+		f.Languages = []string{"en"}
+		f.HasSeries = &no
+		assert.Equal(t, []string{"Bravo"}, run(0, 0, f), "memdb=%v", memdb)
+
+		f = byTitle
+		f.Narrator = "JANE"
+		f.YearMin, f.YearMax = year(2000), year(2016)
+		assert.Equal(t, []string{"Alpha", "Charlie"}, run(0, 0, f), "memdb=%v: books without a year fail a year range", memdb)
+
+		f = byTitle
+		f.HasSeries = &yes
+		assert.Equal(t, []string{"Alpha", "Delta"}, run(0, 0, f), "memdb=%v", memdb)
+
+		f = byTitle
+		f.MissingMetadata = []string{"narrator", "author"}
+		assert.Equal(t, []string{"Delta", "Echo"}, run(0, 0, f), "memdb=%v", memdb)
+
+		f = byTitle
+		f.Languages = []string{"es"}
+		assert.Empty(t, run(0, 0, f), "memdb=%v: no match is an empty page, not the library", memdb)
+		assert.Zero(t, count(f))
+
+		// Sorts other than title order the whole set before paging.
+		assert.Equal(t, []string{"Charlie", "Alpha"}, run(2, 0, ListFilters{SortBy: "size", SortOrder: "desc"}), "memdb=%v", memdb)
+		assert.Equal(t, []string{"Delta", "Bravo"}, run(2, 2, ListFilters{SortBy: "size", SortOrder: "desc"}), "memdb=%v", memdb)
+		assert.Equal(t, []string{"Echo", "Delta"}, run(2, 0, ListFilters{SortBy: "added_at", SortOrder: "desc"}), "memdb=%v", memdb)
+		assert.Equal(t, []string{"Delta", "Bravo", "Charlie", "Alpha"}, run(0, 0, ListFilters{SortBy: "author", Languages: []string{"en", "de"}}), "memdb=%v: authorless books sort first", memdb)
+	}
+}
+
+func TestCountAudiobooksMatching(t *testing.T) {
+	store := seedListFilterStore(t)
+	svc := NewAudiobookService(store)
+	ctx := context.Background()
+	amy, err := store.GetAuthorByName("Amy Author")
+	require.NoError(t, err)
+	series, err := store.GetSeriesByName("Saga", &amy.ID)
+	require.NoError(t, err)
+
+	count := func(search string, authorID, seriesID *int, f ListFilters) int {
+		t.Helper()
+		n, err := svc.CountAudiobooksMatching(ctx, search, authorID, seriesID, f)
+		require.NoError(t, err)
+		return n
+	}
+
+	assert.Equal(t, 5, count("", nil, nil, ListFilters{}))
+	assert.Equal(t, 3, count("", nil, nil, ListFilters{Languages: []string{"en"}}))
+	assert.Equal(t, 2, count("", nil, &series.ID, ListFilters{}))
+	assert.Equal(t, 1, count("", nil, &series.ID, ListFilters{Codecs: []string{"opus"}}))
+	assert.Equal(t, 1, count("", &amy.ID, nil, ListFilters{}))
+	assert.Equal(t, 0, count("", &amy.ID, nil, ListFilters{Languages: []string{"de"}}))
+	// Without a search index the count comes from the store's search,
+	// unbounded by any page size.
+	assert.Equal(t, 1, count("charlie", nil, nil, ListFilters{}))
+}
@@ -1,5 +1,5 @@
 // file: internal/audiobooks/organize_preview.go
-// version: 2.0.1
+// version: 2.1.0
 // guid: f1a2b3c4-d5e6-7890-abcd-ef1234567890
 //
 // Thin forwarding layer — the real implementation now lives in
@@ -29,7 +29,7 @@ func NewOrganizePreviewService(db database.Store) *OrganizePreviewService {
 		return isProtectedPath(db, filePath)
 	}
 	svc.ResolveAuthorAndSeriesNames = func(book *database.Book) (string, string) {
-		return resolveAuthorAndSeriesNames(db, book)
+		return ResolveAuthorAndSeriesNames(db, book)
 	}
 	return svc
 }
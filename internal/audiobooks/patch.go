@@ -0,0 +1,248 @@
+// file: internal/audiobooks/patch.go
+// version: 1.0.0
+// guid: bfe02688-50bf-4da8-b1b5-7a092596cce6
+// last-edited: 2026-10-16
+
+package audiobooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+type patchFieldKind int
+
+const (
+	patchString patchFieldKind = iota
+	patchInt
+	patchBool
+)
+
+// bookPatchFields are the fields PATCH /audiobooks/:id may change: the
+// ones UpdateAudiobook applies and records provenance for.
+var bookPatchFields = map[string]patchFieldKind{
+	"title":                  patchString,
+	"author_name":            patchString,
+	"series_name":            patchString,
+	"narrator":               patchString,
+	"publisher":              patchString,
+	"language":               patchString,
+	"isbn10":                 patchString,
+	"isbn13":                 patchString,
+	"audiobook_release_year": patchInt,
+	"explicit":               patchBool,
+}
+
+// ErrPatchTestFailed is returned when a JSON Patch "test" operation does
+// not match the book's current value.
+var ErrPatchTestFailed = errors.New("json patch test operation failed")
+
+// PatchFieldError reports a patch that targets a field which cannot be
+// patched, or sets one to an invalid value.
+type PatchFieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *PatchFieldError) Error() string { return e.Field + ": " + e.Message }
+
+// BookPatchDocument renders the patchable fields of book as the JSON
+// object patches are applied to. Unset fields are null; numbers are
+// float64 so they compare equal to decoded patch values.
+func BookPatchDocument(book *database.Book, authorName, seriesName string) map[string]any {
+	str := func(p *string) any {
+		if p == nil {
+			return nil
+		}
+		return *p
+	}
+	doc := map[string]any{
+		"title":                  book.Title,
+		"author_name":            nil,
+		"series_name":            nil,
+		"narrator":               str(book.Narrator),
+		"publisher":              str(book.Publisher),
+		"language":               str(book.Language),
+		"isbn10":                 str(book.ISBN10),
+		"isbn13":                 str(book.ISBN13),
+		"audiobook_release_year": nil,
+		"explicit":               nil,
+	}
+	if authorName != "" {
+		doc["author_name"] = authorName
+	}
+	if seriesName != "" {
+		doc["series_name"] = seriesName
+	}
+	if book.AudiobookReleaseYear != nil {
+		doc["audiobook_release_year"] = float64(*book.AudiobookReleaseYear)
+	}
+	if book.Explicit != nil {
+		doc["explicit"] = *book.Explicit
+	}
+	return doc
+}
+
+type jsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch to doc and returns the
+// result; doc is not modified. Paths address top-level fields only.
+// "remove" sets a field to null, since the document's fields are fixed.
+func ApplyJSONPatch(doc map[string]any, patch []byte) (map[string]any, error) {
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("invalid JSON Patch document: %w", err)
+	}
+	out := copyDoc(doc)
+	for i, op := range ops {
+		field, err := patchPointerField(op.Path)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add", "replace":
+			if op.Value == nil {
+				return nil, fmt.Errorf("operation %d (%s) requires a value", i, op.Op)
+			}
+			var v any
+			if err := json.Unmarshal(op.Value, &v); err != nil {
+				return nil, fmt.Errorf("operation %d: invalid value: %w", i, err)
+			}
+			out[field] = v
+		case "remove":
+			out[field] = nil
+		case "test":
+			var v any
+			if op.Value != nil {
+				if err := json.Unmarshal(op.Value, &v); err != nil {
+					return nil, fmt.Errorf("operation %d: invalid value: %w", i, err)
+				}
+			}
+			if !reflect.DeepEqual(out[field], v) {
+				return nil, fmt.Errorf("%w: %s", ErrPatchTestFailed, op.Path)
+			}
+		case "copy", "move":
+			from, err := patchPointerField(op.From)
+			if err != nil {
+				return nil, err
+			}
+			out[field] = out[from]
+			if op.Op == "move" && from != field {
+				out[from] = nil
+			}
+		default:
+			return nil, fmt.Errorf("operation %d: unsupported op %q", i, op.Op)
+		}
+	}
+	return out, nil
+}
+
+// ApplyMergePatch applies an RFC 7386 merge patch to doc and returns the
+// result; doc is not modified. A null member clears the field.
+func ApplyMergePatch(doc map[string]any, patch []byte) (map[string]any, error) {
+	var members map[string]any
+	if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+		return nil, fmt.Errorf("merge patch must be a JSON object")
+	}
+	out := copyDoc(doc)
+	for field, v := range members {
+		if _, ok := bookPatchFields[field]; !ok {
+			return nil, &PatchFieldError{Field: field, Message: "field cannot be patched"}
+		}
+		out[field] = v
+	}
+	return out, nil
+}
+
+// BookPatchPayload validates a patched document and returns an update
+// payload, in the shape AudiobookUpdateService.UpdateAudiobook takes,
+// holding only the fields whose value changed. Cleared string fields
+// become ""; numeric and boolean fields cannot be cleared.
+func BookPatchPayload(before, after map[string]any) (map[string]any, error) {
+	fields := make([]string, 0, len(after))
+	for field := range after {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	payload := map[string]any{}
+	for _, field := range fields {
+		kind, ok := bookPatchFields[field]
+		if !ok {
+			return nil, &PatchFieldError{Field: field, Message: "field cannot be patched"}
+		}
+		v := after[field]
+		if reflect.DeepEqual(before[field], v) {
+			continue
+		}
+		switch kind {
+		case patchString:
+			if v == nil {
+				v = ""
+			}
+			s, ok := v.(string)
+			if !ok {
+				return nil, &PatchFieldError{Field: field, Message: "must be a string"}
+			}
+			if field == "title" && strings.TrimSpace(s) == "" {
+				return nil, &PatchFieldError{Field: field, Message: "cannot be empty"}
+			}
+			payload[field] = s
+		case patchInt:
+			if v == nil {
+				return nil, &PatchFieldError{Field: field, Message: "cannot be removed"}
+			}
+			n, ok := v.(float64)
+			if !ok || n != math.Trunc(n) {
+				return nil, &PatchFieldError{Field: field, Message: "must be an integer"}
+			}
+			payload[field] = n
+		case patchBool:
+			if v == nil {
+				return nil, &PatchFieldError{Field: field, Message: "cannot be removed"}
+			}
+			b, ok := v.(bool)
+			if !ok {
+				return nil, &PatchFieldError{Field: field, Message: "must be a boolean"}
+			}
+			payload[field] = b
+		}
+	}
+	return payload, nil
+}
+
+// patchPointerField resolves a JSON Pointer to a patchable top-level field.
+func patchPointerField(pointer string) (string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return "", fmt.Errorf("invalid JSON Pointer %q", pointer)
+	}
+	token := pointer[1:]
+	if strings.Contains(token, "/") {
+		return "", &PatchFieldError{Field: pointer, Message: "nested paths are not supported"}
+	}
+	field := strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	if _, ok := bookPatchFields[field]; !ok {
+		return "", &PatchFieldError{Field: field, Message: "field cannot be patched"}
+	}
+	return field, nil
+}
+
+func copyDoc(doc map[string]any) map[string]any {
+	out := make(map[string]any, len(doc))
+	for k, v := range doc {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+// file: internal/audiobooks/patch_test.go
+// version: 1.0.0
+// guid: 5789f411-3060-4a8c-8f56-57429988eaf6
+// last-edited: 2026-10-16
+
+package audiobooks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func patchTestDoc() map[string]any {
+	narrator := "Scott Brick"
+	year := 2005
+	return BookPatchDocument(&database.Book{
+		Title: "Dune", Narrator: &narrator, AudiobookReleaseYear: &year,
+	}, "Frank Herbert", "")
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	doc := patchTestDoc()
+	patched, err := ApplyJSONPatch(doc, []byte(`[
+		{"op":"test","path":"/title","value":"Dune"},
+		{"op":"replace","path":"/title","value":"Dune Messiah"},
+		{"op":"remove","path":"/narrator"},
+		{"op":"add","path":"/audiobook_release_year","value":2007},
+		{"op":"copy","from":"/author_name","path":"/publisher"}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", doc["title"], "input document must not be modified")
+
+	payload, err := BookPatchPayload(doc, patched)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"title":                  "Dune Messiah",
+		"narrator":               "",
+		"audiobook_release_year": float64(2007),
+		"publisher":              "Frank Herbert",
+	}, payload)
+}
+
+func TestApplyJSONPatch_Errors(t *testing.T) {
+	doc := patchTestDoc()
+
+	_, err := ApplyJSONPatch(doc, []byte(`[{"op":"test","path":"/title","value":"Emma"}]`))
+	assert.ErrorIs(t, err, ErrPatchTestFailed)
+
+	var fieldErr *PatchFieldError
+	_, err = ApplyJSONPatch(doc, []byte(`[{"op":"replace","path":"/file_path","value":"/x"}]`))
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "file_path", fieldErr.Field)
+
+	_, err = ApplyJSONPatch(doc, []byte(`[{"op":"replace","path":"/title/0","value":"x"}]`))
+	assert.True(t, errors.As(err, &fieldErr))
+
+	_, err = ApplyJSONPatch(doc, []byte(`[{"op":"frobnicate","path":"/title"}]`))
+	assert.ErrorContains(t, err, "unsupported op")
+
+	_, err = ApplyJSONPatch(doc, []byte(`{"title":"x"}`))
+	assert.ErrorContains(t, err, "invalid JSON Patch")
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	doc := patchTestDoc()
+	patched, err := ApplyMergePatch(doc, []byte(`{"narrator":null,"explicit":true,"title":"Dune"}`))
+	require.NoError(t, err)
+	payload, err := BookPatchPayload(doc, patched)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"narrator": "", "explicit": true}, payload, "unchanged title is left out")
+
+	_, err = ApplyMergePatch(doc, []byte(`{"id":"other"}`))
+	var fieldErr *PatchFieldError
+	assert.True(t, errors.As(err, &fieldErr))
+}
+
+func TestBookPatchPayload_Validation(t *testing.T) {
+	doc := patchTestDoc()
+	cases := map[string]map[string]any{
+		"title":                  {"title": ""},
+		"narrator":               {"narrator": 5.0},
+		"audiobook_release_year": {"audiobook_release_year": 2001.5},
+		"explicit":               {"explicit": "yes"},
+	}
+	for field, change := range cases {
+		after := copyDoc(doc)
+		for k, v := range change {
+			after[k] = v
+		}
+		_, err := BookPatchPayload(doc, after)
+		var fieldErr *PatchFieldError
+		require.True(t, errors.As(err, &fieldErr), field)
+		assert.Equal(t, field, fieldErr.Field)
+	}
+
+	after := copyDoc(doc)
+	after["audiobook_release_year"] = nil
+	_, err := BookPatchPayload(doc, after)
+	assert.ErrorContains(t, err, "cannot be removed")
+}
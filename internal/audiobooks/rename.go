@@ -1,5 +1,5 @@
 // file: internal/audiobooks/rename.go
-// version: 2.0.1
+// version: 2.1.0
 // guid: e5f6a7b8-c9d0-e1f2-a3b4-c5d6e7f8a9b0
 //
 // Thin forwarding layer — the real implementation now lives in
@@ -34,7 +34,7 @@ func NewRenameService(db database.Store) *RenameService {
 		return isProtectedPath(db, filePath)
 	}
 	svc.ResolveAuthorAndSeriesNames = func(book *database.Book) (string, string) {
-		return resolveAuthorAndSeriesNames(db, book)
+		return ResolveAuthorAndSeriesNames(db, book)
 	}
 	svc.FilterUnchangedTags = metafetch.FilterUnchangedTags
 	svc.ComputeITunesPath = metafetch.ComputeITunesPath
@@ -1,10 +1,11 @@
 // file: internal/audiobooks/revert.go
-// version: 1.2.2
+// version: 1.3.0
 // guid: d4e5f6a7-b8c9-d0e1-f2a3-b4c5d6e7f8a9
 
 package audiobooks
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
@@ -88,6 +89,12 @@ func (rs *RevertService) revertChange(c *database.OperationChange) error {
 		return rs.revertMetadataUpdate(c)
 	case "tag_write":
 		return rs.revertTagWrite(c)
+	case "book_soft_delete":
+		return rs.revertSoftDelete(c)
+	case "version_link":
+		return rs.revertVersionLink(c)
+	case "hash_block":
+		return rs.revertHashBlock(c)
 	case "organize_failed", "organize_skipped", "organize_summary":
 		// No filesystem or DB mutation recorded; nothing to reverse.
 		return nil
@@ -206,3 +213,54 @@ func (rs *RevertService) revertTagWrite(c *database.OperationChange) error {
 
 	return nil
 }
+
+// revertSoftDelete brings back a book soft-deleted by duplicate resolution,
+// restoring the library state recorded in OldValue.
+func (rs *RevertService) revertSoftDelete(c *database.OperationChange) error {
+	book, err := rs.db.GetBookByID(c.BookID)
+	if err != nil || book == nil {
+		return fmt.Errorf("failed to get book %s: %w", c.BookID, err)
+	}
+	book.MarkedForDeletion = nil
+	book.MarkedForDeletionAt = nil
+	book.LibraryState = nil
+	if c.OldValue != "" {
+		state := c.OldValue
+		book.LibraryState = &state
+	}
+	if _, err := rs.db.UpdateBook(book.ID, book); err != nil {
+		return fmt.Errorf("failed to restore book: %w", err)
+	}
+	return nil
+}
+
+// revertVersionLink restores the version group and primary flag a book had
+// before duplicate resolution linked it; OldValue is their JSON snapshot.
+func (rs *RevertService) revertVersionLink(c *database.OperationChange) error {
+	var snap struct {
+		VersionGroupID   *string `json:"version_group_id"`
+		IsPrimaryVersion *bool   `json:"is_primary_version"`
+	}
+	if err := json.Unmarshal([]byte(c.OldValue), &snap); err != nil {
+		return fmt.Errorf("invalid version snapshot: %w", err)
+	}
+	book, err := rs.db.GetBookByID(c.BookID)
+	if err != nil || book == nil {
+		return fmt.Errorf("failed to get book %s: %w", c.BookID, err)
+	}
+	book.VersionGroupID = snap.VersionGroupID
+	book.IsPrimaryVersion = snap.IsPrimaryVersion
+	if _, err := rs.db.UpdateBook(book.ID, book); err != nil {
+		return fmt.Errorf("failed to update book: %w", err)
+	}
+	return nil
+}
+
+// revertHashBlock takes the hash in NewValue off the do-not-import list.
+func (rs *RevertService) revertHashBlock(c *database.OperationChange) error {
+	blocklist, ok := rs.db.(interface{ RemoveBlockedHash(hash string) error })
+	if !ok {
+		return fmt.Errorf("store does not support the hash blocklist")
+	}
+	return blocklist.RemoveBlockedHash(c.NewValue)
+}
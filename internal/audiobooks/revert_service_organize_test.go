@@ -1,5 +1,5 @@
 // file: internal/audiobooks/revert_service_organize_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 4f8c2a1d-5e9b-4f70-a3c6-8d1e0f2b9a47
 
 package audiobooks
@@ -81,7 +81,13 @@ func TestRevertChange_OrganizeNonMutating(t *testing.T) {
 // panic if called, which would surface a regression.
 type stubStoreForRevert struct {
 	database.Store
-	book *database.Book
+	book      *database.Book
+	unblocked []string
+}
+
+func (s *stubStoreForRevert) RemoveBlockedHash(hash string) error {
+	s.unblocked = append(s.unblocked, hash)
+	return nil
 }
 
 func (s *stubStoreForRevert) GetBookByID(id string) (*database.Book, error) {
@@ -92,3 +98,37 @@ func (s *stubStoreForRevert) UpdateBook(id string, b *database.Book) (*database.
 	s.book = b
 	return b, nil
 }
+
+// TestRevertChange_DuplicateResolution verifies the change types written by
+// dedup.ResolveBookDuplicates are reversible.
+func TestRevertChange_DuplicateResolution(t *testing.T) {
+	deleted := true
+	store := &stubStoreForRevert{book: &database.Book{ID: "b1", MarkedForDeletion: &deleted}}
+	rs := NewRevertService(store)
+
+	if err := rs.revertChange(&database.OperationChange{
+		BookID: "b1", ChangeType: "book_soft_delete", FieldName: "library_state", OldValue: "organized", NewValue: "deleted",
+	}); err != nil {
+		t.Fatalf("revert book_soft_delete: %v", err)
+	}
+	if store.book.MarkedForDeletion != nil || store.book.LibraryState == nil || *store.book.LibraryState != "organized" {
+		t.Errorf("book not restored: %+v", store.book)
+	}
+
+	if err := rs.revertChange(&database.OperationChange{
+		BookID: "b1", ChangeType: "version_link", FieldName: "version_group_id",
+		OldValue: `{"version_group_id":"g0","is_primary_version":true}`, NewValue: "g1",
+	}); err != nil {
+		t.Fatalf("revert version_link: %v", err)
+	}
+	if store.book.VersionGroupID == nil || *store.book.VersionGroupID != "g0" || !*store.book.IsPrimaryVersion {
+		t.Errorf("version fields not restored: %+v", store.book)
+	}
+
+	if err := rs.revertChange(&database.OperationChange{ChangeType: "hash_block", NewValue: "h1"}); err != nil {
+		t.Fatalf("revert hash_block: %v", err)
+	}
+	if len(store.unblocked) != 1 || store.unblocked[0] != "h1" {
+		t.Errorf("unblocked = %v, want [h1]", store.unblocked)
+	}
+}
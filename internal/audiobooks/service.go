@@ -1,15 +1,17 @@
 // file: internal/audiobooks/service.go
-// version: 1.30.0
+// version: 1.46.0
 // guid: 5e6f7a8b-9c0d-1e2f-3a4b-5c6d7e8f9a0b
-// last-edited: 2026-05-29
+// last-edited: 2026-10-17
 
 package audiobooks
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -25,6 +27,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/dedup"
 	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+	"github.com/falkcorp/audiobook-organizer/internal/organizer"
 	"github.com/falkcorp/audiobook-organizer/internal/search"
 )
 
@@ -165,6 +168,7 @@ type PurgeResult struct {
 	Attempted    int      `json:"attempted"`
 	Purged       int      `json:"purged"`
 	FilesDeleted int      `json:"files_deleted"`
+	PendingPurge int      `json:"pending_purge"` // moved to trash, purged after trash_retention_days
 	Errors       []string `json:"errors"`
 }
 
@@ -207,6 +211,38 @@ type ListFilters struct {
 	FingerprintStatus  string // "none", "partial", "complete", or "" for any
 	CoveragePercentMin *int   // minimum coverage percentage (inclusive)
 	CoveragePercentMax *int   // maximum coverage percentage (inclusive)
+	// PathFilter, when set, keeps only books whose file path it accepts.
+	// Library isolation uses it to hide other households' libraries.
+	PathFilter func(string) bool
+	// ContentFilter, when set, keeps only books it accepts. It sees the
+	// full book (genre, explicit flag), not the list projection.
+	ContentFilter func(*database.Book) bool
+	// Fields is the caller's sparse fieldset (?fields=); nil means every
+	// field. List builders skip derived data nobody asked for.
+	Fields database.FieldSet
+	// Attribute filters, ANDed with each other and everything above.
+	// Formats, Codecs, Qualities and Languages match any listed value,
+	// ignoring case; Narrator matches a case-insensitive substring;
+	// YearMin/YearMax bound the release year inclusively; HasSeries keeps
+	// books with (true) or without (false) a series; MissingMetadata keeps
+	// books missing any of the named MissingMetadataFields.
+	Formats         []string
+	Codecs          []string
+	Qualities       []string
+	Languages       []string
+	Narrator        string
+	YearMin         *int
+	YearMax         *int
+	HasSeries       *bool
+	MissingMetadata []string
+}
+
+// FileDerivedFields are the AudiobookDetail fields computed from a book's
+// files rather than stored on the book. Lists load files only when one of
+// them is selected.
+var FileDerivedFields = []string{
+	"duration", "file_size", "fingerprint_status", "fingerprinted_file_count",
+	"total_file_count", "coverage_percent", "last_fingerprinted_at",
 }
 
 // PerUserFieldNames is the set of search fields whose values come from
@@ -341,6 +377,19 @@ var sortFieldMap = map[string]func(a, b *database.Book) int{
 	"codec": func(a, b *database.Book) int {
 		return strings.Compare(strings.ToLower(derefStr(a.Codec)), strings.ToLower(derefStr(b.Codec)))
 	},
+	"size": func(a, b *database.Book) int {
+		diff := derefInt64(a.FileSize) - derefInt64(b.FileSize)
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+		return 0
+	},
+	"added_at": func(a, b *database.Book) int {
+		return cmpTime(a.CreatedAt, b.CreatedAt)
+	},
 	"created_at": func(a, b *database.Book) int {
 		return cmpTime(a.CreatedAt, b.CreatedAt)
 	},
@@ -838,9 +887,10 @@ func (svc *AudiobookService) countSummariesPushdownFiltered(filter database.Book
 
 // buildBookSummaryFilter translates service-level ListFilters into a
 // database.BookSummaryFilter the memdb walker can apply in-loop.
-// Returns ok=false when any component CAN'T be pushed down (non-title
-// sort, fingerprint filters), and the caller falls back to the old
-// fetch-all-then-filter path.
+// Returns ok=false when any component CAN'T be pushed down (fingerprint
+// filters), and the caller falls back to the old fetch-all-then-filter
+// path. Only a title sort is pushed down; callers sort any other key
+// themselves after the walker returns every match.
 //
 // pebbleLookups is non-nil when the predicate may invoke a Pebble
 // fallback for memdb-stripped fields (description / version_notes /
@@ -852,9 +902,6 @@ func (svc *AudiobookService) buildBookSummaryFilter(f ListFilters, sortAsc bool)
 }
 
 func (svc *AudiobookService) buildBookSummaryFilterWithLookupCount(f ListFilters, sortAsc bool) (database.BookSummaryFilter, bool, *int64) {
-	if f.SortBy != "" && f.SortBy != "title" {
-		return database.BookSummaryFilter{}, false, nil
-	}
 	if f.FingerprintStatus != "" || f.CoveragePercentMin != nil || f.CoveragePercentMax != nil {
 		return database.BookSummaryFilter{}, false, nil
 	}
@@ -911,7 +958,10 @@ func (svc *AudiobookService) buildBookSummaryFilterWithLookupCount(f ListFilters
 	var predicate func(*database.Book) bool
 	var pebbleLookupsPtr *int64
 	hasPerUser := len(f.PerUserFilters) > 0 && f.UserID != ""
-	if len(remainingFF) > 0 || hasPerUser {
+	pathFilter := f.PathFilter
+	contentFilter := f.ContentFilter
+	hasAttr := f.HasAttributeFilters()
+	if len(remainingFF) > 0 || hasPerUser || pathFilter != nil || contentFilter != nil || hasAttr {
 		store := svc.store
 		userID := f.UserID
 		perUser := f.PerUserFilters
@@ -938,6 +988,15 @@ func (svc *AudiobookService) buildBookSummaryFilterWithLookupCount(f ListFilters
 			return store.GetBookByID(id)
 		}
 		predicate = func(b *database.Book) bool {
+			if pathFilter != nil && !pathFilter(b.FilePath) {
+				return false
+			}
+			if contentFilter != nil && !contentFilter(b) {
+				return false
+			}
+			if hasAttr && !MatchesAttributeFilters(b, f) {
+				return false
+			}
 			if len(remainingFF) > 0 {
 				if !matchesFieldFiltersWithStrippedFallback(b, cheapFF, strippedFF, fetchFull, pebbleLookups, warnFn) {
 					return false
@@ -986,7 +1045,7 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 	}
 
 	// Normalize limit and offset
-	if limit <= 0 || limit > 100000 {
+	if limit <= 0 || limit > maxAudiobookListLimit {
 		limit = 50
 	}
 	if offset < 0 {
@@ -1010,7 +1069,8 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 	// (memdb-backed) can push it down via an indexed iteration — fetching
 	// all 68K rows to satisfy ?is_primary_version=true was the prod
 	// "library spins forever" bug.
-	hasHeavyPostFilters := f.LibraryState != "" || f.Tag != "" || len(f.Tags) > 0 || len(f.FieldFilters) > 0 || hasPerUser || heavySorting || hasFingerprintingFilters
+	hasAttr := f.HasAttributeFilters()
+	hasHeavyPostFilters := f.LibraryState != "" || f.Tag != "" || len(f.Tags) > 0 || len(f.FieldFilters) > 0 || hasPerUser || heavySorting || hasFingerprintingFilters || f.PathFilter != nil || f.ContentFilter != nil || hasAttr
 	hasPostFilters := hasHeavyPostFilters || f.IsPrimaryVersion != nil || titleSortPushdownable
 
 	// When heavy post-filters are active, fetch all and filter in memory.
@@ -1024,13 +1084,20 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 	// Initialize as empty slice to ensure we return [] instead of null
 	books := []database.Book{}
 	var err error
+	// sorted is set once books hold the final order; sortKeys holds the
+	// stored book behind each row for sort keys list rows don't carry.
+	sorted := false
+	var sortKeys map[string]*database.Book
+	// projected is set when books are list projections rather than
+	// stored books, so the post-filters must load each one to judge it.
+	projected := false
 
 	// Apply filters in order of precedence
 	if search != "" {
 		if svc.searchIndex != nil {
-			books, err = svc.searchWithBleve(search, limit, offset)
+			books, err = svc.searchWithBleve(ctx, search, limit, offset)
 		} else {
-			books, err = svc.store.SearchBooks(search, limit, offset)
+			books, err = database.SearchBooksContext(ctx, svc.store, search, limit, offset)
 		}
 	} else if authorID != nil {
 		books, err = svc.store.GetBooksByAuthorID(*authorID)
@@ -1051,8 +1118,12 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 				return cached, nil
 			}
 			summaries, sErr := svc.summariesPushdown(storeLimit, storeOffset, f.IsPrimaryVersion, f.SortBy, sortAsc)
-			if sErr == nil && summaries != nil {
+			if sErr != nil {
+				return nil, sErr
+			}
+			if summaries != nil {
 				books = bookSummariesToBooks(summaries)
+				projected = true
 				svc.listCache.Set(cacheKey, books)
 			}
 		} else {
@@ -1063,13 +1134,27 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 			// materialization, no 1GB working set per query.
 			//
 			// Falls back to the legacy fetch-all-then-filter path when the
-			// filter set contains something we can't push down (non-title
-			// sort, fingerprint filters). Pre-fix this was 100% of heavy
-			// queries; post-fix it's the rare ones.
+			// filter set contains something we can't push down (fingerprint
+			// filters). Pre-fix this was 100% of heavy queries; post-fix
+			// it's the rare ones.
+			//
+			// The walker only orders by title. Any other sort takes every
+			// match, records each stored book as the walker passes it, and
+			// sorts and pages here.
 			if bsf, pushdownOK, pebbleLookups := svc.buildBookSummaryFilterWithLookupCount(f, sortAsc); pushdownOK {
-				summaries, didPushdown, sErr := svc.summariesPushdownFiltered(limit, offset, bsf)
-				if sErr == nil && summaries != nil {
+				pageLimit, pageOffset := limit, offset
+				if heavySorting {
+					pageLimit, pageOffset = 0, 0
+					sortKeys = map[string]*database.Book{}
+					bsf.Predicate = captureSortKeys(bsf.Predicate, sortKeys)
+				}
+				summaries, didPushdown, sErr := svc.summariesPushdownFiltered(pageLimit, pageOffset, bsf)
+				if sErr != nil {
+					return nil, sErr
+				}
+				if summaries != nil {
 					books = bookSummariesToBooks(summaries)
+					projected = true
 				}
 				if pebbleLookups != nil && *pebbleLookups > 0 {
 					slog.Debug("GetAudiobooks: stripped-field predicate Pebble fallback",
@@ -1086,11 +1171,20 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 				// pass below do its thing as the safety net.
 				if didPushdown {
 					hasPostFilters = false
+					if heavySorting {
+						svc.sortListBooks(books, sortKeys, f)
+						books = paginateBooks(books, limit, offset)
+						sorted = true
+					}
 				}
 			} else {
 				summaries, _, sErr := svc.summariesPushdownFiltered(storeLimit, storeOffset, database.BookSummaryFilter{})
-				if sErr == nil && summaries != nil {
+				if sErr != nil {
+					return nil, sErr
+				}
+				if summaries != nil {
 					books = bookSummariesToBooks(summaries)
+					projected = true
 				}
 			}
 		}
@@ -1136,8 +1230,35 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 			}
 		}
 
+		if heavySorting && sortKeys == nil {
+			sortKeys = map[string]*database.Book{}
+		}
 		filtered := make([]database.Book, 0, len(books))
-		for _, b := range books {
+		for i, b := range books {
+			if f.PathFilter != nil && !f.PathFilter(b.FilePath) {
+				continue
+			}
+			// List projections lack genre, the explicit flag and most
+			// attribute and sort fields; judge and sort those by the
+			// stored book. Search, author and series results already
+			// are stored books.
+			var full *database.Book
+			if f.ContentFilter != nil || hasAttr || heavySorting {
+				if projected {
+					full, _ = svc.store.GetBookByID(b.ID)
+				} else {
+					full = &books[i]
+				}
+			}
+			if f.ContentFilter != nil && (full == nil || !f.ContentFilter(full)) {
+				continue
+			}
+			if hasAttr && (full == nil || !MatchesAttributeFilters(full, f)) {
+				continue
+			}
+			if heavySorting && full != nil {
+				sortKeys[b.ID] = full
+			}
 			if len(tagsToMatch) > 0 {
 				if tagBookIDs == nil {
 					continue
@@ -1236,20 +1357,17 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 			filtered = perUserFiltered
 		}
 
-		// Apply pagination after filtering
-		if offset > 0 && offset < len(filtered) {
-			filtered = filtered[offset:]
-		} else if offset >= len(filtered) {
-			filtered = nil
-		}
-		if limit > 0 && limit < len(filtered) {
-			filtered = filtered[:limit]
-		}
-		books = filtered
+		// Sort the whole filtered set, then page it
+		svc.sortListBooks(filtered, sortKeys, f)
+		books = paginateBooks(filtered, limit, offset)
+		sorted = true
 	}
 
-	// Apply sorting after all filtering but before returning
-	applySorting(books, f)
+	// Search, author and series results that skipped the post-filter
+	// pass are sorted as returned.
+	if !sorted {
+		svc.sortListBooks(books, nil, f)
+	}
 
 	// Ensure we never return null - always return empty array
 	if books == nil {
@@ -1259,6 +1377,60 @@ func (svc *AudiobookService) GetAudiobooks(ctx context.Context, limit int, offse
 	return books, nil
 }
 
+// CountAudiobooksMatching returns the total number of audiobooks a
+// GetAudiobooks call with the same search, author, series and filters
+// pages through. Searches count every search hit; list filters are not
+// applied to them, matching how GetAudiobooks filters only the page.
+func (svc *AudiobookService) CountAudiobooksMatching(ctx context.Context, search string, authorID, seriesID *int, filters ListFilters) (int, error) {
+	if svc.store == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+	switch {
+	case search != "":
+		return svc.countSearchResults(ctx, search)
+	case authorID != nil || seriesID != nil:
+		if !filters.Filtered() {
+			var books []database.Book
+			var err error
+			if authorID != nil {
+				books, err = svc.store.GetBooksByAuthorID(*authorID)
+			} else {
+				books, err = svc.store.GetBooksBySeriesID(*seriesID)
+			}
+			return len(books), err
+		}
+		f := filters
+		f.SortBy = ""
+		books, err := svc.GetAudiobooks(ctx, maxAudiobookListLimit, 0, "", authorID, seriesID, f)
+		return len(books), err
+	case filters.Filtered():
+		return svc.CountAudiobooksFiltered(ctx, filters)
+	default:
+		return svc.CountAudiobooks(ctx)
+	}
+}
+
+// maxAudiobookListLimit is the largest page GetAudiobooks serves.
+const maxAudiobookListLimit = 100000
+
+// countSearchResults counts every hit for query, through the same Bleve
+// or substring path GetAudiobooks searches with.
+func (svc *AudiobookService) countSearchResults(ctx context.Context, query string) (int, error) {
+	if svc.searchIndex != nil {
+		if ast, err := search.ParseQuery(query); err == nil {
+			if bleveQ, _, err := search.Translate(ast); err == nil {
+				_, total, err := svc.searchIndex.SearchNative(bleveQ, 0, 1)
+				if err != nil {
+					return 0, fmt.Errorf("bleve search: %w", err)
+				}
+				return int(total), nil
+			}
+		}
+	}
+	books, err := database.SearchBooksContext(ctx, svc.store, query, math.MaxInt, 0)
+	return len(books), err
+}
+
 // CountAudiobooksFiltered returns the count of audiobooks matching the
 // given filters. Uses the memdb count-only pushdown (no projection
 // allocations, no full-corpus materialization) for the common filter set.
@@ -1319,6 +1491,15 @@ func (svc *AudiobookService) CountAudiobooksFiltered(ctx context.Context, filter
 
 	count := 0
 	for _, b := range books {
+		if filters.PathFilter != nil && !filters.PathFilter(b.FilePath) {
+			continue
+		}
+		if filters.ContentFilter != nil && !filters.ContentFilter(&b) {
+			continue
+		}
+		if !MatchesAttributeFilters(&b, filters) || !matchesFieldFilters(b, filters.FieldFilters) {
+			continue
+		}
 		if filters.IsPrimaryVersion != nil {
 			bPrimary := b.IsPrimaryVersion != nil && *b.IsPrimaryVersion
 			if *filters.IsPrimaryVersion != bPrimary {
@@ -1582,7 +1763,7 @@ func (svc *AudiobookService) GetAudiobook(ctx context.Context, id string) (*data
 		state = map[string]metadataFieldState{}
 	}
 
-	authorName, seriesName := resolveAuthorAndSeriesNames(svc.store, book)
+	authorName, seriesName := ResolveAuthorAndSeriesNames(svc.store, book)
 
 	meta := svc.extractBookFileMetadata(book, authorName)
 
@@ -1625,7 +1806,7 @@ func (svc *AudiobookService) GetAudiobookTags(ctx context.Context, id string, co
 		state = map[string]metadataFieldState{}
 	}
 
-	authorName, seriesName := resolveAuthorAndSeriesNames(svc.store, book)
+	authorName, seriesName := ResolveAuthorAndSeriesNames(svc.store, book)
 
 	response := map[string]any{
 		"media_info": map[string]any{
@@ -1666,6 +1847,11 @@ func (svc *AudiobookService) GetAudiobookTags(ctx context.Context, id string, co
 				book.Duration = &mi.Duration
 				needsUpdate = true
 			}
+			if book.QualityScore == nil && mi.Codec != "" {
+				score := mi.QualityScore()
+				book.QualityScore = &score
+				needsUpdate = true
+			}
 			if needsUpdate {
 				if _, err := svc.store.UpdateBook(book.ID, book); err != nil {
 					slog.Warn("GetAudiobookTags failed to backfill media info for", "book", book.ID, "err", err)
@@ -1692,7 +1878,7 @@ func (svc *AudiobookService) GetAudiobookTags(ctx context.Context, id string, co
 		}
 		snapshotBook, verErr := svc.store.GetBookAtVersion(id, ts)
 		if verErr == nil && snapshotBook != nil {
-			snapshotAuthorName, snapshotSeriesName := resolveAuthorAndSeriesNames(svc.store, snapshotBook)
+			snapshotAuthorName, snapshotSeriesName := ResolveAuthorAndSeriesNames(svc.store, snapshotBook)
 			comparisonValues = buildComparisonValuesFromBook(snapshotBook, snapshotAuthorName, snapshotSeriesName)
 		} else {
 			// Fallback: reconstruct "before" state from activity log old_values
@@ -1851,120 +2037,188 @@ func (svc *AudiobookService) PurgeSoftDeletedBooks(ctx context.Context, deleteFi
 	}
 
 	result := &PurgeResult{
-		Attempted: len(books),
-		Errors:    []string{},
+		Errors: []string{},
 	}
 
 	for _, book := range books {
-		// Tombstone external IDs so reimport is blocked
-		if eidStore := asExternalIDStore(svc.store); eidStore != nil {
-			extIDs, _ := eidStore.GetExternalIDsForBook(book.ID)
-			for _, ext := range extIDs {
-				_ = eidStore.TombstoneExternalID(ext.Source, ext.ExternalID)
-			}
+		// Books already in the trash finish via FinalizePendingPurges.
+		if isPendingPurge(&book) {
+			continue
 		}
+		result.Attempted++
+		svc.purgeBook(book, deleteFiles, result)
+	}
 
-		// Defense-in-depth: enqueue iTunes removes for any PIDs still
-		// on this book. Soft-delete already enqueues these but if the
-		// book was soft-deleted before that hook existed, this is the
-		// last chance to clean iTunes before the row vanishes.
-		bookCopy := book
-		svc.enqueueITunesRemovesForBook(book.ID, &bookCopy)
+	if result.Purged > 0 {
+		svc.InvalidateBookCaches()
+	}
+
+	return result, nil
+}
 
-		// Step 1: Create tombstone (snapshot of book for rollback)
-		if err := svc.store.CreateBookTombstone(&book); err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to create tombstone: %v", book.ID, err))
+// PurgeBooks permanently deletes the given books the way
+// PurgeSoftDeletedBooks does, whether or not they were soft-deleted
+// first. IDs that no longer exist are reported in Errors.
+func (svc *AudiobookService) PurgeBooks(ctx context.Context, ids []string, deleteFiles bool) (*PurgeResult, error) {
+	if svc.store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	result := &PurgeResult{
+		Attempted: len(ids),
+		Errors:    []string{},
+	}
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		book, err := svc.store.GetBookByID(id)
+		if err != nil || book == nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: book not found", id))
 			continue
 		}
-
-		// Step 2: Delete from database (book record gone, tombstone preserved)
-		if err := svc.store.DeleteBook(book.ID); err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to delete DB record: %v", book.ID, err))
-			// Tombstone exists but book still exists — sweeper will clean up tombstone
+		if isPendingPurge(book) {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: already pending purge", id))
 			continue
 		}
+		svc.purgeBook(*book, deleteFiles, result)
+	}
 
-		// Step 3: Delete file if requested (only from organizer root, never from protected/import paths)
-		if deleteFiles && book.FilePath != "" {
-			if isProtectedPath(svc.store, book.FilePath) {
-				slog.Debug("purge skipping file deletion for — protected path", "book", book.ID, "book", book.FilePath)
-			} else {
-				info, statErr := os.Stat(book.FilePath)
-				if statErr == nil && info.IsDir() {
-					// Directory-based book: remove all book files then the directory
-					if bookFiles, bfErr := svc.store.GetBookFiles(book.ID); bfErr == nil {
-						for _, bf := range bookFiles {
-							if bf.FilePath != "" && !isProtectedPath(svc.store, bf.FilePath) {
-								if rmErr := os.Remove(bf.FilePath); rmErr != nil && !os.IsNotExist(rmErr) {
-									result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to delete book file %s: %v", book.ID, bf.FilePath, rmErr))
-								}
-							}
-						}
-					}
-					// Remove the directory if it is now empty
-					if entries, rdErr := os.ReadDir(book.FilePath); rdErr == nil && len(entries) == 0 {
-						if rmErr := os.Remove(book.FilePath); rmErr != nil && !os.IsNotExist(rmErr) {
-							result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to remove empty dir %s: %v", book.ID, book.FilePath, rmErr))
-						} else if rmErr == nil {
-							result.FilesDeleted++
-							// Also clean up empty parent dirs up to RootDir
-							if config.AppConfig.RootDir != "" {
-								parentDir := filepath.Dir(book.FilePath)
-								for parentDir != config.AppConfig.RootDir &&
-									strings.HasPrefix(parentDir, config.AppConfig.RootDir) &&
-									parentDir != "/" {
-									pe, peErr := os.ReadDir(parentDir)
-									if peErr != nil || len(pe) > 0 {
-										break
-									}
-									if os.Remove(parentDir) != nil {
-										break
-									}
-									parentDir = filepath.Dir(parentDir)
-								}
+	if result.Purged > 0 {
+		svc.InvalidateBookCaches()
+	}
+
+	return result, nil
+}
+
+// ErrNotSoftDeleted is returned by PurgeDeletedBook for a book that is
+// still in the library.
+var ErrNotSoftDeleted = errors.New("audiobook is not deleted")
+
+// PurgeDeletedBook permanently deletes one soft-deleted book. A book whose
+// files are in the trash is purged now along with them, without waiting
+// out its window; otherwise deleteFiles applies as in PurgeSoftDeletedBooks.
+func (svc *AudiobookService) PurgeDeletedBook(ctx context.Context, id string, deleteFiles bool) (*PurgeResult, error) {
+	if svc.store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	book, err := svc.store.GetBookByID(id)
+	if err != nil || book == nil {
+		return nil, fmt.Errorf("audiobook not found")
+	}
+	if book.MarkedForDeletion == nil || !*book.MarkedForDeletion {
+		return nil, ErrNotSoftDeleted
+	}
+
+	result := &PurgeResult{Errors: []string{}}
+	pending := svc.pendingPurge(id)
+	if kv, ok := svc.store.(database.RawKVStore); ok && pending != nil {
+		svc.finalizePendingPurge(kv, *pending, result)
+	} else {
+		result.Attempted++
+		svc.purgeBook(*book, deleteFiles, result)
+	}
+	if result.Purged > 0 {
+		svc.InvalidateBookCaches()
+	}
+	return result, nil
+}
+
+// purgeBook tombstones and deletes one book record and, when deleteFiles
+// is set, its files outside protected paths, recording the outcome in
+// result. With a trash retention window configured, deleting files moves
+// them to the trash and keeps the record instead (see trashBook).
+func (svc *AudiobookService) purgeBook(book database.Book, deleteFiles bool, result *PurgeResult) {
+	if deleteFiles && svc.trashBook(book, result) {
+		return
+	}
+
+	// Tombstone external IDs so reimport is blocked
+	if eidStore := asExternalIDStore(svc.store); eidStore != nil {
+		extIDs, _ := eidStore.GetExternalIDsForBook(book.ID)
+		for _, ext := range extIDs {
+			_ = eidStore.TombstoneExternalID(ext.Source, ext.ExternalID)
+		}
+	}
+
+	// Defense-in-depth: enqueue iTunes removes for any PIDs still
+	// on this book. Soft-delete already enqueues these but if the
+	// book was soft-deleted before that hook existed, this is the
+	// last chance to clean iTunes before the row vanishes.
+	bookCopy := book
+	svc.enqueueITunesRemovesForBook(book.ID, &bookCopy)
+
+	// Step 1: Create tombstone (snapshot of book for rollback)
+	if err := svc.store.CreateBookTombstone(&book); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to create tombstone: %v", book.ID, err))
+		return
+	}
+
+	// Step 2: Delete from database (book record gone, tombstone preserved)
+	if err := svc.store.DeleteBook(book.ID); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to delete DB record: %v", book.ID, err))
+		// Tombstone exists but book still exists — sweeper will clean up tombstone
+		return
+	}
+
+	// Step 3: Delete file if requested (only from organizer root, never from protected/import paths)
+	if deleteFiles && book.FilePath != "" {
+		if isProtectedPath(svc.store, book.FilePath) {
+			slog.Debug("purge skipping file deletion for — protected path", "book", book.ID, "book", book.FilePath)
+		} else {
+			info, statErr := os.Stat(book.FilePath)
+			if statErr == nil && info.IsDir() {
+				// Directory-based book: remove all book files then the directory
+				if bookFiles, bfErr := svc.store.GetBookFiles(book.ID); bfErr == nil {
+					for _, bf := range bookFiles {
+						if bf.FilePath != "" && !isProtectedPath(svc.store, bf.FilePath) {
+							if rmErr := os.Remove(bf.FilePath); rmErr != nil && !os.IsNotExist(rmErr) {
+								result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to delete book file %s: %v", book.ID, bf.FilePath, rmErr))
 							}
 						}
 					}
-				} else if statErr == nil {
-					// Single-file book
-					if err := os.Remove(book.FilePath); err != nil && !os.IsNotExist(err) {
-						result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to delete file (tombstone preserved): %v", book.ID, err))
-						// DB record gone, file still exists, tombstone preserved for sweeper
-					} else if err == nil {
+				}
+				// Remove the directory if it is now empty
+				if entries, rdErr := os.ReadDir(book.FilePath); rdErr == nil && len(entries) == 0 {
+					if rmErr := os.Remove(book.FilePath); rmErr != nil && !os.IsNotExist(rmErr) {
+						result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to remove empty dir %s: %v", book.ID, book.FilePath, rmErr))
+					} else if rmErr == nil {
 						result.FilesDeleted++
-						// Clean up empty parent dirs up to RootDir
-						if config.AppConfig.RootDir != "" {
-							parentDir := filepath.Dir(book.FilePath)
-							for parentDir != config.AppConfig.RootDir &&
-								strings.HasPrefix(parentDir, config.AppConfig.RootDir) &&
-								parentDir != "/" {
-								pe, peErr := os.ReadDir(parentDir)
-								if peErr != nil || len(pe) > 0 {
-									break
-								}
-								if os.Remove(parentDir) != nil {
-									break
-								}
-								parentDir = filepath.Dir(parentDir)
-							}
-						}
+						// Also clean up empty parent dirs up to RootDir
+						removeEmptyParents(filepath.Dir(book.FilePath))
 					}
 				}
-				// If statErr is os.IsNotExist, file is already gone — that's fine
+			} else if statErr == nil {
+				// Single-file book
+				if err := os.Remove(book.FilePath); err != nil && !os.IsNotExist(err) {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to delete file (tombstone preserved): %v", book.ID, err))
+					// DB record gone, file still exists, tombstone preserved for sweeper
+				} else if err == nil {
+					result.FilesDeleted++
+					// Clean up empty parent dirs up to RootDir
+					removeEmptyParents(filepath.Dir(book.FilePath))
+				}
 			}
+			// If statErr is os.IsNotExist, file is already gone — that's fine
 		}
+	}
 
-		// Step 4: Clean up tombstone (best-effort — sweeper handles failures)
-		_ = svc.store.DeleteBookTombstone(book.ID)
+	// Step 4: Clean up tombstone (best-effort — sweeper handles failures)
+	_ = svc.store.DeleteBookTombstone(book.ID)
 
-		result.Purged++
-	}
+	svc.syncSeriesFolder(&book)
+	result.Purged++
+}
 
-	if result.Purged > 0 {
-		svc.InvalidateBookCaches()
+// syncSeriesFolder refreshes series.json for book's series after the
+// book was deleted or restored.
+func (svc *AudiobookService) syncSeriesFolder(book *database.Book) {
+	if book.SeriesID == nil {
+		return
+	}
+	if err := organizer.SyncSeriesFolders(svc.store, *book.SeriesID, book.FilePath); err != nil {
+		slog.Warn("update series folder file", "series", *book.SeriesID, "err", err)
 	}
-
-	return result, nil
 }
 
 // RestoreAudiobook restores a soft-deleted audiobook
@@ -1978,6 +2232,15 @@ func (svc *AudiobookService) RestoreAudiobook(ctx context.Context, id string) (*
 		return nil, fmt.Errorf("audiobook not found")
 	}
 
+	// A book waiting out the trash window gets its files back first.
+	if isPendingPurge(book) {
+		if pending := svc.pendingPurge(id); pending != nil {
+			if err := svc.restoreFromTrash(pending); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Restore to imported state so the UI can re-process if needed
 	book.MarkedForDeletion = boolPtr(false)
 	book.MarkedForDeletionAt = nil
@@ -1988,6 +2251,7 @@ func (svc *AudiobookService) RestoreAudiobook(ctx context.Context, id string) (*
 		return nil, err
 	}
 
+	svc.syncSeriesFolder(book)
 	svc.InvalidateBookCaches()
 	return updated, nil
 }
@@ -1998,7 +2262,7 @@ func (svc *AudiobookService) CountAudiobooks(ctx context.Context) (int, error) {
 		return 0, fmt.Errorf("database not initialized")
 	}
 
-	count, err := svc.store.CountBooks()
+	count, err := database.CountBooksContext(ctx, svc.store)
 	if err != nil {
 		return 0, err
 	}
@@ -2069,6 +2333,9 @@ func (svc *AudiobookService) UpdateAudiobook(ctx context.Context, id string, req
 	if req.Updates.SeriesID != nil {
 		currentBook.SeriesID = req.Updates.SeriesID
 	}
+	if req.Updates.Explicit != nil {
+		currentBook.Explicit = req.Updates.Explicit
+	}
 
 	payload := &AudiobookUpdate{
 		Book: currentBook,
@@ -2359,6 +2626,12 @@ func (svc *AudiobookService) UpdateAudiobook(ctx context.Context, id string, req
 type DeleteAudiobookOptions struct {
 	SoftDelete bool
 	BlockHash  bool
+	// BlockSignature also blocks the book's title, author and duration,
+	// so a re-encode of the same book is skipped by later scans.
+	BlockSignature bool
+	// MoveToTrash moves a soft-deleted book's files to the trash folder
+	// right away instead of leaving them in place (see softDeleteToTrash).
+	MoveToTrash bool
 }
 
 // DeleteAudiobook deletes an audiobook (soft or hard delete)
@@ -2390,30 +2663,49 @@ func (svc *AudiobookService) DeleteAudiobook(ctx context.Context, id string, opt
 		book.MarkedForDeletionAt = &now
 		book.LibraryState = stringPtr("deleted")
 
-		if _, err := svc.store.UpdateBook(id, book); err != nil {
-			return nil, err
+		// Without a trash folder or files on disk the book is just
+		// marked, as without MoveToTrash.
+		trashed := false
+		if opts.MoveToTrash {
+			trash := &PurgeResult{}
+			if svc.softDeleteToTrash(*book, trash) {
+				if len(trash.Errors) > 0 {
+					return nil, fmt.Errorf("%s", trash.Errors[0])
+				}
+				trashed = true
+			}
+		}
+		if !trashed {
+			if _, err := svc.store.UpdateBook(id, book); err != nil {
+				return nil, err
+			}
 		}
 
 		// Optionally block the hash
 		blocked := false
 		if opts.BlockHash && book.FileHash != nil && *book.FileHash != "" {
-			if err := svc.store.AddBlockedHash(*book.FileHash, "User deleted - soft delete"); err != nil {
+			if err := svc.store.AddBlockedHashWithAlgorithm(*book.FileHash, derefStr(book.FileHashAlgorithm), "User deleted - soft delete"); err != nil {
 				slog.Warn("failed to block hash during soft delete", "err", err)
 			} else {
 				blocked = true
 			}
 		}
 
+		signatureBlocked := opts.BlockSignature && svc.blockSignature(book, "User deleted - soft delete")
+
 		// Remove the book's tracks from iTunes. Soft-delete is treated
 		// as "user no longer wants this in their library" and the
 		// iTunes side should reflect that immediately.
 		svc.enqueueITunesRemovesForBook(id, book)
 
+		svc.syncSeriesFolder(book)
 		svc.InvalidateBookCaches()
 		return map[string]any{
-			"message":     "audiobook soft deleted",
-			"blocked":     blocked,
-			"soft_delete": true,
+			"message":           "audiobook soft deleted",
+			"blocked":           blocked,
+			"signature_blocked": signatureBlocked,
+			"soft_delete":       true,
+			"moved_to_trash":    trashed,
 		}, nil
 	}
 
@@ -2421,13 +2713,14 @@ func (svc *AudiobookService) DeleteAudiobook(ctx context.Context, id string, opt
 	// Optionally block the hash before deleting
 	blocked := false
 	if opts.BlockHash && book.FileHash != nil && *book.FileHash != "" {
-		if err := svc.store.AddBlockedHash(*book.FileHash, "User deleted - prevent reimport"); err != nil {
+		if err := svc.store.AddBlockedHashWithAlgorithm(*book.FileHash, derefStr(book.FileHashAlgorithm), "User deleted - prevent reimport"); err != nil {
 			slog.Warn("failed to block hash before delete", "err", err)
 			// Continue with delete even if blocking fails
 		} else {
 			blocked = true
 		}
 	}
+	signatureBlocked := opts.BlockSignature && svc.blockSignature(book, "User deleted - prevent reimport")
 
 	// Capture iTunes PIDs BEFORE the DB row vanishes so we can
 	// enqueue iTunes removes after the hard delete succeeds.
@@ -2449,13 +2742,39 @@ func (svc *AudiobookService) DeleteAudiobook(ctx context.Context, id string, opt
 		}
 	}
 
+	svc.syncSeriesFolder(book)
 	svc.InvalidateBookCaches()
 	return map[string]any{
-		"message": "audiobook deleted",
-		"blocked": blocked,
+		"message":           "audiobook deleted",
+		"blocked":           blocked,
+		"signature_blocked": signatureBlocked,
 	}, nil
 }
 
+// blockSignature adds book's metadata signature to the blocklist and
+// reports whether it did. Books without a title or duration have no
+// signature.
+func (svc *AudiobookService) blockSignature(book *database.Book, reason string) bool {
+	kv, ok := svc.store.(database.RawKVStore)
+	if !ok || book.Duration == nil {
+		return false
+	}
+	authorName, _ := ResolveAuthorAndSeriesNames(svc.store, book)
+	hash := ""
+	if book.FileHash != nil {
+		hash = *book.FileHash
+	}
+	sig, ok := database.NewBlockedSignature(book.Title, authorName, *book.Duration, hash, reason)
+	if !ok {
+		return false
+	}
+	if err := database.AddBlockedSignature(kv, sig); err != nil {
+		slog.Warn("failed to block metadata signature", "book", book.ID, "err", err)
+		return false
+	}
+	return true
+}
+
 // collectITunesPIDsForBook returns every PID stored on the book's
 // book_files plus the legacy Book.ITunesPersistentID field. Used by
 // hard-delete to pre-capture PIDs before the row is gone, and by the
@@ -2619,6 +2938,65 @@ func (svc *AudiobookService) BatchUpdateUserTags(bookIDs []string, addTags []str
 	return updated, nil
 }
 
+// SearchAudiobooksAfter returns the page of search results following
+// after, plus the cursor for the next page (nil on the last page). With
+// the Bleve index, hits are ordered by relevance then book ID and the
+// cursor carries the last hit's score; without it (or when the DSL
+// rejects the query) results come from the substring scan in book ID
+// order.
+func (svc *AudiobookService) SearchAudiobooksAfter(ctx context.Context, query string, after *database.PageCursor, limit int) ([]database.Book, *database.PageCursor, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if svc.searchIndex != nil {
+		if ast, err := search.ParseQuery(query); err == nil {
+			if bleveQ, _, err := search.Translate(ast); err == nil {
+				var afterKey []string
+				if after != nil {
+					afterKey = []string{after.Key, after.ID}
+				}
+				hits, err := svc.searchIndex.SearchNativeAfter(bleveQ, afterKey, limit)
+				if err != nil {
+					return nil, nil, fmt.Errorf("bleve search: %w", err)
+				}
+				books := make([]database.Book, 0, len(hits))
+				for _, h := range hits {
+					if b, _ := svc.store.GetBookByID(h.BookID); b != nil {
+						books = append(books, *b)
+					}
+				}
+				var next *database.PageCursor
+				if len(hits) == limit {
+					key := search.SearchAfterKey(hits[len(hits)-1])
+					next = &database.PageCursor{Key: key[0], ID: key[1]}
+				}
+				return books, next, nil
+			}
+		}
+	}
+
+	all, err := database.SearchBooksContext(ctx, svc.store, query, math.MaxInt32, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	books := make([]database.Book, 0, limit)
+	for _, b := range all {
+		if after != nil && b.ID <= after.ID {
+			continue
+		}
+		books = append(books, b)
+		if len(books) == limit {
+			break
+		}
+	}
+	var next *database.PageCursor
+	if len(books) == limit {
+		next = &database.PageCursor{ID: books[len(books)-1].ID}
+	}
+	return books, next, nil
+}
+
 // searchWithBleve parses the query via the DSL, translates to a
 // Bleve native query, and returns the matching books. Per-user
 // filters produced by the translator (read_status / progress_pct /
@@ -2628,17 +3006,17 @@ func (svc *AudiobookService) BatchUpdateUserTags(bookIDs []string, addTags []str
 //
 // Falls back to an empty slice (not nil) on zero matches so callers
 // get consistent JSON shape.
-func (svc *AudiobookService) searchWithBleve(query string, limit, offset int) ([]database.Book, error) {
+func (svc *AudiobookService) searchWithBleve(ctx context.Context, query string, limit, offset int) ([]database.Book, error) {
 	ast, err := search.ParseQuery(query)
 	if err != nil {
 		// Parser failure: fall back to the substring search path so
 		// users still see results for simple queries the DSL parser
 		// rejects (e.g. punctuation-heavy book titles).
-		return svc.store.SearchBooks(query, limit, offset)
+		return database.SearchBooksContext(ctx, svc.store, query, limit, offset)
 	}
 	bleveQ, _, err := search.Translate(ast)
 	if err != nil {
-		return svc.store.SearchBooks(query, limit, offset)
+		return database.SearchBooksContext(ctx, svc.store, query, limit, offset)
 	}
 	hits, _, err := svc.searchIndex.SearchNative(bleveQ, offset, limit)
 	if err != nil {
@@ -0,0 +1,319 @@
+// file: internal/audiobooks/trash.go
+// version: 1.2.0
+// guid: 0d6f3a82-4e1b-4c97-b5a8-7c2e9f1d6b34
+// last-edited: 2026-10-17
+
+// Two-phase delete. With trash_retention_days set, purging a book with
+// its files first moves the files to <root_dir>/.trash/<book ID> and
+// leaves the book soft-deleted in the "pending_purge" state; restoring it
+// moves the files back. FinalizePendingPurges, run by the purge-deleted
+// task, removes both once the window has passed. A soft delete with
+// MoveToTrash takes the same route, with purge_soft_deleted_after_days
+// as its window.
+
+package audiobooks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// LibraryStatePendingPurge marks a book whose files are in the trash.
+const LibraryStatePendingPurge = "pending_purge"
+
+// trashDirName is the trash folder under RootDir. Scans and orphan
+// checks skip it.
+const trashDirName = ".trash"
+
+// trashRoot returns the trash folder, or "" when RootDir is unset.
+func trashRoot() string {
+	if config.AppConfig.RootDir == "" {
+		return ""
+	}
+	return filepath.Clean(filepath.Join(config.AppConfig.RootDir, trashDirName))
+}
+
+// isPendingPurge reports whether book's files are in the trash.
+func isPendingPurge(book *database.Book) bool {
+	return book.LibraryState != nil && *book.LibraryState == LibraryStatePendingPurge
+}
+
+// pendingPurge returns the pending purge for bookID, if any.
+func (svc *AudiobookService) pendingPurge(bookID string) *database.PendingPurge {
+	kv, ok := svc.store.(database.RawKVStore)
+	if !ok {
+		return nil
+	}
+	p, err := database.GetPendingPurge(kv, bookID)
+	if err != nil {
+		slog.Warn("load pending purge", "book", bookID, "err", err)
+		return nil
+	}
+	return p
+}
+
+// trashBook is the first phase of purging book with its files: the files
+// go to the trash and the book is kept as pending purge. It reports
+// whether it handled the book; when it didn't (no retention window, no
+// trash folder, nothing on disk) the caller deletes as before.
+func (svc *AudiobookService) trashBook(book database.Book, result *PurgeResult) bool {
+	days := config.AppConfig.TrashRetentionDays
+	if days <= 0 {
+		return false
+	}
+	return svc.moveToTrash(book, time.Now().AddDate(0, 0, days), result)
+}
+
+// softDeleteToTrash moves a book being soft-deleted into the trash. Its
+// files are purged with it after purge_soft_deleted_after_days, or only
+// on request when that is unset.
+func (svc *AudiobookService) softDeleteToTrash(book database.Book, result *PurgeResult) bool {
+	var purgeAfter time.Time
+	if days := config.AppConfig.PurgeSoftDeletedAfterDays; days > 0 {
+		purgeAfter = time.Now().AddDate(0, 0, days)
+	}
+	return svc.moveToTrash(book, purgeAfter, result)
+}
+
+// moveToTrash moves book's files to its trash folder and marks it pending
+// purge until purgeAfter; a zero purgeAfter waits for an explicit purge.
+// It reports whether it handled the book, as trashBook does.
+func (svc *AudiobookService) moveToTrash(book database.Book, purgeAfter time.Time, result *PurgeResult) bool {
+	root := trashRoot()
+	kv, ok := svc.store.(database.RawKVStore)
+	if root == "" || !ok || book.FilePath == "" || isProtectedPath(svc.store, book.FilePath) {
+		return false
+	}
+	sources := svc.trashSources(book)
+	if len(sources) == 0 {
+		return false
+	}
+
+	dir := filepath.Join(root, book.ID)
+	var moved []database.TrashedFile
+	rollback := func() {
+		for i := len(moved) - 1; i >= 0; i-- {
+			if err := os.Rename(moved[i].To, moved[i].From); err != nil {
+				slog.Error("trash rollback failed", "book", book.ID, "file", moved[i].From, "err", err)
+			}
+		}
+		_ = os.RemoveAll(dir)
+	}
+	for _, src := range sources {
+		dest := filepath.Join(dir, src.rel)
+		err := os.MkdirAll(filepath.Dir(dest), 0o755)
+		if err == nil {
+			err = os.Rename(src.path, dest)
+		}
+		if err != nil {
+			rollback()
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to move %s to trash: %v", book.ID, src.path, err))
+			return true
+		}
+		moved = append(moved, database.TrashedFile{From: src.path, To: dest})
+	}
+
+	now := time.Now()
+	pending := database.PendingPurge{
+		BookID:     book.ID,
+		Title:      book.Title,
+		TrashDir:   dir,
+		Files:      moved,
+		TrashedAt:  now,
+		PurgeAfter: purgeAfter,
+	}
+	if err := database.SavePendingPurge(kv, pending); err != nil {
+		rollback()
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to record pending purge: %v", book.ID, err))
+		return true
+	}
+	book.MarkedForDeletion = boolPtr(true)
+	if book.MarkedForDeletionAt == nil {
+		book.MarkedForDeletionAt = &now
+	}
+	book.LibraryState = stringPtr(LibraryStatePendingPurge)
+	if _, err := svc.store.UpdateBook(book.ID, &book); err != nil {
+		rollback()
+		_ = database.DeletePendingPurge(kv, book.ID)
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to mark pending purge: %v", book.ID, err))
+		return true
+	}
+
+	svc.enqueueITunesRemovesForBook(book.ID, &book)
+	if info, err := os.Stat(book.FilePath); err == nil && info.IsDir() {
+		if entries, rdErr := os.ReadDir(book.FilePath); rdErr == nil && len(entries) == 0 {
+			if os.Remove(book.FilePath) == nil {
+				removeEmptyParents(filepath.Dir(book.FilePath))
+			}
+		}
+	} else {
+		removeEmptyParents(filepath.Dir(book.FilePath))
+	}
+	svc.syncSeriesFolder(&book)
+	result.PendingPurge++
+	slog.Info("moved book to trash", "book", book.ID, "files", len(moved), "purge_after", purgeAfter)
+	return true
+}
+
+type trashSource struct {
+	path string
+	rel  string // path inside the book's trash folder
+}
+
+// trashSources lists the files purging book would delete, the same ones
+// purgeBook removes: every unprotected book file of a directory book, or
+// the single file.
+func (svc *AudiobookService) trashSources(book database.Book) []trashSource {
+	info, err := os.Stat(book.FilePath)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		return []trashSource{{path: book.FilePath, rel: filepath.Base(book.FilePath)}}
+	}
+	files, err := svc.store.GetBookFiles(book.ID)
+	if err != nil {
+		return nil
+	}
+	var out []trashSource
+	for _, bf := range files {
+		if bf.FilePath == "" || isProtectedPath(svc.store, bf.FilePath) {
+			continue
+		}
+		if _, err := os.Lstat(bf.FilePath); err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(book.FilePath, bf.FilePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			rel = filepath.Base(bf.FilePath)
+		}
+		out = append(out, trashSource{path: bf.FilePath, rel: rel})
+	}
+	return out
+}
+
+// restoreFromTrash moves a pending purge's files back where they were
+// and drops the record. Nothing moves if any original location has been
+// taken in the meantime.
+func (svc *AudiobookService) restoreFromTrash(p *database.PendingPurge) error {
+	for _, f := range p.Files {
+		if _, err := os.Lstat(f.From); err == nil {
+			return fmt.Errorf("cannot restore from trash: %s already exists", f.From)
+		}
+	}
+	for _, f := range p.Files {
+		if err := os.MkdirAll(filepath.Dir(f.From), 0o755); err != nil {
+			return fmt.Errorf("restore %s: %w", f.From, err)
+		}
+		if err := os.Rename(f.To, f.From); err != nil {
+			return fmt.Errorf("restore %s: %w", f.From, err)
+		}
+	}
+	removeTrashDir(p.TrashDir)
+	if kv, ok := svc.store.(database.RawKVStore); ok {
+		if err := database.DeletePendingPurge(kv, p.BookID); err != nil {
+			return fmt.Errorf("clear pending purge: %w", err)
+		}
+	}
+	return nil
+}
+
+// FinalizePendingPurges is the second phase: books whose retention
+// window has passed are purged and their trashed files deleted.
+func (svc *AudiobookService) FinalizePendingPurges(ctx context.Context) (*PurgeResult, error) {
+	result := &PurgeResult{Errors: []string{}}
+	kv, ok := svc.store.(database.RawKVStore)
+	if !ok {
+		return result, nil
+	}
+	pending, err := database.ListPendingPurges(kv)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, p := range pending {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if p.PurgeAfter.IsZero() || now.Before(p.PurgeAfter) {
+			continue
+		}
+		svc.finalizePendingPurge(kv, p, result)
+	}
+	if result.Purged > 0 {
+		svc.InvalidateBookCaches()
+	}
+	return result, nil
+}
+
+// finalizePendingPurge purges the book behind p and deletes its trashed
+// files, or puts them back when the book was undeleted some other way.
+func (svc *AudiobookService) finalizePendingPurge(kv database.RawKVStore, p database.PendingPurge, result *PurgeResult) {
+	result.Attempted++
+	book, err := svc.store.GetBookByID(p.BookID)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", p.BookID, err))
+		return
+	}
+	if book != nil && (book.MarkedForDeletion == nil || !*book.MarkedForDeletion) {
+		// Undeleted some other way; put the files back instead.
+		if err := svc.restoreFromTrash(&p); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", p.BookID, err))
+		}
+		return
+	}
+	if book != nil {
+		purged := result.Purged
+		svc.purgeBook(*book, false, result)
+		if result.Purged == purged {
+			return // keep the trash; the next run retries
+		}
+	} else {
+		result.Purged++
+	}
+	removeTrashDir(p.TrashDir)
+	result.FilesDeleted += len(p.Files)
+	if err := database.DeletePendingPurge(kv, p.BookID); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to clear pending purge: %v", p.BookID, err))
+	}
+}
+
+// removeTrashDir deletes a book's trash folder, refusing anything outside
+// the trash.
+func removeTrashDir(dir string) {
+	root := trashRoot()
+	dir = filepath.Clean(dir)
+	if root == "" || !strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		slog.Warn("refusing to remove trash folder outside the trash", "dir", dir)
+		return
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		slog.Warn("remove trash folder", "dir", dir, "err", err)
+	}
+}
+
+// removeEmptyParents removes dir and its empty parents up to RootDir.
+func removeEmptyParents(dir string) {
+	root := config.AppConfig.RootDir
+	if root == "" {
+		return
+	}
+	for dir != root && strings.HasPrefix(dir, root) && dir != "/" {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
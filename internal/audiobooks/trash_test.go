@@ -0,0 +1,162 @@
+// file: internal/audiobooks/trash_test.go
+// version: 1.1.0
+// guid: 7e3c1b58-2d9a-4f64-8b0e-6a5f9c2d1e37
+
+package audiobooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeBooks_TrashThenRestoreOrFinalize(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	require.Eventually(t, store.IsMemReady, 5*time.Second, 10*time.Millisecond)
+
+	root := t.TempDir()
+	prev := config.AppConfig
+	config.AppConfig.RootDir = root
+	config.AppConfig.TrashRetentionDays = 7
+	t.Cleanup(func() { config.AppConfig = prev })
+
+	write := func(rel string) string {
+		p := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte("audio"), 0o644))
+		return p
+	}
+	single, err := store.CreateBook(&database.Book{Title: "Mort", FilePath: write("Pratchett/Mort/mort.m4b")})
+	require.NoError(t, err)
+	dir := filepath.Join(root, "Herbert", "Dune")
+	multi, err := store.CreateBook(&database.Book{Title: "Dune", FilePath: dir})
+	require.NoError(t, err)
+	var parts []string
+	for _, name := range []string{"01.mp3", "02.mp3"} {
+		p := write(filepath.Join("Herbert", "Dune", name))
+		parts = append(parts, p)
+		require.NoError(t, store.CreateBookFile(&database.BookFile{BookID: multi.ID, FilePath: p}))
+	}
+
+	svc := NewAudiobookService(store)
+	ctx := context.Background()
+	result, err := svc.PurgeBooks(ctx, []string{single.ID, multi.ID}, true)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 0, result.Purged)
+	assert.Equal(t, 2, result.PendingPurge)
+	assert.NoFileExists(t, single.FilePath)
+	assert.NoDirExists(t, dir, "emptied book folder is removed")
+	assert.FileExists(t, filepath.Join(root, trashDirName, multi.ID, "02.mp3"))
+
+	kept, err := store.GetBookByID(single.ID)
+	require.NoError(t, err)
+	require.NotNil(t, kept, "record is kept while pending")
+	assert.Equal(t, LibraryStatePendingPurge, *kept.LibraryState)
+	assert.True(t, *kept.MarkedForDeletion)
+
+	again, err := svc.PurgeBooks(ctx, []string{single.ID}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{single.ID + ": already pending purge"}, again.Errors)
+
+	// Restoring moves the files back.
+	restored, err := svc.RestoreAudiobook(ctx, multi.ID)
+	require.NoError(t, err)
+	assert.False(t, *restored.MarkedForDeletion)
+	for _, p := range parts {
+		assert.FileExists(t, p)
+	}
+	assert.NoDirExists(t, filepath.Join(root, trashDirName, multi.ID))
+
+	// Nothing is finalized before the window passes.
+	done, err := svc.FinalizePendingPurges(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, done.Attempted)
+
+	p, err := database.GetPendingPurge(store, single.ID)
+	require.NoError(t, err)
+	p.PurgeAfter = time.Now().Add(-time.Minute)
+	require.NoError(t, database.SavePendingPurge(store, *p))
+	done, err = svc.FinalizePendingPurges(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, done.Errors)
+	assert.Equal(t, 1, done.Purged)
+	assert.Equal(t, 1, done.FilesDeleted)
+	gone, err := store.GetBookByID(single.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+	assert.NoDirExists(t, filepath.Join(root, trashDirName, single.ID))
+	left, err := database.ListPendingPurges(store)
+	require.NoError(t, err)
+	assert.Empty(t, left)
+}
+
+func TestDeleteAudiobook_MoveToTrashThenPurge(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	require.Eventually(t, store.IsMemReady, 5*time.Second, 10*time.Millisecond)
+
+	root := t.TempDir()
+	prev := config.AppConfig
+	config.AppConfig.RootDir = root
+	config.AppConfig.TrashRetentionDays = 0
+	config.AppConfig.PurgeSoftDeletedAfterDays = 0
+	t.Cleanup(func() { config.AppConfig = prev })
+
+	create := func(title, rel string) *database.Book {
+		p := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte("audio"), 0o644))
+		b, err := store.CreateBook(&database.Book{Title: title, FilePath: p})
+		require.NoError(t, err)
+		return b
+	}
+	mort := create("Mort", "Pratchett/Mort/mort.m4b")
+	dune := create("Dune", "Herbert/Dune/dune.m4b")
+
+	svc := NewAudiobookService(store)
+	ctx := context.Background()
+	_, err = svc.PurgeDeletedBook(ctx, mort.ID, false)
+	assert.ErrorIs(t, err, ErrNotSoftDeleted)
+
+	for _, b := range []*database.Book{mort, dune} {
+		resp, err := svc.DeleteAudiobook(ctx, b.ID, &DeleteAudiobookOptions{SoftDelete: true, MoveToTrash: true})
+		require.NoError(t, err)
+		assert.Equal(t, true, resp["moved_to_trash"])
+		assert.NoFileExists(t, b.FilePath)
+	}
+
+	// With no purge_soft_deleted_after_days the trash waits for a purge.
+	p, err := database.GetPendingPurge(store, mort.ID)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.True(t, p.PurgeAfter.IsZero())
+	done, err := svc.FinalizePendingPurges(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, done.Attempted)
+
+	restored, err := svc.RestoreAudiobook(ctx, dune.ID)
+	require.NoError(t, err)
+	assert.False(t, *restored.MarkedForDeletion)
+	assert.FileExists(t, dune.FilePath)
+
+	result, err := svc.PurgeDeletedBook(ctx, mort.ID, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Purged)
+	assert.Equal(t, 1, result.FilesDeleted)
+	gone, err := store.GetBookByID(mort.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+	assert.NoDirExists(t, filepath.Join(root, trashDirName, mort.ID))
+}
@@ -1,5 +1,5 @@
 // file: internal/audiobooks/update_service.go
-// version: 1.3.1
+// version: 1.4.0
 // guid: b2c3d4e5-f6g7-h8i9-j0k1-l2m3n4o5p6q7
 
 package audiobooks
@@ -121,6 +121,9 @@ func (aus *AudiobookUpdateService) UpdateAudiobook(ctx context.Context, id strin
 	if desc, ok := util.ExtractStringField(payload, "description"); ok {
 		updates.Description = &desc
 	}
+	if explicit, ok := util.ExtractBoolField(payload, "explicit"); ok {
+		updates.Explicit = &explicit
+	}
 
 	if overridesMap, ok := aus.ExtractOverrides(payload); ok {
 		updates.Overrides = make(map[string]OverridePayload)
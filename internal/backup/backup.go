@@ -1,7 +1,7 @@
 // file: internal/backup/backup.go
-// version: 1.4.0
+// version: 1.5.0
 // guid: 8f9e0a1b-2c3d-4e5f-6a7b-8c9d0e1f2a3b
-// last-edited: 2026-05-18
+// last-edited: 2026-10-16
 
 package backup
 
@@ -37,6 +37,9 @@ type BackupConfig struct {
 	BackupDir        string
 	MaxBackups       int
 	CompressionLevel int
+	// Label, when set, is appended to the backup filename (e.g.
+	// "pre-restore") to mark backups taken automatically.
+	Label string
 }
 
 // DefaultBackupConfig returns default backup configuration
@@ -58,6 +61,9 @@ func CreateBackup(databasePath, databaseType string, config BackupConfig) (*Back
 	// Generate backup filename with timestamp
 	timestamp := time.Now().Format("20060102_150405")
 	backupFilename := fmt.Sprintf("audiobooks_%s_%s.tar.gz", databaseType, timestamp)
+	if config.Label != "" {
+		backupFilename = fmt.Sprintf("audiobooks_%s_%s_%s.tar.gz", databaseType, timestamp, config.Label)
+	}
 	backupPath := filepath.Join(config.BackupDir, backupFilename)
 
 	// Create backup file
@@ -116,6 +122,12 @@ func CreateBackup(databasePath, databaseType string, config BackupConfig) (*Back
 		CreatedAt:    time.Now(),
 	}
 
+	// Record what the archive holds so restores can verify it. A database
+	// that cannot be read back still gets a manifest of its files.
+	if err := writeManifest(info); err != nil {
+		slog.Warn("backup failed to write manifest", "backup", backupFilename, "error", err)
+	}
+
 	// Clean up old backups
 	if err := cleanupOldBackups(config.BackupDir, config.MaxBackups); err != nil {
 		// Log error but don't fail the backup
@@ -144,93 +156,16 @@ func isPathWithinTarget(targetPath, entryPath string) (bool, error) {
 	return true, nil
 }
 
-// RestoreBackup restores a database from a backup file
+// RestoreBackup restores a database from a backup file. verify checks the
+// archive and its files against the backup manifest first; use Restore for
+// full verification, dry runs and safety backups.
 func RestoreBackup(backupPath, targetPath string, verify bool) error {
-	// Verify checksum if requested
+	mode := VerifyNone
 	if verify {
-		// TODO: Store checksums in metadata file and verify
-		slog.Info("backup checksum verification not yet implemented")
-	}
-
-	// Open backup file
-	backupFile, err := os.Open(backupPath)
-	if err != nil {
-		return fmt.Errorf("failed to open backup file: %w", err)
-	}
-	defer backupFile.Close()
-
-	// Create gzip reader
-	gzipReader, err := gzip.NewReader(backupFile)
-	if err != nil {
-		return fmt.Errorf("failed to create gzip reader: %w", err)
+		mode = VerifyChecksum
 	}
-	defer gzipReader.Close()
-
-	// Create tar reader
-	tarReader := tar.NewReader(gzipReader)
-
-	// Extract files
-	for {
-		header, err := tarReader.Next()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("failed to read tar header: %w", err)
-		}
-
-		// Normalise the archive entry name: strip any leading slashes so that
-		// absolute-path entries (e.g. "/etc/passwd") are treated as relative
-		// paths inside the target directory — the same behaviour as
-		// filepath.Join(root, "/etc/passwd") on Unix, but explicit.
-		entryName := strings.TrimLeft(filepath.ToSlash(header.Name), "/")
-		if entryName == "" {
-			continue
-		}
-		// safepath.Join validates that the entry stays within targetPath and
-		// returns a clean path value — breaking the CodeQL taint chain.
-		targetSP, err := safepath.Join(targetPath, entryName)
-		if err != nil {
-			return fmt.Errorf("archive entry %q escapes target directory: %w", header.Name, err)
-		}
-		target := targetSP.String()
-
-		// Handle directories and files
-		switch header.Typeflag {
-		case tar.TypeDir:
-			if err := os.MkdirAll(target, 0775); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", target, err)
-			}
-		case tar.TypeReg:
-			// Ensure parent directory exists
-			if err := os.MkdirAll(filepath.Dir(target), 0775); err != nil {
-				return fmt.Errorf("failed to create parent directory for %s: %w", target, err)
-			}
-
-			// Create file
-			outFile, err := os.Create(target)
-			if err != nil {
-				return fmt.Errorf("failed to create file %s: %w", target, err)
-			}
-
-			// Copy data
-			if _, err := io.Copy(outFile, tarReader); err != nil {
-				outFile.Close()
-				return fmt.Errorf("failed to write file %s: %w", target, err)
-			}
-
-			outFile.Close()
-
-			// Set file permissions
-			if err := os.Chmod(target, os.FileMode(header.Mode)); err != nil {
-				return fmt.Errorf("failed to set permissions on %s: %w", target, err)
-			}
-		default:
-			slog.Warn("backup unsupported file type", "type", header.Typeflag, "name", header.Name)
-		}
-	}
-
-	return nil
+	_, err := Restore(backupPath, targetPath, RestoreOptions{Verify: mode})
+	return err
 }
 
 // ListBackups lists all available backups
@@ -284,6 +219,7 @@ func DeleteBackup(backupPath string) error {
 	if err := os.Remove(backupPath); err != nil {
 		return fmt.Errorf("failed to delete backup: %w", err)
 	}
+	removeManifest(backupPath)
 	return nil
 }
 
@@ -388,6 +324,13 @@ func calculateFileChecksum(path string) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// removeManifest deletes a backup's manifest sidecar, if any.
+func removeManifest(backupPath string) {
+	if err := os.Remove(ManifestPath(backupPath)); err != nil && !os.IsNotExist(err) {
+		slog.Warn("backup failed to delete manifest", "backup", filepath.Base(backupPath), "error", err)
+	}
+}
+
 // cleanupOldBackups removes old backups exceeding the maximum count
 func cleanupOldBackups(backupDir string, maxBackups int) error {
 	backups, err := ListBackups(backupDir)
@@ -414,7 +357,9 @@ func cleanupOldBackups(backupDir string, maxBackups int) error {
 	for i := 0; i < deleteCount; i++ {
 		if err := os.Remove(backups[i].Path); err != nil {
 			slog.Warn("backup failed to delete old backup", "filename", backups[i].Filename, "error", err)
+			continue
 		}
+		removeManifest(backups[i].Path)
 	}
 
 	return nil
@@ -0,0 +1,302 @@
+// file: internal/backup/restore.go
+// version: 1.0.0
+// guid: 23016c95-890c-47c2-baab-5283594a1cd5
+// last-edited: 2026-10-16
+
+package backup
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/security/safepath"
+)
+
+// RestoreOptions controls Restore.
+type RestoreOptions struct {
+	// Verify checks the backup before anything is written; a failed
+	// verification aborts the restore with ErrVerificationFailed.
+	Verify VerifyMode
+	// DryRun reports what the restore would change without writing.
+	DryRun bool
+	// SafetyBackupDir, when set, receives a backup of the database the
+	// restore would overwrite before any file is written.
+	SafetyBackupDir string
+	// DatabaseType names the safety backup (e.g. "pebble").
+	DatabaseType string
+}
+
+// RestoreResult describes what Restore did, or would do on a dry run.
+type RestoreResult struct {
+	Target       string        `json:"target"`
+	DryRun       bool          `json:"dry_run"`
+	Verification *VerifyReport `json:"verification,omitempty"`
+	Changes      *RestoreDiff  `json:"changes,omitempty"`
+	SafetyBackup *BackupInfo   `json:"safety_backup,omitempty"`
+}
+
+// RestoreDiff compares a backup with the database it would replace.
+type RestoreDiff struct {
+	// Database is where the backup's database lands inside the target.
+	Database string `json:"database"`
+	// Exists is false when the target holds no database yet.
+	Exists         bool     `json:"exists"`
+	FilesAdded     []string `json:"files_added,omitempty"`
+	FilesChanged   []string `json:"files_changed,omitempty"`
+	FilesUnchanged int      `json:"files_unchanged"`
+	// FilesExtra are present in the target but not in the backup; a
+	// restore leaves them in place.
+	FilesExtra           []string             `json:"files_extra,omitempty"`
+	CurrentSchemaVersion int                  `json:"current_schema_version,omitempty"`
+	BackupSchemaVersion  int                  `json:"backup_schema_version,omitempty"`
+	RowChanges           map[string]RowChange `json:"row_changes,omitempty"`
+}
+
+// RowChange is a per-prefix key count before and after a restore.
+type RowChange struct {
+	Current int `json:"current"`
+	Backup  int `json:"backup"`
+}
+
+// Restore restores the database in backupPath into targetPath, optionally
+// verifying it first, backing up what it overwrites, or only reporting
+// the changes it would make.
+func Restore(backupPath, targetPath string, opts RestoreOptions) (*RestoreResult, error) {
+	result := &RestoreResult{Target: targetPath, DryRun: opts.DryRun}
+	if opts.Verify != "" && opts.Verify != VerifyNone {
+		report, err := VerifyBackup(backupPath, opts.Verify)
+		if err != nil {
+			return nil, err
+		}
+		result.Verification = report
+		if !report.OK {
+			return result, fmt.Errorf("%w: %s", ErrVerificationFailed, strings.Join(report.Problems, "; "))
+		}
+	}
+
+	if opts.DryRun {
+		diff, err := diffRestore(backupPath, targetPath)
+		if err != nil {
+			return nil, err
+		}
+		result.Changes = diff
+		return result, nil
+	}
+
+	if opts.SafetyBackupDir != "" {
+		info, err := safetyBackup(backupPath, targetPath, opts)
+		if err != nil {
+			return result, fmt.Errorf("pre-restore safety backup failed: %w", err)
+		}
+		result.SafetyBackup = info
+	}
+
+	if _, _, err := extractArchive(backupPath, targetPath); err != nil {
+		if result.SafetyBackup != nil {
+			return result, fmt.Errorf("%w (previous database saved as %s)", err, result.SafetyBackup.Filename)
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// safetyBackup backs up the database the restore would overwrite. It
+// returns nil when the target holds no database yet.
+func safetyBackup(backupPath, targetPath string, opts RestoreOptions) (*BackupInfo, error) {
+	root, err := archiveRoot(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	current := filepath.Join(targetPath, root)
+	if _, err := os.Stat(current); os.IsNotExist(err) {
+		return nil, nil
+	}
+	dbType := opts.DatabaseType
+	if dbType == "" {
+		dbType = "unknown"
+	}
+	cfg := DefaultBackupConfig()
+	cfg.BackupDir = opts.SafetyBackupDir
+	cfg.Label = "pre-restore"
+	// Never prune here: the oldest backup may be the one being restored.
+	cfg.MaxBackups = math.MaxInt32
+	info, err := CreateBackup(current, dbType, cfg)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("backup saved current database before restore", "backup", info.Filename)
+	return info, nil
+}
+
+// archiveRoot returns the top-level entry name of a backup archive.
+func archiveRoot(backupPath string) (string, error) {
+	contents, err := inspectArchive(backupPath, false)
+	if err != nil {
+		return "", err
+	}
+	if contents.root == "" {
+		return "", fmt.Errorf("backup archive is empty")
+	}
+	return contents.root, nil
+}
+
+// diffRestore compares the backup's files and rows with the database
+// currently in targetPath.
+func diffRestore(backupPath, targetPath string) (*RestoreDiff, error) {
+	contents, err := inspectArchive(backupPath, true)
+	if err != nil {
+		return nil, err
+	}
+	if contents.root == "" {
+		return nil, fmt.Errorf("backup archive is empty")
+	}
+	current := filepath.Join(targetPath, contents.root)
+	diff := &RestoreDiff{Database: current, BackupSchemaVersion: contents.schemaVersion}
+	info, err := os.Stat(current)
+	if os.IsNotExist(err) {
+		for _, f := range contents.files {
+			diff.FilesAdded = append(diff.FilesAdded, f.Name)
+		}
+		return diff, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	diff.Exists = true
+
+	currentFiles, err := hashTree(targetPath, current)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range contents.files {
+		hash, ok := currentFiles[f.Name]
+		switch {
+		case !ok:
+			diff.FilesAdded = append(diff.FilesAdded, f.Name)
+		case hash != f.SHA256:
+			diff.FilesChanged = append(diff.FilesChanged, f.Name)
+		default:
+			diff.FilesUnchanged++
+		}
+		delete(currentFiles, f.Name)
+	}
+	for name := range currentFiles {
+		diff.FilesExtra = append(diff.FilesExtra, name)
+	}
+
+	if info.IsDir() && contents.rowCounts != nil {
+		counts, version, err := countPebbleRows(current)
+		if err != nil {
+			return nil, fmt.Errorf("current database: %w", err)
+		}
+		diff.CurrentSchemaVersion = version
+		for _, prefix := range sortedKeys(counts, contents.rowCounts) {
+			if counts[prefix] != contents.rowCounts[prefix] {
+				if diff.RowChanges == nil {
+					diff.RowChanges = make(map[string]RowChange)
+				}
+				diff.RowChanges[prefix] = RowChange{Current: counts[prefix], Backup: contents.rowCounts[prefix]}
+			}
+		}
+	}
+	sortStrings(diff.FilesAdded, diff.FilesChanged, diff.FilesExtra)
+	return diff, nil
+}
+
+// hashTree hashes every regular file under root, keyed by its archive
+// name (slash-separated, relative to base).
+func hashTree(base, root string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		hashes[filepath.ToSlash(rel)] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	})
+	return hashes, err
+}
+
+// extractArchive writes a backup's entries under targetPath, returning the
+// extracted files with their hashes and the archive's top-level entry.
+func extractArchive(backupPath, targetPath string) ([]ManifestFile, string, error) {
+	var files []ManifestFile
+	var root string
+	err := walkArchive(backupPath, func(header *tar.Header, entryName string, r io.Reader) error {
+		if root == "" {
+			root = strings.SplitN(entryName, "/", 2)[0]
+		}
+		// safepath.Join validates that the entry stays within targetPath and
+		// returns a clean path value — breaking the CodeQL taint chain.
+		targetSP, err := safepath.Join(targetPath, entryName)
+		if err != nil {
+			return fmt.Errorf("archive entry %q escapes target directory: %w", header.Name, err)
+		}
+		target := targetSP.String()
+
+		// Handle directories and files
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0775); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			// Ensure parent directory exists
+			if err := os.MkdirAll(filepath.Dir(target), 0775); err != nil {
+				return fmt.Errorf("failed to create parent directory for %s: %w", target, err)
+			}
+
+			// Create file
+			outFile, err := os.Create(target)
+			if err != nil {
+				return fmt.Errorf("failed to create file %s: %w", target, err)
+			}
+
+			// Copy data, hashing it on the way through
+			f, err := hashReader(entryName, io.TeeReader(r, outFile))
+			outFile.Close()
+			if err != nil {
+				return fmt.Errorf("failed to write file %s: %w", target, err)
+			}
+			files = append(files, f)
+
+			// Set file permissions
+			if err := os.Chmod(target, os.FileMode(header.Mode)); err != nil {
+				return fmt.Errorf("failed to set permissions on %s: %w", target, err)
+			}
+		default:
+			slog.Warn("backup unsupported file type", "type", header.Typeflag, "name", header.Name)
+		}
+		return nil
+	})
+	return files, root, err
+}
+
+func sortStrings(lists ...[]string) {
+	for _, l := range lists {
+		sort.Strings(l)
+	}
+}
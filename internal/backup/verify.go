@@ -0,0 +1,419 @@
+// file: internal/backup/verify.go
+// version: 1.0.0
+// guid: 055fc5df-37e2-410c-9dcf-136797393f80
+// last-edited: 2026-10-16
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// ManifestSuffix names the sidecar written next to each backup archive.
+// The sidecar holds the archive checksum, so it cannot live inside it.
+const ManifestSuffix = ".manifest.json"
+
+// ManifestFile describes one file stored in a backup archive.
+type ManifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest records what a backup contained when it was written, so a later
+// verification can tell whether the archive or the database inside it has
+// changed. RowCounts counts PebbleDB keys per top-level prefix ("book",
+// "author", ...) and is empty for single-file databases.
+type Manifest struct {
+	Filename      string         `json:"filename"`
+	Checksum      string         `json:"checksum"`
+	DatabaseType  string         `json:"database_type"`
+	CreatedAt     time.Time      `json:"created_at"`
+	SchemaVersion int            `json:"schema_version,omitempty"`
+	Files         []ManifestFile `json:"files"`
+	RowCounts     map[string]int `json:"row_counts,omitempty"`
+}
+
+// ManifestPath returns the sidecar manifest path for a backup archive.
+func ManifestPath(backupPath string) string {
+	return backupPath + ManifestSuffix
+}
+
+// ReadManifest loads the manifest written alongside backupPath. It returns
+// nil without error for backups made before manifests existed.
+func ReadManifest(backupPath string) (*Manifest, error) {
+	data, err := os.ReadFile(ManifestPath(backupPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse backup manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// writeManifest inspects a freshly written archive and stores its manifest.
+func writeManifest(info *BackupInfo) error {
+	contents, err := inspectArchive(info.Path, true)
+	if err != nil {
+		return err
+	}
+	m := Manifest{
+		Filename:      info.Filename,
+		Checksum:      info.Checksum,
+		DatabaseType:  info.DatabaseType,
+		CreatedAt:     info.CreatedAt,
+		SchemaVersion: contents.schemaVersion,
+		Files:         contents.files,
+		RowCounts:     contents.rowCounts,
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ManifestPath(info.Path), data, 0o644)
+}
+
+// VerifyMode selects how thoroughly a backup is checked.
+type VerifyMode string
+
+const (
+	// VerifyNone skips verification.
+	VerifyNone VerifyMode = "none"
+	// VerifyChecksum compares the archive checksum and every stored file's
+	// size and SHA-256 against the manifest.
+	VerifyChecksum VerifyMode = "checksum"
+	// VerifyFull adds an integrity check of the database itself: it is
+	// extracted to a scratch directory, opened read-only and fully read,
+	// its schema version checked, and its row counts compared.
+	VerifyFull VerifyMode = "full"
+)
+
+// ParseVerifyMode parses a verify mode; the empty string means none.
+func ParseVerifyMode(s string) (VerifyMode, error) {
+	switch VerifyMode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", VerifyNone:
+		return VerifyNone, nil
+	case VerifyChecksum:
+		return VerifyChecksum, nil
+	case VerifyFull:
+		return VerifyFull, nil
+	}
+	return "", fmt.Errorf("unknown verify mode %q (want none, checksum or full)", s)
+}
+
+// VerifyReport is the outcome of VerifyBackup. Problems lists every
+// mismatch found; OK is true when there are none.
+type VerifyReport struct {
+	Mode          VerifyMode     `json:"mode"`
+	OK            bool           `json:"ok"`
+	Checksum      string         `json:"checksum,omitempty"`
+	SchemaVersion int            `json:"schema_version,omitempty"`
+	RowCounts     map[string]int `json:"row_counts,omitempty"`
+	Problems      []string       `json:"problems,omitempty"`
+}
+
+// ErrVerificationFailed is returned by Restore when the backup does not
+// match its manifest.
+var ErrVerificationFailed = errors.New("backup verification failed")
+
+// VerifyBackup checks backupPath against its manifest. An error means the
+// check could not run; mismatches are reported in the report's Problems.
+func VerifyBackup(backupPath string, mode VerifyMode) (*VerifyReport, error) {
+	report := &VerifyReport{Mode: mode, OK: true}
+	if mode == VerifyNone {
+		return report, nil
+	}
+	manifest, err := ReadManifest(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := calculateFileChecksum(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum backup: %w", err)
+	}
+	report.Checksum = checksum
+	if manifest == nil {
+		report.problem("backup has no manifest to verify against")
+		return report, nil
+	}
+	if manifest.Checksum != checksum {
+		report.problem(fmt.Sprintf("archive checksum %s does not match manifest %s", checksum, manifest.Checksum))
+	}
+
+	contents, err := inspectArchive(backupPath, mode == VerifyFull)
+	if err != nil {
+		report.problem(err.Error())
+		return report, nil
+	}
+	compareFiles(report, manifest.Files, contents.files)
+	if mode != VerifyFull {
+		return report, nil
+	}
+
+	for _, p := range contents.problems {
+		report.problem(p)
+	}
+	report.SchemaVersion = contents.schemaVersion
+	report.RowCounts = contents.rowCounts
+	if latest := database.LatestSchemaVersion(); contents.schemaVersion > latest {
+		report.problem(fmt.Sprintf("schema version %d is newer than this build supports (%d)", contents.schemaVersion, latest))
+	}
+	if manifest.SchemaVersion != contents.schemaVersion {
+		report.problem(fmt.Sprintf("schema version %d does not match manifest %d", contents.schemaVersion, manifest.SchemaVersion))
+	}
+	for _, prefix := range sortedKeys(manifest.RowCounts, contents.rowCounts) {
+		if want, got := manifest.RowCounts[prefix], contents.rowCounts[prefix]; want != got {
+			report.problem(fmt.Sprintf("%s: %d rows, manifest says %d", prefix, got, want))
+		}
+	}
+	return report, nil
+}
+
+func (r *VerifyReport) problem(p string) {
+	r.OK = false
+	r.Problems = append(r.Problems, p)
+}
+
+func compareFiles(report *VerifyReport, want, got []ManifestFile) {
+	gotByName := make(map[string]ManifestFile, len(got))
+	for _, f := range got {
+		gotByName[f.Name] = f
+	}
+	for _, w := range want {
+		g, ok := gotByName[w.Name]
+		switch {
+		case !ok:
+			report.problem(fmt.Sprintf("%s: missing from archive", w.Name))
+		case g.Size != w.Size || g.SHA256 != w.SHA256:
+			report.problem(fmt.Sprintf("%s: contents do not match manifest", w.Name))
+		}
+		delete(gotByName, w.Name)
+	}
+	for name := range gotByName {
+		report.problem(fmt.Sprintf("%s: not listed in manifest", name))
+	}
+}
+
+// archiveContents is what inspectArchive learns about a backup.
+type archiveContents struct {
+	files []ManifestFile
+	// root is the archive's top-level entry: the database directory or
+	// file name as it lands in the restore target.
+	root string
+	// The fields below are only filled by a deep inspection.
+	schemaVersion int
+	rowCounts     map[string]int
+	problems      []string
+}
+
+// inspectArchive hashes every file in a backup. A deep inspection also
+// extracts it to a scratch directory beside the archive and reads the
+// database inside.
+func inspectArchive(backupPath string, deep bool) (*archiveContents, error) {
+	contents := &archiveContents{}
+	if !deep {
+		err := walkArchive(backupPath, func(header *tar.Header, name string, r io.Reader) error {
+			contents.noteRoot(name)
+			if header.Typeflag != tar.TypeReg {
+				return nil
+			}
+			f, err := hashReader(name, r)
+			if err != nil {
+				return err
+			}
+			contents.files = append(contents.files, f)
+			return nil
+		})
+		return contents, err
+	}
+
+	scratch, err := os.MkdirTemp(filepath.Dir(backupPath), ".inspect-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+	files, root, err := extractArchive(backupPath, scratch)
+	if err != nil {
+		return nil, err
+	}
+	contents.files, contents.root = files, root
+	if root != "" {
+		contents.inspectDatabase(filepath.Join(scratch, root))
+	}
+	return contents, nil
+}
+
+func (c *archiveContents) noteRoot(name string) {
+	if c.root == "" {
+		c.root = strings.SplitN(name, "/", 2)[0]
+	}
+}
+
+// inspectDatabase reads the database at path. PebbleDB directories are
+// opened read-only and every key is read, which checks each block's
+// checksum; single-file databases only get a header check.
+func (c *archiveContents) inspectDatabase(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		c.problems = append(c.problems, fmt.Sprintf("database missing from archive: %v", err))
+		return
+	}
+	if !info.IsDir() {
+		if err := checkSQLiteHeader(path); err != nil {
+			c.problems = append(c.problems, err.Error())
+		}
+		return
+	}
+	counts, version, err := countPebbleRows(path)
+	if err != nil {
+		c.problems = append(c.problems, err.Error())
+		return
+	}
+	c.rowCounts, c.schemaVersion = counts, version
+}
+
+// countPebbleRows opens a PebbleDB directory read-only and counts its keys
+// by top-level prefix, returning the recorded schema version alongside.
+// Read-only opens take no lock, so this also works on a live database.
+func countPebbleRows(dir string) (map[string]int, int, error) {
+	db, err := pebble.Open(dir, &pebble.Options{ReadOnly: true})
+	if err != nil {
+		return nil, 0, fmt.Errorf("database does not open: %w", err)
+	}
+	defer db.Close()
+
+	iter, err := db.NewIter(nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("database cannot be read: %w", err)
+	}
+	counts := make(map[string]int)
+	version := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		prefix := key
+		if i := bytes.IndexByte(key, ':'); i >= 0 {
+			prefix = key[:i]
+		}
+		counts[string(prefix)]++
+		if string(key) == database.SchemaVersionKey {
+			version = parseSchemaVersion(iter.Value())
+		}
+	}
+	if err := iter.Error(); err != nil {
+		_ = iter.Close()
+		return nil, 0, fmt.Errorf("database cannot be read: %w", err)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, 0, fmt.Errorf("database cannot be read: %w", err)
+	}
+	return counts, version, nil
+}
+
+func parseSchemaVersion(raw []byte) int {
+	var pref database.UserPreference
+	if json.Unmarshal(raw, &pref) != nil || pref.Value == nil {
+		return 0
+	}
+	var v database.DatabaseVersion
+	if json.Unmarshal([]byte(*pref.Value), &v) != nil {
+		return 0
+	}
+	return v.Version
+}
+
+func checkSQLiteHeader(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(f, header); err != nil || string(header) != "SQLite format 3\x00" {
+		return fmt.Errorf("%s is not a SQLite database", filepath.Base(path))
+	}
+	return nil
+}
+
+// walkArchive calls fn for every entry of a gzipped tar backup with the
+// entry's normalised name (leading slashes stripped, forward slashes).
+func walkArchive(backupPath string, fn func(header *tar.Header, name string, r io.Reader) error) error {
+	backupFile, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer backupFile.Close()
+
+	gzipReader, err := gzip.NewReader(backupFile)
+	if err != nil {
+		return fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar header: %w", err)
+		}
+		// Normalise the archive entry name: strip any leading slashes so that
+		// absolute-path entries (e.g. "/etc/passwd") are treated as relative
+		// paths inside the target directory — the same behaviour as
+		// filepath.Join(root, "/etc/passwd") on Unix, but explicit.
+		name := strings.TrimLeft(filepath.ToSlash(header.Name), "/")
+		if name == "" {
+			continue
+		}
+		if err := fn(header, name, tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+func hashReader(name string, r io.Reader) (ManifestFile, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return ManifestFile{}, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return ManifestFile{Name: name, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+func sortedKeys(maps ...map[string]int) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
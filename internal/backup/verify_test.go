@@ -0,0 +1,200 @@
+// file: internal/backup/verify_test.go
+// version: 1.0.0
+// guid: 932c9f44-cb31-459a-9558-e8b8da0a5898
+// last-edited: 2026-10-16
+
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// newPebbleBackup creates a migrated PebbleDB with books, backs it up and
+// returns the database path and backup info.
+func newPebbleBackup(t *testing.T, books int) (string, *BackupInfo) {
+	t.Helper()
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "audiobooks.pebble")
+	store, err := database.NewPebbleStore(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if err := database.RunMigrations(store); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	for i := 0; i < books; i++ {
+		if _, err := store.CreateBook(&database.Book{Title: "Book", FilePath: filepath.Join(tempDir, "b", string(rune('a'+i)))}); err != nil {
+			t.Fatalf("create book: %v", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+
+	info, err := CreateBackup(dbPath, "pebble", BackupConfig{
+		BackupDir:        filepath.Join(tempDir, "backups"),
+		MaxBackups:       10,
+		CompressionLevel: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateBackup: %v", err)
+	}
+	return dbPath, info
+}
+
+func TestCreateBackupWritesManifest(t *testing.T) {
+	_, info := newPebbleBackup(t, 2)
+
+	m, err := ReadManifest(info.Path)
+	if err != nil || m == nil {
+		t.Fatalf("ReadManifest = %v, %v", m, err)
+	}
+	if m.Checksum != info.Checksum {
+		t.Errorf("manifest checksum %s, want %s", m.Checksum, info.Checksum)
+	}
+	if len(m.Files) == 0 {
+		t.Error("manifest lists no files")
+	}
+	if m.SchemaVersion != database.LatestSchemaVersion() {
+		t.Errorf("schema version %d, want %d", m.SchemaVersion, database.LatestSchemaVersion())
+	}
+	if m.RowCounts["book"] == 0 {
+		t.Errorf("expected book rows in manifest, got %v", m.RowCounts)
+	}
+}
+
+func TestVerifyBackupModes(t *testing.T) {
+	_, info := newPebbleBackup(t, 1)
+
+	for _, mode := range []VerifyMode{VerifyChecksum, VerifyFull} {
+		report, err := VerifyBackup(info.Path, mode)
+		if err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		if !report.OK {
+			t.Errorf("%s: unexpected problems %v", mode, report.Problems)
+		}
+	}
+
+	// A manifest that disagrees on row counts fails only the full check.
+	m, _ := ReadManifest(info.Path)
+	m.RowCounts["book"] += 5
+	data, _ := json.Marshal(m)
+	if err := os.WriteFile(ManifestPath(info.Path), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if report, _ := VerifyBackup(info.Path, VerifyChecksum); !report.OK {
+		t.Errorf("checksum mode should ignore row counts: %v", report.Problems)
+	}
+	if report, _ := VerifyBackup(info.Path, VerifyFull); report.OK {
+		t.Error("full mode should report the row count mismatch")
+	}
+}
+
+func TestVerifyBackupDetectsModifiedArchive(t *testing.T) {
+	_, info := newPebbleBackup(t, 1)
+	f, err := os.OpenFile(info.Path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.Write([]byte("tampered"))
+	f.Close()
+
+	report, err := VerifyBackup(info.Path, VerifyChecksum)
+	if err != nil {
+		t.Fatalf("VerifyBackup: %v", err)
+	}
+	if report.OK {
+		t.Error("expected checksum mismatch")
+	}
+
+	_, err = Restore(info.Path, t.TempDir(), RestoreOptions{Verify: VerifyChecksum})
+	if !errors.Is(err, ErrVerificationFailed) {
+		t.Errorf("Restore error = %v, want ErrVerificationFailed", err)
+	}
+}
+
+func TestParseVerifyMode(t *testing.T) {
+	for in, want := range map[string]VerifyMode{"": VerifyNone, "none": VerifyNone, "Checksum": VerifyChecksum, "full": VerifyFull} {
+		if got, err := ParseVerifyMode(in); err != nil || got != want {
+			t.Errorf("ParseVerifyMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseVerifyMode("paranoid"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestRestoreDryRunReportsChanges(t *testing.T) {
+	dbPath, info := newPebbleBackup(t, 1)
+
+	// Add books after the backup so the restore would drop them.
+	store, err := database.NewPebbleStore(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"/x/1", "/x/2"} {
+		if _, err := store.CreateBook(&database.Book{Title: "Later", FilePath: p}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Close()
+	before, err := hashTree(filepath.Dir(dbPath), dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Restore(info.Path, filepath.Dir(dbPath), RestoreOptions{DryRun: true, SafetyBackupDir: filepath.Dir(info.Path)})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	diff := result.Changes
+	if diff == nil || !diff.Exists {
+		t.Fatalf("expected a diff against the existing database, got %+v", diff)
+	}
+	change, ok := diff.RowChanges["book"]
+	if !ok || change.Current <= change.Backup {
+		t.Errorf("expected fewer book rows after restore, got %+v", diff.RowChanges)
+	}
+	if result.SafetyBackup != nil {
+		t.Error("dry run must not take a safety backup")
+	}
+	after, _ := hashTree(filepath.Dir(dbPath), dbPath)
+	if len(after) != len(before) {
+		t.Error("dry run modified the database directory")
+	}
+}
+
+func TestRestoreTakesSafetyBackup(t *testing.T) {
+	dbPath, info := newPebbleBackup(t, 1)
+	backupDir := filepath.Dir(info.Path)
+
+	result, err := Restore(info.Path, filepath.Dir(dbPath), RestoreOptions{SafetyBackupDir: backupDir, DatabaseType: "pebble"})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if result.SafetyBackup == nil {
+		t.Fatal("expected a safety backup of the overwritten database")
+	}
+	if _, err := os.Stat(result.SafetyBackup.Path); err != nil {
+		t.Errorf("safety backup missing: %v", err)
+	}
+	if _, err := os.Stat(info.Path); err != nil {
+		t.Errorf("restored backup was pruned: %v", err)
+	}
+
+	// Restoring into an empty target has nothing to save.
+	result, err = Restore(info.Path, t.TempDir(), RestoreOptions{SafetyBackupDir: backupDir})
+	if err != nil {
+		t.Fatalf("Restore into empty target: %v", err)
+	}
+	if result.SafetyBackup != nil {
+		t.Error("unexpected safety backup for an empty target")
+	}
+}
@@ -0,0 +1,23 @@
+// file: internal/batch/lifecycle.go
+// version: 1.0.0
+
+package batch
+
+import (
+	"context"
+
+	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
+)
+
+// PostInit wires the audiobook service as the BookPurger, so hard
+// deletes with delete_files go through its purge (and trash). Without
+// it those operations fail per item.
+func (bs *BatchService) PostInit(_ context.Context, c *serviceregistry.Container) error {
+	if bs == nil {
+		return nil
+	}
+	if p, ok := serviceregistry.TryGet[BookPurger](c, "audiobook"); ok && p != nil {
+		bs.SetBookPurger(p)
+	}
+	return nil
+}
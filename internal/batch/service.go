@@ -1,25 +1,43 @@
 // file: internal/batch/service.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: a1b2c3d4-e5f6-7a8b-9c0d-1e2f3a4b5c6d
 
 package batch
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 )
 
 // BatchService handles bulk operations on audiobooks.
 type BatchService struct {
-	db database.BookStore
+	db     database.BookStore
+	purger BookPurger
+}
+
+// BookPurger deletes books together with their files and restores
+// books whose files are waiting in the trash. *audiobooks.AudiobookService
+// implements it.
+type BookPurger interface {
+	PurgeBooks(ctx context.Context, ids []string, deleteFiles bool) (*audiobooks.PurgeResult, error)
+	RestoreAudiobook(ctx context.Context, id string) (*database.Book, error)
 }
 
 func NewBatchService(db database.BookStore) *BatchService {
 	return &BatchService{db: db}
 }
 
+// SetBookPurger enables hard deletes that also remove files.
+func (bs *BatchService) SetBookPurger(p BookPurger) {
+	bs.purger = p
+}
+
 // ---------------------------------------------------------------------------
 // Shared types
 // ---------------------------------------------------------------------------
@@ -94,10 +112,11 @@ func (bs *BatchService) UpdateAudiobooks(req *BatchUpdateRequest) *BatchResponse
 
 // BatchOperationItem describes one operation to perform on one book.
 type BatchOperationItem struct {
-	ID         string         `json:"id"`
-	Action     string         `json:"action"`                // "update", "delete", "restore"
-	Updates    map[string]any `json:"updates,omitempty"`     // for action=update
-	HardDelete bool           `json:"hard_delete,omitempty"` // for action=delete
+	ID          string         `json:"id"`
+	Action      string         `json:"action"`                 // "update", "delete", "restore"
+	Updates     map[string]any `json:"updates,omitempty"`      // for action=update
+	HardDelete  bool           `json:"hard_delete,omitempty"`  // for action=delete
+	DeleteFiles bool           `json:"delete_files,omitempty"` // with hard_delete: remove files too (via the trash when enabled)
 }
 
 // BatchOperationsRequest allows different operations per item.
@@ -128,7 +147,9 @@ func (bs *BatchService) ExecuteOperations(req *BatchOperationsRequest) *BatchRes
 				resp.addError(op.ID, "not found")
 				continue
 			}
-			if op.HardDelete {
+			if op.HardDelete && op.DeleteFiles {
+				bs.purgeWithFiles(op.ID, resp)
+			} else if op.HardDelete {
 				if err := bs.db.DeleteBook(op.ID); err != nil {
 					resp.addError(op.ID, err.Error())
 				} else {
@@ -153,6 +174,15 @@ func (bs *BatchService) ExecuteOperations(req *BatchOperationsRequest) *BatchRes
 				resp.addError(op.ID, "not found")
 				continue
 			}
+			if bs.purger != nil && book.LibraryState != nil && *book.LibraryState == audiobooks.LibraryStatePendingPurge {
+				// Files are in the trash; the service moves them back.
+				if _, err := bs.purger.RestoreAudiobook(context.Background(), op.ID); err != nil {
+					resp.addError(op.ID, err.Error())
+				} else {
+					resp.addSuccess(op.ID)
+				}
+				continue
+			}
 			notMarked := false
 			book.MarkedForDeletion = &notMarked
 			book.MarkedForDeletionAt = nil
@@ -169,6 +199,23 @@ func (bs *BatchService) ExecuteOperations(req *BatchOperationsRequest) *BatchRes
 	return resp
 }
 
+// purgeWithFiles hard-deletes one book and its files through the purger.
+func (bs *BatchService) purgeWithFiles(id string, resp *BatchResponse) {
+	if bs.purger == nil {
+		resp.addError(id, "file deletion is not available")
+		return
+	}
+	result, err := bs.purger.PurgeBooks(context.Background(), []string{id}, true)
+	switch {
+	case err != nil:
+		resp.addError(id, err.Error())
+	case len(result.Errors) > 0:
+		resp.addError(id, strings.Join(result.Errors, "; "))
+	default:
+		resp.addSuccess(id)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // applyUpdates — maps JSON fields to Book struct fields
 // ---------------------------------------------------------------------------
@@ -195,9 +242,11 @@ func applyUpdates(book *database.Book, updates map[string]any) {
 	if updates["series_id"] == nil {
 		book.SeriesID = nil
 	}
-	if v, ok := updates["series_sequence"].(float64); ok {
-		seq := int(v)
-		book.SeriesSequence = &seq
+	switch v := updates["series_sequence"].(type) {
+	case float64:
+		book.SeriesSequence = database.ParseSeriesSeq(strconv.FormatFloat(v, 'f', -1, 64))
+	case string:
+		book.SeriesSequence = database.ParseSeriesSeq(v)
 	}
 	if v, ok := updates["version_group_id"].(string); ok {
 		book.VersionGroupID = &v
@@ -1,14 +1,16 @@
 // file: internal/batch/service_test.go
-// version: 1.0.1
+// version: 1.1.0
 // guid: b2c3d4e5-f6a7-b8c9-0d1e-2f3a4b5c6d7e
 
 package batch
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 )
 
@@ -551,3 +553,70 @@ func TestApplyUpdates_ClearSeriesID(t *testing.T) {
 		t.Errorf("expected series_id to be nil, got %v", book.SeriesID)
 	}
 }
+
+// fakePurger records the books it was asked to purge or restore.
+type fakePurger struct {
+	purged   []string
+	restored []string
+	errs     []string
+}
+
+func (p *fakePurger) PurgeBooks(_ context.Context, ids []string, deleteFiles bool) (*audiobooks.PurgeResult, error) {
+	if !deleteFiles {
+		return nil, errors.New("expected deleteFiles")
+	}
+	p.purged = append(p.purged, ids...)
+	return &audiobooks.PurgeResult{Attempted: len(ids), PendingPurge: len(ids), Errors: p.errs}, nil
+}
+
+func (p *fakePurger) RestoreAudiobook(_ context.Context, id string) (*database.Book, error) {
+	p.restored = append(p.restored, id)
+	return &database.Book{ID: id}, nil
+}
+
+// Test 13: hard delete with delete_files goes through the purger, and
+// restoring a book pending purge goes back through it.
+func TestExecuteOperations_HardDeleteWithFiles(t *testing.T) {
+	store := NewMockBookStore()
+	store.books["book1"] = testBook("book1", "Book 1")
+	store.books["book2"] = testBook("book2", "Book 2")
+	pending := audiobooks.LibraryStatePendingPurge
+	store.books["book2"].LibraryState = &pending
+	bs := NewBatchService(store)
+
+	del := &BatchOperationsRequest{Operations: []BatchOperationItem{
+		{ID: "book1", Action: "delete", HardDelete: true, DeleteFiles: true},
+	}}
+	if resp := bs.ExecuteOperations(del); resp.Failed != 1 {
+		t.Errorf("expected failure without a purger, got %+v", resp)
+	}
+
+	purger := &fakePurger{}
+	bs.SetBookPurger(purger)
+	resp := bs.ExecuteOperations(del)
+	if resp.Success != 1 {
+		t.Errorf("expected Success=1, got %+v", resp)
+	}
+	if len(purger.purged) != 1 || purger.purged[0] != "book1" {
+		t.Errorf("expected book1 purged, got %v", purger.purged)
+	}
+	if store.delCnt != 0 {
+		t.Errorf("expected DeleteBook not to be called, was called %d times", store.delCnt)
+	}
+
+	purger.errs = []string{"book1: failed to move to trash"}
+	if resp := bs.ExecuteOperations(del); resp.Failed != 1 || resp.Results[0].Error != purger.errs[0] {
+		t.Errorf("expected purge error surfaced, got %+v", resp)
+	}
+
+	restore := &BatchOperationsRequest{Operations: []BatchOperationItem{
+		{ID: "book1", Action: "restore"},
+		{ID: "book2", Action: "restore"},
+	}}
+	if resp := bs.ExecuteOperations(restore); resp.Success != 2 {
+		t.Errorf("expected Success=2, got %+v", resp)
+	}
+	if len(purger.restored) != 1 || purger.restored[0] != "book2" {
+		t.Errorf("expected only book2 restored through the purger, got %v", purger.restored)
+	}
+}
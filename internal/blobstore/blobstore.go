@@ -0,0 +1,109 @@
+// file: internal/blobstore/blobstore.go
+// version: 1.0.0
+// guid: 8b827a61-3c1b-4196-a292-8d9119201346
+// last-edited: 2026-10-16
+
+// Package blobstore keeps covers and other large binary artifacts behind
+// a small key/value interface, so a deployment can keep them on local
+// disk (the default) or in S3-compatible object storage. Keys are
+// slash-separated relative paths such as "covers/<book-id>.jpg"; the
+// local backend maps them straight onto files under its root, so the
+// default layout is the one the library has always used.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// ErrNotFound is returned when a key has no blob.
+var ErrNotFound = errors.New("blob not found")
+
+// Backend names accepted by the blob_storage_backend setting.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// Info describes a stored blob.
+type Info struct {
+	Key         string
+	Size        int64
+	ModTime     time.Time
+	ContentType string
+}
+
+// Store is a flat namespace of blobs addressed by key.
+type Store interface {
+	// Name is the backend name, "local" or "s3".
+	Name() string
+	// Put stores r under key, replacing any previous blob. size is the
+	// length of r, or -1 when unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the blob stored under key; the caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, Info, error)
+	// Stat returns the blob's Info without reading it.
+	Stat(ctx context.Context, key string) (Info, error)
+	// Delete removes key; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns the blobs whose keys start with prefix.
+	List(ctx context.Context, prefix string) ([]Info, error)
+}
+
+// FromConfig builds the store selected by cfg. The local backend roots at
+// blob_storage_local_dir, or the library root when that is unset; the
+// S3 backend falls back to the standard AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN variables for credentials
+// that are not configured.
+func FromConfig(cfg config.Config) (Store, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.BlobStorageBackend)) {
+	case "", BackendLocal:
+		root := cfg.BlobStorageLocalDir
+		if root == "" {
+			root = cfg.RootDir
+		}
+		return NewLocal(root)
+	case BackendS3:
+		opts := S3Options{
+			Endpoint:        cfg.BlobStorageS3Endpoint,
+			Region:          cfg.BlobStorageS3Region,
+			Bucket:          cfg.BlobStorageS3Bucket,
+			Prefix:          cfg.BlobStorageS3Prefix,
+			AccessKeyID:     cfg.BlobStorageS3AccessKeyID,
+			SecretAccessKey: cfg.BlobStorageS3SecretAccessKey,
+			PathStyle:       cfg.BlobStorageS3PathStyle,
+		}
+		if opts.AccessKeyID == "" && opts.SecretAccessKey == "" {
+			opts.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+			opts.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+			opts.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+		}
+		return NewS3(opts)
+	default:
+		return nil, fmt.Errorf("unknown blob storage backend %q", cfg.BlobStorageBackend)
+	}
+}
+
+// CleanKey validates key and returns it in canonical form: slash
+// separated, without leading or trailing slashes or "." and ".."
+// segments.
+func CleanKey(key string) (string, error) {
+	key = strings.Trim(filepath.ToSlash(key), "/")
+	if key == "" {
+		return "", errors.New("blobstore: empty key")
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", fmt.Errorf("blobstore: invalid key %q", key)
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,116 @@
+// file: internal/blobstore/blobstore_test.go
+// version: 1.0.0
+// guid: 0b5f3c9e-7a26-4d81-93e4-c8d1a57f6b02
+// last-edited: 2026-10-16
+
+package blobstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exerciseStore runs the behaviour every backend must share.
+func exerciseStore(t *testing.T, s Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := s.Stat(ctx, "covers/missing.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, _, err = s.Get(ctx, "covers/missing.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, s.Delete(ctx, "covers/missing.jpg"))
+
+	require.NoError(t, s.Put(ctx, "covers/a b.jpg", strings.NewReader("jpeg bytes"), 10, "image/jpeg"))
+	require.NoError(t, s.Put(ctx, "covers/b.png", strings.NewReader("png"), 3, "image/png"))
+	require.NoError(t, s.Put(ctx, "other/c.txt", strings.NewReader("c"), 1, "text/plain"))
+	require.NoError(t, s.Put(ctx, "covers/b.png", strings.NewReader("png v2"), 6, "image/png"))
+
+	r, info, err := s.Get(ctx, "covers/a b.jpg")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "jpeg bytes", string(body))
+	assert.Equal(t, int64(10), info.Size)
+	assert.Equal(t, "image/jpeg", info.ContentType)
+
+	info, err = s.Stat(ctx, "covers/b.png")
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), info.Size)
+
+	list, err := s.List(ctx, "covers/")
+	require.NoError(t, err)
+	var keys []string
+	for _, i := range list {
+		keys = append(keys, i.Key)
+	}
+	assert.Equal(t, []string{"covers/a b.jpg", "covers/b.png"}, keys)
+
+	list, err = s.List(ctx, "covers/b.")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "covers/b.png", list[0].Key)
+
+	require.NoError(t, s.Delete(ctx, "covers/b.png"))
+	_, err = s.Stat(ctx, "covers/b.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.Error(t, s.Put(ctx, "../escape.jpg", strings.NewReader("x"), 1, ""))
+	assert.Error(t, s.Put(ctx, "", strings.NewReader("x"), 1, ""))
+}
+
+func TestLocal(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewLocal(root)
+	require.NoError(t, err)
+	exerciseStore(t, s)
+
+	// Keys map onto the plain file layout.
+	data, err := os.ReadFile(filepath.Join(root, "covers", "a b.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg bytes", string(data))
+}
+
+func TestCleanKey(t *testing.T) {
+	for in, want := range map[string]string{
+		"covers/x.jpg":   "covers/x.jpg",
+		"/covers/x.jpg/": "covers/x.jpg",
+	} {
+		got, err := CleanKey(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got)
+	}
+	for _, bad := range []string{"", "/", "covers//x", "covers/../x", "./x"} {
+		_, err := CleanKey(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	s, err := FromConfig(config.Config{RootDir: "/library"})
+	require.NoError(t, err)
+	assert.Equal(t, "/library", s.(*Local).Root())
+
+	s, err = FromConfig(config.Config{BlobStorageBackend: "local", BlobStorageLocalDir: "/blobs", RootDir: "/library"})
+	require.NoError(t, err)
+	assert.Equal(t, "/blobs", s.(*Local).Root())
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "env-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+	s, err = FromConfig(config.Config{BlobStorageBackend: "s3", BlobStorageS3Bucket: "covers"})
+	require.NoError(t, err)
+	assert.Equal(t, "env-key", s.(*S3).opts.AccessKeyID)
+
+	_, err = FromConfig(config.Config{BlobStorageBackend: "s3"})
+	assert.Error(t, err)
+	_, err = FromConfig(config.Config{BlobStorageBackend: "ftp"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,217 @@
+// file: internal/blobstore/local.go
+// version: 1.0.0
+// guid: 3f9c2d74-8e1a-4b56-a0d7-51e6c84b29fa
+// last-edited: 2026-10-16
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/security/safepath"
+)
+
+// Local stores blobs as files under a root directory, one file per key.
+type Local struct {
+	root string
+}
+
+// NewLocal returns a store rooted at root.
+func NewLocal(root string) (*Local, error) {
+	if strings.TrimSpace(root) == "" {
+		return nil, errors.New("blobstore: local storage needs a directory")
+	}
+	return &Local{root: filepath.Clean(root)}, nil
+}
+
+// Name implements Store.
+func (l *Local) Name() string { return BackendLocal }
+
+// Root returns the directory blobs are stored under.
+func (l *Local) Root() string { return l.root }
+
+// Path returns the file that holds key.
+func (l *Local) Path(key string) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	sp, err := safepath.Join(l.root, filepath.FromSlash(key))
+	if err != nil {
+		return "", err
+	}
+	return sp.String(), nil
+}
+
+// Put implements Store. The blob is written to a temporary file and
+// renamed into place, so readers never see a partial file.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := l.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o775); err != nil {
+		return fmt.Errorf("blobstore: create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return fmt.Errorf("blobstore: create %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, readerWithContext(ctx, r)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("blobstore: write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("blobstore: write %s: %w", key, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o664); err != nil {
+		return fmt.Errorf("blobstore: write %s: %w", key, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get implements Store.
+func (l *Local) Get(_ context.Context, key string) (io.ReadCloser, Info, error) {
+	path, err := l.Path(key)
+	if err != nil {
+		return nil, Info{}, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, Info{}, localErr(err)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, Info{}, localErr(err)
+	}
+	if st.IsDir() {
+		f.Close()
+		return nil, Info{}, ErrNotFound
+	}
+	return f, localInfo(key, st), nil
+}
+
+// Stat implements Store.
+func (l *Local) Stat(_ context.Context, key string) (Info, error) {
+	path, err := l.Path(key)
+	if err != nil {
+		return Info{}, err
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		return Info{}, localErr(err)
+	}
+	if st.IsDir() {
+		return Info{}, ErrNotFound
+	}
+	return localInfo(key, st), nil
+}
+
+// Delete implements Store.
+func (l *Local) Delete(_ context.Context, key string) error {
+	path, err := l.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List implements Store. It walks only the directory that prefix names,
+// not the whole root, so listing "covers/" does not descend into the
+// library itself.
+func (l *Local) List(ctx context.Context, prefix string) ([]Info, error) {
+	prefix = strings.TrimLeft(filepath.ToSlash(prefix), "/")
+	dir := l.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		sp, err := safepath.Join(l.root, filepath.FromSlash(prefix[:i]))
+		if err != nil {
+			return nil, err
+		}
+		dir = sp.String()
+	}
+	var out []Info
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rel, err := filepath.Rel(l.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if d.IsDir() {
+			// Skip directories that cannot hold a matching key.
+			if path != dir && !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(d.Name(), ".blob-") {
+			return nil
+		}
+		st, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		out = append(out, localInfo(key, st))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+func localInfo(key string, st fs.FileInfo) Info {
+	return Info{
+		Key:         key,
+		Size:        st.Size(),
+		ModTime:     st.ModTime(),
+		ContentType: mime.TypeByExtension(strings.ToLower(filepath.Ext(key))),
+	}
+}
+
+func localErr(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// readerWithContext stops a copy from r once ctx is done.
+func readerWithContext(ctx context.Context, r io.Reader) io.Reader {
+	return ctxReader{ctx: ctx, r: r}
+}
+
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,277 @@
+// file: internal/blobstore/s3.go
+// version: 1.0.0
+// guid: c51e8a93-2f4d-4b7e-96a0-d83b17f5e2c4
+// last-edited: 2026-10-16
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Options configures an S3 store.
+type S3Options struct {
+	// Endpoint is the service URL, e.g. "https://s3.eu-west-1.amazonaws.com"
+	// or "http://minio:9000"; empty means AWS in Region. A bare host
+	// is reached over HTTPS.
+	Endpoint string
+	// Region signs requests; empty means "us-east-1".
+	Region string
+	Bucket string
+	// Prefix is prepended to every key, so several deployments can share
+	// a bucket.
+	Prefix string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// PathStyle addresses the bucket as endpoint/bucket/key instead of
+	// bucket.endpoint/key. Most self-hosted S3 servers (MinIO, Garage,
+	// SeaweedFS) need it.
+	PathStyle bool
+
+	// Client makes the requests; nil means a client with a 60s timeout.
+	Client *http.Client
+}
+
+// S3 stores blobs as objects in an S3-compatible bucket, signing requests
+// with AWS Signature Version 4.
+type S3 struct {
+	opts     S3Options
+	endpoint *url.URL
+	prefix   string
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3 returns a store for opts.Bucket.
+func NewS3(opts S3Options) (*S3, error) {
+	if opts.Bucket == "" {
+		return nil, errors.New("blobstore: s3 storage needs a bucket")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, errors.New("blobstore: s3 storage needs an access key ID and secret access key")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	raw := strings.TrimRight(opts.Endpoint, "/")
+	if raw == "" {
+		raw = "https://s3." + opts.Region + ".amazonaws.com"
+	} else if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	endpoint, err := url.Parse(raw)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("blobstore: invalid s3 endpoint %q", opts.Endpoint)
+	}
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+	prefix := strings.Trim(opts.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &S3{opts: opts, endpoint: endpoint, prefix: prefix, client: client, now: time.Now}, nil
+}
+
+// Name implements Store.
+func (s *S3) Name() string { return BackendS3 }
+
+// Put implements Store. The body is buffered to sign its hash, which is
+// fine for covers and artwork; it is not meant for audio files.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, _ int64, contentType string) error {
+	body, err := io.ReadAll(readerWithContext(ctx, r))
+	if err != nil {
+		return fmt.Errorf("blobstore: read %s: %w", key, err)
+	}
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, nil, header, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, http.MethodPut, key)
+	}
+	return nil
+}
+
+// Get implements Store.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, Info, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, Info{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, Info{}, s3Error(resp, http.MethodGet, key)
+	}
+	return resp.Body, s3Info(key, resp), nil
+}
+
+// Stat implements Store.
+func (s *S3) Stat(ctx context.Context, key string) (Info, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil, nil)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Info{}, s3Error(resp, http.MethodHead, key)
+	}
+	return s3Info(key, resp), nil
+}
+
+// Delete implements Store.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return s3Error(resp, http.MethodDelete, key)
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List implements Store, following continuation tokens until the listing
+// is complete.
+func (s *S3) List(ctx context.Context, prefix string) ([]Info, error) {
+	prefix = strings.TrimLeft(prefix, "/")
+	var out []Info
+	token := ""
+	for {
+		q := url.Values{"list-type": {"2"}, "prefix": {s.prefix + prefix}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		resp, err := s.request(ctx, http.MethodGet, "", true, q, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := s3Error(resp, "LIST", prefix)
+			resp.Body.Close()
+			return nil, err
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("blobstore: decode s3 listing: %w", err)
+		}
+		for _, c := range page.Contents {
+			out = append(out, Info{Key: strings.TrimPrefix(c.Key, s.prefix), Size: c.Size, ModTime: c.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return out, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// do sends a signed request for the object at key.
+func (s *S3) do(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	return s.request(ctx, method, key, false, query, header, body)
+}
+
+// request sends a signed request for key, or for the bucket itself when
+// bucket is set.
+func (s *S3) request(ctx context.Context, method, key string, bucket bool, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	objectPath := ""
+	if !bucket {
+		clean, err := CleanKey(key)
+		if err != nil {
+			return nil, err
+		}
+		objectPath = s.prefix + clean
+	}
+	u := *s.endpoint
+	base := strings.TrimRight(u.Path, "/")
+	var p string
+	if s.opts.PathStyle {
+		p = base + "/" + s.opts.Bucket + "/" + objectPath
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+		p = base + "/" + objectPath
+	}
+	u.Path, u.RawPath = p, uriEncode(p, false)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.ContentLength = int64(len(body))
+	sum := sha256.Sum256(body)
+	signV4(req, hex.EncodeToString(sum[:]), s.opts.AccessKeyID, s.opts.SecretAccessKey, s.opts.SessionToken, s.opts.Region, s.now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("blobstore: s3 %s %s: %w", method, key, err)
+	}
+	return resp, nil
+}
+
+func s3Info(key string, resp *http.Response) Info {
+	info := Info{Key: key, Size: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}
+	if n, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+		info.Size = n
+	}
+	if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = t
+	}
+	return info
+}
+
+// s3Error turns an error response into an error, mapping a missing key
+// to ErrNotFound.
+func s3Error(resp *http.Response, op, key string) error {
+	var e struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&e)
+	if resp.StatusCode == http.StatusNotFound && (e.Code == "" || e.Code == "NoSuchKey") {
+		return ErrNotFound
+	}
+	if e.Code == "" {
+		e.Code = resp.Status
+	}
+	if e.Message != "" {
+		return fmt.Errorf("blobstore: s3 %s %s: %s: %s", op, key, e.Code, e.Message)
+	}
+	return fmt.Errorf("blobstore: s3 %s %s: %s", op, key, e.Code)
+}
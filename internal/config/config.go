@@ -1,18 +1,23 @@
 // file: internal/config/config.go
-// version: 1.48.0
+// version: 1.83.0
 // guid: 7b8c9d0e-1f2a-3b4c-5d6e-7f8a9b0c1d2e
-// last-edited: 2026-06-14
+// last-edited: 2026-10-17
 
 package config
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/viper"
 )
@@ -35,6 +40,68 @@ type MetadataSource struct {
 	Credentials  map[string]string `json:"credentials"`
 }
 
+// MetadataMergeFields are the fields a merged metadata fetch picks
+// separately, and so the keys MetadataFieldPriority accepts. It must
+// match metadata.MergeFieldNames.
+var MetadataMergeFields = []string{
+	"title", "author", "narrator", "description", "publisher", "publish_year",
+	"isbn", "asin", "cover_url", "language", "genre", "series", "duration",
+	"audible_rating", "google_rating", "category_tags",
+}
+
+// TagFieldMapping pulls a metadata field from nonstandard tag placements.
+// Rippers and older taggers often park the narrator in COMPOSER or a
+// custom TXXX frame, or the series in GROUPING; each rule lists the tags
+// (raw frame names, TXXX descriptions, or aliases such as "composer") to
+// try in order for Field.
+type TagFieldMapping struct {
+	// Field is the metadata field to fill: narrator, series or series_index.
+	Field string   `json:"field"`
+	Tags  []string `json:"tags"`
+	// Override replaces a value the built-in extraction already found;
+	// by default a rule only fills an empty field.
+	Override bool `json:"override"`
+}
+
+// LibraryDefinition is one isolated library in library isolation mode. A
+// book belongs to the library whose RootDir or one of whose ImportPaths
+// contains its file path; users see it when they hold one of Roles.
+type LibraryDefinition struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	RootDir     string   `json:"root_dir"`
+	ImportPaths []string `json:"import_paths"`
+	// Roles are the role IDs (e.g. "family", "kids") allowed to see this
+	// library. Admins see every library regardless.
+	Roles []string `json:"roles"`
+}
+
+// ImportQuota caps what one import path, or one user's uploads, may add
+// to the library. Exactly one of Path and UserID is set; a zero limit is
+// unlimited. Imports past the cap are refused, so a misconfigured watch
+// folder cannot fill the disk.
+type ImportQuota struct {
+	Path     string `json:"path,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+	MaxBooks int    `json:"max_books"`
+	MaxBytes int64  `json:"max_bytes"`
+}
+
+// StorageTier marks a library sub-path as fast ("hot", e.g. an SSD) or
+// slow ("cold", e.g. an HDD or archive mount). The tier policy moves
+// rarely-listened books and non-primary versions from hot paths to the
+// first cold path, keeping their path relative to the tier.
+type StorageTier struct {
+	Path string `json:"path"`
+	Tier string `json:"tier"`
+}
+
+// Storage tier names.
+const (
+	StorageTierHot  = "hot"
+	StorageTierCold = "cold"
+)
+
 // DownloadClientConfig represents download client connection settings.
 type DownloadClientConfig struct {
 	Torrent TorrentClientConfig `json:"torrent"`
@@ -79,6 +146,71 @@ type SABnzbdConfig struct {
 	UseHTTPS bool   `json:"use_https"`
 }
 
+// IntegrationsConfig configures the media servers asked to rescan after
+// books are organized, and the Plex-named export of the library. The
+// servers' tokens are secrets and live beside the other API keys as
+// plex_token and jellyfin_api_key.
+type IntegrationsConfig struct {
+	Plex     MediaServerConfig `json:"plex"`
+	Jellyfin MediaServerConfig `json:"jellyfin"`
+	// PlexExportDir receives the link tree written by the
+	// mediaserver.export-plex operation. It must be outside root_dir.
+	PlexExportDir string `json:"plex_export_dir" mapstructure:"plex_export_dir"`
+}
+
+// MediaServerConfig is one media server.
+type MediaServerConfig struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url"`
+	// LibraryID is the Plex library section key to scan. Empty scans the
+	// section whose folders hold each path. Jellyfin doesn't use it.
+	LibraryID string `json:"library_id,omitempty" mapstructure:"library_id"`
+	// PathMappings rewrite local paths to the server's view of them,
+	// for a server that mounts the library elsewhere.
+	PathMappings []MediaServerPathMap `json:"path_mappings,omitempty" mapstructure:"path_mappings"`
+}
+
+// MediaServerPathMap maps a local path prefix to a media server's.
+type MediaServerPathMap struct {
+	Local  string `json:"local"`
+	Remote string `json:"remote"`
+}
+
+// EmailConfig configures email notifications for finished operations.
+// The SMTP password is a secret and lives beside the other credentials
+// as smtp_password.
+type EmailConfig struct {
+	Enabled  bool   `json:"enabled"`
+	SMTPHost string `json:"smtp_host" mapstructure:"smtp_host"`
+	// SMTPPort defaults to 587.
+	SMTPPort     int    `json:"smtp_port" mapstructure:"smtp_port"`
+	SMTPUsername string `json:"smtp_username,omitempty" mapstructure:"smtp_username"`
+	// SMTPSecurity is starttls (the default), tls for a server that
+	// expects TLS from the first byte (usually port 465), or none.
+	SMTPSecurity string   `json:"smtp_security,omitempty" mapstructure:"smtp_security"`
+	From         string   `json:"from"`
+	Recipients   []string `json:"recipients"`
+	// NotifyOn lists the outcomes that send mail: completed, failed.
+	// Empty sends both.
+	NotifyOn []string `json:"notify_on,omitempty" mapstructure:"notify_on"`
+	// MinDurationMinutes skips completions of operations that ran for
+	// less than this. Failures are always sent.
+	MinDurationMinutes int `json:"min_duration_minutes" mapstructure:"min_duration_minutes"`
+	// Operations limits mail to these operation definition IDs (e.g.
+	// library.scan). Empty covers every operation.
+	Operations []string `json:"operations,omitempty"`
+}
+
+// OperationQueueConfig caps how many queued operations run at once.
+type OperationQueueConfig struct {
+	// MaxConcurrent caps running operations across the whole queue.
+	// Default 8; 0 leaves only the worker count.
+	MaxConcurrent int `json:"max_concurrent" mapstructure:"max_concurrent"`
+	// Pools caps running operations per worker pool: scan, organize and
+	// convert. A missing or 0 entry leaves that pool uncapped.
+	Pools map[string]int `json:"pools"`
+}
+
 // PluginConfig holds per-plugin configuration.
 type PluginConfig struct {
 	Enabled  bool              `json:"enabled"`
@@ -103,13 +235,52 @@ type Config struct {
 	AutoScanDebounceSeconds int    `json:"auto_scan_debounce_seconds"`
 	FolderNamingPattern     string `json:"folder_naming_pattern"`
 	FileNamingPattern       string `json:"file_naming_pattern"`
-	CreateBackups           bool   `json:"create_backups"`
+	// OrganizationLayout names the organizer strategy that lays out book
+	// folders under RootDir: "pattern" (folder_naming_pattern, the
+	// default), "author_series", "flat", "genre" or "first_letter", or
+	// one registered by a plugin. Not to be confused with
+	// OrganizationStrategy, which picks how files get there.
+	OrganizationLayout string `json:"organization_layout"`
+	// WriteSeriesMetadata keeps a series.json in each series folder under
+	// RootDir listing the series' books in order and any gaps. Off by
+	// default.
+	WriteSeriesMetadata bool `json:"write_series_metadata"`
+	// DisambiguationRules pick the {disambiguation} suffix that keeps a
+	// book apart from a different book whose organize path it would
+	// take (same title, different author or narrator). The first rule
+	// whose value is set and differs from the other book's wins; see
+	// DisambiguationRuleNames.
+	DisambiguationRules []string `json:"disambiguation_rules"`
+	// NamingReplaceChar replaces characters filesystems reject (<>:"|?*)
+	// in rendered folder and file names; "" means "_".
+	NamingReplaceChar string `json:"naming_replace_char"`
+	// NamingWindowsSafe also trims trailing dots and spaces from rendered
+	// names and renames reserved device names (CON, NUL, COM1, ...), for
+	// libraries on Windows or SMB shares.
+	NamingWindowsSafe bool `json:"naming_windows_safe"`
+	CreateBackups     bool `json:"create_backups"`
+
+	// Startup scan pacing. StartupScanIdleSeconds delays the startup scan
+	// until the API has been quiet and disk IO low for that long (0 starts
+	// it immediately); StartupScanMaxDelayMinutes starts it anyway after
+	// that long (0 waits indefinitely).
+	StartupScanIdleSeconds     int `json:"startup_scan_idle_seconds"`
+	StartupScanMaxDelayMinutes int `json:"startup_scan_max_delay_minutes"`
 
 	// Storage quotas
 	EnableDiskQuota    bool `json:"enable_disk_quota"`
 	DiskQuotaPercent   int  `json:"disk_quota_percent"`
 	EnableUserQuotas   bool `json:"enable_user_quotas"`
 	DefaultUserQuotaGB int  `json:"default_user_quota_gb"`
+	// ImportQuotas are explicit per-path and per-user caps. Users without
+	// an entry fall back to DefaultUserQuotaGB when EnableUserQuotas is on.
+	ImportQuotas []ImportQuota `json:"import_quotas"`
+	// StorageTiers mark library sub-paths as hot or cold; see StorageTier.
+	StorageTiers []StorageTier `json:"storage_tiers"`
+	// TierColdAfterDays is how long a book on a hot tier may go without
+	// being listened to before the tier policy moves it to cold storage.
+	// Default 180. Set to -1 to move only non-primary versions.
+	TierColdAfterDays int `json:"tier_cold_after_days"`
 
 	// Metadata
 	AutoFetchMetadata         bool             `json:"auto_fetch_metadata"`
@@ -118,6 +289,31 @@ type Config struct {
 	MetadataSources           []MetadataSource `json:"metadata_sources"`
 	Language                  string           `json:"language"`
 	MetadataReviewDefaultView string           `json:"metadata_review_default_view"`
+	// TagFieldMappings are applied in order after the dedicated
+	// narrator/series tags and before the artist/album heuristics and
+	// filename fallback.
+	TagFieldMappings []TagFieldMapping `json:"tag_field_mappings"`
+	// MetadataMergeStrategy is how a fetch combines the source chain:
+	// "first" applies the first source with a good match; "merge" asks
+	// every source and picks each field from the most confident one.
+	MetadataMergeStrategy string `json:"metadata_merge_strategy"`
+	// MetadataFieldPriority reorders the sources for single fields when
+	// merging, e.g. {"narrator": ["audible"], "description":
+	// ["openlibrary", "audible"]}. Keys are MetadataMergeFields; sources
+	// not listed follow in chain order.
+	MetadataFieldPriority map[string][]string `json:"metadata_field_priority"`
+	// EntityMatchStrictness controls how scanned and fetched author/series
+	// names are matched to existing records when the name lookup misses:
+	// "exact", "normalized" (ignore punctuation, diacritics, name order)
+	// or "fuzzy" (also accept near-misses at EntityMatchThreshold
+	// similarity).
+	EntityMatchStrictness string  `json:"entity_match_strictness"`
+	EntityMatchThreshold  float64 `json:"entity_match_threshold"`
+
+	// Library isolation: when enabled, non-admin users only see books
+	// under the libraries their roles are bound to.
+	LibraryIsolation bool                `json:"library_isolation"`
+	Libraries        []LibraryDefinition `json:"libraries"`
 
 	// Open Library data dumps
 	OpenLibraryDumpEnabled bool   `json:"openlibrary_dump_enabled"`
@@ -129,6 +325,29 @@ type Config struct {
 	// Google Books API
 	GoogleBooksAPIKey string `json:"google_books_api_key"`
 
+	// AudibleActivationBytes (8 hex digits) decrypt the account's AAX
+	// files during an Audible library import. AAXC files carry their own
+	// key in a voucher file and don't need them.
+	AudibleActivationBytes string `json:"audible_activation_bytes"`
+
+	// Audiobookshelf server the audiobookshelf.import op reads from when
+	// its params name none. The API token is an admin user's token from
+	// the Audiobookshelf user settings; any other token imports only its
+	// own user's progress.
+	AudiobookshelfURL      string `json:"audiobookshelf_url"`
+	AudiobookshelfAPIToken string `json:"audiobookshelf_api_token"`
+
+	// Media servers (Plex, Jellyfin) rescanned after organizing. Their
+	// tokens are secrets, kept out of the integrations section.
+	Integrations   IntegrationsConfig `json:"integrations"`
+	PlexToken      string             `json:"plex_token"`
+	JellyfinAPIKey string             `json:"jellyfin_api_key"`
+
+	// Email notifications for finished operations. The SMTP password is
+	// a secret, kept out of the email section.
+	Email        EmailConfig `json:"email"`
+	SMTPPassword string      `json:"smtp_password"`
+
 	// AI-powered parsing
 	EnableAIParsing bool   `json:"enable_ai_parsing"`
 	OpenAIAPIKey    string `json:"openai_api_key"`
@@ -148,6 +367,22 @@ type Config struct {
 
 	// Performance
 	ConcurrentScans int `json:"concurrent_scans"`
+	// FileHashAlgorithm picks how file content hashes are computed:
+	// "partial" (default) hashes the first and last FileHashPartialMB of
+	// files over 100 MB plus their size and all of smaller files with
+	// SHA-256; "sha256" always hashes the whole file; "xxhash64" hashes the
+	// whole file with the much faster non-cryptographic xxHash. The
+	// algorithm is recorded next to every hash, and the backfill-file-hashes
+	// job rehashes files hashed some other way.
+	FileHashAlgorithm string `json:"file_hash_algorithm"`
+	// FileHashPartialMB is the size of each chunk hashed in partial mode.
+	// Default 10.
+	FileHashPartialMB int `json:"file_hash_partial_mb"`
+	// FileHashWorkers caps how many files of one multi-file book are
+	// hashed at once. Default 4.
+	FileHashWorkers int `json:"file_hash_workers"`
+	// OperationQueue caps the operation queue, globally and per pool.
+	OperationQueue OperationQueueConfig `json:"operation_queue"`
 	// ChapterConsolidationThresholdMin is the per-file duration threshold (minutes)
 	// used during scanning to detect chapter-named files. If a group of ≥ 3 files
 	// sharing the same base title (e.g. "01 - My Book", "02 - My Book") each
@@ -159,14 +394,32 @@ type Config struct {
 	// MinBookSizeBytes: single-file books below this size are flagged as suspicious and
 	// skipped for heavy processing. Set to -1 to disable. Defaults to 5242880 (5 MB).
 	MinBookSizeBytes int64 `json:"min_book_size_bytes"`
-	// Log retention in days (0 = keep forever)
+	// SizeAnomalyMinKbps and SizeAnomalyMaxKbps bound the average bitrate
+	// (file size over claimed duration) of a plausible import. Imported
+	// books outside the range, such as a few kilobytes claiming ten
+	// hours, are quarantined after each scan. Set either to -1 to
+	// disable that side. Default 8 and 2500.
+	SizeAnomalyMinKbps int `json:"size_anomaly_min_kbps"`
+	SizeAnomalyMaxKbps int `json:"size_anomaly_max_kbps"`
+	// Log retention in days (0 = keep forever; default 90). Operation log
+	// lines, change records and system activity older than this are pruned
+	// weekly.
 	LogRetentionDays int `json:"log_retention_days"`
-	// Operation log retention in days (0 = keep forever; default 90)
+	// Operation retention in days (0 = keep forever; default 90). Finished
+	// registry operations, with their logs, are deleted daily once this old.
 	OperationLogRetentionDays int `json:"operation_log_retention_days"`
 	// Activity log retention (separate from operation log retention)
 	ActivityLogRetentionChangeDays int `json:"activity_log_retention_change_days"` // default 90
 	ActivityLogRetentionDebugDays  int `json:"activity_log_retention_debug_days"`  // default 30
 	ActivityLogCompactionDays      int `json:"activity_log_compaction_days"`       // default 14
+	// Application log. Every server log line at or above AppLogLevel
+	// ("debug", "info", "warn", "error", or "off" to disable) is kept in
+	// the database for GET /system/logs. Lines older than
+	// AppLogRetentionDays are pruned hourly, debug lines after
+	// AppLogDebugRetentionDays (0 keeps them). Default info, 14 and 1.
+	AppLogLevel              string `json:"app_log_level"`
+	AppLogRetentionDays      int    `json:"app_log_retention_days"`
+	AppLogDebugRetentionDays int    `json:"app_log_debug_retention_days"`
 
 	// Embedding-based dedup
 	EmbeddingEnabled         bool    `json:"embedding_enabled"`           // default true
@@ -229,6 +482,43 @@ type Config struct {
 	EnableAuth             bool `json:"enable_auth"`
 	EnableRateLimit        bool `json:"enable_rate_limit"`
 
+	// RequestTimeoutReadSeconds and RequestTimeoutWriteSeconds bound API
+	// requests: reads (GET, HEAD) and writes (everything else). Streaming
+	// and transfer routes are never timed. -1 disables a class.
+	RequestTimeoutReadSeconds  int `json:"request_timeout_read_seconds"`  // default 60
+	RequestTimeoutWriteSeconds int `json:"request_timeout_write_seconds"` // default 300
+
+	// Web serving. BasePath hosts the app under a sub-path (e.g.
+	// "/audiobooks") behind a reverse proxy; empty serves from the root.
+	// BaseURL is the external URL clients reach the app at (e.g.
+	// "https://host/audiobooks"), used for generated links; its path
+	// doubles as BasePath when that is unset. WebDir serves a built
+	// frontend (web/dist) from disk in builds without the embedded
+	// frontend.
+	BasePath string `json:"base_path"`
+	BaseURL  string `json:"base_url"`
+	WebDir   string `json:"web_dir"`
+	// ListenHost and ListenPort override the serve command's --host and
+	// --port (unless those are given explicitly). Changing them at
+	// runtime moves the HTTP listener without a restart.
+	ListenHost string `json:"listen_host"`
+	ListenPort int    `json:"listen_port"`
+
+	// Blob storage for covers and other artwork. "local" (the default)
+	// keeps them under BlobStorageLocalDir, or the library root when that
+	// is empty; "s3" keeps them in an S3-compatible bucket, with the local
+	// covers directory acting as a cache. S3 credentials left empty are
+	// read from AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY.
+	BlobStorageBackend           string `json:"blob_storage_backend"`
+	BlobStorageLocalDir          string `json:"blob_storage_local_dir"`
+	BlobStorageS3Endpoint        string `json:"blob_storage_s3_endpoint"`
+	BlobStorageS3Region          string `json:"blob_storage_s3_region"`
+	BlobStorageS3Bucket          string `json:"blob_storage_s3_bucket"`
+	BlobStorageS3Prefix          string `json:"blob_storage_s3_prefix"`
+	BlobStorageS3AccessKeyID     string `json:"blob_storage_s3_access_key_id"`
+	BlobStorageS3SecretAccessKey string `json:"blob_storage_s3_secret_access_key"`
+	BlobStorageS3PathStyle       bool   `json:"blob_storage_s3_path_style"`
+
 	// Basic HTTP auth (lightweight single-user alternative)
 	BasicAuthEnabled  bool   `json:"basic_auth_enabled"`
 	BasicAuthUsername string `json:"basic_auth_username"`
@@ -251,6 +541,47 @@ type Config struct {
 	// Lifecycle / retention
 	PurgeSoftDeletedAfterDays   int  `json:"purge_soft_deleted_after_days"`
 	PurgeSoftDeletedDeleteFiles bool `json:"purge_soft_deleted_delete_files"`
+	// TrashRetentionDays is how long a book hard-deleted with its files
+	// waits in "pending purge": the files sit in <root_dir>/.trash and a
+	// restore moves them back. 0 deletes files immediately. Default 7.
+	TrashRetentionDays int `json:"trash_retention_days"`
+	// SoftDeleteToTrash makes soft deletes move the book's files to
+	// <root_dir>/.trash at once; a restore moves them back and the purge
+	// after PurgeSoftDeletedAfterDays deletes them. Default false.
+	SoftDeleteToTrash bool `json:"soft_delete_to_trash"`
+
+	// Operation archiving. Finished operations older than
+	// OperationArchiveAfterDays move out of the operations table into
+	// compressed archive storage (0 disables archiving); archived entries
+	// older than OperationArchiveRetentionDays are deleted (0 keeps them).
+	OperationArchiveAfterDays     int `json:"operation_archive_after_days"`
+	OperationArchiveRetentionDays int `json:"operation_archive_retention_days"`
+
+	// Freeze snapshots. Before a destructive bulk operation (organize,
+	// transcode, purge) runs, the database and a manifest of the files it
+	// may touch are snapshotted so the operation can be undone by its ID.
+	// The newest FreezeSnapshotRetention snapshots are kept (0 means 5).
+	FreezeSnapshotsEnabled  bool `json:"freeze_snapshots_enabled"`
+	FreezeSnapshotRetention int  `json:"freeze_snapshot_retention"`
+
+	// Slow-query log. When enabled, every database call is timed and
+	// calls taking at least SlowQueryThresholdMs are logged and listed at
+	// GET /system/slow-queries. Takes effect on restart; the threshold
+	// applies immediately.
+	SlowQueryLogEnabled  bool `json:"slow_query_log_enabled"`
+	SlowQueryThresholdMs int  `json:"slow_query_threshold_ms"`
+
+	// Failed-file retry queue. Files whose scan or organize step failed
+	// for a transient reason are retried after RetryBaseDelaySeconds,
+	// doubling per failure, until RetryMaxAttempts failures.
+	RetryMaxAttempts      int `json:"retry_max_attempts"`
+	RetryBaseDelaySeconds int `json:"retry_base_delay_seconds"`
+
+	// Batch metadata fetches (bulk-fetch-metadata) search this many books
+	// at once, calling each provider at most MetadataFetchRatePerSecond
+	// times a second across all workers (0 = unlimited).
+	MetadataFetchConcurrency   int     `json:"metadata_fetch_concurrency"`
+	MetadataFetchRatePerSecond float64 `json:"metadata_fetch_rate_per_second"`
 
 	// Logging
 	LogLevel          string `json:"log_level"`  // 'debug', 'info', 'warn', 'error'
@@ -286,12 +617,22 @@ type Config struct {
 	AutoUpdateCheckMinutes int    `json:"auto_update_check_minutes"` // e.g. 60
 	AutoUpdateWindowStart  int    `json:"auto_update_window_start"`  // hour 0-23, e.g. 1
 	AutoUpdateWindowEnd    int    `json:"auto_update_window_end"`    // hour 0-23, e.g. 4
+	// UpdateCheckEnabled polls GitHub releases on the auto-update interval
+	// and raises an update.available notification without installing
+	// anything. Implied when AutoUpdateEnabled is set.
+	UpdateCheckEnabled bool `json:"update_check_enabled"`
 
 	// Maintenance window (unified — replaces separate auto-update window)
 	MaintenanceWindowEnabled bool `json:"maintenance_window_enabled"`
 	MaintenanceWindowStart   int  `json:"maintenance_window_start"` // hour 0-23, default 1
 	MaintenanceWindowEnd     int  `json:"maintenance_window_end"`   // hour 0-23, default 4
 
+	// Timezone is the IANA zone (e.g. "Europe/Berlin") that schedule hours
+	// (maintenance and update windows), cron schedules and daily activity
+	// digests are read in. Empty uses the server's local zone. API
+	// timestamps are always UTC.
+	Timezone string `json:"timezone"`
+
 	// Download client integration
 	DownloadClient DownloadClientConfig `json:"download_client"`
 
@@ -428,7 +769,14 @@ func InitConfig() {
 	viper.SetDefault("auto_scan_debounce_seconds", 30)
 	viper.SetDefault("folder_naming_pattern", "{author}/{series}/{title} ({print_year})")
 	viper.SetDefault("file_naming_pattern", "{title} - {author} - read by {narrator}")
+	viper.SetDefault("organization_layout", "pattern")
+	viper.SetDefault("write_series_metadata", false)
+	viper.SetDefault("disambiguation_rules", DefaultDisambiguationRules)
+	viper.SetDefault("naming_replace_char", "_")
+	viper.SetDefault("naming_windows_safe", false)
 	viper.SetDefault("create_backups", true)
+	viper.SetDefault("startup_scan_idle_seconds", 120)
+	viper.SetDefault("startup_scan_max_delay_minutes", 30)
 
 	// Set storage quota defaults
 	viper.SetDefault("enable_disk_quota", false)
@@ -442,6 +790,9 @@ func InitConfig() {
 	viper.SetDefault("embed_cover_art", false)
 	viper.SetDefault("language", "en")
 	viper.SetDefault("metadata_review_default_view", "compact")
+	viper.SetDefault("metadata_merge_strategy", "first")
+	viper.SetDefault("entity_match_strictness", "normalized")
+	viper.SetDefault("entity_match_threshold", 0.92)
 
 	// Open Library dump defaults
 	viper.SetDefault("openlibrary_dump_enabled", false)
@@ -467,20 +818,50 @@ func InitConfig() {
 		defaultWorkers = 4
 	}
 	viper.SetDefault("concurrent_scans", defaultWorkers)
+	viper.SetDefault("file_hash_algorithm", "partial")
+	viper.SetDefault("file_hash_partial_mb", 10)
+	viper.SetDefault("file_hash_workers", 4)
+	viper.SetDefault("operation_queue.max_concurrent", 8)
+	viper.SetDefault("operation_queue.pools", map[string]int{"scan": 1, "organize": 1, "convert": 2})
 	viper.SetDefault("chapter_consolidation_threshold_min", 10)
 	viper.SetDefault("operation_timeout_minutes", 30)
 	viper.SetDefault("log_retention_days", 90)
+	viper.SetDefault("operation_log_retention_days", 90)
 
 	// API security/runtime limits
 	viper.SetDefault("api_rate_limit_per_minute", 0)
 	viper.SetDefault("auth_rate_limit_per_minute", 10)
 	viper.SetDefault("json_body_limit_mb", 1)
 	viper.SetDefault("upload_body_limit_mb", 10)
+	viper.SetDefault("request_timeout_read_seconds", 60)
+	viper.SetDefault("request_timeout_write_seconds", 300)
 	viper.SetDefault("enable_auth", true)
 	viper.SetDefault("enable_rate_limit", true)
 	viper.SetDefault("basic_auth_enabled", false)
 	viper.SetDefault("basic_auth_username", "")
 	viper.SetDefault("basic_auth_password", "")
+	viper.SetDefault("base_path", "")
+	viper.SetDefault("base_url", "")
+	viper.SetDefault("listen_host", "")
+	viper.SetDefault("listen_port", 0)
+	viper.SetDefault("blob_storage_backend", "local")
+	viper.SetDefault("blob_storage_local_dir", "")
+	viper.SetDefault("blob_storage_s3_endpoint", "")
+	viper.SetDefault("blob_storage_s3_region", "")
+	viper.SetDefault("blob_storage_s3_bucket", "")
+	viper.SetDefault("blob_storage_s3_prefix", "")
+	viper.SetDefault("blob_storage_s3_access_key_id", "")
+	viper.SetDefault("blob_storage_s3_secret_access_key", "")
+	viper.SetDefault("blob_storage_s3_path_style", false)
+	viper.SetDefault("audible_activation_bytes", "")
+	viper.SetDefault("audiobookshelf_url", "")
+	viper.SetDefault("audiobookshelf_api_token", "")
+	viper.SetDefault("plex_token", "")
+	viper.SetDefault("jellyfin_api_key", "")
+	viper.SetDefault("smtp_password", "")
+	viper.SetDefault("email.smtp_port", 587)
+	viper.SetDefault("email.min_duration_minutes", 5)
+	viper.SetDefault("web_dir", "")
 
 	// Set memory management defaults
 	viper.SetDefault("memory_limit_type", "items")
@@ -492,6 +873,21 @@ func InitConfig() {
 	// Lifecycle / retention defaults
 	viper.SetDefault("purge_soft_deleted_after_days", 30)
 	viper.SetDefault("purge_soft_deleted_delete_files", false)
+	viper.SetDefault("trash_retention_days", 7)
+	viper.SetDefault("soft_delete_to_trash", false)
+	viper.SetDefault("app_log_level", "info")
+	viper.SetDefault("app_log_retention_days", 14)
+	viper.SetDefault("app_log_debug_retention_days", 1)
+	viper.SetDefault("operation_archive_after_days", 30)
+	viper.SetDefault("operation_archive_retention_days", 0)
+	viper.SetDefault("freeze_snapshots_enabled", true)
+	viper.SetDefault("freeze_snapshot_retention", 5)
+	viper.SetDefault("slow_query_log_enabled", true)
+	viper.SetDefault("slow_query_threshold_ms", 250)
+	viper.SetDefault("retry_max_attempts", 5)
+	viper.SetDefault("retry_base_delay_seconds", 60)
+	viper.SetDefault("metadata_fetch_concurrency", 8)
+	viper.SetDefault("metadata_fetch_rate_per_second", 5.0)
 
 	// Set logging defaults
 	viper.SetDefault("log_level", "info")
@@ -533,11 +929,13 @@ func InitConfig() {
 	viper.SetDefault("auto_update_check_minutes", 60)
 	viper.SetDefault("auto_update_window_start", 1)
 	viper.SetDefault("auto_update_window_end", 4)
+	viper.SetDefault("update_check_enabled", false)
 
 	// Maintenance window defaults
 	viper.SetDefault("maintenance_window_enabled", true)
 	viper.SetDefault("maintenance_window_start", 1)
 	viper.SetDefault("maintenance_window_end", 4)
+	viper.SetDefault("timezone", "")
 	// Per-task defaults — maintenance tasks default true
 	viper.SetDefault("maintenance_window_dedup_refresh", true)
 	viper.SetDefault("maintenance_window_series_prune", true)
@@ -621,8 +1019,16 @@ func InitConfig() {
 			AutoScanDebounceSeconds: viper.GetInt("auto_scan_debounce_seconds"),
 			FolderNamingPattern:     viper.GetString("folder_naming_pattern"),
 			FileNamingPattern:       viper.GetString("file_naming_pattern"),
+			OrganizationLayout:      viper.GetString("organization_layout"),
+			WriteSeriesMetadata:     viper.GetBool("write_series_metadata"),
+			DisambiguationRules:     viper.GetStringSlice("disambiguation_rules"),
+			NamingReplaceChar:       viper.GetString("naming_replace_char"),
+			NamingWindowsSafe:       viper.GetBool("naming_windows_safe"),
 			CreateBackups:           viper.GetBool("create_backups"),
 
+			StartupScanIdleSeconds:     viper.GetInt("startup_scan_idle_seconds"),
+			StartupScanMaxDelayMinutes: viper.GetInt("startup_scan_max_delay_minutes"),
+
 			// Storage quotas
 			EnableDiskQuota:    viper.GetBool("enable_disk_quota"),
 			DiskQuotaPercent:   viper.GetInt("disk_quota_percent"),
@@ -656,18 +1062,46 @@ func InitConfig() {
 
 			// Performance
 			ConcurrentScans:                  viper.GetInt("concurrent_scans"),
+			FileHashAlgorithm:                viper.GetString("file_hash_algorithm"),
+			FileHashPartialMB:                viper.GetInt("file_hash_partial_mb"),
+			FileHashWorkers:                  viper.GetInt("file_hash_workers"),
 			ChapterConsolidationThresholdMin: viper.GetInt("chapter_consolidation_threshold_min"),
 			OperationTimeoutMinutes:          viper.GetInt("operation_timeout_minutes"),
 			MinBookSizeBytes:                 viper.GetInt64("min_book_size_bytes"),
+			SizeAnomalyMinKbps:               viper.GetInt("size_anomaly_min_kbps"),
+			SizeAnomalyMaxKbps:               viper.GetInt("size_anomaly_max_kbps"),
+			TierColdAfterDays:                viper.GetInt("tier_cold_after_days"),
 			APIRateLimitPerMinute:            viper.GetInt("api_rate_limit_per_minute"),
 			AuthRateLimitPerMinute:           viper.GetInt("auth_rate_limit_per_minute"),
 			JSONBodyLimitMB:                  viper.GetInt("json_body_limit_mb"),
 			UploadBodyLimitMB:                viper.GetInt("upload_body_limit_mb"),
+			RequestTimeoutReadSeconds:        viper.GetInt("request_timeout_read_seconds"),
+			RequestTimeoutWriteSeconds:       viper.GetInt("request_timeout_write_seconds"),
 			EnableAuth:                       viper.GetBool("enable_auth"),
 			EnableRateLimit:                  viper.GetBool("enable_rate_limit"),
 			BasicAuthEnabled:                 viper.GetBool("basic_auth_enabled"),
 			BasicAuthUsername:                viper.GetString("basic_auth_username"),
 			BasicAuthPassword:                viper.GetString("basic_auth_password"),
+			BasePath:                         viper.GetString("base_path"),
+			BaseURL:                          viper.GetString("base_url"),
+			ListenHost:                       viper.GetString("listen_host"),
+			ListenPort:                       viper.GetInt("listen_port"),
+			BlobStorageBackend:               viper.GetString("blob_storage_backend"),
+			BlobStorageLocalDir:              viper.GetString("blob_storage_local_dir"),
+			BlobStorageS3Endpoint:            viper.GetString("blob_storage_s3_endpoint"),
+			BlobStorageS3Region:              viper.GetString("blob_storage_s3_region"),
+			BlobStorageS3Bucket:              viper.GetString("blob_storage_s3_bucket"),
+			BlobStorageS3Prefix:              viper.GetString("blob_storage_s3_prefix"),
+			BlobStorageS3AccessKeyID:         viper.GetString("blob_storage_s3_access_key_id"),
+			BlobStorageS3SecretAccessKey:     viper.GetString("blob_storage_s3_secret_access_key"),
+			BlobStorageS3PathStyle:           viper.GetBool("blob_storage_s3_path_style"),
+			AudibleActivationBytes:           viper.GetString("audible_activation_bytes"),
+			AudiobookshelfURL:                viper.GetString("audiobookshelf_url"),
+			AudiobookshelfAPIToken:           viper.GetString("audiobookshelf_api_token"),
+			PlexToken:                        viper.GetString("plex_token"),
+			JellyfinAPIKey:                   viper.GetString("jellyfin_api_key"),
+			SMTPPassword:                     viper.GetString("smtp_password"),
+			WebDir:                           viper.GetString("web_dir"),
 
 			// Memory management
 			MemoryLimitType:           viper.GetString("memory_limit_type"),
@@ -679,6 +1113,29 @@ func InitConfig() {
 			// Lifecycle / retention
 			PurgeSoftDeletedAfterDays:   viper.GetInt("purge_soft_deleted_after_days"),
 			PurgeSoftDeletedDeleteFiles: viper.GetBool("purge_soft_deleted_delete_files"),
+			TrashRetentionDays:          viper.GetInt("trash_retention_days"),
+			SoftDeleteToTrash:           viper.GetBool("soft_delete_to_trash"),
+
+			AppLogLevel:              viper.GetString("app_log_level"),
+			AppLogRetentionDays:      viper.GetInt("app_log_retention_days"),
+			AppLogDebugRetentionDays: viper.GetInt("app_log_debug_retention_days"),
+
+			LogRetentionDays:              viper.GetInt("log_retention_days"),
+			OperationLogRetentionDays:     viper.GetInt("operation_log_retention_days"),
+			OperationArchiveAfterDays:     viper.GetInt("operation_archive_after_days"),
+			OperationArchiveRetentionDays: viper.GetInt("operation_archive_retention_days"),
+
+			FreezeSnapshotsEnabled:  viper.GetBool("freeze_snapshots_enabled"),
+			FreezeSnapshotRetention: viper.GetInt("freeze_snapshot_retention"),
+
+			SlowQueryLogEnabled:  viper.GetBool("slow_query_log_enabled"),
+			SlowQueryThresholdMs: viper.GetInt("slow_query_threshold_ms"),
+
+			RetryMaxAttempts:      viper.GetInt("retry_max_attempts"),
+			RetryBaseDelaySeconds: viper.GetInt("retry_base_delay_seconds"),
+
+			MetadataFetchConcurrency:   viper.GetInt("metadata_fetch_concurrency"),
+			MetadataFetchRatePerSecond: viper.GetFloat64("metadata_fetch_rate_per_second"),
 
 			// Logging
 			LogLevel:          viper.GetString("log_level"),
@@ -691,11 +1148,13 @@ func InitConfig() {
 			AutoUpdateCheckMinutes: viper.GetInt("auto_update_check_minutes"),
 			AutoUpdateWindowStart:  viper.GetInt("auto_update_window_start"),
 			AutoUpdateWindowEnd:    viper.GetInt("auto_update_window_end"),
+			UpdateCheckEnabled:     viper.GetBool("update_check_enabled"),
 
 			// Maintenance window
 			MaintenanceWindowEnabled:              viper.GetBool("maintenance_window_enabled"),
 			MaintenanceWindowStart:                viper.GetInt("maintenance_window_start"),
 			MaintenanceWindowEnd:                  viper.GetInt("maintenance_window_end"),
+			Timezone:                              viper.GetString("timezone"),
 			MaintenanceWindowDedupRefresh:         viper.GetBool("maintenance_window_dedup_refresh"),
 			MaintenanceWindowSeriesPrune:          viper.GetBool("maintenance_window_series_prune"),
 			MaintenanceWindowAuthorSplit:          viper.GetBool("maintenance_window_author_split"),
@@ -789,6 +1248,35 @@ func InitConfig() {
 
 		// API Keys (Goodreads deprecated Dec 2020, removed)
 
+		if viper.IsSet("tag_field_mappings") {
+			viper.UnmarshalKey("tag_field_mappings", &c.TagFieldMappings)
+		}
+		c.MetadataMergeStrategy = viper.GetString("metadata_merge_strategy")
+		if viper.IsSet("metadata_field_priority") {
+			viper.UnmarshalKey("metadata_field_priority", &c.MetadataFieldPriority)
+		}
+		c.LibraryIsolation = viper.GetBool("library_isolation")
+		c.EntityMatchStrictness = viper.GetString("entity_match_strictness")
+		c.EntityMatchThreshold = viper.GetFloat64("entity_match_threshold")
+		if viper.IsSet("import_quotas") {
+			viper.UnmarshalKey("import_quotas", &c.ImportQuotas)
+		}
+		if viper.IsSet("storage_tiers") {
+			viper.UnmarshalKey("storage_tiers", &c.StorageTiers)
+		}
+		if viper.IsSet("libraries") {
+			viper.UnmarshalKey("libraries", &c.Libraries)
+		}
+		if viper.IsSet("integrations") {
+			viper.UnmarshalKey("integrations", &c.Integrations)
+		}
+		if viper.IsSet("email") {
+			viper.UnmarshalKey("email", &c.Email)
+		}
+		if viper.IsSet("operation_queue") {
+			viper.UnmarshalKey("operation_queue", &c.OperationQueue)
+		}
+
 		// Load metadata sources from config or use defaults
 		if viper.IsSet("metadata_sources") {
 			viper.UnmarshalKey("metadata_sources", &c.MetadataSources)
@@ -871,12 +1359,29 @@ func InitConfig() {
 	}) // end Mutate
 }
 
-var validPatternPlaceholder = regexp.MustCompile(`\{[A-Za-z0-9_]+\}`)
+// DisambiguationRuleNames are the valid disambiguation_rules entries:
+// book fields, plus "id" (the start of the book ID, always distinct).
+var DisambiguationRuleNames = []string{"narrator", "year", "edition", "publisher", "language", "asin", "id"}
+
+// DefaultDisambiguationRules is the disambiguation_rules default.
+var DefaultDisambiguationRules = []string{"narrator", "year", "edition", "id"}
+
+var validPatternPlaceholder = regexp.MustCompile(`\{[A-Za-z0-9_]+(:[^{}<>]*)?\}`)
 
 func hasBalancedBraces(value string) bool {
 	return strings.Count(value, "{") == strings.Count(value, "}")
 }
 
+// OrganizationLayoutValidator checks OrganizationLayout against the
+// registered layouts. The organizer installs it; config can't import the
+// organizer to look them up itself.
+var OrganizationLayoutValidator func(c *Config) error
+
+// NamingPatternValidator parses a naming pattern with the organizer's
+// template engine, catching what the checks here don't (format specs,
+// optional segments). The organizer installs it.
+var NamingPatternValidator func(pattern string) error
+
 func validateNamingPattern(value string) error {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
@@ -889,6 +1394,9 @@ func validateNamingPattern(value string) error {
 	if strings.Contains(withoutPlaceholders, "{") || strings.Contains(withoutPlaceholders, "}") {
 		return fmt.Errorf("invalid placeholder format in pattern")
 	}
+	if NamingPatternValidator != nil {
+		return NamingPatternValidator(trimmed)
+	}
 	return nil
 }
 
@@ -921,6 +1429,64 @@ func validateParentDirWritable(path string, field string) error {
 	return nil
 }
 
+// basePathPattern matches a normalized base path: "" or "/"-led segments
+// of unreserved URL characters.
+var basePathPattern = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)*$`)
+
+// activationBytesPattern matches Audible activation bytes.
+var activationBytesPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}$`)
+
+// NormalizeBasePath returns p with a leading slash and no trailing slash,
+// or "" for the root. Segments are limited to unreserved URL characters so
+// the value can be spliced into HTML and redirects without escaping.
+func NormalizeBasePath(p string) (string, error) {
+	p = strings.Trim(strings.TrimSpace(p), "/")
+	if p == "" {
+		return "", nil
+	}
+	p = "/" + p
+	if !basePathPattern.MatchString(p) {
+		return "", fmt.Errorf("must be a URL path of letters, digits, '.', '_', '~' or '-' segments")
+	}
+	for _, seg := range strings.Split(p[1:], "/") {
+		if seg == "." || seg == ".." {
+			return "", fmt.Errorf("must not contain %q segments", seg)
+		}
+	}
+	return p, nil
+}
+
+// normalizeBaseURL checks that raw is an absolute http(s) URL without a
+// query or fragment and returns it without a trailing slash, along with
+// its normalized path.
+func normalizeBaseURL(raw string) (baseURL, basePath string, err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", "", fmt.Errorf("must be an absolute http or https URL")
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", "", fmt.Errorf("must not contain credentials, a query or a fragment")
+	}
+	basePath, err = NormalizeBasePath(u.Path)
+	if err != nil {
+		return "", "", fmt.Errorf("path %w", err)
+	}
+	return u.Scheme + "://" + u.Host + basePath, basePath, nil
+}
+
+// ExternalURL returns the absolute URL for path (which starts with "/")
+// under BaseURL, or "" when no base URL is configured.
+func (c *Config) ExternalURL(path string) string {
+	if c == nil || c.BaseURL == "" {
+		return ""
+	}
+	return c.BaseURL + path
+}
+
 // Validate performs structural checks on runtime configuration values.
 func (c *Config) Validate() error {
 	if c == nil {
@@ -948,15 +1514,96 @@ func (c *Config) Validate() error {
 	if c.ConcurrentScans < 0 {
 		errs = append(errs, "concurrent_scans must be >= 0")
 	}
+	switch c.FileHashAlgorithm {
+	case "", "partial", "sha256", "xxhash64":
+	default:
+		errs = append(errs, "file_hash_algorithm must be one of: partial, sha256, xxhash64")
+	}
+	if c.FileHashPartialMB < 0 {
+		errs = append(errs, "file_hash_partial_mb must be >= 0")
+	}
+	if c.FileHashWorkers < 0 {
+		errs = append(errs, "file_hash_workers must be >= 0")
+	}
+	if c.OperationQueue.MaxConcurrent < 0 {
+		errs = append(errs, "operation_queue.max_concurrent must be >= 0")
+	}
+	for _, pool := range slices.Sorted(maps.Keys(c.OperationQueue.Pools)) {
+		if c.OperationQueue.Pools[pool] < 0 {
+			errs = append(errs, fmt.Sprintf("operation_queue.pools.%s must be >= 0", pool))
+		}
+	}
 	if c.MinBookSizeBytes == 0 {
 		c.MinBookSizeBytes = 5 * 1024 * 1024
 	}
+	if c.SizeAnomalyMinKbps == 0 {
+		c.SizeAnomalyMinKbps = 8
+	}
+	if c.SizeAnomalyMaxKbps == 0 {
+		c.SizeAnomalyMaxKbps = 2500
+	}
+	if c.SizeAnomalyMinKbps > 0 && c.SizeAnomalyMaxKbps > 0 && c.SizeAnomalyMinKbps >= c.SizeAnomalyMaxKbps {
+		errs = append(errs, "size_anomaly_min_kbps must be below size_anomaly_max_kbps")
+	}
+	if c.TierColdAfterDays == 0 {
+		c.TierColdAfterDays = 180
+	}
+	if c.TierColdAfterDays < -1 {
+		errs = append(errs, "tier_cold_after_days must be positive, or -1 to disable")
+	}
 	if c.AutoScanDebounceSeconds < 0 {
 		errs = append(errs, "auto_scan_debounce_seconds must be >= 0")
 	}
+	if c.StartupScanIdleSeconds < 0 {
+		errs = append(errs, "startup_scan_idle_seconds must be >= 0")
+	}
+	if c.StartupScanMaxDelayMinutes < 0 {
+		errs = append(errs, "startup_scan_max_delay_minutes must be >= 0")
+	}
 	if c.OperationTimeoutMinutes < 0 {
 		errs = append(errs, "operation_timeout_minutes must be >= 0")
 	}
+	if c.LogRetentionDays < 0 {
+		errs = append(errs, "log_retention_days must be >= 0")
+	}
+	if c.OperationLogRetentionDays < 0 {
+		errs = append(errs, "operation_log_retention_days must be >= 0")
+	}
+	if c.OperationArchiveAfterDays < 0 {
+		errs = append(errs, "operation_archive_after_days must be >= 0")
+	}
+	if c.OperationArchiveRetentionDays < 0 {
+		errs = append(errs, "operation_archive_retention_days must be >= 0")
+	}
+	if c.FreezeSnapshotRetention < 0 {
+		errs = append(errs, "freeze_snapshot_retention must be >= 0")
+	}
+	switch strings.ToLower(c.AppLogLevel) {
+	case "", "debug", "info", "warn", "error", "off":
+	default:
+		errs = append(errs, "app_log_level must be debug, info, warn, error or off")
+	}
+	if c.AppLogRetentionDays < 0 {
+		errs = append(errs, "app_log_retention_days must be >= 0")
+	}
+	if c.AppLogDebugRetentionDays < 0 {
+		errs = append(errs, "app_log_debug_retention_days must be >= 0")
+	}
+	if c.SlowQueryThresholdMs < 0 {
+		errs = append(errs, "slow_query_threshold_ms must be >= 0")
+	}
+	if c.RetryMaxAttempts < 0 {
+		errs = append(errs, "retry_max_attempts must be >= 0")
+	}
+	if c.RetryBaseDelaySeconds < 0 {
+		errs = append(errs, "retry_base_delay_seconds must be >= 0")
+	}
+	if c.MetadataFetchConcurrency < 0 {
+		errs = append(errs, "metadata_fetch_concurrency must be >= 0")
+	}
+	if c.MetadataFetchRatePerSecond < 0 {
+		errs = append(errs, "metadata_fetch_rate_per_second must be >= 0")
+	}
 	if c.APIRateLimitPerMinute < 0 {
 		errs = append(errs, "api_rate_limit_per_minute must be >= 0")
 	}
@@ -969,6 +1616,58 @@ func (c *Config) Validate() error {
 	if c.UploadBodyLimitMB < 0 {
 		errs = append(errs, "upload_body_limit_mb must be >= 0")
 	}
+	if c.RequestTimeoutReadSeconds == 0 {
+		c.RequestTimeoutReadSeconds = 60
+	}
+	if c.RequestTimeoutReadSeconds < -1 {
+		errs = append(errs, "request_timeout_read_seconds must be positive, or -1 to disable")
+	}
+	if c.RequestTimeoutWriteSeconds == 0 {
+		c.RequestTimeoutWriteSeconds = 300
+	}
+	if c.RequestTimeoutWriteSeconds < -1 {
+		errs = append(errs, "request_timeout_write_seconds must be positive, or -1 to disable")
+	}
+	if c.ListenPort < 0 || c.ListenPort > 65535 {
+		errs = append(errs, "listen_port must be between 0 and 65535")
+	}
+	if strings.ContainsAny(c.ListenHost, " /") {
+		errs = append(errs, "listen_host must be a host name or IP address")
+	}
+	if c.AudibleActivationBytes != "" && !activationBytesPattern.MatchString(c.AudibleActivationBytes) {
+		errs = append(errs, "audible_activation_bytes must be 8 hex digits")
+	}
+	switch c.BlobStorageBackend {
+	case "", "local":
+	case "s3":
+		if c.BlobStorageS3Bucket == "" {
+			errs = append(errs, "blob_storage_s3_bucket is required when blob_storage_backend is s3")
+		}
+	default:
+		errs = append(errs, "blob_storage_backend must be local or s3")
+	}
+	if bp, err := NormalizeBasePath(c.BasePath); err != nil {
+		errs = append(errs, "base_path "+err.Error())
+	} else {
+		c.BasePath = bp
+	}
+	if bu, urlPath, err := normalizeBaseURL(c.BaseURL); err != nil {
+		errs = append(errs, "base_url "+err.Error())
+	} else {
+		c.BaseURL = bu
+		switch {
+		case bu == "":
+		case c.BasePath == "":
+			c.BasePath = urlPath
+		case c.BasePath != urlPath:
+			errs = append(errs, fmt.Sprintf("base_url path %q does not match base_path %q", urlPath, c.BasePath))
+		}
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			errs = append(errs, fmt.Sprintf("timezone %q is not a known IANA time zone", c.Timezone))
+		}
+	}
 	if c.EnableDiskQuota && (c.DiskQuotaPercent < 1 || c.DiskQuotaPercent > 100) {
 		errs = append(errs, "disk_quota_percent must be between 1 and 100")
 	}
@@ -982,6 +1681,22 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if OrganizationLayoutValidator != nil {
+		if err := OrganizationLayoutValidator(c); err != nil {
+			errs = append(errs, "organization_layout: "+err.Error())
+		}
+	}
+
+	for _, rule := range c.DisambiguationRules {
+		if !slices.Contains(DisambiguationRuleNames, rule) {
+			errs = append(errs, fmt.Sprintf("disambiguation_rules: unknown rule %q (want one of %s)", rule, strings.Join(DisambiguationRuleNames, ", ")))
+		}
+	}
+
+	if utf8.RuneCountInString(c.NamingReplaceChar) > 1 || strings.ContainsAny(c.NamingReplaceChar, `/\<>:"|?*`) {
+		errs = append(errs, fmt.Sprintf("naming_replace_char %q must be a single character allowed in file names", c.NamingReplaceChar))
+	}
+
 	if strings.TrimSpace(c.FolderNamingPattern) != "" {
 		if err := validateNamingPattern(c.FolderNamingPattern); err != nil {
 			errs = append(errs, "folder_naming_pattern "+err.Error())
@@ -993,6 +1708,79 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for i, m := range c.TagFieldMappings {
+		switch m.Field {
+		case "narrator", "series", "series_index":
+		default:
+			errs = append(errs, fmt.Sprintf("tag_field_mappings[%d].field %q must be one of: narrator, series, series_index", i, m.Field))
+		}
+		if len(m.Tags) == 0 {
+			errs = append(errs, fmt.Sprintf("tag_field_mappings[%d].tags must not be empty", i))
+		}
+	}
+
+	switch c.MetadataMergeStrategy {
+	case "", "first", "merge":
+	default:
+		errs = append(errs, "metadata_merge_strategy must be one of: first, merge")
+	}
+	for _, field := range slices.Sorted(maps.Keys(c.MetadataFieldPriority)) {
+		if !slices.Contains(MetadataMergeFields, field) {
+			errs = append(errs, fmt.Sprintf("metadata_field_priority key %q must be one of: %s", field, strings.Join(MetadataMergeFields, ", ")))
+		}
+	}
+
+	libraryIDs := make(map[string]bool, len(c.Libraries))
+	for i, lib := range c.Libraries {
+		switch {
+		case strings.TrimSpace(lib.ID) == "":
+			errs = append(errs, fmt.Sprintf("libraries[%d].id must not be empty", i))
+		case libraryIDs[lib.ID]:
+			errs = append(errs, fmt.Sprintf("libraries[%d].id %q is duplicated", i, lib.ID))
+		}
+		libraryIDs[lib.ID] = true
+		if !filepath.IsAbs(lib.RootDir) {
+			errs = append(errs, fmt.Sprintf("libraries[%d].root_dir must be an absolute path", i))
+		}
+		if len(lib.Roles) == 0 {
+			errs = append(errs, fmt.Sprintf("libraries[%d].roles must not be empty", i))
+		}
+	}
+	for i, q := range c.ImportQuotas {
+		if (q.Path == "") == (q.UserID == "") {
+			errs = append(errs, fmt.Sprintf("import_quotas[%d] must set exactly one of path or user_id", i))
+		} else if q.Path != "" && !filepath.IsAbs(q.Path) {
+			errs = append(errs, fmt.Sprintf("import_quotas[%d].path must be an absolute path", i))
+		}
+		if q.MaxBooks < 0 || q.MaxBytes < 0 {
+			errs = append(errs, fmt.Sprintf("import_quotas[%d] limits must be >= 0", i))
+		}
+	}
+	tierPaths := make(map[string]bool, len(c.StorageTiers))
+	for i, t := range c.StorageTiers {
+		switch {
+		case !filepath.IsAbs(t.Path):
+			errs = append(errs, fmt.Sprintf("storage_tiers[%d].path must be an absolute path", i))
+		case tierPaths[filepath.Clean(t.Path)]:
+			errs = append(errs, fmt.Sprintf("storage_tiers[%d].path %q is duplicated", i, t.Path))
+		}
+		tierPaths[filepath.Clean(t.Path)] = true
+		if t.Tier != StorageTierHot && t.Tier != StorageTierCold {
+			errs = append(errs, fmt.Sprintf("storage_tiers[%d].tier must be hot or cold", i))
+		}
+	}
+	switch c.EntityMatchStrictness {
+	case "", "exact", "normalized", "fuzzy":
+	default:
+		errs = append(errs, "entity_match_strictness must be one of: exact, normalized, fuzzy")
+	}
+	if c.EntityMatchStrictness == "fuzzy" && (c.EntityMatchThreshold <= 0 || c.EntityMatchThreshold > 1) {
+		errs = append(errs, "entity_match_threshold must be greater than 0 and at most 1")
+	}
+	if c.LibraryIsolation && len(c.Libraries) == 0 {
+		errs = append(errs, "library_isolation requires at least one entry in libraries")
+	}
+
 	for _, ext := range c.SupportedExtensions {
 		if ext == "" {
 			continue
@@ -1033,8 +1821,16 @@ func ResetToDefaults() {
 			AutoScanDebounceSeconds: 30,
 			FolderNamingPattern:     "{author}/{series}/{title} ({print_year})",
 			FileNamingPattern:       "{title} - {author} - read by {narrator}",
+			OrganizationLayout:      "pattern",
+			WriteSeriesMetadata:     false,
+			DisambiguationRules:     append([]string(nil), DefaultDisambiguationRules...),
+			NamingReplaceChar:       "_",
+			NamingWindowsSafe:       false,
 			CreateBackups:           true,
 
+			StartupScanIdleSeconds:     120,
+			StartupScanMaxDelayMinutes: 30,
+
 			// Storage quotas
 			EnableDiskQuota:    false,
 			DiskQuotaPercent:   80,
@@ -1042,9 +1838,12 @@ func ResetToDefaults() {
 			DefaultUserQuotaGB: 100,
 
 			// Metadata
-			AutoFetchMetadata: true,
-			EmbedCoverArt:     false,
-			Language:          "en",
+			AutoFetchMetadata:     true,
+			EmbedCoverArt:         false,
+			Language:              "en",
+			MetadataMergeStrategy: "first",
+			EntityMatchStrictness: "normalized",
+			EntityMatchThreshold:  0.92,
 
 			// Open Library dumps
 			OpenLibraryDumpEnabled: false,
@@ -1060,18 +1859,27 @@ func ResetToDefaults() {
 			CoverArtModel:       "gpt-5-mini",
 
 			// Performance
-			ConcurrentScans:         max(runtime.NumCPU(), 4),
-			OperationTimeoutMinutes: 30,
-			MinBookSizeBytes:        5 * 1024 * 1024,
-			APIRateLimitPerMinute:   100,
-			AuthRateLimitPerMinute:  10,
-			JSONBodyLimitMB:         1,
-			UploadBodyLimitMB:       10,
-			EnableAuth:              true,
-			EnableRateLimit:         true,
-			BasicAuthEnabled:        false,
-			BasicAuthUsername:       "",
-			BasicAuthPassword:       "",
+			ConcurrentScans:            max(runtime.NumCPU(), 4),
+			FileHashAlgorithm:          "partial",
+			FileHashPartialMB:          10,
+			FileHashWorkers:            4,
+			OperationTimeoutMinutes:    30,
+			MinBookSizeBytes:           5 * 1024 * 1024,
+			SizeAnomalyMinKbps:         8,
+			SizeAnomalyMaxKbps:         2500,
+			TierColdAfterDays:          180,
+			APIRateLimitPerMinute:      100,
+			AuthRateLimitPerMinute:     10,
+			JSONBodyLimitMB:            1,
+			UploadBodyLimitMB:          10,
+			RequestTimeoutReadSeconds:  60,
+			RequestTimeoutWriteSeconds: 300,
+			EnableAuth:                 true,
+			EnableRateLimit:            true,
+			BasicAuthEnabled:           false,
+			BasicAuthUsername:          "",
+			BasicAuthPassword:          "",
+			BlobStorageBackend:         "local",
 
 			// Memory management
 			MemoryLimitType:    "items",
@@ -1082,9 +1890,31 @@ func ResetToDefaults() {
 			// Lifecycle / retention
 			PurgeSoftDeletedAfterDays:      30,
 			PurgeSoftDeletedDeleteFiles:    false,
+			TrashRetentionDays:             7,
+			SoftDeleteToTrash:              false,
 			ActivityLogRetentionChangeDays: 90,
 			ActivityLogRetentionDebugDays:  30,
 			ActivityLogCompactionDays:      14,
+			AppLogLevel:                    "info",
+			AppLogRetentionDays:            14,
+			AppLogDebugRetentionDays:       1,
+
+			LogRetentionDays:              90,
+			OperationLogRetentionDays:     90,
+			OperationArchiveAfterDays:     30,
+			OperationArchiveRetentionDays: 0,
+
+			FreezeSnapshotsEnabled:  true,
+			FreezeSnapshotRetention: 5,
+
+			SlowQueryLogEnabled:  true,
+			SlowQueryThresholdMs: 250,
+
+			RetryMaxAttempts:      5,
+			RetryBaseDelaySeconds: 60,
+
+			MetadataFetchConcurrency:   8,
+			MetadataFetchRatePerSecond: 5,
 
 			// Embedding-based dedup
 			EmbeddingEnabled:                true,
@@ -1123,11 +1953,13 @@ func ResetToDefaults() {
 			AutoUpdateCheckMinutes: 60,
 			AutoUpdateWindowStart:  1,
 			AutoUpdateWindowEnd:    4,
+			UpdateCheckEnabled:     false,
 
 			// Maintenance window
 			MaintenanceWindowEnabled:          true,
 			MaintenanceWindowStart:            1,
 			MaintenanceWindowEnd:              4,
+			Timezone:                          "",
 			MaintenanceWindowDedupRefresh:     true,
 			MaintenanceWindowSeriesPrune:      true,
 			MaintenanceWindowAuthorSplit:      true,
@@ -1151,6 +1983,14 @@ func ResetToDefaults() {
 			ITLWriteBackEnabled:    false,
 			ITunesLibraryWritePath: "",
 
+			// Email notifications (off until enabled)
+			Email: EmailConfig{SMTPPort: 587, MinDurationMinutes: 5},
+
+			OperationQueue: OperationQueueConfig{
+				MaxConcurrent: 8,
+				Pools:         map[string]int{"scan": 1, "organize": 1, "convert": 2},
+			},
+
 			// Download client integration
 			DownloadClient: DownloadClientConfig{
 				Torrent: TorrentClientConfig{
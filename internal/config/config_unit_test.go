@@ -1,5 +1,5 @@
 // file: internal/config/config_unit_test.go
-// version: 1.3.1
+// version: 1.22.0
 
 package config
 
@@ -100,6 +100,7 @@ func TestValidateNamingPattern(t *testing.T) {
 		{"valid simple", "{title}", ""},
 		{"valid with separators", "{author}/{series}/{title}", ""},
 		{"valid with literal text", "{title} ({print_year})", ""},
+		{"valid with format spec", "{series}/<{series_num:02d} - >{title}", ""},
 		{"empty pattern", "", "pattern cannot be empty"},
 		{"whitespace only", "   ", "pattern cannot be empty"},
 		{"unbalanced braces", "{author/{title}", "unbalanced braces"},
@@ -119,6 +120,31 @@ func TestValidateNamingPattern(t *testing.T) {
 	}
 }
 
+func TestNormalizeBasePath(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"", "", false},
+		{"/", "", false},
+		{"audiobooks", "/audiobooks", false},
+		{" /apps/audiobooks/ ", "/apps/audiobooks", false},
+		{"/a b", "", true},
+		{"/x/../y", "", true},
+		{"/x?y=1", "", true},
+		{`/"><script>`, "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeBasePath(tt.in)
+		if tt.wantErr {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
+
 func TestValidateParentDirExists(t *testing.T) {
 	t.Run("empty path is ok", func(t *testing.T) {
 		assert.NoError(t, validateParentDirExists("", "test_field"))
@@ -202,6 +228,34 @@ func TestConfigValidate(t *testing.T) {
 		assert.NoError(t, c.Validate())
 	})
 
+	t.Run("base_url sets base_path", func(t *testing.T) {
+		c := &Config{DatabaseType: "pebble", BaseURL: "https://example.com/audiobooks/"}
+		require.NoError(t, c.Validate())
+		assert.Equal(t, "https://example.com/audiobooks", c.BaseURL)
+		assert.Equal(t, "/audiobooks", c.BasePath)
+		assert.Equal(t, "https://example.com/audiobooks/library/b1", c.ExternalURL("/library/b1"))
+	})
+
+	t.Run("base_url must match base_path", func(t *testing.T) {
+		c := &Config{DatabaseType: "pebble", BaseURL: "https://example.com/abs", BasePath: "/books"}
+		assert.ErrorContains(t, c.Validate(), "does not match base_path")
+	})
+
+	t.Run("base_url must be absolute", func(t *testing.T) {
+		for _, raw := range []string{"example.com/abs", "ftp://example.com", "https://example.com/?x=1"} {
+			c := &Config{DatabaseType: "pebble", BaseURL: raw}
+			assert.ErrorContains(t, c.Validate(), "base_url", raw)
+		}
+	})
+
+	t.Run("timezone must be a known zone", func(t *testing.T) {
+		c := &Config{DatabaseType: "pebble", Timezone: "Mars/Olympus_Mons"}
+		assert.ErrorContains(t, c.Validate(), "timezone")
+
+		c = &Config{DatabaseType: "pebble", Timezone: "Europe/Berlin"}
+		assert.NoError(t, c.Validate())
+	})
+
 	t.Run("negative concurrent scans", func(t *testing.T) {
 		c := &Config{DatabaseType: "pebble", ConcurrentScans: -1}
 		err := c.Validate()
@@ -244,6 +298,95 @@ func TestConfigValidate(t *testing.T) {
 		assert.ErrorContains(t, err, "upload_body_limit_mb must be >= 0")
 	})
 
+	t.Run("invalid tag field mapping", func(t *testing.T) {
+		c := &Config{
+			DatabaseType: "pebble",
+			TagFieldMappings: []TagFieldMapping{
+				{Field: "narrator", Tags: []string{"composer"}},
+				{Field: "title", Tags: []string{"TXXX:TITLE"}},
+				{Field: "series"},
+			},
+		}
+		err := c.Validate()
+		assert.ErrorContains(t, err, "tag_field_mappings[1].field \"title\"")
+		assert.ErrorContains(t, err, "tag_field_mappings[2].tags must not be empty")
+		assert.NotContains(t, err.Error(), "tag_field_mappings[0]")
+	})
+
+	t.Run("invalid metadata merge settings", func(t *testing.T) {
+		c := &Config{
+			DatabaseType:          "pebble",
+			MetadataMergeStrategy: "vote",
+			MetadataFieldPriority: map[string][]string{"narrator": {"audible"}, "blurb": {"openlibrary"}},
+		}
+		err := c.Validate()
+		assert.ErrorContains(t, err, "metadata_merge_strategy must be one of: first, merge")
+		assert.ErrorContains(t, err, "metadata_field_priority key \"blurb\"")
+		assert.NotContains(t, err.Error(), "\"narrator\"")
+	})
+
+	t.Run("invalid library definitions", func(t *testing.T) {
+		c := &Config{
+			DatabaseType:     "pebble",
+			LibraryIsolation: true,
+			Libraries: []LibraryDefinition{
+				{ID: "kids", RootDir: "/lib/kids", Roles: []string{"child"}},
+				{ID: "kids", RootDir: "relative", Roles: []string{"parent"}},
+				{RootDir: "/lib/other"},
+			},
+		}
+		err := c.Validate()
+		assert.ErrorContains(t, err, "libraries[1].id \"kids\" is duplicated")
+		assert.ErrorContains(t, err, "libraries[1].root_dir must be an absolute path")
+		assert.ErrorContains(t, err, "libraries[2].id must not be empty")
+		assert.ErrorContains(t, err, "libraries[2].roles must not be empty")
+		assert.NotContains(t, err.Error(), "libraries[0]")
+
+		c.Libraries = nil
+		assert.ErrorContains(t, c.Validate(), "library_isolation requires at least one entry in libraries")
+	})
+
+	t.Run("invalid import quotas", func(t *testing.T) {
+		c := &Config{
+			DatabaseType: "pebble",
+			ImportQuotas: []ImportQuota{
+				{Path: "/watch", MaxBooks: 10},
+				{Path: "/watch", UserID: "alice", MaxBooks: 1},
+				{Path: "relative", MaxBytes: -1},
+			},
+		}
+		err := c.Validate()
+		assert.ErrorContains(t, err, "import_quotas[1] must set exactly one of path or user_id")
+		assert.ErrorContains(t, err, "import_quotas[2].path must be an absolute path")
+		assert.ErrorContains(t, err, "import_quotas[2] limits must be >= 0")
+		assert.NotContains(t, err.Error(), "import_quotas[0]")
+	})
+
+	t.Run("invalid request timeouts", func(t *testing.T) {
+		c := &Config{DatabaseType: "pebble", RequestTimeoutReadSeconds: -2, RequestTimeoutWriteSeconds: -1}
+		err := c.Validate()
+		assert.ErrorContains(t, err, "request_timeout_read_seconds")
+		assert.NotContains(t, err.Error(), "request_timeout_write_seconds")
+	})
+
+	t.Run("invalid storage tiers", func(t *testing.T) {
+		c := &Config{
+			DatabaseType:      "pebble",
+			TierColdAfterDays: -5,
+			StorageTiers: []StorageTier{
+				{Path: "/ssd/books", Tier: "hot"},
+				{Path: "/ssd/books/", Tier: "cold"},
+				{Path: "archive", Tier: "warm"},
+			},
+		}
+		err := c.Validate()
+		assert.ErrorContains(t, err, "storage_tiers[1].path \"/ssd/books/\" is duplicated")
+		assert.ErrorContains(t, err, "storage_tiers[2].path must be an absolute path")
+		assert.ErrorContains(t, err, "storage_tiers[2].tier must be hot or cold")
+		assert.ErrorContains(t, err, "tier_cold_after_days")
+		assert.NotContains(t, err.Error(), "storage_tiers[0]")
+	})
+
 	t.Run("disk quota out of range", func(t *testing.T) {
 		c := &Config{
 			DatabaseType:     "pebble",
@@ -606,6 +749,15 @@ func TestApplySettingStringKeys(t *testing.T) {
 		{"itunes_library_xml_path", "/xml/path2", func() string { return AppConfig.ITunesLibraryReadPath }},
 		{"basic_auth_username", "admin", func() string { return AppConfig.BasicAuthUsername }},
 		{"basic_auth_password", "secret", func() string { return AppConfig.BasicAuthPassword }},
+		{"blob_storage_s3_bucket", "covers", func() string { return AppConfig.BlobStorageS3Bucket }},
+		{"blob_storage_s3_secret_access_key", "s3-secret", func() string { return AppConfig.BlobStorageS3SecretAccessKey }},
+		{"audible_activation_bytes", "1a2b3c4d", func() string { return AppConfig.AudibleActivationBytes }},
+		{"audiobookshelf_url", "http://abs:13378", func() string { return AppConfig.AudiobookshelfURL }},
+		{"audiobookshelf_api_token", "abs-tok", func() string { return AppConfig.AudiobookshelfAPIToken }},
+		{"plex_token", "plex-tok", func() string { return AppConfig.PlexToken }},
+		{"jellyfin_api_key", "jf-key", func() string { return AppConfig.JellyfinAPIKey }},
+		{"smtp_password", "smtp-pass", func() string { return AppConfig.SMTPPassword }},
+		{"metadata_merge_strategy", "merge", func() string { return AppConfig.MetadataMergeStrategy }},
 	}
 	for _, tt := range tests {
 		t.Run(tt.key, func(t *testing.T) {
@@ -638,6 +790,8 @@ func TestApplySettingBoolKeys(t *testing.T) {
 		{"enable_json_logging", func() bool { return AppConfig.EnableJsonLogging }},
 		{"auto_update_enabled", func() bool { return AppConfig.AutoUpdateEnabled }},
 		{"purge_soft_deleted_delete_files", func() bool { return AppConfig.PurgeSoftDeletedDeleteFiles }},
+		{"freeze_snapshots_enabled", func() bool { return AppConfig.FreezeSnapshotsEnabled }},
+		{"slow_query_log_enabled", func() bool { return AppConfig.SlowQueryLogEnabled }},
 		{"itunes_sync_enabled", func() bool { return AppConfig.ITunesSyncEnabled }},
 		{"itl_write_back_enabled", func() bool { return AppConfig.ITLWriteBackEnabled }},
 		{"itunes_auto_write_back", func() bool { return AppConfig.ITunesAutoWriteBack }},
@@ -651,6 +805,7 @@ func TestApplySettingBoolKeys(t *testing.T) {
 		{"maintenance_window_purge_old_logs", func() bool { return AppConfig.MaintenanceWindowPurgeOldLogs }},
 		{"maintenance_window_db_optimize", func() bool { return AppConfig.MaintenanceWindowDbOptimize }},
 		{"maintenance_window_library_scan", func() bool { return AppConfig.MaintenanceWindowLibraryScan }},
+		{"blob_storage_s3_path_style", func() bool { return AppConfig.BlobStorageS3PathStyle }},
 		{"maintenance_window_library_organize", func() bool { return AppConfig.MaintenanceWindowLibraryOrganize }},
 		{"maintenance_window_metadata_refresh", func() bool { return AppConfig.MaintenanceWindowMetadataRefresh }},
 		{"basic_auth_enabled", func() bool { return AppConfig.BasicAuthEnabled }},
@@ -712,6 +867,8 @@ func TestApplySettingIntKeys(t *testing.T) {
 		{"auto_update_window_start", "2", func() int { return AppConfig.AutoUpdateWindowStart }},
 		{"auto_update_window_end", "5", func() int { return AppConfig.AutoUpdateWindowEnd }},
 		{"purge_soft_deleted_after_days", "30", func() int { return AppConfig.PurgeSoftDeletedAfterDays }},
+		{"freeze_snapshot_retention", "7", func() int { return AppConfig.FreezeSnapshotRetention }},
+		{"slow_query_threshold_ms", "500", func() int { return AppConfig.SlowQueryThresholdMs }},
 		{"itunes_sync_interval", "60", func() int { return AppConfig.ITunesSyncInterval }},
 		{"maintenance_window_start", "3", func() int { return AppConfig.MaintenanceWindowStart }},
 		{"maintenance_window_end", "6", func() int { return AppConfig.MaintenanceWindowEnd }},
@@ -777,6 +934,13 @@ func TestApplySettingJSONKeys(t *testing.T) {
 		assert.Empty(t, AppConfig.ExcludePatterns)
 	})
 
+	t.Run("disambiguation_rules", func(t *testing.T) {
+		AppConfig = Config{}
+		err := applySetting("disambiguation_rules", `["year","id"]`, "json")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"year", "id"}, AppConfig.DisambiguationRules)
+	})
+
 	t.Run("metadata_sources", func(t *testing.T) {
 		AppConfig = Config{}
 		sources := []MetadataSource{{ID: "audible", Name: "Audible", Enabled: true}}
@@ -795,6 +959,13 @@ func TestApplySettingJSONKeys(t *testing.T) {
 		assert.Equal(t, orig, AppConfig.MetadataSources)
 	})
 
+	t.Run("metadata_field_priority", func(t *testing.T) {
+		AppConfig = Config{}
+		err := applySetting("metadata_field_priority", `{"narrator":["audible","audnexus"]}`, "json")
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"narrator": {"audible", "audnexus"}}, AppConfig.MetadataFieldPriority)
+	})
+
 	t.Run("itunes_path_mappings", func(t *testing.T) {
 		AppConfig = Config{}
 		mappings := []ITunesPathMap{{From: "/itunes", To: "/local"}}
@@ -1178,9 +1349,34 @@ func TestMinBookSizeBytesDisable(t *testing.T) {
 		"-1 sentinel should not be coerced")
 }
 
+func TestSizeAnomalyKbpsDefaults(t *testing.T) {
+	c := &Config{SizeAnomalyMaxKbps: -1}
+	_ = c.Validate()
+	assert.Equal(t, 8, c.SizeAnomalyMinKbps, "zero value should be coerced to the default")
+	assert.Equal(t, -1, c.SizeAnomalyMaxKbps, "-1 sentinel should not be coerced")
+
+	c = &Config{SizeAnomalyMinKbps: 500, SizeAnomalyMaxKbps: 100}
+	assert.ErrorContains(t, c.Validate(), "size_anomaly_min_kbps")
+}
+
 func TestMinBookSizeBytesCustom(t *testing.T) {
 	c := &Config{MinBookSizeBytes: 5 * 1024 * 1024}
 	_ = c.Validate()
 	assert.Equal(t, int64(5*1024*1024), c.MinBookSizeBytes,
 		"explicit value should be preserved")
 }
+
+func TestLocation(t *testing.T) {
+	orig := AppConfig
+	t.Cleanup(func() { AppConfig = orig })
+
+	AppConfig = Config{}
+	assert.Equal(t, serverLocation, Location())
+
+	AppConfig = Config{Timezone: "America/New_York"}
+	assert.Equal(t, "America/New_York", Location().String())
+	assert.Equal(t, "America/New_York", Now().Location().String())
+
+	AppConfig = Config{Timezone: "not/a-zone"}
+	assert.Equal(t, serverLocation, Location())
+}
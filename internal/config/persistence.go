@@ -1,7 +1,7 @@
 // file: internal/config/persistence.go
-// version: 1.19.0
+// version: 1.49.0
 // guid: 9c8d7e6f-5a4b-3c2d-1e0f-9a8b7c6d5e4f
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package config
 
@@ -111,6 +111,7 @@ func SaveConfigToFile() error {
 		"auto_organize":         c.AutoOrganize,
 		"folder_naming_pattern": c.FolderNamingPattern,
 		"file_naming_pattern":   c.FileNamingPattern,
+		"organization_layout":   c.OrganizationLayout,
 		"auto_fetch_metadata":   c.AutoFetchMetadata,
 		"language":              c.Language,
 		"enable_ai_parsing":     c.EnableAIParsing,
@@ -253,6 +254,18 @@ func LoadConfigFromDatabase(store database.SettingsStore) error {
 				plaintext = snapSecrets.HardcoverAPIToken
 			case "basic_auth_password":
 				plaintext = snapSecrets.BasicAuthPassword
+			case "blob_storage_s3_secret_access_key":
+				plaintext = snapSecrets.BlobStorageS3SecretAccessKey
+			case "audible_activation_bytes":
+				plaintext = snapSecrets.AudibleActivationBytes
+			case "audiobookshelf_api_token":
+				plaintext = snapSecrets.AudiobookshelfAPIToken
+			case "plex_token":
+				plaintext = snapSecrets.PlexToken
+			case "jellyfin_api_key":
+				plaintext = snapSecrets.JellyfinAPIKey
+			case "smtp_password":
+				plaintext = snapSecrets.SMTPPassword
 			}
 			if plaintext != "" {
 				if err := store.SetSetting(key, plaintext, "string", true); err != nil {
@@ -360,6 +373,12 @@ func applySetting(key, value, typ string) error {
 			c.FolderNamingPattern = value
 		case "file_naming_pattern":
 			c.FileNamingPattern = value
+		case "organization_layout":
+			c.OrganizationLayout = value
+		case "write_series_metadata":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.WriteSeriesMetadata = b
+			}
 		case "create_backups":
 			if b, err := strconv.ParseBool(value); err == nil {
 				c.CreateBackups = b
@@ -376,6 +395,17 @@ func applySetting(key, value, typ string) error {
 			if err := json.Unmarshal([]byte(value), &patterns); err == nil {
 				c.ExcludePatterns = patterns
 			}
+		case "disambiguation_rules":
+			var rules []string
+			if err := json.Unmarshal([]byte(value), &rules); err == nil {
+				c.DisambiguationRules = rules
+			}
+		case "naming_replace_char":
+			c.NamingReplaceChar = value
+		case "naming_windows_safe":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.NamingWindowsSafe = b
+			}
 
 		// Storage quotas
 		case "enable_disk_quota":
@@ -409,6 +439,47 @@ func applySetting(key, value, typ string) error {
 			if err := json.Unmarshal([]byte(value), &sources); err == nil && len(sources) > 0 {
 				c.MetadataSources = sources
 			}
+		case "tag_field_mappings":
+			var rules []TagFieldMapping
+			if err := json.Unmarshal([]byte(value), &rules); err == nil {
+				c.TagFieldMappings = rules
+			}
+		case "metadata_merge_strategy":
+			c.MetadataMergeStrategy = value
+		case "metadata_field_priority":
+			var priority map[string][]string
+			if err := json.Unmarshal([]byte(value), &priority); err == nil {
+				c.MetadataFieldPriority = priority
+			}
+		case "entity_match_strictness":
+			c.EntityMatchStrictness = value
+		case "entity_match_threshold":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				c.EntityMatchThreshold = f
+			}
+		case "import_quotas":
+			var quotas []ImportQuota
+			if err := json.Unmarshal([]byte(value), &quotas); err == nil {
+				c.ImportQuotas = quotas
+			}
+		case "storage_tiers":
+			var tiers []StorageTier
+			if err := json.Unmarshal([]byte(value), &tiers); err == nil {
+				c.StorageTiers = tiers
+			}
+		case "tier_cold_after_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.TierColdAfterDays = i
+			}
+		case "library_isolation":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.LibraryIsolation = b
+			}
+		case "libraries":
+			var libs []LibraryDefinition
+			if err := json.Unmarshal([]byte(value), &libs); err == nil {
+				c.Libraries = libs
+			}
 
 		// Open Library dumps
 		case "openlibrary_dump_enabled":
@@ -437,6 +508,16 @@ func applySetting(key, value, typ string) error {
 			if i, err := strconv.Atoi(value); err == nil {
 				c.ConcurrentScans = i
 			}
+		case "file_hash_algorithm":
+			c.FileHashAlgorithm = value
+		case "file_hash_partial_mb":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.FileHashPartialMB = i
+			}
+		case "file_hash_workers":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.FileHashWorkers = i
+			}
 		case "operation_timeout_minutes":
 			if i, err := strconv.Atoi(value); err == nil {
 				c.OperationTimeoutMinutes = i
@@ -457,6 +538,14 @@ func applySetting(key, value, typ string) error {
 			if i, err := strconv.Atoi(value); err == nil {
 				c.UploadBodyLimitMB = i
 			}
+		case "request_timeout_read_seconds":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.RequestTimeoutReadSeconds = i
+			}
+		case "request_timeout_write_seconds":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.RequestTimeoutWriteSeconds = i
+			}
 		case "enable_auth":
 			if b, err := strconv.ParseBool(value); err == nil {
 				c.EnableAuth = b
@@ -477,6 +566,14 @@ func applySetting(key, value, typ string) error {
 			if i, err := strconv.Atoi(value); err == nil {
 				c.AutoScanDebounceSeconds = i
 			}
+		case "startup_scan_idle_seconds":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.StartupScanIdleSeconds = i
+			}
+		case "startup_scan_max_delay_minutes":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.StartupScanMaxDelayMinutes = i
+			}
 
 		// Memory management
 		case "memory_limit_type":
@@ -529,6 +626,10 @@ func applySetting(key, value, typ string) error {
 			if i, err := strconv.Atoi(value); err == nil {
 				c.AutoUpdateWindowEnd = i
 			}
+		case "update_check_enabled":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.UpdateCheckEnabled = b
+			}
 
 		// Lifecycle / retention
 		case "purge_soft_deleted_after_days":
@@ -539,6 +640,96 @@ func applySetting(key, value, typ string) error {
 			if b, err := strconv.ParseBool(value); err == nil {
 				c.PurgeSoftDeletedDeleteFiles = b
 			}
+		case "trash_retention_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.TrashRetentionDays = i
+			}
+		case "soft_delete_to_trash":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.SoftDeleteToTrash = b
+			}
+		case "app_log_level":
+			c.AppLogLevel = value
+		case "app_log_retention_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.AppLogRetentionDays = i
+			}
+		case "app_log_debug_retention_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.AppLogDebugRetentionDays = i
+			}
+		case "log_retention_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.LogRetentionDays = i
+			}
+		case "operation_log_retention_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.OperationLogRetentionDays = i
+			}
+		case "operation_archive_after_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.OperationArchiveAfterDays = i
+			}
+		case "operation_archive_retention_days":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.OperationArchiveRetentionDays = i
+			}
+		case "freeze_snapshots_enabled":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.FreezeSnapshotsEnabled = b
+			}
+		case "freeze_snapshot_retention":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.FreezeSnapshotRetention = i
+			}
+		case "slow_query_log_enabled":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.SlowQueryLogEnabled = b
+			}
+		case "slow_query_threshold_ms":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.SlowQueryThresholdMs = i
+			}
+		case "retry_max_attempts":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.RetryMaxAttempts = i
+			}
+		case "retry_base_delay_seconds":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.RetryBaseDelaySeconds = i
+			}
+		case "listen_host":
+			c.ListenHost = value
+		case "listen_port":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.ListenPort = i
+			}
+		case "blob_storage_backend":
+			c.BlobStorageBackend = value
+		case "blob_storage_local_dir":
+			c.BlobStorageLocalDir = value
+		case "blob_storage_s3_endpoint":
+			c.BlobStorageS3Endpoint = value
+		case "blob_storage_s3_region":
+			c.BlobStorageS3Region = value
+		case "blob_storage_s3_bucket":
+			c.BlobStorageS3Bucket = value
+		case "blob_storage_s3_prefix":
+			c.BlobStorageS3Prefix = value
+		case "blob_storage_s3_access_key_id":
+			c.BlobStorageS3AccessKeyID = value
+		case "blob_storage_s3_path_style":
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.BlobStorageS3PathStyle = b
+			}
+		case "metadata_fetch_concurrency":
+			if i, err := strconv.Atoi(value); err == nil {
+				c.MetadataFetchConcurrency = i
+			}
+		case "metadata_fetch_rate_per_second":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				c.MetadataFetchRatePerSecond = f
+			}
 
 		// iTunes sync
 		case "itunes_sync_enabled":
@@ -588,6 +779,8 @@ func applySetting(key, value, typ string) error {
 			if i, err := strconv.Atoi(value); err == nil {
 				c.MaintenanceWindowEnd = i
 			}
+		case "timezone":
+			c.Timezone = value
 		case "maintenance_window_dedup_refresh":
 			if b, err := strconv.ParseBool(value); err == nil {
 				c.MaintenanceWindowDedupRefresh = b
@@ -714,6 +907,20 @@ func applySetting(key, value, typ string) error {
 			c.BasicAuthUsername = value
 		case "basic_auth_password":
 			c.BasicAuthPassword = value
+		case "blob_storage_s3_secret_access_key":
+			c.BlobStorageS3SecretAccessKey = value
+		case "audible_activation_bytes":
+			c.AudibleActivationBytes = value
+		case "audiobookshelf_url":
+			c.AudiobookshelfURL = value
+		case "audiobookshelf_api_token":
+			c.AudiobookshelfAPIToken = value
+		case "plex_token":
+			c.PlexToken = value
+		case "jellyfin_api_key":
+			c.JellyfinAPIKey = value
+		case "smtp_password":
+			c.SMTPPassword = value
 
 		default:
 			applyErr = fmt.Errorf("unknown setting key: %s", key)
@@ -746,6 +953,12 @@ func SaveConfigToDatabase(store database.SettingsStore) error {
 	safeConfig.GoogleBooksAPIKey = ""
 	safeConfig.HardcoverAPIToken = ""
 	safeConfig.BasicAuthPassword = ""
+	safeConfig.BlobStorageS3SecretAccessKey = ""
+	safeConfig.AudibleActivationBytes = ""
+	safeConfig.AudiobookshelfAPIToken = ""
+	safeConfig.PlexToken = ""
+	safeConfig.JellyfinAPIKey = ""
+	safeConfig.SMTPPassword = ""
 
 	blobJSON, err := json.Marshal(safeConfig)
 	if err != nil {
@@ -768,6 +981,12 @@ func SaveConfigToDatabase(store database.SettingsStore) error {
 		{"google_books_api_key", snap.GoogleBooksAPIKey},
 		{"hardcover_api_token", snap.HardcoverAPIToken},
 		{"basic_auth_password", snap.BasicAuthPassword},
+		{"blob_storage_s3_secret_access_key", snap.BlobStorageS3SecretAccessKey},
+		{"audible_activation_bytes", snap.AudibleActivationBytes},
+		{"audiobookshelf_api_token", snap.AudiobookshelfAPIToken},
+		{"plex_token", snap.PlexToken},
+		{"jellyfin_api_key", snap.JellyfinAPIKey},
+		{"smtp_password", snap.SMTPPassword},
 	}
 	for _, s := range secrets {
 		if s.value == "" {
@@ -0,0 +1,58 @@
+// file: internal/config/timezone.go
+// version: 1.0.0
+// guid: fdb6bc1e-0157-44b6-9346-fd6372367d98
+// last-edited: 2026-10-16
+
+package config
+
+import (
+	"sync"
+	"time"
+
+	// Embedded zone database, so Timezone resolves in minimal containers
+	// without /usr/share/zoneinfo.
+	_ "time/tzdata"
+)
+
+// serverLocation is the process's own zone, captured before UseUTCClock
+// switches time.Local to UTC.
+var serverLocation = time.Local
+
+var (
+	locationMu     sync.Mutex
+	locationName   string
+	locationCached *time.Location
+)
+
+// UseUTCClock makes UTC the process-wide local zone, so every timestamp the
+// server produces (API responses, logs, exports) is UTC. Code that cares
+// about wall-clock hours uses Location/Now instead. Call once at startup,
+// before other goroutines start.
+func UseUTCClock() {
+	time.Local = time.UTC
+}
+
+// Location returns the zone schedules and digests are evaluated in: the
+// configured Timezone, or the server's own zone when unset or invalid.
+func Location() *time.Location {
+	name := AppConfig.Timezone
+	if name == "" {
+		return serverLocation
+	}
+	locationMu.Lock()
+	defer locationMu.Unlock()
+	if name == locationName && locationCached != nil {
+		return locationCached
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return serverLocation
+	}
+	locationName, locationCached = name, loc
+	return loc
+}
+
+// Now returns the current time in Location.
+func Now() time.Time {
+	return time.Now().In(Location())
+}
@@ -1,15 +1,19 @@
 // file: internal/config/update_service.go
-// version: 3.1.0
+// version: 3.7.0
 // guid: f6g7h8i9-j0k1-l2m3-n4o5-p6q7r8s9t0u1
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package config
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/falkcorp/audiobook-organizer/internal/database"
@@ -51,9 +55,64 @@ func (us *UpdateService) MaskSecrets(cfg Config) Config {
 	if masked.BasicAuthPassword != "" {
 		masked.BasicAuthPassword = database.MaskSecret(masked.BasicAuthPassword)
 	}
+	if masked.BlobStorageS3SecretAccessKey != "" {
+		masked.BlobStorageS3SecretAccessKey = database.MaskSecret(masked.BlobStorageS3SecretAccessKey)
+	}
+	if masked.AudibleActivationBytes != "" {
+		masked.AudibleActivationBytes = database.MaskSecret(masked.AudibleActivationBytes)
+	}
+	if masked.AudiobookshelfAPIToken != "" {
+		masked.AudiobookshelfAPIToken = database.MaskSecret(masked.AudiobookshelfAPIToken)
+	}
+	if masked.PlexToken != "" {
+		masked.PlexToken = database.MaskSecret(masked.PlexToken)
+	}
+	if masked.JellyfinAPIKey != "" {
+		masked.JellyfinAPIKey = database.MaskSecret(masked.JellyfinAPIKey)
+	}
+	if masked.SMTPPassword != "" {
+		masked.SMTPPassword = database.MaskSecret(masked.SMTPPassword)
+	}
 	return masked
 }
 
+// Revision returns a short content hash of cfg. It changes whenever any
+// setting changes, so the API hands it out as the config ETag and clients
+// send it back in If-Match to detect concurrent edits.
+func Revision(cfg Config) string {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// ChangedKeys returns the sorted JSON keys whose values differ between
+// before and after.
+func ChangedKeys(before, after Config) []string {
+	var a, b map[string]json.RawMessage
+	if raw, err := json.Marshal(before); err == nil {
+		_ = json.Unmarshal(raw, &a)
+	}
+	if raw, err := json.Marshal(after); err == nil {
+		_ = json.Unmarshal(raw, &b)
+	}
+	var keys []string
+	for k, v := range b {
+		if !bytes.Equal(a[k], v) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // secretFieldKeys are extracted and applied explicitly, then removed before the
 // JSON round-trip so they are never stored in plaintext in the config blob.
 var secretFieldKeys = []string{
@@ -62,6 +121,12 @@ var secretFieldKeys = []string{
 	"google_books_api_key",
 	"hardcover_api_token",
 	"basic_auth_password",
+	"blob_storage_s3_secret_access_key",
+	"audible_activation_bytes",
+	"audiobookshelf_api_token",
+	"plex_token",
+	"jellyfin_api_key",
+	"smtp_password",
 }
 
 // immutableFieldKeys cannot be changed at runtime and are rejected if present.
@@ -109,6 +174,24 @@ func (us *UpdateService) UpdateConfig(payload map[string]any) (int, map[string]a
 	if val, ok := payloadString(payload, "basic_auth_password"); ok {
 		Mutate(func(c *Config) { c.BasicAuthPassword = val })
 	}
+	if val, ok := payloadString(payload, "blob_storage_s3_secret_access_key"); ok {
+		Mutate(func(c *Config) { c.BlobStorageS3SecretAccessKey = val })
+	}
+	if val, ok := payloadString(payload, "audible_activation_bytes"); ok {
+		Mutate(func(c *Config) { c.AudibleActivationBytes = val })
+	}
+	if val, ok := payloadString(payload, "audiobookshelf_api_token"); ok {
+		Mutate(func(c *Config) { c.AudiobookshelfAPIToken = val })
+	}
+	if val, ok := payloadString(payload, "plex_token"); ok {
+		Mutate(func(c *Config) { c.PlexToken = val })
+	}
+	if val, ok := payloadString(payload, "jellyfin_api_key"); ok {
+		Mutate(func(c *Config) { c.JellyfinAPIKey = val })
+	}
+	if val, ok := payloadString(payload, "smtp_password"); ok {
+		Mutate(func(c *Config) { c.SMTPPassword = val })
+	}
 
 	// Build filtered payload without secrets (already applied above)
 	filtered := make(map[string]any, len(payload))
@@ -1,5 +1,5 @@
 // file: internal/config/update_service_test.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: e5f6g7h8-i9j0-k1l2-m3n4-o5p6q7r8s9t0
 
 package config
@@ -83,3 +83,24 @@ func TestUpdateService_ApplyUpdates_Success(t *testing.T) {
 		t.Errorf("expected '/new/library', got %q", AppConfig.RootDir)
 	}
 }
+
+func TestRevisionAndChangedKeys(t *testing.T) {
+	before := Config{RootDir: "/a", ConcurrentScans: 2}
+	after := before
+	if Revision(before) != Revision(after) {
+		t.Fatal("identical configs should share a revision")
+	}
+	if keys := ChangedKeys(before, after); len(keys) != 0 {
+		t.Errorf("expected no changed keys, got %v", keys)
+	}
+
+	after.RootDir = "/b"
+	after.ConcurrentScans = 4
+	if Revision(before) == Revision(after) {
+		t.Error("revision should change with the config")
+	}
+	keys := ChangedKeys(before, after)
+	if len(keys) != 2 || keys[0] != "concurrent_scans" || keys[1] != "root_dir" {
+		t.Errorf("ChangedKeys = %v, want [concurrent_scans root_dir]", keys)
+	}
+}
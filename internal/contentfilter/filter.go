@@ -0,0 +1,178 @@
+// file: internal/contentfilter/filter.go
+// version: 1.0.0
+// guid: d43495ac-327f-48a0-b267-311b5ae81f44
+// last-edited: 2026-10-16
+
+// Package contentfilter implements per-user content filter profiles (for
+// example a kids profile). A profile lists the genres and tags a user may
+// see and whether books flagged explicit are hidden; the server applies it
+// to every book it lists or serves to that user.
+//
+// Profiles are stored as a JSON per-user preference under PreferenceKey.
+// A nil *Filter allows everything, so call sites need not check whether
+// the caller has a profile.
+package contentfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// PreferenceKey is the per-user preference holding the profile JSON.
+const PreferenceKey = "content_filter"
+
+// Profile is one user's content filter.
+type Profile struct {
+	// AllowedGenres and AllowedTags whitelist books: when either is set,
+	// a book is visible only if its genre or one of its tags is listed.
+	// Matching is case-insensitive. Both empty allows every genre.
+	AllowedGenres []string `json:"allowed_genres"`
+	AllowedTags   []string `json:"allowed_tags"`
+	// BlockExplicit hides books flagged explicit.
+	BlockExplicit bool `json:"block_explicit"`
+}
+
+// Active reports whether the profile restricts anything.
+func (p Profile) Active() bool {
+	return p.BlockExplicit || len(p.AllowedGenres) > 0 || len(p.AllowedTags) > 0
+}
+
+// Store is the persistence the filter needs: the profile preference and
+// the tag → book index.
+type Store interface {
+	GetUserPreferenceForUser(userID, key string) (*database.UserPreferenceKV, error)
+	GetBooksByTag(tag string) ([]string, error)
+}
+
+// Load reads userID's profile. A missing preference yields the zero
+// Profile.
+func Load(store Store, userID string) (Profile, error) {
+	var p Profile
+	pref, err := store.GetUserPreferenceForUser(userID, PreferenceKey)
+	if err != nil || pref == nil || strings.TrimSpace(pref.Value) == "" {
+		return p, err
+	}
+	if err := json.Unmarshal([]byte(pref.Value), &p); err != nil {
+		return p, fmt.Errorf("decode content filter for %s: %w", userID, err)
+	}
+	return p, nil
+}
+
+// Filter applies a Profile to books.
+type Filter struct {
+	profile Profile
+	genres  map[string]bool
+	store   Store
+
+	tagOnce sync.Once
+	tagged  map[string]struct{}
+	tagErr  error
+}
+
+// New returns a filter for p, or nil when p restricts nothing. The tag
+// index is read from store on first use.
+func New(p Profile, store Store) *Filter {
+	if !p.Active() {
+		return nil
+	}
+	f := &Filter{profile: p, store: store, genres: make(map[string]bool, len(p.AllowedGenres))}
+	for _, g := range p.AllowedGenres {
+		if g = strings.ToLower(strings.TrimSpace(g)); g != "" {
+			f.genres[g] = true
+		}
+	}
+	return f
+}
+
+// Profile returns the profile the filter applies.
+func (f *Filter) Profile() Profile {
+	if f == nil {
+		return Profile{}
+	}
+	return f.profile
+}
+
+// Allows reports whether book is visible under the filter. Tag lookup
+// failures hide the book: a kids profile must fail closed.
+func (f *Filter) Allows(book *database.Book) bool {
+	if f == nil {
+		return true
+	}
+	if book == nil {
+		return false
+	}
+	if f.profile.BlockExplicit && book.Explicit != nil && *book.Explicit {
+		return false
+	}
+	if len(f.genres) == 0 && len(f.profile.AllowedTags) == 0 {
+		return true
+	}
+	if book.Genre != nil {
+		for _, g := range splitGenres(*book.Genre) {
+			if f.genres[g] {
+				return true
+			}
+		}
+	}
+	if len(f.profile.AllowedTags) == 0 {
+		return false
+	}
+	f.tagOnce.Do(f.loadTagged)
+	if f.tagErr != nil {
+		return false
+	}
+	_, ok := f.tagged[book.ID]
+	return ok
+}
+
+func (f *Filter) loadTagged() {
+	f.tagged = make(map[string]struct{})
+	for _, tag := range f.profile.AllowedTags {
+		if tag = strings.TrimSpace(tag); tag == "" {
+			continue
+		}
+		ids, err := f.store.GetBooksByTag(tag)
+		if err != nil {
+			f.tagErr = err
+			return
+		}
+		for _, id := range ids {
+			f.tagged[id] = struct{}{}
+		}
+	}
+}
+
+// Key identifies the profile for cache keys. Empty for a nil filter.
+func (f *Filter) Key() string {
+	if f == nil {
+		return ""
+	}
+	genres := make([]string, 0, len(f.genres))
+	for g := range f.genres {
+		genres = append(genres, g)
+	}
+	sort.Strings(genres)
+	tags := append([]string(nil), f.profile.AllowedTags...)
+	sort.Strings(tags)
+	return fmt.Sprintf("cf=g:%s;t:%s;x:%v", strings.Join(genres, ","), strings.Join(tags, ","), f.profile.BlockExplicit)
+}
+
+// splitGenres lowercases a stored genre string and splits the
+// multi-genre forms taggers write ("Fantasy; Children", "Fiction/Kids").
+func splitGenres(s string) []string {
+	parts := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ',' || r == ';' || r == '/'
+	})
+	out := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
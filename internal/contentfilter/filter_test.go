@@ -0,0 +1,85 @@
+// file: internal/contentfilter/filter_test.go
+// version: 1.0.0
+// guid: a1677532-75bd-4db9-84f8-7365c525109e
+// last-edited: 2026-10-16
+
+package contentfilter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	prefs  map[string]string
+	tags   map[string][]string
+	tagErr error
+	calls  int
+}
+
+func (f *fakeStore) GetUserPreferenceForUser(userID, key string) (*database.UserPreferenceKV, error) {
+	v, ok := f.prefs[userID+"/"+key]
+	if !ok {
+		return nil, nil
+	}
+	return &database.UserPreferenceKV{UserID: userID, Key: key, Value: v}, nil
+}
+
+func (f *fakeStore) GetBooksByTag(tag string) ([]string, error) {
+	f.calls++
+	return f.tags[tag], f.tagErr
+}
+
+func book(id, genre string, explicit bool) *database.Book {
+	return &database.Book{ID: id, Genre: &genre, Explicit: &explicit}
+}
+
+func TestLoad(t *testing.T) {
+	store := &fakeStore{prefs: map[string]string{
+		"kid/" + PreferenceKey: `{"allowed_genres":["Children"],"block_explicit":true}`,
+		"bad/" + PreferenceKey: `{`,
+	}}
+	p, err := Load(store, "kid")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Children"}, p.AllowedGenres)
+	assert.True(t, p.BlockExplicit)
+
+	p, err = Load(store, "adult")
+	require.NoError(t, err)
+	assert.False(t, p.Active())
+
+	_, err = Load(store, "bad")
+	assert.Error(t, err)
+}
+
+func TestFilterAllows(t *testing.T) {
+	assert.Nil(t, New(Profile{}, nil), "inactive profile needs no filter")
+	var none *Filter
+	assert.True(t, none.Allows(book("x", "Horror", true)))
+
+	store := &fakeStore{tags: map[string][]string{"approved": {"tagged"}}}
+	f := New(Profile{AllowedGenres: []string{"children"}, AllowedTags: []string{"approved"}, BlockExplicit: true}, store)
+	require.NotNil(t, f)
+
+	assert.True(t, f.Allows(book("gruffalo", "Fiction; Children", false)))
+	assert.True(t, f.Allows(book("tagged", "Horror", false)), "an allowed tag approves any genre")
+	assert.False(t, f.Allows(book("dune", "Science Fiction", false)))
+	assert.False(t, f.Allows(book("edgy", "Children", true)), "explicit wins over allowed genre")
+	assert.Equal(t, 1, store.calls, "tag index is read once")
+
+	explicitOnly := New(Profile{BlockExplicit: true}, store)
+	assert.True(t, explicitOnly.Allows(book("dune", "Science Fiction", false)))
+	assert.True(t, explicitOnly.Allows(&database.Book{ID: "unflagged"}))
+	assert.False(t, explicitOnly.Allows(book("edgy", "Thriller", true)))
+	assert.NotEqual(t, f.Key(), explicitOnly.Key())
+}
+
+func TestFilterAllows_TagErrorFailsClosed(t *testing.T) {
+	store := &fakeStore{tagErr: errors.New("boom")}
+	f := New(Profile{AllowedTags: []string{"approved"}}, store)
+	assert.False(t, f.Allows(book("any", "Children", false)))
+}
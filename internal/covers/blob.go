@@ -0,0 +1,309 @@
+// file: internal/covers/blob.go
+// version: 1.0.0
+// guid: 2c7e9f41-a83d-4b60-95e1-f06d3b82a7c4
+// last-edited: 2026-10-16
+//
+// Mirrors book covers into the configured blob store. Covers are always
+// written to {RootDir}/covers first, since tag embedding and the file
+// server need a real file; with a remote backend that directory is only
+// a cache, refilled from the store when a container starts empty.
+
+package covers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/blobstore"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+	"github.com/falkcorp/audiobook-organizer/internal/security/safepath"
+)
+
+// missTTL is how long a cover found in neither place is not looked up in
+// the blob store again.
+const missTTL = 10 * time.Minute
+
+var (
+	blobMu    sync.RWMutex
+	blobStore blobstore.Store
+	misses    sync.Map // key -> time.Time
+)
+
+// coverExts are the image extensions a book cover may have.
+var coverExts = []string{".jpg", ".jpeg", ".png", ".webp", ".gif"}
+
+// SetBlobStore sets the store covers are mirrored to; nil disables
+// mirroring.
+func SetBlobStore(s blobstore.Store) {
+	blobMu.Lock()
+	defer blobMu.Unlock()
+	blobStore = s
+	misses.Clear()
+}
+
+// ConfigureBlobStore sets the blob store from cfg's blob_storage_*
+// settings. The default, local storage in the library root, is the covers
+// directory itself, so no store is set for it.
+func ConfigureBlobStore(cfg config.Config) error {
+	if (cfg.BlobStorageBackend == "" || cfg.BlobStorageBackend == blobstore.BackendLocal) && cfg.BlobStorageLocalDir == "" {
+		SetBlobStore(nil)
+		return nil
+	}
+	s, err := blobstore.FromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	SetBlobStore(s)
+	return nil
+}
+
+// BlobStore returns the store covers are mirrored to, or nil.
+func BlobStore() blobstore.Store {
+	blobMu.RLock()
+	defer blobMu.RUnlock()
+	return blobStore
+}
+
+// CoverKey is the blob key of the cover file named filename.
+func CoverKey(filename string) string {
+	return "covers/" + filepath.Base(filename)
+}
+
+// remoteStore returns the blob store when it keeps covers somewhere
+// other than rootDir/covers, or nil. A local store rooted at the library
+// root is the covers directory itself and needs no mirroring.
+func remoteStore(rootDir string) blobstore.Store {
+	s := BlobStore()
+	if l, ok := s.(*blobstore.Local); ok && rootDir != "" && filepath.Clean(l.Root()) == filepath.Clean(rootDir) {
+		return nil
+	}
+	return s
+}
+
+// PublishCover copies the cover at localPath, a file in
+// {rootDir}/covers, into the blob store and removes the book's covers
+// stored under other extensions.
+func PublishCover(ctx context.Context, rootDir, localPath string) error {
+	s := remoteStore(rootDir)
+	if s == nil || localPath == "" {
+		return nil
+	}
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(localPath)
+	ext := strings.ToLower(filepath.Ext(name))
+	if err := s.Put(ctx, CoverKey(name), f, st.Size(), mime.TypeByExtension(ext)); err != nil {
+		return fmt.Errorf("publish cover %s: %w", name, err)
+	}
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	for _, other := range coverExts {
+		if other != ext {
+			_ = s.Delete(ctx, CoverKey(stem+other))
+		}
+	}
+	misses.Delete(stem)
+	return nil
+}
+
+// PublishCoverAsync publishes the cover in the background, logging
+// failures; the local copy keeps serving either way.
+func PublishCoverAsync(rootDir, localPath string) {
+	if remoteStore(rootDir) == nil || localPath == "" {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		if err := PublishCover(ctx, rootDir, localPath); err != nil {
+			slog.Warn("failed to copy cover to blob storage", "path", localPath, "err", err)
+		}
+	}()
+}
+
+// CoverPathForBook returns the book's cover file under {rootDir}/covers,
+// first downloading it from the blob store if only the store has it.
+// Returns "" when the book has no cover.
+func CoverPathForBook(ctx context.Context, rootDir, bookID string) string {
+	if p := metadata.CoverPathForBook(rootDir, bookID); p != "" {
+		return p
+	}
+	s := remoteStore(rootDir)
+	stem := filepath.Base(bookID)
+	if s == nil || rootDir == "" || stem == "." || stem == "/" || recentMiss(stem) {
+		return ""
+	}
+	found, err := s.List(ctx, CoverKey(stem+"."))
+	if err != nil {
+		slog.Warn("failed to look up cover in blob storage", "book_id", bookID, "err", err)
+		return ""
+	}
+	for _, info := range found {
+		name := strings.TrimPrefix(info.Key, "covers/")
+		if strings.TrimSuffix(name, filepath.Ext(name)) != stem || !isCoverExt(name) {
+			continue
+		}
+		if p, err := fetchCover(ctx, s, rootDir, name); err == nil {
+			return p
+		}
+	}
+	misses.Store(stem, time.Now())
+	return ""
+}
+
+// FetchCoverFile returns {rootDir}/covers/filename, downloading it from
+// the blob store when it is missing locally. Returns os.ErrNotExist when
+// neither has it.
+func FetchCoverFile(ctx context.Context, rootDir, filename string) (string, error) {
+	if p, err := FindCoverFile(filename, rootDir); err == nil {
+		return p, nil
+	}
+	s := remoteStore(rootDir)
+	if s == nil || rootDir == "" || !isCoverExt(filename) {
+		return "", os.ErrNotExist
+	}
+	stem := strings.TrimSuffix(filename, filepath.Ext(filename))
+	if recentMiss(stem) {
+		return "", os.ErrNotExist
+	}
+	p, err := fetchCover(ctx, s, rootDir, filepath.Base(filename))
+	if errors.Is(err, blobstore.ErrNotFound) {
+		misses.Store(stem, time.Now())
+		return "", os.ErrNotExist
+	}
+	return p, err
+}
+
+// fetchCover downloads covers/name into {rootDir}/covers.
+func fetchCover(ctx context.Context, s blobstore.Store, rootDir, name string) (string, error) {
+	dest, err := safepath.Join(rootDir, "covers", name)
+	if err != nil {
+		return "", err
+	}
+	r, _, err := s.Get(ctx, CoverKey(name))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	if err := os.MkdirAll(filepath.Dir(dest.String()), 0o775); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest.String()), ".fetch-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o664); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), dest.String()); err != nil {
+		return "", err
+	}
+	return dest.String(), nil
+}
+
+// SyncCovers copies every cover in {rootDir}/covers that the blob store
+// lacks, or holds at a different size, into the store, reporting each
+// file to progress. Returns the number copied.
+func SyncCovers(ctx context.Context, rootDir string, progress func(done, total int, name string)) (int, error) {
+	s := remoteStore(rootDir)
+	if s == nil {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(filepath.Join(rootDir, "covers"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	stored, err := s.List(ctx, "covers/")
+	if err != nil {
+		return 0, err
+	}
+	sizes := make(map[string]int64, len(stored))
+	for _, info := range stored {
+		sizes[info.Key] = info.Size
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && isCoverExt(e.Name()) {
+			names = append(names, e.Name())
+		}
+	}
+	copied := 0
+	for i, name := range names {
+		if err := ctx.Err(); err != nil {
+			return copied, err
+		}
+		if progress != nil {
+			progress(i, len(names), name)
+		}
+		info, err := os.Stat(filepath.Join(rootDir, "covers", name))
+		if err != nil {
+			continue
+		}
+		if size, ok := sizes[CoverKey(name)]; ok && size == info.Size() {
+			continue
+		}
+		f, err := os.Open(filepath.Join(rootDir, "covers", name))
+		if err != nil {
+			return copied, err
+		}
+		err = s.Put(ctx, CoverKey(name), f, info.Size(), mime.TypeByExtension(strings.ToLower(filepath.Ext(name))))
+		f.Close()
+		if err != nil {
+			return copied, fmt.Errorf("copy %s: %w", name, err)
+		}
+		copied++
+	}
+	if progress != nil {
+		progress(len(names), len(names), "")
+	}
+	return copied, nil
+}
+
+func isCoverExt(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range coverExts {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+func recentMiss(stem string) bool {
+	v, ok := misses.Load(stem)
+	if !ok {
+		return false
+	}
+	if time.Since(v.(time.Time)) > missTTL {
+		misses.Delete(stem)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// file: internal/covers/blob_test.go
+// version: 1.0.0
+// guid: 4f18a0c6-92e7-4d3b-b85a-1e6c7d93f024
+// last-edited: 2026-10-16
+
+package covers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/blobstore"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// useBlobStore points covers at a local store outside rootDir, standing in
+// for a remote backend.
+func useBlobStore(t *testing.T) *blobstore.Local {
+	t.Helper()
+	s, err := blobstore.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetBlobStore(s)
+	t.Cleanup(func() { SetBlobStore(nil) })
+	return s
+}
+
+func writeCover(t *testing.T, rootDir, name, data string) string {
+	t.Helper()
+	p := filepath.Join(rootDir, "covers", name)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestPublishAndRestoreCover(t *testing.T) {
+	ctx := context.Background()
+	store := useBlobStore(t)
+	root := t.TempDir()
+
+	// An older .jpg cover is replaced by a .png one.
+	if err := PublishCover(ctx, root, writeCover(t, root, "book1.jpg", "old")); err != nil {
+		t.Fatal(err)
+	}
+	if err := PublishCover(ctx, root, writeCover(t, root, "book1.png", "new")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Stat(ctx, "covers/book1.jpg"); err != blobstore.ErrNotFound {
+		t.Errorf("old cover still stored: %v", err)
+	}
+
+	// A fresh container starts with an empty covers directory.
+	fresh := t.TempDir()
+	got := CoverPathForBook(ctx, fresh, "book1")
+	if got != filepath.Join(fresh, "covers", "book1.png") {
+		t.Fatalf("CoverPathForBook = %q", got)
+	}
+	if data, _ := os.ReadFile(got); string(data) != "new" {
+		t.Errorf("restored cover = %q", data)
+	}
+
+	if got := CoverPathForBook(ctx, fresh, "book2"); got != "" {
+		t.Errorf("missing cover resolved to %q", got)
+	}
+
+	other := t.TempDir()
+	p, err := FetchCoverFile(ctx, other, "book1.png")
+	if err != nil || p != filepath.Join(other, "covers", "book1.png") {
+		t.Fatalf("FetchCoverFile = %q, %v", p, err)
+	}
+	if _, err := FetchCoverFile(ctx, other, "nope.jpg"); !os.IsNotExist(err) {
+		t.Errorf("FetchCoverFile(missing) err = %v", err)
+	}
+}
+
+func TestSyncCovers(t *testing.T) {
+	ctx := context.Background()
+	store := useBlobStore(t)
+	root := t.TempDir()
+	writeCover(t, root, "a.jpg", "a")
+	writeCover(t, root, "b.webp", "b")
+	writeCover(t, root, "notes.txt", "skip")
+	writeCover(t, root, "history/a/20260101-000000.jpg", "skip")
+
+	n, err := SyncCovers(ctx, root, nil)
+	if err != nil || n != 2 {
+		t.Fatalf("SyncCovers = %d, %v", n, err)
+	}
+	if n, _ := SyncCovers(ctx, root, nil); n != 0 {
+		t.Errorf("second sync copied %d", n)
+	}
+	list, _ := store.List(ctx, "covers/")
+	if len(list) != 2 {
+		t.Errorf("stored %d covers, want 2", len(list))
+	}
+}
+
+func TestConfigureBlobStore(t *testing.T) {
+	t.Cleanup(func() { SetBlobStore(nil) })
+	root := t.TempDir()
+
+	// The default keeps covers where they are.
+	if err := ConfigureBlobStore(config.Config{RootDir: root, BlobStorageBackend: "local"}); err != nil {
+		t.Fatal(err)
+	}
+	if BlobStore() != nil {
+		t.Error("default local storage should not mirror covers")
+	}
+	if err := ConfigureBlobStore(config.Config{RootDir: root, BlobStorageBackend: "local", BlobStorageLocalDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	if BlobStore() == nil {
+		t.Error("separate local directory should mirror covers")
+	}
+	if err := ConfigureBlobStore(config.Config{RootDir: root, BlobStorageBackend: "s3"}); err == nil {
+		t.Error("s3 without a bucket should fail")
+	}
+}
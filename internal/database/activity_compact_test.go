@@ -1,5 +1,5 @@
 // file: internal/database/activity_compact_test.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: a1b2c3d4-e5f6-7a8b-9c0d-1e2f3a4b5c6d
 
 package database
@@ -438,3 +438,37 @@ func TestNutsActivityStore_RecompactDigests(t *testing.T) {
 	assert.Equal(t, 0, res2.Touched, "second run: should be idempotent (0 touched)")
 	assert.Equal(t, 1, res2.Skipped, "second run: digest should be skipped as already clean")
 }
+
+// TestCompactByDay_DigestLocation verifies that day boundaries follow the
+// configured digest zone rather than UTC.
+func TestCompactByDay_DigestLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	SetDigestLocation(func() *time.Location { return ny })
+	t.Cleanup(func() { SetDigestLocation(nil) })
+
+	s := newTestNutsActivityStore(t)
+	// 02:00 and 03:00 UTC on the 11th are the evening of the 10th in New York.
+	for _, ts := range []time.Time{
+		time.Date(2025, 6, 11, 2, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 11, 3, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 11, 14, 0, 0, 0, time.UTC),
+	} {
+		_, err := s.Record(ActivityEntry{
+			Tier: "change", Type: "config_changed", Level: "info",
+			Source: "settings", Summary: "changed setting", Timestamp: ts,
+		})
+		require.NoError(t, err)
+	}
+
+	r, err := s.CompactByDay(context.Background(), time.Date(2025, 6, 12, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, r.DaysCompacted)
+
+	dd, _, err := s.findExistingDigest("2025-06-10")
+	require.NoError(t, err)
+	assert.Equal(t, 2, dd.OriginalCount)
+	dd, _, err = s.findExistingDigest("2025-06-11")
+	require.NoError(t, err)
+	assert.Equal(t, 1, dd.OriginalCount)
+}
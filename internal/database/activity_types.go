@@ -1,7 +1,7 @@
 // file: internal/database/activity_types.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b8c9d0e1-f2a3-4b5c-6d7e-8f9a0b1c2d3e
-// last-edited: 2026-06-10
+// last-edited: 2026-10-16
 
 // Package database — activity log types and helpers previously defined in
 // activity_store.go (the legacy SQLite backend). Extracted here in fable5
@@ -93,6 +93,29 @@ type DigestDetails struct {
 // maxDigestItems caps the number of DigestItems stored per daily digest.
 const maxDigestItems = 500
 
+// digestLocation supplies the zone daily digests are bucketed in. Defaults to
+// UTC; the server points it at the configured schedule time zone.
+var digestLocation = func() *time.Location { return time.UTC }
+
+// SetDigestLocation sets the zone CompactByDay uses to decide which day an
+// entry belongs to. A nil fn restores UTC.
+func SetDigestLocation(fn func() *time.Location) {
+	if fn == nil {
+		fn = func() *time.Location { return time.UTC }
+	}
+	digestLocation = fn
+}
+
+// digestDayBounds parses a "2006-01-02" day key in loc and returns the
+// half-open [start, end) range it covers. AddDate keeps DST days correct.
+func digestDayBounds(dateKey string, loc *time.Location) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", dateKey, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
+
 // SourceCount holds a source name and the number of activity log entries from it.
 type SourceCount struct {
 	Source string `json:"source"`
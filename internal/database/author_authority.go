@@ -0,0 +1,300 @@
+// file: internal/database/author_authority.go
+// version: 1.0.0
+// guid: 8a4c2e61-7f3b-4d90-b5e8-1c6d9f0a3b27
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/pebble/v2"
+)
+
+// Author disambiguation: several author records may share a display name
+// when external authority data (OpenLibrary or Goodreads author IDs, birth
+// year) says they are different people. The first record with a name owns
+// the author:name: index, so GetAuthorByName and CreateAuthor behave as
+// before; each later namesake is indexed under
+// author:samename:<lowercase name>:<id>. External IDs are indexed under
+// author:ext:<source>:<lowercase id>.
+
+// Author authority sources accepted by GetAuthorByExternalID.
+const (
+	AuthoritySourceOpenLibrary = "openlibrary"
+	AuthoritySourceGoodreads   = "goodreads"
+)
+
+// AuthorAuthority is the external data that tells namesakes apart.
+type AuthorAuthority struct {
+	OpenLibraryID string `json:"openlibrary_id,omitempty"`
+	GoodreadsID   string `json:"goodreads_id,omitempty"`
+	BirthYear     *int   `json:"birth_year,omitempty"`
+}
+
+// Empty reports whether a carries no authority data.
+func (a AuthorAuthority) Empty() bool {
+	return a.OpenLibraryID == "" && a.GoodreadsID == "" && a.BirthYear == nil
+}
+
+// Authority returns the authority data recorded on a.
+func (a Author) Authority() AuthorAuthority {
+	return AuthorAuthority{OpenLibraryID: a.OpenLibraryID, GoodreadsID: a.GoodreadsID, BirthYear: a.BirthYear}
+}
+
+// DistinctAuthors reports whether the authority data of a and b says
+// they are different people: both carry an OpenLibrary ID, a Goodreads
+// ID or a birth year, and the values differ. Duplicate detection must
+// never pair such authors.
+func DistinctAuthors(a, b Author) bool {
+	differ := func(x, y string) bool { return x != "" && y != "" && !strings.EqualFold(x, y) }
+	return differ(a.OpenLibraryID, b.OpenLibraryID) || differ(a.GoodreadsID, b.GoodreadsID) ||
+		(a.BirthYear != nil && b.BirthYear != nil && *a.BirthYear != *b.BirthYear)
+}
+
+// ErrAuthorityConflict is returned when an external author ID is already
+// recorded on a different author.
+var ErrAuthorityConflict = errors.New("external author ID already belongs to another author")
+
+// ErrAuthorityRequired is returned when a namesake is created without any
+// authority data to tell it apart from the existing record.
+var ErrAuthorityRequired = errors.New("a namesake author needs an external ID or birth year")
+
+// AuthorAuthorityStore records and looks up author disambiguation data.
+// Only PebbleStore implements it; use AsAuthorAuthorityStore.
+type AuthorAuthorityStore interface {
+	// GetAuthorsByName returns every author record with the display name
+	// (case-insensitive), the name's original record first.
+	GetAuthorsByName(name string) ([]Author, error)
+	// CreateAuthorNamesake creates a new author with name even when one
+	// already exists, carrying authority to tell them apart.
+	CreateAuthorNamesake(name string, authority AuthorAuthority) (*Author, error)
+	// SetAuthorAuthority replaces the authority data of author id.
+	SetAuthorAuthority(id int, authority AuthorAuthority) (*Author, error)
+	// GetAuthorByExternalID returns the author recorded with the external
+	// ID from source, or nil.
+	GetAuthorByExternalID(source, externalID string) (*Author, error)
+}
+
+// AsAuthorAuthorityStore returns the AuthorAuthorityStore behind s,
+// looking through Unwrap layers.
+func AsAuthorAuthorityStore(s any) (AuthorAuthorityStore, bool) {
+	return unwrapStore[AuthorAuthorityStore](s)
+}
+
+func authorSameNamePrefix(name string) string {
+	return fmt.Sprintf("author:samename:%s:", strings.ToLower(name))
+}
+
+func authorExtKey(source, externalID string) string {
+	return fmt.Sprintf("author:ext:%s:%s", source, strings.ToLower(strings.TrimSpace(externalID)))
+}
+
+// authorExtKeys lists the external-ID index keys of a.
+func authorExtKeys(a AuthorAuthority) []string {
+	var keys []string
+	if a.OpenLibraryID != "" {
+		keys = append(keys, authorExtKey(AuthoritySourceOpenLibrary, a.OpenLibraryID))
+	}
+	if a.GoodreadsID != "" {
+		keys = append(keys, authorExtKey(AuthoritySourceGoodreads, a.GoodreadsID))
+	}
+	return keys
+}
+
+// GetAuthorsByName implements AuthorAuthorityStore.
+func (p *PebbleStore) GetAuthorsByName(name string) ([]Author, error) {
+	var authors []Author
+	primary, err := p.GetAuthorByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if primary != nil {
+		authors = append(authors, *primary)
+	}
+	pairs, err := p.ScanPrefix(authorSameNamePrefix(name))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(pairs))
+	for _, kv := range pairs {
+		if id, err := strconv.Atoi(string(kv.Value)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		a, err := p.GetAuthorByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil && (primary == nil || a.ID != primary.ID) {
+			authors = append(authors, *a)
+		}
+	}
+	return authors, nil
+}
+
+// CreateAuthorNamesake implements AuthorAuthorityStore. With no existing
+// author of that name it is CreateAuthor plus the authority data.
+func (p *PebbleStore) CreateAuthorNamesake(name string, authority AuthorAuthority) (*Author, error) {
+	existing, err := p.GetAuthorByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		created, err := p.CreateAuthor(name)
+		if err != nil {
+			return nil, err
+		}
+		if authority.Empty() {
+			return created, nil
+		}
+		return p.SetAuthorAuthority(created.ID, authority)
+	}
+	if authority.Empty() {
+		return nil, ErrAuthorityRequired
+	}
+	if err := p.checkAuthorityFree(0, authority); err != nil {
+		return nil, err
+	}
+
+	id, err := p.nextID("author")
+	if err != nil {
+		return nil, err
+	}
+	author := &Author{ID: id, Name: name, OpenLibraryID: authority.OpenLibraryID, GoodreadsID: authority.GoodreadsID, BirthYear: authority.BirthYear}
+	data, err := json.Marshal(author)
+	if err != nil {
+		return nil, err
+	}
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set([]byte(fmt.Sprintf("author:%d", id)), data, nil); err != nil {
+		return nil, err
+	}
+	if err := batch.Set([]byte(authorSameNamePrefix(name)+strconv.Itoa(id)), []byte(strconv.Itoa(id)), nil); err != nil {
+		return nil, err
+	}
+	for _, key := range authorExtKeys(authority) {
+		if err := batch.Set([]byte(key), []byte(strconv.Itoa(id)), nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return nil, err
+	}
+	p.UpsertAuthorToMemDB(author)
+	return author, nil
+}
+
+// SetAuthorAuthority implements AuthorAuthorityStore.
+func (p *PebbleStore) SetAuthorAuthority(id int, authority AuthorAuthority) (*Author, error) {
+	author, err := p.GetAuthorByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil || author.ID != id {
+		return nil, fmt.Errorf("author %d not found", id)
+	}
+	if err := p.checkAuthorityFree(id, authority); err != nil {
+		return nil, err
+	}
+
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	for _, key := range authorExtKeys(author.Authority()) {
+		if err := batch.Delete([]byte(key), nil); err != nil {
+			return nil, err
+		}
+	}
+	author.OpenLibraryID, author.GoodreadsID, author.BirthYear = authority.OpenLibraryID, authority.GoodreadsID, authority.BirthYear
+	data, err := json.Marshal(author)
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Set([]byte(fmt.Sprintf("author:%d", id)), data, nil); err != nil {
+		return nil, err
+	}
+	for _, key := range authorExtKeys(authority) {
+		if err := batch.Set([]byte(key), []byte(strconv.Itoa(id)), nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return nil, err
+	}
+	p.UpsertAuthorToMemDB(author)
+	return author, nil
+}
+
+// GetAuthorByExternalID implements AuthorAuthorityStore.
+func (p *PebbleStore) GetAuthorByExternalID(source, externalID string) (*Author, error) {
+	if strings.TrimSpace(externalID) == "" {
+		return nil, nil
+	}
+	raw, err := p.GetRaw(authorExtKey(source, externalID))
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	id, err := strconv.Atoi(string(raw))
+	if err != nil {
+		return nil, err
+	}
+	return p.GetAuthorByID(id)
+}
+
+// checkAuthorityFree returns ErrAuthorityConflict if an external ID in a
+// is recorded on an author other than id.
+func (p *PebbleStore) checkAuthorityFree(id int, a AuthorAuthority) error {
+	for _, key := range authorExtKeys(a) {
+		raw, err := p.GetRaw(key)
+		if err != nil {
+			return err
+		}
+		if raw != nil && string(raw) != strconv.Itoa(id) {
+			return fmt.Errorf("%w (author %s)", ErrAuthorityConflict, raw)
+		}
+	}
+	return nil
+}
+
+// dropAuthorNameIndex removes a's entry from the name indexes when it is
+// deleted or renamed. When a owned the name and namesakes remain, the
+// lowest-numbered namesake takes the name over.
+func (p *PebbleStore) dropAuthorNameIndex(batch *pebble.Batch, a *Author) error {
+	lower := strings.ToLower(a.Name)
+	nameKey := []byte("author:name:" + lower)
+	if err := batch.Delete([]byte(authorSameNamePrefix(a.Name)+strconv.Itoa(a.ID)), nil); err != nil {
+		return err
+	}
+	owner, err := p.GetRaw(string(nameKey))
+	if err != nil {
+		return err
+	}
+	if owner != nil && string(owner) != strconv.Itoa(a.ID) {
+		return nil
+	}
+	pairs, err := p.ScanPrefix(authorSameNamePrefix(a.Name))
+	if err != nil {
+		return err
+	}
+	next := 0
+	for _, kv := range pairs {
+		if id, err := strconv.Atoi(string(kv.Value)); err == nil && id != a.ID && (next == 0 || id < next) {
+			next = id
+		}
+	}
+	if next == 0 {
+		return batch.Delete(nameKey, nil)
+	}
+	if err := batch.Delete([]byte(authorSameNamePrefix(a.Name)+strconv.Itoa(next)), nil); err != nil {
+		return err
+	}
+	return batch.Set(nameKey, []byte(strconv.Itoa(next)), nil)
+}
@@ -0,0 +1,114 @@
+// file: internal/database/author_authority_test.go
+// version: 1.0.0
+// guid: 1d6f3a98-b2e4-4c71-9e05-7a8c4b2f6d13
+
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorNamesakes(t *testing.T) {
+	s := setupTestPebbleStore(t)
+	year := func(y int) *int { return &y }
+
+	first, err := s.CreateAuthor("John Smith")
+	require.NoError(t, err)
+	_, err = s.CreateAuthorNamesake("john smith", AuthorAuthority{})
+	assert.ErrorIs(t, err, ErrAuthorityRequired)
+
+	second, err := s.CreateAuthorNamesake("John Smith", AuthorAuthority{OpenLibraryID: "OL1A", BirthYear: year(1950)})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	// The original record keeps the name; CreateAuthor still finds it.
+	byName, err := s.GetAuthorByName("JOHN SMITH")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, byName.ID)
+	again, err := s.CreateAuthor("John Smith")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+
+	all, err := s.GetAuthorsByName("john smith")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, []int{first.ID, second.ID}, []int{all[0].ID, all[1].ID})
+	authors, err := s.GetAllAuthors()
+	require.NoError(t, err)
+	assert.Len(t, authors, 2, "index keys are not listed as authors")
+
+	// External IDs are unique across authors and case-insensitive.
+	found, err := s.GetAuthorByExternalID(AuthoritySourceOpenLibrary, "ol1a")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, second.ID, found.ID)
+	_, err = s.SetAuthorAuthority(first.ID, AuthorAuthority{OpenLibraryID: "OL1A"})
+	assert.True(t, errors.Is(err, ErrAuthorityConflict))
+	updated, err := s.SetAuthorAuthority(first.ID, AuthorAuthority{GoodreadsID: "42", BirthYear: year(1901)})
+	require.NoError(t, err)
+	assert.True(t, DistinctAuthors(*updated, *second))
+	assert.False(t, DistinctAuthors(*updated, Author{Name: "John Smith"}), "an author without authority data may be a duplicate")
+
+	// Deleting the name's owner hands the name to the remaining namesake,
+	// and frees its external IDs.
+	require.NoError(t, s.DeleteAuthor(first.ID))
+	byName, err = s.GetAuthorByName("John Smith")
+	require.NoError(t, err)
+	require.NotNil(t, byName)
+	assert.Equal(t, second.ID, byName.ID)
+	found, err = s.GetAuthorByExternalID(AuthoritySourceGoodreads, "42")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	// Renaming another author onto the name makes it a namesake rather
+	// than taking the name over.
+	other, err := s.CreateAuthor("J. Smith")
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateAuthorName(other.ID, "John Smith"))
+	byName, err = s.GetAuthorByName("John Smith")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, byName.ID)
+	all, err = s.GetAuthorsByName("John Smith")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	old, err := s.GetAuthorByName("J. Smith")
+	require.NoError(t, err)
+	assert.Nil(t, old)
+}
+
+func TestAuthorResolutions(t *testing.T) {
+	s := setupTestPebbleStore(t)
+
+	r, err := AddAuthorResolution(s, AuthorResolution{BookID: "b1", Name: "John Smith", CandidateIDs: []int{1, 2}, AssignedID: 1})
+	require.NoError(t, err)
+	dup, err := AddAuthorResolution(s, AuthorResolution{BookID: "b1", Name: "John Smith", CandidateIDs: []int{1, 2}, AssignedID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, r.ID, dup.ID, "a book has one pending prompt")
+	_, err = AddAuthorResolution(s, AuthorResolution{BookID: "b2", Name: "John Smith", CandidateIDs: []int{1, 2}, AssignedID: 1})
+	require.NoError(t, err)
+
+	pending, err := ListAuthorResolutions(s, AuthorResolutionPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "b1", pending[0].BookID)
+
+	resolved, err := ResolveAuthorResolution(s, r.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, AuthorResolutionResolved, resolved.Status)
+	assert.Equal(t, 2, resolved.ResolvedID)
+	assert.NotNil(t, resolved.ResolvedAt)
+
+	pending, err = ListAuthorResolutions(s, AuthorResolutionPending)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+	all, err := ListAuthorResolutions(s, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	missing, err := GetAuthorResolution(s, "nope")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
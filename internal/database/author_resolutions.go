@@ -0,0 +1,128 @@
+// file: internal/database/author_resolutions.go
+// version: 1.1.0
+// guid: c7e05b3a-2d94-4f18-8b6c-5a1e9d7f0c42
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Author resolution prompts: when a scan meets an author name shared by
+// several author records and nothing on the book says which one it is,
+// the book is filed under the name's original record and a prompt is
+// queued for review. Prompts live in the RawKV space under
+// "author_resolution:<ULID>" and are listed in the order they were
+// raised, by CreatedAt (ULIDs minted in the same millisecond aren't
+// ordered).
+
+const authorResolutionPrefix = "author_resolution:"
+
+// Author resolution statuses.
+const (
+	AuthorResolutionPending  = "pending"
+	AuthorResolutionResolved = "resolved"
+)
+
+// AuthorResolution asks which of several same-named authors wrote a book.
+type AuthorResolution struct {
+	ID     string `json:"id"`
+	BookID string `json:"book_id"`
+	// Name is the author name as the scan read it.
+	Name         string `json:"name"`
+	CandidateIDs []int  `json:"candidate_ids"`
+	// AssignedID is the author the scan filed the book under meanwhile.
+	AssignedID int        `json:"assigned_id"`
+	Status     string     `json:"status"`
+	ResolvedID int        `json:"resolved_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AddAuthorResolution queues r as a pending prompt. A book has at most one
+// pending prompt; if it already has one, that prompt is returned instead.
+func AddAuthorResolution(store RawKVStore, r AuthorResolution) (*AuthorResolution, error) {
+	pending, err := ListAuthorResolutions(store, AuthorResolutionPending)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pending {
+		if pending[i].BookID == r.BookID {
+			return &pending[i], nil
+		}
+	}
+	id, err := newULID()
+	if err != nil {
+		return nil, err
+	}
+	r.ID = id
+	r.Status = AuthorResolutionPending
+	r.CreatedAt = time.Now().UTC()
+	if err := putAuthorResolution(store, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ListAuthorResolutions returns prompts oldest first, only those with
+// status when it is non-empty.
+func ListAuthorResolutions(store RawKVStore, status string) ([]AuthorResolution, error) {
+	pairs, err := store.ScanPrefix(authorResolutionPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("scan author resolutions: %w", err)
+	}
+	out := make([]AuthorResolution, 0, len(pairs))
+	for _, kv := range pairs {
+		var r AuthorResolution
+		if err := json.Unmarshal(kv.Value, &r); err != nil {
+			continue
+		}
+		if status == "" || r.Status == status {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+// GetAuthorResolution returns the prompt with id, or nil.
+func GetAuthorResolution(store RawKVStore, id string) (*AuthorResolution, error) {
+	raw, err := store.GetRaw(authorResolutionPrefix + id)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	var r AuthorResolution
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("decode author resolution %s: %w", id, err)
+	}
+	return &r, nil
+}
+
+// ResolveAuthorResolution records that the prompt was answered with
+// authorID. Updating the book is the caller's job.
+func ResolveAuthorResolution(store RawKVStore, id string, authorID int) (*AuthorResolution, error) {
+	r, err := GetAuthorResolution(store, id)
+	if err != nil || r == nil {
+		return r, err
+	}
+	now := time.Now().UTC()
+	r.Status = AuthorResolutionResolved
+	r.ResolvedID = authorID
+	r.ResolvedAt = &now
+	if err := putAuthorResolution(store, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func putAuthorResolution(store RawKVStore, r *AuthorResolution) error {
+	blob, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshal author resolution: %w", err)
+	}
+	return store.SetRaw(authorResolutionPrefix+r.ID, blob)
+}
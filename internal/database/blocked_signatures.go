@@ -0,0 +1,212 @@
+// file: internal/database/blocked_signatures.go
+// version: 1.0.0
+// guid: da0d882f-f599-4c20-be87-f1260bed5bc9
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Metadata signature blocklist: the hash blocklist only stops the exact
+// file it was given, so a re-encode of a deleted book comes straight back.
+// A signature blocks by normalized title and author plus the duration,
+// which survive a re-encode. Signatures live in the RawKV space under
+// "blocked_signature:<title+author digest>:<duration bucket>", so a
+// lookup is three point reads: the book's bucket and its neighbours.
+
+const (
+	blockedSignaturePrefix = "blocked_signature:"
+	// signatureBucketSec is the width of a duration bucket.
+	signatureBucketSec = 600
+)
+
+// BlockedSignature is a blocked (title, author, duration) combination.
+type BlockedSignature struct {
+	// ID identifies the signature in the API ("<digest>-<bucket>").
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	DurationSec int    `json:"duration_sec"`
+	// Hash is the file hash blocked alongside the signature, if any;
+	// unblocking that hash removes the signature too.
+	Hash      string    `json:"hash,omitempty"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewBlockedSignature builds the signature of a book. ok is false when the
+// title or duration is missing, since a title alone would block every
+// edition of the book.
+func NewBlockedSignature(title, author string, durationSec int, hash, reason string) (sig BlockedSignature, ok bool) {
+	title, author = signatureText(title), signatureText(author)
+	if title == "" || durationSec <= 0 {
+		return BlockedSignature{}, false
+	}
+	sig = BlockedSignature{
+		Title:       title,
+		Author:      author,
+		DurationSec: durationSec,
+		Hash:        hash,
+		Reason:      reason,
+	}
+	sig.ID = signatureDigest(title, author) + "-" + strconv.Itoa(durationSec/signatureBucketSec)
+	return sig, true
+}
+
+// signatureText normalizes a title or author for signatures: lowercased,
+// bracketed asides such as "(Unabridged)" dropped, punctuation folded to
+// spaces and a leading article removed.
+func signatureText(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '(' || r == '[':
+			depth++
+		case (r == ')' || r == ']') && depth > 0:
+			depth--
+		case depth > 0:
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	words := strings.Fields(b.String())
+	if len(words) > 1 && (words[0] == "the" || words[0] == "a" || words[0] == "an") {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+func signatureDigest(title, author string) string {
+	sum := sha256.Sum256([]byte(title + "\x00" + author))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ErrInvalidSignatureID is returned for a signature ID that is not of the
+// "<digest>-<bucket>" form.
+var ErrInvalidSignatureID = errors.New("invalid signature id")
+
+func blockedSignatureKey(id string) (string, error) {
+	digest, bucket, ok := strings.Cut(id, "-")
+	n, err := strconv.Atoi(bucket)
+	if !ok || len(digest) != 16 || err != nil || n < 0 {
+		return "", fmt.Errorf("%w %q", ErrInvalidSignatureID, id)
+	}
+	return fmt.Sprintf("%s%s:%06d", blockedSignaturePrefix, digest, n), nil
+}
+
+// AddBlockedSignature stores sig, replacing a signature with the same ID.
+func AddBlockedSignature(store RawKVStore, sig BlockedSignature) error {
+	key, err := blockedSignatureKey(sig.ID)
+	if err != nil {
+		return err
+	}
+	if sig.CreatedAt.IsZero() {
+		sig.CreatedAt = time.Now().UTC()
+	}
+	blob, err := json.Marshal(sig)
+	if err != nil {
+		return fmt.Errorf("marshal blocked signature: %w", err)
+	}
+	return store.SetRaw(key, blob)
+}
+
+// signatureTolerance is how far a duration may drift between encodes of
+// the same book: 2%, at least two minutes and at most one bucket.
+func signatureTolerance(durationSec int) int {
+	return min(max(durationSec/50, 120), signatureBucketSec)
+}
+
+// MatchBlockedSignature returns the blocked signature a book with this
+// title, author and duration matches, or nil.
+func MatchBlockedSignature(store RawKVStore, title, author string, durationSec int) (*BlockedSignature, error) {
+	probe, ok := NewBlockedSignature(title, author, durationSec, "", "")
+	if !ok {
+		return nil, nil
+	}
+	digest := signatureDigest(probe.Title, probe.Author)
+	bucket := durationSec / signatureBucketSec
+	for b := max(bucket-1, 0); b <= bucket+1; b++ {
+		blob, err := store.GetRaw(fmt.Sprintf("%s%s:%06d", blockedSignaturePrefix, digest, b))
+		if err != nil {
+			return nil, err
+		}
+		if blob == nil {
+			continue
+		}
+		var sig BlockedSignature
+		if err := json.Unmarshal(blob, &sig); err != nil {
+			continue
+		}
+		diff := durationSec - sig.DurationSec
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= signatureTolerance(sig.DurationSec) {
+			return &sig, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListBlockedSignatures returns every blocked signature.
+func ListBlockedSignatures(store RawKVStore) ([]BlockedSignature, error) {
+	pairs, err := store.ScanPrefix(blockedSignaturePrefix)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([]BlockedSignature, 0, len(pairs))
+	for _, kv := range pairs {
+		var sig BlockedSignature
+		if err := json.Unmarshal(kv.Value, &sig); err == nil {
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs, nil
+}
+
+// RemoveBlockedSignature deletes the signature with the given ID and
+// reports whether it existed.
+func RemoveBlockedSignature(store RawKVStore, id string) (bool, error) {
+	key, err := blockedSignatureKey(id)
+	if err != nil {
+		return false, err
+	}
+	blob, err := store.GetRaw(key)
+	if err != nil || blob == nil {
+		return false, err
+	}
+	return true, store.DeleteRaw(key)
+}
+
+// RemoveBlockedSignaturesForHash deletes the signatures blocked together
+// with hash and returns how many there were.
+func RemoveBlockedSignaturesForHash(store RawKVStore, hash string) (int, error) {
+	sigs, err := ListBlockedSignatures(store)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, sig := range sigs {
+		if sig.Hash != hash {
+			continue
+		}
+		if _, err := RemoveBlockedSignature(store, sig.ID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,63 @@
+// file: internal/database/blocked_signatures_test.go
+// version: 1.0.0
+// guid: 400f1045-8c2c-48c5-bc0f-0d8196553e8e
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockedSignatures(t *testing.T) {
+	s := setupTestPebbleStore(t)
+
+	_, ok := NewBlockedSignature("Dune", "Frank Herbert", 0, "", "")
+	assert.False(t, ok, "a signature needs a duration")
+
+	sig, ok := NewBlockedSignature("The Hobbit (Unabridged)", "J. R. R. Tolkien", 11*3600+5, "abc", "deleted")
+	require.True(t, ok)
+	assert.Equal(t, "hobbit", sig.Title)
+	assert.Equal(t, "j r r tolkien", sig.Author)
+	require.NoError(t, AddBlockedSignature(s, sig))
+
+	// A re-encode drifts a little and may land in the next bucket.
+	match, err := MatchBlockedSignature(s, "Hobbit", "J.R.R. Tolkien", 11*3600+5+240)
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, sig.ID, match.ID)
+
+	for name, probe := range map[string]struct {
+		title, author string
+		duration      int
+	}{
+		"other author":    {"The Hobbit", "Someone Else", 11*3600 + 5},
+		"other duration":  {"The Hobbit", "J. R. R. Tolkien", 9 * 3600},
+		"no duration":     {"The Hobbit", "J. R. R. Tolkien", 0},
+		"dramatized cast": {"The Hobbit", "J. R. R. Tolkien", 4 * 3600},
+	} {
+		match, err := MatchBlockedSignature(s, probe.title, probe.author, probe.duration)
+		require.NoError(t, err)
+		assert.Nil(t, match, name)
+	}
+
+	other, _ := NewBlockedSignature("Dune", "Frank Herbert", 21*3600, "", "manual")
+	require.NoError(t, AddBlockedSignature(s, other))
+	all, err := ListBlockedSignatures(s)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	n, err := RemoveBlockedSignaturesForHash(s, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	removed, err := RemoveBlockedSignature(s, other.ID)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = RemoveBlockedSignature(s, other.ID)
+	require.NoError(t, err)
+	assert.False(t, removed)
+	_, err = RemoveBlockedSignature(s, "../etc")
+	assert.Error(t, err)
+}
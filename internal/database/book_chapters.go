@@ -0,0 +1,81 @@
+// file: internal/database/book_chapters.go
+// version: 1.1.0
+// guid: f347c7c8-945a-45bf-b5e9-940a1b61f844
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Embedded chapter markers read during scans, markers read from a cue
+// sheet next to the file, plus markers proposed by silence detection or
+// edited through the API. Keys live under "book_chapters:<book id>" in the
+// RawKV space; a rescan replaces the whole list unless it finds no
+// chapters and the list is unwritten.
+
+// BookChaptersPrefix is the RawKV namespace for chapter lists.
+const BookChaptersPrefix = "book_chapters:"
+
+// BookChapter is one chapter marker embedded in a book's audio file, or
+// proposed for it.
+type BookChapter struct {
+	Index    int     `json:"index"`
+	Title    string  `json:"title"`
+	StartSec float64 `json:"start_sec"`
+	EndSec   float64 `json:"end_sec"`
+	// Source is empty for markers read from the file. Proposed or edited
+	// markers not yet written into the file carry ChapterSourceCue,
+	// ChapterSourceSilence or ChapterSourceManual.
+	Source string `json:"source,omitempty"`
+}
+
+// Sources of chapter markers that are not (yet) in the file.
+const (
+	ChapterSourceCue     = "cue"
+	ChapterSourceSilence = "silence"
+	ChapterSourceManual  = "manual"
+)
+
+// ChaptersUnwritten reports whether chapters hold markers that exist only
+// in the database, which a rescan of the chapterless file must not drop.
+func ChaptersUnwritten(chapters []BookChapter) bool {
+	for _, ch := range chapters {
+		if ch.Source != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBookChapters returns the chapters recorded for bookID, or nil when
+// none were recorded.
+func GetBookChapters(store RawKVStore, bookID string) ([]BookChapter, error) {
+	blob, err := store.GetRaw(BookChaptersPrefix + bookID)
+	if err != nil {
+		return nil, fmt.Errorf("book chapters get: %w", err)
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	var chapters []BookChapter
+	if err := json.Unmarshal(blob, &chapters); err != nil {
+		return nil, fmt.Errorf("book chapters decode: %w", err)
+	}
+	return chapters, nil
+}
+
+// PutBookChapters replaces the chapters recorded for bookID. An empty
+// list deletes the entry.
+func PutBookChapters(store RawKVStore, bookID string, chapters []BookChapter) error {
+	if len(chapters) == 0 {
+		return store.DeleteRaw(BookChaptersPrefix + bookID)
+	}
+	blob, err := json.Marshal(chapters)
+	if err != nil {
+		return fmt.Errorf("book chapters marshal: %w", err)
+	}
+	return store.SetRaw(BookChaptersPrefix+bookID, blob)
+}
@@ -0,0 +1,82 @@
+// file: internal/database/book_identity.go
+// version: 1.0.0
+// guid: 4b9e2d71-6a3f-4c85-9e10-d7f5a8c3b264
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"strconv"
+
+	"github.com/falkcorp/audiobook-organizer/internal/util"
+)
+
+// BookIdentity is what tells two books with the same title apart: two
+// "Endurance"s by different authors are different books, and when an
+// author is unknown, different narrators are too. Fields are normalized;
+// empty means unknown.
+type BookIdentity struct {
+	Title    string
+	Author   string
+	Narrator string
+}
+
+// NewBookIdentity builds a normalized identity.
+func NewBookIdentity(title, author, narrator string) BookIdentity {
+	norm := func(s string) string { return util.CollapseSpaces(util.NormalizeString(s)) }
+	return BookIdentity{Title: norm(title), Author: norm(author), Narrator: norm(narrator)}
+}
+
+// authorGetter is the slice of the store BookIdentityOf needs.
+type authorGetter interface {
+	GetAuthorByID(id int) (*Author, error)
+}
+
+// BookIdentityOf returns book's identity, resolving its author's name
+// through store. With no store, or an author that can't be loaded, the
+// author ID stands in for the name.
+func BookIdentityOf(store authorGetter, book *Book) BookIdentity {
+	author := ""
+	if book.Author != nil {
+		author = book.Author.Name
+	} else if book.AuthorID != nil {
+		author = "#" + strconv.Itoa(*book.AuthorID)
+		if store != nil {
+			if a, err := store.GetAuthorByID(*book.AuthorID); err == nil && a != nil && a.Name != "" {
+				author = a.Name
+			}
+		}
+	}
+	narrator := ""
+	if book.Narrator != nil {
+		narrator = *book.Narrator
+	}
+	return NewBookIdentity(book.Title, author, narrator)
+}
+
+// SameBook reports whether id and other can be the same book. Titles
+// must match. Authors decide when both are known; otherwise narrators
+// do when both are known; otherwise the title alone matches.
+func (id BookIdentity) SameBook(other BookIdentity) bool {
+	if id.Title != other.Title {
+		return false
+	}
+	if id.Author != "" && other.Author != "" {
+		return id.Author == other.Author
+	}
+	if id.Narrator != "" && other.Narrator != "" {
+		return id.Narrator == other.Narrator
+	}
+	return true
+}
+
+// BookDirLister lists the books filed directly in a directory. The
+// organizer uses it to spot a different book already owning a target
+// folder.
+type BookDirLister interface {
+	GetBooksInDir(dirPath string) ([]Book, error)
+}
+
+// AsBookDirLister returns the BookDirLister behind s, looking through
+// Unwrap layers.
+func AsBookDirLister(s any) (BookDirLister, bool) { return unwrapStore[BookDirLister](s) }
@@ -0,0 +1,65 @@
+// file: internal/database/book_identity_test.go
+// version: 1.0.0
+// guid: 93c5e7a1-0b4d-4f26-8a19-6e2d7c4b0f35
+
+package database
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookIdentity_SameBook(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b BookIdentity
+		want bool
+	}{
+		{"different authors", NewBookIdentity("Endurance", "Alfred Lansing", ""), NewBookIdentity("Endurance", "Scott Kelly", ""), false},
+		{"same author, case and spacing", NewBookIdentity("Endurance", "Alfred  Lansing", "A"), NewBookIdentity("endurance", "alfred lansing", "B"), true},
+		{"no authors, different narrators", NewBookIdentity("Endurance", "", "Simon Prebble"), NewBookIdentity("Endurance", "", "Scott Kelly"), false},
+		{"one author unknown, narrators decide", NewBookIdentity("Endurance", "Alfred Lansing", "Simon Prebble"), NewBookIdentity("Endurance", "", "Simon Prebble"), true},
+		{"nothing but title", NewBookIdentity("Endurance", "", ""), NewBookIdentity("Endurance", "Scott Kelly", ""), true},
+		{"different titles", NewBookIdentity("Endurance", "A", ""), NewBookIdentity("Endeavour", "A", ""), false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, tc.a.SameBook(tc.b), tc.name)
+	}
+}
+
+func TestBookIdentityOf_ResolvesAuthor(t *testing.T) {
+	store := setupTestPebbleStore(t)
+	author, err := store.CreateAuthor("Scott Kelly")
+	require.NoError(t, err)
+
+	book := &Book{Title: "Endurance", AuthorID: &author.ID}
+	assert.Equal(t, "scott kelly", BookIdentityOf(store, book).Author)
+	assert.Equal(t, "#"+strconv.Itoa(author.ID), BookIdentityOf(nil, book).Author)
+}
+
+func TestGetBooksInDir(t *testing.T) {
+	store := setupTestPebbleStore(t)
+	for id, path := range map[string]string{
+		"a": "/lib/Endurance/a.m4b",
+		"b": "/lib/Endurance/b.m4b",
+		"c": "/lib/Endurance/disc 2/c.mp3",
+		"d": "/lib/Endurance 2/d.m4b",
+	} {
+		_, err := store.CreateBook(&Book{ID: id, Title: id, FilePath: path})
+		require.NoError(t, err)
+	}
+	books, err := store.GetBooksInDir(filepath.Clean("/lib/Endurance/"))
+	require.NoError(t, err)
+	var ids []string
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, ids)
+
+	_, ok := AsBookDirLister(NewTimedStore(store, nil))
+	assert.True(t, ok, "visible through the timing wrapper")
+}
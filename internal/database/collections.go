@@ -0,0 +1,149 @@
+// file: internal/database/collections.go
+// version: 1.0.0
+// guid: 4a9d2e61-7c3b-4f85-a1d0-8e6b5c2f9a37
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Collections are user-curated shelves ("Favorites", "To Listen") holding
+// an ordered list of books. Unlike series and works they say nothing
+// about the books themselves, and unlike static playlists they are never
+// pushed to iTunes. Each user's collections live in the RawKV space under
+// "collection:<user>:<ULID>", so listing them is a prefix scan and
+// another user's IDs simply aren't found.
+
+const collectionPrefix = "collection:"
+
+// ErrCollectionNameTaken is returned when a user already has a collection
+// with the same name (compared case-insensitively).
+var ErrCollectionNameTaken = errors.New("collection name already in use")
+
+// Collection is one user's ordered shelf of books.
+type Collection struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	BookIDs     []string  `json:"book_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func collectionKey(userID, id string) string {
+	return collectionPrefix + userID + ":" + id
+}
+
+// CreateCollection stores a new collection for c.UserID and returns it
+// with its ID and timestamps set.
+func CreateCollection(store RawKVStore, c Collection) (*Collection, error) {
+	if err := checkCollectionName(store, c.UserID, c.Name, ""); err != nil {
+		return nil, err
+	}
+	id, err := newULID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	c.ID = id
+	c.CreatedAt, c.UpdatedAt = now, now
+	if c.BookIDs == nil {
+		c.BookIDs = []string{}
+	}
+	if err := putCollection(store, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetCollection returns userID's collection id, or nil when the user has
+// no such collection.
+func GetCollection(store RawKVStore, userID, id string) (*Collection, error) {
+	blob, err := store.GetRaw(collectionKey(userID, id))
+	if err != nil || blob == nil {
+		return nil, err
+	}
+	var c Collection
+	if err := json.Unmarshal(blob, &c); err != nil {
+		return nil, fmt.Errorf("unmarshal collection %s: %w", id, err)
+	}
+	return &c, nil
+}
+
+// ListCollections returns userID's collections, oldest first.
+func ListCollections(store RawKVStore, userID string) ([]Collection, error) {
+	pairs, err := store.ScanPrefix(collectionKey(userID, ""))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Collection, 0, len(pairs))
+	for _, kv := range pairs {
+		var c Collection
+		if err := json.Unmarshal(kv.Value, &c); err != nil || c.UserID != userID {
+			continue
+		}
+		out = append(out, c)
+	}
+	// ULIDs minted in the same millisecond aren't ordered, so sort on the
+	// creation time rather than trusting key order.
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+// UpdateCollection saves c, which must already exist, and bumps its
+// UpdatedAt.
+func UpdateCollection(store RawKVStore, c *Collection) error {
+	existing, err := GetCollection(store, c.UserID, c.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("collection %s not found", c.ID)
+	}
+	if err := checkCollectionName(store, c.UserID, c.Name, c.ID); err != nil {
+		return err
+	}
+	c.CreatedAt = existing.CreatedAt
+	c.UpdatedAt = time.Now().UTC()
+	if c.BookIDs == nil {
+		c.BookIDs = []string{}
+	}
+	return putCollection(store, c)
+}
+
+// DeleteCollection removes userID's collection id. The books are not
+// touched.
+func DeleteCollection(store RawKVStore, userID, id string) error {
+	return store.DeleteRaw(collectionKey(userID, id))
+}
+
+func putCollection(store RawKVStore, c *Collection) error {
+	blob, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshal collection: %w", err)
+	}
+	return store.SetRaw(collectionKey(c.UserID, c.ID), blob)
+}
+
+// checkCollectionName refuses a name another of the user's collections
+// (other than exceptID) already has.
+func checkCollectionName(store RawKVStore, userID, name, exceptID string) error {
+	all, err := ListCollections(store, userID)
+	if err != nil {
+		return err
+	}
+	for _, c := range all {
+		if c.ID != exceptID && strings.EqualFold(c.Name, name) {
+			return fmt.Errorf("%w: %q", ErrCollectionNameTaken, name)
+		}
+	}
+	return nil
+}
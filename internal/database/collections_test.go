@@ -0,0 +1,57 @@
+// file: internal/database/collections_test.go
+// version: 1.0.0
+// guid: 3e7c1a94-5d2f-4b68-8c0e-a6f9d2b4e517
+
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollections_PerUser(t *testing.T) {
+	store, err := NewInMemoryPebbleStore()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	fav, err := CreateCollection(store, Collection{UserID: "u1", Name: "Favorites"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, fav.BookIDs)
+	_, err = CreateCollection(store, Collection{UserID: "u1", Name: "FAVORITES"})
+	assert.True(t, errors.Is(err, ErrCollectionNameTaken))
+
+	// Another user may reuse the name, and a user ID that extends the
+	// first one's key prefix doesn't leak into its listing.
+	_, err = CreateCollection(store, Collection{UserID: "u1:x", Name: "Favorites"})
+	require.NoError(t, err)
+	later, err := CreateCollection(store, Collection{UserID: "u1", Name: "To Listen"})
+	require.NoError(t, err)
+
+	list, err := ListCollections(store, "u1")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, fav.ID, list[0].ID, "oldest first")
+	assert.Equal(t, later.ID, list[1].ID)
+
+	got, err := GetCollection(store, "u2", fav.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	later.Name = "favorites"
+	assert.True(t, errors.Is(UpdateCollection(store, later), ErrCollectionNameTaken))
+	later.Name = "Up Next"
+	later.BookIDs = []string{"b1"}
+	require.NoError(t, UpdateCollection(store, later))
+	got, err = GetCollection(store, "u1", later.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Up Next", got.Name)
+	assert.Equal(t, []string{"b1"}, got.BookIDs)
+
+	require.NoError(t, DeleteCollection(store, "u1", fav.ID))
+	list, err = ListCollections(store, "u1")
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
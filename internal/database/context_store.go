@@ -0,0 +1,101 @@
+// file: internal/database/context_store.go
+// version: 1.1.0
+// guid: 2f8c6a41-d3b9-4e07-9a15-7b4e0c8d2f96
+// last-edited: 2026-10-17
+
+package database
+
+import "context"
+
+// ContextBookLister is implemented by stores whose full book listing
+// stops once a context is done.
+type ContextBookLister interface {
+	// GetAllBooksContext is GetAllBooks returning ctx's error instead of
+	// a partial listing once ctx is done.
+	GetAllBooksContext(ctx context.Context, limit, offset int) ([]Book, error)
+}
+
+// ContextStore holds the context-aware variants of the Store calls that
+// scan a whole keyspace and so can outlive a request's deadline. Each
+// returns ctx's error instead of a partial result once ctx is done.
+type ContextStore interface {
+	ContextBookLister
+	SearchBooksContext(ctx context.Context, query string, limit, offset int) ([]Book, error)
+	CountBooksContext(ctx context.Context) (int, error)
+	ListOperationsContext(ctx context.Context, limit, offset int) ([]Operation, int, error)
+}
+
+// The helpers below serve callers holding a narrow store interface
+// without the context variants (handler interfaces, mocks). They call
+// the variant on the store behind it, looking through Unwrap layers, and
+// otherwise make the plain call with ctx checked before and after it, so
+// a request canceled meanwhile still gets ctx's error rather than a
+// result nobody reads.
+
+// GetAllBooksContext lists books through the ContextBookLister behind
+// store.
+func GetAllBooksContext(ctx context.Context, store interface {
+	GetAllBooks(limit, offset int) ([]Book, error)
+}, limit, offset int) ([]Book, error) {
+	if cl, ok := unwrapStore[ContextBookLister](store); ok {
+		return cl.GetAllBooksContext(ctx, limit, offset)
+	}
+	return checkedCall(ctx, func() ([]Book, error) { return store.GetAllBooks(limit, offset) })
+}
+
+// SearchBooksContext searches books through the ContextStore behind
+// store.
+func SearchBooksContext(ctx context.Context, store interface {
+	SearchBooks(query string, limit, offset int) ([]Book, error)
+}, query string, limit, offset int) ([]Book, error) {
+	if cs, ok := unwrapStore[ContextStore](store); ok {
+		return cs.SearchBooksContext(ctx, query, limit, offset)
+	}
+	return checkedCall(ctx, func() ([]Book, error) { return store.SearchBooks(query, limit, offset) })
+}
+
+// CountBooksContext counts books through the ContextStore behind store.
+func CountBooksContext(ctx context.Context, store interface {
+	CountBooks() (int, error)
+}) (int, error) {
+	if cs, ok := unwrapStore[ContextStore](store); ok {
+		return cs.CountBooksContext(ctx)
+	}
+	return checkedCall(ctx, store.CountBooks)
+}
+
+// ListOperationsContext lists operations through the ContextStore behind
+// store.
+func ListOperationsContext(ctx context.Context, store interface {
+	ListOperations(limit, offset int) ([]Operation, int, error)
+}, limit, offset int) ([]Operation, int, error) {
+	if cs, ok := unwrapStore[ContextStore](store); ok {
+		return cs.ListOperationsContext(ctx, limit, offset)
+	}
+	total := 0
+	ops, err := checkedCall(ctx, func() ([]Operation, error) {
+		ops, n, err := store.ListOperations(limit, offset)
+		total = n
+		return ops, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return ops, total, nil
+}
+
+// checkedCall runs call, which cannot stop early, between two ctx checks.
+func checkedCall[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	v, err := call()
+	if err != nil {
+		return zero, err
+	}
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
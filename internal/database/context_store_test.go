@@ -0,0 +1,93 @@
+// file: internal/database/context_store_test.go
+// version: 1.1.0
+// guid: 8e3b1f64-c7a2-4d09-b5e8-1a6d9c2f4b73
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllBooksContext(t *testing.T) {
+	s, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	ps := s.(*PebbleStore)
+	for _, b := range cursorTestBooks() {
+		_, err := ps.CreateBook(&b)
+		require.NoError(t, err)
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, memdb := range []bool{true, false} {
+		ps.UseMemDB = memdb
+		for _, store := range []Store{ps, NewTimedStore(ps, func() time.Duration { return 0 })} {
+			want, err := store.GetAllBooks(0, 0)
+			require.NoError(t, err)
+			books, err := GetAllBooksContext(context.Background(), store, 0, 0)
+			require.NoError(t, err)
+			assert.Equal(t, want, books)
+			_, err = GetAllBooksContext(canceled, store, 0, 0)
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+	}
+
+	// Stores that can't stop early are still checked around the call.
+	calls := 0
+	mock := &MockStore{GetAllBooksFunc: func(limit, offset int) ([]Book, error) {
+		calls++
+		return []Book{{ID: "a"}}, nil
+	}}
+	books, err := GetAllBooksContext(context.Background(), mock, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, books, 1)
+	_, err = GetAllBooksContext(canceled, mock, 0, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestContextStoreVariants(t *testing.T) {
+	s, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	ps := s.(*PebbleStore)
+	for _, b := range cursorTestBooks() {
+		_, err := ps.CreateBook(&b)
+		require.NoError(t, err)
+	}
+	_, err := ps.CreateOperation("op1", "scan", nil)
+	require.NoError(t, err)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, store := range []Store{ps, NewTimedStore(ps, func() time.Duration { return 0 }), &MockStore{}} {
+		wantBooks, err := store.SearchBooks("dune", 10, 0)
+		require.NoError(t, err)
+		books, err := SearchBooksContext(context.Background(), store, "dune", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, wantBooks, books)
+		_, err = SearchBooksContext(canceled, store, "dune", 10, 0)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		wantCount, err := store.CountBooks()
+		require.NoError(t, err)
+		count, err := CountBooksContext(context.Background(), store)
+		require.NoError(t, err)
+		assert.Equal(t, wantCount, count)
+		_, err = CountBooksContext(canceled, store)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		wantOps, wantTotal, err := store.ListOperations(10, 0)
+		require.NoError(t, err)
+		ops, total, err := ListOperationsContext(context.Background(), store, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, wantOps, ops)
+		assert.Equal(t, wantTotal, total)
+		_, _, err = ListOperationsContext(canceled, store, 10, 0)
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
@@ -0,0 +1,172 @@
+// file: internal/database/contributors.go
+// version: 1.0.0
+// guid: 8c1f4e27-b9d3-4a56-8e02-5d7a3c9f1b64
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Contributor roles. A book's authors and narrators are ordered lists of
+// contributors (book_authors / book_narrators); Position 0 is the primary
+// one and mirrors Book.AuthorID / Book.Narrator. Anthologies and
+// multi-author collections list every author, plus editors and
+// translators, in the same table.
+const (
+	BookAuthorRoleAuthor     = "author"
+	BookAuthorRoleCoAuthor   = "co-author"
+	BookAuthorRoleEditor     = "editor"
+	BookAuthorRoleTranslator = "translator"
+
+	BookNarratorRoleNarrator   = "narrator"
+	BookNarratorRoleCoNarrator = "co-narrator"
+)
+
+// BookAuthorRoles lists the valid BookAuthor roles.
+var BookAuthorRoles = []string{BookAuthorRoleAuthor, BookAuthorRoleCoAuthor, BookAuthorRoleEditor, BookAuthorRoleTranslator}
+
+// BookNarratorRoles lists the valid BookNarrator roles.
+var BookNarratorRoles = []string{BookNarratorRoleNarrator, BookNarratorRoleCoNarrator}
+
+// IsWritingRole reports whether role credits the contributor as one of
+// the book's authors, as opposed to an editor or translator.
+func IsWritingRole(role string) bool {
+	return role == BookAuthorRoleAuthor || role == BookAuthorRoleCoAuthor
+}
+
+// NormalizeBookAuthors orders authors by Position, renumbers them from 0,
+// drops repeats of the same author, sets BookID, and fills in a missing
+// role ("author" first, "co-author" after). An unknown role or author ID
+// is an error.
+func NormalizeBookAuthors(bookID string, authors []BookAuthor) ([]BookAuthor, error) {
+	out := append([]BookAuthor(nil), authors...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Position < out[j].Position })
+	seen := make(map[int]bool, len(out))
+	kept := out[:0]
+	for _, a := range out {
+		if a.AuthorID <= 0 {
+			return nil, fmt.Errorf("invalid author_id %d", a.AuthorID)
+		}
+		if seen[a.AuthorID] {
+			continue
+		}
+		seen[a.AuthorID] = true
+		a.BookID = bookID
+		a.Position = len(kept)
+		if a.Role == "" {
+			a.Role = BookAuthorRoleAuthor
+			if a.Position > 0 {
+				a.Role = BookAuthorRoleCoAuthor
+			}
+		} else if !slices.Contains(BookAuthorRoles, a.Role) {
+			return nil, fmt.Errorf("unknown author role %q (want one of %v)", a.Role, BookAuthorRoles)
+		}
+		kept = append(kept, a)
+	}
+	return kept, nil
+}
+
+// NormalizeBookNarrators is NormalizeBookAuthors for narrators; a missing
+// role becomes "narrator" first and "co-narrator" after.
+func NormalizeBookNarrators(bookID string, narrators []BookNarrator) ([]BookNarrator, error) {
+	out := append([]BookNarrator(nil), narrators...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Position < out[j].Position })
+	seen := make(map[int]bool, len(out))
+	kept := out[:0]
+	for _, n := range out {
+		if n.NarratorID <= 0 {
+			return nil, fmt.Errorf("invalid narrator_id %d", n.NarratorID)
+		}
+		if seen[n.NarratorID] {
+			continue
+		}
+		seen[n.NarratorID] = true
+		n.BookID = bookID
+		n.Position = len(kept)
+		if n.Role == "" {
+			n.Role = BookNarratorRoleNarrator
+			if n.Position > 0 {
+				n.Role = BookNarratorRoleCoNarrator
+			}
+		} else if !slices.Contains(BookNarratorRoles, n.Role) {
+			return nil, fmt.Errorf("unknown narrator role %q (want one of %v)", n.Role, BookNarratorRoles)
+		}
+		kept = append(kept, n)
+	}
+	return kept, nil
+}
+
+// BookAuthorNames resolves a book's authors to names in position order.
+// With writingOnly, editors and translators are left out. Authors that
+// can't be loaded are skipped.
+func BookAuthorNames(store authorGetter, authors []BookAuthor, writingOnly bool) []string {
+	sorted := append([]BookAuthor(nil), authors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Position < sorted[j].Position })
+	names := make([]string, 0, len(sorted))
+	for _, a := range sorted {
+		if writingOnly && !IsWritingRole(a.Role) && a.Role != "" {
+			continue
+		}
+		if author, err := store.GetAuthorByID(a.AuthorID); err == nil && author != nil && author.Name != "" {
+			names = append(names, author.Name)
+		}
+	}
+	return names
+}
+
+// contributorStore is the slice of the store the contributor backfill
+// needs.
+type contributorStore interface {
+	GetAllBooks(limit, offset int) ([]Book, error)
+	GetBookAuthors(bookID string) ([]BookAuthor, error)
+	SetBookAuthors(bookID string, authors []BookAuthor) error
+	GetBookNarrators(bookID string) ([]BookNarrator, error)
+	SetBookNarrators(bookID string, narrators []BookNarrator) error
+}
+
+// backfillContributors gives every book with an AuthorID but no
+// book_authors rows a primary "author" row, and rewrites existing rows
+// whose positions have gaps or whose roles are missing. Rows with an
+// unknown role or author are left alone. It returns how many books it
+// changed.
+func backfillContributors(store contributorStore) (int, error) {
+	books, err := store.GetAllBooks(1000000, 0)
+	if err != nil {
+		return 0, fmt.Errorf("list books: %w", err)
+	}
+	changed := 0
+	for _, book := range books {
+		authors, err := store.GetBookAuthors(book.ID)
+		if err != nil {
+			return changed, fmt.Errorf("book %s authors: %w", book.ID, err)
+		}
+		if len(authors) == 0 && book.AuthorID != nil {
+			authors = []BookAuthor{{AuthorID: *book.AuthorID}}
+		}
+		touched := false
+		if norm, err := NormalizeBookAuthors(book.ID, authors); err == nil && !slices.Equal(norm, authors) {
+			if err := store.SetBookAuthors(book.ID, norm); err != nil {
+				return changed, fmt.Errorf("book %s authors: %w", book.ID, err)
+			}
+			touched = true
+		}
+		narrators, err := store.GetBookNarrators(book.ID)
+		if err != nil {
+			return changed, fmt.Errorf("book %s narrators: %w", book.ID, err)
+		}
+		if norm, err := NormalizeBookNarrators(book.ID, narrators); err == nil && !slices.Equal(norm, narrators) {
+			if err := store.SetBookNarrators(book.ID, norm); err != nil {
+				return changed, fmt.Errorf("book %s narrators: %w", book.ID, err)
+			}
+			touched = true
+		}
+		if touched {
+			changed++
+		}
+	}
+	return changed, nil
+}
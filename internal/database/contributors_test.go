@@ -0,0 +1,84 @@
+// file: internal/database/contributors_test.go
+// version: 1.0.0
+// guid: 3e7a9c15-d2b8-4f40-a6e1-9b5c8d2f7a43
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBookAuthors(t *testing.T) {
+	got, err := NormalizeBookAuthors("b1", []BookAuthor{
+		{AuthorID: 3, Position: 5},
+		{AuthorID: 1, Position: 0},
+		{AuthorID: 2, Role: BookAuthorRoleEditor, Position: 2},
+		{AuthorID: 3, Position: 9},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []BookAuthor{
+		{BookID: "b1", AuthorID: 1, Role: "author", Position: 0},
+		{BookID: "b1", AuthorID: 2, Role: "editor", Position: 1},
+		{BookID: "b1", AuthorID: 3, Role: "co-author", Position: 2},
+	}, got)
+
+	_, err = NormalizeBookAuthors("b1", []BookAuthor{{AuthorID: 1, Role: "ghostwriter"}})
+	assert.ErrorContains(t, err, "ghostwriter")
+	_, err = NormalizeBookAuthors("b1", []BookAuthor{{AuthorID: 0}})
+	assert.Error(t, err)
+
+	narrators, err := NormalizeBookNarrators("b1", []BookNarrator{{NarratorID: 4, Position: 1}, {NarratorID: 7, Position: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []BookNarrator{
+		{BookID: "b1", NarratorID: 4, Role: "narrator", Position: 0},
+		{BookID: "b1", NarratorID: 7, Role: "co-narrator", Position: 1},
+	}, narrators)
+}
+
+func TestBackfillContributors_Pebble(t *testing.T) {
+	s := setupTestPebbleStore(t)
+	a1, err := s.CreateAuthor("Ursula K. Le Guin")
+	require.NoError(t, err)
+	a2, err := s.CreateAuthor("Octavia E. Butler")
+	require.NoError(t, err)
+	n1, err := s.CreateNarrator("Kate Mulgrew")
+	require.NoError(t, err)
+
+	legacy, err := s.CreateBook(&Book{Title: "Legacy", FilePath: "/l/legacy.m4b", AuthorID: &a1.ID})
+	require.NoError(t, err)
+	anthology, err := s.CreateBook(&Book{Title: "Anthology", FilePath: "/l/anthology.m4b", AuthorID: &a1.ID})
+	require.NoError(t, err)
+	require.NoError(t, s.SetBookAuthors(anthology.ID, []BookAuthor{
+		{BookID: anthology.ID, AuthorID: a1.ID, Position: 1},
+		{BookID: anthology.ID, AuthorID: a2.ID, Role: "co-author", Position: 4},
+	}))
+	require.NoError(t, s.SetBookNarrators(anthology.ID, []BookNarrator{{BookID: anthology.ID, NarratorID: n1.ID}}))
+	_, err = s.CreateBook(&Book{Title: "No author", FilePath: "/l/none.m4b"})
+	require.NoError(t, err)
+
+	changed, err := backfillContributors(s)
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+
+	authors, err := s.GetBookAuthors(legacy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []BookAuthor{{BookID: legacy.ID, AuthorID: a1.ID, Role: "author", Position: 0}}, authors)
+	authors, err = s.GetBookAuthors(anthology.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []BookAuthor{
+		{BookID: anthology.ID, AuthorID: a1.ID, Role: "author", Position: 0},
+		{BookID: anthology.ID, AuthorID: a2.ID, Role: "co-author", Position: 1},
+	}, authors)
+	narrators, err := s.GetBookNarrators(anthology.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "narrator", narrators[0].Role)
+
+	assert.Equal(t, []string{"Ursula K. Le Guin", "Octavia E. Butler"}, BookAuthorNames(s, authors, true))
+
+	changed, err = backfillContributors(s)
+	require.NoError(t, err)
+	assert.Zero(t, changed, "backfill is idempotent")
+}
@@ -0,0 +1,397 @@
+// file: internal/database/custom_fields.go
+// version: 1.0.0
+// guid: 39abeede-4fbc-46e8-bc3e-513f835f86b8
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+)
+
+// Custom metadata fields are admin-defined columns ("source", "purchase
+// date", "DRM-free", ...) that live beside the fixed Book schema. The
+// definitions say what a field is called, what type its values have and
+// how they are validated; per-book values are stored as canonical strings
+// in a flat key-value space so adding a field never migrates book records.
+//
+// Key-space layout (PebbleDB):
+//
+//	custom_field:def:<key>             → CustomFieldDefinition JSON
+//	custom_field:val:<book id>:<key>   → canonical value string
+const (
+	customFieldDefPrefix = "custom_field:def:"
+	customFieldValPrefix = "custom_field:val:"
+)
+
+// Custom field value types.
+const (
+	CustomFieldText    = "text"
+	CustomFieldNumber  = "number"
+	CustomFieldBoolean = "boolean"
+	CustomFieldDate    = "date"
+	CustomFieldSelect  = "select"
+)
+
+// customFieldKeyPattern keeps keys usable as naming-pattern placeholders
+// ({custom_<key>}) and search fields (custom.<key>:value).
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+// CustomFieldDefinition describes one user-defined metadata field.
+type CustomFieldDefinition struct {
+	Key         string `json:"key"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Options lists the allowed values of a select field.
+	Options []string `json:"options,omitempty"`
+	// Pattern is an optional regular expression text values must match.
+	Pattern string `json:"pattern,omitempty"`
+	// Min and Max bound number values when set.
+	Min       *float64  `json:"min,omitempty"`
+	Max       *float64  `json:"max,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CustomFieldStore is implemented by stores that keep custom metadata
+// field definitions and their per-book values.
+type CustomFieldStore interface {
+	ListCustomFields() ([]CustomFieldDefinition, error)
+	// GetCustomField returns nil when key is not defined.
+	GetCustomField(key string) (*CustomFieldDefinition, error)
+	SaveCustomField(def CustomFieldDefinition) error
+	// DeleteCustomField removes the definition and every book's value for
+	// it. Returns the number of values removed.
+	DeleteCustomField(key string) (int, error)
+	// GetBookCustomFields returns the book's values keyed by field key.
+	GetBookCustomFields(bookID string) (map[string]string, error)
+	// SetBookCustomFields writes values for the book; an empty value
+	// removes that field from the book. Values are stored as given, so
+	// callers validate them with NormalizeValue first.
+	SetBookCustomFields(bookID string, values map[string]string) error
+	// GetAllBookCustomFields returns every stored value, keyed by book ID
+	// then field key.
+	GetAllBookCustomFields() (map[string]map[string]string, error)
+}
+
+// AsCustomFieldStore returns the custom field store behind s, looking
+// through one Unwrap() layer for decorated stores.
+func AsCustomFieldStore(s any) (CustomFieldStore, bool) {
+	if cfs, ok := s.(CustomFieldStore); ok {
+		return cfs, true
+	}
+	if uw, ok := s.(interface{ Unwrap() Store }); ok {
+		cfs, ok := uw.Unwrap().(CustomFieldStore)
+		return cfs, ok
+	}
+	return nil, false
+}
+
+// Normalize validates the definition and fills in defaults (label from
+// key, trimmed and de-duplicated options).
+func (d *CustomFieldDefinition) Normalize() error {
+	d.Key = strings.TrimSpace(d.Key)
+	if !customFieldKeyPattern.MatchString(d.Key) {
+		return fmt.Errorf("key must start with a lowercase letter and contain only a-z, 0-9 and _ (max 40 chars)")
+	}
+	d.Label = strings.TrimSpace(d.Label)
+	if d.Label == "" {
+		d.Label = d.Key
+	}
+	d.Type = strings.ToLower(strings.TrimSpace(d.Type))
+	switch d.Type {
+	case CustomFieldText, CustomFieldNumber, CustomFieldBoolean, CustomFieldDate, CustomFieldSelect:
+	case "":
+		d.Type = CustomFieldText
+	default:
+		return fmt.Errorf("type must be one of: text, number, boolean, date, select")
+	}
+
+	if d.Type == CustomFieldSelect {
+		var opts []string
+		for _, o := range d.Options {
+			if o = strings.TrimSpace(o); o != "" && !slices.Contains(opts, o) {
+				opts = append(opts, o)
+			}
+		}
+		if len(opts) == 0 {
+			return fmt.Errorf("select fields need at least one option")
+		}
+		d.Options = opts
+	} else if len(d.Options) > 0 {
+		return fmt.Errorf("options are only allowed on select fields")
+	}
+
+	if d.Pattern != "" {
+		if d.Type != CustomFieldText {
+			return fmt.Errorf("pattern is only allowed on text fields")
+		}
+		if _, err := regexp.Compile(d.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	if d.Min != nil || d.Max != nil {
+		if d.Type != CustomFieldNumber {
+			return fmt.Errorf("min and max are only allowed on number fields")
+		}
+		if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+			return fmt.Errorf("min must not exceed max")
+		}
+	}
+	return nil
+}
+
+// NormalizeValue validates raw against the definition and returns its
+// canonical form: numbers without trailing zeros, booleans as
+// "true"/"false", dates as YYYY-MM-DD and select values spelled like the
+// matching option. An empty raw value is returned as-is (it clears the
+// field).
+func (d CustomFieldDefinition) NormalizeValue(raw string) (string, error) {
+	v := strings.TrimSpace(raw)
+	if v == "" {
+		return "", nil
+	}
+	switch d.Type {
+	case CustomFieldNumber:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s: must be a number", d.Key)
+		}
+		if d.Min != nil && n < *d.Min {
+			return "", fmt.Errorf("%s: must be at least %v", d.Key, *d.Min)
+		}
+		if d.Max != nil && n > *d.Max {
+			return "", fmt.Errorf("%s: must be at most %v", d.Key, *d.Max)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case CustomFieldBoolean:
+		switch strings.ToLower(v) {
+		case "true", "yes", "y", "1", "on":
+			return "true", nil
+		case "false", "no", "n", "0", "off":
+			return "false", nil
+		}
+		return "", fmt.Errorf("%s: must be true or false", d.Key)
+	case CustomFieldDate:
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.UTC().Format("2006-01-02"), nil
+		}
+		return "", fmt.Errorf("%s: must be a date (YYYY-MM-DD)", d.Key)
+	case CustomFieldSelect:
+		for _, o := range d.Options {
+			if strings.EqualFold(o, v) {
+				return o, nil
+			}
+		}
+		return "", fmt.Errorf("%s: must be one of: %s", d.Key, strings.Join(d.Options, ", "))
+	default:
+		if d.Pattern != "" {
+			re, err := regexp.Compile(d.Pattern)
+			if err != nil {
+				return "", fmt.Errorf("%s: invalid pattern: %w", d.Key, err)
+			}
+			if !re.MatchString(v) {
+				return "", fmt.Errorf("%s: does not match pattern %s", d.Key, d.Pattern)
+			}
+		}
+		return v, nil
+	}
+}
+
+func customFieldValKey(bookID, key string) []byte {
+	return []byte(customFieldValPrefix + bookID + ":" + key)
+}
+
+// ListCustomFields implements CustomFieldStore, ordered by key.
+func (p *PebbleStore) ListCustomFields() ([]CustomFieldDefinition, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	lower := []byte(customFieldDefPrefix)
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: prefixEnd(lower)})
+	if err != nil {
+		return nil, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	defer iter.Close()
+	out := []CustomFieldDefinition{}
+	for iter.First(); iter.Valid(); iter.Next() {
+		var d CustomFieldDefinition
+		if err := json.Unmarshal(iter.Value(), &d); err != nil {
+			return nil, fmt.Errorf("json unmarshal: %w", err)
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// GetCustomField implements CustomFieldStore.
+func (p *PebbleStore) GetCustomField(key string) (*CustomFieldDefinition, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	val, closer, err := p.db.Get([]byte(customFieldDefPrefix + key))
+	if err == pebble.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pebble Get: %w", err)
+	}
+	defer closer.Close()
+	var d CustomFieldDefinition
+	if err := json.Unmarshal(val, &d); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+	return &d, nil
+}
+
+// SaveCustomField validates and stores def, keeping the original
+// created_at when the field already exists.
+func (p *PebbleStore) SaveCustomField(def CustomFieldDefinition) error {
+	if p == nil || p.db == nil {
+		return fmt.Errorf("pebble store not initialized")
+	}
+	if err := def.Normalize(); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	existing, err := p.GetCustomField(def.Key)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		def.CreatedAt = existing.CreatedAt
+	} else if def.CreatedAt.IsZero() {
+		def.CreatedAt = now
+	}
+	def.UpdatedAt = now
+	data, err := json.Marshal(def)
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	if err := p.db.Set([]byte(customFieldDefPrefix+def.Key), data, pebble.Sync); err != nil {
+		return fmt.Errorf("pebble Set: %w", err)
+	}
+	return nil
+}
+
+// DeleteCustomField implements CustomFieldStore. Values are found by
+// scanning every book's entries; deleting a field is rare enough not to
+// warrant a by-field index.
+func (p *PebbleStore) DeleteCustomField(key string) (int, error) {
+	if p == nil || p.db == nil {
+		return 0, fmt.Errorf("pebble store not initialized")
+	}
+	lower := []byte(customFieldValPrefix)
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: prefixEnd(lower)})
+	if err != nil {
+		return 0, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	removed := 0
+	suffix := ":" + key
+	for iter.First(); iter.Valid(); iter.Next() {
+		k := string(iter.Key())
+		rest := strings.TrimPrefix(k, customFieldValPrefix)
+		if !strings.HasSuffix(rest, suffix) || strings.Count(rest, ":") != 1 {
+			continue
+		}
+		if err := batch.Delete([]byte(k), nil); err != nil {
+			iter.Close()
+			return 0, fmt.Errorf("pebble Delete: %w", err)
+		}
+		removed++
+	}
+	if err := iter.Close(); err != nil {
+		return 0, fmt.Errorf("pebble iter: %w", err)
+	}
+	if err := batch.Delete([]byte(customFieldDefPrefix+key), nil); err != nil {
+		return 0, fmt.Errorf("pebble Delete: %w", err)
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return 0, fmt.Errorf("pebble Commit: %w", err)
+	}
+	return removed, nil
+}
+
+// GetBookCustomFields implements CustomFieldStore.
+func (p *PebbleStore) GetBookCustomFields(bookID string) (map[string]string, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	lower := []byte(customFieldValPrefix + bookID + ":")
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: prefixEnd(lower)})
+	if err != nil {
+		return nil, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	defer iter.Close()
+	out := map[string]string{}
+	for iter.First(); iter.Valid(); iter.Next() {
+		out[strings.TrimPrefix(string(iter.Key()), string(lower))] = string(iter.Value())
+	}
+	return out, nil
+}
+
+// SetBookCustomFields implements CustomFieldStore.
+func (p *PebbleStore) SetBookCustomFields(bookID string, values map[string]string) error {
+	if p == nil || p.db == nil {
+		return fmt.Errorf("pebble store not initialized")
+	}
+	if bookID == "" {
+		return fmt.Errorf("book id is required")
+	}
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	for key, v := range values {
+		var err error
+		if v == "" {
+			err = batch.Delete(customFieldValKey(bookID, key), nil)
+		} else {
+			err = batch.Set(customFieldValKey(bookID, key), []byte(v), nil)
+		}
+		if err != nil {
+			return fmt.Errorf("pebble batch: %w", err)
+		}
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("pebble Commit: %w", err)
+	}
+	return nil
+}
+
+// GetAllBookCustomFields implements CustomFieldStore.
+func (p *PebbleStore) GetAllBookCustomFields() (map[string]map[string]string, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	lower := []byte(customFieldValPrefix)
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: prefixEnd(lower)})
+	if err != nil {
+		return nil, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	defer iter.Close()
+	out := map[string]map[string]string{}
+	for iter.First(); iter.Valid(); iter.Next() {
+		bookID, key, ok := strings.Cut(strings.TrimPrefix(string(iter.Key()), customFieldValPrefix), ":")
+		if !ok {
+			continue
+		}
+		if out[bookID] == nil {
+			out[bookID] = map[string]string{}
+		}
+		out[bookID][key] = string(iter.Value())
+	}
+	return out, nil
+}
@@ -0,0 +1,131 @@
+// file: internal/database/custom_fields_test.go
+// version: 1.0.0
+// guid: 61719a61-542e-4976-8dd0-13681a7f7856
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldDefinition_Normalize(t *testing.T) {
+	d := CustomFieldDefinition{Key: "drm_free", Type: "Boolean"}
+	require.NoError(t, d.Normalize())
+	assert.Equal(t, "drm_free", d.Label)
+	assert.Equal(t, CustomFieldBoolean, d.Type)
+
+	for name, bad := range map[string]CustomFieldDefinition{
+		"bad key":            {Key: "Source"},
+		"bad type":           {Key: "source", Type: "blob"},
+		"select w/o options": {Key: "source", Type: CustomFieldSelect, Options: []string{" "}},
+		"options on text":    {Key: "source", Options: []string{"a"}},
+		"bad pattern":        {Key: "source", Pattern: "("},
+		"min on text":        {Key: "source", Min: new(float64)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, bad.Normalize())
+		})
+	}
+}
+
+func TestCustomFieldDefinition_NormalizeValue(t *testing.T) {
+	lo, hi := 0.0, 100.0
+	cases := []struct {
+		def     CustomFieldDefinition
+		in, out string
+		wantErr bool
+	}{
+		{CustomFieldDefinition{Key: "n", Type: CustomFieldNumber}, "12.50", "12.5", false},
+		{CustomFieldDefinition{Key: "n", Type: CustomFieldNumber, Min: &lo, Max: &hi}, "101", "", true},
+		{CustomFieldDefinition{Key: "n", Type: CustomFieldNumber}, "lots", "", true},
+		{CustomFieldDefinition{Key: "b", Type: CustomFieldBoolean}, "Yes", "true", false},
+		{CustomFieldDefinition{Key: "b", Type: CustomFieldBoolean}, "maybe", "", true},
+		{CustomFieldDefinition{Key: "d", Type: CustomFieldDate}, "2024-03-01T10:00:00Z", "2024-03-01", false},
+		{CustomFieldDefinition{Key: "d", Type: CustomFieldDate}, "March", "", true},
+		{CustomFieldDefinition{Key: "s", Type: CustomFieldSelect, Options: []string{"Audible", "Libro.fm"}}, "audible", "Audible", false},
+		{CustomFieldDefinition{Key: "s", Type: CustomFieldSelect, Options: []string{"Audible"}}, "CD", "", true},
+		{CustomFieldDefinition{Key: "t", Type: CustomFieldText, Pattern: `^[A-Z]{3}$`}, "abc", "", true},
+		{CustomFieldDefinition{Key: "t", Type: CustomFieldText}, "  anything ", "anything", false},
+		{CustomFieldDefinition{Key: "n", Type: CustomFieldNumber}, " ", "", false},
+	}
+	for _, tc := range cases {
+		got, err := tc.def.NormalizeValue(tc.in)
+		if tc.wantErr {
+			assert.Error(t, err, "%s=%q", tc.def.Type, tc.in)
+			continue
+		}
+		require.NoError(t, err, "%s=%q", tc.def.Type, tc.in)
+		assert.Equal(t, tc.out, got)
+	}
+}
+
+func TestPebbleStore_CustomFields(t *testing.T) {
+	store, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	p := store.(*PebbleStore)
+
+	require.NoError(t, p.SaveCustomField(CustomFieldDefinition{Key: "source", Label: "Source"}))
+	require.NoError(t, p.SaveCustomField(CustomFieldDefinition{Key: "drm_free", Type: CustomFieldBoolean}))
+	require.Error(t, p.SaveCustomField(CustomFieldDefinition{Key: "Bad Key"}))
+
+	defs, err := p.ListCustomFields()
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	assert.Equal(t, "drm_free", defs[0].Key)
+	assert.Equal(t, "source", defs[1].Key)
+	created := defs[1].CreatedAt
+
+	require.NoError(t, p.SaveCustomField(CustomFieldDefinition{Key: "source", Label: "Where from"}))
+	def, err := p.GetCustomField("source")
+	require.NoError(t, err)
+	require.NotNil(t, def)
+	assert.Equal(t, "Where from", def.Label)
+	assert.Equal(t, created, def.CreatedAt)
+	missing, err := p.GetCustomField("nope")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, p.SetBookCustomFields("b1", map[string]string{"source": "Audible", "drm_free": "true"}))
+	require.NoError(t, p.SetBookCustomFields("b2", map[string]string{"source": "CD"}))
+	require.NoError(t, p.SetBookCustomFields("b1", map[string]string{"drm_free": ""}))
+
+	vals, err := p.GetBookCustomFields("b1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"source": "Audible"}, vals)
+
+	all, err := p.GetAllBookCustomFields()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"b1": {"source": "Audible"},
+		"b2": {"source": "CD"},
+	}, all)
+
+	removed, err := p.DeleteCustomField("source")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	all, err = p.GetAllBookCustomFields()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+	defs, err = p.ListCustomFields()
+	require.NoError(t, err)
+	assert.Len(t, defs, 1)
+}
+
+func TestPebbleStore_DeleteBookRemovesCustomFields(t *testing.T) {
+	store, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	p := store.(*PebbleStore)
+
+	book, err := p.CreateBook(&Book{Title: "Kept Values", FilePath: "/lib/kept.m4b", Format: "m4b"})
+	require.NoError(t, err)
+	require.NoError(t, p.SetBookCustomFields(book.ID, map[string]string{"source": "Audible"}))
+	require.NoError(t, p.DeleteBook(book.ID))
+
+	vals, err := p.GetBookCustomFields(book.ID)
+	require.NoError(t, err)
+	assert.Empty(t, vals)
+}
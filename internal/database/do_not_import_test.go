@@ -1,7 +1,7 @@
 // file: internal/database/do_not_import_test.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 // NOTE(fable5 T022): TestDoNotImport_SQLite removed (SQLite store deleted).
 
@@ -182,3 +182,27 @@ func testDoNotImportOperations(t *testing.T, store Store) {
 		t.Errorf("expected 0 blocked hashes after removing all, got %d", len(allBlocked))
 	}
 }
+
+func TestDoNotImport_RecordsAlgorithm(t *testing.T) {
+	store, err := NewPebbleStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.AddBlockedHashWithAlgorithm("aa11", "xxhash64", "bad rip"); err != nil {
+		t.Fatalf("AddBlockedHashWithAlgorithm failed: %v", err)
+	}
+	if err := store.AddBlockedHash("bb22", "legacy"); err != nil {
+		t.Fatalf("AddBlockedHash failed: %v", err)
+	}
+	for hash, want := range map[string]string{"aa11": "xxhash64", "bb22": ""} {
+		entry, err := store.GetBlockedHashByHash(hash)
+		if err != nil || entry == nil {
+			t.Fatalf("GetBlockedHashByHash(%s): %v, %v", hash, entry, err)
+		}
+		if entry.Algorithm != want {
+			t.Errorf("%s: algorithm = %q, want %q", hash, entry.Algorithm, want)
+		}
+	}
+}
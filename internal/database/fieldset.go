@@ -0,0 +1,186 @@
+// file: internal/database/fieldset.go
+// version: 1.0.0
+// guid: 37c9bd03-6dcd-4159-bbbd-a7d1e5524ba9
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FieldSet is a sparse fieldset: the JSON field names a list caller asked
+// for with ?fields=. A nil FieldSet selects every field.
+type FieldSet map[string]struct{}
+
+// ParseFieldSet parses a comma-separated field list against the JSON field
+// names of sample (a struct or pointer to one, such as Book). "id" is
+// always included. An empty raw string returns a nil (all fields) set.
+func ParseFieldSet(raw string, sample any) (FieldSet, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	known := jsonFieldIndex(reflect.TypeOf(sample))
+	fs := FieldSet{"id": {}}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		fs[name] = struct{}{}
+	}
+	return fs, nil
+}
+
+// Has reports whether name is selected.
+func (fs FieldSet) Has(name string) bool {
+	if fs == nil {
+		return true
+	}
+	_, ok := fs[name]
+	return ok
+}
+
+// HasAny reports whether any of names is selected.
+func (fs FieldSet) HasAny(names ...string) bool {
+	for _, n := range names {
+		if fs.Has(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the selected names sorted, or nil for the all-fields set.
+func (fs FieldSet) Names() []string {
+	if fs == nil {
+		return nil
+	}
+	out := make([]string, 0, len(fs))
+	for n := range fs {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Project returns the selected fields of v (a struct or pointer to one) as
+// a map keyed by JSON name. Only the selected fields are read, so the
+// response encoder never touches the rest. A nil FieldSet returns v as-is.
+func Project(v any, fs FieldSet) any {
+	if fs == nil {
+		return v
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	index := jsonFieldIndex(rv.Type())
+	out := make(map[string]any, len(fs))
+	for name := range fs {
+		path, ok := index[name]
+		if !ok {
+			continue
+		}
+		if f, ok := fieldByPath(rv, path); ok {
+			out[name] = f.Interface()
+		} else {
+			out[name] = nil
+		}
+	}
+	return out
+}
+
+// ProjectSlice applies Project to each element of items.
+func ProjectSlice[T any](items []T, fs FieldSet) any {
+	if fs == nil {
+		return items
+	}
+	out := make([]any, len(items))
+	for i := range items {
+		out[i] = Project(&items[i], fs)
+	}
+	return out
+}
+
+// fieldByPath walks an index path, stopping at nil embedded pointers.
+func fieldByPath(v reflect.Value, path []int) (reflect.Value, bool) {
+	for i, idx := range path {
+		if i > 0 {
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(idx)
+	}
+	return v, true
+}
+
+var jsonFieldIndexes sync.Map // reflect.Type -> map[string][]int
+
+// jsonFieldIndex maps each JSON field name of t to its field index path,
+// following embedded structs the way encoding/json does (outer fields
+// win). Results are cached per type.
+func jsonFieldIndex(t reflect.Type) map[string][]int {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return map[string][]int{}
+	}
+	if cached, ok := jsonFieldIndexes.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	index := map[string][]int{}
+	var walk func(t reflect.Type, prefix []int, depth int)
+	depths := map[string]int{}
+	walk = func(t reflect.Type, prefix []int, depth int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			path := append(append([]int(nil), prefix...), i)
+			if f.Anonymous && name == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, path, depth+1)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if d, seen := depths[name]; seen && d <= depth {
+				continue
+			}
+			depths[name] = depth
+			index[name] = path
+		}
+	}
+	walk(t, nil, 0)
+	jsonFieldIndexes.Store(t, index)
+	return index
+}
@@ -0,0 +1,66 @@
+// file: internal/database/fieldset_test.go
+// version: 1.0.0
+// guid: c0ec7718-fded-48a9-bc06-cc63d2d55e98
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsetDetail struct {
+	*Book
+	AuthorName *string `json:"author_name,omitempty"`
+	Title      string  `json:"title"` // shadows Book.Title
+}
+
+func TestParseFieldSet(t *testing.T) {
+	fs, err := ParseFieldSet("", Book{})
+	require.NoError(t, err)
+	assert.Nil(t, fs)
+	assert.True(t, fs.Has("anything"))
+
+	fs, err = ParseFieldSet(" title , file_size,,", Book{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file_size", "id", "title"}, fs.Names())
+	assert.True(t, fs.HasAny("narrator", "file_size"))
+	assert.False(t, fs.Has("narrator"))
+
+	_, err = ParseFieldSet("title,nope", Book{})
+	assert.ErrorContains(t, err, `"nope"`)
+
+	fs, err = ParseFieldSet("author_name", fieldsetDetail{})
+	require.NoError(t, err)
+	assert.True(t, fs.Has("author_name"))
+}
+
+func TestProject(t *testing.T) {
+	size := int64(42)
+	name := "Frank Herbert"
+	d := fieldsetDetail{
+		Book:       &Book{ID: "b1", Title: "inner", FileSize: &size, FilePath: "/x"},
+		AuthorName: &name,
+		Title:      "outer",
+	}
+	fs, err := ParseFieldSet("title,file_size,author_name", d)
+	require.NoError(t, err)
+
+	got := Project(&d, fs).(map[string]any)
+	assert.Equal(t, "b1", got["id"])
+	assert.Equal(t, "outer", got["title"], "outer fields shadow embedded ones")
+	assert.Equal(t, &size, got["file_size"])
+	assert.Equal(t, &name, got["author_name"])
+	assert.Len(t, got, 4)
+
+	// A nil embedded book projects its fields as null.
+	got = Project(fieldsetDetail{}, fs).(map[string]any)
+	assert.Nil(t, got["id"])
+
+	items := []fieldsetDetail{d}
+	assert.Equal(t, items, ProjectSlice(items, nil))
+	assert.Len(t, ProjectSlice(items, fs), 1)
+}
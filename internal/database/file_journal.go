@@ -0,0 +1,201 @@
+// file: internal/database/file_journal.go
+// version: 1.0.0
+// guid: 7d3b9e52-a4c1-4f87-b6e0-2c5f8a1d9e36
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// File event journal: an append-only log of file-level changes to books
+// (created, moved, deleted, re-tagged) with increasing sequence numbers,
+// so backup tools and media servers can sync incrementally from the last
+// sequence they saw. PebbleStore appends to it from CreateBook, UpdateBook
+// and DeleteBook. Entries live in the RawKV space under
+// "file_journal:<bucket>:<seq>", where a bucket holds fileJournalBucketSize
+// consecutive sequences; the oldest buckets are dropped once more than
+// fileJournalMaxBuckets exist.
+
+// File journal event types.
+const (
+	FileEventCreated  = "created"
+	FileEventMoved    = "moved"
+	FileEventDeleted  = "deleted"
+	FileEventRetagged = "retagged"
+)
+
+const (
+	fileJournalPrefix   = "file_journal:"
+	fileJournalSeqKey   = "file_journal_seq"
+	fileJournalFloorKey = "file_journal_floor"
+)
+
+// Journal retention: the newest fileJournalMaxBuckets buckets of
+// fileJournalBucketSize entries are kept. Variables so tests can shrink
+// them.
+var (
+	fileJournalBucketSize int64 = 1000
+	fileJournalMaxBuckets int64 = 100
+)
+
+// fileJournalMu serializes sequence allocation within the process.
+var fileJournalMu sync.Mutex
+
+// FileJournalEntry is one file-level change. Path is the book's file (or
+// folder, for multi-file books) after the change; OldPath is set on moves.
+type FileJournalEntry struct {
+	Seq     int64     `json:"seq"`
+	Type    string    `json:"type"`
+	BookID  string    `json:"book_id"`
+	Path    string    `json:"path"`
+	OldPath string    `json:"old_path,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// FileJournalPage is a window of the journal returned by ReadFileJournal.
+type FileJournalPage struct {
+	Entries []FileJournalEntry `json:"entries"`
+	// LatestSeq is the newest sequence number written so far.
+	LatestSeq int64 `json:"latest_seq"`
+	// NextSince is the value to pass as since on the next call.
+	NextSince int64 `json:"next_since"`
+	HasMore   bool  `json:"has_more"`
+	// Reset reports that entries after since were already pruned; the
+	// caller missed changes and should resync from scratch before
+	// continuing from NextSince.
+	Reset bool `json:"reset"`
+}
+
+func fileJournalKey(seq int64) string {
+	return fmt.Sprintf("%s%012d:%020d", fileJournalPrefix, seq/fileJournalBucketSize, seq)
+}
+
+func fileJournalBucketPrefix(bucket int64) string {
+	return fmt.Sprintf("%s%012d:", fileJournalPrefix, bucket)
+}
+
+func readJournalCounter(store RawKVStore, key string) (int64, error) {
+	blob, err := store.GetRaw(key)
+	if err != nil || blob == nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(blob), 10, 64)
+}
+
+// AppendFileJournal assigns e the next sequence number and a timestamp
+// (when unset) and stores it.
+func AppendFileJournal(store RawKVStore, e FileJournalEntry) (int64, error) {
+	fileJournalMu.Lock()
+	defer fileJournalMu.Unlock()
+	latest, err := readJournalCounter(store, fileJournalSeqKey)
+	if err != nil {
+		return 0, fmt.Errorf("file journal seq: %w", err)
+	}
+	e.Seq = latest + 1
+	if e.At.IsZero() {
+		e.At = time.Now().UTC()
+	}
+	// The counter moves first so a failed entry write leaves a gap
+	// rather than a reused sequence number.
+	if err := store.SetRaw(fileJournalSeqKey, []byte(strconv.FormatInt(e.Seq, 10))); err != nil {
+		return 0, fmt.Errorf("file journal seq: %w", err)
+	}
+	blob, err := json.Marshal(e)
+	if err != nil {
+		return 0, fmt.Errorf("file journal marshal: %w", err)
+	}
+	if err := store.SetRaw(fileJournalKey(e.Seq), blob); err != nil {
+		return 0, fmt.Errorf("file journal append: %w", err)
+	}
+	if e.Seq%fileJournalBucketSize == 0 {
+		if err := pruneFileJournal(store, e.Seq/fileJournalBucketSize); err != nil {
+			return e.Seq, err
+		}
+	}
+	return e.Seq, nil
+}
+
+// pruneFileJournal drops the bucket that fell out of the retention window
+// when bucket filled up, and raises the floor past it.
+func pruneFileJournal(store RawKVStore, bucket int64) error {
+	old := bucket - fileJournalMaxBuckets
+	if old < 0 {
+		return nil
+	}
+	pairs, err := store.ScanPrefix(fileJournalBucketPrefix(old))
+	if err != nil {
+		return fmt.Errorf("file journal prune: %w", err)
+	}
+	for _, kv := range pairs {
+		if err := store.DeleteRaw(kv.Key); err != nil {
+			return fmt.Errorf("file journal prune: %w", err)
+		}
+	}
+	floor := (old+1)*fileJournalBucketSize - 1
+	return store.SetRaw(fileJournalFloorKey, []byte(strconv.FormatInt(floor, 10)))
+}
+
+// ReadFileJournal returns up to limit entries with a sequence number
+// greater than since, oldest first.
+func ReadFileJournal(store RawKVStore, since int64, limit int) (*FileJournalPage, error) {
+	latest, err := readJournalCounter(store, fileJournalSeqKey)
+	if err != nil {
+		return nil, fmt.Errorf("file journal seq: %w", err)
+	}
+	floor, err := readJournalCounter(store, fileJournalFloorKey)
+	if err != nil {
+		return nil, fmt.Errorf("file journal floor: %w", err)
+	}
+	page := &FileJournalPage{Entries: []FileJournalEntry{}, LatestSeq: latest, NextSince: since}
+	if since < floor {
+		page.Reset = true
+		since = floor
+		page.NextSince = floor
+	}
+	for bucket := (since + 1) / fileJournalBucketSize; bucket <= latest/fileJournalBucketSize; bucket++ {
+		pairs, err := store.ScanPrefix(fileJournalBucketPrefix(bucket))
+		if err != nil {
+			return nil, fmt.Errorf("file journal read: %w", err)
+		}
+		for _, kv := range pairs {
+			var e FileJournalEntry
+			if err := json.Unmarshal(kv.Value, &e); err != nil || e.Seq <= since {
+				continue
+			}
+			if len(page.Entries) == limit {
+				page.HasMore = true
+				return page, nil
+			}
+			page.Entries = append(page.Entries, e)
+			page.NextSince = e.Seq
+		}
+	}
+	return page, nil
+}
+
+// journalBookUpdate records the file-level changes between two snapshots
+// of a book: a moved event when the path changed and a retagged event
+// when tags were written back to the file since the previous snapshot.
+func journalBookUpdate(store RawKVStore, oldBook, newBook *Book) error {
+	if oldBook.FilePath != newBook.FilePath && newBook.FilePath != "" {
+		if _, err := AppendFileJournal(store, FileJournalEntry{
+			Type: FileEventMoved, BookID: newBook.ID, Path: newBook.FilePath, OldPath: oldBook.FilePath,
+		}); err != nil {
+			return err
+		}
+	}
+	if w := newBook.LastWrittenAt; w != nil && (oldBook.LastWrittenAt == nil || w.After(*oldBook.LastWrittenAt)) {
+		if _, err := AppendFileJournal(store, FileJournalEntry{
+			Type: FileEventRetagged, BookID: newBook.ID, Path: newBook.FilePath,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// file: internal/database/file_journal_test.go
+// version: 1.0.0
+// guid: e1a6c3f8-5b2d-4d94-8f07-3a9c7e2b6d15
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileJournal_BookLifecycle_Pebble(t *testing.T) {
+	s := setupTestPebbleStore(t)
+
+	book, err := s.CreateBook(&Book{Title: "Journaled", FilePath: "/in/journaled.m4b"})
+	require.NoError(t, err)
+	moved := *book
+	moved.FilePath = "/library/Author/Journaled.m4b"
+	_, err = s.UpdateBook(book.ID, &moved)
+	require.NoError(t, err)
+	_, err = s.UpdateBook(book.ID, &moved)
+	require.NoError(t, err, "an update that touches no file records nothing")
+	require.NoError(t, s.SetLastWrittenAt(book.ID, time.Now()))
+	require.NoError(t, s.DeleteBook(book.ID))
+
+	page, err := ReadFileJournal(s, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 4)
+	types := make([]string, 0, 4)
+	for i, e := range page.Entries {
+		types = append(types, e.Type)
+		assert.Equal(t, int64(i+1), e.Seq)
+		assert.Equal(t, book.ID, e.BookID)
+	}
+	assert.Equal(t, []string{FileEventCreated, FileEventMoved, FileEventRetagged, FileEventDeleted}, types)
+	assert.Equal(t, "/in/journaled.m4b", page.Entries[1].OldPath)
+	assert.Equal(t, "/library/Author/Journaled.m4b", page.Entries[3].Path)
+	assert.Equal(t, int64(4), page.LatestSeq)
+	assert.Equal(t, int64(4), page.NextSince)
+	assert.False(t, page.HasMore)
+
+	page, err = ReadFileJournal(s, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 2)
+	assert.Equal(t, int64(2), page.Entries[0].Seq)
+	assert.Equal(t, int64(3), page.NextSince)
+	assert.True(t, page.HasMore)
+
+	page, err = ReadFileJournal(s, 4, 10)
+	require.NoError(t, err)
+	assert.Empty(t, page.Entries)
+	assert.Equal(t, int64(4), page.NextSince)
+}
+
+func TestFileJournal_Pruning(t *testing.T) {
+	prevSize, prevBuckets := fileJournalBucketSize, fileJournalMaxBuckets
+	fileJournalBucketSize, fileJournalMaxBuckets = 10, 3
+	t.Cleanup(func() { fileJournalBucketSize, fileJournalMaxBuckets = prevSize, prevBuckets })
+
+	s := setupTestPebbleStore(t)
+	// Seq 30 opens bucket 3, which pushes bucket 0 (seqs 1-9) out.
+	total := fileJournalMaxBuckets * fileJournalBucketSize
+	for i := int64(0); i < total; i++ {
+		_, err := AppendFileJournal(s, FileJournalEntry{Type: FileEventCreated, BookID: "b", Path: "/p"})
+		require.NoError(t, err)
+	}
+
+	page, err := ReadFileJournal(s, 5, 5)
+	require.NoError(t, err)
+	assert.True(t, page.Reset, "the first bucket was pruned")
+	require.Len(t, page.Entries, 5)
+	assert.Equal(t, int64(10), page.Entries[0].Seq)
+	assert.Equal(t, total, page.LatestSeq)
+
+	page, err = ReadFileJournal(s, 9, 50)
+	require.NoError(t, err)
+	assert.False(t, page.Reset)
+	assert.Len(t, page.Entries, int(total-9))
+	assert.Equal(t, int64(10), page.Entries[0].Seq)
+}
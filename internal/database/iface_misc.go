@@ -1,7 +1,7 @@
 // file: internal/database/iface_misc.go
-// version: 1.15.0
+// version: 1.16.0
 // guid: 473781a7-1a31-4914-b7c7-8efc91f9f7e6
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package database
 
@@ -255,6 +255,9 @@ type MetadataStore interface {
 type HashBlocklistStore interface {
 	IsHashBlocked(hash string) (bool, error)
 	AddBlockedHash(hash, reason string) error
+	// AddBlockedHashWithAlgorithm is AddBlockedHash recording the
+	// algorithm that produced hash.
+	AddBlockedHashWithAlgorithm(hash, algorithm, reason string) error
 	RemoveBlockedHash(hash string) error
 	GetAllBlockedHashes() ([]DoNotImport, error)
 	GetBlockedHashByHash(hash string) (*DoNotImport, error)
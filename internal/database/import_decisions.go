@@ -0,0 +1,129 @@
+// file: internal/database/import_decisions.go
+// version: 1.2.0
+// guid: 07cf6e94-dcc3-4f08-843b-26cdc5a79a7c
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+)
+
+// Import decisions recorded by the scanner, one per file.
+const (
+	ImportDecisionImported             = "imported"
+	ImportDecisionUnchanged            = "unchanged"
+	ImportDecisionUnsupportedExtension = "unsupported_extension"
+	ImportDecisionExcluded             = "excluded"
+	ImportDecisionBlockedHash          = "blocked_hash"
+	ImportDecisionBlockedSignature     = "blocked_signature"
+	ImportDecisionDuplicate            = "duplicate"
+	ImportDecisionSuspicious           = "suspicious"
+	ImportDecisionQuotaExceeded        = "quota_exceeded"
+	ImportDecisionError                = "error"
+	// ImportDecisionQuarantined is recorded after the scan, when an
+	// imported book is quarantined for a size anomaly.
+	ImportDecisionQuarantined = "quarantined"
+)
+
+// ImportDecision is the scanner's most recent verdict on a single file:
+// whether it was imported and, if not, why.
+type ImportDecision struct {
+	Path     string `json:"path"`
+	Decision string `json:"decision"`
+	// Detail is a human-readable explanation (the matched exclude
+	// pattern, the blocked hash, the error message, ...).
+	Detail    string    `json:"detail,omitempty"`
+	BookID    string    `json:"book_id,omitempty"`
+	DecidedAt time.Time `json:"decided_at"`
+}
+
+// ImportDecisionStore is implemented by stores that keep the import
+// decision log. Only the latest decision per path is kept.
+type ImportDecisionStore interface {
+	RecordImportDecision(d ImportDecision) error
+	// GetImportDecisions returns the decision for path itself plus, when
+	// path is a directory, those for files below it, ordered by path and
+	// capped at limit (<= 0 means no cap).
+	GetImportDecisions(path string, limit int) ([]ImportDecision, error)
+}
+
+const importDecisionPrefix = "import_decision:"
+
+// RecordImportDecision stores d, replacing any earlier decision for the
+// same path. Writes are not synced: the log is diagnostic and a scan
+// records one entry per file.
+func (p *PebbleStore) RecordImportDecision(d ImportDecision) error {
+	if p == nil || p.db == nil {
+		return fmt.Errorf("pebble store not initialized")
+	}
+	if d.Path == "" {
+		return fmt.Errorf("import decision path is required")
+	}
+	if d.DecidedAt.IsZero() {
+		d.DecidedAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	if err := p.db.Set([]byte(importDecisionPrefix+d.Path), data, pebble.NoSync); err != nil {
+		return fmt.Errorf("pebble Set: %w", err)
+	}
+	return nil
+}
+
+// GetImportDecisions implements ImportDecisionStore.
+func (p *PebbleStore) GetImportDecisions(path string, limit int) ([]ImportDecision, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	path = strings.TrimRight(path, "/")
+	if path == "" {
+		path = "/"
+	}
+	var out []ImportDecision
+	decode := func(val []byte) error {
+		var d ImportDecision
+		if err := json.Unmarshal(val, &d); err != nil {
+			return fmt.Errorf("json unmarshal: %w", err)
+		}
+		out = append(out, d)
+		return nil
+	}
+
+	if path != "/" {
+		val, closer, err := p.db.Get([]byte(importDecisionPrefix + path))
+		switch {
+		case err == nil:
+			derr := decode(val)
+			closer.Close()
+			if derr != nil {
+				return nil, derr
+			}
+		case err != pebble.ErrNotFound:
+			return nil, fmt.Errorf("pebble Get: %w", err)
+		}
+	}
+
+	lower := []byte(importDecisionPrefix + strings.TrimSuffix(path, "/") + "/")
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: prefixEnd(lower)})
+	if err != nil {
+		return nil, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	defer iter.Close()
+	for iter.First(); iter.Valid(); iter.Next() {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+		if err := decode(iter.Value()); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,50 @@
+// file: internal/database/import_decisions_test.go
+// version: 1.0.0
+// guid: d105f407-cb22-4740-bc6e-e88d5e2df77c
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPebbleStore_ImportDecisions(t *testing.T) {
+	store, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	p := store.(*PebbleStore)
+
+	for _, d := range []ImportDecision{
+		{Path: "/lib/a/book.m4b", Decision: ImportDecisionImported, BookID: "b1"},
+		{Path: "/lib/a/cover.jpg", Decision: ImportDecisionUnsupportedExtension},
+		{Path: "/lib/ab/other.mp3", Decision: ImportDecisionExcluded, Detail: "*/ab/*"},
+		{Path: "/lib/a/book.m4b", Decision: ImportDecisionUnchanged, BookID: "b1"},
+	} {
+		require.NoError(t, p.RecordImportDecision(d))
+	}
+	require.Error(t, p.RecordImportDecision(ImportDecision{Decision: ImportDecisionError}))
+
+	got, err := p.GetImportDecisions("/lib/a/book.m4b", 0)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, ImportDecisionUnchanged, got[0].Decision, "latest decision wins")
+	assert.False(t, got[0].DecidedAt.IsZero())
+
+	// A directory lists the files below it, not siblings sharing the prefix.
+	got, err = p.GetImportDecisions("/lib/a/", 0)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "/lib/a/book.m4b", got[0].Path)
+	assert.Equal(t, "/lib/a/cover.jpg", got[1].Path)
+
+	got, err = p.GetImportDecisions("/lib", 1)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	got, err = p.GetImportDecisions("/missing", 0)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
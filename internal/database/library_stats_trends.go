@@ -0,0 +1,150 @@
+// file: internal/database/library_stats_trends.go
+// version: 1.0.0
+// guid: cd709c4e-0872-4355-999a-3b4889b489d7
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+)
+
+// addBookDistributions buckets b into the size, duration and quality
+// distributions, allocating the maps on first use.
+func (s *LibraryStats) addBookDistributions(b *Book) {
+	if s.SizeDistribution == nil {
+		s.SizeDistribution = make(map[string]int)
+		s.DurationDistribution = make(map[string]int)
+		s.QualityDistribution = make(map[string]int)
+	}
+	var size int64
+	if b.FileSize != nil {
+		size = *b.FileSize
+	}
+	var duration int
+	if b.Duration != nil {
+		duration = *b.Duration
+	}
+	s.SizeDistribution[SizeBucket(size)]++
+	s.DurationDistribution[DurationBucket(duration)]++
+	s.QualityDistribution[QualityBucket(b.QualityScore)]++
+}
+
+// SizeBucket labels a book size for the dashboard size distribution.
+func SizeBucket(bytes int64) string {
+	const mb = 1 << 20
+	switch {
+	case bytes <= 0:
+		return "unknown"
+	case bytes < 100*mb:
+		return "<100MB"
+	case bytes < 500*mb:
+		return "100-500MB"
+	case bytes < 1024*mb:
+		return "500MB-1GB"
+	case bytes < 2048*mb:
+		return "1-2GB"
+	default:
+		return "2GB+"
+	}
+}
+
+// DurationBucket labels a duration in seconds for the dashboard duration
+// distribution.
+func DurationBucket(seconds int) string {
+	const hour = 3600
+	switch {
+	case seconds <= 0:
+		return "unknown"
+	case seconds < hour:
+		return "<1h"
+	case seconds < 5*hour:
+		return "1-5h"
+	case seconds < 10*hour:
+		return "5-10h"
+	case seconds < 20*hour:
+		return "10-20h"
+	default:
+		return "20h+"
+	}
+}
+
+// QualityBucket labels a mediainfo quality score (0-100) for the dashboard
+// quality distribution. Lossless codecs score 90 and up.
+func QualityBucket(score *int) string {
+	switch {
+	case score == nil:
+		return "unknown"
+	case *score >= 90:
+		return "lossless"
+	case *score >= 60:
+		return "high"
+	case *score >= 35:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// LibraryStatsSnapshot is the daily record of headline library totals kept
+// so the dashboard can show trends.
+type LibraryStatsSnapshot struct {
+	Date           string `json:"date"` // YYYY-MM-DD
+	TotalBooks     int    `json:"total_books"`
+	TotalSize      int64  `json:"total_size"`
+	TotalDuration  int64  `json:"total_duration"`
+	OrganizedBooks int    `json:"organized_books"`
+}
+
+const statsDailyPrefix = "stats:library:daily:"
+
+// writeLibraryStatsSnapshot records s as the snapshot for the day it was
+// computed. Later recomputes the same day overwrite it, so each day keeps
+// its last totals.
+func (p *PebbleStore) writeLibraryStatsSnapshot(s *LibraryStats) {
+	computed := s.ComputedAt
+	if computed.IsZero() {
+		computed = time.Now()
+	}
+	snap := LibraryStatsSnapshot{
+		Date:           computed.Format(time.DateOnly),
+		TotalBooks:     s.TotalBooks,
+		TotalSize:      s.TotalSize,
+		TotalDuration:  s.TotalDuration,
+		OrganizedBooks: s.OrganizedBooks,
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return
+	}
+	if err := p.db.Set([]byte(statsDailyPrefix+snap.Date), data, pebble.NoSync); err != nil {
+		slog.Warn("pebble Set stats:library:daily", "error", err)
+	}
+}
+
+// GetLibraryStatsSnapshot returns the most recent daily snapshot taken on
+// or before asOf, or nil when none exists.
+func (p *PebbleStore) GetLibraryStatsSnapshot(asOf time.Time) (*LibraryStatsSnapshot, error) {
+	iter, err := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte(statsDailyPrefix),
+		// Keys are date-ordered; the upper bound is exclusive, so step
+		// one day past asOf.
+		UpperBound: []byte(statsDailyPrefix + asOf.AddDate(0, 0, 1).Format(time.DateOnly)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	if !iter.Last() {
+		return nil, nil
+	}
+	var snap LibraryStatsSnapshot
+	if err := json.Unmarshal(iter.Value(), &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
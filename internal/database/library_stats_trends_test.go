@@ -0,0 +1,65 @@
+// file: internal/database/library_stats_trends_test.go
+// version: 1.0.0
+// guid: d9c560c1-9142-4342-8c20-42f4b0ceb835
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLibraryStatsBuckets(t *testing.T) {
+	if got := SizeBucket(300 << 20); got != "100-500MB" {
+		t.Errorf("SizeBucket(300MB) = %q", got)
+	}
+	if got := DurationBucket(12 * 3600); got != "10-20h" {
+		t.Errorf("DurationBucket(12h) = %q", got)
+	}
+	score := 95
+	if got := QualityBucket(&score); got != "lossless" {
+		t.Errorf("QualityBucket(95) = %q", got)
+	}
+	if got := QualityBucket(nil); got != "unknown" {
+		t.Errorf("QualityBucket(nil) = %q", got)
+	}
+
+	var s LibraryStats
+	size, dur := int64(50<<20), 1800
+	s.addBookDistributions(&Book{FileSize: &size, Duration: &dur})
+	s.addBookDistributions(&Book{})
+	if s.SizeDistribution["<100MB"] != 1 || s.SizeDistribution["unknown"] != 1 {
+		t.Errorf("SizeDistribution = %v", s.SizeDistribution)
+	}
+	if s.DurationDistribution["<1h"] != 1 || s.QualityDistribution["unknown"] != 2 {
+		t.Errorf("DurationDistribution = %v, QualityDistribution = %v", s.DurationDistribution, s.QualityDistribution)
+	}
+}
+
+func TestGetLibraryStatsSnapshot(t *testing.T) {
+	store, err := NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("NewPebbleStore: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	old := now.AddDate(0, 0, -40)
+	store.writeLibraryStatsSnapshot(&LibraryStats{TotalBooks: 10, ComputedAt: old})
+	store.writeLibraryStatsSnapshot(&LibraryStats{TotalBooks: 15, ComputedAt: now.AddDate(0, 0, -5)})
+
+	snap, err := store.GetLibraryStatsSnapshot(now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("GetLibraryStatsSnapshot: %v", err)
+	}
+	if snap == nil || snap.TotalBooks != 10 || snap.Date != old.Format(time.DateOnly) {
+		t.Errorf("got %+v, want the 40-day-old snapshot", snap)
+	}
+
+	snap, err = store.GetLibraryStatsSnapshot(now.AddDate(0, 0, -60))
+	if err != nil || snap != nil {
+		t.Errorf("expected no snapshot before history starts, got %+v, %v", snap, err)
+	}
+}
@@ -0,0 +1,107 @@
+// file: internal/database/mediainfo_cache.go
+// version: 1.1.0
+// guid: 4c0054f5-d116-43d3-bf06-2127a3a7ac84
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/metrics"
+)
+
+// MediaInfoCache stores the result of tag parsing + mediainfo
+// extraction keyed by file content hash. A rescan of an unchanged
+// library still has to hash every file (that is how we know it is
+// unchanged), but with a hit it skips the tag parse and the
+// mediainfo build entirely.
+//
+// Keys live under "mediainfo_cache:" and use the same RawKV escape
+// hatch as MetadataFetchCache so no backend migration is needed.
+// Entries never expire: the key is the content hash, so a changed
+// file naturally misses. Callers may suffix the hash with a fingerprint
+// of whatever else shaped the parse (the scanner adds the tag field
+// mappings), so the "hash" arguments below are opaque keys.
+
+// MediaInfoCachePrefix is the RawKV namespace for mediainfo cache rows.
+const MediaInfoCachePrefix = "mediainfo_cache:"
+
+// CachedMediaInfoEntry is the serialized shape of a cache row.
+// Metadata and MediaInfo are left as RawMessage so this package
+// doesn't need to import internal/metadata or internal/mediainfo.
+type CachedMediaInfoEntry struct {
+	Hash      string          `json:"hash"`
+	Path      string          `json:"path"`
+	Size      int64           `json:"size"`
+	Metadata  json.RawMessage `json:"metadata"`
+	MediaInfo json.RawMessage `json:"mediainfo,omitempty"`
+	CachedAt  time.Time       `json:"cached_at"`
+}
+
+func mediaInfoCacheKey(hash string) string {
+	return MediaInfoCachePrefix + hash
+}
+
+// GetCachedMediaInfo looks up the entry for a content hash. A miss
+// returns (nil, nil). Corrupt rows are deleted and reported as a miss.
+func GetCachedMediaInfo(store Store, hash string) (*CachedMediaInfoEntry, error) {
+	if store == nil || hash == "" {
+		return nil, nil
+	}
+	start := time.Now()
+	defer func() { metrics.ObserveCacheGetDuration("mediainfo", time.Since(start)) }()
+
+	blob, err := store.GetRaw(mediaInfoCacheKey(hash))
+	if err != nil {
+		return nil, fmt.Errorf("mediainfo cache get: %w", err)
+	}
+	if blob == nil {
+		metrics.RecordCacheMiss("mediainfo", "not_found")
+		return nil, nil
+	}
+	var entry CachedMediaInfoEntry
+	if err := json.Unmarshal(blob, &entry); err != nil {
+		if delErr := store.DeleteRaw(mediaInfoCacheKey(hash)); delErr != nil {
+			slog.Warn("failed to delete corrupt mediainfo cache entry", "hash", hash, "error", delErr)
+		}
+		metrics.RecordCacheMiss("mediainfo", "stale")
+		return nil, nil
+	}
+	metrics.RecordCacheHit("mediainfo")
+	return &entry, nil
+}
+
+// PutCachedMediaInfo writes a cache entry. Like the metadata fetch
+// cache, writes are best-effort: callers log failures and carry on.
+// path records where the content was parsed so callers can tell whether
+// path-derived fields (filename fallback, format) still apply.
+func PutCachedMediaInfo(store Store, hash, path string, size int64, meta, mi json.RawMessage) error {
+	if store == nil || hash == "" {
+		return nil
+	}
+	blob, err := json.Marshal(CachedMediaInfoEntry{
+		Hash:      hash,
+		Path:      path,
+		Size:      size,
+		Metadata:  meta,
+		MediaInfo: mi,
+		CachedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("mediainfo cache marshal: %w", err)
+	}
+	if err := store.SetRaw(mediaInfoCacheKey(hash), blob); err != nil {
+		return err
+	}
+	metrics.RecordCacheSet("mediainfo")
+	return nil
+}
+
+// CountCachedMediaInfo returns the number of mediainfo cache rows.
+func CountCachedMediaInfo(store Store) (int64, error) {
+	return store.CountPrefix(MediaInfoCachePrefix)
+}
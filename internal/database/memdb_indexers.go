@@ -1,5 +1,5 @@
 // file: internal/database/memdb_indexers.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: a1b2c3d4-mema-aaaa-aaaa-000000000001
 
 package database
@@ -322,13 +322,7 @@ func (titleSortIndex) FromObject(obj interface{}) (bool, []byte, error) {
 	if !ok {
 		return false, nil, fmt.Errorf("titleSortIndex: expected *Book, got %T", obj)
 	}
-	key := strings.ToLower(strings.TrimSpace(b.Title))
-	if key == "" && b.OriginalFilename != nil {
-		key = strings.ToLower(strings.TrimSpace(*b.OriginalFilename))
-	}
-	if key == "" {
-		key = "~" // sort to end
-	}
+	key := bookTitleSortKey(b)
 	// memdb convention: null-terminate for prefix-iteration correctness
 	return true, append([]byte(key), 0), nil
 }
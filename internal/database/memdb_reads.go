@@ -1,5 +1,5 @@
 // file: internal/database/memdb_reads.go
-// version: 1.3.0
+// version: 1.5.0
 // guid: a1b2c3d4-mema-aaaa-aaaa-000000000006
 
 package database
@@ -383,19 +383,10 @@ func (m *MemStore) GetBooksBySeriesID(seriesID int, limit, offset int) ([]Book,
 			keys[i] = strings.ToLower(all[i].Title)
 		}
 		sort.SliceStable(all, func(i, j int) bool {
-			si, sj := all[i].SeriesSequence, all[j].SeriesSequence
-			switch {
-			case si == nil && sj == nil:
-				return keys[i] < keys[j]
-			case si == nil:
-				return false
-			case sj == nil:
-				return true
-			case *si != *sj:
-				return *si < *sj
-			default:
-				return keys[i] < keys[j]
+			if c := CompareSeriesSeq(all[i].SeriesSequence, all[j].SeriesSequence); c != 0 {
+				return c < 0
 			}
+			return keys[i] < keys[j]
 		})
 	}
 	return paginate(all, limit, offset), nil
@@ -687,6 +678,7 @@ func (m *MemStore) ComputeLibraryStats(rootDir string, importPaths []ImportPath)
 			codec = *b.Codec
 		}
 		stats.FormatDistribution[codec]++
+		stats.addBookDistributions(b)
 
 		isPrimary := b.IsPrimaryVersion == nil || *b.IsPrimaryVersion
 		if !isPrimary {
@@ -1,5 +1,5 @@
 // file: internal/database/memdb_reads_test.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: a1b2c3d4-mema-aaaa-aaaa-000000000007
 
 package database
@@ -144,9 +144,9 @@ func TestMemStore_GetBooksBySeriesID(t *testing.T) {
 		t.Fatalf("NewMemStore: %v", err)
 	}
 	books := []Book{
-		{ID: "b1", Title: "Book One", SeriesID: ptrInt_mem(10), SeriesSequence: ptrInt_mem(1), IsPrimaryVersion: ptrBool_mem(true)},
-		{ID: "b2", Title: "Book Three", SeriesID: ptrInt_mem(10), SeriesSequence: ptrInt_mem(3), IsPrimaryVersion: ptrBool_mem(true)},
-		{ID: "b3", Title: "Book Two", SeriesID: ptrInt_mem(10), SeriesSequence: ptrInt_mem(2), IsPrimaryVersion: ptrBool_mem(true)},
+		{ID: "b1", Title: "Book One", SeriesID: ptrInt_mem(10), SeriesSequence: NewSeriesSeq(1), IsPrimaryVersion: ptrBool_mem(true)},
+		{ID: "b2", Title: "Book Three", SeriesID: ptrInt_mem(10), SeriesSequence: NewSeriesSeq(3), IsPrimaryVersion: ptrBool_mem(true)},
+		{ID: "b3", Title: "Book Two", SeriesID: ptrInt_mem(10), SeriesSequence: NewSeriesSeq(2), IsPrimaryVersion: ptrBool_mem(true)},
 		{ID: "b4", Title: "Other Series", SeriesID: ptrInt_mem(20), IsPrimaryVersion: ptrBool_mem(true)},
 	}
 	seedMemStore(t, m, books, nil, nil, nil)
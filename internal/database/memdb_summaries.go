@@ -1,5 +1,5 @@
 // file: internal/database/memdb_summaries.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: a1b2c3d4-mema-aaaa-aaaa-000000000008
 
 package database
@@ -57,6 +57,44 @@ type BookSummaryFilter struct {
 	Predicate func(*Book) bool
 }
 
+// matchesBook reports whether b passes every predicate of f, ignoring
+// sort order and pagination. A nil IsPrimaryVersion on the row counts as
+// primary. Stores without the memdb walker apply the filter with it.
+func (f BookSummaryFilter) matchesBook(b *Book) bool {
+	if f.IsPrimaryVersion != nil {
+		eff := b.IsPrimaryVersion == nil || *b.IsPrimaryVersion
+		if eff != *f.IsPrimaryVersion {
+			return false
+		}
+	}
+	isDel := b.MarkedForDeletion != nil && *b.MarkedForDeletion
+	if f.MarkedForDeletion == nil {
+		if isDel {
+			return false
+		}
+	} else if isDel != *f.MarkedForDeletion {
+		return false
+	}
+	if f.LibraryState != "" && (b.LibraryState == nil || *b.LibraryState != f.LibraryState) {
+		return false
+	}
+	if f.ReviewStatus != "" {
+		rs := ""
+		if b.MetadataReviewStatus != nil {
+			rs = *b.MetadataReviewStatus
+		}
+		if !strings.EqualFold(rs, f.ReviewStatus) {
+			return false
+		}
+	}
+	if f.RestrictToIDs != nil {
+		if _, ok := f.RestrictToIDs[b.ID]; !ok {
+			return false
+		}
+	}
+	return f.Predicate == nil || f.Predicate(b)
+}
+
 // GetBookSummaries returns a paginated slice of BookSummary records,
 // projecting from Book in-place during iteration. Key differences vs.
 // "fetch all Books then project":
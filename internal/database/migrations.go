@@ -1,7 +1,7 @@
 // file: internal/database/migrations.go
-// version: 1.40.0
+// version: 1.45.0
 // guid: 9a8b7c6d-5e4f-3d2c-1b0a-9f8e7d6c5b4a
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package database
 
@@ -399,6 +399,24 @@ var migrations = []Migration{
 		Up:          migration060Up,
 		Down:        nil,
 	},
+	{
+		Version:     61,
+		Description: "Widen books.series_sequence from INTEGER to TEXT for decimal/labelled positions",
+		Up:          migration061Up,
+		Down:        nil,
+	},
+	{
+		Version:     62,
+		Description: "Backfill book contributors and normalize author/narrator roles and positions",
+		Up:          migration062Up,
+		Down:        nil,
+	},
+	{
+		Version:     63,
+		Description: "Index operations by creation time for cursor pagination",
+		Up:          migration063Up,
+		Down:        nil,
+	},
 }
 
 // RunMigrations applies all pending migrations
@@ -451,6 +469,28 @@ func RunMigrations(store Store) error {
 	return nil
 }
 
+// SchemaVersion reports the schema version recorded in store alongside the
+// newest migration this build knows about. current < latest means
+// migrations are still pending.
+func SchemaVersion(store Store) (current, latest int, err error) {
+	current, err = getCurrentVersion(store)
+	return current, LatestSchemaVersion(), err
+}
+
+// LatestSchemaVersion returns the newest migration version this build knows
+// about. A database recording a higher version was written by a newer build.
+func LatestSchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersionKey is the raw PebbleDB key holding the DatabaseVersion
+// record (as a UserPreference), for tools that read a database directory
+// without opening a full store.
+const SchemaVersionKey = "preference:db_version"
+
 // getCurrentVersion retrieves the current schema version
 func getCurrentVersion(store Store) (int, error) {
 	// Try to get version from preferences
@@ -1091,3 +1131,42 @@ func migration060Up(store Store) error {
 	// SQLite-only migration; no-op for PebbleStore.
 	return nil
 }
+
+// migration061Up widens series_sequence so books can sit at 1.5 or
+// "prequel". Pebble rows need no rewrite: SeriesSeq.UnmarshalJSON reads
+// the legacy JSON integers, and whole positions are written back as
+// integers, so old and new rows are byte-compatible.
+func migration061Up(store Store) error {
+	slog.Info("+ Widened books.series_sequence to TEXT (decimal/labelled positions)")
+	// SQLite-only migration; no-op for PebbleStore.
+	return nil
+}
+
+// migration062Up makes book_authors the complete contributor list: books
+// that only have the legacy AuthorID get a primary "author" row, and
+// existing author and narrator rows get contiguous positions and a role.
+// Organize tokens ({authors}, {first_author}), search and the
+// /audiobooks/:id/authors API read the contributor rows.
+func migration062Up(store Store) error {
+	changed, err := backfillContributors(store)
+	if err != nil {
+		return fmt.Errorf("migration 62: %w", err)
+	}
+	slog.Info("+ Backfilled book contributors", "books", changed)
+	return nil
+}
+
+// migration063Up indexes existing operations by creation time, so
+// ListOperationsAfter can seek to a cursor instead of sorting them all.
+func migration063Up(store Store) error {
+	p, ok := AsPebbleStore(store)
+	if !ok {
+		return nil
+	}
+	indexed, err := p.backfillOperationIndex()
+	if err != nil {
+		return fmt.Errorf("migration 63: %w", err)
+	}
+	slog.Info("+ Indexed operations by creation time", "operations", indexed)
+	return nil
+}
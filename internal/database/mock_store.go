@@ -1,7 +1,7 @@
 // file: internal/database/mock_store.go
-// version: 1.61.0
+// version: 1.63.0
 // guid: b2c3d4e5-f6a7-8b9c-0d1e-2f3a4b5c6d7e
-// last-edited: 2026-06-13
+// last-edited: 2026-10-17
 
 package database
 
@@ -296,11 +296,12 @@ type MockStore struct {
 	GetUserStatsFunc             func(userID string) (*UserStats, error)
 
 	// Hash blocklist
-	IsHashBlockedFunc        func(hash string) (bool, error)
-	AddBlockedHashFunc       func(hash, reason string) error
-	RemoveBlockedHashFunc    func(hash string) error
-	GetAllBlockedHashesFunc  func() ([]DoNotImport, error)
-	GetBlockedHashByHashFunc func(hash string) (*DoNotImport, error)
+	IsHashBlockedFunc               func(hash string) (bool, error)
+	AddBlockedHashFunc              func(hash, reason string) error
+	AddBlockedHashWithAlgorithmFunc func(hash, algorithm, reason string) error
+	RemoveBlockedHashFunc           func(hash string) error
+	GetAllBlockedHashesFunc         func() ([]DoNotImport, error)
+	GetBlockedHashByHashFunc        func(hash string) (*DoNotImport, error)
 
 	// Tombstone operations
 	CreateBookTombstoneFunc func(book *Book) error
@@ -663,6 +664,12 @@ func (m *MockStore) GetAllBooks(limit, offset int) ([]Book, error) {
 	return nil, nil
 }
 
+// GetAllBooksContext and the other ContextStore methods below forward to
+// the plain method between ctx checks; the mock has nothing to stop early.
+func (m *MockStore) GetAllBooksContext(ctx context.Context, limit, offset int) ([]Book, error) {
+	return checkedCall(ctx, func() ([]Book, error) { return m.GetAllBooks(limit, offset) })
+}
+
 func (m *MockStore) ListBookIDs() ([]string, error) {
 	if m.ListBookIDsFunc != nil {
 		return m.ListBookIDsFunc()
@@ -847,6 +854,10 @@ func (m *MockStore) SearchBooks(query string, limit, offset int) ([]Book, error)
 	return nil, nil
 }
 
+func (m *MockStore) SearchBooksContext(ctx context.Context, query string, limit, offset int) ([]Book, error) {
+	return checkedCall(ctx, func() ([]Book, error) { return m.SearchBooks(query, limit, offset) })
+}
+
 func (m *MockStore) CountBooks() (int, error) {
 	if m.CountBooksFunc != nil {
 		return m.CountBooksFunc()
@@ -854,6 +865,10 @@ func (m *MockStore) CountBooks() (int, error) {
 	return 0, nil
 }
 
+func (m *MockStore) CountBooksContext(ctx context.Context) (int, error) {
+	return checkedCall(ctx, m.CountBooks)
+}
+
 func (m *MockStore) GetDistinctGenres() ([]string, error) {
 	if m.GetDistinctGenresFunc != nil {
 		return m.GetDistinctGenresFunc()
@@ -1050,6 +1065,16 @@ func (m *MockStore) ListOperations(limit, offset int) ([]Operation, int, error)
 	return nil, 0, nil
 }
 
+func (m *MockStore) ListOperationsContext(ctx context.Context, limit, offset int) ([]Operation, int, error) {
+	total := 0
+	ops, err := checkedCall(ctx, func() ([]Operation, error) {
+		ops, n, err := m.ListOperations(limit, offset)
+		total = n
+		return ops, err
+	})
+	return ops, total, err
+}
+
 func (m *MockStore) UpdateOperationStatus(id, status string, progress, total int, message string) error {
 	if m.UpdateOperationStatusFunc != nil {
 		return m.UpdateOperationStatusFunc(id, status, progress, total, message)
@@ -1780,6 +1805,15 @@ func (m *MockStore) AddBlockedHash(hash, reason string) error {
 	return nil
 }
 
+// AddBlockedHashWithAlgorithm falls back to AddBlockedHashFunc when
+// AddBlockedHashWithAlgorithmFunc is unset.
+func (m *MockStore) AddBlockedHashWithAlgorithm(hash, algorithm, reason string) error {
+	if m.AddBlockedHashWithAlgorithmFunc != nil {
+		return m.AddBlockedHashWithAlgorithmFunc(hash, algorithm, reason)
+	}
+	return m.AddBlockedHash(hash, reason)
+}
+
 func (m *MockStore) RemoveBlockedHash(hash string) error {
 	if m.RemoveBlockedHashFunc != nil {
 		return m.RemoveBlockedHashFunc(hash)
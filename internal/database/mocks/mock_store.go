@@ -27959,6 +27959,72 @@ func (_c *MockStore_AddBlockedHash_Call) RunAndReturn(run func(hash string, reas
 	return _c
 }
 
+// AddBlockedHashWithAlgorithm provides a mock function for the type MockStore
+func (_mock *MockStore) AddBlockedHashWithAlgorithm(hash string, algorithm string, reason string) error {
+	ret := _mock.Called(hash, algorithm, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBlockedHashWithAlgorithm")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		return returnFunc(hash, algorithm, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(hash, algorithm, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_AddBlockedHashWithAlgorithm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBlockedHashWithAlgorithm'
+type MockStore_AddBlockedHashWithAlgorithm_Call struct {
+	*mock.Call
+}
+
+// AddBlockedHashWithAlgorithm is a helper method to define mock.On call
+//   - hash string
+//   - algorithm string
+//   - reason string
+func (_e *MockStore_Expecter) AddBlockedHashWithAlgorithm(hash interface{}, algorithm interface{}, reason interface{}) *MockStore_AddBlockedHashWithAlgorithm_Call {
+	return &MockStore_AddBlockedHashWithAlgorithm_Call{Call: _e.mock.On("AddBlockedHashWithAlgorithm", hash, algorithm, reason)}
+}
+
+func (_c *MockStore_AddBlockedHashWithAlgorithm_Call) Run(run func(hash string, algorithm string, reason string)) *MockStore_AddBlockedHashWithAlgorithm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_AddBlockedHashWithAlgorithm_Call) Return(err error) *MockStore_AddBlockedHashWithAlgorithm_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_AddBlockedHashWithAlgorithm_Call) RunAndReturn(run func(hash string, algorithm string, reason string) error) *MockStore_AddBlockedHashWithAlgorithm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddBookAlternativeTitle provides a mock function for the type MockStore
 func (_mock *MockStore) AddBookAlternativeTitle(bookID string, title string, source string, language string) error {
 	ret := _mock.Called(bookID, title, source, language)
@@ -29176,6 +29242,66 @@ func (_c *MockStore_CountBooksByPathPrefix_Call) RunAndReturn(run func(prefix st
 	return _c
 }
 
+// CountBooksContext provides a mock function for the type MockStore
+func (_mock *MockStore) CountBooksContext(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBooksContext")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_CountBooksContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBooksContext'
+type MockStore_CountBooksContext_Call struct {
+	*mock.Call
+}
+
+// CountBooksContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) CountBooksContext(ctx interface{}) *MockStore_CountBooksContext_Call {
+	return &MockStore_CountBooksContext_Call{Call: _e.mock.On("CountBooksContext", ctx)}
+}
+
+func (_c *MockStore_CountBooksContext_Call) Run(run func(ctx context.Context)) *MockStore_CountBooksContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_CountBooksContext_Call) Return(n int, err error) *MockStore_CountBooksContext_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStore_CountBooksContext_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockStore_CountBooksContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountFiles provides a mock function for the type MockStore
 func (_mock *MockStore) CountFiles() (int, error) {
 	ret := _mock.Called()
@@ -33403,6 +33529,80 @@ func (_c *MockStore_GetAllBooks_Call) RunAndReturn(run func(limit int, offset in
 	return _c
 }
 
+// GetAllBooksContext provides a mock function for the type MockStore
+func (_mock *MockStore) GetAllBooksContext(ctx context.Context, limit int, offset int) ([]database.Book, error) {
+	ret := _mock.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBooksContext")
+	}
+
+	var r0 []database.Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]database.Book, error)); ok {
+		return returnFunc(ctx, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []database.Book); ok {
+		r0 = returnFunc(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_GetAllBooksContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBooksContext'
+type MockStore_GetAllBooksContext_Call struct {
+	*mock.Call
+}
+
+// GetAllBooksContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *MockStore_Expecter) GetAllBooksContext(ctx interface{}, limit interface{}, offset interface{}) *MockStore_GetAllBooksContext_Call {
+	return &MockStore_GetAllBooksContext_Call{Call: _e.mock.On("GetAllBooksContext", ctx, limit, offset)}
+}
+
+func (_c *MockStore_GetAllBooksContext_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockStore_GetAllBooksContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_GetAllBooksContext_Call) Return(books []database.Book, err error) *MockStore_GetAllBooksContext_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockStore_GetAllBooksContext_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]database.Book, error)) *MockStore_GetAllBooksContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllImportPaths provides a mock function for the type MockStore
 func (_mock *MockStore) GetAllImportPaths() ([]database.ImportPath, error) {
 	ret := _mock.Called()
@@ -43272,6 +43472,86 @@ func (_c *MockStore_ListOperations_Call) RunAndReturn(run func(limit int, offset
 	return _c
 }
 
+// ListOperationsContext provides a mock function for the type MockStore
+func (_mock *MockStore) ListOperationsContext(ctx context.Context, limit int, offset int) ([]database.Operation, int, error) {
+	ret := _mock.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOperationsContext")
+	}
+
+	var r0 []database.Operation
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]database.Operation, int, error)); ok {
+		return returnFunc(ctx, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []database.Operation); ok {
+		r0 = returnFunc(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Operation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) int); ok {
+		r1 = returnFunc(ctx, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
+		r2 = returnFunc(ctx, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_ListOperationsContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOperationsContext'
+type MockStore_ListOperationsContext_Call struct {
+	*mock.Call
+}
+
+// ListOperationsContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - offset int
+func (_e *MockStore_Expecter) ListOperationsContext(ctx interface{}, limit interface{}, offset interface{}) *MockStore_ListOperationsContext_Call {
+	return &MockStore_ListOperationsContext_Call{Call: _e.mock.On("ListOperationsContext", ctx, limit, offset)}
+}
+
+func (_c *MockStore_ListOperationsContext_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockStore_ListOperationsContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_ListOperationsContext_Call) Return(operations []database.Operation, n int, err error) *MockStore_ListOperationsContext_Call {
+	_c.Call.Return(operations, n, err)
+	return _c
+}
+
+func (_c *MockStore_ListOperationsContext_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]database.Operation, int, error)) *MockStore_ListOperationsContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListOperationsV2Since provides a mock function for the type MockStore
 func (_mock *MockStore) ListOperationsV2Since(since time.Time, limit int) ([]database.OperationV2Row, error) {
 	ret := _mock.Called(since, limit)
@@ -46700,6 +46980,86 @@ func (_c *MockStore_SearchBooks_Call) RunAndReturn(run func(query string, limit
 	return _c
 }
 
+// SearchBooksContext provides a mock function for the type MockStore
+func (_mock *MockStore) SearchBooksContext(ctx context.Context, query string, limit int, offset int) ([]database.Book, error) {
+	ret := _mock.Called(ctx, query, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchBooksContext")
+	}
+
+	var r0 []database.Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) ([]database.Book, error)); ok {
+		return returnFunc(ctx, query, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) []database.Book); ok {
+		r0 = returnFunc(ctx, query, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, query, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_SearchBooksContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBooksContext'
+type MockStore_SearchBooksContext_Call struct {
+	*mock.Call
+}
+
+// SearchBooksContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+//   - offset int
+func (_e *MockStore_Expecter) SearchBooksContext(ctx interface{}, query interface{}, limit interface{}, offset interface{}) *MockStore_SearchBooksContext_Call {
+	return &MockStore_SearchBooksContext_Call{Call: _e.mock.On("SearchBooksContext", ctx, query, limit, offset)}
+}
+
+func (_c *MockStore_SearchBooksContext_Call) Run(run func(ctx context.Context, query string, limit int, offset int)) *MockStore_SearchBooksContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStore_SearchBooksContext_Call) Return(books []database.Book, err error) *MockStore_SearchBooksContext_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockStore_SearchBooksContext_Call) RunAndReturn(run func(ctx context.Context, query string, limit int, offset int) ([]database.Book, error)) *MockStore_SearchBooksContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetAPIKeyStatus provides a mock function for the type MockStore
 func (_mock *MockStore) SetAPIKeyStatus(id string, status string, at time.Time) error {
 	ret := _mock.Called(id, status, at)
@@ -1,5 +1,5 @@
 // file: internal/database/nuts_activity_store.go
-// version: 1.4.0
+// version: 1.5.0
 // guid: c3d4e5f6-a7b8-0003-cdef-000000000003
 
 package database
@@ -433,10 +433,11 @@ func (s *NutsActivityStore) CompactByDay(ctx context.Context, olderThan time.Tim
 	type dayGroup struct {
 		entries []ActivityEntry
 	}
+	loc := digestLocation()
 	days := make(map[string]*dayGroup)
 	var dayOrder []string
 	for _, e := range all {
-		dk := e.Timestamp.UTC().Format("2006-01-02")
+		dk := e.Timestamp.In(loc).Format("2006-01-02")
 		if _, ok := days[dk]; !ok {
 			days[dk] = &dayGroup{}
 			dayOrder = append(dayOrder, dk)
@@ -541,7 +542,7 @@ func (s *NutsActivityStore) CompactByDay(ctx context.Context, olderThan time.Tim
 			return result, fmt.Errorf("compact marshal: %w", err)
 		}
 
-		startOfDay, err := time.Parse("2006-01-02", dateKey)
+		startOfDay, _, err := digestDayBounds(dateKey, loc)
 		if err != nil {
 			return result, fmt.Errorf("compact parse date: %w", err)
 		}
@@ -876,11 +877,10 @@ func (s *NutsActivityStore) queryByIndex(indexBucket string, f ActivityFilter) (
 
 // findExistingDigest looks for a digest row for the given date string ("2006-01-02").
 func (s *NutsActivityStore) findExistingDigest(dateKey string) (DigestDetails, []byte, error) {
-	day, err := time.Parse("2006-01-02", dateKey)
+	day, dayEnd, err := digestDayBounds(dateKey, digestLocation())
 	if err != nil {
 		return DigestDetails{}, nil, err
 	}
-	dayEnd := day.Add(24 * time.Hour)
 
 	var foundDD DigestDetails
 	var foundKey []byte
@@ -0,0 +1,266 @@
+// file: internal/database/operation_archive.go
+// version: 1.1.0
+// guid: 19a5380a-061c-4b69-a853-c4fd5b24cf4d
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+)
+
+// Finished operations are moved out of the hot "operation:" keyspace into
+// an archive once they age past operation_archive_after_days, so listing
+// recent operations stays cheap however long the server has been running.
+//
+// Key-space layout (PebbleDB):
+//
+//	oparchive:idx:<inverted created_at hex>:<id> → ArchivedOperation JSON (no logs)
+//	oparchive:rec:<id>                           → zstd(ArchivedOperation JSON, with logs)
+//
+// The inverted timestamp makes key order newest-first, which is the order
+// the archive is browsed in.
+const (
+	opArchiveIdxPrefix = "oparchive:idx:"
+	opArchiveRecPrefix = "oparchive:rec:"
+)
+
+// ArchivedOperation is an operation record moved into the archive together
+// with its log lines. List results leave Logs empty; LogCount says how many
+// the full record holds.
+type ArchivedOperation struct {
+	Operation
+	ArchivedAt time.Time      `json:"archived_at"`
+	LogCount   int            `json:"log_count"`
+	Logs       []OperationLog `json:"logs,omitempty"`
+}
+
+// OperationArchiveStore is implemented by stores that can archive finished
+// operations.
+type OperationArchiveStore interface {
+	// ArchiveOperationsBefore moves completed, failed and canceled
+	// operations created before cutoff, with their log lines, into the
+	// archive. Returns the number archived.
+	ArchiveOperationsBefore(ctx context.Context, cutoff time.Time) (int, error)
+	// ListArchivedOperations returns one page of archived operations,
+	// newest first, plus the archive's total size. opType filters by
+	// operation type when non-empty.
+	ListArchivedOperations(opType string, limit, offset int) ([]ArchivedOperation, int, error)
+	// GetArchivedOperation returns the full archived record, or nil when
+	// id is not in the archive.
+	GetArchivedOperation(id string) (*ArchivedOperation, error)
+	// PruneArchivedOperations deletes archived operations created before
+	// cutoff. Returns the number deleted.
+	PruneArchivedOperations(cutoff time.Time) (int, error)
+}
+
+// archivableStatuses are the terminal statuses an operation must be in to
+// be archived; anything else may still be resumed or reported on.
+var archivableStatuses = map[string]bool{"completed": true, "failed": true, "canceled": true}
+
+func opArchiveIdxKey(createdAt time.Time, id string) []byte {
+	inv := uint64(math.MaxInt64 - createdAt.UnixNano())
+	return []byte(fmt.Sprintf("%s%016x:%s", opArchiveIdxPrefix, inv, id))
+}
+
+// opArchiveIdxCreatedAt recovers created_at from an index key.
+func opArchiveIdxCreatedAt(key []byte) (time.Time, bool) {
+	rest := strings.TrimPrefix(string(key), opArchiveIdxPrefix)
+	hexTS, _, ok := strings.Cut(rest, ":")
+	if !ok {
+		return time.Time{}, false
+	}
+	inv, err := strconv.ParseUint(hexTS, 16, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, math.MaxInt64-int64(inv)), true
+}
+
+// ArchiveOperationsBefore implements OperationArchiveStore. Each operation
+// is archived in its own batch, so an interrupted run leaves every
+// operation either fully archived or untouched.
+func (p *PebbleStore) ArchiveOperationsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	if p == nil || p.db == nil {
+		return 0, fmt.Errorf("pebble store not initialized")
+	}
+	// Collect first: archiving deletes from the range being iterated.
+	var eligible []Operation
+	iter, err := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte("operation:"),
+		UpperBound: []byte("operation:~"),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	for iter.First(); iter.Valid(); iter.Next() {
+		var op Operation
+		if err := json.Unmarshal(iter.Value(), &op); err != nil {
+			continue
+		}
+		if archivableStatuses[op.Status] && op.CreatedAt.Before(cutoff) {
+			eligible = append(eligible, op)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return 0, fmt.Errorf("operation iterator: %w", err)
+	}
+
+	archived := 0
+	for i := range eligible {
+		if err := ctx.Err(); err != nil {
+			return archived, err
+		}
+		if err := p.archiveOperation(&eligible[i]); err != nil {
+			return archived, fmt.Errorf("archive operation %s: %w", eligible[i].ID, err)
+		}
+		archived++
+	}
+	return archived, nil
+}
+
+func (p *PebbleStore) archiveOperation(op *Operation) error {
+	logs, err := p.GetOperationLogs(op.ID)
+	if err != nil {
+		return fmt.Errorf("read logs: %w", err)
+	}
+	rec := ArchivedOperation{Operation: *op, ArchivedAt: time.Now().UTC(), LogCount: len(logs)}
+	summary, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	rec.Logs = logs
+	full, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(opArchiveIdxKey(op.CreatedAt, op.ID), summary, nil); err != nil {
+		return err
+	}
+	if err := batch.Set([]byte(opArchiveRecPrefix+op.ID), zstdEnc.EncodeAll(full, nil), nil); err != nil {
+		return err
+	}
+	if err := batch.Delete([]byte("operation:"+op.ID), nil); err != nil {
+		return err
+	}
+	if err := batch.Delete(operationIdxKey(op.CreatedAt, op.ID), nil); err != nil {
+		return err
+	}
+	logPrefix := []byte(fmt.Sprintf("operationlog:%s:", op.ID))
+	if err := batch.DeleteRange(logPrefix, prefixEnd(logPrefix), nil); err != nil {
+		return err
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+// ListArchivedOperations implements OperationArchiveStore.
+func (p *PebbleStore) ListArchivedOperations(opType string, limit, offset int) ([]ArchivedOperation, int, error) {
+	if p == nil || p.db == nil {
+		return nil, 0, fmt.Errorf("pebble store not initialized")
+	}
+	lower := []byte(opArchiveIdxPrefix)
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: prefixEnd(lower)})
+	if err != nil {
+		return nil, 0, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	defer iter.Close()
+
+	out := []ArchivedOperation{}
+	total := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		inPage := total >= offset && (limit <= 0 || len(out) < limit)
+		if opType == "" && !inPage {
+			total++
+			continue
+		}
+		var rec ArchivedOperation
+		if err := json.Unmarshal(iter.Value(), &rec); err != nil {
+			continue
+		}
+		if opType != "" && rec.Type != opType {
+			continue
+		}
+		if inPage {
+			out = append(out, rec)
+		}
+		total++
+	}
+	return out, total, nil
+}
+
+// GetArchivedOperation implements OperationArchiveStore.
+func (p *PebbleStore) GetArchivedOperation(id string) (*ArchivedOperation, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	val, closer, err := p.db.Get([]byte(opArchiveRecPrefix + id))
+	if err == pebble.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pebble Get: %w", err)
+	}
+	defer closer.Close()
+	raw, err := zstdDec.DecodeAll(val, nil)
+	if err != nil {
+		return nil, fmt.Errorf("zstd decode: %w", err)
+	}
+	var rec ArchivedOperation
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+	return &rec, nil
+}
+
+// PruneArchivedOperations implements OperationArchiveStore.
+func (p *PebbleStore) PruneArchivedOperations(cutoff time.Time) (int, error) {
+	if p == nil || p.db == nil {
+		return 0, fmt.Errorf("pebble store not initialized")
+	}
+	// Index keys run newest to oldest, so everything from the cutoff's
+	// position onward is older.
+	lower := opArchiveIdxKey(cutoff, "")
+	upper := prefixEnd([]byte(opArchiveIdxPrefix))
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	if err != nil {
+		return 0, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	pruned := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		createdAt, ok := opArchiveIdxCreatedAt(iter.Key())
+		if !ok || !createdAt.Before(cutoff) {
+			continue
+		}
+		key := append([]byte(nil), iter.Key()...)
+		id := string(key[strings.LastIndexByte(string(key), ':')+1:])
+		if err := batch.Delete(key, nil); err != nil {
+			iter.Close()
+			return 0, err
+		}
+		if err := batch.Delete([]byte(opArchiveRecPrefix+id), nil); err != nil {
+			iter.Close()
+			return 0, err
+		}
+		pruned++
+	}
+	if err := iter.Close(); err != nil {
+		return 0, fmt.Errorf("archive iterator: %w", err)
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+	return pruned, batch.Commit(pebble.Sync)
+}
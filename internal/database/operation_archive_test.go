@@ -0,0 +1,95 @@
+// file: internal/database/operation_archive_test.go
+// version: 1.0.0
+// guid: 611f4bf7-fd58-4333-bdb9-e72039d815e0
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPebbleStore_OperationArchive(t *testing.T) {
+	store, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	p := store.(*PebbleStore)
+
+	now := time.Now()
+	put := func(id, opType, status string, age time.Duration) {
+		t.Helper()
+		data, err := json.Marshal(Operation{ID: id, Type: opType, Status: status, CreatedAt: now.Add(-age)})
+		require.NoError(t, err)
+		require.NoError(t, p.db.Set([]byte("operation:"+id), data, pebble.Sync))
+	}
+	put("old-scan", "scan", "completed", 60*24*time.Hour)
+	put("older-organize", "organize", "failed", 90*24*time.Hour)
+	put("old-running", "scan", "running", 60*24*time.Hour)
+	put("recent", "scan", "completed", time.Hour)
+	require.NoError(t, p.AddOperationLog("old-scan", "info", "scanned 3 books", nil))
+	require.NoError(t, p.AddOperationLog("old-scan", "info", "done", nil))
+
+	archived, err := p.ArchiveOperationsBefore(context.Background(), now.Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, archived)
+
+	// Archived operations leave the hot table with their logs; running and
+	// recent ones stay.
+	hot, err := p.GetRecentOperations(10)
+	require.NoError(t, err)
+	var hotIDs []string
+	for _, op := range hot {
+		hotIDs = append(hotIDs, op.ID)
+	}
+	assert.ElementsMatch(t, []string{"old-running", "recent"}, hotIDs)
+	logs, err := p.GetOperationLogs("old-scan")
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+
+	list, total, err := p.ListArchivedOperations("", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, list, 2)
+	assert.Equal(t, "old-scan", list[0].ID, "newest first")
+	assert.Equal(t, 2, list[0].LogCount)
+	assert.Empty(t, list[0].Logs)
+
+	list, total, err = p.ListArchivedOperations("organize", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, list, 1)
+	assert.Equal(t, "older-organize", list[0].ID)
+
+	list, total, err = p.ListArchivedOperations("", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, list, 1)
+	assert.Equal(t, "older-organize", list[0].ID)
+
+	rec, err := p.GetArchivedOperation("old-scan")
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, "completed", rec.Status)
+	require.Len(t, rec.Logs, 2)
+	assert.Equal(t, "scanned 3 books", rec.Logs[0].Message)
+
+	missing, err := p.GetArchivedOperation("recent")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	pruned, err := p.PruneArchivedOperations(now.Add(-75 * 24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	_, total, err = p.ListArchivedOperations("", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	gone, err := p.GetArchivedOperation("older-organize")
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+}
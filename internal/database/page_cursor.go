@@ -0,0 +1,354 @@
+// file: internal/database/page_cursor.go
+// version: 1.1.0
+// guid: 3729ca9d-7ab7-4b94-a565-7d1aca639b75
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Book sort orders supported by cursor pagination. Only orders backed by
+// an index (or a cheap in-memory sort) are offered; everything else still
+// goes through offset pagination.
+const (
+	BookSortID    = "id"
+	BookSortTitle = "title"
+)
+
+// PageCursor is the position of the last row on a page: the row's sort
+// key plus its ID as a tie-breaker, so rows sharing a sort key are never
+// skipped or repeated. Clients treat the encoded form as opaque.
+type PageCursor struct {
+	Key string `json:"k,omitempty"`
+	ID  string `json:"i"`
+}
+
+// Encode returns the opaque, URL-safe form of the cursor.
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor parses a cursor produced by Encode. Any malformed
+// input yields ErrInvalidCursor.
+func DecodePageCursor(s string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c PageCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// CursorPageStore is implemented by stores that can page books and
+// operations by cursor instead of offset. It is kept out of Store so
+// wrappers and mocks only need it when they page by cursor.
+type CursorPageStore interface {
+	// ListBooksAfter returns up to limit non-deleted books ordered by
+	// sortBy (BookSortID or BookSortTitle, then ID) that sort strictly
+	// after the cursor; a nil cursor starts at the beginning.
+	ListBooksAfter(after *PageCursor, sortBy string, desc bool, limit int) ([]Book, error)
+	// ListOperationsAfter returns up to limit operations, newest first,
+	// that sort strictly after the cursor.
+	ListOperationsAfter(after *PageCursor, limit int) ([]Operation, error)
+}
+
+// BookPageCursor returns the cursor positioned at b for the given sort.
+func BookPageCursor(b *Book, sortBy string) PageCursor {
+	if sortBy == BookSortTitle {
+		return PageCursor{Key: bookTitleSortKey(b), ID: b.ID}
+	}
+	return PageCursor{ID: b.ID}
+}
+
+// OperationPageCursor returns the cursor positioned at op.
+func OperationPageCursor(op *Operation) PageCursor {
+	return PageCursor{Key: op.CreatedAt.UTC().Format(time.RFC3339Nano), ID: op.ID}
+}
+
+// bookTitleSortKey is the key the memdb title index sorts books by.
+func bookTitleSortKey(b *Book) string {
+	key := strings.ToLower(strings.TrimSpace(b.Title))
+	if key == "" && b.OriginalFilename != nil {
+		key = strings.ToLower(strings.TrimSpace(*b.OriginalFilename))
+	}
+	if key == "" {
+		key = "~" // sort to end
+	}
+	return key
+}
+
+// bookAfterCursor reports whether b sorts strictly after c.
+func bookAfterCursor(b *Book, c *PageCursor, sortBy string, desc bool) bool {
+	key := ""
+	if sortBy == BookSortTitle {
+		key = bookTitleSortKey(b)
+	}
+	if key != c.Key {
+		return (key > c.Key) != desc
+	}
+	return b.ID != c.ID && (b.ID > c.ID) != desc
+}
+
+func validBookSort(sortBy string) error {
+	if sortBy != BookSortID && sortBy != BookSortTitle {
+		return fmt.Errorf("unsupported cursor sort %q", sortBy)
+	}
+	return nil
+}
+
+// ListBooksAfter walks the ID or title index from the cursor position, so
+// each page costs O(log n + limit) regardless of depth.
+func (m *MemStore) ListBooksAfter(after *PageCursor, sortBy string, desc bool, limit int) ([]Book, error) {
+	if err := validBookSort(sortBy); err != nil {
+		return nil, err
+	}
+	txn := m.db.Txn(false)
+	defer txn.Abort()
+
+	index := memIdxID
+	if sortBy == BookSortTitle {
+		index = memIdxTitle
+	}
+	var (
+		iter interface{ Next() interface{} }
+		err  error
+	)
+	switch {
+	case after == nil && desc:
+		iter, err = txn.GetReverse(memTableBooks, index)
+	case after == nil:
+		iter, err = txn.Get(memTableBooks, index)
+	case sortBy == BookSortTitle && desc:
+		// Title entries are stored as title\x00id; bumping the bound past
+		// the terminator keeps rows sharing the cursor's title in range.
+		iter, err = txn.ReverseLowerBound(memTableBooks, index, after.Key+"\x01")
+	case sortBy == BookSortTitle:
+		iter, err = txn.LowerBound(memTableBooks, index, after.Key)
+	case desc:
+		iter, err = txn.ReverseLowerBound(memTableBooks, index, after.ID)
+	default:
+		iter, err = txn.LowerBound(memTableBooks, index, after.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("memdb books cursor scan: %w", err)
+	}
+
+	books := make([]Book, 0, max(limit, 0))
+	for obj := iter.Next(); obj != nil; obj = iter.Next() {
+		b := obj.(*Book)
+		if b.MarkedForDeletion != nil && *b.MarkedForDeletion {
+			continue
+		}
+		if after != nil && !bookAfterCursor(b, after, sortBy, desc) {
+			continue
+		}
+		books = append(books, *b)
+		if limit > 0 && len(books) >= limit {
+			break
+		}
+	}
+	return books, nil
+}
+
+// ListBooksAfter implements CursorPageStore. The ID order seeks straight
+// to the cursor key; the title order has no Pebble index and sorts a full
+// scan, so it is only cheap once memdb is serving reads.
+func (p *PebbleStore) ListBooksAfter(after *PageCursor, sortBy string, desc bool, limit int) ([]Book, error) {
+	if p.UseMemDB && p.mem() != nil {
+		return p.mem().ListBooksAfter(after, sortBy, desc, limit)
+	}
+	if err := validBookSort(sortBy); err != nil {
+		return nil, err
+	}
+	if sortBy == BookSortTitle {
+		all, err := p.GetAllBooks(0, 0)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(all, func(i, j int) bool {
+			ki, kj := bookTitleSortKey(&all[i]), bookTitleSortKey(&all[j])
+			if ki != kj {
+				return (ki < kj) != desc
+			}
+			return (all[i].ID < all[j].ID) != desc
+		})
+		books := make([]Book, 0, max(limit, 0))
+		for i := range all {
+			if after != nil && !bookAfterCursor(&all[i], after, sortBy, desc) {
+				continue
+			}
+			books = append(books, all[i])
+			if limit > 0 && len(books) >= limit {
+				break
+			}
+		}
+		return books, nil
+	}
+
+	iter, err := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte("book:0"),
+		UpperBound: []byte("book:;"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var valid bool
+	switch {
+	case after == nil && desc:
+		valid = iter.Last()
+	case after == nil:
+		valid = iter.First()
+	case desc:
+		valid = iter.SeekLT([]byte("book:" + after.ID))
+	default:
+		valid = iter.SeekGE([]byte("book:" + after.ID))
+	}
+	step := iter.Next
+	if desc {
+		step = iter.Prev
+	}
+
+	books := make([]Book, 0, max(limit, 0))
+	for ; valid; valid = step() {
+		id := strings.TrimPrefix(string(iter.Key()), "book:")
+		if strings.Contains(id, ":") {
+			continue
+		}
+		if after != nil && id == after.ID {
+			continue
+		}
+		var book Book
+		if err := json.Unmarshal(iter.Value(), &book); err != nil {
+			return nil, err
+		}
+		if book.MarkedForDeletion != nil && *book.MarkedForDeletion {
+			continue
+		}
+		books = append(books, book)
+		if limit > 0 && len(books) >= limit {
+			break
+		}
+	}
+	return books, nil
+}
+
+// Operations are also indexed by creation time, so operation pages seek
+// to the cursor instead of sorting every operation:
+//
+//	opidx:<created_at hex>:<id> → ""
+//
+// Walking the index backwards gives the ListOperations order: newest
+// first, then ID descending.
+const opIdxPrefix = "opidx:"
+
+func operationIdxKey(createdAt time.Time, id string) []byte {
+	var ns uint64
+	if createdAt.After(time.Unix(0, 0)) {
+		ns = uint64(createdAt.UnixNano())
+	}
+	return []byte(fmt.Sprintf("%s%016x:%s", opIdxPrefix, ns, id))
+}
+
+// backfillOperationIndex adds index entries for operations written before
+// the index existed. Returns the number of operations indexed.
+func (p *PebbleStore) backfillOperationIndex() (int, error) {
+	iter, err := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte("operation:"),
+		UpperBound: []byte("operation:~"),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	indexed := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		var op Operation
+		if err := json.Unmarshal(iter.Value(), &op); err != nil {
+			continue
+		}
+		if err := batch.Set(operationIdxKey(op.CreatedAt, op.ID), nil, nil); err != nil {
+			return 0, err
+		}
+		indexed++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	return indexed, batch.Commit(pebble.Sync)
+}
+
+// ListOperationsAfter implements CursorPageStore. Operations are ordered
+// newest first with the ID breaking ties, matching ListOperations. The
+// page seeks the creation-time index to the cursor and loads only the
+// operations it returns.
+func (p *PebbleStore) ListOperationsAfter(after *PageCursor, limit int) ([]Operation, error) {
+	var seek []byte
+	if after != nil {
+		t, err := time.Parse(time.RFC3339Nano, after.Key)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		seek = operationIdxKey(t, after.ID)
+	}
+
+	iter, err := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte(opIdxPrefix),
+		UpperBound: prefixEnd([]byte(opIdxPrefix)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	valid := iter.Last()
+	if seek != nil {
+		valid = iter.SeekLT(seek)
+	}
+	ops := make([]Operation, 0, max(limit, 0))
+	for ; valid; valid = iter.Prev() {
+		_, id, _ := strings.Cut(strings.TrimPrefix(string(iter.Key()), opIdxPrefix), ":")
+		value, closer, err := p.db.Get([]byte("operation:" + id))
+		if errors.Is(err, pebble.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var op Operation
+		err = json.Unmarshal(value, &op)
+		closer.Close()
+		// Skip corrupt records like ListOperations does, and entries whose
+		// record was rewritten with another timestamp.
+		if err != nil || !bytes.Equal(operationIdxKey(op.CreatedAt, op.ID), iter.Key()) {
+			continue
+		}
+		ops = append(ops, op)
+		if limit > 0 && len(ops) >= limit {
+			break
+		}
+	}
+	return ops, iter.Error()
+}
@@ -0,0 +1,149 @@
+// file: internal/database/page_cursor_test.go
+// version: 1.1.0
+// guid: ce239f34-7420-44ba-b7cf-733f2bd44f32
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cursorTestBooks() []Book {
+	return []Book{
+		{ID: "01A", Title: "Dune"},
+		{ID: "01B", Title: "alpha"},
+		{ID: "01C", Title: "Dune"},
+		{ID: "01D", Title: "Zebra"},
+		{ID: "01E", Title: "Gone", MarkedForDeletion: ptrBool_mem(true)},
+		{ID: "01F", Title: ""},
+		{ID: "01G", Title: "beta"},
+	}
+}
+
+type bookPager interface {
+	ListBooksAfter(after *PageCursor, sortBy string, desc bool, limit int) ([]Book, error)
+}
+
+// walkBooks pages through every book two at a time and returns the IDs in
+// the order they were served.
+func walkBooks(t *testing.T, s bookPager, sortBy string, desc bool) []string {
+	t.Helper()
+	var ids []string
+	var after *PageCursor
+	for range 10 {
+		page, err := s.ListBooksAfter(after, sortBy, desc, 2)
+		require.NoError(t, err)
+		for _, b := range page {
+			ids = append(ids, b.ID)
+		}
+		if len(page) < 2 {
+			return ids
+		}
+		c := BookPageCursor(&page[len(page)-1], sortBy)
+		// Round-trip through the wire form like a client would.
+		after, err = DecodePageCursor(c.Encode())
+		require.NoError(t, err)
+	}
+	t.Fatal("pagination did not terminate")
+	return nil
+}
+
+func assertBookCursorOrders(t *testing.T, s bookPager) {
+	t.Helper()
+	assert.Equal(t, []string{"01A", "01B", "01C", "01D", "01F", "01G"}, walkBooks(t, s, BookSortID, false))
+	assert.Equal(t, []string{"01G", "01F", "01D", "01C", "01B", "01A"}, walkBooks(t, s, BookSortID, true))
+	assert.Equal(t, []string{"01B", "01G", "01A", "01C", "01D", "01F"}, walkBooks(t, s, BookSortTitle, false))
+	assert.Equal(t, []string{"01F", "01D", "01C", "01A", "01G", "01B"}, walkBooks(t, s, BookSortTitle, true))
+}
+
+func TestListBooksAfter_MemStore(t *testing.T) {
+	m, err := NewMemStore()
+	require.NoError(t, err)
+	seedMemStore(t, m, cursorTestBooks(), nil, nil, nil)
+	assertBookCursorOrders(t, m)
+
+	_, err = m.ListBooksAfter(nil, "author", false, 10)
+	assert.Error(t, err)
+}
+
+func TestListBooksAfter_Pebble(t *testing.T) {
+	s := setupTestPebbleStore(t)
+	for _, b := range cursorTestBooks() {
+		data, err := json.Marshal(b)
+		require.NoError(t, err)
+		require.NoError(t, s.db.Set([]byte("book:"+b.ID), data, pebble.Sync))
+	}
+	assertBookCursorOrders(t, s)
+}
+
+func TestListOperationsAfter(t *testing.T) {
+	s := setupTestPebbleStore(t)
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"op-a", "op-b", "op-c", "op-d"} {
+		op := Operation{ID: id, Type: "scan", Status: "completed", CreatedAt: base.Add(time.Duration(i/2) * time.Minute)}
+		data, err := json.Marshal(op)
+		require.NoError(t, err)
+		require.NoError(t, s.db.Set([]byte("operation:"+id), data, pebble.Sync))
+	}
+	// The records were written directly, as before the index existed.
+	indexed, err := s.backfillOperationIndex()
+	require.NoError(t, err)
+	require.Equal(t, 4, indexed)
+
+	first, err := s.ListOperationsAfter(nil, 3)
+	require.NoError(t, err)
+	require.Len(t, first, 3)
+	assert.Equal(t, []string{"op-d", "op-c", "op-b"}, []string{first[0].ID, first[1].ID, first[2].ID})
+
+	c := OperationPageCursor(&first[2])
+	rest, err := s.ListOperationsAfter(&c, 3)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "op-a", rest[0].ID)
+
+	_, err = s.ListOperationsAfter(&PageCursor{Key: "yesterday", ID: "x"}, 3)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestListOperationsAfter_IndexMaintained(t *testing.T) {
+	s := setupTestPebbleStore(t)
+	for _, id := range []string{"op-1", "op-2", "op-3"} {
+		_, err := s.CreateOperation(id, "scan", nil)
+		require.NoError(t, err)
+	}
+	// Recreating an operation moves it to the front without duplicating it.
+	_, err := s.CreateOperation("op-1", "scan", nil)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteOperationWithLogs("op-2"))
+
+	ops, err := s.ListOperationsAfter(nil, 10)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, []string{"op-1", "op-3"}, []string{ops[0].ID, ops[1].ID})
+
+	n, err := s.DeleteOperationsByStatus([]string{"pending"})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	ops, err = s.ListOperationsAfter(nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+
+	iter, err := s.db.NewIter(&pebble.IterOptions{LowerBound: []byte(opIdxPrefix), UpperBound: prefixEnd([]byte(opIdxPrefix))})
+	require.NoError(t, err)
+	defer iter.Close()
+	assert.False(t, iter.First(), "index entries left behind")
+}
+
+func TestDecodePageCursor_Invalid(t *testing.T) {
+	for _, in := range []string{"", "not base64!", PageCursor{Key: "k"}.Encode()} {
+		_, err := DecodePageCursor(in)
+		assert.ErrorIs(t, err, ErrInvalidCursor, in)
+	}
+}
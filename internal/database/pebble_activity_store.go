@@ -1,5 +1,5 @@
 // file: internal/database/pebble_activity_store.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: d4e5f6a7-b8c9-0004-def0-000000000004
 
 // Package database — PebbleDB-backed activity log store.
@@ -424,10 +424,11 @@ func (s *PebbleActivityStore) CompactByDay(ctx context.Context, olderThan time.T
 
 	// Group by date.
 	type dayGroup struct{ kvs []pactKV }
+	loc := digestLocation()
 	days := make(map[string]*dayGroup)
 	var dayOrder []string
 	for _, kv := range all {
-		dk := kv.entry.Timestamp.UTC().Format("2006-01-02")
+		dk := kv.entry.Timestamp.In(loc).Format("2006-01-02")
 		if _, ok := days[dk]; !ok {
 			days[dk] = &dayGroup{}
 			dayOrder = append(dayOrder, dk)
@@ -525,7 +526,7 @@ func (s *PebbleActivityStore) CompactByDay(ctx context.Context, olderThan time.T
 			return result, fmt.Errorf("pebble_activity_store: compact marshal: %w", err)
 		}
 
-		startOfDay, err := time.Parse("2006-01-02", dateKey)
+		startOfDay, _, err := digestDayBounds(dateKey, loc)
 		if err != nil {
 			return result, fmt.Errorf("pebble_activity_store: compact parse date: %w", err)
 		}
@@ -861,11 +862,10 @@ func (s *PebbleActivityStore) queryByIndexPrefix(prefix string, f ActivityFilter
 // findExistingDigest looks for a digest row for the given date string ("2006-01-02").
 // Returns the DigestDetails, the Pebble key, and any error.
 func (s *PebbleActivityStore) findExistingDigest(dateKey string) (DigestDetails, []byte, error) {
-	day, err := time.Parse("2006-01-02", dateKey)
+	day, dayEnd, err := digestDayBounds(dateKey, digestLocation())
 	if err != nil {
 		return DigestDetails{}, nil, err
 	}
-	dayEnd := day.Add(24 * time.Hour)
 
 	lower := pactPrimaryKey("digest", day, "")
 	upper := pactPrimaryKey("digest", dayEnd, "")
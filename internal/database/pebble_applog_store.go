@@ -0,0 +1,315 @@
+// file: internal/database/pebble_applog_store.go
+// version: 1.0.0
+// guid: 01da981e-d041-4699-97cf-5f845fee539a
+// last-edited: 2026-10-17
+
+// Package database — PebbleDB-backed application log store.
+//
+// Every line the server logs through slog is kept here, not just the lines
+// operations log, so GET /system/logs can page back through the whole log
+// without touching operation rows.
+//
+// Key layout (IDs are "<20d-unix-nano>-<ulid>", so keys sort by time):
+//
+//	applog:t:<id>             = JSON(AppLogEntry)   primary
+//	applog:l:<level>:<id>     = primary key         level index
+//	applog:m:<module>:<id>    = primary key         module index
+//
+// The level index makes "warnings and errors only" cheap in a log that is
+// mostly info lines; the module index does the same for one subsystem.
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	appLogPrimaryPrefix = "applog:t:"
+	appLogLevelPrefix   = "applog:l:"
+	appLogModulePrefix  = "applog:m:"
+)
+
+// AppLogLevels are the stored levels, lowest first.
+var AppLogLevels = []string{"debug", "info", "warn", "error"}
+
+// AppLogLevelRank orders levels for minimum-level filters; unknown levels
+// rank as info.
+func AppLogLevelRank(level string) int {
+	for i, l := range AppLogLevels {
+		if l == level {
+			return i
+		}
+	}
+	return 1
+}
+
+// AppLogEntry is one server log line.
+type AppLogEntry struct {
+	ID      string         `json:"id"`
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Module  string         `json:"module,omitempty"`
+	Message string         `json:"message"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// AppLogFilter selects entries for QueryAppLogs. Zero fields don't filter.
+type AppLogFilter struct {
+	// MinLevel keeps entries at or above this level.
+	MinLevel string
+	Module   string
+	// Search matches message and attribute values, case-insensitively.
+	Search string
+	Since  time.Time
+	Until  time.Time
+	// Before is a cursor: only entries older than this ID are returned.
+	Before string
+	// Limit defaults to 100.
+	Limit int
+}
+
+// Matches reports whether e passes every filter in f except the cursor
+// and time bounds, which the store applies through its key ranges.
+func (f AppLogFilter) Matches(e AppLogEntry) bool {
+	if f.MinLevel != "" && AppLogLevelRank(e.Level) < AppLogLevelRank(f.MinLevel) {
+		return false
+	}
+	if f.Module != "" && e.Module != f.Module {
+		return false
+	}
+	if f.Search == "" {
+		return true
+	}
+	search := strings.ToLower(f.Search)
+	if strings.Contains(strings.ToLower(e.Message), search) {
+		return true
+	}
+	for _, v := range e.Attrs {
+		if strings.Contains(strings.ToLower(fmt.Sprint(v)), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// PebbleAppLogStore persists application log entries in the shared
+// PebbleDB. The caller retains ownership of the *pebble.DB.
+type PebbleAppLogStore struct {
+	db *pebble.DB
+}
+
+// NewPebbleAppLogStore creates an application log store backed by db.
+func NewPebbleAppLogStore(db *pebble.DB) *PebbleAppLogStore {
+	return &PebbleAppLogStore{db: db}
+}
+
+// NewAppLogID builds a time-ordered entry ID, so IDs double as cursors.
+func NewAppLogID(t time.Time) string {
+	return fmt.Sprintf("%020d-%s", t.UnixNano(), ulid.Make().String())
+}
+
+// appLogTimeKey is the lowest ID at or after t, used as a range bound.
+func appLogTimeKey(t time.Time) string {
+	return fmt.Sprintf("%020d", t.UnixNano())
+}
+
+// AppendAppLogs writes entries in one batch, filling in missing IDs,
+// times and levels. Log lines are written without fsync: losing the last
+// few on a crash is cheaper than syncing every batch.
+func (s *PebbleAppLogStore) AppendAppLogs(entries []AppLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	for i := range entries {
+		e := &entries[i]
+		if e.Time.IsZero() {
+			e.Time = time.Now().UTC()
+		}
+		if e.Level == "" {
+			e.Level = "info"
+		}
+		if e.ID == "" {
+			e.ID = NewAppLogID(e.Time)
+		}
+		b, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("applog: marshal: %w", err)
+		}
+		primary := []byte(appLogPrimaryPrefix + e.ID)
+		if err := batch.Set(primary, b, nil); err != nil {
+			return fmt.Errorf("applog: set primary: %w", err)
+		}
+		if err := batch.Set([]byte(appLogLevelPrefix+e.Level+":"+e.ID), primary, nil); err != nil {
+			return fmt.Errorf("applog: set level index: %w", err)
+		}
+		if e.Module != "" {
+			if err := batch.Set([]byte(appLogModulePrefix+e.Module+":"+e.ID), primary, nil); err != nil {
+				return fmt.Errorf("applog: set module index: %w", err)
+			}
+		}
+	}
+	if err := batch.Commit(pebble.NoSync); err != nil {
+		return fmt.Errorf("applog: commit: %w", err)
+	}
+	return nil
+}
+
+// QueryAppLogs returns the entries matching f, newest first. Pass the
+// last entry's ID as f.Before to get the next page.
+func (s *PebbleAppLogStore) QueryAppLogs(f AppLogFilter) ([]AppLogEntry, error) {
+	if f.Limit <= 0 {
+		f.Limit = 100
+	}
+	// Pick the narrowest index: a module, the levels at or above a
+	// warn/error minimum, or else the whole log.
+	var prefixes []string
+	switch {
+	case f.Module != "":
+		prefixes = []string{appLogModulePrefix + f.Module + ":"}
+	case AppLogLevelRank(f.MinLevel) >= AppLogLevelRank("warn"):
+		for _, l := range AppLogLevels[AppLogLevelRank(f.MinLevel):] {
+			prefixes = append(prefixes, appLogLevelPrefix+l+":")
+		}
+	default:
+		prefixes = []string{appLogPrimaryPrefix}
+	}
+
+	var out []AppLogEntry
+	for _, prefix := range prefixes {
+		entries, err := s.scanAppLogs(prefix, f)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entries...)
+	}
+	if len(prefixes) > 1 {
+		sort.Slice(out, func(i, j int) bool { return out[i].ID > out[j].ID })
+		if len(out) > f.Limit {
+			out = out[:f.Limit]
+		}
+	}
+	return out, nil
+}
+
+// scanAppLogs walks one key range newest first and returns up to f.Limit
+// matching entries.
+func (s *PebbleAppLogStore) scanAppLogs(prefix string, f AppLogFilter) ([]AppLogEntry, error) {
+	lower := []byte(prefix)
+	if !f.Since.IsZero() {
+		lower = []byte(prefix + appLogTimeKey(f.Since))
+	}
+	upper := prefixEnd([]byte(prefix))
+	if !f.Until.IsZero() {
+		upper = []byte(prefix + appLogTimeKey(f.Until.Add(time.Nanosecond)))
+	}
+	if f.Before != "" {
+		if before := []byte(prefix + f.Before); string(before) < string(upper) {
+			upper = before
+		}
+	}
+	iter, err := s.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	if err != nil {
+		return nil, fmt.Errorf("applog: iterate: %w", err)
+	}
+	defer iter.Close()
+
+	indexed := prefix != appLogPrimaryPrefix
+	var out []AppLogEntry
+	for valid := iter.Last(); valid && len(out) < f.Limit; valid = iter.Prev() {
+		data := iter.Value()
+		if indexed {
+			val, closer, err := s.db.Get(data)
+			if err != nil {
+				continue // pruned between the index and primary reads
+			}
+			data = append([]byte(nil), val...)
+			closer.Close()
+		}
+		var e AppLogEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		if f.Matches(e) {
+			out = append(out, e)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("applog: iterate: %w", err)
+	}
+	return out, nil
+}
+
+// PruneAppLogs deletes entries older than olderThan, only those at level
+// when it is set, and returns how many it deleted.
+func (s *PebbleAppLogStore) PruneAppLogs(olderThan time.Time, level string) (int, error) {
+	prefix := appLogPrimaryPrefix
+	if level != "" {
+		prefix = appLogLevelPrefix + level + ":"
+	}
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte(prefix),
+		UpperBound: []byte(prefix + appLogTimeKey(olderThan)),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("applog: iterate: %w", err)
+	}
+	defer iter.Close()
+
+	deleted := 0
+	batch := s.db.NewBatch()
+	defer func() { batch.Close() }()
+	flush := func() error {
+		if batch.Empty() {
+			return nil
+		}
+		if err := batch.Commit(pebble.NoSync); err != nil {
+			return fmt.Errorf("applog: prune commit: %w", err)
+		}
+		batch.Close()
+		batch = s.db.NewBatch()
+		return nil
+	}
+	for valid := iter.First(); valid; valid = iter.Next() {
+		primary := iter.Key()
+		data := iter.Value()
+		if level != "" {
+			primary = append([]byte(nil), data...)
+			val, closer, err := s.db.Get(primary)
+			if err != nil {
+				_ = batch.Delete(append([]byte(nil), iter.Key()...), nil)
+				continue
+			}
+			data = append([]byte(nil), val...)
+			closer.Close()
+		}
+		var e AppLogEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			_ = batch.Delete(append([]byte(nil), primary...), nil)
+			continue
+		}
+		_ = batch.Delete([]byte(appLogPrimaryPrefix+e.ID), nil)
+		_ = batch.Delete([]byte(appLogLevelPrefix+e.Level+":"+e.ID), nil)
+		if e.Module != "" {
+			_ = batch.Delete([]byte(appLogModulePrefix+e.Module+":"+e.ID), nil)
+		}
+		deleted++
+		if deleted%500 == 0 {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return deleted, fmt.Errorf("applog: iterate: %w", err)
+	}
+	return deleted, flush()
+}
@@ -0,0 +1,97 @@
+// file: internal/database/pebble_applog_store_test.go
+// version: 1.0.0
+// guid: 6fa36784-9e20-4bd5-b7f1-2a6deb7267f9
+
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPebbleAppLogStore(t *testing.T) *PebbleAppLogStore {
+	t.Helper()
+	db, err := pebble.Open(filepath.Join(t.TempDir(), "applog.pebble"), &pebble.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return NewPebbleAppLogStore(db)
+}
+
+func appLogMessages(entries []AppLogEntry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Message
+	}
+	return out
+}
+
+func TestPebbleAppLogStore_Query(t *testing.T) {
+	s := newTestPebbleAppLogStore(t)
+	base := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, s.AppendAppLogs([]AppLogEntry{
+		{Time: base, Level: "debug", Module: "scanner", Message: "walking /in"},
+		{Time: base.Add(time.Second), Level: "info", Module: "scanner", Message: "found 3 books"},
+		{Time: base.Add(2 * time.Second), Level: "warn", Module: "metafetch", Message: "rate limited", Attrs: map[string]any{"source": "audible"}},
+		{Time: base.Add(3 * time.Second), Level: "error", Module: "organizer", Message: "rename failed"},
+		{Time: base.Add(4 * time.Second), Message: "no level"},
+	}))
+
+	all, err := s.QueryAppLogs(AppLogFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"no level", "rename failed", "rate limited", "found 3 books", "walking /in"}, appLogMessages(all))
+	assert.Equal(t, "info", all[0].Level, "missing level defaults to info")
+
+	got, err := s.QueryAppLogs(AppLogFilter{MinLevel: "warn"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rename failed", "rate limited"}, appLogMessages(got))
+
+	got, err = s.QueryAppLogs(AppLogFilter{Module: "scanner", MinLevel: "info"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"found 3 books"}, appLogMessages(got))
+
+	got, err = s.QueryAppLogs(AppLogFilter{Search: "AUDIBLE"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rate limited"}, appLogMessages(got), "search covers attribute values")
+
+	got, err = s.QueryAppLogs(AppLogFilter{Since: base.Add(time.Second), Until: base.Add(3 * time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rename failed", "rate limited", "found 3 books"}, appLogMessages(got))
+
+	// Paging with the last ID as the cursor walks back without overlap.
+	page, err := s.QueryAppLogs(AppLogFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	page, err = s.QueryAppLogs(AppLogFilter{Limit: 2, Before: page[1].ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rate limited", "found 3 books"}, appLogMessages(page))
+}
+
+func TestPebbleAppLogStore_Prune(t *testing.T) {
+	s := newTestPebbleAppLogStore(t)
+	old := time.Now().UTC().Add(-48 * time.Hour)
+	now := time.Now().UTC()
+	require.NoError(t, s.AppendAppLogs([]AppLogEntry{
+		{Time: old, Level: "debug", Module: "scanner", Message: "old debug"},
+		{Time: old.Add(time.Second), Level: "error", Module: "scanner", Message: "old error"},
+		{Time: now, Level: "debug", Module: "scanner", Message: "new debug"},
+	}))
+
+	n, err := s.PruneAppLogs(now.Add(-24*time.Hour), "debug")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got, err := s.QueryAppLogs(AppLogFilter{Module: "scanner"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new debug", "old error"}, appLogMessages(got), "module index entries are pruned too")
+
+	n, err = s.PruneAppLogs(now.Add(-24*time.Hour), "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got, err = s.QueryAppLogs(AppLogFilter{MinLevel: "error"})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -1,7 +1,7 @@
 // file: internal/database/pebble_store.go
-// version: 1.87.0
+// version: 1.100.0
 // guid: 0c1d2e3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package database
 
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/pebble/v2"
+	"github.com/cockroachdb/pebble/v2/vfs"
 	"github.com/falkcorp/audiobook-organizer/internal/fingerprint"
 	"github.com/falkcorp/audiobook-organizer/internal/util"
 	ulid "github.com/oklog/ulid/v2"
@@ -98,6 +99,7 @@ func isValidULID(s string) bool {
 // - import_path:<id>           -> ImportPath JSON
 // - import_path:path:<path>    -> import_path_id (for lookups)
 // - operation:<id>             -> Operation JSON
+// - opidx:<created_at hex>:<id> -> "" (operations by creation time)
 // - operationlog:<operation_id>:<timestamp>:<seq> -> OperationLog JSON
 // - preference:<key>           -> UserPreference JSON
 // - playlist:<id>              -> Playlist JSON
@@ -209,13 +211,29 @@ func (p *PebbleStore) writeCachedLibraryStats(s *LibraryStats) {
 	if err := p.db.Set([]byte(statsLibraryKey), data, pebble.Sync); err != nil {
 		slog.Error("pebble Set stats:library", "error", err)
 	}
+	p.writeLibraryStatsSnapshot(s)
 }
 
 // NewPebbleStore creates a new PebbleDB store
 func NewPebbleStore(path string) (*PebbleStore, error) {
-	db, err := pebble.Open(path, &pebble.Options{
+	return openPebbleStore(path, &pebble.Options{
 		FormatMajorVersion: pebble.FormatNewest,
 	})
+}
+
+// NewInMemoryPebbleStore creates a PebbleDB store on an in-memory
+// filesystem. It behaves exactly like an on-disk store but writes
+// nothing to disk and vanishes on Close; intended for tests (see the
+// storetest package).
+func NewInMemoryPebbleStore() (*PebbleStore, error) {
+	return openPebbleStore("", &pebble.Options{
+		FormatMajorVersion: pebble.FormatNewest,
+		FS:                 vfs.NewMem(),
+	})
+}
+
+func openPebbleStore(path string, opts *pebble.Options) (*PebbleStore, error) {
+	db, err := pebble.Open(path, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open PebbleDB: %w", err)
 	}
@@ -547,10 +565,16 @@ func (p *PebbleStore) DeleteAuthor(id int) error {
 		batch.Close()
 		return fmt.Errorf("pebble Delete author:%d: %w", id, err)
 	}
-	if err := batch.Delete([]byte(fmt.Sprintf("author:name:%s", strings.ToLower(author.Name))), nil); err != nil {
+	if err := p.dropAuthorNameIndex(batch, author); err != nil {
 		batch.Close()
 		return fmt.Errorf("pebble Delete author:name: %w", err)
 	}
+	for _, key := range authorExtKeys(author.Authority()) {
+		if err := batch.Delete([]byte(key), nil); err != nil {
+			batch.Close()
+			return fmt.Errorf("pebble Delete %s: %w", key, err)
+		}
+	}
 
 	// Delete aliases for this author (cascade)
 	if err := p.deleteAuthorAliases(batch, id); err != nil {
@@ -598,10 +622,14 @@ func (p *PebbleStore) UpdateAuthorName(id int, name string) error {
 	}
 
 	batch := p.db.NewBatch()
-	// Remove old name index
-	if err := batch.Delete([]byte(fmt.Sprintf("author:name:%s", strings.ToLower(author.Name))), nil); err != nil {
-		batch.Close()
-		return fmt.Errorf("pebble Delete author:name: %w", err)
+	// A case-only rename keeps the author's place in the name indexes.
+	reindex := !strings.EqualFold(author.Name, name)
+	if reindex {
+		// Remove old name index
+		if err := p.dropAuthorNameIndex(batch, author); err != nil {
+			batch.Close()
+			return fmt.Errorf("pebble Delete author:name: %w", err)
+		}
 	}
 
 	// Update author record
@@ -615,10 +643,22 @@ func (p *PebbleStore) UpdateAuthorName(id int, name string) error {
 		batch.Close()
 		return err
 	}
-	// Add new name index
-	if err := batch.Set([]byte(fmt.Sprintf("author:name:%s", strings.ToLower(name))), []byte(strconv.Itoa(id)), nil); err != nil {
-		batch.Close()
-		return err
+	// Add new name index. If another author already owns the name, this
+	// one joins it as a namesake instead of taking the index over.
+	if reindex {
+		indexKey := fmt.Sprintf("author:name:%s", strings.ToLower(name))
+		owner, err := p.GetRaw(indexKey)
+		if err != nil {
+			batch.Close()
+			return err
+		}
+		if owner != nil && string(owner) != strconv.Itoa(id) {
+			indexKey = authorSameNamePrefix(name) + strconv.Itoa(id)
+		}
+		if err := batch.Set([]byte(indexKey), []byte(strconv.Itoa(id)), nil); err != nil {
+			batch.Close()
+			return err
+		}
 	}
 
 	if err := batch.Commit(pebble.Sync); err != nil {
@@ -1363,8 +1403,22 @@ func (p *PebbleStore) GetBooksByWorkID(workID string) ([]Book, error) {
 // Book operations
 
 func (p *PebbleStore) GetAllBooks(limit, offset int) ([]Book, error) {
+	return p.GetAllBooksContext(context.Background(), limit, offset)
+}
+
+// GetAllBooksContext implements ContextStore. The Pebble scan checks
+// ctx every few hundred keys; the memdb path is in-memory and only
+// checked once it returns.
+func (p *PebbleStore) GetAllBooksContext(ctx context.Context, limit, offset int) ([]Book, error) {
 	if p.UseMemDB && p.mem() != nil {
-		return p.mem().GetAllBooks(limit, offset, nil)
+		books, err := p.mem().GetAllBooks(limit, offset, nil)
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return books, nil
 	}
 	var books []Book
 	iter, err := p.db.NewIter(&pebble.IterOptions{
@@ -1378,8 +1432,15 @@ func (p *PebbleStore) GetAllBooks(limit, offset int) ([]Book, error) {
 
 	skipped := 0
 	count := 0
+	scanned := 0
 
 	for iter.First(); iter.Valid(); iter.Next() {
+		if scanned%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		scanned++
 		// Skip path index keys (book:series and book:author indexes removed in Task 3.4)
 		key := string(iter.Key())
 		if strings.Contains(key, ":path:") {
@@ -1484,36 +1545,71 @@ func (p *PebbleStore) GetAllBookSummariesFiltered(limit, offset int, f BookSumma
 	if p.UseMemDB && p.mem() != nil {
 		return p.mem().GetBookSummaries(limit, offset, f)
 	}
-	// Pebble fallback: filter manually after a full scan. Matches the
-	// historical service behavior so we never regress correctness when
-	// memdb is unavailable.
-	summaries, err := p.GetAllBookSummaries_Pebble(0, 0)
+	// Pebble fallback: apply the whole filter to a full scan, title sort
+	// included. Callers treat this result as already filtered and
+	// paginated, the same as the memdb walker's.
+	if f.RestrictToIDs != nil && len(f.RestrictToIDs) == 0 {
+		return []BookSummary{}, nil
+	}
+	books, err := p.GetAllBooks(0, 0)
 	if err != nil {
 		return nil, err
 	}
-	filtered := summaries[:0]
-	excludeDeleted := f.MarkedForDeletion == nil
-	for _, s := range summaries {
-		if excludeDeleted {
-			if s.IsPrimaryVersion != nil && false { /* IsPrimaryVersion on BookSummary, MarkedForDeletion is not — handle conservatively below */
-			}
+	filtered := books[:0]
+	for i := range books {
+		if f.matchesBook(&books[i]) {
+			filtered = append(filtered, books[i])
 		}
-		if f.IsPrimaryVersion != nil {
-			eff := s.IsPrimaryVersion == nil || *s.IsPrimaryVersion
-			if eff != *f.IsPrimaryVersion {
-				continue
+	}
+	if f.SortBy == "title" {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			a, b := bookTitleSortKey(&filtered[i]), bookTitleSortKey(&filtered[j])
+			if f.SortAscending {
+				return a < b
 			}
-		}
-		filtered = append(filtered, s)
+			return a > b
+		})
+	}
+	if offset < 0 {
+		offset = 0
 	}
 	if offset >= len(filtered) {
-		return nil, nil
+		return []BookSummary{}, nil
 	}
 	end := len(filtered)
 	if limit > 0 && offset+limit < end {
 		end = offset + limit
 	}
-	return filtered[offset:end], nil
+	summaries := make([]BookSummary, 0, end-offset)
+	for _, b := range filtered[offset:end] {
+		summaries = append(summaries, BookSummary{
+			ID:                   b.ID,
+			Title:                b.Title,
+			AuthorID:             b.AuthorID,
+			SeriesID:             b.SeriesID,
+			SeriesSequence:       b.SeriesSequence,
+			FilePath:             b.FilePath,
+			Format:               b.Format,
+			Duration:             b.Duration,
+			OriginalFilename:     b.OriginalFilename,
+			FileSize:             b.FileSize,
+			FileHash:             b.FileHash,
+			OriginalFileHash:     b.OriginalFileHash,
+			OrganizedFileHash:    b.OrganizedFileHash,
+			LibraryState:         b.LibraryState,
+			QuarantinedAt:        b.QuarantinedAt,
+			QuarantineReason:     b.QuarantineReason,
+			CoverURL:             b.CoverURL,
+			Narrator:             b.Narrator,
+			CreatedAt:            b.CreatedAt,
+			UpdatedAt:            b.UpdatedAt,
+			MetadataUpdatedAt:    b.MetadataUpdatedAt,
+			IsPrimaryVersion:     b.IsPrimaryVersion,
+			VersionGroupID:       b.VersionGroupID,
+			MetadataReviewStatus: b.MetadataReviewStatus,
+		})
+	}
+	return summaries, nil
 }
 
 // GetAllBookSummaries_Pebble is the Pebble-backed implementation.
@@ -1863,6 +1959,35 @@ func (p *PebbleStore) GetBooksByTitleInDir(normalizedTitle, dirPath string) ([]B
 	return results, nil
 }
 
+// GetBooksInDir returns the books whose FilePath lives directly under
+// dirPath, read through the book:path: index.
+func (p *PebbleStore) GetBooksInDir(dirPath string) ([]Book, error) {
+	prefix := "book:path:" + strings.TrimRight(dirPath, "/") + "/"
+	iter, err := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte(prefix),
+		UpperBound: []byte(prefix + "\xff"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var ids []string
+	for iter.First(); iter.Valid(); iter.Next() {
+		if strings.Contains(string(iter.Key())[len(prefix):], "/") {
+			continue
+		}
+		ids = append(ids, string(iter.Value()))
+	}
+	results := make([]Book, 0, len(ids))
+	for _, id := range ids {
+		if book, err := p.GetBookByID(id); err == nil && book != nil {
+			results = append(results, *book)
+		}
+	}
+	return results, nil
+}
+
 func (p *PebbleStore) GetFolderDuplicates() ([][]Book, error) {
 	// PebbleStore doesn't support folder-based duplicate detection efficiently.
 	return nil, nil
@@ -2519,6 +2644,9 @@ func (p *PebbleStore) CreateBook(book *Book) (*Book, error) {
 	}); err != nil {
 		slog.Warn("pebble RecordPathChange on create", "book_id", book.ID, "error", err)
 	}
+	if _, err := AppendFileJournal(p, FileJournalEntry{Type: FileEventCreated, BookID: book.ID, Path: book.FilePath}); err != nil {
+		slog.Warn("pebble file journal on create", "book_id", book.ID, "error", err)
+	}
 
 	p.InvalidateLibraryStats()
 	p.MarkAllQuickQueriesDirty("create_book")
@@ -2710,6 +2838,10 @@ func (p *PebbleStore) UpdateBook(id string, book *Book) (*Book, error) {
 	p.MarkQuickQueryDirty("no_isbn", "update_book")
 	p.MarkQuickQueryDirty("in_import_path", "update_book")
 
+	if err := journalBookUpdate(p, oldBook, book); err != nil {
+		slog.Warn("pebble file journal on update", "book_id", id, "error", err)
+	}
+
 	// memdb write-through
 	p.UpsertBookToMemDB(context.Background(), book)
 
@@ -3037,11 +3169,21 @@ func (p *PebbleStore) DeleteBook(id string) error {
 		}
 	}
 
+	// Custom metadata field values.
+	customPrefix := customFieldValKey(id, "")
+	if err := batch.DeleteRange(customPrefix, prefixEnd(customPrefix), nil); err != nil {
+		batch.Close()
+		return err
+	}
+
 	if err := batch.Commit(pebble.Sync); err != nil {
 		return err
 	}
 	p.InvalidateLibraryStats()
 	p.MarkAllQuickQueriesDirty("delete_book")
+	if _, err := AppendFileJournal(p, FileJournalEntry{Type: FileEventDeleted, BookID: id, Path: book.FilePath}); err != nil {
+		slog.Warn("pebble file journal on delete", "book_id", id, "error", err)
+	}
 
 	// memdb write-through
 	p.DeleteBookFromMemDB(context.Background(), id)
@@ -3050,6 +3192,12 @@ func (p *PebbleStore) DeleteBook(id string) error {
 }
 
 func (p *PebbleStore) SearchBooks(query string, limit, offset int) ([]Book, error) {
+	return p.SearchBooksContext(context.Background(), query, limit, offset)
+}
+
+// SearchBooksContext implements ContextStore, checking ctx every few
+// hundred keys of the author and book scans.
+func (p *PebbleStore) SearchBooksContext(ctx context.Context, query string, limit, offset int) ([]Book, error) {
 	// Scan book:* index directly instead of loading all books into memory
 	// Pre-load author names for author field matching during iteration
 	authorNames := make(map[int]string)
@@ -3057,9 +3205,16 @@ func (p *PebbleStore) SearchBooks(query string, limit, offset int) ([]Book, erro
 		LowerBound: []byte("author:0"),
 		UpperBound: []byte("author:;"),
 	})
+	scanned := 0
 	if authErr == nil {
 		defer authIter.Close()
 		for authIter.First(); authIter.Valid(); authIter.Next() {
+			if scanned%256 == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+			scanned++
 			key := string(authIter.Key())
 			if strings.Contains(key, ":name:") || strings.Contains(key, ":book:") {
 				continue
@@ -3086,6 +3241,12 @@ func (p *PebbleStore) SearchBooks(query string, limit, offset int) ([]Book, erro
 	defer iter.Close()
 
 	for iter.First(); iter.Valid(); iter.Next() {
+		if scanned%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		scanned++
 		key := string(iter.Key())
 		// Skip non-primary book entries
 		if strings.Contains(key, ":") && !strings.HasPrefix(key, "book:") {
@@ -3124,6 +3285,12 @@ func (p *PebbleStore) SearchBooks(query string, limit, offset int) ([]Book, erro
 }
 
 func (p *PebbleStore) CountBooks() (int, error) {
+	return p.CountBooksContext(context.Background())
+}
+
+// CountBooksContext implements ContextStore, checking ctx every few
+// hundred keys of the book scan.
+func (p *PebbleStore) CountBooksContext(ctx context.Context) (int, error) {
 	count := 0
 	iter, err := p.db.NewIter(&pebble.IterOptions{
 		LowerBound: []byte("book:0"),
@@ -3134,7 +3301,14 @@ func (p *PebbleStore) CountBooks() (int, error) {
 	}
 	defer iter.Close()
 
+	scanned := 0
 	for iter.First(); iter.Valid(); iter.Next() {
+		if scanned%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+		scanned++
 		// Skip index keys
 		key := string(iter.Key())
 		if strings.Contains(key, ":path:") || strings.Contains(key, ":series:") ||
@@ -3543,6 +3717,7 @@ func (p *PebbleStore) computeLibraryStats() (*LibraryStats, error) {
 			codec = *b.Codec
 		}
 		stats.FormatDistribution[codec]++
+		stats.addBookDistributions(&b)
 
 		// Organized vs unorganized + per-import-path (primary versions only)
 		if b.IsPrimaryVersion == nil || *b.IsPrimaryVersion {
@@ -3937,6 +4112,7 @@ func (p *PebbleStore) CreateImportPath(path, name string) (*ImportPath, error) {
 		Enabled:   true,
 		CreatedAt: time.Now(),
 		BookCount: 0,
+		ScanMode:  ImportPathScanInitial,
 	}
 
 	data, err := json.Marshal(importPath)
@@ -4060,8 +4236,20 @@ func (p *PebbleStore) CreateOperation(id, opType string, folderPath *string) (*O
 		return nil, err
 	}
 
-	key := []byte(fmt.Sprintf("operation:%s", id))
-	if err := p.db.Set(key, data, pebble.Sync); err != nil {
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if prev, err := p.GetOperationByID(id); err == nil && prev != nil {
+		if err := batch.Delete(operationIdxKey(prev.CreatedAt, id), nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Set([]byte(fmt.Sprintf("operation:%s", id)), data, nil); err != nil {
+		return nil, err
+	}
+	if err := batch.Set(operationIdxKey(op.CreatedAt, id), nil, nil); err != nil {
+		return nil, err
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
 		return nil, err
 	}
 
@@ -4117,6 +4305,12 @@ func (p *PebbleStore) GetRecentOperations(limit int) ([]Operation, error) {
 }
 
 func (p *PebbleStore) ListOperations(limit, offset int) ([]Operation, int, error) {
+	return p.ListOperationsContext(context.Background(), limit, offset)
+}
+
+// ListOperationsContext implements ContextStore, checking ctx every few
+// hundred keys of the operation scan.
+func (p *PebbleStore) ListOperationsContext(ctx context.Context, limit, offset int) ([]Operation, int, error) {
 	var operations []Operation
 	iter, err := p.db.NewIter(&pebble.IterOptions{
 		LowerBound: []byte("operation:"),
@@ -4127,7 +4321,14 @@ func (p *PebbleStore) ListOperations(limit, offset int) ([]Operation, int, error
 	}
 	defer iter.Close()
 
+	scanned := 0
 	for iter.First(); iter.Valid(); iter.Next() {
+		if scanned%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, 0, err
+			}
+		}
+		scanned++
 		var op Operation
 		if err := json.Unmarshal(iter.Value(), &op); err != nil {
 			continue
@@ -5439,6 +5640,16 @@ func (p *PebbleStore) ListUserBookStatesByStatus(userID, status string, limit, o
 	return out, nil
 }
 
+// CountUserBookStatesByStatus counts the books ListUserBookStatesByStatus
+// pages through for userID and status.
+func (p *PebbleStore) CountUserBookStatesByStatus(userID, status string) (int, error) {
+	if userID == "" || status == "" {
+		return 0, nil
+	}
+	n, err := p.CountPrefix("idx:ubs:status:" + userID + ":" + status + ":")
+	return int(n), err
+}
+
 func (p *PebbleStore) ListUserPositionsSince(userID string, t time.Time) ([]UserPosition, error) {
 	if userID == "" {
 		return nil, nil
@@ -6563,8 +6774,13 @@ func (p *PebbleStore) IsHashBlocked(hash string) (bool, error) {
 }
 
 func (p *PebbleStore) AddBlockedHash(hash, reason string) error {
+	return p.AddBlockedHashWithAlgorithm(hash, "", reason)
+}
+
+func (p *PebbleStore) AddBlockedHashWithAlgorithm(hash, algorithm, reason string) error {
 	item := DoNotImport{
 		Hash:      hash,
+		Algorithm: algorithm,
 		Reason:    reason,
 		CreatedAt: time.Now(),
 	}
@@ -6719,6 +6935,10 @@ func (p *PebbleStore) DeleteOperationsByStatus(statuses []string) (int, error) {
 				batch.Close()
 				return 0, fmt.Errorf("pebble batch delete operation: %w", err)
 			}
+			if err := batch.Delete(operationIdxKey(op.CreatedAt, op.ID), nil); err != nil {
+				batch.Close()
+				return 0, fmt.Errorf("pebble batch delete operation: %w", err)
+			}
 			deleted++
 		}
 	}
@@ -6742,11 +6962,17 @@ func (p *PebbleStore) DeleteOperationWithLogs(id string) error {
 	batch := p.db.NewBatch()
 	defer batch.Close()
 
-	// Delete the operation record itself.
+	// Delete the operation record itself, and its creation-time index
+	// entry. An unreadable record leaves the entry behind; readers skip it.
 	opKey := []byte(fmt.Sprintf("operation:%s", id))
 	if err := batch.Delete(opKey, nil); err != nil {
 		return fmt.Errorf("batch delete operation key: %w", err)
 	}
+	if op, err := p.GetOperationByID(id); err == nil && op != nil {
+		if err := batch.Delete(operationIdxKey(op.CreatedAt, id), nil); err != nil {
+			return fmt.Errorf("batch delete operation index key: %w", err)
+		}
+	}
 
 	// Delete all associated log lines via prefix range iteration.
 	// Key format: operationlog:<operation_id>:<timestamp_nano>:<seq>
@@ -0,0 +1,135 @@
+// file: internal/database/pebble_store_bench_test.go
+// version: 1.0.0
+// guid: f926a7ec-3e00-4c1c-b180-9c8e732d7ef8
+
+package database
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Book read/write benchmarks at library scale. The SQLite backend was
+// removed in fable5 TASK-022, so only Pebble is measured; the sub-benchmark
+// names keep the scale in them so benchstat lines up across runs.
+//
+// The 100k scale takes minutes to seed; it only runs when
+// AUDIOBOOK_BENCH_LARGE=1 is set (the `make bench-large` target).
+
+func benchScales() []int {
+	scales := []int{10_000}
+	if os.Getenv("AUDIOBOOK_BENCH_LARGE") == "1" {
+		scales = append(scales, 100_000)
+	}
+	return scales
+}
+
+func openBenchPebble(b *testing.B) *PebbleStore {
+	b.Helper()
+	dir := b.TempDir() + "/bench_" + ulid.Make().String()
+	store, err := NewPebbleStore(dir)
+	if err != nil {
+		b.Fatalf("open pebble: %v", err)
+	}
+	b.Cleanup(func() { store.Close() })
+	return store
+}
+
+func seedBenchBooks(b *testing.B, store *PebbleStore, n int) []string {
+	b.Helper()
+	author, err := store.CreateAuthor("Bench Author")
+	if err != nil {
+		b.Fatal(err)
+	}
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		book, err := store.CreateBook(&Book{
+			Title:    fmt.Sprintf("Bench Book %06d", i),
+			AuthorID: &author.ID,
+			FilePath: fmt.Sprintf("/bench/%06d/book.m4b", i),
+			Format:   "m4b",
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		ids = append(ids, book.ID)
+	}
+	return ids
+}
+
+func BenchmarkPebbleCreateBook(b *testing.B) {
+	for _, n := range benchScales() {
+		b.Run(fmt.Sprintf("books=%d", n), func(b *testing.B) {
+			store := openBenchPebble(b)
+			seedBenchBooks(b, store, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := store.CreateBook(&Book{
+					Title:    fmt.Sprintf("New Book %d", i),
+					FilePath: fmt.Sprintf("/bench/new/%d.m4b", i),
+					Format:   "m4b",
+				}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPebbleGetBookByID(b *testing.B) {
+	for _, n := range benchScales() {
+		b.Run(fmt.Sprintf("books=%d", n), func(b *testing.B) {
+			store := openBenchPebble(b)
+			ids := seedBenchBooks(b, store, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := store.GetBookByID(ids[i%len(ids)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPebbleUpdateBook(b *testing.B) {
+	for _, n := range benchScales() {
+		b.Run(fmt.Sprintf("books=%d", n), func(b *testing.B) {
+			store := openBenchPebble(b)
+			ids := seedBenchBooks(b, store, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				id := ids[i%len(ids)]
+				book, err := store.GetBookByID(id)
+				if err != nil {
+					b.Fatal(err)
+				}
+				book.Title = fmt.Sprintf("Updated %d", i)
+				if _, err := store.UpdateBook(id, book); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPebbleGetAllBooksPage(b *testing.B) {
+	for _, n := range benchScales() {
+		b.Run(fmt.Sprintf("books=%d", n), func(b *testing.B) {
+			store := openBenchPebble(b)
+			seedBenchBooks(b, store, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := store.GetAllBooks(100, (i*100)%n); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 // file: internal/database/pebble_store_book_aggregates.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 7a8b9c0d-1e2f-3a4b-5c6d-7e8f9a0b1c2d
-// last-edited: 2026-06-10
+// last-edited: 2026-10-16
 
 // Package database — book aggregate recomputation from BookFiles.
 //
@@ -134,8 +134,12 @@ func (p *PebbleStore) RecomputeBookAggregates(bookID string) error {
 		return nil
 	}
 
-	// Apply changes.
+	// Apply changes. A duration summed from the files replaces any
+	// size/bitrate estimate.
 	book.Duration = &wantDuration
+	if writeDuration {
+		book.DurationEstimated = nil
+	}
 	book.FileSize = &wantFileSize
 
 	if _, err := p.UpdateBook(bookID, book); err != nil {
@@ -1,7 +1,7 @@
 // file: internal/database/pebble_store_ops_v2.go
-// version: 3.3.0
+// version: 3.5.0
 // guid: c3d4e5f6-a7b8-9c0d-1e2f-3a4b5c6d7e8f
-// last-edited: 2026-06-13
+// last-edited: 2026-10-17
 
 // pebble_store_ops_v2 implements OpsV2Store for PebbleDB (the primary production
 // database). Key schema (all prefixed with "opv2:"):
@@ -18,9 +18,11 @@
 package database
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -677,6 +679,151 @@ func (p *PebbleStore) PromoteToQueued(id string) error {
 	return nil
 }
 
+// ErrOperationNotQueued is returned by MoveQueuedOperationV2 when the op
+// has already left the queue.
+var ErrOperationNotQueued = errors.New("operation is not queued")
+
+// MoveQueuedOperationV2 changes a queued operation's priority and queued_at,
+// which together decide its place in the dispatch order, re-keying the
+// opv2:q: index entry in the same batch as the row.
+func (p *PebbleStore) MoveQueuedOperationV2(id string, priority int, queuedAt time.Time) error {
+	if priority < 0 || priority > 999 {
+		return fmt.Errorf("opv2: priority %d out of range 0-999", priority)
+	}
+	p.opsMu.Lock()
+	defer p.opsMu.Unlock()
+
+	var row OperationV2Row
+	if err := p.pebbleGetJSON(opv2OpKey(id), &row); err != nil {
+		return err
+	}
+	if row.ID == "" {
+		return fmt.Errorf("opv2: operation not found: %s", id)
+	}
+	if row.Status != "queued" {
+		return fmt.Errorf("opv2: move %s: %w", id, ErrOperationNotQueued)
+	}
+
+	oldKey := opv2QueueKey(row.Priority, row.QueuedAt, id)
+	row.Priority = priority
+	row.QueuedAt = queuedAt
+	data, err := json.Marshal(&row)
+	if err != nil {
+		return err
+	}
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Delete(oldKey, nil); err != nil {
+		return err
+	}
+	if err := batch.Set(opv2OpKey(id), data, nil); err != nil {
+		return err
+	}
+	if err := batch.Set(opv2QueueKey(priority, queuedAt, id), []byte(id), nil); err != nil {
+		return err
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+// FinishedOperationV2Statuses are the registry statuses an operation never
+// leaves, and so the ones PruneOperationsV2 may delete.
+var FinishedOperationV2Statuses = []string{"completed", "failed", "canceled", "interrupted_dropped"}
+
+// OperationV2Pruner is implemented by stores that can delete old registry
+// operations.
+type OperationV2Pruner interface {
+	// PruneOperationsV2 deletes operations in one of statuses (default
+	// FinishedOperationV2Statuses) that finished before cutoff, with their
+	// log lines, errors and saved state. Returns the number deleted.
+	PruneOperationsV2(ctx context.Context, cutoff time.Time, statuses []string) (int, error)
+}
+
+// PruneOperationsV2 implements OperationV2Pruner. An operation that never
+// recorded completed_at is aged by queued_at. Statuses outside
+// FinishedOperationV2Statuses are ignored so a live op is never deleted.
+func (p *PebbleStore) PruneOperationsV2(ctx context.Context, cutoff time.Time, statuses []string) (int, error) {
+	if len(statuses) == 0 {
+		statuses = FinishedOperationV2Statuses
+	}
+	want := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		if slices.Contains(FinishedOperationV2Statuses, s) {
+			want[s] = true
+		}
+	}
+	prunable := func(row *OperationV2Row) bool {
+		finished := row.QueuedAt
+		if row.CompletedAt != nil {
+			finished = *row.CompletedAt
+		}
+		return want[row.Status] && finished.Before(cutoff)
+	}
+
+	prefix := []byte("opv2:op:")
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: prefix, UpperBound: prefixEnd(prefix)})
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for iter.First(); iter.Valid(); iter.Next() {
+		var row OperationV2Row
+		if err := json.Unmarshal(iter.Value(), &row); err != nil {
+			continue
+		}
+		if prunable(&row) {
+			ids = append(ids, row.ID)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		iter.Close()
+		return 0, err
+	}
+	iter.Close()
+
+	deleted := 0
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		ok, err := p.pruneOperationV2(id, prunable)
+		if err != nil {
+			return deleted, fmt.Errorf("opv2: prune %s: %w", id, err)
+		}
+		if ok {
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// pruneOperationV2 deletes one operation and everything keyed by its ID,
+// re-checking the row under opsMu in case it changed since the scan.
+func (p *PebbleStore) pruneOperationV2(id string, prunable func(*OperationV2Row) bool) (bool, error) {
+	p.opsMu.Lock()
+	defer p.opsMu.Unlock()
+
+	var row OperationV2Row
+	if err := p.pebbleGetJSON(opv2OpKey(id), &row); err != nil {
+		return false, err
+	}
+	if row.ID == "" || !prunable(&row) {
+		return false, nil
+	}
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	for _, key := range [][]byte{opv2OpKey(id), opv2QueueKey(row.Priority, row.QueuedAt, id), opv2ActKey(id), opv2StateKey(id)} {
+		if err := batch.Delete(key, nil); err != nil {
+			return false, err
+		}
+	}
+	for _, prefix := range []string{"opv2:log:" + id + ":", "opv2:err:" + id + ":"} {
+		if err := batch.DeleteRange([]byte(prefix), prefixEnd([]byte(prefix)), nil); err != nil {
+			return false, err
+		}
+	}
+	return true, batch.Commit(pebble.Sync)
+}
+
 // ── M3 batch bucket (journaled pending subjects) ────────────────────────────
 //
 // Keyspace:
@@ -1,14 +1,16 @@
 // file: internal/database/pebble_store_ops_v2_test.go
-// version: 1.1.0
+// version: 1.3.0
 // guid: d7e8f9a0-b1c2-4d3e-5f6a-7b8c9d0e1f2a
-// last-edited: 2026-06-13
+// last-edited: 2026-10-17
 
 package database
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/pebble/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -173,3 +175,100 @@ func TestOperationV2Row_SubjectFields_RoundTrip(t *testing.T) {
 	require.Equal(t, row.Requirements, got.Requirements)
 	require.Equal(t, uint64(7), got.ReqSnapshotRev)
 }
+
+// TestMoveQueuedOperationV2 verifies a move re-keys the queue index so
+// ListQueuedOperationsV2 returns the new order.
+func TestMoveQueuedOperationV2(t *testing.T) {
+	store, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	s := store.(*PebbleStore)
+
+	base := time.Now().UTC()
+	for i, id := range []string{"a", "b", "c"} {
+		row := buildTestOpRow(id, "queued")
+		row.QueuedAt = base.Add(time.Duration(i) * time.Second)
+		require.NoError(t, s.InsertOperationV2(row))
+	}
+	ids := func() []string {
+		rows, err := s.ListQueuedOperationsV2()
+		require.NoError(t, err)
+		out := make([]string, len(rows))
+		for i, r := range rows {
+			out[i] = r.ID
+		}
+		return out
+	}
+
+	require.NoError(t, s.MoveQueuedOperationV2("c", 5, base.Add(-time.Second)))
+	require.Equal(t, []string{"c", "a", "b"}, ids())
+	require.NoError(t, s.MoveQueuedOperationV2("b", 6, base.Add(time.Second)))
+	require.Equal(t, []string{"b", "c", "a"}, ids())
+
+	row, err := s.GetOperationV2("b")
+	require.NoError(t, err)
+	require.Equal(t, 6, row.Priority)
+
+	require.NoError(t, s.UpdateOperationV2Status("a", "running", &base, nil, nil))
+	require.ErrorIs(t, s.MoveQueuedOperationV2("a", 5, base), ErrOperationNotQueued)
+	require.Error(t, s.MoveQueuedOperationV2("b", 1000, base))
+	require.Equal(t, []string{"b", "c"}, ids())
+}
+
+// TestPruneOperationsV2 verifies only old finished ops are pruned, together
+// with their logs, errors and state.
+func TestPruneOperationsV2(t *testing.T) {
+	store, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	s := store.(*PebbleStore)
+
+	now := time.Now().UTC()
+	old := now.Add(-48 * time.Hour)
+	for _, tc := range []struct {
+		id, status string
+		finished   time.Time
+	}{
+		{"old-done", "completed", old},
+		{"old-failed", "failed", old},
+		{"new-done", "completed", now},
+		{"old-running", "running", old},
+	} {
+		row := buildTestOpRow(tc.id, tc.status)
+		row.QueuedAt = tc.finished
+		if tc.status != "running" {
+			row.CompletedAt = &tc.finished
+		}
+		require.NoError(t, s.InsertOperationV2(row))
+		require.NoError(t, s.AppendOpLogsV2([]OpLogV2Row{{OperationID: tc.id, Level: "info", Message: "hi", CreatedAt: tc.finished}}))
+		require.NoError(t, s.InsertOpErrorV2(OpErrorV2Row{OperationID: tc.id, Message: "boom", OccurredAt: tc.finished}))
+		require.NoError(t, s.UpsertOpStateV2(OpStateV2Row{OperationID: tc.id, WrittenAt: tc.finished}))
+	}
+
+	cutoff := now.Add(-24 * time.Hour)
+	n, err := s.PruneOperationsV2(context.Background(), cutoff, []string{"failed", "running"})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	n, err = s.PruneOperationsV2(context.Background(), cutoff, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	for id, kept := range map[string]bool{"old-done": false, "old-failed": false, "new-done": true, "old-running": true} {
+		row, err := s.GetOperationV2(id)
+		require.NoError(t, err)
+		require.Equal(t, kept, row != nil, id)
+		logs, err := s.GetOpLogsV2(id, 0)
+		require.NoError(t, err)
+		require.Equal(t, kept, len(logs) == 1, id)
+		state, err := s.GetOpStateV2(id)
+		require.NoError(t, err)
+		require.Equal(t, kept, state != nil, id)
+	}
+	iter, err := s.db.NewIter(&pebble.IterOptions{LowerBound: []byte("opv2:err:old-"), UpperBound: []byte("opv2:err:old.")})
+	require.NoError(t, err)
+	errKeys := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		errKeys++
+	}
+	require.NoError(t, iter.Close())
+	require.Equal(t, 1, errKeys) // old-running's
+}
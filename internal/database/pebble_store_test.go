@@ -1,5 +1,5 @@
 // file: internal/database/pebble_store_test.go
-// version: 1.3.0
+// version: 1.4.0
 // guid: 4d5e6f7a-8b9c-0d1e-2f3a-4b5c6d7e8f9a
 
 package database
@@ -228,7 +228,7 @@ func TestPebbleGetBooksBySeriesID(t *testing.T) {
 			Title:          "Series Book " + string(rune('A'+i)),
 			FilePath:       "/test/series/book" + string(rune('A'+i)) + ".mp3",
 			SeriesID:       &series.ID,
-			SeriesSequence: &seq,
+			SeriesSequence: NewSeriesSeq(seq),
 		}
 		_, err := store.CreateBook(book)
 		if err != nil {
@@ -0,0 +1,80 @@
+// file: internal/database/pending_purges.go
+// version: 1.0.0
+// guid: 5b8e2d71-9c4a-4f36-a0d7-3e1f6c9b8a52
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Pending purges are the first phase of a hard delete with file removal:
+// the book's files have been moved to the trash and its record is kept
+// until PurgeAfter, so restoring is a matter of moving the files back.
+// Records live in the RawKV space under "pending_purge:<book ID>".
+
+const pendingPurgePrefix = "pending_purge:"
+
+// TrashedFile is one file moved to the trash, with where it came from.
+type TrashedFile struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PendingPurge records a book waiting out the trash retention window.
+type PendingPurge struct {
+	BookID     string        `json:"book_id"`
+	Title      string        `json:"title"`
+	TrashDir   string        `json:"trash_dir"`
+	Files      []TrashedFile `json:"files"`
+	TrashedAt  time.Time     `json:"trashed_at"`
+	PurgeAfter time.Time     `json:"purge_after"`
+}
+
+// SavePendingPurge stores p, replacing any record for the same book.
+func SavePendingPurge(store RawKVStore, p PendingPurge) error {
+	blob, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal pending purge: %w", err)
+	}
+	return store.SetRaw(pendingPurgePrefix+p.BookID, blob)
+}
+
+// GetPendingPurge returns the pending purge for bookID, or nil when the
+// book isn't waiting to be purged.
+func GetPendingPurge(store RawKVStore, bookID string) (*PendingPurge, error) {
+	blob, err := store.GetRaw(pendingPurgePrefix + bookID)
+	if err != nil || blob == nil {
+		return nil, err
+	}
+	var p PendingPurge
+	if err := json.Unmarshal(blob, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal pending purge %s: %w", bookID, err)
+	}
+	return &p, nil
+}
+
+// ListPendingPurges returns every pending purge.
+func ListPendingPurges(store RawKVStore) ([]PendingPurge, error) {
+	pairs, err := store.ScanPrefix(pendingPurgePrefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]PendingPurge, 0, len(pairs))
+	for _, kv := range pairs {
+		var p PendingPurge
+		if err := json.Unmarshal(kv.Value, &p); err != nil {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// DeletePendingPurge drops the record for bookID.
+func DeletePendingPurge(store RawKVStore, bookID string) error {
+	return store.DeleteRaw(pendingPurgePrefix + bookID)
+}
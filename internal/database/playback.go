@@ -0,0 +1,75 @@
+// file: internal/database/playback.go
+// version: 1.0.0
+// guid: 7e3b9c50-2d4f-4a81-b6e7-0c5a8f1d2e94
+// last-edited: 2026-10-17
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"sort"
+)
+
+// PlaybackStore keys progress, events and stats by a book's numeric ID:
+// the CRC-32 of its ULID, the same number the legacy segment API reports
+// as book_id. PlaybackProgress and PlaybackEvent also carry the ULID as
+// AudiobookID so they can be listed back as books.
+
+// BookNumericID returns the numeric ID PlaybackStore keys bookID under.
+func BookNumericID(bookID string) int {
+	return int(crc32.ChecksumIEEE([]byte(bookID)))
+}
+
+// PlaybackHistoryStore is implemented by stores that can list a user's
+// progress and add listening time without counting a play. It is kept
+// out of PlaybackStore so wrappers and mocks only need it when they
+// persist playback.
+type PlaybackHistoryStore interface {
+	// ListPlaybackProgress returns userID's progress on every book,
+	// most recently updated first.
+	ListPlaybackProgress(userID string) ([]PlaybackProgress, error)
+	// AddBookListenSeconds adds to a book's listening time; plays are
+	// counted by IncrementBookPlayStats.
+	AddBookListenSeconds(bookNumericID int, seconds int) error
+}
+
+// ListPlaybackProgress lists userID's progress through the
+// PlaybackHistoryStore behind store, looking through Unwrap layers.
+// Other stores keep no listing and return none.
+func ListPlaybackProgress(store any, userID string) ([]PlaybackProgress, error) {
+	if hs, ok := unwrapStore[PlaybackHistoryStore](store); ok {
+		return hs.ListPlaybackProgress(userID)
+	}
+	return nil, nil
+}
+
+// AddBookListenSeconds adds listening time through the
+// PlaybackHistoryStore behind store; other stores drop it.
+func AddBookListenSeconds(store any, bookNumericID int, seconds int) error {
+	if hs, ok := unwrapStore[PlaybackHistoryStore](store); ok {
+		return hs.AddBookListenSeconds(bookNumericID, seconds)
+	}
+	return nil
+}
+
+func (p *PebbleStore) ListPlaybackProgress(userID string) ([]PlaybackProgress, error) {
+	pairs, err := p.ScanPrefix(fmt.Sprintf("playp:%s:", userID))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]PlaybackProgress, 0, len(pairs))
+	for _, kv := range pairs {
+		var pr PlaybackProgress
+		if err := json.Unmarshal(kv.Value, &pr); err == nil {
+			list = append(list, pr)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].UpdatedAt.After(list[j].UpdatedAt) })
+	return list, nil
+}
+
+func (p *PebbleStore) AddBookListenSeconds(bookNumericID int, seconds int) error {
+	return p.incrementIntKey(fmt.Sprintf("stats:book:listen_seconds:%d", bookNumericID), seconds)
+}
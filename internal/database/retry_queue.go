@@ -0,0 +1,150 @@
+// file: internal/database/retry_queue.go
+// version: 1.0.0
+// guid: 5b8e2f14-7c3a-4d96-a1e0-3f9b6d2c8e47
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+)
+
+// What a retry entry re-runs.
+const (
+	RetryKindScan     = "scan"     // metadata extraction of a scanned file
+	RetryKindOrganize = "organize" // moving a book into the library
+)
+
+// Retry entry states. Exhausted entries stay listed until they are
+// retried by hand or dismissed.
+const (
+	RetryStatusPending   = "pending"
+	RetryStatusExhausted = "exhausted"
+)
+
+// RetryEntry is a file whose scan or organize step failed for a
+// transient reason and is waiting for another attempt.
+type RetryEntry struct {
+	// ID is derived from Kind and the path (scan) or book ID (organize),
+	// so a file has at most one entry per kind. See RetryEntryID.
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	BookID string `json:"book_id,omitempty"`
+	// ImportPath is the import path a scanned file was found under.
+	ImportPath    string    `json:"import_path,omitempty"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	MaxAttempts   int       `json:"max_attempts"`
+	LastError     string    `json:"last_error"`
+	FirstFailedAt time.Time `json:"first_failed_at"`
+	LastFailedAt  time.Time `json:"last_failed_at"`
+	// NextAttemptAt is zero once the entry is exhausted.
+	NextAttemptAt time.Time `json:"next_attempt_at,omitzero"`
+}
+
+// RetryEntryID is the ID of the kind entry for target (a path or a
+// book ID). Targets are hashed so IDs are safe in URL paths.
+func RetryEntryID(kind, target string) string {
+	sum := sha256.Sum256([]byte(target))
+	return kind + "-" + hex.EncodeToString(sum[:8])
+}
+
+// RetryQueueStore is implemented by stores that keep the failed-file
+// retry queue.
+type RetryQueueStore interface {
+	PutRetryEntry(e RetryEntry) error
+	// GetRetryEntry returns nil, nil when id is not queued.
+	GetRetryEntry(id string) (*RetryEntry, error)
+	// ListRetryEntries returns every entry, soonest next attempt first
+	// and exhausted entries last.
+	ListRetryEntries() ([]RetryEntry, error)
+	DeleteRetryEntry(id string) error
+}
+
+const retryEntryPrefix = "retry_queue:"
+
+// PutRetryEntry stores e, replacing any entry with the same ID.
+func (p *PebbleStore) PutRetryEntry(e RetryEntry) error {
+	if p == nil || p.db == nil {
+		return fmt.Errorf("pebble store not initialized")
+	}
+	if e.ID == "" {
+		return fmt.Errorf("retry entry id is required")
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	if err := p.db.Set([]byte(retryEntryPrefix+e.ID), data, pebble.Sync); err != nil {
+		return fmt.Errorf("pebble Set: %w", err)
+	}
+	return nil
+}
+
+// GetRetryEntry implements RetryQueueStore.
+func (p *PebbleStore) GetRetryEntry(id string) (*RetryEntry, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	val, closer, err := p.db.Get([]byte(retryEntryPrefix + id))
+	if err == pebble.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pebble Get: %w", err)
+	}
+	defer closer.Close()
+	var e RetryEntry
+	if err := json.Unmarshal(val, &e); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+	return &e, nil
+}
+
+// ListRetryEntries implements RetryQueueStore.
+func (p *PebbleStore) ListRetryEntries() ([]RetryEntry, error) {
+	if p == nil || p.db == nil {
+		return nil, fmt.Errorf("pebble store not initialized")
+	}
+	lower := []byte(retryEntryPrefix)
+	iter, err := p.db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: prefixEnd(lower)})
+	if err != nil {
+		return nil, fmt.Errorf("pebble NewIter: %w", err)
+	}
+	defer iter.Close()
+	var out []RetryEntry
+	for iter.First(); iter.Valid(); iter.Next() {
+		var e RetryEntry
+		if err := json.Unmarshal(iter.Value(), &e); err != nil {
+			return nil, fmt.Errorf("json unmarshal: %w", err)
+		}
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		pi, pj := out[i].Status == RetryStatusPending, out[j].Status == RetryStatusPending
+		if pi != pj {
+			return pi
+		}
+		return out[i].NextAttemptAt.Before(out[j].NextAttemptAt)
+	})
+	return out, nil
+}
+
+// DeleteRetryEntry removes id; deleting an absent entry is not an error.
+func (p *PebbleStore) DeleteRetryEntry(id string) error {
+	if p == nil || p.db == nil {
+		return fmt.Errorf("pebble store not initialized")
+	}
+	if err := p.db.Delete([]byte(retryEntryPrefix+id), pebble.Sync); err != nil {
+		return fmt.Errorf("pebble Delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// file: internal/database/retry_queue_test.go
+// version: 1.0.0
+// guid: 7a2f9d41-c6e3-4b80-95d1-e4b06c37f218
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryQueue_CRUDAndOrder(t *testing.T) {
+	store, cleanup := setupPebbleTestDB(t)
+	defer cleanup()
+	rq := store.(RetryQueueStore)
+
+	now := time.Now().UTC()
+	later := RetryEntry{ID: RetryEntryID(RetryKindScan, "/b"), Kind: RetryKindScan, Path: "/b",
+		Status: RetryStatusPending, NextAttemptAt: now.Add(time.Hour)}
+	sooner := RetryEntry{ID: RetryEntryID(RetryKindScan, "/a"), Kind: RetryKindScan, Path: "/a",
+		Status: RetryStatusPending, NextAttemptAt: now.Add(time.Minute)}
+	done := RetryEntry{ID: RetryEntryID(RetryKindOrganize, "b1"), Kind: RetryKindOrganize, BookID: "b1",
+		Status: RetryStatusExhausted}
+	for _, e := range []RetryEntry{done, later, sooner} {
+		require.NoError(t, rq.PutRetryEntry(e))
+	}
+	assert.Error(t, rq.PutRetryEntry(RetryEntry{}))
+
+	all, err := rq.ListRetryEntries()
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "/a", all[0].Path)
+	assert.Equal(t, "/b", all[1].Path)
+	assert.Equal(t, "b1", all[2].BookID)
+
+	got, err := rq.GetRetryEntry(sooner.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "/a", got.Path)
+
+	require.NoError(t, rq.DeleteRetryEntry(sooner.ID))
+	require.NoError(t, rq.DeleteRetryEntry(sooner.ID))
+	got, err = rq.GetRetryEntry(sooner.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRetryEntryID(t *testing.T) {
+	id := RetryEntryID(RetryKindScan, "/lib/a b/c.m4b")
+	assert.Equal(t, id, RetryEntryID(RetryKindScan, "/lib/a b/c.m4b"))
+	assert.NotEqual(t, id, RetryEntryID(RetryKindOrganize, "/lib/a b/c.m4b"))
+	assert.Regexp(t, `^scan-[0-9a-f]{16}$`, id)
+}
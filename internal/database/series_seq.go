@@ -0,0 +1,199 @@
+// file: internal/database/series_seq.go
+// version: 1.0.0
+// guid: 919837a9-8354-40c7-9cf0-fb59fe03bb48
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SeriesSeq is a book's position within its series. Most positions are
+// whole numbers, but novellas routinely sit at 1.5 or 2.5 and some books
+// are labelled ("prequel", "short story") rather than numbered.
+//
+// Values are stored normalized: numeric positions lose leading zeros and
+// trailing fractional zeros ("01" → "1", "2.50" → "2.5"); labels are
+// trimmed and lowercased. On the wire whole numbers stay JSON numbers so
+// existing API consumers and legacy Pebble rows (written when the field
+// was *int) round-trip unchanged; everything else is a JSON string.
+type SeriesSeq string
+
+// prequelLabels sort as position 0, ahead of book 1.
+var prequelLabels = map[string]bool{
+	"prequel":  true,
+	"prologue": true,
+	"origin":   true,
+}
+
+// NewSeriesSeq returns a pointer to the whole-number position n.
+func NewSeriesSeq(n int) *SeriesSeq {
+	s := SeriesSeq(strconv.Itoa(n))
+	return &s
+}
+
+// ParseSeriesSeq normalizes raw into a SeriesSeq. Empty input returns nil.
+// A leading "#" or "book"/"vol" prefix is ignored ("Book 3" → "3").
+func ParseSeriesSeq(raw string) *SeriesSeq {
+	v := strings.ToLower(strings.TrimSpace(raw))
+	for _, p := range []string{"#", "book", "vol.", "vol"} {
+		if rest, ok := strings.CutPrefix(v, p); ok {
+			if trimmed := strings.TrimSpace(rest); trimmed != "" {
+				if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+					v = trimmed
+					break
+				}
+			}
+		}
+	}
+	if v == "" {
+		return nil
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		v = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	s := SeriesSeq(v)
+	return &s
+}
+
+// String returns the normalized value.
+func (s SeriesSeq) String() string { return string(s) }
+
+// Float returns the numeric position. Prequel labels report 0; other
+// labels report ok=false.
+func (s SeriesSeq) Float() (float64, bool) {
+	if prequelLabels[string(s)] {
+		return 0, true
+	}
+	f, err := strconv.ParseFloat(string(s), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// Int returns the whole-number part of the position, or 0 for labels.
+// Callers that only understand integer positions (tag writers, the
+// legacy series_index field) use this.
+func (s SeriesSeq) Int() int {
+	f, ok := s.Float()
+	if !ok {
+		return 0
+	}
+	return int(math.Floor(f))
+}
+
+// IsWhole reports whether s is an integer position.
+func (s SeriesSeq) IsWhole() bool {
+	_, err := strconv.Atoi(string(s))
+	return err == nil
+}
+
+// JSONValue returns s as an int for whole positions, float64 for other
+// numeric positions, and string for labels — the shape provenance and
+// comparison maps expect.
+func (s SeriesSeq) JSONValue() any {
+	if n, err := strconv.Atoi(string(s)); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(string(s), 64); err == nil {
+		return f
+	}
+	return string(s)
+}
+
+// CompareSeriesSeq orders positions numerically (1 < 1.5 < 2 < 10),
+// prequel labels as 0, other labels after all numbers (alphabetically),
+// and nil last. Returns -1, 0 or +1.
+func CompareSeriesSeq(a, b *SeriesSeq) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	fa, okA := a.Float()
+	fb, okB := b.Float()
+	switch {
+	case okA && okB:
+		if fa < fb {
+			return -1
+		}
+		if fa > fb {
+			return 1
+		}
+		return 0
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return strings.Compare(string(*a), string(*b))
+}
+
+// seqFormatSpec matches the printf-style specs accepted by Format:
+// optional zero flag and width, optional precision, then f or d.
+var seqFormatSpec = regexp.MustCompile(`^(0?\d*)(\.\d+)?([fd])$`)
+
+// Format renders s using a printf-style spec without the leading "%",
+// e.g. "04.1f" → "01.5", "02d" → "03". With "d", a fractional position
+// keeps its fraction after the padded whole part ("02d" on 1.5 → "01.5")
+// rather than being truncated. Labels and invalid specs return String().
+func (s SeriesSeq) Format(spec string) string {
+	m := seqFormatSpec.FindStringSubmatch(spec)
+	f, ok := s.Float()
+	if m == nil || !ok || prequelLabels[string(s)] {
+		return s.String()
+	}
+	if m[3] == "f" {
+		return fmt.Sprintf("%"+spec, f)
+	}
+	// Split the normalized string rather than subtracting floats so 1.1
+	// stays "1.1" instead of picking up binary rounding noise.
+	_, frac, _ := strings.Cut(s.String(), ".")
+	out := fmt.Sprintf("%"+m[1]+"d", int(math.Floor(f)))
+	if frac != "" {
+		out += "." + frac
+	}
+	return out
+}
+
+// MarshalJSON writes whole numbers as JSON numbers and everything else
+// as a JSON string.
+func (s SeriesSeq) MarshalJSON() ([]byte, error) {
+	if s.IsWhole() {
+		return []byte(s), nil
+	}
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON accepts a JSON number (legacy int rows, 1.5) or a string.
+func (s *SeriesSeq) UnmarshalJSON(data []byte) error {
+	var raw string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	} else {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("series sequence: %w", err)
+		}
+		raw = n.String()
+	}
+	parsed := ParseSeriesSeq(raw)
+	if parsed == nil {
+		*s = ""
+		return nil
+	}
+	*s = *parsed
+	return nil
+}
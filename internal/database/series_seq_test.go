@@ -0,0 +1,87 @@
+// file: internal/database/series_seq_test.go
+// version: 1.0.0
+// guid: 2eb22ff8-4acc-4587-935a-87b0cdf8c352
+// last-edited: 2026-10-16
+
+package database
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeriesSeq(t *testing.T) {
+	cases := map[string]string{
+		"01":      "1",
+		"2.50":    "2.5",
+		" 1.5 ":   "1.5",
+		"Book 3":  "3",
+		"#4":      "4",
+		"Prequel": "prequel",
+	}
+	for in, want := range cases {
+		got := ParseSeriesSeq(in)
+		require.NotNil(t, got, in)
+		assert.Equal(t, want, got.String(), in)
+	}
+	assert.Nil(t, ParseSeriesSeq("  "))
+}
+
+func TestCompareSeriesSeq_SortOrder(t *testing.T) {
+	seqs := []*SeriesSeq{
+		ParseSeriesSeq("10"), nil, ParseSeriesSeq("short story"),
+		ParseSeriesSeq("2"), ParseSeriesSeq("1.5"), ParseSeriesSeq("prequel"), ParseSeriesSeq("1"),
+	}
+	sort.SliceStable(seqs, func(i, j int) bool { return CompareSeriesSeq(seqs[i], seqs[j]) < 0 })
+
+	var got []string
+	for _, s := range seqs {
+		if s == nil {
+			got = append(got, "<nil>")
+			continue
+		}
+		got = append(got, s.String())
+	}
+	assert.Equal(t, []string{"prequel", "1", "1.5", "2", "10", "short story", "<nil>"}, got)
+}
+
+func TestSeriesSeqFormat(t *testing.T) {
+	tests := []struct {
+		seq, spec, want string
+	}{
+		{"1.5", "04.1f", "01.5"},
+		{"3", "04.1f", "03.0"},
+		{"3", "02d", "03"},
+		{"1.5", "02d", "01.5"},
+		{"1.1", "d", "1.1"},
+		{"prequel", "02d", "prequel"},
+		{"3", "bogus", "3"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ParseSeriesSeq(tt.seq).Format(tt.spec), "%s with %s", tt.seq, tt.spec)
+	}
+}
+
+// TestSeriesSeqJSON_LegacyIntRows verifies rows written when the field was
+// *int still decode, and whole positions re-encode as numbers.
+func TestSeriesSeqJSON_LegacyIntRows(t *testing.T) {
+	var b Book
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"b1","series_sequence":3}`), &b))
+	require.NotNil(t, b.SeriesSequence)
+	assert.Equal(t, SeriesSeq("3"), *b.SeriesSequence)
+
+	out, err := json.Marshal(Book{ID: "b1", SeriesSequence: NewSeriesSeq(3)})
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"series_sequence":3`)
+
+	out, err = json.Marshal(Book{ID: "b2", SeriesSequence: ParseSeriesSeq("1.5")})
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"series_sequence":"1.5"`)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"series_sequence":2.5}`), &b))
+	assert.Equal(t, SeriesSeq("2.5"), *b.SeriesSequence)
+}
@@ -1,7 +1,7 @@
 // file: internal/database/store.go
-// version: 2.78.0
+// version: 2.95.0
 // guid: 8a9b0c1d-2e3f-4a5b-6c7d-8e9f0a1b2c3d
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package database
 
@@ -53,6 +53,7 @@ type Store interface {
 	RejectedMetadataStore
 	OpsV2Store
 	MetadataCacheStore
+	ContextStore
 }
 
 // BookAlternativeTitle represents a variant name for a book — romaji
@@ -75,6 +76,11 @@ type BookAlternativeTitle struct {
 type Author struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
+	// Authority data telling apart authors who share a name; see
+	// AuthorAuthorityStore.
+	OpenLibraryID string `json:"openlibrary_id,omitempty"`
+	GoodreadsID   string `json:"goodreads_id,omitempty"`
+	BirthYear     *int   `json:"birth_year,omitempty"`
 }
 
 // AuthorAlias represents a pen name, handle, or alternative name for an author
@@ -118,14 +124,17 @@ type Series struct {
 
 // Book represents an audiobook
 type Book struct {
-	ID             string `json:"id"` // ULID format
-	Title          string `json:"title"`
-	AuthorID       *int   `json:"author_id,omitempty"`
-	SeriesID       *int   `json:"series_id,omitempty"`
-	SeriesSequence *int   `json:"series_sequence,omitempty"`
-	FilePath       string `json:"file_path"`
-	Format         string `json:"format,omitempty"`
-	Duration       *int   `json:"duration,omitempty"`
+	ID             string     `json:"id"` // ULID format
+	Title          string     `json:"title"`
+	AuthorID       *int       `json:"author_id,omitempty"`
+	SeriesID       *int       `json:"series_id,omitempty"`
+	SeriesSequence *SeriesSeq `json:"series_sequence,omitempty"`
+	FilePath       string     `json:"file_path"`
+	Format         string     `json:"format,omitempty"`
+	Duration       *int       `json:"duration,omitempty"`
+	// DurationEstimated marks Duration as derived from file size and
+	// bitrate rather than read from the audio stream.
+	DurationEstimated *bool `json:"duration_estimated,omitempty"`
 	// Extended metadata (optional)
 	WorkID               *string `json:"work_id,omitempty"`
 	Narrator             *string `json:"narrator,omitempty"`
@@ -139,6 +148,9 @@ type Book struct {
 	ISBN10               *string `json:"isbn10,omitempty"`
 	ISBN13               *string `json:"isbn13,omitempty"`
 	ASIN                 *string `json:"asin,omitempty"`
+	// Explicit flags mature content; content filter profiles with
+	// block_explicit hide it.
+	Explicit *bool `json:"explicit,omitempty"`
 	// External provider IDs
 	OpenLibraryID *string `json:"open_library_id,omitempty"`
 	HardcoverID   *string `json:"hardcover_id,omitempty"`
@@ -161,13 +173,24 @@ type Book struct {
 	Channels   *int    `json:"channels,omitempty"`
 	BitDepth   *int    `json:"bit_depth,omitempty"`
 	Quality    *string `json:"quality,omitempty"`
+	// QualityScore is mediainfo.QualityScore (0–100) for this version's
+	// codec, bitrate, sample rate and bit depth.
+	QualityScore *int `json:"quality_score,omitempty"`
 	// Version management
 	IsPrimaryVersion *bool   `json:"is_primary_version,omitempty"`
 	VersionGroupID   *string `json:"version_group_id,omitempty"`
 	VersionNotes     *string `json:"version_notes,omitempty"`
+	// UpgradeAvailable is set on a primary version when another version in
+	// its group scores higher; UpgradeCandidateID names the best such
+	// version. Both clear when the primary changes.
+	UpgradeAvailable   *bool   `json:"upgrade_available,omitempty"`
+	UpgradeCandidateID *string `json:"upgrade_candidate_id,omitempty"`
 	// File hash tracking for deduplication
 	FileHash *string `json:"file_hash,omitempty"`
 	FileSize *int64  `json:"file_size,omitempty"`
+	// FileHashAlgorithm names how FileHash was computed (see
+	// scanner.FileHashAlgorithmFor); nil for hashes recorded before it was.
+	FileHashAlgorithm *string `json:"file_hash_algorithm,omitempty"`
 	// Lifecycle tracking
 	OriginalFileHash    *string    `json:"original_file_hash,omitempty"`
 	OrganizedFileHash   *string    `json:"organized_file_hash,omitempty"`
@@ -250,6 +273,9 @@ type Book struct {
 	// CountBooksByPathPrefix can correctly count books even after they have been
 	// relocated to RootDir.
 	SourceImportPath *string `json:"source_import_path,omitempty"`
+	// ImportedByUserID is the user whose upload created the book; nil for
+	// books found by scans. Per-user import quotas count against it.
+	ImportedByUserID *string `json:"imported_by_user_id,omitempty"`
 	// Scan cache for incremental scanning (set by scanner, not user-facing)
 	LastScanMtime *int64 `json:"last_scan_mtime,omitempty"`
 	LastScanSize  *int64 `json:"last_scan_size,omitempty"`
@@ -278,7 +304,7 @@ type BookSummary struct {
 	Title                string     `json:"title"`
 	AuthorID             *int       `json:"author_id,omitempty"`
 	SeriesID             *int       `json:"series_id,omitempty"`
-	SeriesSequence       *int       `json:"series_sequence,omitempty"`
+	SeriesSequence       *SeriesSeq `json:"series_sequence,omitempty"`
 	FilePath             string     `json:"file_path"`
 	Format               string     `json:"format,omitempty"`
 	Duration             *int       `json:"duration,omitempty"`
@@ -393,8 +419,75 @@ type ImportPath struct {
 	CreatedAt time.Time  `json:"created_at"`
 	LastScan  *time.Time `json:"last_scan,omitempty"`
 	BookCount int        `json:"book_count"`
+	// ScanProfile is the default scan profile for this path (quick,
+	// standard or deep); empty means standard.
+	ScanProfile string `json:"scan_profile,omitempty"`
+	// RequireMount marks a path that must be its own mountpoint (an NFS
+	// or SMB share); scans are skipped when it is not mounted.
+	RequireMount bool `json:"require_mount,omitempty"`
+	// Watch overrides auto_scan_enabled for this path: true watches it for
+	// new files even with auto-scan off, false never watches it, nil
+	// follows the global setting.
+	Watch *bool `json:"watch,omitempty"`
+	// Health is the result of the last pre-scan check: "healthy",
+	// "unhealthy" or empty when never checked.
+	Health          string     `json:"health,omitempty"`
+	HealthReason    string     `json:"health_reason,omitempty"`
+	HealthCheckedAt *time.Time `json:"health_checked_at,omitempty"`
+	// Scan history that predicts how long the next scan will take: the
+	// storage backend the path sat on (local, nfs, smb, fuse), the last
+	// scan's file count, duration and profile, and a smoothed
+	// files-per-second rate for that profile.
+	ScanBackend        string  `json:"scan_backend,omitempty"`
+	LastScanFiles      int     `json:"last_scan_files,omitempty"`
+	LastScanSeconds    float64 `json:"last_scan_seconds,omitempty"`
+	LastScanProfile    string  `json:"last_scan_profile,omitempty"`
+	ScanFilesPerSecond float64 `json:"scan_files_per_second,omitempty"`
+	// ScanMode is "initial" from creation until the path's first deep
+	// scan completes, then "incremental". Empty (paths added before scan
+	// modes) counts as incremental.
+	ScanMode string `json:"scan_mode,omitempty"`
+	// Adopt marks an already organized library: scans read author,
+	// series, title and sequence from each book's folders using
+	// AdoptPattern (folder_naming_pattern when empty) and save matching
+	// books as organized, in place.
+	Adopt        bool   `json:"adopt,omitempty"`
+	AdoptPattern string `json:"adopt_pattern,omitempty"`
+}
+
+// Import path scan modes. An initial path is deep-scanned by the next
+// scan that doesn't ask for a profile, ignoring the scan cache, and is
+// not watched; an incremental one follows its scan profile, skips
+// unchanged files and is watched as configured.
+const (
+	ImportPathScanInitial     = "initial"
+	ImportPathScanIncremental = "incremental"
+)
+
+// InitialScanPending reports whether ip still awaits its first deep scan.
+func (ip ImportPath) InitialScanPending() bool {
+	return ip.ScanMode == ImportPathScanInitial
+}
+
+// Watched reports whether the file watcher should monitor ip, given the
+// global auto_scan_enabled setting. Disabled paths, and paths still
+// awaiting their initial scan, are never watched.
+func (ip ImportPath) Watched(autoScan bool) bool {
+	if !ip.Enabled || ip.InitialScanPending() {
+		return false
+	}
+	if ip.Watch != nil {
+		return *ip.Watch
+	}
+	return autoScan
 }
 
+// Import path health states.
+const (
+	ImportPathHealthy   = "healthy"
+	ImportPathUnhealthy = "unhealthy"
+)
+
 // Operation represents an async operation
 type Operation struct {
 	ID           string     `json:"id"`
@@ -479,7 +572,11 @@ type UserPreference struct {
 
 // DoNotImport represents a blocked file hash to prevent reimport
 type DoNotImport struct {
-	Hash      string    `json:"hash"`
+	Hash string `json:"hash"`
+	// Algorithm names how Hash was computed (see Book.FileHashAlgorithm).
+	// Empty for entries recorded before the algorithm was stored, which
+	// used the default partial mode.
+	Algorithm string    `json:"algorithm,omitempty"`
 	Reason    string    `json:"reason"`
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -669,6 +766,9 @@ type BookFile struct {
 	BitDepth           int    `json:"bit_depth,omitempty"`
 	FileHash           string `json:"file_hash,omitempty"`
 	OriginalFileHash   string `json:"original_file_hash,omitempty"`
+	// FileHashAlgorithm names how FileHash was computed; empty for hashes
+	// recorded before it was.
+	FileHashAlgorithm string `json:"file_hash_algorithm,omitempty"`
 	// PostMetadataHash is the SHA-256 of the file immediately after a metadata
 	// tag write. It differs from OriginalFileHash because tag writes add/change
 	// bytes in the header. Store it so pre-write identity is always recoverable.
@@ -774,6 +874,7 @@ const FinishedThreshold = 0.95
 type PlaybackEvent struct {
 	UserID      string    `json:"user_id"`
 	BookID      int       `json:"book_id"`
+	AudiobookID string    `json:"audiobook_id,omitempty"` // ULID behind BookID
 	SegmentID   string    `json:"segment_id"`
 	PositionSec int       `json:"position_seconds"`
 	EventType   string    `json:"event_type"` // progress|start|pause|complete
@@ -786,6 +887,7 @@ type PlaybackEvent struct {
 type PlaybackProgress struct {
 	UserID      string    `json:"user_id"`
 	BookID      int       `json:"book_id"`
+	AudiobookID string    `json:"audiobook_id,omitempty"` // ULID behind BookID
 	SegmentID   string    `json:"segment_id"`
 	PositionSec int       `json:"position_seconds"`
 	Percent     float64   `json:"percent_complete"`
@@ -928,6 +1030,11 @@ type LibraryStats struct {
 	SizeByImportPath   map[int]int64  `json:"size_by_import_path"`
 	StateDistribution  map[string]int `json:"state_distribution"`
 	FormatDistribution map[string]int `json:"format_distribution"`
+	// Size/duration/quality buckets; see SizeBucket, DurationBucket and
+	// QualityBucket for the labels.
+	SizeDistribution     map[string]int `json:"size_distribution"`
+	DurationDistribution map[string]int `json:"duration_distribution"`
+	QualityDistribution  map[string]int `json:"quality_distribution"`
 	// BrokenFiles is the number of distinct books that have at least one recorded
 	// file error (from the book_file_errors_by_book: index). Populated alongside
 	// TotalBooks/TotalFiles in computeLibraryStats so all three counts share a
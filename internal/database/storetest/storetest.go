@@ -0,0 +1,146 @@
+// file: internal/database/storetest/storetest.go
+// version: 1.0.0
+// guid: 5c7e3a19-d2b8-4f64-a0e1-9b6d4c8f2a73
+// last-edited: 2026-10-17
+
+// Package storetest provides an in-memory database.Store and fixture
+// factories for tests of handlers, plugins and integrations.
+//
+// The store is a real PebbleStore on pebble's in-memory filesystem, so it
+// behaves exactly like production (indexes, memdb, migrations) without
+// touching disk. Use it when a test needs a working store; hand-written
+// database.MockStore stubs remain the tool for forcing specific errors.
+//
+//	store := storetest.New(t)
+//	author := storetest.Author(t, store, "Jane Doe")
+//	book := storetest.Book(t, store, database.Book{Title: "Dune", AuthorID: &author.ID})
+package storetest
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	ulid "github.com/oklog/ulid/v2"
+)
+
+// New returns an empty, migrated in-memory store that is closed when the
+// test ends.
+func New(t testing.TB) *database.PebbleStore {
+	t.Helper()
+	store, err := database.NewInMemoryPebbleStore()
+	if err != nil {
+		t.Fatalf("storetest: open in-memory store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := database.RunMigrations(store); err != nil {
+		t.Fatalf("storetest: run migrations: %v", err)
+	}
+	return store
+}
+
+// NewGlobal is New plus database.SetGlobalStore, restoring the previous
+// global store when the test ends. Server and service code that reads
+// the global store needs this.
+func NewGlobal(t testing.TB) *database.PebbleStore {
+	t.Helper()
+	store := New(t)
+	orig := database.GetGlobalStore()
+	database.SetGlobalStore(store)
+	t.Cleanup(func() { database.SetGlobalStore(orig) })
+	return store
+}
+
+var seq atomic.Int64
+
+// next returns a number unique within the test binary, for default names.
+func next() int64 { return seq.Add(1) }
+
+// Author creates an author.
+func Author(t testing.TB, store database.Store, name string) *database.Author {
+	t.Helper()
+	if name == "" {
+		name = fmt.Sprintf("Test Author %d", next())
+	}
+	a, err := store.CreateAuthor(name)
+	if err != nil {
+		t.Fatalf("storetest: create author %q: %v", name, err)
+	}
+	return a
+}
+
+// Series creates a series, optionally attributed to author.
+func Series(t testing.TB, store database.Store, name string, author *database.Author) *database.Series {
+	t.Helper()
+	if name == "" {
+		name = fmt.Sprintf("Test Series %d", next())
+	}
+	var authorID *int
+	if author != nil {
+		authorID = &author.ID
+	}
+	s, err := store.CreateSeries(name, authorID)
+	if err != nil {
+		t.Fatalf("storetest: create series %q: %v", name, err)
+	}
+	return s
+}
+
+// Book creates a book from tmpl. Unset fields get defaults: a unique
+// title, and a file path of /library/<title>.m4b. The file is not
+// created on disk.
+func Book(t testing.TB, store database.Store, tmpl database.Book) *database.Book {
+	t.Helper()
+	b := tmpl
+	if b.Title == "" {
+		b.Title = fmt.Sprintf("Test Book %d", next())
+	}
+	if b.FilePath == "" {
+		b.FilePath = filepath.Join("/library", b.Title+".m4b")
+	}
+	created, err := store.CreateBook(&b)
+	if err != nil {
+		t.Fatalf("storetest: create book %q: %v", b.Title, err)
+	}
+	return created
+}
+
+// BookFile adds a file to book. Unset fields get defaults: a path under
+// the book's folder and the book ID.
+func BookFile(t testing.TB, store database.Store, book *database.Book, tmpl database.BookFile) *database.BookFile {
+	t.Helper()
+	f := tmpl
+	f.BookID = book.ID
+	if f.ID == "" {
+		f.ID = ulid.Make().String()
+	}
+	if f.FilePath == "" {
+		f.FilePath = filepath.Join(filepath.Dir(book.FilePath), fmt.Sprintf("part%d.mp3", next()))
+	}
+	if err := store.CreateBookFile(&f); err != nil {
+		t.Fatalf("storetest: create book file %q: %v", f.FilePath, err)
+	}
+	return &f
+}
+
+// Operation creates an operation of opType. A non-empty status is
+// applied after creation (new operations start "pending").
+func Operation(t testing.TB, store database.Store, opType, status string) *database.Operation {
+	t.Helper()
+	op, err := store.CreateOperation(ulid.Make().String(), opType, nil)
+	if err != nil {
+		t.Fatalf("storetest: create operation %q: %v", opType, err)
+	}
+	if status != "" && status != op.Status {
+		if err := store.UpdateOperationStatus(op.ID, status, 0, 0, ""); err != nil {
+			t.Fatalf("storetest: set operation %s status %q: %v", op.ID, status, err)
+		}
+		id := op.ID
+		if op, err = store.GetOperationByID(id); err != nil {
+			t.Fatalf("storetest: reload operation %s: %v", id, err)
+		}
+	}
+	return op
+}
@@ -0,0 +1,52 @@
+// file: internal/database/storetest/storetest_test.go
+// version: 1.0.0
+// guid: 8f1b6d42-a3c7-4e95-b2d0-e7c4a9f3516b
+// last-edited: 2026-10-17
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactories(t *testing.T) {
+	store := New(t)
+
+	author := Author(t, store, "Jane Doe")
+	series := Series(t, store, "", author)
+	book := Book(t, store, database.Book{AuthorID: &author.ID, SeriesID: &series.ID, SeriesSequence: database.NewSeriesSeq(2)})
+	assert.NotEmpty(t, book.ID)
+	assert.Contains(t, book.FilePath, book.Title)
+
+	got, err := store.GetBookByID(book.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, author.ID, *got.AuthorID)
+
+	f := BookFile(t, store, book, database.BookFile{FileSize: 10})
+	files, err := store.GetBookFiles(book.ID)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, f.FilePath, files[0].FilePath)
+
+	op := Operation(t, store, "scan", "running")
+	assert.Equal(t, "running", op.Status)
+
+	// Each New is a separate, empty store.
+	other := New(t)
+	none, err := other.GetBookByID(book.ID)
+	assert.True(t, none == nil || err != nil)
+}
+
+func TestNewGlobal(t *testing.T) {
+	orig := database.GetGlobalStore()
+	t.Run("sets", func(t *testing.T) {
+		store := NewGlobal(t)
+		assert.Same(t, store, database.GetGlobalStore())
+	})
+	assert.Equal(t, orig, database.GetGlobalStore())
+}
@@ -0,0 +1,275 @@
+// file: internal/database/timed_store.go
+// version: 1.1.0
+// guid: 2f6b8d41-9a3c-4e75-b0d2-6c1e8f4a7b93
+// last-edited: 2026-10-17
+
+package database
+
+//go:generate go run ../../tools/cmd/gen-timed-store
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// slowQueryRecentCap bounds the ring of recent slow calls kept for the
+// report.
+const slowQueryRecentCap = 200
+
+// slowQueryStringMax truncates string parameters in slow-query records.
+const slowQueryStringMax = 80
+
+// TimedStore wraps a PebbleStore and times every call. Calls at or over
+// the threshold are logged with sanitized parameters and kept for
+// SlowQueryReport. Its methods are generated (timed_store_gen.go) from
+// the PebbleStore method set, so it satisfies every interface the
+// concrete store does; code that needs the *PebbleStore itself uses
+// AsPebbleStore.
+type TimedStore struct {
+	inner     *PebbleStore
+	threshold func() time.Duration
+	stats     [len(timedStoreMethods)]timedMethodStats
+
+	mu     sync.Mutex
+	recent []SlowQuery // ring buffer, oldest at next once full
+	next   int
+}
+
+type timedMethodStats struct {
+	calls   atomic.Int64
+	slow    atomic.Int64
+	totalNs atomic.Int64
+	maxNs   atomic.Int64
+}
+
+// SlowQuery is one logged slow call.
+type SlowQuery struct {
+	Method     string            `json:"method"`
+	StartedAt  time.Time         `json:"started_at"`
+	DurationMs float64           `json:"duration_ms"`
+	Params     map[string]string `json:"params,omitempty"`
+}
+
+// SlowQueryMethodStats aggregates the calls to one method since startup.
+type SlowQueryMethodStats struct {
+	Method    string  `json:"method"`
+	Calls     int64   `json:"calls"`
+	SlowCalls int64   `json:"slow_calls"`
+	TotalMs   float64 `json:"total_ms"`
+	AvgMs     float64 `json:"avg_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+// SlowQueryReport is the TimedStore's view of database latency.
+type SlowQueryReport struct {
+	ThresholdMs float64                `json:"threshold_ms"`
+	Methods     []SlowQueryMethodStats `json:"methods"`
+	Recent      []SlowQuery            `json:"recent"`
+}
+
+// NewTimedStore wraps inner. threshold is consulted on every call so it
+// can follow config changes; a threshold <= 0 records timings without
+// flagging anything as slow.
+func NewTimedStore(inner *PebbleStore, threshold func() time.Duration) *TimedStore {
+	return &TimedStore{inner: inner, threshold: threshold}
+}
+
+var _ Store = (*TimedStore)(nil)
+
+// Unwrap returns the wrapped store.
+func (t *TimedStore) Unwrap() Store { return t.inner }
+
+// AsPebbleStore returns the PebbleStore behind store, looking through
+// wrappers that expose Unwrap.
+func AsPebbleStore(store any) (*PebbleStore, bool) { return unwrapStore[*PebbleStore](store) }
+
+// AsTimedStore returns the TimedStore behind store, if there is one.
+func AsTimedStore(store any) (*TimedStore, bool) { return unwrapStore[*TimedStore](store) }
+
+func unwrapStore[T any](store any) (T, bool) {
+	for store != nil {
+		if s, ok := store.(T); ok {
+			return s, true
+		}
+		uw, ok := store.(interface{ Unwrap() Store })
+		if !ok {
+			break
+		}
+		store = uw.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
+
+// record adds one call to method idx's statistics and reports whether it
+// was slow.
+func (t *TimedStore) record(idx int, start time.Time) (time.Duration, bool) {
+	d := time.Since(start)
+	st := &t.stats[idx]
+	st.calls.Add(1)
+	st.totalNs.Add(int64(d))
+	for {
+		prev := st.maxNs.Load()
+		if int64(d) <= prev || st.maxNs.CompareAndSwap(prev, int64(d)) {
+			break
+		}
+	}
+	limit := t.threshold()
+	if limit <= 0 || d < limit {
+		return d, false
+	}
+	st.slow.Add(1)
+	return d, true
+}
+
+// slow logs a slow call. kv alternates parameter names and values.
+func (t *TimedStore) slow(idx int, start time.Time, d time.Duration, kv ...any) {
+	q := SlowQuery{
+		Method:     timedStoreMethods[idx],
+		StartedAt:  start.UTC(),
+		DurationMs: durationMs(d),
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		name, _ := kv[i].(string)
+		if secretMethodParams[q.Method][name] {
+			if q.Params == nil {
+				q.Params = make(map[string]string, len(kv)/2)
+			}
+			q.Params[name] = "[redacted]"
+			continue
+		}
+		if v, ok := sanitizeQueryParam(name, kv[i+1]); ok {
+			if q.Params == nil {
+				q.Params = make(map[string]string, len(kv)/2)
+			}
+			q.Params[name] = v
+		}
+	}
+	slog.Warn("slow database call", "method", q.Method, "duration_ms", q.DurationMs, "params", q.Params)
+
+	t.mu.Lock()
+	if len(t.recent) < slowQueryRecentCap {
+		t.recent = append(t.recent, q)
+	} else {
+		t.recent[t.next] = q
+	}
+	t.next = (t.next + 1) % slowQueryRecentCap
+	t.mu.Unlock()
+}
+
+// SlowQueryReport returns per-method statistics for every method called
+// so far, by total time descending, and the recent slow calls, newest
+// first.
+func (t *TimedStore) SlowQueryReport() SlowQueryReport {
+	report := SlowQueryReport{
+		ThresholdMs: durationMs(t.threshold()),
+		Methods:     []SlowQueryMethodStats{},
+	}
+	for i := range t.stats {
+		st := &t.stats[i]
+		calls := st.calls.Load()
+		if calls == 0 {
+			continue
+		}
+		total := time.Duration(st.totalNs.Load())
+		report.Methods = append(report.Methods, SlowQueryMethodStats{
+			Method:    timedStoreMethods[i],
+			Calls:     calls,
+			SlowCalls: st.slow.Load(),
+			TotalMs:   durationMs(total),
+			AvgMs:     durationMs(total / time.Duration(calls)),
+			MaxMs:     durationMs(time.Duration(st.maxNs.Load())),
+		})
+	}
+	slices.SortStableFunc(report.Methods, func(a, b SlowQueryMethodStats) int {
+		return cmp.Compare(b.TotalMs, a.TotalMs)
+	})
+
+	t.mu.Lock()
+	report.Recent = make([]SlowQuery, 0, len(t.recent))
+	for i := 1; i <= len(t.recent); i++ {
+		report.Recent = append(report.Recent, t.recent[(t.next-i+len(t.recent))%len(t.recent)])
+	}
+	t.mu.Unlock()
+	return report
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// secretParamHints mark parameters whose values are never logged.
+var secretParamHints = []string{"password", "secret", "token", "credential", "apikey", "api_key", "value", "data", "blob"}
+
+// secretMethodParams mark parameters whose names look harmless but whose
+// values are credentials for one method: a session ID is the bearer
+// token the auth middleware looks up.
+var secretMethodParams = map[string]map[string]bool{
+	"GetSession":    {"id": true},
+	"RevokeSession": {"id": true},
+}
+
+// sanitizeQueryParam renders a parameter for the slow-query log: scalars
+// as themselves (strings truncated), collections as their length and
+// structs as their type, so records never carry whole rows or secrets.
+// It reports false for parameters not worth showing (contexts, funcs).
+func sanitizeQueryParam(name string, v any) (string, bool) {
+	lower := strings.ToLower(name)
+	for _, hint := range secretParamHints {
+		if strings.Contains(lower, hint) {
+			return "[redacted]", true
+		}
+	}
+	switch x := v.(type) {
+	case nil:
+		return "nil", true
+	case context.Context:
+		return "", false
+	case string:
+		if len(x) > slowQueryStringMax {
+			return strings.ToValidUTF8(x[:slowQueryStringMax], "") + "…", true
+		}
+		return x, true
+	case []byte:
+		return fmt.Sprintf("[%d bytes]", len(x)), true
+	case time.Time:
+		return x.UTC().Format(time.RFC3339), true
+	case time.Duration:
+		return x.String(), true
+	case *time.Time:
+		if x == nil {
+			return "nil", true
+		}
+		return x.UTC().Format(time.RFC3339), true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v), true
+	case reflect.Slice, reflect.Array:
+		return fmt.Sprintf("[%d items]", rv.Len()), true
+	case reflect.Map:
+		return fmt.Sprintf("[%d entries]", rv.Len()), true
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "nil", true
+		}
+		if e := rv.Elem(); e.Kind() != reflect.Struct {
+			return sanitizeQueryParam(name, e.Interface())
+		}
+		return rv.Type().String(), true
+	case reflect.Struct:
+		return rv.Type().String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,115 @@
+// file: internal/database/timed_store_test.go
+// version: 1.1.0
+// guid: 8e3b1f60-7d24-4a9c-b5e8-2c0f9d4a6b17
+
+package database
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimedStore_Report(t *testing.T) {
+	inner := setupTestPebbleStore(t)
+	threshold := time.Hour
+	ts := NewTimedStore(inner, func() time.Duration { return threshold })
+
+	_, err := ts.CreateBook(&Book{ID: "b1", Title: "One", FilePath: "/books/one.m4b"})
+	require.NoError(t, err)
+	_, _ = ts.GetBookByID("b1")
+	report := ts.SlowQueryReport()
+	assert.Equal(t, float64(time.Hour.Milliseconds()), report.ThresholdMs)
+	assert.Empty(t, report.Recent)
+	calls := map[string]int64{}
+	for _, m := range report.Methods {
+		calls[m.Method] = m.Calls
+		assert.Zero(t, m.SlowCalls)
+	}
+	assert.Equal(t, map[string]int64{"CreateBook": 1, "GetBookByID": 1}, calls)
+
+	// Every call is slow at a 1ns threshold.
+	threshold = time.Nanosecond
+	_, _ = ts.GetBookByID("b1")
+	_, _ = ts.GetBookByID(strings.Repeat("x", 200))
+	report = ts.SlowQueryReport()
+	require.Len(t, report.Recent, 2)
+	assert.Equal(t, "GetBookByID", report.Recent[0].Method)
+	assert.Equal(t, strings.Repeat("x", slowQueryStringMax)+"…", report.Recent[0].Params["id"], "newest first, truncated")
+	assert.Equal(t, "b1", report.Recent[1].Params["id"])
+}
+
+func TestTimedStore_SessionTokenRedacted(t *testing.T) {
+	inner := setupTestPebbleStore(t)
+	ts := NewTimedStore(inner, func() time.Duration { return time.Nanosecond })
+	sess, err := inner.CreateSession("u1", "127.0.0.1", "test", time.Hour)
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	_, _ = ts.GetSession(sess.ID)
+	require.NoError(t, ts.RevokeSession(sess.ID))
+
+	report := ts.SlowQueryReport()
+	require.Len(t, report.Recent, 2)
+	for _, q := range report.Recent {
+		assert.Equal(t, "[redacted]", q.Params["id"], q.Method)
+	}
+	assert.Contains(t, logs.String(), "GetSession")
+	assert.NotContains(t, logs.String(), sess.ID)
+}
+
+func TestTimedStore_Unwrap(t *testing.T) {
+	inner := setupTestPebbleStore(t)
+	ts := NewTimedStore(inner, func() time.Duration { return 0 })
+
+	ps, ok := AsPebbleStore(ts)
+	assert.True(t, ok)
+	assert.Same(t, inner, ps)
+	got, ok := AsTimedStore(Store(ts))
+	assert.True(t, ok)
+	assert.Same(t, ts, got)
+	_, ok = AsTimedStore(inner)
+	assert.False(t, ok)
+
+	// Optional interfaces the concrete store implements survive wrapping.
+	var s Store = ts
+	_, ok = s.(interface{ IsMemReady() bool })
+	assert.True(t, ok)
+}
+
+func TestSanitizeQueryParam(t *testing.T) {
+	cases := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"id", "abc", "abc"},
+		{"limit", 25, "25"},
+		{"enabled", true, "true"},
+		{"ids", []string{"a", "b", "c"}, "[3 items]"},
+		{"fp", []byte{1, 2}, "[2 bytes]"},
+		{"fields", map[string]string{"a": "b"}, "[1 entries]"},
+		{"book", &Book{Title: "secret title"}, "*database.Book"},
+		{"book", (*Book)(nil), "nil"},
+		{"password", "hunter2", "[redacted]"},
+		{"apiKeyToken", "abc", "[redacted]"},
+		{"value", "anything", "[redacted]"},
+		{"cutoff", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "2026-01-02T03:04:05Z"},
+	}
+	for _, tc := range cases {
+		got, ok := sanitizeQueryParam(tc.name, tc.v)
+		assert.True(t, ok, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+	_, ok := sanitizeQueryParam("fn", func() {})
+	assert.False(t, ok)
+}
@@ -1,5 +1,5 @@
 // file: internal/dedup/author.go
-// version: 1.10.0
+// version: 1.11.0
 // guid: d4e5f6a7-b8c9-0d1e-2f3a-4b5c6d7e8f90
 
 package dedup
@@ -1100,6 +1100,11 @@ func findDuplicateAuthorsInternal(authors []database.Author, threshold float64,
 // areAuthorsDuplicatePrecomputed is a faster version of areAuthorsDuplicate
 // that uses pre-computed normalized names to avoid redundant string operations.
 func areAuthorsDuplicatePrecomputed(a, b *authorPrecomputed) bool {
+	// Namesakes whose authority data differs are different people.
+	if database.DistinctAuthors(a.author, b.author) {
+		return false
+	}
+
 	// Exact normalized match
 	if a.norm == b.norm {
 		return true
@@ -0,0 +1,347 @@
+// file: internal/dedup/book_resolve.go
+// version: 1.1.0
+// guid: 5c8e2a47-9b31-4d6f-a0e8-3f7b1c9d2e64
+// last-edited: 2026-10-17
+
+// Package dedup: book_resolve.go contains the execution logic for the
+// "dedup.book-resolve" operation, which applies per-group decisions to book
+// duplicate groups. Every change is recorded as an OperationChange that
+// audiobooks.RevertService knows how to reverse, so the whole resolution
+// can be rolled back with POST /operations/:id/revert: books are only ever
+// soft-deleted, never purged.
+package dedup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
+	ulid "github.com/oklog/ulid/v2"
+)
+
+// Keep strategies for a BookResolveDecision.
+const (
+	KeepBestQuality = "best_quality"
+	KeepPrimary     = "primary"
+	KeepBook        = "book"
+)
+
+// Change types recorded by ResolveBookDuplicates besides metadata_update.
+const (
+	ChangeBookSoftDelete = "book_soft_delete"
+	ChangeVersionLink    = "version_link"
+	ChangeHashBlock      = "hash_block"
+)
+
+// BookResolveDecision is what to do with one duplicate group.
+type BookResolveDecision struct {
+	BookIDs []string `json:"book_ids"`
+	// Keep picks the book that stays: best_quality (highest quality score,
+	// then largest file), primary (the group's primary version, else the
+	// first book) or book (KeepID).
+	Keep   string `json:"keep"`
+	KeepID string `json:"keep_id,omitempty"`
+	// DeleteOthers soft-deletes the other books; otherwise they are linked
+	// to the kept book as non-primary versions.
+	DeleteOthers bool `json:"delete_others"`
+	// MergeMetadata fills the kept book's empty metadata fields from the
+	// others, in BookIDs order.
+	MergeMetadata bool `json:"merge_metadata"`
+	// BlockHash puts the deleted books' file hashes on the do-not-import
+	// list, except a hash the kept book shares. Requires DeleteOthers.
+	BlockHash bool `json:"block_hash"`
+}
+
+// Validate reports the first problem with d, or nil.
+func (d BookResolveDecision) Validate() error {
+	if len(d.BookIDs) < 2 {
+		return fmt.Errorf("book_ids needs at least 2 books")
+	}
+	switch d.Keep {
+	case KeepBestQuality, KeepPrimary:
+	case KeepBook:
+		if !slices.Contains(d.BookIDs, d.KeepID) {
+			return fmt.Errorf("keep_id must be one of book_ids")
+		}
+	default:
+		return fmt.Errorf("keep must be one of: best_quality, primary, book")
+	}
+	if d.BlockHash && !d.DeleteOthers {
+		return fmt.Errorf("block_hash requires delete_others")
+	}
+	return nil
+}
+
+// BookResolveGroupResult is the outcome for one decision.
+type BookResolveGroupResult struct {
+	KeptID      string   `json:"kept_id,omitempty"`
+	Deleted     []string `json:"deleted,omitempty"`
+	Linked      []string `json:"linked,omitempty"`
+	Merged      []string `json:"merged_fields,omitempty"`
+	Blocked     []string `json:"blocked_hashes,omitempty"`
+	SkippedNote string   `json:"skipped,omitempty"`
+}
+
+// BookResolveResult summarises ResolveBookDuplicates.
+type BookResolveResult struct {
+	Groups  []BookResolveGroupResult `json:"groups"`
+	Deleted int                      `json:"deleted"`
+	Linked  int                      `json:"linked"`
+	Errors  []string                 `json:"errors,omitempty"`
+}
+
+// mergeableFields are the metadata fields MergeMetadata fills, named as
+// audiobooks.RevertService restores them.
+var mergeableFields = []struct {
+	name string
+	ptr  func(*database.Book) **string
+}{
+	{"narrator", func(b *database.Book) **string { return &b.Narrator }},
+	{"edition", func(b *database.Book) **string { return &b.Edition }},
+	{"language", func(b *database.Book) **string { return &b.Language }},
+	{"publisher", func(b *database.Book) **string { return &b.Publisher }},
+	{"isbn10", func(b *database.Book) **string { return &b.ISBN10 }},
+	{"isbn13", func(b *database.Book) **string { return &b.ISBN13 }},
+	{"asin", func(b *database.Book) **string { return &b.ASIN }},
+	{"cover_url", func(b *database.Book) **string { return &b.CoverURL }},
+	{"open_library_id", func(b *database.Book) **string { return &b.OpenLibraryID }},
+	{"hardcover_id", func(b *database.Book) **string { return &b.HardcoverID }},
+	{"google_books_id", func(b *database.Book) **string { return &b.GoogleBooksID }},
+}
+
+// versionSnapshot is the OldValue of a version_link change.
+type versionSnapshot struct {
+	VersionGroupID   *string `json:"version_group_id"`
+	IsPrimaryVersion *bool   `json:"is_primary_version"`
+}
+
+// ResolveBookDuplicates applies decisions in order, recording each change
+// under opID. A group whose books are no longer there (fewer than two live
+// books left) is skipped; errors on one group do not stop the others.
+func ResolveBookDuplicates(
+	_ context.Context,
+	store database.Store,
+	opID string,
+	decisions []BookResolveDecision,
+	progress ProgressReporter,
+) (BookResolveResult, error) {
+	result := BookResolveResult{Groups: make([]BookResolveGroupResult, 0, len(decisions))}
+	total := len(decisions)
+	for i, d := range decisions {
+		if progress != nil && progress.IsCanceled() {
+			return result, fmt.Errorf("cancelled")
+		}
+		gr, err := resolveGroup(store, opID, d)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("group %d: %v", i+1, err))
+		}
+		result.Groups = append(result.Groups, gr)
+		result.Deleted += len(gr.Deleted)
+		result.Linked += len(gr.Linked)
+		if progress != nil {
+			if gr.SkippedNote != "" {
+				_ = progress.Log("warn", fmt.Sprintf("Group %d skipped: %s", i+1, gr.SkippedNote), nil)
+			}
+			_ = progress.UpdateProgress(i+1, total, fmt.Sprintf("Resolved %d/%d duplicate groups", i+1, total))
+		}
+	}
+	return result, nil
+}
+
+func resolveGroup(store database.Store, opID string, d BookResolveDecision) (BookResolveGroupResult, error) {
+	var gr BookResolveGroupResult
+	if err := d.Validate(); err != nil {
+		gr.SkippedNote = err.Error()
+		return gr, nil
+	}
+	var books []*database.Book
+	for _, id := range d.BookIDs {
+		b, err := store.GetBookByID(id)
+		if err != nil || b == nil || isSoftDeleted(b) {
+			continue
+		}
+		books = append(books, b)
+	}
+	if len(books) < 2 {
+		gr.SkippedNote = "fewer than two of its books are still in the library"
+		return gr, nil
+	}
+	keep := pickKeep(books, d)
+	if keep == nil {
+		gr.SkippedNote = fmt.Sprintf("book %s to keep is no longer in the library", d.KeepID)
+		return gr, nil
+	}
+	gr.KeptID = keep.ID
+	record := func(bookID, changeType, field, oldValue, newValue string) {
+		_ = store.CreateOperationChange(&database.OperationChange{
+			ID:          ulid.Make().String(),
+			OperationID: opID,
+			BookID:      bookID,
+			ChangeType:  changeType,
+			FieldName:   field,
+			OldValue:    oldValue,
+			NewValue:    newValue,
+		})
+	}
+
+	others := make([]*database.Book, 0, len(books)-1)
+	for _, b := range books {
+		if b.ID != keep.ID {
+			others = append(others, b)
+		}
+	}
+
+	if d.MergeMetadata {
+		for _, f := range mergeableFields {
+			dst := f.ptr(keep)
+			if *dst != nil && **dst != "" {
+				continue
+			}
+			for _, o := range others {
+				if v := *f.ptr(o); v != nil && *v != "" {
+					val := *v
+					*dst = &val
+					record(keep.ID, "metadata_update", f.name, "", val)
+					gr.Merged = append(gr.Merged, f.name)
+					break
+				}
+			}
+		}
+	}
+
+	if d.DeleteOthers {
+		now := time.Now()
+		deleted := true
+		for _, o := range others {
+			oldState := ""
+			if o.LibraryState != nil {
+				oldState = *o.LibraryState
+			}
+			o.MarkedForDeletion = &deleted
+			o.MarkedForDeletionAt = &now
+			state := "deleted"
+			o.LibraryState = &state
+			if _, err := store.UpdateBook(o.ID, o); err != nil {
+				return gr, fmt.Errorf("soft-delete %s: %w", o.ID, err)
+			}
+			record(o.ID, ChangeBookSoftDelete, "library_state", oldState, "deleted")
+			gr.Deleted = append(gr.Deleted, o.ID)
+
+			if d.BlockHash && o.FileHash != nil && *o.FileHash != "" &&
+				(keep.FileHash == nil || *keep.FileHash != *o.FileHash) &&
+				!slices.Contains(gr.Blocked, *o.FileHash) {
+				if blocked, _ := store.IsHashBlocked(*o.FileHash); blocked {
+					continue
+				}
+				algo := ""
+				if o.FileHashAlgorithm != nil {
+					algo = *o.FileHashAlgorithm
+				}
+				if err := store.AddBlockedHashWithAlgorithm(*o.FileHash, algo, "Duplicate of "+keep.ID); err != nil {
+					return gr, fmt.Errorf("block hash of %s: %w", o.ID, err)
+				}
+				record(o.ID, ChangeHashBlock, "file_hash", "", *o.FileHash)
+				gr.Blocked = append(gr.Blocked, *o.FileHash)
+			}
+		}
+	} else {
+		groupID := ulid.Make().String()
+		if keep.VersionGroupID != nil && *keep.VersionGroupID != "" {
+			groupID = *keep.VersionGroupID
+		}
+		for _, b := range append([]*database.Book{keep}, others...) {
+			primary := b.ID == keep.ID
+			if b.VersionGroupID != nil && *b.VersionGroupID == groupID &&
+				b.IsPrimaryVersion != nil && *b.IsPrimaryVersion == primary {
+				continue
+			}
+			snap, _ := json.Marshal(versionSnapshot{VersionGroupID: b.VersionGroupID, IsPrimaryVersion: b.IsPrimaryVersion})
+			gid := groupID
+			b.VersionGroupID = &gid
+			b.IsPrimaryVersion = &primary
+			// The kept book is saved once, with any merged metadata, below.
+			if !primary {
+				if _, err := store.UpdateBook(b.ID, b); err != nil {
+					return gr, fmt.Errorf("link %s as a version: %w", b.ID, err)
+				}
+				gr.Linked = append(gr.Linked, b.ID)
+			}
+			record(b.ID, ChangeVersionLink, "version_group_id", string(snap), groupID)
+		}
+	}
+
+	if _, err := store.UpdateBook(keep.ID, keep); err != nil {
+		return gr, fmt.Errorf("update kept book %s: %w", keep.ID, err)
+	}
+	return gr, nil
+}
+
+// pickKeep returns the book d keeps, or nil when KeepID is not among books.
+func pickKeep(books []*database.Book, d BookResolveDecision) *database.Book {
+	switch d.Keep {
+	case KeepBook:
+		for _, b := range books {
+			if b.ID == d.KeepID {
+				return b
+			}
+		}
+		return nil
+	case KeepPrimary:
+		for _, b := range books {
+			if b.IsPrimaryVersion != nil && *b.IsPrimaryVersion {
+				return b
+			}
+		}
+		return books[0]
+	default:
+		best := books[0]
+		for _, b := range books[1:] {
+			if betterQuality(b, best) {
+				best = b
+			}
+		}
+		return best
+	}
+}
+
+// betterQuality reports whether a outranks b: higher quality score first,
+// then larger file.
+func betterQuality(a, b *database.Book) bool {
+	if sa, sb := qualityScore(a), qualityScore(b); sa != sb {
+		return sa > sb
+	}
+	return deref64(a.FileSize) > deref64(b.FileSize)
+}
+
+// qualityScore is the stored score, else one computed from the stored
+// media fields; -1 when nothing is known.
+func qualityScore(b *database.Book) int {
+	if b.QualityScore != nil {
+		return *b.QualityScore
+	}
+	if b.Codec == nil || *b.Codec == "" {
+		return -1
+	}
+	deref := func(p *int) int {
+		if p == nil {
+			return 0
+		}
+		return *p
+	}
+	return mediainfo.QualityScore(*b.Codec, deref(b.Bitrate), deref(b.SampleRate), deref(b.BitDepth))
+}
+
+func deref64(p *int64) int64 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func isSoftDeleted(b *database.Book) bool {
+	return b.MarkedForDeletion != nil && *b.MarkedForDeletion
+}
@@ -0,0 +1,140 @@
+// file: internal/dedup/book_resolve_test.go
+// version: 1.0.0
+// guid: 8e1f4c27-3a96-4b5d-92c0-d7a6e3b1f508
+
+package dedup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resolveStore is a MockStore over an in-memory book map that keeps the
+// operation changes it is asked to record.
+type resolveStore struct {
+	*database.MockStore
+	books   map[string]*database.Book
+	changes []*database.OperationChange
+	blocked []string
+}
+
+func newResolveStore(books ...*database.Book) *resolveStore {
+	rs := &resolveStore{MockStore: &database.MockStore{}, books: map[string]*database.Book{}}
+	for _, b := range books {
+		rs.books[b.ID] = b
+	}
+	rs.GetBookByIDFunc = func(id string) (*database.Book, error) {
+		b, ok := rs.books[id]
+		if !ok {
+			return nil, nil
+		}
+		cp := *b
+		return &cp, nil
+	}
+	rs.UpdateBookFunc = func(id string, b *database.Book) (*database.Book, error) {
+		rs.books[id] = b
+		return b, nil
+	}
+	rs.AddBlockedHashFunc = func(hash, _ string) error {
+		rs.blocked = append(rs.blocked, hash)
+		return nil
+	}
+	return rs
+}
+
+func (rs *resolveStore) CreateOperationChange(c *database.OperationChange) error {
+	rs.changes = append(rs.changes, c)
+	return nil
+}
+
+func strp(s string) *string { return &s }
+
+func TestBookResolveDecision_Validate(t *testing.T) {
+	ok := BookResolveDecision{BookIDs: []string{"a", "b"}, Keep: KeepBestQuality}
+	assert.NoError(t, ok.Validate())
+
+	bad := []BookResolveDecision{
+		{BookIDs: []string{"a"}, Keep: KeepBestQuality},
+		{BookIDs: []string{"a", "b"}, Keep: "newest"},
+		{BookIDs: []string{"a", "b"}, Keep: KeepBook, KeepID: "c"},
+		{BookIDs: []string{"a", "b"}, Keep: KeepPrimary, BlockHash: true},
+	}
+	for _, d := range bad {
+		assert.Error(t, d.Validate(), "%+v", d)
+	}
+}
+
+func TestResolveBookDuplicates_KeepBestQualityDeleteAndBlock(t *testing.T) {
+	low := &database.Book{ID: "low", QualityScore: intp(40), FileHash: strp("h-low"), Publisher: strp("Pub")}
+	high := &database.Book{ID: "high", QualityScore: intp(90), FileHash: strp("h-high")}
+	store := newResolveStore(low, high)
+	progress := &stubProgress{}
+
+	result, err := ResolveBookDuplicates(context.Background(), store, "op1", []BookResolveDecision{{
+		BookIDs:       []string{"low", "high"},
+		Keep:          KeepBestQuality,
+		DeleteOthers:  true,
+		MergeMetadata: true,
+		BlockHash:     true,
+	}}, progress)
+	require.NoError(t, err)
+	require.Len(t, result.Groups, 1)
+	assert.Equal(t, "high", result.Groups[0].KeptID)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, 100, progress.lastPct)
+
+	assert.True(t, *store.books["low"].MarkedForDeletion)
+	assert.Equal(t, "deleted", *store.books["low"].LibraryState)
+	assert.Equal(t, "Pub", *store.books["high"].Publisher)
+	assert.Equal(t, []string{"h-low"}, store.blocked)
+
+	var types []string
+	for _, c := range store.changes {
+		assert.Equal(t, "op1", c.OperationID)
+		types = append(types, c.ChangeType)
+	}
+	assert.Equal(t, []string{"metadata_update", ChangeBookSoftDelete, ChangeHashBlock}, types)
+}
+
+func TestResolveBookDuplicates_LinkAsVersions(t *testing.T) {
+	a := &database.Book{ID: "a"}
+	b := &database.Book{ID: "b"}
+	store := newResolveStore(a, b)
+
+	result, err := ResolveBookDuplicates(context.Background(), store, "op1", []BookResolveDecision{{
+		BookIDs: []string{"a", "b"},
+		Keep:    KeepBook,
+		KeepID:  "b",
+	}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, result.Groups[0].Linked)
+
+	ga, gb := store.books["a"], store.books["b"]
+	require.NotNil(t, ga.VersionGroupID)
+	assert.Equal(t, *gb.VersionGroupID, *ga.VersionGroupID)
+	assert.False(t, *ga.IsPrimaryVersion)
+	assert.True(t, *gb.IsPrimaryVersion)
+	require.Len(t, store.changes, 2)
+	assert.Equal(t, ChangeVersionLink, store.changes[0].ChangeType)
+	assert.Equal(t, `{"version_group_id":null,"is_primary_version":null}`, store.changes[0].OldValue)
+}
+
+func TestResolveBookDuplicates_SkipsGoneBooks(t *testing.T) {
+	deleted := true
+	store := newResolveStore(
+		&database.Book{ID: "a"},
+		&database.Book{ID: "b", MarkedForDeletion: &deleted},
+	)
+	result, err := ResolveBookDuplicates(context.Background(), store, "op1", []BookResolveDecision{{
+		BookIDs:      []string{"a", "b", "missing"},
+		Keep:         KeepPrimary,
+		DeleteOthers: true,
+	}}, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Groups[0].SkippedNote)
+	assert.Empty(t, store.changes)
+}
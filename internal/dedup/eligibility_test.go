@@ -1,7 +1,7 @@
 // file: internal/dedup/eligibility_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: f2a3b4c5-d6e7-4f8a-9b0c-1d2e3f4a5b6c
-// last-edited: 2026-06-10
+// last-edited: 2026-10-16
 
 package dedup
 
@@ -61,20 +61,20 @@ func TestPairEligibility_TableDriven(t *testing.T) {
 		// ── series_volume_differs (structured SeriesSequence) ────────────────
 		{
 			name: "series_volume: distinct sequence numbers → suppressed",
-			a:    &database.Book{ID: "A5", Title: "Series Name 3", SeriesSequence: intPtr(3)},
-			b:    &database.Book{ID: "B5", Title: "Series Name 4", SeriesSequence: intPtr(4)},
+			a:    &database.Book{ID: "A5", Title: "Series Name 3", SeriesSequence: database.NewSeriesSeq(3)},
+			b:    &database.Book{ID: "B5", Title: "Series Name 4", SeriesSequence: database.NewSeriesSeq(4)},
 			wantOK: false,
 			wantSuppressors: []string{"series_volume_differs"},
 		},
 		{
 			name: "series_volume: same sequence number → eligible",
-			a:    &database.Book{ID: "A6", Title: "My Book", SeriesSequence: intPtr(1)},
-			b:    &database.Book{ID: "B6", Title: "My Book", SeriesSequence: intPtr(1)},
+			a:    &database.Book{ID: "A6", Title: "My Book", SeriesSequence: database.NewSeriesSeq(1)},
+			b:    &database.Book{ID: "B6", Title: "My Book", SeriesSequence: database.NewSeriesSeq(1)},
 			wantOK: true,
 		},
 		{
 			name: "series_volume: one has no sequence → eligible",
-			a:    &database.Book{ID: "A7", Title: "My Book", SeriesSequence: intPtr(3)},
+			a:    &database.Book{ID: "A7", Title: "My Book", SeriesSequence: database.NewSeriesSeq(3)},
 			b:    &database.Book{ID: "B7", Title: "My Book"},
 			wantOK: true,
 		},
@@ -198,8 +198,8 @@ func TestPairEligibility_SymmetricDecisions(t *testing.T) {
 		},
 		{
 			name: "series differs",
-			a:    &database.Book{ID: "A3", Title: "S 3", SeriesSequence: intPtr(3)},
-			b:    &database.Book{ID: "B3", Title: "S 4", SeriesSequence: intPtr(4)},
+			a:    &database.Book{ID: "A3", Title: "S 3", SeriesSequence: database.NewSeriesSeq(3)},
+			b:    &database.Book{ID: "B3", Title: "S 4", SeriesSequence: database.NewSeriesSeq(4)},
 		},
 		{
 			name: "eligible plain",
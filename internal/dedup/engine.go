@@ -1,7 +1,7 @@
 // file: internal/dedup/engine.go
-// version: 1.26.2
+// version: 1.27.0
 // guid: 8f3a1c6e-d472-4b9a-a5e1-7c2d9f0b3e84
-// last-edited: 2026-06-13
+// last-edited: 2026-10-16
 
 package dedup
 
@@ -1102,7 +1102,7 @@ func hasPlausibleAudio(book *database.Book) bool {
 // if no position can be determined.
 func seriesNumberOf(book *database.Book) string {
 	if book.SeriesSequence != nil {
-		return strconv.Itoa(*book.SeriesSequence)
+		return book.SeriesSequence.String()
 	}
 	return extractSeriesNumberFromTitle(book.Title)
 }
@@ -1,5 +1,5 @@
 // file: internal/dedup/engine_unit_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: f1a2b3c4-d5e6-7890-abcd-1234567890ab
 
 package dedup
@@ -283,7 +283,7 @@ func TestSeriesNumberOf_StructuredField(t *testing.T) {
 	book := &database.Book{
 		ID:             "BOOK_1",
 		Title:          "Foundation Book 3", // title says 3 but structured says 5
-		SeriesSequence: &seq,
+		SeriesSequence: database.NewSeriesSeq(seq),
 	}
 	// SeriesSequence should take priority over title extraction
 	got := seriesNumberOf(book)
@@ -1,5 +1,5 @@
 // file: internal/dedup/op_params.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b2c3d4e5-f6a7-8901-bcde-f12345678901
 
 // Package dedup: op_params.go defines the JSON-unmarshal parameter structs for
@@ -56,3 +56,9 @@ type SeriesMergeParams struct {
 type SeriesNormalizeParams struct {
 	LegacyOpID string `json:"legacy_op_id"`
 }
+
+// BookResolveParams are the parameters for the "dedup.book-resolve" operation.
+type BookResolveParams struct {
+	LegacyOpID string                `json:"legacy_op_id"`
+	Groups     []BookResolveDecision `json:"groups"`
+}
@@ -0,0 +1,315 @@
+// file: internal/diagnostics/bundle.go
+// version: 1.2.0
+// guid: 98a3465f-5aba-4239-99f3-733e6fe7c114
+// last-edited: 2026-10-17
+
+package diagnostics
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+const (
+	// bundleLogWindow and bundleLogLimit bound the activity log excerpt.
+	bundleLogWindow = 24 * time.Hour
+	bundleLogLimit  = 1000
+	// bundleOpWindow and bundleOpLimit bound the failed-operation scan;
+	// bundleOpLogLines is how many trailing log lines each one carries.
+	bundleOpWindow   = 7 * 24 * time.Hour
+	bundleOpLimit    = 500
+	bundleOpLogLines = 50
+
+	redactedValue = "<redacted>"
+	redactedPath  = "<path>"
+)
+
+// IssueBundleInfo carries the process state the issue bundle can't read
+// from the store.
+type IssueBundleInfo struct {
+	AppVersion string
+	Config     config.Config
+}
+
+// issueBundleSystem is written to system.json.
+type issueBundleSystem struct {
+	AppVersion    string    `json:"app_version"`
+	GoVersion     string    `json:"go_version"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	DatabaseType  string    `json:"database_type"`
+	SchemaVersion int       `json:"schema_version"`
+	LatestSchema  int       `json:"latest_schema_version"`
+	SchemaError   string    `json:"schema_error,omitempty"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	// Timezone is the configured schedule zone; GeneratedAt is UTC.
+	Timezone string `json:"timezone"`
+}
+
+// issueBundleLog is one sanitized activity log line. The user ID is
+// dropped.
+type issueBundleLog struct {
+	Source    string    `json:"source"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// issueBundleOpLog is one sanitized operation log line.
+type issueBundleOpLog struct {
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// issueBundleOp is one failed operation in failed_operations.json.
+type issueBundleOp struct {
+	ID          string             `json:"id"`
+	Type        string             `json:"type"`
+	Plugin      string             `json:"plugin,omitempty"`
+	Phase       string             `json:"phase,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	Progress    int                `json:"progress"`
+	Total       int                `json:"total"`
+	ResumeCount int                `json:"resume_count,omitempty"`
+	QueuedAt    time.Time          `json:"queued_at"`
+	StartedAt   *time.Time         `json:"started_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	Logs        []issueBundleOpLog `json:"logs"`
+}
+
+// WriteIssueBundle writes a ZIP meant to be attached to a public bug
+// report: system.json (versions, database type, schema version),
+// config.json, library_stats.json, logs.json (last 24h of activity) and
+// failed_operations.json (last 7 days). Nothing per-book is included;
+// secret config values are dropped and file paths, URL credentials and
+// inline tokens are redacted from every string.
+func (ds *Service) WriteIssueBundle(w io.Writer, info IssueBundleInfo) error {
+	zw := zip.NewWriter(w)
+
+	if err := WriteJSON(zw, "system.json", ds.issueBundleSystem(info)); err != nil {
+		return err
+	}
+	cfg, err := SanitizeConfig(info.Config)
+	if err != nil {
+		return err
+	}
+	if err := WriteJSON(zw, "config.json", cfg); err != nil {
+		return err
+	}
+	if err := WriteJSON(zw, "library_stats.json", ds.issueBundleStats()); err != nil {
+		return err
+	}
+	if err := WriteJSON(zw, "logs.json", ds.issueBundleLogs()); err != nil {
+		return err
+	}
+	if err := WriteJSON(zw, "failed_operations.json", ds.issueBundleFailedOps()); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (ds *Service) issueBundleSystem(info IssueBundleInfo) issueBundleSystem {
+	sys := issueBundleSystem{
+		AppVersion:   info.AppVersion,
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		DatabaseType: info.Config.DatabaseType,
+		GeneratedAt:  time.Now().UTC(),
+		Timezone:     config.Location().String(),
+	}
+	current, latest, err := database.SchemaVersion(ds.db)
+	sys.SchemaVersion, sys.LatestSchema = current, latest
+	if err != nil {
+		sys.SchemaError = RedactText(err.Error())
+	}
+	return sys
+}
+
+// issueBundleStats returns the cached library stats, or an empty object
+// when they can't be computed.
+func (ds *Service) issueBundleStats() any {
+	stats, err := ds.db.GetDashboardStats()
+	if err != nil || stats == nil {
+		return map[string]any{}
+	}
+	return stats
+}
+
+func (ds *Service) issueBundleLogs() []issueBundleLog {
+	logs, err := ds.db.GetSystemActivityLogs("", bundleLogLimit)
+	out := []issueBundleLog{}
+	if err != nil {
+		return out
+	}
+	cutoff := time.Now().Add(-bundleLogWindow)
+	for _, l := range logs {
+		if l.CreatedAt.Before(cutoff) {
+			continue
+		}
+		out = append(out, issueBundleLog{
+			Source:    l.Source,
+			Level:     l.Level,
+			Message:   RedactText(l.Message),
+			CreatedAt: l.CreatedAt,
+		})
+	}
+	return out
+}
+
+// issueBundleFailedOps collects failed operations from both the v2
+// registry and the legacy operations table. Params and result data are
+// left out: they routinely carry paths and book IDs.
+func (ds *Service) issueBundleFailedOps() []issueBundleOp {
+	out := []issueBundleOp{}
+	seen := map[string]bool{}
+
+	if rows, err := ds.db.ListOperationsV2Since(time.Now().Add(-bundleOpWindow), bundleOpLimit); err == nil {
+		for _, r := range rows {
+			if r.Status != "failed" {
+				continue
+			}
+			op := issueBundleOp{
+				ID:          r.ID,
+				Type:        r.DefID,
+				Plugin:      r.Plugin,
+				Progress:    r.ProgressCurrent,
+				Total:       r.ProgressTotal,
+				ResumeCount: r.ResumeCount,
+				QueuedAt:    r.QueuedAt,
+				StartedAt:   r.StartedAt,
+				CompletedAt: r.CompletedAt,
+				Logs:        []issueBundleOpLog{},
+			}
+			if r.CurrentPhase != nil {
+				op.Phase = *r.CurrentPhase
+			}
+			if r.ErrorMessage != nil {
+				op.Error = RedactText(*r.ErrorMessage)
+			}
+			if lines, err := ds.db.GetOpLogsV2(r.ID, bundleOpLogLines); err == nil {
+				for _, l := range lines {
+					op.Logs = append(op.Logs, issueBundleOpLog{Level: l.Level, Message: RedactText(l.Message), CreatedAt: l.CreatedAt})
+				}
+			}
+			seen[r.ID] = true
+			out = append(out, op)
+		}
+	}
+
+	if ops, err := ds.db.GetRecentOperations(bundleOpLimit); err == nil {
+		cutoff := time.Now().Add(-bundleOpWindow)
+		for _, o := range ops {
+			if o.Status != "failed" || seen[o.ID] || o.CreatedAt.Before(cutoff) {
+				continue
+			}
+			op := issueBundleOp{
+				ID:          o.ID,
+				Type:        o.Type,
+				Progress:    o.Progress,
+				Total:       o.Total,
+				QueuedAt:    o.CreatedAt,
+				StartedAt:   o.StartedAt,
+				CompletedAt: o.CompletedAt,
+				Logs:        []issueBundleOpLog{},
+			}
+			if o.ErrorMessage != nil {
+				op.Error = RedactText(*o.ErrorMessage)
+			}
+			if lines, err := ds.db.GetOperationLogs(o.ID); err == nil {
+				if len(lines) > bundleOpLogLines {
+					lines = lines[len(lines)-bundleOpLogLines:]
+				}
+				for _, l := range lines {
+					op.Logs = append(op.Logs, issueBundleOpLog{Level: l.Level, Message: RedactText(l.Message), CreatedAt: l.CreatedAt})
+				}
+			}
+			out = append(out, op)
+		}
+	}
+	return out
+}
+
+// secretKeyPattern matches config keys whose values are credentials.
+var secretKeyPattern = regexp.MustCompile(`(?i)(password|secret|token|api_?key|private|credential|salt|activation_?bytes)`)
+
+// SanitizeConfig returns cfg as a JSON object with every non-empty secret
+// value replaced by "<redacted>" and every other string passed through
+// RedactText.
+func SanitizeConfig(cfg config.Config) (map[string]any, error) {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return sanitizeValue("", out).(map[string]any), nil
+}
+
+func sanitizeValue(key string, v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			t[k] = sanitizeValue(k, child)
+		}
+		return t
+	case []any:
+		for i, child := range t {
+			t[i] = sanitizeValue(key, child)
+		}
+		return t
+	case string:
+		if t == "" {
+			return t
+		}
+		if secretKeyPattern.MatchString(key) {
+			return redactedValue
+		}
+		return RedactText(t)
+	default:
+		return v
+	}
+}
+
+var (
+	// urlCredentialPattern matches the user:pass@ part of a URL.
+	urlCredentialPattern = regexp.MustCompile(`://[^/\s:@]+:[^/\s@]+@`)
+	// inlineSecretPattern matches key=value / "key": "value" pairs whose
+	// value is a credential.
+	inlineSecretPattern = regexp.MustCompile(`(?i)\b(password|passwd|secret|token|api[_-]?key)(["']?\s*[:=]\s*["']?)[^\s"'&,;]+`)
+	// bearerPattern matches bearer tokens in logged headers.
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer\s+)[^\s"'&,;]+`)
+	// unixPathPattern matches absolute and home-relative paths with at
+	// least two components, at the start of the string or after a
+	// delimiter so URL paths are left alone. Directory components may
+	// contain spaces; the last one may not, so trailing prose survives.
+	unixPathPattern = regexp.MustCompile(`(^|[\s"'=(\[,:])~?(?:/[^\s/"'(),;:]+(?: [^\s/"'(),;:]+)*)+/[^\s/"'(),;:]+/?`)
+	// windowsPathPattern matches drive-letter and UNC paths.
+	windowsPathPattern = regexp.MustCompile(`(?:\b[A-Za-z]:|\\\\[^\s\\"']+)\\[^\s"'(),;]*`)
+)
+
+// RedactText strips file paths, URL credentials and inline secrets from s.
+func RedactText(s string) string {
+	s = urlCredentialPattern.ReplaceAllString(s, "://"+redactedValue+"@")
+	s = inlineSecretPattern.ReplaceAllString(s, "${1}${2}"+redactedValue)
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redactedValue)
+	s = windowsPathPattern.ReplaceAllString(s, redactedPath)
+	s = unixPathPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasPrefix(m, "/") || strings.HasPrefix(m, "~") {
+			return redactedPath
+		}
+		return m[:1] + redactedPath
+	})
+	return s
+}
@@ -0,0 +1,19 @@
+// file: internal/doctor/disk_unix.go
+// version: 1.0.0
+// guid: 72c70b19-b5c1-4a1e-a91d-e189b194486a
+
+//go:build !windows
+
+package doctor
+
+import "syscall"
+
+// diskStats returns total, free bytes for the given path.
+func diskStats(path string) (total, free uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	blockSize := uint64(stat.Bsize)
+	return stat.Blocks * blockSize, stat.Bavail * blockSize, nil
+}
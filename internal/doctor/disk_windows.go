@@ -0,0 +1,34 @@
+// file: internal/doctor/disk_windows.go
+// version: 1.0.0
+// guid: c226aa2e-78a6-472b-8026-08bbece7f4bc
+
+//go:build windows
+
+package doctor
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// diskStats returns total, free bytes for the given path using Windows API.
+func diskStats(path string) (total, free uint64, err error) {
+	kernel32 := syscall.NewLazyDLL("kernel32.dll")
+	proc := kernel32.NewProc("GetDiskFreeSpaceExW")
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid path: %w", err)
+	}
+	var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+	r1, _, e1 := proc.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeBytesAvailable)),
+		uintptr(unsafe.Pointer(&totalBytes)),
+		uintptr(unsafe.Pointer(&totalFreeBytes)),
+	)
+	if r1 == 0 {
+		return 0, 0, fmt.Errorf("GetDiskFreeSpaceExW failed: %w", e1)
+	}
+	return totalBytes, freeBytesAvailable, nil
+}
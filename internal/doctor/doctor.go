@@ -0,0 +1,375 @@
+// file: internal/doctor/doctor.go
+// version: 1.1.0
+// guid: f38f4aac-7b6b-4a44-a0e1-728904163774
+// last-edited: 2026-10-17
+
+// Package doctor implements the startup self-check behind the
+// `audiobook-organizer doctor` CLI command and GET /api/v1/system/doctor.
+//
+// Each check is independent and never aborts the run: a broken database
+// should not hide the fact that ffprobe is also missing. The report is
+// meant to be actionable, so every non-ok check carries a Remedy string
+// telling the operator what to do about it.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// severity orders statuses so the report can roll up to the worst one.
+func (s Status) severity() int {
+	switch s {
+	case StatusFail:
+		return 3
+	case StatusWarn:
+		return 2
+	case StatusOK:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Check is one line of the doctor report.
+type Check struct {
+	Category   string `json:"category"`
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	Message    string `json:"message"`
+	Remedy     string `json:"remedy,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report is the full result of a doctor run.
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Overall     Status    `json:"overall"`
+	Checks      []Check   `json:"checks"`
+}
+
+// Counts returns how many checks ended in each status.
+func (r *Report) Counts() map[Status]int {
+	out := make(map[Status]int, 4)
+	for _, c := range r.Checks {
+		out[c.Status]++
+	}
+	return out
+}
+
+// Store is the narrow slice of database.Store the doctor needs.
+type Store interface {
+	CountBooks() (int, error)
+	CountAuthors() (int, error)
+	GetAllImportPaths() ([]database.ImportPath, error)
+}
+
+// DefaultMinFreeBytes is the free-space floor below which the disk check
+// fails outright (1 GiB). Below 5% free the check only warns.
+const DefaultMinFreeBytes uint64 = 1 << 30
+
+// providerProbeURLs maps metadata source IDs to a cheap URL used for the
+// reachability probe. Sources not listed here are reported as skipped.
+var providerProbeURLs = map[string]string{
+	"audible":      "https://api.audible.com",
+	"openlibrary":  "https://openlibrary.org",
+	"audnexus":     "https://api.audnex.us",
+	"google-books": "https://www.googleapis.com/books/v1/volumes?q=isbn:0",
+	"hardcover":    "https://api.hardcover.app/v1/graphql",
+}
+
+// Options configures a doctor run. Zero values pick sensible defaults so
+// callers only fill in what they have.
+type Options struct {
+	// Store is optional; a nil store produces a failed database check.
+	Store Store
+	// Config is the configuration snapshot to validate.
+	Config config.Config
+	// SkipNetwork disables the provider reachability probes.
+	SkipNetwork bool
+	// HTTPClient is used for provider probes (default: 5s timeout).
+	HTTPClient *http.Client
+	// MinFreeBytes overrides DefaultMinFreeBytes.
+	MinFreeBytes uint64
+	// LookPath overrides exec.LookPath (tests).
+	LookPath func(file string) (string, error)
+	// DiskStats overrides the platform free-space probe (tests).
+	DiskStats func(path string) (total, free uint64, err error)
+}
+
+func (o *Options) applyDefaults() {
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	if o.MinFreeBytes == 0 {
+		o.MinFreeBytes = DefaultMinFreeBytes
+	}
+	if o.LookPath == nil {
+		o.LookPath = exec.LookPath
+	}
+	if o.DiskStats == nil {
+		o.DiskStats = diskStats
+	}
+}
+
+// Run executes every check and returns the rolled-up report.
+func Run(ctx context.Context, opts Options) *Report {
+	opts.applyDefaults()
+	r := &Report{GeneratedAt: time.Now().UTC(), Overall: StatusOK}
+
+	add := func(category, name string, fn func() Check) {
+		start := time.Now()
+		c := fn()
+		c.Category = category
+		c.Name = name
+		c.DurationMS = time.Since(start).Milliseconds()
+		r.Checks = append(r.Checks, c)
+		if c.Status.severity() > r.Overall.severity() {
+			r.Overall = c.Status
+		}
+	}
+
+	add("database", "integrity", func() Check { return checkDatabase(opts.Store) })
+	add("config", "validate", func() Check { return checkConfig(opts.Config) })
+
+	for _, p := range pathTargets(opts) {
+		add("paths", p.label, func() Check { return checkPath(p) })
+	}
+
+	for _, tool := range []string{"ffmpeg", "ffprobe"} {
+		add("tools", tool, func() Check { return checkTool(opts.LookPath, tool) })
+	}
+
+	for _, src := range opts.Config.MetadataSources {
+		add("providers", src.ID, func() Check { return checkProvider(ctx, opts, src) })
+	}
+
+	for _, dir := range diskTargets(opts.Config) {
+		add("disk", dir, func() Check { return checkDisk(opts, dir) })
+	}
+
+	return r
+}
+
+func checkDatabase(store Store) Check {
+	if store == nil {
+		return Check{
+			Status:  StatusFail,
+			Message: "database store is not initialized",
+			Remedy:  "check --db / database_path and that no other process holds the Pebble lock",
+		}
+	}
+	books, err := store.CountBooks()
+	if err != nil {
+		return Check{
+			Status:  StatusFail,
+			Message: fmt.Sprintf("counting books failed: %v", err),
+			Remedy:  "run `audiobook-organizer diagnostics query --raw` to inspect the store, or restore from a backup",
+		}
+	}
+	authors, err := store.CountAuthors()
+	if err != nil {
+		return Check{
+			Status:  StatusFail,
+			Message: fmt.Sprintf("counting authors failed: %v", err),
+			Remedy:  "run `audiobook-organizer diagnostics query --raw` to inspect the store, or restore from a backup",
+		}
+	}
+	if _, err := store.GetAllImportPaths(); err != nil {
+		return Check{
+			Status:  StatusFail,
+			Message: fmt.Sprintf("reading import paths failed: %v", err),
+			Remedy:  "import path records may be corrupt; re-add them from the settings page",
+		}
+	}
+	return Check{Status: StatusOK, Message: fmt.Sprintf("%d books, %d authors readable", books, authors)}
+}
+
+func checkConfig(cfg config.Config) Check {
+	if err := cfg.Validate(); err != nil {
+		return Check{
+			Status:  StatusFail,
+			Message: err.Error(),
+			Remedy:  "fix the listed settings in the config file or via PUT /api/v1/config",
+		}
+	}
+	return Check{Status: StatusOK, Message: "configuration is valid"}
+}
+
+type pathTarget struct {
+	label    string
+	path     string
+	writable bool
+	required bool
+}
+
+func pathTargets(opts Options) []pathTarget {
+	targets := []pathTarget{
+		{label: "root_dir", path: opts.Config.RootDir, writable: true, required: true},
+		{label: "database_dir", path: filepath.Dir(opts.Config.DatabasePath), writable: true, required: true},
+	}
+	if opts.Store != nil {
+		if paths, err := opts.Store.GetAllImportPaths(); err == nil {
+			for _, ip := range paths {
+				if !ip.Enabled {
+					continue
+				}
+				targets = append(targets, pathTarget{label: "import:" + ip.Path, path: ip.Path})
+			}
+		}
+	}
+	return targets
+}
+
+func checkPath(p pathTarget) Check {
+	if p.path == "" {
+		if p.required {
+			return Check{Status: StatusWarn, Message: "not configured", Remedy: "set " + p.label + " in the configuration"}
+		}
+		return Check{Status: StatusSkip, Message: "not configured"}
+	}
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return Check{
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%s: %v", p.path, err),
+			Remedy:  "create the directory or fix the mount, then re-run doctor",
+		}
+	}
+	if !info.IsDir() {
+		return Check{Status: StatusFail, Message: p.path + " is not a directory", Remedy: "point " + p.label + " at a directory"}
+	}
+	if _, err := os.ReadDir(p.path); err != nil {
+		return Check{
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%s is not readable: %v", p.path, err),
+			Remedy:  "grant the service user read permission on " + p.path,
+		}
+	}
+	if p.writable {
+		f, err := os.CreateTemp(p.path, ".doctor-*")
+		if err != nil {
+			return Check{
+				Status:  StatusFail,
+				Message: fmt.Sprintf("%s is not writable: %v", p.path, err),
+				Remedy:  "grant the service user write permission on " + p.path,
+			}
+		}
+		name := f.Name()
+		f.Close()
+		os.Remove(name)
+		return Check{Status: StatusOK, Message: p.path + " is readable and writable"}
+	}
+	return Check{Status: StatusOK, Message: p.path + " is readable"}
+}
+
+func checkTool(lookPath func(string) (string, error), tool string) Check {
+	path, err := lookPath(tool)
+	if err != nil {
+		return Check{
+			Status:  StatusWarn,
+			Message: tool + " not found on PATH",
+			Remedy:  "install ffmpeg (which ships " + tool + ") — media info, remux and fingerprinting are degraded without it",
+		}
+	}
+	return Check{Status: StatusOK, Message: path}
+}
+
+func checkProvider(ctx context.Context, opts Options, src config.MetadataSource) Check {
+	if !src.Enabled {
+		return Check{Status: StatusSkip, Message: src.Name + " is disabled"}
+	}
+	if opts.SkipNetwork {
+		return Check{Status: StatusSkip, Message: "network checks disabled"}
+	}
+	url, ok := providerProbeURLs[src.ID]
+	if !ok {
+		return Check{Status: StatusSkip, Message: "no probe URL known for " + src.ID}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return Check{Status: StatusWarn, Message: err.Error()}
+	}
+	resp, err := opts.HTTPClient.Do(req)
+	if err != nil {
+		return Check{
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%s unreachable: %v", src.Name, err),
+			Remedy:  "check DNS, proxy and firewall settings, or disable the source if it is not needed",
+		}
+	}
+	resp.Body.Close()
+	// Any HTTP response proves reachability; 5xx means the provider is up
+	// but unhealthy, which is worth surfacing but not fatal.
+	if resp.StatusCode >= 500 {
+		return Check{
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%s responded %d", src.Name, resp.StatusCode),
+			Remedy:  "provider is having problems; metadata fetches may fail until it recovers",
+		}
+	}
+	return Check{Status: StatusOK, Message: fmt.Sprintf("%s reachable (HTTP %d)", src.Name, resp.StatusCode)}
+}
+
+func diskTargets(cfg config.Config) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, p := range []string{cfg.RootDir, filepath.Dir(cfg.DatabasePath)} {
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+func checkDisk(opts Options, dir string) Check {
+	total, free, err := opts.DiskStats(dir)
+	if err != nil {
+		return Check{Status: StatusSkip, Message: fmt.Sprintf("disk stats unavailable: %v", err)}
+	}
+	msg := fmt.Sprintf("%s free of %s", formatBytes(free), formatBytes(total))
+	if free < opts.MinFreeBytes {
+		return Check{
+			Status:  StatusFail,
+			Message: msg,
+			Remedy:  "free up space — organize and database compaction need headroom to run safely",
+		}
+	}
+	if total > 0 && free*100/total < 5 {
+		return Check{Status: StatusWarn, Message: msg, Remedy: "less than 5% free; consider freeing space soon"}
+	}
+	return Check{Status: StatusOK, Message: msg}
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,138 @@
+// file: internal/doctor/doctor_test.go
+// version: 1.0.0
+// guid: be63fa2d-47ff-4a04-8407-c9781be7b83f
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	books   int
+	err     error
+	imports []database.ImportPath
+}
+
+func (f *fakeStore) CountBooks() (int, error)   { return f.books, f.err }
+func (f *fakeStore) CountAuthors() (int, error) { return 1, f.err }
+func (f *fakeStore) GetAllImportPaths() ([]database.ImportPath, error) {
+	return f.imports, f.err
+}
+
+func baseOptions(t *testing.T) Options {
+	t.Helper()
+	dir := t.TempDir()
+	return Options{
+		Config: config.Config{
+			RootDir:      dir,
+			DatabasePath: filepath.Join(dir, "audiobooks.pebble"),
+			DatabaseType: "pebble",
+			PlaylistDir:  dir,
+		},
+		SkipNetwork: true,
+		LookPath:    func(f string) (string, error) { return "/usr/bin/" + f, nil },
+		DiskStats:   func(string) (uint64, uint64, error) { return 100 << 30, 50 << 30, nil },
+	}
+}
+
+func findCheck(r *Report, category, name string) *Check {
+	for i := range r.Checks {
+		if r.Checks[i].Category == category && r.Checks[i].Name == name {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
+
+func TestRun_AllHealthy(t *testing.T) {
+	opts := baseOptions(t)
+	opts.Store = &fakeStore{books: 3}
+
+	r := Run(context.Background(), opts)
+
+	assert.Equal(t, StatusOK, r.Overall)
+	db := findCheck(r, "database", "integrity")
+	require.NotNil(t, db)
+	assert.Contains(t, db.Message, "3 books")
+	assert.Zero(t, r.Counts()[StatusFail])
+}
+
+func TestRun_NilStoreFails(t *testing.T) {
+	r := Run(context.Background(), baseOptions(t))
+
+	assert.Equal(t, StatusFail, r.Overall)
+	db := findCheck(r, "database", "integrity")
+	require.NotNil(t, db)
+	assert.Equal(t, StatusFail, db.Status)
+	assert.NotEmpty(t, db.Remedy)
+}
+
+func TestRun_MissingToolWarns(t *testing.T) {
+	opts := baseOptions(t)
+	opts.Store = &fakeStore{}
+	opts.LookPath = func(f string) (string, error) {
+		if f == "ffprobe" {
+			return "", errors.New("not found")
+		}
+		return "/usr/bin/" + f, nil
+	}
+
+	r := Run(context.Background(), opts)
+
+	assert.Equal(t, StatusWarn, r.Overall)
+	assert.Equal(t, StatusWarn, findCheck(r, "tools", "ffprobe").Status)
+	assert.Equal(t, StatusOK, findCheck(r, "tools", "ffmpeg").Status)
+}
+
+func TestRun_UnreachableImportPathFails(t *testing.T) {
+	opts := baseOptions(t)
+	missing := filepath.Join(t.TempDir(), "gone")
+	opts.Store = &fakeStore{imports: []database.ImportPath{{Path: missing, Enabled: true}}}
+
+	r := Run(context.Background(), opts)
+
+	c := findCheck(r, "paths", "import:"+missing)
+	require.NotNil(t, c)
+	assert.Equal(t, StatusFail, c.Status)
+}
+
+func TestRun_LowDiskSpace(t *testing.T) {
+	opts := baseOptions(t)
+	opts.Store = &fakeStore{}
+	opts.DiskStats = func(string) (uint64, uint64, error) { return 100 << 30, 512 << 20, nil }
+
+	r := Run(context.Background(), opts)
+
+	assert.Equal(t, StatusFail, findCheck(r, "disk", opts.Config.RootDir).Status)
+}
+
+func TestCheckProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	orig := providerProbeURLs["openlibrary"]
+	providerProbeURLs["openlibrary"] = srv.URL
+	t.Cleanup(func() { providerProbeURLs["openlibrary"] = orig })
+
+	opts := baseOptions(t)
+	opts.SkipNetwork = false
+	opts.applyDefaults()
+
+	c := checkProvider(context.Background(), opts, config.MetadataSource{ID: "openlibrary", Name: "Open Library", Enabled: true})
+	assert.Equal(t, StatusWarn, c.Status)
+
+	c = checkProvider(context.Background(), opts, config.MetadataSource{ID: "openlibrary", Enabled: false})
+	assert.Equal(t, StatusSkip, c.Status)
+}
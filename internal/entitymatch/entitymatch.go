@@ -0,0 +1,400 @@
+// file: internal/entitymatch/entitymatch.go
+// version: 1.0.0
+// guid: 832da658-5346-446b-aa53-cdd7cacaa034
+// last-edited: 2026-10-16
+
+// Package entitymatch resolves author and series names read from tags or
+// fetched metadata to existing records when the spelling differs only
+// slightly ("Sanderson, Brandon", "Brandon  Sanderson", "Brandon Sandersen").
+// The store's own GetAuthorByName/GetSeriesByName only fold case, so without
+// this every variant becomes a new author or series.
+//
+// A Resolver is created per import run (a scan, a metadata apply). It loads
+// the author and series lists on the first lookup the store could not answer
+// exactly and keeps them current with AddAuthor/AddSeries as the run creates
+// records. Every non-exact match it makes is recorded so the auto-merges can
+// be reviewed later (see ListAutoMerges).
+package entitymatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/matcher"
+)
+
+// Strictness levels for entity_match_strictness.
+const (
+	// StrictnessExact only reuses records the store matches by name.
+	StrictnessExact = "exact"
+	// StrictnessNormalized also ignores punctuation, diacritics, spacing,
+	// "Last, First" order and leading articles.
+	StrictnessNormalized = "normalized"
+	// StrictnessFuzzy additionally accepts normalized names within
+	// entity_match_threshold similarity (Levenshtein).
+	StrictnessFuzzy = "fuzzy"
+)
+
+// Kinds of entity a Resolver matches.
+const (
+	KindAuthor = "author"
+	KindSeries = "series"
+)
+
+// autoMergePrefix keys the auto-merge report in the raw KV space:
+// entity_auto_merge:<kind>:<input name, lowercased>.
+const autoMergePrefix = "entity_auto_merge:"
+
+// minFuzzyKeyLen keeps fuzzy matching away from short names, where a
+// single edit is a different person ("Ann Lee" vs "Ian Lee").
+const minFuzzyKeyLen = 6
+
+// defaultThreshold applies when entity_match_threshold is unset.
+const defaultThreshold = 0.92
+
+// letterFolds covers letters that NFD does not split into a base letter
+// and a combining mark.
+var letterFolds = map[rune]string{
+	'ł': "l", 'ø': "o", 'đ': "d", 'ß': "ss", 'æ': "ae", 'œ': "oe",
+}
+
+// Store is the persistence a Resolver reads records from and records
+// auto-merges to.
+type Store interface {
+	GetAllAuthors() ([]database.Author, error)
+	GetAllSeries() ([]database.Series, error)
+	database.RawKVStore
+}
+
+// AutoMerge is one name variant that was resolved to an existing record
+// instead of creating a new one.
+type AutoMerge struct {
+	Kind        string `json:"kind"`
+	Input       string `json:"input"`
+	MatchedID   int    `json:"matched_id"`
+	MatchedName string `json:"matched_name"`
+	// Strategy is "normalized" or "fuzzy".
+	Strategy  string    `json:"strategy"`
+	Score     float64   `json:"score"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+type entry struct {
+	id       int
+	name     string
+	key      string
+	digits   string
+	authorID *int
+}
+
+// Resolver matches names against the library's authors and series. A nil
+// *Resolver matches nothing. Safe for concurrent use.
+type Resolver struct {
+	strictness string
+	threshold  float64
+	store      Store
+
+	mu      sync.Mutex
+	loaded  bool
+	authors []entry
+	series  []entry
+}
+
+// NewResolver returns a resolver for cfg's strictness, or nil when cfg
+// asks for exact matching only.
+func NewResolver(cfg *config.Config, store Store) *Resolver {
+	if cfg == nil || store == nil {
+		return nil
+	}
+	strictness := cfg.EntityMatchStrictness
+	if strictness == "" {
+		strictness = StrictnessNormalized
+	}
+	if strictness == StrictnessExact {
+		return nil
+	}
+	threshold := cfg.EntityMatchThreshold
+	if threshold <= 0 || threshold > 1 {
+		threshold = defaultThreshold
+	}
+	return &Resolver{
+		strictness: strictness,
+		threshold:  threshold,
+		store:      store,
+	}
+}
+
+// MatchAuthor returns the existing author name resolves to, or nil when
+// there is none and a new author should be created. Call it after the
+// store's exact lookup missed.
+func (r *Resolver) MatchAuthor(name string) (*database.Author, error) {
+	if r == nil {
+		return nil, nil
+	}
+	e, strategy, score, err := r.match(KindAuthor, name, nil)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	r.record(KindAuthor, name, *e, strategy, score)
+	return &database.Author{ID: e.id, Name: e.name}, nil
+}
+
+// MatchSeries is MatchAuthor for series. Only series with the same author
+// (or, for a nil authorID, with no author) are considered.
+func (r *Resolver) MatchSeries(name string, authorID *int) (*database.Series, error) {
+	if r == nil {
+		return nil, nil
+	}
+	e, strategy, score, err := r.match(KindSeries, name, authorID)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	r.record(KindSeries, name, *e, strategy, score)
+	return &database.Series{ID: e.id, Name: e.name, AuthorID: e.authorID}, nil
+}
+
+// AddAuthor makes an author created during the run matchable.
+func (r *Resolver) AddAuthor(a *database.Author) {
+	if r == nil || a == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.loaded {
+		r.authors = append(r.authors, newEntry(KindAuthor, a.ID, a.Name, nil))
+	}
+}
+
+// AddSeries makes a series created during the run matchable.
+func (r *Resolver) AddSeries(s *database.Series) {
+	if r == nil || s == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.loaded {
+		r.series = append(r.series, newEntry(KindSeries, s.ID, s.Name, s.AuthorID))
+	}
+}
+
+func (r *Resolver) match(kind, name string, authorID *int) (*entry, string, float64, error) {
+	key := Normalize(kind, name)
+	if key == "" {
+		return nil, "", 0, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.loadLocked(); err != nil {
+		return nil, "", 0, fmt.Errorf("load %s names: %w", kind, err)
+	}
+	candidates := r.authors
+	if kind == KindSeries {
+		candidates = r.series
+	}
+
+	var best *entry
+	bestScore := 0.0
+	digits := digitsOf(key)
+	for i := range candidates {
+		c := &candidates[i]
+		if kind == KindSeries && !sameAuthor(c.authorID, authorID) {
+			continue
+		}
+		if c.key == key {
+			if best == nil || bestScore < 1 || c.id < best.id {
+				best, bestScore = c, 1
+			}
+			continue
+		}
+		if r.strictness != StrictnessFuzzy || bestScore == 1 {
+			continue
+		}
+		if c.digits != digits || len(key) < minFuzzyKeyLen || len(c.key) < minFuzzyKeyLen {
+			continue
+		}
+		longer := max(len(key), len(c.key))
+		if float64(longer-min(len(key), len(c.key)))/float64(longer) > 1-r.threshold {
+			continue
+		}
+		score := 1 - float64(matcher.LevenshteinDistance(key, c.key))/float64(longer)
+		if score >= r.threshold && (score > bestScore || (score == bestScore && c.id < best.id)) {
+			best, bestScore = c, score
+		}
+	}
+	if best == nil {
+		return nil, "", 0, nil
+	}
+	strategy := StrictnessFuzzy
+	if bestScore == 1 {
+		strategy = StrictnessNormalized
+	}
+	return best, strategy, bestScore, nil
+}
+
+func (r *Resolver) loadLocked() error {
+	if r.loaded {
+		return nil
+	}
+	authors, err := r.store.GetAllAuthors()
+	if err != nil {
+		return err
+	}
+	series, err := r.store.GetAllSeries()
+	if err != nil {
+		return err
+	}
+	r.authors = make([]entry, 0, len(authors))
+	for _, a := range authors {
+		r.authors = append(r.authors, newEntry(KindAuthor, a.ID, a.Name, nil))
+	}
+	r.series = make([]entry, 0, len(series))
+	for _, s := range series {
+		r.series = append(r.series, newEntry(KindSeries, s.ID, s.Name, s.AuthorID))
+	}
+	r.loaded = true
+	return nil
+}
+
+// record adds one hit to the auto-merge report. Failures are ignored: the
+// report must never fail an import.
+func (r *Resolver) record(kind, input string, e entry, strategy string, score float64) {
+	key := autoMergePrefix + kind + ":" + strings.ToLower(strings.TrimSpace(input))
+	now := time.Now().UTC()
+	am := AutoMerge{Kind: kind, Input: strings.TrimSpace(input), FirstSeen: now}
+	if raw, err := r.store.GetRaw(key); err == nil && raw != nil {
+		_ = json.Unmarshal(raw, &am)
+	}
+	if am.MatchedID != e.id {
+		am.Count = 0
+	}
+	am.MatchedID = e.id
+	am.MatchedName = e.name
+	am.Strategy = strategy
+	am.Score = score
+	am.Count++
+	am.LastSeen = now
+	if data, err := json.Marshal(am); err == nil {
+		_ = r.store.SetRaw(key, data)
+	}
+}
+
+// ListAutoMerges returns the recorded auto-merges, most recent first.
+// kind filters to KindAuthor or KindSeries; empty returns both.
+func ListAutoMerges(store database.RawKVStore, kind string) ([]AutoMerge, error) {
+	prefix := autoMergePrefix
+	if kind != "" {
+		prefix += kind + ":"
+	}
+	pairs, err := store.ScanPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AutoMerge, 0, len(pairs))
+	for _, p := range pairs {
+		var am AutoMerge
+		if err := json.Unmarshal(p.Value, &am); err != nil {
+			continue
+		}
+		out = append(out, am)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].LastSeen.After(out[j].LastSeen) })
+	return out, nil
+}
+
+// Normalize returns the comparison key for an author or series name:
+// lowercased, diacritics and punctuation removed, "Last, First" authors
+// reordered, and leading articles and a trailing "series" dropped from
+// series names.
+func Normalize(kind, name string) string {
+	name = strings.TrimSpace(name)
+	if kind == KindAuthor {
+		if last, first, ok := strings.Cut(name, ","); ok && !strings.Contains(first, ",") &&
+			strings.TrimSpace(last) != "" && strings.TrimSpace(first) != "" {
+			name = first + " " + last
+		}
+	}
+	var words []string
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			words = append(words, b.String())
+			b.Reset()
+		}
+	}
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			lower := unicode.ToLower(r)
+			if folded, ok := letterFolds[lower]; ok {
+				b.WriteString(folded)
+			} else {
+				b.WriteRune(lower)
+			}
+		case r == '\'' || r == '’' || r == '.':
+			// "O'Brien" and "J.R.R." keep their letters together.
+		default:
+			flush()
+		}
+	}
+	flush()
+	if kind == KindAuthor {
+		// "J. R. R." and "J.R.R." both become "jrr".
+		var compact []string
+		prevInitial := false
+		for _, w := range words {
+			initial := len([]rune(w)) == 1
+			if initial && prevInitial {
+				compact[len(compact)-1] += w
+			} else {
+				compact = append(compact, w)
+			}
+			prevInitial = initial
+		}
+		words = compact
+	}
+	if kind == KindSeries {
+		if len(words) > 1 && (words[0] == "the" || words[0] == "a" || words[0] == "an") {
+			words = words[1:]
+		}
+		if len(words) > 1 && words[len(words)-1] == "the" {
+			words = words[:len(words)-1]
+		}
+		if len(words) > 1 && words[len(words)-1] == "series" {
+			words = words[:len(words)-1]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func newEntry(kind string, id int, name string, authorID *int) entry {
+	key := Normalize(kind, name)
+	return entry{id: id, name: name, key: key, digits: digitsOf(key), authorID: authorID}
+}
+
+func digitsOf(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func sameAuthor(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
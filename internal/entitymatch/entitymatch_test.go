@@ -0,0 +1,148 @@
+// file: internal/entitymatch/entitymatch_test.go
+// version: 1.0.0
+// guid: 7ddfc91c-eb51-4a1b-bc21-442147b67da9
+// last-edited: 2026-10-16
+
+package entitymatch
+
+import (
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) database.Store {
+	t.Helper()
+	store, err := database.NewPebbleStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		kind, a, b string
+	}{
+		{KindAuthor, "Sanderson, Brandon", "Brandon Sanderson"},
+		{KindAuthor, "J.R.R. Tolkien", "J. R. R. Tolkien"},
+		{KindAuthor, "Stanisław Lem", "Stanislaw Lem"},
+		{KindAuthor, "Patrick  O'Brian", "patrick obrian"},
+		{KindSeries, "The Stormlight Archive", "Stormlight Archive"},
+		{KindSeries, "Wheel of Time, The", "The Wheel of Time"},
+		{KindSeries, "Expanse Series", "The Expanse"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, Normalize(tc.kind, tc.b), Normalize(tc.kind, tc.a), tc.a)
+	}
+	assert.NotEqual(t, Normalize(KindSeries, "Discworld 2"), Normalize(KindSeries, "Discworld 3"))
+}
+
+func TestNewResolver_Exact(t *testing.T) {
+	assert.Nil(t, NewResolver(&config.Config{EntityMatchStrictness: StrictnessExact}, newStore(t)))
+	var r *Resolver
+	a, err := r.MatchAuthor("Anyone")
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+}
+
+func TestMatchAuthor_Normalized(t *testing.T) {
+	store := newStore(t)
+	existing, err := store.CreateAuthor("Brandon Sanderson")
+	require.NoError(t, err)
+
+	r := NewResolver(&config.Config{EntityMatchStrictness: StrictnessNormalized, EntityMatchThreshold: 0.9}, store)
+	got, err := r.MatchAuthor("Sanderson, Brandon")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, existing.ID, got.ID)
+
+	// A typo needs fuzzy matching.
+	got, err = r.MatchAuthor("Brandon Sandersen")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestMatchAuthor_Fuzzy(t *testing.T) {
+	store := newStore(t)
+	existing, err := store.CreateAuthor("Brandon Sanderson")
+	require.NoError(t, err)
+	_, err = store.CreateAuthor("Ann Lee")
+	require.NoError(t, err)
+
+	r := NewResolver(&config.Config{EntityMatchStrictness: StrictnessFuzzy, EntityMatchThreshold: 0.9}, store)
+	got, err := r.MatchAuthor("Brandon Sandersen")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, existing.ID, got.ID)
+
+	// Short names never fuzzy-match.
+	got, err = r.MatchAuthor("Ian Lee")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Authors created during the run become matchable.
+	created, err := store.CreateAuthor("Mary Robinette Kowal")
+	require.NoError(t, err)
+	r.AddAuthor(created)
+	got, err = r.MatchAuthor("Mary Robinette Kowall")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, created.ID, got.ID)
+}
+
+func TestMatchSeries_ScopedToAuthor(t *testing.T) {
+	store := newStore(t)
+	a1, err := store.CreateAuthor("Robert Jordan")
+	require.NoError(t, err)
+	a2, err := store.CreateAuthor("Someone Else")
+	require.NoError(t, err)
+	s1, err := store.CreateSeries("The Wheel of Time", &a1.ID)
+	require.NoError(t, err)
+	_, err = store.CreateSeries("Discworld 2", nil)
+	require.NoError(t, err)
+
+	r := NewResolver(&config.Config{EntityMatchStrictness: StrictnessFuzzy, EntityMatchThreshold: 0.9}, store)
+	got, err := r.MatchSeries("Wheel of Time, The", &a1.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, s1.ID, got.ID)
+
+	got, err = r.MatchSeries("Wheel of Time", &a2.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Differing numbers are different series however close the names.
+	got, err = r.MatchSeries("Discworld 3", nil)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListAutoMerges(t *testing.T) {
+	store := newStore(t)
+	existing, err := store.CreateAuthor("Brandon Sanderson")
+	require.NoError(t, err)
+	r := NewResolver(&config.Config{EntityMatchStrictness: StrictnessFuzzy, EntityMatchThreshold: 0.9}, store)
+	for _, name := range []string{"Sanderson, Brandon", "Brandon Sandersen", "Brandon Sandersen"} {
+		_, err := r.MatchAuthor(name)
+		require.NoError(t, err)
+	}
+
+	merges, err := ListAutoMerges(store, KindAuthor)
+	require.NoError(t, err)
+	require.Len(t, merges, 2)
+	byInput := map[string]AutoMerge{}
+	for _, m := range merges {
+		byInput[m.Input] = m
+		assert.Equal(t, existing.ID, m.MatchedID)
+	}
+	assert.Equal(t, StrictnessNormalized, byInput["Sanderson, Brandon"].Strategy)
+	assert.Equal(t, StrictnessFuzzy, byInput["Brandon Sandersen"].Strategy)
+	assert.Equal(t, 2, byInput["Brandon Sandersen"].Count)
+
+	merges, err = ListAutoMerges(store, KindSeries)
+	require.NoError(t, err)
+	assert.Empty(t, merges)
+}
@@ -0,0 +1,362 @@
+// file: internal/freeze/freeze.go
+// version: 1.0.0
+// guid: 6d0b8e3f-a41c-4f72-9c5e-2b7d13e8a946
+// last-edited: 2026-10-16
+
+// Package freeze takes lightweight "freeze" snapshots of the library
+// before destructive bulk operations and restores them by operation ID.
+//
+// A snapshot is a database backup plus a manifest of the book files the
+// operation may touch, stored in a directory named after the operation:
+//
+//	<dir>/<operation id>/snapshot.json
+//	<dir>/<operation id>/files.json.gz
+//	<dir>/<operation id>/audiobooks_<type>_<timestamp>_freeze.tar.gz
+//
+// Snapshots live on disk rather than in the database so that restoring
+// the database does not erase the record of which snapshot was restored.
+// Audio files are not copied; a restore moves files that were relocated
+// back into place and reports files that no longer exist.
+package freeze
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/backup"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+const (
+	snapshotFile = "snapshot.json"
+	manifestFile = "files.json.gz"
+	backupLabel  = "freeze"
+
+	// DefaultRetention is the number of snapshots kept when Options
+	// leaves Retention unset.
+	DefaultRetention = 5
+)
+
+// ErrNotFound is returned when no snapshot exists for an operation.
+var ErrNotFound = errors.New("freeze: snapshot not found")
+
+// Store is the part of the database a snapshot reads.
+type Store interface {
+	GetAllBookFiles() ([]database.BookFile, error)
+	GetBookFiles(bookID string) ([]database.BookFile, error)
+}
+
+// Options locates snapshots and the database they back up.
+type Options struct {
+	// Dir holds one subdirectory per snapshot.
+	Dir          string
+	DatabasePath string
+	DatabaseType string
+	// Retention is how many snapshots Create keeps; 0 means
+	// DefaultRetention.
+	Retention int
+}
+
+// Snapshot describes one frozen operation.
+type Snapshot struct {
+	OperationID string          `json:"operation_id"`
+	DefID       string          `json:"def_id"`
+	Params      json.RawMessage `json:"params,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	// Backup is the database backup; its Path is relative to the
+	// snapshot directory.
+	Backup *backup.BackupInfo `json:"backup"`
+	Files  int                `json:"files"`
+	Books  int                `json:"books"`
+	// Scoped is true when the manifest covers only the books named in
+	// the operation's parameters.
+	Scoped bool `json:"scoped"`
+}
+
+// FileEntry is one book file as it was when the snapshot was taken.
+type FileEntry struct {
+	BookID string `json:"book_id"`
+	FileID string `json:"file_id"`
+	Path   string `json:"path"`
+	Size   int64  `json:"size,omitempty"`
+	Hash   string `json:"hash,omitempty"`
+}
+
+// Create snapshots the database and the files of the books in params
+// ("book_id" or "book_ids"; the whole library when neither is set) for
+// operation opID, then prunes old snapshots. It returns the existing
+// snapshot when opID already has one, so a resumed run keeps the state
+// from before its first attempt.
+func Create(ctx context.Context, store Store, opts Options, opID, defID string, params json.RawMessage) (*Snapshot, error) {
+	dir, err := snapshotDir(opts.Dir, opID)
+	if err != nil {
+		return nil, err
+	}
+	if snap, err := readSnapshot(dir); err == nil {
+		return snap, nil
+	}
+	if opts.DatabasePath == "" {
+		return nil, errors.New("freeze: database path is not configured")
+	}
+
+	bookIDs := scopeBookIDs(params)
+	files, err := manifestFiles(ctx, store, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the snapshot in a temporary directory and rename it into
+	// place, so a crash never leaves a half-written snapshot behind.
+	if err := os.MkdirAll(opts.Dir, 0o775); err != nil {
+		return nil, fmt.Errorf("freeze: create snapshot directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(opts.Dir, "."+opID+"-")
+	if err != nil {
+		return nil, fmt.Errorf("freeze: create snapshot directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := writeManifest(filepath.Join(tmp, manifestFile), files); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	info, err := backup.CreateBackup(opts.DatabasePath, opts.DatabaseType, backup.BackupConfig{
+		BackupDir:        tmp,
+		MaxBackups:       1,
+		CompressionLevel: gzip.DefaultCompression,
+		Label:            backupLabel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("freeze: back up database: %w", err)
+	}
+	info.Path = info.Filename
+
+	books := map[string]bool{}
+	for _, f := range files {
+		books[f.BookID] = true
+	}
+	snap := &Snapshot{
+		OperationID: opID,
+		DefID:       defID,
+		Params:      params,
+		CreatedAt:   time.Now().UTC(),
+		Backup:      info,
+		Files:       len(files),
+		Books:       len(books),
+		Scoped:      len(bookIDs) > 0,
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, snapshotFile), data, 0o644); err != nil {
+		return nil, fmt.Errorf("freeze: write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, fmt.Errorf("freeze: save snapshot: %w", err)
+	}
+
+	retention := opts.Retention
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	if err := prune(opts.Dir, retention, opID); err != nil {
+		return snap, fmt.Errorf("freeze: prune old snapshots: %w", err)
+	}
+	return snap, nil
+}
+
+// List returns the snapshots in dir, newest first.
+func List(dir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("freeze: list snapshots: %w", err)
+	}
+	var out []Snapshot
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		snap, err := readSnapshot(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		out = append(out, *snap)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+// Get returns the snapshot taken for operation opID.
+func Get(dir, opID string) (*Snapshot, error) {
+	sd, err := snapshotDir(dir, opID)
+	if err != nil {
+		return nil, err
+	}
+	return readSnapshot(sd)
+}
+
+// Manifest returns the files recorded in operation opID's snapshot.
+func Manifest(dir, opID string) ([]FileEntry, error) {
+	sd, err := snapshotDir(dir, opID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(sd, manifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("freeze: open manifest: %w", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("freeze: read manifest: %w", err)
+	}
+	defer gz.Close()
+	var files []FileEntry
+	if err := json.NewDecoder(gz).Decode(&files); err != nil {
+		return nil, fmt.Errorf("freeze: decode manifest: %w", err)
+	}
+	return files, nil
+}
+
+// Delete removes operation opID's snapshot.
+func Delete(dir, opID string) error {
+	sd, err := snapshotDir(dir, opID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(sd); errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return os.RemoveAll(sd)
+}
+
+// snapshotDir returns the directory for opID's snapshot, refusing IDs
+// that would escape dir.
+func snapshotDir(dir, opID string) (string, error) {
+	if dir == "" {
+		return "", errors.New("freeze: snapshot directory is not configured")
+	}
+	if opID == "" || strings.HasPrefix(opID, ".") || strings.ContainsAny(opID, `/\`) {
+		return "", fmt.Errorf("freeze: invalid operation id %q", opID)
+	}
+	return filepath.Join(dir, opID), nil
+}
+
+func readSnapshot(dir string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("freeze: read snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("freeze: decode snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// scopeBookIDs extracts the books an operation targets from its params.
+func scopeBookIDs(params json.RawMessage) []string {
+	var p struct {
+		BookID  string   `json:"book_id"`
+		BookIDs []string `json:"book_ids"`
+	}
+	if len(params) == 0 || json.Unmarshal(params, &p) != nil {
+		return nil
+	}
+	ids := p.BookIDs
+	if p.BookID != "" {
+		ids = append(ids, p.BookID)
+	}
+	return ids
+}
+
+func manifestFiles(ctx context.Context, store Store, bookIDs []string) ([]FileEntry, error) {
+	var rows []database.BookFile
+	if len(bookIDs) == 0 {
+		all, err := store.GetAllBookFiles()
+		if err != nil {
+			return nil, fmt.Errorf("freeze: list book files: %w", err)
+		}
+		rows = all
+	} else {
+		seen := map[string]bool{}
+		for _, id := range bookIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			files, err := store.GetBookFiles(id)
+			if err != nil {
+				return nil, fmt.Errorf("freeze: list files for book %s: %w", id, err)
+			}
+			rows = append(rows, files...)
+		}
+	}
+	out := make([]FileEntry, 0, len(rows))
+	for _, f := range rows {
+		if f.FilePath == "" {
+			continue
+		}
+		out = append(out, FileEntry{BookID: f.BookID, FileID: f.ID, Path: f.FilePath, Size: f.FileSize, Hash: f.FileHash})
+	}
+	return out, nil
+}
+
+func writeManifest(path string, files []FileEntry) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("freeze: create manifest: %w", err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	if err := json.NewEncoder(gz).Encode(files); err != nil {
+		return fmt.Errorf("freeze: write manifest: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("freeze: write manifest: %w", err)
+	}
+	return f.Close()
+}
+
+// prune removes the oldest snapshots beyond keep, never removing the one
+// for opID.
+func prune(dir string, keep int, opID string) error {
+	snaps, err := List(dir)
+	if err != nil {
+		return err
+	}
+	kept := 0
+	for _, s := range snaps {
+		if s.OperationID == opID || kept < keep {
+			kept++
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, s.OperationID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// file: internal/freeze/freeze_test.go
+// version: 1.1.0
+// guid: 15e9c3a8-6b2d-4f07-a3e1-8c4d92b7f061
+// last-edited: 2026-10-17
+
+package freeze
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	files []database.BookFile
+}
+
+func (s *fakeStore) GetAllBookFiles() ([]database.BookFile, error) { return s.files, nil }
+
+func (s *fakeStore) GetBookFiles(bookID string) ([]database.BookFile, error) {
+	var out []database.BookFile
+	for _, f := range s.files {
+		if f.BookID == bookID {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+}
+
+// openTestDB opens the Pebble database at path, closed with the test.
+func openTestDB(t *testing.T, path string) *database.PebbleStore {
+	t.Helper()
+	db, err := database.NewPebbleStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// testLibrary lays out a Pebble database holding one book and two books'
+// files.
+func testLibrary(t *testing.T) (Options, *fakeStore, string) {
+	t.Helper()
+	root := t.TempDir()
+	dbPath := filepath.Join(root, "data", "audiobooks.pebble")
+	db, err := database.NewPebbleStore(dbPath)
+	require.NoError(t, err)
+	_, err = db.CreateBook(&database.Book{ID: "before", Title: "Before", FilePath: "/x/before.m4b"})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	lib := filepath.Join(root, "library")
+	store := &fakeStore{files: []database.BookFile{
+		{ID: "f1", BookID: "b1", FilePath: filepath.Join(lib, "incoming", "one.m4b"), FileSize: 3},
+		{ID: "f2", BookID: "b2", FilePath: filepath.Join(lib, "incoming", "two.mp3"), FileSize: 3},
+	}}
+	for _, f := range store.files {
+		writeFile(t, f.FilePath, "mp3")
+	}
+	return Options{Dir: filepath.Join(root, "data", "snapshots"), DatabasePath: dbPath, DatabaseType: "pebble"}, store, lib
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	opts, store, _ := testLibrary(t)
+
+	snap, err := Create(ctx, store, opts, "op1", "library.transcode", json.RawMessage(`{"book_id":"b2"}`))
+	require.NoError(t, err)
+	assert.True(t, snap.Scoped)
+	assert.Equal(t, 1, snap.Files)
+	files, err := Manifest(opts.Dir, "op1")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "f2", files[0].FileID)
+	assert.FileExists(t, filepath.Join(opts.Dir, "op1", snap.Backup.Filename))
+
+	// A resumed run keeps the first snapshot.
+	again, err := Create(ctx, store, opts, "op1", "library.transcode", nil)
+	require.NoError(t, err)
+	assert.Equal(t, snap.CreatedAt, again.CreatedAt)
+
+	whole, err := Create(ctx, store, opts, "op2", "library.organize", json.RawMessage(`{"folder_path":"/x"}`))
+	require.NoError(t, err)
+	assert.False(t, whole.Scoped)
+	assert.Equal(t, 2, whole.Books)
+
+	list, err := List(opts.Dir)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "op2", list[0].OperationID)
+
+	_, err = Create(ctx, store, opts, "../escape", "x", nil)
+	assert.Error(t, err)
+	_, err = Get(opts.Dir, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCreate_Retention(t *testing.T) {
+	ctx := context.Background()
+	opts, store, _ := testLibrary(t)
+	opts.Retention = 2
+	for _, id := range []string{"op1", "op2", "op3"} {
+		_, err := Create(ctx, store, opts, id, "library.organize", nil)
+		require.NoError(t, err)
+	}
+	list, err := List(opts.Dir)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	_, err = Get(opts.Dir, "op1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, Delete(opts.Dir, "op3"))
+	assert.ErrorIs(t, Delete(opts.Dir, "op3"), ErrNotFound)
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	opts, store, lib := testLibrary(t)
+	_, err := Create(ctx, store, opts, "op1", "library.organize", nil)
+	require.NoError(t, err)
+
+	// The operation moves one book, deletes the other and rewrites the
+	// database.
+	moved := filepath.Join(lib, "Author", "One", "one.m4b")
+	writeFile(t, moved, "mp3")
+	require.NoError(t, os.Remove(store.files[0].FilePath))
+	require.NoError(t, os.Remove(store.files[1].FilePath))
+	original := store.files[0].FilePath
+	store.files[0].FilePath = moved
+	// Reopening rotates Pebble's MANIFEST, so the live directory holds a
+	// newer marker than the backup.
+	db, err := database.NewPebbleStore(opts.DatabasePath)
+	require.NoError(t, err)
+	_, err = db.CreateBook(&database.Book{ID: "after", Title: "After", FilePath: "/x/after.m4b"})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	ropts := RestoreOptions{DryRun: true, DatabasePath: opts.DatabasePath, DatabaseType: opts.DatabaseType}
+	res, err := Restore(ctx, store, opts.Dir, "op1", ropts)
+	require.NoError(t, err)
+	require.Len(t, res.FilesMoved, 1)
+	assert.Equal(t, FileMove{FileID: "f1", From: moved, To: original}, res.FilesMoved[0])
+	assert.Equal(t, []string{store.files[1].FilePath}, res.FilesMissing)
+	assert.False(t, res.RestartRequired)
+	assert.FileExists(t, moved)
+
+	ropts.DryRun = false
+	// Restore closes the live store itself; a second Close would panic.
+	db, err = database.NewPebbleStore(opts.DatabasePath)
+	require.NoError(t, err)
+	ropts.SafetyBackupDir = filepath.Join(filepath.Dir(opts.DatabasePath), "backups")
+	closed := false
+	ropts.CloseDatabase = func() error {
+		closed = true
+		return db.Close()
+	}
+	res, err = Restore(ctx, store, opts.Dir, "op1", ropts)
+	require.NoError(t, err)
+	assert.True(t, closed)
+	assert.True(t, res.RestartRequired)
+	assert.FileExists(t, original)
+	assert.NoFileExists(t, moved)
+	require.NotNil(t, res.Database.SafetyBackup)
+
+	restored := openTestDB(t, opts.DatabasePath)
+	book, err := restored.GetBookByID("before")
+	require.NoError(t, err)
+	assert.NotNil(t, book)
+	book, err = restored.GetBookByID("after")
+	require.NoError(t, err)
+	assert.Nil(t, book, "written after the snapshot")
+	entries, err := os.ReadDir(filepath.Dir(opts.DatabasePath))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".freeze-restore-")
+	}
+}
@@ -0,0 +1,203 @@
+// file: internal/freeze/restore.go
+// version: 1.2.0
+// guid: a7c25f90-3e1b-4d86-b8f4-91e06d2c5b37
+// last-edited: 2026-10-17
+
+package freeze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/falkcorp/audiobook-organizer/internal/backup"
+)
+
+// RestoreOptions controls Restore.
+type RestoreOptions struct {
+	// DryRun reports what the restore would do without moving files or
+	// touching the database.
+	DryRun bool
+	// DatabasePath is the live database the snapshot's backup replaces.
+	DatabasePath string
+	DatabaseType string
+	// SafetyBackupDir, when set, receives a backup of the current
+	// database before it is overwritten.
+	SafetyBackupDir string
+	// CloseDatabase, when set, closes the live database before the
+	// restored one is swapped in. A store left open keeps writing into
+	// the directory it opened, which after the swap is the restored one.
+	CloseDatabase func() error
+}
+
+// FileMove is a file Restore moved, or would move, back into place.
+type FileMove struct {
+	FileID string `json:"file_id"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RestoreResult reports a snapshot rollback: the audio files walked back
+// to their pre-operation paths, and the result of restoring the database
+// backup taken alongside them.
+type RestoreResult struct {
+	OperationID    string     `json:"operation_id"`
+	DryRun         bool       `json:"dry_run"`
+	FilesUnchanged int        `json:"files_unchanged"`
+	FilesMoved     []FileMove `json:"files_moved,omitempty"`
+	// FilesMissing were recorded by the snapshot but exist neither where
+	// they were nor where the database now places them, usually because
+	// the operation deleted or converted them.
+	FilesMissing []string              `json:"files_missing,omitempty"`
+	Database     *backup.RestoreResult `json:"database,omitempty"`
+	// RestartRequired is set once the database files have been replaced;
+	// the running server keeps its open handle until it restarts.
+	RestartRequired bool `json:"restart_required"`
+}
+
+// Restore returns the library to operation opID's snapshot: files the
+// operation moved are moved back, then the database backup replaces the
+// live database directory. store is the live database, consulted to find
+// where each file is now.
+func Restore(ctx context.Context, store Store, dir, opID string, opts RestoreOptions) (*RestoreResult, error) {
+	snap, err := Get(dir, opID)
+	if err != nil {
+		return nil, err
+	}
+	files, err := Manifest(dir, opID)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DatabasePath == "" {
+		return nil, errors.New("freeze: database path is not configured")
+	}
+
+	current, err := store.GetAllBookFiles()
+	if err != nil {
+		return nil, fmt.Errorf("freeze: list book files: %w", err)
+	}
+	now := make(map[string]string, len(current))
+	for _, f := range current {
+		now[f.ID] = f.FilePath
+	}
+
+	result := &RestoreResult{OperationID: opID, DryRun: opts.DryRun}
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if exists(f.Path) {
+			result.FilesUnchanged++
+			continue
+		}
+		from := now[f.FileID]
+		if from == "" || from == f.Path || !exists(from) {
+			result.FilesMissing = append(result.FilesMissing, f.Path)
+			continue
+		}
+		move := FileMove{FileID: f.FileID, From: from, To: f.Path}
+		if !opts.DryRun {
+			if err := moveBack(from, f.Path); err != nil {
+				move.Error = err.Error()
+			}
+		}
+		result.FilesMoved = append(result.FilesMoved, move)
+	}
+
+	backupPath := filepath.Join(dir, opID, snap.Backup.Filename)
+	var dbResult *backup.RestoreResult
+	if opts.DryRun {
+		dbResult, err = backup.Restore(backupPath, filepath.Dir(opts.DatabasePath), backup.RestoreOptions{
+			Verify:       backup.VerifyChecksum,
+			DryRun:       true,
+			DatabaseType: opts.DatabaseType,
+		})
+	} else {
+		dbResult, err = replaceDatabase(backupPath, opts)
+	}
+	result.Database = dbResult
+	if err != nil {
+		return result, fmt.Errorf("freeze: restore database: %w", err)
+	}
+	result.RestartRequired = !opts.DryRun
+	return result, nil
+}
+
+// replaceDatabase restores the backup at backupPath in place of the live
+// database. Extracting over a Pebble directory would leave its newer
+// MANIFEST and marker files beside the restored ones, and Pebble opens
+// whichever marker is newest, so the backup is extracted into a staging
+// directory next to the database and renamed over it once the live store
+// is closed.
+func replaceDatabase(backupPath string, opts RestoreOptions) (*backup.RestoreResult, error) {
+	parent := filepath.Dir(opts.DatabasePath)
+	staging, err := os.MkdirTemp(parent, ".freeze-restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	result, err := backup.Restore(backupPath, staging, backup.RestoreOptions{
+		Verify:       backup.VerifyChecksum,
+		DatabaseType: opts.DatabaseType,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Target = parent
+	restored := filepath.Join(staging, filepath.Base(opts.DatabasePath))
+	if !exists(restored) {
+		return result, fmt.Errorf("backup holds no %s", filepath.Base(opts.DatabasePath))
+	}
+
+	live := exists(opts.DatabasePath)
+	if live && opts.SafetyBackupDir != "" {
+		cfg := backup.DefaultBackupConfig()
+		cfg.BackupDir = opts.SafetyBackupDir
+		cfg.Label = "pre-restore"
+		// Never prune here: the oldest backup may be another snapshot's.
+		cfg.MaxBackups = math.MaxInt32
+		info, err := backup.CreateBackup(opts.DatabasePath, opts.DatabaseType, cfg)
+		if err != nil {
+			return result, fmt.Errorf("pre-restore safety backup failed: %w", err)
+		}
+		result.SafetyBackup = info
+	}
+	if opts.CloseDatabase != nil {
+		if err := opts.CloseDatabase(); err != nil {
+			return result, fmt.Errorf("close database: %w", err)
+		}
+	}
+	replaced := filepath.Join(staging, "replaced")
+	if live {
+		if err := os.Rename(opts.DatabasePath, replaced); err != nil {
+			return result, err
+		}
+	}
+	if err := os.Rename(restored, opts.DatabasePath); err != nil {
+		if live {
+			_ = os.Rename(replaced, opts.DatabasePath)
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// moveBack renames from to to, creating to's directory. Like the undo
+// engine it only renames; a file the operation moved across filesystems
+// is reported rather than copied back.
+func moveBack(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0o775); err != nil {
+		return err
+	}
+	return os.Rename(from, to)
+}
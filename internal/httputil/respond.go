@@ -1,13 +1,15 @@
 // file: internal/httputil/respond.go
-// version: 1.0.2
+// version: 1.2.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
-// last-edited: 2026-06-01
+// last-edited: 2026-10-17
 
 // Package httputil provides shared HTTP response helpers for all packages
 // that handle gin HTTP requests (server, middleware, itunes/service, etc).
 package httputil
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -38,6 +40,18 @@ func RespondWithValidationError(c *gin.Context, field string, reason string) {
 	RespondWithError(c, http.StatusBadRequest, message, "VALIDATION_ERROR")
 }
 
+// RespondWithFieldErrors sends a 400 validation error listing every
+// invalid field.
+func RespondWithFieldErrors(c *gin.Context, message string, fields []FieldError) {
+	logErrorWithContext(c, http.StatusBadRequest, message)
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error:  message,
+		Code:   "VALIDATION_ERROR",
+		Status: http.StatusBadRequest,
+		Fields: fields,
+	})
+}
+
 // RespondWithNotFound sends a 404 Not Found error response.
 func RespondWithNotFound(c *gin.Context, resourceType string, id string) {
 	message := resourceType + " not found"
@@ -72,10 +86,29 @@ func RespondWithServiceUnavailable(c *gin.Context, message string) {
 	RespondWithError(c, http.StatusServiceUnavailable, message, "SERVICE_UNAVAILABLE")
 }
 
+// RespondWithTimeout sends a 504 Gateway Timeout error response for a
+// request that ran past its deadline.
+func RespondWithTimeout(c *gin.Context) {
+	RespondWithError(c, http.StatusGatewayTimeout, "request timed out", "TIMEOUT")
+}
+
 // InternalError logs the full underlying error then sends a 500 response.
 // Use this when you have a concrete error value to log but only want to expose
 // a generic message to the client.
+//
+// An error caused by the request's own context ending is not a server
+// fault: past the deadline it is answered 504, and after the client
+// disconnected nothing is sent.
 func InternalError(c *gin.Context, msg string, err error) {
+	if ctxErr := c.Request.Context().Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			RespondWithTimeout(c)
+			return
+		}
+		slog.Debug(msg+": client disconnected", "path", c.Request.URL.Path)
+		c.Abort()
+		return
+	}
 	slog.Error(msg, "err", err)
 	RespondWithInternalError(c, msg)
 }
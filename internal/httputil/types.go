@@ -1,7 +1,7 @@
 // file: internal/httputil/types.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: b2c3d4e5-f6a7-8901-bcde-f12345678901
-// last-edited: 2026-05-01
+// last-edited: 2026-10-17
 
 package httputil
 
@@ -10,6 +10,14 @@ type ErrorResponse struct {
 	Error  string `json:"error"`
 	Code   string `json:"code,omitempty"`
 	Status int    `json:"status"`
+	// Fields lists the invalid request fields of a validation error.
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError is one invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // SuccessResponse is the standard envelope for all successful API responses.
@@ -26,7 +34,7 @@ type ListResponse struct {
 	Count  int `json:"count"`
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
-	Total  int `json:"total,omitempty"`
+	Total  int `json:"total"`
 }
 
 // BulkItem represents a single item result in a bulk operation response.
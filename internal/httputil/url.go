@@ -0,0 +1,43 @@
+// file: internal/httputil/url.go
+// version: 1.0.0
+// guid: c040fe32-b694-462c-b61f-d4e02bb9175a
+// last-edited: 2026-10-16
+
+package httputil
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// ExternalURL returns the absolute URL clients should use for path (which
+// starts with "/", relative to the app root). The configured base_url wins
+// so links are right behind a reverse proxy; otherwise the URL is built
+// from the request's scheme and Host plus base_path. With no Host header
+// the result is just base_path + path.
+func ExternalURL(c *gin.Context, path string) string {
+	if u := config.AppConfig.ExternalURL(path); u != "" {
+		return u
+	}
+	rel := config.AppConfig.BasePath + path
+	if c == nil || c.Request == nil {
+		return rel
+	}
+	host := strings.TrimSpace(c.Request.Host)
+	if host == "" {
+		return rel
+	}
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(strings.TrimSpace(c.GetHeader("X-Forwarded-Proto")), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + host + rel
+}
+
+// AppPath returns path (relative to the app root) prefixed with base_path,
+// for redirects and other same-origin links.
+func AppPath(path string) string {
+	return config.AppConfig.BasePath + path
+}
@@ -1,7 +1,7 @@
 // file: internal/importer/service.go
-// version: 1.1.0
+// version: 1.3.0
 // guid: d0e1f2a3-b4c5-6d7e-8f9a-0b1c2d3e4f5b
-// last-edited: 2026-06-14
+// last-edited: 2026-10-16
 
 package importer
 
@@ -18,6 +18,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/dedup"
 	itunesservice "github.com/falkcorp/audiobook-organizer/internal/itunes/service"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
 	"github.com/falkcorp/audiobook-organizer/internal/versions"
 	"github.com/falkcorp/audiobook-organizer/pkg/plugin/sdk"
 )
@@ -64,6 +65,9 @@ func NewImportService(db Store) *ImportService {
 type ImportFileRequest struct {
 	FilePath string `json:"file_path" binding:"required"`
 	Organize bool   `json:"organize"`
+	// UserID is the importing user, set by the handler from the session.
+	// It attributes the book for per-user import quotas.
+	UserID string `json:"-"`
 }
 
 type ImportFileResponse struct {
@@ -103,6 +107,14 @@ func (is *ImportService) ImportFile(req *ImportFileRequest) (*ImportFileResponse
 		return nil, fmt.Errorf("unsupported file type: %s", ext)
 	}
 
+	// Refuse over-quota imports before any metadata work or author/series
+	// rows are created.
+	size := fileInfo.Size()
+	probe := &database.Book{FilePath: req.FilePath, FileSize: &size}
+	if err := quota.NewEnforcer(&config.AppConfig, is.db).Admit(probe, req.UserID); err != nil {
+		return nil, err
+	}
+
 	// Extract metadata — use folder-aware assembly for generic part filenames.
 	var meta metadata.Metadata
 	if metadata.IsGenericPartFilename(req.FilePath) {
@@ -139,6 +151,10 @@ func (is *ImportService) ImportFile(req *ImportFileRequest) (*ImportFileResponse
 		Title:            meta.Title,
 		FilePath:         req.FilePath,
 		OriginalFilename: stringPtr(filepath.Base(req.FilePath)),
+		FileSize:         &size,
+	}
+	if req.UserID != "" {
+		book.ImportedByUserID = stringPtr(req.UserID)
 	}
 
 	// Set author if available
@@ -168,7 +184,7 @@ func (is *ImportService) ImportFile(req *ImportFileRequest) (*ImportFileResponse
 		if series != nil {
 			book.SeriesID = &series.ID
 			if meta.SeriesIndex > 0 {
-				book.SeriesSequence = &meta.SeriesIndex
+				book.SeriesSequence = database.NewSeriesSeq(meta.SeriesIndex)
 			}
 		}
 	}
@@ -1,5 +1,5 @@
 // file: internal/itunes/service/importer.go
-// version: 1.1.0
+// version: 1.3.0
 // guid: 2b8e5f1a-4c7d-4e9f-b3a0-6d8c2e7a4f1b
 
 package itunesservice
@@ -349,6 +349,7 @@ func (imp *Importer) Execute(ctx context.Context, opID string, req ImportRequest
 
 		hashLinked := 0
 		hashBlocked := 0
+		scanner.ResetBlockedHashAlgorithms()
 		for hi, bookID := range newBookIDs {
 			if log.IsCanceled() {
 				log.Info("Hash validation canceled")
@@ -360,7 +361,7 @@ func (imp *Importer) Execute(ctx context.Context, opID string, req ImportRequest
 				continue
 			}
 
-			hash, err := scanner.ComputeFileHash(book.FilePath)
+			hash, algo, err := scanner.ComputeFileHashWithAlgorithm(book.FilePath)
 			if err != nil {
 				log.Warn("Hash validation: failed to hash %s: %v", book.FilePath, err)
 				continue
@@ -370,12 +371,13 @@ func (imp *Importer) Execute(ctx context.Context, opID string, req ImportRequest
 			}
 
 			book.FileHash = strPtr(hash)
+			book.FileHashAlgorithm = strPtr(algo)
 			book.OriginalFileHash = strPtr(hash)
 			if importMode == itunes.ImportModeOrganized {
 				book.OrganizedFileHash = strPtr(hash)
 			}
 
-			if blocked, err := imp.store.IsHashBlocked(hash); err == nil && blocked {
+			if blocked, err := scanner.IsFileBlocked(imp.store, book.FilePath, hash, algo); err == nil && blocked {
 				log.Warn("Hash validation: blocked hash for %s, soft-deleting", book.Title)
 				marked := true
 				now := time.Now()
@@ -1010,11 +1012,12 @@ func (imp *Importer) organizeOneBook(book *database.Book, log logger.Logger) err
 }
 
 func (imp *Importer) applyOrganizedFileMetadata(book *database.Book, newPath string) {
-	hash, err := scanner.ComputeFileHash(newPath)
+	hash, algo, err := scanner.ComputeFileHashWithAlgorithm(newPath)
 	if err != nil {
 		slog.Warn("failed to compute organized hash", "path", newPath, "error", err)
 	} else if hash != "" {
 		book.FileHash = strPtr(hash)
+		book.FileHashAlgorithm = strPtr(algo)
 		book.OrganizedFileHash = strPtr(hash)
 		if book.OriginalFileHash == nil {
 			book.OriginalFileHash = strPtr(hash)
@@ -0,0 +1,225 @@
+// file: internal/layout/apply.go
+// version: 1.0.0
+// guid: d8a2c5f1-6e3b-4097-b4d1-2f7c9e0a8b56
+// last-edited: 2026-10-17
+
+package layout
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/falkcorp/audiobook-organizer/internal/fileops"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+)
+
+// Apply modes.
+const (
+	ModeMove    = "move"
+	ModeSymlink = "symlink"
+)
+
+// Per-file outcomes of Apply.
+const (
+	StatusPresent    = "present"     // already at the target with matching content
+	StatusPlaced     = "placed"      // moved or linked into place
+	StatusWouldPlace = "would_place" // dry run: would be moved or linked
+	StatusMissing    = "missing"     // no matching file under the source
+	StatusConflict   = "conflict"    // the target holds a different file
+	StatusFailed     = "error"
+)
+
+// ApplyOptions configures Apply.
+type ApplyOptions struct {
+	// SourceDir is searched for the layout's files: first at the same
+	// relative path, then anywhere beneath it by size and hash.
+	SourceDir string
+	// TargetRoot is where the layout is re-created.
+	TargetRoot string
+	// Mode is ModeMove (default) or ModeSymlink.
+	Mode   string
+	DryRun bool
+}
+
+// FileResult is the outcome for one layout file.
+type FileResult struct {
+	Path   string `json:"path"`
+	Source string `json:"source,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ApplyResult reports an Apply run.
+type ApplyResult struct {
+	Mode   string         `json:"mode"`
+	DryRun bool           `json:"dry_run"`
+	Counts map[string]int `json:"counts"`
+	Files  []FileResult   `json:"files"`
+}
+
+// Apply re-creates layout under opts.TargetRoot from the files found in
+// opts.SourceDir, moving them or symlinking to them. Files already in
+// place are left alone, and nothing at the target is ever overwritten.
+// A file whose layout entry carries a hash must match it to be used.
+func Apply(ctx context.Context, layout *Layout, opts ApplyOptions) (*ApplyResult, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeMove
+	}
+	if opts.Mode != ModeMove && opts.Mode != ModeSymlink {
+		return nil, fmt.Errorf("mode must be %q or %q", ModeMove, ModeSymlink)
+	}
+	if opts.SourceDir == "" || opts.TargetRoot == "" {
+		return nil, fmt.Errorf("source and target directories are required")
+	}
+	src, err := filepath.Abs(opts.SourceDir)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(src); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("source directory %s is not readable", opts.SourceDir)
+	}
+	target, err := filepath.Abs(opts.TargetRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApplyResult{Mode: opts.Mode, DryRun: opts.DryRun, Counts: map[string]int{}, Files: []FileResult{}}
+	finder := &fileFinder{root: src, used: map[string]bool{}}
+	for _, f := range layout.Files() {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		r := applyFile(f, finder, target, opts)
+		result.Counts[r.Status]++
+		result.Files = append(result.Files, r)
+	}
+	return result, nil
+}
+
+func applyFile(f File, finder *fileFinder, target string, opts ApplyOptions) FileResult {
+	rel := filepath.FromSlash(f.Path)
+	dst := filepath.Join(target, rel)
+	r := FileResult{Path: f.Path}
+
+	if _, err := os.Lstat(dst); err == nil {
+		if matches(dst, f) {
+			r.Status = StatusPresent
+		} else {
+			r.Status, r.Error = StatusConflict, "target exists with different content"
+		}
+		return r
+	}
+
+	srcPath := finder.find(rel, f)
+	if srcPath == "" {
+		r.Status = StatusMissing
+		return r
+	}
+	r.Source = srcPath
+	if opts.DryRun {
+		r.Status = StatusWouldPlace
+		return r
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		r.Status, r.Error = StatusFailed, err.Error()
+		return r
+	}
+	switch opts.Mode {
+	case ModeSymlink:
+		err := os.Symlink(srcPath, dst)
+		if err != nil {
+			r.Status, r.Error = StatusFailed, err.Error()
+			return r
+		}
+	default:
+		if err := os.Rename(srcPath, dst); err != nil {
+			// Across filesystems: copy, verify, then remove the source.
+			if err := fileops.SafeMove(srcPath, dst, fileops.OperationConfig{VerifyChecksums: true}); err != nil {
+				r.Status, r.Error = StatusFailed, err.Error()
+				return r
+			}
+		}
+	}
+	r.Status = StatusPlaced
+	return r
+}
+
+// matches reports whether path holds f: same hash when the layout has
+// one, else same size when it has that, else any file counts.
+func matches(path string, f File) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if f.Size > 0 && info.Size() != f.Size {
+		return false
+	}
+	if f.Hash == "" {
+		return true
+	}
+	h, err := scanner.ComputeFileHash(path)
+	return err == nil && h == f.Hash
+}
+
+// fileFinder locates layout files under a source directory. The source
+// tree is walked at most once, on the first file not found at its own
+// relative path, and indexed by size; hashes are computed only for files
+// whose size matches.
+type fileFinder struct {
+	root    string
+	bySize  map[int64][]string
+	indexed bool
+	// used holds source files already claimed, so two layout entries with
+	// the same content map to two different files.
+	used map[string]bool
+}
+
+func (ff *fileFinder) find(rel string, f File) string {
+	if p := filepath.Join(ff.root, rel); !ff.used[p] && matches(p, f) {
+		ff.used[p] = true
+		return p
+	}
+	if f.Hash == "" && f.Size == 0 {
+		// Nothing to recognise the file by anywhere else.
+		return ""
+	}
+	ff.index()
+	var candidates []string
+	if f.Size > 0 {
+		candidates = ff.bySize[f.Size]
+	} else {
+		for _, paths := range ff.bySize {
+			candidates = append(candidates, paths...)
+		}
+	}
+	for _, p := range candidates {
+		if !ff.used[p] && matches(p, f) {
+			ff.used[p] = true
+			return p
+		}
+	}
+	return ""
+}
+
+func (ff *fileFinder) index() {
+	if ff.indexed {
+		return
+	}
+	ff.indexed = true
+	ff.bySize = map[int64][]string{}
+	_ = filepath.WalkDir(ff.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, ierr := d.Info(); ierr == nil {
+			ff.bySize[info.Size()] = append(ff.bySize[info.Size()], path)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,283 @@
+// file: internal/layout/layout.go
+// version: 1.1.0
+// guid: 3b7e1f94-c2a8-4d56-8e0b-a9d4f6c21e73
+// last-edited: 2026-10-17
+
+// Package layout exports the organized library's logical layout — author,
+// series and book, with each file's path relative to the library root and
+// its hash — as a portable structure file, and re-creates that layout
+// from the same files on another machine.
+package layout
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// FormatVersion is the structure file format written by Export.
+const FormatVersion = 1
+
+// Layout is the portable structure file. It is written as JSON or YAML;
+// the field names are the same in both.
+type Layout struct {
+	Version   int       `json:"version" yaml:"version"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	// Root is the library root the paths were relative to, for reference;
+	// Apply places them under its own target root.
+	Root    string   `json:"root" yaml:"root"`
+	Authors []Author `json:"authors" yaml:"authors"`
+}
+
+// Author groups an author's series and standalone books.
+type Author struct {
+	Name   string   `json:"name" yaml:"name"`
+	Series []Series `json:"series,omitempty" yaml:"series,omitempty"`
+	Books  []Book   `json:"books,omitempty" yaml:"books,omitempty"`
+}
+
+// Series lists a series' books in series order.
+type Series struct {
+	Name  string `json:"name" yaml:"name"`
+	Books []Book `json:"books" yaml:"books"`
+}
+
+// Book is one book and its files.
+type Book struct {
+	ID       string `json:"id" yaml:"id"`
+	Title    string `json:"title" yaml:"title"`
+	Sequence string `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	Files    []File `json:"files" yaml:"files"`
+}
+
+// File is one audio file. Path is relative to the library root and always
+// uses forward slashes.
+type File struct {
+	Path string `json:"path" yaml:"path"`
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
+	Size int64  `json:"size,omitempty" yaml:"size,omitempty"`
+}
+
+// Files returns every file in the layout, in layout order.
+func (l *Layout) Files() []File {
+	var out []File
+	for _, a := range l.Authors {
+		for _, s := range a.Series {
+			for _, b := range s.Books {
+				out = append(out, b.Files...)
+			}
+		}
+		for _, b := range a.Books {
+			out = append(out, b.Files...)
+		}
+	}
+	return out
+}
+
+// Parse decodes a structure file written as JSON or YAML and validates
+// it. YAML is a superset of JSON, so one decoder reads either.
+func Parse(data []byte) (*Layout, error) {
+	var l Layout
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("not a valid JSON or YAML layout: %w", err)
+	}
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Validate checks that the layout is a version this build understands
+// and that every path is relative and stays inside the root.
+func (l *Layout) Validate() error {
+	if l.Version != FormatVersion {
+		return fmt.Errorf("unsupported layout version %d (want %d)", l.Version, FormatVersion)
+	}
+	for _, f := range l.Files() {
+		if err := checkRelPath(f.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRelPath(p string) error {
+	clean := filepath.Clean(filepath.FromSlash(p))
+	if p == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("layout path %q must be relative to the library root", p)
+	}
+	return nil
+}
+
+// ExportStore is the store surface Export reads.
+type ExportStore interface {
+	GetAllBooks(limit, offset int) ([]database.Book, error)
+	GetBookFiles(bookID string) ([]database.BookFile, error)
+	GetAuthorByID(id int) (*database.Author, error)
+	GetSeriesByID(id int) (*database.Series, error)
+}
+
+// unknownAuthor files books with no author.
+const unknownAuthor = "Unknown Author"
+
+// Export builds the layout of every book whose files sit under root.
+// Books with any file outside root (still in an import path, say) are
+// left out and counted in skipped, as are books marked for deletion.
+func Export(store ExportStore, root string) (layout *Layout, skipped int, err error) {
+	root = filepath.Clean(root)
+	type seriesKey struct {
+		author string
+		series string
+	}
+	authorNames := map[int]string{}
+	seriesNames := map[int]string{}
+	standalone := map[string][]Book{}
+	inSeries := map[seriesKey][]Book{}
+	seqs := map[string]*database.SeriesSeq{}
+
+	const pageSize = 500
+	for offset := 0; ; offset += pageSize {
+		books, err := store.GetAllBooks(pageSize, offset)
+		if err != nil {
+			return nil, 0, fmt.Errorf("list books: %w", err)
+		}
+		for _, book := range books {
+			if book.MarkedForDeletion != nil && *book.MarkedForDeletion {
+				skipped++
+				continue
+			}
+			files, ok := exportFiles(store, book, root)
+			if !ok {
+				skipped++
+				continue
+			}
+			entry := Book{ID: book.ID, Title: book.Title, Files: files}
+			if book.SeriesSequence != nil {
+				entry.Sequence = book.SeriesSequence.String()
+				seqs[book.ID] = book.SeriesSequence
+			}
+
+			author := unknownAuthor
+			if book.AuthorID != nil {
+				name, cached := authorNames[*book.AuthorID]
+				if !cached {
+					if a, aerr := store.GetAuthorByID(*book.AuthorID); aerr == nil && a != nil {
+						name = a.Name
+					}
+					authorNames[*book.AuthorID] = name
+				}
+				if name != "" {
+					author = name
+				}
+			}
+			series := ""
+			if book.SeriesID != nil {
+				name, cached := seriesNames[*book.SeriesID]
+				if !cached {
+					if s, serr := store.GetSeriesByID(*book.SeriesID); serr == nil && s != nil {
+						name = s.Name
+					}
+					seriesNames[*book.SeriesID] = name
+				}
+				series = name
+			}
+			if series == "" {
+				standalone[author] = append(standalone[author], entry)
+			} else {
+				k := seriesKey{author, series}
+				inSeries[k] = append(inSeries[k], entry)
+			}
+		}
+		if len(books) < pageSize {
+			break
+		}
+	}
+
+	byAuthor := map[string]*Author{}
+	author := func(name string) *Author {
+		if a, ok := byAuthor[name]; ok {
+			return a
+		}
+		a := &Author{Name: name}
+		byAuthor[name] = a
+		return a
+	}
+	for name, books := range standalone {
+		sortByTitle(books)
+		author(name).Books = books
+	}
+	for k, books := range inSeries {
+		sort.SliceStable(books, func(i, j int) bool {
+			if c := database.CompareSeriesSeq(seqs[books[i].ID], seqs[books[j].ID]); c != 0 {
+				return c < 0
+			}
+			return books[i].Title < books[j].Title
+		})
+		a := author(k.author)
+		a.Series = append(a.Series, Series{Name: k.series, Books: books})
+	}
+
+	layout = &Layout{Version: FormatVersion, CreatedAt: time.Now().UTC(), Root: root, Authors: []Author{}}
+	for _, a := range byAuthor {
+		sort.Slice(a.Series, func(i, j int) bool { return a.Series[i].Name < a.Series[j].Name })
+		layout.Authors = append(layout.Authors, *a)
+	}
+	sort.Slice(layout.Authors, func(i, j int) bool { return layout.Authors[i].Name < layout.Authors[j].Name })
+	return layout, skipped, nil
+}
+
+// exportFiles lists book's present files relative to root. ok is false
+// when the book has no files or any of them lies outside root.
+func exportFiles(store ExportStore, book database.Book, root string) ([]File, bool) {
+	var out []File
+	if bookFiles, err := store.GetBookFiles(book.ID); err == nil {
+		for _, bf := range bookFiles {
+			if bf.Missing {
+				continue
+			}
+			rel, ok := relUnder(root, bf.FilePath)
+			if !ok {
+				return nil, false
+			}
+			hash := bf.FileHash
+			if hash == "" {
+				hash = bf.OriginalFileHash
+			}
+			out = append(out, File{Path: rel, Hash: hash, Size: bf.FileSize})
+		}
+	}
+	if len(out) == 0 && book.FilePath != "" && filepath.Ext(book.FilePath) != "" {
+		rel, ok := relUnder(root, book.FilePath)
+		if !ok {
+			return nil, false
+		}
+		f := File{Path: rel}
+		if book.FileHash != nil {
+			f.Hash = *book.FileHash
+		}
+		if book.FileSize != nil {
+			f.Size = *book.FileSize
+		}
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, len(out) > 0
+}
+
+func relUnder(root, path string) (string, bool) {
+	rel, err := filepath.Rel(root, filepath.Clean(path))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func sortByTitle(books []Book) {
+	sort.SliceStable(books, func(i, j int) bool { return books[i].Title < books[j].Title })
+}
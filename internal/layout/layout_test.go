@@ -0,0 +1,165 @@
+// file: internal/layout/layout_test.go
+// version: 1.1.0
+// guid: 5f2b8d16-a7c4-4e93-b0d5-e1c9a6f73b28
+// last-edited: 2026-10-17
+
+package layout
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+)
+
+func TestExport(t *testing.T) {
+	authorID, seriesID := 1, 2
+	deleted := true
+	books := []database.Book{
+		{ID: "b2", Title: "Second", AuthorID: &authorID, SeriesID: &seriesID, SeriesSequence: database.NewSeriesSeq(2), FilePath: "/lib/A/S/Second.m4b"},
+		{ID: "b1", Title: "First", AuthorID: &authorID, SeriesID: &seriesID, SeriesSequence: database.NewSeriesSeq(1), FilePath: "/lib/A/S/First"},
+		{ID: "b3", Title: "Solo", FilePath: "/lib/Unknown/Solo.mp3"},
+		{ID: "b4", Title: "Incoming", FilePath: "/import/x.mp3"},
+		{ID: "b5", Title: "Gone", FilePath: "/lib/A/Gone.mp3", MarkedForDeletion: &deleted},
+	}
+	store := &database.MockStore{
+		GetAllBooksFunc: func(limit, offset int) ([]database.Book, error) {
+			if offset > 0 {
+				return nil, nil
+			}
+			return books, nil
+		},
+		GetBookFilesFunc: func(id string) ([]database.BookFile, error) {
+			if id == "b1" {
+				return []database.BookFile{
+					{FilePath: "/lib/A/S/First/02.mp3", FileHash: "h2", FileSize: 20},
+					{FilePath: "/lib/A/S/First/01.mp3", FileHash: "h1", FileSize: 10},
+					{FilePath: "/lib/A/S/First/03.mp3", Missing: true},
+				}, nil
+			}
+			return nil, nil
+		},
+		GetAuthorByIDFunc: func(id int) (*database.Author, error) { return &database.Author{ID: id, Name: "Author A"}, nil },
+		GetSeriesByIDFunc: func(id int) (*database.Series, error) { return &database.Series{ID: id, Name: "Saga"}, nil },
+	}
+
+	l, skipped, err := Export(store, "/lib")
+	require.NoError(t, err)
+	assert.Equal(t, 2, skipped)
+	require.Len(t, l.Authors, 2)
+
+	a := l.Authors[0]
+	assert.Equal(t, "Author A", a.Name)
+	require.Len(t, a.Series, 1)
+	assert.Equal(t, "Saga", a.Series[0].Name)
+	require.Len(t, a.Series[0].Books, 2)
+	first := a.Series[0].Books[0]
+	assert.Equal(t, "b1", first.ID)
+	assert.Equal(t, "1", first.Sequence)
+	assert.Equal(t, []File{{Path: "A/S/First/01.mp3", Hash: "h1", Size: 10}, {Path: "A/S/First/02.mp3", Hash: "h2", Size: 20}}, first.Files)
+	assert.Equal(t, "A/S/Second.m4b", a.Series[0].Books[1].Files[0].Path)
+
+	assert.Equal(t, unknownAuthor, l.Authors[1].Name)
+	assert.Equal(t, "Solo", l.Authors[1].Books[0].Title)
+	assert.NoError(t, l.Validate())
+}
+
+func TestValidate(t *testing.T) {
+	bad := &Layout{Version: FormatVersion, Authors: []Author{{Books: []Book{{Files: []File{{Path: "../etc/passwd"}}}}}}}
+	assert.Error(t, bad.Validate())
+	bad.Authors[0].Books[0].Files[0].Path = "/abs.mp3"
+	assert.Error(t, bad.Validate())
+	assert.Error(t, (&Layout{Version: 99}).Validate())
+}
+
+func TestParse(t *testing.T) {
+	l, err := Parse([]byte(`{"version": 1, "authors": [{"name": "A", "books": [{"title": "B", "files": [{"path": "A/B/b.mp3"}]}]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "A/B/b.mp3", l.Files()[0].Path)
+
+	_, err = Parse([]byte("version: 1\nauthors:\n  - name: A\n    books:\n      - title: B\n        files:\n          - path: ../b.mp3\n"))
+	assert.Error(t, err)
+	_, err = Parse([]byte("{not a layout"))
+	assert.ErrorContains(t, err, "not a valid JSON or YAML layout")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestApply(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	// One file at its layout path, one elsewhere (found by hash), one
+	// absent, one already in place, one blocked by a different file.
+	writeFile(t, filepath.Join(src, "A/Book/01.mp3"), "one")
+	writeFile(t, filepath.Join(src, "random/renamed.mp3"), "two")
+	writeFile(t, filepath.Join(src, "random/decoy.mp3"), "owt") // same size, wrong hash
+	writeFile(t, filepath.Join(dst, "A/Book/04.mp3"), "four")
+	writeFile(t, filepath.Join(dst, "A/Book/05.mp3"), "other")
+	writeFile(t, filepath.Join(src, "A/Book/05.mp3"), "five!")
+
+	hash := func(s string) string {
+		p := filepath.Join(t.TempDir(), "h")
+		require.NoError(t, os.WriteFile(p, []byte(s), 0o644))
+		h, err := scanner.ComputeFileHash(p)
+		require.NoError(t, err)
+		return h
+	}
+	l := &Layout{Version: FormatVersion, Authors: []Author{{Name: "A", Books: []Book{{ID: "b", Title: "Book", Files: []File{
+		{Path: "A/Book/01.mp3", Hash: hash("one"), Size: 3},
+		{Path: "A/Book/02.mp3", Hash: hash("two"), Size: 3},
+		{Path: "A/Book/03.mp3", Hash: hash("three"), Size: 5},
+		{Path: "A/Book/04.mp3", Hash: hash("four"), Size: 4},
+		{Path: "A/Book/05.mp3", Hash: hash("five!"), Size: 5},
+	}}}}}}
+
+	// The layout survives a YAML round trip.
+	data, err := yaml.Marshal(l)
+	require.NoError(t, err)
+	var decoded Layout
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+
+	opts := ApplyOptions{SourceDir: src, TargetRoot: dst, DryRun: true}
+	res, err := Apply(context.Background(), &decoded, opts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{StatusWouldPlace: 2, StatusMissing: 1, StatusPresent: 1, StatusConflict: 1}, res.Counts)
+	assert.FileExists(t, filepath.Join(src, "A/Book/01.mp3"))
+
+	opts.DryRun = false
+	res, err = Apply(context.Background(), &decoded, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Counts[StatusPlaced])
+	assert.Equal(t, filepath.Join(src, "random/renamed.mp3"), res.Files[1].Source)
+	got, err := os.ReadFile(filepath.Join(dst, "A/Book/02.mp3"))
+	require.NoError(t, err)
+	assert.Equal(t, "two", string(got))
+	assert.NoFileExists(t, filepath.Join(src, "A/Book/01.mp3"))
+	got, _ = os.ReadFile(filepath.Join(dst, "A/Book/05.mp3"))
+	assert.Equal(t, "other", string(got), "existing target must not be overwritten")
+}
+
+func TestApply_Symlink(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(src, "x.mp3"), "data")
+	l := &Layout{Version: FormatVersion, Authors: []Author{{Name: "A", Books: []Book{{Files: []File{{Path: "A/B/x.mp3", Size: 4}}}}}}}
+
+	res, err := Apply(context.Background(), l, ApplyOptions{SourceDir: src, TargetRoot: dst, Mode: ModeSymlink})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Counts[StatusPlaced])
+	target, err := os.Readlink(filepath.Join(dst, "A/B/x.mp3"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(src, "x.mp3"), target)
+	assert.FileExists(t, filepath.Join(src, "x.mp3"))
+
+	_, err = Apply(context.Background(), l, ApplyOptions{SourceDir: src, TargetRoot: dst, Mode: "copy"})
+	assert.Error(t, err)
+}
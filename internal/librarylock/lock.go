@@ -0,0 +1,311 @@
+// file: internal/librarylock/lock.go
+// version: 1.0.0
+// guid: 6d2f8b40-1e7c-4a59-93b6-c0a4e5d17f82
+// last-edited: 2026-10-17
+
+// Package librarylock is an advisory lock that keeps two server instances
+// from running destructive operations against the same library at once.
+// The holder heartbeats its identity into a "library_lock" row in the
+// database and a lock file in the library root; an instance that finds
+// another live holder in either place leaves destructive operations
+// alone until the holder goes quiet for StaleAfter, or an admin forces a
+// takeover.
+package librarylock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	ulid "github.com/oklog/ulid/v2"
+)
+
+const (
+	// FileName is the lock file kept in the library root.
+	FileName = ".audiobook-organizer.lock"
+
+	rowKey = "library_lock"
+
+	// HeartbeatInterval is how often the holder refreshes its heartbeat
+	// and everyone else re-checks the lock.
+	HeartbeatInterval = 15 * time.Second
+
+	// StaleAfter is how long a holder may miss heartbeats before its
+	// lock is free to take.
+	StaleAfter = 4 * HeartbeatInterval
+)
+
+// ErrHeld is returned for destructive work while another instance holds
+// the lock.
+var ErrHeld = errors.New("library is locked by another instance")
+
+// Holder identifies an instance holding the lock.
+type Holder struct {
+	InstanceID  string    `json:"instance_id"`
+	Hostname    string    `json:"hostname"`
+	PID         int       `json:"pid"`
+	StartedAt   time.Time `json:"started_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+}
+
+// Status is the lock as this instance last saw it.
+type Status struct {
+	InstanceID string `json:"instance_id"`
+	Held       bool   `json:"held"`
+	// Holder is the other live instance keeping the lock, when Held is
+	// false.
+	Holder    *Holder   `json:"holder,omitempty"`
+	LockFile  string    `json:"lock_file,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Lock is this instance's side of the library lock.
+type Lock struct {
+	kv      database.RawKVStore
+	rootDir func() string
+	now     func() time.Time
+
+	mu        sync.Mutex
+	self      Holder
+	started   bool
+	checked   bool
+	held      bool
+	other     *Holder
+	lockFile  string
+	checkedAt time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns the lock for an instance using kv, with rootDir reporting
+// the library root (read on every check so config changes are picked up).
+// kv may be nil, leaving only the lock file.
+func New(kv database.RawKVStore, rootDir func() string) *Lock {
+	host, _ := os.Hostname()
+	now := time.Now()
+	return &Lock{
+		kv:      kv,
+		rootDir: rootDir,
+		now:     time.Now,
+		self: Holder{
+			InstanceID: ulid.Make().String(),
+			Hostname:   host,
+			PID:        os.Getpid(),
+			StartedAt:  now,
+		},
+	}
+}
+
+// Start takes the lock if it is free and keeps checking it in the
+// background until Stop.
+func (l *Lock) Start(ctx context.Context) error {
+	l.mu.Lock()
+	l.started = true
+	l.refreshLocked(false)
+	if l.stop != nil {
+		l.mu.Unlock()
+		return nil
+	}
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	stop, done := l.stop, l.done
+	l.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.mu.Lock()
+				l.refreshLocked(false)
+				l.mu.Unlock()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop ends the heartbeat and releases the lock if this instance holds
+// it, so the next instance can start without waiting out StaleAfter.
+func (l *Lock) Stop(context.Context) error {
+	l.mu.Lock()
+	stop, done := l.stop, l.done
+	l.stop, l.done = nil, nil
+	l.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.started = false
+	if !l.held {
+		return nil
+	}
+	if h := l.readRow(); h != nil && h.InstanceID == l.self.InstanceID {
+		if err := l.kv.DeleteRaw(rowKey); err != nil {
+			slog.Warn("release library lock row", "err", err)
+		}
+	}
+	if h := readFile(l.lockFile); h != nil && h.InstanceID == l.self.InstanceID {
+		if err := os.Remove(l.lockFile); err != nil && !os.IsNotExist(err) {
+			slog.Warn("remove library lock file", "file", l.lockFile, "err", err)
+		}
+	}
+	l.held = false
+	return nil
+}
+
+// Status reports the lock as of the last check.
+func (l *Lock) Status() Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.started && !l.checked {
+		l.refreshLocked(false)
+	}
+	return l.statusLocked()
+}
+
+// AllowDestructive returns ErrHeld, naming the holder, unless this
+// instance holds the lock. Before Start the lock doesn't take part and
+// everything is allowed. It satisfies the operations registry's
+// RunGuard.
+func (l *Lock) AllowDestructive(defID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.started {
+		return nil
+	}
+	l.refreshLocked(false)
+	if l.held {
+		return nil
+	}
+	h := l.other
+	return fmt.Errorf("%w: %s refused while %s (pid %d, instance %s) holds it, last seen %s",
+		ErrHeld, defID, h.Hostname, h.PID, h.InstanceID, h.HeartbeatAt.Format(time.RFC3339))
+}
+
+// ForceTakeover claims the lock regardless of the current holder. The
+// other instance notices on its next check and stops running
+// destructive operations.
+func (l *Lock) ForceTakeover() Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev := l.other
+	l.refreshLocked(true)
+	if prev != nil {
+		slog.Warn("library lock taken over", "from_instance", prev.InstanceID, "from_host", prev.Hostname, "from_pid", prev.PID)
+	}
+	return l.statusLocked()
+}
+
+func (l *Lock) statusLocked() Status {
+	st := Status{
+		InstanceID: l.self.InstanceID,
+		Held:       l.held,
+		LockFile:   l.lockFile,
+		CheckedAt:  l.checkedAt,
+	}
+	if !l.held && l.other != nil {
+		h := *l.other
+		st.Holder = &h
+	}
+	return st
+}
+
+// refreshLocked re-reads both lock records and, unless another live
+// instance holds either, writes this instance's heartbeat to both.
+func (l *Lock) refreshLocked(force bool) {
+	now := l.now()
+	l.checked = true
+	l.checkedAt = now
+	l.lockFile = ""
+	if root := l.rootDir(); root != "" {
+		if info, err := os.Stat(root); err == nil && info.IsDir() {
+			l.lockFile = filepath.Join(root, FileName)
+		}
+	}
+
+	if !force {
+		for _, h := range []*Holder{l.readRow(), readFile(l.lockFile)} {
+			if h != nil && h.InstanceID != l.self.InstanceID && now.Sub(h.HeartbeatAt) < StaleAfter {
+				if l.held {
+					slog.Warn("library lock lost to another instance", "instance", h.InstanceID, "host", h.Hostname, "pid", h.PID)
+				}
+				l.held = false
+				l.other = h
+				return
+			}
+		}
+	}
+
+	l.self.HeartbeatAt = now
+	blob, err := json.Marshal(l.self)
+	if err != nil {
+		return
+	}
+	if l.kv != nil {
+		if err := l.kv.SetRaw(rowKey, blob); err != nil {
+			slog.Warn("write library lock heartbeat", "err", err)
+		}
+	}
+	if l.lockFile != "" {
+		tmp := l.lockFile + ".tmp"
+		if err := os.WriteFile(tmp, blob, 0o644); err == nil {
+			err = os.Rename(tmp, l.lockFile)
+		}
+		if err != nil {
+			slog.Warn("write library lock file", "file", l.lockFile, "err", err)
+		}
+	}
+	if !l.held {
+		slog.Info("library lock acquired", "instance", l.self.InstanceID)
+	}
+	l.held = true
+	l.other = nil
+}
+
+func (l *Lock) readRow() *Holder {
+	if l.kv == nil {
+		return nil
+	}
+	blob, err := l.kv.GetRaw(rowKey)
+	if err != nil || blob == nil {
+		return nil
+	}
+	var h Holder
+	if json.Unmarshal(blob, &h) != nil {
+		return nil
+	}
+	return &h
+}
+
+func readFile(path string) *Holder {
+	if path == "" {
+		return nil
+	}
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var h Holder
+	if json.Unmarshal(blob, &h) != nil {
+		return nil
+	}
+	return &h
+}
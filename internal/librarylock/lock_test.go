@@ -0,0 +1,84 @@
+// file: internal/librarylock/lock_test.go
+// version: 1.0.0
+// guid: 1f7a3c92-8e05-4b6d-a2c8-4d9e0b6f3a17
+
+package librarylock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestLock_SecondInstanceWaitsForHolder(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	root := t.TempDir()
+	rootDir := func() string { return root }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a, b := New(store, rootDir), New(store, rootDir)
+	if err := b.AllowDestructive("test.op"); err != nil {
+		t.Fatalf("before Start the lock should not take part: %v", err)
+	}
+	if err := a.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer a.Stop(ctx)
+	if err := b.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer b.Stop(ctx)
+
+	if st := a.Status(); !st.Held || st.LockFile != filepath.Join(root, FileName) {
+		t.Fatalf("first instance: %+v", st)
+	}
+	err = b.AllowDestructive("test.op")
+	if !errors.Is(err, ErrHeld) {
+		t.Fatalf("second instance: expected ErrHeld, got %v", err)
+	}
+	if st := b.Status(); st.Held || st.Holder == nil || st.Holder.InstanceID != a.Status().InstanceID {
+		t.Fatalf("second instance status: %+v", st)
+	}
+
+	// A forced takeover moves the lock; the old holder notices on its
+	// next check.
+	if st := b.ForceTakeover(); !st.Held {
+		t.Fatalf("takeover: %+v", st)
+	}
+	if err := b.AllowDestructive("test.op"); err != nil {
+		t.Fatalf("after takeover: %v", err)
+	}
+	if err := a.AllowDestructive("test.op"); !errors.Is(err, ErrHeld) {
+		t.Fatalf("old holder: expected ErrHeld, got %v", err)
+	}
+
+	// A holder that stops heartbeating goes stale.
+	a.now = func() time.Time { return time.Now().Add(StaleAfter + time.Second) }
+	if err := a.AllowDestructive("test.op"); err != nil {
+		t.Fatalf("stale holder should be taken over: %v", err)
+	}
+
+	// Stopping releases both records.
+	if err := a.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, FileName)); !os.IsNotExist(err) {
+		t.Errorf("expected lock file removed, stat err %v", err)
+	}
+	if blob, _ := store.GetRaw(rowKey); blob != nil {
+		t.Errorf("expected lock row removed, got %s", blob)
+	}
+	if err := b.AllowDestructive("test.op"); err != nil {
+		t.Errorf("after release: %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+// file: internal/librarylock/register.go
+// version: 1.0.0
+
+package librarylock
+
+import (
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
+)
+
+func init() {
+	serviceregistry.Register(serviceregistry.ServiceDef{
+		Name:   "librarylock",
+		Needs:  []string{"store"},
+		Groups: []string{"core"},
+		Build: func(c *serviceregistry.Container) (any, error) {
+			store := serviceregistry.Get[database.Store](c, "store")
+			return New(store, func() string { return config.AppConfig.RootDir }), nil
+		},
+	})
+}
@@ -0,0 +1,136 @@
+// file: internal/libraryscope/scope.go
+// version: 1.0.0
+// guid: b92585cb-a434-437c-98c0-461d5058e447
+// last-edited: 2026-10-16
+
+// Package libraryscope resolves which isolated libraries a user may see
+// when library isolation mode is on.
+//
+// A Scope is nil for unrestricted callers (isolation off, anonymous
+// single-user setups, admins), so call sites can pass it around without
+// checking the mode first: every method treats a nil Scope as "allow all".
+package libraryscope
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// adminRole bypasses isolation, matching the RequireAdmin middleware.
+const adminRole = "admin"
+
+// Scope is the set of libraries visible to one user.
+type Scope struct {
+	libraryIDs []string
+	roots      []string
+}
+
+// ForUser returns the scope for user under cfg, or nil when the user is
+// not restricted. A user with no matching library gets an empty, non-nil
+// scope that allows nothing.
+func ForUser(cfg *config.Config, user *database.User) *Scope {
+	if cfg == nil || !cfg.LibraryIsolation || user == nil {
+		return nil
+	}
+	roles := make(map[string]bool, len(user.Roles))
+	for _, r := range user.Roles {
+		if r == adminRole {
+			return nil
+		}
+		roles[r] = true
+	}
+	s := &Scope{}
+	for _, lib := range cfg.Libraries {
+		allowed := false
+		for _, r := range lib.Roles {
+			if roles[r] {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			continue
+		}
+		s.libraryIDs = append(s.libraryIDs, lib.ID)
+		for _, root := range append([]string{lib.RootDir}, lib.ImportPaths...) {
+			if root = strings.TrimSpace(root); root != "" {
+				s.roots = append(s.roots, filepath.Clean(root))
+			}
+		}
+	}
+	sort.Strings(s.libraryIDs)
+	return s
+}
+
+// LibraryIDs returns the visible library IDs, sorted. Nil for an
+// unrestricted scope.
+func (s *Scope) LibraryIDs() []string {
+	if s == nil {
+		return nil
+	}
+	return s.libraryIDs
+}
+
+// Allows reports whether path lies under one of the scope's library
+// roots. Matching is on whole path components, so /lib/kids does not
+// admit /lib/kidsmovies.
+func (s *Scope) Allows(path string) bool {
+	if s == nil {
+		return true
+	}
+	if path == "" {
+		return false
+	}
+	path = filepath.Clean(path)
+	for _, root := range s.roots {
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsBook reports whether book is visible in the scope.
+func (s *Scope) AllowsBook(book *database.Book) bool {
+	if s == nil {
+		return true
+	}
+	return book != nil && s.Allows(book.FilePath)
+}
+
+// FilterBooks drops the books outside the scope, reusing books' backing
+// array.
+func (s *Scope) FilterBooks(books []database.Book) []database.Book {
+	if s == nil {
+		return books
+	}
+	out := books[:0]
+	for i := range books {
+		if s.AllowsBook(&books[i]) {
+			out = append(out, books[i])
+		}
+	}
+	return out
+}
+
+// PathFilter returns s.Allows for use as a list filter, or nil when the
+// scope is unrestricted so the filter can be skipped entirely.
+func (s *Scope) PathFilter() func(string) bool {
+	if s == nil {
+		return nil
+	}
+	return s.Allows
+}
+
+// Key identifies the scope for cache keys: responses computed for one
+// scope must not be served to another. Empty for an unrestricted scope.
+func (s *Scope) Key() string {
+	if s == nil {
+		return ""
+	}
+	return "libs=" + strings.Join(s.libraryIDs, ",")
+}
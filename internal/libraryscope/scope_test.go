@@ -0,0 +1,63 @@
+// file: internal/libraryscope/scope_test.go
+// version: 1.0.0
+// guid: 5ec389e5-dfa5-4160-8d0c-76614109b154
+// last-edited: 2026-10-16
+
+package libraryscope
+
+import (
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		LibraryIsolation: true,
+		Libraries: []config.LibraryDefinition{
+			{ID: "adults", RootDir: "/lib/adults", Roles: []string{"parent"}},
+			{ID: "kids", RootDir: "/lib/kids", ImportPaths: []string{"/imports/kids"}, Roles: []string{"parent", "child"}},
+		},
+	}
+}
+
+func TestForUser_Unrestricted(t *testing.T) {
+	cfg := testConfig()
+	assert.Nil(t, ForUser(cfg, nil), "anonymous callers are unrestricted")
+	assert.Nil(t, ForUser(cfg, &database.User{Roles: []string{"admin"}}))
+
+	cfg.LibraryIsolation = false
+	assert.Nil(t, ForUser(cfg, &database.User{Roles: []string{"child"}}))
+
+	var s *Scope
+	assert.True(t, s.Allows("/anywhere"))
+	assert.Equal(t, "", s.Key())
+	assert.Nil(t, s.PathFilter())
+}
+
+func TestForUser_Restricted(t *testing.T) {
+	child := ForUser(testConfig(), &database.User{Roles: []string{"child"}})
+	require.NotNil(t, child)
+	assert.Equal(t, []string{"kids"}, child.LibraryIDs())
+	assert.True(t, child.Allows("/lib/kids/Gruffalo/book.m4b"))
+	assert.True(t, child.Allows("/imports/kids/new.mp3"))
+	assert.False(t, child.Allows("/lib/adults/Dune/dune.m4b"))
+	assert.False(t, child.Allows("/lib/kidsmovies/x.m4b"), "prefix must match whole components")
+	assert.False(t, child.Allows(""))
+
+	parent := ForUser(testConfig(), &database.User{Roles: []string{"parent"}})
+	assert.Equal(t, []string{"adults", "kids"}, parent.LibraryIDs())
+	assert.NotEqual(t, child.Key(), parent.Key())
+
+	books := []database.Book{{ID: "a", FilePath: "/lib/adults/a.m4b"}, {ID: "k", FilePath: "/lib/kids/k.m4b"}}
+	filtered := child.FilterBooks(books)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "k", filtered[0].ID)
+
+	stranger := ForUser(testConfig(), &database.User{Roles: []string{"viewer"}})
+	require.NotNil(t, stranger)
+	assert.False(t, stranger.Allows("/lib/kids/k.m4b"), "no bound library means nothing is visible")
+}
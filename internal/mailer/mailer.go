@@ -0,0 +1,166 @@
+// file: internal/mailer/mailer.go
+// version: 1.0.0
+// guid: ce81d639-3625-4d5e-83e9-5331ad67167a
+// last-edited: 2026-10-17
+
+// Package mailer sends email notifications over SMTP: a summary of each
+// long-running operation that completes, and of every one that fails, to
+// the recipients in the email config section.
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// SMTP security modes.
+const (
+	SecurityStartTLS = "starttls"
+	SecurityTLS      = "tls"
+	SecurityNone     = "none"
+)
+
+// defaultPort is the submission port, used when email.smtp_port is unset.
+const defaultPort = 587
+
+// ErrNotConfigured is returned by Send while smtp_host or from is unset.
+var ErrNotConfigured = errors.New("email: smtp_host and from must be configured")
+
+// Message is one plain-text email.
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Send delivers msg to each address in to through the SMTP server in
+// cfg.Email. It ignores email.enabled, so a test message can be sent
+// before notifications are switched on.
+func Send(ctx context.Context, cfg config.Config, to []string, msg Message) error {
+	ec := cfg.Email
+	if ec.SMTPHost == "" || ec.From == "" {
+		return ErrNotConfigured
+	}
+	from, err := mail.ParseAddress(ec.From)
+	if err != nil {
+		return fmt.Errorf("email: from %q: %w", ec.From, err)
+	}
+	if len(to) == 0 {
+		return errors.New("email: no recipients")
+	}
+	rcpts := make([]string, 0, len(to))
+	for _, addr := range to {
+		a, err := mail.ParseAddress(addr)
+		if err != nil {
+			return fmt.Errorf("email: recipient %q: %w", addr, err)
+		}
+		rcpts = append(rcpts, a.Address)
+	}
+
+	c, err := dial(ctx, ec)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ec.SMTPUsername != "" {
+		if err := c.Auth(smtp.PlainAuth("", ec.SMTPUsername, cfg.SMTPPassword, ec.SMTPHost)); err != nil {
+			return fmt.Errorf("email: auth: %w", err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("email: MAIL FROM: %w", err)
+	}
+	for _, r := range rcpts {
+		if err := c.Rcpt(r); err != nil {
+			return fmt.Errorf("email: RCPT TO %s: %w", r, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("email: DATA: %w", err)
+	}
+	if _, err := w.Write(compose(from, rcpts, msg, time.Now())); err != nil {
+		return fmt.Errorf("email: write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("email: send message: %w", err)
+	}
+	return c.Quit()
+}
+
+// dial connects to the SMTP server and, for starttls, upgrades the
+// connection before anything else is said.
+func dial(ctx context.Context, ec config.EmailConfig) (*smtp.Client, error) {
+	port := ec.SMTPPort
+	if port == 0 {
+		port = defaultPort
+	}
+	addr := net.JoinHostPort(ec.SMTPHost, strconv.Itoa(port))
+	security := strings.ToLower(ec.SMTPSecurity)
+	if security == "" {
+		security = SecurityStartTLS
+	}
+	tlsConfig := &tls.Config{ServerName: ec.SMTPHost}
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+
+	var conn net.Conn
+	var err error
+	switch security {
+	case SecurityTLS:
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	case SecurityStartTLS, SecurityNone:
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	default:
+		return nil, fmt.Errorf("email: smtp_security must be %q, %q or %q", SecurityStartTLS, SecurityTLS, SecurityNone)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("email: connect %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, ec.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("email: %s: %w", addr, err)
+	}
+	if security == SecurityStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			c.Close()
+			return nil, fmt.Errorf("email: %s does not offer STARTTLS; set smtp_security to tls or none", addr)
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("email: STARTTLS: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// compose renders the message headers and body. The SMTP data writer
+// turns its line endings into CRLF and dot-stuffs it.
+func compose(from *mail.Address, to []string, msg Message, now time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\n", from.String())
+	fmt.Fprintf(&b, "To: %s\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\n\n")
+	b.WriteString(msg.Body)
+	if !strings.HasSuffix(msg.Body, "\n") {
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
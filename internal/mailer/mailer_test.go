@@ -0,0 +1,184 @@
+// file: internal/mailer/mailer_test.go
+// version: 1.0.0
+// guid: 5c331a24-a6ba-412d-bcf9-d5a98c71d58b
+
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+)
+
+// fakeSMTP accepts one message over plain SMTP and hands it back.
+type fakeSMTP struct {
+	addr string
+	got  chan smtpMessage
+}
+
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	f := &fakeSMTP{addr: ln.Addr().String(), got: make(chan smtpMessage, 1)}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		var msg smtpMessage
+		_ = tp.PrintfLine("220 fake ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 fake")
+			case "MAIL":
+				msg.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+				_ = tp.PrintfLine("250 ok")
+			case "RCPT":
+				msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+				_ = tp.PrintfLine("250 ok")
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				data, _ := tp.ReadDotBytes()
+				msg.data = string(data)
+				_ = tp.PrintfLine("250 queued")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				f.got <- msg
+				return
+			default:
+				_ = tp.PrintfLine("502 unsupported")
+			}
+		}
+	}()
+	return f
+}
+
+func (f *fakeSMTP) config(t *testing.T) config.Config {
+	host, port, err := net.SplitHostPort(f.addr)
+	require.NoError(t, err)
+	p, _ := strconv.Atoi(port)
+	return config.Config{Email: config.EmailConfig{
+		Enabled: true, SMTPHost: host, SMTPPort: p, SMTPSecurity: SecurityNone,
+		From: "Organizer <organizer@example.com>", Recipients: []string{"me@example.com"},
+	}}
+}
+
+func TestSend(t *testing.T) {
+	f := newFakeSMTP(t)
+	err := Send(context.Background(), f.config(t), []string{"me@example.com", "You <you@example.com>"},
+		Message{Subject: "Library Scan completed", Body: "Books added: 3\n.dot line"})
+	require.NoError(t, err)
+
+	msg := <-f.got
+	assert.Equal(t, "organizer@example.com", msg.from)
+	assert.Equal(t, []string{"me@example.com", "you@example.com"}, msg.to)
+	assert.Contains(t, msg.data, "Subject: Library Scan completed\n")
+	assert.Contains(t, msg.data, "To: me@example.com, you@example.com\n")
+	assert.Contains(t, msg.data, "\n\nBooks added: 3\n.dot line\n", "dot-stuffing round-trips")
+
+	assert.ErrorIs(t, Send(context.Background(), config.Config{}, []string{"me@example.com"}, Message{}), ErrNotConfigured)
+	err = Send(context.Background(), f.config(t), []string{"me@example.com\nBcc: x@example.com"}, Message{})
+	assert.Error(t, err, "header injection in an address")
+}
+
+type fakeStore struct {
+	books []database.Book
+	logs  []database.OpLogV2Row
+}
+
+func (s *fakeStore) GetAllBooks(limit, offset int) ([]database.Book, error) {
+	if offset >= len(s.books) {
+		return nil, nil
+	}
+	return s.books[offset:min(offset+limit, len(s.books))], nil
+}
+
+func (s *fakeStore) GetOpLogsV2(string, int) ([]database.OpLogV2Row, error) { return s.logs, nil }
+
+func TestNotifier(t *testing.T) {
+	finished := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	before, during := finished.Add(-time.Hour), finished.Add(-time.Minute)
+	store := &fakeStore{
+		books: []database.Book{{ID: "old", CreatedAt: &before}, {ID: "a", CreatedAt: &during}, {ID: "b", CreatedAt: &during}},
+		logs: []database.OpLogV2Row{
+			{Level: "info", Message: "scanning"},
+			{Level: "warn", Message: "skipped /in/bad.mp3: unreadable"},
+		},
+	}
+	cfg := config.Config{BaseURL: "https://books.example.com/", Email: config.EmailConfig{
+		Enabled: true, From: "o@example.com", Recipients: []string{"me@example.com"}, MinDurationMinutes: 5,
+	}}
+	n := NewNotifier(store, func() config.Config { return cfg })
+	n.names = func(string) string { return "Library Scan" }
+	var sent []Message
+	n.send = func(_ context.Context, _ config.Config, to []string, msg Message) error {
+		assert.Equal(t, []string{"me@example.com"}, to)
+		sent = append(sent, msg)
+		return nil
+	}
+	event := func(typ plugin.EventType, d time.Duration, data map[string]any) plugin.Event {
+		ev := plugin.NewEvent(typ, "", map[string]any{"op_id": "op-1", "def_id": "library.scan", "duration_ms": d.Milliseconds()})
+		for k, v := range data {
+			ev.Data[k] = v
+		}
+		ev.Timestamp = finished
+		return ev
+	}
+
+	// A short completion is skipped; a long one and any failure are sent.
+	require.NoError(t, n.onOperation(context.Background(), event(plugin.EventOperationCompleted, time.Minute, nil)))
+	assert.Empty(t, sent)
+	require.NoError(t, n.onOperation(context.Background(), event(plugin.EventOperationCompleted, 10*time.Minute,
+		map[string]any{"status": "completed", "progress_current": 40, "progress_total": 40})))
+	require.NoError(t, n.onOperation(context.Background(), event(plugin.EventOperationFailed, time.Second,
+		map[string]any{"status": "failed", "error": "disk full"})))
+	require.Len(t, sent, 2)
+
+	assert.Equal(t, "[Audiobook Organizer] Library Scan completed", sent[0].Subject)
+	body := sent[0].Body
+	assert.Contains(t, body, "Library Scan completed after 10m0s.")
+	assert.Contains(t, body, "Progress:  40 of 40")
+	assert.Contains(t, body, "Books added: 2")
+	assert.Contains(t, body, "  - skipped /in/bad.mp3: unreadable")
+	assert.Contains(t, body, "Activity: https://books.example.com/activity")
+	assert.Equal(t, "[Audiobook Organizer] Library Scan failed", sent[1].Subject)
+	assert.Contains(t, sent[1].Body, "Error: disk full")
+	assert.NotContains(t, sent[1].Body, "Books added", "no books created in the last second")
+
+	// notify_on and the operations filter narrow what is sent.
+	sent = nil
+	cfg.Email.NotifyOn = []string{OutcomeFailed}
+	require.NoError(t, n.onOperation(context.Background(), event(plugin.EventOperationCompleted, time.Hour, nil)))
+	cfg.Email.Operations = []string{"library.organize"}
+	require.NoError(t, n.onOperation(context.Background(), event(plugin.EventOperationFailed, time.Hour, nil)))
+	cfg.Email.Enabled = false
+	cfg.Email.Operations = nil
+	require.NoError(t, n.onOperation(context.Background(), event(plugin.EventOperationFailed, time.Hour, nil)))
+	assert.Empty(t, sent)
+}
@@ -0,0 +1,272 @@
+// file: internal/mailer/notifier.go
+// version: 1.0.0
+// guid: fbc2e5e9-871a-439c-aa1f-e832a558c5b6
+// last-edited: 2026-10-17
+
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
+)
+
+// Outcomes named in email.notify_on.
+const (
+	OutcomeCompleted = "completed"
+	OutcomeFailed    = "failed"
+)
+
+// maxErrors is how many logged errors a summary lists.
+const maxErrors = 10
+
+// Store is the store surface summaries are built from.
+type Store interface {
+	GetAllBooks(limit, offset int) ([]database.Book, error)
+	GetOpLogsV2(opID string, limit int) ([]database.OpLogV2Row, error)
+}
+
+// Summary is what the email templates render for one finished operation.
+type Summary struct {
+	OpID            string
+	DefID           string
+	Name            string
+	Status          string
+	Duration        time.Duration
+	Finished        time.Time
+	ProgressCurrent int
+	ProgressTotal   int
+	Message         string
+	Error           string
+	// BooksAdded counts books created while the operation ran.
+	BooksAdded int
+	// Errors are the last error and warning lines the operation logged,
+	// at most maxErrors of ErrorCount.
+	Errors     []string
+	ErrorCount int
+	// URL links to the activity page when base_url is configured.
+	URL string
+}
+
+var subjectTemplate = template.Must(template.New("subject").Parse(
+	`[Audiobook Organizer] {{.Name}} {{.Status}}`))
+
+var bodyTemplate = template.Must(template.New("body").Parse(`{{.Name}} {{.Status}} after {{.Duration}}.
+
+Operation: {{.DefID}} ({{.OpID}})
+Finished:  {{.Finished.Format "2006-01-02 15:04:05 MST"}}
+{{- if .ProgressTotal}}
+Progress:  {{.ProgressCurrent}} of {{.ProgressTotal}}{{end}}
+{{- if .Message}}
+Last step: {{.Message}}{{end}}
+{{- if .BooksAdded}}
+Books added: {{.BooksAdded}}{{end}}
+{{- if .Error}}
+
+Error: {{.Error}}{{end}}
+{{- if .Errors}}
+
+Errors and warnings logged{{if gt .ErrorCount (len .Errors)}} (last {{len .Errors}} of {{.ErrorCount}}){{end}}:
+{{range .Errors}}  - {{.}}
+{{end}}{{end}}
+{{- if .URL}}
+
+Activity: {{.URL}}{{end}}
+`))
+
+// Render formats s as an email message.
+func (s Summary) Render() (Message, error) {
+	var subject, body strings.Builder
+	if err := subjectTemplate.Execute(&subject, s); err != nil {
+		return Message{}, err
+	}
+	if err := bodyTemplate.Execute(&body, s); err != nil {
+		return Message{}, err
+	}
+	return Message{Subject: subject.String(), Body: body.String()}, nil
+}
+
+// Notifier mails a summary of each finished operation that the email
+// config asks for.
+type Notifier struct {
+	store  Store
+	config func() config.Config
+	// names returns an operation definition's display name.
+	names func(defID string) string
+	// send is Send, swapped out in tests.
+	send func(ctx context.Context, cfg config.Config, to []string, msg Message) error
+}
+
+// NewNotifier returns a notifier that reads the email settings from
+// config for each operation, so settings changes apply without a restart.
+func NewNotifier(store Store, cfg func() config.Config) *Notifier {
+	return &Notifier{store: store, config: cfg, send: Send}
+}
+
+// PostInit subscribes to operation outcomes on the plugin event bus.
+func (n *Notifier) PostInit(_ context.Context, c *serviceregistry.Container) error {
+	if reg, ok := serviceregistry.TryGet[*opsregistry.RegistryWrapper](c, "opregistry"); ok && reg != nil {
+		n.names = func(defID string) string {
+			if def, ok := reg.Def(defID); ok {
+				return def.DisplayName
+			}
+			return ""
+		}
+	}
+	bus, ok := serviceregistry.TryGet[*plugin.EventBus](c, "eventbus")
+	if !ok || bus == nil {
+		return nil
+	}
+	bus.Subscribe(plugin.EventOperationCompleted, n.onOperation)
+	bus.Subscribe(plugin.EventOperationFailed, n.onOperation)
+	return nil
+}
+
+func (n *Notifier) onOperation(ctx context.Context, ev plugin.Event) error {
+	cfg := n.config()
+	if !n.wants(cfg.Email, ev) {
+		return nil
+	}
+	msg, err := n.Summarize(ev, cfg.BaseURL).Render()
+	if err != nil {
+		return err
+	}
+	if err := n.send(ctx, cfg, cfg.Email.Recipients, msg); err != nil {
+		slog.Warn("operation email failed", "op_id", ev.Data["op_id"], "err", err)
+		return err
+	}
+	return nil
+}
+
+// wants applies the email settings to an operation outcome.
+func (n *Notifier) wants(ec config.EmailConfig, ev plugin.Event) bool {
+	if !ec.Enabled || len(ec.Recipients) == 0 {
+		return false
+	}
+	outcome := OutcomeCompleted
+	if ev.Type == plugin.EventOperationFailed {
+		outcome = OutcomeFailed
+	}
+	if len(ec.NotifyOn) > 0 && !slices.Contains(ec.NotifyOn, outcome) {
+		return false
+	}
+	defID, _ := ev.Data["def_id"].(string)
+	if len(ec.Operations) > 0 && !slices.Contains(ec.Operations, defID) {
+		return false
+	}
+	if outcome == OutcomeCompleted {
+		minDuration := time.Duration(ec.MinDurationMinutes) * time.Minute
+		if durationOf(ev) < minDuration {
+			return false
+		}
+	}
+	return true
+}
+
+// Summarize builds the summary of a finished operation from its
+// operation.completed or operation.failed event.
+func (n *Notifier) Summarize(ev plugin.Event, baseURL string) Summary {
+	s := Summary{
+		Duration: durationOf(ev).Round(time.Second),
+		Finished: ev.Timestamp,
+	}
+	s.OpID, _ = ev.Data["op_id"].(string)
+	s.DefID, _ = ev.Data["def_id"].(string)
+	s.Status, _ = ev.Data["status"].(string)
+	s.Message, _ = ev.Data["progress_message"].(string)
+	s.Error, _ = ev.Data["error"].(string)
+	s.ProgressCurrent = int(number(ev.Data["progress_current"]))
+	s.ProgressTotal = int(number(ev.Data["progress_total"]))
+	if s.Status == "" {
+		s.Status = OutcomeCompleted
+		if ev.Type == plugin.EventOperationFailed {
+			s.Status = OutcomeFailed
+		}
+	}
+	s.Name = s.DefID
+	if n.names != nil {
+		if name := n.names(s.DefID); name != "" {
+			s.Name = name
+		}
+	}
+	if baseURL != "" {
+		s.URL = strings.TrimRight(baseURL, "/") + "/activity"
+	}
+	if n.store == nil {
+		return s
+	}
+	if added, err := n.booksAddedSince(ev.Timestamp.Add(-durationOf(ev))); err == nil {
+		s.BooksAdded = added
+	}
+	if s.OpID != "" {
+		if logs, err := n.store.GetOpLogsV2(s.OpID, 1000); err == nil {
+			for _, l := range logs {
+				if l.Level == "error" || l.Level == "warn" {
+					s.ErrorCount++
+					s.Errors = append(s.Errors, l.Message)
+				}
+			}
+			if len(s.Errors) > maxErrors {
+				s.Errors = s.Errors[len(s.Errors)-maxErrors:]
+			}
+		}
+	}
+	return s
+}
+
+// booksAddedSince counts the books created at or after t.
+func (n *Notifier) booksAddedSince(t time.Time) (int, error) {
+	added := 0
+	const pageSize = 500
+	for offset := 0; ; offset += pageSize {
+		books, err := n.store.GetAllBooks(pageSize, offset)
+		if err != nil {
+			return 0, err
+		}
+		for _, b := range books {
+			if b.CreatedAt != nil && !b.CreatedAt.Before(t) {
+				added++
+			}
+		}
+		if len(books) < pageSize {
+			return added, nil
+		}
+	}
+}
+
+// TestMessage is the message the test-send endpoint delivers.
+func TestMessage() Message {
+	return Message{
+		Subject: "[Audiobook Organizer] Test email",
+		Body: fmt.Sprintf("This is a test email from Audiobook Organizer, sent %s.\n\n"+
+			"Operation notifications will reach this address.\n", time.Now().Format("2006-01-02 15:04:05 MST")),
+	}
+}
+
+func durationOf(ev plugin.Event) time.Duration {
+	return time.Duration(number(ev.Data["duration_ms"])) * time.Millisecond
+}
+
+// number reads an event field that may have been through a JSON round
+// trip.
+func number(v any) int64 {
+	switch d := v.(type) {
+	case int64:
+		return d
+	case int:
+		return int64(d)
+	case float64:
+		return int64(d)
+	}
+	return 0
+}
@@ -1,13 +1,14 @@
 // file: internal/maintenance/jobs/backfill_file_hashes.go
-// version: 1.2.1
+// version: 1.3.0
 // guid: a1000014-0000-0000-0000-000000000014
-// last-edited: 2026-05-16
+// last-edited: 2026-10-17
 
 package jobs
 
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/falkcorp/audiobook-organizer/internal/database"
@@ -24,7 +25,7 @@ func (j *backfillFileHashesJob) ID() string          { return "backfill-file-has
 func (j *backfillFileHashesJob) Name() string        { return "Backfill File Hashes" }
 func (j *backfillFileHashesJob) Category() string    { return "files" }
 func (j *backfillFileHashesJob) DefaultParams() any  { return struct{ DryRun bool `json:"dry_run"` }{DryRun: false} }
-func (j *backfillFileHashesJob) Description() string { return "Compute and store file hashes for book_files missing them" }
+func (j *backfillFileHashesJob) Description() string { return "Compute and store file hashes for book_files missing them and rehash files hashed with another algorithm than configured" }
 // Job supports checkpoint-based resume after restart.
 func (j *backfillFileHashesJob) CanResume() bool { return true }
 func (j *backfillFileHashesJob) Run(ctx context.Context, store database.Store, reporter maintenance.ProgressReporter, dryRun bool) error {
@@ -32,17 +33,24 @@ func (j *backfillFileHashesJob) Run(ctx context.Context, store database.Store, r
 	if err != nil {
 		return err
 	}
-	reporter.SetTotal(len(files))
-	hashed := 0
+	books, err := store.GetAllBooks(0, 0)
+	if err != nil {
+		return err
+	}
+	reporter.SetTotal(len(files) + len(books))
+	hashed, rehashed := 0, 0
 
 	// Resume support: load checkpoint if present.
 	opID := maintenance.OperationIDFromCtx(ctx)
-	resumeIndex := 0
+	resumeIndex, bookResumeIndex := 0, 0
 	if opID != "" {
 		if cp, _ := operations.LoadCheckpoint(store, opID); cp != nil {
-			// resume phase 'scanning'
-			if cp.Phase == "scanning" {
+			// resume phase 'scanning' or 'books'
+			switch cp.Phase {
+			case "scanning":
 				resumeIndex = cp.PhaseIndex
+			case "books":
+				resumeIndex, bookResumeIndex = len(files), cp.PhaseIndex
 			}
 		}
 	}
@@ -53,10 +61,11 @@ func (j *backfillFileHashesJob) Run(ctx context.Context, store database.Store, r
 		}
 		reporter.Increment()
 		bf := files[i]
-		if bf.FileHash != "" {
+		stale := bf.FileHash != "" && staleFileHash(bf.FilePath, bf.FileHashAlgorithm, bf.FileSize)
+		if bf.FileHash != "" && !stale {
 			continue
 		}
-		hash, herr := scanner.ComputeFileHash(bf.FilePath)
+		hash, algo, herr := scanner.ComputeFileHashWithAlgorithm(bf.FilePath)
 		if herr != nil {
 			msg := herr.Error()
 			slog.Warn("backfill-file-hashes hash failed for"+bf.FilePath, "details", msg)
@@ -64,14 +73,32 @@ func (j *backfillFileHashesJob) Run(ctx context.Context, store database.Store, r
 			continue
 		}
 		if !dryRun {
-			if serr := store.SetBookFileHash(bf.ID, hash); serr != nil {
+			var serr error
+			if !stale && algo == legacyAlgorithmAt(bf.FilePath) {
+				// A hash stored without its algorithm reads as legacy.
+				serr = store.SetBookFileHash(bf.ID, hash)
+			} else {
+				// The original hash describes the same bytes unless the
+				// file changed since, so it moves to the new algorithm too.
+				if bf.OriginalFileHash == "" || bf.OriginalFileHash == bf.FileHash {
+					bf.OriginalFileHash = hash
+				}
+				bf.FileHash = hash
+				bf.FileHashAlgorithm = algo
+				serr = store.UpdateBookFile(bf.ID, &bf)
+			}
+			if serr != nil {
 				msg := serr.Error()
-				slog.Error("backfill-file-hashes SetBookFileHash failed", "details", msg)
+				slog.Error("backfill-file-hashes saving file hash failed", "details", msg)
 				reporter.Log("error", "Failed to save file hash for "+bf.FilePath, &msg)
 				continue
 			}
 		}
-		hashed++
+		if stale {
+			rehashed++
+		} else {
+			hashed++
+		}
 
 		// Periodic checkpoint so long runs can resume after restart.
 		if opID != "" && i%50 == 0 {
@@ -79,13 +106,66 @@ func (j *backfillFileHashesJob) Run(ctx context.Context, store database.Store, r
 		}
 	}
 
+	// Single-file books carry their own hash, which duplicate detection
+	// groups on; rehash those made with another algorithm. Directory books
+	// are hashed from their first file and left to the next scan.
+	for i := bookResumeIndex; i < len(books); i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		reporter.Increment()
+		book := books[i]
+		if book.FileHash == nil || *book.FileHash == "" {
+			continue
+		}
+		var recorded string
+		if book.FileHashAlgorithm != nil {
+			recorded = *book.FileHashAlgorithm
+		}
+		var size int64
+		if book.FileSize != nil {
+			size = *book.FileSize
+		}
+		if !staleFileHash(book.FilePath, recorded, size) {
+			continue
+		}
+		hash, algo, herr := scanner.ComputeFileHashWithAlgorithm(book.FilePath)
+		if herr != nil {
+			msg := herr.Error()
+			reporter.Log("warn", "Hash computation failed for "+book.FilePath, &msg)
+			continue
+		}
+		if !dryRun {
+			old := *book.FileHash
+			book.FileHash = &hash
+			book.FileHashAlgorithm = &algo
+			if book.OriginalFileHash != nil && *book.OriginalFileHash == old {
+				book.OriginalFileHash = &hash
+			}
+			if book.OrganizedFileHash != nil && *book.OrganizedFileHash == old {
+				book.OrganizedFileHash = &hash
+			}
+			if _, uerr := store.UpdateBook(book.ID, &book); uerr != nil {
+				msg := uerr.Error()
+				slog.Error("backfill-file-hashes UpdateBook failed", "details", msg)
+				reporter.Log("error", "Failed to save file hash for "+book.FilePath, &msg)
+				continue
+			}
+		}
+		rehashed++
+
+		if opID != "" && i%50 == 0 {
+			_ = operations.SaveCheckpoint(store, opID, "maintenance:backfill-file-hashes", "books", i, len(books))
+		}
+	}
+
 	// Clear any saved state on clean completion.
 	if opID != "" {
 		_ = operations.ClearState(store, opID)
 	}
 
 	// Save a lightweight operation summary for the UI and activity feed.
-	res := fmt.Sprintf("Backfilled hashes for %d files", hashed)
+	res := fmt.Sprintf("Backfilled hashes for %d files, rehashed %d", hashed, rehashed)
 	now := time.Now()
 	opLog := &database.OperationSummaryLog{
 		ID:          opID,
@@ -102,3 +182,31 @@ func (j *backfillFileHashesJob) Run(ctx context.Context, store database.Store, r
 	slog.Info("backfill-file-hashes complete")
 	return nil
 }
+
+// staleFileHash reports whether the hash recorded for the regular file at
+// path was made with another algorithm than the configured one. Hashes
+// recorded without an algorithm are judged by the size they were made
+// at, when known.
+func staleFileHash(path, recorded string, recordedSize int64) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if recorded == "" {
+		if recordedSize <= 0 {
+			recordedSize = info.Size()
+		}
+		recorded = scanner.LegacyFileHashAlgorithm(recordedSize)
+	}
+	return recorded != scanner.FileHashAlgorithmFor(info.Size())
+}
+
+// legacyAlgorithmAt is the algorithm a hash of path recorded without one
+// is taken to have been made with.
+func legacyAlgorithmAt(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return scanner.LegacyFileHashAlgorithm(info.Size())
+}
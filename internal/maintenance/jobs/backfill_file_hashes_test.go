@@ -1,7 +1,6 @@
 // file: internal/maintenance/jobs/backfill_file_hashes_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b2c3d4e5-f6a7-8901-bcde-f01234567890
-// last-edited: 2026-05-16
 
 package jobs_test
 
@@ -12,6 +11,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/maintenance"
 	"github.com/stretchr/testify/assert"
@@ -127,3 +127,54 @@ func TestBackfillFileHashesJob_Cancellation(t *testing.T) {
 		assert.ErrorIs(t, err, context.Canceled)
 	}
 }
+
+func TestBackfillFileHashesJob_RehashesOtherAlgorithm(t *testing.T) {
+	orig := config.AppConfig.FileHashAlgorithm
+	config.AppConfig.FileHashAlgorithm = "xxhash64"
+	defer func() { config.AppConfig.FileHashAlgorithm = orig }()
+
+	dir := t.TempDir()
+	stalePath := dir + "/stale.m4b"
+	currentPath := dir + "/current.m4b"
+	bookPath := dir + "/book.m4b"
+	for _, p := range []string{stalePath, currentPath, bookPath} {
+		require.NoError(t, os.WriteFile(p, []byte("audio "+p), 0o644))
+	}
+
+	files := []database.BookFile{
+		// No recorded algorithm reads as the legacy sha256.
+		{ID: "f1", BookID: "b1", FilePath: stalePath, FileHash: "old", OriginalFileHash: "old"},
+		{ID: "f2", BookID: "b1", FilePath: currentPath, FileHash: "cur", FileHashAlgorithm: "xxhash64"},
+	}
+	oldHash, sha := "oldbook", "sha256"
+	books := []database.Book{{ID: "b2", FilePath: bookPath, FileHash: &oldHash, FileHashAlgorithm: &sha, OrganizedFileHash: &oldHash}}
+	updatedFiles := map[string]database.BookFile{}
+	var updatedBook *database.Book
+	store := &database.MockStore{
+		GetAllBookFilesFunc: func() ([]database.BookFile, error) { return files, nil },
+		GetAllBooksFunc:     func(int, int) ([]database.Book, error) { return books, nil },
+		UpdateBookFileFunc: func(id string, f *database.BookFile) error {
+			updatedFiles[id] = *f
+			return nil
+		},
+		UpdateBookFunc: func(id string, b *database.Book) (*database.Book, error) {
+			updatedBook = b
+			return b, nil
+		},
+	}
+
+	j, err := maintenance.Get("backfill-file-hashes")
+	require.NoError(t, err)
+	require.NoError(t, j.Run(context.Background(), store, &noopReporter{}, false))
+
+	require.Len(t, updatedFiles, 1, "only the file hashed with another algorithm is rehashed")
+	got := updatedFiles["f1"]
+	assert.Equal(t, "xxhash64", got.FileHashAlgorithm)
+	assert.Len(t, got.FileHash, 16)
+	assert.Equal(t, got.FileHash, got.OriginalFileHash)
+
+	require.NotNil(t, updatedBook)
+	assert.Equal(t, "xxhash64", *updatedBook.FileHashAlgorithm)
+	assert.NotEqual(t, oldHash, *updatedBook.FileHash)
+	assert.Equal(t, *updatedBook.FileHash, *updatedBook.OrganizedFileHash)
+}
@@ -1,7 +1,7 @@
 // file: internal/maintenance/jobs/bulk_fetch_metadata.go
-// version: 1.1.1
+// version: 1.2.0
 // guid: b3c9d7e8-0f1a-2b3c-4d5e-6f7a8b9c0d1e
-// last-edited: 2026-05-05
+// last-edited: 2026-10-16
 
 package jobs
 
@@ -13,8 +13,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
-	"sync/atomic"
-	"time"
+		"time"
 
 	"github.com/falkcorp/audiobook-organizer/internal/auth"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
@@ -30,6 +29,8 @@ type bulkFetchMetadataJob struct{}
 type bmf_params struct {
 	PreferAudible bool `json:"prefer_audible"`
 	SkipCached    bool `json:"skip_cached"`
+	// Concurrency overrides metadata_fetch_concurrency for this run.
+	Concurrency int `json:"concurrency,omitempty"`
 }
 
 func (j *bulkFetchMetadataJob) ID() string       { return "bulk-fetch-metadata" }
@@ -47,12 +48,14 @@ func (j *bulkFetchMetadataJob) Run(ctx context.Context, store database.Store, re
 
 	preferAudible := false
 	skipCached := false
+	override := 0
 	if opID != "" {
 		if raw, err := store.GetOperationParams(opID); err == nil && len(raw) > 0 {
 			var p bmf_params
 			if jerr := json.Unmarshal(raw, &p); jerr == nil {
 				preferAudible = p.PreferAudible
 				skipCached = p.SkipCached
+				override = p.Concurrency
 			}
 		}
 	}
@@ -63,6 +66,7 @@ func (j *bulkFetchMetadataJob) Run(ctx context.Context, store database.Store, re
 	}
 
 	ttlDays := config.AppConfig.MetadataFetchCacheTTLDays
+	maxAge := time.Duration(ttlDays) * 24 * time.Hour
 
 	var existingResults []database.OperationResult
 	if opID != "" {
@@ -108,7 +112,6 @@ func (j *bulkFetchMetadataJob) Run(ctx context.Context, store database.Store, re
 			continue
 		}
 		if skipCached {
-			maxAge := time.Duration(ttlDays) * 24 * time.Hour
 			hasFreshCache := false
 			for _, src := range sourceChain {
 				if cached, _, cerr := database.GetCachedMetadataFetchWithMaxAge(store, b.ID, src.Name(), maxAge); cerr == nil && cached != nil {
@@ -141,102 +144,92 @@ func (j *bulkFetchMetadataJob) Run(ctx context.Context, store database.Store, re
 		return nil
 	}
 
-	completed := int64(alreadyDone)
-	found := 0
-	notFound := 0
+	concurrency := config.AppConfig.MetadataFetchConcurrency
+	if override > 0 {
+		concurrency = override
+	}
+	fetcher := metadata.NewBatchFetcher(sourceChain, metadata.BatchOptions{
+		Concurrency:   concurrency,
+		RatePerSecond: config.AppConfig.MetadataFetchRatePerSecond,
+	})
+
+	var found, notFound, failed, coalesced int
+	record := func(bookID, status, source string) {
+		if opID != "" {
+			_ = store.CreateOperationResult(&database.OperationResult{
+				OperationID: opID,
+				BookID:      bookID,
+				ResultJSON:  fmt.Sprintf(`{"status":%q,"source":%q}`, status, source),
+				Status:      status,
+			})
+		}
+		reporter.Increment()
+	}
 
-	for i, w := range work {
+	// Books with a fresh cache entry need no live call.
+	var queries []metadata.BatchQuery
+	var live []bookWork
+	for _, w := range work {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-
-		bookID := w.book.ID
-		currentAuthor := w.authorName
-		searchTitle := bmf_stripChapterFromTitle(w.book.Title)
-
-		var metaResults []metadata.BookMetadata
-		var sourceName string
-		cacheHit := false
-
-		maxAge := time.Duration(ttlDays) * 24 * time.Hour
-		for _, src := range sourceChain {
-			if cached, _, cerr := database.GetCachedMetadataFetchWithMaxAge(store, bookID, src.Name(), maxAge); cerr == nil && cached != nil {
-				var cachedResults []metadata.BookMetadata
-				if jerr := json.Unmarshal(cached.Results, &cachedResults); jerr == nil && len(cachedResults) > 0 {
-					metaResults = cachedResults
-					sourceName = src.Name()
-					cacheHit = true
-					break
-				}
-			}
-			var fetchErr error
-			if currentAuthor != "" {
-				metaResults, fetchErr = src.SearchByTitleAndAuthor(ctx, searchTitle, currentAuthor)
-				if fetchErr == nil && len(metaResults) > 0 {
-					sourceName = src.Name()
-					break
-				}
-			}
-			metaResults, fetchErr = src.SearchByTitle(ctx, searchTitle)
-			if fetchErr == nil && len(metaResults) > 0 {
-				sourceName = src.Name()
-				break
-			}
-			if searchTitle != w.book.Title {
-				if currentAuthor != "" {
-					metaResults, fetchErr = src.SearchByTitleAndAuthor(ctx, w.book.Title, currentAuthor)
-					if fetchErr == nil && len(metaResults) > 0 {
-						sourceName = src.Name()
-						break
-					}
-				}
-				metaResults, fetchErr = src.SearchByTitle(ctx, w.book.Title)
-				if fetchErr == nil && len(metaResults) > 0 {
-					sourceName = src.Name()
-					break
-				}
-			}
+		if source := bmf_cachedSource(store, w.book.ID, sourceChain, maxAge); source != "" {
+			found++
+			record(w.book.ID, "cached", source)
+			continue
 		}
+		queries = append(queries, metadata.BatchQuery{
+			Title:    bmf_stripChapterFromTitle(w.book.Title),
+			Author:   w.authorName,
+			AltTitle: w.book.Title,
+		})
+		live = append(live, w)
+	}
 
-		resultStatus := "not_found"
-		if len(metaResults) > 0 && sourceName != "" {
-			if !cacheHit {
-				if blob, merr := json.Marshal(metaResults); merr == nil {
-					_ = database.PutCachedMetadataFetch(store, bookID, sourceName, blob, 0)
-				}
+	// Failed lookups get no operation result, so resuming the job (or
+	// running it again) retries them; everything finished before a
+	// cancel stays recorded.
+	runErr := fetcher.Run(ctx, queries, func(i int, r metadata.BatchResult) {
+		bookID := live[i].book.ID
+		if r.Coalesced {
+			coalesced++
+		}
+		switch {
+		case len(r.Results) > 0:
+			if blob, merr := json.Marshal(r.Results); merr == nil {
+				_ = database.PutCachedMetadataFetch(store, bookID, r.Source, blob, 0)
 			}
 			found++
-			resultStatus = "cached"
-		} else {
+			record(bookID, "cached", r.Source)
+		case r.Failed():
+			failed++
+			reporter.Increment()
+			slog.Warn("bulk-fetch-metadata lookup failed", "opID", opID, "bookID", bookID, "errors", r.Errors)
+		default:
 			notFound++
+			record(bookID, "not_found", "")
 		}
+	})
 
-		if opID != "" {
-			_ = store.CreateOperationResult(&database.OperationResult{
-				OperationID: opID,
-				BookID:      bookID,
-				ResultJSON:  fmt.Sprintf(`{"status":%q,"source":%q}`, resultStatus, sourceName),
-				Status:      resultStatus,
-			})
-		}
-
-		atomic.AddInt64(&completed, 1)
-		reporter.Increment()
+	slog.Info("bulk-fetch-metadata done", "opID", opID, "found", found, "notFound", notFound,
+		"failed", failed, "coalesced", coalesced, "concurrency", concurrency)
+	return runErr
+}
 
-		// Rate-limit live API calls.
-		if !cacheHit && sourceName != "" && i < len(work)-1 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(200 * time.Millisecond):
-			}
+// bmf_cachedSource returns the first source in chain with a fresh,
+// non-empty cache entry for bookID, or "" when there is none.
+func bmf_cachedSource(store database.Store, bookID string, chain []metadata.MetadataSource, maxAge time.Duration) string {
+	for _, src := range chain {
+		cached, _, err := database.GetCachedMetadataFetchWithMaxAge(store, bookID, src.Name(), maxAge)
+		if err != nil || cached == nil {
+			continue
+		}
+		var results []metadata.BookMetadata
+		if json.Unmarshal(cached.Results, &results) == nil && len(results) > 0 {
+			return src.Name()
 		}
 	}
-
-	finalCount := atomic.LoadInt64(&completed)
-	slog.Info("bulk-fetch-metadata done books — cached not_found", "opID", opID, "finalCount", finalCount, "found", found, "notFound", notFound)
-	slog.Info("complete — cached not_found", "found", found, "notFound", notFound)
-	return nil
+	return ""
 }
 
 // bmf_buildSourceChain reads config.AppConfig.MetadataSources and returns
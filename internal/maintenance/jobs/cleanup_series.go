@@ -1,7 +1,7 @@
 // file: internal/maintenance/jobs/cleanup_series.go
-// version: 2.1.1
+// version: 2.2.0
 // guid: a1000002-0000-0000-0000-000000000002
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package jobs
 
@@ -70,7 +70,7 @@ func (j *cleanupSeriesJob) Run(ctx context.Context, store database.Store, report
 			continue
 		}
 		book := books[0]
-		if book.SeriesSequence != nil && *book.SeriesSequence > 1 {
+		if pos, ok := seriesSeqFloat(book.SeriesSequence); ok && pos > 1 {
 			reporter.Increment()
 			continue
 		}
@@ -179,6 +179,14 @@ func csMergeSeriesGroup(store database.Store, keepID int, mergeIDs []int) error
 
 var csNonAlphanumRE = regexp.MustCompile(`[^\p{L}\p{N}\s]+`)
 
+// seriesSeqFloat returns the numeric series position, if any.
+func seriesSeqFloat(seq *database.SeriesSeq) (float64, bool) {
+	if seq == nil {
+		return 0, false
+	}
+	return seq.Float()
+}
+
 func csNormalizeSeriesName(name string) string {
 	s := strings.ToLower(strings.TrimSpace(name))
 	s = strings.TrimPrefix(s, "the ")
@@ -1,7 +1,7 @@
 // file: internal/maintenance/jobs/dedup_books.go
-// version: 2.2.0
+// version: 2.3.0
 // guid: a1000010-0000-0000-0000-000000000010
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package jobs
 
@@ -112,10 +112,13 @@ func (j *dedupBooksJob) Run(ctx context.Context, store database.Store, reporter
 		}
 	}
 
-	// Phase 3: Merge books with same normalised title + author in same dir
+	// Phase 3: Merge books with same normalised title + author in same dir.
+	// Without an author the narrator stands in, so same-titled books by
+	// unknown authors but different narrators are not merged.
 	type titleAuthorKey struct {
 		NormTitle string
 		AuthorID  int
+		Narrator  string
 		Dir       string
 	}
 	taGroups := make(map[titleAuthorKey][]database.Book)
@@ -136,7 +139,11 @@ func (j *dedupBooksJob) Run(ctx context.Context, store database.Store, reporter
 		if book.FilePath != "" {
 			dir = filepath.Dir(book.FilePath)
 		}
-		key := titleAuthorKey{NormTitle: normTitle, AuthorID: authorID, Dir: dir}
+		narrator := ""
+		if authorID == 0 {
+			narrator = database.BookIdentityOf(nil, book).Narrator
+		}
+		key := titleAuthorKey{NormTitle: normTitle, AuthorID: authorID, Narrator: narrator, Dir: dir}
 		taGroups[key] = append(taGroups[key], *book)
 	}
 
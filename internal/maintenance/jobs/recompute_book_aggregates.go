@@ -1,5 +1,5 @@
 // file: internal/maintenance/jobs/recompute_book_aggregates.go
-// version: 1.0.0
+// version: 1.0.1
 // guid: 9b0c1d2e-3f4a-5b6c-7d8e-9f0a1b2c3d4e
 // last-edited: 2026-06-10
 
@@ -58,7 +58,7 @@ func (j *recomputeBookAggregatesJob) Run(
 	reporter maintenance.ProgressReporter,
 	dryRun bool,
 ) error {
-	pebbleStore, ok := store.(*database.PebbleStore)
+	pebbleStore, ok := database.AsPebbleStore(store)
 	if !ok {
 		// Fallback for test double or SQLite: iterate via the Store interface.
 		return j.runViaInterface(ctx, store, reporter, dryRun)
@@ -1,5 +1,5 @@
 // file: internal/maintenance/jobs/sweep_pebble_metrics_ttl.go
-// version: 1.0.0
+// version: 1.0.1
 // guid: b8c9d0e1-f2a3-0008-1234-000000000008
 
 // Package jobs — maintenance job: sweep expired Pebble metrics snapshots.
@@ -46,7 +46,7 @@ func (j *sweepPebbleMetricsTTLJob) Run(
 	reporter maintenance.ProgressReporter,
 	dryRun bool,
 ) error {
-	ps, ok := store.(*database.PebbleStore)
+	ps, ok := database.AsPebbleStore(store)
 	if !ok {
 		// Not a Pebble backend — no-op (test double or SQLite fallback).
 		slog.Info("sweep-pebble-metrics-ttl: store is not a PebbleStore; skipping")
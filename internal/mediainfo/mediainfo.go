@@ -1,5 +1,5 @@
 // file: internal/mediainfo/mediainfo.go
-// version: 1.3.0
+// version: 1.5.0
 // guid: f1e2d3c4-b5a6-7c8d-9e0f-1a2b3c4d5e6f
 
 package mediainfo
@@ -209,11 +209,18 @@ func estimateDurationFromFile(filePath string, bitrateKbps int) int {
 	if err != nil || fi.Size() == 0 {
 		return 0
 	}
-	bytesPerSec := (bitrateKbps * 1000) / 8
-	if bytesPerSec <= 0 {
+	return EstimateDuration(fi.Size(), bitrateKbps)
+}
+
+// EstimateDuration approximates the playing time in seconds of sizeBytes of
+// audio at a constant bitrateKbps. Container overhead and VBR drift make it
+// a rough figure; callers should flag it as estimated. Returns 0 when
+// either input is non-positive.
+func EstimateDuration(sizeBytes int64, bitrateKbps int) int {
+	if sizeBytes <= 0 || bitrateKbps <= 0 {
 		return 0
 	}
-	return int(fi.Size()) / bytesPerSec
+	return int(sizeBytes / (int64(bitrateKbps) * 1000 / 8))
 }
 
 func inferFromFormat(filePath string, info *MediaInfo) (*MediaInfo, error) {
@@ -292,3 +299,68 @@ func GetQualityTier(info *MediaInfo) int {
 		return 30
 	}
 }
+
+// codecEfficiency scales a lossy codec's bitrate to an MP3-equivalent
+// bitrate before scoring: 64kbps Opus sounds roughly like 128kbps MP3 for
+// spoken word, and AAC/Vorbis sit in between.
+var codecEfficiency = map[string]float64{
+	"mp3":    1.0,
+	"aac":    1.4,
+	"he-aac": 1.8,
+	"vorbis": 1.3,
+	"opus":   2.0,
+}
+
+// losslessCodecs score above every lossy encoding regardless of bitrate.
+var losslessCodecs = map[string]bool{
+	"flac": true,
+	"alac": true,
+	"wav":  true,
+	"pcm":  true,
+	"aiff": true,
+}
+
+// QualityScore rates an encoding from 0 to 100 so versions of the same book
+// can be compared across formats. Lossless encodings score 90–100 (bonus
+// for 24-bit and ≥48kHz). Lossy encodings score up to 85: an MP3-equivalent
+// bitrate component (codec-weighted, capped at 320kbps → 80) plus up to 5
+// for sample rate. Unknown lossy codecs are weighted like MP3; a zero
+// bitrate scores 0.
+func QualityScore(codec string, bitrateKbps, sampleRateHz, bitDepth int) int {
+	c := strings.ToLower(strings.TrimSpace(codec))
+	if losslessCodecs[c] {
+		score := 90
+		if bitDepth >= 24 {
+			score += 5
+		}
+		if sampleRateHz >= 48000 {
+			score += 5
+		}
+		return score
+	}
+	if bitrateKbps <= 0 {
+		return 0
+	}
+	eff, ok := codecEfficiency[c]
+	if !ok {
+		eff = 1.0
+	}
+	score := int(float64(bitrateKbps) * eff / 4)
+	if score > 80 {
+		score = 80
+	}
+	switch {
+	case sampleRateHz >= 44100:
+		score += 5
+	case sampleRateHz >= 32000:
+		score += 3
+	case sampleRateHz >= 22050:
+		score += 1
+	}
+	return score
+}
+
+// QualityScore returns the 0–100 quality score for info.
+func (info *MediaInfo) QualityScore() int {
+	return QualityScore(info.Codec, info.Bitrate, info.SampleRate, info.BitDepth)
+}
@@ -1,7 +1,7 @@
 // file: internal/mediainfo/mediainfo_test.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: a2b3c4d5-e6f7-8a9b-0c1d-2e3f4a5b6c7d
-// last-edited: 2026-01-21
+// last-edited: 2026-10-16
 
 package mediainfo
 
@@ -111,6 +111,30 @@ func TestGetQualityTier(t *testing.T) {
 	}
 }
 
+func TestQualityScore(t *testing.T) {
+	tests := []struct {
+		name string
+		info *MediaInfo
+		want int
+	}{
+		{"FLAC 24-bit 96kHz", &MediaInfo{Codec: "FLAC", BitDepth: 24, SampleRate: 96000}, 100},
+		{"FLAC 16-bit 44.1kHz", &MediaInfo{Codec: "FLAC", BitDepth: 16, SampleRate: 44100}, 90},
+		{"MP3 320kbps", &MediaInfo{Codec: "MP3", Bitrate: 320, SampleRate: 44100}, 85},
+		{"MP3 128kbps", &MediaInfo{Codec: "MP3", Bitrate: 128, SampleRate: 44100}, 37},
+		{"Opus 64kbps matches MP3 128kbps", &MediaInfo{Codec: "Opus", Bitrate: 64, SampleRate: 48000}, 37},
+		{"AAC 64kbps", &MediaInfo{Codec: "AAC", Bitrate: 64, SampleRate: 44100}, 27},
+		{"MP3 64kbps 22kHz", &MediaInfo{Codec: "MP3", Bitrate: 64, SampleRate: 22050}, 17},
+		{"unknown bitrate", &MediaInfo{Codec: "MP3"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.QualityScore(); got != tt.want {
+				t.Errorf("QualityScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestInferFromFormat(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,213 @@
+// file: internal/mediaserver/export.go
+// version: 1.0.0
+// guid: 4a9c1f35-e8b2-4d67-9f04-c3d6b7a2e185
+// last-edited: 2026-10-17
+
+package mediaserver
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/layout"
+	"github.com/falkcorp/audiobook-organizer/internal/organizer"
+)
+
+// Export modes.
+const (
+	ExportSymlink  = "symlink"
+	ExportHardlink = "hardlink"
+)
+
+// Link is one file of the Plex-named tree: Source is relative to the
+// library root, Target to the export directory.
+type Link struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// PlexTree names every file in l the way Plex's music agents read an
+// audiobook library: Author/Album/NN - Title.ext, one album per book.
+// A series book's album is "Series N - Title" so the series sorts
+// together; a single-file book keeps just its title as the file name.
+func PlexTree(l *layout.Layout) []Link {
+	var links []Link
+	add := func(author string, album string, b layout.Book) {
+		dir := filepath.Join(plexName(author), plexName(album))
+		width := max(2, len(fmt.Sprint(len(b.Files))))
+		for i, f := range b.Files {
+			ext := strings.ToLower(filepath.Ext(f.Path))
+			name := plexName(b.Title) + ext
+			if len(b.Files) > 1 {
+				name = fmt.Sprintf("%0*d - %s%s", width, i+1, plexName(b.Title), ext)
+			}
+			links = append(links, Link{Source: f.Path, Target: filepath.ToSlash(filepath.Join(dir, name))})
+		}
+	}
+	for _, a := range l.Authors {
+		for _, s := range a.Series {
+			for _, b := range s.Books {
+				album := s.Name + " - " + b.Title
+				if b.Sequence != "" {
+					album = s.Name + " " + b.Sequence + " - " + b.Title
+				}
+				add(a.Name, album, b)
+			}
+		}
+		for _, b := range a.Books {
+			add(a.Name, b.Title, b)
+		}
+	}
+	return links
+}
+
+// plexName makes s safe as one path component.
+func plexName(s string) string {
+	s = strings.Trim(organizer.SanitizePathComponent(s), ".")
+	if s == "" {
+		return "Unknown"
+	}
+	return s
+}
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// Root is the library root the layout's paths are relative to.
+	Root string
+	// Dir receives the tree.
+	Dir string
+	// Mode is ExportSymlink (default) or ExportHardlink.
+	Mode   string
+	DryRun bool
+}
+
+// ExportResult reports an Export run. Files use the layout package's
+// per-file statuses.
+type ExportResult struct {
+	Mode    string              `json:"mode"`
+	DryRun  bool                `json:"dry_run"`
+	Counts  map[string]int      `json:"counts"`
+	Files   []layout.FileResult `json:"files"`
+	Removed int                 `json:"removed"`
+}
+
+// Export writes PlexTree(l) under opts.Dir as links to the library's
+// files. Links already in place are kept and nothing else is
+// overwritten. Symlinks under opts.Dir that are no longer part of the
+// tree (a book renamed or deleted since the last export) are removed.
+func Export(ctx context.Context, l *layout.Layout, opts ExportOptions) (*ExportResult, error) {
+	if opts.Mode == "" {
+		opts.Mode = ExportSymlink
+	}
+	if opts.Mode != ExportSymlink && opts.Mode != ExportHardlink {
+		return nil, fmt.Errorf("mode must be %q or %q", ExportSymlink, ExportHardlink)
+	}
+	if opts.Root == "" || opts.Dir == "" {
+		return nil, fmt.Errorf("library root and export directory are required")
+	}
+	dir, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	if dir == root || strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		return nil, fmt.Errorf("export directory %s must be outside the library root", opts.Dir)
+	}
+
+	result := &ExportResult{Mode: opts.Mode, DryRun: opts.DryRun, Counts: map[string]int{}, Files: []layout.FileResult{}}
+	keep := map[string]bool{}
+	for _, link := range PlexTree(l) {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(link.Target))
+		keep[dst] = true
+		r := exportLink(filepath.Join(root, filepath.FromSlash(link.Source)), dst, opts)
+		r.Path = link.Target
+		result.Counts[r.Status]++
+		result.Files = append(result.Files, r)
+	}
+	if !opts.DryRun {
+		result.Removed = pruneLinks(dir, keep)
+	}
+	return result, nil
+}
+
+func exportLink(src, dst string, opts ExportOptions) layout.FileResult {
+	r := layout.FileResult{Source: src}
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		r.Status = layout.StatusMissing
+		return r
+	}
+	if _, err := os.Lstat(dst); err == nil {
+		if linksTo(dst, src, srcInfo) {
+			r.Status = layout.StatusPresent
+		} else {
+			r.Status, r.Error = layout.StatusConflict, "target exists and is not a link to the library file"
+		}
+		return r
+	}
+	if opts.DryRun {
+		r.Status = layout.StatusWouldPlace
+		return r
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		r.Status, r.Error = layout.StatusFailed, err.Error()
+		return r
+	}
+	if opts.Mode == ExportHardlink {
+		err = os.Link(src, dst)
+	} else {
+		err = os.Symlink(src, dst)
+	}
+	if err != nil {
+		r.Status, r.Error = layout.StatusFailed, err.Error()
+		return r
+	}
+	r.Status = layout.StatusPlaced
+	return r
+}
+
+// linksTo reports whether dst is a symlink to src or the same file.
+func linksTo(dst, src string, srcInfo os.FileInfo) bool {
+	if target, err := os.Readlink(dst); err == nil && target == src {
+		return true
+	}
+	info, err := os.Stat(dst)
+	return err == nil && os.SameFile(info, srcInfo)
+}
+
+// pruneLinks removes symlinks under dir not in keep, then the folders
+// that leaves empty, and returns how many links it removed.
+func pruneLinks(dir string, keep map[string]bool) int {
+	removed := 0
+	var dirs []string
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return nil
+		case d.IsDir():
+			if path != dir {
+				dirs = append(dirs, path)
+			}
+		case d.Type()&fs.ModeSymlink != 0 && !keep[path]:
+			if os.Remove(path) == nil {
+				removed++
+			}
+		}
+		return nil
+	})
+	// Deepest first, so a folder's subfolders are gone before it.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = os.Remove(dirs[i]) // fails, harmlessly, unless empty
+	}
+	return removed
+}
@@ -0,0 +1,220 @@
+// file: internal/mediaserver/mediaserver.go
+// version: 1.0.0
+// guid: 8d2f4a61-3c7e-4b95-a0d8-e5b1c9f7a342
+// last-edited: 2026-10-17
+
+// Package mediaserver tells Plex and Jellyfin about organized books: it
+// asks them for a partial scan of the folders an organize run touched, so
+// new and moved books show up without waiting for their periodic scan,
+// and it writes a Plex-named link tree of the library for Plex libraries
+// that want Plex's own naming.
+package mediaserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// Server is a media server that can rescan part of its library.
+type Server interface {
+	Name() string
+	// Scan asks the server to rescan the given folders, as it sees them.
+	Scan(ctx context.Context, paths []string) error
+}
+
+// Servers returns the enabled, fully configured media servers in cfg.
+func Servers(cfg config.Config) []Server {
+	var out []Server
+	in := cfg.Integrations
+	if in.Plex.Enabled && in.Plex.URL != "" && cfg.PlexToken != "" {
+		out = append(out, &Plex{BaseURL: in.Plex.URL, Token: cfg.PlexToken, SectionID: in.Plex.LibraryID})
+	}
+	if in.Jellyfin.Enabled && in.Jellyfin.URL != "" && cfg.JellyfinAPIKey != "" {
+		out = append(out, &Jellyfin{BaseURL: in.Jellyfin.URL, APIKey: cfg.JellyfinAPIKey})
+	}
+	return out
+}
+
+// MapPath rewrites a local path to the media server's view of it with the
+// longest matching mapping; p is returned unchanged when none matches.
+func MapPath(p string, mappings []config.MediaServerPathMap) string {
+	best := -1
+	for i, m := range mappings {
+		local := strings.TrimRight(m.Local, "/")
+		if local == "" || (p != local && !strings.HasPrefix(p, local+"/")) {
+			continue
+		}
+		if best < 0 || len(local) > len(strings.TrimRight(mappings[best].Local, "/")) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return p
+	}
+	rest := strings.TrimPrefix(p, strings.TrimRight(mappings[best].Local, "/"))
+	return strings.TrimRight(mappings[best].Remote, "/") + rest
+}
+
+// pathMappings returns the configured mappings for the server named name.
+func pathMappings(cfg config.Config, name string) []config.MediaServerPathMap {
+	switch name {
+	case "plex":
+		return cfg.Integrations.Plex.PathMappings
+	case "jellyfin":
+		return cfg.Integrations.Jellyfin.PathMappings
+	}
+	return nil
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Plex scans through the Plex Media Server API.
+type Plex struct {
+	BaseURL string
+	Token   string
+	// SectionID is the library section to scan. Empty scans, for each
+	// path, the section whose folders hold it.
+	SectionID string
+}
+
+func (p *Plex) Name() string { return "plex" }
+
+// Scan requests GET /library/sections/{id}/refresh?path=... per folder.
+func (p *Plex) Scan(ctx context.Context, paths []string) error {
+	var sections []plexSection
+	if p.SectionID == "" {
+		var err error
+		if sections, err = p.sections(ctx); err != nil {
+			return err
+		}
+	}
+	for _, path := range paths {
+		id := p.SectionID
+		if id == "" {
+			if id = sectionFor(sections, path); id == "" {
+				return fmt.Errorf("plex: no library section holds %s", path)
+			}
+		}
+		q := url.Values{"path": {path}}
+		if err := p.do(ctx, "/library/sections/"+url.PathEscape(id)+"/refresh?"+q.Encode(), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type plexSection struct {
+	Key      string `json:"key"`
+	Location []struct {
+		Path string `json:"path"`
+	} `json:"Location"`
+}
+
+func (p *Plex) sections(ctx context.Context) ([]plexSection, error) {
+	var resp struct {
+		MediaContainer struct {
+			Directory []plexSection `json:"Directory"`
+		} `json:"MediaContainer"`
+	}
+	if err := p.do(ctx, "/library/sections", &resp); err != nil {
+		return nil, err
+	}
+	return resp.MediaContainer.Directory, nil
+}
+
+// sectionFor returns the key of the section with the longest folder
+// holding path.
+func sectionFor(sections []plexSection, path string) string {
+	key, best := "", -1
+	for _, s := range sections {
+		for _, loc := range s.Location {
+			root := strings.TrimRight(loc.Path, "/")
+			if (path == root || strings.HasPrefix(path, root+"/")) && len(root) > best {
+				key, best = s.Key, len(root)
+			}
+		}
+	}
+	return key
+}
+
+func (p *Plex) do(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(p.BaseURL, "/")+path, nil)
+	if err != nil {
+		return fmt.Errorf("plex: %w", err)
+	}
+	req.Header.Set("X-Plex-Token", p.Token)
+	req.Header.Set("Accept", "application/json")
+	return send(req, "plex", out)
+}
+
+// Jellyfin scans through Jellyfin's library-changed notification.
+type Jellyfin struct {
+	BaseURL string
+	APIKey  string
+}
+
+func (j *Jellyfin) Name() string { return "jellyfin" }
+
+// Scan posts every folder to /Library/Media/Updated in one request.
+func (j *Jellyfin) Scan(ctx context.Context, paths []string) error {
+	type update struct {
+		Path       string `json:"Path"`
+		UpdateType string `json:"UpdateType"`
+	}
+	body := struct {
+		Updates []update `json:"Updates"`
+	}{}
+	for _, p := range paths {
+		body.Updates = append(body.Updates, update{Path: p, UpdateType: "Modified"})
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("jellyfin: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(j.BaseURL, "/")+"/Library/Media/Updated", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("jellyfin: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("MediaBrowser Token=%q", j.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+	return send(req, "jellyfin", nil)
+}
+
+func send(req *http.Request, name string, out any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s %s: %s: %s", name, req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: decode %s: %w", name, req.URL.Path, err)
+	}
+	return nil
+}
+
+// bookFolder is the folder holding a book's files: path itself for a
+// folder book, its parent for a single-file one.
+func bookFolder(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return path
+	}
+	return filepath.Dir(path)
+}
@@ -0,0 +1,146 @@
+// file: internal/mediaserver/mediaserver_test.go
+// version: 1.0.0
+// guid: 6f3d8a20-b4c9-4e71-a5f2-0d8e1b7c9a43
+
+package mediaserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/layout"
+)
+
+// fakeServers is one httptest server playing both Plex and Jellyfin.
+type fakeServers struct {
+	mu       sync.Mutex
+	plex     []string // refreshed "section:path"
+	jellyfin []string
+}
+
+func (f *fakeServers) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/library/sections", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ptok", r.Header.Get("X-Plex-Token"))
+		_, _ = w.Write([]byte(`{"MediaContainer":{"Directory":[
+			{"key":"1","Location":[{"path":"/data"}]},
+			{"key":"7","Location":[{"path":"/data/audiobooks"}]}]}}`))
+	})
+	mux.HandleFunc("/library/sections/{id}/refresh", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ptok", r.Header.Get("X-Plex-Token"))
+		f.mu.Lock()
+		f.plex = append(f.plex, r.PathValue("id")+":"+r.URL.Query().Get("path"))
+		f.mu.Unlock()
+	})
+	mux.HandleFunc("POST /Library/Media/Updated", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `MediaBrowser Token="jkey"`, r.Header.Get("Authorization"))
+		var body struct {
+			Updates []struct{ Path, UpdateType string }
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		f.mu.Lock()
+		for _, u := range body.Updates {
+			f.jellyfin = append(f.jellyfin, u.Path)
+		}
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func TestNotifierNotify(t *testing.T) {
+	fake := &fakeServers{}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+
+	cfg := config.Config{
+		RootDir:        "/library",
+		PlexToken:      "ptok",
+		JellyfinAPIKey: "jkey",
+		Integrations: config.IntegrationsConfig{
+			Plex: config.MediaServerConfig{Enabled: true, URL: srv.URL,
+				PathMappings: []config.MediaServerPathMap{{Local: "/library", Remote: "/data/audiobooks"}}},
+			Jellyfin: config.MediaServerConfig{Enabled: true, URL: srv.URL + "/"},
+		},
+	}
+	n := NewNotifier(nil, func() config.Config { return cfg })
+	require.NoError(t, n.Notify(context.Background(), []string{"/library/Herbert/Dune", "/library/Pratchett/Mort"}))
+	assert.Equal(t, []string{"7:/data/audiobooks/Herbert/Dune", "7:/data/audiobooks/Pratchett/Mort"}, fake.plex,
+		"mapped paths scan the deepest section holding them")
+	assert.Equal(t, []string{"/library/Herbert/Dune", "/library/Pratchett/Mort"}, fake.jellyfin, "no mapping, local paths")
+
+	// A section that holds nothing fails Plex but still reaches Jellyfin.
+	fake.plex, fake.jellyfin = nil, nil
+	err := n.Notify(context.Background(), []string{"/elsewhere/x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no library section holds /elsewhere/x")
+	assert.Equal(t, []string{"/elsewhere/x"}, fake.jellyfin)
+
+	// Disabled servers are skipped, and nothing is queued without any.
+	cfg.Integrations.Plex.Enabled, cfg.Integrations.Jellyfin.Enabled = false, false
+	assert.Empty(t, Servers(cfg))
+	n.Queue("/library/a")
+	assert.Empty(t, n.pending)
+}
+
+func TestMapPath(t *testing.T) {
+	maps := []config.MediaServerPathMap{{Local: "/library", Remote: "/data"}, {Local: "/library/kids/", Remote: "/kids"}}
+	for in, want := range map[string]string{
+		"/library/Dune":      "/data/Dune",
+		"/library/kids/Mort": "/kids/Mort",
+		"/library":           "/data",
+		"/library2/Dune":     "/library2/Dune",
+	} {
+		assert.Equal(t, want, MapPath(in, maps), in)
+	}
+}
+
+func TestExport(t *testing.T) {
+	root, dir := t.TempDir(), t.TempDir()
+	for _, p := range []string{"Herbert/Dune/1.mp3", "Herbert/Dune/2.mp3", "Pratchett/Mort.M4B"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(p)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, p), []byte(p), 0o644))
+	}
+	l := &layout.Layout{Authors: []layout.Author{
+		{Name: "Frank Herbert", Series: []layout.Series{{Name: "Dune", Books: []layout.Book{
+			{Title: "Dune: Book One", Sequence: "1", Files: []layout.File{{Path: "Herbert/Dune/1.mp3"}, {Path: "Herbert/Dune/2.mp3"}}},
+		}}}},
+		{Name: "Terry Pratchett", Books: []layout.Book{{Title: "Mort", Files: []layout.File{{Path: "Pratchett/Mort.M4B"}}}}},
+	}}
+	assert.Equal(t, []Link{
+		{Source: "Herbert/Dune/1.mp3", Target: "Frank Herbert/Dune 1 - Dune - Book One/01 - Dune - Book One.mp3"},
+		{Source: "Herbert/Dune/2.mp3", Target: "Frank Herbert/Dune 1 - Dune - Book One/02 - Dune - Book One.mp3"},
+		{Source: "Pratchett/Mort.M4B", Target: "Terry Pratchett/Mort/Mort.m4b"},
+	}, PlexTree(l))
+
+	// A stale link from an earlier export goes away.
+	stale := filepath.Join(dir, "Old Author", "Old Book", "old.mp3")
+	require.NoError(t, os.MkdirAll(filepath.Dir(stale), 0o755))
+	require.NoError(t, os.Symlink(filepath.Join(root, "gone.mp3"), stale))
+
+	res, err := Export(context.Background(), l, ExportOptions{Root: root, Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Counts[layout.StatusPlaced])
+	assert.Equal(t, 1, res.Removed)
+	assert.NoDirExists(t, filepath.Join(dir, "Old Author"))
+	data, err := os.ReadFile(filepath.Join(dir, "Terry Pratchett", "Mort", "Mort.m4b"))
+	require.NoError(t, err)
+	assert.Equal(t, "Pratchett/Mort.M4B", string(data))
+
+	res, err = Export(context.Background(), l, ExportOptions{Root: root, Dir: dir, Mode: ExportHardlink})
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Counts[layout.StatusPresent], "an existing link to the same file is kept")
+
+	_, err = Export(context.Background(), l, ExportOptions{Root: root, Dir: filepath.Join(root, "plex")})
+	assert.Error(t, err, "export inside the library root")
+}
@@ -0,0 +1,192 @@
+// file: internal/mediaserver/notifier.go
+// version: 1.0.0
+// guid: 1e6b9c47-d2a5-4f08-83c1-7a4f0e5d2b96
+// last-edited: 2026-10-17
+
+package mediaserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
+)
+
+// ExportOpID is the operation that writes the Plex-named link tree; its
+// completion rescans plex_export_dir.
+const ExportOpID = "mediaserver.export-plex"
+
+// maxFolders is how many folders one batch scans one by one; a bigger
+// batch rescans the whole library root instead.
+const maxFolders = 50
+
+// BookLister is the store surface the notifier reads organized books from.
+type BookLister interface {
+	GetAllBooks(limit, offset int) ([]database.Book, error)
+}
+
+// Notifier collects the folders organize runs touch and, after a short
+// quiet period, asks every configured media server to rescan them.
+type Notifier struct {
+	store  BookLister
+	config func() config.Config
+	// delay batches the folders of an organize burst into one scan.
+	delay time.Duration
+
+	mu      sync.Mutex
+	pending map[string]bool
+	timer   *time.Timer
+}
+
+// NewNotifier returns a notifier that reads the media servers from
+// config at each scan, so settings changes apply without a restart.
+func NewNotifier(store BookLister, cfg func() config.Config) *Notifier {
+	return &Notifier{store: store, config: cfg, delay: 5 * time.Second, pending: map[string]bool{}}
+}
+
+// PostInit subscribes to organize events on the plugin event bus.
+func (n *Notifier) PostInit(_ context.Context, c *serviceregistry.Container) error {
+	bus, ok := serviceregistry.TryGet[*plugin.EventBus](c, "eventbus")
+	if !ok || bus == nil {
+		return nil
+	}
+	bus.Subscribe(plugin.EventFileOrganized, n.onFileOrganized)
+	bus.Subscribe(plugin.EventOperationCompleted, n.onOperationCompleted)
+	return nil
+}
+
+// Stop drops any batch still waiting for its quiet period.
+func (n *Notifier) Stop(context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.timer != nil {
+		n.timer.Stop()
+		n.timer = nil
+	}
+	return nil
+}
+
+func (n *Notifier) onFileOrganized(_ context.Context, ev plugin.Event) error {
+	if path, _ := ev.Data["new_path"].(string); path != "" {
+		n.Queue(bookFolder(path))
+	}
+	return nil
+}
+
+func (n *Notifier) onOperationCompleted(_ context.Context, ev plugin.Event) error {
+	switch ev.Data["def_id"] {
+	case "library.organize":
+		// The op records its moves under its own run ID, so find the
+		// books by when they were organized.
+		start := ev.Timestamp.Add(-time.Duration(durationMs(ev.Data["duration_ms"]))*time.Millisecond - time.Second)
+		folders, err := n.organizedSince(start)
+		if err != nil {
+			return err
+		}
+		n.Queue(folders...)
+	case ExportOpID:
+		if dir := n.config().Integrations.PlexExportDir; dir != "" {
+			n.Queue(dir)
+		}
+	}
+	return nil
+}
+
+func durationMs(v any) int64 {
+	switch d := v.(type) {
+	case int64:
+		return d
+	case int:
+		return int64(d)
+	case float64:
+		return int64(d)
+	}
+	return 0
+}
+
+// organizedSince returns the folders of books organized at or after t.
+func (n *Notifier) organizedSince(t time.Time) ([]string, error) {
+	var folders []string
+	const pageSize = 500
+	for offset := 0; ; offset += pageSize {
+		books, err := n.store.GetAllBooks(pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range books {
+			if b.LastOrganizedAt != nil && !b.LastOrganizedAt.Before(t) && b.FilePath != "" {
+				folders = append(folders, bookFolder(b.FilePath))
+			}
+		}
+		if len(books) < pageSize {
+			return folders, nil
+		}
+	}
+}
+
+// Queue adds folders to the next batch. Nothing is queued while no media
+// server is configured.
+func (n *Notifier) Queue(folders ...string) {
+	if len(folders) == 0 || len(Servers(n.config())) == 0 {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, f := range folders {
+		n.pending[f] = true
+	}
+	if n.timer == nil {
+		n.timer = time.AfterFunc(n.delay, n.flush)
+	}
+}
+
+func (n *Notifier) flush() {
+	n.mu.Lock()
+	folders := make([]string, 0, len(n.pending))
+	for f := range n.pending {
+		folders = append(folders, f)
+	}
+	n.pending = map[string]bool{}
+	n.timer = nil
+	n.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	_ = n.Notify(ctx, folders)
+}
+
+// Notify asks every configured media server to rescan folders now,
+// rewriting them with each server's path mappings. A batch of more than
+// maxFolders rescans the library root instead. Failures are logged and
+// returned together.
+func (n *Notifier) Notify(ctx context.Context, folders []string) error {
+	cfg := n.config()
+	if len(folders) > maxFolders && cfg.RootDir != "" {
+		folders = []string{cfg.RootDir}
+	}
+	var errs []error
+	for _, srv := range Servers(cfg) {
+		seen := map[string]bool{}
+		var paths []string
+		for _, f := range folders {
+			if p := MapPath(f, pathMappings(cfg, srv.Name())); !seen[p] {
+				seen[p] = true
+				paths = append(paths, p)
+			}
+		}
+		sort.Strings(paths)
+		if err := srv.Scan(ctx, paths); err != nil {
+			slog.Warn("media server scan failed", "server", srv.Name(), "folders", len(paths), "err", err)
+			errs = append(errs, err)
+			continue
+		}
+		slog.Info("media server scan requested", "server", srv.Name(), "folders", len(paths))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,22 @@
+// file: internal/mediaserver/register.go
+// version: 1.0.0
+
+package mediaserver
+
+import (
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
+)
+
+func init() {
+	serviceregistry.Register(serviceregistry.ServiceDef{
+		Name:   "mediaserver",
+		Needs:  []string{"store", "eventbus"},
+		Groups: []string{"core"},
+		Build: func(c *serviceregistry.Container) (any, error) {
+			store := serviceregistry.Get[database.Store](c, "store")
+			return NewNotifier(store, config.Snapshot), nil
+		},
+	})
+}
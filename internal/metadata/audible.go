@@ -1,5 +1,5 @@
 // file: internal/metadata/audible.go
-// version: 1.5.3
+// version: 1.6.0
 // guid: a9b8c7d6-e5f4-3a2b-1c0d-9e8f7a6b5c4d
 
 package metadata
@@ -195,6 +195,16 @@ func (c *AudibleClient) LookupByASIN(asin string) (*BookMetadata, error) {
 	return &meta, nil
 }
 
+// GetByASIN implements Provider.
+func (c *AudibleClient) GetByASIN(ctx context.Context, asin string) (*BookMetadata, error) {
+	return c.LookupByASIN(asin)
+}
+
+// GetByISBN implements Provider. The catalog API can't be queried by ISBN.
+func (c *AudibleClient) GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
 func (c *AudibleClient) searchCatalog(ctx context.Context, searchURL string) ([]BookMetadata, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchURL, nil)
 	if err != nil {
@@ -1,5 +1,5 @@
 // file: internal/metadata/audnexus.go
-// version: 2.3.2
+// version: 2.4.0
 // guid: c3d4e5f6-a7b8-9c0d-1e2f-a3b4c5d6e7f8
 
 package metadata
@@ -188,6 +188,16 @@ func (c *AudnexusClient) LookupByASIN(asin string) (*BookMetadata, error) {
 	return nil, lastErr
 }
 
+// GetByASIN implements Provider.
+func (c *AudnexusClient) GetByASIN(ctx context.Context, asin string) (*BookMetadata, error) {
+	return c.LookupByASIN(asin)
+}
+
+// GetByISBN implements Provider. Audnexus only indexes ASINs.
+func (c *AudnexusClient) GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
 func (c *AudnexusClient) bookToMetadata(book *audnexusBook) *BookMetadata {
 	meta := &BookMetadata{
 		Title:     book.Title,
@@ -0,0 +1,233 @@
+// file: internal/metadata/batch.go
+// version: 1.0.0
+// guid: 6e1d9a37-4b28-4f0c-8a53-c2f7b94e1d60
+// last-edited: 2026-10-16
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// BatchQuery is one book's search in a batch fetch.
+type BatchQuery struct {
+	Title  string
+	Author string
+	// AltTitle is searched after Title when it differs, e.g. the raw
+	// title before track or chapter prefixes were stripped.
+	AltTitle string
+}
+
+// key identifies queries that are answered identically, so the batch
+// only sends one of them to the providers.
+func (q BatchQuery) key() string {
+	norm := func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+	alt := norm(q.AltTitle)
+	if alt == norm(q.Title) {
+		alt = ""
+	}
+	return norm(q.Title) + "\x00" + norm(q.Author) + "\x00" + alt
+}
+
+// BatchResult is the outcome of one BatchQuery.
+type BatchResult struct {
+	Results []BookMetadata
+	// Source is the provider that answered; empty when none did.
+	Source string
+	// Errors holds the providers that failed, as opposed to finding
+	// nothing, keyed by provider name.
+	Errors map[string]error
+	// Coalesced is set when an identical query earlier in the batch
+	// supplied the result.
+	Coalesced bool
+}
+
+// Failed reports whether the query found nothing while at least one
+// provider failed, so the answer is unknown rather than "no match" and
+// the query is worth repeating later.
+func (r BatchResult) Failed() bool {
+	return len(r.Results) == 0 && len(r.Errors) > 0
+}
+
+// BatchOptions configures a BatchFetcher.
+type BatchOptions struct {
+	// Concurrency is the number of queries in flight; <= 0 means 1.
+	Concurrency int
+	// RatePerSecond caps the calls made to each provider, shared by all
+	// workers; <= 0 means unlimited.
+	RatePerSecond float64
+}
+
+// BatchFetcher searches a provider chain for many books at once. Each
+// query tries the sources in order and stops at the first with results,
+// like a single fetch. Calls to each provider share one rate limiter,
+// and identical queries are sent once and share the answer.
+type BatchFetcher struct {
+	sources     []MetadataSource
+	concurrency int
+	limiters    map[string]*rate.Limiter
+
+	mu    sync.Mutex
+	calls map[string]*batchCall
+}
+
+type batchCall struct {
+	done chan struct{}
+	res  BatchResult
+}
+
+// NewBatchFetcher returns a fetcher over sources, in priority order.
+func NewBatchFetcher(sources []MetadataSource, opts BatchOptions) *BatchFetcher {
+	limit := rate.Inf
+	if opts.RatePerSecond > 0 {
+		limit = rate.Limit(opts.RatePerSecond)
+	}
+	limiters := make(map[string]*rate.Limiter, len(sources))
+	for _, src := range sources {
+		if _, ok := limiters[src.Name()]; !ok {
+			limiters[src.Name()] = rate.NewLimiter(limit, 1)
+		}
+	}
+	return &BatchFetcher{
+		sources:     sources,
+		concurrency: max(opts.Concurrency, 1),
+		limiters:    limiters,
+		calls:       map[string]*batchCall{},
+	}
+}
+
+// Run fetches every query with up to the configured concurrency and
+// calls each with the query's index and result as it finishes; calls
+// to each are serialized. Queries already finished stay reported when
+// ctx is canceled part way, and Run then returns ctx's error.
+func (f *BatchFetcher) Run(ctx context.Context, queries []BatchQuery, each func(i int, r BatchResult)) error {
+	work := make(chan int)
+	var (
+		wg     sync.WaitGroup
+		eachMu sync.Mutex
+	)
+	for range min(f.concurrency, len(queries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				r := f.Fetch(ctx, queries[i])
+				if ctx.Err() != nil {
+					// Cut short, so the result says nothing about the book.
+					continue
+				}
+				eachMu.Lock()
+				each(i, r)
+				eachMu.Unlock()
+			}
+		}()
+	}
+feed:
+	for i := range queries {
+		select {
+		case work <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	return ctx.Err()
+}
+
+// Fetch answers one query. It is safe for concurrent use; a query
+// identical to one already fetched or in flight waits for that answer.
+func (f *BatchFetcher) Fetch(ctx context.Context, q BatchQuery) BatchResult {
+	key := q.key()
+	f.mu.Lock()
+	if c, ok := f.calls[key]; ok {
+		f.mu.Unlock()
+		select {
+		case <-c.done:
+			r := c.res
+			r.Coalesced = true
+			return r
+		case <-ctx.Done():
+			return BatchResult{Errors: map[string]error{"": ctx.Err()}}
+		}
+	}
+	c := &batchCall{done: make(chan struct{})}
+	f.calls[key] = c
+	f.mu.Unlock()
+
+	c.res = f.search(ctx, q)
+	if c.res.Failed() {
+		// Let a later identical query try again rather than inherit the
+		// failure.
+		f.mu.Lock()
+		delete(f.calls, key)
+		f.mu.Unlock()
+	}
+	close(c.done)
+	return c.res
+}
+
+// search runs q against the sources in order: title and author, then
+// title alone, then the same for AltTitle.
+func (f *BatchFetcher) search(ctx context.Context, q BatchQuery) BatchResult {
+	titles := []string{q.Title}
+	if alt := strings.TrimSpace(q.AltTitle); alt != "" && alt != q.Title {
+		titles = append(titles, alt)
+	}
+	var res BatchResult
+	for _, src := range f.sources {
+		limiter := f.limiters[src.Name()]
+		call := func(search func() ([]BookMetadata, error)) bool {
+			if err := limiter.Wait(ctx); err != nil {
+				res.addError(src.Name(), err)
+				return false
+			}
+			results, err := search()
+			if err != nil {
+				res.addError(src.Name(), err)
+				return false
+			}
+			if len(results) > 0 {
+				res.Results, res.Source = results, src.Name()
+				return true
+			}
+			return false
+		}
+		for _, title := range titles {
+			if q.Author != "" && call(func() ([]BookMetadata, error) {
+				return src.SearchByTitleAndAuthor(ctx, title, q.Author)
+			}) {
+				return res.found()
+			}
+			if call(func() ([]BookMetadata, error) { return src.SearchByTitle(ctx, title) }) {
+				return res.found()
+			}
+			if errors.Is(res.Errors[src.Name()], ErrCircuitOpen) || ctx.Err() != nil {
+				break
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return res
+}
+
+func (r *BatchResult) addError(source string, err error) {
+	if r.Errors == nil {
+		r.Errors = map[string]error{}
+	}
+	r.Errors[source] = err
+}
+
+// found drops the errors of providers tried before the one that
+// answered; the answer stands either way.
+func (r BatchResult) found() BatchResult {
+	r.Errors = nil
+	return r
+}
@@ -0,0 +1,149 @@
+// file: internal/metadata/batch_test.go
+// version: 1.0.0
+// guid: 92c4e0b8-7f15-4d3a-b6e9-1a8d05f3c27e
+// last-edited: 2026-10-16
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchSource answers from a title table, counting calls and the
+// largest number of calls in flight at once.
+type batchSource struct {
+	name   string
+	titles map[string]string // title -> result title
+	fail   map[string]error
+	delay  time.Duration
+
+	calls, inFlight, peak atomic.Int32
+}
+
+func (s *batchSource) Name() string { return s.name }
+
+func (s *batchSource) SearchByTitle(ctx context.Context, title string) ([]BookMetadata, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		p := s.peak.Load()
+		if n <= p || s.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	s.calls.Add(1)
+	time.Sleep(s.delay)
+	if err := s.fail[title]; err != nil {
+		return nil, err
+	}
+	if match, ok := s.titles[title]; ok {
+		return []BookMetadata{{Title: match}}, nil
+	}
+	return nil, nil
+}
+
+func (s *batchSource) SearchByTitleAndAuthor(ctx context.Context, title, _ string) ([]BookMetadata, error) {
+	return s.SearchByTitle(ctx, title)
+}
+
+func TestBatchFetcher_ChainAndPartialResults(t *testing.T) {
+	first := &batchSource{name: "first", titles: map[string]string{"Dune": "Dune (first)"},
+		fail: map[string]error{"Emma": errors.New("timeout")}}
+	second := &batchSource{name: "second", titles: map[string]string{"Raw Title": "Found by alt"}}
+	f := NewBatchFetcher([]MetadataSource{first, second}, BatchOptions{Concurrency: 4})
+
+	queries := []BatchQuery{
+		{Title: "Dune"},
+		{Title: "Stripped", AltTitle: "Raw Title"},
+		{Title: "Nothing"},
+		{Title: "Emma"},
+	}
+	got := map[int]BatchResult{}
+	require.NoError(t, f.Run(context.Background(), queries, func(i int, r BatchResult) { got[i] = r }))
+	require.Len(t, got, 4)
+
+	assert.Equal(t, "first", got[0].Source)
+	assert.Equal(t, "Dune (first)", got[0].Results[0].Title)
+	assert.Equal(t, "second", got[1].Source)
+	assert.False(t, got[2].Failed())
+	assert.Empty(t, got[2].Results)
+	assert.True(t, got[3].Failed())
+	assert.Contains(t, got[3].Errors, "first")
+}
+
+func TestBatchFetcher_CoalescesIdenticalQueries(t *testing.T) {
+	src := &batchSource{name: "src", titles: map[string]string{"Dune": "Dune"}, delay: 20 * time.Millisecond}
+	f := NewBatchFetcher([]MetadataSource{src}, BatchOptions{Concurrency: 8})
+
+	queries := make([]BatchQuery, 10)
+	for i := range queries {
+		queries[i] = BatchQuery{Title: "Dune", Author: "Frank Herbert"}
+	}
+	queries[9].Title = " dune "
+	var mu sync.Mutex
+	coalesced := 0
+	require.NoError(t, f.Run(context.Background(), queries, func(i int, r BatchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, r.Results, 1)
+		if r.Coalesced {
+			coalesced++
+		}
+	}))
+	assert.Equal(t, int32(1), src.calls.Load())
+	assert.Equal(t, 9, coalesced)
+}
+
+func TestBatchFetcher_FailuresAreNotCoalesced(t *testing.T) {
+	src := &batchSource{name: "src", fail: map[string]error{"Emma": errors.New("503")}}
+	f := NewBatchFetcher([]MetadataSource{src}, BatchOptions{})
+	assert.True(t, f.Fetch(context.Background(), BatchQuery{Title: "Emma"}).Failed())
+	assert.True(t, f.Fetch(context.Background(), BatchQuery{Title: "Emma"}).Failed())
+	assert.Equal(t, int32(2), src.calls.Load())
+}
+
+func TestBatchFetcher_ConcurrencyAndRateLimit(t *testing.T) {
+	src := &batchSource{name: "src", delay: 10 * time.Millisecond}
+	f := NewBatchFetcher([]MetadataSource{src}, BatchOptions{Concurrency: 3})
+	queries := make([]BatchQuery, 12)
+	for i := range queries {
+		queries[i] = BatchQuery{Title: string(rune('a' + i))}
+	}
+	require.NoError(t, f.Run(context.Background(), queries, func(int, BatchResult) {}))
+	assert.LessOrEqual(t, src.peak.Load(), int32(3))
+	assert.Greater(t, src.peak.Load(), int32(1))
+
+	// 5 calls at 50/s with a burst of 1 take at least 80ms.
+	limited := NewBatchFetcher([]MetadataSource{&batchSource{name: "src"}}, BatchOptions{Concurrency: 5, RatePerSecond: 50})
+	start := time.Now()
+	require.NoError(t, limited.Run(context.Background(), queries[:5], func(int, BatchResult) {}))
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+}
+
+func TestBatchFetcher_CancelKeepsFinishedResults(t *testing.T) {
+	src := &batchSource{name: "src", delay: 5 * time.Millisecond}
+	f := NewBatchFetcher([]MetadataSource{src}, BatchOptions{Concurrency: 1})
+	queries := make([]BatchQuery, 50)
+	for i := range queries {
+		queries[i] = BatchQuery{Title: string(rune('A' + i))}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	reported := 0
+	err := f.Run(ctx, queries, func(int, BatchResult) {
+		reported++
+		if reported == 3 {
+			cancel()
+		}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.GreaterOrEqual(t, reported, 3)
+	assert.Less(t, reported, 50)
+}
@@ -1,5 +1,5 @@
 // file: internal/metadata/circuitbreaker.go
-// version: 1.2.1
+// version: 1.3.0
 // guid: e2f3a4b5-c6d7-8901-ef23-456789abcdef
 
 package metadata
@@ -201,3 +201,40 @@ func (ps *ProtectedSource) SearchByContext(ctx *SearchContext) ([]BookMetadata,
 	ps.breaker.RecordSuccess()
 	return results, nil
 }
+
+// GetByISBN forwards to the underlying source when it is a Provider. A
+// source without the lookup returns ErrNotSupported without touching the
+// breaker, since nothing was called.
+func (ps *ProtectedSource) GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
+	inner, ok := ps.source.(Provider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return ps.lookup(func() (*BookMetadata, error) { return inner.GetByISBN(ctx, isbn) })
+}
+
+// GetByASIN forwards to the underlying source when it is a Provider; see
+// GetByISBN.
+func (ps *ProtectedSource) GetByASIN(ctx context.Context, asin string) (*BookMetadata, error) {
+	inner, ok := ps.source.(Provider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return ps.lookup(func() (*BookMetadata, error) { return inner.GetByASIN(ctx, asin) })
+}
+
+func (ps *ProtectedSource) lookup(call func() (*BookMetadata, error)) (*BookMetadata, error) {
+	if err := ps.breaker.AllowRequest(); err != nil {
+		return nil, err
+	}
+	result, err := call()
+	switch {
+	case errors.Is(err, ErrNotSupported):
+		return nil, err
+	case err != nil:
+		ps.breaker.RecordFailure()
+		return nil, err
+	}
+	ps.breaker.RecordSuccess()
+	return result, nil
+}
@@ -1,5 +1,5 @@
 // file: internal/metadata/enhanced.go
-// version: 1.9.1
+// version: 1.11.0
 // guid: 7e8d9c0b-1a2f-3e4d-5c6b-7a8d9c0b1a2f
 
 package metadata
@@ -11,9 +11,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/fileops"
 )
@@ -271,6 +273,8 @@ func writeM4BCustomTagsWithFFmpeg(filePath string, metadata map[string]interface
 	}
 	if si, ok := metadata["series_index"].(int); ok && si > 0 {
 		customTags["SERIES_INDEX"] = fmt.Sprintf("%d", si)
+	} else if si, ok := metadata["series_index"].(string); ok && si != "" {
+		customTags["SERIES_INDEX"] = si
 	}
 	if asin, ok := metadata["asin"].(string); ok && asin != "" {
 		customTags["ASIN"] = asin
@@ -617,7 +621,8 @@ func ExportMetadata(books []database.Book) (map[string]interface{}, error) {
 
 	result["books"] = bookData
 	result["count"] = len(books)
-	result["exported_at"] = time.Now().Format(time.RFC3339)
+	result["exported_at"] = time.Now().UTC().Format(time.RFC3339)
+	result["timezone"] = config.Location().String()
 
 	return result, nil
 }
@@ -657,7 +662,7 @@ func ImportMetadata(data map[string]interface{}, store database.BookStore, valid
 			Duration:       &duration,
 			AuthorID:       getIntPtrField(bookData, "author_id"),
 			SeriesID:       getIntPtrField(bookData, "series_id"),
-			SeriesSequence: getIntPtrField(bookData, "series_sequence"),
+			SeriesSequence: getSeriesSeqField(bookData, "series_sequence"),
 		}
 
 		// Create or update book
@@ -691,6 +696,19 @@ func getIntField(data map[string]interface{}, field string) int {
 	return 0
 }
 
+// getSeriesSeqField accepts a numeric or string series position.
+func getSeriesSeqField(data map[string]interface{}, field string) *database.SeriesSeq {
+	switch val := data[field].(type) {
+	case float64:
+		return database.ParseSeriesSeq(strconv.FormatFloat(val, 'f', -1, 64))
+	case int:
+		return database.NewSeriesSeq(val)
+	case string:
+		return database.ParseSeriesSeq(val)
+	}
+	return nil
+}
+
 func getIntPtrField(data map[string]interface{}, field string) *int {
 	if val, ok := data[field].(float64); ok {
 		intVal := int(val)
@@ -1,5 +1,5 @@
 // file: internal/metadata/enhanced_test.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: 8f7e6d5c-4b3a-2c1d-0e9f-8a7b6c5d4e3f
 
 package metadata
@@ -426,7 +426,7 @@ func TestExportMetadata(t *testing.T) {
 			FilePath:       "/path/to/book1.m4b",
 			AuthorID:       &authorID,
 			SeriesID:       &seriesID,
-			SeriesSequence: &seriesSeq,
+			SeriesSequence: database.NewSeriesSeq(seriesSeq),
 			Duration:       &duration,
 		},
 		{
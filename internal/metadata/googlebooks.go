@@ -1,5 +1,5 @@
 // file: internal/metadata/googlebooks.go
-// version: 1.3.2
+// version: 1.4.0
 // guid: b2c3d4e5-f6a7-8b9c-0d1e-f2a3b4c5d6e7
 
 package metadata
@@ -94,6 +94,20 @@ func (c *GoogleBooksClient) SearchByTitleAndAuthor(ctx context.Context, title, a
 	return c.search(ctx, q)
 }
 
+// GetByISBN implements Provider using the isbn: search operator.
+func (c *GoogleBooksClient) GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
+	results, err := c.search(ctx, url.QueryEscape("isbn:"+isbn))
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// GetByASIN implements Provider. Google Books doesn't index ASINs.
+func (c *GoogleBooksClient) GetByASIN(ctx context.Context, asin string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
 func (c *GoogleBooksClient) search(ctx context.Context, escapedQuery string) ([]BookMetadata, error) {
 	searchURL := fmt.Sprintf("%s/volumes?q=%s&maxResults=5", c.baseURL, escapedQuery)
 	if c.apiKey != "" {
@@ -1,5 +1,5 @@
 // file: internal/metadata/hardcover.go
-// version: 1.2.2
+// version: 1.3.0
 // guid: e7e02554-8931-49ba-9528-d3d51279da1d
 
 package metadata
@@ -230,6 +230,21 @@ func (c *HardcoverClient) SearchByContext(ctx *SearchContext) ([]BookMetadata, e
 	return nil, nil
 }
 
+// GetByISBN implements Provider. Hardcover's search matches ISBNs
+// exactly, so the first hit is the edition.
+func (c *HardcoverClient) GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
+	results, err := c.search(ctx, isbn)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// GetByASIN implements Provider. Hardcover doesn't index ASINs.
+func (c *HardcoverClient) GetByASIN(ctx context.Context, asin string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
 func (c *HardcoverClient) search(ctx context.Context, query string) ([]BookMetadata, error) {
 	c.waitForRateLimit()
 
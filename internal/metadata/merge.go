@@ -0,0 +1,111 @@
+// file: internal/metadata/merge.go
+// version: 1.0.0
+// guid: 0f4b9c27-3a1e-4d86-b5e2-7c9a61d8f034
+
+package metadata
+
+// ProviderResult is one provider's best match for a book.
+type ProviderResult struct {
+	// Provider is the registry id (see ProviderIDForName).
+	Provider string
+	// Rank is the provider's position in the source chain, 0 first.
+	Rank int
+	// Confidence is how sure the caller is that Metadata describes the
+	// book, from 0 to 1.
+	Confidence float64
+	Metadata   BookMetadata
+}
+
+// FieldSource records where a merged field came from.
+type FieldSource struct {
+	Provider   string  `json:"provider"`
+	Confidence float64 `json:"confidence"`
+}
+
+// mergeField is one unit MergeResults picks independently. Related
+// values (series and position, the rating counters) travel together so a
+// merged book never pairs one provider's series with another's position.
+type mergeField struct {
+	name  string
+	set   func(m *BookMetadata) bool
+	apply func(dst, src *BookMetadata)
+}
+
+var mergeFields = []mergeField{
+	{"title", func(m *BookMetadata) bool { return m.Title != "" }, func(d, s *BookMetadata) { d.Title = s.Title }},
+	{"author", func(m *BookMetadata) bool { return m.Author != "" }, func(d, s *BookMetadata) { d.Author = s.Author }},
+	{"narrator", func(m *BookMetadata) bool { return m.Narrator != "" }, func(d, s *BookMetadata) { d.Narrator = s.Narrator }},
+	{"description", func(m *BookMetadata) bool { return m.Description != "" }, func(d, s *BookMetadata) { d.Description = s.Description }},
+	{"publisher", func(m *BookMetadata) bool { return m.Publisher != "" }, func(d, s *BookMetadata) { d.Publisher = s.Publisher }},
+	{"publish_year", func(m *BookMetadata) bool { return m.PublishYear > 0 }, func(d, s *BookMetadata) { d.PublishYear = s.PublishYear }},
+	{"isbn", func(m *BookMetadata) bool { return m.ISBN != "" }, func(d, s *BookMetadata) { d.ISBN = s.ISBN }},
+	{"asin", func(m *BookMetadata) bool { return m.ASIN != "" }, func(d, s *BookMetadata) { d.ASIN = s.ASIN }},
+	{"cover_url", func(m *BookMetadata) bool { return m.CoverURL != "" }, func(d, s *BookMetadata) { d.CoverURL = s.CoverURL }},
+	{"language", func(m *BookMetadata) bool { return m.Language != "" }, func(d, s *BookMetadata) { d.Language = s.Language }},
+	{"genre", func(m *BookMetadata) bool { return m.Genre != "" }, func(d, s *BookMetadata) { d.Genre = s.Genre }},
+	{"series", func(m *BookMetadata) bool { return m.Series != "" }, func(d, s *BookMetadata) {
+		d.Series, d.SeriesPosition = s.Series, s.SeriesPosition
+	}},
+	{"duration", func(m *BookMetadata) bool { return m.DurationSec > 0 }, func(d, s *BookMetadata) { d.DurationSec = s.DurationSec }},
+	{"audible_rating", func(m *BookMetadata) bool { return m.AudibleRatingCount > 0 || m.AudibleRatingOverall > 0 }, func(d, s *BookMetadata) {
+		d.AudibleRatingOverall, d.AudibleRatingPerformance, d.AudibleRatingStory = s.AudibleRatingOverall, s.AudibleRatingPerformance, s.AudibleRatingStory
+		d.AudibleRatingCount, d.AudibleNumReviews = s.AudibleRatingCount, s.AudibleNumReviews
+	}},
+	{"google_rating", func(m *BookMetadata) bool { return m.GoogleRatingCount > 0 || m.GoogleRatingAverage > 0 }, func(d, s *BookMetadata) {
+		d.GoogleRatingAverage, d.GoogleRatingCount = s.GoogleRatingAverage, s.GoogleRatingCount
+	}},
+	{"category_tags", func(m *BookMetadata) bool { return len(m.CategoryTags) > 0 }, func(d, s *BookMetadata) { d.CategoryTags = s.CategoryTags }},
+}
+
+// MergeFieldNames returns the field names MergeResults picks
+// individually, which are also the keys metadata_field_priority accepts.
+func MergeFieldNames() []string {
+	names := make([]string, len(mergeFields))
+	for i, f := range mergeFields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// MergeResults builds one record from several providers' matches,
+// choosing every field separately. A field's confidence is the result's
+// Confidence discounted by how far down the provider ranks for that
+// field: the position in fieldPriority[field] when the provider is
+// listed there, otherwise after every listed provider in chain order.
+// The most confident non-empty value wins; ties go to the better rank.
+// The second return value says which provider supplied each field.
+func MergeResults(results []ProviderResult, fieldPriority map[string][]string) (BookMetadata, map[string]FieldSource) {
+	var merged BookMetadata
+	sources := make(map[string]FieldSource)
+	for _, f := range mergeFields {
+		best, bestConf, bestRank := -1, 0.0, 0
+		for i := range results {
+			r := &results[i]
+			if !f.set(&r.Metadata) {
+				continue
+			}
+			rank := fieldRank(fieldPriority[f.name], r.Provider, r.Rank)
+			conf := r.Confidence / (1 + 0.25*float64(rank))
+			if best < 0 || conf > bestConf || (conf == bestConf && rank < bestRank) {
+				best, bestConf, bestRank = i, conf, rank
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		f.apply(&merged, &results[best].Metadata)
+		sources[f.name] = FieldSource{Provider: results[best].Provider, Confidence: bestConf}
+	}
+	return merged, sources
+}
+
+// fieldRank is a provider's rank for one field: its index in the field's
+// priority list, or the list length plus its chain rank when unlisted.
+func fieldRank(priority []string, provider string, chainRank int) int {
+	for i, id := range priority {
+		if id == provider {
+			return i
+		}
+	}
+	return len(priority) + chainRank
+}
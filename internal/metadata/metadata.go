@@ -1,5 +1,5 @@
 // file: internal/metadata/metadata.go
-// version: 1.17.0
+// version: 1.18.0
 // guid: 9d0e1f2a-3b4c-5d6e-7f8a-9b0c1d2e3f4a
 
 package metadata
@@ -253,12 +253,6 @@ func BuildMetadataFromTag(m tag.Metadata, filePath string, metaLog logger.Logger
 	metadata.Narrator = cleanTagValue(narratorValue)
 	if metadata.Narrator != "" {
 		setFieldSource(fieldSources, "narrator", narratorSource)
-	} else if !authorFromArtist {
-		artistFallback := cleanTagValue(artistValue)
-		if artistFallback != "" && artistFallback != metadata.Artist {
-			metadata.Narrator = artistFallback
-			setFieldSource(fieldSources, "narrator", artistSource)
-		}
 	}
 
 	languageValue, languageSource := pickFirstNonEmpty(
@@ -318,6 +312,23 @@ func BuildMetadataFromTag(m tag.Metadata, filePath string, metaLog logger.Logger
 	if metadata.Series != "" {
 		setFieldSource(fieldSources, "series", seriesSource)
 	}
+
+	// User tag_field_mappings run after the dedicated tags but before the
+	// artist/album/title heuristics, so a mapped COMPOSER narrator or
+	// GROUPING series beats a guess.
+	applyTagFieldMappings(config.AppConfig.TagFieldMappings, &metadata, fieldSources, func(keys ...string) string {
+		return getRawString(raw, keys...)
+	})
+	if src := fieldSources["series_index"]; strings.HasPrefix(src, "mapping:") {
+		seriesIndexSource = src
+	}
+	if metadata.Narrator == "" && !authorFromArtist {
+		artistFallback := cleanTagValue(artistValue)
+		if artistFallback != "" && artistFallback != metadata.Artist {
+			metadata.Narrator = artistFallback
+			setFieldSource(fieldSources, "narrator", artistSource)
+		}
+	}
 	if metadata.Series == "" && strings.Contains(metadata.Album, " - ") {
 		parts := strings.Split(metadata.Album, " - ")
 		if len(parts) > 1 {
@@ -1,5 +1,5 @@
 // file: internal/metadata/openlibrary.go
-// version: 1.8.2
+// version: 1.9.0
 // guid: 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d
 
 package metadata
@@ -274,6 +274,16 @@ func (c *OpenLibraryClient) SearchByTitleAndAuthor(ctx context.Context, title, a
 	return results, nil
 }
 
+// GetByISBN implements Provider.
+func (c *OpenLibraryClient) GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
+	return c.GetBookByISBN(ctx, isbn)
+}
+
+// GetByASIN implements Provider. Open Library doesn't record ASINs.
+func (c *OpenLibraryClient) GetByASIN(ctx context.Context, asin string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
 // GetBookByISBN fetches book details by ISBN. Checks local dump store first if available.
 func (c *OpenLibraryClient) GetBookByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
 	if c.olStore != nil {
@@ -0,0 +1,247 @@
+// file: internal/metadata/path_pattern.go
+// version: 1.0.0
+// guid: 6c2e9a47-1d8b-4f35-b0e6-7a4f2c9d8e13
+// last-edited: 2026-10-17
+
+package metadata
+
+import (
+	"fmt"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PathFields are the book fields a PathPattern reads back out of a path.
+type PathFields struct {
+	Author   string
+	Series   string
+	Position float64 // 0 when the path carries no series number
+	Title    string
+	Narrator string
+	Year     int
+}
+
+// PathPattern parses folder paths laid out by a naming pattern such as
+// folder_naming_pattern ("{author}/{series}/{title} ({print_year})") back
+// into book fields. Segments and decorations the organizer drops when a
+// value is empty (a "{series}" folder, " ({print_year})", " - {narrator}")
+// are optional, so books without a series or year still match.
+type PathPattern struct {
+	pattern  string
+	variants []pathVariant
+}
+
+// pathVariant is the pattern with one choice of optional segments left
+// out; segs[i] matches the i-th folder of the path.
+type pathVariant struct {
+	segs []pathSegment
+}
+
+type pathSegment struct {
+	re    *regexp.Regexp
+	names []string // placeholder name per capture group
+}
+
+var pathPlaceholderRegex = regexp.MustCompile(`\{([A-Za-z0-9_]+)(?::[^}]*)?\}`)
+
+// requiredPathPlaceholders are never dropped by the organizer, so a
+// segment holding one of them is always present.
+var requiredPathPlaceholders = map[string]bool{
+	"title": true, "author": true, "authors": true, "first_author": true,
+}
+
+// CompilePathPattern compiles a naming pattern for parsing. The pattern
+// must name the title; unknown placeholders match any text and are
+// ignored.
+func CompilePathPattern(pattern string) (*PathPattern, error) {
+	pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern cannot be empty")
+	}
+	if !strings.Contains(pattern, "{title}") {
+		return nil, fmt.Errorf("pattern must contain {title}")
+	}
+	var segs []pathSegment
+	var optional []bool
+	for _, raw := range strings.Split(pattern, "/") {
+		seg, required, err := compilePathSegment(raw)
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, seg)
+		optional = append(optional, !required)
+	}
+
+	// One variant per subset of the optional segments, fullest first so a
+	// path with a series folder is never read as one without.
+	var droppable []int
+	for i, opt := range optional {
+		if opt {
+			droppable = append(droppable, i)
+		}
+	}
+	p := &PathPattern{pattern: pattern}
+	for drop := 0; drop <= len(droppable); drop++ {
+		for mask := 0; mask < 1<<len(droppable); mask++ {
+			if bits.OnesCount(uint(mask)) != drop {
+				continue
+			}
+			skip := map[int]bool{}
+			for b, idx := range droppable {
+				if mask&(1<<b) != 0 {
+					skip[idx] = true
+				}
+			}
+			var v pathVariant
+			for i, s := range segs {
+				if !skip[i] {
+					v.segs = append(v.segs, s)
+				}
+			}
+			p.variants = append(p.variants, v)
+		}
+	}
+	return p, nil
+}
+
+// String returns the pattern as compiled.
+func (p *PathPattern) String() string { return p.pattern }
+
+// Match parses relDir, a slash-separated folder path relative to the
+// library root. Folders below the ones the pattern describes (a "Disc 1"
+// under the book folder) are ignored. ok is false when no arrangement of
+// the pattern fits or the title comes out empty.
+func (p *PathPattern) Match(relDir string) (fields PathFields, ok bool) {
+	relDir = strings.Trim(relDir, "/")
+	if relDir == "" || relDir == "." {
+		return PathFields{}, false
+	}
+	parts := strings.Split(relDir, "/")
+	// The most specific fit wins: variants that use every folder of the
+	// path before those that leave sub-folders over.
+	for _, exact := range []bool{true, false} {
+		for _, v := range p.variants {
+			if len(v.segs) > len(parts) || (exact && len(v.segs) != len(parts)) {
+				continue
+			}
+			if f, ok := v.match(parts[:len(v.segs)]); ok {
+				return f, true
+			}
+		}
+	}
+	return PathFields{}, false
+}
+
+func (v pathVariant) match(parts []string) (PathFields, bool) {
+	values := map[string]string{}
+	for i, seg := range v.segs {
+		m := seg.re.FindStringSubmatch(parts[i])
+		if m == nil {
+			return PathFields{}, false
+		}
+		for g, name := range seg.names {
+			if val := strings.TrimSpace(m[g+1]); val != "" && values[name] == "" {
+				values[name] = val
+			}
+		}
+	}
+	f := PathFields{
+		Title:    values["title"],
+		Series:   values["series"],
+		Narrator: values["narrator"],
+	}
+	for _, key := range []string{"author", "first_author", "authors"} {
+		if values[key] != "" {
+			f.Author = values[key]
+			break
+		}
+	}
+	for _, key := range []string{"series_number", "series_num"} {
+		if n, err := strconv.ParseFloat(values[key], 64); err == nil && n > 0 {
+			f.Position = n
+			break
+		}
+	}
+	for _, key := range []string{"print_year", "year"} {
+		if n, err := strconv.Atoi(values[key]); err == nil {
+			f.Year = n
+			break
+		}
+	}
+	if f.Title == "" {
+		return PathFields{}, false
+	}
+	return f, true
+}
+
+// compilePathSegment turns one folder of the pattern into an anchored
+// regexp. A placeholder the organizer may leave empty takes its
+// decoration with it: "(...{x}...)", " - {x}" and "{x} - " become
+// optional groups. required reports whether the segment names a
+// placeholder that is never empty.
+func compilePathSegment(raw string) (seg pathSegment, required bool, err error) {
+	locs := pathPlaceholderRegex.FindAllStringSubmatchIndex(raw, -1)
+
+	// lits[i] is the literal text before placeholder i; the last entry
+	// follows the final placeholder.
+	lits := make([]string, len(locs)+1)
+	names := make([]string, len(locs))
+	prev := 0
+	for i, loc := range locs {
+		lits[i] = raw[prev:loc[0]]
+		names[i] = strings.ToLower(raw[loc[2]:loc[3]])
+		prev = loc[1]
+	}
+	lits[len(locs)] = raw[prev:]
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i, name := range names {
+		before, after := lits[i], lits[i+1]
+		capture := "(" + placeholderExpr(name) + ")"
+		if requiredPathPlaceholders[name] {
+			required = true
+			b.WriteString(regexp.QuoteMeta(before) + capture)
+			continue
+		}
+		open, closing := strings.LastIndex(before, "("), strings.Index(after, ")")
+		switch {
+		case open >= 0 && !strings.Contains(before[open:], ")") && closing >= 0:
+			b.WriteString(regexp.QuoteMeta(strings.TrimRight(before[:open], " ")))
+			b.WriteString(`(?:\s*\(` + regexp.QuoteMeta(before[open+1:]) + capture + regexp.QuoteMeta(after[:closing]) + `\))?`)
+			lits[i+1] = after[closing+1:]
+		case strings.HasSuffix(before, " - "):
+			b.WriteString(regexp.QuoteMeta(strings.TrimSuffix(before, " - ")))
+			b.WriteString(`(?: - ` + capture + `)?`)
+		case strings.HasPrefix(after, " - "):
+			b.WriteString(regexp.QuoteMeta(before))
+			b.WriteString(`(?:` + capture + ` - )?`)
+			lits[i+1] = strings.TrimPrefix(after, " - ")
+		default:
+			b.WriteString(regexp.QuoteMeta(before) + capture)
+		}
+	}
+	b.WriteString(regexp.QuoteMeta(lits[len(lits)-1]) + "$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return pathSegment{}, false, fmt.Errorf("invalid pattern segment %q: %w", raw, err)
+	}
+	if len(locs) == 0 {
+		required = true // a fixed folder name is always there
+	}
+	return pathSegment{re: re, names: names}, required, nil
+}
+
+// placeholderExpr is what one placeholder's value may look like.
+func placeholderExpr(name string) string {
+	switch name {
+	case "series_number", "series_num":
+		return `\d+(?:\.\d+)?`
+	case "print_year", "year":
+		return `\d{4}`
+	}
+	return `.+?`
+}
@@ -0,0 +1,74 @@
+// file: internal/metadata/path_pattern_test.go
+// version: 1.0.0
+// guid: 0b7d3e58-9a2c-4f61-8e14-5c3a6f9b2d70
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathPattern_Match(t *testing.T) {
+	p, err := CompilePathPattern("{author}/{series}/{title} ({print_year})")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		relDir string
+		want   PathFields
+		ok     bool
+	}{
+		{"full", "Frank Herbert/Dune Chronicles/Dune (1965)",
+			PathFields{Author: "Frank Herbert", Series: "Dune Chronicles", Title: "Dune", Year: 1965}, true},
+		{"no year", "Frank Herbert/Dune Chronicles/Dune",
+			PathFields{Author: "Frank Herbert", Series: "Dune Chronicles", Title: "Dune"}, true},
+		{"no series folder", "Andy Weir/Project Hail Mary (2021)",
+			PathFields{Author: "Andy Weir", Title: "Project Hail Mary", Year: 2021}, true},
+		{"parenthesised title keeps its text", "Iain Banks/Culture/Excession (Special Edition) (1996)",
+			PathFields{Author: "Iain Banks", Series: "Culture", Title: "Excession (Special Edition)", Year: 1996}, true},
+		{"too shallow", "Loose File", PathFields{}, false},
+		{"root", ".", PathFields{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := p.Match(tt.relDir)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPathPattern_SeriesNumberAndNarrator(t *testing.T) {
+	p, err := CompilePathPattern("{author}/{series}/{series_number} - {title} - {narrator}")
+	require.NoError(t, err)
+
+	got, ok := p.Match("Terry Pratchett/Discworld/01 - The Colour of Magic - Nigel Planer/Disc 1")
+	require.True(t, ok)
+	assert.Equal(t, PathFields{Author: "Terry Pratchett", Series: "Discworld", Position: 1,
+		Title: "The Colour of Magic", Narrator: "Nigel Planer"}, got)
+
+	got, ok = p.Match("Terry Pratchett/Discworld/2.5 - Mort")
+	require.True(t, ok)
+	assert.Equal(t, 2.5, got.Position)
+	assert.Equal(t, "Mort", got.Title)
+	assert.Empty(t, got.Narrator)
+}
+
+func TestPathPattern_IgnoresFoldersBelowTheBook(t *testing.T) {
+	p, err := CompilePathPattern("{author}/{title}")
+	require.NoError(t, err)
+
+	got, ok := p.Match("Andy Weir/The Martian/CD 1")
+	require.True(t, ok)
+	assert.Equal(t, PathFields{Author: "Andy Weir", Title: "The Martian"}, got)
+}
+
+func TestCompilePathPattern_Invalid(t *testing.T) {
+	_, err := CompilePathPattern("")
+	assert.Error(t, err)
+	_, err = CompilePathPattern("{author}/{series}")
+	assert.Error(t, err)
+}
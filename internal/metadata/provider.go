@@ -0,0 +1,139 @@
+// file: internal/metadata/provider.go
+// version: 1.0.0
+// guid: 6d2f8a41-b7c3-4e09-95a1-0c8e3f7b2d64
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/openlibrary"
+)
+
+// ErrNotSupported is returned by a Provider lookup the backing service
+// can't do, such as an ASIN lookup against Open Library.
+var ErrNotSupported = errors.New("metadata: lookup not supported by this provider")
+
+// Provider is a metadata source that can also fetch a book directly by
+// identifier. Every built-in source implements it; lookups a service has
+// no endpoint for return ErrNotSupported.
+type Provider interface {
+	MetadataSource
+	GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error)
+	GetByASIN(ctx context.Context, asin string) (*BookMetadata, error)
+}
+
+// ProviderConfig is what a ProviderFactory is built from: the
+// metadata_sources entry's credentials plus shared dependencies.
+type ProviderConfig struct {
+	// Credentials are the source's credentials. Callers fold top-level
+	// settings such as google_books_api_key in before building.
+	Credentials map[string]string
+	// OLStore is the local Open Library dump, if one is loaded.
+	OLStore *openlibrary.OLStore
+}
+
+// Credential returns the first non-empty credential among keys.
+func (c ProviderConfig) Credential(keys ...string) string {
+	for _, k := range keys {
+		if v := c.Credentials[k]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ProviderFactory builds a Provider. It returns an error when the
+// configuration can't produce a working provider, e.g. a missing token.
+type ProviderFactory func(cfg ProviderConfig) (Provider, error)
+
+type providerEntry struct {
+	name    string
+	factory ProviderFactory
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]providerEntry{}
+)
+
+// RegisterProvider makes a provider available to the source chain under
+// id, the value used in metadata_sources and metadata_field_priority.
+// name is what the provider's Name method returns, so results can be
+// traced back to their id. Registering an id again replaces it.
+func RegisterProvider(id, name string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[id] = providerEntry{name: name, factory: factory}
+}
+
+// NewProvider builds the provider registered under id.
+func NewProvider(id string, cfg ProviderConfig) (Provider, error) {
+	providersMu.RLock()
+	entry, ok := providers[id]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("metadata: unknown provider %q", id)
+	}
+	return entry.factory(cfg)
+}
+
+// ProviderIDs returns the registered provider ids, sorted.
+func ProviderIDs() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	ids := make([]string, 0, len(providers))
+	for id := range providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ProviderIDForName maps a provider's display name back to its id. Names
+// that aren't registered (test doubles, ad-hoc sources) map to
+// themselves, lowercased.
+func ProviderIDForName(name string) string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	for id, e := range providers {
+		if e.name == name {
+			return id
+		}
+	}
+	return strings.ToLower(name)
+}
+
+func init() {
+	RegisterProvider("audible", "Audible", func(ProviderConfig) (Provider, error) {
+		return NewAudibleClient(), nil
+	})
+	RegisterProvider("audnexus", "Audnexus (Audible)", func(ProviderConfig) (Provider, error) {
+		return NewAudnexusClient(), nil
+	})
+	RegisterProvider("openlibrary", "Open Library", func(cfg ProviderConfig) (Provider, error) {
+		client := NewOpenLibraryClient()
+		if cfg.OLStore != nil {
+			client.SetOLStore(cfg.OLStore)
+		}
+		return client, nil
+	})
+	RegisterProvider("google-books", "Google Books", func(cfg ProviderConfig) (Provider, error) {
+		return NewGoogleBooksClient(cfg.Credential("apiKey")), nil
+	})
+	RegisterProvider("hardcover", "Hardcover", func(cfg ProviderConfig) (Provider, error) {
+		token := cfg.Credential("api_token", "apiKey")
+		if token == "" {
+			return nil, errors.New("hardcover: no API token configured")
+		}
+		return NewHardcoverClient(token), nil
+	})
+	RegisterProvider("wikipedia", "Wikipedia", func(ProviderConfig) (Provider, error) {
+		return NewWikipediaClient(), nil
+	})
+}
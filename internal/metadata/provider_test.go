@@ -0,0 +1,137 @@
+// file: internal/metadata/provider_test.go
+// version: 1.0.0
+// guid: 9b3e6d10-5c4a-4f7e-8a2d-e1c07b94f56a
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderCompliance(t *testing.T) {
+	var _ Provider = (*AudibleClient)(nil)
+	var _ Provider = (*AudnexusClient)(nil)
+	var _ Provider = (*OpenLibraryClient)(nil)
+	var _ Provider = (*GoogleBooksClient)(nil)
+	var _ Provider = (*HardcoverClient)(nil)
+	var _ Provider = (*WikipediaClient)(nil)
+	var _ Provider = (*ProtectedSource)(nil)
+}
+
+func TestProviderRegistry(t *testing.T) {
+	assert.Equal(t, []string{"audible", "audnexus", "google-books", "hardcover", "openlibrary", "wikipedia"}, ProviderIDs())
+
+	for _, id := range ProviderIDs() {
+		p, err := NewProvider(id, ProviderConfig{Credentials: map[string]string{"api_token": "tok"}})
+		require.NoError(t, err, id)
+		assert.Equal(t, id, ProviderIDForName(p.Name()))
+	}
+	assert.Equal(t, "some source", ProviderIDForName("Some Source"))
+
+	_, err := NewProvider("hardcover", ProviderConfig{})
+	assert.Error(t, err)
+	_, err = NewProvider("goodreads", ProviderConfig{})
+	assert.ErrorContains(t, err, "unknown provider")
+
+	ctx := context.Background()
+	_, err = NewOpenLibraryClient().GetByASIN(ctx, "B000000000")
+	assert.ErrorIs(t, err, ErrNotSupported)
+	_, err = NewAudibleClient().GetByISBN(ctx, "9780765311788")
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+// stubSource is a MetadataSource without identifier lookups.
+type stubSource struct{}
+
+func (stubSource) Name() string { return "stub" }
+func (stubSource) SearchByTitle(context.Context, string) ([]BookMetadata, error) {
+	return nil, nil
+}
+func (stubSource) SearchByTitleAndAuthor(context.Context, string, string) ([]BookMetadata, error) {
+	return nil, nil
+}
+
+// stubProvider answers ASIN lookups with err.
+type stubProvider struct {
+	stubSource
+	err error
+}
+
+func (p stubProvider) GetByASIN(context.Context, string) (*BookMetadata, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &BookMetadata{Title: "Found"}, nil
+}
+func (stubProvider) GetByISBN(context.Context, string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
+func TestProtectedSourceLookups(t *testing.T) {
+	ctx := context.Background()
+
+	ps := NewProtectedSource(stubSource{}, 1, time.Minute)
+	_, err := ps.GetByASIN(ctx, "B0")
+	assert.ErrorIs(t, err, ErrNotSupported)
+
+	ps = NewProtectedSource(stubProvider{}, 1, time.Minute)
+	meta, err := ps.GetByASIN(ctx, "B0")
+	require.NoError(t, err)
+	assert.Equal(t, "Found", meta.Title)
+	_, err = ps.GetByISBN(ctx, "978")
+	assert.ErrorIs(t, err, ErrNotSupported)
+	assert.Equal(t, "closed", ps.Breaker().StateName(), "unsupported lookups don't count as failures")
+
+	ps = NewProtectedSource(stubProvider{err: errors.New("boom")}, 1, time.Minute)
+	_, err = ps.GetByASIN(ctx, "B0")
+	assert.Error(t, err)
+	assert.Equal(t, "open", ps.Breaker().StateName())
+}
+
+func TestMergeResults(t *testing.T) {
+	results := []ProviderResult{
+		{Provider: "audible", Rank: 0, Confidence: 0.9, Metadata: BookMetadata{
+			Title: "Mistborn", Narrator: "Michael Kramer", Series: "Mistborn", SeriesPosition: "1",
+		}},
+		{Provider: "openlibrary", Rank: 1, Confidence: 1, Metadata: BookMetadata{
+			Title: "Mistborn: The Final Empire", Description: "A long description", Series: "The Mistborn Saga",
+			PublishYear: 2006,
+		}},
+		{Provider: "google-books", Rank: 2, Confidence: 0.4, Metadata: BookMetadata{
+			Title: "Mistborn", Narrator: "Wrong Narrator", GoogleRatingAverage: 4.5, GoogleRatingCount: 10,
+		}},
+	}
+
+	merged, sources := MergeResults(results, nil)
+	// Rank discounts: audible 0.9, openlibrary 1/1.25 = 0.8.
+	assert.Equal(t, "Mistborn", merged.Title)
+	assert.Equal(t, "audible", sources["title"].Provider)
+	assert.InDelta(t, 0.9, sources["title"].Confidence, 1e-9)
+	assert.Equal(t, "Michael Kramer", merged.Narrator)
+	assert.Equal(t, "A long description", merged.Description)
+	assert.Equal(t, 2006, merged.PublishYear)
+	assert.Equal(t, "Mistborn", merged.Series)
+	assert.Equal(t, "1", merged.SeriesPosition, "series and position come from the same provider")
+	assert.Equal(t, 4.5, merged.GoogleRatingAverage)
+	assert.NotContains(t, sources, "asin")
+
+	merged, sources = MergeResults(results, map[string][]string{
+		"title":  {"openlibrary"},
+		"series": {"openlibrary", "audible"},
+	})
+	assert.Equal(t, "Mistborn: The Final Empire", merged.Title)
+	assert.Equal(t, "openlibrary", sources["title"].Provider)
+	assert.Equal(t, "The Mistborn Saga", merged.Series)
+	assert.Empty(t, merged.SeriesPosition)
+	assert.Equal(t, "audible", sources["narrator"].Provider)
+
+	// Keys accepted by metadata_field_priority must match the merge.
+	assert.Equal(t, config.MetadataMergeFields, MergeFieldNames())
+}
@@ -0,0 +1,75 @@
+// file: internal/metadata/series_sequence.go
+// version: 1.0.0
+// guid: 5d0e8a37-c1f4-4b92-a6e3-9f27b4c81d05
+// last-edited: 2026-10-17
+
+package metadata
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Where an inferred series position came from.
+const (
+	SeqSourceFilename = "filename"
+	SeqSourceFolder   = "folder"
+	SeqSourceTrack    = "track"
+)
+
+// SeriesSeqHint is a series position read off a file or folder name.
+type SeriesSeqHint struct {
+	// Value is the position as written ("03", "2.5"); callers normalize it
+	// with database.ParseSeriesSeq.
+	Value string `json:"value"`
+	// Source is one of the SeqSource constants.
+	Source string `json:"source"`
+	// Name is the file or folder name the position was read from.
+	Name string `json:"name"`
+}
+
+var (
+	// "Book 3", "Bk. 3", "Vol 2.5", "#4". Numeric only: roman numerals
+	// match too many ordinary words ("Book Club").
+	explicitSeqPattern = regexp.MustCompile(`(?i)(?:\bbook|\bbk|\bvol(?:ume)?|#)\.?\s*(\d{1,3}(?:\.\d{1,2})?)\b`)
+	// "03 - Title", "3. Title", "03_Title", "[03] Title", "(3) Title",
+	// and zero-padded "03 Title". Plain "100 Years…" needs a separator;
+	// four digits are a year, never a position.
+	leadingSeqPattern = regexp.MustCompile(`^\s*(?:\[(\d{1,3}(?:\.\d{1,2})?)\]|\((\d{1,3}(?:\.\d{1,2})?)\)|(\d{1,3}(?:\.\d{1,2})?)\s*[-–_.:]|(0\d{1,2})\s)\s*\S`)
+)
+
+// SeriesPositionFromName returns the series position a file or folder
+// name encodes, or "" when it has none. An explicit marker ("Book 3")
+// wins over a leading number.
+func SeriesPositionFromName(name string) string {
+	if m := explicitSeqPattern.FindStringSubmatch(name); m != nil {
+		return m[1]
+	}
+	if m := leadingSeqPattern.FindStringSubmatch(name); m != nil {
+		for _, g := range m[1:] {
+			if g != "" {
+				return g
+			}
+		}
+	}
+	return ""
+}
+
+// InferSeriesSequence reads a book's series position from its file name,
+// then from its folder name. fileName is empty for multi-file books,
+// whose file numbers are track order rather than series order.
+func InferSeriesSequence(fileName, dirName string) (SeriesSeqHint, bool) {
+	if fileName != "" {
+		stem := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		if v := SeriesPositionFromName(stem); v != "" {
+			return SeriesSeqHint{Value: v, Source: SeqSourceFilename, Name: fileName}, true
+		}
+	}
+	if dirName != "" {
+		if v := SeriesPositionFromName(dirName); v != "" {
+			return SeriesSeqHint{Value: v, Source: SeqSourceFolder, Name: dirName}, true
+		}
+	}
+	return SeriesSeqHint{}, false
+}
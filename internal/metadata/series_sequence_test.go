@@ -0,0 +1,49 @@
+// file: internal/metadata/series_sequence_test.go
+// version: 1.0.0
+// guid: 2e9b7c41-d6a8-4f03-b5e1-7c38a0f94d62
+
+package metadata
+
+import "testing"
+
+func TestSeriesPositionFromName(t *testing.T) {
+	for name, want := range map[string]string{
+		"03 - The Title":          "03",
+		"3. The Title":            "3",
+		"03_The_Title":            "03",
+		"[12] The Title":          "12",
+		"(2.5) A Novella":         "2.5",
+		"07 The Title":            "07",
+		"Mistborn Book 3":         "3",
+		"Dune, Vol. 2":            "2",
+		"The Expanse #4":          "4",
+		"Book 2.5 - Edgedancer":   "2.5",
+		"100 Years of Solitude":   "",
+		"1984":                    "",
+		"2019 - A Year in Review": "",
+		"The Book Club":           "",
+		"The Title":               "",
+	} {
+		if got := SeriesPositionFromName(name); got != want {
+			t.Errorf("SeriesPositionFromName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestInferSeriesSequence(t *testing.T) {
+	hint, ok := InferSeriesSequence("04 - Title.m4b", "Book 9")
+	if !ok || hint.Value != "04" || hint.Source != SeqSourceFilename {
+		t.Errorf("file name first: got %+v, %v", hint, ok)
+	}
+	hint, ok = InferSeriesSequence("Title.m4b", "02 - Title")
+	if !ok || hint.Value != "02" || hint.Source != SeqSourceFolder || hint.Name != "02 - Title" {
+		t.Errorf("folder fallback: got %+v, %v", hint, ok)
+	}
+	// The extension isn't part of the name ("Title.5.mp3" is not position 5).
+	if hint, ok = InferSeriesSequence("Title.5.mp3", ""); ok {
+		t.Errorf("expected no position, got %+v", hint)
+	}
+	if _, ok = InferSeriesSequence("", "Title"); ok {
+		t.Error("expected no position for an unnumbered folder")
+	}
+}
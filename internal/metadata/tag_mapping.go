@@ -0,0 +1,173 @@
+// file: internal/metadata/tag_mapping.go
+// version: 1.1.0
+// guid: b86e3595-0f22-4d0a-a6c8-bb997441235e
+// last-edited: 2026-10-17
+
+package metadata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// tagAliases maps the friendly names accepted in tag_field_mappings to the
+// raw keys each parser emits for them: ID3v2 frame IDs, MP4 atoms and
+// Vorbis/TagLib property names. Lookups are case-insensitive, so lowercase
+// Vorbis keys are covered by the uppercase TagLib names.
+var tagAliases = map[string][]string{
+	"composer":     {"TCOM", "©wrt", "\xa9wrt", "COMPOSER"},
+	"artist":       {"TPE1", "©ART", "\xa9ART", "ARTIST"},
+	"album_artist": {"TPE2", "aART", "ALBUMARTIST", "ALBUM_ARTIST", "ALBUM ARTIST"},
+	"conductor":    {"TPE3", "CONDUCTOR"},
+	"grouping":     {"TIT1", "GRP1", "©grp", "\xa9grp", "GROUPING"},
+	"comment":      {"COMM", "©cmt", "\xa9cmt", "COMMENT", "DESCRIPTION"},
+	"subtitle":     {"TIT3", "SUBTITLE"},
+}
+
+// expandMappingTag returns the raw keys to try for one configured tag.
+// Aliases expand to their raw keys; "TXXX:NAME" also tries the bare
+// description, which is how dhowden/tag and TagLib surface user frames.
+func expandMappingTag(t string) []string {
+	t = strings.TrimSpace(t)
+	if keys, ok := tagAliases[strings.ToLower(t)]; ok {
+		return keys
+	}
+	if desc, ok := strings.CutPrefix(t, "TXXX:"); ok && desc != "" {
+		return []string{t, desc}
+	}
+	return []string{t}
+}
+
+// tagMappingRuleName is the label a rule's hits are reported under,
+// e.g. "narrator<-composer,TXXX:READER".
+func tagMappingRuleName(m config.TagFieldMapping) string {
+	return m.Field + "<-" + strings.Join(m.Tags, ",")
+}
+
+// tagMappingHits counts, per rule, how many files had a field filled (or
+// overridden) by that rule since the last ResetTagMappingStats. The scan
+// service resets it at scan start and reports it in the completion summary.
+var (
+	tagMappingMu   sync.Mutex
+	tagMappingHits = map[string]int64{}
+)
+
+// TagMappingHit is one rule's hit count.
+type TagMappingHit struct {
+	Rule string `json:"rule"`
+	Hits int64  `json:"hits"`
+}
+
+// ResetTagMappingStats zeroes the per-rule hit counters.
+func ResetTagMappingStats() {
+	tagMappingMu.Lock()
+	defer tagMappingMu.Unlock()
+	tagMappingHits = map[string]int64{}
+}
+
+// TagMappingStats returns the rules that hit at least once since the last
+// ResetTagMappingStats, most hits first.
+func TagMappingStats() []TagMappingHit {
+	tagMappingMu.Lock()
+	defer tagMappingMu.Unlock()
+	out := make([]TagMappingHit, 0, len(tagMappingHits))
+	for rule, n := range tagMappingHits {
+		out = append(out, TagMappingHit{Rule: rule, Hits: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Hits != out[j].Hits {
+			return out[i].Hits > out[j].Hits
+		}
+		return out[i].Rule < out[j].Rule
+	})
+	return out
+}
+
+func recordTagMappingHit(rule string) {
+	tagMappingMu.Lock()
+	defer tagMappingMu.Unlock()
+	tagMappingHits[rule]++
+}
+
+// TagFieldMappingsKey fingerprints the configured tag_field_mappings, so
+// caches of parsed tags can tell results built under different rules
+// apart. Empty when no rules are configured.
+func TagFieldMappingsKey() string {
+	rules := config.AppConfig.TagFieldMappings
+	if len(rules) == 0 {
+		return ""
+	}
+	blob, err := json.Marshal(rules)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(blob)
+	return hex.EncodeToString(sum[:8])
+}
+
+// applyTagFieldMappings runs the configured tag_field_mappings against a
+// file's tags. lookup returns the first non-empty value among the given
+// raw keys. Each rule fills its field when empty, or replaces it when the
+// rule sets Override; the first rule to set a field wins over later ones.
+// fieldSources may be nil.
+func applyTagFieldMappings(rules []config.TagFieldMapping, md *Metadata, fieldSources map[string]string, lookup func(keys ...string) string) {
+	claimed := map[string]bool{}
+	for _, rule := range rules {
+		if claimed[rule.Field] {
+			continue
+		}
+		switch rule.Field {
+		case "narrator":
+			if md.Narrator != "" && !rule.Override {
+				continue
+			}
+		case "series":
+			if md.Series != "" && !rule.Override {
+				continue
+			}
+		case "series_index":
+			if md.SeriesIndex > 0 && !rule.Override {
+				continue
+			}
+		default:
+			continue
+		}
+
+		var value string
+		for _, t := range rule.Tags {
+			if value = cleanTagValue(lookup(expandMappingTag(t)...)); value != "" {
+				break
+			}
+		}
+		if value == "" {
+			continue
+		}
+
+		switch rule.Field {
+		case "narrator":
+			md.Narrator = value
+		case "series":
+			md.Series = value
+		case "series_index":
+			idx, err := strconv.Atoi(value)
+			if err != nil {
+				idx = DetectVolumeNumber(value)
+			}
+			if idx <= 0 {
+				continue
+			}
+			md.SeriesIndex = idx
+		}
+		name := tagMappingRuleName(rule)
+		claimed[rule.Field] = true
+		setFieldSource(fieldSources, rule.Field, "mapping:"+name)
+		recordTagMappingHit(name)
+	}
+}
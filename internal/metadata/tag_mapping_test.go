@@ -0,0 +1,112 @@
+// file: internal/metadata/tag_mapping_test.go
+// version: 1.0.0
+// guid: 67c7a2bb-8f95-4f89-b80f-13dbbf4013f8
+// last-edited: 2026-10-16
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/dhowden/tag"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+func withTagFieldMappings(t *testing.T, rules ...config.TagFieldMapping) {
+	t.Helper()
+	prev := config.AppConfig.TagFieldMappings
+	config.AppConfig.TagFieldMappings = rules
+	ResetTagMappingStats()
+	t.Cleanup(func() {
+		config.AppConfig.TagFieldMappings = prev
+		ResetTagMappingStats()
+	})
+}
+
+// TestApplyTagFieldMappings_RawID3 covers the dhowden raw shape: COMPOSER
+// as TCOM via the alias, and a user TXXX frame matched by description.
+func TestApplyTagFieldMappings_RawID3(t *testing.T) {
+	raw := map[string]interface{}{
+		"TCOM":   "Kate Reading",
+		"TXXX_1": &tag.Comm{Description: "BOOKNUM", Text: "Book 4"},
+		"TIT1":   "The Wheel of Time",
+	}
+	rules := []config.TagFieldMapping{
+		{Field: "narrator", Tags: []string{"composer"}},
+		{Field: "series_index", Tags: []string{"TXXX:BOOKNUM"}},
+		{Field: "series", Tags: []string{"grouping"}},
+	}
+	withTagFieldMappings(t)
+
+	md := Metadata{Series: "Album Guess"}
+	sources := map[string]string{}
+	applyTagFieldMappings(rules, &md, sources, func(keys ...string) string { return getRawString(raw, keys...) })
+
+	if md.Narrator != "Kate Reading" {
+		t.Errorf("narrator = %q, want Kate Reading", md.Narrator)
+	}
+	if md.SeriesIndex != 4 {
+		t.Errorf("series index = %d, want 4", md.SeriesIndex)
+	}
+	// Series was already set and the rule does not override.
+	if md.Series != "Album Guess" {
+		t.Errorf("series = %q, want Album Guess", md.Series)
+	}
+	if got := sources["narrator"]; got != "mapping:narrator<-composer" {
+		t.Errorf("narrator source = %q", got)
+	}
+
+	stats := TagMappingStats()
+	if len(stats) != 2 {
+		t.Fatalf("stats = %+v, want 2 rules", stats)
+	}
+	for _, h := range stats {
+		if h.Hits != 1 {
+			t.Errorf("rule %s hits = %d, want 1", h.Rule, h.Hits)
+		}
+	}
+}
+
+func TestApplyTagFieldMappings_OverrideAndFirstRuleWins(t *testing.T) {
+	tags := map[string]string{"GROUPING": "Discworld", "CONTENTGROUP": "Rincewind"}
+	lookup := func(keys ...string) string {
+		for _, k := range keys {
+			if v := tags[k]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	rules := []config.TagFieldMapping{
+		{Field: "series", Tags: []string{"grouping"}, Override: true},
+		{Field: "series", Tags: []string{"CONTENTGROUP"}, Override: true},
+	}
+	withTagFieldMappings(t)
+
+	md := Metadata{Series: "From Album"}
+	applyTagFieldMappings(rules, &md, nil, lookup)
+	if md.Series != "Discworld" {
+		t.Errorf("series = %q, want Discworld", md.Series)
+	}
+	stats := TagMappingStats()
+	if len(stats) != 1 || stats[0].Rule != "series<-grouping" {
+		t.Errorf("stats = %+v, want only series<-grouping", stats)
+	}
+}
+
+// TestBuildMetadataFromTaglibMap_TagFieldMappings checks the mapped
+// narrator beats the artist → narrator fallback.
+func TestBuildMetadataFromTaglibMap_TagFieldMappings(t *testing.T) {
+	withTagFieldMappings(t, config.TagFieldMapping{Field: "narrator", Tags: []string{"composer"}})
+
+	m := BuildMetadataFromTaglibMap(map[string][]string{
+		"TITLE":       {"Dune"},
+		"ALBUMARTIST": {"Frank Herbert"},
+		"ARTIST":      {"Full Cast"},
+		"COMPOSER":    {"Scott Brick"},
+	}, "/tmp/dune.m4b", nil)
+
+	if m.Narrator != "Scott Brick" {
+		t.Errorf("narrator = %q, want Scott Brick", m.Narrator)
+	}
+}
@@ -0,0 +1,115 @@
+// file: internal/metadata/tag_writer.go
+// version: 1.0.0
+// guid: 5c8e2a17-4d93-4f0b-b6a1-e07d9c3f2b58
+// last-edited: 2026-10-16
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/fileops"
+)
+
+// ErrTagWriteUnsupported is returned for files whose format the tag
+// writer can't edit.
+var ErrTagWriteUnsupported = errors.New("tag writing is not supported for this format")
+
+// BookTags are the book-level tags the tag writer keeps in sync with the
+// library: the book title (album), the track title, author, narrator and
+// series. Empty fields are left as they are in the file.
+type BookTags struct {
+	Album       string `json:"album"`
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	Narrator    string `json:"narrator"`
+	Series      string `json:"series"`
+	SeriesIndex string `json:"series_index"`
+}
+
+// TagChange is one tag a write would change.
+type TagChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// TagWriteSupported reports whether path is a format WriteBookTags can
+// edit (MP3, M4B/M4A, FLAC).
+func TagWriteSupported(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mp3", ".m4b", ".m4a", ".flac":
+		return true
+	}
+	return false
+}
+
+// PlanBookTags reads path's current tags and returns the changes writing
+// want would make, in a fixed field order. No changes means the file
+// already matches.
+func PlanBookTags(path string, want BookTags) ([]TagChange, error) {
+	if !TagWriteSupported(path) {
+		return nil, fmt.Errorf("%s: %w", filepath.Ext(path), ErrTagWriteUnsupported)
+	}
+	current, err := ExtractMetadata(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read tags: %w", err)
+	}
+	currentIndex := ""
+	if current.SeriesIndex > 0 {
+		currentIndex = strconv.Itoa(current.SeriesIndex)
+	}
+	fields := []struct{ name, old, new string }{
+		{"album", current.Album, want.Album},
+		{"title", current.Title, want.Title},
+		{"author", current.Artist, want.Author},
+		{"narrator", current.Narrator, want.Narrator},
+		{"series", current.Series, want.Series},
+		{"series_index", currentIndex, want.SeriesIndex},
+	}
+	var changes []TagChange
+	for _, f := range fields {
+		if f.new != "" && f.new != f.old {
+			changes = append(changes, TagChange{Field: f.name, Old: f.old, New: f.new})
+		}
+	}
+	return changes, nil
+}
+
+// WriteBookTags writes want's non-empty fields to path.
+func WriteBookTags(path string, want BookTags, cfg fileops.OperationConfig) error {
+	if !TagWriteSupported(path) {
+		return fmt.Errorf("%s: %w", filepath.Ext(path), ErrTagWriteUnsupported)
+	}
+	return WriteMetadataToFile(path, want.tagMap(), cfg)
+}
+
+// tagMap converts the tags to WriteMetadataToFile's input. The album is
+// always present: the writers blank it when a series is written without
+// one.
+func (t BookTags) tagMap() map[string]interface{} {
+	m := map[string]interface{}{"album": t.Album}
+	for key, v := range map[string]string{
+		"title":    t.Title,
+		"artist":   t.Author,
+		"narrator": t.Narrator,
+		"series":   t.Series,
+	} {
+		if v != "" {
+			m[key] = v
+		}
+	}
+	if t.SeriesIndex != "" {
+		// Whole positions go as ints, which every writer understands.
+		if n, err := strconv.Atoi(t.SeriesIndex); err == nil {
+			m["series_index"] = n
+		} else {
+			m["series_index"] = t.SeriesIndex
+		}
+	}
+	return m
+}
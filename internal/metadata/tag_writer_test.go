@@ -0,0 +1,52 @@
+// file: internal/metadata/tag_writer_test.go
+// version: 1.0.0
+// guid: 3a9f6c12-7e48-4d0b-91c5-b8e2d4f07a63
+
+package metadata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/fileops"
+)
+
+func TestTagWriteSupported(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/a/book.mp3":  true,
+		"/a/book.M4B":  true,
+		"/a/book.m4a":  true,
+		"/a/book.flac": true,
+		"/a/book.ogg":  false,
+		"/a/book":      false,
+	} {
+		if got := TagWriteSupported(path); got != want {
+			t.Errorf("TagWriteSupported(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestBookTags_TagMap(t *testing.T) {
+	m := BookTags{Album: "Endurance", Author: "Alfred Lansing", Series: "Polar", SeriesIndex: "3"}.tagMap()
+	want := map[string]interface{}{"album": "Endurance", "artist": "Alfred Lansing", "series": "Polar", "series_index": 3}
+	if len(m) != len(want) {
+		t.Fatalf("tagMap = %v, want %v", m, want)
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Errorf("tagMap[%q] = %v (%T), want %v", k, m[k], m[k], v)
+		}
+	}
+	if got := (BookTags{SeriesIndex: "1.5"}).tagMap()["series_index"]; got != "1.5" {
+		t.Errorf("decimal position = %v, want string 1.5", got)
+	}
+}
+
+func TestBookTags_Unsupported(t *testing.T) {
+	if _, err := PlanBookTags("/a/book.ogg", BookTags{Album: "x"}); !errors.Is(err, ErrTagWriteUnsupported) {
+		t.Errorf("PlanBookTags err = %v, want ErrTagWriteUnsupported", err)
+	}
+	if err := WriteBookTags("/a/book.txt", BookTags{Album: "x"}, fileops.DefaultConfig()); !errors.Is(err, ErrTagWriteUnsupported) {
+		t.Errorf("WriteBookTags err = %v, want ErrTagWriteUnsupported", err)
+	}
+}
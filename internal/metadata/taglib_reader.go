@@ -1,5 +1,5 @@
 // file: internal/metadata/taglib_reader.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 9e8d7c6b-5a4f-3e2d-1c0b-9a8b7c6d5e4f
 
 package metadata
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/logger"
 )
 
@@ -81,9 +82,6 @@ func BuildMetadataFromTaglibMap(tags map[string][]string, filePath string, metaL
 	// Narrator: dedicated fields first, then fall back to artist if
 	// artist wasn't already used for the author slot.
 	metadata.Narrator = get("NARRATOR", "PERFORMER", "READER", "TXXX:NARRATOR", "TXXX:PERFORMER")
-	if metadata.Narrator == "" && !authorFromArtist && artist != "" && artist != metadata.Artist {
-		metadata.Narrator = artist
-	}
 
 	metadata.Language = get("LANGUAGE")
 	metadata.Publisher = get("PUBLISHER", "LABEL")
@@ -106,6 +104,14 @@ func BuildMetadataFromTaglibMap(tags map[string][]string, filePath string, metaL
 			metadata.SeriesIndex = idx
 		}
 	}
+
+	// User tag_field_mappings, then the artist → narrator fallback; same
+	// ordering as BuildMetadataFromTag.
+	applyTagFieldMappings(config.AppConfig.TagFieldMappings, &metadata, nil, get)
+	if metadata.Narrator == "" && !authorFromArtist && artist != "" && artist != metadata.Artist {
+		metadata.Narrator = artist
+	}
+
 	if metadata.Series == "" && strings.Contains(metadata.Album, " - ") {
 		parts := strings.Split(metadata.Album, " - ")
 		if len(parts) > 1 {
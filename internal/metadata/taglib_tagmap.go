@@ -1,5 +1,5 @@
 // file: internal/metadata/taglib_tagmap.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: 8b9c0d1e-2f3a-4b5c-6d7e-8f9a0b1c2d3e
 //
 // Shared tag map builder used by both WASM and CGO taglib writers.
@@ -60,6 +60,8 @@ func buildWriteTagMap(metadata map[string]interface{}) map[string][]string {
 	}
 	if si, ok := metadata["series_index"].(int); ok && si > 0 {
 		tags["SERIES_INDEX"] = []string{fmt.Sprintf("%d", si)}
+	} else if si, ok := metadata["series_index"].(string); ok && si != "" {
+		tags["SERIES_INDEX"] = []string{si}
 	}
 
 	// Custom AUDIOBOOK_ORGANIZER_* tags
@@ -1,5 +1,5 @@
 // file: internal/metadata/wikipedia.go
-// version: 1.1.1
+// version: 1.2.0
 // guid: c3d4e5f6-a7b8-9c0d-1e2f-3a4b5c6d7e8f
 
 package metadata
@@ -108,6 +108,16 @@ func (c *WikipediaClient) SearchByTitleAndAuthor(ctx context.Context, title, aut
 	return c.search(ctx, query)
 }
 
+// GetByISBN implements Provider. Wikipedia has no identifier lookup.
+func (c *WikipediaClient) GetByISBN(ctx context.Context, isbn string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
+// GetByASIN implements Provider. Wikipedia has no identifier lookup.
+func (c *WikipediaClient) GetByASIN(ctx context.Context, asin string) (*BookMetadata, error) {
+	return nil, ErrNotSupported
+}
+
 func (c *WikipediaClient) search(ctx context.Context, query string) ([]BookMetadata, error) {
 	searchURL := fmt.Sprintf("%s?action=query&list=search&srsearch=%s&format=json&srlimit=5",
 		c.baseURL, url.QueryEscape(query))
@@ -1,7 +1,7 @@
 // file: internal/metafetch/service.go
-// version: 5.1.0
+// version: 5.3.0
 // guid: e5f6a7b8-c9d0-e1f2-a3b4-c5d6e7f8a9b0
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package metafetch
 
@@ -12,7 +12,6 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -52,6 +51,9 @@ type FetchMetadataResponse struct {
 	Source          string
 	FetchedCount    int
 	PendingCoverURL string // set by ApplyMetadataCandidate for background download
+	// FieldSources says which source supplied each field of a merged
+	// fetch; nil under the default first-match strategy.
+	FieldSources map[string]metadata.FieldSource
 }
 
 // MetadataCandidate represents a single search result for manual metadata matching.
@@ -391,7 +393,7 @@ func (mfs *Service) RunApplyPipelineRenameOnly(id string, book *database.Book) e
 			seriesName = series.Name
 		}
 		if book.SeriesSequence != nil {
-			seriesPos = strconv.Itoa(*book.SeriesSequence)
+			seriesPos = book.SeriesSequence.String()
 		}
 	}
 	year := 0
@@ -1,7 +1,7 @@
 // file: internal/metafetch/service_apply.go
-// version: 1.2.0
+// version: 1.5.0
 // guid: 6ca469ca-7d2e-4738-b6f1-ae09449ed9e4
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package metafetch
 
@@ -9,7 +9,9 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/entitymatch"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
 	"github.com/falkcorp/audiobook-organizer/internal/organizer"
 	"github.com/falkcorp/audiobook-organizer/internal/policy"
@@ -70,8 +72,14 @@ func (mfs *Service) ApplyMetadataToBook(book *database.Book, meta metadata.BookM
 			}
 		}
 	}
+	// Spelling variants of existing authors/series resolve to them rather
+	// than creating near-duplicates (entity_match_strictness).
+	resolver := entitymatch.NewResolver(&config.AppConfig, mfs.db)
 	if extractedAuthor != "" && !IsGarbageValue(extractedAuthor) {
 		author, err := mfs.db.GetAuthorByName(extractedAuthor)
+		if err == nil && author == nil {
+			author, err = resolver.MatchAuthor(extractedAuthor)
+		}
 		if err == nil && author == nil {
 			author, err = mfs.db.CreateAuthor(extractedAuthor)
 		}
@@ -111,16 +119,17 @@ func (mfs *Service) ApplyMetadataToBook(book *database.Book, meta metadata.BookM
 	// Apply series info if available
 	if meta.Series != "" && !IsGarbageValue(meta.Series) {
 		series, err := mfs.db.GetSeriesByName(meta.Series, book.AuthorID)
+		if err == nil && series == nil {
+			series, err = resolver.MatchSeries(meta.Series, book.AuthorID)
+		}
 		if err == nil && series == nil {
 			series, err = mfs.db.CreateSeries(meta.Series, book.AuthorID)
 		}
 		if err == nil && series != nil {
 			book.SeriesID = &series.ID
 		}
-		if meta.SeriesPosition != "" {
-			if pos, err := strconv.Atoi(meta.SeriesPosition); err == nil {
-				book.SeriesSequence = &pos
-			}
+		if pos := database.ParseSeriesSeq(meta.SeriesPosition); pos != nil {
+			book.SeriesSequence = pos
 		}
 	}
 }
@@ -156,7 +165,7 @@ func (mfs *Service) RecordChangeHistory(book *database.Book, meta metadata.BookM
 		{"publisher", derefString(book.Publisher), meta.Publisher},
 		{"language", derefString(book.Language), meta.Language},
 		{"series", currentSeries, meta.Series},
-		{"series_position", derefIntAsString(book.SeriesSequence), meta.SeriesPosition},
+		{"series_position", derefSeriesSeq(book.SeriesSequence), meta.SeriesPosition},
 		{"cover_url", derefString(book.CoverURL), meta.CoverURL},
 	}
 
@@ -560,6 +569,7 @@ func (mfs *Service) ApplyMetadataCandidate(id string, candidate MetadataCandidat
 						slog.Warn("cover art download failed for", "id", id, "error", coverErr)
 		} else {
 						slog.Info("cover art saved to", "path", coverPath)
+			covers.PublishCoverAsync(config.AppConfig.RootDir, coverPath)
 			localCoverURL := "/api/v1/covers/local/" + filepath.Base(coverPath)
 			if updatedBook != nil {
 				updatedBook.CoverURL = &localCoverURL
@@ -1,15 +1,17 @@
 // file: internal/metafetch/service_fetch.go
-// version: 1.2.0
+// version: 1.4.0
 // guid: b24c7a25-2efa-4b85-adb0-2d591218eff2
-// last-edited: 2026-05-05
+// last-edited: 2026-10-16
 
 package metafetch
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
 	"log/slog"
@@ -39,8 +41,10 @@ func (mfs *Service) queueISBNEnrichment(id string, book *database.Book) {
 	}(id)
 }
 
-// FetchMetadataForBook fetches and applies metadata for a single audiobook,
-// trying each configured source in priority order until one succeeds.
+// FetchMetadataForBook fetches and applies metadata for a single audiobook.
+// By default it tries each configured source in priority order until one
+// succeeds; with metadata_merge_strategy "merge" it combines all of them
+// field by field (see fetchMergedMetadata).
 func (mfs *Service) FetchMetadataForBook(id string) (*FetchMetadataResponse, error) {
 	book, err := mfs.db.GetBookByID(id)
 	if err != nil || book == nil {
@@ -80,174 +84,316 @@ func (mfs *Service) FetchMetadataForBook(id string) (*FetchMetadataResponse, err
 		currentNarrator = *book.Narrator
 	}
 
+	if config.AppConfig.MetadataMergeStrategy == "merge" {
+		return mfs.fetchMergedMetadata(book, sources, searchTitle, currentAuthor, currentNarrator)
+	}
+
 	var lastErr error
 	for _, src := range sources {
-		var results []metadata.BookMetadata
-		var searchErr error
-
-		// Check the persistent fetch cache before hitting the external API.
-		// The cache is shared with the search-dialog path — a bulk library
-		// fetch or a prior search dialog populates it, so a subsequent single-
-		// book fetch can return immediately without another network round-trip.
-		maxAge := time.Duration(config.AppConfig.MetadataFetchCacheTTLDays) * 24 * time.Hour
-		if cached, _, cerr := database.GetCachedMetadataFetchWithMaxAge(mfs.db, id, src.Name(), maxAge); cerr == nil && cached != nil {
-			var cachedResults []metadata.BookMetadata
-			if jerr := json.Unmarshal(cached.Results, &cachedResults); jerr == nil && len(cachedResults) > 0 {
-				results = cachedResults
-								slog.Debug("metadata-fetch cache HIT for ( ) — results, age", "id", id, "name", src.Name(), "count", len(cachedResults), "value", time.Since(cached.CachedAt).Round(time.Second))
-			}
+		results, searchErr := mfs.searchSourceForBook(book, src, searchTitle, currentAuthor, currentNarrator)
+		if searchErr != nil {
+			lastErr = searchErr
+		}
+		meta, _, ok := mfs.pickSourceMatch(book, src, results, searchTitle, currentAuthor, currentNarrator)
+		if !ok {
+			continue // try next source
 		}
+		return mfs.applyFetchedMetadata(book, meta, src.Name())
+	}
+	return nil, noMetadataFoundError(book, lastErr)
+}
 
-		if len(results) == 0 {
-			// Try the ContextualSearch path first if the source implements
-			// it. This hands richer context (ASIN, ISBN, narrator) to
-			// sources that can use it — Audnexus uses the ASIN for a direct
-			// lookup that works when title search can't, Hardcover uses
-			// the ISBN for a more precise match than the fuzzy GraphQL
-			// search. Sources that don't implement the interface just
-			// fall through to the title/author path below.
-			if ctxSearch, ok := src.(metadata.ContextualSearch); ok {
-				ctx := mfs.buildSearchContext(book, searchTitle, currentAuthor, currentNarrator)
-				results, searchErr = ctxSearch.SearchByContext(ctx)
-				if searchErr != nil {
-										slog.Warn("context search failed for", "name", src.Name(), "value", book.Title, "error", searchErr)
-					// Context search failure is non-fatal — fall through
-					// to the regular title/author path in case that works.
-				}
-			}
+// fetchMergedMetadata is FetchMetadataForBook under the "merge" strategy:
+// every source is searched, each one's best match is kept with its score,
+// and metadata.MergeResults picks every field from the source most
+// confident about it, honoring MetadataFieldPriority.
+func (mfs *Service) fetchMergedMetadata(
+	book *database.Book,
+	sources []metadata.MetadataSource,
+	searchTitle, currentAuthor, currentNarrator string,
+) (*FetchMetadataResponse, error) {
+	var results []metadata.ProviderResult
+	var names []string
+	var lastErr error
+	for rank, src := range sources {
+		found, searchErr := mfs.searchSourceForBook(book, src, searchTitle, currentAuthor, currentNarrator)
+		if searchErr != nil {
+			lastErr = searchErr
+		}
+		meta, score, ok := mfs.pickSourceMatch(book, src, found, searchTitle, currentAuthor, currentNarrator)
+		if !ok {
+			continue
+		}
+		results = append(results, metadata.ProviderResult{
+			Provider:   metadata.ProviderIDForName(src.Name()),
+			Rank:       rank,
+			Confidence: min(score, 1),
+			Metadata:   meta,
+		})
+		names = append(names, src.Name())
+	}
+	if len(results) == 0 {
+		return nil, noMetadataFoundError(book, lastErr)
+	}
 
-			// Try title+author search first for better match quality
-			if len(results) == 0 && currentAuthor != "" {
-				results, searchErr = src.SearchByTitleAndAuthor(context.Background(), searchTitle, currentAuthor)
-				if searchErr != nil {
-										slog.Warn("title+author search failed for by", "name", src.Name(), "value", searchTitle, "value", currentAuthor, "error", searchErr)
-				}
-			}
+	merged, fieldSources := metadata.MergeResults(results, config.AppConfig.MetadataFieldPriority)
+	// Name only the sources that contributed a field, in chain order.
+	used := make(map[string]bool, len(fieldSources))
+	for _, fs := range fieldSources {
+		used[fs.Provider] = true
+	}
+	var contributors []string
+	for i, r := range results {
+		if used[r.Provider] {
+			contributors = append(contributors, names[i])
+		}
+	}
+	resp, err := mfs.applyFetchedMetadata(book, merged, strings.Join(contributors, " + "))
+	if err != nil {
+		return nil, err
+	}
+	resp.FieldSources = fieldSources
+	return resp, nil
+}
 
-			// Fall back to title-only search
-			if len(results) == 0 {
-				results, searchErr = src.SearchByTitle(context.Background(), searchTitle)
-				if searchErr != nil {
-										slog.Warn("failed for", "name", src.Name(), "value", searchTitle, "error", searchErr)
-					lastErr = searchErr
-				}
-			}
+func noMetadataFoundError(book *database.Book, lastErr error) error {
+	if lastErr != nil {
+		return fmt.Errorf("no metadata found from any source (last error: %v)", lastErr)
+	}
+	return fmt.Errorf("no metadata found for '%s' from any source", book.Title)
+}
 
-			// Try original title if cleaned title returned nothing
-			if len(results) == 0 && searchTitle != book.Title {
-				results, searchErr = src.SearchByTitle(context.Background(), book.Title)
-				if searchErr != nil {
-					lastErr = searchErr
-					continue
-				}
-			}
+// searchSourceForBook runs one source's lookups for book, cheapest and
+// most precise first, stopping at the first that returns anything: the
+// fetch cache, an identifier lookup, ContextualSearch, title+author, then
+// title-only variants. Non-empty results are cached. The error is the
+// last title-search failure, for the caller's "no metadata" message.
+func (mfs *Service) searchSourceForBook(
+	book *database.Book,
+	src metadata.MetadataSource,
+	searchTitle, currentAuthor, currentNarrator string,
+) ([]metadata.BookMetadata, error) {
+	id := book.ID
+	var results []metadata.BookMetadata
+	var searchErr, lastErr error
+
+	// Check the persistent fetch cache before hitting the external API.
+	// The cache is shared with the search-dialog path — a bulk library
+	// fetch or a prior search dialog populates it, so a subsequent single-
+	// book fetch can return immediately without another network round-trip.
+	maxAge := time.Duration(config.AppConfig.MetadataFetchCacheTTLDays) * 24 * time.Hour
+	if cached, _, cerr := database.GetCachedMetadataFetchWithMaxAge(mfs.db, id, src.Name(), maxAge); cerr == nil && cached != nil {
+		var cachedResults []metadata.BookMetadata
+		if jerr := json.Unmarshal(cached.Results, &cachedResults); jerr == nil && len(cachedResults) > 0 {
+						slog.Debug("metadata-fetch cache HIT for ( ) — results, age", "id", id, "name", src.Name(), "count", len(cachedResults), "value", time.Since(cached.CachedAt).Round(time.Second))
+			return cachedResults, nil
+		}
+	}
 
-			// Try with subtitle stripped (e.g. "Title: Subtitle" → "Title")
-			if len(results) == 0 {
-				strippedTitle := stripSubtitle(searchTitle)
-				if strippedTitle != searchTitle && strippedTitle != book.Title {
-					results, searchErr = src.SearchByTitle(context.Background(), strippedTitle)
-					if searchErr != nil {
-						lastErr = searchErr
-						continue
-					}
-				}
-			}
+	// A direct ASIN/ISBN lookup beats any search when the book already
+	// carries an identifier and the provider has the endpoint.
+	results, triedIdentifier := mfs.lookupByIdentifier(book, src)
+
+	// Try the ContextualSearch path first if the source implements
+	// it. This hands richer context (ASIN, ISBN, narrator) to
+	// sources that can use it — Audnexus uses the ASIN for a direct
+	// lookup that works when title search can't, Hardcover uses
+	// the ISBN for a more precise match than the fuzzy GraphQL
+	// search. Sources that don't implement the interface just
+	// fall through to the title/author path below. When the
+	// identifier lookup already ran, the context search would only
+	// repeat it.
+	if ctxSearch, ok := src.(metadata.ContextualSearch); ok && len(results) == 0 && !triedIdentifier {
+		ctx := mfs.buildSearchContext(book, searchTitle, currentAuthor, currentNarrator)
+		results, searchErr = ctxSearch.SearchByContext(ctx)
+		if searchErr != nil {
+						slog.Warn("context search failed for", "name", src.Name(), "value", book.Title, "error", searchErr)
+			// Context search failure is non-fatal — fall through
+			// to the regular title/author path in case that works.
+		}
+	}
 
-			// Write non-empty results to cache so future fetch/search calls
-			// for this book+source can skip the external API entirely.
-			if len(results) > 0 {
-				if blob, merr := json.Marshal(results); merr == nil {
-					if perr := database.PutCachedMetadataFetch(mfs.db, id, src.Name(), blob, 0); perr != nil {
-												slog.Warn("metadata-fetch cache put failed for ( )", "id", id, "name", src.Name(), "error", perr)
-					}
-				}
-			}
+	// Try title+author search first for better match quality
+	if len(results) == 0 && currentAuthor != "" {
+		results, searchErr = src.SearchByTitleAndAuthor(context.Background(), searchTitle, currentAuthor)
+		if searchErr != nil {
+						slog.Warn("title+author search failed for by", "name", src.Name(), "value", searchTitle, "value", currentAuthor, "error", searchErr)
 		}
+	}
 
-		if len(results) == 0 {
-						slog.Debug("returned 0 results for", "name", src.Name(), "value", searchTitle)
+	// Fall back to title-only search
+	if len(results) == 0 {
+		results, searchErr = src.SearchByTitle(context.Background(), searchTitle)
+		if searchErr != nil {
+						slog.Warn("failed for", "name", src.Name(), "value", searchTitle, "error", searchErr)
+			lastErr = searchErr
 		}
-		if len(results) > 0 {
-			// Score all results and pick the best; reject if below quality threshold.
-			scored := mfs.bestTitleMatchForBook(book, results, currentAuthor, currentNarrator, searchTitle, book.Title)
-			if len(scored) == 0 {
-								slog.Debug("all results rejected by quality scorer for", "name", src.Name(), "count", len(results), "value", searchTitle)
-				continue // try next source
-			}
-			// Apply series position filter if the book's position is already known.
-			if book.SeriesSequence != nil {
-				scored = ApplySeriesPositionFilter(scored, *book.SeriesSequence)
-				if len(scored) == 0 {
-										slog.Debug("best result rejected by series position filter for", "name", src.Name(), "value", searchTitle)
-					continue
-				}
+	}
+
+	// Try original title if cleaned title returned nothing
+	if len(results) == 0 && searchTitle != book.Title {
+		results, searchErr = src.SearchByTitle(context.Background(), book.Title)
+		if searchErr != nil {
+			return nil, searchErr
+		}
+	}
+
+	// Try with subtitle stripped (e.g. "Title: Subtitle" → "Title")
+	if len(results) == 0 {
+		strippedTitle := stripSubtitle(searchTitle)
+		if strippedTitle != searchTitle && strippedTitle != book.Title {
+			results, searchErr = src.SearchByTitle(context.Background(), strippedTitle)
+			if searchErr != nil {
+				return nil, searchErr
 			}
-			meta := scored[0]
-			NormalizeMetaSeries(&meta)
+		}
+	}
 
-			// Safety: never apply empty/untitled metadata
-			if meta.Title == "" || strings.ToLower(meta.Title) == "untitled" {
-				meta.Title = book.Title // keep original
+	// Write non-empty results to cache so future fetch/search calls
+	// for this book+source can skip the external API entirely.
+	if len(results) > 0 {
+		if blob, merr := json.Marshal(results); merr == nil {
+			if perr := database.PutCachedMetadataFetch(mfs.db, id, src.Name(), blob, 0); perr != nil {
+										slog.Warn("metadata-fetch cache put failed for ( )", "id", id, "name", src.Name(), "error", perr)
 			}
+		}
+	}
+	return results, lastErr
+}
+
+// lookupByIdentifier asks a metadata.Provider for book by ASIN, then by
+// ISBN-13 and ISBN-10. The bool reports whether any lookup the provider
+// supports was attempted, hit or miss.
+func (mfs *Service) lookupByIdentifier(book *database.Book, src metadata.MetadataSource) ([]metadata.BookMetadata, bool) {
+	provider, ok := src.(metadata.Provider)
+	if !ok {
+		return nil, false
+	}
+	type lookup struct {
+		kind, value string
+		get         func(context.Context, string) (*metadata.BookMetadata, error)
+	}
+	lookups := []lookup{
+		{"asin", derefStr(book.ASIN), provider.GetByASIN},
+		{"isbn", derefStr(book.ISBN13), provider.GetByISBN},
+		{"isbn", derefStr(book.ISBN10), provider.GetByISBN},
+	}
+	tried := false
+	for _, l := range lookups {
+		if l.value == "" {
+			continue
+		}
+		meta, err := l.get(context.Background(), l.value)
+		if errors.Is(err, metadata.ErrNotSupported) {
+			continue
+		}
+		tried = true
+		if err != nil {
+			slog.Debug("identifier lookup failed", "name", src.Name(), "kind", l.kind, "value", l.value, "error", err)
+			continue
+		}
+		if meta != nil {
+			return []metadata.BookMetadata{*meta}, true
+		}
+	}
+	return nil, tried
+}
 
-			// Record history before applying changes
-			mfs.RecordChangeHistory(book, meta, src.Name())
+// pickSourceMatch scores one source's results against book and returns
+// the best acceptable match, normalized and ready to apply, with its
+// score. ok is false when every result was rejected.
+func (mfs *Service) pickSourceMatch(
+	book *database.Book,
+	src metadata.MetadataSource,
+	results []metadata.BookMetadata,
+	searchTitle, currentAuthor, currentNarrator string,
+) (meta metadata.BookMetadata, score float64, ok bool) {
+	if len(results) == 0 {
+				slog.Debug("returned 0 results for", "name", src.Name(), "value", searchTitle)
+		return meta, 0, false
+	}
+	// Score all results and pick the best; reject if below quality threshold.
+	best, score := mfs.bestTitleMatchIndexForBook(book, results, currentAuthor, currentNarrator, searchTitle, book.Title)
+	if best < 0 {
+				slog.Debug("all results rejected by quality scorer for", "name", src.Name(), "count", len(results), "value", searchTitle)
+		return meta, 0, false
+	}
+	scored := []metadata.BookMetadata{results[best]}
+	// Apply series position filter if the book's position is already known.
+	if book.SeriesSequence != nil {
+		scored = ApplySeriesPositionFilter(scored, *book.SeriesSequence)
+		if len(scored) == 0 {
+						slog.Debug("best result rejected by series position filter for", "name", src.Name(), "value", searchTitle)
+			return meta, 0, false
+		}
+	}
+	meta = scored[0]
+	NormalizeMetaSeries(&meta)
 
-			// Apply metadata with downgrade protection
-			mfs.ApplyMetadataToBook(book, meta)
+	// Safety: never apply empty/untitled metadata
+	if meta.Title == "" || strings.ToLower(meta.Title) == "untitled" {
+		meta.Title = book.Title // keep original
+	}
+	return meta, score, true
+}
 
-			updatedBook, updateErr := mfs.db.UpdateBook(id, book)
-			if updateErr != nil {
-				return nil, fmt.Errorf("failed to update book: %w", updateErr)
-			}
+// applyFetchedMetadata records history for, applies and persists meta on
+// book, then handles the follow-ups: cover download and embedding, tag
+// write-back and identifier enrichment.
+func (mfs *Service) applyFetchedMetadata(book *database.Book, meta metadata.BookMetadata, sourceName string) (*FetchMetadataResponse, error) {
+	id := book.ID
 
-			mfs.persistFetchedMetadata(id, meta)
-
-			// Download cover art locally if we got a cover URL
-			if meta.CoverURL != "" && config.AppConfig.RootDir != "" {
-				coverPath, coverErr := metadata.DownloadCoverArt(meta.CoverURL, config.AppConfig.RootDir, id)
-				if coverErr != nil {
-										slog.Warn("cover art download failed for", "id", id, "error", coverErr)
-				} else {
-										slog.Info("cover art saved to", "path", coverPath)
-					// Update book's cover_url to the local path for serving
-					localCoverURL := "/api/v1/covers/local/" + filepath.Base(coverPath)
-					if updatedBook != nil {
-						updatedBook.CoverURL = &localCoverURL
-						// Write the full book back — UpdateBook does full column
-						// replacement, so passing only CoverURL would wipe everything.
-						mfs.db.UpdateBook(id, updatedBook)
-					}
-					// Embed cover art into all audio files for this book
-					if updatedBook != nil {
-						mfs.embedCoverInBookFiles(updatedBook, coverPath)
-					}
-				}
-			}
+	// Record history before applying changes
+	mfs.RecordChangeHistory(book, meta, sourceName)
 
-			// Write metadata back to audio file(s) if enabled
-			if config.AppConfig.WriteBackMetadata {
-				mfs.writeBackMetadata(updatedBook, meta)
-			}
+	// Apply metadata with downgrade protection
+	mfs.ApplyMetadataToBook(book, meta)
 
-			// Queue background ISBN/ASIN enrichment if identifiers are missing
+	updatedBook, updateErr := mfs.db.UpdateBook(id, book)
+	if updateErr != nil {
+		return nil, fmt.Errorf("failed to update book: %w", updateErr)
+	}
+
+	mfs.persistFetchedMetadata(id, meta)
+
+	// Download cover art locally if we got a cover URL
+	if meta.CoverURL != "" && config.AppConfig.RootDir != "" {
+		coverPath, coverErr := metadata.DownloadCoverArt(meta.CoverURL, config.AppConfig.RootDir, id)
+		if coverErr != nil {
+								slog.Warn("cover art download failed for", "id", id, "error", coverErr)
+		} else {
+								slog.Info("cover art saved to", "path", coverPath)
+			covers.PublishCoverAsync(config.AppConfig.RootDir, coverPath)
+			// Update book's cover_url to the local path for serving
+			localCoverURL := "/api/v1/covers/local/" + filepath.Base(coverPath)
 			if updatedBook != nil {
-				mfs.queueISBNEnrichment(id, updatedBook)
+				updatedBook.CoverURL = &localCoverURL
+				// Write the full book back — UpdateBook does full column
+				// replacement, so passing only CoverURL would wipe everything.
+				mfs.db.UpdateBook(id, updatedBook)
+			}
+			// Embed cover art into all audio files for this book
+			if updatedBook != nil {
+				mfs.embedCoverInBookFiles(updatedBook, coverPath)
 			}
-
-			return &FetchMetadataResponse{
-				Message: "metadata fetched and applied",
-				Book:    updatedBook,
-				Source:  src.Name(),
-			}, nil
 		}
 	}
 
-	if lastErr != nil {
-		return nil, fmt.Errorf("no metadata found from any source (last error: %v)", lastErr)
+	// Write metadata back to audio file(s) if enabled
+	if config.AppConfig.WriteBackMetadata {
+		mfs.writeBackMetadata(updatedBook, meta)
 	}
-	return nil, fmt.Errorf("no metadata found for '%s' from any source", book.Title)
+
+	// Queue background ISBN/ASIN enrichment if identifiers are missing
+	if updatedBook != nil {
+		mfs.queueISBNEnrichment(id, updatedBook)
+	}
+
+	return &FetchMetadataResponse{
+		Message: "metadata fetched and applied",
+		Book:    updatedBook,
+		Source:  sourceName,
+	}, nil
 }
 
 // FetchMetadataForBookByTitle searches metadata sources using only the book's title,
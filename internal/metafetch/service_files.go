@@ -1,14 +1,15 @@
 // file: internal/metafetch/service_files.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: 969b284a-5657-442b-beba-275e325e000b
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package metafetch
 
 import (
+	"context"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
 	"github.com/falkcorp/audiobook-organizer/internal/fileops"
-	"github.com/falkcorp/audiobook-organizer/internal/metadata"
 	"log/slog"
 	"net/url"
 	"os"
@@ -85,7 +86,7 @@ func (mfs *Service) ApplyMetadataFileIO(id string) {
 
 	// Embed cover art into audio files (slow: ffmpeg)
 	if config.AppConfig.RootDir != "" {
-		mfs.embedCoverInBookFiles(book, metadata.CoverPathForBook(config.AppConfig.RootDir, id))
+		mfs.embedCoverInBookFiles(book, covers.CoverPathForBook(context.Background(), config.AppConfig.RootDir, id))
 	}
 
 	// Run file rename + tag write pipeline
@@ -1,7 +1,7 @@
 // file: internal/metafetch/service_mock_test.go
-// version: 1.1.1
+// version: 1.3.0
 // guid: c3d4e5f6-a7b8-9012-cdef-012345678901
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package metafetch
 
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
 )
@@ -575,7 +576,7 @@ func TestBuildFullTagMap(t *testing.T) {
 			GoogleBooksID:  &gbID,
 			Edition:        &edition,
 			PrintYear:      &printYear,
-			SeriesSequence: &seriesSeq,
+			SeriesSequence: database.NewSeriesSeq(seriesSeq),
 		}
 
 		tags := svc.BuildFullTagMap(book, "Album", "Track", "Artist", "Narrator", 2021, "1")
@@ -976,7 +977,7 @@ func TestApplyMetadataToBook(t *testing.T) {
 		assert.Equal(t, "Michael Kramer", *book.Narrator)
 		assert.Equal(t, 10, *book.AuthorID)
 		assert.Equal(t, 5, *book.SeriesID)
-		assert.Equal(t, 1, *book.SeriesSequence)
+		assert.Equal(t, database.SeriesSeq("1"), *book.SeriesSequence)
 		assert.Equal(t, "9781234567890", *book.ISBN13)
 		assert.Equal(t, "B01N5AZR76", *book.ASIN)
 		assert.Equal(t, "An epic fantasy", *book.Description)
@@ -1068,7 +1069,7 @@ func TestApplyMetadataToBook(t *testing.T) {
 		svc.ApplyMetadataToBook(book, meta)
 		assert.True(t, seriesCreated, "should create new series")
 		assert.Equal(t, 77, *book.SeriesID)
-		assert.Equal(t, 3, *book.SeriesSequence)
+		assert.Equal(t, database.SeriesSeq("3"), *book.SeriesSequence)
 	})
 }
 
@@ -1809,6 +1810,22 @@ func (m *mockMetadataSource) SearchByTitleAndAuthor(ctx context.Context, title,
 	return m.results, m.err
 }
 
+// mockMetadataProvider adds an ASIN lookup to mockMetadataSource.
+type mockMetadataProvider struct {
+	mockMetadataSource
+	byASIN map[string]metadata.BookMetadata
+}
+
+func (m *mockMetadataProvider) GetByASIN(ctx context.Context, asin string) (*metadata.BookMetadata, error) {
+	if meta, ok := m.byASIN[asin]; ok {
+		return &meta, nil
+	}
+	return nil, nil
+}
+func (m *mockMetadataProvider) GetByISBN(ctx context.Context, isbn string) (*metadata.BookMetadata, error) {
+	return nil, metadata.ErrNotSupported
+}
+
 // ---------------------------------------------------------------------------
 // FetchMetadataForBook
 // ---------------------------------------------------------------------------
@@ -1908,6 +1925,70 @@ func TestFetchMetadataForBook(t *testing.T) {
 		assert.Equal(t, "Mistborn", updatedBook.Title)
 	})
 
+	t.Run("identifier_lookup_preferred", func(t *testing.T) {
+		asin := "B002UZMLXM"
+		mock := &database.MockStore{
+			GetBookByIDFunc: func(id string) (*database.Book, error) {
+				return &database.Book{ID: id, Title: "Mistborn", ASIN: &asin}, nil
+			},
+			UpdateBookFunc: func(id string, book *database.Book) (*database.Book, error) {
+				return book, nil
+			},
+		}
+		svc := NewService(mock)
+		svc.SetOverrideSources([]metadata.MetadataSource{
+			&mockMetadataProvider{
+				mockMetadataSource: mockMetadataSource{
+					name:    "test-source",
+					results: []metadata.BookMetadata{{Title: "Mistborn", Publisher: "from search"}},
+				},
+				byASIN: map[string]metadata.BookMetadata{asin: {Title: "Mistborn", Publisher: "from asin"}},
+			},
+		})
+
+		resp, err := svc.FetchMetadataForBook("b1")
+		require.NoError(t, err)
+		require.NotNil(t, resp.Book.Publisher)
+		assert.Equal(t, "from asin", *resp.Book.Publisher)
+	})
+
+	t.Run("merge_strategy_picks_fields_per_source", func(t *testing.T) {
+		orig := config.AppConfig
+		t.Cleanup(func() { config.AppConfig = orig })
+		config.AppConfig.MetadataMergeStrategy = "merge"
+		config.AppConfig.MetadataFieldPriority = map[string][]string{"description": {"second"}}
+
+		mock := &database.MockStore{
+			GetBookByIDFunc: func(id string) (*database.Book, error) {
+				return &database.Book{ID: id, Title: "Mistborn"}, nil
+			},
+			UpdateBookFunc: func(id string, book *database.Book) (*database.Book, error) {
+				return book, nil
+			},
+		}
+		svc := NewService(mock)
+		svc.SetOverrideSources([]metadata.MetadataSource{
+			&mockMetadataSource{name: "first", results: []metadata.BookMetadata{
+				{Title: "Mistborn", Narrator: "Michael Kramer", Description: "short"},
+			}},
+			&mockMetadataSource{name: "second", results: []metadata.BookMetadata{
+				{Title: "Mistborn", Narrator: "Someone Else", Description: "long", Publisher: "Tor"},
+			}},
+			&mockMetadataSource{name: "third", results: nil},
+		})
+
+		resp, err := svc.FetchMetadataForBook("b1")
+		require.NoError(t, err)
+		assert.Equal(t, "first + second", resp.Source)
+		assert.Equal(t, "first", resp.FieldSources["narrator"].Provider)
+		assert.Equal(t, "second", resp.FieldSources["description"].Provider)
+		assert.Equal(t, "second", resp.FieldSources["publisher"].Provider)
+		require.NotNil(t, resp.Book.Narrator)
+		assert.Equal(t, "Michael Kramer", *resp.Book.Narrator)
+		require.NotNil(t, resp.Book.Description)
+		assert.Equal(t, "long", *resp.Book.Description)
+	})
+
 	t.Run("results_all_rejected_by_scorer", func(t *testing.T) {
 		mock := &database.MockStore{
 			GetBookByIDFunc: func(id string) (*database.Book, error) {
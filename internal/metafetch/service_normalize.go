@@ -1,12 +1,13 @@
 // file: internal/metafetch/service_normalize.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: eceba49a-b99f-476f-9d43-fd6fd39a8e24
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package metafetch
 
 import (
 	"encoding/json"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
 	"regexp"
 	"strconv"
@@ -25,6 +26,12 @@ func derefIntAsString(p *int) string {
 	}
 	return strconv.Itoa(*p)
 }
+func derefSeriesSeq(p *database.SeriesSeq) string {
+	if p == nil {
+		return ""
+	}
+	return p.String()
+}
 func jsonEncodeString(s string) string {
 	b, _ := json.Marshal(s)
 	return string(b)
@@ -1,7 +1,7 @@
 // file: internal/metafetch/service_scoring.go
-// version: 1.1.0
+// version: 1.3.0
 // guid: d2226468-bed1-4989-93f3-b0bc3a344424
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package metafetch
 
@@ -14,7 +14,6 @@ import (
 	"log/slog"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 )
 
@@ -265,6 +264,24 @@ func pickBestMatchFromScored(
 	bookAuthor, bookNarrator string,
 	bookDurationSec int,
 ) []metadata.BookMetadata {
+	if i, _ := bestScoredIndex(results, baseScores, baseTier, searchWords, bookAuthor, bookNarrator, bookDurationSec); i >= 0 {
+		return []metadata.BookMetadata{results[i]}
+	}
+	return nil
+}
+
+// bestScoredIndex is pickBestMatchFromScored with the winning index and
+// its final score exposed, for callers that weigh the match itself (the
+// per-field merge in fetchMergedMetadata). It returns -1 when no result
+// clears the threshold.
+func bestScoredIndex(
+	results []metadata.BookMetadata,
+	baseScores []float64,
+	baseTier string,
+	searchWords map[string]bool,
+	bookAuthor, bookNarrator string,
+	bookDurationSec int,
+) (int, float64) {
 	const f1MinScore = 0.35
 
 	minScore := f1MinScore
@@ -335,9 +352,9 @@ func pickBestMatchFromScored(
 	}
 
 	if bestIdx >= 0 && bestScore >= minScore {
-		return []metadata.BookMetadata{results[bestIdx]}
+		return bestIdx, bestScore
 	}
-	return nil
+	return -1, 0
 }
 
 // scoreOneResult computes a quality score in [0, ~1.15] for a single result
@@ -426,9 +443,23 @@ func (mfs *Service) bestTitleMatchForBook(
 	bookAuthor, bookNarrator string,
 	titles ...string,
 ) []metadata.BookMetadata {
+	if i, _ := mfs.bestTitleMatchIndexForBook(book, results, bookAuthor, bookNarrator, titles...); i >= 0 {
+		return []metadata.BookMetadata{results[i]}
+	}
+	return nil
+}
+
+// bestTitleMatchIndexForBook is bestTitleMatchForBook returning the index
+// of the winning result and its score, or -1 when nothing qualifies.
+func (mfs *Service) bestTitleMatchIndexForBook(
+	book *database.Book,
+	results []metadata.BookMetadata,
+	bookAuthor, bookNarrator string,
+	titles ...string,
+) (int, float64) {
 	// Union of significant words from all title variants. Needed by both
 	// the F1 fallback path (via scoreBaseCandidates) and by
-	// pickBestMatchFromScored for the length penalty.
+	// bestScoredIndex for the length penalty.
 	searchWords := map[string]bool{}
 	for _, t := range titles {
 		for w := range SignificantWords(t) {
@@ -441,7 +472,7 @@ func (mfs *Service) bestTitleMatchForBook(
 	if book.Duration != nil {
 		bookDurationSec = *book.Duration
 	}
-	return pickBestMatchFromScored(results, baseScores, baseTier, searchWords, bookAuthor, bookNarrator, bookDurationSec)
+	return bestScoredIndex(results, baseScores, baseTier, searchWords, bookAuthor, bookNarrator, bookDurationSec)
 }
 
 // rerankTopK asks the LLM scorer to re-judge the ambiguous top candidates
@@ -544,18 +575,19 @@ func (mfs *Service) RerankTopK(
 }
 
 // applySeriesPositionFilter rejects the top result if it claims a different
-// series position than the book's known position. If the result has no
-// SeriesPosition or the book has no known position, results pass through.
+// series position than the book's known position. Positions compare
+// numerically ("2.50" matches "2.5"). If the result has no SeriesPosition
+// or the book has no known position, results pass through.
 func ApplySeriesPositionFilter(
 	results []metadata.BookMetadata,
-	knownPosition int,
+	knownPosition database.SeriesSeq,
 ) []metadata.BookMetadata {
-	if len(results) == 0 || knownPosition <= 0 {
+	if len(results) == 0 || knownPosition == "" || knownPosition == "0" {
 		return results
 	}
-	wantPos := strconv.Itoa(knownPosition)
+	wantPos := knownPosition.String()
 	best := results[0]
-	if best.SeriesPosition != "" && best.SeriesPosition != wantPos {
+	if got := database.ParseSeriesSeq(best.SeriesPosition); got != nil && database.CompareSeriesSeq(got, &knownPosition) != 0 {
 				slog.Debug("scorer rejecting result (series position ! expected )", "value", best.Title, "value", best.SeriesPosition, "value", wantPos)
 		return nil
 	}
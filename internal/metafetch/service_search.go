@@ -1,7 +1,7 @@
 // file: internal/metafetch/service_search.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: bcba782a-8ed4-4285-be91-2af3eddc90e3
-// last-edited: 2026-05-05
+// last-edited: 2026-10-16
 
 package metafetch
 
@@ -65,49 +65,27 @@ func (mfs *Service) BuildSourceChain() []metadata.MetadataSource {
 		if !src.Enabled {
 			continue
 		}
-		var rawSource metadata.MetadataSource
+		cfg := metadata.ProviderConfig{Credentials: map[string]string{}, OLStore: mfs.olStore}
+		for k, v := range src.Credentials {
+			cfg.Credentials[k] = v
+		}
+		// Top-level settings win over the per-source credentials map.
 		switch src.ID {
-		case "openlibrary":
-			client := metadata.NewOpenLibraryClient()
-			if mfs.olStore != nil {
-				client.SetOLStore(mfs.olStore)
-			}
-			rawSource = client
 		case "google-books":
-			apiKey := config.AppConfig.GoogleBooksAPIKey
-			if apiKey == "" {
-				if k, ok := src.Credentials["apiKey"]; ok && k != "" {
-					apiKey = k
-				}
+			if k := config.AppConfig.GoogleBooksAPIKey; k != "" {
+				cfg.Credentials["apiKey"] = k
 			}
-			rawSource = metadata.NewGoogleBooksClient(apiKey)
-		case "audible":
-			rawSource = metadata.NewAudibleClient()
-		case "audnexus":
-			rawSource = metadata.NewAudnexusClient()
 		case "hardcover":
-			token := config.AppConfig.HardcoverAPIToken
-			if token == "" {
-				// Also check credentials map from metadata source config
-				if apiToken, ok := src.Credentials["api_token"]; ok && apiToken != "" {
-					token = apiToken
-				} else if apiKey, ok := src.Credentials["apiKey"]; ok && apiKey != "" {
-					token = apiKey
-				}
+			if k := config.AppConfig.HardcoverAPIToken; k != "" {
+				cfg.Credentials["api_token"] = k
 			}
-			if token != "" {
-				rawSource = metadata.NewHardcoverClient(token)
-			} else {
-								slog.Warn("Hardcover source enabled but no API token configured")
-			}
-		case "wikipedia":
-			rawSource = metadata.NewWikipediaClient()
-		default:
-						slog.Warn("Unknown metadata source", "id", src.ID)
 		}
-		if rawSource != nil {
-			chain = append(chain, metadata.NewProtectedSource(rawSource, 5, 30*time.Second))
+		provider, err := metadata.NewProvider(src.ID, cfg)
+		if err != nil {
+			slog.Warn("Metadata source unavailable", "id", src.ID, "error", err)
+			continue
 		}
+		chain = append(chain, metadata.NewProtectedSource(provider, 5, 30*time.Second))
 	}
 	return chain
 }
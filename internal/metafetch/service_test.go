@@ -1,5 +1,5 @@
 // file: internal/metafetch/service_test.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
 
 package metafetch
@@ -425,30 +425,30 @@ func TestApplySeriesPositionFilter(t *testing.T) {
 	}
 
 	t.Run("matching_position", func(t *testing.T) {
-		filtered := ApplySeriesPositionFilter(results, 1)
+		filtered := ApplySeriesPositionFilter(results, "1")
 		require.Len(t, filtered, 2)
 		assert.Equal(t, "Book One", filtered[0].Title)
 	})
 
 	t.Run("mismatched_position", func(t *testing.T) {
 		// First result has position "1" but we want "3"
-		filtered := ApplySeriesPositionFilter(results, 3)
+		filtered := ApplySeriesPositionFilter(results, "3")
 		assert.Nil(t, filtered)
 	})
 
 	t.Run("empty_position_passes", func(t *testing.T) {
 		noPos := []metadata.BookMetadata{{Title: "Unknown Position", SeriesPosition: ""}}
-		filtered := ApplySeriesPositionFilter(noPos, 1)
+		filtered := ApplySeriesPositionFilter(noPos, "1")
 		require.Len(t, filtered, 1)
 	})
 
 	t.Run("zero_known_position", func(t *testing.T) {
-		filtered := ApplySeriesPositionFilter(results, 0)
+		filtered := ApplySeriesPositionFilter(results, "")
 		assert.Len(t, filtered, 2, "zero knownPosition should return all results")
 	})
 
 	t.Run("empty_results", func(t *testing.T) {
-		filtered := ApplySeriesPositionFilter(nil, 1)
+		filtered := ApplySeriesPositionFilter(nil, "1")
 		assert.Nil(t, filtered)
 	})
 }
@@ -0,0 +1,146 @@
+// file: internal/metafetch/service_write_tags.go
+// version: 1.0.0
+// guid: e4a1c7b2-93d5-4f68-8a0e-2b6f1d9c5e37
+// last-edited: 2026-10-16
+
+package metafetch
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/fileops"
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+)
+
+// Per-file outcomes of WriteTagsForBook.
+const (
+	TagWriteUnchanged  = "unchanged"
+	TagWriteWritten    = "written"
+	TagWriteWouldWrite = "would_write"
+	TagWriteSkipped    = "skipped"
+	TagWriteFailed     = "error"
+)
+
+// TagWriteResult reports a tag write (or dry run) for one book.
+type TagWriteResult struct {
+	BookID string            `json:"book_id"`
+	DryRun bool              `json:"dry_run"`
+	Tags   metadata.BookTags `json:"tags"`
+	Files  []FileTagWrite    `json:"files"`
+	// Written counts files written; in a dry run, files that would be.
+	Written int `json:"written"`
+}
+
+// FileTagWrite is the outcome for one file.
+type FileTagWrite struct {
+	Path    string               `json:"path"`
+	Status  string               `json:"status"`
+	Changes []metadata.TagChange `json:"changes,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// WriteTagsForBook writes the book's title, author, narrator and series
+// from the database into the tags of each of its audio files, touching
+// only files whose tags differ. With dryRun nothing is written; the
+// result lists the changes a write would make.
+//
+// Unlike WriteBackMetadataForBook this writes only those core fields,
+// never renames, and skips protected (download-client owned) files
+// rather than importing a library copy first.
+func (mfs *Service) WriteTagsForBook(id string, dryRun bool) (*TagWriteResult, error) {
+	book, err := mfs.db.GetBookByID(id)
+	if err != nil || book == nil {
+		return nil, fmt.Errorf("audiobook not found: %s", id)
+	}
+
+	authors, narrators := mfs.bookCredits(id, book)
+	want := metadata.BookTags{Album: book.Title, Author: authors, Narrator: narrators}
+	if book.SeriesID != nil {
+		if series, serr := mfs.db.GetSeriesByID(*book.SeriesID); serr == nil && series != nil {
+			want.Series = series.Name
+		}
+	}
+	if want.Series != "" && book.SeriesSequence != nil {
+		want.SeriesIndex = book.SeriesSequence.String()
+	}
+
+	result := &TagWriteResult{BookID: id, DryRun: dryRun, Tags: want, Files: []FileTagWrite{}}
+	files := mfs.tagWriteFiles(book)
+	opConfig := fileops.OperationConfig{VerifyChecksums: true}
+	for _, f := range files {
+		fileTags := want
+		// One file is the whole book; segments keep their own titles.
+		fileTags.Title = f.title
+		if len(files) == 1 && fileTags.Title == "" {
+			fileTags.Title = book.Title
+		}
+		out := FileTagWrite{Path: f.path}
+		switch {
+		case !metadata.TagWriteSupported(f.path):
+			out.Status, out.Error = TagWriteSkipped, metadata.ErrTagWriteUnsupported.Error()
+		case mfs.isProtectedPath(f.path):
+			out.Status, out.Error = TagWriteSkipped, "protected path"
+		default:
+			out.Changes, err = metadata.PlanBookTags(f.path, fileTags)
+			switch {
+			case err != nil:
+				out.Status, out.Error = TagWriteFailed, err.Error()
+			case len(out.Changes) == 0:
+				out.Status = TagWriteUnchanged
+			case dryRun:
+				out.Status = TagWriteWouldWrite
+				result.Written++
+			default:
+				backupFileBeforeWrite(f.path)
+				opts := fileops.WriteTagsSafeOptions{BookFileID: f.bookFileID}
+				if f.bookFileID != "" {
+					opts.Store = mfs.db
+				}
+				if _, _, werr := fileops.WriteTagsSafe(f.path, func(tmpPath string) error {
+					return metadata.WriteBookTags(tmpPath, fileTags, opConfig)
+				}, opts); werr != nil {
+					slog.Warn("tag write failed", "book_id", id, "path", f.path, "error", werr)
+					out.Status, out.Error = TagWriteFailed, werr.Error()
+				} else {
+					out.Status = TagWriteWritten
+					result.Written++
+				}
+			}
+		}
+		result.Files = append(result.Files, out)
+	}
+	return result, nil
+}
+
+type tagWriteFile struct {
+	path       string
+	title      string
+	bookFileID string
+}
+
+// tagWriteFiles lists the audio files a tag write covers: the book's
+// present book_files, or its file_path (or the audio files inside it,
+// for a directory) when it has none.
+func (mfs *Service) tagWriteFiles(book *database.Book) []tagWriteFile {
+	var out []tagWriteFile
+	if bookFiles, err := mfs.db.GetBookFiles(book.ID); err == nil {
+		for _, bf := range bookFiles {
+			if !bf.Missing {
+				out = append(out, tagWriteFile{path: bf.FilePath, title: bf.Title, bookFileID: bf.ID})
+			}
+		}
+	}
+	if len(out) > 0 || book.FilePath == "" {
+		return out
+	}
+	if info, err := os.Stat(book.FilePath); err == nil && info.IsDir() {
+		for _, f := range AudioFilesInDir(book.FilePath) {
+			out = append(out, tagWriteFile{path: f})
+		}
+		return out
+	}
+	return []tagWriteFile{{path: book.FilePath}}
+}
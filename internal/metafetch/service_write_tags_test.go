@@ -0,0 +1,71 @@
+// file: internal/metafetch/service_write_tags_test.go
+// version: 1.0.0
+// guid: c83e5b17-2f9a-4d60-a4b1-6d07e9f2c358
+
+package metafetch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestWriteTagsForBook_ResolvesTagsAndSkips(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	author, err := store.CreateAuthor("Alfred Lansing")
+	require.NoError(t, err)
+	series, err := store.CreateSeries("Polar", &author.ID)
+	require.NoError(t, err)
+	narrator := "Simon Prebble"
+
+	dir := t.TempDir()
+	imports := filepath.Join(dir, "incoming")
+	require.NoError(t, os.MkdirAll(imports, 0o755))
+	_, err = store.CreateImportPath(imports, "incoming")
+	require.NoError(t, err)
+
+	book := &database.Book{
+		ID: "b1", Title: "Endurance", AuthorID: &author.ID, SeriesID: &series.ID,
+		SeriesSequence: database.NewSeriesSeq(2), Narrator: &narrator, FilePath: filepath.Join(dir, "endurance.ogg"),
+	}
+	_, err = store.CreateBook(book)
+	require.NoError(t, err)
+	for _, bf := range []database.BookFile{
+		{ID: "f1", BookID: "b1", FilePath: filepath.Join(dir, "endurance.ogg")},
+		{ID: "f2", BookID: "b1", FilePath: filepath.Join(imports, "endurance.mp3")},
+		{ID: "f3", BookID: "b1", FilePath: filepath.Join(dir, "gone.mp3"), Missing: true},
+	} {
+		require.NoError(t, store.CreateBookFile(&bf))
+	}
+
+	svc := NewService(store)
+	res, err := svc.WriteTagsForBook("b1", true)
+	require.NoError(t, err)
+	assert.True(t, res.DryRun)
+	assert.Equal(t, "Endurance", res.Tags.Album)
+	assert.Equal(t, "Alfred Lansing", res.Tags.Author)
+	assert.Equal(t, "Simon Prebble", res.Tags.Narrator)
+	assert.Equal(t, "Polar", res.Tags.Series)
+	assert.Equal(t, "2", res.Tags.SeriesIndex)
+
+	// The missing file is left out; the others are skipped with a reason.
+	require.Len(t, res.Files, 2)
+	statuses := map[string]string{}
+	for _, f := range res.Files {
+		statuses[filepath.Base(f.Path)] = f.Status
+		assert.NotEmpty(t, f.Error)
+	}
+	assert.Equal(t, map[string]string{"endurance.ogg": TagWriteSkipped, "endurance.mp3": TagWriteSkipped}, statuses)
+	assert.Zero(t, res.Written)
+
+	_, err = svc.WriteTagsForBook("nope", true)
+	assert.Error(t, err)
+}
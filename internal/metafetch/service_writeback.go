@@ -1,20 +1,21 @@
 // file: internal/metafetch/service_writeback.go
-// version: 1.2.0
+// version: 1.5.0
 // guid: fad73c11-30c2-4fdc-addd-45afef25d792
-// last-edited: 2026-06-10
+// last-edited: 2026-10-16
 
 package metafetch
 
 import (
+	"context"
 	"fmt"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/fileops"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
 	"log/slog"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -324,7 +325,13 @@ func (mfs *Service) BuildFullTagMap(
 		}
 	}
 	if book.SeriesSequence != nil {
-		tagMap["series_index"] = *book.SeriesSequence
+		// Whole positions stay ints (every writer understands those);
+		// decimal/label positions go through as strings.
+		if book.SeriesSequence.IsWhole() {
+			tagMap["series_index"] = book.SeriesSequence.Int()
+		} else {
+			tagMap["series_index"] = book.SeriesSequence.String()
+		}
 	}
 
 	// External provider IDs (written as AUDIOBOOK_ORGANIZER_* custom tags)
@@ -529,7 +536,7 @@ func (mfs *Service) runApplyPipeline(id string, book *database.Book) error {
 			seriesName = series.Name
 		}
 		if book.SeriesSequence != nil {
-			seriesPos = strconv.Itoa(*book.SeriesSequence)
+			seriesPos = book.SeriesSequence.String()
 		}
 	}
 
@@ -656,6 +663,38 @@ func (mfs *Service) runApplyPipeline(id string, book *database.Book) error {
 	return nil
 }
 
+// bookCredits returns the book's authors (", "-joined) and narrators
+// (" & "-joined) as written to tags, preferring the book_authors /
+// book_narrators associations over the book's own AuthorID and Narrator.
+func (mfs *Service) bookCredits(id string, book *database.Book) (authors, narrators string) {
+	var authorNames []string
+	bookAuthors, err := mfs.db.GetBookAuthors(id)
+	if err == nil && len(bookAuthors) > 0 {
+		for _, ba := range bookAuthors {
+			if author, aerr := mfs.db.GetAuthorByID(ba.AuthorID); aerr == nil && author != nil {
+				authorNames = append(authorNames, author.Name)
+			}
+		}
+	} else if book.AuthorID != nil {
+		if author, aerr := mfs.db.GetAuthorByID(*book.AuthorID); aerr == nil && author != nil {
+			authorNames = append(authorNames, author.Name)
+		}
+	}
+
+	var narratorNames []string
+	bookNarrators, err := mfs.db.GetBookNarrators(id)
+	if err == nil && len(bookNarrators) > 0 {
+		for _, bn := range bookNarrators {
+			if narrator, nerr := mfs.db.GetNarratorByID(bn.NarratorID); nerr == nil && narrator != nil {
+				narratorNames = append(narratorNames, narrator.Name)
+			}
+		}
+	} else if book.Narrator != nil && *book.Narrator != "" {
+		narratorNames = append(narratorNames, *book.Narrator)
+	}
+	return strings.Join(authorNames, ", "), strings.Join(narratorNames, " & ")
+}
+
 // WriteBackMetadataForBook reads current DB metadata for the book, resolves authors and
 // narrators, writes comprehensive tags to all active audio file segments, and records a
 // history entry. It is called by POST /api/v1/audiobooks/:id/write-back.
@@ -681,37 +720,10 @@ func (mfs *Service) WriteBackMetadataForBook(id string, segmentFilter ...[]strin
 		book = libCopy
 	}
 
-	// --- Resolve author names ---
+	// --- Resolve author and narrator names ---
 	// Use the original book's ID for author/narrator lookup since that's where
 	// ApplyMetadataCandidate stores the updated associations.
-	var authorNames []string
-	bookAuthors, err := mfs.db.GetBookAuthors(originalID)
-	if err == nil && len(bookAuthors) > 0 {
-		for _, ba := range bookAuthors {
-			if author, aerr := mfs.db.GetAuthorByID(ba.AuthorID); aerr == nil && author != nil {
-				authorNames = append(authorNames, author.Name)
-			}
-		}
-	} else if originalBook.AuthorID != nil {
-		if author, aerr := mfs.db.GetAuthorByID(*originalBook.AuthorID); aerr == nil && author != nil {
-			authorNames = append(authorNames, author.Name)
-		}
-	}
-	artistStr := strings.Join(authorNames, ", ")
-
-	// --- Resolve narrator names ---
-	var narratorNames []string
-	bookNarrators, err := mfs.db.GetBookNarrators(originalID)
-	if err == nil && len(bookNarrators) > 0 {
-		for _, bn := range bookNarrators {
-			if narrator, nerr := mfs.db.GetNarratorByID(bn.NarratorID); nerr == nil && narrator != nil {
-				narratorNames = append(narratorNames, narrator.Name)
-			}
-		}
-	} else if originalBook.Narrator != nil && *originalBook.Narrator != "" {
-		narratorNames = append(narratorNames, *originalBook.Narrator)
-	}
-	narratorStr := strings.Join(narratorNames, " & ")
+	artistStr, narratorStr := mfs.bookCredits(originalID, originalBook)
 
 	// --- Determine year ---
 	// Use original book's year since it has the freshly-applied metadata
@@ -758,7 +770,7 @@ func (mfs *Service) WriteBackMetadataForBook(id string, segmentFilter ...[]strin
 
 	// Embed cover art via TagLib (independent of tag writes — no ordering constraint).
 	if config.AppConfig.RootDir != "" {
-		mfs.embedCoverInBookFiles(book, metadata.CoverPathForBook(config.AppConfig.RootDir, book.ID))
+		mfs.embedCoverInBookFiles(book, covers.CoverPathForBook(context.Background(), config.AppConfig.RootDir, book.ID))
 	}
 
 	// Use the original book's title for tag content (it has freshly-applied metadata)
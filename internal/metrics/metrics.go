@@ -1,5 +1,5 @@
 // file: internal/metrics/metrics.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: 9f8e7d6c-5b4a-3210-9fed-cba876543210
 
 package metrics
@@ -102,6 +102,40 @@ var (
 		Buckets:   prometheus.ExponentialBuckets(0.0000005, 4, 10), // 500ns up to ~130ms
 	}, []string{"cache"})
 
+	// Per import path and per library root metrics. The {path} and {root}
+	// labels are configured directories, so cardinality is bounded by the
+	// configuration, not the library's size.
+	importPathBooks = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "audiobook_organizer",
+		Name:      "import_path_books",
+		Help:      "Number of books recorded under each import path at its last scan",
+	}, []string{"path"})
+	importPathLastScanAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "audiobook_organizer",
+		Name:      "import_path_last_scan_age_seconds",
+		Help:      "Seconds since each import path was last scanned; absent for paths never scanned",
+	}, []string{"path"})
+	importPathHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "audiobook_organizer",
+		Name:      "import_path_healthy",
+		Help:      "1 when an import path's last pre-scan check passed (or it was never checked), 0 when it failed",
+	}, []string{"path"})
+	importPathScanErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "audiobook_organizer",
+		Name:      "import_path_scan_errors_total",
+		Help:      "Total folder scans of each import path that failed or were skipped as unhealthy",
+	}, []string{"path"})
+	libraryBooks = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "audiobook_organizer",
+		Name:      "library_books",
+		Help:      "Number of books under each library root",
+	}, []string{"library", "root"})
+	librarySizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "audiobook_organizer",
+		Name:      "library_size_bytes",
+		Help:      "Recorded size in bytes of the books under each library root",
+	}, []string{"library", "root"})
+
 	// itunesLocationUnmappable counts iTunes writeback location values that could
 	// NOT be normalized into a valid 0x0B/0x0D LocationPair and were therefore
 	// SKIPPED (never written raw — CRIT-2). The {reason} label is a small enum
@@ -121,6 +155,7 @@ func Register() {
 		prometheus.MustRegister(operationStarted, operationCompleted, operationFailed, operationCanceled, operationDuration,
 			booksGauge, foldersGauge, memoryAllocGauge, goroutinesGauge,
 			cacheHits, cacheMisses, cacheSets, cacheInvalidations, cacheEvictions, cacheSize, cacheGetDuration,
+			importPathBooks, importPathLastScanAge, importPathHealthy, importPathScanErrors, libraryBooks, librarySizeBytes,
 			itunesLocationUnmappable)
 	})
 }
@@ -159,3 +194,53 @@ func SetCacheSize(cache string, n int)         { cacheSize.WithLabelValues(cache
 func ObserveCacheGetDuration(cache string, d time.Duration) {
 	cacheGetDuration.WithLabelValues(cache).Observe(d.Seconds())
 }
+
+// ImportPathStat is one import path's state for the per-path gauges.
+type ImportPathStat struct {
+	Path    string
+	Books   int
+	Healthy bool
+	// LastScan is zero when the path was never scanned.
+	LastScan time.Time
+}
+
+// SetImportPathStats replaces the per-path gauges with stats, dropping
+// paths no longer configured. Last-scan ages are measured from now.
+func SetImportPathStats(stats []ImportPathStat, now time.Time) {
+	importPathBooks.Reset()
+	importPathLastScanAge.Reset()
+	importPathHealthy.Reset()
+	for _, st := range stats {
+		importPathBooks.WithLabelValues(st.Path).Set(float64(st.Books))
+		healthy := 0.0
+		if st.Healthy {
+			healthy = 1
+		}
+		importPathHealthy.WithLabelValues(st.Path).Set(healthy)
+		if !st.LastScan.IsZero() {
+			importPathLastScanAge.WithLabelValues(st.Path).Set(now.Sub(st.LastScan).Seconds())
+		}
+	}
+}
+
+// RecordImportPathScanError counts a failed or skipped scan of an import
+// path.
+func RecordImportPathScanError(path string) { importPathScanErrors.WithLabelValues(path).Inc() }
+
+// LibraryStat totals the books under one library root.
+type LibraryStat struct {
+	Library string
+	Root    string
+	Books   int
+	Bytes   int64
+}
+
+// SetLibraryStats replaces the per-library gauges with stats.
+func SetLibraryStats(stats []LibraryStat) {
+	libraryBooks.Reset()
+	librarySizeBytes.Reset()
+	for _, st := range stats {
+		libraryBooks.WithLabelValues(st.Library, st.Root).Set(float64(st.Books))
+		librarySizeBytes.WithLabelValues(st.Library, st.Root).Set(float64(st.Bytes))
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/metrics/metrics_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 5e6f7a8b-9c0d-1e2f-3a4b-5c6d7e8f9a0b
 
 package metrics
@@ -7,6 +7,9 @@ package metrics
 import (
 	"testing"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 func TestRegister(t *testing.T) {
@@ -235,3 +238,80 @@ func TestOperationLifecycle_Canceled(t *testing.T) {
 
 	t.Log("Successfully recorded canceled operation")
 }
+
+func TestSetImportPathStats(t *testing.T) {
+	Register()
+
+	now := time.Now()
+	SetImportPathStats([]ImportPathStat{
+		{Path: "/imports/a", Books: 3, Healthy: true, LastScan: now.Add(-time.Minute)},
+		{Path: "/imports/b"},
+	}, now)
+	if got := metricValue(t, importPathBooks.WithLabelValues("/imports/a")); got != 3 {
+		t.Errorf("books = %v, want 3", got)
+	}
+	if got := metricValue(t, importPathLastScanAge.WithLabelValues("/imports/a")); got != 60 {
+		t.Errorf("last scan age = %v, want 60", got)
+	}
+	if got := metricValue(t, importPathHealthy.WithLabelValues("/imports/b")); got != 0 {
+		t.Errorf("healthy = %v, want 0", got)
+	}
+	if n := seriesCount(importPathLastScanAge); n != 1 {
+		t.Errorf("last scan age series = %d, want 1 (never-scanned paths are absent)", n)
+	}
+
+	// A removed path drops out on the next refresh.
+	SetImportPathStats([]ImportPathStat{{Path: "/imports/b"}}, now)
+	if n := seriesCount(importPathBooks); n != 1 {
+		t.Errorf("books series = %d, want 1", n)
+	}
+}
+
+func TestRecordImportPathScanError(t *testing.T) {
+	Register()
+
+	before := metricValue(t, importPathScanErrors.WithLabelValues("/imports/err"))
+	RecordImportPathScanError("/imports/err")
+	if got := metricValue(t, importPathScanErrors.WithLabelValues("/imports/err")); got != before+1 {
+		t.Errorf("scan errors = %v, want %v", got, before+1)
+	}
+}
+
+func TestSetLibraryStats(t *testing.T) {
+	Register()
+
+	SetLibraryStats([]LibraryStat{{Library: "default", Root: "/library", Books: 2, Bytes: 4096}})
+	if got := metricValue(t, libraryBooks.WithLabelValues("default", "/library")); got != 2 {
+		t.Errorf("library books = %v, want 2", got)
+	}
+	if got := metricValue(t, librarySizeBytes.WithLabelValues("default", "/library")); got != 4096 {
+		t.Errorf("library size = %v, want 4096", got)
+	}
+}
+
+// metricValue reads a single gauge or counter's current value.
+func metricValue(t *testing.T, m prometheus.Metric) float64 {
+	t.Helper()
+	var out dto.Metric
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("write metric: %v", err)
+	}
+	if out.Gauge != nil {
+		return out.Gauge.GetValue()
+	}
+	return out.Counter.GetValue()
+}
+
+// seriesCount returns how many labeled series c currently exports.
+func seriesCount(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
@@ -1,7 +1,7 @@
 // file: internal/operations/registry/dispatcher.go
-// version: 2.0.1
+// version: 2.2.0
 // guid: a7b8c9d0-e1f2-3a4b-5c6d-7e8f9a0b1c2d
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package registry
 
@@ -13,7 +13,8 @@ import (
 
 // runDispatcher is the central dispatch loop. It ticks every 100ms or
 // on a signal, walks queued ops in priority DESC / queued_at ASC order,
-// and dispatches eligible ones to the worker pool.
+// and dispatches eligible ones to the worker pool. It does nothing while
+// the queue is paused.
 func (r *Registry) runDispatcher(ctx context.Context) {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
@@ -33,14 +34,17 @@ func (r *Registry) runDispatcher(ctx context.Context) {
 
 // dispatchCycle walks all queued ops and sends eligible ones to nextRun.
 func (r *Registry) dispatchCycle(ctx context.Context) {
-	if r.shuttingDown.Load() {
+	if r.shuttingDown.Load() || r.paused.Load() {
 		return
 	}
+	limits := r.limits()
 	queued, err := r.store.ListQueuedOperationsV2()
 	if err != nil {
 		r.logger.Warn("registry: list queued ops failed", "error", err)
 		return
 	}
+	r.pruneLeaseWaits(queued)
+	var pending []pendingLease
 
 	for _, row := range queued {
 		if ctx.Err() != nil {
@@ -63,6 +67,17 @@ func (r *Registry) dispatchCycle(ctx context.Context) {
 			continue
 		}
 
+		// Gate 0b: global max_concurrent. Nothing later in the queue
+		// can start either, so stop the cycle.
+		if limits.MaxConcurrent > 0 {
+			r.mu.RLock()
+			full := len(r.running) >= limits.MaxConcurrent
+			r.mu.RUnlock()
+			if full {
+				return
+			}
+		}
+
 		// Gate 1: def must be registered.
 		r.mu.RLock()
 		def, ok := r.defs[row.DefID]
@@ -81,6 +96,20 @@ func (r *Registry) dispatchCycle(ctx context.Context) {
 			continue
 		}
 
+		// Gate 2a: worker pool max_concurrent.
+		poolMax := 0
+		if def.Pool != "" {
+			poolMax = limits.Pools[def.Pool]
+		}
+		if poolMax > 0 {
+			r.mu.RLock()
+			poolFull := r.poolRunningLocked(def.Pool) >= poolMax
+			r.mu.RUnlock()
+			if poolFull {
+				continue
+			}
+		}
+
 		// Gate 2b: abandoned goroutine cap.
 		if r.abandoned.isBlocked(def.Plugin) {
 			r.logger.Warn("registry: plugin blocked due to abandoned goroutines; skipping dispatch",
@@ -103,6 +132,23 @@ func (r *Registry) dispatchCycle(ctx context.Context) {
 			continue
 		}
 
+		// Gate 5: path leases — no running op, and no conflicting run
+		// queued ahead of this one, may hold an overlapping lease.
+		leases := r.runLeases(def, json.RawMessage(row.Params))
+		if len(leases) > 0 {
+			b, blocked := pendingLeaseBlocker(pending, leases)
+			if !blocked {
+				r.mu.RLock()
+				b, blocked = r.leaseBlockerLocked(row.ID, leases)
+				r.mu.RUnlock()
+			}
+			if blocked {
+				pending = append(pending, pendingLease{opID: row.ID, defID: row.DefID, leases: leases})
+				r.noteLeaseWait(row.ID, b)
+				continue
+			}
+		}
+
 		// All gates passed — claim and dispatch.
 		r.mu.Lock()
 		// Re-check under write lock to avoid TOCTOU.
@@ -116,6 +162,18 @@ func (r *Registry) dispatchCycle(ctx context.Context) {
 			r.mu.Unlock()
 			continue
 		}
+		if limits.MaxConcurrent > 0 && len(r.running) >= limits.MaxConcurrent {
+			r.mu.Unlock()
+			return
+		}
+		if poolMax > 0 && r.poolRunningLocked(def.Pool) >= poolMax {
+			r.mu.Unlock()
+			continue
+		}
+		if _, blocked := r.leaseBlockerLocked(row.ID, leases); blocked {
+			r.mu.Unlock()
+			continue
+		}
 		if def.ConcurrencyKey != "" {
 			if holder, held := r.concurrencyKeys[def.ConcurrencyKey]; held && holder != row.ID {
 				r.mu.Unlock()
@@ -131,10 +189,13 @@ func (r *Registry) dispatchCycle(ctx context.Context) {
 			id:             row.ID,
 			defID:          row.DefID,
 			plugin:         def.Plugin,
+			pool:           def.Pool,
 			concurrencyKey: def.ConcurrencyKey,
 			resumePolicy:   def.ResumePolicy,
+			leases:         leases,
 		}
 		r.mu.Unlock()
+		r.clearLeaseWait(row.ID)
 
 		qr := &queuedRun{
 			opID:         row.ID,
@@ -143,7 +204,9 @@ func (r *Registry) dispatchCycle(ctx context.Context) {
 			priority:     Priority(row.Priority),
 			concurrKey:   def.ConcurrencyKey,
 			plugin:       def.Plugin,
+			pool:         def.Pool,
 			resumePolicy: def.ResumePolicy,
+			leases:       leases,
 		}
 
 		select {
@@ -0,0 +1,33 @@
+// file: internal/operations/registry/freezer.go
+// version: 1.0.0
+// guid: 3c9e71b4-5a08-4f2d-b6e3-d17a4c0f9e58
+// last-edited: 2026-10-16
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Freezer snapshots library state before a Destructive op runs. Freeze is
+// called synchronously on the worker, after the op is marked running and
+// before Run; it must be idempotent because a resumed run calls it again
+// with the same opID.
+type Freezer interface {
+	Freeze(ctx context.Context, opID, defID string, params json.RawMessage) error
+}
+
+// SetFreezer wires the pre-run snapshot hook for Destructive ops. Safe to
+// call with nil, which disables snapshots.
+func (r *Registry) SetFreezer(f Freezer) {
+	r.mu.Lock()
+	r.freezer = f
+	r.mu.Unlock()
+}
+
+func (r *Registry) currentFreezer() Freezer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.freezer
+}
@@ -0,0 +1,122 @@
+// file: internal/operations/registry/freezer_test.go
+// version: 1.0.0
+// guid: 8b2f64d1-c7a3-4e95-90d8-5e1a3b7c6f24
+// last-edited: 2026-10-16
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+type recordingFreezer struct {
+	mu    sync.Mutex
+	calls []string
+	err   error
+}
+
+func (f *recordingFreezer) Freeze(_ context.Context, opID, defID string, _ json.RawMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, defID+"/"+opID)
+	return f.err
+}
+
+// TestFreezer_DestructiveOnly verifies only Destructive ops are frozen,
+// before Run is called.
+func TestFreezer_DestructiveOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := registry.New(newFakeStore(), slog.Default(), 1, nil)
+	freezer := &recordingFreezer{}
+	r.SetFreezer(freezer)
+
+	var frozenBeforeRun atomic.Bool
+	destructive := makeValidDef("test.freeze-destructive")
+	destructive.Destructive = true
+	destructive.Run = func(context.Context, json.RawMessage, registry.Reporter) error {
+		freezer.mu.Lock()
+		frozenBeforeRun.Store(len(freezer.calls) == 1)
+		freezer.mu.Unlock()
+		return nil
+	}
+	_ = r.RegisterOp(destructive)
+	_ = r.RegisterOp(makeValidDef("test.freeze-plain"))
+	r.Start(ctx)
+
+	plainObs := newRecordingObserver()
+	r.SetLifecycleObserver(plainObs)
+	if _, err := r.EnqueueOp(ctx, "test.freeze-plain", nil); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	plainObs.wait(t)
+
+	obs := newRecordingObserver()
+	r.SetLifecycleObserver(obs)
+	opID, err := r.EnqueueOp(ctx, "test.freeze-destructive", nil)
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	events := obs.wait(t)
+	if last := events[len(events)-1]; last.Type != registry.LifecycleCompleted {
+		t.Fatalf("last event = %s, want completed", last.Type)
+	}
+	freezer.mu.Lock()
+	defer freezer.mu.Unlock()
+	if len(freezer.calls) != 1 || freezer.calls[0] != "test.freeze-destructive/"+opID {
+		t.Errorf("freeze calls = %v", freezer.calls)
+	}
+	if !frozenBeforeRun.Load() {
+		t.Error("Run was called before the snapshot")
+	}
+}
+
+// TestFreezer_FailureFailsRun verifies a failed snapshot fails the op
+// without calling Run.
+func TestFreezer_FailureFailsRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 1, nil)
+	r.SetFreezer(&recordingFreezer{err: errors.New("disk full")})
+	obs := newRecordingObserver()
+	r.SetLifecycleObserver(obs)
+
+	var ran atomic.Bool
+	def := makeValidDef("test.freeze-fail")
+	def.Destructive = true
+	def.Run = func(context.Context, json.RawMessage, registry.Reporter) error {
+		ran.Store(true)
+		return nil
+	}
+	_ = r.RegisterOp(def)
+	r.Start(ctx)
+
+	opID, err := r.EnqueueOp(ctx, "test.freeze-fail", nil)
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	events := obs.wait(t)
+	last := events[len(events)-1]
+	if last.Type != registry.LifecycleFailed || !strings.Contains(last.Error, "pre-run snapshot failed: disk full") {
+		t.Fatalf("last event = %s %q", last.Type, last.Error)
+	}
+	if ran.Load() {
+		t.Error("Run was called after the snapshot failed")
+	}
+	row, _ := store.GetOperationV2(opID)
+	if row == nil || row.Status != "failed" {
+		t.Errorf("row = %+v, want failed", row)
+	}
+}
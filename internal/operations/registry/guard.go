@@ -0,0 +1,36 @@
+// file: internal/operations/registry/guard.go
+// version: 1.0.0
+// guid: 9a4c0e73-5b2d-4f18-86e9-d1f7b3a5c204
+// last-edited: 2026-10-17
+
+package registry
+
+// RunGuard can veto Destructive ops, e.g. while another server instance
+// holds the library. It is asked at enqueue, so the caller sees the
+// error, and again right before a run starts, for ops queued or resumed
+// before the veto began.
+type RunGuard interface {
+	AllowDestructive(defID string) error
+}
+
+// SetRunGuard wires the veto for Destructive ops. Safe to call with nil,
+// which allows them all.
+func (r *Registry) SetRunGuard(g RunGuard) {
+	r.mu.Lock()
+	r.guard = g
+	r.mu.Unlock()
+}
+
+// guardDestructive returns the guard's veto for def, if any.
+func (r *Registry) guardDestructive(def OperationDef) error {
+	if !def.Destructive {
+		return nil
+	}
+	r.mu.RLock()
+	g := r.guard
+	r.mu.RUnlock()
+	if g == nil {
+		return nil
+	}
+	return g.AllowDestructive(def.ID)
+}
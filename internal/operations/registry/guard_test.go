@@ -0,0 +1,92 @@
+// file: internal/operations/registry/guard_test.go
+// version: 1.0.0
+// guid: 4b8d1f62-0c3e-4a97-b5d4-7e2a9c6f1b30
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+// vetoAfter allows the first n destructive checks and vetoes the rest.
+type vetoAfter struct {
+	n     int32
+	calls atomic.Int32
+}
+
+func (g *vetoAfter) AllowDestructive(defID string) error {
+	if g.calls.Add(1) > g.n {
+		return errors.New("library locked: " + defID)
+	}
+	return nil
+}
+
+// TestRunGuard_VetoesDestructiveOps verifies a veto rejects destructive
+// enqueues, fails runs vetoed after enqueue without calling Run, and
+// leaves other ops alone.
+func TestRunGuard_VetoesDestructiveOps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 1, nil)
+	guard := &vetoAfter{n: 1}
+	r.SetRunGuard(guard)
+
+	var ran atomic.Bool
+	def := makeValidDef("test.guard-destructive")
+	def.Destructive = true
+	def.Run = func(context.Context, json.RawMessage, registry.Reporter) error {
+		ran.Store(true)
+		return nil
+	}
+	_ = r.RegisterOp(def)
+	_ = r.RegisterOp(makeValidDef("test.guard-plain"))
+	r.Start(ctx)
+
+	// Allowed at enqueue, vetoed when the run starts.
+	obs := newRecordingObserver()
+	r.SetLifecycleObserver(obs)
+	opID, err := r.EnqueueOp(ctx, "test.guard-destructive", nil)
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	events := obs.wait(t)
+	last := events[len(events)-1]
+	if last.Type != registry.LifecycleFailed || !strings.Contains(last.Error, "library locked: test.guard-destructive") {
+		t.Fatalf("last event = %s %q", last.Type, last.Error)
+	}
+	if ran.Load() {
+		t.Error("Run was called after the veto")
+	}
+	if row, _ := store.GetOperationV2(opID); row == nil || row.Status != "failed" {
+		t.Errorf("row = %+v, want failed", row)
+	}
+
+	// Vetoed at enqueue.
+	if _, err := r.EnqueueOp(ctx, "test.guard-destructive", nil); err == nil {
+		t.Error("expected the vetoed enqueue to fail")
+	}
+
+	// Other ops never ask the guard.
+	calls := guard.calls.Load()
+	plainObs := newRecordingObserver()
+	r.SetLifecycleObserver(plainObs)
+	if _, err := r.EnqueueOp(ctx, "test.guard-plain", nil); err != nil {
+		t.Fatalf("enqueue plain: %v", err)
+	}
+	if events := plainObs.wait(t); events[len(events)-1].Type != registry.LifecycleCompleted {
+		t.Errorf("plain op did not complete: %+v", events)
+	}
+	if guard.calls.Load() != calls {
+		t.Error("guard was asked about a non-destructive op")
+	}
+}
@@ -0,0 +1,38 @@
+// file: internal/operations/registry/interactive.go
+// version: 1.0.0
+// guid: 092e077d-ec94-4a90-bc17-62d2f2ee116f
+// last-edited: 2026-10-16
+
+package registry
+
+import "context"
+
+type interactiveKey struct{}
+
+// WithInteractive marks ctx as serving a user-facing request. Operations
+// enqueued with such a context count toward RunningInteractive, which
+// background work (startup scans, maintenance) consults to step aside.
+func WithInteractive(ctx context.Context) context.Context {
+	return context.WithValue(ctx, interactiveKey{}, true)
+}
+
+func isInteractive(ctx context.Context) bool {
+	v, _ := ctx.Value(interactiveKey{}).(bool)
+	return v
+}
+
+// RunningInteractive returns how many operations enqueued from an
+// interactive context are running right now. Queued ones are not counted:
+// a background op waiting on them could otherwise hold the worker they
+// need.
+func (r *Registry) RunningInteractive() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n := 0
+	for id := range r.interactive {
+		if _, ok := r.running[id]; ok {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,61 @@
+// file: internal/operations/registry/interactive_test.go
+// version: 1.0.0
+// guid: 6e85a72e-5919-4194-a7a3-820972548eb1
+// last-edited: 2026-10-16
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+// TestRunningInteractive_CountsOnlyInteractiveRunningOps verifies that only
+// ops enqueued from an interactive context are counted, and only while they
+// run.
+func TestRunningInteractive_CountsOnlyInteractiveRunningOps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, _ := newTestRegistry(t)
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	def := makeValidDef("test.interactive")
+	def.Run = func(_ context.Context, _ json.RawMessage, _ registry.Reporter) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+	_ = r.RegisterOp(def)
+	r.Start(ctx)
+
+	if _, err := r.EnqueueOp(ctx, "test.interactive", nil); err != nil {
+		t.Fatalf("enqueue background: %v", err)
+	}
+	if _, err := r.EnqueueOp(registry.WithInteractive(ctx), "test.interactive", nil); err != nil {
+		t.Fatalf("enqueue interactive: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for ops to start")
+		}
+	}
+	if got := r.RunningInteractive(); got != 1 {
+		t.Fatalf("RunningInteractive() = %d while running, want 1", got)
+	}
+
+	close(release)
+	deadline := time.Now().Add(5 * time.Second)
+	for r.RunningInteractive() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("RunningInteractive() = %d after completion, want 0", r.RunningInteractive())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,145 @@
+// file: internal/operations/registry/lifecycle.go
+// version: 1.0.0
+// guid: aa5da0d0-df25-4236-9be2-cde956c4a890
+// last-edited: 2026-10-16
+
+package registry
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Lifecycle event types delivered to a LifecycleObserver.
+const (
+	LifecycleCreated   = "created"
+	LifecycleStarted   = "started"
+	LifecycleProgress  = "progress"
+	LifecycleCompleted = "completed"
+	LifecycleFailed    = "failed"
+	LifecycleCanceled  = "canceled"
+)
+
+// progressMilestoneStep is the percentage granularity of progress
+// lifecycle events: one fires as a run crosses 25%, 50% and 75%.
+const progressMilestoneStep = 25
+
+// LifecycleEvent is one operation lifecycle transition. Unlike the SSE
+// events on Bus, which carry just enough for the UI to refetch, it is
+// self-contained: external consumers get the op's parameters and, on
+// terminal events, its outcome without calling back into the API.
+type LifecycleEvent struct {
+	Type            string          `json:"type"`
+	OpID            string          `json:"op_id"`
+	DefID           string          `json:"def_id"`
+	Plugin          string          `json:"plugin"`
+	Status          string          `json:"status"`
+	Params          json.RawMessage `json:"params,omitempty"`
+	ProgressCurrent int             `json:"progress_current"`
+	ProgressTotal   int             `json:"progress_total"`
+	ProgressMessage string          `json:"progress_message,omitempty"`
+	// Percent is the milestone crossed, set on progress events only.
+	Percent int    `json:"percent,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// Result is the op's recorded result data, on terminal events when
+	// the op stored one.
+	Result     json.RawMessage `json:"result,omitempty"`
+	Resumed    bool            `json:"resumed,omitempty"`
+	DurationMs int64           `json:"duration_ms,omitempty"`
+	Timestamp  time.Time       `json:"timestamp"`
+}
+
+// LifecycleObserver receives operation lifecycle events. OpLifecycle is
+// called synchronously from the registry's workers and must not block.
+type LifecycleObserver interface {
+	OpLifecycle(ev LifecycleEvent)
+}
+
+// SetLifecycleObserver wires an observer for operation lifecycle events
+// (created, started, progress milestones, completed, failed, canceled).
+// Safe to call with nil.
+func (r *Registry) SetLifecycleObserver(obs LifecycleObserver) {
+	r.mu.Lock()
+	r.lifecycle = obs
+	r.mu.Unlock()
+}
+
+func (r *Registry) lifecycleObserver() LifecycleObserver {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lifecycle
+}
+
+// notifyLifecycle stamps ev and hands it to the observer, if any.
+func (r *Registry) notifyLifecycle(ev LifecycleEvent) {
+	obs := r.lifecycleObserver()
+	if obs == nil {
+		return
+	}
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now().UTC()
+	}
+	obs.OpLifecycle(ev)
+}
+
+// notifyTerminal reports a finished run. The row is re-read so the event
+// carries the final progress and any result data the op recorded.
+func (r *Registry) notifyTerminal(qr *queuedRun, status string, runErr error, startedAt time.Time) {
+	if r.lifecycleObserver() == nil {
+		return
+	}
+	ev := LifecycleEvent{
+		Type:       status,
+		OpID:       qr.opID,
+		DefID:      qr.defID,
+		Plugin:     qr.plugin,
+		Status:     status,
+		Params:     qr.params,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}
+	if runErr != nil && status == LifecycleFailed {
+		ev.Error = runErr.Error()
+	}
+	if row, err := r.store.GetOperationV2(qr.opID); err == nil && row != nil {
+		ev.ProgressCurrent = row.ProgressCurrent
+		ev.ProgressTotal = row.ProgressTotal
+		ev.ProgressMessage = row.ProgressMessage
+		if row.ResultData != nil && json.Valid([]byte(*row.ResultData)) {
+			ev.Result = json.RawMessage(*row.ResultData)
+		}
+	}
+	r.notifyLifecycle(ev)
+}
+
+// lifecycleFromRow builds a created event from a freshly inserted row.
+func lifecycleFromRow(row database.OperationV2Row, resumed bool) LifecycleEvent {
+	ev := LifecycleEvent{
+		Type:    LifecycleCreated,
+		OpID:    row.ID,
+		DefID:   row.DefID,
+		Plugin:  row.Plugin,
+		Status:  row.Status,
+		Resumed: resumed,
+	}
+	if row.Params != "" && json.Valid([]byte(row.Params)) {
+		ev.Params = json.RawMessage(row.Params)
+	}
+	return ev
+}
+
+// progressMilestone returns the milestone percentage current/total has
+// reached, rounded down to progressMilestoneStep. 0 and 100 are never
+// milestones: started and completed events cover them.
+func progressMilestone(current, total int) int {
+	if total <= 0 || current <= 0 {
+		return 0
+	}
+	pct := current * 100 / total
+	m := pct / progressMilestoneStep * progressMilestoneStep
+	if m >= 100 {
+		return 0
+	}
+	return m
+}
@@ -0,0 +1,141 @@
+// file: internal/operations/registry/lifecycle_test.go
+// version: 1.0.0
+// guid: f9929540-3aa8-4c30-b12b-0a2434564495
+// last-edited: 2026-10-16
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []registry.LifecycleEvent
+	done   chan struct{}
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{done: make(chan struct{})}
+}
+
+func (o *recordingObserver) OpLifecycle(ev registry.LifecycleEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, ev)
+	switch ev.Type {
+	case registry.LifecycleCompleted, registry.LifecycleFailed, registry.LifecycleCanceled:
+		close(o.done)
+	}
+}
+
+func (o *recordingObserver) wait(t *testing.T) []registry.LifecycleEvent {
+	t.Helper()
+	select {
+	case <-o.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for terminal lifecycle event")
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]registry.LifecycleEvent(nil), o.events...)
+}
+
+// TestLifecycle_CompletedRun verifies the created → started → milestones →
+// completed sequence and that params ride along on every event.
+func TestLifecycle_CompletedRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 1, nil)
+	obs := newRecordingObserver()
+	r.SetLifecycleObserver(obs)
+
+	def := makeValidDef("test.lc-success")
+	def.Run = func(_ context.Context, _ json.RawMessage, rep registry.Reporter) error {
+		for i := 1; i <= 8; i++ {
+			if err := rep.UpdateProgress(i, 8, "working"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	_ = r.RegisterOp(def)
+	r.Start(ctx)
+
+	opID, err := r.EnqueueOp(ctx, "test.lc-success", json.RawMessage(`{"path":"/library"}`))
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	events := obs.wait(t)
+
+	var types []string
+	var percents []int
+	for _, ev := range events {
+		if ev.OpID != opID {
+			t.Errorf("event %s has op_id %q, want %q", ev.Type, ev.OpID, opID)
+		}
+		if string(ev.Params) != `{"path":"/library"}` {
+			t.Errorf("event %s params = %s", ev.Type, ev.Params)
+		}
+		types = append(types, ev.Type)
+		if ev.Type == registry.LifecycleProgress {
+			percents = append(percents, ev.Percent)
+		}
+	}
+	want := []string{"created", "started", "progress", "progress", "progress", "completed"}
+	if len(types) != len(want) {
+		t.Fatalf("types = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("types = %v, want %v", types, want)
+		}
+	}
+	if len(percents) != 3 || percents[0] != 25 || percents[1] != 50 || percents[2] != 75 {
+		t.Errorf("milestones = %v, want [25 50 75]", percents)
+	}
+	last := events[len(events)-1]
+	if last.ProgressCurrent != 8 || last.ProgressTotal != 8 {
+		t.Errorf("completed progress = %d/%d, want 8/8", last.ProgressCurrent, last.ProgressTotal)
+	}
+}
+
+// TestLifecycle_FailedRun verifies the failed event carries the error.
+func TestLifecycle_FailedRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 1, nil)
+	obs := newRecordingObserver()
+	r.SetLifecycleObserver(obs)
+
+	def := makeValidDef("test.lc-fail")
+	def.Run = func(_ context.Context, _ json.RawMessage, _ registry.Reporter) error {
+		return errors.New("disk full")
+	}
+	_ = r.RegisterOp(def)
+	r.Start(ctx)
+
+	if _, err := r.EnqueueOp(ctx, "test.lc-fail", nil); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	events := obs.wait(t)
+	last := events[len(events)-1]
+	if last.Type != registry.LifecycleFailed || last.Status != "failed" {
+		t.Fatalf("last event = %s/%s, want failed", last.Type, last.Status)
+	}
+	if last.Error != "disk full" {
+		t.Errorf("error = %q, want %q", last.Error, "disk full")
+	}
+}
@@ -0,0 +1,241 @@
+// file: internal/operations/registry/params.go
+// version: 1.0.0
+// guid: 9e4c2a7b-d1f8-4b36-a5e0-6c8d3f2b1a94
+// last-edited: 2026-10-17
+
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// FieldError is one rejected operation parameter. Field is a dotted path
+// into the params object ("book_ids[2]"); it is empty for errors about
+// the params document as a whole.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ParamsError is returned by EnqueueOp when params fail the def's
+// ParamsSchema. Nothing is enqueued.
+type ParamsError struct {
+	DefID  string
+	Fields []FieldError
+}
+
+func (e *ParamsError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		if f.Field == "" {
+			parts = append(parts, f.Message)
+		} else {
+			parts = append(parts, f.Field+": "+f.Message)
+		}
+	}
+	return fmt.Sprintf("registry: invalid params for %s: %s", e.DefID, strings.Join(parts, "; "))
+}
+
+// MustParamsSchema parses a JSON Schema for OperationDef.ParamsSchema,
+// panicking if it is not valid JSON. Meant for package-level vars.
+func MustParamsSchema(schema string) *json.RawMessage {
+	var probe map[string]any
+	if err := json.Unmarshal([]byte(schema), &probe); err != nil {
+		panic(fmt.Sprintf("registry: invalid params schema: %v", err))
+	}
+	raw := json.RawMessage(schema)
+	return &raw
+}
+
+// ValidateParams checks params against a JSON Schema and returns every
+// violation, or nil. Only the subset of JSON Schema that operation params
+// need is supported: type (a name or a list of names), properties,
+// required, additionalProperties: false, enum, minLength, minimum,
+// maximum, items and minItems. Other keywords are ignored.
+func ValidateParams(schema, params json.RawMessage) []FieldError {
+	var s map[string]any
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return []FieldError{{Message: "params schema is not valid JSON"}}
+	}
+	if len(bytes.TrimSpace(params)) == 0 {
+		params = json.RawMessage("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return []FieldError{{Message: "params are not valid JSON"}}
+	}
+	var errs []FieldError
+	validateValue(s, v, "", &errs)
+	return errs
+}
+
+// validateEnqueueParams marshals params the way EnqueueOp stores them and
+// validates them against def.ParamsSchema.
+func validateEnqueueParams(def OperationDef, params any) error {
+	if def.ParamsSchema == nil {
+		return nil
+	}
+	raw := json.RawMessage("{}")
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("registry: marshal params: %w", err)
+		}
+		raw = b
+	}
+	if errs := ValidateParams(*def.ParamsSchema, raw); len(errs) > 0 {
+		return &ParamsError{DefID: def.ID, Fields: errs}
+	}
+	return nil
+}
+
+func validateValue(s map[string]any, v any, path string, errs *[]FieldError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if types := schemaTypes(s["type"]); len(types) > 0 && !matchesAnyType(v, types) {
+		fail("must be %s", strings.Join(types, " or "))
+		return
+	}
+	if enum, ok := s["enum"].([]any); ok && !inEnum(v, enum) {
+		names := make([]string, 0, len(enum))
+		for _, e := range enum {
+			b, _ := json.Marshal(e)
+			names = append(names, string(b))
+		}
+		fail("must be one of %s", strings.Join(names, ", "))
+		return
+	}
+	switch val := v.(type) {
+	case string:
+		if n, ok := schemaNumber(s["minLength"]); ok && float64(len([]rune(val))) < n {
+			fail("must be at least %v characters", n)
+		}
+	case json.Number:
+		f, _ := val.Float64()
+		if n, ok := schemaNumber(s["minimum"]); ok && f < n {
+			fail("must be at least %v", n)
+		}
+		if n, ok := schemaNumber(s["maximum"]); ok && f > n {
+			fail("must be at most %v", n)
+		}
+	case []any:
+		if n, ok := schemaNumber(s["minItems"]); ok && float64(len(val)) < n {
+			fail("must have at least %v items", n)
+		}
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, item := range val {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case map[string]any:
+		props, _ := s["properties"].(map[string]any)
+		if req, ok := s["required"].([]any); ok {
+			for _, r := range req {
+				name, _ := r.(string)
+				if _, present := val[name]; !present {
+					*errs = append(*errs, FieldError{Field: joinParamPath(path, name), Message: "is required"})
+				}
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		closed := s["additionalProperties"] == false
+		for _, k := range keys {
+			if ps, ok := props[k].(map[string]any); ok {
+				validateValue(ps, val[k], joinParamPath(path, k), errs)
+			} else if closed {
+				*errs = append(*errs, FieldError{Field: joinParamPath(path, k), Message: "unknown parameter"})
+			}
+		}
+	}
+}
+
+func joinParamPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func schemaTypes(t any) []string {
+	switch tv := t.(type) {
+	case string:
+		return []string{tv}
+	case []any:
+		out := make([]string, 0, len(tv))
+		for _, x := range tv {
+			if s, ok := x.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func schemaNumber(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func matchesAnyType(v any, types []string) bool {
+	for _, t := range types {
+		if matchesType(v, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(v any, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	}
+	return false
+}
+
+// inEnum compares by JSON encoding, so 1 and 1.0 differ but "a" == "a".
+func inEnum(v any, enum []any) bool {
+	got, _ := json.Marshal(v)
+	for _, e := range enum {
+		want, _ := json.Marshal(e)
+		if bytes.Equal(got, want) {
+			return true
+		}
+	}
+	return false
+}
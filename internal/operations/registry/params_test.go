@@ -0,0 +1,90 @@
+// file: internal/operations/registry/params_test.go
+// version: 1.0.0
+// guid: 2d7f9b3e-a6c1-4e58-b0d4-8f1a5c3e7b62
+// last-edited: 2026-10-17
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+var testParamsSchema = registry.MustParamsSchema(`{
+	"type": "object",
+	"additionalProperties": false,
+	"required": ["book_id"],
+	"properties": {
+		"book_id":  {"type": "string", "minLength": 1},
+		"mode":     {"type": "string", "enum": ["", "fast", "full"]},
+		"limit":    {"type": ["integer", "null"], "minimum": 1, "maximum": 100},
+		"tags":     {"type": "array", "items": {"type": "string", "minLength": 1}},
+		"dry_run":  {"type": "boolean"}
+	}
+}`)
+
+func TestValidateParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		want   []registry.FieldError
+	}{
+		{"valid", `{"book_id":"b1","mode":"fast","limit":5,"tags":["x"],"dry_run":true}`, nil},
+		{"null allowed", `{"book_id":"b1","limit":null}`, nil},
+		{"empty body", ``, []registry.FieldError{{Field: "book_id", Message: "is required"}}},
+		{"unknown field", `{"book_id":"b1","bookid":"b1"}`, []registry.FieldError{{Field: "bookid", Message: "unknown parameter"}}},
+		{"wrong type", `{"book_id":7}`, []registry.FieldError{{Field: "book_id", Message: "must be string"}}},
+		{"enum", `{"book_id":"b1","mode":"slow"}`, []registry.FieldError{{Field: "mode", Message: `must be one of "", "fast", "full"`}}},
+		{"not integer", `{"book_id":"b1","limit":1.5}`, []registry.FieldError{{Field: "limit", Message: "must be integer or null"}}},
+		{"range", `{"book_id":"b1","limit":0}`, []registry.FieldError{{Field: "limit", Message: "must be at least 1"}}},
+		{"items", `{"book_id":"b1","tags":["a",""]}`, []registry.FieldError{{Field: "tags[1]", Message: "must be at least 1 characters"}}},
+		{"not object", `[1]`, []registry.FieldError{{Field: "", Message: "must be object"}}},
+		{"bad json", `{"book_id":`, []registry.FieldError{{Field: "", Message: "params are not valid JSON"}}},
+		{"several", `{"book_id":"","dry_run":"yes","extra":1}`, []registry.FieldError{
+			{Field: "book_id", Message: "must be at least 1 characters"},
+			{Field: "dry_run", Message: "must be boolean"},
+			{Field: "extra", Message: "unknown parameter"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := registry.ValidateParams(*testParamsSchema, json.RawMessage(tt.params))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateParams(%s)\n got %+v\nwant %+v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnqueueOp_RejectsInvalidParams(t *testing.T) {
+	r, store := newTestRegistry(t)
+	def := makeValidDef("test.schema")
+	def.ParamsSchema = testParamsSchema
+	_ = r.RegisterOp(def)
+
+	_, err := r.EnqueueOp(context.Background(), "test.schema", map[string]any{"book_id": "b1", "bogus": true})
+	var perr *registry.ParamsError
+	if !errors.As(err, &perr) {
+		t.Fatalf("want *ParamsError, got %v", err)
+	}
+	if perr.DefID != "test.schema" || len(perr.Fields) != 1 || perr.Fields[0].Field != "bogus" {
+		t.Errorf("unexpected error: %+v", perr)
+	}
+	if n := len(store.ops); n != 0 {
+		t.Errorf("invalid params enqueued %d ops", n)
+	}
+
+	opID, err := r.EnqueueOp(context.Background(), "test.schema", json.RawMessage(`{"book_id": "b1", "limit": 3}`))
+	if err != nil {
+		t.Fatalf("EnqueueOp: %v", err)
+	}
+	row, _ := store.GetOperationV2(opID)
+	if row == nil || row.Params != `{"book_id":"b1","limit":3}` {
+		t.Errorf("persisted params = %+v", row)
+	}
+}
@@ -0,0 +1,193 @@
+// file: internal/operations/registry/path_lease.go
+// version: 1.0.0
+// guid: 5e2a8c71-9d4f-4b36-a1e7-c38f0b6d92a4
+// last-edited: 2026-10-17
+
+package registry
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// LeaseMode says whether a run only reads files under a path or also
+// moves, renames, or deletes them.
+type LeaseMode int
+
+const (
+	LeaseRead  LeaseMode = iota // reads files; compatible with other readers
+	LeaseWrite                  // moves or deletes files; excludes every other lease
+)
+
+// PathLease is a directory a run works in. Leases on the same directory,
+// or where one directory contains the other, overlap.
+type PathLease struct {
+	Path string
+	Mode LeaseMode
+}
+
+// ReadLeases and WriteLeases build leases of one mode for paths, skipping
+// empty ones.
+func ReadLeases(paths ...string) []PathLease  { return leasesOf(LeaseRead, paths) }
+func WriteLeases(paths ...string) []PathLease { return leasesOf(LeaseWrite, paths) }
+
+func leasesOf(mode LeaseMode, paths []string) []PathLease {
+	leases := make([]PathLease, 0, len(paths))
+	for _, p := range paths {
+		if p != "" {
+			leases = append(leases, PathLease{Path: filepath.Clean(p), Mode: mode})
+		}
+	}
+	return leases
+}
+
+// conflicts reports whether l and other cannot be held at once: they
+// overlap and at least one of them writes.
+func (l PathLease) conflicts(other PathLease) bool {
+	if l.Mode == LeaseRead && other.Mode == LeaseRead {
+		return false
+	}
+	return pathContains(l.Path, other.Path) || pathContains(other.Path, l.Path)
+}
+
+// pathContains reports whether child is dir or lies beneath it.
+func pathContains(dir, child string) bool {
+	if dir == child || dir == string(filepath.Separator) {
+		return true
+	}
+	return strings.HasPrefix(child, dir+string(filepath.Separator))
+}
+
+// leaseBlocker is a run whose lease keeps a queued run waiting.
+type leaseBlocker struct {
+	opID  string
+	defID string
+	path  string
+}
+
+// findLeaseConflict returns the first lease in held that conflicts with
+// one of want, and the path where they meet (the narrower of the two).
+func findLeaseConflict(want, held []PathLease) (string, bool) {
+	for _, w := range want {
+		for _, h := range held {
+			if w.conflicts(h) {
+				if len(w.Path) > len(h.Path) {
+					return w.Path, true
+				}
+				return h.Path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// pendingLease is a queued run held back by a lease earlier in the same
+// dispatch cycle. Later runs that conflict with it wait behind it rather
+// than overtake it, so conflicting runs start in queue order.
+type pendingLease struct {
+	opID   string
+	defID  string
+	leases []PathLease
+}
+
+// pendingLeaseBlocker returns the first pending run whose leases conflict
+// with want.
+func pendingLeaseBlocker(pending []pendingLease, want []PathLease) (leaseBlocker, bool) {
+	for _, p := range pending {
+		if path, ok := findLeaseConflict(want, p.leases); ok {
+			return leaseBlocker{opID: p.opID, defID: p.defID, path: path}, true
+		}
+	}
+	return leaseBlocker{}, false
+}
+
+// runLeases evaluates def.PathLeases for params. A panicking callback
+// is treated as no leases so a bad registration can't stall the queue.
+func (r *Registry) runLeases(def OperationDef, params []byte) (leases []PathLease) {
+	if def.PathLeases == nil {
+		return nil
+	}
+	defer func() {
+		if rec := recover(); rec != nil {
+			r.logger.Warn("registry: PathLeases panicked; running without leases", "def_id", def.ID, "panic", rec)
+			leases = nil
+		}
+	}()
+	return def.PathLeases(params)
+}
+
+// leaseBlockerLocked returns the running op whose leases conflict with
+// want, if any. Caller holds r.mu.
+func (r *Registry) leaseBlockerLocked(opID string, want []PathLease) (leaseBlocker, bool) {
+	if len(want) == 0 {
+		return leaseBlocker{}, false
+	}
+	for id, h := range r.running {
+		if id == opID {
+			continue
+		}
+		if path, ok := findLeaseConflict(want, h.leases); ok {
+			return leaseBlocker{opID: id, defID: h.defID, path: path}, true
+		}
+	}
+	return leaseBlocker{}, false
+}
+
+// noteLeaseWait writes the reason a queued run is waiting into its
+// progress message, once per blocker, so the operations list says why
+// it hasn't started.
+func (r *Registry) noteLeaseWait(opID string, b leaseBlocker) {
+	key := b.opID + "\x00" + b.path
+	r.mu.Lock()
+	if r.leaseWaits[opID] == key {
+		r.mu.Unlock()
+		return
+	}
+	r.leaseWaits[opID] = key
+	name := b.defID
+	if def, ok := r.defs[b.defID]; ok && def.DisplayName != "" {
+		name = def.DisplayName
+	}
+	r.mu.Unlock()
+
+	msg := fmt.Sprintf("Waiting for %s (%s) to finish with %s", name, b.opID, b.path)
+	if err := r.store.UpdateOpProgressV2(opID, 0, 0, msg); err != nil {
+		r.logger.Warn("registry: failed to record lease wait", "op_id", opID, "error", err)
+	}
+	r.logger.Info("registry: op waiting on path lease", "op_id", opID, "blocked_by", b.opID, "path", b.path)
+}
+
+// clearLeaseWait drops the waiting message of a run about to start.
+func (r *Registry) clearLeaseWait(opID string) {
+	r.mu.Lock()
+	_, waited := r.leaseWaits[opID]
+	delete(r.leaseWaits, opID)
+	r.mu.Unlock()
+	if waited {
+		if err := r.store.UpdateOpProgressV2(opID, 0, 0, ""); err != nil {
+			r.logger.Warn("registry: failed to clear lease wait", "op_id", opID, "error", err)
+		}
+	}
+}
+
+// pruneLeaseWaits forgets waits of runs no longer queued (canceled
+// while waiting).
+func (r *Registry) pruneLeaseWaits(queued []database.OperationV2Row) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.leaseWaits) == 0 {
+		return
+	}
+	live := make(map[string]bool, len(queued))
+	for _, row := range queued {
+		live[row.ID] = true
+	}
+	for id := range r.leaseWaits {
+		if !live[id] {
+			delete(r.leaseWaits, id)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// file: internal/operations/registry/path_lease_test.go
+// version: 1.0.0
+// guid: 2d7b5f90-e3c1-4a68-8f24-b9a06c1e7d35
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+type leaseParams struct {
+	Path string `json:"path"`
+}
+
+// leaseDef registers an op that takes a lease of mode on its params' path
+// and blocks until release is closed.
+func leaseDef(id string, mode registry.LeaseMode, started chan<- string, release <-chan struct{}) registry.OperationDef {
+	def := makeValidDef(id)
+	def.DisplayName = id
+	def.PathLeases = func(raw json.RawMessage) []registry.PathLease {
+		var p leaseParams
+		_ = json.Unmarshal(raw, &p)
+		if mode == registry.LeaseWrite {
+			return registry.WriteLeases(p.Path)
+		}
+		return registry.ReadLeases(p.Path)
+	}
+	def.Run = func(ctx context.Context, raw json.RawMessage, _ registry.Reporter) error {
+		var p leaseParams
+		_ = json.Unmarshal(raw, &p)
+		started <- id + ":" + p.Path
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}
+	return def
+}
+
+func awaitStart(t *testing.T, started <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-started:
+		if got != want {
+			t.Fatalf("started %s, want %s", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s never started", want)
+	}
+}
+
+func assertNotStarted(t *testing.T, started <-chan string) {
+	t.Helper()
+	select {
+	case got := <-started:
+		t.Fatalf("%s started while a conflicting lease was held", got)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestDispatcher_PathLeasesSerializeOverlappingWrites(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 4, nil)
+	started := make(chan string, 8)
+	organizeDone := make(chan struct{})
+	scanDone := make(chan struct{})
+	_ = r.RegisterOp(leaseDef("test.organize", registry.LeaseWrite, started, organizeDone))
+	_ = r.RegisterOp(leaseDef("test.scan", registry.LeaseRead, started, scanDone))
+	r.Start(ctx)
+
+	organizeID, _ := r.EnqueueOp(ctx, "test.organize", leaseParams{Path: "/lib"})
+	awaitStart(t, started, "test.organize:/lib")
+
+	// A scan beneath the folder organize is moving waits, and says why.
+	scanID, _ := r.EnqueueOp(ctx, "test.scan", leaseParams{Path: "/lib/author"})
+	// A scan of an unrelated folder is not held up.
+	_, _ = r.EnqueueOp(ctx, "test.scan", leaseParams{Path: "/library-two"})
+	awaitStart(t, started, "test.scan:/library-two")
+	assertNotStarted(t, started)
+	if _, _, msg := store.progressOf(scanID); !strings.Contains(msg, "test.organize ("+organizeID+")") || !strings.Contains(msg, "/lib/author") {
+		t.Errorf("queued scan message = %q, want it to name the organize run and the path", msg)
+	}
+	if got := store.statusOf(scanID); got != "queued" {
+		t.Errorf("scan status = %s, want queued", got)
+	}
+
+	close(organizeDone)
+	awaitStatus(t, store, organizeID, "completed", 5*time.Second)
+	awaitStart(t, started, "test.scan:/lib/author")
+	if _, _, msg := store.progressOf(scanID); strings.HasPrefix(msg, "Waiting for") {
+		t.Errorf("started scan still shows wait message %q", msg)
+	}
+	close(scanDone)
+}
+
+func TestDispatcher_PathLeasesReadersShareAndQueueInOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 4, nil)
+	started := make(chan string, 8)
+	scanDone := make(chan struct{})
+	organizeDone := make(chan struct{})
+	_ = r.RegisterOp(leaseDef("test.scan", registry.LeaseRead, started, scanDone))
+	_ = r.RegisterOp(leaseDef("test.organize", registry.LeaseWrite, started, organizeDone))
+	r.Start(ctx)
+
+	// Two readers of the same folder run side by side.
+	_, _ = r.EnqueueOp(ctx, "test.scan", leaseParams{Path: "/lib"})
+	awaitStart(t, started, "test.scan:/lib")
+	_, _ = r.EnqueueOp(ctx, "test.scan", leaseParams{Path: "/lib"})
+	awaitStart(t, started, "test.scan:/lib")
+
+	// A writer waits for them; a reader queued after the writer waits
+	// behind it rather than overtaking.
+	organizeID, _ := r.EnqueueOp(ctx, "test.organize", leaseParams{Path: "/"})
+	time.Sleep(150 * time.Millisecond)
+	lateScanID, _ := r.EnqueueOp(ctx, "test.scan", leaseParams{Path: "/lib/x"})
+	assertNotStarted(t, started)
+	if _, _, msg := store.progressOf(lateScanID); !strings.Contains(msg, organizeID) {
+		t.Errorf("late scan message = %q, want it to wait on the queued organize %s", msg, organizeID)
+	}
+
+	close(scanDone)
+	awaitStart(t, started, "test.organize:/")
+	assertNotStarted(t, started)
+	close(organizeDone)
+	awaitStart(t, started, "test.scan:/lib/x")
+}
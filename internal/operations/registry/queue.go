@@ -0,0 +1,249 @@
+// file: internal/operations/registry/queue.go
+// version: 1.0.0
+// guid: 1b278a12-caf2-4639-8e5b-b3fd408ba53c
+// last-edited: 2026-10-17
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// ErrNotQueued is returned by MoveQueued when the op, or the op it is
+// placed next to, is no longer waiting in the queue.
+var ErrNotQueued = database.ErrOperationNotQueued
+
+// QueueLimits caps how many operations run at once.
+type QueueLimits struct {
+	// MaxConcurrent caps running operations across every plugin and pool.
+	// Zero leaves only the worker count.
+	MaxConcurrent int
+	// Pools caps running operations per OperationDef.Pool. A missing or
+	// zero entry leaves that pool uncapped.
+	Pools map[string]int
+}
+
+// QueueMover is the store surface MoveQueued needs. PebbleStore has it;
+// stores without it cannot reorder.
+type QueueMover interface {
+	MoveQueuedOperationV2(id string, priority int, queuedAt time.Time) error
+}
+
+// QueuePosition says where MoveQueued puts an op. Before and After place
+// it next to another queued op, taking that op's priority; otherwise
+// Priority changes its priority and it keeps its age within it.
+type QueuePosition struct {
+	Before   string
+	After    string
+	Priority *Priority
+}
+
+// PoolStatus is one worker pool's share of the queue.
+type PoolStatus struct {
+	Name          string
+	MaxConcurrent int
+	Running       int
+	Queued        int
+}
+
+// QueueStatus is a snapshot of the queue for GET /operations/queue.
+type QueueStatus struct {
+	Paused        bool
+	Workers       int
+	MaxConcurrent int
+	Running       int
+	Pools         []PoolStatus
+	// Queued lists waiting ops in dispatch order.
+	Queued []database.OperationV2Row
+}
+
+// SetQueueLimits wires the global and per-pool running caps. fn is read on
+// every dispatch cycle, so config changes apply without a restart. Safe
+// to call with nil, which leaves only the worker count.
+func (r *Registry) SetQueueLimits(fn func() QueueLimits) {
+	r.mu.Lock()
+	r.queueLimits = fn
+	r.mu.Unlock()
+}
+
+func (r *Registry) limits() QueueLimits {
+	r.mu.RLock()
+	fn := r.queueLimits
+	r.mu.RUnlock()
+	if fn == nil {
+		return QueueLimits{}
+	}
+	return fn()
+}
+
+// PauseQueue stops the dispatcher from starting queued ops. Running ops
+// finish normally and new ops still queue.
+func (r *Registry) PauseQueue() {
+	if !r.paused.Swap(true) {
+		r.logger.Info("registry: queue paused")
+	}
+}
+
+// ResumeQueue undoes PauseQueue.
+func (r *Registry) ResumeQueue() {
+	if r.paused.Swap(false) {
+		r.logger.Info("registry: queue resumed")
+		r.pingDispatch()
+	}
+}
+
+// QueuePaused reports whether PauseQueue is in effect.
+func (r *Registry) QueuePaused() bool {
+	return r.paused.Load()
+}
+
+// poolRunningLocked counts running ops in pool. Caller holds r.mu.
+func (r *Registry) poolRunningLocked(pool string) int {
+	n := 0
+	for _, h := range r.running {
+		if h.pool == pool {
+			n++
+		}
+	}
+	return n
+}
+
+// QueueStatus reports the queue's limits, what is running and what waits.
+func (r *Registry) QueueStatus() (QueueStatus, error) {
+	queued, err := r.store.ListQueuedOperationsV2()
+	if err != nil {
+		return QueueStatus{}, fmt.Errorf("registry: list queued ops: %w", err)
+	}
+	limits := r.limits()
+
+	r.mu.RLock()
+	st := QueueStatus{
+		Paused:        r.paused.Load(),
+		Workers:       r.workers,
+		MaxConcurrent: limits.MaxConcurrent,
+		Running:       len(r.running),
+		Queued:        queued,
+	}
+	pools := map[string]*PoolStatus{}
+	pool := func(name string) *PoolStatus {
+		p, ok := pools[name]
+		if !ok {
+			p = &PoolStatus{Name: name, MaxConcurrent: limits.Pools[name]}
+			pools[name] = p
+		}
+		return p
+	}
+	for name := range limits.Pools {
+		pool(name)
+	}
+	for _, def := range r.defs {
+		if def.Pool != "" {
+			pool(def.Pool)
+		}
+	}
+	for _, h := range r.running {
+		if h.pool != "" {
+			pool(h.pool).Running++
+		}
+	}
+	for _, row := range queued {
+		if def, ok := r.defs[row.DefID]; ok && def.Pool != "" {
+			pool(def.Pool).Queued++
+		}
+	}
+	r.mu.RUnlock()
+
+	for _, p := range pools {
+		st.Pools = append(st.Pools, *p)
+	}
+	sort.Slice(st.Pools, func(i, j int) bool { return st.Pools[i].Name < st.Pools[j].Name })
+	return st, nil
+}
+
+// MoveQueued changes where a queued op sits in the dispatch order.
+//
+// The queue is ordered by priority, then queued_at, so a move next to
+// another op takes that op's priority and a queued_at between it and its
+// neighbour on the moving side.
+func (r *Registry) MoveQueued(opID string, pos QueuePosition) error {
+	mover, ok := r.store.(QueueMover)
+	if !ok {
+		if ps, isPebble := database.AsPebbleStore(r.store); isPebble {
+			mover, ok = ps, true
+		}
+	}
+	if !ok {
+		return errors.New("registry: the store cannot reorder the queue")
+	}
+	queued, err := r.store.ListQueuedOperationsV2()
+	if err != nil {
+		return fmt.Errorf("registry: list queued ops: %w", err)
+	}
+
+	var op *database.OperationV2Row
+	rest := make([]database.OperationV2Row, 0, len(queued))
+	for i := range queued {
+		if queued[i].ID == opID {
+			op = &queued[i]
+			continue
+		}
+		rest = append(rest, queued[i])
+	}
+	if op == nil {
+		return fmt.Errorf("registry: move %s: %w", opID, ErrNotQueued)
+	}
+
+	priority, queuedAt := op.Priority, op.QueuedAt
+	switch {
+	case pos.Before != "" || pos.After != "":
+		target := pos.Before
+		if target == "" {
+			target = pos.After
+		}
+		if target == opID {
+			return nil
+		}
+		ti := -1
+		for i := range rest {
+			if rest[i].ID == target {
+				ti = i
+				break
+			}
+		}
+		if ti < 0 {
+			return fmt.Errorf("registry: move next to %s: %w", target, ErrNotQueued)
+		}
+		t := rest[ti]
+		priority = t.Priority
+		if pos.Before != "" {
+			queuedAt = t.QueuedAt.Add(-time.Millisecond)
+			if ti > 0 && rest[ti-1].Priority == t.Priority {
+				queuedAt = midpoint(rest[ti-1].QueuedAt, t.QueuedAt)
+			}
+		} else {
+			queuedAt = t.QueuedAt.Add(time.Millisecond)
+			if ti+1 < len(rest) && rest[ti+1].Priority == t.Priority {
+				queuedAt = midpoint(t.QueuedAt, rest[ti+1].QueuedAt)
+			}
+		}
+	case pos.Priority != nil:
+		priority = int(*pos.Priority)
+	default:
+		return errors.New("registry: move needs before, after or priority")
+	}
+
+	if err := mover.MoveQueuedOperationV2(opID, priority, queuedAt); err != nil {
+		return fmt.Errorf("registry: move %s: %w", opID, err)
+	}
+	r.pingDispatch()
+	return nil
+}
+
+func midpoint(a, b time.Time) time.Time {
+	return a.Add(b.Sub(a) / 2)
+}
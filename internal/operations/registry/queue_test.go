@@ -0,0 +1,185 @@
+// file: internal/operations/registry/queue_test.go
+// version: 1.0.0
+// guid: 8c1f4e6a-2d9b-4a73-b5e0-6f3c9d2a7b18
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+// peakCounter records the most calls inside it at once.
+type peakCounter struct{ cur, peak int64 }
+
+func (p *peakCounter) enter() {
+	cur := atomic.AddInt64(&p.cur, 1)
+	for {
+		old := atomic.LoadInt64(&p.peak)
+		if cur <= old || atomic.CompareAndSwapInt64(&p.peak, old, cur) {
+			return
+		}
+	}
+}
+
+func (p *peakCounter) leave() { atomic.AddInt64(&p.cur, -1) }
+
+func TestQueue_PauseResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 2, nil)
+	_ = r.RegisterOp(makeValidDef("test.paused"))
+	r.Start(ctx)
+
+	r.PauseQueue()
+	if !r.QueuePaused() {
+		t.Fatal("QueuePaused = false after PauseQueue")
+	}
+	opID, err := r.EnqueueOp(ctx, "test.paused", nil)
+	if err != nil {
+		t.Fatalf("EnqueueOp: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	if got := store.statusOf(opID); got != "queued" {
+		t.Fatalf("paused queue started op: status=%s", got)
+	}
+
+	r.ResumeQueue()
+	awaitStatus(t, store, opID, "completed", 5*time.Second)
+}
+
+// TestQueue_PoolLimit verifies a pool cap spans plugins and the global cap
+// spans pools.
+func TestQueue_PoolLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 8, nil)
+	r.SetQueueLimits(func() registry.QueueLimits {
+		return registry.QueueLimits{MaxConcurrent: 2, Pools: map[string]int{"convert": 1}}
+	})
+
+	var all, convert peakCounter
+	run := func(pooled bool) func(context.Context, json.RawMessage, registry.Reporter) error {
+		return func(context.Context, json.RawMessage, registry.Reporter) error {
+			all.enter()
+			if pooled {
+				convert.enter()
+				defer convert.leave()
+			}
+			defer all.leave()
+			time.Sleep(40 * time.Millisecond)
+			return nil
+		}
+	}
+	for _, id := range []string{"a.convert", "b.convert"} {
+		def := makeValidDef(id)
+		def.Plugin = id
+		def.Pool = "convert"
+		def.Run = run(true)
+		_ = r.RegisterOp(def)
+	}
+	free := makeValidDef("free.op")
+	free.Run = run(false)
+	_ = r.RegisterOp(free)
+	r.Start(ctx)
+
+	var ops []string
+	for _, id := range []string{"a.convert", "b.convert", "a.convert", "free.op", "free.op", "free.op"} {
+		opID, err := r.EnqueueOp(ctx, id, nil)
+		if err != nil {
+			t.Fatalf("EnqueueOp %s: %v", id, err)
+		}
+		ops = append(ops, opID)
+	}
+	for _, opID := range ops {
+		awaitStatus(t, store, opID, "completed", 10*time.Second)
+	}
+	if got := atomic.LoadInt64(&convert.peak); got > 1 {
+		t.Errorf("convert pool cap violated: peak=%d", got)
+	}
+	if got := atomic.LoadInt64(&all.peak); got > 2 {
+		t.Errorf("global cap violated: peak=%d", got)
+	}
+}
+
+func TestQueue_MoveQueued(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newFakeStore()
+	r := registry.New(store, slog.Default(), 2, nil)
+	def := makeValidDef("test.move")
+	def.Pool = "scan"
+	_ = r.RegisterOp(def)
+	r.Start(ctx)
+	r.PauseQueue()
+
+	a, _ := r.EnqueueOp(ctx, "test.move", nil)
+	b, _ := r.EnqueueOp(ctx, "test.move", nil)
+	c, _ := r.EnqueueOp(ctx, "test.move", nil)
+	order := func() []string {
+		st, err := r.QueueStatus()
+		if err != nil {
+			t.Fatalf("QueueStatus: %v", err)
+		}
+		ids := make([]string, len(st.Queued))
+		for i, row := range st.Queued {
+			ids[i] = row.ID
+		}
+		return ids
+	}
+	assertOrder := func(want ...string) {
+		t.Helper()
+		got := order()
+		if len(got) != len(want) {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("order = %v, want %v", got, want)
+			}
+		}
+	}
+
+	if err := r.MoveQueued(c, registry.QueuePosition{Before: b}); err != nil {
+		t.Fatalf("MoveQueued before: %v", err)
+	}
+	assertOrder(a, c, b)
+	if err := r.MoveQueued(a, registry.QueuePosition{After: b}); err != nil {
+		t.Fatalf("MoveQueued after: %v", err)
+	}
+	assertOrder(c, b, a)
+	high := registry.PriorityHigh
+	if err := r.MoveQueued(a, registry.QueuePosition{Priority: &high}); err != nil {
+		t.Fatalf("MoveQueued priority: %v", err)
+	}
+	assertOrder(a, c, b)
+	// Placing next to a higher-priority op takes its priority.
+	if err := r.MoveQueued(b, registry.QueuePosition{Before: a}); err != nil {
+		t.Fatalf("MoveQueued before high: %v", err)
+	}
+	assertOrder(b, a, c)
+
+	st, err := r.QueueStatus()
+	if err != nil {
+		t.Fatalf("QueueStatus: %v", err)
+	}
+	if !st.Paused || len(st.Pools) != 1 || st.Pools[0].Name != "scan" || st.Pools[0].Queued != 3 {
+		t.Errorf("QueueStatus = %+v", st)
+	}
+
+	if err := r.MoveQueued("missing", registry.QueuePosition{Before: a}); !errors.Is(err, registry.ErrNotQueued) {
+		t.Errorf("MoveQueued(missing) err = %v, want ErrNotQueued", err)
+	}
+}
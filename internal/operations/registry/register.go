@@ -1,7 +1,7 @@
 // file: internal/operations/registry/register.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: c3d4e5f6-a7b8-9c0d-1e2f-3a4b5c6d7e8f
-// last-edited: 2026-06-14
+// last-edited: 2026-10-17
 
 package registry
 
@@ -9,6 +9,7 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
 )
@@ -66,7 +67,14 @@ func init() {
 			// OpsV2Store methods from the same concrete *PebbleStore instance.
 			store := serviceregistry.Get[database.Store](c, "store")
 			hub := serviceregistry.Get[*EventHub](c, "ophub")
-			reg := New(store, slog.Default(), 8, hub)
+			// The worker count is fixed at startup, so a max_concurrent above
+			// the default 8 raises it; the cap itself is read live below.
+			workers := max(8, config.Snapshot().OperationQueue.MaxConcurrent)
+			reg := New(store, slog.Default(), workers, hub)
+			reg.SetQueueLimits(func() QueueLimits {
+				q := config.Snapshot().OperationQueue
+				return QueueLimits{MaxConcurrent: q.MaxConcurrent, Pools: q.Pools}
+			})
 
 			// Wire the book store for dep evaluation (ReqFieldSet).
 			// prodSchedulerStore wraps database.Store and adds BookFiles (nil shim).
@@ -1,7 +1,7 @@
 // file: internal/operations/registry/registry.go
-// version: 3.1.1
+// version: 3.8.0
 // guid: f6a7b8c9-d0e1-2f3a-4b5c-6d7e8f9a0b1c
-// last-edited: 2026-06-14
+// last-edited: 2026-10-17
 
 package registry
 
@@ -29,11 +29,15 @@ type Registry struct {
 	pluginRunning    map[string]int        // plugin → count of running ops
 	pluginMax        map[string]int        // plugin → max_concurrent (0 = unlimited)
 	concurrencyKeys  map[string]string     // key → opID of holder
+	leaseWaits       map[string]string     // queued opID → blocker last written to its progress message
 	nextRun          chan *queuedRun
 	dispatch         chan struct{}
 	store            database.OpsV2Store
 	bus              Bus // may be nil; wired in UOS-06
 	activityRecorder ActivityRecorder
+	lifecycle        LifecycleObserver
+	freezer          Freezer
+	guard            RunGuard
 	logger           *slog.Logger
 	workers          int
 	abandoned        *abandonedTracker
@@ -45,6 +49,12 @@ type Registry struct {
 	// and panic with "pebble: closed".
 	shuttingDown atomic.Bool
 
+	// paused stops the dispatcher starting queued ops (PauseQueue).
+	paused atomic.Bool
+	// queueLimits supplies the global and per-pool running caps; nil
+	// leaves only the worker count. Set via SetQueueLimits.
+	queueLimits func() QueueLimits
+
 	// depsScheduler is the optional dependency-scheduling coordinator.
 	// Set via SetDepsScheduler before Start(). Nil is safe: worker hooks
 	// check for nil before notifying.
@@ -58,6 +68,10 @@ type Registry struct {
 	// batch manages the M3 per-op-type debounce buckets for Batchable ops.
 	batch *batchManager
 
+	// interactive holds the IDs of ops enqueued from a user-facing request
+	// (see WithInteractive) until they finish or are canceled.
+	interactive map[string]struct{}
+
 	// cancelFn cancels the internal goroutine context created in Start().
 	// Shutdown() calls this after draining running ops to stop the
 	// dispatcher, watchdog, and idle workers before returning.
@@ -111,6 +125,7 @@ func NewWithOptions(store database.OpsV2Store, logger *slog.Logger, workers int,
 		pluginRunning:    make(map[string]int),
 		pluginMax:        make(map[string]int),
 		concurrencyKeys:  make(map[string]string),
+		leaseWaits:       make(map[string]string),
 		nextRun:          make(chan *queuedRun, workers*2),
 		dispatch:         make(chan struct{}, 1),
 		store:            store,
@@ -122,6 +137,7 @@ func NewWithOptions(store database.OpsV2Store, logger *slog.Logger, workers int,
 		abandonGrace:     opts.AbandonGrace,
 		sweepInterval:    opts.SweepInterval,
 		batch:            newBatchManager(),
+		interactive:      make(map[string]struct{}),
 	}
 }
 
@@ -426,6 +442,12 @@ func (r *Registry) EnqueueOp(ctx context.Context, defID string, params any, opts
 	if !ok {
 		return "", fmt.Errorf("registry: unknown defID %q", defID)
 	}
+	if err := validateEnqueueParams(def, params); err != nil {
+		return "", err
+	}
+	if err := r.guardDestructive(def); err != nil {
+		return "", err
+	}
 
 	// --- M3 Batching: intercept before ConcurrencyKey dedupe ---
 	// For Batchable ops we bucket the subject and return early.
@@ -595,6 +617,12 @@ func (r *Registry) EnqueueOp(ctx context.Context, defID string, params any, opts
 		r.logger.Info("registry: enqueued op", "op_id", opID, "def_id", defID, "priority", priority)
 	}
 
+	if isInteractive(ctx) {
+		r.mu.Lock()
+		r.interactive[opID] = struct{}{}
+		r.mu.Unlock()
+	}
+
 	r.publishOpCreated(row, false)
 
 	// Signal the dispatcher only for queued ops (waiting_deps are not dispatchable).
@@ -667,10 +695,11 @@ func subjectsFromParams(params json.RawMessage) []Subject {
 
 // publishOpCreated fans out an op.created SSE event so the UI's operations
 // bell can pick up newly enqueued OR server-resumed ops without waiting for
-// the next op.updated event. The "resumed" flag distinguishes startup
+// the next op.updated event, and reports the created lifecycle event. The "resumed" flag distinguishes startup
 // resume from a fresh enqueue so the client can render a "Resumed" badge
 // if desired (currently it just triggers loadFromServer()).
 func (r *Registry) publishOpCreated(row database.OperationV2Row, resumed bool) {
+	r.notifyLifecycle(lifecycleFromRow(row, resumed))
 	if r.bus == nil {
 		return
 	}
@@ -705,6 +734,9 @@ func (r *Registry) Cancel(opID string) error {
 	}
 	if updated {
 		r.logger.Info("registry: canceled queued op", "op_id", opID)
+		r.mu.Lock()
+		delete(r.interactive, opID)
+		r.mu.Unlock()
 	}
 	return nil
 }
@@ -864,6 +896,7 @@ func (r *Registry) pingDispatch() {
 func (r *Registry) releaseRunHandle(opID string) {
 	r.mu.Lock()
 	h, ok := r.running[opID]
+	delete(r.interactive, opID)
 	if ok {
 		delete(r.running, opID)
 		if h.plugin != "" {
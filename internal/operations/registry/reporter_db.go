@@ -1,7 +1,7 @@
 // file: internal/operations/registry/reporter_db.go
-// version: 1.3.1
+// version: 1.4.0
 // guid: 1a2b3c4d-5e6f-7890-abcd-ef0123456789
-// last-edited: 2026-06-01
+// last-edited: 2026-10-16
 
 package registry
 
@@ -62,10 +62,15 @@ type dbReporter struct {
 	progressMu          sync.Mutex
 	progressCurrent     int
 	lastProgressMessage string
+	lastMilestone       int
 
 	// setCurrentItemFn, if non-nil, updates the runHandle's in-memory label.
 	setCurrentItemFn func(string)
 
+	// onMilestone, if non-nil, is called once per progress milestone
+	// (see progressMilestone) the run crosses.
+	onMilestone func(percent, current, total int, message string)
+
 	runCtx context.Context
 }
 
@@ -240,6 +245,11 @@ func (r *dbReporter) UpdateProgress(current, total int, message string) error {
 	last := r.lastProgressMessage
 	r.progressCurrent = current
 	r.lastProgressMessage = message
+	milestone := progressMilestone(current, total)
+	crossed := milestone > r.lastMilestone
+	if crossed {
+		r.lastMilestone = milestone
+	}
 	r.progressMu.Unlock()
 
 	if err := r.store.UpdateOpProgressV2(r.opID, current, total, message); err != nil {
@@ -252,6 +262,9 @@ func (r *dbReporter) UpdateProgress(current, total int, message string) error {
 			"progress_total":   total,
 		})
 	}
+	if crossed && r.onMilestone != nil {
+		r.onMilestone(milestone, current, total, message)
+	}
 	// Emit one log line per *distinct* progress message so the op_log feed
 	// has a searchable trail of the phases the Run went through. Skipping
 	// duplicates keeps a 50K-row scan from producing 50K log lines.
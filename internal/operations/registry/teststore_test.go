@@ -1,7 +1,7 @@
 // file: internal/operations/registry/teststore_test.go
-// version: 2.7.0
+// version: 2.8.0
 // guid: c9d0e1f2-a3b4-5c6d-7e8f-9a0b1c2d3e4f
-// last-edited: 2026-06-14
+// last-edited: 2026-10-17
 
 package registry_test
 
@@ -539,6 +539,23 @@ func (f *fakeStore) PromoteToQueued(id string) error {
 	return nil
 }
 
+// MoveQueuedOperationV2 mirrors PebbleStore: only queued ops move.
+func (f *fakeStore) MoveQueuedOperationV2(id string, priority int, queuedAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	op, ok := f.ops[id]
+	if !ok {
+		return fmt.Errorf("fakeStore: MoveQueuedOperationV2: op %s not found", id)
+	}
+	if op.Status != "queued" {
+		return database.ErrOperationNotQueued
+	}
+	op.Priority = priority
+	op.QueuedAt = queuedAt
+	f.ops[id] = op
+	return nil
+}
+
 // --- M3 batch bucket ---
 
 func (f *fakeStore) AddToBatchBucket(opType string, sub database.OpSubject) error {
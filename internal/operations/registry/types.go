@@ -1,7 +1,7 @@
 // file: internal/operations/registry/types.go
-// version: 2.3.0
+// version: 2.7.0
 // guid: d4e5f6a7-b8c9-0d1e-2f3a-4b5c6d7e8f9a
-// last-edited: 2026-06-13
+// last-edited: 2026-10-17
 
 // Package registry provides the UOS-02 in-memory OperationDef registry,
 // dispatcher, and in-process worker pool. See the spec at
@@ -53,11 +53,25 @@ type OperationDef struct {
 	// ConcurrencyKey: ops with same non-empty key serialize; empty = no serialization.
 	ConcurrencyKey string
 
+	// PathLeases, if set, lists the directories a run with the given
+	// params works in. A queued run whose leases conflict with a running
+	// op's (see PathLease) waits, with the reason in its progress
+	// message, until that op finishes. Nil = no path coordination.
+	PathLeases func(params json.RawMessage) []PathLease
+
 	// MaxConcurrent is set on the Plugin, not the OperationDef (spec §1).
 	// Per-plugin caps are tracked in Registry.pluginMax via SetPluginMaxConcurrent.
 
+	// Pool names the worker pool the op shares a running limit with
+	// across plugins, e.g. "scan", "organize" or "convert". Limits come
+	// from SetQueueLimits; empty = only the global limit applies.
+	Pool string
+
 	// Inputs. Optional.
-	ParamsSchema *json.RawMessage // if set, params validated before enqueue
+	// ParamsSchema, if set, is a JSON Schema (see ValidateParams for the
+	// supported subset) that EnqueueOp checks params against; failures
+	// return a *ParamsError and nothing is enqueued.
+	ParamsSchema *json.RawMessage
 
 	// Permissions. Optional.
 	Permissions  []auth.Permission // user perms required to trigger via API
@@ -117,6 +131,12 @@ type OperationDef struct {
 	// in the activity timeline only — use for background/single-book ops that
 	// don't need to interrupt the user.
 	NotifyLevel NotifyLevel
+
+	// Destructive marks ops that delete, convert, or move library files in
+	// bulk. Before such a run starts the registry asks its Freezer (if one
+	// is wired) to snapshot the library, so the run can be undone by
+	// operation ID. A failed snapshot fails the run without calling Run.
+	Destructive bool
 }
 
 // ResumePolicy controls what happens when the server restarts with an
@@ -1,7 +1,7 @@
 // file: internal/operations/registry/worker.go
-// version: 2.6.0
+// version: 2.11.0
 // guid: b8c9d0e1-f2a3-4b5c-6d7e-8f9a0b1c2d3e
-// last-edited: 2026-06-14
+// last-edited: 2026-10-17
 
 package registry
 
@@ -44,8 +44,10 @@ type runHandle struct {
 	id             string
 	defID          string
 	plugin         string
+	pool           string
 	concurrencyKey string
 	resumePolicy   ResumePolicy
+	leases         []PathLease
 	cancel         context.CancelFunc
 	abandoned      bool
 	currentItem    string
@@ -89,7 +91,9 @@ type queuedRun struct {
 	priority     Priority
 	concurrKey   string
 	plugin       string
+	pool         string
 	resumePolicy ResumePolicy
+	leases       []PathLease
 }
 
 // startWorker is a long-running goroutine that reads from r.nextRun and
@@ -157,8 +161,10 @@ func (r *Registry) executeRun(parentCtx context.Context, qr *queuedRun) (wasAban
 		id:             qr.opID,
 		defID:          qr.defID,
 		plugin:         qr.plugin,
+		pool:           qr.pool,
 		concurrencyKey: qr.concurrKey,
 		resumePolicy:   qr.resumePolicy,
+		leases:         qr.leases,
 		cancel:         cancel,
 	}
 	r.mu.Lock()
@@ -179,6 +185,15 @@ func (r *Registry) executeRun(parentCtx context.Context, qr *queuedRun) (wasAban
 	reporter := newDBReporter(runCtx, qr.opID, qr.defID, def.DisplayName, qr.plugin,
 		"", "", // traceID / spanID loaded from DB row in future; empty for now
 		r.store, r.bus, r.activityRecorder, r.logger, setItemFn)
+	if dr, ok := reporter.(*dbReporter); ok {
+		dr.onMilestone = func(percent, current, total int, message string) {
+			r.notifyLifecycle(LifecycleEvent{
+				Type: LifecycleProgress, OpID: qr.opID, DefID: qr.defID, Plugin: qr.plugin,
+				Status: "running", Params: qr.params, Percent: percent,
+				ProgressCurrent: current, ProgressTotal: total, ProgressMessage: message,
+			})
+		}
+	}
 
 	// Canonical "operation started" log line, with all the tags downstream
 	// readers (op_log feed, activity-log enricher, digest aggregator) need
@@ -193,6 +208,35 @@ func (r *Registry) executeRun(parentCtx context.Context, qr *queuedRun) (wasAban
 		slog.Int("priority", int(def.DefaultPriority)),
 		slog.String("concurrency_key", def.ConcurrencyKey),
 	)
+	r.notifyLifecycle(LifecycleEvent{
+		Type: LifecycleStarted, OpID: qr.opID, DefID: qr.defID, Plugin: qr.plugin,
+		Status: "running", Params: qr.params,
+	})
+
+	// Destructive ops snapshot the library first; without a snapshot the
+	// run cannot be undone, so a failure here fails the run. So does a
+	// RunGuard veto, e.g. while another instance holds the library.
+	preRunErr := r.guardDestructive(def)
+	if f := r.currentFreezer(); preRunErr == nil && def.Destructive && f != nil {
+		if err := f.Freeze(runCtx, qr.opID, qr.defID, qr.params); err != nil {
+			preRunErr = fmt.Errorf("pre-run snapshot failed: %w", err)
+		}
+	}
+	if preRunErr != nil {
+		r.releaseRunHandle(qr.opID)
+		completedAt := time.Now().UTC()
+		msg := preRunErr.Error()
+		if uerr := r.store.UpdateOperationV2Status(qr.opID, "failed", nil, &completedAt, &msg); uerr != nil {
+			r.logger.Warn("registry: failed to update op terminal status", "op_id", qr.opID, "error", uerr)
+		}
+		for _, sub := range subjectsFromParams(qr.params) {
+			r.notifyDepFailed(sub, qr.defID)
+		}
+		emitOpFinishedLog(runCtx, reporter, runStartedAt, "failed", preRunErr, false)
+		r.notifyTerminal(qr, "failed", preRunErr, runStartedAt)
+		r.logger.Warn("registry: pre-run check failed", "op_id", qr.opID, "def_id", qr.defID, "error", preRunErr)
+		return false
+	}
 
 	// Subprocess path (Isolate=true): re-exec self.
 	if def.Isolate {
@@ -214,6 +258,7 @@ func (r *Registry) executeRun(parentCtx context.Context, qr *queuedRun) (wasAban
 			r.logger.Warn("registry: failed to update subprocess op terminal status", "op_id", qr.opID, "error", err)
 		}
 		emitOpFinishedLog(runCtx, reporter, runStartedAt, finalStatus, runErr, true)
+		r.notifyTerminal(qr, finalStatus, runErr, runStartedAt)
 		r.logger.Info("registry: subprocess run finished", "op_id", qr.opID, "status", finalStatus)
 		return false
 	}
@@ -323,6 +368,7 @@ func (r *Registry) executeRun(parentCtx context.Context, qr *queuedRun) (wasAban
 	}
 
 	emitOpFinishedLog(runCtx, reporter, runStartedAt, finalStatus, runErr, false)
+	r.notifyTerminal(qr, finalStatus, runErr, runStartedAt)
 	r.logger.Info("registry: run finished", "op_id", qr.opID, "status", finalStatus)
 	return false
 }
@@ -1,7 +1,7 @@
 // file: internal/operations/state.go
-// version: 1.5.0
+// version: 1.6.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
-// last-edited: 2026-05-03
+// last-edited: 2026-10-16
 
 package operations
 
@@ -38,6 +38,7 @@ type ITunesImportParams struct {
 type ScanParams struct {
 	FolderPath  *string `json:"folder_path,omitempty"`
 	ForceUpdate bool    `json:"force_update"`
+	Profile     string  `json:"profile,omitempty"`
 }
 
 // OrganizeParams stores the immutable parameters for an organize operation.
@@ -0,0 +1,138 @@
+// file: internal/organizer/disambiguate.go
+// version: 1.0.0
+// guid: 6a1d3f85-2c47-4e9b-8b06-f3e2a9c7d514
+// last-edited: 2026-10-16
+
+package organizer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// disambiguationPlaceholder expands to the suffix that separates two
+// different books whose patterns produce the same path — "Endurance" by
+// Lansing and "Endurance" by Kelly under a "{title}" pattern. It is empty
+// (and its segment dropped) unless there is such a collision. Patterns
+// that don't place it get it appended as " ({disambiguation})" to the
+// file name, or to the folder for directory books.
+const disambiguationPlaceholder = "{disambiguation}"
+
+// withDisambiguation returns pattern with the placeholder appended
+// unless it, or one of the patterns it's combined with, already places it.
+func withDisambiguation(pattern string, combined ...string) string {
+	for _, p := range append(combined, pattern) {
+		if strings.Contains(strings.ToLower(p), disambiguationPlaceholder) {
+			return pattern
+		}
+	}
+	return pattern + " (" + disambiguationPlaceholder + ")"
+}
+
+// disambiguate returns build("") unless that path is already owned by a
+// different book, in which case it tries a suffix for each configured
+// disambiguation rule the book has a value for and returns the first
+// free path. When every candidate collides the plain path comes back, so
+// the caller's usual target-occupied handling applies.
+//
+// owners lists the books filed at a candidate path. Books that share the
+// title, author and narrator identity are versions of the same book, not
+// collisions; they're left to dedup.
+func (o *Organizer) disambiguate(book *database.Book, owners func(path string) []database.Book, build func(suffix string) (string, error)) (string, error) {
+	base, err := build("")
+	if err != nil || o.store == nil {
+		return base, err
+	}
+	identity := database.BookIdentityOf(o.store, book)
+	collides := func(path string) bool {
+		others := owners(path)
+		for i := range others {
+			other := &others[i]
+			if other.ID != book.ID && !identity.SameBook(database.BookIdentityOf(o.store, other)) {
+				return true
+			}
+		}
+		return false
+	}
+	if !collides(base) {
+		return base, nil
+	}
+	rules := o.config.DisambiguationRules
+	if len(rules) == 0 {
+		rules = config.DefaultDisambiguationRules
+	}
+	for _, rule := range rules {
+		suffix := disambiguationValue(rule, book)
+		if suffix == "" {
+			continue
+		}
+		path, err := build(suffix)
+		if err != nil {
+			return "", err
+		}
+		if path != base && !collides(path) {
+			return path, nil
+		}
+	}
+	return base, nil
+}
+
+// fileOwners lists the book whose file is path.
+func (o *Organizer) fileOwners(path string) []database.Book {
+	if b, err := o.store.GetBookByFilePath(path); err == nil && b != nil {
+		return []database.Book{*b}
+	}
+	return nil
+}
+
+// dirOwners lists the books filed at dir: a directory book whose
+// file_path is dir itself, and books whose files sit directly in it.
+func (o *Organizer) dirOwners(dir string) []database.Book {
+	out := o.fileOwners(dir)
+	if lister, ok := database.AsBookDirLister(o.store); ok {
+		if books, err := lister.GetBooksInDir(dir); err == nil {
+			out = append(out, books...)
+		}
+	}
+	return out
+}
+
+// disambiguationValue is book's value for one rule, "" when it has none.
+func disambiguationValue(rule string, book *database.Book) string {
+	year := func() string {
+		for _, y := range []*int{book.PrintYear, book.AudiobookReleaseYear} {
+			if y != nil && *y > 0 {
+				return fmt.Sprintf("%d", *y)
+			}
+		}
+		return ""
+	}
+	var v string
+	switch rule {
+	case "narrator":
+		v = stringOrEmpty(book.Narrator)
+	case "year":
+		v = year()
+	case "edition":
+		v = stringOrEmpty(book.Edition)
+	case "publisher":
+		v = stringOrEmpty(book.Publisher)
+	case "language":
+		v = stringOrEmpty(book.Language)
+	case "asin":
+		v = stringOrEmpty(book.ASIN)
+	case "id":
+		// The tail of a ULID is its random part; eight characters is
+		// plenty to separate two books that agree on everything else.
+		v = book.ID
+		if len(v) > 8 {
+			v = v[len(v)-8:]
+		}
+	}
+	// The suffix lands inside one path segment.
+	v = strings.NewReplacer("/", " ", "\\", " ").Replace(v)
+	return strings.TrimSpace(v)
+}
@@ -0,0 +1,121 @@
+// file: internal/organizer/disambiguate_test.go
+// version: 1.0.0
+// guid: d27f4a09-8e13-4b6c-a5f2-1c9b0e6d3a78
+
+package organizer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func newDisambiguationStore(t *testing.T) *database.PebbleStore {
+	t.Helper()
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("pebble open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func fileBook(t *testing.T, store *database.PebbleStore, id, title, author, narrator, path string) *database.Book {
+	t.Helper()
+	a, err := store.CreateAuthor(author)
+	if err != nil {
+		t.Fatalf("create author: %v", err)
+	}
+	book := &database.Book{ID: id, Title: title, AuthorID: &a.ID, FilePath: path}
+	if narrator != "" {
+		book.Narrator = &narrator
+	}
+	if path != "" {
+		if _, err := store.CreateBook(book); err != nil {
+			t.Fatalf("create book: %v", err)
+		}
+	}
+	return book
+}
+
+func TestGenerateTargetPath_SameTitleDifferentAuthor(t *testing.T) {
+	root := t.TempDir()
+	store := newDisambiguationStore(t)
+	org := &Organizer{config: &config.Config{RootDir: root, FolderNamingPattern: "{title}", FileNamingPattern: "{title}"}, store: store}
+
+	plain := filepath.Join(root, "Endurance", "Endurance.m4b")
+	lansing := fileBook(t, store, "b-lansing", "Endurance", "Alfred Lansing", "Simon Prebble", plain)
+
+	// The occupant keeps its path.
+	if got, err := org.generateTargetPath(lansing); err != nil || got != plain {
+		t.Fatalf("occupant: got %q, %v; want %q", got, err, plain)
+	}
+
+	// A different Endurance gets the first rule it has a value for.
+	kelly := fileBook(t, store, "b-kelly", "Endurance", "Scott Kelly", "Scott Kelly", "/incoming/kelly.m4b")
+	got, err := org.generateTargetPath(kelly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "Endurance", "Endurance (Scott Kelly).m4b"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Another copy of the same book is a version, not a collision.
+	copyOf := fileBook(t, store, "b-lansing-2", "Endurance", "Alfred Lansing", "", "/incoming/lansing.m4b")
+	if got, err := org.generateTargetPath(copyOf); err != nil || got != plain {
+		t.Errorf("same identity: got %q, %v; want %q", got, err, plain)
+	}
+}
+
+func TestGenerateTargetPath_DisambiguationFallsThroughRules(t *testing.T) {
+	root := t.TempDir()
+	store := newDisambiguationStore(t)
+	org := &Organizer{config: &config.Config{
+		RootDir:             root,
+		FolderNamingPattern: "books",
+		FileNamingPattern:   "{title} - {disambiguation}",
+		DisambiguationRules: []string{"narrator", "year", "id"},
+	}, store: store}
+
+	fileBook(t, store, "b1", "Endurance", "Alfred Lansing", "", filepath.Join(root, "books", "Endurance.mp3"))
+	// Narrator suffix is taken too, by yet another Endurance.
+	fileBook(t, store, "b2", "Endurance", "Someone Else", "", filepath.Join(root, "books", "Endurance - Jane Doe.mp3"))
+
+	book := fileBook(t, store, "01J0000000000000ABCD1234", "Endurance", "Scott Kelly", "Jane Doe", "")
+	book.FilePath = "/in/x.mp3"
+	got, err := org.generateTargetPath(book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "books", "Endurance - ABCD1234.mp3"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGenerateTargetDirPath_Disambiguates(t *testing.T) {
+	root := t.TempDir()
+	store := newDisambiguationStore(t)
+	org := &Organizer{config: &config.Config{RootDir: root, FolderNamingPattern: "{title}", FileNamingPattern: "{title}"}, store: store}
+
+	// A single-file book filed in the folder claims it.
+	fileBook(t, store, "b1", "Endurance", "Alfred Lansing", "", filepath.Join(root, "Endurance", "Endurance.m4b"))
+
+	year := 2017
+	kelly := &database.Book{ID: "b2", Title: "Endurance", Author: &database.Author{Name: "Scott Kelly"}, PrintYear: &year}
+	got, err := org.GenerateTargetDirPath(kelly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "Endurance (2017)"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// No store, no lookups: the plain path.
+	org.store = nil
+	if got, _ := org.GenerateTargetDirPath(kelly); got != filepath.Join(root, "Endurance") {
+		t.Errorf("without store got %q", got)
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/organizer/organizer.go
-// version: 1.17.1
+// version: 1.23.0
 // guid: 5e6f7a8b-9c0d-1e2f-3a4b-5c6d7e8f9a0b
 
 package organizer
@@ -61,11 +61,16 @@ const (
 	defaultTitle    = "Unknown Title"
 	defaultNarrator = "narrator"
 	tempFileSuffix  = ".tmp"
+
+	// maxPathAuthors is how many names {authors} spells out before it
+	// shortens to "<first> et al." to keep anthology paths short.
+	maxPathAuthors = 3
 )
 
 var (
 	leftoverPlaceholderRegex  = regexp.MustCompile(`\{[^}]+\}`)
 	placeholderNormalizeRegex = regexp.MustCompile(`\{[A-Za-z_]+\}`)
+	seriesNumberSpecRegex     = regexp.MustCompile(`\{series_num(?:ber)?(?::([^}]+))?\}`)
 	tempCleanupOnce           sync.Once
 )
 
@@ -211,12 +216,26 @@ func (o *Organizer) GenerateTargetPath(book *database.Book) (string, error) {
 
 // GenerateTargetDirPath returns the target directory path for a directory-based
 // (multi-file) book. It uses the folder naming pattern only (no file name).
+// A folder already filed under a different book with the same title
+// gets a disambiguation suffix (see disambiguate).
 func (o *Organizer) GenerateTargetDirPath(book *database.Book) (string, error) {
-	folderPath, err := o.expandPattern(o.config.FolderNamingPattern, book)
+	return o.disambiguate(book, o.dirOwners, func(suffix string) (string, error) {
+		return o.buildTargetDirPath(book, suffix)
+	})
+}
+
+// buildTargetDirPath is GenerateTargetDirPath with the given
+// disambiguation suffix ("" for none).
+func (o *Organizer) buildTargetDirPath(book *database.Book, disambiguation string) (string, error) {
+	folderPattern, err := o.folderPattern(book)
+	if err != nil {
+		return "", err
+	}
+	folderPath, err := o.expandPatternWith(withDisambiguation(folderPattern), book, disambiguation)
 	if err != nil {
 		return "", fmt.Errorf("folder pattern: %w", err)
 	}
-	folderPath = sanitizePath(folderPath)
+	folderPath = sanitizeRulesFor(o.config).path(folderPath)
 	result := filepath.Join(o.config.RootDir, folderPath)
 	if err := ensureUnderRoot(result, o.config.RootDir); err != nil {
 		return "", err
@@ -224,24 +243,31 @@ func (o *Organizer) GenerateTargetDirPath(book *database.Book) (string, error) {
 	return result, nil
 }
 
-// generateTargetPath creates the target file path based on naming patterns
+// generateTargetPath creates the target file path based on naming
+// patterns, disambiguating it when a different book with the same title
+// already owns it.
 func (o *Organizer) generateTargetPath(book *database.Book) (string, error) {
+	return o.disambiguate(book, o.fileOwners, func(suffix string) (string, error) {
+		return o.buildTargetPath(book, suffix)
+	})
+}
+
+// buildTargetPath expands the naming patterns with the given
+// disambiguation suffix ("" for none).
+func (o *Organizer) buildTargetPath(book *database.Book, disambiguation string) (string, error) {
 	// Get file extension
 	ext := filepath.Ext(book.FilePath)
 
-	// Generate folder path
-	folderPath, err := o.expandPattern(o.config.FolderNamingPattern, book)
+	// Generate folder path from the configured layout
+	folderPattern, err := o.folderPattern(book)
 	if err != nil {
-		return "", fmt.Errorf("folder pattern: %w", err)
+		return "", err
 	}
-	folderPath = sanitizePath(folderPath)
-
-	// Generate file name
-	fileName, err := o.expandPattern(o.config.FileNamingPattern, book)
+	folderPath, fileName, err := o.renderNames(folderPattern, withDisambiguation(o.config.FileNamingPattern, folderPattern), book, disambiguation)
 	if err != nil {
-		return "", fmt.Errorf("file pattern: %w", err)
+		return "", err
 	}
-	fileName = sanitizeFilename(fileName) + ext
+	fileName += ext
 
 	// When iTunes path trimming is enabled, shorten the filename stem so the
 	// Windows-equivalent path stays under MAX_PATH (260 chars). This uses
@@ -273,29 +299,51 @@ func (o *Organizer) generateTargetPath(book *database.Book) (string, error) {
 	return fullPath, nil
 }
 
+// renderNames expands the folder and file patterns for book and applies
+// the sanitize rules, giving the folder relative to RootDir and the file
+// name without its extension.
+func (o *Organizer) renderNames(folderPattern, filePattern string, book *database.Book, disambiguation string) (string, string, error) {
+	folderPath, err := o.expandPatternWith(folderPattern, book, disambiguation)
+	if err != nil {
+		return "", "", fmt.Errorf("folder pattern: %w", err)
+	}
+	fileName, err := o.expandPatternWith(filePattern, book, disambiguation)
+	if err != nil {
+		return "", "", fmt.Errorf("file pattern: %w", err)
+	}
+	rules := sanitizeRulesFor(o.config)
+	return rules.path(folderPath), rules.segment(fileName), nil
+}
+
 // expandPattern expands a pattern with book metadata
 func (o *Organizer) expandPattern(pattern string, book *database.Book) (string, error) {
+	return o.expandPatternWith(pattern, book, "")
+}
+
+// expandPatternWith expands a pattern with book metadata, filling
+// {disambiguation} with the given suffix. See pattern.go for the syntax.
+func (o *Organizer) expandPatternWith(pattern string, book *database.Book, disambiguation string) (string, error) {
 	result := placeholderNormalizeRegex.ReplaceAllStringFunc(pattern, strings.ToLower)
 
 	// Get author name - look up by ID if Author object is nil but AuthorID is set
-	authorName := "Unknown Author"
+	authorName := ""
 	if book.Author != nil {
-		if trimmed := strings.TrimSpace(book.Author.Name); trimmed != "" {
-			authorName = trimmed
-		}
+		authorName = strings.TrimSpace(book.Author.Name)
 	} else if book.AuthorID != nil && o.store != nil {
 		// Author object not populated, but we have an ID - look it up
 		author, err := o.store.GetAuthorByID(*book.AuthorID)
 		if err == nil && author != nil {
-			if trimmed := strings.TrimSpace(author.Name); trimmed != "" {
-				authorName = trimmed
-			}
+			authorName = strings.TrimSpace(author.Name)
 		}
 	}
+	author := placeholderValue{text: authorName, set: authorName != ""}
+	if !author.set {
+		author.text = "Unknown Author"
+	}
 
-	title := strings.TrimSpace(book.Title)
-	if title == "" {
-		title = defaultTitle
+	title := placeholderValue{text: strings.TrimSpace(book.Title), set: strings.TrimSpace(book.Title) != ""}
+	if !title.set {
+		title.text = defaultTitle
 	}
 
 	// Get series info - look up by ID if Series object is nil but SeriesID is set
@@ -311,8 +359,14 @@ func (o *Organizer) expandPattern(pattern string, book *database.Book) (string,
 	}
 
 	seriesNum := ""
-	if book.SeriesSequence != nil && *book.SeriesSequence > 0 {
-		seriesNum = fmt.Sprintf("%d", *book.SeriesSequence)
+	if seq := book.SeriesSequence; seq != nil && seq.String() != "" && seq.String() != "0" {
+		seriesNum = seq.String()
+	}
+	// Without a position, {series_number:04.1f} / {series_num:02d} become
+	// the bare {series_number} so the empty-segment pass below drops them
+	// with their segment; with one, the spec is applied at render time.
+	if seriesNum == "" {
+		result = seriesNumberSpecRegex.ReplaceAllString(result, "{series_number}")
 	}
 
 	// Helper to convert int pointer to string
@@ -323,39 +377,77 @@ func (o *Organizer) expandPattern(pattern string, book *database.Book) (string,
 		return fmt.Sprintf("%d", *i)
 	}
 
-	narrator := strings.TrimSpace(stringOrEmpty(book.Narrator))
-	if narrator == "" {
-		narrator = defaultNarrator
+	narrator := placeholderValue{text: strings.TrimSpace(stringOrEmpty(book.Narrator))}
+	narrator.set = narrator.text != ""
+	if !narrator.set {
+		narrator.text = defaultNarrator
 	}
 
 	// Replacements map
 	replacements := map[string]string{
-		"{title}":         title,
-		"{author}":        authorName,
-		"{series}":        seriesName,
-		"{series_number}": seriesNum,
-		"{narrator}":      narrator,
-		"{publisher}":     stringOrEmpty(book.Publisher),
-		"{language}":      stringOrEmpty(book.Language),
-		"{edition}":       stringOrEmpty(book.Edition),
-		"{print_year}":    intToString(book.PrintYear),
-		"{year}":          intToString(book.PrintYear),
-		"{isbn10}":        stringOrEmpty(book.ISBN10),
-		"{isbn13}":        stringOrEmpty(book.ISBN13),
-		"{bitrate}":       intToString(book.Bitrate),
-		"{codec}":         stringOrEmpty(book.Codec),
-		"{quality}":       stringOrEmpty(book.Quality),
-	}
-
-	// Perform replacements
-	for placeholder, value := range replacements {
-		if strings.TrimSpace(value) == "" {
-			result = removeEmptySegment(result, placeholder)
-			result = strings.ReplaceAll(result, placeholder, "")
-		} else {
-			result = strings.ReplaceAll(result, placeholder, value)
+		"series":         seriesName,
+		"series_number":  seriesNum,
+		"publisher":      stringOrEmpty(book.Publisher),
+		"language":       stringOrEmpty(book.Language),
+		"edition":        stringOrEmpty(book.Edition),
+		"print_year":     intToString(book.PrintYear),
+		"year":           intToString(book.PrintYear),
+		"isbn10":         stringOrEmpty(book.ISBN10),
+		"isbn13":         stringOrEmpty(book.ISBN13),
+		"bitrate":        intToString(book.Bitrate),
+		"codec":          stringOrEmpty(book.Codec),
+		"quality":        stringOrEmpty(book.Quality),
+		"genre":          primaryGenre(book),
+		"author_initial": authorInitial(author.text),
+		"disambiguation": disambiguation,
+	}
+	if strings.Contains(result, "{authors}") || strings.Contains(result, "{first_author}") {
+		names := o.contributorNames(book, author.text)
+		replacements["authors"] = joinAuthorNames(names)
+		replacements["first_author"] = names[0]
+	}
+	if strings.Contains(result, "{custom_") {
+		for placeholder, value := range o.customFieldReplacements(book) {
+			replacements[strings.Trim(placeholder, "{}")] = value
 		}
 	}
+	values := map[string]placeholderValue{
+		"title":    title,
+		"author":   author,
+		"narrator": narrator,
+	}
+	for name, value := range replacements {
+		value = strings.TrimSpace(value)
+		values[name] = placeholderValue{text: value, set: value != ""}
+	}
+	values["series_num"] = values["series_number"]
+
+	// Empty placeholders take their " - " or parentheses with them.
+	for name, value := range values {
+		if !value.set && value.text == "" {
+			result = removeEmptySegment(result, "{"+name+"}")
+		}
+	}
+
+	parsed, err := ParsePattern(result)
+	if err != nil {
+		return "", err
+	}
+	result = parsed.render(func(name, spec string) (placeholderValue, bool) {
+		v, ok := values[name]
+		if !ok {
+			return v, false
+		}
+		if spec != "" {
+			if (name != "series_number" && name != "series_num") || !v.set {
+				return v, false
+			}
+			v.text = book.SeriesSequence.Format(spec)
+		}
+		// A value is one path segment, whatever the metadata holds.
+		v.text = scrubVar(v.text)
+		return v, true
+	})
 
 	result = cleanupPattern(result)
 	if leftoverPlaceholderRegex.MatchString(result) {
@@ -365,6 +457,63 @@ func (o *Organizer) expandPattern(pattern string, book *database.Book) (string,
 	return result, nil
 }
 
+// contributorNames returns the names of the book's writing authors
+// (authors and co-authors, not editors or translators) in credit order,
+// from book.Authors or else the store's book_authors rows. Books without
+// contributor rows fall back to primary, the resolved {author} value.
+func (o *Organizer) contributorNames(book *database.Book, primary string) []string {
+	if o.store != nil {
+		rows := book.Authors
+		if len(rows) == 0 && book.ID != "" {
+			rows, _ = o.store.GetBookAuthors(book.ID)
+		}
+		if names := database.BookAuthorNames(o.store, rows, true); len(names) > 0 {
+			return names
+		}
+	}
+	return []string{primary}
+}
+
+// joinAuthorNames renders {authors}: "A", "A & B", "A, B & C", and
+// "A et al." past maxPathAuthors.
+func joinAuthorNames(names []string) string {
+	switch {
+	case len(names) > maxPathAuthors:
+		return names[0] + " et al."
+	case len(names) == 1:
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " & " + names[len(names)-1]
+}
+
+// customFieldReplacements maps {custom_<key>} to the book's value for
+// every defined custom metadata field. Fields the book has no value for
+// map to "" so their segment drops like any other missing field; keys
+// that aren't defined stay unresolved and fail the pattern.
+func (o *Organizer) customFieldReplacements(book *database.Book) map[string]string {
+	cfs, ok := database.AsCustomFieldStore(o.store)
+	if !ok {
+		return nil
+	}
+	defs, err := cfs.ListCustomFields()
+	if err != nil || len(defs) == 0 {
+		return nil
+	}
+	values := map[string]string{}
+	if book.ID != "" {
+		if v, err := cfs.GetBookCustomFields(book.ID); err == nil {
+			values = v
+		}
+	}
+	out := make(map[string]string, len(defs))
+	for _, d := range defs {
+		// Values are free text; keep them to a single path segment.
+		v := strings.NewReplacer("/", " ", "\\", " ").Replace(values[d.Key])
+		out["{custom_"+d.Key+"}"] = strings.TrimSpace(v)
+	}
+	return out
+}
+
 // removeEmptySegment removes segments containing empty placeholders
 func removeEmptySegment(pattern, placeholder string) string {
 	patterns := []string{
@@ -398,17 +547,53 @@ func cleanupPattern(pattern string) string {
 	return pattern
 }
 
+// sanitizeRules are the character rules applied to every path segment
+// a naming pattern produces, from naming_replace_char and
+// naming_windows_safe.
+type sanitizeRules struct {
+	// replace stands in for characters filesystems reject; "_" when empty.
+	replace string
+	// windowsSafe also trims trailing dots and spaces and renames
+	// reserved device names (CON, NUL, COM1, ...), which Windows and SMB
+	// shares cannot store.
+	windowsSafe bool
+}
+
+func sanitizeRulesFor(cfg *config.Config) sanitizeRules {
+	if cfg == nil {
+		return sanitizeRules{}
+	}
+	return sanitizeRules{replace: cfg.NamingReplaceChar, windowsSafe: cfg.NamingWindowsSafe}
+}
+
 // sanitizePath sanitizes a path for filesystem use
 func sanitizePath(path string) string {
+	return sanitizeRulesFor(&config.AppConfig).path(path)
+}
+
+// sanitizeFilename sanitizes a filename for filesystem use
+func sanitizeFilename(name string) string {
+	return sanitizeRulesFor(&config.AppConfig).segment(name)
+}
+
+// path applies segment to each part of a slash-separated path.
+func (r sanitizeRules) path(path string) string {
 	parts := strings.Split(path, "/")
 	for i, part := range parts {
-		parts[i] = sanitizeFilename(part)
+		parts[i] = r.segment(part)
 	}
 	return strings.Join(parts, "/")
 }
 
-// sanitizeFilename sanitizes a filename for filesystem use
-func sanitizeFilename(name string) string {
+var windowsReservedName = regexp.MustCompile(`(?i)^(con|prn|aux|nul|com[1-9]|lpt[1-9])(\.|$)`)
+
+// segment sanitizes one file or folder name.
+func (r sanitizeRules) segment(name string) string {
+	replace := r.replace
+	if replace == "" {
+		replace = "_"
+	}
+
 	// Remove control characters and non-printable bytes
 	name = strings.Map(func(r rune) rune {
 		if r < 32 || r == 127 {
@@ -422,7 +607,7 @@ func sanitizeFilename(name string) string {
 
 	invalid := []string{"<", ">", ":", "\"", "|", "?", "*"}
 	for _, char := range invalid {
-		name = strings.ReplaceAll(name, char, "_")
+		name = strings.ReplaceAll(name, char, replace)
 	}
 
 	// Strip brackets (ugly in paths, cause shell escaping issues)
@@ -438,6 +623,13 @@ func sanitizeFilename(name string) string {
 		name = name[:200]
 	}
 
+	if r.windowsSafe {
+		name = strings.TrimRight(name, ". ")
+		if windowsReservedName.MatchString(name) {
+			name = name + replace
+		}
+	}
+
 	return name
 }
 
@@ -543,12 +735,10 @@ func (o *Organizer) OrganizeBookDirectory(book *database.Book, segmentPaths []st
 	}
 
 	// Generate target directory from folder naming pattern
-	folderPath, err := o.expandPattern(o.config.FolderNamingPattern, book)
+	targetDir, err := o.GenerateTargetDirPath(book)
 	if err != nil {
-		return "", nil, fmt.Errorf("folder pattern: %w", err)
+		return "", nil, err
 	}
-	folderPath = sanitizePath(folderPath)
-	targetDir := filepath.Join(o.config.RootDir, folderPath)
 
 	if err := os.MkdirAll(targetDir, 0775); err != nil {
 		return "", nil, fmt.Errorf("failed to create target directory: %w", err)
@@ -1,5 +1,5 @@
 // file: internal/organizer/organizer_integration_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: d4e5f6a7-b8c9-0123-defa-456789012bcd
 
 package organizer
@@ -112,7 +112,7 @@ func TestOrganizer_ComplexPatterns(t *testing.T) {
 				Format:         ".m4b",
 				Author:         &database.Author{Name: "J.R.R. Tolkien"},
 				Series:         &database.Series{Name: "Lord of the Rings"},
-				SeriesSequence: &seriesSeq,
+				SeriesSequence: database.NewSeriesSeq(seriesSeq),
 			},
 			wantContains: []string{"J.R.R. Tolkien", "Lord of the Rings", "2 - The Two Towers"},
 		},
@@ -1,5 +1,5 @@
 // file: internal/organizer/organizer_test.go
-// version: 1.7.0
+// version: 1.8.0
 // guid: 8b9c0d1e-2f3a-4b5c-6d7e-8f9a0b1c2d3e
 
 package organizer
@@ -491,7 +491,7 @@ func TestExpandPattern_WithSeries(t *testing.T) {
 		Title:          "The Two Towers",
 		Author:         &database.Author{Name: "J.R.R. Tolkien"},
 		Series:         &database.Series{Name: "The Lord of the Rings"},
-		SeriesSequence: &seriesNum,
+		SeriesSequence: database.NewSeriesSeq(seriesNum),
 	}
 
 	result, err := org.expandPattern("{author}/{series}/{series_number} - {title}", book)
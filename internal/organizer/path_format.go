@@ -1,5 +1,5 @@
 // file: internal/organizer/path_format.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: a7b3c1d2-e4f5-6789-abcd-ef0123456789
 
 package organizer
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
 )
 
 // FormatVars holds all variables available for path/title formatting.
@@ -148,6 +150,11 @@ func FormatPath(format string, vars FormatVars) string {
 			return fmt.Sprintf("%d", vars.Track)
 		case "total_tracks":
 			return fmt.Sprintf("%d", vars.TotalTracks)
+		case "series_position", "series_num":
+			if spec != "" && seriesPos != "" {
+				return scrubVar(database.ParseSeriesSeq(seriesPos).Format(spec))
+			}
+			return seriesPos
 		}
 		return match
 	})
@@ -1,5 +1,5 @@
 // file: internal/organizer/path_format_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: a7b3c1d2-e4f5-6789-abcd-ef0123456f01
 
 package organizer
@@ -128,3 +128,19 @@ func TestScrubVar(t *testing.T) {
 		}
 	}
 }
+
+// TestFormatPath_SeriesPositionSpec covers {series_position:spec} and the
+// {series_num} alias with decimal positions.
+func TestFormatPath_SeriesPositionSpec(t *testing.T) {
+	vars := FormatVars{Author: "A", Series: "S", SeriesPos: "1.5", Title: "T", Ext: "m4b"}
+	cases := map[string]string{
+		"{series_position:04.1f} {title}.{ext}": "01.5 T.m4b",
+		"{series_num:02d} {title}.{ext}":        "01.5 T.m4b",
+		"{series_num} {title}.{ext}":            "1.5 T.m4b",
+	}
+	for format, want := range cases {
+		if got := FormatPath(format, vars); got != want {
+			t.Errorf("FormatPath(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
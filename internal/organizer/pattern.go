@@ -0,0 +1,237 @@
+// file: internal/organizer/pattern.go
+// version: 1.0.0
+// guid: 4d2b9e71-8c35-4a06-b1f7-e6a3c0d95b28
+// last-edited: 2026-10-17
+
+package organizer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Naming patterns (folder_naming_pattern, file_naming_pattern) are
+// templates:
+//
+//	{name}       a placeholder, e.g. {author}; names are case-insensitive
+//	{name:spec}  a placeholder with a format spec, e.g. {series_num:02d}
+//	< ... >      an optional segment, dropped unless every placeholder in
+//	             it has a value: "{author}/<{series}/><{series_num:02d} - >{title}"
+//
+// Optional segments go by the book's own fields, so a segment with
+// {narrator} is dropped for a book without one even though a bare
+// {narrator} renders the "narrator" default. Outside optional segments
+// the older rule still applies: an empty placeholder takes a neighbouring
+// " - " or its enclosing parentheses with it (see removeEmptySegment).
+// Square brackets are plain text; < and > can't appear in file names, so
+// they are free to mark segments.
+
+// Pattern is a parsed naming pattern.
+type Pattern struct {
+	src   string
+	nodes []patternNode
+}
+
+// patternNode is literal text, a placeholder (name set) or an optional
+// segment (group set).
+type patternNode struct {
+	text  string
+	name  string
+	spec  string
+	group []patternNode
+	raw   string // placeholder as written, for unresolved-placeholder errors
+}
+
+var placeholderNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ParsePattern parses a naming pattern, reporting the first syntax error.
+func ParsePattern(src string) (*Pattern, error) {
+	var stack [][]patternNode
+	var nodes []patternNode
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, patternNode{text: text.String()})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '{':
+			end := strings.IndexAny(src[i+1:], "{}<>")
+			if end < 0 || src[i+1+end] != '}' {
+				return nil, fmt.Errorf("unbalanced braces in pattern")
+			}
+			body := src[i+1 : i+1+end]
+			name, spec, _ := strings.Cut(body, ":")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if !placeholderNameRegex.MatchString(name) {
+				return nil, fmt.Errorf("invalid placeholder {%s} in pattern", body)
+			}
+			flush()
+			nodes = append(nodes, patternNode{name: name, spec: spec, raw: "{" + name + specSuffix(spec) + "}"})
+			i += end + 1
+		case '}':
+			return nil, fmt.Errorf("unbalanced braces in pattern")
+		case '<':
+			flush()
+			stack = append(stack, nodes)
+			nodes = nil
+		case '>':
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("unbalanced optional segment in pattern")
+			}
+			flush()
+			group := nodes
+			nodes = append(stack[len(stack)-1], patternNode{group: group})
+			stack = stack[:len(stack)-1]
+		default:
+			text.WriteByte(src[i])
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("unbalanced optional segment in pattern")
+	}
+	flush()
+	return &Pattern{src: src, nodes: nodes}, nil
+}
+
+func specSuffix(spec string) string {
+	if spec == "" {
+		return ""
+	}
+	return ":" + spec
+}
+
+// String returns the pattern as written.
+func (p *Pattern) String() string { return p.src }
+
+// placeholderValue is what a placeholder renders as. set is false when
+// the book lacks the field, even if text holds a default.
+type placeholderValue struct {
+	text string
+	set  bool
+}
+
+// render expands p. lookup resolves a placeholder and reports false for
+// one it does not know; unknown placeholders are left in the output as
+// written so the caller can report them.
+func (p *Pattern) render(lookup func(name, spec string) (placeholderValue, bool)) string {
+	var b strings.Builder
+	renderNodes(&b, p.nodes, lookup)
+	return b.String()
+}
+
+// renderNodes writes nodes to b and reports whether every placeholder
+// among them (outside nested optional segments) had a value.
+func renderNodes(b *strings.Builder, nodes []patternNode, lookup func(name, spec string) (placeholderValue, bool)) bool {
+	complete := true
+	for _, n := range nodes {
+		switch {
+		case n.group != nil:
+			var seg strings.Builder
+			if renderNodes(&seg, n.group, lookup) {
+				b.WriteString(seg.String())
+			}
+		case n.name != "":
+			v, ok := lookup(n.name, n.spec)
+			if !ok {
+				b.WriteString(n.raw)
+				continue
+			}
+			if !v.set {
+				complete = false
+			}
+			b.WriteString(v.text)
+		default:
+			b.WriteString(n.text)
+		}
+	}
+	return complete
+}
+
+// ValidatePattern reports a syntax error in a naming pattern. It is
+// installed as config.NamingPatternValidator.
+func ValidatePattern(src string) error {
+	_, err := ParsePattern(src)
+	return err
+}
+
+// PatternPreview is one book's rendering in a pattern preview.
+type PatternPreview struct {
+	BookID string `json:"book_id,omitempty"`
+	Title  string `json:"title"`
+	Folder string `json:"folder,omitempty"`
+	File   string `json:"file,omitempty"`
+	// Path is Folder/File, relative to root_dir.
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// PreviewPatterns renders a folder and file pattern against books without
+// touching the filesystem, using the organizer's sanitize rules. A syntax
+// error in either pattern fails the preview; a book the patterns can't
+// name (an unresolved placeholder) gets its error in its entry instead.
+// Layouts and disambiguation are not applied.
+func (o *Organizer) PreviewPatterns(folderPattern, filePattern string, books []*database.Book) ([]PatternPreview, error) {
+	if _, err := ParsePattern(folderPattern); err != nil {
+		return nil, fmt.Errorf("folder pattern: %w", err)
+	}
+	if _, err := ParsePattern(filePattern); err != nil {
+		return nil, fmt.Errorf("file pattern: %w", err)
+	}
+	out := make([]PatternPreview, 0, len(books))
+	for _, book := range books {
+		p := PatternPreview{BookID: book.ID, Title: book.Title}
+		folder, file, err := o.renderNames(folderPattern, filePattern, book, "")
+		if err != nil {
+			p.Error = err.Error()
+		} else {
+			p.Folder = folder
+			p.File = file + filepath.Ext(book.FilePath)
+			p.Path = path.Join(p.Folder, p.File)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// SamplePatternBooks are the books a pattern preview renders when no
+// book IDs are given: one with every common field, a mid-series novella
+// with a fractional position, and a standalone with no narrator, year or
+// quality and a title that needs scrubbing.
+func SamplePatternBooks() []*database.Book {
+	str := func(s string) *string { return &s }
+	year := func(y int) *int { return &y }
+	return []*database.Book{
+		{
+			Title:          "The Way of Kings",
+			FilePath:       "/samples/The Way of Kings.m4b",
+			Author:         &database.Author{Name: "Brandon Sanderson"},
+			Series:         &database.Series{Name: "The Stormlight Archive"},
+			SeriesSequence: database.NewSeriesSeq(1),
+			Narrator:       str("Michael Kramer, Kate Reading"),
+			PrintYear:      year(2010),
+			Quality:        str("AAC 128kbps"),
+		},
+		{
+			Title:          "Edgedancer",
+			FilePath:       "/samples/Edgedancer.m4b",
+			Author:         &database.Author{Name: "Brandon Sanderson"},
+			Series:         &database.Series{Name: "The Stormlight Archive"},
+			SeriesSequence: database.ParseSeriesSeq("2.5"),
+			Narrator:       str("Kate Reading"),
+			PrintYear:      year(2016),
+		},
+		{
+			Title:    "Project Hail Mary: A Novel / Unabridged",
+			FilePath: "/samples/Project Hail Mary.mp3",
+			Author:   &database.Author{Name: "Andy Weir"},
+		},
+	}
+}
@@ -1,11 +1,12 @@
 // file: internal/organizer/pattern_test.go
-// version: 1.4.0
+// version: 1.8.0
 // guid: 9a0b1c2d-3e4f-5a6b-7c8d-9e0f1a2b3c4d
 
 package organizer
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/falkcorp/audiobook-organizer/internal/config"
@@ -43,7 +44,7 @@ func TestPatternExpansionWithRealData(t *testing.T) {
 				FilePath:       "/source/book3.m4b",
 				Author:         &database.Author{Name: "Richard Morgan"},
 				Series:         &database.Series{Name: "Takeshi Kovacs"},
-				SeriesSequence: intPtr(3),
+				SeriesSequence: database.NewSeriesSeq(3),
 			},
 			folderPattern:  "{author}/{series}",
 			filePattern:    "{series_number} - {title}",
@@ -57,7 +58,7 @@ func TestPatternExpansionWithRealData(t *testing.T) {
 				FilePath:       "/source/book1.m4b",
 				Author:         &database.Author{Name: "Richard Morgan"},
 				Series:         &database.Series{Name: "Takeshi Kovacs"},
-				SeriesSequence: intPtr(1),
+				SeriesSequence: database.NewSeriesSeq(1),
 			},
 			folderPattern:  "{author}/{series}",
 			filePattern:    "{series} #{series_number} - {title}",
@@ -114,7 +115,7 @@ func TestPatternExpansionWithRealData(t *testing.T) {
 				FilePath:       "/source/hunger.m4b",
 				Author:         &database.Author{Name: "Michael Grant"},
 				Series:         &database.Series{Name: "Gone"},
-				SeriesSequence: intPtr(2),
+				SeriesSequence: database.NewSeriesSeq(2),
 				Narrator:       stringPtr("Nick Podehl"),
 			},
 			folderPattern:  "{author}/{series}",
@@ -291,7 +292,7 @@ func TestEmptyFieldRemoval(t *testing.T) {
 				Title:          "Book Title",
 				Author:         &database.Author{Name: "Author Name"},
 				Series:         &database.Series{Name: "Series Name"},
-				SeriesSequence: intPtr(5),
+				SeriesSequence: database.NewSeriesSeq(5),
 			},
 			pattern:  "{series} #{series_number} - {title}",
 			expected: "Series Name #5 - Book Title",
@@ -331,7 +332,7 @@ func TestComplexRealWorldPaths(t *testing.T) {
 				FilePath:       "/old/path/file.m4b",
 				Author:         &database.Author{Name: "Richard Morgan"},
 				Series:         &database.Series{Name: "Takeshi Kovacs"},
-				SeriesSequence: intPtr(3),
+				SeriesSequence: database.NewSeriesSeq(3),
 			},
 			folderPat:    "{author}/{series}",
 			filePat:      "Book {series_number} - {title}",
@@ -355,7 +356,7 @@ func TestComplexRealWorldPaths(t *testing.T) {
 				FilePath:       "/old/spellmonger9.m4b",
 				Author:         &database.Author{Name: "Terry Mancour"},
 				Series:         &database.Series{Name: "Spellmonger"},
-				SeriesSequence: intPtr(9),
+				SeriesSequence: database.NewSeriesSeq(9),
 			},
 			folderPat:    "{author}/{series}",
 			filePat:      "Book {series_number} - {title}",
@@ -554,3 +555,232 @@ func TestCollapseRedundantDup(t *testing.T) {
 		})
 	}
 }
+
+// TestPatternSeriesNumberFormatSpec covers {series_number:spec}, the
+// {series_num} alias, and segment removal when the position is unknown.
+func TestPatternSeriesNumberFormatSpec(t *testing.T) {
+	org := &Organizer{config: &config.Config{}}
+	tests := []struct {
+		pattern string
+		seq     *database.SeriesSeq
+		want    string
+	}{
+		{"{series_num:04.1f} - {title}", database.ParseSeriesSeq("1.5"), "01.5 - Gardens"},
+		{"{series_number:02d} - {title}", database.NewSeriesSeq(3), "03 - Gardens"},
+		{"{series_num} - {title}", database.ParseSeriesSeq("2.5"), "2.5 - Gardens"},
+		{"{series_number:02d} - {title}", nil, "Gardens"},
+	}
+	for _, tt := range tests {
+		book := &database.Book{Title: "Gardens", Author: &database.Author{Name: "Steven Erikson"}, SeriesSequence: tt.seq}
+		got, err := org.expandPattern(tt.pattern, book)
+		if err != nil {
+			t.Fatalf("expandPattern(%q): %v", tt.pattern, err)
+		}
+		if got != tt.want {
+			t.Errorf("expandPattern(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestPatternCustomFieldPlaceholders(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("pebble open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	for _, def := range []database.CustomFieldDefinition{{Key: "source"}, {Key: "shelf"}} {
+		if err := store.SaveCustomField(def); err != nil {
+			t.Fatalf("save %s: %v", def.Key, err)
+		}
+	}
+	if err := store.SetBookCustomFields("b1", map[string]string{"source": "Audible/US"}); err != nil {
+		t.Fatalf("set values: %v", err)
+	}
+
+	org := &Organizer{config: &config.Config{}, store: store}
+	book := &database.Book{ID: "b1", Title: "Gardens", Author: &database.Author{Name: "Steven Erikson"}}
+	got, err := org.expandPattern("{custom_source}/{author}/{title} - {custom_shelf}", book)
+	if err != nil {
+		t.Fatalf("expandPattern: %v", err)
+	}
+	if want := "Audible US/Steven Erikson/Gardens"; got != want {
+		t.Errorf("expandPattern = %q, want %q", got, want)
+	}
+
+	if _, err := org.expandPattern("{title} {custom_undefined}", book); err == nil {
+		t.Error("expected error for undefined custom field placeholder")
+	}
+}
+
+func TestPatternContributorPlaceholders(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("pebble open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	var ids []int
+	for _, name := range []string{"Neil Gaiman", "Terry Pratchett", "Ellen Datlow", "Jo Walton", "Ted Chiang"} {
+		a, err := store.CreateAuthor(name)
+		if err != nil {
+			t.Fatalf("create author: %v", err)
+		}
+		ids = append(ids, a.ID)
+	}
+	if err := store.SetBookAuthors("b1", []database.BookAuthor{
+		{BookID: "b1", AuthorID: ids[1], Role: "co-author", Position: 1},
+		{BookID: "b1", AuthorID: ids[0], Role: "author", Position: 0},
+		{BookID: "b1", AuthorID: ids[2], Role: "editor", Position: 2},
+	}); err != nil {
+		t.Fatalf("set authors: %v", err)
+	}
+
+	org := &Organizer{config: &config.Config{}, store: store}
+	tests := []struct {
+		name    string
+		book    *database.Book
+		pattern string
+		want    string
+	}{
+		{"co-authored", &database.Book{ID: "b1", Title: "Good Omens", Author: &database.Author{Name: "Neil Gaiman & Terry Pratchett"}},
+			"{authors}/{title}", "Neil Gaiman & Terry Pratchett/Good Omens"},
+		{"first author", &database.Book{ID: "b1", Title: "Good Omens"},
+			"{first_author}/{title}", "Neil Gaiman/Good Omens"},
+		{"book.Authors wins", &database.Book{ID: "b2", Title: "Anthology", Authors: []database.BookAuthor{
+			{AuthorID: ids[3], Position: 0}, {AuthorID: ids[4], Position: 1}, {AuthorID: ids[1], Position: 2},
+		}}, "{authors}/{title}", "Jo Walton, Ted Chiang & Terry Pratchett/Anthology"},
+		{"et al.", &database.Book{ID: "b3", Title: "Big", Authors: []database.BookAuthor{
+			{AuthorID: ids[0], Position: 0}, {AuthorID: ids[1], Position: 1}, {AuthorID: ids[3], Position: 2}, {AuthorID: ids[4], Position: 3},
+		}}, "{authors}/{title}", "Neil Gaiman et al./Big"},
+		{"no contributor rows", &database.Book{ID: "b4", Title: "Solo", Author: &database.Author{Name: "Jo Walton"}},
+			"{authors} - {first_author}", "Jo Walton - Jo Walton"},
+	}
+	for _, tt := range tests {
+		got, err := org.expandPattern(tt.pattern, tt.book)
+		if err != nil {
+			t.Fatalf("%s: expandPattern: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expandPattern(%q) = %q, want %q", tt.name, tt.pattern, got, tt.want)
+		}
+	}
+
+	// Without a store, the tokens fall back to the primary author.
+	bare := &Organizer{config: &config.Config{}}
+	got, err := bare.expandPattern("{first_author}/{authors}", &database.Book{Title: "X", Author: &database.Author{Name: "Ted Chiang"}})
+	if err != nil || got != "Ted Chiang/Ted Chiang" {
+		t.Errorf("no store: got %q, %v", got, err)
+	}
+}
+
+// TestPatternOptionalSegments covers <...> segments, which go by the
+// book's own fields rather than the rendered defaults.
+func TestPatternOptionalSegments(t *testing.T) {
+	org := &Organizer{config: &config.Config{}}
+	narrator := "Kate Reading"
+	full := &database.Book{
+		Title: "Edgedancer", Author: &database.Author{Name: "Brandon Sanderson"},
+		Series: &database.Series{Name: "Stormlight"}, SeriesSequence: database.ParseSeriesSeq("2.5"),
+		Narrator: &narrator,
+	}
+	bare := &database.Book{Title: "Project Hail Mary", Author: &database.Author{Name: "Andy Weir"}}
+	tests := []struct {
+		book    *database.Book
+		pattern string
+		want    string
+	}{
+		{full, "{author}/<{series}/><{series_num:04.1f} - >{title}", "Brandon Sanderson/Stormlight/02.5 - Edgedancer"},
+		{bare, "{author}/<{series}/><{series_num:04.1f} - >{title}", "Andy Weir/Project Hail Mary"},
+		{full, "{title}< (read by {narrator})>", "Edgedancer (read by Kate Reading)"},
+		{bare, "{title}< (read by {narrator})>", "Project Hail Mary"},
+		{bare, "{title} - {narrator}", "Project Hail Mary - narrator"},
+		{bare, "{author}<<{series}> - {title}>", "Andy Weir - Project Hail Mary"},
+		{&database.Book{Title: "Dune/Messiah: Part 1", Author: &database.Author{Name: "Frank Herbert"}},
+			"{author}/{title}", "Frank Herbert/Dune Messiah: Part 1"},
+	}
+	for _, tt := range tests {
+		got, err := org.expandPattern(tt.pattern, tt.book)
+		if err != nil {
+			t.Fatalf("expandPattern(%q): %v", tt.pattern, err)
+		}
+		if got != tt.want {
+			t.Errorf("expandPattern(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestParsePatternErrors(t *testing.T) {
+	tests := map[string]string{
+		"{author/{title}":    "unbalanced braces",
+		"{author}}":          "unbalanced braces",
+		"{author}/<{series}": "unbalanced optional segment",
+		"{title}>":           "unbalanced optional segment",
+		"{bad placeholder}":  "invalid placeholder",
+		"{}":                 "invalid placeholder",
+	}
+	for pattern, want := range tests {
+		err := ValidatePattern(pattern)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidatePattern(%q) = %v, want %q", pattern, err, want)
+		}
+	}
+	p, err := ParsePattern("{Author}/<{series}/>[{quality}]")
+	if err != nil {
+		t.Fatalf("ParsePattern: %v", err)
+	}
+	if p.String() != "{Author}/<{series}/>[{quality}]" {
+		t.Errorf("String() = %q", p.String())
+	}
+}
+
+func TestSanitizeRules(t *testing.T) {
+	tests := []struct {
+		rules sanitizeRules
+		in    string
+		want  string
+	}{
+		{sanitizeRules{}, `Who: What? "Why"`, "Who_ What_ _Why_"},
+		{sanitizeRules{replace: "-"}, "Who: What?", "Who- What-"},
+		{sanitizeRules{}, "Vol. 2...", "Vol. 2_."},
+		{sanitizeRules{windowsSafe: true}, "The End. ", "The End"},
+		{sanitizeRules{windowsSafe: true}, "con", "con_"},
+		{sanitizeRules{windowsSafe: true}, "Nul.m4b", "Nul.m4b_"},
+		{sanitizeRules{windowsSafe: true}, "Console", "Console"},
+	}
+	for _, tt := range tests {
+		if got := tt.rules.segment(tt.in); got != tt.want {
+			t.Errorf("%+v.segment(%q) = %q, want %q", tt.rules, tt.in, got, tt.want)
+		}
+	}
+	if got := (sanitizeRules{windowsSafe: true}).path("AUX/Book."); got != "AUX_/Book" {
+		t.Errorf("path = %q, want %q", got, "AUX_/Book")
+	}
+}
+
+func TestPreviewPatterns(t *testing.T) {
+	org := &Organizer{config: &config.Config{NamingReplaceChar: "-"}}
+	previews, err := org.PreviewPatterns("{author}/<{series}/>", "<{series_num:02d} - >{title}< [{quality}]>", SamplePatternBooks())
+	if err != nil {
+		t.Fatalf("PreviewPatterns: %v", err)
+	}
+	want := []string{
+		"Brandon Sanderson/The Stormlight Archive/01 - The Way of Kings AAC 128kbps.m4b",
+		"Brandon Sanderson/The Stormlight Archive/02.5 - Edgedancer.m4b",
+		"Andy Weir/Project Hail Mary- A Novel Unabridged.mp3",
+	}
+	if len(previews) != len(want) {
+		t.Fatalf("got %d previews, want %d", len(previews), len(want))
+	}
+	for i, p := range previews {
+		if p.Error != "" || p.Path != want[i] {
+			t.Errorf("preview %d = %q (error %q), want %q", i, p.Path, p.Error, want[i])
+		}
+	}
+
+	previews, err = org.PreviewPatterns("{author}", "{title} {nope}", SamplePatternBooks()[:1])
+	if err != nil || len(previews) != 1 || previews[0].Error == "" {
+		t.Errorf("unknown placeholder: previews %+v, err %v; want a per-book error", previews, err)
+	}
+	if _, err := org.PreviewPatterns("{author", "{title}", nil); err == nil {
+		t.Error("expected a syntax error for an unbalanced folder pattern")
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/organizer/realworld_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 2b3c4d5e-6f7a-8b9c-0d1e-2f3a4b5c6d7e
 
 package organizer
@@ -295,7 +295,7 @@ func extractMetadataFromPath(filePath string) *database.Book {
 	if series, position := extractSeriesInfo(nameWithoutExt); series != "" {
 		book.Series = &database.Series{Name: series}
 		if position > 0 {
-			book.SeriesSequence = &position
+			book.SeriesSequence = database.NewSeriesSeq(position)
 		}
 	}
 
@@ -0,0 +1,58 @@
+// file: internal/organizer/retry.go
+// version: 1.0.0
+// guid: c8f03b62-9d1e-4a57-b6e4-15a7d2e9c830
+// last-edited: 2026-10-16
+
+package organizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/retryqueue"
+)
+
+// queueOrganizeRetry puts a book whose move failed into the retry queue
+// when the error is transient (the file was locked, the share dropped).
+func (orgSvc *Service) queueOrganizeRetry(book *database.Book, err error, log logger.Logger) {
+	queued, qerr := retryqueue.Fail(retryqueue.Store(orgSvc.db), database.RetryKindOrganize, book.ID,
+		database.RetryEntry{Path: book.FilePath, BookID: book.ID}, err)
+	if qerr != nil {
+		log.Warn("Failed to queue %s for organize retry: %v", book.ID, qerr)
+	} else if queued {
+		log.Info("Queued %s for organize retry", book.Title)
+	}
+}
+
+// clearOrganizeRetry drops a book from the retry queue once it organizes.
+func (orgSvc *Service) clearOrganizeRetry(bookID string, log logger.Logger) {
+	if err := retryqueue.Clear(retryqueue.Store(orgSvc.db), database.RetryKindOrganize, bookID); err != nil {
+		log.Debug("Failed to clear organize retry for %s: %v", bookID, err)
+	}
+}
+
+// RetryOrganizeBook organizes one queued book again under operationID.
+// Unlike PerformOrganize it takes no backup and syncs nothing first; the
+// organize itself re-queues the book if the error persists. A book that
+// was deleted or no longer needs organizing is dropped from the queue.
+func (orgSvc *Service) RetryOrganizeBook(ctx context.Context, bookID, operationID string, log logger.Logger) error {
+	book, err := orgSvc.db.GetBookByID(bookID)
+	if err != nil {
+		return fmt.Errorf("failed to load book %s: %w", bookID, err)
+	}
+	if book == nil {
+		orgSvc.clearOrganizeRetry(bookID, log)
+		return nil
+	}
+	toOrganize, _ := orgSvc.FilterBooksNeedingOrganization([]database.Book{*book}, log)
+	if len(toOrganize) == 0 {
+		orgSvc.clearOrganizeRetry(bookID, log)
+		return nil
+	}
+	if stats := orgSvc.organizeBooks(ctx, toOrganize, nil, log, operationID); stats.Failed > 0 {
+		return fmt.Errorf("organize of %s failed again", bookID)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+// file: internal/organizer/series_folder.go
+// version: 1.0.0
+// guid: 8c4e2a61-3f7b-4d95-b0a6-5e1d9c7f2b48
+// last-edited: 2026-10-17
+
+package organizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+)
+
+// SeriesFolderFile is written to each series folder when
+// write_series_metadata is on.
+const SeriesFolderFile = "series.json"
+
+// SeriesFolderStore is what syncing series folders reads.
+type SeriesFolderStore interface {
+	GetBooksBySeriesID(seriesID int) ([]database.Book, error)
+	GetSeriesByID(id int) (*database.Series, error)
+	GetAuthorByID(id int) (*database.Author, error)
+}
+
+// SeriesFolderInfo is the content of series.json.
+type SeriesFolderInfo struct {
+	SeriesID     int                `json:"series_id"`
+	Series       string             `json:"series"`
+	Author       string             `json:"author,omitempty"`
+	BookCount    int                `json:"book_count"`
+	Books        []SeriesFolderBook `json:"books"`
+	Completeness SeriesCompleteness `json:"completeness"`
+}
+
+// SeriesFolderBook is one book in series.json, in series order.
+type SeriesFolderBook struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Position string `json:"position,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Narrator string `json:"narrator,omitempty"`
+	Year     int    `json:"year,omitempty"`
+	Path     string `json:"path"` // relative to the series folder
+}
+
+// SeriesCompleteness reports gaps in the folder's numbered books. The
+// library doesn't know how long a series really is, so Complete only
+// means nothing is missing up to the highest position present.
+type SeriesCompleteness struct {
+	HighestPosition  int   `json:"highest_position"`
+	MissingPositions []int `json:"missing_positions"`
+	Unnumbered       int   `json:"unnumbered"`
+	Complete         bool  `json:"complete"`
+}
+
+// SyncSeriesFolders rewrites series.json in every folder holding books
+// of seriesID, and removes it from the series folders above formerPaths
+// (paths books were moved or deleted from) that no longer hold any. A
+// series folder is the folder named after the series, so layouts
+// without one get no file. No-op unless write_series_metadata is on.
+func SyncSeriesFolders(store SeriesFolderStore, seriesID int, formerPaths ...string) error {
+	if !config.AppConfig.WriteSeriesMetadata || config.AppConfig.RootDir == "" {
+		return nil
+	}
+	series, err := store.GetSeriesByID(seriesID)
+	if err != nil {
+		return fmt.Errorf("load series %d: %w", seriesID, err)
+	}
+	groups := map[string][]database.Book{}
+	if series != nil {
+		books, err := store.GetBooksBySeriesID(seriesID)
+		if err != nil {
+			return fmt.Errorf("load books of series %d: %w", seriesID, err)
+		}
+		for _, b := range books {
+			if b.MarkedForDeletion != nil && *b.MarkedForDeletion {
+				continue
+			}
+			if dir := seriesFolder(b.FilePath, series.Name); dir != "" {
+				groups[dir] = append(groups[dir], b)
+			}
+		}
+	}
+
+	var errs []error
+	for dir, books := range groups {
+		if err := writeSeriesFolderFile(store, dir, series, books); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, p := range formerPaths {
+		if dir := staleSeriesFolder(p, seriesID); dir != "" && groups[dir] == nil {
+			if err := removeSeriesFolderFile(dir); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncSeriesFolders syncs the series of books after an organize run,
+// using their pre-organize paths as former paths.
+func (orgSvc *Service) syncSeriesFolders(books []database.Book, log logger.Logger) {
+	if !config.AppConfig.WriteSeriesMetadata {
+		return
+	}
+	store, ok := orgSvc.db.(SeriesFolderStore)
+	if !ok {
+		return
+	}
+	former := map[int][]string{}
+	for _, b := range books {
+		if b.SeriesID != nil {
+			former[*b.SeriesID] = append(former[*b.SeriesID], b.FilePath)
+		}
+	}
+	for id, paths := range former {
+		if err := SyncSeriesFolders(store, id, paths...); err != nil {
+			log.Warn("Failed to update %s for series %d: %s", SeriesFolderFile, id, err.Error())
+		}
+	}
+	if len(former) > 0 {
+		log.Info("Updated %s for %d series", SeriesFolderFile, len(former))
+	}
+}
+
+// seriesFolder returns the outermost folder between the library root and
+// path that is named after series, or "" when there is none.
+func seriesFolder(path, series string) string {
+	name := sanitizeFilename(series)
+	root := filepath.Clean(config.AppConfig.RootDir)
+	if name == "" || path == "" {
+		return ""
+	}
+	dir := filepath.Clean(path)
+	if !isDirectoryPath(dir) {
+		dir = filepath.Dir(dir)
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	cur := root
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		cur = filepath.Join(cur, part)
+		if part == name {
+			return cur
+		}
+	}
+	return ""
+}
+
+// staleSeriesFolder finds the series.json for seriesID above path, by
+// content rather than folder name so renamed series are found too.
+func staleSeriesFolder(path string, seriesID int) string {
+	root := filepath.Clean(config.AppConfig.RootDir)
+	dir := filepath.Clean(path)
+	if !isDirectoryPath(dir) {
+		dir = filepath.Dir(dir)
+	}
+	for strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		data, err := os.ReadFile(filepath.Join(dir, SeriesFolderFile))
+		if err == nil {
+			var info SeriesFolderInfo
+			if json.Unmarshal(data, &info) == nil && info.SeriesID == seriesID {
+				return dir
+			}
+		}
+		dir = filepath.Dir(dir)
+	}
+	return ""
+}
+
+// writeSeriesFolderFile writes dir's series.json, leaving it untouched
+// when nothing changed.
+func writeSeriesFolderFile(store SeriesFolderStore, dir string, series *database.Series, books []database.Book) error {
+	info := buildSeriesFolderInfo(store, dir, series, books)
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	path := filepath.Join(dir, SeriesFolderFile)
+	if old, err := os.ReadFile(path); err == nil && bytes.Equal(old, data) {
+		return nil
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// removeSeriesFolderFile deletes dir's series.json, then dir and its
+// parents up to the library root if that left them empty.
+func removeSeriesFolderFile(dir string) error {
+	if err := os.Remove(filepath.Join(dir, SeriesFolderFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	root := filepath.Clean(config.AppConfig.RootDir)
+	for strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		if entries, err := os.ReadDir(dir); err != nil || len(entries) > 0 {
+			break
+		}
+		if os.Remove(dir) != nil {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	return nil
+}
+
+func buildSeriesFolderInfo(store SeriesFolderStore, dir string, series *database.Series, books []database.Book) SeriesFolderInfo {
+	authors := map[int]string{}
+	authorName := func(id *int) string {
+		if id == nil {
+			return ""
+		}
+		if name, ok := authors[*id]; ok {
+			return name
+		}
+		name := ""
+		if a, err := store.GetAuthorByID(*id); err == nil && a != nil {
+			name = a.Name
+		}
+		authors[*id] = name
+		return name
+	}
+
+	sort.SliceStable(books, func(i, j int) bool {
+		if c := database.CompareSeriesSeq(books[i].SeriesSequence, books[j].SeriesSequence); c != 0 {
+			return c < 0
+		}
+		return books[i].Title < books[j].Title
+	})
+
+	info := SeriesFolderInfo{
+		SeriesID:  series.ID,
+		Series:    series.Name,
+		Author:    authorName(series.AuthorID),
+		BookCount: len(books),
+		Books:     make([]SeriesFolderBook, 0, len(books)),
+	}
+	have := map[int]bool{}
+	for _, b := range books {
+		entry := SeriesFolderBook{
+			ID:       b.ID,
+			Title:    b.Title,
+			Narrator: stringOrEmpty(b.Narrator),
+		}
+		if b.Author != nil {
+			entry.Author = b.Author.Name
+		} else {
+			entry.Author = authorName(b.AuthorID)
+		}
+		if b.PrintYear != nil {
+			entry.Year = *b.PrintYear
+		} else if b.AudiobookReleaseYear != nil {
+			entry.Year = *b.AudiobookReleaseYear
+		}
+		if rel, err := filepath.Rel(dir, b.FilePath); err == nil {
+			entry.Path = filepath.ToSlash(rel)
+		}
+		if seq := b.SeriesSequence; seq != nil && seq.String() != "" {
+			entry.Position = seq.String()
+			if seq.IsWhole() && seq.Int() > 0 {
+				have[seq.Int()] = true
+				info.Completeness.HighestPosition = max(info.Completeness.HighestPosition, seq.Int())
+			}
+		} else {
+			info.Completeness.Unnumbered++
+		}
+		if info.Author == "" {
+			info.Author = entry.Author
+		}
+		info.Books = append(info.Books, entry)
+	}
+	info.Completeness.MissingPositions = []int{}
+	for n := 1; n <= info.Completeness.HighestPosition; n++ {
+		if !have[n] {
+			info.Completeness.MissingPositions = append(info.Completeness.MissingPositions, n)
+		}
+	}
+	info.Completeness.Complete = info.Completeness.HighestPosition > 0 && len(info.Completeness.MissingPositions) == 0
+	return info
+}
@@ -0,0 +1,129 @@
+// file: internal/organizer/series_folder_test.go
+// version: 1.0.0
+// guid: 3e9b7d15-6a2c-4f08-8d41-b5c0e2a7f963
+
+package organizer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestSyncSeriesFolders(t *testing.T) {
+	root := t.TempDir()
+	prev := config.AppConfig
+	config.AppConfig.RootDir = root
+	config.AppConfig.WriteSeriesMetadata = true
+	t.Cleanup(func() { config.AppConfig = prev })
+
+	store := newDisambiguationStore(t)
+	author, err := store.CreateAuthor("Frank Herbert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	series, err := store.CreateSeries("Dune Chronicles", &author.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seriesDir := filepath.Join(root, "Frank Herbert", "Dune Chronicles")
+	addBook := func(title string, pos int, rel string) *database.Book {
+		t.Helper()
+		path := filepath.Join(seriesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("audio"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		b, err := store.CreateBook(&database.Book{
+			Title: title, AuthorID: &author.ID, SeriesID: &series.ID,
+			SeriesSequence: database.NewSeriesSeq(pos), FilePath: path,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	children := addBook("Children of Dune", 3, "Children of Dune/Children of Dune.m4b")
+	dune := addBook("Dune", 1, "Dune/Dune.m4b")
+
+	read := func() *SeriesFolderInfo {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(seriesDir, SeriesFolderFile))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var info SeriesFolderInfo
+		if err := json.Unmarshal(data, &info); err != nil {
+			t.Fatal(err)
+		}
+		return &info
+	}
+
+	if err := SyncSeriesFolders(store, series.ID); err != nil {
+		t.Fatal(err)
+	}
+	info := read()
+	if info == nil {
+		t.Fatal("expected series.json in the series folder")
+	}
+	want := []SeriesFolderBook{
+		{ID: dune.ID, Title: "Dune", Position: "1", Author: "Frank Herbert", Path: "Dune/Dune.m4b"},
+		{ID: children.ID, Title: "Children of Dune", Position: "3", Author: "Frank Herbert", Path: "Children of Dune/Children of Dune.m4b"},
+	}
+	if !reflect.DeepEqual(info.Books, want) {
+		t.Errorf("books:\nexpected: %+v\ngot:      %+v", want, info.Books)
+	}
+	if info.Author != "Frank Herbert" || info.BookCount != 2 {
+		t.Errorf("header: %+v", info)
+	}
+	if c := info.Completeness; c.HighestPosition != 3 || !reflect.DeepEqual(c.MissingPositions, []int{2}) || c.Complete {
+		t.Errorf("completeness: %+v", c)
+	}
+
+	// Removing the later book closes the gap.
+	if err := store.DeleteBook(children.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := SyncSeriesFolders(store, series.ID, children.FilePath); err != nil {
+		t.Fatal(err)
+	}
+	if info := read(); info == nil || info.BookCount != 1 || !info.Completeness.Complete {
+		t.Errorf("after removal: %+v", info)
+	}
+
+	// The last book leaving takes the file and emptied folders with it.
+	if err := store.DeleteBook(dune.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{filepath.Dir(dune.FilePath), filepath.Dir(children.FilePath)} {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := SyncSeriesFolders(store, series.ID, dune.FilePath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "Frank Herbert")); !os.IsNotExist(err) {
+		t.Errorf("expected the emptied author folder to be removed, stat err %v", err)
+	}
+
+	// Nothing is written while the setting is off.
+	config.AppConfig.WriteSeriesMetadata = false
+	addBook("Dune Messiah", 2, "Dune Messiah/Dune Messiah.m4b")
+	if err := SyncSeriesFolders(store, series.ID); err != nil {
+		t.Fatal(err)
+	}
+	if read() != nil {
+		t.Error("expected no series.json with write_series_metadata off")
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/organizer/service.go
-// version: 1.3.1
+// version: 1.9.0
 // guid: c3d4e5f6-a7b8-c9d0-e1f2-a3b4c5d6e7f8
 
 package organizer
@@ -118,6 +118,60 @@ type Request struct {
 	SyncITunesFirst    bool
 	OperationID        string
 	BookIDs            []string // if set, only organize these books
+
+	// Scope filters. Each one that is set narrows the candidate books;
+	// FolderPath, when set, keeps books whose file lives under it.
+	AuthorID     *int // primary or co-author
+	SeriesID     *int
+	WorkID       string
+	LibraryState string
+}
+
+// ScopeBooks returns the books matching every scope filter set on req.
+func (req *Request) ScopeBooks(books []database.Book) []database.Book {
+	if req.AuthorID == nil && req.SeriesID == nil && req.WorkID == "" && req.LibraryState == "" &&
+		(req.FolderPath == nil || *req.FolderPath == "") {
+		return books
+	}
+	var folder string
+	if req.FolderPath != nil && *req.FolderPath != "" {
+		folder = filepath.Clean(*req.FolderPath)
+	}
+	kept := make([]database.Book, 0, len(books))
+	for _, b := range books {
+		if req.AuthorID != nil && !bookHasAuthor(b, *req.AuthorID) {
+			continue
+		}
+		if req.SeriesID != nil && (b.SeriesID == nil || *b.SeriesID != *req.SeriesID) {
+			continue
+		}
+		if req.WorkID != "" && (b.WorkID == nil || *b.WorkID != req.WorkID) {
+			continue
+		}
+		if req.LibraryState != "" && (b.LibraryState == nil || *b.LibraryState != req.LibraryState) {
+			continue
+		}
+		if folder != "" {
+			p := filepath.Clean(b.FilePath)
+			if p != folder && !strings.HasPrefix(p, folder+string(filepath.Separator)) {
+				continue
+			}
+		}
+		kept = append(kept, b)
+	}
+	return kept
+}
+
+func bookHasAuthor(b database.Book, authorID int) bool {
+	if b.AuthorID != nil && *b.AuthorID == authorID {
+		return true
+	}
+	for _, a := range b.Authors {
+		if a.AuthorID == authorID {
+			return true
+		}
+	}
+	return false
 }
 
 // Stats holds organize operation statistics.
@@ -151,30 +205,12 @@ func (orgSvc *Service) PerformOrganize(ctx context.Context, req *Request, log lo
 	// Auto-backup database before organizing
 	orgSvc.autoBackup(log)
 
-	// Get books — either specific IDs or all books
-	const fetchPageSize = 1000
-	var allBooks []database.Book
-	if len(req.BookIDs) > 0 {
-		for _, id := range req.BookIDs {
-			book, err := orgSvc.db.GetBookByID(id)
-			if err != nil || book == nil {
-				log.Warn("Book %s not found, skipping", id)
-				continue
-			}
-			allBooks = append(allBooks, *book)
-		}
-	} else {
-		for offset := 0; ; offset += fetchPageSize {
-			page, fetchErr := orgSvc.db.GetAllBooks(fetchPageSize, offset)
-			if fetchErr != nil {
-				log.Error("Failed to fetch books: %s", fetchErr.Error())
-				return fmt.Errorf("failed to fetch books: %w", fetchErr)
-			}
-			allBooks = append(allBooks, page...)
-			if len(page) < fetchPageSize {
-				break
-			}
-		}
+	// Get books — either specific IDs or all books — then narrow to the
+	// requested scope.
+	allBooks, err := orgSvc.loadBooks(req, log)
+	if err != nil {
+		log.Error("Failed to fetch books: %s", err.Error())
+		return fmt.Errorf("failed to fetch books: %w", err)
 	}
 
 	logMsg := fmt.Sprintf("Fetched %d total books from database", len(allBooks))
@@ -196,17 +232,10 @@ func (orgSvc *Service) PerformOrganize(ctx context.Context, req *Request, log lo
 		}
 		log.Info("Metadata enriched for %d books", enriched)
 
-		// Re-fetch all books since metadata may have changed
-		allBooks = nil
-		for offset := 0; ; offset += fetchPageSize {
-			page, fetchErr := orgSvc.db.GetAllBooks(fetchPageSize, offset)
-			if fetchErr != nil {
-				return fmt.Errorf("failed to re-fetch books after metadata: %w", fetchErr)
-			}
-			allBooks = append(allBooks, page...)
-			if len(page) < fetchPageSize {
-				break
-			}
+		// Re-fetch the scoped books since metadata may have changed
+		allBooks, err = orgSvc.loadBooks(req, log)
+		if err != nil {
+			return fmt.Errorf("failed to re-fetch books after metadata: %w", err)
 		}
 	}
 
@@ -220,6 +249,7 @@ func (orgSvc *Service) PerformOrganize(ctx context.Context, req *Request, log lo
 
 	// Perform organization
 	stats := orgSvc.organizeBooks(ctx, booksToOrganize, alreadyCorrect, log, req.OperationID)
+	orgSvc.syncSeriesFolders(append(append([]database.Book(nil), booksToOrganize...), alreadyCorrect...), log)
 
 	// Post-organize auto write-back now rides the batcher.
 	if stats.Organized > 0 || stats.ReOrganized > 0 {
@@ -229,6 +259,35 @@ func (orgSvc *Service) PerformOrganize(ctx context.Context, req *Request, log lo
 	return nil
 }
 
+// loadBooks fetches req.BookIDs (or every book when none are given) and
+// applies the request's scope filters.
+func (orgSvc *Service) loadBooks(req *Request, log logger.Logger) ([]database.Book, error) {
+	const fetchPageSize = 1000
+	var books []database.Book
+	if len(req.BookIDs) > 0 {
+		for _, id := range req.BookIDs {
+			book, err := orgSvc.db.GetBookByID(id)
+			if err != nil || book == nil {
+				log.Warn("Book %s not found, skipping", id)
+				continue
+			}
+			books = append(books, *book)
+		}
+	} else {
+		for offset := 0; ; offset += fetchPageSize {
+			page, err := orgSvc.db.GetAllBooks(fetchPageSize, offset)
+			if err != nil {
+				return nil, err
+			}
+			books = append(books, page...)
+			if len(page) < fetchPageSize {
+				break
+			}
+		}
+	}
+	return req.ScopeBooks(books), nil
+}
+
 func (orgSvc *Service) autoBackup(log logger.Logger) {
 	dbPath := config.AppConfig.DatabasePath
 	dbType := config.AppConfig.DatabaseType
@@ -272,6 +331,8 @@ func (orgSvc *Service) FilterBooksNeedingOrganization(allBooks []database.Book,
 	alreadyCorrect := make([]database.Book, 0)
 	skippedMissingFiles := 0
 	skippedDeleted := 0
+	skippedAdopted := 0
+	adoptedRoots := orgSvc.adoptedImportPaths()
 	for i, book := range allBooks {
 		// Update progress during filtering so the UI doesn't show 0/0
 		if i%500 == 0 || i == len(allBooks)-1 {
@@ -284,6 +345,12 @@ func (orgSvc *Service) FilterBooksNeedingOrganization(allBooks []database.Book,
 			continue
 		}
 
+		// Books adopted in place stay where their library put them.
+		if underAnyRoot(book.FilePath, adoptedRoots) {
+			skippedAdopted++
+			continue
+		}
+
 		// Skip non-primary versions — unless they're the only version in their VG
 		// (i.e., no organized primary copy exists yet)
 		if book.IsPrimaryVersion != nil && !*book.IsPrimaryVersion {
@@ -357,9 +424,42 @@ func (orgSvc *Service) FilterBooksNeedingOrganization(allBooks []database.Book,
 	if skippedMissingFiles > 0 {
 		log.Info("Organize: Skipped %d book(s) with missing book files", skippedMissingFiles)
 	}
+	if skippedAdopted > 0 {
+		log.Info("Organize: Skipped %d book(s) adopted in place", skippedAdopted)
+	}
 	return booksToOrganize, alreadyCorrect
 }
 
+// adoptedImportPaths lists the import paths marked for adopt-in-place.
+// Asserted rather than added to Store so narrower stores still satisfy it.
+func (orgSvc *Service) adoptedImportPaths() []string {
+	ips, ok := orgSvc.db.(database.ImportPathStore)
+	if !ok {
+		return nil
+	}
+	paths, err := ips.GetAllImportPaths()
+	if err != nil {
+		return nil
+	}
+	var roots []string
+	for _, p := range paths {
+		if p.Adopt {
+			roots = append(roots, filepath.Clean(p.Path))
+		}
+	}
+	return roots
+}
+
+// underAnyRoot reports whether path lies inside one of roots.
+func underAnyRoot(path string, roots []string) bool {
+	for _, root := range roots {
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
 // bookNeedsReOrganize checks whether a book already in RootDir needs to be
 // moved because its current path doesn't match the target path derived from
 // current metadata.
@@ -541,6 +641,7 @@ func (orgSvc *Service) organizeBooks(ctx context.Context, booksToOrganize []data
 					statsMu.Lock()
 					stats.Failed++
 					statsMu.Unlock()
+					orgSvc.queueOrganizeRetry(&book, err, log)
 
 					if operationID != "" {
 						_ = orgSvc.db.CreateOperationChange(&database.OperationChange{
@@ -637,6 +738,10 @@ func (orgSvc *Service) organizeBooks(ctx context.Context, booksToOrganize []data
 					statsMu.Unlock()
 				}
 
+				if err == nil {
+					orgSvc.clearOrganizeRetry(book.ID, log)
+				}
+
 				// --- Step 3: Enqueue iTunes writeback ---
 				if err == nil && oldPath != newPath && orgSvc.writeBackBatcher != nil {
 					orgSvc.writeBackBatcher.Enqueue(book.ID)
@@ -781,6 +886,7 @@ func (orgSvc *Service) CreateOrganizedVersion(org *Organizer, book *database.Boo
 		Format:               book.Format,
 		FileSize:             book.FileSize,
 		FileHash:             book.FileHash,
+		FileHashAlgorithm:    book.FileHashAlgorithm,
 		OriginalFileHash:     book.OriginalFileHash,
 		Duration:             book.Duration,
 		Bitrate:              book.Bitrate,
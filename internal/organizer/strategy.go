@@ -0,0 +1,152 @@
+// file: internal/organizer/strategy.go
+// version: 1.1.0
+// guid: 2b7e5d90-4c1a-4f83-a6e2-8d3f0b9c5a71
+// last-edited: 2026-10-17
+
+package organizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Strategy lays out organized books under the library root. The
+// organization_layout setting picks one by name; RegisterStrategy adds
+// layouts without touching the organizer core.
+type Strategy interface {
+	// FolderPattern returns the naming pattern for book's folder,
+	// relative to the library root. The organizer expands it like
+	// folder_naming_pattern, so placeholders, sanitizing and
+	// disambiguation work the same for every layout; file names still
+	// come from file_naming_pattern.
+	FolderPattern(cfg *config.Config, book *database.Book) string
+	// Validate reports settings the strategy can't work with.
+	Validate(cfg *config.Config) error
+}
+
+// DefaultStrategy is the layout used when organization_layout is unset.
+const DefaultStrategy = "pattern"
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]Strategy{}
+)
+
+// RegisterStrategy makes s available as organization_layout name.
+// Registering a name again replaces it.
+func RegisterStrategy(name string, s Strategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[name] = s
+}
+
+// LookupStrategy returns the strategy registered under name, with ""
+// meaning DefaultStrategy.
+func LookupStrategy(name string) (Strategy, error) {
+	if name == "" {
+		name = DefaultStrategy
+	}
+	strategiesMu.RLock()
+	s, ok := strategies[name]
+	strategiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown layout %q (want one of %s)", name, strings.Join(StrategyNames(), ", "))
+	}
+	return s, nil
+}
+
+// StrategyNames returns the registered layout names, sorted.
+func StrategyNames() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateLayout checks cfg's organization_layout exists and accepts cfg.
+func ValidateLayout(cfg *config.Config) error {
+	s, err := LookupStrategy(cfg.OrganizationLayout)
+	if err != nil {
+		return err
+	}
+	return s.Validate(cfg)
+}
+
+// folderPattern returns the folder pattern of the configured layout.
+func (o *Organizer) folderPattern(book *database.Book) (string, error) {
+	s, err := LookupStrategy(o.config.OrganizationLayout)
+	if err != nil {
+		return "", err
+	}
+	return s.FolderPattern(o.config, book), nil
+}
+
+// patternStrategy is folder_naming_pattern, as configured.
+type patternStrategy struct{}
+
+func (patternStrategy) FolderPattern(cfg *config.Config, _ *database.Book) string {
+	return cfg.FolderNamingPattern
+}
+
+// Validate has nothing to add: config validates folder_naming_pattern.
+func (patternStrategy) Validate(*config.Config) error { return nil }
+
+// fixedStrategy is a layout with one folder pattern for every book.
+type fixedStrategy string
+
+func (f fixedStrategy) FolderPattern(*config.Config, *database.Book) string { return string(f) }
+
+func (fixedStrategy) Validate(*config.Config) error { return nil }
+
+// genreStrategy files books under their primary genre, and books without
+// one under "Unsorted".
+type genreStrategy struct{}
+
+func (genreStrategy) FolderPattern(_ *config.Config, book *database.Book) string {
+	if primaryGenre(book) == "" {
+		return "Unsorted/{author}/{series}/{title}"
+	}
+	return "{genre}/{author}/{series}/{title}"
+}
+
+func (genreStrategy) Validate(*config.Config) error { return nil }
+
+// primaryGenre is the first genre listed on book.
+func primaryGenre(book *database.Book) string {
+	genre := strings.TrimSpace(stringOrEmpty(book.Genre))
+	if i := strings.IndexAny(genre, ",;/"); i >= 0 {
+		genre = strings.TrimSpace(genre[:i])
+	}
+	return genre
+}
+
+// authorInitial buckets an author folder name under its first letter,
+// A–Z, and anything else under "#".
+func authorInitial(name string) string {
+	for _, r := range strings.ToUpper(name) {
+		if r >= 'A' && r <= 'Z' {
+			return string(r)
+		}
+		return "#"
+	}
+	return "#"
+}
+
+func init() {
+	RegisterStrategy(DefaultStrategy, patternStrategy{})
+	RegisterStrategy("author_series", fixedStrategy("{author}/{series}/{title}"))
+	RegisterStrategy("flat", fixedStrategy("{author} - {title}"))
+	RegisterStrategy("genre", genreStrategy{})
+	RegisterStrategy("first_letter", fixedStrategy("{author_initial}/{author}/{series}/{title}"))
+	config.OrganizationLayoutValidator = ValidateLayout
+	config.NamingPatternValidator = ValidatePattern
+}
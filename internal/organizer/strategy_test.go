@@ -0,0 +1,85 @@
+// file: internal/organizer/strategy_test.go
+// version: 1.0.0
+// guid: 5f8a2c6e-9d13-4b70-a4e8-1c7b3e0d9f26
+
+package organizer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+type shelfStrategy struct{}
+
+func (shelfStrategy) FolderPattern(*config.Config, *database.Book) string { return "Shelf/{title}" }
+
+func (shelfStrategy) Validate(cfg *config.Config) error {
+	if cfg.FileNamingPattern == "" {
+		return errors.New("needs file_naming_pattern")
+	}
+	return nil
+}
+
+func TestLayoutStrategies(t *testing.T) {
+	genre := "Science Fiction, Classics"
+	book := &database.Book{
+		Title:    "Dune",
+		FilePath: "/import/dune.m4b",
+		Author:   &database.Author{Name: "Frank Herbert"},
+		Series:   &database.Series{Name: "Dune Chronicles"},
+		Genre:    &genre,
+	}
+	digits := &database.Book{Title: "2001", FilePath: "/import/2001.m4b", Author: &database.Author{Name: "42 Authors"}}
+	RegisterStrategy("test_shelf", shelfStrategy{})
+
+	tests := []struct {
+		layout string
+		book   *database.Book
+		want   string
+	}{
+		{"", book, "Frank Herbert/Dune Chronicles/Dune.m4b"},
+		{"pattern", book, "Frank Herbert/Dune Chronicles/Dune.m4b"},
+		{"author_series", book, "Frank Herbert/Dune Chronicles/Dune/Dune.m4b"},
+		{"flat", book, "Frank Herbert - Dune/Dune.m4b"},
+		{"genre", book, "Science Fiction/Frank Herbert/Dune Chronicles/Dune/Dune.m4b"},
+		{"genre", digits, "Unsorted/42 Authors/2001/2001.m4b"},
+		{"first_letter", book, "F/Frank Herbert/Dune Chronicles/Dune/Dune.m4b"},
+		{"first_letter", digits, "#/42 Authors/2001/2001.m4b"},
+		{"test_shelf", book, "Shelf/Dune/Dune.m4b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout+"/"+tt.book.Title, func(t *testing.T) {
+			cfg := &config.Config{
+				RootDir:             "/library",
+				FolderNamingPattern: "{author}/{series}",
+				FileNamingPattern:   "{title}",
+				OrganizationLayout:  tt.layout,
+			}
+			if err := ValidateLayout(cfg); err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+			got, err := (&Organizer{config: cfg}).GenerateTargetPath(tt.book)
+			if err != nil {
+				t.Fatalf("target path: %v", err)
+			}
+			if want := filepath.Join("/library", tt.want); got != want {
+				t.Errorf("expected: %q\ngot:      %q", want, got)
+			}
+		})
+	}
+
+	cfg := &config.Config{OrganizationLayout: "spiral"}
+	if err := ValidateLayout(cfg); err == nil {
+		t.Error("expected an unknown layout to fail validation")
+	}
+	if _, err := (&Organizer{config: cfg}).GenerateTargetPath(book); err == nil {
+		t.Error("expected an unknown layout to fail organizing")
+	}
+	if err := ValidateLayout(&config.Config{OrganizationLayout: "test_shelf"}); err == nil {
+		t.Error("expected the strategy's own validation to run")
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/organizer/unit_test.go
-// version: 1.0.2
+// version: 1.1.0
 // guid: d4e5f6a7-b8c9-0d1e-2f3a-4b5c6d7e8f90
 
 package organizer
@@ -2083,3 +2083,45 @@ func TestSetHooks(t *testing.T) {
 		t.Error("hooks should be nil after unsetting")
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Request.ScopeBooks
+// ---------------------------------------------------------------------------
+
+func TestRequestScopeBooks(t *testing.T) {
+	ip := func(v int) *int { return &v }
+	sp := func(v string) *string { return &v }
+	books := []database.Book{
+		{ID: "1", AuthorID: ip(7), SeriesID: ip(3), WorkID: sp("w1"), LibraryState: sp("imported"), FilePath: "/lib/A/book1.m4b"},
+		{ID: "2", AuthorID: ip(8), Authors: []database.BookAuthor{{AuthorID: 7, Role: "co-author"}}, FilePath: "/lib/B/book2.m4b"},
+		{ID: "3", AuthorID: ip(8), SeriesID: ip(3), LibraryState: sp("organized"), FilePath: "/lib/AB/book3.m4b"},
+	}
+	ids := func(bs []database.Book) []string {
+		out := []string{}
+		for _, b := range bs {
+			out = append(out, b.ID)
+		}
+		return out
+	}
+	folder := "/lib/A/"
+
+	tests := []struct {
+		name string
+		req  Request
+		want []string
+	}{
+		{"no scope", Request{}, []string{"1", "2", "3"}},
+		{"author includes co-authors", Request{AuthorID: ip(7)}, []string{"1", "2"}},
+		{"series", Request{SeriesID: ip(3)}, []string{"1", "3"}},
+		{"work", Request{WorkID: "w1"}, []string{"1"}},
+		{"library state", Request{LibraryState: "organized"}, []string{"3"}},
+		{"folder does not match sibling prefix", Request{FolderPath: &folder}, []string{"1"}},
+		{"filters combine", Request{SeriesID: ip(3), AuthorID: ip(8)}, []string{"3"}},
+	}
+	for _, tt := range tests {
+		got := ids(tt.req.ScopeBooks(books))
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/playlist/evaluator.go
-// version: 1.1.1
+// version: 1.2.0
 // guid: 9c2d5f1e-6b4a-4a70-b8c5-3d7e0f1b9a68
 //
 // Smart playlist query evaluator (spec 3.4 task 2).
@@ -123,7 +123,9 @@ func EvaluateSmartPlaylist(
 // whose per-user state doesn't satisfy every filter. Filters for
 // fields the user never wrote are treated as "no match" (the user
 // hasn't engaged with the book, so e.g. `read_status:finished`
-// doesn't match an unstarted book).
+// doesn't match an unstarted book). Custom metadata field filters
+// (custom.<key>) are checked against the book's stored values; a
+// book without a value for the field doesn't match.
 func applyPerUserFilters(
 	store database.UserPositionStore,
 	ids []string,
@@ -133,12 +135,30 @@ func applyPerUserFilters(
 	if len(filters) == 0 {
 		return ids
 	}
+	customStore, _ := database.AsCustomFieldStore(store)
 	kept := make([]string, 0, len(ids))
 	for _, id := range ids {
-		state, _ := store.GetUserBookState(userID, id)
+		var (
+			state               *database.UserBookState
+			custom              map[string]string
+			stateLoaded, loaded bool
+		)
 		match := true
 		for _, f := range filters {
-			ok := perUserFilterMatches(state, f.Node)
+			var ok bool
+			if key, isCustom := search.CustomFieldKey(f.Node.Field); isCustom {
+				if !loaded && customStore != nil {
+					custom, _ = customStore.GetBookCustomFields(id)
+				}
+				loaded = true
+				ok = customFieldMatches(custom[key], f.Node)
+			} else {
+				if !stateLoaded {
+					state, _ = store.GetUserBookState(userID, id)
+					stateLoaded = true
+				}
+				ok = perUserFilterMatches(state, f.Node)
+			}
 			if f.Negated {
 				ok = !ok
 			}
@@ -179,6 +199,29 @@ func perUserFilterMatches(state *database.UserBookState, node *search.FieldNode)
 	}
 }
 
+// customFieldMatches evaluates a custom.<key> filter against the
+// book's stored value. Comparators and ranges compare numerically, or
+// as dates when the value is one; anything else is a case-insensitive
+// match, with a trailing * matching by prefix.
+func customFieldMatches(value string, node *search.FieldNode) bool {
+	if value == "" {
+		return false
+	}
+	if node.Op != "" && node.Op != "=" {
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return numericFieldMatches(n, node)
+		}
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return timeFieldMatches(t, node)
+		}
+		return false
+	}
+	if node.Prefix && !node.Wildcard {
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(node.Value))
+	}
+	return strings.EqualFold(value, node.Value)
+}
+
 func numericFieldMatches(got float64, node *search.FieldNode) bool {
 	switch node.Op {
 	case "range":
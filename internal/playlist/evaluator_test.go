@@ -1,5 +1,5 @@
 // file: internal/playlist/evaluator_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 9d3e5f2a-7b4a-4a70-b8c5-3d7e0f1b9a69
 
 package playlist
@@ -199,6 +199,39 @@ func TestEvaluate_PerUserFilterProgressRange(t *testing.T) {
 	}
 }
 
+func TestEvaluate_CustomFieldFilters(t *testing.T) {
+	store, idx, _ := buildEvalFixture(t)
+
+	_ = store.SetBookCustomFields("b1", map[string]string{"source": "Audible", "price": "25"})
+	_ = store.SetBookCustomFields("b2", map[string]string{"source": "CD", "price": "9.5"})
+
+	cases := map[string][]string{
+		"custom.source:audible":                     {"b1"},
+		"author:sanderson -custom.source:audible":   {"b2"},
+		"custom.price:<10":                          {"b2"},
+		"custom.price:[5 TO 30]":                    {"b1", "b2"},
+		"custom.source:aud*":                        {"b1"},
+		"author:jemisin custom.source:cd":           nil,
+		"author:sanderson custom.missing_field:any": nil,
+	}
+	for q, want := range cases {
+		got, err := EvaluateSmartPlaylist(store, idx, q, "", 0, "_local")
+		if err != nil {
+			t.Fatalf("%s: evaluate: %v", q, err)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: got %v, want %v", q, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: got %v, want %v", q, got, want)
+				break
+			}
+		}
+	}
+}
+
 func TestEvaluate_YearRangeQuery(t *testing.T) {
 	store, idx, _ := buildEvalFixture(t)
 
@@ -1,5 +1,5 @@
 // file: internal/plugin/events.go
-// version: 1.2.1
+// version: 1.5.0
 
 package plugin
 
@@ -26,6 +26,24 @@ const (
 	EventScanCompleted     EventType = "scan.completed"
 	EventBookQuarantined   EventType = "book.quarantined"
 	EventBookUnquarantined EventType = "book.unquarantined"
+
+	// Import path health events fire when a pre-scan check finds a path
+	// unreachable (e.g. an unmounted share) and when it recovers.
+	EventImportPathUnhealthy EventType = "import_path.unhealthy"
+	EventImportPathRecovered EventType = "import_path.recovered"
+
+	// EventUpdateAvailable fires the first time an update check finds a
+	// release newer than the running version.
+	EventUpdateAvailable EventType = "update.available"
+
+	// Operation lifecycle events. Data carries the op's id, definition,
+	// structured params and, on terminal events, its outcome.
+	EventOperationCreated   EventType = "operation.created"
+	EventOperationStarted   EventType = "operation.started"
+	EventOperationProgress  EventType = "operation.progress"
+	EventOperationCompleted EventType = "operation.completed"
+	EventOperationFailed    EventType = "operation.failed"
+	EventOperationCanceled  EventType = "operation.canceled"
 )
 
 // Event is a JSON-serializable lifecycle event.
@@ -1,5 +1,5 @@
 // file: internal/plugin/plugin.go
-// version: 1.1.0
+// version: 1.2.0
 
 package plugin
 
@@ -41,6 +41,9 @@ type Deps struct {
 	Config map[string]string
 	Logger logger.Logger
 	Router PluginRouter
+	// BaseURL is the public URL of the web UI (config base_url), without
+	// a trailing slash. Empty when unset.
+	BaseURL string
 }
 
 // DownloadClient is implemented by plugins that manage a download client.
@@ -1,7 +1,7 @@
 // file: internal/plugins/maintenance/cleanup.go
-// version: 1.0.1
+// version: 1.1.0
 // guid: c3d4e5f6-a7b8-9012-cdef-234567890123
-// last-edited: 2026-05-07
+// last-edited: 2026-10-16
 
 package maintenance
 
@@ -30,6 +30,7 @@ func (p *Plugin) purgeDeletedDef() sdk.OperationDef {
 		ResumePolicy:    sdk.ResumeDrop,
 		DefaultPriority: sdk.PriorityLow,
 		ConcurrencyKey:  "maintenance.purge-deleted",
+		Destructive:     true,
 		Cancellable:     false,
 		Isolate:         false,
 		Timeout:         30 * time.Minute,
@@ -0,0 +1,184 @@
+// file: internal/plugins/maintenance/duration_backfill.go
+// version: 1.0.0
+// guid: 9eb2b93f-7929-4e09-a74b-250e6e766713
+// last-edited: 2026-10-16
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
+	"github.com/falkcorp/audiobook-organizer/pkg/plugin/sdk"
+)
+
+type durationBackfillParams struct {
+	DryRun bool `json:"dryRun"`
+}
+
+func (p *Plugin) durationBackfillDef() sdk.OperationDef {
+	return sdk.OperationDef{
+		ID:              "maintenance.duration-backfill",
+		Plugin:          "maintenance",
+		DisplayName:     "Estimate missing book durations",
+		Description:     "Fills in missing book durations from file size and bitrate, marking them as estimated. Per-file durations are summed exactly where known. Default dry-run previews changes; set dryRun=false to apply.",
+		ResumePolicy:    sdk.ResumeDrop,
+		DefaultPriority: sdk.PriorityLow,
+		ConcurrencyKey:  "maintenance.duration-backfill",
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         30 * time.Minute,
+		Schedule:        nil,
+		Capabilities:    []sdk.Capability{sdk.CapLibraryRead, sdk.CapLibraryWrite},
+		Run:             p.runDurationBackfill,
+	}
+}
+
+// estimateBookDuration works out a duration in seconds for a book with
+// none recorded. Per-file durations are used as-is; files without one are
+// estimated from their size and bitrate (falling back to the book's
+// bitrate). When the files can't account for the whole book, the book's
+// own size and bitrate are used. estimated is false only when every file
+// carried a real duration. Returns 0 when nothing usable is known.
+func estimateBookDuration(book database.Book, files []database.BookFile) (seconds int, estimated bool) {
+	bookBitrate := 0
+	if book.Bitrate != nil {
+		bookBitrate = *book.Bitrate
+	}
+	if len(files) > 0 {
+		total, complete := 0, true
+		for _, f := range files {
+			if f.Duration > 0 {
+				total += f.Duration
+				continue
+			}
+			bitrate := f.BitrateKbps
+			if bitrate <= 0 {
+				bitrate = bookBitrate
+			}
+			d := mediainfo.EstimateDuration(f.FileSize, bitrate)
+			if d <= 0 {
+				complete = false
+				break
+			}
+			total += d
+			estimated = true
+		}
+		if complete && total > 0 {
+			return total, estimated
+		}
+	}
+	if book.FileSize == nil {
+		return 0, false
+	}
+	d := mediainfo.EstimateDuration(*book.FileSize, bookBitrate)
+	return d, d > 0
+}
+
+func (p *Plugin) runDurationBackfill(ctx context.Context, raw json.RawMessage, reporter sdk.Reporter) error {
+	params := durationBackfillParams{DryRun: true} // safe default
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+	}
+
+	store := p.deps.Store()
+	if store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if params.DryRun {
+		_ = reporter.Log(slog.LevelInfo, "DRY RUN — no changes will be written")
+	}
+
+	totalBooks, countErr := store.CountBooks()
+	if countErr != nil || totalBooks <= 0 {
+		totalBooks = 0
+	}
+
+	const pageSize = 500
+	var scanned, missing, filled, exact, unknown, errCount int
+	for offset := 0; ; {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		books, err := store.GetAllBooks(pageSize, offset)
+		if err != nil {
+			return fmt.Errorf("GetAllBooks offset=%d: %w", offset, err)
+		}
+		if len(books) == 0 {
+			break
+		}
+
+		for _, book := range books {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			scanned++
+			if book.Duration != nil && *book.Duration > 0 {
+				continue
+			}
+			missing++
+
+			files, err := store.GetBookFiles(book.ID)
+			if err != nil {
+				_ = reporter.Log(slog.LevelWarn, fmt.Sprintf("book %s: GetBookFiles failed: %v", book.ID, err))
+				files = nil
+			}
+			seconds, estimated := estimateBookDuration(book, files)
+			if seconds <= 0 {
+				unknown++
+				continue
+			}
+			if !estimated {
+				exact++
+			}
+			_ = reporter.Log(slog.LevelDebug, fmt.Sprintf("book %s: duration %ds (estimated=%v)", book.ID, seconds, estimated))
+
+			if !params.DryRun {
+				book.Duration = &seconds
+				book.DurationEstimated = nil
+				if estimated {
+					book.DurationEstimated = &estimated
+				}
+				if _, err := store.UpdateBook(book.ID, &book); err != nil {
+					_ = reporter.Log(slog.LevelWarn, fmt.Sprintf("book %s: UpdateBook failed: %v", book.ID, err))
+					errCount++
+					continue
+				}
+			}
+			filled++
+		}
+
+		offset += len(books)
+		total := totalBooks
+		if total == 0 {
+			total = scanned
+		}
+		_ = reporter.UpdateProgress(scanned, total,
+			fmt.Sprintf("Scanned %d/%d — %d durations filled", scanned, total, filled))
+		if len(books) < pageSize {
+			break
+		}
+	}
+
+	suffix := ""
+	if params.DryRun {
+		suffix = " (dry run — no writes)"
+	}
+	result := fmt.Sprintf("Scanned %d books: %d missing a duration, %d filled (%d exact from files), %d without size/bitrate, %d errors%s",
+		scanned, missing, filled, exact, unknown, errCount, suffix)
+	_ = reporter.Log(slog.LevelInfo, result)
+	_ = reporter.UpdateProgress(scanned, scanned, result)
+
+	if errCount > 0 {
+		return fmt.Errorf("%d UpdateBook errors (see op log for details)", errCount)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// file: internal/plugins/maintenance/duration_backfill_test.go
+// version: 1.0.0
+// guid: 76321bd1-9191-4901-96a3-5bc3616a9273
+// last-edited: 2026-10-16
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestEstimateBookDuration(t *testing.T) {
+	i64 := func(v int64) *int64 { return &v }
+	ip := func(v int) *int { return &v }
+
+	// 64kbps = 8000 bytes/s.
+	book := database.Book{FileSize: i64(8000 * 3600), Bitrate: ip(64)}
+	if d, est := estimateBookDuration(book, nil); d != 3600 || !est {
+		t.Errorf("book-level: got %d/%v, want 3600/true", d, est)
+	}
+
+	files := []database.BookFile{{Duration: 100}, {Duration: 200}}
+	if d, est := estimateBookDuration(database.Book{}, files); d != 300 || est {
+		t.Errorf("exact files: got %d/%v, want 300/false", d, est)
+	}
+
+	// Second file has no duration: estimated from its size at the book bitrate.
+	files = []database.BookFile{{Duration: 100}, {FileSize: 8000 * 50}}
+	if d, est := estimateBookDuration(database.Book{Bitrate: ip(64)}, files); d != 150 || !est {
+		t.Errorf("mixed files: got %d/%v, want 150/true", d, est)
+	}
+
+	if d, _ := estimateBookDuration(database.Book{FileSize: i64(1000)}, nil); d != 0 {
+		t.Errorf("no bitrate: got %d, want 0", d)
+	}
+}
+
+func TestDurationBackfill_Apply(t *testing.T) {
+	i64 := func(v int64) *int64 { return &v }
+	ip := func(v int) *int { return &v }
+	books := []database.Book{
+		{ID: "b1", FileSize: i64(16000 * 60), Bitrate: ip(128)},
+		{ID: "b2", Duration: ip(500), FileSize: i64(16000 * 60), Bitrate: ip(128)}, // already known
+		{ID: "b3"}, // nothing to go on
+	}
+	p, written := newTestPlugin(books)
+	store := p.deps.Store().(*database.MockStore)
+	store.GetBookFilesFunc = func(string) ([]database.BookFile, error) { return nil, nil }
+
+	raw, _ := json.Marshal(durationBackfillParams{DryRun: false})
+	if err := p.runDurationBackfill(context.Background(), raw, &fakeReporter{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*written) != 1 {
+		t.Fatalf("expected 1 write, got %d", len(*written))
+	}
+	got := (*written)[0]
+	if got.ID != "b1" || got.Duration == nil || *got.Duration != 60 {
+		t.Errorf("b1: got %+v", got.Duration)
+	}
+	if got.DurationEstimated == nil || !*got.DurationEstimated {
+		t.Error("b1 duration should be flagged as estimated")
+	}
+}
@@ -0,0 +1,101 @@
+// file: internal/plugins/maintenance/operation_archive.go
+// version: 1.1.0
+// guid: 6854747f-e81e-4804-8918-0d57bee4407d
+// last-edited: 2026-10-17
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/pkg/plugin/sdk"
+)
+
+// --- archive-operations ---
+
+func (p *Plugin) archiveOperationsDef() sdk.OperationDef {
+	sched := "45 1 * * *" // 01:45 daily
+	return sdk.OperationDef{
+		ID:              "maintenance.archive-operations",
+		Plugin:          "maintenance",
+		DisplayName:     "Archive old operations",
+		Description:     "Moves finished operations older than operation_archive_after_days, with their logs, into compressed archive storage and prunes archived entries past operation_archive_retention_days. Also deletes registry operations, with their logs, that finished more than operation_log_retention_days ago.",
+		ResumePolicy:    sdk.ResumeDrop,
+		DefaultPriority: sdk.PriorityLow,
+		ConcurrencyKey:  "maintenance.archive-operations",
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         30 * time.Minute,
+		Schedule:        &sched,
+		Capabilities:    []sdk.Capability{sdk.CapLibraryRead, sdk.CapLibraryWrite},
+		Run:             p.runArchiveOperations,
+	}
+}
+
+func (p *Plugin) runArchiveOperations(ctx context.Context, _ json.RawMessage, reporter sdk.Reporter) error {
+	store := p.deps.Store()
+	if store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	var unwrapped database.Store
+	if uw, isWrapped := store.(interface{ Unwrap() database.Store }); isWrapped {
+		unwrapped = uw.Unwrap()
+	}
+
+	archive, ok := store.(database.OperationArchiveStore)
+	if !ok {
+		archive, ok = unwrapped.(database.OperationArchiveStore)
+	}
+	switch archiveDays := config.AppConfig.OperationArchiveAfterDays; {
+	case archiveDays <= 0:
+		_ = reporter.Log(slog.LevelInfo, "Operation archiving disabled, skipping")
+	case !ok:
+		_ = reporter.Log(slog.LevelInfo, "Store does not support operation archiving, skipping")
+	default:
+		if err := archiveOperations(ctx, archive, archiveDays, reporter); err != nil {
+			return err
+		}
+	}
+
+	retentionDays := config.AppConfig.OperationLogRetentionDays
+	if retentionDays <= 0 {
+		return nil
+	}
+	pruner, ok := store.(database.OperationV2Pruner)
+	if !ok {
+		pruner, ok = unwrapped.(database.OperationV2Pruner)
+	}
+	if !ok {
+		return nil
+	}
+	pruned, err := pruner.PruneOperationsV2(ctx, time.Now().AddDate(0, 0, -retentionDays), nil)
+	if err != nil {
+		return fmt.Errorf("prune registry operations: %w", err)
+	}
+	_ = reporter.Log(slog.LevelInfo, fmt.Sprintf("Deleted %d registry operations finished more than %d days ago", pruned, retentionDays))
+	return nil
+}
+
+func archiveOperations(ctx context.Context, archive database.OperationArchiveStore, archiveDays int, reporter sdk.Reporter) error {
+	archived, err := archive.ArchiveOperationsBefore(ctx, time.Now().AddDate(0, 0, -archiveDays))
+	if err != nil {
+		return fmt.Errorf("archive operations: %w", err)
+	}
+	msg := fmt.Sprintf("Archived %d operations older than %d days", archived, archiveDays)
+
+	if retentionDays := config.AppConfig.OperationArchiveRetentionDays; retentionDays > 0 {
+		pruned, err := archive.PruneArchivedOperations(time.Now().AddDate(0, 0, -retentionDays))
+		if err != nil {
+			return fmt.Errorf("prune archived operations: %w", err)
+		}
+		msg += fmt.Sprintf("; pruned %d archived operations older than %d days", pruned, retentionDays)
+	}
+	_ = reporter.Log(slog.LevelInfo, msg)
+	return nil
+}
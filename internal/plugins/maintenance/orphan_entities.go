@@ -0,0 +1,218 @@
+// file: internal/plugins/maintenance/orphan_entities.go
+// version: 1.0.0
+// guid: 16e80ad0-651c-4b5d-86c9-aef7ec7f2f32
+// last-edited: 2026-10-16
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/pkg/plugin/sdk"
+)
+
+// OrphanEntitiesCleanupParams are the JSON parameters for the orphan
+// author/series/work cleanup op. When Delete is false (default), the op
+// only reports what it would remove.
+type OrphanEntitiesCleanupParams struct {
+	Delete bool `json:"delete"`
+}
+
+// orphanEntities is the result of findOrphanEntities.
+type orphanEntities struct {
+	Authors []database.Author
+	Series  []database.Series
+	Works   []database.Work
+}
+
+func (o orphanEntities) total() int { return len(o.Authors) + len(o.Series) + len(o.Works) }
+
+// orphanEntitiesCleanupDef registers maintenance.orphan-entities-cleanup.
+// Book deletes and merges leave author, series and work rows behind with
+// nothing pointing at them; this op finds and (optionally) removes them.
+// Runs weekly on Wednesday at 03:30, clear of series-prune (Tue 03:00).
+func (p *Plugin) orphanEntitiesCleanupDef() sdk.OperationDef {
+	sched := "30 3 * * 3" // 03:30 every Wednesday
+	return sdk.OperationDef{
+		ID:              "maintenance.orphan-entities-cleanup",
+		Plugin:          "maintenance",
+		DisplayName:     "Orphan author/series/work cleanup",
+		Description:     "Detects authors, series and works no book references (including soft-deleted books). Reports the counts by default; pass {\"delete\": true} to remove them.",
+		ResumePolicy:    sdk.ResumeDrop,
+		DefaultPriority: sdk.PriorityLow,
+		ConcurrencyKey:  "maintenance.orphan-entities-cleanup",
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         30 * time.Minute,
+		Schedule:        &sched,
+		Capabilities:    []sdk.Capability{sdk.CapLibraryRead, sdk.CapLibraryWrite},
+		Run:             p.runOrphanEntitiesCleanup,
+	}
+}
+
+func (p *Plugin) runOrphanEntitiesCleanup(ctx context.Context, raw json.RawMessage, reporter sdk.Reporter) error {
+	var params OrphanEntitiesCleanupParams
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+	}
+	store := p.deps.Store()
+	if store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	scanProg := sdk.NewProgress(reporter, 0)
+	scanProg.Start("Scanning authors, series and works for orphans...")
+
+	orphans, err := findOrphanEntities(ctx, store)
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+	_ = reporter.Log(slog.LevelInfo, "Orphan entity scan complete",
+		slog.Int("authors", len(orphans.Authors)),
+		slog.Int("series", len(orphans.Series)),
+		slog.Int("works", len(orphans.Works)),
+	)
+	summary := fmt.Sprintf("%d author(s), %d series, %d work(s)",
+		len(orphans.Authors), len(orphans.Series), len(orphans.Works))
+
+	if !params.Delete || orphans.total() == 0 {
+		msg := "Orphan entity scan: " + summary + " detected (report-only)"
+		if params.Delete {
+			msg = "Orphan entity cleanup: no orphans found, nothing to delete"
+		}
+		_ = reporter.Log(slog.LevelInfo, msg)
+		scanProg.Done(msg)
+		return nil
+	}
+
+	total := orphans.total()
+	prog := sdk.NewProgress(reporter, total)
+	prog.Start("Deleting orphans: " + summary)
+	var deleted, failed, step int
+	try := func(kind, id string, fn func() error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		step++
+		if err := fn(); err != nil {
+			failed++
+			_ = reporter.Log(slog.LevelWarn, "Failed to delete orphan "+kind,
+				slog.String("id", id),
+				slog.String("error", err.Error()),
+			)
+			return nil
+		}
+		deleted++
+		prog.StepN(step, fmt.Sprintf("Deleting orphans: %d/%d", step, total))
+		return nil
+	}
+	// Series before authors: an orphan series may be the last thing
+	// pointing at an author, and findOrphanEntities already counted that
+	// author as orphaned on the same basis.
+	for _, s := range orphans.Series {
+		if err := try("series", fmt.Sprint(s.ID), func() error { return store.DeleteSeries(s.ID) }); err != nil {
+			return err
+		}
+	}
+	for _, a := range orphans.Authors {
+		if err := try("author", fmt.Sprint(a.ID), func() error { return store.DeleteAuthor(a.ID) }); err != nil {
+			return err
+		}
+	}
+	for _, w := range orphans.Works {
+		if err := try("work", w.ID, func() error { return store.DeleteWork(w.ID) }); err != nil {
+			return err
+		}
+	}
+
+	final := fmt.Sprintf("Orphan entity cleanup: deleted %d, failed %d (of %d detected)", deleted, failed, total)
+	_ = reporter.Log(slog.LevelInfo, final)
+	prog.Done(final)
+	return nil
+}
+
+// findOrphanEntities returns every author, series and work that no book
+// references. Soft-deleted books still count as references — they can be
+// restored, and purge-deleted will orphan their entities in due course.
+//
+// An author is referenced by a book's AuthorID, by any book_authors row, or
+// by a series that is itself referenced. This is the testable core of
+// runOrphanEntitiesCleanup and never modifies the store.
+func findOrphanEntities(ctx context.Context, store database.Store) (orphanEntities, error) {
+	var out orphanEntities
+	books, err := store.GetAllBooks(0, 0)
+	if err != nil {
+		return out, fmt.Errorf("GetAllBooks: %w", err)
+	}
+	deleted, err := store.ListSoftDeletedBooks(0, 0, nil)
+	if err != nil {
+		return out, fmt.Errorf("ListSoftDeletedBooks: %w", err)
+	}
+	books = append(books, deleted...)
+
+	authorRefs := make(map[int]struct{})
+	seriesRefs := make(map[int]struct{})
+	workRefs := make(map[string]struct{})
+	for _, b := range books {
+		if ctx.Err() != nil {
+			return out, ctx.Err()
+		}
+		if b.AuthorID != nil {
+			authorRefs[*b.AuthorID] = struct{}{}
+		}
+		if b.SeriesID != nil {
+			seriesRefs[*b.SeriesID] = struct{}{}
+		}
+		if b.WorkID != nil && *b.WorkID != "" {
+			workRefs[*b.WorkID] = struct{}{}
+		}
+		links, err := store.GetBookAuthors(b.ID)
+		if err != nil {
+			return out, fmt.Errorf("GetBookAuthors(%s): %w", b.ID, err)
+		}
+		for _, ba := range links {
+			authorRefs[ba.AuthorID] = struct{}{}
+		}
+	}
+
+	series, err := store.GetAllSeries()
+	if err != nil {
+		return out, fmt.Errorf("GetAllSeries: %w", err)
+	}
+	for _, s := range series {
+		if _, ok := seriesRefs[s.ID]; !ok {
+			out.Series = append(out.Series, s)
+			continue
+		}
+		if s.AuthorID != nil {
+			authorRefs[*s.AuthorID] = struct{}{}
+		}
+	}
+
+	authors, err := store.GetAllAuthors()
+	if err != nil {
+		return out, fmt.Errorf("GetAllAuthors: %w", err)
+	}
+	for _, a := range authors {
+		if _, ok := authorRefs[a.ID]; !ok {
+			out.Authors = append(out.Authors, a)
+		}
+	}
+
+	works, err := store.GetAllWorks()
+	if err != nil {
+		return out, fmt.Errorf("GetAllWorks: %w", err)
+	}
+	for _, w := range works {
+		if _, ok := workRefs[w.ID]; !ok {
+			out.Works = append(out.Works, w)
+		}
+	}
+	return out, nil
+}
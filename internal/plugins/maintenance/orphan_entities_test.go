@@ -0,0 +1,64 @@
+// file: internal/plugins/maintenance/orphan_entities_test.go
+// version: 1.0.0
+// guid: 60656e62-7fa4-4f5c-8d76-23e896faa58a
+// last-edited: 2026-10-16
+
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// TestFindOrphanEntities covers the three reference paths: a live book, a
+// soft-deleted book, and an author kept alive only by a referenced series.
+func TestFindOrphanEntities(t *testing.T) {
+	ip := func(v int) *int { return &v }
+	sp := func(v string) *string { return &v }
+	store := &database.MockStore{
+		GetAllBooksFunc: func(limit, offset int) ([]database.Book, error) {
+			return []database.Book{{ID: "b1", AuthorID: ip(1), SeriesID: ip(10), WorkID: sp("w1")}}, nil
+		},
+		ListSoftDeletedBooksFunc: func(limit, offset int, olderThan *time.Time) ([]database.Book, error) {
+			return []database.Book{{ID: "b2", AuthorID: ip(2)}}, nil
+		},
+		GetAllSeriesFunc: func() ([]database.Series, error) {
+			return []database.Series{
+				{ID: 10, Name: "Live", AuthorID: ip(3)},
+				{ID: 11, Name: "Dead", AuthorID: ip(4)},
+			}, nil
+		},
+		GetAllAuthorsFunc: func() ([]database.Author, error) {
+			return []database.Author{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}, nil
+		},
+		GetAllWorksFunc: func() ([]database.Work, error) {
+			return []database.Work{{ID: "w1"}, {ID: "w2"}}, nil
+		},
+	}
+
+	got, err := findOrphanEntities(context.Background(), store)
+	if err != nil {
+		t.Fatalf("findOrphanEntities returned error: %v", err)
+	}
+
+	var authorIDs []int
+	for _, a := range got.Authors {
+		authorIDs = append(authorIDs, a.ID)
+	}
+	// 4 is only referenced by orphan series 11; 5 by nothing.
+	if len(authorIDs) != 2 || authorIDs[0] != 4 || authorIDs[1] != 5 {
+		t.Errorf("orphan authors = %v, want [4 5]", authorIDs)
+	}
+	if len(got.Series) != 1 || got.Series[0].ID != 11 {
+		t.Errorf("orphan series = %+v, want [11]", got.Series)
+	}
+	if len(got.Works) != 1 || got.Works[0].ID != "w2" {
+		t.Errorf("orphan works = %+v, want [w2]", got.Works)
+	}
+	if got.total() != 4 {
+		t.Errorf("total = %d, want 4", got.total())
+	}
+}
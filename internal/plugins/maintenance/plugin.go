@@ -1,7 +1,7 @@
 // file: internal/plugins/maintenance/plugin.go
-// version: 1.2.0
+// version: 1.6.0
 // guid: b2c3d4e5-f6a7-8901-bcde-123456789012
-// last-edited: 2026-05-31
+// last-edited: 2026-10-17
 
 package maintenance
 
@@ -41,6 +41,8 @@ func (p *Plugin) Register(r sdk.Registry) error {
 		p.trashCleanupDef(),
 		p.archiveSweepDef(),
 		p.orphanBookFilesCleanupDef(),
+		p.orphanEntitiesCleanupDef(),
+		p.archiveOperationsDef(),
 
 		// --- database ---
 		p.dbOptimizeDef(),
@@ -50,6 +52,7 @@ func (p *Plugin) Register(r sdk.Registry) error {
 		p.authorSplitScanDef(),
 		p.seriesNormalizeDef(),
 		p.seriesPruneDef(),
+		p.seriesSequenceInferDef(),
 		p.resolveProductionAuthorsDef(),
 
 		// --- metadata ---
@@ -73,6 +76,9 @@ func (p *Plugin) Register(r sdk.Registry) error {
 		// --- title cleanup ---
 		p.titleBackfillDef(),
 
+		// --- media info backfill ---
+		p.durationBackfillDef(),
+
 		// --- one-shot startup backfills ---
 		p.externalIDBackfillDef(),
 		p.movementAtomCleanupDef(),
@@ -0,0 +1,207 @@
+// file: internal/plugins/maintenance/series_sequence.go
+// version: 1.0.0
+// guid: 8c4f1e26-b7a3-4d95-9e08-3a6d2c5b7f14
+// last-edited: 2026-10-17
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+	"github.com/falkcorp/audiobook-organizer/pkg/plugin/sdk"
+)
+
+type seriesSequenceInferParams struct {
+	DryRun bool `json:"dryRun"`
+}
+
+func (p *Plugin) seriesSequenceInferDef() sdk.OperationDef {
+	return sdk.OperationDef{
+		ID:              "maintenance.series-sequence-infer",
+		Plugin:          "maintenance",
+		DisplayName:     "Infer series positions from file names",
+		Description:     "Reads series positions from file names ('03 - Title', 'Book 3'), folder names and album track numbers for books in a series. Fills missing positions and logs every inference; positions that disagree with existing (provider) data are reported as conflicts and left alone. Default dry-run previews changes; set dryRun=false to apply.",
+		ResumePolicy:    sdk.ResumeDrop,
+		DefaultPriority: sdk.PriorityLow,
+		ConcurrencyKey:  "maintenance.series-sequence-infer",
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         30 * time.Minute,
+		Schedule:        nil,
+		Capabilities:    []sdk.Capability{sdk.CapLibraryRead, sdk.CapLibraryWrite},
+		Run:             p.runSeriesSequenceInfer,
+	}
+}
+
+// inferBookSeriesSequence works out a series position for book from its
+// files. Single-file books are read from the file name (the original
+// name when the file has since been renamed), then the folder name, then
+// the file's track number when it is one track of a larger album — a
+// series ripped as one album per book. Multi-file books are read from
+// their folder name only: their file numbers are chapter order.
+func inferBookSeriesSequence(book database.Book, files []database.BookFile) (metadata.SeriesSeqHint, bool) {
+	present := make([]database.BookFile, 0, len(files))
+	for _, f := range files {
+		if !f.Missing {
+			present = append(present, f)
+		}
+	}
+	if len(present) > 1 {
+		return metadata.InferSeriesSequence("", filepath.Base(filepath.Dir(present[0].FilePath)))
+	}
+
+	path, fileName := book.FilePath, ""
+	if book.OriginalFilename != nil {
+		fileName = *book.OriginalFilename
+	}
+	if len(present) == 1 {
+		path = present[0].FilePath
+		if present[0].OriginalFilename != "" {
+			fileName = present[0].OriginalFilename
+		}
+	}
+	if path == "" {
+		return metadata.SeriesSeqHint{}, false
+	}
+	if fileName == "" {
+		fileName = filepath.Base(path)
+	}
+	if hint, ok := metadata.InferSeriesSequence(fileName, filepath.Base(filepath.Dir(path))); ok {
+		return hint, true
+	}
+	if len(present) == 1 && present[0].TrackNumber > 0 && present[0].TrackCount > 1 {
+		return metadata.SeriesSeqHint{
+			Value:  strconv.Itoa(present[0].TrackNumber),
+			Source: metadata.SeqSourceTrack,
+			Name:   fmt.Sprintf("track %d of %d", present[0].TrackNumber, present[0].TrackCount),
+		}, true
+	}
+	return metadata.SeriesSeqHint{}, false
+}
+
+func (p *Plugin) runSeriesSequenceInfer(ctx context.Context, raw json.RawMessage, reporter sdk.Reporter) error {
+	params := seriesSequenceInferParams{DryRun: true} // safe default
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+	}
+
+	store := p.deps.Store()
+	if store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if params.DryRun {
+		_ = reporter.Log(slog.LevelInfo, "DRY RUN — no changes will be written")
+	}
+
+	totalBooks, countErr := store.CountBooks()
+	if countErr != nil || totalBooks <= 0 {
+		totalBooks = 0
+	}
+
+	const pageSize = 500
+	var scanned, inSeries, filled, agreed, conflicts, errCount int
+	for offset := 0; ; {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		books, err := store.GetAllBooks(pageSize, offset)
+		if err != nil {
+			return fmt.Errorf("GetAllBooks offset=%d: %w", offset, err)
+		}
+		if len(books) == 0 {
+			break
+		}
+
+		for _, book := range books {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			scanned++
+			if book.SeriesID == nil {
+				continue
+			}
+			inSeries++
+
+			files, err := store.GetBookFiles(book.ID)
+			if err != nil {
+				_ = reporter.Log(slog.LevelWarn, fmt.Sprintf("book %s: GetBookFiles failed: %v", book.ID, err))
+				files = nil
+			}
+			hint, ok := inferBookSeriesSequence(book, files)
+			if !ok {
+				continue
+			}
+			seq := database.ParseSeriesSeq(hint.Value)
+			attrs := []slog.Attr{
+				slog.String("book_id", book.ID),
+				slog.String("title", book.Title),
+				slog.String("inferred", seq.String()),
+				slog.String("source", hint.Source),
+				slog.String("from", hint.Name),
+			}
+
+			if book.SeriesSequence != nil {
+				if database.CompareSeriesSeq(seq, book.SeriesSequence) == 0 {
+					agreed++
+					continue
+				}
+				conflicts++
+				provider := "unknown"
+				if book.MetadataSource != nil && *book.MetadataSource != "" {
+					provider = *book.MetadataSource
+				}
+				attrs = append(attrs, slog.String("existing", book.SeriesSequence.String()), slog.String("metadata_source", provider))
+				_ = reporter.Log(slog.LevelWarn, fmt.Sprintf("book %s: %s says position %s, existing data says %s",
+					book.ID, hint.Source, seq, book.SeriesSequence), attrs...)
+				continue
+			}
+
+			_ = reporter.Log(slog.LevelInfo, fmt.Sprintf("book %s: position %s from %s %q", book.ID, seq, hint.Source, hint.Name), attrs...)
+			if !params.DryRun {
+				book.SeriesSequence = seq
+				if _, err := store.UpdateBook(book.ID, &book); err != nil {
+					_ = reporter.Log(slog.LevelWarn, fmt.Sprintf("book %s: UpdateBook failed: %v", book.ID, err))
+					errCount++
+					continue
+				}
+			}
+			filled++
+		}
+
+		offset += len(books)
+		total := totalBooks
+		if total == 0 {
+			total = scanned
+		}
+		_ = reporter.UpdateProgress(scanned, total,
+			fmt.Sprintf("Scanned %d/%d — %d positions filled, %d conflicts", scanned, total, filled, conflicts))
+		if len(books) < pageSize {
+			break
+		}
+	}
+
+	suffix := ""
+	if params.DryRun {
+		suffix = " (dry run — no writes)"
+	}
+	result := fmt.Sprintf("Scanned %d books (%d in a series): %d positions filled, %d confirmed, %d conflicts with existing data, %d errors%s",
+		scanned, inSeries, filled, agreed, conflicts, errCount, suffix)
+	_ = reporter.Log(slog.LevelInfo, result)
+	_ = reporter.UpdateProgress(scanned, scanned, result)
+
+	if errCount > 0 {
+		return fmt.Errorf("%d UpdateBook errors (see op log for details)", errCount)
+	}
+	return nil
+}
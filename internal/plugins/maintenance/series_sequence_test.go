@@ -0,0 +1,84 @@
+// file: internal/plugins/maintenance/series_sequence_test.go
+// version: 1.0.0
+// guid: 6a1d3f85-e2c7-4b09-8d64-b5f0c9e27a13
+// last-edited: 2026-10-17
+
+package maintenance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+)
+
+func TestInferBookSeriesSequence(t *testing.T) {
+	orig := "05 - Title.mp3"
+	hint, ok := inferBookSeriesSequence(database.Book{FilePath: "/lib/A/S/Title.m4b", OriginalFilename: &orig}, nil)
+	if !ok || hint.Value != "05" || hint.Source != metadata.SeqSourceFilename {
+		t.Errorf("original filename: got %+v, %v", hint, ok)
+	}
+
+	// Multi-file: leading file numbers are chapters, the folder decides.
+	files := []database.BookFile{
+		{FilePath: "/lib/S/Book 2 - Title/01 - Chapter.mp3"},
+		{FilePath: "/lib/S/Book 2 - Title/02 - Chapter.mp3"},
+		{FilePath: "/lib/S/Book 2 - Title/gone.mp3", Missing: true},
+	}
+	hint, ok = inferBookSeriesSequence(database.Book{}, files)
+	if !ok || hint.Value != "2" || hint.Source != metadata.SeqSourceFolder {
+		t.Errorf("multi-file: got %+v, %v", hint, ok)
+	}
+	if _, ok = inferBookSeriesSequence(database.Book{}, files[:1:1]); !ok {
+		t.Error("single present file should still read its folder")
+	}
+
+	hint, ok = inferBookSeriesSequence(database.Book{}, []database.BookFile{{FilePath: "/lib/S/Title.m4b", TrackNumber: 3, TrackCount: 7}})
+	if !ok || hint.Value != "3" || hint.Source != metadata.SeqSourceTrack {
+		t.Errorf("track: got %+v, %v", hint, ok)
+	}
+	if _, ok = inferBookSeriesSequence(database.Book{}, []database.BookFile{{FilePath: "/lib/S/Title.m4b", TrackNumber: 1, TrackCount: 1}}); ok {
+		t.Error("track 1 of 1 is not a series position")
+	}
+}
+
+func TestSeriesSequenceInfer_Apply(t *testing.T) {
+	sid := 1
+	source := "audible"
+	books := []database.Book{
+		{ID: "b1", SeriesID: &sid, FilePath: "/lib/S/03 - Third.m4b"},                                                                    // filled
+		{ID: "b2", SeriesID: &sid, FilePath: "/lib/S/04 - Fourth.m4b", SeriesSequence: database.NewSeriesSeq(4)},                         // agrees
+		{ID: "b3", SeriesID: &sid, FilePath: "/lib/S/05 - Fifth.m4b", SeriesSequence: database.NewSeriesSeq(6), MetadataSource: &source}, // conflict
+		{ID: "b4", FilePath: "/lib/S/07 - Standalone.m4b"},                                                                               // no series
+		{ID: "b5", SeriesID: &sid, FilePath: "/lib/S/Untitled.m4b"},                                                                      // nothing to read
+	}
+
+	p, written := newTestPlugin(books)
+	rep := &fakeReporter{}
+	if err := p.runSeriesSequenceInfer(context.Background(), []byte(`{"dryRun":true}`), rep); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(*written) != 0 {
+		t.Fatalf("dry run wrote %d books", len(*written))
+	}
+	var conflict bool
+	for _, msg := range rep.logs {
+		conflict = conflict || strings.Contains(msg, "book b3: filename says position 5, existing data says 6")
+	}
+	if !conflict {
+		t.Errorf("conflict not reported; logs: %v", rep.logs)
+	}
+
+	if err := p.runSeriesSequenceInfer(context.Background(), []byte(`{"dryRun":false}`), rep); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(*written) != 1 {
+		t.Fatalf("expected 1 write, got %d", len(*written))
+	}
+	got := (*written)[0]
+	if got.ID != "b1" || got.SeriesSequence == nil || got.SeriesSequence.String() != "3" {
+		t.Errorf("b1: got %s %v", got.ID, got.SeriesSequence)
+	}
+}
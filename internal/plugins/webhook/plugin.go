@@ -1,6 +1,7 @@
 // file: internal/plugins/webhook/plugin.go
-// version: 1.0.0
+// version: 1.4.0
 // guid: f7a8b9c0-d1e2-3f4a-5b6c-7d8e9f0a1b2c
+// last-edited: 2026-10-16
 
 package webhook
 
@@ -13,6 +14,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -22,11 +24,27 @@ import (
 // Plugin delivers lifecycle events to configured webhook URLs via HTTP POST.
 // It implements CapEventSubscriber: on Init it subscribes to the EventBus for
 // each event type listed in its config and fires HMAC-signed HTTP POSTs.
+//
+// Config keys:
+//   - urls: comma-separated endpoints (required).
+//   - secret: HMAC-SHA256 signing key.
+//   - events: comma-separated event types; "all" (the default) covers the
+//     library events. Operation events are opt-in: list them by name or
+//     use "operation.*" for the whole lifecycle.
+//   - operations: comma-separated operation definition IDs (e.g.
+//     "library.scan,library.organize") limiting which operations'
+//     events are delivered. Empty delivers every operation.
+//
+// When the server has base_url configured, payloads carry a "url" field
+// linking to the book (book events) or the activity log (operation
+// events) in the web UI.
 type Plugin struct {
-	urls   []string
-	secret string
-	events []plugin.EventType
-	client *http.Client
+	urls    []string
+	secret  string
+	events  []plugin.EventType
+	opDefs  map[string]bool
+	baseURL string
+	client  *http.Client
 }
 
 func init() { plugin.Register(&Plugin{}) }
@@ -55,6 +73,7 @@ func (p *Plugin) Init(ctx context.Context, deps plugin.Deps) error {
 	}
 
 	p.secret = deps.Config["secret"]
+	p.baseURL = strings.TrimRight(deps.BaseURL, "/")
 	p.client = &http.Client{Timeout: 10 * time.Second}
 
 	// Determine which events to subscribe to. Default: all.
@@ -64,11 +83,25 @@ func (p *Plugin) Init(ctx context.Context, deps plugin.Deps) error {
 	} else {
 		for _, e := range strings.Split(rawEvents, ",") {
 			e = strings.TrimSpace(e)
-			if e != "" {
+			switch e {
+			case "":
+			case "all":
+				p.events = append(p.events, allEventTypes()...)
+			case "operation.*":
+				p.events = append(p.events, operationEventTypes()...)
+			default:
 				p.events = append(p.events, plugin.EventType(e))
 			}
 		}
 	}
+	for _, id := range strings.Split(deps.Config["operations"], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			if p.opDefs == nil {
+				p.opDefs = make(map[string]bool)
+			}
+			p.opDefs[id] = true
+		}
+	}
 
 	if deps.Events == nil {
 		return fmt.Errorf("webhook: event bus not provided")
@@ -100,7 +133,13 @@ func (p *Plugin) HealthCheck() error {
 // Each request includes an X-Audiobook-Signature-256 header with an
 // HMAC-SHA256 hex digest of the payload if a secret is configured.
 func (p *Plugin) deliver(ctx context.Context, event plugin.Event) error {
-	body, err := json.Marshal(event)
+	if !p.wantsOperation(event) {
+		return nil
+	}
+	body, err := json.Marshal(struct {
+		plugin.Event
+		URL string `json:"url,omitempty"`
+	}{event, p.eventURL(event)})
 	if err != nil {
 		return fmt.Errorf("webhook: marshal event: %w", err)
 	}
@@ -113,10 +152,10 @@ func (p *Plugin) deliver(ctx context.Context, event plugin.Event) error {
 	}
 
 	var errs []string
-	for _, url := range p.urls {
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	for _, target := range p.urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
 		if err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", url, err))
+			errs = append(errs, fmt.Sprintf("%s: %v", target, err))
 			continue
 		}
 		req.Header.Set("Content-Type", "application/json")
@@ -127,12 +166,12 @@ func (p *Plugin) deliver(ctx context.Context, event plugin.Event) error {
 
 		resp, err := p.client.Do(req)
 		if err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", url, err))
+			errs = append(errs, fmt.Sprintf("%s: %v", target, err))
 			continue
 		}
 		resp.Body.Close()
 		if resp.StatusCode >= 400 {
-			errs = append(errs, fmt.Sprintf("%s: HTTP %d", url, resp.StatusCode))
+			errs = append(errs, fmt.Sprintf("%s: HTTP %d", target, resp.StatusCode))
 		}
 	}
 
@@ -142,6 +181,31 @@ func (p *Plugin) deliver(ctx context.Context, event plugin.Event) error {
 	return nil
 }
 
+// eventURL links the event to its page in the web UI, or "" when no
+// base_url is configured.
+func (p *Plugin) eventURL(event plugin.Event) string {
+	switch {
+	case p.baseURL == "":
+		return ""
+	case event.BookID != "":
+		return p.baseURL + "/library/" + url.PathEscape(event.BookID)
+	case strings.HasPrefix(string(event.Type), "operation."):
+		return p.baseURL + "/activity"
+	default:
+		return p.baseURL
+	}
+}
+
+// wantsOperation applies the operations filter to operation.* events.
+// Other events always pass.
+func (p *Plugin) wantsOperation(event plugin.Event) bool {
+	if len(p.opDefs) == 0 || !strings.HasPrefix(string(event.Type), "operation.") {
+		return true
+	}
+	defID, _ := event.Data["def_id"].(string)
+	return p.opDefs[defID]
+}
+
 func allEventTypes() []plugin.EventType {
 	return []plugin.EventType{
 		plugin.EventBookImported,
@@ -154,6 +218,20 @@ func allEventTypes() []plugin.EventType {
 		plugin.EventCoverChanged,
 		plugin.EventReadStatusChanged,
 		plugin.EventScanCompleted,
+		plugin.EventImportPathUnhealthy,
+		plugin.EventImportPathRecovered,
+		plugin.EventUpdateAvailable,
+	}
+}
+
+func operationEventTypes() []plugin.EventType {
+	return []plugin.EventType{
+		plugin.EventOperationCreated,
+		plugin.EventOperationStarted,
+		plugin.EventOperationProgress,
+		plugin.EventOperationCompleted,
+		plugin.EventOperationFailed,
+		plugin.EventOperationCanceled,
 	}
 }
 
@@ -1,7 +1,7 @@
 // file: internal/plugins/webhook/plugin_test.go
-// version: 1.0.1
+// version: 1.2.0
 // guid: c4d5e6f7-a8b9-0c1d-2e3f-4a5b6c7d8e9f
-// last-edited: 2026-04-30
+// last-edited: 2026-10-16
 
 package webhook
 
@@ -96,6 +96,25 @@ func TestInit_SubscribesToSpecificEvents(t *testing.T) {
 	assert.Equal(t, 0, bus.SubscriberCount(plugin.EventScanCompleted))
 }
 
+func TestInit_OperationEventsAreOptIn(t *testing.T) {
+	bus := plugin.NewEventBus()
+	require.NoError(t, makePlugin().Init(context.Background(), plugin.Deps{
+		Config: map[string]string{"urls": "http://example.com"},
+		Events: bus,
+	}))
+	assert.Equal(t, 0, bus.SubscriberCount(plugin.EventOperationCompleted))
+
+	bus = plugin.NewEventBus()
+	require.NoError(t, makePlugin().Init(context.Background(), plugin.Deps{
+		Config: map[string]string{"urls": "http://example.com", "events": "all,operation.*"},
+		Events: bus,
+	}))
+	assert.Equal(t, 1, bus.SubscriberCount(plugin.EventBookImported))
+	for _, et := range operationEventTypes() {
+		assert.Equal(t, 1, bus.SubscriberCount(et), "expected subscription for %s", et)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // HealthCheck
 // ---------------------------------------------------------------------------
@@ -236,3 +255,71 @@ func TestDeliver_MultipleURLs(t *testing.T) {
 
 	assert.Len(t, hits, 2)
 }
+
+func TestDeliver_FiltersOperationsByDefinition(t *testing.T) {
+	received := make(chan plugin.Event, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got plugin.Event
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		received <- got
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	bus := plugin.NewEventBus()
+	require.NoError(t, makePlugin().Init(context.Background(), plugin.Deps{
+		Config: map[string]string{
+			"urls":       srv.URL,
+			"events":     "operation.completed",
+			"operations": "library.scan",
+		},
+		Events: bus,
+	}))
+
+	bus.Publish(context.Background(), plugin.NewEvent(plugin.EventOperationCompleted, "", map[string]any{"def_id": "dedup.full-scan"}))
+	bus.Publish(context.Background(), plugin.NewEvent(plugin.EventOperationCompleted, "", map[string]any{"def_id": "library.scan"}))
+
+	select {
+	case got := <-received:
+		assert.Equal(t, "library.scan", got.Data["def_id"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for HTTP delivery")
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, received, 0)
+}
+
+func TestDeliver_IncludesUILinkWithBaseURL(t *testing.T) {
+	received := make(chan map[string]any, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		received <- got
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	bus := plugin.NewEventBus()
+	require.NoError(t, makePlugin().Init(context.Background(), plugin.Deps{
+		Config:  map[string]string{"urls": srv.URL, "events": "book.imported,operation.completed"},
+		Events:  bus,
+		BaseURL: "https://example.com/audiobooks/",
+	}))
+
+	bus.Publish(context.Background(), plugin.NewEvent(plugin.EventBookImported, "book 1", nil))
+	select {
+	case got := <-received:
+		assert.Equal(t, "https://example.com/audiobooks/library/book%201", got["url"])
+		assert.Equal(t, "book 1", got["book_id"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for HTTP delivery")
+	}
+
+	bus.Publish(context.Background(), plugin.NewEvent(plugin.EventOperationCompleted, "", nil))
+	select {
+	case got := <-received:
+		assert.Equal(t, "https://example.com/audiobooks/activity", got["url"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for HTTP delivery")
+	}
+}
@@ -1,7 +1,7 @@
 // file: internal/quarantine/service.go
-// version: 1.0.1
+// version: 1.1.0
 // guid: e5f6a7b8-c9d0-1e2f-3a4b-5c6d7e8f9a0b
-// last-edited: 2025-07-21
+// last-edited: 2026-10-17
 
 package quarantine
 
@@ -30,6 +30,8 @@ type Store interface {
 	GetAllBooks(limit, offset int) ([]database.Book, error)
 	GetScanFailCount(pathHash string) (int, error)
 	GetITunesPurgePendingBooks() ([]database.Book, error)
+	GetRaw(key string) ([]byte, error)
+	SetRaw(key string, value []byte) error
 }
 
 // WriteBackEnqueuer is the narrow interface for queuing iTunes track removals.
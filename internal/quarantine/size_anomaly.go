@@ -0,0 +1,149 @@
+// file: internal/quarantine/size_anomaly.go
+// version: 1.0.0
+// guid: 10587a00-0acd-42e4-adec-7a3f952b0a59
+// last-edited: 2026-10-17
+
+package quarantine
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Size anomaly safeguard: an imported book whose file size and claimed
+// duration disagree (a 2 KB mp3 tagged as a ten-hour novel, a 5 GB file
+// tagged as a twenty-minute story) is quarantined after the scan instead
+// of staying in the library. An override marks the book as reviewed,
+// restores it and keeps later scans from quarantining it again.
+
+// SizeAnomalyReasonPrefix starts the quarantine reason of books
+// quarantined by AutoQuarantineSizeAnomalies.
+const SizeAnomalyReasonPrefix = "size anomaly: "
+
+// sizeOverridePrefix keys the RawKV markers of books whose size anomaly
+// was overridden.
+const sizeOverridePrefix = "quarantine_size_override:"
+
+// ErrNotSizeAnomaly is returned by OverrideSizeAnomaly for a book
+// quarantined for another reason.
+var ErrNotSizeAnomaly = errors.New("book was not quarantined for a size anomaly")
+
+// SizeAnomaly returns why book's file size is implausible for its
+// duration, or "" when it is plausible or can't be judged: the size or
+// duration is unknown, or the duration was itself estimated from the
+// size. minKbps and maxKbps bound the average bitrate; a bound <= 0 is
+// not checked.
+func SizeAnomaly(book *database.Book, minKbps, maxKbps int) string {
+	if book.FileSize == nil || *book.FileSize <= 0 || book.Duration == nil || *book.Duration <= 0 {
+		return ""
+	}
+	if book.DurationEstimated != nil && *book.DurationEstimated {
+		return ""
+	}
+	kbps := float64(*book.FileSize) * 8 / 1000 / float64(*book.Duration)
+	claim := fmt.Sprintf("%s for %s", formatBytes(*book.FileSize), formatDuration(*book.Duration))
+	switch {
+	case minKbps > 0 && kbps < float64(minKbps):
+		return fmt.Sprintf("%s%s is %.1f kbps, below %d kbps", SizeAnomalyReasonPrefix, claim, kbps, minKbps)
+	case maxKbps > 0 && kbps > float64(maxKbps):
+		return fmt.Sprintf("%s%s is %.0f kbps, above %d kbps", SizeAnomalyReasonPrefix, claim, kbps, maxKbps)
+	}
+	return ""
+}
+
+// AutoQuarantineSizeAnomalies quarantines imported books that fail
+// SizeAnomaly and have not been overridden. Organized books are left
+// alone: they already passed review.
+func (qs *QuarantineService) AutoQuarantineSizeAnomalies() {
+	if qs.store == nil || qs.cfg == nil {
+		return
+	}
+	minKbps, maxKbps := qs.cfg.SizeAnomalyMinKbps, qs.cfg.SizeAnomalyMaxKbps
+	if minKbps <= 0 && maxKbps <= 0 {
+		return
+	}
+	const pageSize = 1000
+	var offset int
+	for {
+		page, err := qs.store.GetAllBooks(pageSize, offset)
+		if err != nil || len(page) == 0 {
+			break
+		}
+		for _, b := range page {
+			if b.QuarantinedAt != nil || b.LibraryState == nil || *b.LibraryState != "imported" {
+				continue
+			}
+			reason := SizeAnomaly(&b, minKbps, maxKbps)
+			if reason == "" || qs.sizeOverridden(b.ID) {
+				continue
+			}
+			path := b.FilePath
+			if err := qs.QuarantineBook(b.ID, reason); err != nil {
+				slog.Warn("size anomaly quarantine failed", "book", b.ID, "path", path, "err", err)
+				continue
+			}
+			slog.Info("size anomaly quarantined", "book", b.ID, "path", path, "reason", reason)
+			if ds, ok := qs.store.(database.ImportDecisionStore); ok {
+				_ = ds.RecordImportDecision(database.ImportDecision{
+					Path:     path,
+					Decision: database.ImportDecisionQuarantined,
+					Detail:   reason,
+					BookID:   b.ID,
+				})
+			}
+		}
+		if len(page) < pageSize {
+			break
+		}
+		offset += pageSize
+	}
+}
+
+// OverrideSizeAnomaly accepts book's size as genuine: it records the
+// override so later scans skip the book and, if the book is quarantined
+// for a size anomaly, restores it. A book quarantined for any other
+// reason is left alone and ErrNotSizeAnomaly returned.
+func (qs *QuarantineService) OverrideSizeAnomaly(bookID string) error {
+	if qs.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	book, err := qs.store.GetBookByID(bookID)
+	if err != nil || book == nil {
+		return fmt.Errorf("book not found: %s", bookID)
+	}
+	if book.QuarantinedAt != nil && (book.QuarantineReason == nil || !strings.HasPrefix(*book.QuarantineReason, SizeAnomalyReasonPrefix)) {
+		return ErrNotSizeAnomaly
+	}
+	if err := qs.store.SetRaw(sizeOverridePrefix+bookID, []byte("1")); err != nil {
+		return fmt.Errorf("record override: %w", err)
+	}
+	return qs.UnquarantineBook(bookID)
+}
+
+func (qs *QuarantineService) sizeOverridden(bookID string) bool {
+	v, err := qs.store.GetRaw(sizeOverridePrefix + bookID)
+	return err == nil && v != nil
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
+
+func formatDuration(sec int) string {
+	if sec >= 3600 {
+		return fmt.Sprintf("%dh%02dm", sec/3600, sec%3600/60)
+	}
+	return fmt.Sprintf("%dm%02ds", sec/60, sec%60)
+}
@@ -0,0 +1,304 @@
+// file: internal/quota/quota.go
+// version: 1.1.0
+// guid: 69990f1a-3218-42f0-8fab-be03d8c349e6
+// last-edited: 2026-10-17
+
+// Package quota enforces import quotas: caps on how many books, or bytes,
+// one import path or one user's uploads may add to the library.
+//
+// An Enforcer is created per import run (a scan, a single upload). It
+// computes current usage with one pass over the library on first use and
+// then counts each admitted book itself, so a run that crosses a cap is
+// stopped at the cap rather than at the next run.
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Store is the persistence usage is computed from.
+type Store interface {
+	GetAllBooks(limit, offset int) ([]database.Book, error)
+	ListSoftDeletedBooks(limit, offset int, olderThan *time.Time) ([]database.Book, error)
+}
+
+// Usage is what a quota's path or user currently holds.
+type Usage struct {
+	Books int   `json:"books"`
+	Bytes int64 `json:"bytes"`
+}
+
+// Status is one quota with its usage, as reported by the usage endpoint.
+type Status struct {
+	Path     string `json:"path,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+	MaxBooks int    `json:"max_books"`
+	MaxBytes int64  `json:"max_bytes"`
+	// Default marks a user quota derived from default_user_quota_gb
+	// rather than an import_quotas entry.
+	Default  bool  `json:"default,omitempty"`
+	Usage    Usage `json:"usage"`
+	Exceeded bool  `json:"exceeded"`
+}
+
+// ErrExceeded is matched by every *ExceededError via errors.Is.
+var ErrExceeded = errors.New("import quota exceeded")
+
+// ExceededError reports an import refused by a quota.
+type ExceededError struct {
+	Quota config.ImportQuota
+	Usage Usage
+	// AddBytes is the size of the refused book.
+	AddBytes int64
+}
+
+func (e *ExceededError) Error() string {
+	subject := "import path " + e.Quota.Path
+	if e.Quota.UserID != "" {
+		subject = "user " + e.Quota.UserID
+	}
+	if e.Quota.MaxBooks > 0 && e.Usage.Books+1 > e.Quota.MaxBooks {
+		return fmt.Sprintf("import quota exceeded for %s: %d of %d books used", subject, e.Usage.Books, e.Quota.MaxBooks)
+	}
+	return fmt.Sprintf("import quota exceeded for %s: %d of %d bytes used, book needs %d", subject, e.Usage.Bytes, e.Quota.MaxBytes, e.AddBytes)
+}
+
+// Is lets errors.Is(err, ErrExceeded) match.
+func (e *ExceededError) Is(target error) bool { return target == ErrExceeded }
+
+// Enforcer admits or refuses imports against the configured quotas. A nil
+// *Enforcer admits everything. Safe for concurrent use.
+type Enforcer struct {
+	quotas      []config.ImportQuota
+	userDefault int64
+	store       Store
+
+	mu     sync.Mutex
+	loaded bool
+	paths  map[string]*Usage // keyed by quota path
+	users  map[string]*Usage // keyed by user ID, every importing user
+}
+
+// NewEnforcer returns an enforcer for cfg's quotas, or nil when no quota
+// is configured.
+func NewEnforcer(cfg *config.Config, store Store) *Enforcer {
+	if cfg == nil || store == nil {
+		return nil
+	}
+	var userDefault int64
+	if cfg.EnableUserQuotas && cfg.DefaultUserQuotaGB > 0 {
+		userDefault = int64(cfg.DefaultUserQuotaGB) << 30
+	}
+	if len(cfg.ImportQuotas) == 0 && userDefault == 0 {
+		return nil
+	}
+	return &Enforcer{
+		quotas:      append([]config.ImportQuota(nil), cfg.ImportQuotas...),
+		userDefault: userDefault,
+		store:       store,
+	}
+}
+
+// Admit checks book against the quotas for its import path and, when
+// userID is set, the importing user. On success the book is counted
+// towards those quotas; on refusal an *ExceededError is returned and
+// nothing is counted.
+func (e *Enforcer) Admit(book *database.Book, userID string) error {
+	if e == nil || book == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.loadLocked(); err != nil {
+		return fmt.Errorf("compute import quota usage: %w", err)
+	}
+	size := bookSize(book)
+	type hit struct {
+		q config.ImportQuota
+		u *Usage
+	}
+	var hits []hit
+	for _, q := range e.quotas {
+		if q.Path != "" && underPath(bookImportPath(book), q.Path) {
+			hits = append(hits, hit{q, e.pathUsage(q.Path)})
+		}
+	}
+	if userID != "" {
+		if q, ok := e.userQuota(userID); ok {
+			hits = append(hits, hit{q, e.userUsage(userID)})
+		} else {
+			hits = append(hits, hit{u: e.userUsage(userID)})
+		}
+	}
+	for _, h := range hits {
+		if exceeds(h.q, *h.u, size) {
+			return &ExceededError{Quota: h.q, Usage: *h.u, AddBytes: size}
+		}
+	}
+	for _, h := range hits {
+		h.u.Books++
+		h.u.Bytes += size
+	}
+	return nil
+}
+
+// Statuses reports every configured quota, plus the default quota of each
+// user who has imported books, with current usage.
+func (e *Enforcer) Statuses() ([]Status, error) {
+	if e == nil {
+		return []Status{}, nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.loadLocked(); err != nil {
+		return nil, err
+	}
+	out := []Status{}
+	explicitUsers := map[string]bool{}
+	for _, q := range e.quotas {
+		u := e.pathUsage(q.Path)
+		if q.UserID != "" {
+			explicitUsers[q.UserID] = true
+			u = e.userUsage(q.UserID)
+		}
+		out = append(out, status(q, *u, false))
+	}
+	if e.userDefault > 0 {
+		ids := make([]string, 0, len(e.users))
+		for id := range e.users {
+			if !explicitUsers[id] {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			out = append(out, status(config.ImportQuota{UserID: id, MaxBytes: e.userDefault}, *e.users[id], true))
+		}
+	}
+	return out, nil
+}
+
+func status(q config.ImportQuota, u Usage, isDefault bool) Status {
+	return Status{
+		Path: q.Path, UserID: q.UserID, MaxBooks: q.MaxBooks, MaxBytes: q.MaxBytes,
+		Default: isDefault, Usage: u,
+		Exceeded: (q.MaxBooks > 0 && u.Books >= q.MaxBooks) || (q.MaxBytes > 0 && u.Bytes >= q.MaxBytes),
+	}
+}
+
+// loadLocked computes usage with a single pass over the library. Books
+// marked for deletion still count: their files stay on disk until purged.
+func (e *Enforcer) loadLocked() error {
+	if e.loaded {
+		return nil
+	}
+	all, err := e.store.GetAllBooks(0, 0)
+	if err != nil {
+		return err
+	}
+	deleted, err := e.store.ListSoftDeletedBooks(0, 0, nil)
+	if err != nil {
+		return err
+	}
+	// Whether GetAllBooks includes soft-deleted books depends on the
+	// backend, so they are taken from ListSoftDeletedBooks only.
+	books := make([]database.Book, 0, len(all)+len(deleted))
+	for _, b := range all {
+		if b.MarkedForDeletion == nil || !*b.MarkedForDeletion {
+			books = append(books, b)
+		}
+	}
+	books = append(books, deleted...)
+	e.paths = make(map[string]*Usage)
+	e.users = make(map[string]*Usage)
+	for i := range books {
+		b := &books[i]
+		size := bookSize(b)
+		for _, q := range e.quotas {
+			if q.Path != "" && underPath(bookImportPath(b), q.Path) {
+				u := e.pathUsage(q.Path)
+				u.Books++
+				u.Bytes += size
+			}
+		}
+		if b.ImportedByUserID != nil && *b.ImportedByUserID != "" {
+			u := e.userUsage(*b.ImportedByUserID)
+			u.Books++
+			u.Bytes += size
+		}
+	}
+	e.loaded = true
+	return nil
+}
+
+func (e *Enforcer) pathUsage(path string) *Usage {
+	u, ok := e.paths[path]
+	if !ok {
+		u = &Usage{}
+		e.paths[path] = u
+	}
+	return u
+}
+
+func (e *Enforcer) userUsage(id string) *Usage {
+	u, ok := e.users[id]
+	if !ok {
+		u = &Usage{}
+		e.users[id] = u
+	}
+	return u
+}
+
+// userQuota returns the explicit quota for id, else the default one.
+func (e *Enforcer) userQuota(id string) (config.ImportQuota, bool) {
+	for _, q := range e.quotas {
+		if q.UserID == id {
+			return q, true
+		}
+	}
+	if e.userDefault > 0 {
+		return config.ImportQuota{UserID: id, MaxBytes: e.userDefault}, true
+	}
+	return config.ImportQuota{}, false
+}
+
+func exceeds(q config.ImportQuota, u Usage, size int64) bool {
+	if q.MaxBooks > 0 && u.Books+1 > q.MaxBooks {
+		return true
+	}
+	return q.MaxBytes > 0 && u.Bytes+size > q.MaxBytes
+}
+
+// bookImportPath is where the book came in: its source import path when
+// recorded (it survives organize moves), else its current path.
+func bookImportPath(b *database.Book) string {
+	if b.SourceImportPath != nil && *b.SourceImportPath != "" {
+		return *b.SourceImportPath
+	}
+	return b.FilePath
+}
+
+func bookSize(b *database.Book) int64 {
+	if b.FileSize == nil {
+		return 0
+	}
+	return *b.FileSize
+}
+
+// underPath reports whether p is root or inside it, on whole components.
+func underPath(p, root string) bool {
+	if p == "" || root == "" {
+		return false
+	}
+	p, root = filepath.Clean(p), filepath.Clean(root)
+	return p == root || strings.HasPrefix(p, root+string(filepath.Separator))
+}
@@ -0,0 +1,137 @@
+// file: internal/quota/quota_test.go
+// version: 1.1.0
+// guid: 5dd27bbb-ecfc-41b6-b056-21ec0afecabc
+// last-edited: 2026-10-17
+
+package quota
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore leaves soft-deleted books out of GetAllBooks, as the Pebble
+// scan does, unless withDeleted is set, as for the memdb path.
+type fakeStore struct {
+	books       []database.Book
+	calls       int
+	withDeleted bool
+}
+
+func (f *fakeStore) GetAllBooks(limit, offset int) ([]database.Book, error) {
+	f.calls++
+	var out []database.Book
+	for _, b := range f.books {
+		if f.withDeleted || b.MarkedForDeletion == nil || !*b.MarkedForDeletion {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) ListSoftDeletedBooks(limit, offset int, olderThan *time.Time) ([]database.Book, error) {
+	var out []database.Book
+	for _, b := range f.books {
+		if b.MarkedForDeletion != nil && *b.MarkedForDeletion {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func sized(id, path string, size int64) database.Book {
+	return database.Book{ID: id, FilePath: path, FileSize: &size}
+}
+
+func TestNewEnforcer_NoQuotas(t *testing.T) {
+	assert.Nil(t, NewEnforcer(&config.Config{}, &fakeStore{}))
+	var e *Enforcer
+	assert.NoError(t, e.Admit(&database.Book{}, "u1"))
+}
+
+func TestAdmit_PathQuota(t *testing.T) {
+	src := "/watch/downloads"
+	organized := sized("moved", "/library/A/book.m4b", 100)
+	organized.SourceImportPath = &src
+	store := &fakeStore{books: []database.Book{
+		organized,
+		sized("new", "/watch/downloads/x.m4b", 100),
+		sized("other", "/watch/downloads2/y.m4b", 100),
+	}}
+	e := NewEnforcer(&config.Config{ImportQuotas: []config.ImportQuota{
+		{Path: "/watch/downloads", MaxBooks: 3},
+	}}, store)
+	require.NotNil(t, e)
+
+	b := sized("third", "/watch/downloads/z.m4b", 10)
+	require.NoError(t, e.Admit(&b, ""))
+
+	b = sized("fourth", "/watch/downloads/w.m4b", 10)
+	err := e.Admit(&b, "")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrExceeded))
+	assert.Contains(t, err.Error(), "import path /watch/downloads: 3 of 3 books used")
+
+	outside := sized("ok", "/watch/downloads2/v.m4b", 10)
+	assert.NoError(t, e.Admit(&outside, ""), "prefix must match whole components")
+	assert.Equal(t, 1, store.calls, "usage is computed once per enforcer")
+}
+
+func TestAdmit_UserQuotas(t *testing.T) {
+	alice := "alice"
+	owned := sized("a1", "/lib/a1.m4b", 1<<30)
+	owned.ImportedByUserID = &alice
+	store := &fakeStore{books: []database.Book{owned}}
+	e := NewEnforcer(&config.Config{
+		EnableUserQuotas:   true,
+		DefaultUserQuotaGB: 1,
+		ImportQuotas:       []config.ImportQuota{{UserID: "bob", MaxBytes: 500}},
+	}, store)
+
+	b := sized("a2", "/lib/a2.m4b", 1)
+	err := e.Admit(&b, "alice")
+	require.Error(t, err, "alice is at the 1 GiB default")
+	assert.Contains(t, err.Error(), "user alice")
+
+	b = sized("b1", "/lib/b1.m4b", 400)
+	require.NoError(t, e.Admit(&b, "bob"))
+	b = sized("b2", "/lib/b2.m4b", 200)
+	assert.Error(t, e.Admit(&b, "bob"), "bob's explicit quota overrides the default")
+
+	statuses, err := e.Statuses()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "bob", statuses[0].UserID)
+	assert.Equal(t, Usage{Books: 1, Bytes: 400}, statuses[0].Usage)
+	assert.False(t, statuses[0].Exceeded)
+	assert.Equal(t, "alice", statuses[1].UserID)
+	assert.True(t, statuses[1].Default)
+	assert.True(t, statuses[1].Exceeded)
+}
+
+// Soft-deleted books keep their files until purged, so they still count,
+// once, whichever way the store lists them.
+func TestAdmit_CountsSoftDeletedBooks(t *testing.T) {
+	marked := true
+	deleted := sized("trashed", "/watch/a.m4b", 100)
+	deleted.MarkedForDeletion = &marked
+	for _, withDeleted := range []bool{false, true} {
+		store := &fakeStore{books: []database.Book{deleted, sized("kept", "/watch/b.m4b", 100)}, withDeleted: withDeleted}
+		e := NewEnforcer(&config.Config{ImportQuotas: []config.ImportQuota{
+			{Path: "/watch", MaxBooks: 2},
+		}}, store)
+
+		statuses, err := e.Statuses()
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, Usage{Books: 2, Bytes: 200}, statuses[0].Usage, "withDeleted=%v", withDeleted)
+		b := sized("new", "/watch/c.m4b", 10)
+		assert.ErrorIs(t, e.Admit(&b, ""), ErrExceeded)
+	}
+}
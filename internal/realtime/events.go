@@ -1,5 +1,5 @@
 // file: internal/realtime/events.go
-// version: 1.2.1
+// version: 1.5.0
 // guid: 9e8d7f6a-5c4b-3a21-0f9e-8d7c6b5a4392
 
 package realtime
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +23,8 @@ const (
 	EventOperationStatus   EventType = "operation.status"
 	EventOperationLog      EventType = "operation.log"
 	EventSystemStatus      EventType = "system.status"
+	EventConfigUpdated     EventType = "config.updated"
+	EventPlaybackProgress  EventType = "playback.progress"
 )
 
 // Event represents a real-time event to send to clients
@@ -37,6 +40,7 @@ type Client struct {
 	ID         string
 	Channel    chan *Event
 	Operations map[string]bool // Operations this client is interested in
+	Types      []string        // Event type filters; empty means all types
 	closed     bool            // true after Channel is closed
 	mu         sync.RWMutex
 }
@@ -73,7 +77,49 @@ func (c *Client) IsSubscribed(operationID string) bool {
 	return c.Operations[operationID]
 }
 
-// EventHub manages SSE connections and event distribution
+// wantsOperation reports whether the client takes events for operationID:
+// it is subscribed to it or has no operation subscriptions at all.
+func (c *Client) wantsOperation(operationID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.Operations) == 0 || c.Operations[operationID]
+}
+
+// SetTypeFilters replaces the client's event type filters. A filter is an
+// exact type ("system.status") or a prefix ending in ".*" ("operation.*");
+// with no filters the client receives every type.
+func (c *Client) SetTypeFilters(filters []string) {
+	var kept []string
+	for _, f := range filters {
+		if f = strings.TrimSpace(f); f != "" {
+			kept = append(kept, f)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Types = kept
+}
+
+// WantsType reports whether the client's type filters admit t.
+func (c *Client) WantsType(t EventType) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.Types) == 0 {
+		return true
+	}
+	for _, f := range c.Types {
+		if prefix, ok := strings.CutSuffix(f, "*"); ok {
+			if strings.HasPrefix(string(t), prefix) {
+				return true
+			}
+		} else if string(t) == f {
+			return true
+		}
+	}
+	return false
+}
+
+// EventHub manages SSE and WebSocket connections and event distribution
 type EventHub struct {
 	mu      sync.RWMutex
 	clients map[string]*Client
@@ -128,7 +174,11 @@ func (h *EventHub) Broadcast(event *Event) {
 		// 1. Event has no ID (system-wide events), OR
 		// 2. Client has no subscriptions (wants all events), OR
 		// 3. Client is subscribed to this specific operation
-		if event.ID == "" || len(client.Operations) == 0 || client.IsSubscribed(event.ID) {
+		// and the client's type filters admit the event.
+		if !client.WantsType(event.Type) {
+			continue
+		}
+		if event.ID == "" || client.wantsOperation(event.ID) {
 			select {
 			case client.Channel <- event:
 				count++
@@ -204,6 +254,41 @@ func (h *EventHub) SendSystemStatus(data map[string]interface{}) {
 	h.Broadcast(event)
 }
 
+// SendConfigUpdated tells every client that the server config changed.
+// It carries only the changed key names and the new config revision:
+// every client receives it, so values stay behind GET /config and its
+// settings permission.
+func (h *EventHub) SendConfigUpdated(version string, keys []string) {
+	event := &Event{
+		Type:      EventConfigUpdated,
+		ID:        "",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"version": version,
+			"keys":    keys,
+		},
+	}
+	h.Broadcast(event)
+}
+
+// SendPlaybackProgress tells clients a user's position in a book moved.
+// Every client receives it; data carries user_id so players can pick out
+// their own user's other sessions.
+func (h *EventHub) SendPlaybackProgress(userID string, data map[string]interface{}) {
+	payload := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		payload[k] = v
+	}
+	payload["user_id"] = userID
+	event := &Event{
+		Type:      EventPlaybackProgress,
+		ID:        "",
+		Timestamp: time.Now(),
+		Data:      payload,
+	}
+	h.Broadcast(event)
+}
+
 // GetClientCount returns the number of connected clients
 func (h *EventHub) GetClientCount() int {
 	h.mu.RLock()
@@ -228,6 +313,9 @@ func (h *EventHub) HandleSSE(c *gin.Context) {
 	if operationID := c.Query("operation"); operationID != "" {
 		client.Subscribe(operationID)
 	}
+	if types := c.Query("types"); types != "" {
+		client.SetTypeFilters(strings.Split(types, ","))
+	}
 
 	// Register client
 	h.RegisterClient(client)
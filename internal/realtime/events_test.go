@@ -1,5 +1,5 @@
 // file: internal/realtime/events_test.go
-// version: 1.2.0
+// version: 1.5.0
 // guid: 6f7a8b9c-0d1e-2f3a-4b5c-6d7e8f9a0b1c
 
 package realtime
@@ -318,6 +318,54 @@ func TestEventHub_SendSystemStatus(t *testing.T) {
 	}
 }
 
+func TestEventHub_SendConfigUpdated(t *testing.T) {
+	hub := NewEventHub()
+	client := NewClient("client-1")
+	client.Subscribe("op-1") // config events reach operation-scoped clients too
+	hub.RegisterClient(client)
+
+	hub.SendConfigUpdated("abc123", []string{"root_dir"})
+
+	select {
+	case event := <-client.Channel:
+		if event.Type != EventConfigUpdated {
+			t.Errorf("event type = %q, want %q", event.Type, EventConfigUpdated)
+		}
+		if event.Data["version"] != "abc123" {
+			t.Errorf("version = %v, want abc123", event.Data["version"])
+		}
+		if _, ok := event.Data["changes"]; ok {
+			t.Error("config updated event carries config values")
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Did not receive config updated event")
+	}
+}
+
+func TestEventHub_SendPlaybackProgress(t *testing.T) {
+	hub := NewEventHub()
+	client := NewClient("client-1")
+	hub.RegisterClient(client)
+
+	data := map[string]interface{}{"audiobook_id": "book-1", "position_seconds": 42}
+	hub.SendPlaybackProgress("user-1", data)
+
+	select {
+	case event := <-client.Channel:
+		if event.Type != EventPlaybackProgress {
+			t.Errorf("event type = %q, want %q", event.Type, EventPlaybackProgress)
+		}
+		if event.Data["user_id"] != "user-1" || event.Data["position_seconds"] != 42 {
+			t.Errorf("data = %v", event.Data)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Did not receive playback progress event")
+	}
+	if _, ok := data["user_id"]; ok {
+		t.Error("caller's data map was modified")
+	}
+}
+
 func TestCalculatePercentage(t *testing.T) {
 	tests := []struct {
 		current  int
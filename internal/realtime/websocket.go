@@ -0,0 +1,157 @@
+// file: internal/realtime/websocket.go
+// version: 1.0.0
+// guid: 3b7e9d14-6a2c-4f85-b0d1-9c4e2a7f5813
+// last-edited: 2026-10-17
+
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// wsCommand is a message a WebSocket client sends to change what it
+// receives:
+//
+//	{"action":"subscribe","types":["operation.*"]}   replace type filters ([] = all)
+//	{"action":"subscribe","operation":"<op id>"}     add an operation subscription
+//	{"action":"unsubscribe","operation":"<op id>"}   drop an operation subscription
+type wsCommand struct {
+	Action    string   `json:"action"`
+	Types     []string `json:"types,omitempty"`
+	Operation string   `json:"operation,omitempty"`
+}
+
+// HandleWebSocket serves the hub's events over a WebSocket, for clients and
+// proxies that handle SSE poorly. Events are the same JSON objects HandleSSE
+// sends, one per text message. The operation and types query parameters
+// set the initial subscriptions, as for HandleSSE; the client can change
+// them later by sending wsCommand messages.
+func (h *EventHub) HandleWebSocket(c *gin.Context) {
+	clientID := fmt.Sprintf("ws-%d", time.Now().UnixNano())
+	client := NewClient(clientID)
+	if operationID := c.Query("operation"); operationID != "" {
+		client.Subscribe(operationID)
+	}
+	if types := c.Query("types"); types != "" {
+		client.SetTypeFilters(strings.Split(types, ","))
+	}
+
+	// The router's CORS middleware has already vetted Origin (same host,
+	// base_url, the dev server); follow its decision.
+	corsOrigin := c.Writer.Header().Get("Access-Control-Allow-Origin")
+	server := websocket.Server{
+		Handshake: func(cfg *websocket.Config, req *http.Request) error {
+			return checkOrigin(cfg, req, corsOrigin)
+		},
+		Handler: func(ws *websocket.Conn) { h.serveWebSocket(ws, client) },
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkOrigin rejects cross-site handshakes: browsers send the session
+// cookie with them, so any page could otherwise read the event stream.
+// An Origin is accepted when it is the request's own host or the origin
+// the CORS middleware allowed; clients that send no Origin are allowed.
+func checkOrigin(cfg *websocket.Config, req *http.Request, corsOrigin string) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || (!strings.EqualFold(u.Host, req.Host) && origin != corsOrigin) {
+		return fmt.Errorf("cross-origin websocket request from %q", origin)
+	}
+	cfg.Origin = u
+	return nil
+}
+
+func (h *EventHub) serveWebSocket(ws *websocket.Conn, client *Client) {
+	defer ws.Close()
+	h.RegisterClient(client)
+	defer h.UnregisterClient(client.ID)
+
+	// Only this goroutine writes; the reader below just updates the
+	// client's subscriptions.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var raw []byte
+			if err := websocket.Message.Receive(ws, &raw); err != nil {
+				return
+			}
+			var cmd wsCommand
+			if err := json.Unmarshal(raw, &cmd); err != nil {
+				slog.Debug("Ignoring malformed websocket message", "clientID", client.ID, "err", err)
+				continue
+			}
+			applyCommand(client, cmd)
+		}
+	}()
+
+	initialEvent := &Event{
+		Type:      "connection.established",
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"client_id": client.ID},
+	}
+	if err := websocket.JSON.Send(ws, initialEvent); err != nil {
+		return
+	}
+
+	// Ping frames keep idle connections open through proxies, like the SSE
+	// heartbeat comment.
+	heartbeat := time.NewTicker(25 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			slog.Info("WebSocket client connection closed", "clientID", client.ID)
+			return
+		case event, ok := <-client.Channel:
+			if !ok {
+				return
+			}
+			if err := websocket.JSON.Send(ws, event); err != nil {
+				slog.Info("Error writing to websocket client", "clientID", client.ID, "err", err)
+				return
+			}
+		case <-heartbeat.C:
+			ws.PayloadType = websocket.PingFrame
+			_, err := ws.Write(nil)
+			ws.PayloadType = websocket.TextFrame
+			if err != nil {
+				slog.Info("Error writing heartbeat to websocket client", "clientID", client.ID, "err", err)
+				return
+			}
+		}
+	}
+}
+
+// applyCommand updates client's subscriptions from cmd.
+func applyCommand(client *Client, cmd wsCommand) {
+	switch cmd.Action {
+	case "subscribe":
+		if cmd.Types != nil {
+			client.SetTypeFilters(cmd.Types)
+		}
+		if cmd.Operation != "" {
+			client.Subscribe(cmd.Operation)
+		}
+	case "unsubscribe":
+		if cmd.Operation != "" {
+			client.Unsubscribe(cmd.Operation)
+		}
+	default:
+		slog.Debug("Ignoring unknown websocket action", "clientID", client.ID, "action", cmd.Action)
+	}
+}
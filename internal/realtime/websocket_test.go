@@ -0,0 +1,134 @@
+// file: internal/realtime/websocket_test.go
+// version: 1.0.0
+// guid: 7c2f8e51-4d93-4a06-b8e7-1f5a3c9d6b20
+
+package realtime
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+func TestClient_WantsType(t *testing.T) {
+	client := NewClient("c")
+	if !client.WantsType(EventSystemStatus) {
+		t.Error("client without filters should want every type")
+	}
+
+	client.SetTypeFilters([]string{"operation.*", " config.updated ", ""})
+	for typ, want := range map[EventType]bool{
+		EventOperationProgress: true,
+		EventOperationLog:      true,
+		EventConfigUpdated:     true,
+		EventSystemStatus:      false,
+		EventPlaybackProgress:  false,
+	} {
+		if got := client.WantsType(typ); got != want {
+			t.Errorf("WantsType(%s) = %v, want %v", typ, got, want)
+		}
+	}
+}
+
+func dialHub(t *testing.T, hub *EventHub, query string) *websocket.Conn {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", hub.HandleWebSocket)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws" + query
+	ws, err := websocket.Dial(wsURL, "", srv.URL)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+func receiveEvent(t *testing.T, ws *websocket.Conn) Event {
+	t.Helper()
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var ev Event
+	if err := websocket.JSON.Receive(ws, &ev); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	return ev
+}
+
+func TestHandleWebSocket_TypeFilters(t *testing.T) {
+	hub := NewEventHub()
+	ws := dialHub(t, hub, "?types=operation.*")
+	if ev := receiveEvent(t, ws); ev.Type != "connection.established" {
+		t.Fatalf("first event = %s, want connection.established", ev.Type)
+	}
+
+	hub.SendSystemStatus(map[string]interface{}{"ok": true})
+	hub.SendOperationProgress("op-1", 1, 2, "half")
+	if ev := receiveEvent(t, ws); ev.Type != EventOperationProgress || ev.ID != "op-1" {
+		t.Fatalf("got %s/%s, want the operation.progress event only", ev.Type, ev.ID)
+	}
+
+	// Switch to system events over the socket.
+	if err := websocket.JSON.Send(ws, wsCommand{Action: "subscribe", Types: []string{"system.status"}}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		hub.mu.RLock()
+		var c *Client
+		for _, cl := range hub.clients {
+			c = cl
+		}
+		hub.mu.RUnlock()
+		if c != nil && c.WantsType(EventSystemStatus) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("subscribe command not applied")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	hub.SendOperationProgress("op-1", 2, 2, "done")
+	hub.SendSystemStatus(map[string]interface{}{"ok": true})
+	if ev := receiveEvent(t, ws); ev.Type != EventSystemStatus {
+		t.Fatalf("got %s, want system.status", ev.Type)
+	}
+}
+
+func TestHandleWebSocket_RejectsCrossOrigin(t *testing.T) {
+	hub := NewEventHub()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", hub.HandleWebSocket)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+	if ws, err := websocket.Dial(wsURL, "", "https://evil.example"); err == nil {
+		ws.Close()
+		t.Fatal("expected the cross-origin handshake to be rejected")
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://127.0.0.1:8484/api/v1/ws", nil)
+	for origin, ok := range map[string]bool{
+		"":                            true,
+		"http://127.0.0.1:8484":       true,
+		"https://example.com":         true, // allowed by CORS (base_url)
+		"https://evil.example":        false,
+		"http://127.0.0.1:8484.evil.": false,
+	} {
+		req.Header.Set("Origin", origin)
+		err := checkOrigin(&websocket.Config{}, req, "https://example.com")
+		if (err == nil) != ok {
+			t.Errorf("origin %q: err = %v, want allowed=%v", origin, err, ok)
+		}
+	}
+}
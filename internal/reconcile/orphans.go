@@ -0,0 +1,217 @@
+// file: internal/reconcile/orphans.go
+// version: 1.1.0
+// guid: b94aed20-0b51-452d-9c32-ccaf461b43c3
+// last-edited: 2026-10-17
+
+package reconcile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// orphanIgnorePrefix keys the raw KV entries marking orphans the user
+// chose to keep out of the report.
+const orphanIgnorePrefix = "orphan_ignore:"
+
+// ErrNotOrphan is returned when an orphan action targets a path that is
+// outside the library root or is referenced by a book record.
+var ErrNotOrphan = errors.New("not an orphaned file under the library root")
+
+// OrphanStore is the database dependency for the orphan file report.
+type OrphanStore interface {
+	GetAllBooks(limit, offset int) ([]database.Book, error)
+	GetAllBookFiles() ([]database.BookFile, error)
+	database.RawKVStore
+}
+
+// OrphanFile is an audio file under the library root that no book record
+// references.
+type OrphanFile struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Ignored bool      `json:"ignored"`
+}
+
+// OrphanReport is the result of FindOrphanFiles.
+type OrphanReport struct {
+	RootDir      string       `json:"root_dir"`
+	Files        []OrphanFile `json:"files"`
+	TotalBytes   int64        `json:"total_bytes"`
+	IgnoredCount int          `json:"ignored_count"`
+}
+
+// referencedPaths collects every path a book or book file points at. A
+// book whose path is a directory (multi-file books) covers everything
+// below it, so those are returned separately as prefixes.
+func referencedPaths(store OrphanStore) (files map[string]bool, dirs []string, err error) {
+	books, err := store.GetAllBooks(0, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list books: %w", err)
+	}
+	bookFiles, err := store.GetAllBookFiles()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list book files: %w", err)
+	}
+	files = make(map[string]bool, len(books)+len(bookFiles))
+	for _, b := range books {
+		if b.FilePath == "" {
+			continue
+		}
+		p := filepath.Clean(b.FilePath)
+		files[p] = true
+		if info, statErr := os.Stat(p); statErr == nil && info.IsDir() {
+			dirs = append(dirs, p+string(filepath.Separator))
+		}
+	}
+	for _, f := range bookFiles {
+		if f.FilePath != "" {
+			files[filepath.Clean(f.FilePath)] = true
+		}
+	}
+	return files, dirs, nil
+}
+
+func isReferenced(path string, files map[string]bool, dirs []string) bool {
+	if files[path] {
+		return true
+	}
+	return slices.ContainsFunc(dirs, func(d string) bool { return strings.HasPrefix(path, d) })
+}
+
+// FindOrphanFiles walks rootDir for audio files (by extension) that no
+// book or book file references: leftovers from manual copies or
+// interrupted moves. Ignored orphans are left out unless includeIgnored
+// is set; IgnoredCount always reports how many there are.
+func FindOrphanFiles(store OrphanStore, rootDir string, exts []string, includeIgnored bool) (*OrphanReport, error) {
+	report := &OrphanReport{RootDir: rootDir, Files: []OrphanFile{}}
+	if rootDir == "" {
+		return report, nil
+	}
+	files, dirs, err := referencedPaths(store)
+	if err != nil {
+		return nil, err
+	}
+	ignored, err := ignoredOrphans(store)
+	if err != nil {
+		return nil, err
+	}
+	extSet := make(map[string]bool, len(exts))
+	for _, ext := range exts {
+		extSet[strings.ToLower(ext)] = true
+	}
+
+	err = filepath.WalkDir(rootDir, func(path string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if path == rootDir {
+				return walkErr
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".failed" || d.Name() == ".trash" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !extSet[strings.ToLower(filepath.Ext(path))] || isReferenced(path, files, dirs) {
+			return nil
+		}
+		info, infoErr := d.Info()
+		if infoErr != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		if ignored[path] {
+			report.IgnoredCount++
+			if !includeIgnored {
+				return nil
+			}
+		}
+		report.Files = append(report.Files, OrphanFile{
+			Path: path, Size: info.Size(), ModTime: info.ModTime().UTC(), Ignored: ignored[path],
+		})
+		report.TotalBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", rootDir, err)
+	}
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Path < report.Files[j].Path })
+	return report, nil
+}
+
+func ignoredOrphans(store database.RawKVStore) (map[string]bool, error) {
+	pairs, err := store.ScanPrefix(orphanIgnorePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ignored orphans: %w", err)
+	}
+	out := make(map[string]bool, len(pairs))
+	for _, kv := range pairs {
+		out[strings.TrimPrefix(kv.Key, orphanIgnorePrefix)] = true
+	}
+	return out, nil
+}
+
+// SetOrphanIgnored adds path to, or removes it from, the ignore list.
+func SetOrphanIgnored(store database.RawKVStore, path string, ignored bool) error {
+	key := orphanIgnorePrefix + filepath.Clean(path)
+	if ignored {
+		return store.SetRaw(key, []byte(time.Now().UTC().Format(time.RFC3339)))
+	}
+	return store.DeleteRaw(key)
+}
+
+// OrphanChecker re-verifies paths before destructive actions. Build one
+// per request with NewOrphanChecker so the book index is loaded once.
+type OrphanChecker struct {
+	rootDir string
+	files   map[string]bool
+	dirs    []string
+}
+
+// NewOrphanChecker snapshots the referenced paths for rootDir.
+func NewOrphanChecker(store OrphanStore, rootDir string) (*OrphanChecker, error) {
+	files, dirs, err := referencedPaths(store)
+	if err != nil {
+		return nil, err
+	}
+	return &OrphanChecker{rootDir: filepath.Clean(rootDir), files: files, dirs: dirs}, nil
+}
+
+// Check returns the cleaned path when it is an unreferenced regular file
+// under the library root, and ErrNotOrphan otherwise.
+func (oc *OrphanChecker) Check(path string) (string, error) {
+	if oc.rootDir == "" || oc.rootDir == "." || !filepath.IsAbs(path) {
+		return "", ErrNotOrphan
+	}
+	path = filepath.Clean(path)
+	if !strings.HasPrefix(path, oc.rootDir+string(filepath.Separator)) || isReferenced(path, oc.files, oc.dirs) {
+		return "", ErrNotOrphan
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", ErrNotOrphan
+	}
+	return path, nil
+}
+
+// RemoveOrphanFile deletes an orphan and drops any ignore entry for it.
+// Callers must have vetted path with OrphanChecker.Check.
+func RemoveOrphanFile(store database.RawKVStore, path string) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	return SetOrphanIgnored(store, path, false)
+}
@@ -0,0 +1,71 @@
+// file: internal/reconcile/orphans_test.go
+// version: 1.0.0
+// guid: d242a2f7-dd6f-48f4-9d24-d78d2fa2344e
+// last-edited: 2026-10-16
+
+package reconcile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphanFiles(t *testing.T) {
+	store, err := database.NewPebbleStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	root := t.TempDir()
+	write := func(rel string) string {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(rel), 0o644))
+		return path
+	}
+	tracked := write("Author/Book/book.m4b")
+	write("Author/Multi/01.mp3") // covered by the directory-path book
+	segment := write("Author/Split/part2.mp3")
+	orphan := write("Author/Stray/copy.m4b")
+	ignored := write("Author/Stray/old.mp3")
+	write("Author/Book/cover.jpg") // not audio
+
+	book, err := store.CreateBook(&database.Book{Title: "Book", FilePath: tracked})
+	require.NoError(t, err)
+	_, err = store.CreateBook(&database.Book{Title: "Multi", FilePath: filepath.Join(root, "Author/Multi")})
+	require.NoError(t, err)
+	require.NoError(t, store.CreateBookFile(&database.BookFile{BookID: book.ID, FilePath: segment}))
+	require.NoError(t, SetOrphanIgnored(store, ignored, true))
+
+	exts := []string{".m4b", ".mp3"}
+	report, err := FindOrphanFiles(store, root, exts, false)
+	require.NoError(t, err)
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, orphan, report.Files[0].Path)
+	assert.Equal(t, int64(len("Author/Stray/copy.m4b")), report.TotalBytes)
+	assert.Equal(t, 1, report.IgnoredCount)
+
+	report, err = FindOrphanFiles(store, root, exts, true)
+	require.NoError(t, err)
+	require.Len(t, report.Files, 2)
+	assert.True(t, report.Files[1].Ignored)
+
+	checker, err := NewOrphanChecker(store, root)
+	require.NoError(t, err)
+	for _, p := range []string{tracked, segment, "/etc/passwd", root + "/Author/../../outside.mp3"} {
+		_, err := checker.Check(p)
+		assert.ErrorIs(t, err, ErrNotOrphan, p)
+	}
+
+	path, err := checker.Check(ignored)
+	require.NoError(t, err)
+	require.NoError(t, RemoveOrphanFile(store, path))
+	assert.NoFileExists(t, ignored)
+	report, err = FindOrphanFiles(store, root, exts, true)
+	require.NoError(t, err)
+	assert.Zero(t, report.IgnoredCount)
+}
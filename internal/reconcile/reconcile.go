@@ -1,7 +1,7 @@
 // file: internal/reconcile/reconcile.go
-// version: 1.0.1
+// version: 1.1.0
 // guid: c3d4e5f6-a7b8-9c0d-1e2f-3a4b5c6d7e8f
-// last-edited: 2026-05-15
+// last-edited: 2026-10-17
 
 package reconcile
 
@@ -1064,6 +1064,7 @@ func MergeBookMetadata(dst, src *database.Book) []string {
 	}
 	if dst.FileHash == nil && src.FileHash != nil {
 		dst.FileHash = src.FileHash
+		dst.FileHashAlgorithm = src.FileHashAlgorithm
 		merged = append(merged, "file_hash")
 	}
 	if dst.FileSize == nil && src.FileSize != nil {
@@ -0,0 +1,168 @@
+// file: internal/retryqueue/retryqueue.go
+// version: 1.0.0
+// guid: 0e6a9d3b-41f7-4c25-b8d2-7a15c9e4f361
+// last-edited: 2026-10-16
+
+// Package retryqueue keeps files whose scan or organize step failed for a
+// transient reason — a file locked by another process, a network share
+// that dropped for a moment — and schedules them for another attempt with
+// exponential backoff, so a blip does not cost a full rescan.
+//
+// Callers report outcomes with Fail and Clear; the library.retry-failed
+// operation re-runs the entries that are Due. After retry_max_attempts
+// failures an entry is marked exhausted and left for the user to retry
+// or dismiss.
+package retryqueue
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// MaxDelay caps the backoff between attempts.
+const MaxDelay = 6 * time.Hour
+
+// Store returns the retry queue kept by store (or the store it wraps), or
+// nil when it keeps none.
+func Store(store any) database.RetryQueueStore {
+	if store == nil {
+		return nil
+	}
+	if rq, ok := store.(database.RetryQueueStore); ok {
+		return rq
+	}
+	if uw, ok := store.(interface{ Unwrap() database.Store }); ok {
+		if rq, ok := uw.Unwrap().(database.RetryQueueStore); ok {
+			return rq
+		}
+	}
+	return nil
+}
+
+// IsTransient reports whether err looks like a condition that clears up
+// by itself: a lock held by another process, an I/O timeout, or a
+// network filesystem losing its connection.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, os.ErrDeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return isTransientErrno(err)
+}
+
+// Backoff is the wait after the given number of failures: the base delay
+// doubled for every failure after the first, capped at MaxDelay.
+func Backoff(failures int) time.Duration {
+	base := time.Duration(config.AppConfig.RetryBaseDelaySeconds) * time.Second
+	if base <= 0 {
+		base = time.Minute
+	}
+	d := base
+	for i := 1; i < failures && d < MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, MaxDelay)
+}
+
+// Fail records a failed attempt at the kind step for target (a path for
+// scans, a book ID for organizes). Non-transient errors are not queued;
+// they clear any earlier entry instead, since retrying will not help.
+// Nothing is queued while retry_max_attempts is 0. Returns whether the
+// failure was queued.
+func Fail(rq database.RetryQueueStore, kind, target string, entry database.RetryEntry, err error) (bool, error) {
+	maxAttempts := config.AppConfig.RetryMaxAttempts
+	if rq == nil || target == "" || maxAttempts <= 0 {
+		return false, nil
+	}
+	id := database.RetryEntryID(kind, target)
+	if !IsTransient(err) {
+		return false, Clear(rq, kind, target)
+	}
+	now := time.Now().UTC()
+	prev, getErr := rq.GetRetryEntry(id)
+	if getErr != nil {
+		return false, getErr
+	}
+	if prev != nil {
+		entry.FirstFailedAt = prev.FirstFailedAt
+		entry.Attempts = prev.Attempts
+		if entry.ImportPath == "" {
+			entry.ImportPath = prev.ImportPath
+		}
+	} else {
+		entry.FirstFailedAt = now
+	}
+	entry.ID = id
+	entry.Kind = kind
+	entry.Attempts++
+	entry.MaxAttempts = maxAttempts
+	entry.LastError = err.Error()
+	entry.LastFailedAt = now
+	if entry.Attempts >= entry.MaxAttempts {
+		entry.Status = database.RetryStatusExhausted
+		entry.NextAttemptAt = time.Time{}
+	} else {
+		entry.Status = database.RetryStatusPending
+		entry.NextAttemptAt = now.Add(Backoff(entry.Attempts))
+	}
+	return true, rq.PutRetryEntry(entry)
+}
+
+// Clear drops target's kind entry, if it has one. The lookup comes
+// first so the common case (nothing queued) costs no write.
+func Clear(rq database.RetryQueueStore, kind, target string) error {
+	if rq == nil || target == "" {
+		return nil
+	}
+	id := database.RetryEntryID(kind, target)
+	prev, err := rq.GetRetryEntry(id)
+	if err != nil || prev == nil {
+		return err
+	}
+	return rq.DeleteRetryEntry(id)
+}
+
+// Due returns the pending entries whose next attempt is at or before now.
+func Due(rq database.RetryQueueStore, now time.Time) ([]database.RetryEntry, error) {
+	if rq == nil {
+		return nil, nil
+	}
+	all, err := rq.ListRetryEntries()
+	if err != nil {
+		return nil, err
+	}
+	var due []database.RetryEntry
+	for _, e := range all {
+		if e.Status == database.RetryStatusPending && !e.NextAttemptAt.After(now) {
+			due = append(due, e)
+		}
+	}
+	return due, nil
+}
+
+// Requeue makes id due immediately, reviving an exhausted entry with a
+// fresh attempt budget. Returns nil, nil when id is not queued.
+func Requeue(rq database.RetryQueueStore, id string) (*database.RetryEntry, error) {
+	e, err := rq.GetRetryEntry(id)
+	if err != nil || e == nil {
+		return e, err
+	}
+	if e.Status == database.RetryStatusExhausted {
+		e.Attempts = 0
+	}
+	e.Status = database.RetryStatusPending
+	e.NextAttemptAt = time.Now().UTC()
+	return e, rq.PutRetryEntry(*e)
+}
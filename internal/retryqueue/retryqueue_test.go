@@ -0,0 +1,125 @@
+// file: internal/retryqueue/retryqueue_test.go
+// version: 1.0.0
+// guid: 0d6c3e8f-2b71-4a95-b4e7-91f2a8c5d036
+// last-edited: 2026-10-16
+
+package retryqueue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQueue(t *testing.T, maxAttempts, baseDelay int) database.RetryQueueStore {
+	t.Helper()
+	orig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = orig })
+	config.AppConfig.RetryMaxAttempts = maxAttempts
+	config.AppConfig.RetryBaseDelaySeconds = baseDelay
+
+	store, err := database.NewPebbleStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, IsTransient(fmt.Errorf("read: %w", syscall.EBUSY)))
+	assert.True(t, IsTransient(&os.PathError{Op: "open", Path: "/nas/x", Err: syscall.ESTALE}))
+	assert.True(t, IsTransient(os.ErrDeadlineExceeded))
+	assert.False(t, IsTransient(os.ErrNotExist))
+	assert.False(t, IsTransient(errors.New("unsupported codec")))
+	assert.False(t, IsTransient(nil))
+}
+
+func TestBackoff(t *testing.T) {
+	newTestQueue(t, 5, 30)
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, 60*time.Second, Backoff(2))
+	assert.Equal(t, 120*time.Second, Backoff(3))
+	assert.Equal(t, MaxDelay, Backoff(40))
+
+	config.AppConfig.RetryBaseDelaySeconds = 0
+	assert.Equal(t, time.Minute, Backoff(1))
+}
+
+func TestFail_BacksOffThenExhausts(t *testing.T) {
+	rq := newTestQueue(t, 2, 60)
+	busy := fmt.Errorf("open: %w", syscall.EBUSY)
+	id := database.RetryEntryID(database.RetryKindScan, "/nas/a.m4b")
+
+	queued, err := Fail(rq, database.RetryKindScan, "/nas/a.m4b", database.RetryEntry{Path: "/nas/a.m4b", ImportPath: "/nas"}, busy)
+	require.NoError(t, err)
+	assert.True(t, queued)
+	e, err := rq.GetRetryEntry(id)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	assert.Equal(t, database.RetryStatusPending, e.Status)
+	assert.Equal(t, 1, e.Attempts)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), e.NextAttemptAt, 5*time.Second)
+
+	due, err := Due(rq, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	due, err = Due(rq, time.Now().Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	_, err = Fail(rq, database.RetryKindScan, "/nas/a.m4b", database.RetryEntry{Path: "/nas/a.m4b"}, busy)
+	require.NoError(t, err)
+	e, _ = rq.GetRetryEntry(id)
+	assert.Equal(t, database.RetryStatusExhausted, e.Status)
+	assert.Equal(t, "/nas", e.ImportPath)
+	assert.True(t, e.NextAttemptAt.IsZero())
+
+	// A manual retry revives it with a fresh budget.
+	e, err = Requeue(rq, id)
+	require.NoError(t, err)
+	assert.Equal(t, database.RetryStatusPending, e.Status)
+	assert.Zero(t, e.Attempts)
+	due, _ = Due(rq, time.Now())
+	assert.Len(t, due, 1)
+
+	missing, err := Requeue(rq, "scan-nope")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestFail_PermanentErrorClears(t *testing.T) {
+	rq := newTestQueue(t, 5, 60)
+	_, err := Fail(rq, database.RetryKindOrganize, "b1", database.RetryEntry{BookID: "b1"}, syscall.EIO)
+	require.NoError(t, err)
+
+	queued, err := Fail(rq, database.RetryKindOrganize, "b1", database.RetryEntry{BookID: "b1"}, errors.New("no author"))
+	require.NoError(t, err)
+	assert.False(t, queued)
+	all, err := rq.ListRetryEntries()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestFail_Disabled(t *testing.T) {
+	rq := newTestQueue(t, 0, 60)
+	queued, err := Fail(rq, database.RetryKindScan, "/nas/a.m4b", database.RetryEntry{}, syscall.EBUSY)
+	require.NoError(t, err)
+	assert.False(t, queued)
+}
+
+func TestClear(t *testing.T) {
+	rq := newTestQueue(t, 5, 60)
+	_, err := Fail(rq, database.RetryKindScan, "/nas/a.m4b", database.RetryEntry{}, syscall.EAGAIN)
+	require.NoError(t, err)
+	require.NoError(t, Clear(rq, database.RetryKindScan, "/nas/a.m4b"))
+	require.NoError(t, Clear(rq, database.RetryKindScan, "/nas/a.m4b"))
+	all, _ := rq.ListRetryEntries()
+	assert.Empty(t, all)
+}
@@ -0,0 +1,46 @@
+// file: internal/retryqueue/transient_unix.go
+// version: 1.0.0
+// guid: 7d2f5a91-c3e8-4b06-9f4a-28e6b1d07c53
+// last-edited: 2026-10-16
+
+//go:build !windows
+
+package retryqueue
+
+import (
+	"errors"
+	"syscall"
+)
+
+// transientErrnos are the errors a locked file or a flaky network mount
+// produces; they usually clear without intervention.
+var transientErrnos = []syscall.Errno{
+	syscall.EAGAIN,
+	syscall.EBUSY,
+	syscall.ETXTBSY,
+	syscall.EINTR,
+	syscall.EIO,
+	syscall.ETIMEDOUT,
+	syscall.ESTALE,
+	syscall.ENOTCONN,
+	syscall.ECONNRESET,
+	syscall.ECONNABORTED,
+	syscall.EHOSTDOWN,
+	syscall.EHOSTUNREACH,
+	syscall.ENETDOWN,
+	syscall.ENETUNREACH,
+	syscall.ENOLCK,
+}
+
+func isTransientErrno(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	for _, e := range transientErrnos {
+		if errno == e {
+			return true
+		}
+	}
+	return false
+}
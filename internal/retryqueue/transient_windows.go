@@ -0,0 +1,38 @@
+// file: internal/retryqueue/transient_windows.go
+// version: 1.0.0
+// guid: 3a9c6e0f-85d2-4b71-a4e3-c91f07d52b68
+// last-edited: 2026-10-16
+
+//go:build windows
+
+package retryqueue
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Win32 error codes for sharing violations and dropped network paths.
+var transientErrnos = []syscall.Errno{
+	32,   // ERROR_SHARING_VIOLATION
+	33,   // ERROR_LOCK_VIOLATION
+	53,   // ERROR_BAD_NETPATH
+	54,   // ERROR_NETWORK_BUSY
+	59,   // ERROR_UNEXP_NET_ERR
+	64,   // ERROR_NETNAME_DELETED
+	121,  // ERROR_SEM_TIMEOUT
+	1231, // ERROR_NETWORK_UNREACHABLE
+}
+
+func isTransientErrno(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	for _, e := range transientErrnos {
+		if errno == e {
+			return true
+		}
+	}
+	return false
+}
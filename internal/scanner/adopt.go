@@ -0,0 +1,137 @@
+// file: internal/scanner/adopt.go
+// version: 1.0.0
+// guid: 9e4b1c73-6a2d-4f58-b3e0-2d8c7f5a1b96
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+)
+
+// An adopt scan takes an already organized library as it is: author,
+// series, title and sequence come from each book's folders, parsed with
+// the import path's adopt pattern (folder_naming_pattern when unset),
+// and matching books are saved as organized. Nothing is moved, and auto
+// organize and the organize operation leave adopted books alone. Books
+// whose folders don't fit the pattern are imported as usual.
+
+// adoption is the adopt setting ProcessBooksParallel applies. Set per
+// folder via SetAdoption by ScanService.scanFolder; nil outside an adopt
+// scan.
+type adoption struct {
+	root    string
+	pattern *metadata.PathPattern
+}
+
+var (
+	adoptionState *adoption
+	adoptionMu    sync.RWMutex
+)
+
+// SetAdoption installs an adopt scan of root with the given pattern (an
+// empty pattern uses folder_naming_pattern). An empty root ends it.
+func SetAdoption(root, pattern string) error {
+	if root == "" {
+		adoptionMu.Lock()
+		adoptionState = nil
+		adoptionMu.Unlock()
+		return nil
+	}
+	compiled, err := compileAdoptPattern(pattern)
+	if err != nil {
+		return err
+	}
+	adoptionMu.Lock()
+	defer adoptionMu.Unlock()
+	adoptionState = &adoption{root: filepath.Clean(root), pattern: compiled}
+	return nil
+}
+
+func currentAdoption() *adoption {
+	adoptionMu.RLock()
+	defer adoptionMu.RUnlock()
+	return adoptionState
+}
+
+// Adopting reports whether an adopt scan is in progress.
+func Adopting() bool {
+	return currentAdoption() != nil
+}
+
+// ValidAdoptPattern reports whether pattern can parse book folders. The
+// empty string is valid and means folder_naming_pattern.
+func ValidAdoptPattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	_, err := metadata.CompilePathPattern(pattern)
+	return err
+}
+
+func compileAdoptPattern(pattern string) (*metadata.PathPattern, error) {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = config.AppConfig.FolderNamingPattern
+	}
+	return metadata.CompilePathPattern(pattern)
+}
+
+// folderAdoption picks the adopt setting for one folder: the request's
+// when it asks for adoption, otherwise the import path's.
+func folderAdoption(requested bool, requestedPattern, folder string, paths []database.ImportPath) (adopt bool, pattern string) {
+	if requested {
+		return true, requestedPattern
+	}
+	for _, p := range paths {
+		if p.Path == folder {
+			return p.Adopt, p.AdoptPattern
+		}
+	}
+	return false, ""
+}
+
+// adoptFromPath fills book from its folders when an adopt scan is in
+// progress and they fit the pattern; the folder values win over tags.
+// isDir marks a book that is a directory rather than a file. It reports
+// whether the book was adopted.
+func adoptFromPath(book *Book, isDir bool) bool {
+	a := currentAdoption()
+	if a == nil || book.LibraryState != "" {
+		return false
+	}
+	rel, err := filepath.Rel(a.root, book.FilePath)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	if !isDir {
+		rel = filepath.Dir(rel)
+	}
+	fields, ok := a.pattern.Match(filepath.ToSlash(rel))
+	if !ok {
+		return false
+	}
+	book.Title = fields.Title
+	if fields.Author != "" {
+		book.Author = fields.Author
+	}
+	if fields.Series != "" {
+		book.Series = fields.Series
+	}
+	// Book.Position is whole; a fractional folder number ("2.5") is left
+	// for the tags or a metadata fetch to supply.
+	if fields.Position > 0 && fields.Position == math.Trunc(fields.Position) {
+		book.Position = int(fields.Position)
+	}
+	if fields.Narrator != "" {
+		book.Narrator = fields.Narrator
+	}
+	book.LibraryState = "organized"
+	return true
+}
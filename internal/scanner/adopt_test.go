@@ -0,0 +1,97 @@
+// file: internal/scanner/adopt_test.go
+// version: 1.0.0
+// guid: 2f8a6d35-c1e7-4b90-9d24-6e3b7a1c5f08
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdoptFromPath_QuickScan(t *testing.T) {
+	store, cleanup := setupPebbleStore(t)
+	defer cleanup()
+	SetStore(store)
+	t.Cleanup(func() { SetStore(nil) })
+	prevConfig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = prevConfig })
+	config.AppConfig.FolderNamingPattern = "{author}/{series}/{title}"
+
+	root := t.TempDir()
+	require.NoError(t, SetAdoption(root, ""))
+	t.Cleanup(func() { _ = SetAdoption("", "") })
+	assert.True(t, Adopting())
+
+	scan := func(rel string) *database.Book {
+		t.Helper()
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(path), 0o644))
+		require.NoError(t, processQuick(context.Background(), &Book{FilePath: path, Format: ".mp3"}, logger.New("test")))
+		saved, err := store.GetBookByFilePath(path)
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		return saved
+	}
+	authorName := func(b *database.Book) string {
+		t.Helper()
+		require.NotNil(t, b.AuthorID)
+		a, err := store.GetAuthorByID(*b.AuthorID)
+		require.NoError(t, err)
+		return a.Name
+	}
+
+	mort := scan("Terry Pratchett/Discworld/Mort/01 - Chapter.mp3")
+	assert.Equal(t, "Mort", mort.Title)
+	assert.Equal(t, "Terry Pratchett", authorName(mort))
+	require.NotNil(t, mort.SeriesID)
+	series, err := store.GetSeriesByID(*mort.SeriesID)
+	require.NoError(t, err)
+	assert.Equal(t, "Discworld", series.Name)
+	assert.Equal(t, "organized", *mort.LibraryState)
+	assert.Equal(t, filepath.Join(root, "Terry Pratchett/Discworld/Mort/01 - Chapter.mp3"), mort.FilePath, "nothing moves")
+
+	martian := scan("Andy Weir/The Martian/part1.mp3")
+	assert.Equal(t, "The Martian", martian.Title)
+	assert.Equal(t, "Andy Weir", authorName(martian))
+	assert.Nil(t, martian.SeriesID)
+	assert.Equal(t, "organized", *martian.LibraryState)
+
+	// A file outside the pattern's layout is imported as usual.
+	loose := scan("loose.mp3")
+	assert.Equal(t, "imported", *loose.LibraryState)
+
+	require.NoError(t, SetAdoption("", ""))
+	assert.False(t, Adopting())
+	assert.Equal(t, "imported", *scan("Ann Leckie/Ancillary Justice/a.mp3").LibraryState)
+}
+
+func TestFolderAdoption(t *testing.T) {
+	paths := []database.ImportPath{
+		{Path: "/adopted", Adopt: true, AdoptPattern: "{author}/{title}"},
+		{Path: "/plain"},
+	}
+	adopt, pattern := folderAdoption(false, "", "/adopted", paths)
+	assert.True(t, adopt)
+	assert.Equal(t, "{author}/{title}", pattern)
+
+	adopt, _ = folderAdoption(false, "", "/plain", paths)
+	assert.False(t, adopt)
+
+	adopt, pattern = folderAdoption(true, "{title}", "/plain", paths)
+	assert.True(t, adopt)
+	assert.Equal(t, "{title}", pattern)
+
+	assert.NoError(t, ValidAdoptPattern(""))
+	assert.Error(t, ValidAdoptPattern("{author}/{series}"))
+	assert.Error(t, SetAdoption("/lib", "{author}"))
+}
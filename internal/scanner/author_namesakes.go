@@ -0,0 +1,118 @@
+// file: internal/scanner/author_namesakes.go
+// version: 1.0.0
+// guid: 4b9d7e20-6a1c-4f83-a2d5-e08c3f6b1a79
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// resolveBookAuthorID is resolveAuthorID for a scanned book. When several
+// author records share the book's author name (namesakes told apart by
+// external IDs or birth year), it picks one from what the library already
+// knows about the book. If nothing decides, it returns the name's
+// original record along with the namesakes, and the caller queues a
+// resolution prompt once the book is saved.
+func resolveBookAuthorID(book *Book) (*int, []database.Author, error) {
+	name := normalizeAuthorName(book.Author)
+	if as, ok := database.AsAuthorAuthorityStore(getStore()); ok && name != "" {
+		namesakes, err := as.GetAuthorsByName(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("author lookup failed: %w", err)
+		}
+		if len(namesakes) > 1 {
+			if id, ok := pickNamesake(book, namesakes); ok {
+				return &id, nil, nil
+			}
+			return &namesakes[0].ID, namesakes, nil
+		}
+	}
+	id, err := resolveAuthorID(book.Author)
+	return id, nil, err
+}
+
+// pickNamesake chooses among namesakes using, in order: the author the
+// book is already filed under (a rescan keeps an earlier answer), the
+// author of other books in the same folder, and the author owning a
+// series of the book's series name. Each must point at exactly one
+// namesake.
+func pickNamesake(book *Book, namesakes []database.Author) (int, bool) {
+	store := getStore()
+	isNamesake := func(id *int) bool {
+		if id == nil {
+			return false
+		}
+		for _, a := range namesakes {
+			if a.ID == *id {
+				return true
+			}
+		}
+		return false
+	}
+
+	if existing, err := store.GetBookByFilePath(book.FilePath); err == nil && existing != nil && isNamesake(existing.AuthorID) {
+		return *existing.AuthorID, true
+	}
+
+	if lister, ok := database.AsBookDirLister(store); ok {
+		if siblings, err := lister.GetBooksInDir(filepath.Dir(book.FilePath)); err == nil {
+			found := map[int]bool{}
+			for _, s := range siblings {
+				if s.FilePath != book.FilePath && isNamesake(s.AuthorID) {
+					found[*s.AuthorID] = true
+				}
+			}
+			if len(found) == 1 {
+				for id := range found {
+					return id, true
+				}
+			}
+		}
+	}
+
+	if book.Series != "" {
+		owner, owners := 0, 0
+		for _, a := range namesakes {
+			authorID := a.ID
+			if s, err := store.GetSeriesByName(book.Series, &authorID); err == nil && s != nil && s.AuthorID != nil && *s.AuthorID == a.ID {
+				owner = a.ID
+				owners++
+			}
+		}
+		if owners == 1 {
+			return owner, true
+		}
+	}
+	return 0, false
+}
+
+// queueAuthorResolution raises a review prompt for the book saved at
+// filePath if it was filed under assignedID because its author name was
+// ambiguous. A book saved under another author (say, preserved from an
+// earlier record) needs no prompt.
+func queueAuthorResolution(filePath, name string, assignedID int, namesakes []database.Author) {
+	store := getStore()
+	book, err := store.GetBookByFilePath(filePath)
+	if err != nil || book == nil || book.AuthorID == nil || *book.AuthorID != assignedID {
+		return
+	}
+	ids := make([]int, len(namesakes))
+	for i, a := range namesakes {
+		ids[i] = a.ID
+	}
+	if _, err := database.AddAuthorResolution(store, database.AuthorResolution{
+		BookID:       book.ID,
+		Name:         name,
+		CandidateIDs: ids,
+		AssignedID:   assignedID,
+	}); err != nil {
+		defaultLog.Warn("failed to queue author resolution for %s: %v", book.ID, err)
+		return
+	}
+	defaultLog.Info("Author %q matches %d authors; %s queued for review", name, len(namesakes), book.Title)
+}
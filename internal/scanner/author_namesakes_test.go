@@ -0,0 +1,78 @@
+// file: internal/scanner/author_namesakes_test.go
+// version: 1.0.0
+// guid: 7c2e9a41-d5f8-4b36-a0e7-3f1b8d6c2e95
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveBookToDatabase_AuthorNamesakes(t *testing.T) {
+	store, cleanup := setupPebbleStore(t)
+	defer cleanup()
+	SetStore(store)
+	t.Cleanup(func() { SetStore(nil) })
+	prevConfig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = prevConfig })
+	config.AppConfig.RootDir = t.TempDir()
+
+	poet, err := store.CreateAuthor("John Smith")
+	require.NoError(t, err)
+	year := 1580
+	explorer, err := store.CreateAuthorNamesake("John Smith", database.AuthorAuthority{BirthYear: &year})
+	require.NoError(t, err)
+	_, err = store.CreateSeries("Virginia", &explorer.ID)
+	require.NoError(t, err)
+
+	save := func(path, series string) *database.Book {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(path), 0o644))
+		require.NoError(t, saveBookToDatabase(context.Background(), &Book{
+			FilePath: path, Title: filepath.Base(path), Author: "John Smith", Series: series, Format: ".m4b",
+		}))
+		saved, err := store.GetBookByFilePath(path)
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		return saved
+	}
+	dir := filepath.Join(config.AppConfig.RootDir, "smith")
+
+	// Nothing says which John Smith: filed under the original record and
+	// queued for review.
+	ambiguous := save(filepath.Join(dir, "poems.m4b"), "")
+	assert.Equal(t, poet.ID, *ambiguous.AuthorID)
+	pending, err := database.ListAuthorResolutions(store, database.AuthorResolutionPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, ambiguous.ID, pending[0].BookID)
+	assert.ElementsMatch(t, []int{poet.ID, explorer.ID}, pending[0].CandidateIDs)
+
+	// The series owner decides; a rescan raises no second prompt.
+	history := save(filepath.Join(config.AppConfig.RootDir, "virginia", "history.m4b"), "Virginia")
+	assert.Equal(t, explorer.ID, *history.AuthorID)
+	save(filepath.Join(dir, "poems.m4b"), "")
+
+	// Once answered, the book's folder decides for its neighbours, and a
+	// rescan keeps the answer.
+	ambiguous.AuthorID = &explorer.ID
+	_, err = store.UpdateBook(ambiguous.ID, ambiguous)
+	require.NoError(t, err)
+	_, err = database.ResolveAuthorResolution(store, pending[0].ID, explorer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, explorer.ID, *save(filepath.Join(dir, "maps.m4b"), "").AuthorID)
+	assert.Equal(t, explorer.ID, *save(filepath.Join(dir, "poems.m4b"), "").AuthorID)
+
+	pending, err = database.ListAuthorResolutions(store, database.AuthorResolutionPending)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
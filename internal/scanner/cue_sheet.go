@@ -0,0 +1,196 @@
+// file: internal/scanner/cue_sheet.go
+// version: 1.0.0
+// guid: 5b2e8d41-7c3a-4f96-b1d0-9e6a4c2f8b17
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// cueFramesPerSecond is the CD frame rate cue sheet INDEX times count in.
+const cueFramesPerSecond = 75
+
+// maxCueSheetBytes bounds how much of a .cue file is read; real sheets are
+// a few KB even with hundreds of tracks.
+const maxCueSheetBytes = 1 << 20
+
+// findCueSheet returns the cue sheet describing audioPath: "<name>.cue"
+// next to it (also "<name>.<ext>.cue"), or otherwise the only .cue file in
+// its directory when that sheet's FILE line names audioPath. It returns ""
+// when there is none.
+func findCueSheet(audioPath string) string {
+	dir := filepath.Dir(audioPath)
+	base := filepath.Base(audioPath)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	for _, name := range []string{stem + ".cue", base + ".cue"} {
+		p := filepath.Join(dir, name)
+		if fi, err := os.Stat(p); err == nil && fi.Mode().IsRegular() {
+			return p
+		}
+	}
+	sheets, _ := filepath.Glob(filepath.Join(dir, "*.cue"))
+	if len(sheets) != 1 {
+		return ""
+	}
+	f, err := os.Open(sheets[0])
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sheet, err := parseCueSheet(io.LimitReader(f, maxCueSheetBytes), 0)
+	if err != nil || !strings.EqualFold(sheet.file, base) {
+		return ""
+	}
+	return sheets[0]
+}
+
+// readCueChapters returns the chapters of the cue sheet that describes
+// audioPath, tagged ChapterSourceCue, or nil when there is no usable
+// sheet. durationSec closes the last chapter; 0 leaves it open.
+func readCueChapters(audioPath string, durationSec float64) []database.BookChapter {
+	path := findCueSheet(audioPath)
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	sheet, err := parseCueSheet(io.LimitReader(f, maxCueSheetBytes), durationSec)
+	if err != nil {
+		defaultLog.Debug("scan: ignoring cue sheet %s: %v", path, err)
+		return nil
+	}
+	return sheet.chapters
+}
+
+// cueSheet is what the scanner needs from a parsed .cue file.
+type cueSheet struct {
+	file     string // base name from the first FILE line
+	chapters []database.BookChapter
+}
+
+// parseCueSheet reads the TRACK / TITLE / INDEX 01 entries of a cue sheet.
+// Tracks of a second FILE are ignored, since one sheet chapters one audio
+// file. Each chapter ends where the next starts; the last ends at
+// durationSec when that is later than its start. A sheet without tracks,
+// or with start times that go backwards, is an error.
+func parseCueSheet(r io.Reader, durationSec float64) (*cueSheet, error) {
+	sheet := &cueSheet{}
+	files := 0
+	var cur *database.BookChapter
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(sc.Text(), "\ufeff"))
+		keyword, rest, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(keyword) {
+		case "FILE":
+			files++
+			if files == 1 {
+				sheet.file = filepath.Base(filepath.FromSlash(strings.ReplaceAll(cueString(rest, true), `\`, "/")))
+			}
+		case "TRACK":
+			if files > 1 {
+				continue
+			}
+			sheet.chapters = append(sheet.chapters, database.BookChapter{
+				Index:    len(sheet.chapters) + 1,
+				StartSec: -1,
+				Source:   database.ChapterSourceCue,
+			})
+			cur = &sheet.chapters[len(sheet.chapters)-1]
+		case "TITLE":
+			if cur != nil && files <= 1 {
+				cur.Title = cueString(rest, false)
+			}
+		case "INDEX":
+			if cur == nil || files > 1 {
+				continue
+			}
+			num, ts, _ := strings.Cut(strings.TrimSpace(rest), " ")
+			if num != "01" && num != "1" {
+				continue
+			}
+			sec, err := cueTime(strings.TrimSpace(ts))
+			if err != nil {
+				return nil, fmt.Errorf("track %d: %w", cur.Index, err)
+			}
+			cur.StartSec = sec
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(sheet.chapters) == 0 {
+		return nil, fmt.Errorf("no tracks")
+	}
+	for i := range sheet.chapters {
+		ch := &sheet.chapters[i]
+		if ch.StartSec < 0 {
+			return nil, fmt.Errorf("track %d has no INDEX 01", ch.Index)
+		}
+		if ch.Title == "" {
+			ch.Title = fmt.Sprintf("Chapter %d", ch.Index)
+		}
+		if i > 0 {
+			prev := &sheet.chapters[i-1]
+			if ch.StartSec < prev.StartSec {
+				return nil, fmt.Errorf("track %d starts before track %d", ch.Index, prev.Index)
+			}
+			prev.EndSec = ch.StartSec
+		}
+	}
+	if last := &sheet.chapters[len(sheet.chapters)-1]; durationSec > last.StartSec {
+		last.EndSec = durationSec
+	}
+	return sheet, nil
+}
+
+// cueString unquotes a cue sheet value. FILE lines end in a file type
+// ("WAVE", "MP3") after the name, which stripType drops.
+func cueString(s string, stripType bool) string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, `"`) {
+		if end := strings.Index(s[1:], `"`); end >= 0 {
+			return s[1 : end+1]
+		}
+		return strings.Trim(s, `"`)
+	}
+	if stripType {
+		if i := strings.LastIndex(s, " "); i > 0 {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// cueTime parses an mm:ss:ff INDEX time into seconds.
+func cueTime(s string) (float64, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("bad time %q", s)
+	}
+	var n [3]int
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("bad time %q", s)
+		}
+		n[i] = v
+	}
+	if n[1] >= 60 || n[2] >= cueFramesPerSecond {
+		return 0, fmt.Errorf("bad time %q", s)
+	}
+	return float64(n[0]*60+n[1]) + float64(n[2])/cueFramesPerSecond, nil
+}
@@ -0,0 +1,98 @@
+// file: internal/scanner/cue_sheet_test.go
+// version: 1.0.0
+// guid: c8a4f2e6-1d9b-4b73-9e05-7f3b6a1d4c28
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCueSheet = "\ufeffREM GENRE Audiobook\r\n" +
+	`PERFORMER "Jane Author"
+TITLE "The Long Book"
+FILE "The Long Book.mp3" MP3
+  TRACK 01 AUDIO
+    TITLE "Opening Credits"
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    TITLE "Chapter One"
+    INDEX 00 00:40:00
+    INDEX 01 00:41:30
+  TRACK 03 AUDIO
+    INDEX 01 61:05:74
+`
+
+func TestParseCueSheet(t *testing.T) {
+	sheet, err := parseCueSheet(strings.NewReader(testCueSheet), 4000)
+	require.NoError(t, err)
+	assert.Equal(t, "The Long Book.mp3", sheet.file)
+	require.Len(t, sheet.chapters, 3)
+	assert.Equal(t, database.BookChapter{Index: 1, Title: "Opening Credits", StartSec: 0, EndSec: 41.4, Source: "cue"}, sheet.chapters[0])
+	assert.Equal(t, "Chapter One", sheet.chapters[1].Title)
+	assert.InDelta(t, 3665+74.0/75, sheet.chapters[1].EndSec, 1e-9)
+	assert.Equal(t, "Chapter 3", sheet.chapters[2].Title)
+	assert.Equal(t, 4000.0, sheet.chapters[2].EndSec)
+
+	open, err := parseCueSheet(strings.NewReader(testCueSheet), 0)
+	require.NoError(t, err)
+	assert.Zero(t, open.chapters[2].EndSec, "unknown duration leaves the last chapter open")
+
+	for name, bad := range map[string]string{
+		"no tracks":  `FILE "a.mp3" MP3`,
+		"no index":   "FILE \"a.mp3\" MP3\nTRACK 01 AUDIO\n",
+		"bad time":   "FILE \"a.mp3\" MP3\nTRACK 01 AUDIO\nINDEX 01 00:61:00\n",
+		"backwards":  "FILE \"a.mp3\" MP3\nTRACK 01 AUDIO\nINDEX 01 02:00:00\nTRACK 02 AUDIO\nINDEX 01 01:00:00\n",
+		"frames":     "FILE \"a.mp3\" MP3\nTRACK 01 AUDIO\nINDEX 01 00:00:75\n",
+		"not a time": "FILE \"a.mp3\" MP3\nTRACK 01 AUDIO\nINDEX 01 soon\n",
+	} {
+		_, err := parseCueSheet(strings.NewReader(bad), 0)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestReadCueChapters(t *testing.T) {
+	dir := t.TempDir()
+	audio := filepath.Join(dir, "The Long Book.mp3")
+	require.NoError(t, os.WriteFile(audio, []byte("audio"), 0o644))
+	assert.Nil(t, readCueChapters(audio, 0), "no sheet")
+
+	// A lone sheet with another name is used when its FILE line matches.
+	sheet := filepath.Join(dir, "album.cue")
+	require.NoError(t, os.WriteFile(sheet, []byte(testCueSheet), 0o644))
+	assert.Len(t, readCueChapters(audio, 0), 3)
+
+	other := filepath.Join(dir, "Other Book.mp3")
+	require.NoError(t, os.WriteFile(other, []byte("audio"), 0o644))
+	assert.Nil(t, readCueChapters(other, 0), "sheet names a different file")
+
+	// A sheet named after the file wins regardless of its FILE line.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Other Book.cue"),
+		[]byte("FILE \"whatever.wav\" WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:00\n"), 0o644))
+	assert.Len(t, readCueChapters(other, 0), 1)
+}
+
+func TestKeepUnwrittenChapters_Cue(t *testing.T) {
+	store, cleanup := setupPebbleStore(t)
+	defer cleanup()
+	embedded := []database.BookChapter{{Index: 1, Title: "One", EndSec: 10}}
+	cue := []database.BookChapter{{Index: 1, Title: "One", EndSec: 10, Source: database.ChapterSourceCue}}
+	manual := []database.BookChapter{{Index: 1, Title: "Edited", EndSec: 10, Source: database.ChapterSourceManual}}
+
+	require.NoError(t, database.PutBookChapters(store, "b1", cue))
+	assert.False(t, keepUnwrittenChapters(store, "b1", cue), "a rescan refreshes cue chapters")
+	assert.False(t, keepUnwrittenChapters(store, "b1", embedded))
+	assert.True(t, keepUnwrittenChapters(store, "b1", nil))
+
+	require.NoError(t, database.PutBookChapters(store, "b1", manual))
+	assert.True(t, keepUnwrittenChapters(store, "b1", cue), "cue chapters don't replace edits")
+	assert.False(t, keepUnwrittenChapters(store, "b1", embedded))
+}
@@ -0,0 +1,173 @@
+// file: internal/scanner/deep_probe.go
+// version: 1.1.0
+// guid: 063783b2-ce83-4d27-acd1-4c35c4e3f8e4
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
+)
+
+// deepProbeTimeout bounds one ffprobe run; long m4b files with hundreds of
+// chapters still finish well inside it.
+const deepProbeTimeout = 30 * time.Second
+
+var (
+	ffprobeOnce sync.Once
+	ffprobePath string
+)
+
+// deepProbe is what a deep scan learns from ffprobe beyond the tags.
+type deepProbe struct {
+	DurationSec float64
+	BitrateKbps int
+	Codec       string
+	SampleRate  int
+	Channels    int
+	Chapters    []database.BookChapter
+}
+
+// probeFile runs ffprobe on path for exact stream info and embedded
+// chapters. It returns nil when ffprobe is not installed or the file
+// cannot be probed; deep scans then keep the tag-derived values.
+func probeFile(ctx context.Context, path string) *deepProbe {
+	ffprobeOnce.Do(func() { ffprobePath, _ = exec.LookPath("ffprobe") })
+	if ffprobePath == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, deepProbeTimeout)
+	defer cancel()
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, ffprobePath, "-v", "quiet", "-print_format", "json",
+		"-show_format", "-show_streams", "-show_chapters", path)
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		defaultLog.Debug("deep scan: ffprobe failed for %s: %v", path, err)
+		return nil
+	}
+
+	var out struct {
+		Format struct {
+			Duration string `json:"duration"`
+			BitRate  string `json:"bit_rate"`
+		} `json:"format"`
+		Streams []struct {
+			CodecName  string `json:"codec_name"`
+			CodecType  string `json:"codec_type"`
+			SampleRate string `json:"sample_rate"`
+			Channels   int    `json:"channels"`
+		} `json:"streams"`
+		Chapters []struct {
+			StartTime string `json:"start_time"`
+			EndTime   string `json:"end_time"`
+			Tags      struct {
+				Title string `json:"title"`
+			} `json:"tags"`
+		} `json:"chapters"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil
+	}
+	p := &deepProbe{Chapters: []database.BookChapter{}}
+	p.DurationSec, _ = strconv.ParseFloat(out.Format.Duration, 64)
+	if bps, err := strconv.ParseInt(out.Format.BitRate, 10, 64); err == nil {
+		p.BitrateKbps = int(bps / 1000)
+	}
+	for _, s := range out.Streams {
+		if s.CodecType == "audio" {
+			p.Codec = s.CodecName
+			p.SampleRate, _ = strconv.Atoi(s.SampleRate)
+			p.Channels = s.Channels
+			break
+		}
+	}
+	for i, ch := range out.Chapters {
+		start, _ := strconv.ParseFloat(ch.StartTime, 64)
+		end, _ := strconv.ParseFloat(ch.EndTime, 64)
+		p.Chapters = append(p.Chapters, database.BookChapter{Index: i + 1, Title: ch.Tags.Title, StartSec: start, EndSec: end})
+	}
+	return p
+}
+
+// ProbeChapters returns the chapters embedded in path and its duration in
+// seconds. ok is false when ffprobe is not installed or can't read path.
+func ProbeChapters(ctx context.Context, path string) (chapters []database.BookChapter, durationSec float64, ok bool) {
+	p := probeFile(ctx, path)
+	if p == nil {
+		return nil, 0, false
+	}
+	return p.Chapters, p.DurationSec, true
+}
+
+// embeddedChapterExts are the formats whose chapter markers standard scans
+// read as well; deep scans probe every file.
+var embeddedChapterExts = map[string]bool{".m4b": true, ".m4a": true}
+
+// hasEmbeddedChapters reports whether path is in a format that carries
+// chapter markers standard scans read.
+func hasEmbeddedChapters(path string) bool {
+	return embeddedChapterExts[strings.ToLower(filepath.Ext(path))]
+}
+
+// keepUnwrittenChapters reports whether a scan that found the chapters
+// listed in probed should leave the stored list alone: the file has none
+// of its own, and the stored markers were proposed or edited but not yet
+// written into it. Chapters from a cue sheet replace earlier cue chapters
+// but not proposed or edited ones.
+func keepUnwrittenChapters(store database.RawKVStore, bookID string, probed []database.BookChapter) bool {
+	if len(probed) > 0 && !database.ChaptersUnwritten(probed) {
+		return false
+	}
+	existing, err := database.GetBookChapters(store, bookID)
+	if err != nil || !database.ChaptersUnwritten(existing) {
+		return false
+	}
+	if len(probed) == 0 {
+		return true
+	}
+	for _, ch := range existing {
+		if ch.Source != database.ChapterSourceCue {
+			return true
+		}
+	}
+	return false
+}
+
+// apply overwrites the tag-estimated media info with the probed values.
+func (p *deepProbe) apply(b *Book) {
+	if p == nil {
+		return
+	}
+	if b.MediaInfo == nil {
+		b.MediaInfo = &mediainfo.MediaInfo{}
+	}
+	mi := b.MediaInfo
+	if p.DurationSec > 0 {
+		mi.Duration = int(p.DurationSec + 0.5)
+		b.Duration = mi.Duration
+	}
+	if p.BitrateKbps > 0 {
+		mi.Bitrate = p.BitrateKbps
+	}
+	if p.Codec != "" {
+		mi.Codec = p.Codec
+	}
+	if p.SampleRate > 0 {
+		mi.SampleRate = p.SampleRate
+	}
+	if p.Channels > 0 {
+		mi.Channels = p.Channels
+	}
+}
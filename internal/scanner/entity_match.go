@@ -0,0 +1,36 @@
+// file: internal/scanner/entity_match.go
+// version: 1.0.0
+// guid: 7e03dd8c-07da-4d2b-b8a6-1485b39871b3
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/entitymatch"
+)
+
+// entityMatcher is the per-scan author/series resolver. Set via
+// SetEntityMatcher from ScanService.performScanInternal and consulted by
+// resolveAuthorID/resolveSeriesID when the exact name lookup misses; nil
+// outside a scan (or with exact matching configured), which creates a new
+// record for every unknown spelling.
+var (
+	entityMatcher   *entitymatch.Resolver
+	entityMatcherMu sync.RWMutex
+)
+
+// SetEntityMatcher installs the author/series resolver for the current
+// scan. Pass nil to clear it.
+func SetEntityMatcher(r *entitymatch.Resolver) {
+	entityMatcherMu.Lock()
+	defer entityMatcherMu.Unlock()
+	entityMatcher = r
+}
+
+func currentEntityMatcher() *entitymatch.Resolver {
+	entityMatcherMu.RLock()
+	defer entityMatcherMu.RUnlock()
+	return entityMatcher
+}
@@ -0,0 +1,51 @@
+// file: internal/scanner/entity_match_test.go
+// version: 1.0.0
+// guid: e5064520-e140-48ea-84b8-acdbb0806658
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/entitymatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveIDs_UseEntityMatcher(t *testing.T) {
+	store, cleanup := setupPebbleStore(t)
+	defer cleanup()
+	SetStore(store)
+	t.Cleanup(func() { SetStore(nil) })
+
+	author, err := store.CreateAuthor("Brandon Sanderson")
+	require.NoError(t, err)
+	series, err := store.CreateSeries("The Stormlight Archive", &author.ID)
+	require.NoError(t, err)
+
+	// Without a matcher every spelling is a new record.
+	id, err := resolveAuthorID("Sanderson, Brandon")
+	require.NoError(t, err)
+	assert.NotEqual(t, author.ID, *id)
+
+	cfg := config.Config{EntityMatchStrictness: entitymatch.StrictnessFuzzy, EntityMatchThreshold: 0.9}
+	SetEntityMatcher(entitymatch.NewResolver(&cfg, store))
+	t.Cleanup(func() { SetEntityMatcher(nil) })
+
+	id, err = resolveAuthorID("Brandon Sandersen")
+	require.NoError(t, err)
+	assert.Equal(t, author.ID, *id)
+
+	sid, err := resolveSeriesID("Stormlight Archive", &author.ID)
+	require.NoError(t, err)
+	assert.Equal(t, series.ID, *sid)
+
+	// New names are still created, and later variants of them match.
+	created, err := resolveAuthorID("Mary Robinette Kowal")
+	require.NoError(t, err)
+	id, err = resolveAuthorID("Kowal, Mary Robinette")
+	require.NoError(t, err)
+	assert.Equal(t, *created, *id)
+}
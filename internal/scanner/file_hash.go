@@ -0,0 +1,223 @@
+// file: internal/scanner/file_hash.go
+// version: 1.1.0
+// guid: 9d3e5a71-c2b8-4f06-a4e1-6b7c0d2f8e95
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// File hashing modes accepted by config.FileHashAlgorithm.
+const (
+	FileHashPartial  = "partial"
+	FileHashSHA256   = "sha256"
+	FileHashXXHash64 = "xxhash64"
+)
+
+// Every hash is stored with the algorithm that produced it ("sha256",
+// "xxhash64" or "sha256-partial-<N>mb"). Hashes made different ways never
+// match, so after the setting changes duplicate detection misses copies
+// hashed the old way until the backfill-file-hashes job has rehashed every
+// file whose recorded algorithm is not the configured one. Records written
+// before the algorithm was recorded used the default partial mode, see
+// LegacyFileHashAlgorithm.
+
+// fileHashSettings returns the configured mode and partial chunk size in
+// bytes, defaulting to partial hashing with 10 MB chunks.
+func fileHashSettings() (mode string, chunk int64) {
+	mode = config.AppConfig.FileHashAlgorithm
+	if mode == "" {
+		mode = FileHashPartial
+	}
+	mb := config.AppConfig.FileHashPartialMB
+	if mb <= 0 {
+		return mode, hashChunkSize
+	}
+	return mode, int64(mb) << 20
+}
+
+// partialHashThreshold is the size above which partial mode hashes only the
+// ends of a file; it is never less than twice the chunk so the two chunks
+// cannot overlap.
+func partialHashThreshold(chunk int64) int64 {
+	return max(hashThreshold, 2*chunk)
+}
+
+func partialHashAlgorithm(chunk int64) string {
+	return fmt.Sprintf("sha256-partial-%dmb", chunk>>20)
+}
+
+// FileHashAlgorithmFor returns the algorithm the current configuration
+// uses to hash a file of size bytes.
+func FileHashAlgorithmFor(size int64) string {
+	mode, chunk := fileHashSettings()
+	switch {
+	case mode == FileHashXXHash64:
+		return FileHashXXHash64
+	case mode == FileHashPartial && size > partialHashThreshold(chunk):
+		return partialHashAlgorithm(chunk)
+	default:
+		return FileHashSHA256
+	}
+}
+
+// LegacyFileHashAlgorithm returns the algorithm behind a hash recorded
+// without one, which is always the default partial mode.
+func LegacyFileHashAlgorithm(size int64) string {
+	if size > hashThreshold {
+		return partialHashAlgorithm(hashChunkSize)
+	}
+	return FileHashSHA256
+}
+
+// hashedBytes is how many bytes of a size-byte file the current
+// configuration reads to hash it.
+func hashedBytes(size int64) int64 {
+	mode, chunk := fileHashSettings()
+	if mode == FileHashPartial && size > partialHashThreshold(chunk) {
+		return 2 * chunk
+	}
+	return size
+}
+
+// hashOpenFile hashes f, positioned at its start, with the configured
+// algorithm and returns the hex hash and the algorithm's name.
+func hashOpenFile(f *os.File, size int64) (hash, algorithm string, err error) {
+	mode, chunk := fileHashSettings()
+	return hashOpenFileAs(f, size, mode, chunk)
+}
+
+// hashOpenFileAs is hashOpenFile for an explicit mode and partial chunk
+// size.
+func hashOpenFileAs(f *os.File, size int64, mode string, chunk int64) (hash, algorithm string, err error) {
+	switch {
+	case mode == FileHashXXHash64:
+		h := xxhash.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return "", "", err
+		}
+		return hex.EncodeToString(h.Sum(nil)), FileHashXXHash64, nil
+	case mode == FileHashPartial && size > partialHashThreshold(chunk):
+		// First chunk + last chunk + size.
+		h := sha256.New()
+		if _, err := io.CopyN(h, f, chunk); err != nil && err != io.EOF {
+			return "", "", err
+		}
+		if _, err := f.Seek(-chunk, io.SeekEnd); err != nil {
+			return "", "", err
+		}
+		if _, err := io.CopyN(h, f, chunk); err != nil && err != io.EOF {
+			return "", "", err
+		}
+		fmt.Fprintf(h, "%d", size)
+		return hex.EncodeToString(h.Sum(nil)), partialHashAlgorithm(chunk), nil
+	default:
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return "", "", err
+		}
+		return hex.EncodeToString(h.Sum(nil)), FileHashSHA256, nil
+	}
+}
+
+// ComputeFileHashWithAlgorithm hashes filePath like ComputeFileHash and
+// also returns the algorithm used.
+func ComputeFileHashWithAlgorithm(filePath string) (hash, algorithm string, err error) {
+	if activeScanner != nil {
+		hash, err = activeScanner.ComputeFileHash(filePath)
+		if err != nil {
+			return "", "", err
+		}
+		size, _ := getFileSize(filePath)
+		return hash, FileHashAlgorithmFor(size), nil
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", "", err
+	}
+	return hashOpenFile(f, info.Size())
+}
+
+// ParseFileHashAlgorithm splits a recorded algorithm name into the mode
+// and partial chunk size that produce it. ok is false for names no mode
+// produces.
+func ParseFileHashAlgorithm(algorithm string) (mode string, chunk int64, ok bool) {
+	switch algorithm {
+	case FileHashSHA256, FileHashXXHash64:
+		return algorithm, 0, true
+	}
+	var mb int64
+	if _, err := fmt.Sscanf(algorithm, "sha256-partial-%dmb", &mb); err != nil || mb <= 0 ||
+		algorithm != partialHashAlgorithm(mb<<20) {
+		return "", 0, false
+	}
+	return FileHashPartial, mb << 20, true
+}
+
+// ComputeFileHashAs hashes filePath with a recorded algorithm name
+// ("sha256", "xxhash64" or "sha256-partial-<N>mb") instead of the
+// configured one. A partial algorithm yields a full sha256 for files too
+// small to be hashed partially, as it did when the hash was recorded.
+func ComputeFileHashAs(filePath, algorithm string) (string, error) {
+	mode, chunk, ok := ParseFileHashAlgorithm(algorithm)
+	if !ok {
+		return "", fmt.Errorf("unknown file hash algorithm %q", algorithm)
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	hash, _, err := hashOpenFileAs(f, info.Size(), mode, chunk)
+	return hash, err
+}
+
+// fileHashResult is the outcome of hashing one file in hashFiles.
+type fileHashResult struct {
+	Hash      string
+	Algorithm string
+	Err       error
+}
+
+// hashFiles hashes paths, up to config.FileHashWorkers (default 4) at a
+// time, and returns the results in the order of paths.
+func hashFiles(paths []string) []fileHashResult {
+	results := make([]fileHashResult, len(paths))
+	workers := config.AppConfig.FileHashWorkers
+	if workers <= 0 {
+		workers = 4
+	}
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, workers)
+	for i, path := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			r := &results[i]
+			r.Hash, r.Algorithm, r.Err = ComputeFileHashWithAlgorithm(path)
+		}()
+	}
+	wg.Wait()
+	return results
+}
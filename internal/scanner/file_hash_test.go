@@ -0,0 +1,122 @@
+// file: internal/scanner/file_hash_test.go
+// version: 1.0.0
+// guid: 2a7c4e19-b8d3-4f60-9e15-c3d7a0b6f842
+
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// withHashConfig sets the hashing settings for the test's duration.
+func withHashConfig(t *testing.T, algorithm string, partialMB int) {
+	t.Helper()
+	origAlgo, origMB := config.AppConfig.FileHashAlgorithm, config.AppConfig.FileHashPartialMB
+	config.AppConfig.FileHashAlgorithm, config.AppConfig.FileHashPartialMB = algorithm, partialMB
+	t.Cleanup(func() {
+		config.AppConfig.FileHashAlgorithm, config.AppConfig.FileHashPartialMB = origAlgo, origMB
+	})
+}
+
+func TestComputeFileHashWithAlgorithm_Modes(t *testing.T) {
+	content := []byte("some audio bytes")
+	path := filepath.Join(t.TempDir(), "small.mp3")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	xx := xxhash.Sum64(content)
+	xxBytes := []byte{byte(xx >> 56), byte(xx >> 48), byte(xx >> 40), byte(xx >> 32), byte(xx >> 24), byte(xx >> 16), byte(xx >> 8), byte(xx)}
+
+	tests := []struct {
+		algorithm string
+		wantHash  string
+		wantAlgo  string
+	}{
+		{"", hex.EncodeToString(sum[:]), FileHashSHA256},
+		{FileHashPartial, hex.EncodeToString(sum[:]), FileHashSHA256},
+		{FileHashSHA256, hex.EncodeToString(sum[:]), FileHashSHA256},
+		{FileHashXXHash64, hex.EncodeToString(xxBytes), FileHashXXHash64},
+	}
+	for _, tt := range tests {
+		withHashConfig(t, tt.algorithm, 0)
+		hash, algo, err := ComputeFileHashWithAlgorithm(path)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.algorithm, err)
+		}
+		if hash != tt.wantHash || algo != tt.wantAlgo {
+			t.Errorf("%q: got (%s, %s), want (%s, %s)", tt.algorithm, hash, algo, tt.wantHash, tt.wantAlgo)
+		}
+	}
+}
+
+func TestComputeFileHashWithAlgorithm_PartialLargeFile(t *testing.T) {
+	// A sparse file is cheap to create at any size.
+	path := filepath.Join(t.TempDir(), "large.m4b")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Truncate(hashThreshold + 1); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	withHashConfig(t, FileHashPartial, 0)
+	partial, algo, err := ComputeFileHashWithAlgorithm(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if algo != "sha256-partial-10mb" || algo != LegacyFileHashAlgorithm(hashThreshold+1) {
+		t.Errorf("algorithm = %q, want the legacy sha256-partial-10mb", algo)
+	}
+
+	// Chunks of 60 MB raise the partial threshold to 120 MB, so the
+	// same file is hashed whole.
+	withHashConfig(t, FileHashPartial, 60)
+	full, algo, err := ComputeFileHashWithAlgorithm(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if algo != FileHashSHA256 || full == partial {
+		t.Errorf("got (%s, %s), want a full sha256 different from the partial hash", full, algo)
+	}
+	if got := FileHashAlgorithmFor(130 << 20); got != "sha256-partial-60mb" {
+		t.Errorf("FileHashAlgorithmFor(130MB) = %q, want sha256-partial-60mb", got)
+	}
+}
+
+func TestHashFiles_KeepsOrder(t *testing.T) {
+	withHashConfig(t, FileHashSHA256, 0)
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"01.mp3", "02.mp3", "03.mp3", "04.mp3", "05.mp3"} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, p)
+	}
+	paths = append(paths, filepath.Join(dir, "missing.mp3"))
+
+	results := hashFiles(paths)
+	for i, p := range paths[:5] {
+		want, err := computeFullFileHash(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results[i].Hash != want || results[i].Err != nil {
+			t.Errorf("results[%d] = %+v, want hash %s", i, results[i], want)
+		}
+	}
+	if results[5].Err == nil {
+		t.Error("expected an error for the missing file")
+	}
+}
@@ -0,0 +1,95 @@
+// file: internal/scanner/hash_blocklist.go
+// version: 1.0.0
+// guid: 8dc32141-6c16-4796-90e3-cea9ca0f33bb
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// blockedAlgorithms caches the distinct algorithms do-not-import entries
+// were recorded with, so IsFileBlocked lists the blocklist once per scan
+// rather than once per file. ResetBlockedHashAlgorithms clears it.
+var blockedAlgorithms struct {
+	sync.Mutex
+	loaded bool
+	algos  []string
+}
+
+// ResetBlockedHashAlgorithms drops the cached blocklist algorithms. The
+// scan service and the iTunes importer call it before each run, so a run
+// sees blocks added before it started.
+func ResetBlockedHashAlgorithms() {
+	blockedAlgorithms.Lock()
+	defer blockedAlgorithms.Unlock()
+	blockedAlgorithms.loaded = false
+	blockedAlgorithms.algos = nil
+}
+
+func blockedHashAlgorithms(store database.HashBlocklistStore) ([]string, error) {
+	blockedAlgorithms.Lock()
+	defer blockedAlgorithms.Unlock()
+	if blockedAlgorithms.loaded {
+		return blockedAlgorithms.algos, nil
+	}
+	entries, err := store.GetAllBlockedHashes()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var algos []string
+	for _, e := range entries {
+		if !seen[e.Algorithm] {
+			seen[e.Algorithm] = true
+			algos = append(algos, e.Algorithm)
+		}
+	}
+	sort.Strings(algos)
+	blockedAlgorithms.algos, blockedAlgorithms.loaded = algos, true
+	return algos, nil
+}
+
+// IsFileBlocked reports whether the file at path, hashed as hash with
+// algorithm, is on the do-not-import list. Blocks recorded with another
+// algorithm are checked by rehashing the file that way, so changing
+// file_hash_algorithm does not let blocked files back in. Entries without
+// an algorithm predate it being recorded and use LegacyFileHashAlgorithm.
+func IsFileBlocked(store database.HashBlocklistStore, path, hash, algorithm string) (bool, error) {
+	if blocked, err := store.IsHashBlocked(hash); err != nil || blocked {
+		return blocked, err
+	}
+	algos, err := blockedHashAlgorithms(store)
+	if err != nil || len(algos) == 0 {
+		return false, err
+	}
+	size, err := getFileSize(path)
+	if err != nil {
+		return false, err
+	}
+	tried := map[string]bool{hash: true}
+	for _, algo := range algos {
+		if algo == "" {
+			algo = LegacyFileHashAlgorithm(size)
+		}
+		if algo == algorithm {
+			continue
+		}
+		other, err := ComputeFileHashAs(path, algo)
+		if err != nil {
+			return false, err
+		}
+		if tried[other] {
+			continue
+		}
+		tried[other] = true
+		if blocked, err := store.IsHashBlocked(other); err != nil || blocked {
+			return blocked, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,87 @@
+// file: internal/scanner/hash_blocklist_test.go
+// version: 1.0.0
+// guid: 4cdcf39c-09d3-42fc-bedc-6b673aaaebe5
+
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// blocklistStore is a MockStore backed by an in-memory blocklist.
+func blocklistStore(entries ...database.DoNotImport) *database.MockStore {
+	return &database.MockStore{
+		IsHashBlockedFunc: func(hash string) (bool, error) {
+			for _, e := range entries {
+				if e.Hash == hash {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		GetAllBlockedHashesFunc: func() ([]database.DoNotImport, error) { return entries, nil },
+	}
+}
+
+// TestIsFileBlocked_OtherAlgorithm verifies a file blocked under one
+// algorithm stays blocked after file_hash_algorithm changes.
+func TestIsFileBlocked_OtherAlgorithm(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "book.mp3")
+	if err := os.WriteFile(path, []byte("blocked audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	withHashConfig(t, FileHashSHA256, 0)
+	shaHash, _, err := ComputeFileHashWithAlgorithm(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withHashConfig(t, FileHashXXHash64, 0)
+	xxHash, algo, err := ComputeFileHashWithAlgorithm(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name  string
+		entry database.DoNotImport
+		want  bool
+	}{
+		{"recorded sha256", database.DoNotImport{Hash: shaHash, Algorithm: FileHashSHA256}, true},
+		// Legacy entries used the default partial mode, which hashes a
+		// file this small in full.
+		{"legacy entry", database.DoNotImport{Hash: shaHash}, true},
+		{"other file", database.DoNotImport{Hash: "feed", Algorithm: FileHashSHA256}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ResetBlockedHashAlgorithms()
+			t.Cleanup(ResetBlockedHashAlgorithms)
+			blocked, err := IsFileBlocked(blocklistStore(tc.entry), path, xxHash, algo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if blocked != tc.want {
+				t.Errorf("blocked = %v, want %v", blocked, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseFileHashAlgorithm(t *testing.T) {
+	for name, want := range map[string]bool{
+		"sha256":              true,
+		"xxhash64":            true,
+		"sha256-partial-10mb": true,
+		"sha256-partial-0mb":  false,
+		"sha256-partial-10":   false,
+		"md5":                 false,
+		"":                    false,
+	} {
+		if _, _, ok := ParseFileHashAlgorithm(name); ok != want {
+			t.Errorf("ParseFileHashAlgorithm(%q) ok = %v, want %v", name, ok, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// file: internal/scanner/import_decisions.go
+// version: 1.0.0
+// guid: e600bbf4-eca7-4156-88ea-4dc8ff0a7af9
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"errors"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
+)
+
+// importDecisionStore returns the store's import decision log, or nil when
+// the store (or the store it wraps) does not keep one.
+func importDecisionStore() database.ImportDecisionStore {
+	store := getStore()
+	if store == nil {
+		return nil
+	}
+	if ds, ok := store.(database.ImportDecisionStore); ok {
+		return ds
+	}
+	if uw, ok := store.(interface{ Unwrap() database.Store }); ok {
+		if ds, ok := uw.Unwrap().(database.ImportDecisionStore); ok {
+			return ds
+		}
+	}
+	return nil
+}
+
+// recordImportDecision logs why path was or wasn't imported. Failures are
+// logged at debug level only: the decision log must never fail a scan.
+func recordImportDecision(path, decision, detail, bookID string) {
+	ds := importDecisionStore()
+	if ds == nil {
+		return
+	}
+	err := ds.RecordImportDecision(database.ImportDecision{
+		Path:     path,
+		Decision: decision,
+		Detail:   detail,
+		BookID:   bookID,
+	})
+	if err != nil {
+		defaultLog.Debug("failed to record import decision for %s: %v", path, err)
+	}
+}
+
+// recordImportError logs a failed save, distinguishing quota refusals from
+// other errors.
+func recordImportError(path string, err error) {
+	decision := database.ImportDecisionError
+	if errors.Is(err, quota.ErrExceeded) {
+		decision = database.ImportDecisionQuotaExceeded
+	}
+	recordImportDecision(path, decision, err.Error(), "")
+}
@@ -0,0 +1,104 @@
+// file: internal/scanner/import_decisions_test.go
+// version: 1.1.0
+// guid: e0acbe90-f686-4799-9b27-b4a12e6d9ff6
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportDecisionsRecordedDuringScan(t *testing.T) {
+	store, cleanup := setupPebbleStore(t)
+	defer cleanup()
+	SetStore(store)
+	t.Cleanup(func() { SetStore(nil) })
+
+	prevConfig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = prevConfig })
+	config.AppConfig.SupportedExtensions = []string{".m4b"}
+	config.AppConfig.ExcludePatterns = []string{"sample*"}
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	keep := write("keep.m4b", "keep")
+	blocked := write("blocked.m4b", "blocked")
+	write("notes.txt", "x")
+	write("sample.m4b", "x")
+
+	books, err := ScanDirectoryParallel(dir, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, books, 2)
+
+	hash, err := ComputeFileHash(blocked)
+	require.NoError(t, err)
+	require.NoError(t, store.AddBlockedHash(hash, "test"))
+	for _, path := range []string{keep, blocked} {
+		require.NoError(t, saveBookToDatabase(context.Background(), &Book{FilePath: path, Title: filepath.Base(path), Format: ".m4b"}))
+	}
+
+	got, err := store.GetImportDecisions(dir, 0)
+	require.NoError(t, err)
+	byName := map[string]database.ImportDecision{}
+	for _, d := range got {
+		byName[filepath.Base(d.Path)] = d
+	}
+	assert.Equal(t, database.ImportDecisionImported, byName["keep.m4b"].Decision)
+	assert.NotEmpty(t, byName["keep.m4b"].BookID)
+	assert.Equal(t, database.ImportDecisionBlockedHash, byName["blocked.m4b"].Decision)
+	assert.Equal(t, database.ImportDecisionUnsupportedExtension, byName["notes.txt"].Decision)
+	assert.Equal(t, database.ImportDecisionExcluded, byName["sample.m4b"].Decision)
+	assert.Contains(t, byName["sample.m4b"].Detail, "sample*")
+}
+
+func TestBlockedSignatureSkipsReencodes(t *testing.T) {
+	store, cleanup := setupPebbleStore(t)
+	defer cleanup()
+	SetStore(store)
+	t.Cleanup(func() { SetStore(nil) })
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	existing := write("kept.m4b", "original encode")
+	require.NoError(t, saveBookToDatabase(context.Background(),
+		&Book{FilePath: existing, Title: "Dune", Author: "Frank Herbert", Duration: 21 * 3600, Format: ".m4b"}))
+
+	sig, ok := database.NewBlockedSignature("Dune", "Frank Herbert", 21*3600, "", "test")
+	require.True(t, ok)
+	require.NoError(t, database.AddBlockedSignature(store, sig))
+
+	reencode := write("dune-64k.mp3", "another encode")
+	require.NoError(t, saveBookToDatabase(context.Background(),
+		&Book{FilePath: reencode, Title: "Dune (Unabridged)", Author: "Frank Herbert", Duration: 21*3600 + 90, Format: ".mp3"}))
+	book, err := store.GetBookByFilePath(reencode)
+	require.NoError(t, err)
+	assert.Nil(t, book, "the re-encode is not imported")
+	got, err := store.GetImportDecisions(reencode, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, database.ImportDecisionBlockedSignature, got[0].Decision)
+	assert.Contains(t, got[0].Detail, sig.ID)
+
+	require.NoError(t, saveBookToDatabase(context.Background(),
+		&Book{FilePath: existing, Title: "Dune", Author: "Frank Herbert", Duration: 21 * 3600, Format: ".m4b"}))
+	book, err = store.GetBookByFilePath(existing)
+	require.NoError(t, err)
+	assert.NotNil(t, book, "books already in the library are kept")
+}
@@ -0,0 +1,39 @@
+// file: internal/scanner/import_quota.go
+// version: 1.0.0
+// guid: 15729b84-17a7-4fb2-a11f-4650930efbeb
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
+)
+
+// importQuota is the per-scan quota enforcer. Set via SetImportQuota from
+// ScanService.performScanInternal and consulted by saveBookToDatabase
+// before creating a book; nil outside a scan (or with no quotas), which
+// admits everything.
+var (
+	importQuota   *quota.Enforcer
+	importQuotaMu sync.RWMutex
+)
+
+// SetImportQuota installs the quota enforcer for the current scan. Pass
+// nil to clear it.
+func SetImportQuota(e *quota.Enforcer) {
+	importQuotaMu.Lock()
+	defer importQuotaMu.Unlock()
+	importQuota = e
+}
+
+// admitImportQuota checks a new book against the scan's import quotas.
+// Scanned books have no importing user, so only path quotas apply.
+func admitImportQuota(book *database.Book) error {
+	importQuotaMu.RLock()
+	e := importQuota
+	importQuotaMu.RUnlock()
+	return e.Admit(book, "")
+}
@@ -0,0 +1,109 @@
+// file: internal/scanner/incremental.go
+// version: 1.0.0
+// guid: 4f8b2d6e-1a93-4c7e-b5d0-8e2f6a4c9b13
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// Incremental scans compare each book against the mtime and size recorded
+// for it by the last scan (see SetScanCache) and leave unchanged books
+// alone. A force_update scan installs no cache and so reprocesses
+// everything.
+//
+// A multi-file book has no single file to stat, so its signature is the
+// newest mtime and the total size of its files: editing, adding or
+// removing any of them changes it.
+
+// bookSignature returns the mtime and size recorded in the scan cache for
+// b: the file's own for a single-file book, else the newest mtime and total
+// size of its segment files or, for a directory book, of the audio files
+// directly inside it. ok is false when nothing could be stat'ed.
+func bookSignature(b *Book) (mtime, size int64, ok bool) {
+	if len(b.SegmentFiles) > 1 {
+		return filesSignature(b.SegmentFiles)
+	}
+	fi, err := os.Stat(b.FilePath)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !fi.IsDir() {
+		return fi.ModTime().Unix(), fi.Size(), true
+	}
+	return filesSignature(audioFilesInDir(b.FilePath))
+}
+
+// filesSignature is the newest mtime and total size of paths.
+func filesSignature(paths []string) (mtime, size int64, ok bool) {
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return 0, 0, false
+		}
+		mtime = max(mtime, fi.ModTime().Unix())
+		size += fi.Size()
+		ok = true
+	}
+	return mtime, size, ok
+}
+
+// audioFilesInDir lists the supported, non-excluded audio files directly
+// inside dir, the same set scanDirectory groups into books.
+func audioFilesInDir(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if isExcludedPath(path) {
+			continue
+		}
+		if slices.Contains(config.AppConfig.SupportedExtensions, strings.ToLower(filepath.Ext(path))) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// unchangedDirectoryBooks returns the books the last scan found in dir when
+// none of audioFiles changed since, so scanDirectory can skip reading their
+// tags to group them again. That is either one directory book whose
+// signature still matches, or every file as its own unchanged single-file
+// book. ok is false when dir must be grouped afresh, which includes
+// segment books (their grouping cannot be recovered from the cache) and
+// anything flagged needs_rescan.
+func unchangedDirectoryBooks(dir string, audioFiles []string, cache map[string]database.ScanCacheEntry) ([]Book, bool) {
+	if cache == nil || len(audioFiles) < 2 {
+		return nil, false
+	}
+	if _, found := cache[dir]; found {
+		mtime, size, ok := filesSignature(audioFiles)
+		if !ok || !shouldSkipFile(dir, mtime, size, cache) {
+			return nil, false
+		}
+		return []Book{{FilePath: dir, Format: strings.ToLower(filepath.Ext(audioFiles[0]))}}, true
+	}
+	books := make([]Book, 0, len(audioFiles))
+	for _, f := range audioFiles {
+		fi, err := os.Stat(f)
+		if err != nil || !shouldSkipFile(f, fi.ModTime().Unix(), fi.Size(), cache) {
+			return nil, false
+		}
+		books = append(books, Book{FilePath: f, Format: strings.ToLower(filepath.Ext(f))})
+	}
+	return books, true
+}
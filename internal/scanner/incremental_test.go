@@ -1,12 +1,16 @@
 // file: internal/scanner/incremental_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: e9f0a1b2-c3d4-5e6f-7a8b-9c0d1e2f3a4b
 
 package scanner
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 )
 
@@ -60,3 +64,91 @@ func TestShouldSkipFile_NilCache(t *testing.T) {
 		t.Error("expected process when cache is nil")
 	}
 }
+
+// writeAudio writes size bytes to dir/name with the given mtime.
+func writeAudio(t *testing.T, dir, name string, size int, mtime time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBookSignature(t *testing.T) {
+	orig := config.AppConfig.SupportedExtensions
+	config.AppConfig.SupportedExtensions = []string{".mp3"}
+	t.Cleanup(func() { config.AppConfig.SupportedExtensions = orig })
+
+	dir := t.TempDir()
+	base := time.Unix(1700000000, 0)
+	a := writeAudio(t, dir, "01.mp3", 10, base)
+	b := writeAudio(t, dir, "02.mp3", 20, base.Add(time.Hour))
+	writeAudio(t, dir, "cover.jpg", 99, base.Add(2*time.Hour))
+
+	mtime, size, ok := bookSignature(&Book{FilePath: dir})
+	if !ok || mtime != base.Add(time.Hour).Unix() || size != 30 {
+		t.Errorf("directory signature = %d, %d, %v", mtime, size, ok)
+	}
+	mtime, size, ok = bookSignature(&Book{FilePath: a, SegmentFiles: []string{a, b}})
+	if !ok || mtime != base.Add(time.Hour).Unix() || size != 30 {
+		t.Errorf("segment signature = %d, %d, %v", mtime, size, ok)
+	}
+	mtime, size, ok = bookSignature(&Book{FilePath: a})
+	if !ok || mtime != base.Unix() || size != 10 {
+		t.Errorf("file signature = %d, %d, %v", mtime, size, ok)
+	}
+	if _, _, ok := bookSignature(&Book{FilePath: filepath.Join(dir, "missing.mp3")}); ok {
+		t.Error("signature of a missing file should not be ok")
+	}
+}
+
+func TestUnchangedDirectoryBooks(t *testing.T) {
+	orig := config.AppConfig.SupportedExtensions
+	config.AppConfig.SupportedExtensions = []string{".mp3"}
+	t.Cleanup(func() { config.AppConfig.SupportedExtensions = orig })
+
+	dir := t.TempDir()
+	base := time.Unix(1700000000, 0)
+	a := writeAudio(t, dir, "01.mp3", 10, base)
+	b := writeAudio(t, dir, "02.mp3", 20, base)
+	files := []string{a, b}
+
+	dirCache := map[string]database.ScanCacheEntry{dir: {Mtime: base.Unix(), Size: 30}}
+	books, ok := unchangedDirectoryBooks(dir, files, dirCache)
+	if !ok || len(books) != 1 || books[0].FilePath != dir || books[0].Format != ".mp3" {
+		t.Fatalf("directory book = %+v, %v", books, ok)
+	}
+
+	fileCache := map[string]database.ScanCacheEntry{
+		a: {Mtime: base.Unix(), Size: 10},
+		b: {Mtime: base.Unix(), Size: 20},
+	}
+	if books, ok := unchangedDirectoryBooks(dir, files, fileCache); !ok || len(books) != 2 {
+		t.Fatalf("single-file books = %+v, %v", books, ok)
+	}
+
+	// A segment book is keyed by its first file with the combined signature.
+	segCache := map[string]database.ScanCacheEntry{a: {Mtime: base.Unix(), Size: 30}}
+	if _, ok := unchangedDirectoryBooks(dir, files, segCache); ok {
+		t.Error("segment books must be regrouped")
+	}
+	dirty := map[string]database.ScanCacheEntry{dir: {Mtime: base.Unix(), Size: 30, NeedsRescan: true}}
+	if _, ok := unchangedDirectoryBooks(dir, files, dirty); ok {
+		t.Error("needs_rescan directory must be regrouped")
+	}
+	if _, ok := unchangedDirectoryBooks(dir, files, nil); ok {
+		t.Error("no cache means a full scan")
+	}
+
+	writeAudio(t, dir, "02.mp3", 25, base)
+	if _, ok := unchangedDirectoryBooks(dir, files, dirCache); ok {
+		t.Error("changed file must regroup the directory")
+	}
+	if _, ok := unchangedDirectoryBooks(dir, files, fileCache); ok {
+		t.Error("changed file must regroup the directory")
+	}
+}
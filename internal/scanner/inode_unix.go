@@ -1,5 +1,5 @@
 // file: internal/scanner/inode_unix.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
 
 //go:build !windows
@@ -20,3 +20,13 @@ func getInode(info os.FileInfo) (uint64, bool) {
 	}
 	return uint64(sys.Ino), true
 }
+
+// getDevice returns the ID of the device holding the given file info.
+// Returns 0, false if the underlying syscall type is unavailable.
+func getDevice(info os.FileInfo) (uint64, bool) {
+	sys, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(sys.Dev), true
+}
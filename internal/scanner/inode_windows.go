@@ -1,5 +1,5 @@
 // file: internal/scanner/inode_windows.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b2c3d4e5-f6a7-8901-bcde-f12345678901
 
 //go:build windows
@@ -12,3 +12,8 @@ import "os"
 func getInode(_ os.FileInfo) (uint64, bool) {
 	return 0, false
 }
+
+// getDevice is a no-op on Windows; mountpoints are not detected there.
+func getDevice(_ os.FileInfo) (uint64, bool) {
+	return 0, false
+}
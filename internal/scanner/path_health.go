@@ -0,0 +1,151 @@
+// file: internal/scanner/path_health.go
+// version: 1.1.0
+// guid: d7c1603a-9f1d-4585-b911-6e89ea344bc0
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/activity"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/metrics"
+)
+
+// importPathCheckTimeout bounds the pre-scan probe. A hard-mounted NFS
+// share whose server has gone away can block stat indefinitely.
+var importPathCheckTimeout = 15 * time.Second
+
+// PathHealth is the outcome of CheckImportPathHealth.
+type PathHealth struct {
+	Healthy bool
+	Reason  string
+}
+
+// CheckImportPathHealth probes a folder before it is scanned. It reports
+// unhealthy when the folder is missing, unreachable or unreadable, when
+// requireMount is set and the folder is not a mountpoint, or when the
+// folder is empty while knownBooks books are still recorded under it —
+// what an unmounted share's bare mountpoint directory looks like.
+func CheckImportPathHealth(folderPath string, requireMount bool, knownBooks int) PathHealth {
+	done := make(chan PathHealth, 1)
+	go func() { done <- probeImportPath(folderPath, requireMount, knownBooks) }()
+	select {
+	case h := <-done:
+		return h
+	case <-time.After(importPathCheckTimeout):
+		return PathHealth{Reason: fmt.Sprintf("path did not respond within %s", importPathCheckTimeout)}
+	}
+}
+
+func probeImportPath(folderPath string, requireMount bool, knownBooks int) PathHealth {
+	info, err := os.Stat(folderPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return PathHealth{Reason: "path does not exist"}
+	}
+	if err != nil {
+		return PathHealth{Reason: fmt.Sprintf("path is unreachable: %v", err)}
+	}
+	if !info.IsDir() {
+		return PathHealth{Reason: "path is not a directory"}
+	}
+	if requireMount && !isMountpoint(folderPath, info) {
+		return PathHealth{Reason: "path is not mounted"}
+	}
+	f, err := os.Open(folderPath)
+	if err != nil {
+		return PathHealth{Reason: fmt.Sprintf("path cannot be read: %v", err)}
+	}
+	defer f.Close()
+	entries, err := f.ReadDir(1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return PathHealth{Reason: fmt.Sprintf("path cannot be read: %v", err)}
+	}
+	if len(entries) == 0 && knownBooks > 0 {
+		return PathHealth{Reason: fmt.Sprintf("path is empty but %d books are recorded under it", knownBooks)}
+	}
+	return PathHealth{Healthy: true}
+}
+
+// isMountpoint reports whether folderPath sits on a different device than
+// its parent. Platforms without device IDs always report true so that
+// require_mount never blocks scans there.
+func isMountpoint(folderPath string, info os.FileInfo) bool {
+	dev, ok := getDevice(info)
+	if !ok {
+		return true
+	}
+	parent := filepath.Dir(filepath.Clean(folderPath))
+	if parent == folderPath {
+		return true
+	}
+	parentInfo, err := os.Stat(parent)
+	if err != nil {
+		return false
+	}
+	parentDev, ok := getDevice(parentInfo)
+	return !ok || parentDev != dev
+}
+
+// importPathFor returns the import path record for folderPath, or nil when
+// the folder is not a configured import path (library root, dirty folders).
+func (ss *ScanService) importPathFor(folderPath string) *database.ImportPath {
+	folders, err := ss.db.GetAllImportPaths()
+	if err != nil {
+		return nil
+	}
+	for i := range folders {
+		if folders[i].Path == folderPath {
+			return &folders[i]
+		}
+	}
+	return nil
+}
+
+// recordImportPathHealth stores the check result on the import path and,
+// when the path changes state, writes an activity entry and calls
+// ImportPathHealthFn so the server can alert.
+func (ss *ScanService) recordImportPathHealth(folderPath string, health PathHealth, opID string, log logger.Logger) {
+	if !health.Healthy {
+		metrics.RecordImportPathScanError(folderPath)
+	}
+	ip := ss.importPathFor(folderPath)
+	if ip == nil {
+		return
+	}
+	wasUnhealthy := ip.Health == database.ImportPathUnhealthy
+	now := time.Now()
+	ip.HealthCheckedAt = &now
+	if health.Healthy {
+		ip.Health = database.ImportPathHealthy
+		ip.HealthReason = ""
+	} else {
+		ip.Health = database.ImportPathUnhealthy
+		ip.HealthReason = health.Reason
+	}
+	if err := ss.db.UpdateImportPath(ip.ID, ip); err != nil {
+		log.Warn("Failed to record health for import path %s: %v", folderPath, err)
+	}
+
+	switch {
+	case !health.Healthy && !wasUnhealthy:
+		activity.EmitInfo(ss.activityWriter, opID, "import-path-health", "scan-service",
+			fmt.Sprintf("Import path %s is unhealthy: %s", folderPath, health.Reason), activity.AlwaysShow)
+	case health.Healthy && wasUnhealthy:
+		activity.EmitInfo(ss.activityWriter, opID, "import-path-health", "scan-service",
+			fmt.Sprintf("Import path %s recovered", folderPath), activity.AlwaysShow)
+	default:
+		return
+	}
+	if ss.ImportPathHealthFn != nil {
+		ss.ImportPathHealthFn(*ip)
+	}
+}
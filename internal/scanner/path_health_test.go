@@ -0,0 +1,156 @@
+// file: internal/scanner/path_health_test.go
+// version: 1.1.0
+// guid: 45842463-6dbc-484f-b287-898742a746bc
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckImportPathHealth(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.Mkdir(empty, 0o755))
+	file := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0o644))
+
+	tests := []struct {
+		name         string
+		path         string
+		requireMount bool
+		knownBooks   int
+		healthy      bool
+		reason       string
+	}{
+		{"populated", dir, false, 10, true, ""},
+		{"missing", filepath.Join(dir, "gone"), false, 0, false, "does not exist"},
+		{"not a directory", file, false, 0, false, "not a directory"},
+		{"empty with no books recorded", empty, false, 0, true, ""},
+		{"empty with books recorded", empty, false, 3, false, "3 books are recorded"},
+		{"require mount on plain subdirectory", empty, true, 0, false, "not mounted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.requireMount {
+				if _, ok := getDevice(mustStat(t, empty)); !ok {
+					t.Skip("device IDs unavailable on this platform")
+				}
+			}
+			h := CheckImportPathHealth(tt.path, tt.requireMount, tt.knownBooks)
+			assert.Equal(t, tt.healthy, h.Healthy)
+			assert.Contains(t, h.Reason, tt.reason)
+		})
+	}
+}
+
+func mustStat(t *testing.T, path string) os.FileInfo {
+	t.Helper()
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info
+}
+
+// healthTestStore serves a single import path and records its updates.
+func healthTestStore(ip database.ImportPath, knownBooks int) (*database.MockStore, *database.ImportPath) {
+	current := ip
+	return &database.MockStore{
+		CountBooksByPathPrefixFunc: func(string) (int, error) { return knownBooks, nil },
+		GetAllImportPathsFunc: func() ([]database.ImportPath, error) {
+			return []database.ImportPath{current}, nil
+		},
+		UpdateImportPathFunc: func(_ int, updated *database.ImportPath) error {
+			current = *updated
+			return nil
+		},
+	}, &current
+}
+
+func TestScanService_ScanFolder_SkipsUnhealthyImportPath(t *testing.T) {
+	dir := t.TempDir()
+	store, current := healthTestStore(database.ImportPath{ID: 7, Path: dir, BookCount: 12}, 12)
+	ss := NewScanService(store)
+	var alerts []database.ImportPath
+	ss.ImportPathHealthFn = func(ip database.ImportPath) { alerts = append(alerts, ip) }
+
+	var processed atomic.Int32
+	err := ss.scanFolder(context.Background(), 0, dir, []string{dir}, 0, &processed, &ScanStats{}, "", logger.New("test"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, current.BookCount, "book count must survive a skipped scan")
+	assert.Equal(t, database.ImportPathUnhealthy, current.Health)
+	assert.Contains(t, current.HealthReason, "12 books are recorded")
+	require.Len(t, alerts, 1)
+	assert.Equal(t, 7, alerts[0].ID)
+
+	// A second failing scan does not alert again.
+	require.NoError(t, ss.scanFolder(context.Background(), 0, dir, []string{dir}, 0, &processed, &ScanStats{}, "", logger.New("test")))
+	assert.Len(t, alerts, 1)
+}
+
+func TestScanService_ScanFolder_NoAudioFilesKeepsBookCount(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".mounted"), nil, 0o644))
+	store, current := healthTestStore(database.ImportPath{ID: 3, Path: dir, BookCount: 5}, 5)
+	ss := NewScanService(store)
+
+	var processed atomic.Int32
+	require.NoError(t, ss.scanFolder(context.Background(), 0, dir, []string{dir}, 0, &processed, &ScanStats{}, "", logger.New("test")))
+
+	assert.Equal(t, 5, current.BookCount)
+	assert.Equal(t, database.ImportPathUnhealthy, current.Health)
+	assert.Contains(t, current.HealthReason, "no audiobook files found")
+}
+
+func TestScanService_ScanFolder_CompletesInitialScan(t *testing.T) {
+	dir := t.TempDir()
+	store, current := healthTestStore(database.ImportPath{ID: 5, Path: dir, ScanMode: database.ImportPathScanInitial}, 0)
+	ss := NewScanService(store)
+	t.Cleanup(func() { SetScanProfile("") })
+	var processed atomic.Int32
+	scan := func() {
+		require.NoError(t, ss.scanFolder(context.Background(), 0, dir, []string{dir}, 0, &processed, &ScanStats{}, "", logger.New("test")))
+	}
+
+	// Only a deep scan counts as the initial scan.
+	SetScanProfile(ScanProfileQuick)
+	scan()
+	assert.True(t, current.InitialScanPending())
+
+	SetScanProfile(ScanProfileDeep)
+	require.NoError(t, os.Remove(dir))
+	scan()
+	assert.True(t, current.InitialScanPending(), "a skipped scan leaves the path initial")
+
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	scan()
+	assert.Equal(t, database.ImportPathScanIncremental, current.ScanMode)
+}
+
+func TestScanService_ScanFolder_RecoveryAlerts(t *testing.T) {
+	dir := t.TempDir()
+	store, current := healthTestStore(database.ImportPath{
+		ID: 4, Path: dir, Health: database.ImportPathUnhealthy, HealthReason: "path does not exist",
+	}, 0)
+	ss := NewScanService(store)
+	var alerts []database.ImportPath
+	ss.ImportPathHealthFn = func(ip database.ImportPath) { alerts = append(alerts, ip) }
+
+	var processed atomic.Int32
+	require.NoError(t, ss.scanFolder(context.Background(), 0, dir, []string{dir}, 0, &processed, &ScanStats{}, "", logger.New("test")))
+
+	assert.Equal(t, database.ImportPathHealthy, current.Health)
+	assert.Empty(t, current.HealthReason)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, database.ImportPathHealthy, alerts[0].Health)
+}
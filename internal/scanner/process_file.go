@@ -1,5 +1,5 @@
 // file: internal/scanner/process_file.go
-// version: 1.2.1
+// version: 1.5.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
 
 // Package scanner provides file scanning and processing utilities for the
@@ -8,33 +8,30 @@
 package scanner
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
 
 	"github.com/dhowden/tag"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
 )
 
 const (
 	hashThreshold = 100 * 1024 * 1024 // 100 MB — files above this get a partial hash
-	hashChunkSize = 10 * 1024 * 1024  // 10 MB chunks for the partial hash
-	// MaxScanBufferBytes is the named compile-time bound on per-operation buffer
-	// allocations. It equals hashChunkSize so CodeQL can statically verify that
-	// every make([]byte, MaxScanBufferBytes) call is bounded.
-	MaxScanBufferBytes = hashChunkSize // 10 MB
+	hashChunkSize = 10 * 1024 * 1024  // default 10 MB chunks for the partial hash
 )
 
 // ProcessFile opens filePath exactly once and returns:
 //   - meta: extracted audio metadata (never nil on success)
 //   - mi:   technical media info (nil for directories or when tags cannot be read)
-//   - hash: SHA-256 hex string of the file content (empty for directories)
+//   - hash: hex hash of the file content (empty for directories)
 //
-// The hash algorithm matches ComputeFileHash: full SHA-256 for files ≤100 MB,
-// and first-10MB + last-10MB + file-size for larger files.
+// The hash is the one ComputeFileHash returns under the configured
+// algorithm (see FileHashAlgorithmFor).
 //
 // Existing callers of metadata.ExtractMetadata, mediainfo.Extract, and
 // ComputeFileHash are unaffected — those functions continue to work as before.
@@ -68,23 +65,7 @@ func ProcessFile(filePath string) (*metadata.Metadata, *mediainfo.MediaInfo, str
 	fileSize := fi.Size()
 
 	// Read tags — on failure we still need to hash, so don't abort yet
-	tagMeta, tagErr := tag.ReadFrom(f)
-
-	// Extract metadata
-	var meta metadata.Metadata
-	var mi *mediainfo.MediaInfo
-
-	if tagErr != nil {
-		defaultLog.Warn("scanner.ProcessFile: tag read failed for %s: %v; using filename fallback", filePath, tagErr)
-		meta, err = metadata.ExtractMetadata(filePath, nil) // opens file again — rare error path
-		if err != nil {
-			defaultLog.Warn("scanner.ProcessFile: filename fallback also failed for %s: %v", filePath, err)
-		}
-		// mi stays nil — we have no tag to build from
-	} else {
-		meta = metadata.BuildMetadataFromTag(tagMeta, filePath, nil)
-		mi = mediainfo.BuildFromTag(tagMeta, filePath, fileSize)
-	}
+	meta, mi, _ := extractFromOpenFile(f, filePath, fileSize)
 
 	// Seek back to start for hashing
 	if _, err := f.Seek(0, io.SeekStart); err != nil {
@@ -100,47 +81,120 @@ func ProcessFile(filePath string) (*metadata.Metadata, *mediainfo.MediaInfo, str
 	return &meta, mi, hash, nil
 }
 
-// computeHashFromReader hashes content from an open file reader.
-// For files ≤ hashThreshold it hashes all bytes; for larger files it hashes
-// the first MaxScanBufferBytes bytes + last MaxScanBufferBytes bytes + the
-// file size. This is the same algorithm as ComputeFileHash.
-// MaxScanBufferBytes == hashChunkSize, so CodeQL can statically verify the
-// allocation bound without any runtime cap check.
-func computeHashFromReader(f *os.File, fileSize int64) (string, error) {
-	if fileSize > hashThreshold {
-		h := sha256.New()
-
-		// First chunk
-		first := make([]byte, MaxScanBufferBytes)
-		n, err := f.Read(first)
-		if err != nil && err != io.EOF {
-			return "", err
+// mediaInfoCacheHits / mediaInfoCacheMisses count ProcessFileCached lookups
+// for the current scan. Reset by ResetMediaInfoCacheStats at scan start and
+// read by the scan service for the completion summary.
+var (
+	mediaInfoCacheHits   atomic.Int64
+	mediaInfoCacheMisses atomic.Int64
+)
+
+// ResetMediaInfoCacheStats zeroes the per-scan mediainfo cache counters.
+func ResetMediaInfoCacheStats() {
+	mediaInfoCacheHits.Store(0)
+	mediaInfoCacheMisses.Store(0)
+}
+
+// MediaInfoCacheStats returns the mediainfo cache hits and misses recorded
+// since the last ResetMediaInfoCacheStats.
+func MediaInfoCacheStats() (hits, misses int64) {
+	return mediaInfoCacheHits.Load(), mediaInfoCacheMisses.Load()
+}
+
+// ProcessFileCached is ProcessFile with a content-hash keyed cache in front
+// of tag parsing and mediainfo extraction. The file is still opened once,
+// but hashed first; when store holds an entry for that hash the cached
+// metadata and media info are returned without reading tags. On a miss the
+// tags are parsed as usual and the result written back.
+//
+// Entries whose metadata relied on the filename fallback depend on the path
+// as well as the content, so they only hit for the path they were parsed
+// from. The tag_field_mappings shape the parsed metadata too, so the cache
+// key carries their fingerprint and a rule change misses. Files whose tags cannot be read are never cached. A nil store
+// behaves exactly like ProcessFile.
+func ProcessFileCached(filePath string, store database.Store) (*metadata.Metadata, *mediainfo.MediaInfo, string, error) {
+	if store == nil {
+		return ProcessFile(filePath)
+	}
+	fi, err := os.Stat(filePath)
+	if err != nil || fi.IsDir() {
+		return ProcessFile(filePath)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("ProcessFile: open %q: %w", filePath, err)
+	}
+	defer f.Close()
+
+	fileSize := fi.Size()
+	hash, err := computeHashFromReader(f, fileSize)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("ProcessFile: hash %q: %w", filePath, err)
+	}
+
+	cacheKey := hash
+	if rules := metadata.TagFieldMappingsKey(); rules != "" {
+		cacheKey += ":" + rules
+	}
+	if entry, err := database.GetCachedMediaInfo(store, cacheKey); err != nil {
+		defaultLog.Warn("scanner.ProcessFileCached: cache lookup failed for %s: %v", filePath, err)
+	} else if entry != nil {
+		var meta metadata.Metadata
+		var mi *mediainfo.MediaInfo
+		decodeErr := json.Unmarshal(entry.Metadata, &meta)
+		if decodeErr == nil && len(entry.MediaInfo) > 0 {
+			decodeErr = json.Unmarshal(entry.MediaInfo, &mi)
 		}
-		h.Write(first[:n])
-
-		// Last chunk (seek from end)
-		if fileSize > MaxScanBufferBytes {
-			if _, err := f.Seek(-MaxScanBufferBytes, io.SeekEnd); err != nil {
-				return "", err
-			}
-			last := make([]byte, MaxScanBufferBytes)
-			n, err = f.Read(last)
-			if err != nil && err != io.EOF {
-				return "", err
-			}
-			h.Write(last[:n])
+		// Filename-derived fields only carry over when the path is unchanged.
+		if decodeErr == nil && (entry.Path == filePath || !meta.UsedFilenameFallback) {
+			mediaInfoCacheHits.Add(1)
+			return &meta, mi, hash, nil
 		}
+	}
+	mediaInfoCacheMisses.Add(1)
 
-		// Include size in hash
-		h.Write([]byte(fmt.Sprintf("%d", fileSize)))
-
-		return hex.EncodeToString(h.Sum(nil)), nil
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, hash, fmt.Errorf("ProcessFile: seek to start for tags %q: %w", filePath, err)
+	}
+	meta, mi, tagsOK := extractFromOpenFile(f, filePath, fileSize)
+	if tagsOK {
+		metaBlob, mErr := json.Marshal(meta)
+		var miBlob []byte
+		if mErr == nil && mi != nil {
+			miBlob, mErr = json.Marshal(mi)
+		}
+		if mErr == nil {
+			mErr = database.PutCachedMediaInfo(store, cacheKey, filePath, fileSize, metaBlob, miBlob)
+		}
+		if mErr != nil {
+			defaultLog.Warn("scanner.ProcessFileCached: cache write failed for %s: %v", filePath, mErr)
+		}
 	}
+	return &meta, mi, hash, nil
+}
 
-	// Full hash for smaller files
-	h := sha256.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return "", err
+// extractFromOpenFile reads tags from f (positioned at the start) and builds
+// metadata and media info. tagsOK is false when the tag read failed and the
+// filename fallback was used instead; mi is nil in that case.
+func extractFromOpenFile(f *os.File, filePath string, fileSize int64) (meta metadata.Metadata, mi *mediainfo.MediaInfo, tagsOK bool) {
+	tagMeta, tagErr := tag.ReadFrom(f)
+	if tagErr != nil {
+		defaultLog.Warn("scanner.ProcessFile: tag read failed for %s: %v; using filename fallback", filePath, tagErr)
+		var err error
+		meta, err = metadata.ExtractMetadata(filePath, nil) // opens file again — rare error path
+		if err != nil {
+			defaultLog.Warn("scanner.ProcessFile: filename fallback also failed for %s: %v", filePath, err)
+		}
+		// mi stays nil — we have no tag to build from
+		return meta, nil, false
 	}
-	return hex.EncodeToString(h.Sum(nil)), nil
+	return metadata.BuildMetadataFromTag(tagMeta, filePath, nil), mediainfo.BuildFromTag(tagMeta, filePath, fileSize), true
+}
+
+// computeHashFromReader hashes content from an open file positioned at its
+// start. This is the same hash as ComputeFileHash; see hashOpenFile.
+func computeHashFromReader(f *os.File, fileSize int64) (string, error) {
+	hash, _, err := hashOpenFile(f, fileSize)
+	return hash, err
 }
@@ -1,5 +1,5 @@
 // file: internal/scanner/process_file_test.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: b2c3d4e5-f6a7-8901-bcde-f12345678901
 
 package scanner
@@ -9,6 +9,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
 )
 
 // testdataDir returns the absolute path to the project testdata/fixtures directory.
@@ -145,3 +148,106 @@ func TestProcessFile_HashConsistency(t *testing.T) {
 		t.Fatalf("hash mismatch: ProcessFile=%q, ComputeFileHash=%q", hashFromProcessFile, hashFromComputeFileHash)
 	}
 }
+
+// writeTaggedMP3 writes a tiny MP3 with ID3v2.3 title and artist frames so
+// tag parsing succeeds without the filename fallback.
+func writeTaggedMP3(t *testing.T, path string) {
+	t.Helper()
+	frame := func(id, text string) []byte {
+		body := append([]byte{0x00}, text...) // ISO-8859-1
+		n := len(body)
+		hdr := []byte(id)
+		hdr = append(hdr, byte(n>>24), byte(n>>16), byte(n>>8), byte(n), 0x00, 0x00)
+		return append(hdr, body...)
+	}
+	frames := append(frame("TIT2", "Cached Title"), frame("TPE1", "Cached Author")...)
+	buf := []byte("ID3\x03\x00\x00")
+	buf = append(buf, 0x00, 0x00, 0x00, byte(len(frames)))
+	buf = append(buf, frames...)
+	buf = append(buf, 0xFF, 0xFB, 0x90, 0x00)
+	buf = append(buf, make([]byte, 256)...)
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestProcessFileCached_HitSkipsParse verifies the second lookup for
+// unchanged content is served from the mediainfo cache and matches the
+// first, freshly parsed result.
+func TestProcessFileCached_HitSkipsParse(t *testing.T) {
+	mp3Path := filepath.Join(t.TempDir(), "book.mp3")
+	writeTaggedMP3(t, mp3Path)
+
+	kv := map[string][]byte{}
+	store := &database.MockStore{
+		GetRawFunc: func(key string) ([]byte, error) { return kv[key], nil },
+		SetRawFunc: func(key string, value []byte) error { kv[key] = value; return nil },
+	}
+	ResetMediaInfoCacheStats()
+
+	meta1, mi1, hash1, err := ProcessFileCached(mp3Path, store)
+	if err != nil {
+		t.Fatalf("first ProcessFileCached: %v", err)
+	}
+	if hits, misses := MediaInfoCacheStats(); hits != 0 || misses != 1 {
+		t.Fatalf("after first call: hits=%d misses=%d, want 0/1", hits, misses)
+	}
+	if _, ok := kv[database.MediaInfoCachePrefix+hash1]; !ok {
+		t.Fatal("expected cache entry to be written on miss")
+	}
+
+	meta2, mi2, hash2, err := ProcessFileCached(mp3Path, store)
+	if err != nil {
+		t.Fatalf("second ProcessFileCached: %v", err)
+	}
+	if hits, misses := MediaInfoCacheStats(); hits != 1 || misses != 1 {
+		t.Fatalf("after second call: hits=%d misses=%d, want 1/1", hits, misses)
+	}
+	if meta1.Title != "Cached Title" {
+		t.Fatalf("unexpected title %q", meta1.Title)
+	}
+	if hash1 != hash2 || *meta1 != *meta2 {
+		t.Fatalf("cached result differs: %+v vs %+v", meta1, meta2)
+	}
+	if (mi1 == nil) != (mi2 == nil) || (mi1 != nil && *mi1 != *mi2) {
+		t.Fatalf("cached mediainfo differs: %+v vs %+v", mi1, mi2)
+	}
+}
+
+// TestProcessFileCached_TagMappingChangeMisses verifies an entry parsed
+// under one set of tag_field_mappings is not served once the rules change.
+func TestProcessFileCached_TagMappingChangeMisses(t *testing.T) {
+	mp3Path := filepath.Join(t.TempDir(), "book.mp3")
+	writeTaggedMP3(t, mp3Path)
+
+	kv := map[string][]byte{}
+	store := &database.MockStore{
+		GetRawFunc: func(key string) ([]byte, error) { return kv[key], nil },
+		SetRawFunc: func(key string, value []byte) error { kv[key] = value; return nil },
+	}
+	orig := config.AppConfig.TagFieldMappings
+	t.Cleanup(func() { config.AppConfig.TagFieldMappings = orig })
+	config.AppConfig.TagFieldMappings = nil
+	ResetMediaInfoCacheStats()
+
+	if _, _, _, err := ProcessFileCached(mp3Path, store); err != nil {
+		t.Fatalf("first ProcessFileCached: %v", err)
+	}
+	config.AppConfig.TagFieldMappings = []config.TagFieldMapping{{Field: "series", Tags: []string{"artist"}}}
+	meta, _, _, err := ProcessFileCached(mp3Path, store)
+	if err != nil {
+		t.Fatalf("second ProcessFileCached: %v", err)
+	}
+	if hits, misses := MediaInfoCacheStats(); hits != 0 || misses != 2 {
+		t.Fatalf("hits=%d misses=%d, want 0/2", hits, misses)
+	}
+	if meta.Series != "Cached Author" {
+		t.Fatalf("series = %q, want the mapped artist", meta.Series)
+	}
+	if _, _, _, err := ProcessFileCached(mp3Path, store); err != nil {
+		t.Fatalf("third ProcessFileCached: %v", err)
+	}
+	if hits, _ := MediaInfoCacheStats(); hits != 1 {
+		t.Fatalf("hits=%d, want the entry for the new rules to hit", hits)
+	}
+}
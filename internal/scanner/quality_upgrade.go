@@ -0,0 +1,109 @@
+// file: internal/scanner/quality_upgrade.go
+// version: 1.0.0
+// guid: 6c654f07-1912-43d2-a8ec-a32df8930372
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
+)
+
+// applyMediaInfo copies codec/bitrate info and the derived quality score
+// onto book. A nil mi leaves book untouched.
+func applyMediaInfo(book *database.Book, mi *mediainfo.MediaInfo) {
+	if mi == nil || mi.Codec == "" {
+		return
+	}
+	book.Codec = stringPtr(mi.Codec)
+	if mi.Bitrate > 0 {
+		book.Bitrate = intPtr(mi.Bitrate)
+	}
+	if mi.SampleRate > 0 {
+		book.SampleRate = intPtr(mi.SampleRate)
+	}
+	if mi.Channels > 0 {
+		book.Channels = intPtr(mi.Channels)
+	}
+	if mi.BitDepth > 0 {
+		book.BitDepth = intPtr(mi.BitDepth)
+	}
+	if mi.Quality != "" {
+		book.Quality = stringPtr(mi.Quality)
+	}
+	book.QualityScore = intPtr(mi.QualityScore())
+}
+
+// bookQualityScore returns the stored quality score, falling back to
+// scoring the stored media fields for books saved before scores existed.
+// ok is false when the book has no codec recorded.
+func bookQualityScore(b *database.Book) (score int, ok bool) {
+	if b.QualityScore != nil {
+		return *b.QualityScore, true
+	}
+	if b.Codec == nil || *b.Codec == "" {
+		return 0, false
+	}
+	deref := func(p *int) int {
+		if p == nil {
+			return 0
+		}
+		return *p
+	}
+	return mediainfo.QualityScore(*b.Codec, deref(b.Bitrate), deref(b.SampleRate), deref(b.BitDepth)), true
+}
+
+// flagVersionUpgrade marks the primary of newBook's version group as having
+// an upgrade available when newBook, a freshly imported non-primary
+// version, outscores it. An existing candidate is only replaced by a
+// higher-scoring one. Errors are logged, not returned: a missed flag must
+// not fail the import.
+func flagVersionUpgrade(store database.Store, newBook *database.Book, log logger.Logger) {
+	if store == nil || newBook == nil || newBook.ID == "" || newBook.VersionGroupID == nil || *newBook.VersionGroupID == "" {
+		return
+	}
+	if newBook.IsPrimaryVersion != nil && *newBook.IsPrimaryVersion {
+		return
+	}
+	newScore, ok := bookQualityScore(newBook)
+	if !ok {
+		return
+	}
+	group, err := store.GetBooksByVersionGroup(*newBook.VersionGroupID)
+	if err != nil {
+		log.Warn("upgrade check: failed to load version group %s: %v", *newBook.VersionGroupID, err)
+		return
+	}
+	byID := make(map[string]*database.Book, len(group))
+	var primary *database.Book
+	for i := range group {
+		byID[group[i].ID] = &group[i]
+		if group[i].IsPrimaryVersion != nil && *group[i].IsPrimaryVersion && group[i].ID != newBook.ID {
+			primary = &group[i]
+		}
+	}
+	if primary == nil {
+		return
+	}
+	primaryScore, ok := bookQualityScore(primary)
+	if !ok || newScore <= primaryScore {
+		return
+	}
+	if primary.UpgradeCandidateID != nil {
+		if cur, found := byID[*primary.UpgradeCandidateID]; found {
+			if curScore, ok := bookQualityScore(cur); ok && curScore >= newScore {
+				return
+			}
+		}
+	}
+	flag := true
+	primary.UpgradeAvailable = &flag
+	primary.UpgradeCandidateID = stringPtr(newBook.ID)
+	if _, err := store.UpdateBook(primary.ID, primary); err != nil {
+		log.Warn("upgrade check: failed to flag %s: %v", primary.ID, err)
+		return
+	}
+	log.Info("Upgrade available for %q: %s scores %d vs primary %d", primary.Title, newBook.FilePath, newScore, primaryScore)
+}
@@ -0,0 +1,53 @@
+// file: internal/scanner/quality_upgrade_test.go
+// version: 1.0.0
+// guid: c494a5e0-3921-4303-8fdc-88ce3fd6047a
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagVersionUpgrade(t *testing.T) {
+	boolp := func(v bool) *bool { return &v }
+	group := "vg-1"
+	primary := database.Book{
+		ID: "old", Title: "Dune", VersionGroupID: &group, IsPrimaryVersion: boolp(true),
+		Codec: stringPtr("MP3"), Bitrate: intPtr(64), SampleRate: intPtr(22050),
+	}
+
+	newBook := &database.Book{ID: "new", VersionGroupID: &group, IsPrimaryVersion: boolp(false)}
+	applyMediaInfo(newBook, &mediainfo.MediaInfo{Codec: "AAC", Bitrate: 128, SampleRate: 44100})
+	require.NotNil(t, newBook.QualityScore)
+
+	var updated *database.Book
+	store := &database.MockStore{
+		GetBooksByVersionGroupFunc: func(groupID string) ([]database.Book, error) {
+			return []database.Book{primary, *newBook}, nil
+		},
+		UpdateBookFunc: func(id string, book *database.Book) (*database.Book, error) {
+			updated = book
+			return book, nil
+		},
+	}
+
+	flagVersionUpgrade(store, newBook, logger.New("test"))
+	require.NotNil(t, updated, "primary should be flagged")
+	assert.Equal(t, "old", updated.ID)
+	assert.True(t, *updated.UpgradeAvailable)
+	assert.Equal(t, "new", *updated.UpgradeCandidateID)
+
+	// A lower-scoring import leaves the primary alone.
+	updated = nil
+	worse := &database.Book{ID: "worse", VersionGroupID: &group, IsPrimaryVersion: boolp(false)}
+	applyMediaInfo(worse, &mediainfo.MediaInfo{Codec: "MP3", Bitrate: 32, SampleRate: 22050})
+	flagVersionUpgrade(store, worse, logger.New("test"))
+	assert.Nil(t, updated)
+}
@@ -0,0 +1,78 @@
+// file: internal/scanner/relink.go
+// version: 1.0.0
+// guid: 77eda132-b8cf-403b-9a36-d5235f4ca158
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// relinkedBooks counts records that followed a moved or renamed file during
+// the current scan instead of being re-imported. Reset by ResetRelinkStats at
+// scan start and read by the scan service for the completion summary.
+var relinkedBooks atomic.Int64
+
+// ResetRelinkStats zeroes the per-scan relink counter.
+func ResetRelinkStats() {
+	relinkedBooks.Store(0)
+}
+
+// RelinkStats returns the number of books relinked since the last
+// ResetRelinkStats.
+func RelinkStats() int64 {
+	return relinkedBooks.Load()
+}
+
+// fileMoved reports whether newPath is where the file recorded at oldPath
+// now lives: either oldPath is gone, or both names resolve to the same
+// device and inode (a case-only rename on a case-insensitive filesystem).
+// Two distinct files with the same content — a hardlinked or copied library
+// file next to its import original — are not a move.
+func fileMoved(oldPath, newPath string) bool {
+	if oldPath == "" || oldPath == newPath {
+		return false
+	}
+	oldInfo, err := os.Stat(oldPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true
+	}
+	if err != nil || !strings.EqualFold(oldPath, newPath) {
+		return false
+	}
+	newInfo, err := os.Stat(newPath)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(oldInfo, newInfo)
+}
+
+// relinkBookFiles rewrites the paths of a relinked book's files: the file
+// at oldPath itself, or everything under it when the book is a directory.
+func relinkBookFiles(bookID, oldPath, newPath string) {
+	files, err := getStore().GetBookFiles(bookID)
+	if err != nil {
+		defaultLog.Warn("relink: failed to load files for book %s: %v", bookID, err)
+		return
+	}
+	prefix := oldPath + string(filepath.Separator)
+	for i := range files {
+		switch {
+		case files[i].FilePath == oldPath:
+			files[i].FilePath = newPath
+		case strings.HasPrefix(files[i].FilePath, prefix):
+			files[i].FilePath = filepath.Join(newPath, strings.TrimPrefix(files[i].FilePath, prefix))
+		default:
+			continue
+		}
+		if err := getStore().UpdateBookFile(files[i].ID, &files[i]); err != nil {
+			defaultLog.Warn("relink: failed to update file %s for book %s: %v", files[i].ID, bookID, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// file: internal/scanner/relink_test.go
+// version: 1.0.0
+// guid: 4b82c1eb-6f8b-426d-8f87-5755da4677b8
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMoved(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "Book.m4b")
+	b := filepath.Join(dir, "other.m4b")
+	require.NoError(t, os.WriteFile(a, []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(b, []byte("a"), 0o644))
+	// A hardlink under the lower-cased name stands in for a case-insensitive
+	// filesystem resolving both spellings to one inode.
+	alias := filepath.Join(dir, "book.m4b")
+	require.NoError(t, os.Link(a, alias))
+
+	assert.True(t, fileMoved(filepath.Join(dir, "gone.m4b"), a), "old path missing")
+	assert.False(t, fileMoved(a, a), "same path")
+	assert.False(t, fileMoved("", a), "no previous path")
+	assert.False(t, fileMoved(a, b), "distinct copy")
+	assert.True(t, fileMoved(a, alias), "case-only rename of the same inode")
+}
+
+func setupRelinkTest(t *testing.T) *database.PebbleStore {
+	t.Helper()
+	store, cleanup := setupPebbleStore(t)
+	t.Cleanup(cleanup)
+	prevStore := database.GetGlobalStore()
+	database.SetGlobalStore(store)
+	SetStore(store)
+	prevConfig := config.AppConfig
+	t.Cleanup(func() {
+		database.SetGlobalStore(prevStore)
+		SetStore(nil)
+		config.AppConfig = prevConfig
+	})
+	config.AppConfig.RootDir = t.TempDir()
+	ResetRelinkStats()
+	return store
+}
+
+func TestSaveBookToDatabase_RelinksMovedFile(t *testing.T) {
+	store := setupRelinkTest(t)
+	importDir := t.TempDir()
+	oldPath := filepath.Join(importDir, "Author", "Book.m4b")
+	require.NoError(t, os.MkdirAll(filepath.Dir(oldPath), 0o755))
+	require.NoError(t, os.WriteFile(oldPath, []byte("moved audiobook content"), 0o644))
+
+	require.NoError(t, saveBookToDatabase(context.Background(), &Book{FilePath: oldPath, Title: "Book", Author: "Author", Format: ".m4b"}))
+	original, err := store.GetBookByFilePath(oldPath)
+	require.NoError(t, err)
+	require.NotNil(t, original)
+	require.NoError(t, store.CreateBookFile(&database.BookFile{ID: "bf-1", BookID: original.ID, FilePath: oldPath, Format: "m4b"}))
+
+	newPath := filepath.Join(importDir, "Renamed", "book.m4b")
+	require.NoError(t, os.MkdirAll(filepath.Dir(newPath), 0o755))
+	require.NoError(t, os.Rename(oldPath, newPath))
+
+	require.NoError(t, saveBookToDatabase(context.Background(), &Book{FilePath: newPath, Title: "Book", Author: "Author", Format: ".m4b"}))
+
+	moved, err := store.GetBookByFilePath(newPath)
+	require.NoError(t, err)
+	require.NotNil(t, moved)
+	assert.Equal(t, original.ID, moved.ID, "moved file keeps its record")
+	assert.Nil(t, moved.VersionGroupID)
+	assert.Equal(t, int64(1), RelinkStats())
+
+	files, err := store.GetBookFiles(original.ID)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, newPath, files[0].FilePath)
+}
+
+func TestSaveBookToDatabase_CopyIsNotRelinked(t *testing.T) {
+	store := setupRelinkTest(t)
+	importDir := t.TempDir()
+	first := filepath.Join(importDir, "a", "Book.m4b")
+	second := filepath.Join(importDir, "b", "Book.m4b")
+	for _, p := range []string{first, second} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte("copied audiobook content"), 0o644))
+	}
+
+	require.NoError(t, saveBookToDatabase(context.Background(), &Book{FilePath: first, Title: "Book", Author: "Author", Format: ".m4b"}))
+	require.NoError(t, saveBookToDatabase(context.Background(), &Book{FilePath: second, Title: "Book", Author: "Author", Format: ".m4b"}))
+
+	a, err := store.GetBookByFilePath(first)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	b, err := store.GetBookByFilePath(second)
+	require.NoError(t, err)
+	require.NotNil(t, b)
+	assert.NotEqual(t, a.ID, b.ID)
+	assert.Zero(t, RelinkStats())
+}
@@ -0,0 +1,75 @@
+// file: internal/scanner/retry.go
+// version: 1.0.0
+// guid: 62c1f8e7-0b4d-4a93-8e25-d4a7b3c9f018
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/retryqueue"
+)
+
+// retryQueue returns the store's failed-file retry queue, or nil.
+func retryQueue() database.RetryQueueStore {
+	store := getStore()
+	if store == nil {
+		return nil
+	}
+	return retryqueue.Store(store)
+}
+
+// queueScanRetry puts a file whose metadata could not be read into the
+// retry queue when the error is transient. It reports whether the file
+// was queued; callers then skip it rather than import it with
+// filename-only metadata.
+func queueScanRetry(book *Book, err error) bool {
+	queued, qerr := retryqueue.Fail(retryQueue(), database.RetryKindScan, book.FilePath,
+		database.RetryEntry{Path: book.FilePath, ImportPath: book.SourceImportPath}, err)
+	if qerr != nil {
+		defaultLog.Warn("failed to queue %s for retry: %v", book.FilePath, qerr)
+	}
+	if queued {
+		recordImportDecision(book.FilePath, database.ImportDecisionError, err.Error()+" (queued for retry)", "")
+	}
+	return queued
+}
+
+// clearScanRetry drops path from the retry queue after a successful read.
+func clearScanRetry(path string) {
+	if err := retryqueue.Clear(retryQueue(), database.RetryKindScan, path); err != nil {
+		defaultLog.Debug("failed to clear retry entry for %s: %v", path, err)
+	}
+}
+
+// RetryScan processes a file queued by an earlier scan again. A file that
+// has since disappeared is dropped from the queue; otherwise the outcome
+// is recorded by the processing itself, re-queuing the file with a longer
+// backoff if the error persists.
+func RetryScan(ctx context.Context, e database.RetryEntry, scanLog logger.Logger) error {
+	if _, err := os.Stat(e.Path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			clearScanRetry(e.Path)
+			return nil
+		}
+		book := Book{FilePath: e.Path, SourceImportPath: e.ImportPath}
+		if !queueScanRetry(&book, err) {
+			return err
+		}
+		return nil
+	}
+	book := Book{
+		FilePath:         e.Path,
+		Format:           strings.ToLower(filepath.Ext(e.Path)),
+		SourceImportPath: e.ImportPath,
+	}
+	return ProcessBooksParallel(ctx, []Book{book}, 1, nil, scanLog)
+}
@@ -0,0 +1,250 @@
+// file: internal/scanner/scan_history.go
+// version: 1.0.0
+// guid: 9d0f8492-5128-4f96-8315-106b37510c77
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+)
+
+// Storage backends recorded in ImportPath.ScanBackend. Scan rates are
+// learned per backend so a new NFS import path borrows what earlier NFS
+// scans measured rather than a local disk's speed.
+const (
+	StorageLocal = "local"
+	StorageNFS   = "nfs"
+	StorageSMB   = "smb"
+	StorageFUSE  = "fuse"
+)
+
+// Where a predicted rate came from.
+const (
+	PredictionBasisFolder  = "folder"  // the import path's own earlier scans
+	PredictionBasisBackend = "backend" // other paths on the same storage backend
+	PredictionBasisDefault = "default" // built-in per-file costs, no history
+	PredictionBasisMixed   = "mixed"   // some folders learned, some default
+)
+
+// storageProbeTimeout bounds backend detection; statfs on a hung NFS
+// mount can block indefinitely.
+var storageProbeTimeout = 2 * time.Second
+
+// scanRateSmoothing is the weight of the newest scan when it is folded
+// into an import path's files-per-second rate.
+const scanRateSmoothing = 0.5
+
+// StorageBackend reports the kind of filesystem folderPath lives on, or
+// "" when it cannot be determined in time.
+func StorageBackend(folderPath string) string {
+	done := make(chan string, 1)
+	go func() {
+		b, _ := detectStorageBackend(folderPath)
+		done <- b
+	}()
+	select {
+	case b := <-done:
+		return b
+	case <-time.After(storageProbeTimeout):
+		return ""
+	}
+}
+
+// ScanPrediction forecasts how long a scan will take from the import
+// paths' scan history. It is returned when a scan is queued and drives
+// the "about N left" hint in the scan's progress messages.
+type ScanPrediction struct {
+	EstimatedSeconds int                    `json:"estimated_seconds"`
+	Files            int                    `json:"files"`
+	Basis            string                 `json:"basis"`
+	Folders          []ScanPredictionFolder `json:"folders"`
+}
+
+// ScanPredictionFolder is the forecast for one folder. Files is the last
+// scan's file count, or the path's book count before its first scan.
+type ScanPredictionFolder struct {
+	Path             string  `json:"path"`
+	Profile          string  `json:"profile"`
+	Backend          string  `json:"backend,omitempty"`
+	Files            int     `json:"files"`
+	FilesPerSecond   float64 `json:"files_per_second,omitempty"`
+	EstimatedSeconds int     `json:"estimated_seconds"`
+	Basis            string  `json:"basis"`
+}
+
+// PredictScan forecasts a scan request (the same folder_path /
+// force_update / profile a scan takes) without reading the folders.
+// Returns nil when the request would visit no folders.
+func PredictScan(importPaths []database.ImportPath, folderPath *string, forceUpdate bool, profile string) *ScanPrediction {
+	return predictFolders(scanFolderList(folderPath, forceUpdate, importPaths), profile, importPaths)
+}
+
+type scanRateTotal struct {
+	files   int
+	seconds float64
+}
+
+func predictFolders(folders []string, profile string, importPaths []database.ImportPath) *ScanPrediction {
+	if len(folders) == 0 {
+		return nil
+	}
+	byPath := make(map[string]*database.ImportPath, len(importPaths))
+	backendRates := make(map[string]scanRateTotal)
+	for i := range importPaths {
+		ip := &importPaths[i]
+		byPath[ip.Path] = ip
+		if ip.ScanBackend == "" || ip.LastScanFiles == 0 || ip.LastScanSeconds <= 0 {
+			continue
+		}
+		key := ip.ScanBackend + "/" + ip.LastScanProfile
+		t := backendRates[key]
+		t.files += ip.LastScanFiles
+		t.seconds += ip.LastScanSeconds
+		backendRates[key] = t
+	}
+	workers := config.AppConfig.ConcurrentScans
+	if workers < 1 {
+		workers = 4
+	}
+
+	pred := &ScanPrediction{Folders: make([]ScanPredictionFolder, 0, len(folders))}
+	var total float64
+	learned := 0
+	for _, folder := range folders {
+		f := ScanPredictionFolder{
+			Path:    folder,
+			Profile: folderScanProfile(profile, folder, importPaths),
+			Basis:   PredictionBasisDefault,
+		}
+		ip := byPath[folder]
+		if ip != nil {
+			f.Files = ip.LastScanFiles
+			if f.Files == 0 {
+				f.Files = ip.BookCount
+			}
+			f.Backend = ip.ScanBackend
+		}
+		if f.Backend == "" {
+			f.Backend = StorageBackend(folder)
+		}
+		if ip != nil && ip.ScanFilesPerSecond > 0 && ip.LastScanProfile == f.Profile {
+			f.FilesPerSecond = ip.ScanFilesPerSecond
+			f.Basis = PredictionBasisFolder
+		} else if t, ok := backendRates[f.Backend+"/"+f.Profile]; ok && f.Backend != "" {
+			f.FilesPerSecond = float64(t.files) / t.seconds
+			f.Basis = PredictionBasisBackend
+		}
+
+		var secs float64
+		if f.FilesPerSecond > 0 {
+			secs = float64(f.Files) / f.FilesPerSecond
+			learned++
+		} else {
+			secs = estimateSeconds(f.Profile, folderSize{files: f.Files}, workers)
+		}
+		f.EstimatedSeconds = int(secs + 0.5)
+		total += secs
+		pred.Files += f.Files
+		pred.Folders = append(pred.Folders, f)
+	}
+	pred.EstimatedSeconds = int(total + 0.5)
+	switch learned {
+	case 0:
+		pred.Basis = PredictionBasisDefault
+	case len(folders):
+		pred.Basis = PredictionBasisFolder
+		for _, f := range pred.Folders {
+			if f.Basis == PredictionBasisBackend {
+				pred.Basis = PredictionBasisBackend
+			}
+		}
+	default:
+		pred.Basis = PredictionBasisMixed
+	}
+	return pred
+}
+
+// recordScanHistory folds a finished folder scan into its import path's
+// history. Folders that are not import paths, and scans that found no
+// files, teach nothing and are skipped.
+func (ss *ScanService) recordScanHistory(folderPath, profile string, files int, elapsed time.Duration, log logger.Logger) {
+	if files == 0 || elapsed <= 0 {
+		return
+	}
+	ip := ss.importPathFor(folderPath)
+	if ip == nil {
+		return
+	}
+	if backend := StorageBackend(folderPath); backend != "" {
+		ip.ScanBackend = backend
+	}
+	secs := elapsed.Seconds()
+	rate := float64(files) / secs
+	if ip.ScanFilesPerSecond > 0 && ip.LastScanProfile == profile {
+		rate = scanRateSmoothing*rate + (1-scanRateSmoothing)*ip.ScanFilesPerSecond
+	}
+	ip.LastScanFiles = files
+	ip.LastScanSeconds = secs
+	ip.LastScanProfile = profile
+	ip.ScanFilesPerSecond = rate
+	if err := ss.db.UpdateImportPath(ip.ID, ip); err != nil {
+		log.Warn("Failed to record scan history for import path %s: %v", folderPath, err)
+	}
+}
+
+// scanETA turns a prediction into the remaining-time hint appended to
+// progress messages. Until the prediction runs out it counts down from
+// it; after that it extrapolates from the rate observed so far. A nil
+// *scanETA yields no hint.
+type scanETA struct {
+	start     time.Time
+	predicted time.Duration
+}
+
+// newScanETA returns nil for predictions with no learned rate behind
+// them; the built-in per-file costs are too rough to promise a time.
+func newScanETA(pred *ScanPrediction) *scanETA {
+	if pred == nil || pred.Basis == PredictionBasisDefault {
+		return nil
+	}
+	return &scanETA{start: time.Now(), predicted: time.Duration(pred.EstimatedSeconds) * time.Second}
+}
+
+func (e *scanETA) remaining(done, total int) time.Duration {
+	if e == nil {
+		return 0
+	}
+	elapsed := time.Since(e.start)
+	if elapsed < e.predicted {
+		return e.predicted - elapsed
+	}
+	if done > 0 && total > done {
+		return elapsed / time.Duration(done) * time.Duration(total-done)
+	}
+	return 0
+}
+
+// suffix is the hint appended to a progress message, or "".
+func (e *scanETA) suffix(done, total int) string {
+	r := e.remaining(done, total)
+	if r <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", about %s left", formatETA(r))
+}
+
+// formatETA renders d to the second under a minute and to the minute
+// above ("45s", "12m", "1h5m").
+func formatETA(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+}
@@ -0,0 +1,66 @@
+// file: internal/scanner/scan_history_test.go
+// version: 1.0.0
+// guid: 4c0b7e1d-93a6-4f0e-b2c5-6d8e1f7a2b94
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredictScan_Bases(t *testing.T) {
+	paths := []database.ImportPath{
+		{Path: "/nas/a", Enabled: true, ScanBackend: StorageNFS, LastScanFiles: 200, LastScanSeconds: 100,
+			LastScanProfile: ScanProfileStandard, ScanFilesPerSecond: 2},
+		{Path: "/nas/b", Enabled: true, ScanBackend: StorageNFS, BookCount: 40},
+		{Path: "/off", Enabled: false},
+	}
+
+	one := "/nas/a"
+	pred := PredictScan(paths, &one, false, "")
+	require.NotNil(t, pred)
+	assert.Equal(t, PredictionBasisFolder, pred.Basis)
+	assert.Equal(t, 100, pred.EstimatedSeconds)
+
+	// /nas/b has never been scanned; it borrows the NFS rate from /nas/a.
+	pred = PredictScan(paths, nil, false, "")
+	require.NotNil(t, pred)
+	require.Len(t, pred.Folders, 2)
+	assert.Equal(t, PredictionBasisBackend, pred.Folders[1].Basis)
+	assert.Equal(t, 20, pred.Folders[1].EstimatedSeconds)
+	assert.Equal(t, PredictionBasisBackend, pred.Basis)
+	assert.Equal(t, 240, pred.Files)
+
+	// A deep scan has no history on either path.
+	pred = PredictScan(paths, nil, false, ScanProfileDeep)
+	require.NotNil(t, pred)
+	assert.Equal(t, PredictionBasisDefault, pred.Basis)
+
+	assert.Nil(t, PredictScan(nil, nil, false, ""))
+}
+
+func TestScanETA(t *testing.T) {
+	assert.Nil(t, newScanETA(&ScanPrediction{Basis: PredictionBasisDefault, EstimatedSeconds: 60}))
+	var none *scanETA
+	assert.Equal(t, "", none.suffix(1, 10))
+
+	e := newScanETA(&ScanPrediction{Basis: PredictionBasisFolder, EstimatedSeconds: 600})
+	require.NotNil(t, e)
+	assert.Equal(t, ", about 10m left", e.suffix(0, 10))
+
+	// Past the prediction, the rate observed so far takes over.
+	e.start = time.Now().Add(-20 * time.Minute)
+	assert.Equal(t, ", about 20m left", e.suffix(5, 10))
+}
+
+func TestFormatETA(t *testing.T) {
+	assert.Equal(t, "45s", formatETA(45*time.Second))
+	assert.Equal(t, "12m", formatETA(12*time.Minute+10*time.Second))
+	assert.Equal(t, "1h5m", formatETA(65*time.Minute))
+}
@@ -0,0 +1,292 @@
+// file: internal/scanner/scan_profile.go
+// version: 1.5.0
+// guid: 1fa8f9a0-5bf5-4633-ad9f-ad7919b99613
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+)
+
+// Scan profiles trade thoroughness for speed:
+//   - quick reads only path, size and mtime. New files are imported with
+//     filename-derived metadata and flagged needs_rescan; changed files are
+//     flagged without being re-read. Nothing is hashed.
+//   - standard hashes each file and reads its tags (the historical
+//     behaviour), plus the embedded chapters of M4B/M4A files and any cue
+//     sheet next to a file.
+//   - deep adds an ffprobe pass for exact duration/bitrate and embedded
+//     chapters, and queues fingerprinting of the scanned files.
+const (
+	ScanProfileQuick    = "quick"
+	ScanProfileStandard = "standard"
+	ScanProfileDeep     = "deep"
+)
+
+// ScanProfiles lists the valid profiles, fastest first.
+var ScanProfiles = []string{ScanProfileQuick, ScanProfileStandard, ScanProfileDeep}
+
+// ValidScanProfile reports whether p names a scan profile. The empty
+// string is valid and means "the import path's default".
+func ValidScanProfile(p string) bool {
+	return p == "" || slices.Contains(ScanProfiles, p)
+}
+
+// scanProfile is the profile ProcessBooksParallel applies. Set per folder
+// via SetScanProfile by ScanService.scanFolder; standard outside a scan.
+var (
+	scanProfile   = ScanProfileStandard
+	scanProfileMu sync.RWMutex
+)
+
+// SetScanProfile installs the profile for the folder being scanned. An
+// empty profile restores standard.
+func SetScanProfile(p string) {
+	if p == "" {
+		p = ScanProfileStandard
+	}
+	scanProfileMu.Lock()
+	defer scanProfileMu.Unlock()
+	scanProfile = p
+}
+
+func currentScanProfile() string {
+	scanProfileMu.RLock()
+	defer scanProfileMu.RUnlock()
+	return scanProfile
+}
+
+// folderScanProfile picks the profile for one folder: the request's when
+// set, deep for an import path awaiting its initial scan, otherwise the
+// path's default, otherwise standard.
+func folderScanProfile(requested, folder string, paths []database.ImportPath) string {
+	if requested != "" {
+		return requested
+	}
+	for _, p := range paths {
+		if p.Path != folder {
+			continue
+		}
+		if p.InitialScanPending() {
+			return ScanProfileDeep
+		}
+		if p.ScanProfile != "" {
+			return p.ScanProfile
+		}
+	}
+	return ScanProfileStandard
+}
+
+// initialScanPending reports whether folder is an import path awaiting
+// its initial scan.
+func initialScanPending(folder string, paths []database.ImportPath) bool {
+	for _, p := range paths {
+		if p.Path == folder {
+			return p.InitialScanPending()
+		}
+	}
+	return false
+}
+
+// forgetScanCacheUnder drops the scan cache entries under folder, so an
+// initial scan reads every file, including those an earlier import path
+// at the same location left in the cache.
+func forgetScanCacheUnder(cache map[string]database.ScanCacheEntry, folder string) {
+	prefix := strings.TrimSuffix(folder, string(filepath.Separator)) + string(filepath.Separator)
+	for path := range cache {
+		if strings.HasPrefix(path, prefix) {
+			delete(cache, path)
+		}
+	}
+}
+
+// completeInitialScan switches folder's import path to incremental once
+// a deep scan of it has finished.
+func (ss *ScanService) completeInitialScan(folderPath string, log logger.Logger) {
+	ip := ss.importPathFor(folderPath)
+	if ip == nil || !ip.InitialScanPending() {
+		return
+	}
+	ip.ScanMode = database.ImportPathScanIncremental
+	if err := ss.db.UpdateImportPath(ip.ID, ip); err != nil {
+		log.Warn("Failed to record initial scan of import path %s: %v", folderPath, err)
+		return
+	}
+	log.Info("Initial scan of %s complete; later scans are incremental", folderPath)
+}
+
+// processQuick handles one book under the quick profile. Only the path,
+// size and mtime are consulted: a book already in the library is flagged
+// needs_rescan (the scan cache skip has already ruled out an unchanged
+// file), and a new one is saved with path-derived metadata, unhashed, and
+// flagged so the next standard scan reads its tags.
+func processQuick(ctx context.Context, book *Book, scanLog logger.Logger) error {
+	store := getStore()
+	if store == nil {
+		return fmt.Errorf("database store not initialized")
+	}
+	if existing, err := store.GetBookByFilePath(book.FilePath); err == nil && existing != nil {
+		if err := store.MarkNeedsRescan(existing.ID); err != nil {
+			return err
+		}
+		recordImportDecision(book.FilePath, database.ImportDecisionImported,
+			"changed since the last scan; flagged for rescan (quick scan)", existing.ID)
+		return nil
+	}
+
+	extractInfoFromPath(book)
+	if book.Position <= 0 {
+		book.Position = metadata.DetectVolumeNumber(book.Title)
+	}
+	info, statErr := os.Stat(book.FilePath)
+	adoptFromPath(book, statErr == nil && info.IsDir())
+	book.SkipHash = true
+	if err := saveBook(ctx, book); err != nil {
+		return err
+	}
+	if dbBook, err := store.GetBookByFilePath(book.FilePath); err == nil && dbBook != nil {
+		if err := store.MarkNeedsRescan(dbBook.ID); err != nil {
+			scanLog.Warn("quick scan: failed to flag %s for rescan: %v", book.FilePath, err)
+		}
+	}
+	return nil
+}
+
+// Rough per-worker costs behind ScanEstimate. They are deliberately
+// conservative: a warm page cache or an SSD makes real scans faster.
+const (
+	quickSecondsPerFile    = 0.002
+	standardSecondsPerFile = 0.015
+	deepSecondsPerFile     = 0.4       // ffprobe start-up and parse
+	hashBytesPerSecond     = 150 << 20 // sequential read while hashing
+	fingerprintBytesPerSec = 20 << 20  // fpcalc decode throughput
+)
+
+// ScanEstimate is the projected cost of scanning with one profile.
+type ScanEstimate struct {
+	Profile          string `json:"profile"`
+	Files            int    `json:"files"`
+	Bytes            int64  `json:"bytes"`
+	EstimatedSeconds int    `json:"estimated_seconds"`
+}
+
+// ScanPlanFolder is one folder a scan would visit.
+type ScanPlanFolder struct {
+	Path             string `json:"path"`
+	Profile          string `json:"profile"`
+	Files            int    `json:"files"`
+	Bytes            int64  `json:"bytes"`
+	EstimatedSeconds int    `json:"estimated_seconds"`
+}
+
+// ScanPlan is what a scan request would do, with estimates for running
+// it under each profile.
+type ScanPlan struct {
+	Folders []ScanPlanFolder `json:"folders"`
+	// EstimatedSeconds is for the plan as requested (per-folder profiles).
+	EstimatedSeconds int `json:"estimated_seconds"`
+	// Estimates covers every profile applied to all folders.
+	Estimates []ScanEstimate `json:"estimates"`
+	// Predicted is the forecast from earlier scans' measured rates.
+	Predicted *ScanPrediction `json:"predicted,omitempty"`
+}
+
+// folderSize is the walk result the estimates are computed from.
+type folderSize struct {
+	files     int
+	bytes     int64
+	hashBytes int64
+}
+
+// EstimateScan walks the folders a scan with these parameters would visit
+// and projects how long each profile would take. Nothing is read beyond
+// directory entries.
+func (ss *ScanService) EstimateScan(folderPath *string, forceUpdate bool, profile string) (*ScanPlan, error) {
+	folders, err := ss.determineFoldersToScan(folderPath, forceUpdate, logger.New("scan-estimate"))
+	if err != nil {
+		return nil, err
+	}
+	paths, _ := ss.db.GetAllImportPaths()
+	workers := config.AppConfig.ConcurrentScans
+	if workers < 1 {
+		workers = 4
+	}
+
+	plan := &ScanPlan{Folders: []ScanPlanFolder{}}
+	var total folderSize
+	var planned float64
+	for _, folder := range folders {
+		size := measureFolder(folder)
+		p := folderScanProfile(profile, folder, paths)
+		secs := estimateSeconds(p, size, workers)
+		planned += secs
+		total.files += size.files
+		total.bytes += size.bytes
+		total.hashBytes += size.hashBytes
+		plan.Folders = append(plan.Folders, ScanPlanFolder{
+			Path: folder, Profile: p, Files: size.files, Bytes: size.bytes, EstimatedSeconds: int(secs + 0.5),
+		})
+	}
+	plan.EstimatedSeconds = int(planned + 0.5)
+	plan.Predicted = predictFolders(folders, profile, paths)
+	for _, p := range ScanProfiles {
+		plan.Estimates = append(plan.Estimates, ScanEstimate{
+			Profile: p, Files: total.files, Bytes: total.bytes,
+			EstimatedSeconds: int(estimateSeconds(p, total, workers) + 0.5),
+		})
+	}
+	return plan, nil
+}
+
+func measureFolder(folder string) folderSize {
+	var s folderSize
+	if _, err := os.Stat(folder); err != nil {
+		return s
+	}
+	_ = filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if !slices.Contains(config.AppConfig.SupportedExtensions, ext) || isExcludedPath(path) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		s.files++
+		s.bytes += info.Size()
+		s.hashBytes += hashedBytes(info.Size())
+		return nil
+	})
+	return s
+}
+
+func estimateSeconds(profile string, s folderSize, workers int) float64 {
+	files := float64(s.files)
+	var secs float64
+	switch profile {
+	case ScanProfileQuick:
+		secs = files * quickSecondsPerFile
+	case ScanProfileDeep:
+		secs = files*(standardSecondsPerFile+deepSecondsPerFile) +
+			float64(s.hashBytes)/hashBytesPerSecond + float64(s.bytes)/fingerprintBytesPerSec
+	default:
+		secs = files*standardSecondsPerFile + float64(s.hashBytes)/hashBytesPerSecond
+	}
+	return secs / float64(workers)
+}
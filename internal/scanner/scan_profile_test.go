@@ -0,0 +1,122 @@
+// file: internal/scanner/scan_profile_test.go
+// version: 1.1.0
+// guid: 01103d70-8d10-48c2-8832-1e25c93b8667
+// last-edited: 2026-10-17
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolderScanProfile(t *testing.T) {
+	paths := []database.ImportPath{
+		{Path: "/in/fast", ScanProfile: ScanProfileQuick},
+		{Path: "/in/plain"},
+		{Path: "/in/new", ScanProfile: ScanProfileQuick, ScanMode: database.ImportPathScanInitial},
+	}
+	assert.Equal(t, ScanProfileQuick, folderScanProfile("", "/in/fast", paths))
+	assert.Equal(t, ScanProfileDeep, folderScanProfile("", "/in/new", paths), "a new path is deep-scanned first")
+	assert.Equal(t, ScanProfileQuick, folderScanProfile(ScanProfileQuick, "/in/new", paths))
+	assert.Equal(t, ScanProfileStandard, folderScanProfile("", "/in/plain", paths))
+	assert.Equal(t, ScanProfileStandard, folderScanProfile("", "/elsewhere", paths))
+	assert.Equal(t, ScanProfileDeep, folderScanProfile(ScanProfileDeep, "/in/fast", paths))
+
+	assert.True(t, ValidScanProfile(""))
+	assert.False(t, ValidScanProfile("thorough"))
+}
+
+func TestForgetScanCacheUnder(t *testing.T) {
+	cache := map[string]database.ScanCacheEntry{
+		"/in/new/a.m4b":   {},
+		"/in/new/b/c.mp3": {},
+		"/in/newer/d.m4b": {},
+		"/in/old/e.m4b":   {},
+	}
+	forgetScanCacheUnder(cache, "/in/new/")
+	assert.Len(t, cache, 2)
+	assert.Contains(t, cache, "/in/newer/d.m4b")
+	forgetScanCacheUnder(nil, "/in/old")
+}
+
+func TestEstimateSeconds_OrderedByProfile(t *testing.T) {
+	size := folderSize{files: 1000, bytes: 200 << 30, hashBytes: 20 << 30}
+	quick := estimateSeconds(ScanProfileQuick, size, 4)
+	standard := estimateSeconds(ScanProfileStandard, size, 4)
+	deep := estimateSeconds(ScanProfileDeep, size, 4)
+	assert.Less(t, quick, standard)
+	assert.Less(t, standard, deep)
+	assert.InDelta(t, quick*2, estimateSeconds(ScanProfileQuick, size, 2), 1e-9)
+}
+
+func TestMeasureFolder(t *testing.T) {
+	prevConfig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = prevConfig })
+	config.AppConfig.SupportedExtensions = []string{".m4b", ".mp3"}
+	config.AppConfig.ExcludePatterns = nil
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.m4b"), make([]byte, 300), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.mp3"), make([]byte, 200), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cover.jpg"), make([]byte, 50), 0o644))
+
+	s := measureFolder(dir)
+	assert.Equal(t, 2, s.files)
+	assert.Equal(t, int64(500), s.bytes)
+	assert.Equal(t, int64(500), s.hashBytes)
+}
+
+func TestProcessBooksParallel_QuickProfile(t *testing.T) {
+	store, cleanup := setupPebbleStore(t)
+	defer cleanup()
+	SetStore(store)
+	t.Cleanup(func() { SetStore(nil) })
+	prevConfig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = prevConfig })
+	config.AppConfig.RootDir = ""
+	config.AppConfig.MinBookSizeBytes = 0
+	config.AppConfig.EnableAIParsing = false
+
+	SetScanProfile(ScanProfileQuick)
+	t.Cleanup(func() { SetScanProfile("") })
+
+	dir := filepath.Join(t.TempDir(), "Jane Author")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	path := filepath.Join(dir, "Quick Book.m4b")
+	require.NoError(t, os.WriteFile(path, []byte("not really audio"), 0o644))
+
+	books := []Book{{FilePath: path, Format: ".m4b"}}
+	require.NoError(t, ProcessBooksParallel(context.Background(), books, 1, nil, nil))
+
+	saved, err := store.GetBookByFilePath(path)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Nil(t, saved.FileHash, "quick scans do not hash")
+	require.NotNil(t, saved.NeedsRescan)
+	assert.True(t, *saved.NeedsRescan, "new books are left for a standard scan to fill in")
+
+	// A changed file already in the library is flagged, not re-read.
+	_, err = store.UpdateBook(saved.ID, func() *database.Book {
+		b := *saved
+		f := false
+		b.NeedsRescan = &f
+		b.Title = "Edited Title"
+		return &b
+	}())
+	require.NoError(t, err)
+	require.NoError(t, ProcessBooksParallel(context.Background(), []Book{{FilePath: path, Format: ".m4b"}}, 1, nil, nil))
+	again, err := store.GetBookByFilePath(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited Title", again.Title)
+	require.NotNil(t, again.NeedsRescan)
+	assert.True(t, *again.NeedsRescan)
+}
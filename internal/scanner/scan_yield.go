@@ -0,0 +1,38 @@
+// file: internal/scanner/scan_yield.go
+// version: 1.0.0
+// guid: 2615cce5-23e5-45f0-8e55-a63c6b1ae473
+// last-edited: 2026-10-16
+
+package scanner
+
+import (
+	"context"
+	"sync"
+)
+
+// scanYield lets a background scan step aside for user work. Set via
+// SetScanYield from ScanService.performScanInternal when the request
+// carries a Yield func; ProcessBooksParallel workers call it before each
+// book, and it blocks for as long as the scan should stay paused. Nil
+// outside a scan and for user-started scans.
+var (
+	scanYield   func(ctx context.Context)
+	scanYieldMu sync.RWMutex
+)
+
+// SetScanYield installs the pause hook for the current scan. Pass nil to
+// clear it.
+func SetScanYield(fn func(ctx context.Context)) {
+	scanYieldMu.Lock()
+	defer scanYieldMu.Unlock()
+	scanYield = fn
+}
+
+func waitScanYield(ctx context.Context) {
+	scanYieldMu.RLock()
+	fn := scanYield
+	scanYieldMu.RUnlock()
+	if fn != nil {
+		fn(ctx)
+	}
+}
@@ -1,7 +1,7 @@
 // file: internal/scanner/scanner.go
-// version: 1.42.0
+// version: 1.62.0
 // guid: 3c4d5e6f-7a8b-9c0d-1e2f-3a4b5c6d7e8f
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package scanner
 
@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -17,9 +18,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dhowden/tag"
@@ -28,7 +31,9 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/logger"
 	"github.com/falkcorp/audiobook-organizer/internal/matcher"
+	"github.com/falkcorp/audiobook-organizer/internal/mediainfo"
 	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
 	"github.com/falkcorp/audiobook-organizer/internal/util"
 	"github.com/oklog/ulid/v2"
 )
@@ -299,18 +304,24 @@ func shouldSkipFile(filePath string, mtime int64, size int64, cache map[string]d
 
 // isExcludedPath checks whether a path matches any configured exclude pattern.
 func isExcludedPath(path string) bool {
+	return excludedByPattern(path) != ""
+}
+
+// excludedByPattern returns the first configured exclude pattern matching
+// path, or "" when none does.
+func excludedByPattern(path string) string {
 	for _, pattern := range config.AppConfig.ExcludePatterns {
 		if pattern == "" {
 			continue
 		}
 		if matched, err := filepath.Match(pattern, filepath.Base(path)); err == nil && matched {
-			return true
+			return pattern
 		}
 		if matched, err := filepath.Match(pattern, path); err == nil && matched {
-			return true
+			return pattern
 		}
 	}
-	return false
+	return ""
 }
 
 // Book represents an audiobook file
@@ -334,6 +345,19 @@ type Book struct {
 	FileHash         string // Pre-computed hash from ProcessFile (avoids double-read)
 	LibraryState     string // If set, overrides the default "imported" state in saveBookToDatabase
 	SourceImportPath string // Top-level import path this file was discovered in; set by scan_service
+	// MediaInfo is the codec/bitrate info read alongside the tags; nil for
+	// directory-assembled books.
+	MediaInfo *mediainfo.MediaInfo
+	// SkipHash leaves the file unhashed when FileHash is empty (quick
+	// scans); hash-based dedup and blocklist checks are skipped with it.
+	SkipHash bool
+	// FileHashAlgorithm names how FileHash was computed; when empty it is
+	// taken to be the configured algorithm for the file's size.
+	FileHashAlgorithm string
+	// Chapters are the embedded chapters read by a deep scan (or by a
+	// standard scan of an M4B/M4A), else those of a cue sheet next to the
+	// file; nil when the file was not probed, empty when it has none.
+	Chapters []database.BookChapter
 }
 
 // ScanDirectory scans the given directory for audiobook files.
@@ -351,6 +375,22 @@ func ScanDirectoryParallel(rootDir string, workers int, scanLog logger.Logger) (
 	if activeScanner != nil {
 		return activeScanner.ScanDirectoryParallel(rootDir, workers, scanLog)
 	}
+	return scanDirectory(context.Background(), rootDir, workers, scanLog)
+}
+
+// ScanDirectoryContext is ScanDirectoryParallel stopping the walk, and
+// returning ctx's error, once ctx is done.
+func ScanDirectoryContext(ctx context.Context, rootDir string, workers int, scanLog logger.Logger) ([]Book, error) {
+	if activeScanner != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return activeScanner.ScanDirectoryParallel(rootDir, workers, scanLog)
+	}
+	return scanDirectory(ctx, rootDir, workers, scanLog)
+}
+
+func scanDirectory(ctx context.Context, rootDir string, workers int, scanLog logger.Logger) ([]Book, error) {
 	if scanLog == nil {
 		scanLog = logger.New("scanner")
 	}
@@ -390,6 +430,9 @@ func ScanDirectoryParallel(rootDir string, workers int, scanLog logger.Logger) (
 	}
 
 	err := filepath.WalkDir(rootDir, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
 			if path == rootDir {
 				return err
@@ -405,7 +448,7 @@ func ScanDirectoryParallel(rootDir string, workers int, scanLog logger.Logger) (
 			return nil
 		}
 		if d.IsDir() {
-			if d.Name() == ".failed" {
+			if d.Name() == ".failed" || d.Name() == ".trash" {
 				return filepath.SkipDir
 			}
 			if !registerDirectory(path, info) {
@@ -418,6 +461,13 @@ func ScanDirectoryParallel(rootDir string, workers int, scanLog logger.Logger) (
 		return nil, err
 	}
 
+	// In an incremental scan, directories whose files are unchanged reuse
+	// the last scan's grouping instead of reading every file's tags.
+	globalScanCacheMu.RLock()
+	cache := globalScanCache
+	globalScanCacheMu.RUnlock()
+	var reused atomic.Int32
+
 	// Parallel scan of directories
 	var mu sync.Mutex
 	var books []Book
@@ -430,6 +480,9 @@ func ScanDirectoryParallel(rootDir string, workers int, scanLog logger.Logger) (
 			defer wg.Done()
 			semaphore <- struct{}{}        // Acquire
 			defer func() { <-semaphore }() // Release
+			if ctx.Err() != nil {
+				return
+			}
 
 			// Read directory entries
 			entries, err := os.ReadDir(scanDir)
@@ -444,20 +497,26 @@ func ScanDirectoryParallel(rootDir string, workers int, scanLog logger.Logger) (
 					continue
 				}
 				path := filepath.Join(scanDir, entry.Name())
-				if isExcludedPath(path) {
+				if pattern := excludedByPattern(path); pattern != "" {
+					recordImportDecision(path, database.ImportDecisionExcluded, "matches exclude pattern "+pattern, "")
 					continue
 				}
 				ext := strings.ToLower(filepath.Ext(path))
-				for _, supportedExt := range config.AppConfig.SupportedExtensions {
-					if ext == supportedExt {
-						audioFiles = append(audioFiles, path)
-						break
-					}
+				if slices.Contains(config.AppConfig.SupportedExtensions, ext) {
+					audioFiles = append(audioFiles, path)
+				} else {
+					recordImportDecision(path, database.ImportDecisionUnsupportedExtension,
+						fmt.Sprintf("extension %q is not in supported_extensions", ext), "")
 				}
 			}
 
 			// Group files into logical books using album tags
-			localBooks := groupFilesIntoBooks(audioFiles)
+			localBooks, unchanged := unchangedDirectoryBooks(scanDir, audioFiles, cache)
+			if unchanged {
+				reused.Add(1)
+			} else {
+				localBooks = groupFilesIntoBooks(audioFiles)
+			}
 
 			// Merge results
 			if len(localBooks) > 0 {
@@ -469,6 +528,12 @@ func ScanDirectoryParallel(rootDir string, workers int, scanLog logger.Logger) (
 	}
 
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if n := reused.Load(); n > 0 {
+		scanLog.Info("Incremental scan: reused the grouping of %d unchanged directories", n)
+	}
 	return books, nil
 }
 
@@ -566,6 +631,10 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 			if ctx.Err() != nil {
 				return
 			}
+			waitScanYield(ctx)
+			if ctx.Err() != nil {
+				return
+			}
 
 			// Incremental skip check: if mtime+size unchanged and no rescan flag, skip.
 			{
@@ -573,8 +642,10 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 				cache := globalScanCache
 				globalScanCacheMu.RUnlock()
 				if cache != nil {
-					if fi, statErr := os.Stat(books[idx].FilePath); statErr == nil {
-						if shouldSkipFile(books[idx].FilePath, fi.ModTime().Unix(), fi.Size(), cache) {
+					if mtime, size, ok := bookSignature(&books[idx]); ok {
+						if shouldSkipFile(books[idx].FilePath, mtime, size, cache) {
+							recordImportDecision(books[idx].FilePath, database.ImportDecisionUnchanged,
+								"unchanged since the last scan", "")
 							return // progress deferred func will still fire
 						}
 					}
@@ -587,6 +658,8 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 			fallbackUsed := false
 			filePath := books[idx].FilePath
 
+			profile := currentScanProfile()
+
 			// Suspicious-file guard: single files below MinBookSizeBytes skip heavy processing.
 			if threshold := config.AppConfig.MinBookSizeBytes; threshold > 0 {
 				if fi, statErr := os.Stat(filePath); statErr == nil && !fi.IsDir() && fi.Size() < threshold {
@@ -596,6 +669,8 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 						scanLog.Warn("failed to save suspicious book %s: %v", filePath, saveErr)
 					}
 					scanLog.Warn("suspicious file (%d bytes, threshold %d): %s", fi.Size(), threshold, filePath)
+					recordImportDecision(filePath, database.ImportDecisionSuspicious,
+						fmt.Sprintf("%d bytes is below min_book_size_bytes (%d); saved as suspicious", fi.Size(), threshold), "")
 					func() {
 						defer func() { recover() }()
 						if store := getStore(); store != nil {
@@ -608,6 +683,14 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 				}
 			}
 
+			if profile == ScanProfileQuick {
+				if err := processQuick(ctx, &books[idx], scanLog); err != nil {
+					recordImportError(books[idx].FilePath, err)
+					errChan <- fmt.Errorf("failed to save book %s: %w", books[idx].FilePath, err)
+				}
+				return
+			}
+
 			// Handle directory-based books (multi-file books grouped by album tag)
 			if info, statErr := os.Stat(filePath); statErr == nil && info.IsDir() {
 				dirPath := filePath
@@ -641,8 +724,9 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 					}
 				}
 				// Compute hash from first file for dedup
-				if h, herr := ComputeFileHash(firstFile); herr == nil {
+				if h, algo, herr := ComputeFileHashWithAlgorithm(firstFile); herr == nil {
 					books[idx].FileHash = h
+					books[idx].FileHashAlgorithm = algo
 				}
 				// Fallback to filepath extraction if title/author still unknown
 				if books[idx].Title == "" || books[idx].Author == "" {
@@ -658,8 +742,10 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 				if books[idx].Position == 0 && position > 0 {
 					books[idx].Position = position
 				}
+				adoptFromPath(&books[idx], true)
 				// Save the book and create segments
 				if err := saveBook(ctx, &books[idx]); err != nil {
+					recordImportError(books[idx].FilePath, err)
 					errChan <- fmt.Errorf("failed to save book %s: %w", books[idx].FilePath, err)
 				} else {
 					createBookFilesForBook(dirPath, nil, scanLog)
@@ -704,15 +790,23 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 				}
 			} else {
 				// Single-pass extraction: open file once for tags + mediainfo + hash.
-				meta, mi, fileHash, pfErr := ProcessFile(filePath)
+				// Unchanged content is served from the hash-keyed mediainfo cache.
+				meta, mi, fileHash, pfErr := ProcessFileCached(filePath, getStore())
 				if pfErr != nil {
 					scanLog.Warn("ProcessFile failed for %s: %v", filePath, pfErr)
+					// A locked file or a dropped share is retried later
+					// instead of being imported with filename-only metadata.
+					if queueScanRetry(&books[idx], pfErr) {
+						scanLog.Info("Queued %s for retry", filePath)
+						return
+					}
 					fallbackUsed = true
 					if gs := getStore(); gs != nil {
 						sum := sha256.Sum256([]byte(filePath))
 						_, _ = gs.IncrScanFailCount(fmt.Sprintf("%x", sum[:8]))
 					}
 				} else {
+					clearScanRetry(filePath)
 					// Reset fail counter on successful parse so transient failures
 					// don't accumulate toward the auto-quarantine threshold.
 					// Use recover guard: GetGlobalStore may return a non-nil interface
@@ -771,8 +865,26 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 						if mi.Duration > 0 {
 							books[idx].Duration = mi.Duration
 						}
+						books[idx].MediaInfo = mi
 					}
 					books[idx].FileHash = fileHash
+					if profile == ScanProfileDeep {
+						if probe := probeFile(ctx, filePath); probe != nil {
+							probe.apply(&books[idx])
+							books[idx].Chapters = probe.Chapters
+						}
+					} else if hasEmbeddedChapters(filePath) {
+						if chapters, _, ok := ProbeChapters(ctx, filePath); ok {
+							books[idx].Chapters = chapters
+						}
+					}
+					// A cue sheet chapters files that carry no markers of
+					// their own (typically one long MP3 or FLAC).
+					if len(books[idx].Chapters) == 0 {
+						if chapters := readCueChapters(filePath, float64(books[idx].Duration)); chapters != nil {
+							books[idx].Chapters = chapters
+						}
+					}
 				}
 			}
 
@@ -813,6 +925,7 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 			if books[idx].Position == 0 && position > 0 {
 				books[idx].Position = position
 			}
+			adoptFromPath(&books[idx], false)
 
 			// Check cancellation before saving
 			if ctx.Err() != nil {
@@ -821,6 +934,7 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 
 			// Save to database (database operations are thread-safe)
 			if err := saveBook(ctx, &books[idx]); err != nil {
+				recordImportError(books[idx].FilePath, err)
 				errChan <- fmt.Errorf("failed to save book %s: %w", books[idx].FilePath, err)
 			} else {
 				// Create segments for multi-file books grouped by album
@@ -840,9 +954,14 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 					if store == nil {
 						return
 					}
-					if fi, statErr := os.Stat(books[idx].FilePath); statErr == nil {
+					if mtime, size, ok := bookSignature(&books[idx]); ok {
 						if dbBook, dbErr := store.GetBookByFilePath(books[idx].FilePath); dbErr == nil && dbBook != nil {
-							_ = store.UpdateScanCache(dbBook.ID, fi.ModTime().Unix(), fi.Size())
+							_ = store.UpdateScanCache(dbBook.ID, mtime, size)
+							if chapters := books[idx].Chapters; chapters != nil && !keepUnwrittenChapters(store, dbBook.ID, chapters) {
+								if err := database.PutBookChapters(store, dbBook.ID, chapters); err != nil {
+									scanLog.Warn("failed to store chapters for %s: %v", books[idx].FilePath, err)
+								}
+							}
 						}
 					}
 				}()
@@ -857,13 +976,21 @@ func ProcessBooksParallel(ctx context.Context, books []Book, workers int, progre
 
 	// Collect any errors
 	var errs []error
+	var quotaRefused []error
 	for err := range errChan {
+		if errors.Is(err, quota.ErrExceeded) {
+			quotaRefused = append(quotaRefused, err)
+			continue
+		}
 		errs = append(errs, err)
 	}
 
 	if len(errs) > 0 {
 		scanLog.Warn("%d books failed to save", len(errs))
 	}
+	if len(quotaRefused) > 0 {
+		scanLog.Warn("%d new books refused by import quota, e.g. %v", len(quotaRefused), quotaRefused[0])
+	}
 
 	if ctxErr != nil {
 		return ctxErr
@@ -1260,6 +1387,7 @@ func createBookFilesForBook(bookFilePath string, segmentFiles []string, scanLog
 		}
 	}
 
+	hashes := hashFiles(segmentFiles)
 	for i, filePath := range segmentFiles {
 		trackNum := i + 1
 		ext := strings.ToLower(filepath.Ext(filePath))
@@ -1278,9 +1406,10 @@ func createBookFilesForBook(bookFilePath string, segmentFiles []string, scanLog
 			TrackNumber:      trackNum,
 		}
 
-		if h, herr := ComputeFileHash(filePath); herr == nil {
-			bf.FileHash = h
-			bf.OriginalFileHash = h
+		if h := hashes[i]; h.Err == nil {
+			bf.FileHash = h.Hash
+			bf.OriginalFileHash = h.Hash
+			bf.FileHashAlgorithm = h.Algorithm
 		}
 
 		if serr := getStore().UpsertBookFile(bf); serr != nil {
@@ -1619,6 +1748,17 @@ func groupFilesIntoBooks(files []string) []Book {
 	return books
 }
 
+// matchBlockedSignature returns the blocked metadata signature book
+// matches, or nil. Lookup errors are logged and treated as no match.
+func matchBlockedSignature(book *Book) *database.BlockedSignature {
+	sig, err := database.MatchBlockedSignature(getStore(), book.Title, book.Author, book.Duration)
+	if err != nil {
+		defaultLog.Warn("failed to check signature blocklist: %v", err)
+		return nil
+	}
+	return sig
+}
+
 // saveBookToDatabase saves the book information to the database.
 // ctx is used to abort early if the enclosing operation has been canceled —
 // in particular, we snapshot config.AppConfig.RootDir at entry so goroutine
@@ -1636,10 +1776,13 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 	// Prefer using the unified Store API when available
 	if getStore() != nil {
 		// Resolve author/series with conflict-aware get-or-create semantics.
-		authorID, err := resolveAuthorID(book.Author)
+		authorID, namesakes, err := resolveBookAuthorID(book)
 		if err != nil {
 			return err
 		}
+		if len(namesakes) > 1 {
+			defer queueAuthorResolution(book.FilePath, normalizeAuthorName(book.Author), *authorID, namesakes)
+		}
 		seriesID, err := resolveSeriesID(book.Series, authorID)
 		if err != nil {
 			return err
@@ -1690,21 +1833,25 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 		var fileSize *int64
 		var originalFileHash *string
 		var organizedFileHash *string
+		var fileHashAlgorithm *string
 		precomputedHash := book.FileHash
+		hashAlgo := book.FileHashAlgorithm
 		var hash string
 		var hashErr error
 		if precomputedHash != "" {
 			hash = precomputedHash
-		} else {
-			hash, hashErr = ComputeFileHash(book.FilePath)
+		} else if !book.SkipHash {
+			hash, hashAlgo, hashErr = ComputeFileHashWithAlgorithm(book.FilePath)
 		}
 		if hashErr == nil && hash != "" {
 			// Check if this hash is blocked
-			blocked, err := getStore().IsHashBlocked(hash)
+			blocked, err := IsFileBlocked(getStore(), book.FilePath, hash, hashAlgo)
 			if err != nil {
 				defaultLog.Warn("failed to check hash blocklist: %v", err)
 			} else if blocked {
 				defaultLog.Info("Skipping file %s: hash %s is blocked", book.FilePath, hash)
+				recordImportDecision(book.FilePath, database.ImportDecisionBlockedHash,
+					"file hash "+hash+" is on the do-not-import list", "")
 				return nil // Skip this file
 			}
 
@@ -1712,15 +1859,33 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 			originalFileHash = stringPtrValue(hash)
 			if size, err := getFileSize(book.FilePath); err == nil {
 				fileSize = &size
+				if hashAlgo == "" {
+					hashAlgo = FileHashAlgorithmFor(size)
+				}
+			}
+			if hashAlgo != "" {
+				fileHashAlgorithm = stringPtrValue(hashAlgo)
 			}
 			if rootDir != "" && strings.HasPrefix(book.FilePath, rootDir) {
 				organizedFileHash = stringPtrValue(hash)
 			}
 		}
 
-		var seriesSequence *int
+		// A different encode of a book blocked by signature is refused too;
+		// books already in the library are left alone.
+		if sig := matchBlockedSignature(book); sig != nil {
+			if existing, _ := getStore().GetBookByFilePath(book.FilePath); existing == nil {
+				defaultLog.Info("Skipping file %s: matches blocked signature %s", book.FilePath, sig.ID)
+				recordImportDecision(book.FilePath, database.ImportDecisionBlockedSignature,
+					fmt.Sprintf("title, author and duration match blocked signature %s (%q by %q, %s)",
+						sig.ID, sig.Title, sig.Author, time.Duration(sig.DurationSec)*time.Second), "")
+				return nil
+			}
+		}
+
+		var seriesSequence *database.SeriesSeq
 		if book.Position > 0 {
-			seriesSequence = &book.Position
+			seriesSequence = database.NewSeriesSeq(book.Position)
 		}
 		var duration *int
 		if book.Duration > 0 {
@@ -1749,12 +1914,14 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 			GoogleBooksID:     nullablePtr(book.GoogleBooksID),
 			FileHash:          fileHash,
 			FileSize:          fileSize,
+			FileHashAlgorithm: fileHashAlgorithm,
 			OriginalFileHash:  originalFileHash,
 			OrganizedFileHash: organizedFileHash,
 			LibraryState:      stringPtr(ls),
 			Quantity:          intPtr(1),
 			SourceImportPath:  nullablePtr(book.SourceImportPath),
 		}
+		applyMediaInfo(dbBook, book.MediaInfo)
 
 		// Re-link by embedded AUDIOBOOK_ORGANIZER_ID: if the file contains our ID tag,
 		// find the existing record and update its path (handles file moves/renames).
@@ -1766,6 +1933,10 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 				existingByOrgID.FilePath = book.FilePath
 				preserveExistingFields(dbBook, existingByOrgID)
 				_, err = getStore().UpdateBook(existingByOrgID.ID, existingByOrgID)
+				if err == nil {
+					recordImportDecision(book.FilePath, database.ImportDecisionImported,
+						"re-linked moved book by embedded ID", existingByOrgID.ID)
+				}
 				return err
 			}
 		}
@@ -1777,6 +1948,11 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 			return fmt.Errorf("book lookup failed: %w", err)
 		}
 
+		// movedFrom is the previous path when the hash match turns out to be
+		// this very file after a move or rename; the record is relinked to
+		// the new path rather than duplicated.
+		var movedFrom string
+
 		// 2. If not found by path but we have a file hash, check for duplicates via indexes
 		if existing == nil && fileHash != nil && *fileHash != "" {
 			hashLookups := []func(string) (*database.Book, error){
@@ -1795,7 +1971,9 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 				}
 			}
 
-			if existing != nil {
+			if existing != nil && fileMoved(existing.FilePath, book.FilePath) {
+				movedFrom = existing.FilePath
+			} else if existing != nil {
 				defaultLog.Debug("Found duplicate book by hash: %s (existing: %s, new: %s)",
 					existing.Title, existing.FilePath, book.FilePath)
 
@@ -1808,6 +1986,8 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 					defaultLog.Debug("Promoting organized path for %s", existing.Title)
 				} else if alreadyLinked {
 					defaultLog.Debug("Already version-linked (group %s), skipping: %s", *existing.VersionGroupID, existing.FilePath)
+					recordImportDecision(book.FilePath, database.ImportDecisionDuplicate,
+						"same file hash as "+existing.FilePath+", already in a version group", existing.ID)
 					return nil
 				} else {
 					// Link both records via version_group_id. Primary = the one in RootDir.
@@ -1845,12 +2025,11 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 			bookVotes := make(map[string]int)
 			bookCandidates := make(map[string]*database.Book)
 
-			for _, segFile := range book.SegmentFiles {
-				h, herr := ComputeFileHash(segFile)
-				if herr != nil || h == "" {
+			for _, h := range hashFiles(book.SegmentFiles) {
+				if h.Err != nil || h.Hash == "" {
 					continue
 				}
-				candidate, lerr := getStore().GetBookBySegmentFileHash(h)
+				candidate, lerr := getStore().GetBookBySegmentFileHash(h.Hash)
 				if lerr != nil || candidate == nil {
 					continue
 				}
@@ -1868,8 +2047,11 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 
 			if bestID != "" {
 				threshold := int(math.Ceil(float64(len(book.SegmentFiles)) * 0.8))
-				if bestCount >= threshold {
-					matchedBook := bookCandidates[bestID]
+				matchedBook := bookCandidates[bestID]
+				if bestCount >= threshold && fileMoved(matchedBook.FilePath, book.FilePath) {
+					existing = matchedBook
+					movedFrom = matchedBook.FilePath
+				} else if bestCount >= threshold {
 					if bestCount < len(book.SegmentFiles) {
 						defaultLog.Warn(
 							"Multi-file dedup: %d/%d files matched existing book %q — possible corruption or bit rot in %s",
@@ -1883,6 +2065,9 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 					if alreadyLinked {
 						defaultLog.Debug("Multi-file dedup: already version-linked (group %s), skipping: %s",
 							*matchedBook.VersionGroupID, book.FilePath)
+						recordImportDecision(book.FilePath, database.ImportDecisionDuplicate,
+							fmt.Sprintf("%d/%d files match %s, already in a version group", bestCount, len(book.SegmentFiles), matchedBook.FilePath),
+							matchedBook.ID)
 						return nil
 					}
 					h2 := sha256.Sum256([]byte(matchedBook.ID + "|" + book.FilePath))
@@ -1909,10 +2094,19 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 		}
 
 		if existing == nil {
-			// Smart dedup: check for same-title books in same directory (format-aware version linking)
+			// Smart dedup: check for same-title books in same directory (format-aware version linking).
+			// Only siblings with the same identity count: two "Endurance"s by
+			// different authors sharing a download folder are different books.
 			if dbBook.Title != "" {
 				parentDir := filepath.Dir(book.FilePath)
 				siblings, lookupErr := getStore().GetBooksByTitleInDir(strings.ToLower(dbBook.Title), parentDir)
+				var identity database.BookIdentity
+				if len(siblings) > 0 {
+					identity = database.BookIdentityOf(getStore(), dbBook)
+					siblings = slices.DeleteFunc(siblings, func(sib database.Book) bool {
+						return !identity.SameBook(database.BookIdentityOf(getStore(), &sib))
+					})
+				}
 				if lookupErr == nil && len(siblings) > 0 {
 					// Determine or reuse version_group_id
 					var groupID string
@@ -1923,7 +2117,7 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 						}
 					}
 					if groupID == "" {
-						h := sha256.Sum256([]byte(parentDir + "/" + strings.ToLower(dbBook.Title)))
+						h := sha256.Sum256([]byte(parentDir + "/" + strings.ToLower(dbBook.Title) + "|" + identity.Author + "|" + identity.Narrator))
 						groupID = fmt.Sprintf("vg-%x", h[:8])
 					}
 					dbBook.VersionGroupID = &groupID
@@ -1946,10 +2140,15 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 				}
 			}
 
+			if err := admitImportQuota(dbBook); err != nil {
+				return err
+			}
 			_, err = getStore().CreateBook(dbBook)
 			if err == nil {
+				recordImportDecision(book.FilePath, database.ImportDecisionImported, "", dbBook.ID)
 				// Check for metadata hash duplicates
 				detectMetadataHashDuplicate(dbBook, defaultLog)
+				flagVersionUpgrade(getStore(), dbBook, defaultLog)
 				if scanHooks != nil {
 					scanHooks.OnBookScanned(dbBook.ID, dbBook.Title)
 					scanHooks.OnImportDedup(dbBook.ID)
@@ -1969,8 +2168,17 @@ func saveBookToDatabase(ctx context.Context, book *Book) error {
 		// Preserve enriched fields that scanner doesn't extract (e.g. from metadata fetch or AI parse)
 		preserveExistingFields(dbBook, existing)
 
+		if movedFrom != "" {
+			defaultLog.Info("re-linking book %s (moved from %s to %s)", existing.ID, movedFrom, book.FilePath)
+			relinkBookFiles(existing.ID, movedFrom, book.FilePath)
+		}
+
 		_, err = getStore().UpdateBook(existing.ID, dbBook)
-		if err == nil {
+		if err == nil && movedFrom != "" {
+			relinkedBooks.Add(1)
+			recordImportDecision(book.FilePath, database.ImportDecisionImported, "re-linked moved book from "+movedFrom, existing.ID)
+		} else if err == nil {
+			recordImportDecision(book.FilePath, database.ImportDecisionImported, "updated existing book", existing.ID)
 			// Check for metadata hash duplicates after update
 			detectMetadataHashDuplicate(dbBook, defaultLog)
 		}
@@ -1998,58 +2206,11 @@ func ComputeSegmentFileHash(filePath string) (string, error) {
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
-// ComputeFileHash computes a SHA256 hash of the file, using a chunked strategy
-// for large audiobook files to balance accuracy and performance.
+// ComputeFileHash computes the content hash of a file with the algorithm
+// selected by config.FileHashAlgorithm; see ComputeFileHashWithAlgorithm.
 func ComputeFileHash(filePath string) (string, error) {
-	if activeScanner != nil {
-		return activeScanner.ComputeFileHash(filePath)
-	}
-	file, err := os.Open(filePath)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
-	// For large files (> 100MB), hash first 10MB + last 10MB + size for speed
-	info, err := file.Stat()
-	if err != nil {
-		return "", err
-	}
-
-	const threshold = 100 * 1024 * 1024 // 100MB
-	const chunkSize = 10 * 1024 * 1024  // 10MB
-
-	if info.Size() > threshold {
-		// Quick hash for large files: first chunk + last chunk + size
-		h := sha256.New()
-
-		// First chunk
-		first := make([]byte, chunkSize)
-		n, err := file.Read(first)
-		if err != nil && err != io.EOF {
-			return "", err
-		}
-		h.Write(first[:n])
-
-		// Last chunk
-		if info.Size() > chunkSize {
-			file.Seek(-chunkSize, io.SeekEnd)
-			last := make([]byte, chunkSize)
-			n, err := file.Read(last)
-			if err != nil && err != io.EOF {
-				return "", err
-			}
-			h.Write(last[:n])
-		}
-
-		// Include size in hash
-		h.Write([]byte(fmt.Sprintf("%d", info.Size())))
-
-		return hex.EncodeToString(h.Sum(nil)), nil
-	}
-
-	// Full hash for smaller files
-	return computeFullFileHash(filePath)
+	hash, _, err := ComputeFileHashWithAlgorithm(filePath)
+	return hash, err
 }
 
 // computeFullFileHash computes the SHA256 hash of the entire file
@@ -2097,18 +2258,22 @@ func nullablePtr(s string) *string {
 	return &s
 }
 
-func resolveAuthorID(authorName string) (*int, error) {
+// normalizeAuthorName trims authorName and expands collapsed initials
+// ("J.B." → "J. B."), the form author records are looked up by.
+func normalizeAuthorName(authorName string) string {
 	trimmed := strings.TrimSpace(authorName)
-	if trimmed == "" {
-		return nil, nil
-	}
-
-	// Normalize collapsed initials: "J.B." → "J. B."
 	initialsRe := regexp.MustCompile(`([A-Z]\.)([A-Z])`)
 	for initialsRe.MatchString(trimmed) {
 		trimmed = initialsRe.ReplaceAllString(trimmed, "$1 $2")
 	}
-	trimmed = strings.TrimSpace(trimmed)
+	return strings.TrimSpace(trimmed)
+}
+
+func resolveAuthorID(authorName string) (*int, error) {
+	trimmed := normalizeAuthorName(authorName)
+	if trimmed == "" {
+		return nil, nil
+	}
 
 	author, err := getStore().GetAuthorByName(trimmed)
 	if err != nil {
@@ -2117,6 +2282,14 @@ func resolveAuthorID(authorName string) (*int, error) {
 	if author != nil {
 		return &author.ID, nil
 	}
+	resolver := currentEntityMatcher()
+	author, err = resolver.MatchAuthor(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("author match failed: %w", err)
+	}
+	if author != nil {
+		return &author.ID, nil
+	}
 
 	author, err = getStore().CreateAuthor(trimmed)
 	if err != nil {
@@ -2132,6 +2305,7 @@ func resolveAuthorID(authorName string) (*int, error) {
 			return nil, fmt.Errorf("author conflict detected but author not found: %s", trimmed)
 		}
 	}
+	resolver.AddAuthor(author)
 	return &author.ID, nil
 }
 
@@ -2154,6 +2328,14 @@ func resolveSeriesID(seriesName string, authorID *int) (*int, error) {
 	if series != nil {
 		return &series.ID, nil
 	}
+	resolver := currentEntityMatcher()
+	series, err = resolver.MatchSeries(trimmed, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("series match failed: %w", err)
+	}
+	if series != nil {
+		return &series.ID, nil
+	}
 
 	series, err = getStore().CreateSeries(trimmed, authorID)
 	if err != nil {
@@ -2169,6 +2351,7 @@ func resolveSeriesID(seriesName string, authorID *int) (*int, error) {
 			return nil, fmt.Errorf("series conflict detected but series not found: %s", trimmed)
 		}
 	}
+	resolver.AddSeries(series)
 	return &series.ID, nil
 }
 
@@ -2266,6 +2449,22 @@ func preserveExistingFields(scanned *database.Book, existing *database.Book) {
 	if scanned.VersionNotes == nil && existing.VersionNotes != nil {
 		scanned.VersionNotes = existing.VersionNotes
 	}
+	if scanned.UpgradeAvailable == nil && existing.UpgradeAvailable != nil {
+		scanned.UpgradeAvailable = existing.UpgradeAvailable
+	}
+	if scanned.UpgradeCandidateID == nil && existing.UpgradeCandidateID != nil {
+		scanned.UpgradeCandidateID = existing.UpgradeCandidateID
+	}
+	// Preserve media info when this scan did not read it (directory books)
+	if scanned.Codec == nil && existing.Codec != nil {
+		scanned.Codec = existing.Codec
+		scanned.Bitrate = existing.Bitrate
+		scanned.SampleRate = existing.SampleRate
+		scanned.Channels = existing.Channels
+		scanned.BitDepth = existing.BitDepth
+		scanned.Quality = existing.Quality
+		scanned.QualityScore = existing.QualityScore
+	}
 	// Preserve deletion state
 	if scanned.MarkedForDeletion == nil && existing.MarkedForDeletion != nil {
 		scanned.MarkedForDeletion = existing.MarkedForDeletion
@@ -2274,7 +2473,7 @@ func preserveExistingFields(scanned *database.Book, existing *database.Book) {
 		scanned.MarkedForDeletionAt = existing.MarkedForDeletionAt
 	}
 	// Preserve series sequence if scan has nil/zero and existing has a value
-	if (scanned.SeriesSequence == nil || *scanned.SeriesSequence == 0) && existing.SeriesSequence != nil && *existing.SeriesSequence != 0 {
+	if !hasSeriesSeq(scanned.SeriesSequence) && hasSeriesSeq(existing.SeriesSequence) {
 		scanned.SeriesSequence = existing.SeriesSequence
 	}
 	// Preserve SourceImportPath — once set it must never be overwritten
@@ -2309,3 +2508,8 @@ func countAudioFilesInDir(dirPath string, supportedExts []string) int {
 	}
 	return count
 }
+
+// hasSeriesSeq reports whether seq holds a real position (not nil, empty or 0).
+func hasSeriesSeq(seq *database.SeriesSeq) bool {
+	return seq != nil && *seq != "" && *seq != "0"
+}
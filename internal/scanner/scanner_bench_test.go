@@ -0,0 +1,81 @@
+// file: internal/scanner/scanner_bench_test.go
+// version: 1.0.0
+// guid: c6e2353f-1990-444d-b9ca-b762176027f6
+
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+)
+
+// buildBenchLibrary lays out authors × booksPerAuthor directories, each with
+// a single small .m4b, mirroring the Author/Title shape of a real import path.
+func buildBenchLibrary(b *testing.B, authors, booksPerAuthor int) string {
+	b.Helper()
+	root := b.TempDir()
+	for a := 0; a < authors; a++ {
+		for n := 0; n < booksPerAuthor; n++ {
+			dir := filepath.Join(root, fmt.Sprintf("Author %03d", a), fmt.Sprintf("Book %03d", n))
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				b.Fatalf("mkdir: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "book.m4b"), []byte("bench"), 0o644); err != nil {
+				b.Fatalf("write: %v", err)
+			}
+		}
+	}
+	return root
+}
+
+func BenchmarkScanDirectoryParallel(b *testing.B) {
+	oldExts := config.AppConfig.SupportedExtensions
+	b.Cleanup(func() { config.AppConfig.SupportedExtensions = oldExts })
+	config.AppConfig.SupportedExtensions = []string{".m4b", ".mp3"}
+
+	root := buildBenchLibrary(b, 20, 50)
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				books, err := ScanDirectoryParallel(root, workers, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(books) != 1000 {
+					b.Fatalf("expected 1000 books, got %d", len(books))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkComputeFileHash(b *testing.B) {
+	// 1 MiB exercises the full-read path; 128 MiB crosses the 100 MiB
+	// threshold and exercises the first/last-chunk fast path.
+	for _, size := range []int64{1 << 20, 128 << 20} {
+		b.Run(fmt.Sprintf("size=%dMiB", size>>20), func(b *testing.B) {
+			path := filepath.Join(b.TempDir(), "audio.m4b")
+			f, err := os.Create(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := f.Truncate(size); err != nil {
+				b.Fatal(err)
+			}
+			f.Close()
+
+			b.SetBytes(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := ComputeFileHash(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/scanner/scanner_test.go
-// version: 1.3.0
+// version: 1.4.0
 // guid: 5c1a2b3c-4d5e-6f7a-8b9c-0d1e2f3a4b5c
 
 package scanner
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -347,6 +348,27 @@ func TestScanDirectoryParallelNegativeWorkers(t *testing.T) {
 	}
 }
 
+func TestScanDirectoryContextCanceled(t *testing.T) {
+	oldExts := config.AppConfig.SupportedExtensions
+	t.Cleanup(func() { config.AppConfig.SupportedExtensions = oldExts })
+	config.AppConfig.SupportedExtensions = []string{".m4b"}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.m4b"), []byte("test"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	books, err := ScanDirectoryContext(context.Background(), dir, 2, nil)
+	if err != nil || len(books) != 1 {
+		t.Fatalf("expected 1 book, got %d (err %v)", len(books), err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ScanDirectoryContext(ctx, dir, 2, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
 func TestProcessBooksParallelNegativeWorkers(t *testing.T) {
 	oldExts := config.AppConfig.SupportedExtensions
 	t.Cleanup(func() { config.AppConfig.SupportedExtensions = oldExts })
@@ -1,7 +1,7 @@
 // file: internal/scanner/service.go
-// version: 1.7.0
+// version: 1.22.0
 // guid: a1b2c3d4-e5f6-7a8b-9c0d-1e2f3a4b5c6d
-// last-edited: 2026-05-05
+// last-edited: 2026-10-17
 package scanner
 
 import (
@@ -12,12 +12,17 @@ import (
 	"path/filepath"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/falkcorp/audiobook-organizer/internal/activity"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+	"github.com/falkcorp/audiobook-organizer/internal/metrics"
 	"github.com/falkcorp/audiobook-organizer/internal/operations"
+	"github.com/falkcorp/audiobook-organizer/internal/entitymatch"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
 )
 
 // scanServiceStore is the narrow slice of database.Store this service uses.
@@ -39,6 +44,12 @@ type ScanService struct {
 	// folder. The server layer wires in the auto-organize logic here to avoid
 	// an import cycle (organizer → scanner → organizer).
 	AutoOrganizeFn func(ctx context.Context, books []Book, log logger.Logger)
+	// FingerprintFn is an optional hook called after a scan in which at
+	// least one folder ran the deep profile, to queue audio fingerprinting.
+	FingerprintFn func(ctx context.Context)
+	// ImportPathHealthFn is an optional hook called when an import path
+	// turns unhealthy or recovers; Health on the passed path says which.
+	ImportPathHealthFn func(ip database.ImportPath)
 }
 
 // NewScanService creates a new ScanService backed by the given store and embedding store.
@@ -61,6 +72,17 @@ type ScanRequest struct {
 	FolderPath  *string
 	Priority    *int
 	ForceUpdate *bool
+	// Profile is one of ScanProfiles; empty uses each import path's
+	// default (standard when unset).
+	Profile string
+	// Adopt scans every folder in adopt mode (see SetAdoption), parsing
+	// book folders with AdoptPattern or folder_naming_pattern; false
+	// leaves it to each import path's adopt setting.
+	Adopt        bool
+	AdoptPattern string
+	// Yield, when set, is called before each book and blocks while the
+	// scan should pause (background scans stepping aside for user work).
+	Yield func(ctx context.Context)
 }
 
 // ScanStats accumulates per-scan book counts by source.
@@ -68,6 +90,20 @@ type ScanStats struct {
 	TotalBooks   int
 	LibraryBooks int
 	ImportBooks  int
+	// MediaInfoCacheHits / MediaInfoCacheMisses count files whose tags and
+	// media info were served from (or missed) the hash-keyed mediainfo cache.
+	MediaInfoCacheHits   int64
+	MediaInfoCacheMisses int64
+	// RelinkedBooks counts existing records moved to a file's new path
+	// after a move or rename instead of being re-imported.
+	RelinkedBooks int64
+	// TagMappingHits counts files per tag_field_mappings rule that filled
+	// or overrode a field.
+	TagMappingHits []metadata.TagMappingHit
+
+	// eta adds the remaining-time hint to progress messages; nil when the
+	// scan has no learned prediction.
+	eta *scanETA
 }
 
 // PerformScanWithID executes the multi-folder scan operation with checkpoint support.
@@ -76,6 +112,7 @@ func (ss *ScanService) PerformScanWithID(ctx context.Context, opID string, req *
 	_ = operations.SaveParams(ss.db, opID, operations.ScanParams{
 		FolderPath:  req.FolderPath,
 		ForceUpdate: req.ForceUpdate != nil && *req.ForceUpdate,
+		Profile:     req.Profile,
 	})
 	err := ss.performScanInternal(ctx, opID, req, log)
 	_ = operations.ClearState(ss.db, opID)
@@ -167,8 +204,48 @@ func (ss *ScanService) performScanInternal(ctx context.Context, opID string, req
 	InitWorksLookupCache()
 	defer ClearWorksLookupCache()
 
+	// Import quotas are checked against usage computed once per scan.
+	SetImportQuota(quota.NewEnforcer(&config.AppConfig, ss.db))
+	defer SetImportQuota(nil)
+
+	// Author/series spelling variants resolve against the library as it
+	// stood at scan start plus whatever the scan creates.
+	SetEntityMatcher(entitymatch.NewResolver(&config.AppConfig, getStore()))
+	defer SetEntityMatcher(nil)
+
+	SetScanYield(req.Yield)
+	defer SetScanYield(nil)
+
+	ResetMediaInfoCacheStats()
+	ResetBlockedHashAlgorithms()
+	ResetRelinkStats()
+	metadata.ResetTagMappingStats()
+
+	// Each folder runs under the requested profile or its import path's
+	// default.
+	if !ValidScanProfile(req.Profile) {
+		return fmt.Errorf("unknown scan profile %q", req.Profile)
+	}
+	if req.Adopt {
+		if _, err := compileAdoptPattern(req.AdoptPattern); err != nil {
+			return fmt.Errorf("invalid adopt pattern: %w", err)
+		}
+	}
+	importPaths, _ := ss.db.GetAllImportPaths()
+	defer SetScanProfile("")
+	defer func() { _ = SetAdoption("", "") }()
+	ranDeep := false
+
+	// Predict the duration from earlier scans so progress messages can
+	// say how long is left.
+	prediction := predictFolders(foldersToScan, req.Profile, importPaths)
+	if prediction != nil && prediction.Basis != PredictionBasisDefault {
+		log.Info("Predicted scan time: %s for ~%d files (%s history)",
+			formatETA(time.Duration(prediction.EstimatedSeconds)*time.Second), prediction.Files, prediction.Basis)
+	}
+
 	// Scan each folder
-	stats := &ScanStats{}
+	stats := &ScanStats{eta: newScanETA(prediction)}
 	var processedFiles atomic.Int32
 
 	for folderIdx, folderPath := range foldersToScan {
@@ -177,9 +254,28 @@ func (ss *ScanService) performScanInternal(ctx context.Context, opID string, req
 			return fmt.Errorf("scan canceled")
 		}
 
+		profile := folderScanProfile(req.Profile, folderPath, importPaths)
+		SetScanProfile(profile)
+		switch {
+		case profile == ScanProfileDeep && initialScanPending(folderPath, importPaths):
+			log.Info("Folder %s: initial deep scan", folderPath)
+			forgetScanCacheUnder(scanCache, folderPath)
+		case profile != ScanProfileStandard:
+			log.Info("Folder %s: %s scan", folderPath, profile)
+		}
+		ranDeep = ranDeep || profile == ScanProfileDeep
+		_ = SetAdoption("", "")
+		if adopt, pattern := folderAdoption(req.Adopt, req.AdoptPattern, folderPath, importPaths); adopt {
+			if err := SetAdoption(folderPath, pattern); err != nil {
+				log.Warn("Folder %s: not adopting, bad adopt pattern: %v", folderPath, err)
+			} else {
+				log.Info("Folder %s: adopting books in place", folderPath)
+			}
+		}
 		err := ss.scanFolder(ctx, folderIdx, folderPath, foldersToScan, totalFilesAcrossFolders, &processedFiles, stats, opID, log)
 		if err != nil {
 			log.Error("Error scanning folder %s: %v", folderPath, err)
+			metrics.RecordImportPathScanError(folderPath)
 			continue
 		}
 	}
@@ -198,6 +294,9 @@ func (ss *ScanService) performScanInternal(ctx context.Context, opID string, req
 	if ss.PostScanFn != nil {
 		ss.PostScanFn()
 	}
+	if ranDeep && ss.FingerprintFn != nil {
+		ss.FingerprintFn(ctx)
+	}
 	return nil
 }
 
@@ -206,24 +305,16 @@ func (ss *ScanService) determineFoldersToScan(folderPath *string, forceUpdate bo
 
 	if folderPath != nil && *folderPath != "" {
 		// Scan specific folder
-		foldersToScan = []string{*folderPath}
+		foldersToScan = scanFolderList(folderPath, forceUpdate, nil)
 		log.Info("Starting scan of folder: %s", *folderPath)
 	} else {
-		// Full scan: include RootDir if force_update enabled, then all import paths
-		if forceUpdate && config.AppConfig.RootDir != "" {
-			foldersToScan = append(foldersToScan, config.AppConfig.RootDir)
-			log.Info("Full rescan: including library path %s", config.AppConfig.RootDir)
-		}
-
-		// Add all import paths
 		folders, err := ss.db.GetAllImportPaths()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get import paths: %w", err)
 		}
-		for _, folder := range folders {
-			if folder.Enabled {
-				foldersToScan = append(foldersToScan, folder.Path)
-			}
+		foldersToScan = scanFolderList(nil, forceUpdate, folders)
+		if forceUpdate && config.AppConfig.RootDir != "" {
+			log.Info("Full rescan: including library path %s", config.AppConfig.RootDir)
 		}
 		log.Info("Scanning %d total folders (%d import paths)", len(foldersToScan), len(folders))
 	}
@@ -231,6 +322,25 @@ func (ss *ScanService) determineFoldersToScan(folderPath *string, forceUpdate bo
 	return foldersToScan, nil
 }
 
+// scanFolderList is the set of folders a scan request visits: the named
+// folder, or else every enabled import path, preceded by the library root
+// when forceUpdate is set.
+func scanFolderList(folderPath *string, forceUpdate bool, importPaths []database.ImportPath) []string {
+	if folderPath != nil && *folderPath != "" {
+		return []string{*folderPath}
+	}
+	var folders []string
+	if forceUpdate && config.AppConfig.RootDir != "" {
+		folders = append(folders, config.AppConfig.RootDir)
+	}
+	for _, ip := range importPaths {
+		if ip.Enabled {
+			folders = append(folders, ip.Path)
+		}
+	}
+	return folders
+}
+
 func (ss *ScanService) countFilesAcrossFolders(foldersToScan []string, log logger.Logger) int {
 	totalFilesAcrossFolders := 0
 	for _, folderPath := range foldersToScan {
@@ -264,26 +374,41 @@ func (ss *ScanService) scanFolder(ctx context.Context, folderIdx int, folderPath
 	if currentProcessed > displayTotal {
 		displayTotal = currentProcessed
 	}
-	log.UpdateProgress(currentProcessed, displayTotal, fmt.Sprintf("Scanning folder %d/%d: %s", folderIdx+1, len(foldersToScan), folderPath))
+	log.UpdateProgress(currentProcessed, displayTotal, fmt.Sprintf("Scanning folder %d/%d: %s%s",
+		folderIdx+1, len(foldersToScan), folderPath, stats.eta.suffix(currentProcessed, displayTotal)))
 	log.Info("Scanning folder: %s", folderPath)
 
-	// Check if folder exists
-	if _, err := os.Stat(folderPath); os.IsNotExist(err) {
-		log.Warn("Folder does not exist: %s", folderPath)
+	// An unmounted network share looks like a missing or empty folder;
+	// scanning it would zero the import path's book count, so skip it.
+	knownBooks, _ := ss.db.CountBooksByPathPrefix(folderPath)
+	requireMount := false
+	if ip := ss.importPathFor(folderPath); ip != nil {
+		requireMount = ip.RequireMount
+	}
+	if health := CheckImportPathHealth(folderPath, requireMount, knownBooks); !health.Healthy {
+		log.Warn("Skipping folder %s: %s", folderPath, health.Reason)
+		ss.recordImportPathHealth(folderPath, health, opID, log)
 		return nil
 	}
 
 	// Scan directory for audiobook files (parallel)
+	started := time.Now()
 	workers := config.AppConfig.ConcurrentScans
 	if workers < 1 {
 		workers = 4
 	}
-	books, err := ScanDirectoryParallel(folderPath, workers, log.With("scanner"))
+	books, err := ScanDirectoryContext(ctx, folderPath, workers, log.With("scanner"))
 	if err != nil {
 		return fmt.Errorf("failed to scan folder: %w", err)
 	}
 
 	log.Info("Found %d audiobook files in %s", len(books), folderPath)
+	if len(books) == 0 && knownBooks > 0 {
+		health := PathHealth{Reason: fmt.Sprintf("no audiobook files found but %d books are recorded under it", knownBooks)}
+		log.Warn("Skipping folder %s: %s", folderPath, health.Reason)
+		ss.recordImportPathHealth(folderPath, health, opID, log)
+		return nil
+	}
 	stats.TotalBooks += len(books)
 	if folderPath == config.AppConfig.RootDir {
 		stats.LibraryBooks += len(books)
@@ -306,6 +431,7 @@ func (ss *ScanService) scanFolder(ctx context.Context, folderIdx int, folderPath
 		if bookPath != "" {
 			message = fmt.Sprintf("Processed: %d/%d books (%s)", current, displayTotal, filepath.Base(bookPath))
 		}
+		message += stats.eta.suffix(int(current), displayTotal)
 		log.UpdateProgress(int(current), displayTotal, message)
 		if ss.activityWriter != nil && opID != "" {
 			activity.LogBatch(ss.activityWriter, opID, "tag-scan", "scan-service",
@@ -314,6 +440,7 @@ func (ss *ScanService) scanFolder(ctx context.Context, folderIdx int, folderPath
 	}
 
 	// Process the books to extract metadata (parallel)
+	var processErr error
 	if len(books) > 0 {
 		// Tag every book with its source import path before saving to DB.
 		// This must happen before ProcessBooksParallel (which calls CreateBook)
@@ -331,18 +458,26 @@ func (ss *ScanService) scanFolder(ctx context.Context, folderIdx int, folderPath
 		log.Info("Processing metadata for %d books using %d workers", len(books), workers)
 		if err := ProcessBooksParallel(ctx, books, workers, progressCallback, log.With("scanner")); err != nil {
 			log.Error("Failed to process books: %v", err)
+			processErr = err
 		} else {
 			log.Info("Successfully processed %d books", len(books))
 		}
 
-		// Auto-organize if enabled (via server-layer hook to avoid import cycle)
-		if ss.AutoOrganizeFn != nil {
+		// Auto-organize if enabled (via server-layer hook to avoid import cycle).
+		// Adopted books stay where they are.
+		if ss.AutoOrganizeFn != nil && !Adopting() {
 			ss.AutoOrganizeFn(ctx, books, log)
 		}
 	}
 
+	ss.recordScanHistory(folderPath, currentScanProfile(), len(books), time.Since(started), log)
+
 	// Update book count for this import path
 	ss.updateImportPathBookCount(folderPath, len(books), log)
+	ss.recordImportPathHealth(folderPath, PathHealth{Healthy: true}, opID, log)
+	if currentScanProfile() == ScanProfileDeep && processErr == nil && ctx.Err() == nil {
+		ss.completeInitialScan(folderPath, log)
+	}
 
 	return nil
 }
@@ -380,6 +515,21 @@ func (ss *ScanService) reportCompletion(totalFilesAcrossFolders int, finalProces
 	} else {
 		completionMsg = "Scan completed. No books found"
 	}
+	stats.MediaInfoCacheHits, stats.MediaInfoCacheMisses = MediaInfoCacheStats()
+	if lookups := stats.MediaInfoCacheHits + stats.MediaInfoCacheMisses; lookups > 0 {
+		completionMsg += fmt.Sprintf(". Mediainfo cache: %d/%d hits", stats.MediaInfoCacheHits, lookups)
+	}
+	if stats.RelinkedBooks = RelinkStats(); stats.RelinkedBooks > 0 {
+		completionMsg += fmt.Sprintf(". Relinked %d moved books", stats.RelinkedBooks)
+	}
+	stats.TagMappingHits = metadata.TagMappingStats()
+	if len(stats.TagMappingHits) > 0 {
+		parts := make([]string, 0, len(stats.TagMappingHits))
+		for _, h := range stats.TagMappingHits {
+			parts = append(parts, fmt.Sprintf("%s=%d", h.Rule, h.Hits))
+		}
+		completionMsg += ". Tag mappings: " + strings.Join(parts, ", ")
+	}
 
 	finalTotal := totalFilesAcrossFolders
 	if finalProcessed > finalTotal {
@@ -392,11 +542,12 @@ func (ss *ScanService) reportCompletion(totalFilesAcrossFolders int, finalProces
 // ApplyOrganizedFileMetadata updates a book's hash and size fields to reflect
 // a newly-organized file path. It is exported so server-layer code can reuse it.
 func ApplyOrganizedFileMetadata(book *database.Book, newPath string) {
-	hash, err := ComputeFileHash(newPath)
+	hash, algo, err := ComputeFileHashWithAlgorithm(newPath)
 	if err != nil {
 		defaultLog.Warn("failed to compute organized hash for %s: %v", newPath, err)
 	} else if hash != "" {
 		book.FileHash = stringPtr(hash)
+		book.FileHashAlgorithm = stringPtr(algo)
 		book.OrganizedFileHash = stringPtr(hash)
 		if book.OriginalFileHash == nil {
 			book.OriginalFileHash = stringPtr(hash)
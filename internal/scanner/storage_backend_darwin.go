@@ -0,0 +1,39 @@
+// file: internal/scanner/storage_backend_darwin.go
+// version: 1.0.0
+// guid: 1cc4a693-df30-40b3-8ec4-ffca28f4aa92
+// last-edited: 2026-10-16
+
+//go:build darwin
+
+package scanner
+
+import (
+	"strings"
+	"syscall"
+)
+
+// detectStorageBackend classifies the filesystem under path by the type
+// name statfs(2) reports (nfs, smbfs, macfuse, ...).
+func detectStorageBackend(path string) (string, bool) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	for _, c := range st.Fstypename {
+		if c == 0 {
+			break
+		}
+		b.WriteByte(byte(c))
+	}
+	name := b.String()
+	switch {
+	case name == "nfs":
+		return StorageNFS, true
+	case name == "smbfs" || name == "cifs":
+		return StorageSMB, true
+	case strings.Contains(name, "fuse"):
+		return StorageFUSE, true
+	}
+	return StorageLocal, true
+}
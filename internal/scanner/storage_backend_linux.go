@@ -0,0 +1,37 @@
+// file: internal/scanner/storage_backend_linux.go
+// version: 1.0.0
+// guid: 8abd46f3-52a4-4776-85bb-dbb23d368a3d
+// last-edited: 2026-10-16
+
+//go:build linux
+
+package scanner
+
+import "syscall"
+
+// Filesystem magic numbers from statfs(2).
+const (
+	nfsSuperMagic  = 0x6969
+	smbSuperMagic  = 0x517B
+	cifsSuperMagic = 0xFF534D42
+	smb2SuperMagic = 0xFE534D42
+	fuseSuperMagic = 0x65735546
+)
+
+// detectStorageBackend classifies the filesystem under path by its statfs
+// magic number.
+func detectStorageBackend(path string) (string, bool) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return "", false
+	}
+	switch uint32(st.Type) {
+	case nfsSuperMagic:
+		return StorageNFS, true
+	case smbSuperMagic, cifsSuperMagic, smb2SuperMagic:
+		return StorageSMB, true
+	case fuseSuperMagic:
+		return StorageFUSE, true
+	}
+	return StorageLocal, true
+}
@@ -0,0 +1,14 @@
+// file: internal/scanner/storage_backend_other.go
+// version: 1.0.0
+// guid: 111bf6c2-3e02-480b-b215-a0190daafe19
+// last-edited: 2026-10-16
+
+//go:build !linux && !darwin
+
+package scanner
+
+// detectStorageBackend cannot tell network filesystems apart on this
+// platform; every path counts as local.
+func detectStorageBackend(string) (string, bool) {
+	return StorageLocal, true
+}
@@ -1,7 +1,7 @@
 // file: internal/scanner/unit_test.go
-// version: 1.3.0
+// version: 1.7.0
 // guid: a2b3c4d5-e6f7-8901-abcd-ef2345678901
-// last-edited: 2026-06-01
+// last-edited: 2026-10-16
 
 package scanner
 
@@ -631,7 +631,7 @@ func TestPreserveExistingFields(t *testing.T) {
 		GoogleBooksID:      &gbID,
 		ITunesPersistentID: &itunesPID,
 		VersionNotes:       &versionNotes,
-		SeriesSequence:     &seqNum,
+		SeriesSequence:     database.NewSeriesSeq(seqNum),
 	}
 
 	scanned := &database.Book{} // all nil
@@ -653,7 +653,7 @@ func TestPreserveExistingFields(t *testing.T) {
 	assert.Equal(t, &gbID, scanned.GoogleBooksID)
 	assert.Equal(t, &itunesPID, scanned.ITunesPersistentID)
 	assert.Equal(t, &versionNotes, scanned.VersionNotes)
-	assert.Equal(t, &seqNum, scanned.SeriesSequence)
+	assert.Equal(t, database.NewSeriesSeq(seqNum), scanned.SeriesSequence)
 }
 
 func TestPreserveExistingFieldsDoesNotOverwrite(t *testing.T) {
@@ -670,11 +670,11 @@ func TestPreserveExistingFieldsDoesNotOverwrite(t *testing.T) {
 func TestPreserveExistingFieldsZeroSequence(t *testing.T) {
 	zero := 0
 	existingSeq := 5
-	scanned := &database.Book{SeriesSequence: &zero}
-	existing := &database.Book{SeriesSequence: &existingSeq}
+	scanned := &database.Book{SeriesSequence: database.NewSeriesSeq(zero)}
+	existing := &database.Book{SeriesSequence: database.NewSeriesSeq(existingSeq)}
 
 	preserveExistingFields(scanned, existing)
-	assert.Equal(t, &existingSeq, scanned.SeriesSequence, "zero sequence should be replaced by existing")
+	assert.Equal(t, database.NewSeriesSeq(existingSeq), scanned.SeriesSequence, "zero sequence should be replaced by existing")
 }
 
 // ---------------------------------------------------------------------------
@@ -1384,6 +1384,7 @@ func TestProcessBooksParallelSaveWithScanCacheUpdate(t *testing.T) {
 	store.EXPECT().GetBookByFilePath(p).Return(&database.Book{ID: "b1", FilePath: p}, nil).Maybe()
 	store.EXPECT().UpdateScanCache("b1", mock.Anything, mock.Anything).Return(nil).Maybe()
 	store.EXPECT().ResetScanFailCount(mock.Anything).Return(nil).Maybe()
+	store.EXPECT().GetRaw(mock.Anything).Return(nil, nil).Maybe()
 
 	books := []Book{{FilePath: p, Format: ".m4b"}}
 	err := ProcessBooksParallel(t.Context(), books, 1, nil, nil)
@@ -1483,6 +1484,39 @@ func TestSaveBookToDatabaseNewBook(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSaveBookToDatabaseSameTitleDifferentAuthorNotLinked(t *testing.T) {
+	store := dbmocks.NewMockStore(t)
+	origStore := database.GetGlobalStore()
+	database.SetGlobalStore(store)
+	SetStore(store)
+	t.Cleanup(func() { database.SetGlobalStore(origStore); SetStore(nil) })
+
+	store.EXPECT().GetAuthorByName("Scott Kelly").Return(&database.Author{ID: 1, Name: "Scott Kelly"}, nil)
+	store.EXPECT().GetAuthorByID(1).Return(&database.Author{ID: 1, Name: "Scott Kelly"}, nil).Maybe()
+	store.EXPECT().GetSeriesByName(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	store.EXPECT().GetAllWorks().Return(nil, nil)
+	store.EXPECT().CreateWork(mock.Anything).Return(&database.Work{ID: "w1"}, nil)
+	store.EXPECT().IsHashBlocked(mock.Anything).Return(false, nil).Maybe()
+	store.EXPECT().GetBookByFilePath(mock.Anything).Return(nil, nil)
+	store.EXPECT().GetBookByFileHash(mock.Anything).Return(nil, nil).Maybe()
+	store.EXPECT().GetBookByOriginalHash(mock.Anything).Return(nil, nil).Maybe()
+	store.EXPECT().GetBookByOrganizedHash(mock.Anything).Return(nil, nil).Maybe()
+	// Another "Endurance" in the same download folder, by someone else.
+	store.EXPECT().GetBooksByTitleInDir(mock.Anything, mock.Anything).Return([]database.Book{
+		{ID: "lansing", Title: "Endurance", Author: &database.Author{Name: "Alfred Lansing"}},
+	}, nil)
+	store.EXPECT().CreateBook(mock.MatchedBy(func(b *database.Book) bool {
+		return b.VersionGroupID == nil
+	})).Return(nil, nil)
+
+	tmp := t.TempDir()
+	fpath := filepath.Join(tmp, "endurance.m4b")
+	require.NoError(t, os.WriteFile(fpath, []byte("audio data"), 0o644))
+
+	book := &Book{Title: "Endurance", Author: "Scott Kelly", FilePath: fpath, Format: ".m4b"}
+	assert.NoError(t, saveBookToDatabase(context.Background(), book))
+}
+
 func TestSaveBookToDatabaseExistingBook(t *testing.T) {
 	store := dbmocks.NewMockStore(t)
 	origStore := database.GetGlobalStore()
@@ -1704,21 +1738,24 @@ func TestSaveBookToDatabaseHashDedupAlreadyLinked(t *testing.T) {
 	// Not found by path
 	store.EXPECT().GetBookByFilePath(mock.Anything).Return(nil, nil)
 
+	tmp := t.TempDir()
+	fpath := filepath.Join(tmp, "dup.m4b")
+	require.NoError(t, os.WriteFile(fpath, []byte("data"), 0o644))
+	// The other copy must still exist, or the match is a moved file.
+	otherPath := filepath.Join(tmp, "path.m4b")
+	require.NoError(t, os.WriteFile(otherPath, []byte("data"), 0o644))
+
 	// Found by hash — already version-linked
 	vgID := "vg-existing"
 	existingBook := &database.Book{
 		ID:             "dup-id",
 		Title:          "Dup Book",
-		FilePath:       "/other/path.m4b",
+		FilePath:       otherPath,
 		VersionGroupID: &vgID,
 	}
 	store.EXPECT().GetBookByFileHash(mock.Anything).Return(existingBook, nil)
 	// Already linked — should return nil without creating new book
 
-	tmp := t.TempDir()
-	fpath := filepath.Join(tmp, "dup.m4b")
-	require.NoError(t, os.WriteFile(fpath, []byte("data"), 0o644))
-
 	book := &Book{Title: "Dup Book", FilePath: fpath, Format: ".m4b"}
 	err := saveBookToDatabase(context.Background(), book)
 	assert.NoError(t, err) // silently skips already-linked
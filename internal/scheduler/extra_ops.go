@@ -1,5 +1,5 @@
 // file: internal/scheduler/extra_ops.go
-// version: 1.0.2
+// version: 1.2.0
 // guid: a9b8c7d6-e5f4-3210-fedc-ba9876543210
 
 // extra_ops registers OperationDefs for 13 scheduler tasks that previously
@@ -27,6 +27,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/activity"
 	audiobookspkg "github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/backup"
 	"github.com/falkcorp/audiobook-organizer/internal/cache"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
@@ -52,7 +53,7 @@ type ExtraOpsDeps struct {
 	AudiobookService     *audiobookspkg.AudiobookService
 }
 
-// ExtraOpsRegistrar holds a Store reference and typed Deps so the 13 scheduler
+// ExtraOpsRegistrar holds a Store reference and typed Deps so the scheduler
 // OperationDefs can be registered without a *Server pointer.
 type ExtraOpsRegistrar struct {
 	Deps  ExtraOpsDeps
@@ -502,6 +503,45 @@ func (r *ExtraOpsRegistrar) RegisterCleanupOldBackupsOp(reg *opsregistry.Registr
 	})
 }
 
+// --- database-backup ---
+
+// RegisterDatabaseBackupOp registers the scheduler.database-backup OperationDef.
+func (r *ExtraOpsRegistrar) RegisterDatabaseBackupOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              "scheduler.database-backup",
+		Plugin:          "scheduler",
+		DisplayName:     "Database Backup",
+		Description:     "Create a compressed database backup, keeping the most recent ones.",
+		DefaultPriority: opsregistry.PriorityLow,
+		Cancellable:     false,
+		Isolate:         false,
+		Timeout:         2 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeDrop,
+		ConcurrencyKey:  "scheduler.database-backup",
+		Permissions:     []auth.Permission{auth.PermSettingsManage},
+		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead},
+		Run: func(ctx context.Context, rawParams json.RawMessage, reporter opsregistry.Reporter) error {
+			progress := extraOpsProgressAdapter{r: reporter}
+			dbPath := config.AppConfig.DatabasePath
+			if dbPath == "" {
+				_ = progress.Log("info", "No database path configured, skipping backup", nil)
+				return nil
+			}
+			backupConfig := backup.DefaultBackupConfig()
+			if !filepath.IsAbs(backupConfig.BackupDir) {
+				backupConfig.BackupDir = filepath.Join(filepath.Dir(dbPath), backupConfig.BackupDir)
+			}
+			backupConfig.Label = "scheduled"
+			info, err := backup.CreateBackup(dbPath, config.AppConfig.DatabaseType, backupConfig)
+			if err != nil {
+				return fmt.Errorf("database-backup: %w", err)
+			}
+			_ = progress.Log("info", fmt.Sprintf("Backup created: %s (%d bytes)", info.Filename, info.Size), nil)
+			return nil
+		},
+	})
+}
+
 // --- isbn-enrichment ---
 
 // RegisterISBNEnrichmentOp registers the scheduler.isbn-enrichment OperationDef.
@@ -770,9 +810,10 @@ func (r *ExtraOpsRegistrar) runIsbnEnrichment(ctx context.Context, progress oper
 	return nil
 }
 
-// runAutoPurgeSoftDeleted purges soft-deleted books past the retention period.
+// runAutoPurgeSoftDeleted empties trash entries past their retention
+// window, then purges soft-deleted books past the retention period.
 func (r *ExtraOpsRegistrar) runAutoPurgeSoftDeleted(ctx context.Context, opID string) {
-	if config.AppConfig.PurgeSoftDeletedAfterDays <= 0 {
+	if config.AppConfig.PurgeSoftDeletedAfterDays <= 0 && config.AppConfig.TrashRetentionDays <= 0 {
 		return
 	}
 	if r.Store == nil {
@@ -784,6 +825,22 @@ func (r *ExtraOpsRegistrar) runAutoPurgeSoftDeleted(ctx context.Context, opID st
 		return
 	}
 
+	if emptied, err := r.Deps.AudiobookService.FinalizePendingPurges(ctx); err != nil {
+		slog.Warn("Emptying trash failed", "error", err)
+	} else if emptied.Attempted > 0 {
+		msg := fmt.Sprintf("Emptied trash: purged %d/%d books (%d files deleted, %d errors)",
+			emptied.Purged, emptied.Attempted, emptied.FilesDeleted, len(emptied.Errors))
+		slog.Info("Auto-purge", "msg", msg)
+		activity.EmitInfo(r.Deps.ActivityWriter, opID, "purge-deleted", "purge-deleted", msg)
+		for _, e := range emptied.Errors {
+			activity.LogBatch(r.Deps.ActivityWriter, opID, "purge-deleted", "purge-deleted",
+				activity.BatchItem{Name: e, Detail: "error"})
+		}
+	}
+	if config.AppConfig.PurgeSoftDeletedAfterDays <= 0 {
+		return
+	}
+
 	days := config.AppConfig.PurgeSoftDeletedAfterDays
 	result, err := r.Deps.AudiobookService.PurgeSoftDeletedBooks(ctx, config.AppConfig.PurgeSoftDeletedDeleteFiles, &days)
 	if err != nil {
@@ -791,11 +848,11 @@ func (r *ExtraOpsRegistrar) runAutoPurgeSoftDeleted(ctx context.Context, opID st
 		return
 	}
 
-	msg := fmt.Sprintf("Purged %d/%d soft-deleted books (%d files deleted, %d errors)",
-		result.Purged, result.Attempted, result.FilesDeleted, len(result.Errors))
+	msg := fmt.Sprintf("Purged %d/%d soft-deleted books (%d files deleted, %d moved to trash, %d errors)",
+		result.Purged, result.Attempted, result.FilesDeleted, result.PendingPurge, len(result.Errors))
 	slog.Info("Auto-purge", "msg", msg)
 	activity.EmitInfo(r.Deps.ActivityWriter, opID, "purge-deleted", "purge-deleted", msg,
-		activity.TagsIf(result.Purged == 0, activity.NoOpTag)...)
+		activity.TagsIf(result.Purged == 0 && result.PendingPurge == 0, activity.NoOpTag)...)
 	for _, e := range result.Errors {
 		activity.LogBatch(r.Deps.ActivityWriter, opID, "purge-deleted", "purge-deleted",
 			activity.BatchItem{Name: e, Detail: "error"})
@@ -0,0 +1,132 @@
+// file: internal/scheduler/history.go
+// version: 1.0.0
+// guid: 8c2f5a19-d6e4-4b73-9f01-3a7e6c4d2b58
+// last-edited: 2026-10-17
+
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	ulid "github.com/oklog/ulid/v2"
+)
+
+// TaskRun is one trigger of a task, from any source: interval, startup,
+// cron schedule, maintenance window or the API. Runs are kept in the
+// database so last-run state and history survive restarts.
+type TaskRun struct {
+	ID          string    `json:"id"`
+	Task        string    `json:"task"`
+	Source      string    `json:"source"`
+	StartedAt   time.Time `json:"started_at"`
+	OperationID string    `json:"operation_id,omitempty"`
+	// Error is set when the task could not be started.
+	Error string `json:"error,omitempty"`
+	// Status is the operation's status, looked up when the run is read.
+	Status string `json:"status,omitempty"`
+}
+
+// ErrUnknownTask is returned for a task name that is not registered.
+var ErrUnknownTask = errors.New("unknown task")
+
+// taskRunPrefix keys runs as "task_run:<task>:<ulid>", so a task's runs
+// scan in start order.
+const taskRunPrefix = "task_run:"
+
+// taskRunsKept is how many runs are kept per task; older ones are
+// dropped as new ones are recorded.
+const taskRunsKept = 50
+
+// recordRun stores run and trims its task's history.
+func (ts *TaskScheduler) recordRun(run TaskRun) {
+	store := ts.deps.Store()
+	if store == nil {
+		return
+	}
+	data, err := json.Marshal(run)
+	if err != nil {
+		return
+	}
+	if err := store.SetRaw(taskRunPrefix+run.Task+":"+run.ID, data); err != nil {
+		slog.Warn("Failed to record task run", "task", run.Task, "err", err)
+		return
+	}
+	pairs, err := store.ScanPrefix(taskRunPrefix + run.Task + ":")
+	if err != nil {
+		return
+	}
+	for _, kv := range pairs[:max(len(pairs)-taskRunsKept, 0)] {
+		_ = store.DeleteRaw(kv.Key)
+	}
+}
+
+// newTaskRun starts a run record for a trigger of name from source.
+func newTaskRun(name, source string, now time.Time) TaskRun {
+	return TaskRun{ID: ulid.Make().String(), Task: name, Source: source, StartedAt: now.UTC()}
+}
+
+// TaskHistory returns up to limit of name's most recent runs, newest
+// first, each with its operation's current status.
+func (ts *TaskScheduler) TaskHistory(name string, limit int) ([]TaskRun, error) {
+	if _, ok := ts.GetTask(name); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTask, name)
+	}
+	store := ts.deps.Store()
+	if store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	pairs, err := store.ScanPrefix(taskRunPrefix + name + ":")
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]TaskRun, 0, min(len(pairs), limit))
+	for _, kv := range slices.Backward(pairs) {
+		if len(runs) == limit {
+			break
+		}
+		var run TaskRun
+		if err := json.Unmarshal(kv.Value, &run); err != nil {
+			continue
+		}
+		if run.OperationID != "" {
+			if op, err := store.GetOperationByID(run.OperationID); err == nil && op != nil {
+				run.Status = op.Status
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// loadLastRuns restores each task's last run from its history.
+func (ts *TaskScheduler) loadLastRuns() {
+	store := ts.deps.Store()
+	if store == nil {
+		return
+	}
+	pairs, err := store.ScanPrefix(taskRunPrefix)
+	if err != nil {
+		return
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for _, kv := range pairs {
+		name, _, ok := strings.Cut(strings.TrimPrefix(kv.Key, taskRunPrefix), ":")
+		if !ok {
+			continue
+		}
+		var run TaskRun
+		if err := json.Unmarshal(kv.Value, &run); err != nil {
+			continue
+		}
+		if run.StartedAt.After(ts.lastRun[name].StartedAt) {
+			ts.lastRun[name] = run
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// file: internal/scheduler/history_test.go
+// version: 1.0.0
+// guid: 1f6d3b82-a9c4-4e05-b7d8-5c2e9a0f4d61
+
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHistory(t *testing.T) {
+	store := &database.MockStore{}
+	memKV(store)
+	store.GetOperationByIDFunc = func(id string) (*database.Operation, error) {
+		return &database.Operation{ID: id, Status: "completed"}, nil
+	}
+	deps := testDeps()
+	deps.Store = func() database.Store { return store }
+	ts := NewTaskScheduler(deps)
+
+	n := 0
+	ts.RegisterTask(TaskDefinition{
+		Name: "test_task",
+		TriggerFn: func(source string) (*database.Operation, error) {
+			n++
+			if n == 2 {
+				return nil, errors.New("busy")
+			}
+			return &database.Operation{ID: fmt.Sprintf("op-%d", n)}, nil
+		},
+		IsEnabled:   func() bool { return true },
+		GetInterval: func() time.Duration { return 0 },
+		RunOnStart:  func() bool { return false },
+	})
+
+	_, err := ts.RunTaskManual("test_task")
+	require.NoError(t, err)
+	_, err = ts.RunTask("test_task")
+	assert.EqualError(t, err, "busy")
+
+	runs, err := ts.TaskHistory("test_task", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "scheduled", runs[0].Source)
+	assert.Equal(t, "busy", runs[0].Error)
+	assert.Empty(t, runs[0].Status)
+	assert.Equal(t, "manual", runs[1].Source)
+	assert.Equal(t, "op-1", runs[1].OperationID)
+	assert.Equal(t, "completed", runs[1].Status)
+
+	_, err = ts.TaskHistory("no_such_task", 10)
+	assert.ErrorIs(t, err, ErrUnknownTask)
+
+	for range taskRunsKept + 5 {
+		_, _ = ts.RunTask("test_task")
+	}
+	runs, err = ts.TaskHistory("test_task", 100)
+	require.NoError(t, err)
+	assert.Len(t, runs, taskRunsKept)
+	last := runs[0]
+
+	// A restarted scheduler picks the last run up from the database.
+	restarted := NewTaskScheduler(deps)
+	restarted.RegisterTask(*ts.tasks["test_task"])
+	restarted.loadLastRuns()
+	for _, info := range restarted.ListTasks() {
+		if info.Name != "test_task" {
+			continue
+		}
+		require.NotNil(t, info.LastRun)
+		assert.Equal(t, last.StartedAt.Format(time.RFC3339), *info.LastRun)
+		assert.Equal(t, last.OperationID, info.LastOperationID)
+	}
+}
@@ -1,7 +1,7 @@
 // file: internal/scheduler/maintenance.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: 7d2e8f4a-c3b1-4a09-8e5f-2d6c0b9a3e71
-// last-edited: 2026-05-11
+// last-edited: 2026-10-16
 
 package scheduler
 
@@ -38,9 +38,10 @@ func IsInMaintenanceWindowAt(hour int) bool {
 	return hour >= start || hour < end
 }
 
-// IsInMaintenanceWindow checks if the current time falls within the configured window.
+// IsInMaintenanceWindow checks if the current time falls within the configured
+// window, reading the hours in the configured time zone.
 func IsInMaintenanceWindow() bool {
-	return IsInMaintenanceWindowAt(time.Now().Hour())
+	return IsInMaintenanceWindowAt(config.Now().Hour())
 }
 
 // loadLastMaintenanceRun reads the persisted last-run date from the database.
@@ -66,9 +67,10 @@ func (ts *TaskScheduler) saveLastMaintenanceRun() {
 	if store == nil {
 		return
 	}
-	today := time.Now().Format("2006-01-02")
+	now := config.Now()
+	today := now.Format("2006-01-02")
 	_ = store.SetSetting("maintenance_window_last_run", today, "string", false)
-	ts.lastMaintenanceRun = time.Now()
+	ts.lastMaintenanceRun = now
 }
 
 // GetLastMaintenanceRunDate returns the last-run date as "2006-01-02", or "" if never run.
@@ -99,7 +101,7 @@ func (ts *TaskScheduler) IsMaintenanceRunning() bool {
 
 // hasRunToday checks if the maintenance window has already run today.
 func (ts *TaskScheduler) hasRunToday() bool {
-	today := time.Now().Format("2006-01-02")
+	today := config.Now().Format("2006-01-02")
 	return ts.lastMaintenanceRun.Format("2006-01-02") == today
 }
 
@@ -126,6 +128,7 @@ func (ts *TaskScheduler) isTaskRunning(name string) bool {
 		"purge_deleted": "purge-deleted", "tombstone_cleanup": "tombstone-cleanup",
 		"reconcile_scan": "reconcile_scan", "purge_old_logs": "purge_old_logs",
 		"cleanup_old_backups": "cleanup-old-backups",
+		"database_backup":     "database-backup",
 		"metadata_refresh":    "metadata-refresh",
 	}
 	opType, ok := opTypeMap[name]
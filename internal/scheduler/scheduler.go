@@ -1,7 +1,7 @@
 // file: internal/scheduler/scheduler.go
-// version: 1.0.1
+// version: 1.4.0
 // guid: 3f7a9c21-b4d8-4e05-a6f2-8c1d0e3b7a94
-// last-edited: 2026-05-11
+// last-edited: 2026-10-17
 
 // Package scheduler implements the unified task scheduling system.
 // TaskScheduler manages all registered tasks, their schedules, and manual
@@ -51,6 +51,11 @@ type SchedulerDeps struct {
 
 	// HasBatchPoller returns true when a batch poller is available.
 	HasBatchPoller func() bool
+
+	// WaitForIdle blocks until the server is idle enough for heavy startup
+	// work, returning false if shutdown closes first. Consulted before
+	// startup tasks with StartWhenIdle; nil starts them immediately.
+	WaitForIdle func(shutdown <-chan struct{}) bool
 }
 
 // TaskDefinition defines a registered task in the unified task system.
@@ -65,6 +70,9 @@ type TaskDefinition struct {
 	GetInterval            func() time.Duration // 0 = manual only
 	RunOnStart             func() bool
 	RunInMaintenanceWindow func() bool // whether this task runs during the maintenance window
+	// StartWhenIdle defers the RunOnStart trigger until SchedulerDeps.WaitForIdle
+	// reports the server idle.
+	StartWhenIdle bool
 }
 
 // TaskInfo is the API-facing view of a registered task.
@@ -77,7 +85,10 @@ type TaskInfo struct {
 	RunOnStartup           bool    `json:"run_on_startup"`
 	RunInMaintenanceWindow bool    `json:"run_in_maintenance_window"`
 	LastRun                *string `json:"last_run,omitempty"`
-	IsRunning              bool    `json:"is_running"`
+	LastOperationID        string  `json:"last_operation_id,omitempty"`
+	// LastError is set when the last run could not be started.
+	LastError string `json:"last_error,omitempty"`
+	IsRunning bool   `json:"is_running"`
 }
 
 // TaskScheduler manages all registered tasks, their schedules, and manual triggers.
@@ -85,11 +96,14 @@ type TaskScheduler struct {
 	deps               SchedulerDeps
 	tasks              map[string]*TaskDefinition
 	order              []string // insertion order for listing
-	lastRun            map[string]time.Time
+	lastRun            map[string]TaskRun
 	mu                 sync.RWMutex
 	shutdown           chan struct{}
 	maintenanceOrder   []string
 	lastMaintenanceRun time.Time
+	// scheduleMu serializes schedule read-modify-write between the
+	// /schedules API and the run loop.
+	scheduleMu sync.Mutex
 }
 
 // NewTaskScheduler creates a scheduler and registers all known tasks.
@@ -97,7 +111,7 @@ func NewTaskScheduler(deps SchedulerDeps) *TaskScheduler {
 	ts := &TaskScheduler{
 		deps:    deps,
 		tasks:   make(map[string]*TaskDefinition),
-		lastRun: make(map[string]time.Time),
+		lastRun: make(map[string]TaskRun),
 	}
 	ts.registerAllTasks()
 	ts.maintenanceOrder = []string{
@@ -135,6 +149,7 @@ func (ts *TaskScheduler) registerTask(def TaskDefinition) {
 func (ts *TaskScheduler) Start(shutdown chan struct{}, wg *sync.WaitGroup) {
 	ts.shutdown = shutdown
 	ts.loadLastMaintenanceRun()
+	ts.loadLastRuns()
 
 	for _, name := range ts.order {
 		task := ts.tasks[name]
@@ -142,7 +157,11 @@ func (ts *TaskScheduler) Start(shutdown chan struct{}, wg *sync.WaitGroup) {
 		// Run on startup if configured
 		if task.RunOnStart != nil && task.RunOnStart() && task.IsEnabled() {
 			taskName := name
+			startWhenIdle := task.StartWhenIdle
 			go func() {
+				if startWhenIdle && ts.deps.WaitForIdle != nil && !ts.deps.WaitForIdle(shutdown) {
+					return
+				}
 				slog.Info("Running startup task", "taskName", taskName)
 				if op, err := ts.RunTask(taskName); err != nil {
 					slog.Warn("Startup task failed", "taskName", taskName, "err", err)
@@ -178,6 +197,13 @@ func (ts *TaskScheduler) Start(shutdown chan struct{}, wg *sync.WaitGroup) {
 		}
 	}
 
+	// Cron schedules (schedules.go)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ts.runSchedules(shutdown)
+	}()
+
 	// Maintenance window checker — runs every 60 seconds
 	if config.AppConfig.MaintenanceWindowEnabled {
 		wg.Add(1)
@@ -226,18 +252,23 @@ func (ts *TaskScheduler) runTask(name, source string) (*database.Operation, erro
 	task, ok := ts.tasks[name]
 	ts.mu.RUnlock()
 	if !ok {
-		return nil, fmt.Errorf("unknown task: %s", name)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTask, name)
 	}
 
+	run := newTaskRun(name, source, time.Now())
 	op, err := task.TriggerFn(source)
 	if err != nil {
-		return nil, err
+		run.Error = err.Error()
+	} else if op != nil {
+		run.OperationID = op.ID
 	}
-
 	ts.mu.Lock()
-	ts.lastRun[name] = time.Now()
+	ts.lastRun[name] = run
 	ts.mu.Unlock()
-
+	ts.recordRun(run)
+	if err != nil {
+		return nil, err
+	}
 	return op, nil
 }
 
@@ -260,9 +291,11 @@ func (ts *TaskScheduler) ListTasks() []TaskInfo {
 		if task.RunInMaintenanceWindow != nil {
 			info.RunInMaintenanceWindow = task.RunInMaintenanceWindow()
 		}
-		if t, ok := ts.lastRun[name]; ok {
-			s := t.Format(time.RFC3339)
+		if run, ok := ts.lastRun[name]; ok {
+			s := run.StartedAt.UTC().Format(time.RFC3339)
 			info.LastRun = &s
+			info.LastOperationID = run.OperationID
+			info.LastError = run.Error
 		}
 		info.IsRunning = ts.isTaskRunning(info.Name)
 		result = append(result, info)
@@ -0,0 +1,301 @@
+// file: internal/scheduler/schedules.go
+// version: 1.0.0
+// guid: 2e9d4b61-a7c3-4f85-b0d2-6c1e8f3a5d97
+// last-edited: 2026-10-17
+
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/operations"
+	ulid "github.com/oklog/ulid/v2"
+)
+
+// Schedule runs a registered task whenever its cron expression matches,
+// in the configured time zone. Each run enqueues the task's operation as
+// a scheduled trigger, so it shows up in the operations queue like any
+// other. Schedules are kept alongside the interval and maintenance-window
+// triggers, not instead of them.
+type Schedule struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Task    string `json:"task"`
+	Cron    string `json:"cron"`
+	Enabled bool   `json:"enabled"`
+
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastOperationID string     `json:"last_operation_id,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	// NextRunAt is computed when the schedule is read, never stored.
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+}
+
+// ScheduleUpdate is a partial update; nil fields are left unchanged.
+type ScheduleUpdate struct {
+	Name    *string `json:"name"`
+	Task    *string `json:"task"`
+	Cron    *string `json:"cron"`
+	Enabled *bool   `json:"enabled"`
+}
+
+// ErrScheduleNotFound is returned for an unknown schedule ID.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ErrInvalidSchedule wraps validation failures (unknown task, bad cron).
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+const schedulePrefix = "task_schedule:"
+
+// scheduleCheckInterval is how often due schedules are looked for. Cron
+// has minute resolution, so this only needs to be under a minute.
+const scheduleCheckInterval = 20 * time.Second
+
+// ListSchedules returns every schedule ordered by name.
+func (ts *TaskScheduler) ListSchedules() ([]Schedule, error) {
+	store := ts.deps.Store()
+	if store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	pairs, err := store.ScanPrefix(schedulePrefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Schedule, 0, len(pairs))
+	for _, kv := range pairs {
+		var s Schedule
+		if err := json.Unmarshal(kv.Value, &s); err != nil {
+			slog.Warn("Skipping unreadable schedule", "key", kv.Key, "err", err)
+			continue
+		}
+		s.fillNextRun(config.Now())
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// GetSchedule returns one schedule.
+func (ts *TaskScheduler) GetSchedule(id string) (*Schedule, error) {
+	s, err := ts.loadSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	s.fillNextRun(config.Now())
+	return s, nil
+}
+
+// CreateSchedule validates and stores a new schedule. Name defaults to
+// the task name.
+func (ts *TaskScheduler) CreateSchedule(name, task, cron string, enabled bool) (*Schedule, error) {
+	now := time.Now().UTC()
+	s := &Schedule{
+		ID:        ulid.Make().String(),
+		Name:      strings.TrimSpace(name),
+		Task:      strings.TrimSpace(task),
+		Cron:      strings.TrimSpace(cron),
+		Enabled:   enabled,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if s.Name == "" {
+		s.Name = s.Task
+	}
+	if err := ts.validateSchedule(s); err != nil {
+		return nil, err
+	}
+	ts.scheduleMu.Lock()
+	defer ts.scheduleMu.Unlock()
+	if err := ts.saveSchedule(s); err != nil {
+		return nil, err
+	}
+	s.fillNextRun(config.Now())
+	return s, nil
+}
+
+// UpdateSchedule applies a partial update.
+func (ts *TaskScheduler) UpdateSchedule(id string, u ScheduleUpdate) (*Schedule, error) {
+	ts.scheduleMu.Lock()
+	defer ts.scheduleMu.Unlock()
+	s, err := ts.loadSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	if u.Name != nil {
+		s.Name = strings.TrimSpace(*u.Name)
+	}
+	if u.Task != nil {
+		s.Task = strings.TrimSpace(*u.Task)
+	}
+	if u.Cron != nil {
+		s.Cron = strings.TrimSpace(*u.Cron)
+	}
+	if u.Enabled != nil {
+		s.Enabled = *u.Enabled
+	}
+	if s.Name == "" {
+		s.Name = s.Task
+	}
+	if err := ts.validateSchedule(s); err != nil {
+		return nil, err
+	}
+	s.UpdatedAt = time.Now().UTC()
+	if err := ts.saveSchedule(s); err != nil {
+		return nil, err
+	}
+	s.fillNextRun(config.Now())
+	return s, nil
+}
+
+// DeleteSchedule removes a schedule.
+func (ts *TaskScheduler) DeleteSchedule(id string) error {
+	ts.scheduleMu.Lock()
+	defer ts.scheduleMu.Unlock()
+	if _, err := ts.loadSchedule(id); err != nil {
+		return err
+	}
+	return ts.deps.Store().DeleteRaw(schedulePrefix + id)
+}
+
+func (ts *TaskScheduler) validateSchedule(s *Schedule) error {
+	if _, ok := ts.GetTask(s.Task); !ok {
+		return fmt.Errorf("%w: unknown task %q", ErrInvalidSchedule, s.Task)
+	}
+	if _, err := ParseCron(s.Cron); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return nil
+}
+
+func (ts *TaskScheduler) loadSchedule(id string) (*Schedule, error) {
+	store := ts.deps.Store()
+	if store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if id == "" || strings.Contains(id, ":") {
+		return nil, ErrScheduleNotFound
+	}
+	data, err := store.GetRaw(schedulePrefix + id)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrScheduleNotFound
+	}
+	var s Schedule
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("decode schedule %s: %w", id, err)
+	}
+	return &s, nil
+}
+
+func (ts *TaskScheduler) saveSchedule(s *Schedule) error {
+	store := ts.deps.Store()
+	if store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	stored := *s
+	stored.NextRunAt = nil
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return store.SetRaw(schedulePrefix+s.ID, data)
+}
+
+func (s *Schedule) fillNextRun(now time.Time) {
+	s.NextRunAt = nil
+	if !s.Enabled {
+		return
+	}
+	expr, err := ParseCron(s.Cron)
+	if err != nil {
+		return
+	}
+	if next := expr.Next(now); !next.IsZero() {
+		next = next.UTC()
+		s.NextRunAt = &next
+	}
+}
+
+// runSchedules checks for due schedules until shutdown. Only times after
+// the loop started count: runs missed while the server was down are not
+// made up.
+func (ts *TaskScheduler) runSchedules(shutdown <-chan struct{}) {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+	last := config.Now()
+	for {
+		select {
+		case <-ticker.C:
+			now := config.Now()
+			ts.runDueSchedules(last, now)
+			last = now
+		case <-shutdown:
+			return
+		}
+	}
+}
+
+// runDueSchedules runs every enabled schedule with a cron time in
+// (from, to]. A schedule whose task still has an operation running is
+// skipped for that time and the skip recorded in its last_error.
+func (ts *TaskScheduler) runDueSchedules(from, to time.Time) {
+	schedules, err := ts.ListSchedules()
+	if err != nil {
+		return
+	}
+	loc := config.Location()
+	for _, s := range schedules {
+		if !s.Enabled {
+			continue
+		}
+		expr, err := ParseCron(s.Cron)
+		if err != nil {
+			continue
+		}
+		if next := expr.Next(from.In(loc)); next.IsZero() || next.After(to) {
+			continue
+		}
+		ts.fireSchedule(s.ID)
+	}
+}
+
+func (ts *TaskScheduler) fireSchedule(id string) {
+	ts.scheduleMu.Lock()
+	defer ts.scheduleMu.Unlock()
+	s, err := ts.loadSchedule(id)
+	if err != nil {
+		return
+	}
+	now := time.Now().UTC()
+	s.LastRunAt = &now
+	s.LastError = ""
+	if ts.isTaskRunning(s.Task) {
+		s.LastError = "skipped: task already running"
+		slog.Info("Skipping scheduled run, task already running", "schedule", s.Name, "task", s.Task)
+	} else if op, err := ts.runTask(s.Task, operations.TriggerScheduled); err != nil {
+		s.LastError = err.Error()
+		slog.Warn("Scheduled run failed", "schedule", s.Name, "task", s.Task, "err", err)
+	} else if op != nil {
+		s.LastOperationID = op.ID
+		slog.Info("Scheduled run started operation", "schedule", s.Name, "task", s.Task, "op", op.ID)
+	}
+	if err := ts.saveSchedule(s); err != nil {
+		slog.Warn("Failed to record scheduled run", "schedule", s.Name, "err", err)
+	}
+}
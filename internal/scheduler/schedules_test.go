@@ -0,0 +1,161 @@
+// file: internal/scheduler/schedules_test.go
+// version: 1.0.0
+// guid: 6a4d2c8e-b9f1-4e37-a5c0-d3e7b1f92c46
+// last-edited: 2026-10-17
+
+package scheduler
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02 15:04", s)
+		require.NoError(t, err)
+		return v
+	}
+	tests := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{"0 3 * * *", "2026-10-17 02:59", "2026-10-17 03:00"},
+		{"0 3 * * *", "2026-10-17 03:00", "2026-10-18 03:00"},
+		{"@daily", "2026-10-17 12:00", "2026-10-18 00:00"},
+		{"*/15 * * * *", "2026-10-17 12:01", "2026-10-17 12:15"},
+		{"30 1-5/2 * * *", "2026-10-17 02:00", "2026-10-17 03:30"},
+		{"0 0 * * 0", "2026-10-17 12:00", "2026-10-18 00:00"}, // Sunday
+		{"0 0 * * 7", "2026-10-17 12:00", "2026-10-18 00:00"},
+		{"0 4 1 * *", "2026-10-17 12:00", "2026-11-01 04:00"},
+		{"0 0 13 * 5", "2026-10-17 12:00", "2026-10-23 00:00"}, // dom OR dow
+		{"0 0 29 2 *", "2026-10-17 12:00", "2028-02-29 00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec+"@"+tt.from, func(t *testing.T) {
+			e, err := ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, at(tt.want), e.Next(at(tt.from)))
+		})
+	}
+
+	e, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, e.Next(at("2026-10-17 12:00")).IsZero())
+
+	for _, bad := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "x * * * *"} {
+		_, err := ParseCron(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+// memKV backs the raw key-value calls of a MockStore with a map.
+func memKV(store *database.MockStore) {
+	var mu sync.Mutex
+	kv := map[string][]byte{}
+	store.SetRawFunc = func(key string, value []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		kv[key] = append([]byte(nil), value...)
+		return nil
+	}
+	store.GetRawFunc = func(key string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return kv[key], nil
+	}
+	store.DeleteRawFunc = func(key string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(kv, key)
+		return nil
+	}
+	store.ScanPrefixFunc = func(prefix string) ([]database.KVPair, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var out []database.KVPair
+		for k, v := range kv {
+			if strings.HasPrefix(k, prefix) {
+				out = append(out, database.KVPair{Key: k, Value: v})
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+		return out, nil
+	}
+}
+
+func TestSchedules_CRUDAndRun(t *testing.T) {
+	config.AppConfig.Timezone = "UTC"
+	t.Cleanup(func() { config.AppConfig.Timezone = "" })
+
+	store := &database.MockStore{}
+	memKV(store)
+	deps := testDeps()
+	deps.Store = func() database.Store { return store }
+	ts := NewTaskScheduler(deps)
+
+	runs := 0
+	ts.RegisterTask(TaskDefinition{
+		Name: "test_task",
+		TriggerFn: func(source string) (*database.Operation, error) {
+			runs++
+			assert.Equal(t, "scheduled", source)
+			return &database.Operation{ID: "op-1"}, nil
+		},
+		IsEnabled:   func() bool { return true },
+		GetInterval: func() time.Duration { return 0 },
+		RunOnStart:  func() bool { return false },
+	})
+
+	_, err := ts.CreateSchedule("", "no_such_task", "@daily", true)
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+	_, err = ts.CreateSchedule("", "test_task", "not cron", true)
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+
+	s, err := ts.CreateSchedule("", "test_task", "0 3 * * *", true)
+	require.NoError(t, err)
+	assert.Equal(t, "test_task", s.Name)
+	require.NotNil(t, s.NextRunAt)
+
+	list, err := ts.ListSchedules()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	ts.runDueSchedules(day.Add(2*time.Hour+59*time.Minute), day.Add(2*time.Hour+59*time.Minute+30*time.Second))
+	assert.Equal(t, 0, runs)
+	ts.runDueSchedules(day.Add(2*time.Hour+59*time.Minute+30*time.Second), day.Add(3*time.Hour+10*time.Second))
+	assert.Equal(t, 1, runs)
+	// The next check window starts after 03:00 — no second run.
+	ts.runDueSchedules(day.Add(3*time.Hour+10*time.Second), day.Add(3*time.Hour+30*time.Second))
+	assert.Equal(t, 1, runs)
+
+	got, err := ts.GetSchedule(s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", got.LastOperationID)
+	require.NotNil(t, got.LastRunAt)
+
+	disabled := false
+	_, err = ts.UpdateSchedule(s.ID, ScheduleUpdate{Enabled: &disabled})
+	require.NoError(t, err)
+	ts.runDueSchedules(day.Add(26*time.Hour+59*time.Minute), day.Add(27*time.Hour))
+	assert.Equal(t, 1, runs)
+
+	bad := "61 * * * *"
+	_, err = ts.UpdateSchedule(s.ID, ScheduleUpdate{Cron: &bad})
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+
+	require.NoError(t, ts.DeleteSchedule(s.ID))
+	_, err = ts.GetSchedule(s.ID)
+	assert.ErrorIs(t, err, ErrScheduleNotFound)
+	assert.ErrorIs(t, ts.DeleteSchedule(s.ID), ErrScheduleNotFound)
+}
@@ -1,10 +1,10 @@
 // file: internal/scheduler/tasks.go
-// version: 1.0.1
+// version: 1.4.0
 // guid: 9b4c7e21-a5f3-4d08-b2e6-3c8d1f7a0e54
-// last-edited: 2026-05-11
+// last-edited: 2026-10-17
 
 // Package scheduler — task registrations.
-// All 22 registered tasks are defined here. Each task's TriggerFn and
+// All registered tasks are defined here. Each task's TriggerFn and
 // IsEnabled read from SchedulerDeps (not *Server) so the scheduler package
 // remains independent of the server package.
 package scheduler
@@ -17,6 +17,8 @@ import (
 
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/operations"
+	"github.com/falkcorp/audiobook-organizer/internal/retryqueue"
 	ulid "github.com/oklog/ulid/v2"
 )
 
@@ -25,7 +27,11 @@ import (
 // duplicates_ops}.go. They are intentionally minimal — only the fields used
 // when the scheduler triggers the operations.
 
-type libraryScanParams struct{}
+// Scheduler-triggered scans run in the background: they pause while
+// user-started operations run.
+type libraryScanParams struct {
+	Background bool `json:"background,omitempty"`
+}
 
 type libraryOrganizeParams struct{}
 
@@ -67,7 +73,8 @@ func (ts *TaskScheduler) registerAllTasks() {
 			if err != nil {
 				return nil, fmt.Errorf("failed to create operation: %w", err)
 			}
-			if _, enqErr := ts.deps.OpRegistry.EnqueueOp(context.Background(), "library.scan", libraryScanParams{}); enqErr != nil {
+			params := libraryScanParams{Background: source != operations.TriggerManual}
+			if _, enqErr := ts.deps.OpRegistry.EnqueueOp(context.Background(), "library.scan", params); enqErr != nil {
 				return nil, fmt.Errorf("failed to enqueue library.scan: %w", enqErr)
 			}
 			return op, nil
@@ -76,6 +83,7 @@ func (ts *TaskScheduler) registerAllTasks() {
 		GetInterval:            func() time.Duration { return 0 },
 		RunOnStart:             func() bool { return config.AppConfig.ScanOnStartup },
 		RunInMaintenanceWindow: func() bool { return config.AppConfig.MaintenanceWindowLibraryScan },
+		StartWhenIdle:          true,
 	})
 
 	ts.registerTask(TaskDefinition{
@@ -103,6 +111,40 @@ func (ts *TaskScheduler) registerAllTasks() {
 		RunInMaintenanceWindow: func() bool { return config.AppConfig.MaintenanceWindowLibraryOrganize },
 	})
 
+	// Failed-file retries — runs only while queued files are due, so an
+	// empty queue leaves no operation behind.
+	ts.registerTask(TaskDefinition{
+		Name:        "retry_failed_files",
+		Description: "Retry files whose scan or organize step failed for a transient reason",
+		Category:    "library",
+		TriggerFn: func(source string) (*database.Operation, error) {
+			store := ts.deps.Store()
+			if store == nil {
+				return nil, fmt.Errorf("database not initialized")
+			}
+			due, err := retryqueue.Due(retryqueue.Store(store), time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("failed to list retries: %w", err)
+			}
+			if len(due) == 0 {
+				return nil, nil
+			}
+			opID := ulid.Make().String()
+			op, err := store.CreateOperation(opID, "retry_failed_files", nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create operation: %w", err)
+			}
+			if _, enqErr := ts.deps.OpRegistry.EnqueueOp(context.Background(), "library.retry-failed", nil); enqErr != nil {
+				return nil, fmt.Errorf("failed to enqueue library.retry-failed: %w", enqErr)
+			}
+			return op, nil
+		},
+		IsEnabled:              func() bool { return config.AppConfig.RetryMaxAttempts > 0 },
+		GetInterval:            func() time.Duration { return time.Minute },
+		RunOnStart:             func() bool { return true },
+		RunInMaintenanceWindow: func() bool { return false },
+	})
+
 	ts.registerTask(TaskDefinition{
 		Name:        "library_size_refresh",
 		Description: "Walk library + import-path trees to refresh on-disk size cache",
@@ -528,6 +570,32 @@ func (ts *TaskScheduler) registerAllTasks() {
 		RunInMaintenanceWindow: func() bool { return config.AppConfig.MaintenanceWindowDbOptimize },
 	})
 
+	ts.registerTask(TaskDefinition{
+		Name:        "database_backup",
+		Description: "Create a compressed database backup (keeps the 10 most recent)",
+		Category:    "maintenance",
+		TriggerFn: func(source string) (*database.Operation, error) {
+			store := ts.deps.Store()
+			if store == nil {
+				return nil, fmt.Errorf("database not initialized")
+			}
+			opID := ulid.Make().String()
+			op, err := store.CreateOperation(opID, "database-backup", nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create operation: %w", err)
+			}
+			if _, enqErr := ts.deps.OpRegistry.EnqueueOp(context.Background(), "scheduler.database-backup", schedulerExtraOpParams{LegacyOpID: op.ID}); enqErr != nil {
+				return nil, fmt.Errorf("failed to enqueue scheduler.database-backup: %w", enqErr)
+			}
+			return op, nil
+		},
+		// Manual or cron-scheduled only (see schedules.go).
+		IsEnabled:              func() bool { return true },
+		GetInterval:            func() time.Duration { return 0 },
+		RunOnStart:             func() bool { return false },
+		RunInMaintenanceWindow: func() bool { return false },
+	})
+
 	ts.registerTask(TaskDefinition{
 		Name:        "purge_deleted",
 		Description: "Purge soft-deleted books past retention",
@@ -548,14 +616,14 @@ func (ts *TaskScheduler) registerAllTasks() {
 			}
 			return op, nil
 		},
-		IsEnabled: func() bool { return config.AppConfig.PurgeSoftDeletedAfterDays > 0 },
+		IsEnabled: purgeDeletedEnabled,
 		GetInterval: func() time.Duration {
-			if config.AppConfig.PurgeSoftDeletedAfterDays > 0 {
+			if purgeDeletedEnabled() {
 				return 6 * time.Hour
 			}
 			return 0
 		},
-		RunOnStart:             func() bool { return config.AppConfig.PurgeSoftDeletedAfterDays > 0 },
+		RunOnStart:             purgeDeletedEnabled,
 		RunInMaintenanceWindow: func() bool { return config.AppConfig.MaintenanceWindowPurgeDeleted },
 	})
 
@@ -794,3 +862,9 @@ func (ts *TaskScheduler) registerAllTasks() {
 		RunInMaintenanceWindow: func() bool { return true },
 	})
 }
+
+// purgeDeletedEnabled reports whether purge_deleted has anything to do:
+// soft-deleted books to purge or trash to empty.
+func purgeDeletedEnabled() bool {
+	return config.AppConfig.PurgeSoftDeletedAfterDays > 0 || config.AppConfig.TrashRetentionDays > 0
+}
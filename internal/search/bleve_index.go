@@ -1,5 +1,5 @@
 // file: internal/search/bleve_index.go
-// version: 1.1.0
+// version: 1.4.0
 // guid: 3c8e1a2f-4d9b-4f70-a5c6-2f8d0e1b9a47
 //
 // BleveIndex is the single-package wrapper around a Bleve v2 scorch
@@ -20,6 +20,7 @@ package search
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"sync"
 
 	"github.com/blevesearch/bleve/v2"
@@ -198,6 +199,43 @@ func (b *BleveIndex) SearchNative(q query.Query, from, size int) ([]SearchResult
 	return out, res.Total, nil
 }
 
+// SearchNativeAfter runs q sorted by score then document ID and returns
+// the page following the hit identified by after (its formatted score
+// and book ID, see SearchAfterKey); a nil after returns the first page.
+// Unlike offset paging, deep pages cost the same as the first one.
+func (b *BleveIndex) SearchNativeAfter(q query.Query, after []string, size int) ([]SearchResult, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.idx == nil {
+		return nil, fmt.Errorf("bleve index not open")
+	}
+	if q == nil {
+		return nil, fmt.Errorf("nil query")
+	}
+	if size <= 0 {
+		size = 20
+	}
+	req := bleve.NewSearchRequestOptions(q, size, 0, false)
+	req.SortBy([]string{"-_score", "_id"})
+	if len(after) > 0 {
+		req.SearchAfter = after
+	}
+	res, err := b.idx.Search(req)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SearchResult, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		out = append(out, SearchResult{BookID: hit.ID, Score: hit.Score})
+	}
+	return out, nil
+}
+
+// SearchAfterKey formats a hit's position for SearchNativeAfter.
+func SearchAfterKey(r SearchResult) []string {
+	return []string{strconv.FormatFloat(r.Score, 'g', -1, 64), r.BookID}
+}
+
 // DocCount returns the number of documents currently indexed. Useful
 // for readiness checks and tests.
 func (b *BleveIndex) DocCount() (uint64, error) {
@@ -259,7 +297,9 @@ func bookIndexMapping() mapping.IndexMapping {
 	filePath := textAnalyzed(0.5)
 
 	book.AddFieldMappingsAt("title", title)
+	book.AddFieldMappingsAt("alt_titles", textAnalyzed(3.0))
 	book.AddFieldMappingsAt("author", author)
+	book.AddFieldMappingsAt("contributors", textAnalyzed(2.0))
 	book.AddFieldMappingsAt("narrator", narrator)
 	book.AddFieldMappingsAt("series", series)
 	book.AddFieldMappingsAt("publisher", publisher)
@@ -1,5 +1,5 @@
 // file: internal/search/bleve_index_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 8e2c4a1d-5b9f-4f70-a7d6-2f8e0c1b9a58
 
 package search
@@ -7,6 +7,8 @@ package search
 import (
 	"path/filepath"
 	"testing"
+
+	"github.com/blevesearch/bleve/v2"
 )
 
 func openTestIndex(t *testing.T) *BleveIndex {
@@ -145,3 +147,38 @@ func hitIDs(hits []SearchResult) []string {
 	}
 	return out
 }
+
+func TestBleveIndex_SearchNativeAfter(t *testing.T) {
+	idx := openTestIndex(t)
+	for _, id := range []string{"b1", "b2", "b3", "b4", "b5"} {
+		if err := idx.IndexBook(BookDocument{BookID: id, Title: "Stormlight " + id, Author: "Brandon Sanderson"}); err != nil {
+			t.Fatalf("index %s: %v", id, err)
+		}
+	}
+	if err := idx.IndexBook(BookDocument{BookID: "other", Title: "The Fifth Season", Author: "N. K. Jemisin"}); err != nil {
+		t.Fatalf("index other: %v", err)
+	}
+
+	q := bleve.NewMatchQuery("sanderson")
+	seen := map[string]bool{}
+	var after []string
+	for page := 0; page < 5; page++ {
+		hits, err := idx.SearchNativeAfter(q, after, 2)
+		if err != nil {
+			t.Fatalf("search after: %v", err)
+		}
+		for _, h := range hits {
+			if seen[h.BookID] {
+				t.Fatalf("book %s served twice", h.BookID)
+			}
+			seen[h.BookID] = true
+		}
+		if len(hits) < 2 {
+			break
+		}
+		after = SearchAfterKey(hits[len(hits)-1])
+	}
+	if len(seen) != 5 || seen["other"] {
+		t.Errorf("paged hits = %v, want the 5 Sanderson books", seen)
+	}
+}
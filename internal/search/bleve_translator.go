@@ -1,5 +1,5 @@
 // file: internal/search/bleve_translator.go
-// version: 1.0.0
+// version: 1.3.0
 // guid: 9c2a4f1d-5b3e-4f70-a7d6-2e8c0f1b9a47
 //
 // AST → Bleve query translator (spec DES-1 v1.1). Walks the AST
@@ -9,22 +9,24 @@
 // Per-user fields (read_status, progress_pct, last_played) are NOT
 // sent to Bleve — they're split off into a PerUserFilter list for
 // Go-side post-filtering per spec 3.6 §5 + DES-1 v1.1 per-user
-// deferred section.
+// deferred section. Custom metadata fields (custom.<key>) aren't
+// indexed either and take the same route.
 
 package search
 
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/search/query"
 )
 
 // PerUserFilter is a FieldNode that references per-user state
-// (read_status, progress_pct, last_played). Translator collects
-// these into a slice the caller applies after Bleve returns
-// candidate book IDs.
+// (read_status, progress_pct, last_played) or a custom metadata
+// field. Translator collects these into a slice the caller applies
+// after Bleve returns candidate book IDs.
 type PerUserFilter struct {
 	Node *FieldNode
 	// Negated is true when this filter sat under a NotNode in the
@@ -40,6 +42,28 @@ var perUserFieldSet = map[string]struct{}{
 	"last_played":  {},
 }
 
+// CustomFieldPrefix marks a query field as a custom metadata field:
+// custom.source:audible matches books whose "source" value is
+// "audible".
+const CustomFieldPrefix = "custom."
+
+// CustomFieldKey returns the custom field key a query field names,
+// and false when the field is not a custom field.
+func CustomFieldKey(field string) (string, bool) {
+	key, ok := strings.CutPrefix(field, CustomFieldPrefix)
+	return key, ok && key != ""
+}
+
+// isPostFilterField reports whether field is evaluated in Go after
+// the Bleve search rather than by Bleve itself.
+func isPostFilterField(field string) bool {
+	if _, ok := perUserFieldSet[field]; ok {
+		return true
+	}
+	_, ok := CustomFieldKey(field)
+	return ok
+}
+
 // Translate converts an AST into a Bleve query plus any per-user
 // filters. Returns an error if the AST references unknown operators
 // or if a range can't be parsed as numeric.
@@ -141,12 +165,40 @@ func translateNot(n *NotNode, perUser *[]PerUserFilter, negated bool) (query.Que
 }
 
 func translateField(n *FieldNode, perUser *[]PerUserFilter, negated bool) (query.Query, error) {
-	// Per-user fields → post-filter, not Bleve.
-	if _, ok := perUserFieldSet[n.Field]; ok {
+	// Per-user and custom fields → post-filter, not Bleve.
+	if isPostFilterField(n.Field) {
 		*perUser = append(*perUser, PerUserFilter{Node: n, Negated: negated})
 		return nil, nil
 	}
 
+	// title: also matches alternate / localized titles, author: also
+	// matches co-authors, editors and translators.
+	if also, ok := fieldAlsoSearches[n.Field]; ok {
+		alt := *n
+		alt.Field = also
+		tq, err := translateFieldQuery(n)
+		if err != nil {
+			return nil, err
+		}
+		aq, err := translateFieldQuery(&alt)
+		if err != nil {
+			return nil, err
+		}
+		return bleve.NewDisjunctionQuery(tq, aq), nil
+	}
+	return translateFieldQuery(n)
+}
+
+// fieldAlsoSearches maps a query field to the indexed field that a match
+// on it should also try.
+var fieldAlsoSearches = map[string]string{
+	"title":  "alt_titles",
+	"author": "contributors",
+}
+
+// translateFieldQuery builds the Bleve query for a single indexed field.
+func translateFieldQuery(n *FieldNode) (query.Query, error) {
+
 	// Range queries.
 	if n.Op == "range" {
 		return buildNumericRange(n.Field, n.RangeMin, n.RangeMax, true, true)
@@ -216,7 +268,7 @@ func translateField(n *FieldNode, perUser *[]PerUserFilter, negated bool) (query
 func translateValueAlt(n *ValueAltNode, perUser *[]PerUserFilter, negated bool) (query.Query, error) {
 	// Per-user field alternation still goes to post-filter. Rare in
 	// practice but handle it for correctness.
-	if _, ok := perUserFieldSet[n.Field]; ok {
+	if isPostFilterField(n.Field) {
 		// Build a synthetic set of PerUserFilter entries.
 		for _, v := range n.Values {
 			*perUser = append(*perUser, PerUserFilter{
@@ -227,11 +279,17 @@ func translateValueAlt(n *ValueAltNode, perUser *[]PerUserFilter, negated bool)
 		return nil, nil
 	}
 
+	fields := []string{n.Field}
+	if also, ok := fieldAlsoSearches[n.Field]; ok {
+		fields = append(fields, also)
+	}
 	var children []query.Query
 	for _, v := range n.Values {
-		mq := bleve.NewMatchQuery(v)
-		mq.SetField(n.Field)
-		children = append(children, mq)
+		for _, f := range fields {
+			mq := bleve.NewMatchQuery(v)
+			mq.SetField(f)
+			children = append(children, mq)
+		}
 	}
 	if len(children) == 0 {
 		return nil, nil
@@ -1,5 +1,5 @@
 // file: internal/search/bleve_translator_test.go
-// version: 1.0.0
+// version: 1.3.0
 // guid: 1a8c2f4d-5b9e-4f70-a7d6-2e8d0f1b9a57
 
 package search
@@ -20,9 +20,9 @@ func translate(t *testing.T, q string) (hits []SearchResult, total uint64, filte
 	// Seed a small library.
 	docs := []BookDocument{
 		{BookID: "b1", Title: "The Way of Kings", Author: "Brandon Sanderson", Series: "Stormlight Archive", SeriesNumber: 1, Year: 2010, Format: "m4b", Tags: []string{"epic", "favorite"}},
-		{BookID: "b2", Title: "Words of Radiance", Author: "Brandon Sanderson", Series: "Stormlight Archive", SeriesNumber: 2, Year: 2014, Format: "m4b"},
+		{BookID: "b2", Title: "Words of Radiance", Author: "Brandon Sanderson", Series: "Stormlight Archive", SeriesNumber: 2, Year: 2014, Format: "m4b", AltTitles: []string{"Palabras radiantes"}},
 		{BookID: "b3", Title: "Vampire Hunter", Author: "Jane Smith", Year: 2020, Format: "mp3", Tags: []string{"horror"}},
-		{BookID: "b4", Title: "New Dawn", Author: "Jane Smyth", Year: 1995, Format: "mp3"},
+		{BookID: "b4", Title: "New Dawn", Author: "Jane Smyth", Contributors: []string{"Ellen Datlow"}, Year: 1995, Format: "mp3"},
 	}
 	for _, d := range docs {
 		if err := idx.IndexBook(d); err != nil {
@@ -156,6 +156,22 @@ func TestTranslate_PerUserNegated(t *testing.T) {
 	}
 }
 
+func TestTranslate_CustomFieldSplit(t *testing.T) {
+	hits, _, filters := translate(t, "author:sanderson custom.source:audible")
+	if len(hits) != 2 {
+		t.Errorf("Bleve hits = %d, want 2 (custom field split out)", len(hits))
+	}
+	if len(filters) != 1 || filters[0].Node.Field != "custom.source" {
+		t.Fatalf("filters = %+v, want one custom.source filter", filters)
+	}
+	if key, ok := CustomFieldKey(filters[0].Node.Field); !ok || key != "source" {
+		t.Errorf("CustomFieldKey = %q, %v; want source, true", key, ok)
+	}
+	if _, ok := CustomFieldKey("custom."); ok {
+		t.Error("empty custom field key should not be a custom field")
+	}
+}
+
 func TestTranslate_PhraseMatch(t *testing.T) {
 	hits, _, _ := translate(t, `title:"New Dawn"`)
 	if len(hits) != 1 || hits[0].BookID != "b4" {
@@ -163,6 +179,24 @@ func TestTranslate_PhraseMatch(t *testing.T) {
 	}
 }
 
+func TestTranslate_TitleMatchesAltTitles(t *testing.T) {
+	for _, q := range []string{"title:palabras", "palabras", "title:(palabras|nothing)"} {
+		hits, _, _ := translate(t, q)
+		if len(hits) != 1 || hits[0].BookID != "b2" {
+			t.Errorf("%s → %v, want [b2]", q, hitIDs(hits))
+		}
+	}
+}
+
+func TestTranslate_AuthorMatchesContributors(t *testing.T) {
+	for _, q := range []string{"author:datlow", "datlow", "author:(datlow|nobody)"} {
+		hits, _, _ := translate(t, q)
+		if len(hits) != 1 || hits[0].BookID != "b4" {
+			t.Errorf("%s → %v, want [b4]", q, hitIDs(hits))
+		}
+	}
+}
+
 func TestTranslate_Empty(t *testing.T) {
 	// A completely empty / match-all query through the translator.
 	q, pu, err := Translate(nil)
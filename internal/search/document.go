@@ -1,5 +1,5 @@
 // file: internal/search/document.go
-// version: 1.0.0
+// version: 1.3.0
 // guid: 6a2d8f1c-4b3e-4f60-a7c5-2e8d0f1b9a47
 //
 // BookDocument is the flat, Bleve-indexable projection of a Book
@@ -12,8 +12,9 @@ package search
 // BookDocument is the denormalized record indexed in Bleve.
 //
 // Field boost policy (applied via the index mapping, not this
-// struct): title 3×, author 2×, series 1.5×, narrator 1.2×,
-// description 0.5×. All other text fields default boost 1.0.
+// struct): title and alt_titles 3×, author and contributors 2×,
+// series 1.5×, narrator 1.2×, description 0.5×. All other text fields default
+// boost 1.0.
 // Numeric and keyword fields are stored without analysis so
 // range + exact queries land on them.
 type BookDocument struct {
@@ -29,6 +30,17 @@ type BookDocument struct {
 	Description string `json:"description,omitempty"`
 	FilePath    string `json:"file_path,omitempty"`
 
+	// Alternate / localized titles (book_alternative_titles). Indexed
+	// with the same boost as Title so a translated or regional title
+	// ranks like the primary one; `title:` queries also search here.
+	AltTitles []string `json:"alt_titles,omitempty"`
+
+	// Every credited author, editor and translator from book_authors
+	// other than the primary Author, so co-authored books and
+	// anthologies match on any contributor. `author:` queries also
+	// search here.
+	Contributors []string `json:"contributors,omitempty"`
+
 	// Tag names flattened for multi-value match. Each tag is indexed
 	// as a keyword (case-insensitive exact). Search `tag:favorites`
 	// matches if "favorites" appears in this slice.
@@ -44,14 +56,14 @@ type BookDocument struct {
 	ASIN         string `json:"asin,omitempty"`
 
 	// Numeric (for range queries: year:>2000, bitrate:<128, …)
-	Year          int   `json:"year,omitempty"`
-	SeriesNumber  int   `json:"series_number,omitempty"`
-	DurationSec   int   `json:"duration_seconds,omitempty"`
-	BitrateKbps   int   `json:"bitrate_kbps,omitempty"`
-	SampleRateHz  int   `json:"sample_rate_hz,omitempty"`
-	Channels      int   `json:"channels,omitempty"`
-	BitDepth      int   `json:"bit_depth,omitempty"`
-	FileSizeBytes int64 `json:"file_size_bytes,omitempty"`
+	Year          int     `json:"year,omitempty"`
+	SeriesNumber  float64 `json:"series_number,omitempty"` // 1.5 for novellas
+	DurationSec   int     `json:"duration_seconds,omitempty"`
+	BitrateKbps   int     `json:"bitrate_kbps,omitempty"`
+	SampleRateHz  int     `json:"sample_rate_hz,omitempty"`
+	Channels      int     `json:"channels,omitempty"`
+	BitDepth      int     `json:"bit_depth,omitempty"`
+	FileSizeBytes int64   `json:"file_size_bytes,omitempty"`
 
 	// Boolean flags (for `has_cover:true`-style queries)
 	HasCover bool `json:"has_cover,omitempty"`
@@ -1,5 +1,5 @@
 // file: internal/search/index_builder.go
-// version: 1.2.0
+// version: 1.5.0
 // guid: 8a1c2f4d-5b3e-4f70-b7d6-2e8d0f1b9a57
 //
 // Helpers that project a database.Book (with its author, series,
@@ -125,7 +125,9 @@ func BookToDoc(store interface {
 		doc.Year = *book.PrintYear
 	}
 	if book.SeriesSequence != nil {
-		doc.SeriesNumber = *book.SeriesSequence
+		if f, ok := book.SeriesSequence.Float(); ok {
+			doc.SeriesNumber = f
+		}
 	}
 	if book.Duration != nil {
 		doc.DurationSec = *book.Duration
@@ -147,18 +149,49 @@ func BookToDoc(store interface {
 	}
 	doc.HasCover = book.CoverURL != nil && *book.CoverURL != ""
 
-	// Resolve author name.
+	// Resolve author name, then the other credited contributors.
 	if store != nil && book.AuthorID != nil {
 		if author, err := store.GetAuthorByID(*book.AuthorID); err == nil && author != nil {
 			doc.Author = author.Name
 		}
 	}
+	if store != nil && book.ID != "" {
+		if rows, err := store.GetBookAuthors(book.ID); err == nil {
+			for _, name := range database.BookAuthorNames(store, rows, false) {
+				if name != doc.Author {
+					doc.Contributors = append(doc.Contributors, name)
+				}
+			}
+		}
+	}
 	// Resolve series name.
 	if store != nil && book.SeriesID != nil {
 		if series, err := store.GetSeriesByID(*book.SeriesID); err == nil && series != nil {
 			doc.Series = series.Name
 		}
 	}
+	// Alternate titles live outside the book row. The lookup is on the
+	// metadata store rather than BookReader, so probe for it instead of
+	// widening every caller's store interface.
+	if alts, ok := store.(interface {
+		GetBookAlternativeTitles(bookID string) ([]database.BookAlternativeTitle, error)
+	}); ok {
+		if rows, err := alts.GetBookAlternativeTitles(book.ID); err == nil {
+			for _, r := range rows {
+				if r.Title != "" {
+					doc.AltTitles = append(doc.AltTitles, r.Title)
+				}
+			}
+		}
+	}
+	// A book also answers to its work's alternate titles.
+	if works, ok := store.(interface {
+		GetWorkByID(id string) (*database.Work, error)
+	}); ok && book.WorkID != nil && *book.WorkID != "" {
+		if w, err := works.GetWorkByID(*book.WorkID); err == nil && w != nil {
+			doc.AltTitles = append(doc.AltTitles, w.AltTitles...)
+		}
+	}
 	// Resolve tags (user + system). Tags on a book come from the
 	// existing BookTag / BookUserTag APIs.
 	if store != nil {
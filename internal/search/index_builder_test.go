@@ -1,5 +1,5 @@
 // file: internal/search/index_builder_test.go
-// version: 1.1.0
+// version: 1.4.0
 // guid: 9d8e2c1a-5b4f-4f70-a7c6-2d8e0f1b9a47
 
 package search
@@ -28,7 +28,7 @@ func TestBookToDoc_ResolvesRelations(t *testing.T) {
 	book := &database.Book{
 		ID: "b1", Title: "The Way of Kings",
 		AuthorID: &author.ID, SeriesID: &series.ID,
-		SeriesSequence:       &seq,
+		SeriesSequence:       database.NewSeriesSeq(seq),
 		Format:               "m4b",
 		Language:             &lang,
 		AudiobookReleaseYear: &year,
@@ -56,7 +56,7 @@ func TestBookToDoc_ResolvesRelations(t *testing.T) {
 		t.Errorf("Series = %q, want resolved from SeriesID", doc.Series)
 	}
 	if doc.SeriesNumber != 1 {
-		t.Errorf("SeriesNumber = %d, want 1", doc.SeriesNumber)
+		t.Errorf("SeriesNumber = %v, want 1", doc.SeriesNumber)
 	}
 	if doc.Year != 2010 {
 		t.Errorf("Year = %d, want 2010", doc.Year)
@@ -153,3 +153,66 @@ func TestBookToDoc_MissingRelationsSafelySkipped(t *testing.T) {
 		t.Errorf("Title = %q", doc.Title)
 	}
 }
+
+func TestBookToDoc_AltTitles(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	work, err := store.CreateWork(&database.Work{Title: "Foundation", AltTitles: []string{"Fondation"}})
+	if err != nil {
+		t.Fatalf("create work: %v", err)
+	}
+	created, err := store.CreateBook(&database.Book{ID: "b1", Title: "Foundation", WorkID: &work.ID})
+	if err != nil {
+		t.Fatalf("create book: %v", err)
+	}
+	if err := store.AddBookAlternativeTitle(created.ID, "Foundation (Japanese)", "user", "ja"); err != nil {
+		t.Fatalf("add alt title: %v", err)
+	}
+
+	doc := BookToDoc(store, created)
+	want := []string{"Foundation (Japanese)", "Fondation"}
+	if strings.Join(doc.AltTitles, "|") != strings.Join(want, "|") {
+		t.Errorf("AltTitles = %v, want %v", doc.AltTitles, want)
+	}
+}
+
+func TestBookToDoc_Contributors(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	var ids []int
+	for _, name := range []string{"Neil Gaiman", "Terry Pratchett", "Ellen Datlow"} {
+		a, err := store.CreateAuthor(name)
+		if err != nil {
+			t.Fatalf("create author: %v", err)
+		}
+		ids = append(ids, a.ID)
+	}
+	created, err := store.CreateBook(&database.Book{ID: "b1", Title: "Good Omens", AuthorID: &ids[0]})
+	if err != nil {
+		t.Fatalf("create book: %v", err)
+	}
+	if err := store.SetBookAuthors(created.ID, []database.BookAuthor{
+		{BookID: created.ID, AuthorID: ids[0], Role: "author", Position: 0},
+		{BookID: created.ID, AuthorID: ids[1], Role: "co-author", Position: 1},
+		{BookID: created.ID, AuthorID: ids[2], Role: "editor", Position: 2},
+	}); err != nil {
+		t.Fatalf("set authors: %v", err)
+	}
+
+	doc := BookToDoc(store, created)
+	if doc.Author != "Neil Gaiman" {
+		t.Errorf("Author = %q", doc.Author)
+	}
+	want := []string{"Terry Pratchett", "Ellen Datlow"}
+	if strings.Join(doc.Contributors, "|") != strings.Join(want, "|") {
+		t.Errorf("Contributors = %v, want %v", doc.Contributors, want)
+	}
+}
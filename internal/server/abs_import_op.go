@@ -0,0 +1,452 @@
+// file: internal/server/abs_import_op.go
+// version: 1.1.0
+// guid: c47d2e19-6a8b-4f35-9e02-b1d5a7c3f864
+// last-edited: 2026-10-17
+
+// abs_import_op registers "audiobookshelf.import", which migrates an
+// Audiobookshelf library in one run: fetch its libraries and users,
+// import each book from its (optionally remapped) path with the
+// Audiobookshelf metadata, create the users, and copy their listening
+// progress. A re-run finds books by their Audiobookshelf item ID and only
+// overwrites progress that is newer on the Audiobookshelf side.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/absimport"
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/importer"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/readstatus"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+)
+
+type absImportParams struct {
+	// ServerURL is the Audiobookshelf server; empty uses the
+	// audiobookshelf_url setting. The audiobookshelf_api_token setting is
+	// only ever sent to that configured server: a different ServerURL
+	// needs its own APIToken.
+	ServerURL string `json:"server_url"`
+	// APIToken authenticates against ServerURL when it is not the
+	// configured server.
+	APIToken string `json:"api_token"`
+	// SnapshotPath reads a JSON snapshot file on this server instead of
+	// the API.
+	SnapshotPath string `json:"snapshot_path"`
+	// LibraryIDs limits the import to these libraries; empty imports
+	// every book library.
+	LibraryIDs []string `json:"library_ids"`
+	// PathMap rewrites item paths from the Audiobookshelf server's view
+	// to this server's.
+	PathMap []absimport.PathMapping `json:"path_map"`
+	// ImportUsers creates missing users. Defaults to true.
+	ImportUsers *bool `json:"import_users"`
+	// ImportProgress copies listening progress. Defaults to true.
+	ImportProgress *bool `json:"import_progress"`
+	// DryRun stops after fetching and logs what would be imported.
+	DryRun bool `json:"dry_run"`
+}
+
+// absSource is the source name Audiobookshelf item IDs are recorded
+// under as external IDs and book metadata source.
+const absSource = "audiobookshelf"
+
+// RegisterABSImportOp registers the "audiobookshelf.import" OperationDef.
+func (s *Server) RegisterABSImportOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              "audiobookshelf.import",
+		Plugin:          "audiobookshelf",
+		DisplayName:     "Audiobookshelf Import",
+		Description:     "Import books, metadata, users and listening progress from an Audiobookshelf server or snapshot file.",
+		DefaultPriority: opsregistry.PriorityNormal,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         24 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeRestart,
+		ConcurrencyKey:  "audiobookshelf.import",
+		Permissions:     []auth.Permission{auth.PermIntegrationsManage, auth.PermUsersManage},
+		Capabilities: []opsregistry.Capability{
+			opsregistry.CapFilesRead, opsregistry.CapLibraryWrite, opsregistry.CapNetworkGeneric,
+		},
+		Phases: []opsregistry.Phase{{Name: "fetch"}, {Name: "books"}, {Name: "users"}, {Name: "progress"}},
+		Run: func(ctx context.Context, raw json.RawMessage, reporter opsregistry.Reporter) error {
+			var p absImportParams
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &p); err != nil {
+					return fmt.Errorf("audiobookshelf.import: decode params: %w", err)
+				}
+			}
+			return s.runABSImport(ctx, p, reporter)
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterABSImportOp(reg) })
+}
+
+func (s *Server) runABSImport(ctx context.Context, p absImportParams, reporter opsregistry.Reporter) error {
+	store := s.Store()
+	if store == nil {
+		return errors.New("audiobookshelf.import: database not initialized")
+	}
+	logf := func(level slog.Level, format string, args ...any) {
+		_ = reporter.Log(level, fmt.Sprintf(format, args...))
+	}
+
+	var snap *absimport.Snapshot
+	err := reporter.RunPhase(ctx, "fetch", func(ctx context.Context, r opsregistry.Reporter) error {
+		var err error
+		if p.SnapshotPath != "" {
+			snap, err = readABSSnapshot(p.SnapshotPath, p.LibraryIDs)
+		} else {
+			snap, err = fetchABSSnapshot(ctx, p, logf)
+		}
+		if err != nil {
+			return err
+		}
+		logf(slog.LevelInfo, "Read %d libraries, %d items and %d users", len(snap.Libraries), len(snap.Items), len(snap.Users))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if p.DryRun {
+		for _, it := range snap.Items {
+			path := absimport.MapPath(it.Path, p.PathMap)
+			_, statErr := os.Stat(path)
+			logf(slog.LevelInfo, "Would import %q from %s (found on disk: %t)", it.Media.Metadata.Title, path, statErr == nil)
+		}
+		for _, u := range snap.Users {
+			logf(slog.LevelInfo, "Would import user %s with %d progress entries", u.Username, len(u.MediaProgress))
+		}
+		logf(slog.LevelInfo, "Dry run: %d items and %d users would be imported", len(snap.Items), len(snap.Users))
+		return nil
+	}
+
+	// bookIDs maps Audiobookshelf item IDs to the imported books.
+	bookIDs := make(map[string]string, len(snap.Items))
+	imported, failed := 0, 0
+	err = reporter.RunPhase(ctx, "books", func(ctx context.Context, r opsregistry.Reporter) error {
+		for i, it := range snap.Items {
+			if r.IsCanceled() {
+				return ctx.Err()
+			}
+			_ = r.UpdateProgress(i, len(snap.Items), "Importing "+it.Media.Metadata.Title)
+			r.SetCurrentItem(it.Media.Metadata.Title)
+			if it.IsMissing {
+				logf(slog.LevelWarn, "Skipping %q: missing on the Audiobookshelf server", it.Media.Metadata.Title)
+				continue
+			}
+			bookID, created, err := s.importABSItem(ctx, store, it, absimport.MapPath(it.Path, p.PathMap))
+			if err != nil {
+				failed++
+				logf(slog.LevelError, "Import %q (%s): %v", it.Media.Metadata.Title, it.Path, err)
+				continue
+			}
+			bookIDs[it.ID] = bookID
+			if created {
+				imported++
+			}
+		}
+		_ = r.UpdateProgress(len(snap.Items), len(snap.Items), fmt.Sprintf("Imported %d books", imported))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// userIDs maps Audiobookshelf usernames to organizer user IDs.
+	userIDs := make(map[string]string, len(snap.Users))
+	createUsers := p.ImportUsers == nil || *p.ImportUsers
+	err = reporter.RunPhase(ctx, "users", func(ctx context.Context, r opsregistry.Reporter) error {
+		for i, u := range snap.Users {
+			if r.IsCanceled() {
+				return ctx.Err()
+			}
+			_ = r.UpdateProgress(i, len(snap.Users), "User "+u.Username)
+			id, err := importABSUser(store, u, createUsers)
+			switch {
+			case err != nil:
+				logf(slog.LevelError, "User %s: %v", u.Username, err)
+			case id == "":
+				logf(slog.LevelInfo, "Skipping user %s: not in this library and import_users is off", u.Username)
+			default:
+				userIDs[u.Username] = id
+			}
+		}
+		_ = r.UpdateProgress(len(snap.Users), len(snap.Users), fmt.Sprintf("Matched %d users", len(userIDs)))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	copied := 0
+	if p.ImportProgress == nil || *p.ImportProgress {
+		err = reporter.RunPhase(ctx, "progress", func(ctx context.Context, r opsregistry.Reporter) error {
+			for i, u := range snap.Users {
+				if r.IsCanceled() {
+					return ctx.Err()
+				}
+				_ = r.UpdateProgress(i, len(snap.Users), "Progress for "+u.Username)
+				userID, ok := userIDs[u.Username]
+				if !ok {
+					continue
+				}
+				for _, mp := range u.MediaProgress {
+					bookID, ok := bookIDs[mp.LibraryItemID]
+					if !ok || mp.EpisodeID != "" {
+						continue
+					}
+					ok, err := importABSProgress(store, userID, bookID, mp)
+					if err != nil {
+						logf(slog.LevelError, "Progress for %s on book %s: %v", u.Username, bookID, err)
+						continue
+					}
+					if ok {
+						copied++
+					}
+				}
+			}
+			_ = r.UpdateProgress(len(snap.Users), len(snap.Users), fmt.Sprintf("Copied %d progress entries", copied))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	logf(slog.LevelInfo, "Audiobookshelf import finished: %d books imported, %d already present, %d failed; %d users; %d progress entries copied",
+		imported, len(bookIDs)-imported, failed, len(userIDs), copied)
+	if len(bookIDs) == 0 && failed > 0 {
+		return fmt.Errorf("audiobookshelf.import: all %d items failed", failed)
+	}
+	return nil
+}
+
+// readABSSnapshot reads a snapshot file, keeping only the given libraries'
+// items when ids are set.
+func readABSSnapshot(path string, ids []string) (*absimport.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("audiobookshelf.import: %w", err)
+	}
+	defer f.Close()
+	snap, err := absimport.ReadSnapshot(f)
+	if err != nil || len(ids) == 0 {
+		return snap, err
+	}
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	items := snap.Items[:0]
+	for _, it := range snap.Items {
+		if want[it.LibraryID] {
+			items = append(items, it)
+		}
+	}
+	snap.Items = items
+	return snap, nil
+}
+
+// sameABSServer reports whether two Audiobookshelf server URLs name the
+// same server, ignoring case and a trailing slash.
+func sameABSServer(a, b string) bool {
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
+
+// fetchABSSnapshot reads the book libraries and users from the server's
+// API. A token that may not list users imports only its own user.
+func fetchABSSnapshot(ctx context.Context, p absImportParams, logf func(slog.Level, string, ...any)) (*absimport.Snapshot, error) {
+	cfg := config.Snapshot()
+	configured := strings.TrimSpace(cfg.AudiobookshelfURL)
+	c := &absimport.Client{BaseURL: strings.TrimSpace(p.ServerURL), Token: strings.TrimSpace(p.APIToken)}
+	if c.BaseURL == "" {
+		c.BaseURL = configured
+	}
+	if c.Token == "" {
+		// Whoever may start the op must not be able to point it at their
+		// own host and collect the stored token.
+		if !sameABSServer(c.BaseURL, configured) {
+			return nil, errors.New("audiobookshelf.import: server_url is not the configured audiobookshelf_url; pass api_token for it")
+		}
+		c.Token = strings.TrimSpace(cfg.AudiobookshelfAPIToken)
+	}
+	if c.BaseURL == "" || c.Token == "" {
+		return nil, errors.New("audiobookshelf.import: set audiobookshelf_url and audiobookshelf_api_token in settings, or pass snapshot_path")
+	}
+	libs, err := c.Libraries(ctx, p.LibraryIDs)
+	if err != nil {
+		return nil, err
+	}
+	snap := &absimport.Snapshot{Libraries: libs}
+	for _, l := range libs {
+		items, err := c.Items(ctx, l.ID)
+		if err != nil {
+			return nil, err
+		}
+		logf(slog.LevelInfo, "Library %s: %d items", l.Name, len(items))
+		snap.Items = append(snap.Items, items...)
+	}
+	snap.Users, err = c.Users(ctx)
+	if errors.Is(err, absimport.ErrUsersForbidden) {
+		logf(slog.LevelWarn, "The API token is not an admin's; importing only its own user")
+		var me *absimport.User
+		if me, err = c.Me(ctx); err == nil {
+			snap.Users = []absimport.User{*me}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// importABSItem finds or imports the book at path and applies the item's
+// metadata to it, returning its ID and whether it was new.
+func (s *Server) importABSItem(ctx context.Context, store database.Store, it absimport.Item, path string) (string, bool, error) {
+	if id, _ := store.GetBookByExternalID(absSource, it.ID); id != "" {
+		return id, false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false, fmt.Errorf("not found on this server (check path_map): %w", err)
+	}
+	book, _ := store.GetBookByFilePath(path)
+	created := book == nil
+	if created {
+		if book, err = s.importABSPath(ctx, store, path, info.IsDir()); err != nil {
+			return "", false, err
+		}
+	}
+	if it.Media.Metadata.Title != "" {
+		if s.metadataFetchService != nil {
+			s.metadataFetchService.ApplyMetadataToBook(book, it.Metadata())
+		} else {
+			book.Title = it.Media.Metadata.Title
+		}
+		source := absSource
+		book.MetadataSource = &source
+		if _, err := store.UpdateBook(book.ID, book); err != nil {
+			return "", false, fmt.Errorf("apply metadata: %w", err)
+		}
+	}
+	if err := store.CreateExternalIDMapping(&database.ExternalIDMapping{
+		Source: absSource, ExternalID: it.ID, BookID: book.ID, FilePath: path,
+	}); err != nil {
+		return "", false, fmt.Errorf("record item ID: %w", err)
+	}
+	return book.ID, created, nil
+}
+
+// importABSPath imports a single-file book through the import service and
+// a book folder through the scanner, which groups its files.
+func (s *Server) importABSPath(ctx context.Context, store database.Store, path string, isDir bool) (*database.Book, error) {
+	if !isDir {
+		if s.importService == nil {
+			return nil, errors.New("import service not initialized")
+		}
+		resp, err := s.importService.ImportFile(&importer.ImportFileRequest{FilePath: path})
+		if err != nil {
+			return nil, err
+		}
+		return bookOrError(store.GetBookByID(resp.ID))
+	}
+	books, err := scanner.ScanDirectoryContext(ctx, path, 1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	if len(books) == 0 {
+		return nil, errors.New("no audio files in folder")
+	}
+	if err := scanner.ProcessBooksParallel(ctx, books, 1, nil, nil); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	return bookOrError(store.GetBookByFilePath(books[0].FilePath))
+}
+
+func bookOrError(book *database.Book, err error) (*database.Book, error) {
+	if err == nil && book == nil {
+		err = errors.New("book not found after import")
+	}
+	return book, err
+}
+
+// importABSUser returns the organizer user with u's username, creating
+// it when create is set. Created users have no password; an admin resets
+// it to invite them. Audiobookshelf admins become admins, everyone else
+// a viewer.
+func importABSUser(store database.Store, u absimport.User, create bool) (string, error) {
+	if existing, err := store.GetUserByUsername(u.Username); err != nil {
+		return "", err
+	} else if existing != nil {
+		return existing.ID, nil
+	}
+	if !create {
+		return "", nil
+	}
+	roleName := auth.SeedRoleViewer
+	if u.Type == "root" || u.Type == "admin" {
+		roleName = auth.SeedRoleAdmin
+	}
+	roleID := roleName
+	if role, _ := store.GetRoleByName(roleName); role != nil {
+		roleID = role.ID
+	}
+	status := "active"
+	if !u.IsActive {
+		status = "locked"
+	}
+	email := strings.ToLower(u.Username) + "@audiobookshelf.invalid"
+	created, err := store.CreateUser(u.Username, email, "", "", []string{roleID}, status)
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// importABSProgress copies one progress entry unless the organizer's is
+// as recent, marking finished books finished. It reports whether it
+// copied anything.
+func importABSProgress(store database.Store, userID, bookID string, mp absimport.MediaProgress) (bool, error) {
+	numID := database.BookNumericID(bookID)
+	at := mp.UpdatedAt()
+	if existing, err := store.GetPlaybackProgress(userID, numID); err != nil {
+		return false, err
+	} else if existing != nil && !at.After(existing.UpdatedAt) {
+		return false, nil
+	}
+	percent := min(100, mp.Progress*100)
+	if mp.IsFinished {
+		percent = 100
+	}
+	if err := store.UpdatePlaybackProgress(&database.PlaybackProgress{
+		UserID:      userID,
+		BookID:      numID,
+		AudiobookID: bookID,
+		PositionSec: int(mp.CurrentTime),
+		Percent:     percent,
+		UpdatedAt:   at,
+	}); err != nil {
+		return false, err
+	}
+	if mp.IsFinished {
+		if _, err := readstatus.SetManualStatus(store, userID, bookID, database.UserBookStatusFinished); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,52 @@
+// file: internal/server/abs_import_op_test.go
+// version: 1.0.0
+// guid: 5c1e8a37-b2f4-4d90-8e6a-07d3f9b2c415
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchABSSnapshot_OverriddenURLNeverGetsConfiguredToken(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"libraries": []}`))
+	}))
+	defer other.Close()
+
+	orig := config.Snapshot()
+	t.Cleanup(func() { config.Mutate(func(c *config.Config) { *c = orig }) })
+	config.Mutate(func(c *config.Config) {
+		c.AudiobookshelfURL = "http://abs.invalid"
+		c.AudiobookshelfAPIToken = "configured-secret"
+	})
+	logf := func(slog.Level, string, ...any) {}
+
+	_, err := fetchABSSnapshot(context.Background(), absImportParams{ServerURL: other.URL}, logf)
+	require.Error(t, err)
+	assert.Empty(t, seen, "no request without an explicit token")
+
+	_, _ = fetchABSSnapshot(context.Background(), absImportParams{ServerURL: other.URL + "/", APIToken: "their-token"}, logf)
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, seen)
+	for _, h := range seen {
+		assert.Equal(t, "Bearer their-token", h)
+	}
+}
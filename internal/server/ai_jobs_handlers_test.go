@@ -1,7 +1,7 @@
 // file: internal/server/ai_jobs_handlers_test.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: 136d5ad0-d226-471a-8c2c-64992ba3882d
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 // NOTE(fable5 T022): Ported from SQLiteStore to PebbleStore.
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,16 +27,7 @@ func setupAIJobsTestServer(t *testing.T) (*Server, *database.PebbleStore) {
 	t.Helper()
 	gin.SetMode(gin.TestMode)
 
-	store, err := database.NewPebbleStore(t.TempDir())
-	require.NoError(t, err)
-	require.NoError(t, database.RunMigrations(store))
-
-	origStore := database.GetGlobalStore()
-	database.SetGlobalStore(store)
-	t.Cleanup(func() {
-		database.SetGlobalStore(origStore)
-		store.Close()
-	})
+	store := storetest.NewGlobal(t)
 
 	srv := NewServer(store)
 	return srv, store
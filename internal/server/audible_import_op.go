@@ -0,0 +1,286 @@
+// file: internal/server/audible_import_op.go
+// version: 1.0.0
+// guid: 5a0e9c71-d8b4-4f26-a3e7-1c6b82f4d09e
+// last-edited: 2026-10-16
+
+// audible_import_op registers "audible.import", which imports a user's
+// Audible purchases end to end in one chained run: match the library
+// export to downloaded AAX/AAXC files, decrypt them to M4B, import the
+// M4Bs and apply the export's metadata, then fetch full metadata by ASIN.
+// Each step is a phase, and a re-run skips what an earlier run finished.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audibleimport"
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/importer"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/transcode"
+)
+
+type audibleImportParams struct {
+	// ExportPath is the library export (TSV or CSV) on the server.
+	ExportPath string `json:"export_path"`
+	// SourceDir is searched recursively for .aax/.aaxc files.
+	SourceDir string `json:"source_dir"`
+	// OutputDir receives the converted M4Bs; empty writes each next to
+	// its source file.
+	OutputDir string `json:"output_dir"`
+	// FetchMetadata fetches full metadata (description, cover, ...) by
+	// ASIN after import. Defaults to true.
+	FetchMetadata *bool `json:"fetch_metadata"`
+	// DryRun stops after matching and logs what would be imported.
+	DryRun bool `json:"dry_run"`
+}
+
+// audiblePurchaseDateField is the custom field the purchase date from
+// the export is recorded in.
+const audiblePurchaseDateField = "audible_purchase_date"
+
+// RegisterAudibleImportOp registers the "audible.import" OperationDef.
+func (s *Server) RegisterAudibleImportOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              "audible.import",
+		Plugin:          "audible",
+		DisplayName:     "Audible Library Import",
+		Description:     "Match an Audible library export to downloaded AAX/AAXC files, convert them to M4B and import them with metadata.",
+		DefaultPriority: opsregistry.PriorityNormal,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         24 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeRestart,
+		ConcurrencyKey:  "audible.import",
+		Permissions:     []auth.Permission{auth.PermIntegrationsManage},
+		Capabilities: []opsregistry.Capability{
+			opsregistry.CapFilesRead, opsregistry.CapFilesWrite, opsregistry.CapSubprocessSpawn,
+			opsregistry.CapLibraryWrite, opsregistry.CapNetworkAudible,
+		},
+		Phases: []opsregistry.Phase{{Name: "match"}, {Name: "convert"}, {Name: "import"}},
+		Run: func(ctx context.Context, raw json.RawMessage, reporter opsregistry.Reporter) error {
+			var p audibleImportParams
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &p); err != nil {
+					return fmt.Errorf("audible.import: decode params: %w", err)
+				}
+			}
+			return s.runAudibleImport(ctx, p, reporter)
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterAudibleImportOp(reg) })
+}
+
+// audibleConverted is a match whose M4B is ready to import.
+type audibleConverted struct {
+	match audibleimport.Match
+	path  string
+}
+
+func (s *Server) runAudibleImport(ctx context.Context, p audibleImportParams, reporter opsregistry.Reporter) error {
+	if p.ExportPath == "" || p.SourceDir == "" {
+		return errors.New("audible.import: export_path and source_dir are required")
+	}
+	store := s.Store()
+	if store == nil {
+		return errors.New("audible.import: database not initialized")
+	}
+	logf := func(level slog.Level, format string, args ...any) {
+		_ = reporter.Log(level, fmt.Sprintf(format, args...))
+	}
+
+	var matches []audibleimport.Match
+	err := reporter.RunPhase(ctx, "match", func(ctx context.Context, r opsregistry.Reporter) error {
+		f, err := os.Open(p.ExportPath)
+		if err != nil {
+			return fmt.Errorf("audible.import: %w", err)
+		}
+		titles, err := audibleimport.ParseExport(f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		files, err := audibleimport.FindFiles(p.SourceDir)
+		if err != nil {
+			return fmt.Errorf("audible.import: scan %s: %w", p.SourceDir, err)
+		}
+		res := audibleimport.MatchFiles(titles, files)
+		logf(slog.LevelInfo, "Export lists %d titles; found %d AAX/AAXC files; matched %d", len(titles), len(files), len(res.Matched))
+		for _, t := range res.UnmatchedTitles {
+			logf(slog.LevelDebug, "No downloaded file for %s (%s)", t.Title, t.ASIN)
+		}
+		for _, f := range res.UnmatchedFiles {
+			logf(slog.LevelWarn, "No export row for %s", f)
+		}
+		for _, m := range res.Matched {
+			if id, _ := store.GetBookByExternalID("audible", m.Title.ASIN); id != "" {
+				logf(slog.LevelInfo, "Skipping %s: already in the library as book %s", m.Title.Title, id)
+				continue
+			}
+			matches = append(matches, m)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if p.DryRun {
+		for _, m := range matches {
+			logf(slog.LevelInfo, "Would import %s (%s) from %s [matched by %s]", m.Title.Title, m.Title.ASIN, m.File, m.By)
+		}
+		logf(slog.LevelInfo, "Dry run: %d titles would be imported", len(matches))
+		return nil
+	}
+	if len(matches) == 0 {
+		logf(slog.LevelInfo, "Nothing to import")
+		return nil
+	}
+
+	var converted []audibleConverted
+	failed := 0
+	err = reporter.RunPhase(ctx, "convert", func(ctx context.Context, r opsregistry.Reporter) error {
+		ffmpeg, err := transcode.FindFFmpeg()
+		if err != nil {
+			return fmt.Errorf("audible.import: %w", err)
+		}
+		opts := audibleimport.ConvertOptions{
+			FFmpeg:          ffmpeg,
+			ActivationBytes: strings.TrimSpace(config.Snapshot().AudibleActivationBytes),
+			OutputDir:       p.OutputDir,
+		}
+		for i, m := range matches {
+			if r.IsCanceled() {
+				return ctx.Err()
+			}
+			_ = r.UpdateProgress(i, len(matches), "Converting "+m.Title.Title)
+			r.SetCurrentItem(m.Title.Title)
+			// A previous run may have converted it before being interrupted.
+			if out := audibleimport.OutputPath(m, p.OutputDir); audibleOutputExists(out) {
+				converted = append(converted, audibleConverted{match: m, path: out})
+				continue
+			}
+			out, err := audibleimport.Convert(ctx, m, opts)
+			if errors.Is(err, audibleimport.ErrNoActivationBytes) {
+				return fmt.Errorf("audible.import: %w; set audible_activation_bytes in settings", err)
+			}
+			if err != nil {
+				failed++
+				logf(slog.LevelError, "Convert %s: %v", m.File, err)
+				continue
+			}
+			converted = append(converted, audibleConverted{match: m, path: out})
+		}
+		_ = r.UpdateProgress(len(matches), len(matches), fmt.Sprintf("Converted %d files", len(converted)))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	fetch := p.FetchMetadata == nil || *p.FetchMetadata
+	err = reporter.RunPhase(ctx, "import", func(ctx context.Context, r opsregistry.Reporter) error {
+		for i, c := range converted {
+			if r.IsCanceled() {
+				return ctx.Err()
+			}
+			_ = r.UpdateProgress(i, len(converted), "Importing "+c.match.Title.Title)
+			r.SetCurrentItem(c.match.Title.Title)
+			bookID, err := s.importAudibleTitle(store, c)
+			if err != nil {
+				failed++
+				logf(slog.LevelError, "Import %s: %v", c.path, err)
+				continue
+			}
+			imported++
+			if fetch && s.metadataFetchService != nil {
+				if _, err := s.metadataFetchService.FetchMetadataForBook(bookID); err != nil {
+					logf(slog.LevelWarn, "Metadata fetch for %s: %v", c.match.Title.Title, err)
+				}
+			}
+		}
+		_ = r.UpdateProgress(len(converted), len(converted), fmt.Sprintf("Imported %d books", imported))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logf(slog.LevelInfo, "Audible import finished: %d imported, %d failed", imported, failed)
+	if imported == 0 && failed > 0 {
+		return fmt.Errorf("audible.import: all %d titles failed", failed)
+	}
+	return nil
+}
+
+// importAudibleTitle imports one converted file and applies the export's
+// metadata to the new book, returning its ID.
+func (s *Server) importAudibleTitle(store database.Store, c audibleConverted) (string, error) {
+	resp, err := s.importService.ImportFile(&importer.ImportFileRequest{FilePath: c.path})
+	if err != nil {
+		return "", err
+	}
+	book, err := store.GetBookByID(resp.ID)
+	if err != nil || book == nil {
+		return "", fmt.Errorf("reload imported book %s: %w", resp.ID, err)
+	}
+	t := c.match.Title
+	if s.metadataFetchService != nil {
+		s.metadataFetchService.ApplyMetadataToBook(book, t.Metadata())
+	} else {
+		book.Title = t.Title
+		book.ASIN = &t.ASIN
+	}
+	source := "audible"
+	book.MetadataSource = &source
+	if _, err := store.UpdateBook(book.ID, book); err != nil {
+		return "", fmt.Errorf("apply export metadata: %w", err)
+	}
+	if err := store.CreateExternalIDMapping(&database.ExternalIDMapping{
+		Source: "audible", ExternalID: t.ASIN, BookID: book.ID, FilePath: c.path,
+	}); err != nil {
+		return "", fmt.Errorf("record ASIN: %w", err)
+	}
+	if t.PurchaseDate != nil {
+		recordAudiblePurchaseDate(store, book.ID, *t.PurchaseDate)
+	}
+	return book.ID, nil
+}
+
+// recordAudiblePurchaseDate stores the purchase date in a custom field,
+// defining the field on first use. Stores without custom fields skip it.
+func recordAudiblePurchaseDate(store database.Store, bookID string, at time.Time) {
+	cfs, ok := database.AsCustomFieldStore(store)
+	if !ok {
+		return
+	}
+	if def, err := cfs.GetCustomField(audiblePurchaseDateField); err == nil && def == nil {
+		def := database.CustomFieldDefinition{
+			Key:         audiblePurchaseDateField,
+			Label:       "Audible Purchase Date",
+			Type:        database.CustomFieldDate,
+			Description: "When the book was bought on Audible, from the library export.",
+		}
+		if err := def.Normalize(); err != nil || cfs.SaveCustomField(def) != nil {
+			return
+		}
+	}
+	_ = cfs.SetBookCustomFields(bookID, map[string]string{audiblePurchaseDateField: at.UTC().Format("2006-01-02")})
+}
+
+func audibleOutputExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Size() > 0
+}
@@ -1,7 +1,7 @@
 // file: internal/server/audiobooks_helpers.go
-// version: 1.0.0
+// version: 1.6.0
 // guid: 439aa827-edea-481d-8918-ddacd2c140b7
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Server-package helpers relocated out of audiobooks_handlers.go when the
 // audiobooks HTTP handlers were extracted into the handlers/audiobooks
@@ -30,7 +30,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/activity"
+	audiobookspkg "github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/fingerprint"
 )
 
@@ -59,39 +61,38 @@ func (s *Server) buildAudiobookListResponse(ctx context.Context, limit, offset i
 	// Fetch book_files ONCE up front; thread the map into both enrichment
 	// (for duration/file-size aggregation) and the fingerprint compute loop
 	// below. Previously each path independently called GetBookFilesForIDs.
-	bookFilesMap := s.audiobookService.FetchBookFilesForBooks(books)
+	// A sparse fieldset without file-derived fields skips the load entirely.
+	loadFiles := filters.Fields.HasAny(audiobookspkg.FileDerivedFields...)
+	var bookFilesMap map[string][]database.BookFile
+	if loadFiles {
+		bookFilesMap = s.audiobookService.FetchBookFilesForBooks(books)
+	}
 
 	enriched := s.audiobookService.EnrichAudiobooksWithNamesAndFiles(books, bookFilesMap)
 
-	for i, book := range enriched {
-		files := bookFilesMap[book.ID]
-		fpFiles := make([]fingerprint.FileWithFingerprint, len(files))
-		for j := range files {
-			fpFiles[j] = &files[j]
+	if loadFiles {
+		for i, book := range enriched {
+			files := bookFilesMap[book.ID]
+			fpFiles := make([]fingerprint.FileWithFingerprint, len(files))
+			for j := range files {
+				fpFiles[j] = &files[j]
+			}
+			status, fpCount, coverage, lastFp := fingerprint.ComputeFingerprintFields(fpFiles)
+			enriched[i].FingerprintStatus = status
+			enriched[i].FingerprintedFileCount = fpCount
+			enriched[i].TotalFileCount = len(files)
+			enriched[i].CoveragePercent = coverage
+			enriched[i].LastFingerprintedAt = lastFp
 		}
-		status, fpCount, coverage, lastFp := fingerprint.ComputeFingerprintFields(fpFiles)
-		enriched[i].FingerprintStatus = status
-		enriched[i].FingerprintedFileCount = fpCount
-		enriched[i].TotalFileCount = len(files)
-		enriched[i].CoveragePercent = coverage
-		enriched[i].LastFingerprintedAt = lastFp
 	}
 
+	// count predates total and carries the same value.
 	totalCount := len(enriched)
-	hasFilters := filters.IsPrimaryVersion != nil || filters.LibraryState != "" || filters.Tag != "" || len(filters.Tags) > 0
-	if search == "" && authorID == nil && seriesID == nil {
-		if hasFilters {
-			if tc, err := s.audiobookService.CountAudiobooksFiltered(ctx, filters); err == nil {
-				totalCount = tc
-			}
-		} else {
-			if tc, err := s.audiobookService.CountAudiobooks(ctx); err == nil {
-				totalCount = tc
-			}
-		}
+	if tc, err := s.audiobookService.CountAudiobooksMatching(ctx, search, authorID, seriesID, filters); err == nil {
+		totalCount = tc
 	}
 
-	return gin.H{"items": enriched, "count": totalCount, "limit": limit, "offset": offset}, nil
+	return gin.H{"items": database.ProjectSlice(enriched, filters.Fields), "count": totalCount, "total": totalCount, "limit": limit, "offset": offset}, nil
 }
 
 const facetsCacheKey = "all"
@@ -124,11 +125,12 @@ func (s *Server) warmFacetsCache() {
 	slog.Info("facets cache warm genres, languages", "genres_count", len(genres), "languages_count", len(languages))
 }
 
-// runAutoPurgeSoftDeleted purges soft-deleted books older than the configured
-// retention window, emitting activity log entries. Invoked from the maintenance
+// runAutoPurgeSoftDeleted empties trash entries past trash_retention_days,
+// then purges soft-deleted books older than the configured retention
+// window, emitting activity log entries. Invoked from the maintenance
 // scheduler (server_maintenance_deps.go RunAutoPurgeSoftDeleted).
 func (s *Server) runAutoPurgeSoftDeleted(opID string) {
-	if config.AppConfig.PurgeSoftDeletedAfterDays <= 0 {
+	if config.AppConfig.PurgeSoftDeletedAfterDays <= 0 && config.AppConfig.TrashRetentionDays <= 0 {
 		return
 	}
 	if s.Store() == nil {
@@ -136,6 +138,22 @@ func (s *Server) runAutoPurgeSoftDeleted(opID string) {
 		return
 	}
 
+	if emptied, err := s.audiobookService.FinalizePendingPurges(context.Background()); err != nil {
+		slog.Warn("Emptying trash failed", "err", err)
+	} else if emptied.Attempted > 0 {
+		msg := fmt.Sprintf("Emptied trash: purged %d/%d books (%d files deleted, %d errors)",
+			emptied.Purged, emptied.Attempted, emptied.FilesDeleted, len(emptied.Errors))
+		slog.Info("Auto-purge", "msg", msg)
+		activity.EmitInfo(s.activityWriter, opID, "purge-deleted", "purge-deleted", msg)
+		for _, e := range emptied.Errors {
+			activity.LogBatch(s.activityWriter, opID, "purge-deleted", "purge-deleted",
+				activity.BatchItem{Name: e, Detail: "error"})
+		}
+	}
+	if config.AppConfig.PurgeSoftDeletedAfterDays <= 0 {
+		return
+	}
+
 	days := config.AppConfig.PurgeSoftDeletedAfterDays
 	result, err := s.audiobookService.PurgeSoftDeletedBooks(context.Background(), config.AppConfig.PurgeSoftDeletedDeleteFiles, &days)
 	if err != nil {
@@ -143,11 +161,11 @@ func (s *Server) runAutoPurgeSoftDeleted(opID string) {
 		return
 	}
 
-	msg := fmt.Sprintf("Purged %d/%d soft-deleted books (%d files deleted, %d errors)",
-		result.Purged, result.Attempted, result.FilesDeleted, len(result.Errors))
+	msg := fmt.Sprintf("Purged %d/%d soft-deleted books (%d files deleted, %d moved to trash, %d errors)",
+		result.Purged, result.Attempted, result.FilesDeleted, result.PendingPurge, len(result.Errors))
 	slog.Info("Auto-purge", "msg", msg)
 	activity.EmitInfo(s.activityWriter, opID, "purge-deleted", "purge-deleted", msg,
-		activity.TagsIf(result.Purged == 0, activity.NoOpTag)...)
+		activity.TagsIf(result.Purged == 0 && result.PendingPurge == 0, activity.NoOpTag)...)
 	for _, e := range result.Errors {
 		activity.LogBatch(s.activityWriter, opID, "purge-deleted", "purge-deleted",
 			activity.BatchItem{Name: e, Detail: "error"})
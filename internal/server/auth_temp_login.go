@@ -1,5 +1,5 @@
 // file: internal/server/auth_temp_login.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: 5b6c7d8e-9f0a-1b2c-3d4e-5f6a7b8c9d0e
 
 // Temp-login token: admin mints a short-lived single-use URL for a user.
@@ -13,7 +13,6 @@ package server
 import (
 	"crypto/rand"
 	"encoding/hex"
-	"fmt"
 	"net/http"
 	"strings"
 	"sync"
@@ -104,18 +103,9 @@ func (s *Server) createTempLoginToken(c *gin.Context) {
 	tempLoginTokens[token] = tempLoginEntry{UserID: user.ID, ExpiresAt: expires}
 	tempLoginMu.Unlock()
 
-	// Build absolute URL from the inbound request. Falls back to a
-	// relative path if the Host header is unset (shouldn't happen in
-	// production but defensive).
-	scheme := "https"
-	if c.Request.TLS == nil && !handlers.IsHTTPSRequest(c) {
-		scheme = "http"
-	}
-	host := strings.TrimSpace(c.Request.Host)
-	loginURL := fmt.Sprintf("/auth/temp-login?token=%s", token)
-	if host != "" {
-		loginURL = fmt.Sprintf("%s://%s/auth/temp-login?token=%s", scheme, host, token)
-	}
+	// Absolute URL under base_url when configured, otherwise built from
+	// the inbound request (relative if the Host header is unset).
+	loginURL := httputil.ExternalURL(c, "/auth/temp-login?token="+token)
 
 	httputil.RespondWithCreated(c, gin.H{
 		"token":      token,
@@ -136,12 +126,12 @@ func (s *Server) createTempLoginToken(c *gin.Context) {
 // error query param so the SPA can show a message.
 func (s *Server) consumeTempLoginToken(c *gin.Context) {
 	if s.Store() == nil {
-		c.Redirect(http.StatusSeeOther, "/login?error=server")
+		c.Redirect(http.StatusSeeOther, httputil.AppPath("/login?error=server"))
 		return
 	}
 	token := strings.TrimSpace(c.Query("token"))
 	if token == "" {
-		c.Redirect(http.StatusSeeOther, "/login?error=missing_token")
+		c.Redirect(http.StatusSeeOther, httputil.AppPath("/login?error=missing_token"))
 		return
 	}
 
@@ -155,13 +145,13 @@ func (s *Server) consumeTempLoginToken(c *gin.Context) {
 	tempLoginMu.Unlock()
 
 	if !ok || time.Now().After(entry.ExpiresAt) {
-		c.Redirect(http.StatusSeeOther, "/login?error=invalid_or_expired_token")
+		c.Redirect(http.StatusSeeOther, httputil.AppPath("/login?error=invalid_or_expired_token"))
 		return
 	}
 
 	user, err := s.Store().GetUserByID(entry.UserID)
 	if err != nil || user == nil {
-		c.Redirect(http.StatusSeeOther, "/login?error=user_not_found")
+		c.Redirect(http.StatusSeeOther, httputil.AppPath("/login?error=user_not_found"))
 		return
 	}
 	// Tighten: only "active" users can consume a temp-login token.
@@ -169,7 +159,7 @@ func (s *Server) consumeTempLoginToken(c *gin.Context) {
 	// that was later disabled / suspended — without this check the
 	// user could still log in via the stale URL.
 	if !strings.EqualFold(user.Status, "active") {
-		c.Redirect(http.StatusSeeOther, "/login?error=account_inactive")
+		c.Redirect(http.StatusSeeOther, httputil.AppPath("/login?error=account_inactive"))
 		return
 	}
 
@@ -180,11 +170,11 @@ func (s *Server) consumeTempLoginToken(c *gin.Context) {
 		handlers.DefaultSessionTTL,
 	)
 	if err != nil {
-		c.Redirect(http.StatusSeeOther, "/login?error=session_failed")
+		c.Redirect(http.StatusSeeOther, httputil.AppPath("/login?error=session_failed"))
 		return
 	}
 	handlers.SetSessionCookie(c, session.ID, session.ExpiresAt)
-	c.Redirect(http.StatusSeeOther, "/")
+	c.Redirect(http.StatusSeeOther, httputil.AppPath("/"))
 }
 
 // permTempLoginMint returns the permission required to mint temp-login
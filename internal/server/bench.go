@@ -1,5 +1,5 @@
 // file: internal/server/bench.go
-// version: 1.3.0
+// version: 1.4.0
 // guid: 5e6f7a8b-9c0d-1234-ef01-555555555555
 
 //go:build bench
@@ -30,15 +30,15 @@ import (
 )
 
 // setupBenchRoutes registers the bench experiment API endpoints.
-func (s *Server) setupBenchRoutes(protected *gin.RouterGroup) {
+func (s *Server) setupBenchRoutes(protected *routeGroup) {
 	bench := protected.Group("/bench")
 	{
-		bench.GET("/status", s.benchStatus)
-		bench.POST("/submit", s.benchSubmit)
-		bench.GET("/check/:runDir", s.benchCheck)
-		bench.GET("/runs", s.benchListRuns)
-		bench.POST("/pass2", s.benchPass2)
-		bench.POST("/crossval", s.benchCrossval)
+		bench.GET("/status", noPermission, s.benchStatus)
+		bench.POST("/submit", noPermission, s.benchSubmit)
+		bench.GET("/check/:runDir", noPermission, s.benchCheck)
+		bench.GET("/runs", noPermission, s.benchListRuns)
+		bench.POST("/pass2", noPermission, s.benchPass2)
+		bench.POST("/crossval", noPermission, s.benchCrossval)
 	}
 	slog.Info("Bench experiment routes enabled")
 }
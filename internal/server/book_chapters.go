@@ -0,0 +1,157 @@
+// file: internal/server/book_chapters.go
+// version: 1.0.0
+// guid: 4b9d2e71-8c05-4a3f-9e6b-17d0c5a8f392
+// last-edited: 2026-10-16
+//
+// Chapter editing: replace a book's chapter list (typically to fix up
+// markers proposed by silence detection) and write the list into the
+// book's audio file.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/tagger"
+	"github.com/falkcorp/audiobook-organizer/internal/transcode"
+	"github.com/gin-gonic/gin"
+)
+
+// errMultiFileChapters is returned for books split over several files,
+// where every file is already a part and markers would have to span
+// files.
+var errMultiFileChapters = errors.New("chapters can only be written to single-file books")
+
+// handleUpdateBookChapters replaces a book's chapter list. The markers are
+// stored as manual edits until written into the file.
+// PUT /api/v1/audiobooks/:id/chapters
+func (s *Server) handleUpdateBookChapters(c *gin.Context) {
+	bookID := c.Param("id")
+	book, err := s.Store().GetBookByID(bookID)
+	if err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "book", bookID)
+		return
+	}
+	var req struct {
+		Chapters []database.BookChapter `json:"chapters"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, "invalid request body")
+		return
+	}
+	durationSec := 0.0
+	if book.Duration != nil {
+		durationSec = float64(*book.Duration)
+	}
+	chapters, err := normalizeChapters(req.Chapters, durationSec)
+	if err != nil {
+		httputil.RespondWithValidationError(c, "chapters", err.Error())
+		return
+	}
+	for i := range chapters {
+		chapters[i].Source = database.ChapterSourceManual
+	}
+	if err := database.PutBookChapters(s.Store(), bookID, chapters); err != nil {
+		httputil.InternalError(c, "failed to save chapters", err)
+		return
+	}
+	if chapters == nil {
+		chapters = []database.BookChapter{}
+	}
+	httputil.RespondWithOK(c, gin.H{"chapters": chapters, "count": len(chapters)})
+}
+
+// handleWriteBookChapters embeds the stored chapter list into the book's
+// audio file.
+// POST /api/v1/audiobooks/:id/chapters/write
+func (s *Server) handleWriteBookChapters(c *gin.Context) {
+	bookID := c.Param("id")
+	book, err := s.Store().GetBookByID(bookID)
+	if err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "book", bookID)
+		return
+	}
+	chapters, err := database.GetBookChapters(s.Store(), bookID)
+	if err != nil {
+		httputil.InternalError(c, "failed to load chapters", err)
+		return
+	}
+	if len(chapters) == 0 {
+		httputil.RespondWithBadRequest(c, "book has no chapters to write")
+		return
+	}
+	path, err := s.writeBookChapters(c.Request.Context(), book, chapters)
+	if errors.Is(err, errMultiFileChapters) {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if err != nil {
+		httputil.InternalError(c, "failed to write chapters", err)
+		return
+	}
+	chapters, _ = database.GetBookChapters(s.Store(), bookID)
+	httputil.RespondWithOK(c, gin.H{"chapters": chapters, "count": len(chapters), "path": path})
+}
+
+// normalizeChapters validates an edited chapter list and renumbers it.
+// Chapters must be in order, not overlap and, when the duration is
+// known, end within it; blank titles become "Chapter N".
+func normalizeChapters(in []database.BookChapter, durationSec float64) ([]database.BookChapter, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := slices.Clone(in)
+	for i := range out {
+		ch := &out[i]
+		ch.Index = i + 1
+		ch.Title = strings.TrimSpace(ch.Title)
+		if ch.Title == "" {
+			ch.Title = fmt.Sprintf("Chapter %d", i+1)
+		}
+		if ch.StartSec < 0 || ch.EndSec <= ch.StartSec {
+			return nil, fmt.Errorf("chapter %d must end after it starts", i+1)
+		}
+		if i > 0 && ch.StartSec < out[i-1].EndSec-0.001 {
+			return nil, fmt.Errorf("chapter %d starts before chapter %d ends", i+1, i)
+		}
+		// Durations are stored in whole seconds; allow the rounding.
+		if durationSec > 0 && ch.EndSec > durationSec+1 {
+			return nil, fmt.Errorf("chapter %d ends after the book (%.0fs)", i+1, durationSec)
+		}
+	}
+	return out, nil
+}
+
+// writeBookChapters writes chapters into book's file and records them as
+// embedded. It returns the path written.
+func (s *Server) writeBookChapters(ctx context.Context, book *database.Book, chapters []database.BookChapter) (string, error) {
+	if files, err := s.Store().GetBookFiles(book.ID); err == nil && len(files) > 1 {
+		return "", errMultiFileChapters
+	}
+	if !slices.Contains(tagger.ChapterFormats, strings.ToLower(filepath.Ext(book.FilePath))) {
+		return "", fmt.Errorf("chapters can't be written to %s files", filepath.Ext(book.FilePath))
+	}
+	ffmpeg, err := transcode.FindFFmpeg()
+	if err != nil {
+		return "", err
+	}
+	path, err := tagger.WriteChapters(ctx, ffmpeg, book.FilePath, chapters, s.safeWriteDeps())
+	if err != nil {
+		return "", err
+	}
+	written := slices.Clone(chapters)
+	for i := range written {
+		written[i].Source = ""
+	}
+	if err := database.PutBookChapters(s.Store(), book.ID, written); err != nil {
+		return path, fmt.Errorf("chapters written but not recorded: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,79 @@
+// file: internal/server/book_chapters_test.go
+// version: 1.1.0
+// guid: 9c4e1b7a-6f20-4d83-a5e9-2b8d0f3c7a16
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestNormalizeChapters(t *testing.T) {
+	got, err := normalizeChapters([]database.BookChapter{
+		{Index: 7, Title: " Opening ", StartSec: 0, EndSec: 600},
+		{Index: 9, Title: "", StartSec: 600, EndSec: 1200.4},
+	}, 1200)
+	if err != nil {
+		t.Fatalf("normalizeChapters: %v", err)
+	}
+	if got[0].Index != 1 || got[0].Title != "Opening" || got[1].Index != 2 || got[1].Title != "Chapter 2" {
+		t.Errorf("normalizeChapters = %+v", got)
+	}
+
+	bad := map[string][]database.BookChapter{
+		"empty chapter": {{StartSec: 10, EndSec: 10}},
+		"overlap":       {{StartSec: 0, EndSec: 600}, {StartSec: 500, EndSec: 900}},
+		"past the end":  {{StartSec: 0, EndSec: 1300}},
+	}
+	for name, in := range bad {
+		if _, err := normalizeChapters(in, 1200); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestGetAudiobook_ChapterCount(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	store := database.GetGlobalStore()
+	book, err := store.CreateBook(&database.Book{Title: "Chaptered", FilePath: "/tmp/chaptered.m4b"})
+	if err != nil {
+		t.Fatalf("CreateBook: %v", err)
+	}
+	chapterCount := func() *int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/audiobooks/"+book.ID, nil)
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET audiobook: %d %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Data struct {
+				ChapterCount *int `json:"chapter_count"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp.Data.ChapterCount
+	}
+
+	if n := chapterCount(); n == nil || *n != 0 {
+		t.Errorf("chapter_count without chapters = %v, want 0", n)
+	}
+	if err := database.PutBookChapters(store, book.ID, []database.BookChapter{
+		{Index: 1, Title: "One", EndSec: 60},
+		{Index: 2, Title: "Two", StartSec: 60, EndSec: 120, Source: database.ChapterSourceCue},
+	}); err != nil {
+		t.Fatalf("PutBookChapters: %v", err)
+	}
+	if n := chapterCount(); n == nil || *n != 2 {
+		t.Errorf("chapter_count = %v, want 2", n)
+	}
+}
@@ -0,0 +1,101 @@
+// file: internal/server/book_convert.go
+// version: 1.0.0
+// guid: cc8b9292-a6cf-469e-9c15-ff45d801b058
+// last-edited: 2026-10-17
+//
+// Book conversion: merge a book's audio files into a single chaptered
+// M4B (or M4A) through the library.transcode operation.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
+	"github.com/falkcorp/audiobook-organizer/internal/transcode"
+	"github.com/gin-gonic/gin"
+)
+
+// findFFmpeg is transcode.FindFFmpeg; tests replace it.
+var findFFmpeg = transcode.FindFFmpeg
+
+// convertBookRequest is the body of POST /audiobooks/:id/convert. Both
+// fields are optional: format defaults to m4b and bitrate (AAC kbps) to
+// 128.
+type convertBookRequest struct {
+	Format  string `json:"format"`
+	Bitrate int    `json:"bitrate"`
+}
+
+// handleConvertBook queues a library.transcode of the book and returns
+// its operation ID; progress arrives on the operations event stream like
+// any other operation. The converted file becomes the book's primary
+// version and the original is kept as a non-primary one.
+// POST /api/v1/audiobooks/:id/convert
+func (s *Server) handleConvertBook(c *gin.Context) {
+	var req convertBookRequest
+	if body, _ := c.GetRawData(); len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			httputil.RespondWithBadRequest(c, "invalid request body")
+			return
+		}
+	}
+	req.Format = strings.ToLower(strings.TrimSpace(req.Format))
+	if req.Format == "" {
+		req.Format = "m4b"
+	}
+	if !slices.Contains(transcode.OutputFormats, req.Format) {
+		httputil.RespondWithValidationError(c, "format", "must be one of "+strings.Join(transcode.OutputFormats, ", "))
+		return
+	}
+	if req.Bitrate != 0 && (req.Bitrate < minTranscodeBitrate || req.Bitrate > maxTranscodeBitrate) {
+		httputil.RespondWithValidationError(c, "bitrate",
+			fmt.Sprintf("must be between %d and %d", minTranscodeBitrate, maxTranscodeBitrate))
+		return
+	}
+
+	bookID := c.Param("id")
+	book, err := s.Store().GetBookByID(bookID)
+	if err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "book", bookID)
+		return
+	}
+	files, err := s.Store().GetBookFiles(bookID)
+	if err != nil {
+		httputil.InternalError(c, "failed to load book files", err)
+		return
+	}
+	if len(files) <= 1 && strings.EqualFold(filepath.Ext(book.FilePath), "."+req.Format) {
+		httputil.RespondWithConflict(c, "book is already a single "+req.Format+" file")
+		return
+	}
+	if _, err := findFFmpeg(); err != nil {
+		httputil.RespondWithServiceUnavailable(c, "ffmpeg is not installed")
+		return
+	}
+	if s.opRegistry == nil {
+		httputil.RespondWithInternalError(c, "operation registry not initialized")
+		return
+	}
+
+	opID, err := s.opRegistry.EnqueueOp(c.Request.Context(), "library.transcode", libraryTranscodeParams{
+		BookID:       bookID,
+		OutputFormat: req.Format,
+		Bitrate:      req.Bitrate,
+		KeepOriginal: true,
+	})
+	if handlers.RespondWithParamsError(c, err) {
+		return
+	}
+	if err != nil {
+		httputil.InternalError(c, "failed to enqueue conversion", err)
+		return
+	}
+	httputil.RespondWithSuccess(c, http.StatusAccepted, gin.H{"op_id": opID, "id": opID, "format": req.Format})
+}
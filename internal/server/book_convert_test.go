@@ -0,0 +1,65 @@
+// file: internal/server/book_convert_test.go
+// version: 1.0.0
+// guid: 28f3bcaa-47e1-48ce-b882-c95f3073be79
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertBook(t *testing.T) {
+	srv := setupMaintenanceTestServer(t)
+	require.NoError(t, srv.RegisterLibraryTranscodeOp(srv.opRegistry))
+	prev := findFFmpeg
+	t.Cleanup(func() { findFFmpeg = prev })
+	findFFmpeg = func() (string, error) { return "/usr/bin/ffmpeg", nil }
+
+	store := srv.Store()
+	multi := storetest.Book(t, store, database.Book{FilePath: "/library/Multi/part01.mp3", Format: "mp3"})
+	storetest.BookFile(t, store, multi, database.BookFile{TrackNumber: 1})
+	storetest.BookFile(t, store, multi, database.BookFile{TrackNumber: 2})
+	single := storetest.Book(t, store, database.Book{})
+
+	post := func(id, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/audiobooks/"+id+"/convert", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(multi.ID, `{"bitrate": 96}`)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var resp struct {
+		Data struct {
+			OpID   string `json:"op_id"`
+			Format string `json:"format"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "m4b", resp.Data.Format)
+	row, err := store.GetOperationV2(resp.Data.OpID)
+	require.NoError(t, err)
+	assert.Equal(t, "library.transcode", row.DefID)
+	assert.JSONEq(t, `{"book_id":"`+multi.ID+`","output_format":"m4b","bitrate":96,"keep_original":true}`, row.Params)
+
+	assert.Equal(t, http.StatusAccepted, post(single.ID, `{"format":"m4a"}`).Code, "a single m4b can become an m4a")
+	assert.Equal(t, http.StatusConflict, post(single.ID, "").Code)
+	assert.Equal(t, http.StatusNotFound, post("missing", "").Code)
+	assert.Equal(t, http.StatusBadRequest, post(multi.ID, `{"format":"flac"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(multi.ID, `{"bitrate":8}`).Code)
+
+	findFFmpeg = func() (string, error) { return "", errors.New("not found") }
+	assert.Equal(t, http.StatusServiceUnavailable, post(multi.ID, "").Code)
+}
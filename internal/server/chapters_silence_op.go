@@ -0,0 +1,202 @@
+// file: internal/server/chapters_silence_op.go
+// version: 1.0.0
+// guid: d3a7f0c8-9e42-4b16-8c5d-62b1e07a4f93
+// last-edited: 2026-10-16
+
+// chapters_silence_op registers "chapters.detect-silence", which proposes
+// chapter markers for chapterless single-file books by running ffmpeg's
+// silencedetect over them. Proposals are stored like probed chapters,
+// marked as unwritten, so they show up in the chapters API for review and
+// survive rescans until written into the file.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audio"
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+	"github.com/falkcorp/audiobook-organizer/internal/tagger"
+	"github.com/falkcorp/audiobook-organizer/internal/transcode"
+)
+
+type chapterSilenceParams struct {
+	// BookIDs limits the run to these books; empty means every
+	// single-file m4b/m4a/mp3 book without chapters.
+	BookIDs []string `json:"book_ids"`
+	audio.SilenceOptions
+	// Overwrite replaces proposals or edits that haven't been written yet.
+	Overwrite bool `json:"overwrite"`
+	// Write embeds the proposed chapters into the file straight away
+	// instead of leaving them for review.
+	Write bool `json:"write"`
+}
+
+// RegisterChapterSilenceOp registers the "chapters.detect-silence"
+// OperationDef.
+func (s *Server) RegisterChapterSilenceOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              "chapters.detect-silence",
+		Plugin:          "chapters",
+		DisplayName:     "Detect Chapters From Silence",
+		Description:     "Propose chapter markers for chapterless books at long silences in the audio.",
+		DefaultPriority: opsregistry.PriorityLow,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         24 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeRestart,
+		ConcurrencyKey:  "chapters.detect-silence",
+		Permissions:     []auth.Permission{auth.PermLibraryEditMetadata},
+		Capabilities: []opsregistry.Capability{
+			opsregistry.CapFilesRead, opsregistry.CapFilesWrite, opsregistry.CapSubprocessSpawn,
+			opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite,
+		},
+		Run: func(ctx context.Context, raw json.RawMessage, reporter opsregistry.Reporter) error {
+			var p chapterSilenceParams
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &p); err != nil {
+					return fmt.Errorf("chapters.detect-silence: decode params: %w", err)
+				}
+			}
+			return s.runChapterSilence(ctx, p, reporter)
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterChapterSilenceOp(reg) })
+}
+
+func (s *Server) runChapterSilence(ctx context.Context, p chapterSilenceParams, reporter opsregistry.Reporter) error {
+	store := s.Store()
+	if store == nil {
+		return errors.New("chapters.detect-silence: database not initialized")
+	}
+	ffmpeg, err := transcode.FindFFmpeg()
+	if err != nil {
+		return fmt.Errorf("chapters.detect-silence: %w", err)
+	}
+	logf := func(level slog.Level, format string, args ...any) {
+		_ = reporter.Log(level, fmt.Sprintf(format, args...))
+	}
+
+	books, err := s.chapterSilenceTargets(store, p.BookIDs)
+	if err != nil {
+		return err
+	}
+	// Progress is counted in thousandths of a book so long decodes move
+	// the bar.
+	const steps = 1000
+	total := len(books) * steps
+	proposed, skipped, failed := 0, 0, 0
+	for i, book := range books {
+		if reporter.IsCanceled() {
+			return ctx.Err()
+		}
+		title := book.Title
+		reporter.SetCurrentItem(title)
+		_ = reporter.UpdateProgress(i*steps, total, "Checking "+title)
+
+		existing, _ := database.GetBookChapters(store, book.ID)
+		if len(existing) > 0 && !(p.Overwrite && database.ChaptersUnwritten(existing)) {
+			skipped++
+			continue
+		}
+		embedded, durationSec, ok := scanner.ProbeChapters(ctx, book.FilePath)
+		if !ok {
+			failed++
+			logf(slog.LevelWarn, "Probe %s failed", book.FilePath)
+			continue
+		}
+		if len(embedded) > 0 {
+			// The file has chapters the library never recorded.
+			_ = database.PutBookChapters(store, book.ID, embedded)
+			skipped++
+			continue
+		}
+
+		silences, err := audio.DetectSilences(ctx, ffmpeg, book.FilePath, p.SilenceOptions, func(pos float64) {
+			if durationSec > 0 {
+				frac := min(int(pos/durationSec*steps), steps-1)
+				_ = reporter.UpdateProgress(i*steps+frac, total, "Listening to "+title)
+			}
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed++
+			logf(slog.LevelError, "Silence detection on %s: %v", book.FilePath, err)
+			continue
+		}
+		chapters := audio.ProposeChapters(silences, durationSec, p.SilenceOptions)
+		if len(chapters) < 2 {
+			skipped++
+			logf(slog.LevelInfo, "%s: no chapter breaks found (%d silences)", title, len(silences))
+			continue
+		}
+		if err := database.PutBookChapters(store, book.ID, chapters); err != nil {
+			failed++
+			logf(slog.LevelError, "Save chapters for %s: %v", title, err)
+			continue
+		}
+		proposed++
+		logf(slog.LevelInfo, "%s: proposed %d chapters from %d silences", title, len(chapters), len(silences))
+		if p.Write {
+			b := book
+			if _, err := s.writeBookChapters(ctx, &b, chapters); err != nil {
+				failed++
+				logf(slog.LevelError, "Write chapters to %s: %v", book.FilePath, err)
+			}
+		}
+	}
+	_ = reporter.UpdateProgress(total, total, fmt.Sprintf("Proposed chapters for %d books", proposed))
+	logf(slog.LevelInfo, "Silence detection finished: %d proposed, %d skipped, %d failed", proposed, skipped, failed)
+	return nil
+}
+
+// chapterSilenceTargets resolves the books to analyze: the requested
+// IDs, or every single-file book in a format chapters can be written to.
+func (s *Server) chapterSilenceTargets(store database.Store, ids []string) ([]database.Book, error) {
+	var books []database.Book
+	if len(ids) > 0 {
+		for _, id := range ids {
+			book, err := store.GetBookByID(id)
+			if err != nil || book == nil {
+				return nil, fmt.Errorf("chapters.detect-silence: book %s not found", id)
+			}
+			books = append(books, *book)
+		}
+		return books, nil
+	}
+	const page = 500
+	for offset := 0; ; offset += page {
+		batch, err := store.GetAllBooks(page, offset)
+		if err != nil {
+			return nil, fmt.Errorf("chapters.detect-silence: list books: %w", err)
+		}
+		for _, b := range batch {
+			if !slices.Contains(tagger.ChapterFormats, strings.ToLower(filepath.Ext(b.FilePath))) {
+				continue
+			}
+			if files, err := store.GetBookFiles(b.ID); err == nil && len(files) > 1 {
+				continue
+			}
+			books = append(books, b)
+		}
+		if len(batch) < page {
+			return books, nil
+		}
+	}
+}
@@ -1,7 +1,7 @@
 // file: internal/server/cover_history.go
-// version: 1.2.1
+// version: 1.3.0
 // guid: 6d4e5f3a-7b8c-4a70-b8c5-3d7e0f1b9a99
-// last-edited: 2026-05-15
+// last-edited: 2026-10-16
 //
 // HTTP handlers for cover art history browsing and restore.
 // Each time a book's cover is updated, the previous cover is saved
@@ -71,7 +71,7 @@ func (s *Server) handleRestoreCover(c *gin.Context) {
 	}
 
 	// Restore the cover file
-	_, err = covers.RestoreCoverFile(bookID, req.Filename, config.AppConfig.RootDir)
+	restored, err := covers.RestoreCoverFile(bookID, req.Filename, config.AppConfig.RootDir)
 	if err != nil {
 		if err == os.ErrInvalid {
 			httputil.RespondWithBadRequest(c, "invalid filename")
@@ -85,6 +85,8 @@ func (s *Server) handleRestoreCover(c *gin.Context) {
 		return
 	}
 
+	covers.PublishCoverAsync(config.AppConfig.RootDir, restored)
+
 	// Update book's cover_url
 	ext := filepath.Ext(req.Filename)
 	coverURL := "/api/v1/covers/local/" + bookID + ext
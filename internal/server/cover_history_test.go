@@ -1,7 +1,7 @@
 // file: internal/server/cover_history_test.go
-// version: 1.0.3
+// version: 1.1.0
 // guid: 5e6f7a8b-9c0d-1e2f-3a4b-5c6d7e8f9a0b
-// last-edited: 2026-05-07
+// last-edited: 2026-10-17
 
 package server
 
@@ -18,6 +18,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/covers"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
 )
 
 func setupCoverHistoryServer(t *testing.T) (*Server, database.Store, string) {
@@ -28,18 +29,8 @@ func setupCoverHistoryServer(t *testing.T) (*Server, database.Store, string) {
 	origCfg := config.AppConfig
 	config.AppConfig.RootDir = rootDir
 
-	pebblePath := filepath.Join(t.TempDir(), "pebble")
-	store, err := database.NewPebbleStore(pebblePath)
-	if err != nil {
-		t.Fatalf("open pebble: %v", err)
-	}
-	origStore := database.GetGlobalStore()
-	database.SetGlobalStore(store)
-	t.Cleanup(func() {
-		config.AppConfig = origCfg
-		database.SetGlobalStore(origStore)
-		store.Close()
-	})
+	store := storetest.NewGlobal(t)
+	t.Cleanup(func() { config.AppConfig = origCfg })
 
 	srv := NewServer(store)
 	return srv, store, rootDir
@@ -0,0 +1,66 @@
+// file: internal/server/cover_sync_op.go
+// version: 1.0.0
+// guid: e6a2c58d-13f7-4b94-8d0e-5c71b9f3a28e
+// last-edited: 2026-10-16
+
+// cover_sync_op registers "covers.sync-blob-store", which copies the
+// covers already on local disk into the configured blob store. New
+// covers are copied as they are downloaded; run this once after moving
+// a library to S3.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
+	"github.com/falkcorp/audiobook-organizer/internal/operations"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+// RegisterCoverSyncOp registers the "covers.sync-blob-store" OperationDef.
+func (s *Server) RegisterCoverSyncOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              "covers.sync-blob-store",
+		Plugin:          "covers",
+		DisplayName:     "Copy Covers to Blob Storage",
+		Description:     "Copy local cover images that are missing from the configured blob storage backend.",
+		DefaultPriority: opsregistry.PriorityLow,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         6 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeRestart,
+		ConcurrencyKey:  "covers.sync-blob-store",
+		Permissions:     []auth.Permission{auth.PermSettingsManage},
+		Capabilities:    []opsregistry.Capability{opsregistry.CapFilesRead, opsregistry.CapNetworkGeneric},
+		Run: func(ctx context.Context, _ json.RawMessage, reporter opsregistry.Reporter) error {
+			rootDir := config.Snapshot().RootDir
+			if rootDir == "" {
+				return fmt.Errorf("covers.sync-blob-store: root_dir is not configured")
+			}
+			progress := registryProgressAdapter{r: reporter}
+			log := operations.LoggerFromReporter(progress)
+			if covers.BlobStore() == nil {
+				log.Info("Covers are stored in the library root; nothing to copy")
+				return nil
+			}
+			copied, err := covers.SyncCovers(ctx, rootDir, func(done, total int, name string) {
+				_ = progress.UpdateProgress(done, total, name)
+			})
+			if err != nil {
+				return fmt.Errorf("covers.sync-blob-store: %w", err)
+			}
+			log.Info("Copied %d covers to %s blob storage", copied, covers.BlobStore().Name())
+			return nil
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterCoverSyncOp(reg) })
+}
@@ -1,7 +1,7 @@
 // file: internal/server/covers.go
-// version: 1.4.1
+// version: 1.5.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
-// last-edited: 2026-05-15
+// last-edited: 2026-10-16
 //
 // HTTP handlers for cover proxy and local cover serving.
 // Business logic extracted to internal/covers.
@@ -80,7 +80,7 @@ func (s *Server) handleLocalCover(c *gin.Context) {
 	}
 	coverPath := coverSP.String()
 
-	coverPath, err = covers.FindCoverFile(filepath.Base(coverPath), config.AppConfig.RootDir)
+	coverPath, err = covers.FetchCoverFile(c.Request.Context(), config.AppConfig.RootDir, filepath.Base(coverPath))
 	if err != nil {
 		if os.IsNotExist(err) {
 			httputil.RespondWithNotFound(c, "cover", "")
@@ -1,7 +1,7 @@
 // file: internal/server/deluge_integration.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: 1c9d0e8f-2a3b-4a70-b8c5-3d7e0f1b9a99
-// last-edited: 2026-05-11
+// last-edited: 2026-10-17
 //
 // Deluge integration — HTTP handlers and thin shims.
 //
@@ -144,17 +144,17 @@ func (s *Server) handleDelugeStatus(c *gin.Context) {
 }
 
 // registerDelugeRoutes wires the Deluge integration endpoints.
-func (s *Server) registerDelugeRoutes(protected *gin.RouterGroup) {
+func (s *Server) registerDelugeRoutes(protected *routeGroup) {
 	dg := protected.Group("/deluge")
 	{
-		dg.GET("/status", s.perm("integrations.manage"), s.handleDelugeStatus)
-		dg.POST("/test-connection", s.perm("integrations.manage"), s.handleDelugeTestConnection)
-		dg.GET("/torrents", s.perm("integrations.manage"), s.handleDelugeListTorrents)
-		dg.GET("/labels", s.perm("integrations.manage"), s.handleDelugeListLabels)
-		dg.GET("/discover", s.perm("integrations.manage"), s.handleDelugeDiscover)
-		dg.POST("/discover/import", s.perm("integrations.manage"), s.handleDelugeDiscoverImport)
+		dg.GET("/status", "integrations.manage", s.handleDelugeStatus)
+		dg.POST("/test-connection", "integrations.manage", s.handleDelugeTestConnection)
+		dg.GET("/torrents", "integrations.manage", s.handleDelugeListTorrents)
+		dg.GET("/labels", "integrations.manage", s.handleDelugeListLabels)
+		dg.GET("/discover", "integrations.manage", s.handleDelugeDiscover)
+		dg.POST("/discover/import", "integrations.manage", s.handleDelugeDiscoverImport)
 	}
 
 	// Bulk-import pending Deluge files (settings.manage permission).
-	protected.POST("/discovery/import", s.perm("settings.manage"), s.handleDiscoveryImport)
+	protected.POST("/discovery/import", "settings.manage", s.handleDiscoveryImport)
 }
@@ -1,5 +1,5 @@
 // file: internal/server/duplicates_ops.go
-// version: 2.3.0
+// version: 2.4.0
 // guid: 8b3e1f92-d4c7-4a6e-b5f0-2a7c9d1e3f45
 
 // duplicates_ops registers v2 OperationDefs for the 8 async dedup operations
@@ -169,6 +169,70 @@ func (s *Server) RegisterBookMergeOp(reg *opsregistry.Registry) error {
 	})
 }
 
+// RegisterBookResolveOp registers the "dedup.book-resolve" v2 OperationDef.
+// Its changes are recorded against the legacy op, so POST
+// /operations/:id/revert rolls the whole resolution back.
+func (s *Server) RegisterBookResolveOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              "dedup.book-resolve",
+		Plugin:          "dedup",
+		DisplayName:     "Book Duplicate Resolution",
+		Description:     "Apply keep, delete, merge-metadata and block-hash decisions to duplicate audiobook groups.",
+		DefaultPriority: opsregistry.PriorityNormal,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         1 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeDrop,
+		ConcurrencyKey:  "dedup.book-merge",
+		Permissions:     []auth.Permission{auth.PermLibraryEditMetadata},
+		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite},
+		Run: func(ctx context.Context, rawParams json.RawMessage, reporter opsregistry.Reporter) error {
+			var p dedup.BookResolveParams
+			if err := json.Unmarshal(rawParams, &p); err != nil {
+				return fmt.Errorf("dedup.book-resolve: decode params: %w", err)
+			}
+			store := s.Store()
+			if store == nil {
+				return fmt.Errorf("dedup.book-resolve: database not initialized")
+			}
+
+			op := &logging.OpContext{
+				ID:     p.LegacyOpID,
+				Type:   "dedup.book-resolve",
+				Status: "pending",
+			}
+			ctx = logging.WithOp(ctx, op)
+			for _, g := range p.Groups {
+				op.AddEntity("books", g.BookIDs...)
+			}
+			logging.Info(ctx, "book duplicate resolution starting", "groups", len(p.Groups))
+
+			result, err := dedup.ResolveBookDuplicates(ctx, store, p.LegacyOpID, p.Groups, registryProgressAdapter{r: reporter})
+			s.dedupCache.InvalidateAll()
+			if err != nil {
+				op.SetStatus("failed")
+				logging.Error(ctx, "book duplicate resolution failed", "err", err)
+				return err
+			}
+			for _, msg := range result.Errors {
+				logging.Warn(ctx, "book duplicate group failed", "err", msg)
+			}
+			op.SetStatus("success")
+			logging.Info(ctx, "book duplicate resolution complete",
+				"deleted", result.Deleted, "linked", result.Linked, "errors", len(result.Errors))
+
+			if s.activityWriter != nil && p.LegacyOpID != "" {
+				activity.FlushOperation(s.activityWriter, p.LegacyOpID)
+				activity.EmitInfo(s.activityWriter, p.LegacyOpID, "dedup.book-resolve", "dedup",
+					fmt.Sprintf("Duplicate resolution completed: %d groups, %d books deleted, %d linked as versions, %d errors",
+						len(p.Groups), result.Deleted, result.Linked, len(result.Errors)),
+					activity.AlwaysShow)
+			}
+			return nil
+		},
+	})
+}
+
 // RegisterAuthorDedupScanOp registers the "dedup.author-scan" v2 OperationDef.
 // NOTE: The author-scan logic is not extracted because the only server-side
 // step beyond calling dedup.FindDuplicateAuthors is s.filterReviewedAuthorGroups,
@@ -627,6 +691,7 @@ func (s *Server) RegisterSeriesNormalizeOp(reg *opsregistry.Registry) error {
 func init() {
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterBookDedupScanOp(reg) })
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterBookMergeOp(reg) })
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterBookResolveOp(reg) })
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterAuthorDedupScanOp(reg) })
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterSeriesDedupScanOp(reg) })
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterSeriesDedupOp(reg) })
@@ -0,0 +1,62 @@
+// file: internal/server/email_notifications.go
+// version: 1.0.0
+// guid: bb97eacc-ac87-40ec-91a6-dada1ac02d76
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/mailer"
+)
+
+// handleEmailTest sends a test message through the configured SMTP
+// server, to the addresses in the body or else email.recipients.
+// POST /api/v1/notifications/email/test
+func (s *Server) handleEmailTest(c *gin.Context) {
+	var req struct {
+		To []string `json:"to"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httputil.RespondWithBadRequest(c, "invalid request body: "+err.Error())
+			return
+		}
+	}
+	cfg := config.Snapshot()
+	to := req.To
+	if len(to) == 0 {
+		to = cfg.Email.Recipients
+	}
+	if len(to) == 0 {
+		httputil.RespondWithBadRequest(c, "no recipients: pass to or set email.recipients")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+	defer cancel()
+	if err := mailer.Send(ctx, cfg, to, mailer.TestMessage()); err != nil {
+		if errors.Is(err, mailer.ErrNotConfigured) {
+			httputil.RespondWithBadRequest(c, err.Error())
+			return
+		}
+		httputil.RespondWithError(c, http.StatusBadGateway, err.Error(), "BAD_GATEWAY")
+		return
+	}
+	httputil.RespondWithOK(c, struct {
+		SentTo []string `json:"sent_to"`
+	}{SentTo: to})
+}
+
+// registerEmailRoutes wires the email notification endpoints.
+func (s *Server) registerEmailRoutes(protected *routeGroup) {
+	protected.POST("/notifications/email/test", auth.PermSettingsManage, s.handleEmailTest)
+}
@@ -1,5 +1,5 @@
 // file: internal/server/entity_tag_handlers.go
-// version: 2.1.0
+// version: 2.2.0
 // guid: 7e5f6a4b-8c9d-4a70-b8c5-3d7e0f1b9a99
 //
 // HTTP endpoints for author and series tags (backlog 7.7).
@@ -106,13 +106,13 @@ func (s *Server) handleAddEntityTag(c *gin.Context, ops entityTagOps) {
 }
 
 // registerEntityTagRoutes wires the author/series tag endpoints.
-func (s *Server) registerEntityTagRoutes(protected *gin.RouterGroup) {
-	protected.GET("/authors/:id/tags", s.perm(auth.PermLibraryView),
+func (s *Server) registerEntityTagRoutes(protected *routeGroup) {
+	protected.GET("/authors/:id/tags", auth.PermLibraryView,
 		func(c *gin.Context) { s.handleGetEntityTags(c, s.authorTagOps()) })
-	protected.POST("/authors/:id/tags", s.perm(auth.PermLibraryEditMetadata),
+	protected.POST("/authors/:id/tags", auth.PermLibraryEditMetadata,
 		func(c *gin.Context) { s.handleAddEntityTag(c, s.authorTagOps()) })
-	protected.GET("/series/:id/tags", s.perm(auth.PermLibraryView),
+	protected.GET("/series/:id/tags", auth.PermLibraryView,
 		func(c *gin.Context) { s.handleGetEntityTags(c, s.seriesTagOps()) })
-	protected.POST("/series/:id/tags", s.perm(auth.PermLibraryEditMetadata),
+	protected.POST("/series/:id/tags", auth.PermLibraryEditMetadata,
 		func(c *gin.Context) { s.handleAddEntityTag(c, s.seriesTagOps()) })
 }
@@ -1,5 +1,5 @@
 // file: internal/server/entity_tag_handlers_test.go
-// version: 1.0.1
+// version: 1.1.0
 
 package server
 
@@ -8,29 +8,19 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
 )
 
 func setupEntityTagServer(t *testing.T) (*Server, database.Store) {
 	t.Helper()
 	gin.SetMode(gin.TestMode)
 
-	pebblePath := filepath.Join(t.TempDir(), "pebble")
-	store, err := database.NewPebbleStore(pebblePath)
-	if err != nil {
-		t.Fatalf("open pebble: %v", err)
-	}
-	origStore := database.GetGlobalStore()
-	database.SetGlobalStore(store)
-	t.Cleanup(func() {
-		database.SetGlobalStore(origStore)
-		store.Close()
-	})
+	store := storetest.NewGlobal(t)
 
 	srv := NewServer(store)
 	return srv, store
@@ -0,0 +1,54 @@
+// file: internal/server/file_journal.go
+// version: 1.0.0
+// guid: 4c8e2a71-d6b3-4f09-9a5e-1b7d3f6c2e84
+// last-edited: 2026-10-17
+//
+// File event journal endpoint for external sync tools.
+
+package server
+
+import (
+	"strconv"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultJournalLimit = 500
+	maxJournalLimit     = 5000
+)
+
+// handleFileJournal returns file-level changes after a sequence number.
+// GET /api/v1/journal?since=<seq>&limit=<n>
+//
+// Clients store next_since and pass it back as since; has_more means
+// another page is ready now. reset means entries after since were pruned,
+// so the client should resync the whole library before continuing.
+func (s *Server) handleFileJournal(c *gin.Context) {
+	var since int64
+	if v := c.Query("since"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			httputil.RespondWithValidationError(c, "since", "must be a non-negative integer")
+			return
+		}
+		since = n
+	}
+	limit := defaultJournalLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxJournalLimit {
+			httputil.RespondWithValidationError(c, "limit", "must be between 1 and "+strconv.Itoa(maxJournalLimit))
+			return
+		}
+		limit = n
+	}
+	page, err := database.ReadFileJournal(s.Store(), since, limit)
+	if err != nil {
+		httputil.InternalError(c, "failed to read file journal", err)
+		return
+	}
+	httputil.RespondWithOK(c, page)
+}
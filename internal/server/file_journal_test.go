@@ -0,0 +1,60 @@
+// file: internal/server/file_journal_test.go
+// version: 1.0.0
+// guid: 9a2f6d13-7e4b-4c58-b1a0-5d8c3e9f7b26
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileJournalEndpoint(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	store := database.GetGlobalStore()
+	book, err := store.CreateBook(&database.Book{Title: "Synced", FilePath: "/tmp/journal/synced.m4b"})
+	require.NoError(t, err)
+	moved := *book
+	moved.FilePath = "/tmp/journal/Author/synced.m4b"
+	_, err = store.UpdateBook(book.ID, &moved)
+	require.NoError(t, err)
+
+	get := func(query string) (int, database.FileJournalPage) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/journal"+query, nil)
+		server.router.ServeHTTP(w, req)
+		var resp struct {
+			Data database.FileJournalPage `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp.Data
+	}
+
+	code, page := get("")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, page.Entries, 2)
+	assert.Equal(t, database.FileEventCreated, page.Entries[0].Type)
+	assert.Equal(t, database.FileEventMoved, page.Entries[1].Type)
+	assert.Equal(t, "/tmp/journal/synced.m4b", page.Entries[1].OldPath)
+
+	code, page = get("?since=" + strconv.FormatInt(page.Entries[0].Seq, 10) + "&limit=1")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, database.FileEventMoved, page.Entries[0].Type)
+	assert.Equal(t, page.LatestSeq, page.NextSince)
+	assert.False(t, page.HasMore)
+
+	for _, bad := range []string{"?since=-1", "?since=abc", "?limit=0", "?limit=100000"} {
+		code, _ := get(bad)
+		assert.Equal(t, http.StatusBadRequest, code, bad)
+	}
+}
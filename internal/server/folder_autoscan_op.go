@@ -1,7 +1,7 @@
 // file: internal/server/folder_autoscan_op.go
-// version: 1.1.0
+// version: 1.5.0
 // guid: 7b3e9f2a-4c1d-4e85-a6b8-2f0d5c8e1a93
-// last-edited: 2026-05-10
+// last-edited: 2026-10-17
 //
 // folder_autoscan_op registers the "library.folder-auto-scan" UOS v2 OperationDef.
 // This op is enqueued when a new import path is added to the library; it replicates
@@ -21,6 +21,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/activity"
 	"github.com/falkcorp/audiobook-organizer/internal/auth"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/operations"
 	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
 	"github.com/falkcorp/audiobook-organizer/internal/organizer"
@@ -47,6 +48,7 @@ func (s *Server) RegisterFolderAutoScanOp(reg *opsregistry.Registry) error {
 		Timeout:         2 * time.Hour,
 		ResumePolicy:    opsregistry.ResumeDrop,
 		ConcurrencyKey:  "", // parallel per-folder scans are fine
+		PathLeases:      s.folderAutoScanLeases,
 		Permissions:     []auth.Permission{auth.PermScanTrigger},
 		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite, opsregistry.CapFilesRead},
 		Run: func(ctx context.Context, rawParams json.RawMessage, reporter opsregistry.Reporter) error {
@@ -66,12 +68,36 @@ func (s *Server) RegisterFolderAutoScanOp(reg *opsregistry.Registry) error {
 				return fmt.Errorf("folder does not exist: %s", folderPath)
 			}
 
+			// A new import path gets a deep scan; later scans of it are
+			// incremental.
+			initial, adopt, adoptPattern := false, false, ""
+			if p.FolderID != 0 {
+				if folder, err := s.Store().GetImportPathByID(p.FolderID); err == nil && folder != nil {
+					initial = folder.InitialScanPending()
+					adopt, adoptPattern = folder.Adopt, folder.AdoptPattern
+				}
+			}
+			if initial {
+				scanner.SetScanProfile(scanner.ScanProfileDeep)
+				defer scanner.SetScanProfile("")
+				scanLog.Info("Initial deep scan of %s", folderPath)
+			}
+			// An adopted folder keeps its layout: books take their fields
+			// from it and are not auto-organized.
+			if adopt {
+				if err := scanner.SetAdoption(folderPath, adoptPattern); err != nil {
+					return fmt.Errorf("invalid adopt pattern for %s: %w", folderPath, err)
+				}
+				defer func() { _ = scanner.SetAdoption("", "") }()
+				scanLog.Info("Adopting books in place under %s", folderPath)
+			}
+
 			// Scan directory for audiobook files (parallel).
 			workers := config.AppConfig.ConcurrentScans
 			if workers < 1 {
 				workers = 4
 			}
-			books, err := scanner.ScanDirectoryParallel(folderPath, workers, scanLog)
+			books, err := scanner.ScanDirectoryContext(ctx, folderPath, workers, scanLog)
 			if err != nil {
 				return fmt.Errorf("failed to scan folder: %w", err)
 			}
@@ -85,8 +111,9 @@ func (s *Server) RegisterFolderAutoScanOp(reg *opsregistry.Registry) error {
 					return fmt.Errorf("failed to process books: %w", err)
 				}
 
-				// Auto-organize if enabled.
-				if config.AppConfig.AutoOrganize && config.AppConfig.RootDir != "" {
+				// Auto-organize if enabled; adopted books are already
+				// organized where they are.
+				if config.AppConfig.AutoOrganize && !adopt && config.AppConfig.RootDir != "" {
 					org := organizer.NewOrganizer(&config.AppConfig)
 					organized := 0
 					for _, b := range books {
@@ -110,7 +137,7 @@ func (s *Server) RegisterFolderAutoScanOp(reg *opsregistry.Registry) error {
 						}
 					}
 					_ = progress.Log("info", fmt.Sprintf("Auto-organize complete: %d organized", organized), nil)
-				} else if config.AppConfig.AutoOrganize && config.AppConfig.RootDir == "" {
+				} else if config.AppConfig.AutoOrganize && !adopt && config.AppConfig.RootDir == "" {
 					_ = progress.Log("warn", "Auto-organize enabled but root_dir not set", nil)
 				}
 			}
@@ -139,6 +166,9 @@ func (s *Server) RegisterFolderAutoScanOp(reg *opsregistry.Registry) error {
 					folder.BookCount = len(books)
 					now := time.Now()
 					folder.LastScan = &now
+					if initial {
+						folder.ScanMode = database.ImportPathScanIncremental
+					}
 					if err := s.Store().UpdateImportPath(folder.ID, folder); err != nil {
 						_ = progress.Log("warn", fmt.Sprintf("Failed to update book count: %v", err), nil)
 					}
@@ -0,0 +1,160 @@
+// file: internal/server/freeze.go
+// version: 1.1.0
+// guid: 0f6d3b92-7c4e-4a15-8e9b-d25a1f7c3e80
+// last-edited: 2026-10-17
+//
+// Freeze snapshots: the registry calls libraryFreezer before every
+// Destructive operation, and the endpoints here list the snapshots and
+// restore the library to the state before a given operation ran.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/backup"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/freeze"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// freezeSnapshotDir is where snapshots live: next to the database, like
+// backups, so they survive a library move.
+func freezeSnapshotDir(cfg config.Config) string {
+	if cfg.DatabasePath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(cfg.DatabasePath), "snapshots")
+}
+
+// libraryFreezer implements opsregistry.Freezer.
+type libraryFreezer struct {
+	s *Server
+}
+
+// Freeze snapshots the library for opID unless snapshots are disabled or
+// there is no on-disk database to back up.
+func (f libraryFreezer) Freeze(ctx context.Context, opID, defID string, params json.RawMessage) error {
+	cfg := config.Snapshot()
+	if !cfg.FreezeSnapshotsEnabled || cfg.DatabasePath == "" {
+		return nil
+	}
+	store := f.s.Store()
+	if store == nil {
+		return errors.New("database not initialized")
+	}
+	snap, err := freeze.Create(ctx, store, freeze.Options{
+		Dir:          freezeSnapshotDir(cfg),
+		DatabasePath: cfg.DatabasePath,
+		DatabaseType: cfg.DatabaseType,
+		Retention:    cfg.FreezeSnapshotRetention,
+	}, opID, defID, params)
+	if err != nil && snap == nil {
+		return err
+	}
+	if err != nil {
+		slog.Warn("freeze snapshot taken but pruning failed", "op_id", opID, "error", err)
+	}
+	slog.Info("freeze snapshot taken", "op_id", opID, "def_id", defID, "files", snap.Files, "books", snap.Books)
+	return nil
+}
+
+// handleListSnapshots lists freeze snapshots, newest first.
+// GET /api/v1/snapshots
+func (s *Server) handleListSnapshots(c *gin.Context) {
+	snaps, err := freeze.List(freezeSnapshotDir(config.Snapshot()))
+	if err != nil {
+		httputil.InternalError(c, "failed to list snapshots", err)
+		return
+	}
+	if snaps == nil {
+		snaps = []freeze.Snapshot{}
+	}
+	httputil.RespondWithOK(c, gin.H{"snapshots": snaps, "count": len(snaps)})
+}
+
+// handleGetOperationSnapshot returns the snapshot taken before an
+// operation ran.
+// GET /api/v1/operations/:id/snapshot
+func (s *Server) handleGetOperationSnapshot(c *gin.Context) {
+	id := c.Param("id")
+	snap, err := freeze.Get(freezeSnapshotDir(config.Snapshot()), id)
+	if err != nil {
+		s.respondSnapshotError(c, id, "failed to read snapshot", err)
+		return
+	}
+	httputil.RespondWithOK(c, snap)
+}
+
+// handleRestoreOperationSnapshot undoes an operation by restoring the
+// snapshot taken before it ran: moved files go back and the database
+// backup replaces the live database. dry_run only reports the plan. The
+// live database is closed before the swap, so the server must be
+// restarted afterwards to open the restored one.
+// POST /api/v1/operations/:id/snapshot/restore
+func (s *Server) handleRestoreOperationSnapshot(c *gin.Context) {
+	id := c.Param("id")
+	var req struct {
+		DryRun bool `json:"dry_run"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	store := s.Store()
+	if store == nil {
+		httputil.RespondWithServiceUnavailable(c, "database not initialized")
+		return
+	}
+	if row, err := store.GetOperationV2(id); err == nil && row != nil && (row.Status == "running" || row.Status == "queued") {
+		httputil.RespondWithConflict(c, fmt.Sprintf("operation %s is still %s; cancel it before restoring", id, row.Status))
+		return
+	}
+
+	cfg := config.Snapshot()
+	result, err := freeze.Restore(c.Request.Context(), store, freezeSnapshotDir(cfg), id, freeze.RestoreOptions{
+		DryRun:          req.DryRun,
+		DatabasePath:    cfg.DatabasePath,
+		DatabaseType:    cfg.DatabaseType,
+		SafetyBackupDir: filepath.Join(filepath.Dir(cfg.DatabasePath), backup.DefaultBackupConfig().BackupDir),
+		CloseDatabase:   database.CloseStore,
+	})
+	if errors.Is(err, backup.ErrVerificationFailed) {
+		httputil.RespondWithError(c, http.StatusUnprocessableEntity, err.Error(), "VERIFICATION_FAILED")
+		return
+	}
+	if err != nil {
+		s.respondSnapshotError(c, id, "failed to restore snapshot", err)
+		return
+	}
+	if !req.DryRun {
+		slog.Warn("library restored from freeze snapshot; restart required",
+			"op_id", id, "files_moved", len(result.FilesMoved), "files_missing", len(result.FilesMissing))
+	}
+	httputil.RespondWithOK(c, result)
+}
+
+// handleDeleteOperationSnapshot removes an operation's snapshot.
+// DELETE /api/v1/operations/:id/snapshot
+func (s *Server) handleDeleteOperationSnapshot(c *gin.Context) {
+	id := c.Param("id")
+	if err := freeze.Delete(freezeSnapshotDir(config.Snapshot()), id); err != nil {
+		s.respondSnapshotError(c, id, "failed to delete snapshot", err)
+		return
+	}
+	httputil.RespondWithNoContent(c)
+}
+
+func (s *Server) respondSnapshotError(c *gin.Context, id, message string, err error) {
+	if errors.Is(err, freeze.ErrNotFound) {
+		httputil.RespondWithNotFound(c, "snapshot", id)
+		return
+	}
+	httputil.InternalError(c, message, err)
+}
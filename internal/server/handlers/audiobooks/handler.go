@@ -1,7 +1,7 @@
 // file: internal/server/handlers/audiobooks/handler.go
-// version: 1.0.0
+// version: 1.10.0
 // guid: 51fac747-9478-4075-8621-9da4bbdedc37
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Package audiobookshandler hosts the main library list / CRUD HTTP handlers
 // extracted from the server package's audiobooks_handlers.go: book listing
@@ -51,7 +51,9 @@ package audiobookshandler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -59,10 +61,12 @@ import (
 	audiobookspkg "github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/cache"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/contentfilter"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/fingerprint"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
-	"github.com/falkcorp/audiobook-organizer/internal/metadata"
+	"github.com/falkcorp/audiobook-organizer/internal/libraryscope"
 	"github.com/falkcorp/audiobook-organizer/internal/plugin"
 	"github.com/falkcorp/audiobook-organizer/internal/security/pathvalidation"
 	servermiddleware "github.com/falkcorp/audiobook-organizer/internal/server/middleware"
@@ -216,14 +220,26 @@ func ptrStr(p *string) string {
 // ListAudiobooks handles GET /audiobooks. Mirrors the original listAudiobooks:
 // has_file_errors fast-path, quick-query (missing_covers / in_import_path /
 // no_isbn / duplicates_flagged) fast-path, then the filtered list pipeline with
-// the list cache (skipped when per-user filters are active).
+// the list cache (skipped when per-user filters are active). Requests with a
+// cursor (or pagination=cursor) are served by listAudiobooksByCursor instead.
+// Every path honours ?fields= (see parseListFields).
 func (h *Handler) ListAudiobooks(c *gin.Context) {
+	fields, ok := parseListFields(c)
+	if !ok {
+		return
+	}
+	if wantsCursorPagination(c) {
+		h.listAudiobooksByCursor(c, fields)
+		return
+	}
 	store := h.resolveStore()
 
 	// Parse pagination parameters
 	params := httputil.ParsePaginationParams(c)
 	authorID := httputil.ParseQueryIntPtr(c, "author_id")
 	seriesID := httputil.ParseQueryIntPtr(c, "series_id")
+	scope := servermiddleware.LibraryScope(c)
+	contentFilter := servermiddleware.CurrentContentFilter(c)
 
 	// If the client asked for books with file errors, handle that fast-path here.
 	if c.Query("has_file_errors") == "true" {
@@ -253,9 +269,10 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 
 		if bookIDs == nil {
 			// No implementation available — return empty set
-			httputil.RespondWithOK(c, gin.H{"items": []database.Book{}, "count": 0, "limit": params.Limit, "offset": params.Offset})
+			httputil.RespondWithOK(c, gin.H{"items": []database.Book{}, "count": 0, "total": 0, "limit": params.Limit, "offset": params.Offset})
 			return
 		}
+		bookIDs, loaded := visibleBookIDs(store, scope, contentFilter, bookIDs)
 
 		total := len(bookIDs)
 		start := params.Offset
@@ -272,14 +289,17 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 		selected := bookIDs[start:end]
 		books := make([]database.Book, 0, len(selected))
 		for _, id := range selected {
-			b, err := store.GetBookByID(id)
-			if err != nil || b == nil {
-				continue
+			b := loaded[id]
+			if b == nil {
+				var err error
+				if b, err = store.GetBookByID(id); err != nil || b == nil {
+					continue
+				}
 			}
 			books = append(books, *b)
 		}
 		enriched := h.audiobookService.EnrichAudiobooksWithNames(books)
-		httputil.RespondWithOK(c, gin.H{"items": enriched, "count": total, "limit": params.Limit, "offset": params.Offset})
+		httputil.RespondWithOK(c, gin.H{"items": database.ProjectSlice(enriched, fields), "count": total, "total": total, "limit": params.Limit, "offset": params.Offset})
 		return
 	}
 
@@ -324,9 +344,10 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 
 		if bookIDs == nil {
 			// Store doesn't support this method — return empty set.
-			httputil.RespondWithOK(c, gin.H{"items": []audiobookspkg.AudiobookDetail{}, "count": 0, "limit": params.Limit, "offset": params.Offset})
+			httputil.RespondWithOK(c, gin.H{"items": []audiobookspkg.AudiobookDetail{}, "count": 0, "total": 0, "limit": params.Limit, "offset": params.Offset})
 			return
 		}
+		bookIDs, loaded := visibleBookIDs(store, scope, contentFilter, bookIDs)
 
 		total := len(bookIDs)
 		start := params.Offset
@@ -343,9 +364,12 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 		selected := bookIDs[start:end]
 		books := make([]database.Book, 0, len(selected))
 		for _, id := range selected {
-			b, err := store.GetBookByID(id)
-			if err != nil || b == nil {
-				continue
+			b := loaded[id]
+			if b == nil {
+				var err error
+				if b, err = store.GetBookByID(id); err != nil || b == nil {
+					continue
+				}
 			}
 			books = append(books, *b)
 		}
@@ -389,7 +413,7 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 			enriched[i].LastFingerprintedAt = lastFp
 		}
 
-		httputil.RespondWithOK(c, gin.H{"items": enriched, "count": total, "limit": params.Limit, "offset": params.Offset})
+		httputil.RespondWithOK(c, gin.H{"items": database.ProjectSlice(enriched, fields), "count": total, "total": total, "limit": params.Limit, "offset": params.Offset})
 		return
 	}
 
@@ -426,6 +450,14 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 		FingerprintStatus:  httputil.ParseQueryString(c, "fingerprint_status"),
 		CoveragePercentMin: coveragePercentMin,
 		CoveragePercentMax: coveragePercentMax,
+		PathFilter:         scope.PathFilter(),
+		Fields:             fields,
+	}
+	if contentFilter != nil {
+		filters.ContentFilter = contentFilter.Allows
+	}
+	if !parseAttributeFilters(c, &filters) {
+		return
 	}
 
 	// Parse field filters from JSON query param. Per-user filters
@@ -457,8 +489,15 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 	// Cache key from the full query string. Skip the cache when
 	// per-user filters are active because the cache key doesn't
 	// encode userID — a hit could leak User A's filtered list
-	// to User B.
+	// to User B. Library-isolated and content-filtered callers get the
+	// scope and profile folded into the key for the same reason.
 	cacheKey := "list:" + c.Request.URL.RawQuery
+	if scope != nil {
+		cacheKey += "|" + scope.Key()
+	}
+	if contentFilter != nil {
+		cacheKey += "|" + contentFilter.Key()
+	}
 	if len(filters.PerUserFilters) == 0 {
 		if cached, ok := h.listCache.Get(cacheKey); ok {
 			httputil.RespondWithOK(c, cached)
@@ -478,23 +517,172 @@ func (h *Handler) ListAudiobooks(c *gin.Context) {
 	httputil.RespondWithOK(c, resp)
 }
 
-// ListSoftDeletedAudiobooks handles GET /audiobooks/soft-deleted.
+// parseListFields reads the ?fields= sparse fieldset: a comma-separated
+// list of AudiobookDetail JSON names ("id" is always returned). Unknown
+// names are rejected with 400 and ok=false. No param selects every field.
+func parseListFields(c *gin.Context) (database.FieldSet, bool) {
+	fields, err := database.ParseFieldSet(c.Query("fields"), audiobookspkg.AudiobookDetail{})
+	if err != nil {
+		httputil.RespondWithValidationError(c, "fields", err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// parseAttributeFilters reads the per-book attribute filters into f:
+// format, codec, quality and language (comma-separated, any value
+// matches), narrator (substring), year_min / year_max, has_series and
+// missing_metadata (comma-separated field names, or "any"). Malformed
+// values are rejected with 400 and ok=false.
+func parseAttributeFilters(c *gin.Context, f *audiobookspkg.ListFilters) bool {
+	f.Formats = parseListParam(c, "format")
+	f.Codecs = parseListParam(c, "codec")
+	f.Qualities = parseListParam(c, "quality")
+	f.Languages = parseListParam(c, "language")
+	f.Narrator = strings.TrimSpace(c.Query("narrator"))
+	f.HasSeries = httputil.ParseQueryBoolPtr(c, "has_series")
+	for _, bound := range []struct {
+		name string
+		dst  **int
+	}{{"year_min", &f.YearMin}, {"year_max", &f.YearMax}} {
+		raw := c.Query(bound.name)
+		if raw == "" {
+			continue
+		}
+		year, err := strconv.Atoi(raw)
+		if err != nil {
+			httputil.RespondWithValidationError(c, bound.name, "must be a year")
+			return false
+		}
+		*bound.dst = &year
+	}
+	if f.YearMin != nil && f.YearMax != nil && *f.YearMin > *f.YearMax {
+		httputil.RespondWithValidationError(c, "year_min", "must not be after year_max")
+		return false
+	}
+	for _, field := range parseListParam(c, "missing_metadata") {
+		field = strings.ToLower(field)
+		if field == "any" {
+			f.MissingMetadata = f.MissingMetadata[:0]
+			for name := range audiobookspkg.MissingMetadataFields {
+				f.MissingMetadata = append(f.MissingMetadata, name)
+			}
+			sort.Strings(f.MissingMetadata)
+			break
+		}
+		if _, ok := audiobookspkg.MissingMetadataFields[field]; !ok {
+			httputil.RespondWithValidationError(c, "missing_metadata", "unknown field "+strconv.Quote(field))
+			return false
+		}
+		f.MissingMetadata = append(f.MissingMetadata, field)
+	}
+	return true
+}
+
+// parseListParam splits a comma-separated (or repeated) query param into
+// its non-empty values.
+func parseListParam(c *gin.Context, key string) []string {
+	var out []string
+	for _, raw := range c.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+// visibleBookIDs drops the IDs of books outside scope or rejected by the
+// caller's content filter. The fast paths list bare IDs, so each book is
+// loaded to check it and returned keyed by ID for building the page; an
+// unrestricted caller gets ids untouched and a nil map.
+func visibleBookIDs(store AudiobooksStore, scope *libraryscope.Scope, cf *contentfilter.Filter, ids []string) ([]string, map[string]*database.Book) {
+	if scope == nil && cf == nil {
+		return ids, nil
+	}
+	out := make([]string, 0, len(ids))
+	loaded := make(map[string]*database.Book, len(ids))
+	for _, id := range ids {
+		if b, err := store.GetBookByID(id); err == nil && scope.AllowsBook(b) && cf.Allows(b) {
+			out = append(out, id)
+			loaded[id] = b
+		}
+	}
+	return out, loaded
+}
+
+// bookVisible reports whether the caller may see book under their library
+// scope and content filter.
+func bookVisible(c *gin.Context, book *database.Book) bool {
+	return servermiddleware.LibraryScope(c).AllowsBook(book) && servermiddleware.CurrentContentFilter(c).Allows(book)
+}
+
+// requireVisibleBook guards the per-book subresource endpoints: for a caller
+// with a library scope or content filter it loads book id and responds 404
+// unless bookVisible. Unrestricted callers skip the lookup and leave
+// not-found handling to the endpoint. Returns false once a response has been
+// written.
+func (h *Handler) requireVisibleBook(c *gin.Context, id string) bool {
+	if servermiddleware.LibraryScope(c) == nil && servermiddleware.CurrentContentFilter(c) == nil {
+		return true
+	}
+	store := h.resolveStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return false
+	}
+	if book, err := store.GetBookByID(id); err != nil || !bookVisible(c, book) {
+		httputil.RespondWithNotFound(c, "audiobook", id)
+		return false
+	}
+	return true
+}
+
+// ListSoftDeletedAudiobooks handles GET /audiobooks/soft-deleted. Honours
+// ?fields= like ListAudiobooks.
 func (h *Handler) ListSoftDeletedAudiobooks(c *gin.Context) {
+	fields, ok := parseListFields(c)
+	if !ok {
+		return
+	}
 	params := httputil.ParsePaginationParams(c)
 	olderThanDays := httputil.ParseQueryIntPtr(c, "older_than_days")
 
-	books, err := h.audiobookService.GetSoftDeletedBooks(c.Request.Context(), params.Limit, params.Offset, olderThanDays)
-	if err != nil {
-		httputil.InternalError(c, "failed to list deleted audiobooks", err)
-		return
-	}
+	var books []database.Book
+	var total int
+	if servermiddleware.LibraryScope(c) != nil || servermiddleware.CurrentContentFilter(c) != nil {
+		// Restricted callers page over the deleted books they can see, so
+		// the window is cut after filtering.
+		allBooks, err := h.audiobookService.GetSoftDeletedBooks(c.Request.Context(), 10000, 0, olderThanDays)
+		if err != nil {
+			httputil.InternalError(c, "failed to list deleted audiobooks", err)
+			return
+		}
+		visible := allBooks[:0]
+		for i := range allBooks {
+			if bookVisible(c, &allBooks[i]) {
+				visible = append(visible, allBooks[i])
+			}
+		}
+		total = len(visible)
+		start := min(params.Offset, total)
+		books = visible[start:min(start+params.Limit, total)]
+	} else {
+		var err error
+		books, err = h.audiobookService.GetSoftDeletedBooks(c.Request.Context(), params.Limit, params.Offset, olderThanDays)
+		if err != nil {
+			httputil.InternalError(c, "failed to list deleted audiobooks", err)
+			return
+		}
 
-	// Get total count (unpaginated) for proper pagination support
-	allBooks, _ := h.audiobookService.GetSoftDeletedBooks(c.Request.Context(), 10000, 0, olderThanDays)
-	total := len(allBooks)
+		// Get total count (unpaginated) for proper pagination support
+		allBooks, _ := h.audiobookService.GetSoftDeletedBooks(c.Request.Context(), 10000, 0, olderThanDays)
+		total = len(allBooks)
+	}
 
 	httputil.RespondWithOK(c, gin.H{
-		"items":  books,
+		"items":  database.ProjectSlice(books, fields),
 		"count":  len(books),
 		"total":  total,
 		"limit":  params.Limit,
@@ -523,6 +711,35 @@ func (h *Handler) PurgeSoftDeletedAudiobooks(c *gin.Context) {
 	httputil.RespondWithOK(c, result)
 }
 
+// PurgeAudiobook handles DELETE /audiobooks/:id/purge, which purges one
+// soft-deleted book (e.g. from the trash view) instead of waiting for its
+// scheduled purge.
+func (h *Handler) PurgeAudiobook(c *gin.Context) {
+	id := c.Param("id")
+	deleteFiles := c.Query("delete_files") == "true"
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
+
+	result, err := h.audiobookService.PurgeDeletedBook(c.Request.Context(), id, deleteFiles)
+	if err != nil {
+		switch {
+		case errors.Is(err, audiobookspkg.ErrNotSoftDeleted):
+			httputil.RespondWithConflict(c, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			httputil.RespondWithNotFound(c, "audiobook", id)
+		default:
+			httputil.InternalError(c, "failed to purge audiobook", err)
+		}
+		return
+	}
+
+	h.authorsCache.InvalidateAll()
+	h.seriesCache.InvalidateAll()
+
+	httputil.RespondWithOK(c, result)
+}
+
 // RescanAudiobook re-stats the book's files on disk and updates FileSize fields
 // in the DB to match physical reality. POST /audiobooks/:id/rescan.
 func (h *Handler) RescanAudiobook(c *gin.Context) {
@@ -533,7 +750,7 @@ func (h *Handler) RescanAudiobook(c *gin.Context) {
 		return
 	}
 	book, err := store.GetBookByID(id)
-	if err != nil || book == nil {
+	if err != nil || book == nil || !bookVisible(c, book) {
 		httputil.RespondWithNotFound(c, "audiobook", id)
 		return
 	}
@@ -622,6 +839,9 @@ func (h *Handler) RescanAudiobook(c *gin.Context) {
 // RestoreAudiobook handles POST /audiobooks/:id/restore.
 func (h *Handler) RestoreAudiobook(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	updated, err := h.audiobookService.RestoreAudiobook(c.Request.Context(), id)
 	if err != nil {
 		httputil.RespondWithNotFound(c, "audiobook", id)
@@ -636,6 +856,23 @@ func (h *Handler) RestoreAudiobook(c *gin.Context) {
 
 // CountAudiobooks handles GET /audiobooks/count.
 func (h *Handler) CountAudiobooks(c *gin.Context) {
+	scope := servermiddleware.LibraryScope(c)
+	contentFilter := servermiddleware.CurrentContentFilter(c)
+	if scope != nil || contentFilter != nil {
+		// Restricted callers count only what they can see; the list
+		// pipeline's filtered count already honours both filters.
+		filters := audiobookspkg.ListFilters{PathFilter: scope.PathFilter()}
+		if contentFilter != nil {
+			filters.ContentFilter = contentFilter.Allows
+		}
+		resp, err := h.buildListResponse(c.Request.Context(), 1, 0, "", nil, nil, filters, true)
+		if err != nil {
+			httputil.InternalError(c, "failed to count audiobooks", err)
+			return
+		}
+		httputil.RespondWithOK(c, gin.H{"count": resp["count"]})
+		return
+	}
 	count, err := h.audiobookService.CountAudiobooks(c.Request.Context())
 	if err != nil {
 		httputil.InternalError(c, "failed to count audiobooks", err)
@@ -648,12 +885,20 @@ func (h *Handler) CountAudiobooks(c *gin.Context) {
 // AudiobookFacets handles GET /audiobooks/facets. Returns lightweight lists of
 // distinct genres and languages for filter dropdowns. Results are cached for 5
 // minutes and pre-warmed at startup (warmFacetsCache stays in package server).
+// Library-isolated and content-filtered callers get facets drawn from the
+// books they can see, cached under their scope and profile.
 func (h *Handler) AudiobookFacets(c *gin.Context) {
 	store := h.resolveStore()
 	if store == nil {
 		httputil.RespondWithInternalError(c, "database not initialized")
 		return
 	}
+	scope := servermiddleware.LibraryScope(c)
+	contentFilter := servermiddleware.CurrentContentFilter(c)
+	if scope != nil || contentFilter != nil {
+		h.restrictedFacets(c, store, facetsCacheKey+"|"+scope.Key()+"|"+contentFilter.Key())
+		return
+	}
 	if cached, ok := h.facetsCache.Get(facetsCacheKey); ok {
 		httputil.RespondWithOK(c, cached)
 		return
@@ -680,6 +925,42 @@ func (h *Handler) AudiobookFacets(c *gin.Context) {
 	httputil.RespondWithOK(c, result)
 }
 
+// restrictedFacets serves AudiobookFacets for a caller with a library scope
+// or content filter. The store's distinct-value scans cover every book, so
+// the genres and languages are collected from the visible books instead.
+func (h *Handler) restrictedFacets(c *gin.Context, store AudiobooksStore, cacheKey string) {
+	if cached, ok := h.facetsCache.Get(cacheKey); ok {
+		httputil.RespondWithOK(c, cached)
+		return
+	}
+	books, err := database.GetAllBooksContext(c.Request.Context(), store, 0, 0)
+	if err != nil {
+		httputil.InternalError(c, "failed to fetch facets", err)
+		return
+	}
+	genres, languages := []string{}, []string{}
+	seenGenres, seenLanguages := map[string]bool{}, map[string]bool{}
+	for i := range books {
+		b := &books[i]
+		if !bookVisible(c, b) {
+			continue
+		}
+		if b.Genre != nil && *b.Genre != "" && !seenGenres[*b.Genre] {
+			seenGenres[*b.Genre] = true
+			genres = append(genres, *b.Genre)
+		}
+		if b.Language != nil && *b.Language != "" && !seenLanguages[*b.Language] {
+			seenLanguages[*b.Language] = true
+			languages = append(languages, *b.Language)
+		}
+	}
+	sort.Strings(genres)
+	sort.Strings(languages)
+	result := gin.H{"genres": genres, "languages": languages}
+	h.facetsCache.Set(cacheKey, result)
+	httputil.RespondWithOK(c, result)
+}
+
 // ServeAudiobookCover handles GET /audiobooks/:id/cover.
 func (h *Handler) ServeAudiobookCover(c *gin.Context) {
 	id := pathvalidation.SanitizeFilename(c.Param("id"))
@@ -691,7 +972,18 @@ func (h *Handler) ServeAudiobookCover(c *gin.Context) {
 		httputil.RespondWithInternalError(c, "root_dir not configured")
 		return
 	}
-	coverPath := metadata.CoverPathForBook(config.AppConfig.RootDir, id)
+	if servermiddleware.LibraryScope(c) != nil || servermiddleware.CurrentContentFilter(c) != nil {
+		store := h.resolveStore()
+		if store == nil {
+			httputil.RespondWithInternalError(c, "database not initialized")
+			return
+		}
+		if book, err := store.GetBookByID(id); err != nil || !bookVisible(c, book) {
+			httputil.RespondWithNotFound(c, "cover art", id)
+			return
+		}
+	}
+	coverPath := covers.CoverPathForBook(c.Request.Context(), config.AppConfig.RootDir, id)
 	if coverPath == "" {
 		httputil.RespondWithNotFound(c, "cover art", id)
 		return
@@ -712,6 +1004,10 @@ func (h *Handler) GetAudiobook(c *gin.Context) {
 		httputil.InternalError(c, "failed to get audiobook", err)
 		return
 	}
+	if !bookVisible(c, book) {
+		httputil.RespondWithNotFound(c, "audiobook", id)
+		return
+	}
 
 	httputil.RespondWithOK(c, h.enrichBook(book))
 }
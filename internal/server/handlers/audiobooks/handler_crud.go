@@ -1,18 +1,22 @@
 // file: internal/server/handlers/audiobooks/handler_crud.go
-// version: 1.0.0
+// version: 1.6.0
 // guid: 7f0f10bf-7554-4af5-b2d2-ce0a6af6b46e
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Write-side CRUD + batch endpoints for the audiobooks domain: update
 // (full-column replacement with change-history recording + file write-back),
-// delete (soft/hard, event publish), batch update, and batch operations.
+// partial update via JSON Patch / merge patch, delete (soft/hard, event publish), batch update, and batch operations.
 // Split out of handler.go for readability; one Handler, one New().
 
 package audiobookshandler
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +24,7 @@ import (
 	"github.com/gin-gonic/gin"
 	audiobookspkg "github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/batch"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/fileops"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
@@ -39,6 +44,9 @@ func (h *Handler) UpdateAudiobook(c *gin.Context) {
 		httputil.RespondWithBadRequest(c, err.Error())
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 
 	// Fetch old book for change history comparison
 	var oldBook *database.Book
@@ -56,6 +64,107 @@ func (h *Handler) UpdateAudiobook(c *gin.Context) {
 		return
 	}
 
+	h.finishBookUpdate(store, id, oldBook, updatedBook, payload)
+	httputil.RespondWithOK(c, h.enrichBook(updatedBook))
+}
+
+// PatchAudiobook handles PATCH /audiobooks/:id. The body is either an RFC
+// 6902 JSON Patch (application/json-patch+json, or a JSON array) or an RFC
+// 7386 merge patch (application/merge-patch+json, or a JSON object) over
+// the fields in audiobooks.BookPatchDocument. Only fields the patch
+// actually changes reach the update service, so provenance and change
+// history are recorded per changed field.
+func (h *Handler) PatchAudiobook(c *gin.Context) {
+	id := c.Param("id")
+	store := h.resolveStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		httputil.RespondWithBadRequest(c, "failed to read request body")
+		return
+	}
+
+	book, err := store.GetBookByID(id)
+	if err != nil || book == nil || !bookVisible(c, book) {
+		httputil.RespondWithNotFound(c, "audiobook", id)
+		return
+	}
+	authorName, seriesName := audiobookspkg.ResolveAuthorAndSeriesNames(store, book)
+	doc := audiobookspkg.BookPatchDocument(book, authorName, seriesName)
+
+	var patched map[string]any
+	switch patchFormat(c.ContentType(), body) {
+	case "json-patch":
+		patched, err = audiobookspkg.ApplyJSONPatch(doc, body)
+	case "merge-patch":
+		patched, err = audiobookspkg.ApplyMergePatch(doc, body)
+	default:
+		httputil.RespondWithError(c, http.StatusUnsupportedMediaType,
+			"body must be application/json-patch+json or application/merge-patch+json", "UNSUPPORTED_PATCH_FORMAT")
+		return
+	}
+	var payload map[string]any
+	if err == nil {
+		payload, err = audiobookspkg.BookPatchPayload(doc, patched)
+	}
+	if err != nil {
+		var fieldErr *audiobookspkg.PatchFieldError
+		switch {
+		case errors.As(err, &fieldErr):
+			httputil.RespondWithValidationError(c, fieldErr.Field, fieldErr.Message)
+		case errors.Is(err, audiobookspkg.ErrPatchTestFailed):
+			httputil.RespondWithConflict(c, err.Error())
+		default:
+			httputil.RespondWithBadRequest(c, err.Error())
+		}
+		return
+	}
+	if len(payload) == 0 {
+		httputil.RespondWithOK(c, h.enrichBook(book))
+		return
+	}
+
+	updatedBook, err := h.audiobookUpdater.UpdateAudiobook(c.Request.Context(), id, payload)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			httputil.RespondWithNotFound(c, "audiobook", id)
+			return
+		}
+		httputil.InternalError(c, "failed to update audiobook", err)
+		return
+	}
+
+	h.finishBookUpdate(store, id, book, updatedBook, payload)
+	httputil.RespondWithOK(c, h.enrichBook(updatedBook))
+}
+
+// patchFormat picks the patch dialect from the content type, falling back
+// to the body's shape for plain application/json.
+func patchFormat(contentType string, body []byte) string {
+	switch contentType {
+	case "application/json-patch+json":
+		return "json-patch"
+	case "application/merge-patch+json":
+		return "merge-patch"
+	case "", "application/json":
+		switch trimmed := bytes.TrimSpace(body); {
+		case bytes.HasPrefix(trimmed, []byte("[")):
+			return "json-patch"
+		case bytes.HasPrefix(trimmed, []byte("{")):
+			return "merge-patch"
+		}
+	}
+	return ""
+}
+
+// finishBookUpdate runs the side effects shared by PUT and PATCH once the
+// update service has saved a book: manual change history, metadata
+// write-back to the file, iTunes write-back, and cache invalidation.
+func (h *Handler) finishBookUpdate(store AudiobooksStore, id string, oldBook, updatedBook *database.Book, payload map[string]any) {
 	// Record metadata change history for manual edits
 	if oldBook != nil && store != nil {
 		now := time.Now()
@@ -201,19 +310,29 @@ func (h *Handler) UpdateAudiobook(c *gin.Context) {
 	if h.audiobookService != nil {
 		h.audiobookService.InvalidateBookCaches()
 	}
-
-	httputil.RespondWithOK(c, h.enrichBook(updatedBook))
 }
 
 // DeleteAudiobook handles DELETE /audiobooks/:id.
 func (h *Handler) DeleteAudiobook(c *gin.Context) {
 	id := c.Param("id")
 	blockHash := c.Query("block_hash") == "true"
+	blockSignature := c.Query("block_signature") == "true"
 	softDelete := c.Query("soft_delete") == "true"
+	// move_to_trash only applies to soft deletes; absent, the
+	// soft_delete_to_trash setting decides.
+	moveToTrash := config.AppConfig.SoftDeleteToTrash
+	if v, err := strconv.ParseBool(c.Query("move_to_trash")); err == nil {
+		moveToTrash = v
+	}
 
 	opts := &audiobookspkg.DeleteAudiobookOptions{
-		SoftDelete: softDelete,
-		BlockHash:  blockHash,
+		SoftDelete:     softDelete,
+		MoveToTrash:    softDelete && moveToTrash,
+		BlockHash:      blockHash,
+		BlockSignature: blockSignature,
+	}
+	if !h.requireVisibleBook(c, id) {
+		return
 	}
 
 	result, err := h.audiobookService.DeleteAudiobook(c.Request.Context(), id, opts)
@@ -227,8 +346,9 @@ func (h *Handler) DeleteAudiobook(c *gin.Context) {
 	}
 
 	h.publishEvent(c.Request.Context(), plugin.NewEvent(plugin.EventBookDeleted, id, map[string]any{
-		"soft_delete": softDelete,
-		"block_hash":  blockHash,
+		"soft_delete":     softDelete,
+		"block_hash":      blockHash,
+		"block_signature": blockSignature,
 	}))
 
 	// Invalidate caches since book-author and book-series relationships may have changed
@@ -238,6 +358,70 @@ func (h *Handler) DeleteAudiobook(c *gin.Context) {
 	httputil.RespondWithOK(c, result)
 }
 
+// batchDeleteRequest is the body of POST /audiobooks/batch-delete. The
+// options mean what the DELETE /audiobooks/:id query parameters do.
+type batchDeleteRequest struct {
+	IDs            []string `json:"ids"`
+	SoftDelete     bool     `json:"soft_delete"`
+	BlockHash      bool     `json:"block_hash"`
+	BlockSignature bool     `json:"block_signature"`
+	MoveToTrash    *bool    `json:"move_to_trash,omitempty"`
+}
+
+// BatchDeleteAudiobooks handles POST /audiobooks/batch-delete: each listed
+// book is deleted as DeleteAudiobook would, and failures are reported per
+// book rather than stopping the batch.
+func (h *Handler) BatchDeleteAudiobooks(c *gin.Context) {
+	var req batchDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if len(req.IDs) == 0 {
+		httputil.RespondWithBadRequest(c, "no ids provided")
+		return
+	}
+	if len(req.IDs) > 10000 {
+		httputil.RespondWithBadRequest(c, "max 10000 ids per request")
+		return
+	}
+
+	moveToTrash := config.AppConfig.SoftDeleteToTrash
+	if req.MoveToTrash != nil {
+		moveToTrash = *req.MoveToTrash
+	}
+	opts := &audiobookspkg.DeleteAudiobookOptions{
+		SoftDelete:     req.SoftDelete,
+		MoveToTrash:    req.SoftDelete && moveToTrash,
+		BlockHash:      req.BlockHash,
+		BlockSignature: req.BlockSignature,
+	}
+
+	ctx := c.Request.Context()
+	resp := &batch.BatchResponse{Results: []batch.BatchResult{}, Total: len(req.IDs)}
+	for _, id := range req.IDs {
+		if _, err := h.audiobookService.DeleteAudiobook(ctx, id, opts); err != nil {
+			resp.Results = append(resp.Results, batch.BatchResult{ID: id, Error: err.Error()})
+			resp.Failed++
+			continue
+		}
+		resp.Results = append(resp.Results, batch.BatchResult{ID: id, Success: true})
+		resp.Success++
+		h.publishEvent(ctx, plugin.NewEvent(plugin.EventBookDeleted, id, map[string]any{
+			"soft_delete":     req.SoftDelete,
+			"block_hash":      req.BlockHash,
+			"block_signature": req.BlockSignature,
+		}))
+	}
+
+	if resp.Success > 0 {
+		h.authorsCache.InvalidateAll()
+		h.seriesCache.InvalidateAll()
+	}
+
+	httputil.RespondWithOK(c, resp)
+}
+
 // BatchUpdateAudiobooks handles POST /audiobooks/batch.
 func (h *Handler) BatchUpdateAudiobooks(c *gin.Context) {
 	var req batch.BatchUpdateRequest
@@ -0,0 +1,207 @@
+// file: internal/server/handlers/audiobooks/handler_cursor.go
+// version: 1.4.0
+// guid: b7a8538a-c6d2-4678-8c66-c2d4ece86943
+// last-edited: 2026-10-17
+
+package audiobookshandler
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	audiobookspkg "github.com/falkcorp/audiobook-organizer/internal/audiobooks"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	servermiddleware "github.com/falkcorp/audiobook-organizer/internal/server/middleware"
+)
+
+// cursorIncompatibleParams are ListAudiobooks params that need a full scan
+// or a second pass over the library, so they stay offset-only.
+var cursorIncompatibleParams = []string{
+	"offset", "author_id", "series_id", "has_file_errors", "missing_covers",
+	"in_import_path", "no_isbn", "duplicates_flagged", "library_state", "tag",
+	"tags", "tags[]", "filters", "fingerprint_status", "coverage_percent_min",
+	"coverage_percent_max",
+}
+
+// cursorSearcher is the *audiobookspkg.AudiobookService method backing
+// cursor-paged search. Kept out of AudiobookService so the existing mocks
+// don't need it.
+type cursorSearcher interface {
+	SearchAudiobooksAfter(ctx context.Context, query string, after *database.PageCursor, limit int) ([]database.Book, *database.PageCursor, error)
+}
+
+// totalCounter is the *audiobookspkg.AudiobookService method that counts
+// every row a list matches, reported as total on cursor pages.
+type totalCounter interface {
+	CountAudiobooksMatching(ctx context.Context, search string, authorID, seriesID *int, filters audiobookspkg.ListFilters) (int, error)
+}
+
+// wantsCursorPagination reports whether the request opted into cursor
+// pagination, either by passing a cursor or with pagination=cursor for the
+// first page.
+func wantsCursorPagination(c *gin.Context) bool {
+	return c.Query("cursor") != "" || c.Query("pagination") == "cursor"
+}
+
+// cursorPageStore returns the store's CursorPageStore, looking through
+// decorators that expose Unwrap.
+func cursorPageStore(store AudiobooksStore) (database.CursorPageStore, bool) {
+	if cs, ok := store.(database.CursorPageStore); ok {
+		return cs, true
+	}
+	if uw, ok := store.(interface{ Unwrap() database.Store }); ok {
+		if cs, ok := uw.Unwrap().(database.CursorPageStore); ok {
+			return cs, true
+		}
+	}
+	return nil, false
+}
+
+// listAudiobooksByCursor serves GET /audiobooks in cursor mode. Pages are
+// ordered by sort_by (id or title, then id) or, with search, by relevance.
+// Rows hidden by the library scope, content filter, is_primary_version or
+// quarantine are skipped and the page refilled, so next_cursor always
+// points past the last row examined rather than the last row returned.
+// total counts every matching row, as in offset mode, so it is the same
+// on every page.
+func (h *Handler) listAudiobooksByCursor(c *gin.Context, fields database.FieldSet) {
+	for _, param := range cursorIncompatibleParams {
+		if _, ok := c.GetQuery(param); ok {
+			httputil.RespondWithBadRequest(c, param+" is not supported with cursor pagination")
+			return
+		}
+	}
+	var after *database.PageCursor
+	if raw := c.Query("cursor"); raw != "" {
+		cur, err := database.DecodePageCursor(raw)
+		if err != nil {
+			httputil.RespondWithValidationError(c, "cursor", "malformed cursor")
+			return
+		}
+		after = cur
+	}
+	params := httputil.ParsePaginationParams(c)
+	sortBy := c.DefaultQuery("sort_by", database.BookSortID)
+	if sortBy != database.BookSortID && sortBy != database.BookSortTitle {
+		httputil.RespondWithValidationError(c, "sort_by", "must be id or title with cursor pagination")
+		return
+	}
+	desc := c.Query("sort_order") == "desc"
+	if params.Search != "" && (c.Query("sort_by") != "" || desc) {
+		httputil.RespondWithValidationError(c, "sort_by", "search results are ordered by relevance with cursor pagination")
+		return
+	}
+	isPrimary := httputil.ParseQueryBoolPtr(c, "is_primary_version")
+	showQuarantined := c.Query("show_quarantined") == "true"
+	var attrs audiobookspkg.ListFilters
+	if !parseAttributeFilters(c, &attrs) {
+		return
+	}
+	hasAttrs := attrs.HasAttributeFilters()
+
+	store := h.resolveStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	keep := func(b *database.Book) bool {
+		if isPrimary != nil {
+			primary := b.IsPrimaryVersion == nil || *b.IsPrimaryVersion
+			if primary != *isPrimary {
+				return false
+			}
+		}
+		if !showQuarantined && b.QuarantinedAt != nil {
+			return false
+		}
+		if hasAttrs && !audiobookspkg.MatchesAttributeFilters(b, attrs) {
+			return false
+		}
+		return bookVisible(c, b)
+	}
+
+	books := make([]database.Book, 0, params.Limit)
+	var next *database.PageCursor
+	if params.Search != "" {
+		searcher, ok := h.audiobookService.(cursorSearcher)
+		if !ok {
+			httputil.RespondWithBadRequest(c, "cursor pagination is not supported for search")
+			return
+		}
+		// Each batch asks only for the rows still missing so the page
+		// never overfills and the service's cursor stays exact.
+		for next = after; len(books) < params.Limit; {
+			batch, cur, err := searcher.SearchAudiobooksAfter(c.Request.Context(), params.Search, next, params.Limit-len(books))
+			if err != nil {
+				httputil.InternalError(c, "failed to search audiobooks", err)
+				return
+			}
+			for i := range batch {
+				if keep(&batch[i]) {
+					books = append(books, batch[i])
+				}
+			}
+			if next = cur; next == nil {
+				break
+			}
+		}
+	} else {
+		pager, ok := cursorPageStore(store)
+		if !ok {
+			httputil.RespondWithBadRequest(c, "cursor pagination is not supported by this store")
+			return
+		}
+		for cur := after; ; {
+			batch, err := pager.ListBooksAfter(cur, sortBy, desc, params.Limit)
+			if err != nil {
+				httputil.InternalError(c, "failed to list audiobooks", err)
+				return
+			}
+			filled := false
+			for i := range batch {
+				pos := database.BookPageCursor(&batch[i], sortBy)
+				cur = &pos
+				if !keep(&batch[i]) {
+					continue
+				}
+				books = append(books, batch[i])
+				if len(books) == params.Limit {
+					// More rows may follow unless this was the last
+					// row of a short batch.
+					if i < len(batch)-1 || len(batch) == params.Limit {
+						next = cur
+					}
+					filled = true
+					break
+				}
+			}
+			if filled || len(batch) < params.Limit {
+				break
+			}
+		}
+	}
+
+	var nextCursor any
+	if next != nil {
+		nextCursor = next.Encode()
+	}
+	resp := gin.H{
+		"items":       database.ProjectSlice(h.audiobookService.EnrichAudiobooksWithNames(books), fields),
+		"count":       len(books),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+	if counter, ok := h.audiobookService.(totalCounter); ok {
+		f := attrs
+		f.IsPrimaryVersion = isPrimary
+		f.PathFilter = servermiddleware.LibraryScope(c).PathFilter()
+		if cf := servermiddleware.CurrentContentFilter(c); cf != nil {
+			f.ContentFilter = cf.Allows
+		}
+		if total, err := counter.CountAudiobooksMatching(c.Request.Context(), params.Search, nil, nil, f); err == nil {
+			resp["total"] = total
+		}
+	}
+	httputil.RespondWithOK(c, resp)
+}
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/audiobooks/handler_files.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: 82f8d1f7-46d5-4ead-b5c1-ba796fd785f9
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // File / segment endpoints for the audiobooks domain: segment listing,
 // book-file listing + patch, track-info extraction, relocate, and segment
@@ -11,7 +11,6 @@ package audiobookshandler
 
 import (
 	"fmt"
-	"hash/crc32"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -37,7 +36,7 @@ func (h *Handler) ListAudiobookSegments(c *gin.Context) {
 	}
 
 	book, err := store.GetBookByID(id)
-	if err != nil || book == nil {
+	if err != nil || book == nil || !bookVisible(c, book) {
 		httputil.RespondWithNotFound(c, "audiobook", id)
 		return
 	}
@@ -57,7 +56,7 @@ func (h *Handler) ListAudiobookSegments(c *gin.Context) {
 	for _, f := range files {
 		result = append(result, gin.H{
 			"id":               f.ID,
-			"book_id":          int(crc32.ChecksumIEEE([]byte(f.BookID))),
+			"book_id":          database.BookNumericID(f.BookID),
 			"file_path":        f.FilePath,
 			"format":           f.Format,
 			"size_bytes":       f.FileSize,
@@ -86,6 +85,9 @@ func (h *Handler) ListBookFiles(c *gin.Context) {
 		httputil.RespondWithInternalError(c, "database not initialized")
 		return
 	}
+	if !h.requireVisibleBook(c, bookID) {
+		return
+	}
 	files, err := store.GetBookFiles(bookID)
 	if err != nil {
 		httputil.InternalError(c, "failed to get book files", err)
@@ -161,6 +163,9 @@ func (h *Handler) PatchBookFile(c *gin.Context) {
 		httputil.RespondWithBadRequest(c, "invalid request body")
 		return
 	}
+	if !h.requireVisibleBook(c, bookID) {
+		return
+	}
 
 	file, err := store.GetBookFileByID(bookID, fileID)
 	if err != nil {
@@ -199,7 +204,7 @@ func (h *Handler) ExtractTrackInfo(c *gin.Context) {
 	}
 
 	book, err := store.GetBookByID(id)
-	if err != nil || book == nil {
+	if err != nil || book == nil || !bookVisible(c, book) {
 		httputil.RespondWithNotFound(c, "audiobook", id)
 		return
 	}
@@ -312,7 +317,7 @@ func (h *Handler) RelocateBookFiles(c *gin.Context) {
 	}
 
 	book, err := store.GetBookByID(id)
-	if err != nil || book == nil {
+	if err != nil || book == nil || !bookVisible(c, book) {
 		httputil.RespondWithNotFound(c, "audiobook", id)
 		return
 	}
@@ -413,7 +418,7 @@ func (h *Handler) GetSegmentTags(c *gin.Context) {
 	}
 
 	book, err := store.GetBookByID(id)
-	if err != nil || book == nil {
+	if err != nil || book == nil || !bookVisible(c, book) {
 		httputil.RespondWithNotFound(c, "audiobook", id)
 		return
 	}
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/audiobooks/handler_metadata.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 591661c3-5e87-4559-9a08-3203eec4fb68
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Metadata-history / undo / field-state / path-history / external-id /
 // changelog / changes endpoints for the audiobooks domain. Split out of
@@ -31,6 +31,9 @@ func (h *Handler) GetBookMetadataHistory(c *gin.Context) {
 		httputil.RespondWithInternalError(c, "database not initialized")
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	limit := 100
 	if l := c.Query("limit"); l != "" {
 		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
@@ -53,6 +56,9 @@ func (h *Handler) GetBookMetadataHistory(c *gin.Context) {
 // reached through the injected getFieldStates closure (surfaced as any).
 func (h *Handler) GetAudiobookFieldStates(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	states, err := h.getFieldStates(id)
 	if err != nil {
 		httputil.InternalError(c, "failed to get field states", err)
@@ -70,6 +76,9 @@ func (h *Handler) GetFieldMetadataHistory(c *gin.Context) {
 		httputil.RespondWithInternalError(c, "database not initialized")
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	limit := 50
 	if l := c.Query("limit"); l != "" {
 		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
@@ -96,6 +105,9 @@ func (h *Handler) UndoMetadataChange(c *gin.Context) {
 		httputil.RespondWithInternalError(c, "database not initialized")
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 
 	// Get the latest change for this field
 	records, err := store.GetMetadataChangeHistory(id, field, 1)
@@ -163,6 +175,9 @@ func (h *Handler) UndoLastApply(c *gin.Context) {
 		httputil.RespondWithInternalError(c, "database not initialized")
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 
 	// Get recent history for this book (enough to find the last apply batch)
 	history, err := store.GetBookChangeHistory(id, 50)
@@ -266,6 +281,9 @@ func (h *Handler) UndoLastApply(c *gin.Context) {
 // GetBookPathHistory handles GET /audiobooks/:id/path-history.
 func (h *Handler) GetBookPathHistory(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	history, err := h.resolveStore().GetBookPathHistory(id)
 	if err != nil {
 		httputil.RespondWithOK(c, gin.H{"history": []any{}})
@@ -279,6 +297,9 @@ func (h *Handler) GetBookPathHistory(c *gin.Context) {
 // through the injected getExternalIDStore closure.
 func (h *Handler) GetAudiobookExternalIDs(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	eidStore := h.getExternalIDStore()
 	if eidStore == nil {
 		httputil.RespondWithOK(c, gin.H{"external_ids": []any{}, "itunes_linked": false})
@@ -306,6 +327,9 @@ func (h *Handler) GetAudiobookExternalIDs(c *gin.Context) {
 // GetBookChangelog handles GET /audiobooks/:id/changelog.
 func (h *Handler) GetBookChangelog(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	entries, err := h.changelogService.GetBookChangelog(id)
 	if err != nil {
 		httputil.InternalError(c, "failed to get changelog", err)
@@ -321,6 +345,9 @@ func (h *Handler) GetBookChangelog(c *gin.Context) {
 // GET /audiobooks/:id/changes.
 func (h *Handler) GetBookChanges(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	changes, err := h.resolveStore().GetBookChanges(id)
 	if err != nil {
 		httputil.InternalError(c, "failed to get book changes", err)
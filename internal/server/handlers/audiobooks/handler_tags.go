@@ -1,7 +1,7 @@
 // file: internal/server/handlers/audiobooks/handler_tags.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: ff2e3609-5ce3-4414-a18b-976d21b929fb
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Tag read/write, alternative-title CRUD, and batch tag-update endpoints for
 // the audiobooks domain. Split out of handler.go for readability; one Handler,
@@ -21,6 +21,9 @@ import (
 // GetAudiobookTags handles GET /audiobooks/:id/tags.
 func (h *Handler) GetAudiobookTags(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	compareID := c.Query("compare_id")
 	snapshotTS := c.Query("snapshot_ts")
 	if snapshotTS != "" {
@@ -58,6 +61,9 @@ func (h *Handler) ListAllUserTags(c *gin.Context) {
 // GetBookUserTags handles GET /audiobooks/:id/user-tags.
 func (h *Handler) GetBookUserTags(c *gin.Context) {
 	id := c.Param("id")
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	tags, err := h.audiobookService.GetBookUserTags(id)
 	if err != nil {
 		httputil.RespondWithInternalError(c, err.Error())
@@ -77,6 +83,9 @@ func (h *Handler) GetBookTagsDetailed(c *gin.Context) {
 		httputil.RespondWithBadRequest(c, "book id is required")
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	tags, err := h.resolveStore().GetBookTagsDetailed(id)
 	if err != nil {
 		httputil.RespondWithInternalError(c, err.Error())
@@ -95,6 +104,9 @@ func (h *Handler) GetBookAlternativeTitles(c *gin.Context) {
 		httputil.RespondWithBadRequest(c, "id is required")
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	alts, err := h.resolveStore().GetBookAlternativeTitles(id)
 	if err != nil {
 		httputil.InternalError(c, "failed to get alternative titles", err)
@@ -126,7 +138,7 @@ func (h *Handler) AddBookAlternativeTitle(c *gin.Context) {
 	store := h.resolveStore()
 	// Confirm the book exists before inserting — avoids orphan alt
 	// title rows for deleted books.
-	if book, err := store.GetBookByID(id); err != nil || book == nil {
+	if book, err := store.GetBookByID(id); err != nil || book == nil || !bookVisible(c, book) {
 		httputil.RespondWithNotFound(c, "book", id)
 		return
 	}
@@ -145,6 +157,9 @@ func (h *Handler) RemoveBookAlternativeTitle(c *gin.Context) {
 		httputil.RespondWithBadRequest(c, "id is required")
 		return
 	}
+	if !h.requireVisibleBook(c, id) {
+		return
+	}
 	var body struct {
 		Title string `json:"title"`
 	}
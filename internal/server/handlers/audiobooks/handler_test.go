@@ -1,7 +1,7 @@
 // file: internal/server/handlers/audiobooks/handler_test.go
-// version: 1.0.0
+// version: 1.9.0
 // guid: 5cd764d5-8036-425c-842e-c49d0d44acec
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Tests for the audiobooks-domain handlers (main library list / CRUD). The
 // store / audiobook-service / updater / write-back / metadata-state /
@@ -20,7 +20,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
@@ -28,6 +30,8 @@ import (
 	audiobookspkg "github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/batch"
 	"github.com/falkcorp/audiobook-organizer/internal/cache"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/contentfilter"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/plugin"
 	audiobookshandler "github.com/falkcorp/audiobook-organizer/internal/server/handlers/audiobooks"
@@ -48,6 +52,9 @@ type recorders struct {
 	listResp         gin.H
 	listErr          error
 	extIDStore       audiobookshandler.ExternalIDStore
+	// storeOverride, when set, replaces the mocked store (for stores that
+	// need optional methods the mock lacks).
+	storeOverride audiobookshandler.AudiobooksStore
 }
 
 type testDeps struct {
@@ -86,7 +93,12 @@ func newHandler(t *testing.T) (*audiobookshandler.Handler, testDeps) {
 	rec := &recorders{}
 
 	h := audiobookshandler.New(
-		func() audiobookshandler.AudiobooksStore { return store },
+		func() audiobookshandler.AudiobooksStore {
+			if rec.storeOverride != nil {
+				return rec.storeOverride
+			}
+			return store
+		},
 		svc,
 		updater,
 		func() audiobookshandler.WriteBackEnqueuer { return writeBack },
@@ -168,6 +180,46 @@ func TestListAudiobooks_FileErrorsFastPath(t *testing.T) {
 	_ = json.Unmarshal(w.Body.Bytes(), &resp)
 }
 
+// fileErrorsStore adds the file-errors index the has_file_errors fast
+// path looks for.
+type fileErrorsStore struct {
+	*audiobooksmocks.MockAudiobooksStore
+	ids []string
+}
+
+func (s *fileErrorsStore) ListBooksWithFileErrors() ([]string, error) { return s.ids, nil }
+
+func TestListAudiobooks_FileErrorsFastPathContentFiltered(t *testing.T) {
+	h, d := newHandler(t)
+	d.rec.storeOverride = &fileErrorsStore{MockAudiobooksStore: d.store, ids: []string{"k1", "a1"}}
+	explicit := true
+	// Each book is loaded once: the visibility pass's copy builds the page.
+	d.store.EXPECT().GetBookByID("k1").Return(&database.Book{ID: "k1"}, nil).Once()
+	d.store.EXPECT().GetBookByID("a1").Return(&database.Book{ID: "a1", Explicit: &explicit}, nil).Once()
+	d.svc.EXPECT().EnrichAudiobooksWithNames(mock.Anything).RunAndReturn(func(books []database.Book) []audiobookspkg.AudiobookDetail {
+		out := make([]audiobookspkg.AudiobookDetail, len(books))
+		for i := range books {
+			out[i].Book = &books[i]
+		}
+		return out
+	})
+	c, w := newCtx("GET", "/audiobooks?has_file_errors=true", nil, nil)
+	withExplicitBlocked(c)
+	h.ListAudiobooks(c)
+	var resp struct {
+		Data struct {
+			Items []database.Book `json:"items"`
+			Total int             `json:"total"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Total != 1 || len(resp.Data.Items) != 1 || resp.Data.Items[0].ID != "k1" {
+		t.Fatalf("want only k1, got %s", w.Body.String())
+	}
+}
+
 func TestListAudiobooks_QuickQueryEmpty(t *testing.T) {
 	h, _ := newHandler(t)
 	// store doesn't implement GetAllBookIDsForQuickQuery → empty set
@@ -178,6 +230,132 @@ func TestListAudiobooks_QuickQueryEmpty(t *testing.T) {
 	}
 }
 
+// cursorBooksStore adds cursor paging over a fixed, ID-ordered book list to
+// the mocked store.
+type cursorBooksStore struct {
+	*audiobooksmocks.MockAudiobooksStore
+	books []database.Book
+}
+
+func (s *cursorBooksStore) ListBooksAfter(after *database.PageCursor, sortBy string, desc bool, limit int) ([]database.Book, error) {
+	out := []database.Book{}
+	for _, b := range s.books {
+		if after != nil && b.ID <= after.ID {
+			continue
+		}
+		out = append(out, b)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *cursorBooksStore) ListOperationsAfter(*database.PageCursor, int) ([]database.Operation, error) {
+	return nil, nil
+}
+
+func TestListAudiobooks_Cursor(t *testing.T) {
+	h, d := newHandler(t)
+	quarantined := time.Now()
+	d.rec.storeOverride = &cursorBooksStore{
+		MockAudiobooksStore: d.store,
+		books: []database.Book{
+			{ID: "b1"}, {ID: "b2", QuarantinedAt: &quarantined}, {ID: "b3"}, {ID: "b4"}, {ID: "b5"},
+		},
+	}
+	d.svc.EXPECT().EnrichAudiobooksWithNames(mock.Anything).RunAndReturn(func(books []database.Book) []audiobookspkg.AudiobookDetail {
+		out := make([]audiobookspkg.AudiobookDetail, len(books))
+		for i := range books {
+			out[i] = audiobookspkg.AudiobookDetail{Book: &books[i]}
+		}
+		return out
+	})
+	list := func(target string) (int, []string, string) {
+		c, w := newCtx("GET", target, nil, nil)
+		h.ListAudiobooks(c)
+		var resp struct {
+			Data struct {
+				Items []struct {
+					ID string `json:"id"`
+				} `json:"items"`
+				NextCursor string `json:"next_cursor"`
+			} `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		var ids []string
+		for _, it := range resp.Data.Items {
+			ids = append(ids, it.ID)
+		}
+		return w.Code, ids, resp.Data.NextCursor
+	}
+
+	// The quarantined b2 is skipped and the page refilled from the store.
+	code, ids, next := list("/audiobooks?pagination=cursor&limit=2")
+	if code != http.StatusOK || !reflect.DeepEqual(ids, []string{"b1", "b3"}) || next == "" {
+		t.Fatalf("first page = %d %v %q", code, ids, next)
+	}
+	code, ids, next = list("/audiobooks?limit=2&cursor=" + next)
+	if code != http.StatusOK || !reflect.DeepEqual(ids, []string{"b4", "b5"}) {
+		t.Fatalf("second page = %d %v", code, ids)
+	}
+	code, ids, next = list("/audiobooks?limit=2&cursor=" + next)
+	if code != http.StatusOK || len(ids) != 0 || next != "" {
+		t.Fatalf("last page = %d %v %q", code, ids, next)
+	}
+
+	for _, target := range []string{
+		"/audiobooks?cursor=garbage",
+		"/audiobooks?pagination=cursor&offset=10",
+		"/audiobooks?pagination=cursor&author_id=3",
+		"/audiobooks?pagination=cursor&sort_by=author",
+	} {
+		if code, _, _ := list(target); code != http.StatusBadRequest {
+			t.Errorf("%s: want 400, got %d", target, code)
+		}
+	}
+}
+
+func TestListAudiobooks_Fields(t *testing.T) {
+	h, d := newHandler(t)
+	title := "Dune"
+	d.rec.storeOverride = &cursorBooksStore{
+		MockAudiobooksStore: d.store,
+		books:               []database.Book{{ID: "b1", Title: title, FilePath: "/lib/dune.m4b"}},
+	}
+	d.svc.EXPECT().EnrichAudiobooksWithNames(mock.Anything).RunAndReturn(func(books []database.Book) []audiobookspkg.AudiobookDetail {
+		out := make([]audiobookspkg.AudiobookDetail, len(books))
+		for i := range books {
+			out[i] = audiobookspkg.AudiobookDetail{Book: &books[i], AuthorName: &title}
+		}
+		return out
+	})
+
+	c, w := newCtx("GET", "/audiobooks?pagination=cursor&fields=title,author_name", nil, nil)
+	h.ListAudiobooks(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data struct {
+			Items []map[string]any `json:"items"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Data.Items) != 1 {
+		t.Fatalf("decode: %v (%s)", err, w.Body.String())
+	}
+	want := map[string]any{"id": "b1", "title": "Dune", "author_name": "Dune"}
+	if !reflect.DeepEqual(resp.Data.Items[0], want) {
+		t.Errorf("item = %v, want %v", resp.Data.Items[0], want)
+	}
+
+	c, w = newCtx("GET", "/audiobooks?fields=title,bogus", nil, nil)
+	h.ListAudiobooks(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown field: want 400, got %d", w.Code)
+	}
+}
+
 func TestCountAudiobooks(t *testing.T) {
 	h, d := newHandler(t)
 	d.svc.EXPECT().CountAudiobooks(mock.Anything).Return(42, nil)
@@ -223,6 +401,35 @@ func TestPurgeSoftDeletedAudiobooks(t *testing.T) {
 	}
 }
 
+func TestPurgeAudiobook(t *testing.T) {
+	h, d := newHandler(t)
+	d.svc.EXPECT().PurgeDeletedBook(mock.Anything, "b1", true).
+		Return(&audiobookspkg.PurgeResult{Purged: 1}, nil)
+	c, w := newCtx("DELETE", "/audiobooks/b1/purge?delete_files=true", nil, p("id", "b1"))
+	h.PurgeAudiobook(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d", w.Code)
+	}
+}
+
+func TestPurgeAudiobook_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{audiobookspkg.ErrNotSoftDeleted, http.StatusConflict},
+		{errString("audiobook not found"), http.StatusNotFound},
+	} {
+		h, d := newHandler(t)
+		d.svc.EXPECT().PurgeDeletedBook(mock.Anything, "b1", false).Return(nil, tc.err)
+		c, w := newCtx("DELETE", "/audiobooks/b1/purge", nil, p("id", "b1"))
+		h.PurgeAudiobook(c)
+		if w.Code != tc.want {
+			t.Fatalf("%v: want %d, got %d", tc.err, tc.want, w.Code)
+		}
+	}
+}
+
 func TestRestoreAudiobook(t *testing.T) {
 	h, d := newHandler(t)
 	d.svc.EXPECT().RestoreAudiobook(mock.Anything, "b1").Return(&database.Book{ID: "b1"}, nil)
@@ -298,6 +505,206 @@ func TestGetAudiobook_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetAudiobook_OutsideLibraryScope(t *testing.T) {
+	orig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = orig })
+	config.AppConfig.LibraryIsolation = true
+	config.AppConfig.Libraries = []config.LibraryDefinition{
+		{ID: "kids", RootDir: "/lib/kids", Roles: []string{"child"}},
+	}
+
+	h, d := newHandler(t)
+	d.svc.EXPECT().GetAudiobook(mock.Anything, "b1").Return(&database.Book{ID: "b1", FilePath: "/lib/adults/b1.m4b"}, nil)
+	c, w := newCtx("GET", "/audiobooks/b1", nil, p("id", "b1"))
+	c.Set("auth_user", &database.User{ID: "u1", Roles: []string{"child"}})
+	h.GetAudiobook(c)
+	if w.Code != http.StatusNotFound || d.rec.enrichCalls != 0 {
+		t.Fatalf("want 404 without enrich, got %d enrich=%d", w.Code, d.rec.enrichCalls)
+	}
+}
+
+// isolateKidsLibrary enables library isolation with a single "kids" library
+// visible to the child role and returns a caller holding only that role.
+func isolateKidsLibrary(t *testing.T) *database.User {
+	t.Helper()
+	orig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = orig })
+	config.AppConfig.LibraryIsolation = true
+	config.AppConfig.Libraries = []config.LibraryDefinition{
+		{ID: "kids", RootDir: "/lib/kids", Roles: []string{"child"}},
+	}
+	return &database.User{ID: "u1", Roles: []string{"child"}}
+}
+
+// perBookEndpoints lists the per-book subresource endpoints that must
+// answer 404 for a book the caller may not see.
+var perBookEndpoints = []struct {
+	name   string
+	method string
+	body   any
+	params gin.Params
+	call   func(*audiobookshandler.Handler, *gin.Context)
+}{
+	{"segments", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).ListAudiobookSegments},
+	{"files", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).ListBookFiles},
+	{"patch file", "PATCH", gin.H{"skip_scan": true}, gin.Params{{Key: "id", Value: "b1"}, {Key: "file_id", Value: "f1"}}, (*audiobookshandler.Handler).PatchBookFile},
+	{"tags", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetAudiobookTags},
+	{"user tags", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetBookUserTags},
+	{"tags detailed", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetBookTagsDetailed},
+	{"alternative titles", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetBookAlternativeTitles},
+	{"metadata history", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetBookMetadataHistory},
+	{"path history", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetBookPathHistory},
+	{"changelog", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetBookChangelog},
+	{"changes", "GET", nil, p("id", "b1"), (*audiobookshandler.Handler).GetBookChanges},
+	{"purge", "DELETE", nil, p("id", "b1"), (*audiobookshandler.Handler).PurgeAudiobook},
+	{"restore", "POST", nil, p("id", "b1"), (*audiobookshandler.Handler).RestoreAudiobook},
+	{"delete", "DELETE", nil, p("id", "b1"), (*audiobookshandler.Handler).DeleteAudiobook},
+}
+
+func TestPerBookEndpoints_OutsideLibraryScope(t *testing.T) {
+	for _, tc := range perBookEndpoints {
+		t.Run(tc.name, func(t *testing.T) {
+			user := isolateKidsLibrary(t)
+			h, d := newHandler(t)
+			// Only the visibility lookup is expected; the mocks fail the
+			// test if the endpoint reaches its own store or service call.
+			d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", FilePath: "/lib/adults/b1.m4b"}, nil)
+			c, w := newCtx(tc.method, "/audiobooks/b1", tc.body, tc.params)
+			c.Set("auth_user", user)
+			tc.call(h, c)
+			if w.Code != http.StatusNotFound {
+				t.Fatalf("want 404, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestAudiobookFacets_LibraryScope(t *testing.T) {
+	user := isolateKidsLibrary(t)
+	h, d := newHandler(t)
+	picture, thriller, en, de := "Picture Books", "Thriller", "en", "de"
+	d.store.EXPECT().GetAllBooks(0, 0).Return([]database.Book{
+		{ID: "k1", FilePath: "/lib/kids/k1.m4b", Genre: &picture, Language: &en},
+		{ID: "a1", FilePath: "/lib/adults/a1.m4b", Genre: &thriller, Language: &de},
+	}, nil)
+	c, w := newCtx("GET", "/audiobooks/facets", nil, nil)
+	c.Set("auth_user", user)
+	h.AudiobookFacets(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data struct {
+			Genres    []string `json:"genres"`
+			Languages []string `json:"languages"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Data.Genres, []string{picture}) || !reflect.DeepEqual(resp.Data.Languages, []string{en}) {
+		t.Fatalf("facets leaked outside the scope: %+v", resp.Data)
+	}
+	if _, ok := d.facetCache.Get("all"); ok {
+		t.Fatal("scoped facets must not fill the unrestricted cache entry")
+	}
+}
+
+func TestListSoftDeletedAudiobooks_LibraryScope(t *testing.T) {
+	user := isolateKidsLibrary(t)
+	h, d := newHandler(t)
+	d.svc.EXPECT().GetSoftDeletedBooks(mock.Anything, 10000, 0, mock.Anything).Return([]database.Book{
+		{ID: "a1", FilePath: "/lib/adults/a1.m4b"},
+		{ID: "k1", FilePath: "/lib/kids/k1.m4b"},
+	}, nil)
+	c, w := newCtx("GET", "/audiobooks/soft-deleted", nil, nil)
+	c.Set("auth_user", user)
+	h.ListSoftDeletedAudiobooks(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data struct {
+			Items []database.Book `json:"items"`
+			Total int             `json:"total"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Total != 1 || len(resp.Data.Items) != 1 || resp.Data.Items[0].ID != "k1" {
+		t.Fatalf("want only k1, got %s", w.Body.String())
+	}
+}
+
+// withExplicitBlocked installs a content filter hiding explicit books.
+func withExplicitBlocked(c *gin.Context) {
+	c.Set("content_filter", contentfilter.New(contentfilter.Profile{BlockExplicit: true}, nil))
+}
+
+func TestPerBookEndpoints_ContentFiltered(t *testing.T) {
+	explicit := true
+	for _, tc := range perBookEndpoints {
+		t.Run(tc.name, func(t *testing.T) {
+			h, d := newHandler(t)
+			d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", Explicit: &explicit}, nil)
+			c, w := newCtx(tc.method, "/audiobooks/b1", tc.body, tc.params)
+			withExplicitBlocked(c)
+			tc.call(h, c)
+			if w.Code != http.StatusNotFound {
+				t.Fatalf("want 404, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestAudiobookFacets_ContentFiltered(t *testing.T) {
+	h, d := newHandler(t)
+	explicit, picture, thriller := true, "Picture Books", "Thriller"
+	d.store.EXPECT().GetAllBooks(0, 0).Return([]database.Book{
+		{ID: "k1", Genre: &picture},
+		{ID: "a1", Genre: &thriller, Explicit: &explicit},
+	}, nil)
+	c, w := newCtx("GET", "/audiobooks/facets", nil, nil)
+	withExplicitBlocked(c)
+	h.AudiobookFacets(c)
+	var resp struct {
+		Data struct {
+			Genres []string `json:"genres"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Data.Genres, []string{picture}) {
+		t.Fatalf("want only %q, got %s", picture, w.Body.String())
+	}
+}
+
+func TestListSoftDeletedAudiobooks_ContentFiltered(t *testing.T) {
+	h, d := newHandler(t)
+	explicit := true
+	d.svc.EXPECT().GetSoftDeletedBooks(mock.Anything, 10000, 0, mock.Anything).Return([]database.Book{
+		{ID: "a1", Explicit: &explicit},
+		{ID: "k1"},
+	}, nil)
+	c, w := newCtx("GET", "/audiobooks/soft-deleted", nil, nil)
+	withExplicitBlocked(c)
+	h.ListSoftDeletedAudiobooks(c)
+	var resp struct {
+		Data struct {
+			Items []database.Book `json:"items"`
+			Total int             `json:"total"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Total != 1 || len(resp.Data.Items) != 1 || resp.Data.Items[0].ID != "k1" {
+		t.Fatalf("want only k1, got %s", w.Body.String())
+	}
+}
+
 // ---- files / segments ----
 
 func TestListAudiobookSegments(t *testing.T) {
@@ -679,6 +1086,80 @@ func TestUpdateAudiobook_ProtectedPathSkipsWriteBack(t *testing.T) {
 	}
 }
 
+func TestPatchAudiobook_JSONPatch(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", Title: "Old"}, nil)
+	d.updater.EXPECT().UpdateAudiobook(mock.Anything, "b1", map[string]any{"title": "New"}).
+		Return(&database.Book{ID: "b1", Title: "New"}, nil)
+	d.store.EXPECT().RecordMetadataChange(mock.Anything).Return(nil).Maybe()
+	d.svc.EXPECT().InvalidateBookCaches().Return()
+	d.writeBack.EXPECT().Enqueue("b1").Return()
+	c, w := newCtx("PATCH", "/audiobooks/b1", []map[string]any{
+		{"op": "test", "path": "/title", "value": "Old"},
+		{"op": "replace", "path": "/title", "value": "New"},
+	}, p("id", "b1"))
+	h.PatchAudiobook(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestPatchAudiobook_SeriesNameResolved(t *testing.T) {
+	h, d := newHandler(t)
+	seriesID := 3
+	// GetBookByID does not hydrate Book.Series; the patch document must
+	// look the name up so a test op against it can succeed.
+	d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", Title: "Old", SeriesID: &seriesID}, nil)
+	d.store.EXPECT().GetSeriesByID(seriesID).Return(&database.Series{ID: seriesID, Name: "Dune Chronicles"}, nil)
+	d.updater.EXPECT().UpdateAudiobook(mock.Anything, "b1", map[string]any{"title": "New"}).
+		Return(&database.Book{ID: "b1", Title: "New", SeriesID: &seriesID}, nil)
+	d.store.EXPECT().RecordMetadataChange(mock.Anything).Return(nil).Maybe()
+	d.svc.EXPECT().InvalidateBookCaches().Return()
+	d.writeBack.EXPECT().Enqueue("b1").Return()
+	c, w := newCtx("PATCH", "/audiobooks/b1", []map[string]any{
+		{"op": "test", "path": "/series_name", "value": "Dune Chronicles"},
+		{"op": "replace", "path": "/title", "value": "New"},
+	}, p("id", "b1"))
+	h.PatchAudiobook(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestPatchAudiobook_MergePatchNoChange(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", Title: "Old"}, nil)
+	c, w := newCtx("PATCH", "/audiobooks/b1", map[string]any{"title": "Old"}, p("id", "b1"))
+	h.PatchAudiobook(c)
+	if w.Code != http.StatusOK || d.rec.enrichCalls != 1 {
+		t.Fatalf("want 200 without update, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestPatchAudiobook_Rejected(t *testing.T) {
+	cases := []struct {
+		name string
+		body any
+		want int
+	}{
+		{"test op mismatch", []map[string]any{{"op": "test", "path": "/title", "value": "Other"}}, http.StatusConflict},
+		{"unknown field", map[string]any{"file_path": "/tmp/x"}, http.StatusBadRequest},
+		{"wrong type", map[string]any{"audiobook_release_year": "soon"}, http.StatusBadRequest},
+		{"not a patch", "title", http.StatusUnsupportedMediaType},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, d := newHandler(t)
+			d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", Title: "Old"}, nil)
+			c, w := newCtx("PATCH", "/audiobooks/b1", tc.body, p("id", "b1"))
+			h.PatchAudiobook(c)
+			if w.Code != tc.want {
+				t.Fatalf("want %d, got %d (%s)", tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
 func TestDeleteAudiobook(t *testing.T) {
 	h, d := newHandler(t)
 	d.svc.EXPECT().DeleteAudiobook(mock.Anything, "b1", mock.Anything).Return(map[string]any{"deleted": true}, nil)
@@ -689,6 +1170,28 @@ func TestDeleteAudiobook(t *testing.T) {
 	}
 }
 
+func TestDeleteAudiobook_MoveToTrash(t *testing.T) {
+	prev := config.AppConfig.SoftDeleteToTrash
+	config.AppConfig.SoftDeleteToTrash = true
+	t.Cleanup(func() { config.AppConfig.SoftDeleteToTrash = prev })
+
+	for target, want := range map[string]bool{
+		"/audiobooks/b1?soft_delete=true":                     true,
+		"/audiobooks/b1?soft_delete=true&move_to_trash=false": false,
+		"/audiobooks/b1": false,
+	} {
+		h, d := newHandler(t)
+		d.svc.EXPECT().DeleteAudiobook(mock.Anything, "b1", mock.MatchedBy(func(o *audiobookspkg.DeleteAudiobookOptions) bool {
+			return o.MoveToTrash == want
+		})).Return(map[string]any{"deleted": true}, nil)
+		c, w := newCtx("DELETE", target, nil, p("id", "b1"))
+		h.DeleteAudiobook(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: want 200, got %d", target, w.Code)
+		}
+	}
+}
+
 func TestDeleteAudiobook_Conflict(t *testing.T) {
 	h, d := newHandler(t)
 	d.svc.EXPECT().DeleteAudiobook(mock.Anything, "b1", mock.Anything).Return(nil, errString("already soft deleted"))
@@ -699,6 +1202,42 @@ func TestDeleteAudiobook_Conflict(t *testing.T) {
 	}
 }
 
+func TestBatchDeleteAudiobooks(t *testing.T) {
+	h, d := newHandler(t)
+	opts := mock.MatchedBy(func(o *audiobookspkg.DeleteAudiobookOptions) bool {
+		return o.SoftDelete && o.BlockHash && !o.MoveToTrash
+	})
+	d.svc.EXPECT().DeleteAudiobook(mock.Anything, "b1", opts).Return(map[string]any{"deleted": true}, nil)
+	d.svc.EXPECT().DeleteAudiobook(mock.Anything, "b2", opts).Return(nil, errString("audiobook not found"))
+	body := map[string]any{"ids": []string{"b1", "b2"}, "soft_delete": true, "block_hash": true, "move_to_trash": false}
+	c, w := newCtx("POST", "/audiobooks/batch-delete", body, nil)
+	h.BatchDeleteAudiobooks(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data batch.BatchResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Success != 1 || resp.Data.Failed != 1 || resp.Data.Results[1].Error != "audiobook not found" {
+		t.Fatalf("unexpected response: %+v", resp.Data)
+	}
+	if len(d.rec.publishedEvents) != 1 {
+		t.Fatalf("want 1 event, got %d", len(d.rec.publishedEvents))
+	}
+}
+
+func TestBatchDeleteAudiobooks_NoIDs(t *testing.T) {
+	h, _ := newHandler(t)
+	c, w := newCtx("POST", "/audiobooks/batch-delete", map[string]any{"ids": []string{}}, nil)
+	h.BatchDeleteAudiobooks(c)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want 400, got %d", w.Code)
+	}
+}
+
 func TestBatchUpdateAudiobooks(t *testing.T) {
 	h, d := newHandler(t)
 	d.batchSvc.EXPECT().UpdateAudiobooks(mock.Anything).Return(&batch.BatchResponse{})
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/audiobooks/interfaces.go
-// version: 1.0.0
+// version: 1.3.0
 // guid: 110386de-3e07-4ef3-b0e0-2e717a249e91
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Narrow dependency interfaces for the audiobooks-domain HTTP handlers (the
 // main library list / CRUD domain: list, count, facets, soft-delete /
@@ -70,9 +70,11 @@ type AudiobooksStore interface {
 	AddBookAlternativeTitle(bookID, title, source, language string) error
 	RemoveBookAlternativeTitle(bookID, title string) error
 	GetBookChanges(bookID string) ([]*database.OperationChange, error)
+	GetAllBooks(limit, offset int) ([]database.Book, error)
 	GetDistinctGenres() ([]string, error)
 	GetDistinctLanguages() ([]string, error)
 	GetAuthorByID(id int) (*database.Author, error)
+	GetSeriesByID(id int) (*database.Series, error)
 	GetNarratorByID(id int) (*database.Narrator, error)
 	GetBookAuthors(bookID string) ([]database.BookAuthor, error)
 	GetBookNarrators(bookID string) ([]database.BookNarrator, error)
@@ -90,6 +92,7 @@ type AudiobookService interface {
 	GetAudiobook(ctx context.Context, id string) (*database.Book, error)
 	GetAudiobookTags(ctx context.Context, id, compareID, snapshotTS string) (map[string]any, error)
 	GetSoftDeletedBooks(ctx context.Context, limit, offset int, olderThanDays *int) ([]database.Book, error)
+	PurgeDeletedBook(ctx context.Context, id string, deleteFiles bool) (*audiobookspkg.PurgeResult, error)
 	PurgeSoftDeletedBooks(ctx context.Context, deleteFiles bool, olderThanDays *int) (*audiobookspkg.PurgeResult, error)
 	RestoreAudiobook(ctx context.Context, id string) (*database.Book, error)
 	CountAudiobooks(ctx context.Context) (int, error)
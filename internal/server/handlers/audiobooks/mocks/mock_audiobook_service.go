@@ -676,6 +676,80 @@ func (_c *MockAudiobookService_ListAllUserTags_Call) RunAndReturn(run func() ([]
 	return _c
 }
 
+// PurgeDeletedBook provides a mock function for the type MockAudiobookService
+func (_mock *MockAudiobookService) PurgeDeletedBook(ctx context.Context, id string, deleteFiles bool) (*audiobooks.PurgeResult, error) {
+	ret := _mock.Called(ctx, id, deleteFiles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedBook")
+	}
+
+	var r0 *audiobooks.PurgeResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (*audiobooks.PurgeResult, error)); ok {
+		return returnFunc(ctx, id, deleteFiles)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) *audiobooks.PurgeResult); ok {
+		r0 = returnFunc(ctx, id, deleteFiles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*audiobooks.PurgeResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, id, deleteFiles)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudiobookService_PurgeDeletedBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedBook'
+type MockAudiobookService_PurgeDeletedBook_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - deleteFiles bool
+func (_e *MockAudiobookService_Expecter) PurgeDeletedBook(ctx interface{}, id interface{}, deleteFiles interface{}) *MockAudiobookService_PurgeDeletedBook_Call {
+	return &MockAudiobookService_PurgeDeletedBook_Call{Call: _e.mock.On("PurgeDeletedBook", ctx, id, deleteFiles)}
+}
+
+func (_c *MockAudiobookService_PurgeDeletedBook_Call) Run(run func(ctx context.Context, id string, deleteFiles bool)) *MockAudiobookService_PurgeDeletedBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAudiobookService_PurgeDeletedBook_Call) Return(purgeResult *audiobooks.PurgeResult, err error) *MockAudiobookService_PurgeDeletedBook_Call {
+	_c.Call.Return(purgeResult, err)
+	return _c
+}
+
+func (_c *MockAudiobookService_PurgeDeletedBook_Call) RunAndReturn(run func(ctx context.Context, id string, deleteFiles bool) (*audiobooks.PurgeResult, error)) *MockAudiobookService_PurgeDeletedBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PurgeSoftDeletedBooks provides a mock function for the type MockAudiobookService
 func (_mock *MockAudiobookService) PurgeSoftDeletedBooks(ctx context.Context, deleteFiles bool, olderThanDays *int) (*audiobooks.PurgeResult, error) {
 	ret := _mock.Called(ctx, deleteFiles, olderThanDays)
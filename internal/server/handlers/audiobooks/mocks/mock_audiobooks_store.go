@@ -107,6 +107,74 @@ func (_c *MockAudiobooksStore_AddBookAlternativeTitle_Call) RunAndReturn(run fun
 	return _c
 }
 
+// GetAllBooks provides a mock function for the type MockAudiobooksStore
+func (_mock *MockAudiobooksStore) GetAllBooks(limit int, offset int) ([]database.Book, error) {
+	ret := _mock.Called(limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBooks")
+	}
+
+	var r0 []database.Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]database.Book, error)); ok {
+		return returnFunc(limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []database.Book); ok {
+		r0 = returnFunc(limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudiobooksStore_GetAllBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBooks'
+type MockAudiobooksStore_GetAllBooks_Call struct {
+	*mock.Call
+}
+
+// GetAllBooks is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+func (_e *MockAudiobooksStore_Expecter) GetAllBooks(limit interface{}, offset interface{}) *MockAudiobooksStore_GetAllBooks_Call {
+	return &MockAudiobooksStore_GetAllBooks_Call{Call: _e.mock.On("GetAllBooks", limit, offset)}
+}
+
+func (_c *MockAudiobooksStore_GetAllBooks_Call) Run(run func(limit int, offset int)) *MockAudiobooksStore_GetAllBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAudiobooksStore_GetAllBooks_Call) Return(books []database.Book, err error) *MockAudiobooksStore_GetAllBooks_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockAudiobooksStore_GetAllBooks_Call) RunAndReturn(run func(limit int, offset int) ([]database.Book, error)) *MockAudiobooksStore_GetAllBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAuthorByID provides a mock function for the type MockAudiobooksStore
 func (_mock *MockAudiobooksStore) GetAuthorByID(id int) (*database.Author, error) {
 	ret := _mock.Called(id)
@@ -1047,6 +1115,68 @@ func (_c *MockAudiobooksStore_GetNarratorByID_Call) RunAndReturn(run func(id int
 	return _c
 }
 
+// GetSeriesByID provides a mock function for the type MockAudiobooksStore
+func (_mock *MockAudiobooksStore) GetSeriesByID(id int) (*database.Series, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeriesByID")
+	}
+
+	var r0 *database.Series
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (*database.Series, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) *database.Series); ok {
+		r0 = returnFunc(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*database.Series)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudiobooksStore_GetSeriesByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSeriesByID'
+type MockAudiobooksStore_GetSeriesByID_Call struct {
+	*mock.Call
+}
+
+// GetSeriesByID is a helper method to define mock.On call
+//   - id int
+func (_e *MockAudiobooksStore_Expecter) GetSeriesByID(id interface{}) *MockAudiobooksStore_GetSeriesByID_Call {
+	return &MockAudiobooksStore_GetSeriesByID_Call{Call: _e.mock.On("GetSeriesByID", id)}
+}
+
+func (_c *MockAudiobooksStore_GetSeriesByID_Call) Run(run func(id int)) *MockAudiobooksStore_GetSeriesByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAudiobooksStore_GetSeriesByID_Call) Return(series *database.Series, err error) *MockAudiobooksStore_GetSeriesByID_Call {
+	_c.Call.Return(series, err)
+	return _c
+}
+
+func (_c *MockAudiobooksStore_GetSeriesByID_Call) RunAndReturn(run func(id int) (*database.Series, error)) *MockAudiobooksStore_GetSeriesByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RecordMetadataChange provides a mock function for the type MockAudiobooksStore
 func (_mock *MockAudiobooksStore) RecordMetadataChange(record *database.MetadataChangeRecord) error {
 	ret := _mock.Called(record)
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/cache.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: c9d0e1f2-a3b4-5678-cdef-678901234567
-// last-edited: 2026-06-02
+// last-edited: 2026-10-16
 
 package handlers
 
@@ -65,6 +65,13 @@ type CacheMetadataStore interface {
 	CountPrefix(prefix string) (int64, error)
 }
 
+// dbBackedCachePrefixes maps cache metric names to the RawKV prefix their
+// entries are stored under.
+var dbBackedCachePrefixes = map[string]string{
+	"metadata_fetch": "metadata_fetch_cache:",
+	"mediainfo":      database.MediaInfoCachePrefix,
+}
+
 // CacheHandler handles all cache-related HTTP endpoints.
 type CacheHandler struct {
 	metricsStore  CacheMetricsStore
@@ -89,11 +96,15 @@ func (h *CacheHandler) HandleCacheStats(c *gin.Context) {
 	stats := aggregateCacheMetrics(metrics)
 
 	// Patch DB-backed caches that have no in-memory size gauge.
-	// metadata_fetch lives in PebbleDB; count its keys via prefix scan.
+	// metadata_fetch and mediainfo live in PebbleDB; count their keys via prefix scan.
 	if h.metadataStore != nil {
-		if n, err := h.metadataStore.CountPrefix("metadata_fetch_cache:"); err == nil {
+		for name, prefix := range dbBackedCachePrefixes {
+			n, err := h.metadataStore.CountPrefix(prefix)
+			if err != nil {
+				continue
+			}
 			for i := range stats {
-				if stats[i].Name == "metadata_fetch" {
+				if stats[i].Name == name {
 					stats[i].Size = n
 					break
 				}
@@ -0,0 +1,292 @@
+// file: internal/server/handlers/collections.go
+// version: 1.0.0
+// guid: 6c2f8e14-3b7a-4d95-a0e6-9f1d4b8c7a25
+// last-edited: 2026-10-17
+
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// CollectionCreateReq is the payload for POST /api/v1/collections.
+type CollectionCreateReq struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BookIDs     []string `json:"book_ids,omitempty"`
+}
+
+// CollectionUpdateReq is the payload for PUT /api/v1/collections/:id.
+// Only set fields are applied; membership changes go through the books
+// and reorder endpoints.
+type CollectionUpdateReq struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// CollectionBooksAddReq is the payload for POST /api/v1/collections/:id/books.
+// Position is the 0-based index to insert at; omitted or out of range
+// appends.
+type CollectionBooksAddReq struct {
+	BookIDs  []string `json:"book_ids" binding:"required"`
+	Position *int     `json:"position,omitempty"`
+}
+
+// CollectionReorderReq is the payload for POST /api/v1/collections/:id/reorder.
+type CollectionReorderReq struct {
+	BookIDs []string `json:"book_ids" binding:"required"`
+}
+
+// CollectionStore is the narrow database interface CollectionHandler
+// requires: the raw KV space collections are stored in, plus book lookup
+// to refuse unknown IDs.
+type CollectionStore interface {
+	database.RawKVStore
+	GetBookByID(id string) (*database.Book, error)
+}
+
+// CollectionHandler handles all /collections routes. Collections are
+// always scoped to the calling user.
+type CollectionHandler struct {
+	store CollectionStore
+}
+
+// NewCollectionHandler constructs a CollectionHandler.
+func NewCollectionHandler(store CollectionStore) *CollectionHandler {
+	return &CollectionHandler{store: store}
+}
+
+// ListCollections — GET /api/v1/collections?limit=N&offset=M
+func (h *CollectionHandler) ListCollections(c *gin.Context) {
+	all, err := database.ListCollections(h.store, CallingUserID(c))
+	if err != nil {
+		httputil.InternalError(c, "failed to list collections", err)
+		return
+	}
+	p := httputil.ParsePaginationParams(c)
+	total := len(all)
+	page := all
+	if p.Offset >= len(page) {
+		page = page[:0]
+	} else {
+		page = page[p.Offset:]
+	}
+	if p.Limit > 0 && len(page) > p.Limit {
+		page = page[:p.Limit]
+	}
+	httputil.RespondWithList(c, page, total, p.Limit, p.Offset)
+}
+
+// CreateCollection — POST /api/v1/collections
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	var req CollectionCreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		httputil.RespondWithValidationError(c, "name", "required")
+		return
+	}
+	bookIDs, ok := h.checkBooks(c, req.BookIDs)
+	if !ok {
+		return
+	}
+	created, err := database.CreateCollection(h.store, database.Collection{
+		UserID:      CallingUserID(c),
+		Name:        name,
+		Description: req.Description,
+		BookIDs:     bookIDs,
+	})
+	if err != nil {
+		if errors.Is(err, database.ErrCollectionNameTaken) {
+			httputil.RespondWithConflict(c, err.Error())
+			return
+		}
+		httputil.InternalError(c, "failed to create collection", err)
+		return
+	}
+	httputil.RespondWithCreated(c, created)
+}
+
+// GetCollection — GET /api/v1/collections/:id
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	if coll, ok := h.load(c); ok {
+		httputil.RespondWithOK(c, coll)
+	}
+}
+
+// UpdateCollection — PUT /api/v1/collections/:id
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	coll, ok := h.load(c)
+	if !ok {
+		return
+	}
+	var req CollectionUpdateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			httputil.RespondWithValidationError(c, "name", "must not be empty")
+			return
+		}
+		coll.Name = name
+	}
+	if req.Description != nil {
+		coll.Description = *req.Description
+	}
+	h.save(c, coll, "failed to update collection")
+}
+
+// DeleteCollection — DELETE /api/v1/collections/:id
+// The books themselves are untouched.
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	coll, ok := h.load(c)
+	if !ok {
+		return
+	}
+	if err := database.DeleteCollection(h.store, coll.UserID, coll.ID); err != nil {
+		httputil.InternalError(c, "failed to delete collection", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"deleted": coll.ID})
+}
+
+// AddBooksToCollection — POST /api/v1/collections/:id/books
+// Inserts books at position (appending by default), skipping any already
+// in the collection.
+func (h *CollectionHandler) AddBooksToCollection(c *gin.Context) {
+	var req CollectionBooksAddReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	coll, ok := h.load(c)
+	if !ok {
+		return
+	}
+	added, ok := h.checkBooks(c, req.BookIDs)
+	if !ok {
+		return
+	}
+	existing := make(map[string]bool, len(coll.BookIDs))
+	for _, bid := range coll.BookIDs {
+		existing[bid] = true
+	}
+	fresh := make([]string, 0, len(added))
+	for _, bid := range added {
+		if !existing[bid] {
+			fresh = append(fresh, bid)
+		}
+	}
+	at := len(coll.BookIDs)
+	if req.Position != nil && *req.Position >= 0 && *req.Position < at {
+		at = *req.Position
+	}
+	merged := make([]string, 0, len(coll.BookIDs)+len(fresh))
+	merged = append(merged, coll.BookIDs[:at]...)
+	merged = append(merged, fresh...)
+	merged = append(merged, coll.BookIDs[at:]...)
+	coll.BookIDs = merged
+	h.save(c, coll, "failed to add books")
+}
+
+// RemoveBookFromCollection — DELETE /api/v1/collections/:id/books/:bookID
+func (h *CollectionHandler) RemoveBookFromCollection(c *gin.Context) {
+	coll, ok := h.load(c)
+	if !ok {
+		return
+	}
+	bookID := c.Param("bookID")
+	filtered := coll.BookIDs[:0]
+	for _, b := range coll.BookIDs {
+		if b != bookID {
+			filtered = append(filtered, b)
+		}
+	}
+	coll.BookIDs = filtered
+	h.save(c, coll, "failed to remove book")
+}
+
+// ReorderCollection — POST /api/v1/collections/:id/reorder
+// Replaces book order. Rejects if the payload changes the set of
+// books (use add/remove endpoints for that).
+func (h *CollectionHandler) ReorderCollection(c *gin.Context) {
+	var req CollectionReorderReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	coll, ok := h.load(c)
+	if !ok {
+		return
+	}
+	if !sameBookSet(coll.BookIDs, req.BookIDs) {
+		httputil.RespondWithBadRequest(c, "reorder must keep the same book set")
+		return
+	}
+	coll.BookIDs = req.BookIDs
+	h.save(c, coll, "failed to reorder")
+}
+
+// load fetches the caller's collection named by the :id param, writing
+// the error response itself when it can't. Another user's collection is
+// simply not found.
+func (h *CollectionHandler) load(c *gin.Context) (*database.Collection, bool) {
+	id := c.Param("id")
+	coll, err := database.GetCollection(h.store, CallingUserID(c), id)
+	if err != nil {
+		httputil.InternalError(c, "failed to load collection", err)
+		return nil, false
+	}
+	if coll == nil {
+		httputil.RespondWithNotFound(c, "collection", id)
+		return nil, false
+	}
+	return coll, true
+}
+
+func (h *CollectionHandler) save(c *gin.Context, coll *database.Collection, failMsg string) {
+	if err := database.UpdateCollection(h.store, coll); err != nil {
+		if errors.Is(err, database.ErrCollectionNameTaken) {
+			httputil.RespondWithConflict(c, err.Error())
+			return
+		}
+		httputil.InternalError(c, failMsg, err)
+		return
+	}
+	httputil.RespondWithOK(c, coll)
+}
+
+// checkBooks drops blanks and repeats from ids and refuses IDs that
+// aren't books, writing a 400 naming the first unknown one.
+func (h *CollectionHandler) checkBooks(c *gin.Context, ids []string) ([]string, bool) {
+	out := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		book, err := h.store.GetBookByID(id)
+		if err != nil {
+			httputil.InternalError(c, "failed to look up book", err)
+			return nil, false
+		}
+		if book == nil {
+			httputil.RespondWithValidationError(c, "book_ids", "unknown book: "+id)
+			return nil, false
+		}
+		out = append(out, id)
+	}
+	return out, true
+}
@@ -0,0 +1,121 @@
+// file: internal/server/handlers/collections_test.go
+// version: 1.0.0
+// guid: 8d3a5f27-e4b1-4c69-9a0d-2b7e6c1f4d83
+
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := storetest.New(t)
+	b1 := storetest.Book(t, store, database.Book{Title: "Mort", FilePath: "/lib/mort.m4b"})
+	b2 := storetest.Book(t, store, database.Book{Title: "Dune", FilePath: "/lib/dune.m4b"})
+	b3 := storetest.Book(t, store, database.Book{Title: "Emma", FilePath: "/lib/emma.m4b"})
+
+	h := handlers.NewCollectionHandler(store)
+	r := gin.New()
+	user := "userA"
+	r.Use(func(c *gin.Context) { c.Set("auth_user", &database.User{ID: user}) })
+	r.GET("/collections", h.ListCollections)
+	r.POST("/collections", h.CreateCollection)
+	r.GET("/collections/:id", h.GetCollection)
+	r.PUT("/collections/:id", h.UpdateCollection)
+	r.DELETE("/collections/:id", h.DeleteCollection)
+	r.POST("/collections/:id/books", h.AddBooksToCollection)
+	r.DELETE("/collections/:id/books/:bookID", h.RemoveBookFromCollection)
+	r.POST("/collections/:id/reorder", h.ReorderCollection)
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) database.Collection {
+		t.Helper()
+		var resp struct {
+			Data database.Collection `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+		return resp.Data
+	}
+
+	w := do(http.MethodPost, "/collections", gin.H{"name": "Favorites", "book_ids": []string{b1.ID}})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	fav := decode(w)
+	assert.Equal(t, []string{b1.ID}, fav.BookIDs)
+	path := "/collections/" + fav.ID
+
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/collections", gin.H{"name": "favorites"}).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/collections", gin.H{"name": " "}).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, path+"/books", gin.H{"book_ids": []string{"nope"}}).Code)
+
+	w = do(http.MethodPost, path+"/books", gin.H{"book_ids": []string{b2.ID, b1.ID}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{b1.ID, b2.ID}, decode(w).BookIDs, "duplicates skipped, appended")
+
+	w = do(http.MethodPost, path+"/books", gin.H{"book_ids": []string{b3.ID}, "position": 0})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{b3.ID, b1.ID, b2.ID}, decode(w).BookIDs)
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, path+"/reorder", gin.H{"book_ids": []string{b1.ID, b2.ID}}).Code)
+	w = do(http.MethodPost, path+"/reorder", gin.H{"book_ids": []string{b2.ID, b1.ID, b3.ID}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{b2.ID, b1.ID, b3.ID}, decode(w).BookIDs)
+
+	w = do(http.MethodDelete, path+"/books/"+b1.ID, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{b2.ID, b3.ID}, decode(w).BookIDs)
+
+	w = do(http.MethodPut, path, gin.H{"name": "Top Picks"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "Top Picks", decode(w).Name)
+
+	w = do(http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	got := decode(w)
+	assert.Equal(t, "Top Picks", got.Name)
+	assert.Equal(t, []string{b2.ID, b3.ID}, got.BookIDs)
+
+	// Collections are per user: another user neither lists nor reaches them.
+	user = "userB"
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, path, nil).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, path, nil).Code)
+	w = do(http.MethodGet, "/collections", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Items []database.Collection `json:"items"`
+		Total int                   `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Empty(t, list.Items)
+
+	user = "userA"
+	w = do(http.MethodGet, "/collections", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.Total)
+
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, path, nil).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, path, nil).Code)
+	book, err := store.GetBookByID(b2.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, book, "deleting a collection leaves its books alone")
+}
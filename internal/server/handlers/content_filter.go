@@ -0,0 +1,102 @@
+// file: internal/server/handlers/content_filter.go
+// version: 1.0.0
+// guid: 9c749ef9-681b-4d62-be69-bea55cf9f338
+// last-edited: 2026-10-16
+
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/contentfilter"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// ContentFilterStore is the narrow persistence interface required by
+// ContentFilterHandler.
+type ContentFilterStore interface {
+	GetUserByID(id string) (*database.User, error)
+	GetUserPreferenceForUser(userID, key string) (*database.UserPreferenceKV, error)
+	SetUserPreferenceForUser(userID, key, value string) error
+	GetBooksByTag(tag string) ([]string, error)
+}
+
+// ContentFilterHandler manages per-user content filter profiles.
+type ContentFilterHandler struct {
+	store ContentFilterStore
+}
+
+// NewContentFilterHandler constructs a ContentFilterHandler.
+func NewContentFilterHandler(store ContentFilterStore) *ContentFilterHandler {
+	return &ContentFilterHandler{store: store}
+}
+
+// lookupUser resolves :id, answering 404 when the user does not exist.
+func (h *ContentFilterHandler) lookupUser(c *gin.Context) (*database.User, bool) {
+	id := c.Param("id")
+	user, err := h.store.GetUserByID(id)
+	if err != nil || user == nil {
+		httputil.RespondWithNotFound(c, "user", id)
+		return nil, false
+	}
+	return user, true
+}
+
+// GetContentFilter handles GET /api/v1/users/:id/content-filter.
+func (h *ContentFilterHandler) GetContentFilter(c *gin.Context) {
+	user, ok := h.lookupUser(c)
+	if !ok {
+		return
+	}
+	profile, err := contentfilter.Load(h.store, user.ID)
+	if err != nil {
+		httputil.InternalError(c, "failed to load content filter", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"user_id": user.ID, "content_filter": normalizeProfile(profile), "active": profile.Active()})
+}
+
+// UpdateContentFilter handles PUT /api/v1/users/:id/content-filter. The
+// body replaces the whole profile; an empty profile removes filtering.
+func (h *ContentFilterHandler) UpdateContentFilter(c *gin.Context) {
+	user, ok := h.lookupUser(c)
+	if !ok {
+		return
+	}
+	var profile contentfilter.Profile
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	profile = normalizeProfile(profile)
+	raw, err := json.Marshal(profile)
+	if err != nil {
+		httputil.InternalError(c, "failed to encode content filter", err)
+		return
+	}
+	if err := h.store.SetUserPreferenceForUser(user.ID, contentfilter.PreferenceKey, string(raw)); err != nil {
+		httputil.InternalError(c, "failed to save content filter", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"user_id": user.ID, "content_filter": profile, "active": profile.Active()})
+}
+
+// normalizeProfile trims entries, drops blanks and always returns
+// non-nil lists so the JSON shape is stable.
+func normalizeProfile(p contentfilter.Profile) contentfilter.Profile {
+	clean := func(in []string) []string {
+		out := []string{}
+		for _, s := range in {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	p.AllowedGenres = clean(p.AllowedGenres)
+	p.AllowedTags = clean(p.AllowedTags)
+	return p
+}
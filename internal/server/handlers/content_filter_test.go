@@ -0,0 +1,85 @@
+// file: internal/server/handlers/content_filter_test.go
+// version: 1.0.0
+// guid: 09814a61-6dc1-4a3a-971e-b50eee3d25c6
+// last-edited: 2026-10-16
+
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContentFilterStore keeps preferences in memory.
+type fakeContentFilterStore struct {
+	users map[string]*database.User
+	prefs map[string]string
+}
+
+func (f *fakeContentFilterStore) GetUserByID(id string) (*database.User, error) {
+	return f.users[id], nil
+}
+
+func (f *fakeContentFilterStore) GetUserPreferenceForUser(userID, key string) (*database.UserPreferenceKV, error) {
+	v, ok := f.prefs[userID+"/"+key]
+	if !ok {
+		return nil, nil
+	}
+	return &database.UserPreferenceKV{UserID: userID, Key: key, Value: v}, nil
+}
+
+func (f *fakeContentFilterStore) SetUserPreferenceForUser(userID, key, value string) error {
+	f.prefs[userID+"/"+key] = value
+	return nil
+}
+
+func (f *fakeContentFilterStore) GetBooksByTag(tag string) ([]string, error) { return nil, nil }
+
+func TestContentFilterHandler_RoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &fakeContentFilterStore{
+		users: map[string]*database.User{"kid": {ID: "kid"}},
+		prefs: map[string]string{},
+	}
+	h := handlers.NewContentFilterHandler(store)
+	r := gin.New()
+	r.GET("/users/:id/content-filter", h.GetContentFilter)
+	r.PUT("/users/:id/content-filter", h.UpdateContentFilter)
+
+	body := []byte(`{"allowed_genres":[" Children ",""],"block_explicit":true}`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/kid/content-filter", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/kid/content-filter", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Active        bool `json:"active"`
+			ContentFilter struct {
+				AllowedGenres []string `json:"allowed_genres"`
+				AllowedTags   []string `json:"allowed_tags"`
+				BlockExplicit bool     `json:"block_explicit"`
+			} `json:"content_filter"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Data.Active)
+	assert.Equal(t, []string{"Children"}, resp.Data.ContentFilter.AllowedGenres)
+	assert.Equal(t, []string{}, resp.Data.ContentFilter.AllowedTags)
+	assert.True(t, resp.Data.ContentFilter.BlockExplicit)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/ghost/content-filter", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
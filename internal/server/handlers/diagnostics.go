@@ -1,7 +1,7 @@
 // file: internal/server/handlers/diagnostics.go
-// version: 1.1.0
+// version: 1.3.0
 // guid: 14e70c44-73ca-456a-bc67-8dc6ba6e5736
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 // DiagnosticsHandler hosts the diagnostics HTTP endpoints extracted from the
 // server package: ZIP export start/download, AI batch submit + results, applying
@@ -18,16 +18,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/ai"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/diagnostics"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
 	"github.com/falkcorp/audiobook-organizer/internal/merge"
+	"github.com/gin-gonic/gin"
 	ulid "github.com/oklog/ulid/v2"
 )
 
@@ -119,20 +120,21 @@ type dbHealthMetadataCache struct {
 // parser) so the handler is mockable and package handlers never imports package
 // server.
 type DiagnosticsHandler struct {
-	store          database.Store         // full store (db-health type switch + apply/export paths)
-	diagService    DiagnosticsService     // diagnostics service (CollectAllBooks); may be nil
-	mergeService   MergeService           // merge service (MergeBooks); may be nil
+	store          database.Store           // full store (db-health type switch + apply/export paths)
+	diagService    DiagnosticsService       // diagnostics service (CollectAllBooks); may be nil
+	mergeService   MergeService             // merge service (MergeBooks); may be nil
 	embeddingStore *database.EmbeddingStore // embeddings health stats; may be nil
-	aiScanStore    *database.AIScanStore  // ai-scan health stats; may be nil
-	registry       OperationsRegistry     // shared ops registry (EnqueueOp only)
-	batchParser    *ai.OpenAIParser       // resolved from server.batchPoller.parser; may be nil
+	aiScanStore    *database.AIScanStore    // ai-scan health stats; may be nil
+	registry       OperationsRegistry       // shared ops registry (EnqueueOp only)
+	batchParser    *ai.OpenAIParser         // resolved from server.batchPoller.parser; may be nil
+	appVersion     func() string            // reported in the issue bundle; may be nil
 }
 
 // NewDiagnosticsHandler constructs a DiagnosticsHandler. Field/param order:
 // store, diagService, mergeService, embeddingStore, aiScanStore, registry,
-// batchParser. diagService/mergeService may be nil — the handlers replicate the
-// server-side lazy construction fallback. batchParser may be nil — the submit-AI
-// flow falls back to preparing batch data without submission.
+// batchParser, appVersion. diagService/mergeService may be nil — the handlers
+// replicate the server-side lazy construction fallback. batchParser may be nil —
+// the submit-AI flow falls back to preparing batch data without submission.
 func NewDiagnosticsHandler(
 	store database.Store,
 	diagService DiagnosticsService,
@@ -141,6 +143,7 @@ func NewDiagnosticsHandler(
 	aiScanStore *database.AIScanStore,
 	registry OperationsRegistry,
 	batchParser *ai.OpenAIParser,
+	appVersion func() string,
 ) *DiagnosticsHandler {
 	return &DiagnosticsHandler{
 		store:          store,
@@ -150,6 +153,7 @@ func NewDiagnosticsHandler(
 		aiScanStore:    aiScanStore,
 		registry:       registry,
 		batchParser:    batchParser,
+		appVersion:     appVersion,
 	}
 }
 
@@ -208,6 +212,32 @@ func (h *DiagnosticsHandler) StartExport(c *gin.Context) {
 	})
 }
 
+// DownloadIssueBundle implements GET /diagnostics/issue-bundle. It builds
+// the anonymized bundle synchronously and serves it as a ZIP attachment
+// suitable for a public bug report; see diagnostics.Service.WriteIssueBundle
+// for the contents and redaction rules.
+func (h *DiagnosticsHandler) DownloadIssueBundle(c *gin.Context) {
+	store := h.store
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+
+	info := diagnostics.IssueBundleInfo{Config: config.AppConfig}
+	if h.appVersion != nil {
+		info.AppVersion = h.appVersion()
+	}
+	var buf bytes.Buffer
+	if err := diagnostics.NewService(store, nil, "").WriteIssueBundle(&buf, info); err != nil {
+		httputil.InternalError(c, "failed to build diagnostics bundle", err)
+		return
+	}
+
+	name := fmt.Sprintf("audiobook-organizer-diagnostics-%s.zip", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", name))
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
 // DownloadExport serves the ZIP file for a completed diagnostics export.
 func (h *DiagnosticsHandler) DownloadExport(c *gin.Context) {
 	opID := c.Param("operationId")
@@ -607,7 +637,7 @@ func (h *DiagnosticsHandler) GetDBHealth(c *gin.Context) {
 	resp := dbHealthResponse{}
 
 	// Main store stats — PebbleDB only since fable5 T022.
-	if st, ok := store.(*database.PebbleStore); ok {
+	if st, ok := database.AsPebbleStore(store); ok {
 		keyCount, sizeBytes, err := st.KeyCount()
 		if err != nil {
 			slog.Warn("db-health pebble key count", "err", err)
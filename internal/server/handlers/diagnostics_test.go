@@ -1,7 +1,7 @@
 // file: internal/server/handlers/diagnostics_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 8ab4b825-05c3-4569-b450-0dca6b872771
-// last-edited: 2026-06-03
+// last-edited: 2026-10-16
 
 package handlers_test
 
@@ -53,7 +53,7 @@ func TestDiagnosticsHandler_StartExport_Enqueues202(t *testing.T) {
 	reg.EXPECT().EnqueueOp(mock.Anything, "diagnostics.export", mock.Anything).
 		Return("op-1", nil)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, reg, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, reg, nil, nil)
 	c, w := newDiagCtx(http.MethodPost, "/diagnostics/export", `{"category":"general"}`, nil)
 	h.StartExport(c)
 
@@ -63,7 +63,7 @@ func TestDiagnosticsHandler_StartExport_Enqueues202(t *testing.T) {
 
 func TestDiagnosticsHandler_StartExport_InvalidCategory_400(t *testing.T) {
 	store := databasemocks.NewMockStore(t)
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodPost, "/diagnostics/export", `{"category":"bogus"}`, nil)
 	h.StartExport(c)
 
@@ -76,7 +76,7 @@ func TestDiagnosticsHandler_DownloadExport_NotFound(t *testing.T) {
 	store := databasemocks.NewMockStore(t)
 	store.EXPECT().GetOperationByID("missing").Return(nil, assert.AnError)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodGet, "/diagnostics/export/missing/download", "",
 		gin.Params{{Key: "operationId", Value: "missing"}})
 	h.DownloadExport(c)
@@ -89,7 +89,7 @@ func TestDiagnosticsHandler_DownloadExport_NotCompleted_202(t *testing.T) {
 	store.EXPECT().GetOperationByID("op-1").
 		Return(&database.Operation{ID: "op-1", Status: "running", Message: "still going"}, nil)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodGet, "/diagnostics/export/op-1/download", "",
 		gin.Params{{Key: "operationId", Value: "op-1"}})
 	h.DownloadExport(c)
@@ -98,6 +98,26 @@ func TestDiagnosticsHandler_DownloadExport_NotCompleted_202(t *testing.T) {
 	assert.Equal(t, http.StatusAccepted, w.Code)
 }
 
+// ── DownloadIssueBundle ───────────────────────────────────────────────────
+
+func TestDiagnosticsHandler_DownloadIssueBundle_Zip(t *testing.T) {
+	store := databasemocks.NewMockStore(t)
+	store.EXPECT().GetUserPreference("db_version").Return(nil, nil)
+	store.EXPECT().GetDashboardStats().Return(&database.DashboardStats{TotalBooks: 3}, nil)
+	store.EXPECT().GetSystemActivityLogs("", mock.Anything).Return(nil, nil)
+	store.EXPECT().ListOperationsV2Since(mock.Anything, mock.Anything).Return(nil, nil)
+	store.EXPECT().GetRecentOperations(mock.Anything).Return(nil, nil)
+
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, func() string { return "9.9.9" })
+	c, w := newDiagCtx(http.MethodGet, "/diagnostics/issue-bundle", "", nil)
+	h.DownloadIssueBundle(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=audiobook-organizer-diagnostics-")
+	assert.True(t, strings.HasPrefix(w.Body.String(), "PK"), "body should be a zip archive")
+}
+
 // ── SubmitAI ──────────────────────────────────────────────────────────────
 
 func TestDiagnosticsHandler_SubmitAI_NoAPIKey_400(t *testing.T) {
@@ -106,7 +126,7 @@ func TestDiagnosticsHandler_SubmitAI_NoAPIKey_400(t *testing.T) {
 	config.AppConfig.OpenAIAPIKey = ""
 
 	store := databasemocks.NewMockStore(t)
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodPost, "/diagnostics/submit-ai", `{}`, nil)
 	h.SubmitAI(c)
 
@@ -142,7 +162,7 @@ func TestDiagnosticsHandler_SubmitAI_NilParserFallback(t *testing.T) {
 	diagSvc.EXPECT().CollectAllBooks().Return([]database.Book{{ID: "b1", Title: "X"}}, nil)
 
 	// batchParser is nil → the no-parser fallback path.
-	h := handlers.NewDiagnosticsHandler(store, diagSvc, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, diagSvc, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodPost, "/diagnostics/submit-ai", `{"category":"general"}`, nil)
 	h.SubmitAI(c)
 
@@ -162,7 +182,7 @@ func TestDiagnosticsHandler_GetAIResults_NotFound(t *testing.T) {
 	store := databasemocks.NewMockStore(t)
 	store.EXPECT().GetOperationByID("missing").Return(nil, assert.AnError)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodGet, "/diagnostics/ai-results/missing", "",
 		gin.Params{{Key: "operationId", Value: "missing"}})
 	h.GetAIResults(c)
@@ -175,7 +195,7 @@ func TestDiagnosticsHandler_GetAIResults_NotCompleted_200(t *testing.T) {
 	store.EXPECT().GetOperationByID("op-1").
 		Return(&database.Operation{ID: "op-1", Status: "running"}, nil)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodGet, "/diagnostics/ai-results/op-1", "",
 		gin.Params{{Key: "operationId", Value: "op-1"}})
 	h.GetAIResults(c)
@@ -191,7 +211,7 @@ func TestDiagnosticsHandler_GetAIResults_Completed_WithSuggestions(t *testing.T)
 	store.EXPECT().GetOperationByID("op-1").
 		Return(&database.Operation{ID: "op-1", Status: "completed", ResultData: diagStrPtr(rd)}, nil)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodGet, "/diagnostics/ai-results/op-1", "",
 		gin.Params{{Key: "operationId", Value: "op-1"}})
 	h.GetAIResults(c)
@@ -204,7 +224,7 @@ func TestDiagnosticsHandler_GetAIResults_Completed_WithSuggestions(t *testing.T)
 
 func TestDiagnosticsHandler_ApplySuggestions_MissingFields_400(t *testing.T) {
 	store := databasemocks.NewMockStore(t)
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	// Missing required operation_id / approved_suggestion_ids.
 	c, w := newDiagCtx(http.MethodPost, "/diagnostics/apply-suggestions", `{}`, nil)
 	h.ApplySuggestions(c)
@@ -216,7 +236,7 @@ func TestDiagnosticsHandler_ApplySuggestions_OperationNotFound(t *testing.T) {
 	store := databasemocks.NewMockStore(t)
 	store.EXPECT().GetOperationByID("op-x").Return(nil, assert.AnError)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodPost, "/diagnostics/apply-suggestions",
 		`{"operation_id":"op-x","approved_suggestion_ids":["s1"]}`, nil)
 	h.ApplySuggestions(c)
@@ -233,7 +253,7 @@ func TestDiagnosticsHandler_ApplySuggestions_MergeVersions(t *testing.T) {
 		Return(&database.Operation{ID: "op-1", Status: "completed", ResultData: diagStrPtr(rd)}, nil)
 	mergeSvc.EXPECT().MergeBooks([]string{"b1", "b2"}, "b1").Return(nil, nil)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, mergeSvc, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, mergeSvc, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodPost, "/diagnostics/apply-suggestions",
 		`{"operation_id":"op-1","approved_suggestion_ids":["s1"]}`, nil)
 	h.ApplySuggestions(c)
@@ -246,7 +266,7 @@ func TestDiagnosticsHandler_ApplySuggestions_MergeVersions(t *testing.T) {
 
 // TestDiagnosticsHandler_GetDBHealth_NilStore_500 covers the nil-store guard.
 func TestDiagnosticsHandler_GetDBHealth_NilStore_500(t *testing.T) {
-	h := handlers.NewDiagnosticsHandler(nil, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(nil, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodGet, "/diagnostics/db-health", "", nil)
 	h.GetDBHealth(c)
 
@@ -268,7 +288,7 @@ func TestDiagnosticsHandler_GetDBHealth_MockStore(t *testing.T) {
 	store := databasemocks.NewMockStore(t)
 	store.EXPECT().CountPrefix("metadata_fetch_cache:").Return(int64(7), nil)
 
-	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil)
+	h := handlers.NewDiagnosticsHandler(store, nil, nil, nil, nil, nil, nil, nil)
 	c, w := newDiagCtx(http.MethodGet, "/diagnostics/db-health", "", nil)
 	h.GetDBHealth(c)
 
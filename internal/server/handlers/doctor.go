@@ -0,0 +1,35 @@
+// file: internal/server/handlers/doctor.go
+// version: 1.0.0
+// guid: a7509c8e-9a6e-400d-9641-c76f76117198
+// last-edited: 2026-10-16
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/doctor"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// DoctorHandler serves the self-check report used by the settings UI.
+type DoctorHandler struct {
+	store doctor.Store
+}
+
+// NewDoctorHandler constructs a DoctorHandler. store may be nil, in which
+// case the report's database check fails instead of the request.
+func NewDoctorHandler(store doctor.Store) *DoctorHandler {
+	return &DoctorHandler{store: store}
+}
+
+// RunDoctor runs every self-check and returns the report.
+// GET /api/v1/system/doctor?skip_network=true
+func (h *DoctorHandler) RunDoctor(c *gin.Context) {
+	report := doctor.Run(c.Request.Context(), doctor.Options{
+		Store:       h.store,
+		Config:      config.Snapshot(),
+		SkipNetwork: httputil.ParseQueryBool(c, "skip_network", false),
+	})
+	httputil.RespondWithOK(c, report)
+}
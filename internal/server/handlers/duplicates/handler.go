@@ -1,7 +1,7 @@
 // file: internal/server/handlers/duplicates/handler.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 9f41f363-34fc-4ad2-b2f1-46d5ac0ba2f3
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Package duplicates hosts the SQL-backed duplicate-detection HTTP handlers
 // extracted from the server package's duplicates_handlers.go: book / author /
@@ -321,6 +321,52 @@ func (h *Handler) MergeBooks(c *gin.Context) {
 	httputil.RespondWithSuccess(c, 202, op)
 }
 
+// ResolveBookDuplicates enqueues an async resolution of book duplicate
+// groups. POST /audiobooks/duplicates/resolve. Each group names its books
+// and what to do with them (see dedup.BookResolveDecision); the returned
+// operation can be rolled back with POST /operations/:id/revert.
+func (h *Handler) ResolveBookDuplicates(c *gin.Context) {
+	var req struct {
+		Groups []bookResolveDecision `json:"groups" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if len(req.Groups) == 0 {
+		httputil.RespondWithBadRequest(c, "groups must not be empty")
+		return
+	}
+	for i, g := range req.Groups {
+		if err := g.Validate(); err != nil {
+			httputil.RespondWithBadRequest(c, fmt.Sprintf("groups[%d]: %v", i, err))
+			return
+		}
+	}
+
+	if h.opRegistry == nil {
+		httputil.RespondWithInternalError(c, "operation registry not initialized")
+		return
+	}
+
+	store := h.resolveStore()
+	opID := ulid.Make().String()
+	detail := fmt.Sprintf("resolve-book-duplicates:groups=%d", len(req.Groups))
+	op, err := store.CreateOperation(opID, "book-duplicate-resolve", &detail)
+	if err != nil {
+		httputil.InternalError(c, "failed to create operation", err)
+		return
+	}
+
+	params := bookResolveOpParams{LegacyOpID: op.ID, Groups: req.Groups}
+	if _, err := h.opRegistry.EnqueueOp(c.Request.Context(), "dedup.book-resolve", params); err != nil {
+		httputil.InternalError(c, "failed to enqueue operation", err)
+		return
+	}
+
+	httputil.RespondWithSuccess(c, 202, op)
+}
+
 // ListDuplicateAuthors handles GET /authors/duplicates.
 func (h *Handler) ListDuplicateAuthors(c *gin.Context) {
 	if h.dedupCache != nil {
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/duplicates/handler_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 62637af9-347f-4f38-b42b-d90ff3ab3654
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Tests for the duplicates-domain handlers. The store / merge-service /
 // audiobook-service / metadata-fetch-service / operations-registry deps are
@@ -267,6 +267,32 @@ func TestMergeBooks_KeepNotFound(t *testing.T) {
 	}
 }
 
+// --- ResolveBookDuplicates ---
+
+func TestResolveBookDuplicates_Enqueues202(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().CreateOperation(mock.Anything, "book-duplicate-resolve", mock.Anything).Return(opMatcher(), nil)
+	d.reg.EXPECT().EnqueueOp(mock.Anything, "dedup.book-resolve", mock.Anything).Return("rid", nil)
+	w := doReq(t, h.ResolveBookDuplicates, http.MethodPost, "/audiobooks/duplicates/resolve",
+		map[string]any{"groups": []map[string]any{
+			{"book_ids": []string{"a", "b"}, "keep": "best_quality", "delete_others": true, "block_hash": true},
+		}})
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("want 202, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestResolveBookDuplicates_InvalidDecision(t *testing.T) {
+	h, _ := newHandler(t)
+	w := doReq(t, h.ResolveBookDuplicates, http.MethodPost, "/audiobooks/duplicates/resolve",
+		map[string]any{"groups": []map[string]any{
+			{"book_ids": []string{"a", "b"}, "keep": "primary", "block_hash": true},
+		}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
 // --- ListDuplicateAuthors ---
 
 func TestListDuplicateAuthors_EmptyNeedsRefresh(t *testing.T) {
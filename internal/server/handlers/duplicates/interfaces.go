@@ -1,7 +1,7 @@
 // file: internal/server/handlers/duplicates/interfaces.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: a04e0263-a6b1-42b9-9791-1b8b649004b5
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Narrow dependency interfaces for the duplicates-domain HTTP handlers
 // (SQL-backed book/author/series duplicate detection, async merge / dismiss /
@@ -87,6 +87,8 @@ type OperationsRegistry interface {
 type (
 	bookDedupScanOpParams   = dedup.BookDedupScanParams
 	bookMergeOpParams       = dedup.BookMergeParams
+	bookResolveOpParams     = dedup.BookResolveParams
+	bookResolveDecision     = dedup.BookResolveDecision
 	authorDedupScanOpParams = dedup.AuthorDedupScanParams
 	seriesDedupScanOpParams = dedup.SeriesDedupScanParams
 	seriesDedupOpParams     = dedup.SeriesDedupParams
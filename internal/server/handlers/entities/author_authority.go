@@ -0,0 +1,279 @@
+// file: internal/server/handlers/entities/author_authority.go
+// version: 1.0.0
+// guid: e5b18f46-3c07-4a9d-8e21-7d4a6c0f9b53
+// last-edited: 2026-10-17
+
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// authorAuthorityRequest is the body of PUT /authors/:id/authority and
+// POST /authors/:id/namesakes.
+type authorAuthorityRequest struct {
+	OpenLibraryID string `json:"openlibrary_id"`
+	GoodreadsID   string `json:"goodreads_id"`
+	BirthYear     *int   `json:"birth_year"`
+}
+
+func (r authorAuthorityRequest) authority() (database.AuthorAuthority, error) {
+	if r.BirthYear != nil && (*r.BirthYear < 1 || *r.BirthYear > 9999) {
+		return database.AuthorAuthority{}, fmt.Errorf("birth_year %d is out of range", *r.BirthYear)
+	}
+	return database.AuthorAuthority{
+		OpenLibraryID: strings.TrimSpace(r.OpenLibraryID),
+		GoodreadsID:   strings.TrimSpace(r.GoodreadsID),
+		BirthYear:     r.BirthYear,
+	}, nil
+}
+
+// bindAuthorAuthority parses the author ID and authority body shared by
+// the authority endpoints. It writes the error response and returns
+// ok=false on failure.
+func (h *Handler) bindAuthorAuthority(c *gin.Context) (as database.AuthorAuthorityStore, author *database.Author, authority database.AuthorAuthority, ok bool) {
+	authorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httputil.RespondWithBadRequest(c, "invalid author ID")
+		return nil, nil, authority, false
+	}
+	var req authorAuthorityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return nil, nil, authority, false
+	}
+	if authority, err = req.authority(); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return nil, nil, authority, false
+	}
+	as, ok = database.AsAuthorAuthorityStore(h.store)
+	if !ok {
+		httputil.RespondWithInternalError(c, "author disambiguation not available")
+		return nil, nil, authority, false
+	}
+	author, err = h.store.GetAuthorByID(authorID)
+	if err != nil {
+		httputil.InternalError(c, "failed to load author", err)
+		return nil, nil, authority, false
+	}
+	if author == nil || author.ID != authorID {
+		httputil.RespondWithNotFound(c, "author", c.Param("id"))
+		return nil, nil, authority, false
+	}
+	return as, author, authority, true
+}
+
+// respondAuthorityError maps authority store errors to responses.
+func respondAuthorityError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, database.ErrAuthorityConflict):
+		httputil.RespondWithConflict(c, err.Error())
+	case errors.Is(err, database.ErrAuthorityRequired):
+		httputil.RespondWithBadRequest(c, err.Error())
+	default:
+		httputil.InternalError(c, msg, err)
+	}
+}
+
+// SetAuthorAuthority implements PUT /authors/:id/authority.
+//
+// Replaces the author's OpenLibrary and Goodreads author IDs and birth
+// year. An external ID already recorded on another author is a 409.
+func (h *Handler) SetAuthorAuthority(c *gin.Context) {
+	as, author, authority, ok := h.bindAuthorAuthority(c)
+	if !ok {
+		return
+	}
+	updated, err := as.SetAuthorAuthority(author.ID, authority)
+	if err != nil {
+		respondAuthorityError(c, "failed to set author authority", err)
+		return
+	}
+	h.dedupCache.Invalidate("author-duplicates")
+	h.authorsCache.InvalidateAll()
+	httputil.RespondWithOK(c, updated)
+}
+
+// CreateAuthorNamesake implements POST /authors/:id/namesakes.
+//
+// Creates a second author with the same display name as :id, for a
+// different person of that name. The body must carry authority data
+// that tells the two apart (see database.DistinctAuthors); books are
+// not moved, reassign them or answer scan prompts afterwards.
+func (h *Handler) CreateAuthorNamesake(c *gin.Context) {
+	as, author, authority, ok := h.bindAuthorAuthority(c)
+	if !ok {
+		return
+	}
+	candidate := database.Author{Name: author.Name, OpenLibraryID: authority.OpenLibraryID, GoodreadsID: authority.GoodreadsID, BirthYear: authority.BirthYear}
+	if authority.Empty() || !database.DistinctAuthors(*author, candidate) && !author.Authority().Empty() {
+		httputil.RespondWithBadRequest(c, "authority data must tell the namesake apart from the existing author")
+		return
+	}
+	created, err := as.CreateAuthorNamesake(author.Name, authority)
+	if err != nil {
+		respondAuthorityError(c, "failed to create namesake author", err)
+		return
+	}
+	h.authorsCache.InvalidateAll()
+	httputil.RespondWithCreated(c, created)
+}
+
+// authorResolutionResponse is one review prompt with its book and
+// candidate authors resolved for display.
+type authorResolutionResponse struct {
+	database.AuthorResolution
+	BookTitle  string            `json:"book_title"`
+	Candidates []database.Author `json:"candidates"`
+}
+
+// ListAuthorResolutions implements GET /authors/resolutions.
+//
+// Lists the prompts scans raised for books whose author name matches
+// several authors, oldest first. status is "pending" (default),
+// "resolved" or "all". Candidates deleted since are left out.
+func (h *Handler) ListAuthorResolutions(c *gin.Context) {
+	status := c.DefaultQuery("status", database.AuthorResolutionPending)
+	switch status {
+	case database.AuthorResolutionPending, database.AuthorResolutionResolved:
+	case "all":
+		status = ""
+	default:
+		httputil.RespondWithBadRequest(c, "status must be one of: pending, resolved, all")
+		return
+	}
+	kv, ok := h.rawKV()
+	if !ok {
+		httputil.RespondWithInternalError(c, "author resolutions not available")
+		return
+	}
+	resolutions, err := database.ListAuthorResolutions(kv, status)
+	if err != nil {
+		httputil.InternalError(c, "failed to list author resolutions", err)
+		return
+	}
+	items := make([]authorResolutionResponse, 0, len(resolutions))
+	for _, r := range resolutions {
+		item := authorResolutionResponse{AuthorResolution: r, Candidates: []database.Author{}}
+		if book, err := h.store.GetBookByID(r.BookID); err == nil && book != nil {
+			item.BookTitle = book.Title
+		}
+		for _, id := range r.CandidateIDs {
+			if a, err := h.store.GetAuthorByID(id); err == nil && a != nil && a.ID == id {
+				item.Candidates = append(item.Candidates, *a)
+			}
+		}
+		items = append(items, item)
+	}
+	httputil.RespondWithOK(c, gin.H{"items": items, "count": len(items)})
+}
+
+// ResolveAuthorResolution implements POST /authors/resolutions/:id/resolve.
+//
+// Files the prompt's book under author_id, which must be one of the
+// prompt's candidates: the book's primary author and its matching
+// contributor entry move to that author.
+func (h *Handler) ResolveAuthorResolution(c *gin.Context) {
+	var req struct {
+		AuthorID int `json:"author_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	kv, ok := h.rawKV()
+	if !ok {
+		httputil.RespondWithInternalError(c, "author resolutions not available")
+		return
+	}
+	r, err := database.GetAuthorResolution(kv, c.Param("id"))
+	if err != nil {
+		httputil.InternalError(c, "failed to load author resolution", err)
+		return
+	}
+	if r == nil {
+		httputil.RespondWithNotFound(c, "author resolution", c.Param("id"))
+		return
+	}
+	if r.Status != database.AuthorResolutionPending {
+		httputil.RespondWithConflict(c, "author resolution already resolved")
+		return
+	}
+	if !slices.Contains(r.CandidateIDs, req.AuthorID) {
+		httputil.RespondWithBadRequest(c, fmt.Sprintf("author %d is not a candidate", req.AuthorID))
+		return
+	}
+	if a, err := h.store.GetAuthorByID(req.AuthorID); err != nil || a == nil || a.ID != req.AuthorID {
+		httputil.RespondWithBadRequest(c, fmt.Sprintf("author %d not found", req.AuthorID))
+		return
+	}
+	book, err := h.store.GetBookByID(r.BookID)
+	if err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "audiobook", r.BookID)
+		return
+	}
+	if err := h.refileBookAuthor(book, r.CandidateIDs, req.AuthorID); err != nil {
+		httputil.InternalError(c, "failed to update book author", err)
+		return
+	}
+	resolved, err := database.ResolveAuthorResolution(kv, r.ID, req.AuthorID)
+	if err != nil {
+		httputil.InternalError(c, "failed to resolve author resolution", err)
+		return
+	}
+	h.authorsCache.InvalidateAll()
+	httputil.RespondWithOK(c, resolved)
+}
+
+// refileBookAuthor moves book from whichever of candidates it is filed
+// under to authorID, in both the primary author and the contributor list.
+func (h *Handler) refileBookAuthor(book *database.Book, candidates []int, authorID int) error {
+	contributors, err := h.store.GetBookAuthors(book.ID)
+	if err != nil {
+		return err
+	}
+	changed, present := false, false
+	for i := range contributors {
+		if contributors[i].AuthorID == authorID {
+			present = true
+		}
+	}
+	for i := range contributors {
+		if !present && slices.Contains(candidates, contributors[i].AuthorID) && contributors[i].AuthorID != authorID {
+			contributors[i].AuthorID = authorID
+			changed, present = true, true
+		}
+	}
+	if changed {
+		if err := h.store.SetBookAuthors(book.ID, contributors); err != nil {
+			return err
+		}
+	}
+	if book.AuthorID == nil || *book.AuthorID != authorID {
+		book.AuthorID = &authorID
+		book.Author = nil
+		if _, err := h.store.UpdateBook(book.ID, book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rawKV returns the RawKV view of the store, looking through a wrapper.
+func (h *Handler) rawKV() (database.RawKVStore, bool) {
+	if kv, ok := h.store.(database.RawKVStore); ok {
+		return kv, true
+	}
+	if uw, ok := h.store.(interface{ Unwrap() database.Store }); ok {
+		return uw.Unwrap(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,102 @@
+// file: internal/server/handlers/entities/author_authority_test.go
+// version: 1.0.0
+// guid: 9e4b1c73-5a2d-4f08-b6e9-c3d7a0f51b84
+
+package entities_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
+	"github.com/falkcorp/audiobook-organizer/internal/cache"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/entities"
+	entitiesmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/entities/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorAuthorityAndResolutions(t *testing.T) {
+	store := storetest.New(t)
+	h := entities.New(store, entitiesmocks.NewMockWorkService(t), entitiesmocks.NewMockAuthorSeriesService(t),
+		entitiesmocks.NewMockOperationsRegistry(t),
+		cache.NewWithLimit[*audiobooks.AuthorWithCountListResponse]("authors-test", time.Hour, 1),
+		cache.NewWithLimit[*audiobooks.SeriesWithCountsResponse]("series-test", time.Hour, 1),
+		cache.NewWithLimit[gin.H]("dedup-test", time.Hour, 16), nil)
+	idParam := func(id any) gin.Params { return gin.Params{{Key: "id", Value: fmt.Sprint(id)}} }
+
+	smith := storetest.Author(t, store, "John Smith")
+	c, w := newCtx(http.MethodPut, "/authors/1/authority", `{"openlibrary_id":"OL1A","birth_year":1580}`, idParam(smith.ID))
+	h.SetAuthorAuthority(c)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// A namesake needs authority data that differs from the original's.
+	c, w = newCtx(http.MethodPost, "/authors/1/namesakes", `{"birth_year":1580}`, idParam(smith.ID))
+	h.CreateAuthorNamesake(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	c, w = newCtx(http.MethodPost, "/authors/1/namesakes", `{"openlibrary_id":"ol1a"}`, idParam(smith.ID))
+	h.CreateAuthorNamesake(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	c, w = newCtx(http.MethodPost, "/authors/1/namesakes", `{"openlibrary_id":"OL2A","birth_year":1950}`, idParam(smith.ID))
+	h.CreateAuthorNamesake(c)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data database.Author `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "John Smith", created.Data.Name)
+	assert.Equal(t, 1950, *created.Data.BirthYear)
+
+	c, w = newCtx(http.MethodPut, "/authors/1/authority", `{"openlibrary_id":"OL2A"}`, idParam(smith.ID))
+	h.SetAuthorAuthority(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	book := storetest.Book(t, store, database.Book{Title: "Poems", AuthorID: &smith.ID})
+	require.NoError(t, store.SetBookAuthors(book.ID, []database.BookAuthor{{BookID: book.ID, AuthorID: smith.ID, Role: "author"}}))
+	prompt, err := database.AddAuthorResolution(store, database.AuthorResolution{
+		BookID: book.ID, Name: "John Smith", CandidateIDs: []int{smith.ID, created.Data.ID}, AssignedID: smith.ID,
+	})
+	require.NoError(t, err)
+
+	c, w = newCtx(http.MethodGet, "/authors/resolutions", "", nil)
+	h.ListAuthorResolutions(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data struct {
+			Items []struct {
+				ID         string            `json:"id"`
+				BookTitle  string            `json:"book_title"`
+				Candidates []database.Author `json:"candidates"`
+			} `json:"items"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Data.Items, 1)
+	assert.Equal(t, "Poems", list.Data.Items[0].BookTitle)
+	assert.Len(t, list.Data.Items[0].Candidates, 2)
+
+	c, w = newCtx(http.MethodPost, "/authors/resolutions/x/resolve", `{"author_id":99999}`, idParam(prompt.ID))
+	h.ResolveAuthorResolution(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	c, w = newCtx(http.MethodPost, "/authors/resolutions/x/resolve", fmt.Sprintf(`{"author_id":%d}`, created.Data.ID), idParam(prompt.ID))
+	h.ResolveAuthorResolution(c)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	saved, err := store.GetBookByID(book.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.Data.ID, *saved.AuthorID)
+	contributors, err := store.GetBookAuthors(book.ID)
+	require.NoError(t, err)
+	require.Len(t, contributors, 1)
+	assert.Equal(t, created.Data.ID, contributors[0].AuthorID)
+
+	c, w = newCtx(http.MethodPost, "/authors/resolutions/x/resolve", fmt.Sprintf(`{"author_id":%d}`, smith.ID), idParam(prompt.ID))
+	h.ResolveAuthorResolution(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,45 @@
+// file: internal/server/handlers/entities/auto_merges.go
+// version: 1.0.0
+// guid: f4c3be4b-de74-41c1-83e2-927fccaebb93
+// last-edited: 2026-10-16
+
+package entities
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/entitymatch"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// ListAutoMerges implements GET /entities/auto-merges.
+//
+// Lists the author and series name variants that scans and metadata
+// applies resolved to an existing record instead of creating a new one
+// (see entity_match_strictness), most recent first. The optional kind
+// query param ("author" or "series") filters the list.
+func (h *Handler) ListAutoMerges(c *gin.Context) {
+	kind := c.Query("kind")
+	switch kind {
+	case "", entitymatch.KindAuthor, entitymatch.KindSeries:
+	default:
+		httputil.RespondWithBadRequest(c, "kind must be one of: author, series")
+		return
+	}
+	kv, ok := h.store.(database.RawKVStore)
+	if !ok {
+		if uw, isWrapper := h.store.(interface{ Unwrap() database.Store }); isWrapper {
+			kv, ok = uw.Unwrap(), true
+		}
+	}
+	if !ok {
+		httputil.RespondWithInternalError(c, "auto-merge report not available")
+		return
+	}
+	merges, err := entitymatch.ListAutoMerges(kv, kind)
+	if err != nil {
+		httputil.InternalError(c, "failed to list auto-merges", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"merges": merges, "count": len(merges)})
+}
@@ -0,0 +1,75 @@
+// file: internal/server/handlers/entities/auto_merges_test.go
+// version: 1.1.0
+// guid: 054d478f-fe89-464c-89b0-4904dda4cb06
+// last-edited: 2026-10-17
+
+package entities_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
+	"github.com/falkcorp/audiobook-organizer/internal/cache"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
+	"github.com/falkcorp/audiobook-organizer/internal/entitymatch"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/entities"
+	entitiesmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/entities/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kvEntitiesStore adds the raw KV space the auto-merge report lives in to
+// the mocked entities store.
+type kvEntitiesStore struct {
+	*entitiesmocks.MockEntitiesStore
+	database.RawKVStore
+}
+
+func TestListAutoMerges(t *testing.T) {
+	pebble := storetest.New(t)
+	storetest.Author(t, pebble, "Brandon Sanderson")
+	r := entitymatch.NewResolver(&config.Config{EntityMatchStrictness: entitymatch.StrictnessNormalized}, pebble)
+	_, err := r.MatchAuthor("Sanderson, Brandon")
+	require.NoError(t, err)
+
+	store := kvEntitiesStore{entitiesmocks.NewMockEntitiesStore(t), pebble}
+	h := entities.New(store, entitiesmocks.NewMockWorkService(t), entitiesmocks.NewMockAuthorSeriesService(t),
+		entitiesmocks.NewMockOperationsRegistry(t),
+		cache.NewWithLimit[*audiobooks.AuthorWithCountListResponse]("authors-test", time.Hour, 1),
+		cache.NewWithLimit[*audiobooks.SeriesWithCountsResponse]("series-test", time.Hour, 1),
+		cache.NewWithLimit[gin.H]("dedup-test", time.Hour, 16), nil)
+
+	c, w := newCtx(http.MethodGet, "/entities/auto-merges?kind=author", "", nil)
+	h.ListAutoMerges(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Merges []entitymatch.AutoMerge `json:"merges"`
+			Count  int                     `json:"count"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Data.Count)
+	assert.Equal(t, "Sanderson, Brandon", resp.Data.Merges[0].Input)
+	assert.Equal(t, "Brandon Sanderson", resp.Data.Merges[0].MatchedName)
+}
+
+func TestListAutoMerges_BadKind(t *testing.T) {
+	h, _ := newHandler(t)
+	c, w := newCtx(http.MethodGet, "/entities/auto-merges?kind=narrator", "", nil)
+	h.ListAutoMerges(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListAutoMerges_Unsupported(t *testing.T) {
+	h, _ := newHandler(t)
+	c, w := newCtx(http.MethodGet, "/entities/auto-merges", "", nil)
+	h.ListAutoMerges(c)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/entities/handler.go
-// version: 1.0.0
+// version: 1.4.0
 // guid: b02a07d8-1806-4c86-bb72-f0688d6caff3
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Package entities hosts the entity-domain HTTP handlers extracted from the
 // server package: works, authors, series, and narrators — CRUD plus merges,
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
@@ -23,6 +24,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/dedup"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	servermiddleware "github.com/falkcorp/audiobook-organizer/internal/server/middleware"
 	ulid "github.com/oklog/ulid/v2"
 )
 
@@ -151,9 +153,30 @@ func (h *Handler) UpdateWork(c *gin.Context) {
 	httputil.RespondWithOK(c, updated)
 }
 
-// DeleteWork implements DELETE /works/:id.
+// DeleteWork implements DELETE /works/:id. A work still referenced by books
+// is rejected with 409 unless ?cascade=true, which first unlinks those books.
 func (h *Handler) DeleteWork(c *gin.Context) {
 	id := c.Param("id")
+	cascade := httputil.ParseQueryBool(c, "cascade", false)
+	if h.store != nil {
+		books, err := h.store.GetBooksByWorkID(id)
+		if err != nil {
+			httputil.InternalError(c, "failed to get work books", err)
+			return
+		}
+		if len(books) > 0 && !cascade {
+			httputil.RespondWithConflict(c, "cannot delete work with books; pass cascade=true to unlink them")
+			return
+		}
+		for i := range books {
+			b := books[i]
+			b.WorkID = nil
+			if _, err := h.store.UpdateBook(b.ID, &b); err != nil {
+				httputil.InternalError(c, "failed to unlink book "+b.ID, err)
+				return
+			}
+		}
+	}
 	if err := h.workService.DeleteWork(id); err != nil {
 		if err.Error() == "work not found" {
 			httputil.RespondWithNotFound(c, "work", id)
@@ -165,6 +188,160 @@ func (h *Handler) DeleteWork(c *gin.Context) {
 	httputil.RespondWithNoContent(c)
 }
 
+// MergeWorks implements POST /works/merge. Every book linked to one of
+// merge_ids is relinked to keep_id, then the merged works are deleted. Each
+// relink is recorded in the book's change history.
+func (h *Handler) MergeWorks(c *gin.Context) {
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	var req struct {
+		KeepID   string   `json:"keep_id" binding:"required"`
+		MergeIDs []string `json:"merge_ids" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if len(req.MergeIDs) == 0 {
+		httputil.RespondWithBadRequest(c, "merge_ids must not be empty")
+		return
+	}
+	keep, err := h.workService.GetWork(req.KeepID)
+	if err != nil || keep == nil {
+		httputil.RespondWithNotFound(c, "work", req.KeepID)
+		return
+	}
+	for _, id := range req.MergeIDs {
+		if id == req.KeepID {
+			httputil.RespondWithBadRequest(c, "merge_ids must not contain keep_id")
+			return
+		}
+		if w, err := h.workService.GetWork(id); err != nil || w == nil {
+			httputil.RespondWithNotFound(c, "work", id)
+			return
+		}
+	}
+
+	relinked := 0
+	var errs []string
+	for _, id := range req.MergeIDs {
+		books, err := h.store.GetBooksByWorkID(id)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("work %s: %v", id, err))
+			continue
+		}
+		failed := false
+		for i := range books {
+			if err := h.relinkBookWork(&books[i], keep.ID, "work_merge"); err != nil {
+				errs = append(errs, fmt.Sprintf("book %s: %v", books[i].ID, err))
+				failed = true
+				continue
+			}
+			relinked++
+		}
+		// Leave the source work in place if any of its books could not be
+		// moved, so nothing is left pointing at a deleted work.
+		if failed {
+			continue
+		}
+		if err := h.workService.DeleteWork(id); err != nil {
+			errs = append(errs, fmt.Sprintf("work %s: %v", id, err))
+		}
+	}
+	httputil.RespondWithOK(c, gin.H{
+		"work":           keep,
+		"books_relinked": relinked,
+		"errors":         errs,
+	})
+}
+
+// SplitWork implements POST /works/:id/split. The selected books are moved
+// to a newly created work (title defaults to the source title + " (Split)").
+// Book IDs that do not belong to the source work are ignored.
+func (h *Handler) SplitWork(c *gin.Context) {
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	id := c.Param("id")
+	var req struct {
+		BookIDs []string `json:"book_ids" binding:"required"`
+		Title   string   `json:"title"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if len(req.BookIDs) == 0 {
+		httputil.RespondWithBadRequest(c, "book_ids must not be empty")
+		return
+	}
+	src, err := h.workService.GetWork(id)
+	if err != nil || src == nil {
+		httputil.RespondWithNotFound(c, "work", id)
+		return
+	}
+	var toMove []*database.Book
+	for _, bookID := range req.BookIDs {
+		book, err := h.store.GetBookByID(bookID)
+		if err != nil || book == nil || book.WorkID == nil || *book.WorkID != id {
+			continue
+		}
+		toMove = append(toMove, book)
+	}
+	if len(toMove) == 0 {
+		httputil.RespondWithBadRequest(c, "none of book_ids belong to this work")
+		return
+	}
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = src.Title + " (Split)"
+	}
+	newWork, err := h.workService.CreateWork(&database.Work{
+		Title:    title,
+		AuthorID: src.AuthorID,
+		SeriesID: src.SeriesID,
+	})
+	if err != nil {
+		httputil.InternalError(c, "failed to create new work", err)
+		return
+	}
+	moved := 0
+	for _, book := range toMove {
+		if err := h.relinkBookWork(book, newWork.ID, "work_split"); err != nil {
+			continue
+		}
+		moved++
+	}
+	httputil.RespondWithOK(c, gin.H{"new_work": newWork, "books_moved": moved})
+}
+
+// relinkBookWork points book at workID and records the change in the book's
+// metadata history. A failed history write does not undo the relink.
+func (h *Handler) relinkBookWork(book *database.Book, workID, changeType string) error {
+	var prev string
+	if book.WorkID != nil {
+		prev = *book.WorkID
+	}
+	book.WorkID = &workID
+	if _, err := h.store.UpdateBook(book.ID, book); err != nil {
+		return err
+	}
+	next := workID
+	_ = h.store.RecordMetadataChange(&database.MetadataChangeRecord{
+		BookID:        book.ID,
+		Field:         "work_id",
+		PreviousValue: &prev,
+		NewValue:      &next,
+		ChangeType:    changeType,
+		Source:        "manual",
+		ChangedAt:     time.Now(),
+	})
+	return nil
+}
+
 // ListWorkBooks implements GET /works/:id/books.
 func (h *Handler) ListWorkBooks(c *gin.Context) {
 	if h.store == nil {
@@ -287,18 +464,28 @@ func (h *Handler) GetWorkStats(c *gin.Context) {
 
 // --- Authors ---
 
-// ListAuthors implements GET /authors.
+// ListAuthors implements GET /authors. Under library isolation only the
+// authors with books in the caller's libraries are listed, counted over
+// those books.
 func (h *Handler) ListAuthors(c *gin.Context) {
-	if cached, ok := h.authorsCache.Get("all"); ok {
+	scope := servermiddleware.LibraryScope(c)
+	cacheKey := "all"
+	if scope != nil {
+		cacheKey += "|" + scope.Key()
+	}
+	if cached, ok := h.authorsCache.Get(cacheKey); ok {
 		httputil.RespondWithOK(c, cached)
 		return
 	}
 	resp, err := h.authorSeriesService.ListAuthorsWithCounts()
+	if err == nil && scope != nil {
+		resp, err = h.scopeAuthors(c.Request.Context(), scope, resp)
+	}
 	if err != nil {
 		httputil.InternalError(c, "failed to list authors", err)
 		return
 	}
-	h.authorsCache.Set("all", resp)
+	h.authorsCache.Set(cacheKey, resp)
 	httputil.RespondWithOK(c, resp)
 }
 
@@ -509,28 +696,68 @@ func (h *Handler) MergeAuthors(c *gin.Context) {
 	httputil.RespondWithSuccess(c, 202, op)
 }
 
-// DeleteAuthor implements DELETE /authors/:id.
+// DeleteAuthor implements DELETE /authors/:id. An author with books is
+// rejected with 409 unless ?cascade=true, which removes the author from each
+// book's author list first. Books themselves are never deleted.
 func (h *Handler) DeleteAuthor(c *gin.Context) {
 	authorID, err := strconv.Atoi(c.Param("id"))
 	if err != nil || authorID <= 0 {
 		httputil.RespondWithBadRequest(c, "invalid author ID")
 		return
 	}
+	cascade := httputil.ParseQueryBool(c, "cascade", false)
 	books, err := h.store.GetBooksByAuthorID(authorID)
 	if err != nil {
 		httputil.InternalError(c, "failed to get author books", err)
 		return
 	}
-	if len(books) > 0 {
-		httputil.RespondWithConflict(c, "cannot delete author with books")
+	if len(books) > 0 && !cascade {
+		httputil.RespondWithConflict(c, "cannot delete author with books; pass cascade=true to unlink them")
 		return
 	}
+	for i := range books {
+		if err := h.unlinkAuthorFromBook(&books[i], authorID); err != nil {
+			httputil.InternalError(c, "failed to unlink book "+books[i].ID, err)
+			return
+		}
+	}
 	if err := h.store.DeleteAuthor(authorID); err != nil {
 		httputil.InternalError(c, "failed to delete author", err)
 		return
 	}
 	h.authorsCache.InvalidateAll()
-	httputil.RespondWithOK(c, gin.H{"message": "author deleted"})
+	httputil.RespondWithOK(c, gin.H{"message": "author deleted", "unlinked_books": len(books)})
+}
+
+// unlinkAuthorFromBook drops authorID from the book's author join rows and,
+// if it was the book's primary author, promotes the next remaining author
+// (or clears the field when none remain).
+func (h *Handler) unlinkAuthorFromBook(book *database.Book, authorID int) error {
+	links, err := h.store.GetBookAuthors(book.ID)
+	if err != nil {
+		return err
+	}
+	kept := make([]database.BookAuthor, 0, len(links))
+	for _, ba := range links {
+		if ba.AuthorID != authorID {
+			kept = append(kept, ba)
+		}
+	}
+	if len(kept) != len(links) {
+		if err := h.store.SetBookAuthors(book.ID, kept); err != nil {
+			return err
+		}
+	}
+	if book.AuthorID == nil || *book.AuthorID != authorID {
+		return nil
+	}
+	book.AuthorID = nil
+	if len(kept) > 0 {
+		next := kept[0].AuthorID
+		book.AuthorID = &next
+	}
+	_, err = h.store.UpdateBook(book.ID, book)
+	return err
 }
 
 // BulkDeleteAuthors deletes multiple zero-book authors at once.
@@ -788,18 +1015,27 @@ func (h *Handler) CountSeries(c *gin.Context) {
 	httputil.RespondWithOK(c, gin.H{"count": count})
 }
 
-// ListSeries implements GET /series.
+// ListSeries implements GET /series, scoped to the caller's libraries
+// like ListAuthors.
 func (h *Handler) ListSeries(c *gin.Context) {
-	if cached, ok := h.seriesCache.Get("all"); ok {
+	scope := servermiddleware.LibraryScope(c)
+	cacheKey := "all"
+	if scope != nil {
+		cacheKey += "|" + scope.Key()
+	}
+	if cached, ok := h.seriesCache.Get(cacheKey); ok {
 		httputil.RespondWithOK(c, cached)
 		return
 	}
 	resp, err := h.authorSeriesService.ListSeriesWithCounts()
+	if err == nil && scope != nil {
+		resp, err = h.scopeSeries(c.Request.Context(), scope, resp)
+	}
 	if err != nil {
 		httputil.InternalError(c, "failed to list series", err)
 		return
 	}
-	h.seriesCache.Set("all", resp)
+	h.seriesCache.Set(cacheKey, resp)
 	httputil.RespondWithOK(c, resp)
 }
 
@@ -901,28 +1137,40 @@ func (h *Handler) SplitSeries(c *gin.Context) {
 	httputil.RespondWithOK(c, gin.H{"new_series": newSeries, "books_moved": moved})
 }
 
-// DeleteEmptySeries implements DELETE /series/:id.
+// DeleteEmptySeries implements DELETE /series/:id. A series with books is
+// rejected with 409 unless ?cascade=true, which clears the series and
+// position from each book first.
 func (h *Handler) DeleteEmptySeries(c *gin.Context) {
 	seriesID, err := strconv.Atoi(c.Param("id"))
 	if err != nil || seriesID <= 0 {
 		httputil.RespondWithBadRequest(c, "invalid series ID")
 		return
 	}
+	cascade := httputil.ParseQueryBool(c, "cascade", false)
 	books, err := h.store.GetBooksBySeriesID(seriesID)
 	if err != nil {
 		httputil.InternalError(c, "failed to get series books", err)
 		return
 	}
-	if len(books) > 0 {
-		httputil.RespondWithConflict(c, "cannot delete series with books")
+	if len(books) > 0 && !cascade {
+		httputil.RespondWithConflict(c, "cannot delete series with books; pass cascade=true to unlink them")
 		return
 	}
+	for i := range books {
+		b := books[i]
+		b.SeriesID = nil
+		b.SeriesSequence = nil
+		if _, err := h.store.UpdateBook(b.ID, &b); err != nil {
+			httputil.InternalError(c, "failed to unlink book "+b.ID, err)
+			return
+		}
+	}
 	if err := h.store.DeleteSeries(seriesID); err != nil {
 		httputil.InternalError(c, "failed to delete series", err)
 		return
 	}
 	h.seriesCache.InvalidateAll()
-	httputil.RespondWithOK(c, gin.H{"message": "series deleted"})
+	httputil.RespondWithOK(c, gin.H{"message": "series deleted", "unlinked_books": len(books)})
 }
 
 // BulkDeleteSeries deletes multiple empty series at once.
@@ -1025,6 +1273,78 @@ func (h *Handler) CountNarrators(c *gin.Context) {
 	httputil.RespondWithOK(c, gin.H{"count": len(narrators)})
 }
 
+// ListAudiobookAuthors implements GET /audiobooks/:id/authors: the book's
+// contributors (authors, co-authors, editors, translators) in credit
+// order.
+func (h *Handler) ListAudiobookAuthors(c *gin.Context) {
+	id := c.Param("id")
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	authors, err := h.store.GetBookAuthors(id)
+	if err != nil {
+		httputil.InternalError(c, "failed to list audiobook authors", err)
+		return
+	}
+	if authors == nil {
+		authors = []database.BookAuthor{}
+	}
+	httputil.RespondWithOK(c, authors)
+}
+
+// SetAudiobookAuthors implements PUT /audiobooks/:id/authors. The body
+// replaces the book's contributor list; entries are ordered by position
+// and a missing role defaults to "author" for the first and "co-author"
+// after. The first entry becomes the book's primary author.
+func (h *Handler) SetAudiobookAuthors(c *gin.Context) {
+	id := c.Param("id")
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	var authors []database.BookAuthor
+	if err := c.ShouldBindJSON(&authors); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	authors, err := database.NormalizeBookAuthors(id, authors)
+	if err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if len(authors) == 0 {
+		httputil.RespondWithBadRequest(c, "at least one author is required")
+		return
+	}
+	book, err := h.store.GetBookByID(id)
+	if err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "audiobook", id)
+		return
+	}
+	for _, a := range authors {
+		if author, err := h.store.GetAuthorByID(a.AuthorID); err != nil || author == nil {
+			httputil.RespondWithBadRequest(c, fmt.Sprintf("author %d not found", a.AuthorID))
+			return
+		}
+	}
+	if err := h.store.SetBookAuthors(id, authors); err != nil {
+		httputil.InternalError(c, "failed to set audiobook authors", err)
+		return
+	}
+	if primary := authors[0].AuthorID; book.AuthorID == nil || *book.AuthorID != primary {
+		book.AuthorID = &primary
+		book.Author = nil
+		if _, err := h.store.UpdateBook(id, book); err != nil {
+			httputil.InternalError(c, "failed to update primary author", err)
+			return
+		}
+	}
+
+	h.authorsCache.InvalidateAll()
+	httputil.RespondWithOK(c, authors)
+}
+
 // ListAudiobookNarrators implements GET /audiobooks/:id/narrators.
 func (h *Handler) ListAudiobookNarrators(c *gin.Context) {
 	id := c.Param("id")
@@ -1055,6 +1375,11 @@ func (h *Handler) SetAudiobookNarrators(c *gin.Context) {
 		httputil.RespondWithBadRequest(c, err.Error())
 		return
 	}
+	narrators, err := database.NormalizeBookNarrators(id, narrators)
+	if err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
 	if err := h.store.SetBookNarrators(id, narrators); err != nil {
 		httputil.InternalError(c, "failed to set audiobook narrators", err)
 		return
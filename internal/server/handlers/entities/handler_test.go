@@ -1,7 +1,7 @@
 // file: internal/server/handlers/entities/handler_test.go
-// version: 1.0.0
+// version: 1.5.0
 // guid: 163bc668-0761-43eb-9d85-f4983e8b014b
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 package entities_test
 
@@ -15,6 +15,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/cache"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/entities"
 	entitiesmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/entities/mocks"
@@ -163,6 +164,7 @@ func TestUpdateWork_NotFound(t *testing.T) {
 
 func TestDeleteWork(t *testing.T) {
 	h, d := newHandler(t)
+	d.store.EXPECT().GetBooksByWorkID("w1").Return(nil, nil)
 	d.workSvc.EXPECT().DeleteWork("w1").Return(nil)
 	c, w := newCtx(http.MethodDelete, "/works/w1", "", idParam("w1"))
 	h.DeleteWork(c)
@@ -171,12 +173,32 @@ func TestDeleteWork(t *testing.T) {
 
 func TestDeleteWork_NotFound(t *testing.T) {
 	h, d := newHandler(t)
+	d.store.EXPECT().GetBooksByWorkID("w1").Return(nil, nil)
 	d.workSvc.EXPECT().DeleteWork("w1").Return(errString("work not found"))
 	c, w := newCtx(http.MethodDelete, "/works/w1", "", idParam("w1"))
 	h.DeleteWork(c)
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestDeleteWork_HasBooks(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().GetBooksByWorkID("w1").Return([]database.Book{{ID: "b1"}}, nil)
+	c, w := newCtx(http.MethodDelete, "/works/w1", "", idParam("w1"))
+	h.DeleteWork(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestDeleteWork_Cascade(t *testing.T) {
+	h, d := newHandler(t)
+	wid := "w1"
+	d.store.EXPECT().GetBooksByWorkID("w1").Return([]database.Book{{ID: "b1", WorkID: &wid}}, nil)
+	d.store.EXPECT().UpdateBook("b1", mock.MatchedBy(func(b *database.Book) bool { return b.WorkID == nil })).Return(&database.Book{ID: "b1"}, nil)
+	d.workSvc.EXPECT().DeleteWork("w1").Return(nil)
+	c, w := newCtx(http.MethodDelete, "/works/w1?cascade=true", "", idParam("w1"))
+	h.DeleteWork(c)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
 func TestListWorkBooks(t *testing.T) {
 	h, d := newHandler(t)
 	d.store.EXPECT().GetBooksByWorkID("w1").Return([]database.Book{{ID: "b1"}}, nil)
@@ -222,6 +244,42 @@ func TestListAuthors_Cached(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestListAuthors_LibraryScoped(t *testing.T) {
+	orig := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = orig })
+	config.AppConfig.LibraryIsolation = true
+	config.AppConfig.Libraries = []config.LibraryDefinition{
+		{ID: "kids", RootDir: "/lib/kids", Roles: []string{"child"}},
+		{ID: "adults", RootDir: "/lib/adults", Roles: []string{"adult"}},
+	}
+
+	h, d := newHandler(t)
+	kidsAuthor, adultAuthor := 1, 2
+	d.authorSeries.EXPECT().ListAuthorsWithCounts().Return(&audiobooks.AuthorWithCountListResponse{
+		Items: []audiobooks.AuthorWithCount{
+			{ID: kidsAuthor, Name: "Kid Author", BookCount: 1, FileCount: 2},
+			{ID: adultAuthor, Name: "Adult Author", BookCount: 1, FileCount: 1},
+		},
+		Count: 2,
+	}, nil)
+	d.store.EXPECT().GetAllBooks(0, 0).Return([]database.Book{
+		{ID: "k1", FilePath: "/lib/kids/k1", AuthorID: &kidsAuthor},
+		{ID: "a1", FilePath: "/lib/adults/a1", AuthorID: &adultAuthor},
+	}, nil)
+	d.store.EXPECT().GetBookFiles("k1").Return([]database.BookFile{{ID: "f1"}, {ID: "f2"}}, nil)
+	d.store.EXPECT().GetBookAuthors("k1").Return(nil, nil)
+
+	c, w := newCtx(http.MethodGet, "/authors", "", nil)
+	c.Set("auth_user", &database.User{ID: "u1", Roles: []string{"child"}})
+	h.ListAuthors(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Kid Author")
+	assert.NotContains(t, w.Body.String(), "Adult Author")
+
+	_, ok := d.authorsCache.Get("all")
+	assert.False(t, ok, "scoped list must not fill the unscoped cache entry")
+}
+
 func TestCountAuthors(t *testing.T) {
 	h, d := newHandler(t)
 	d.store.EXPECT().CountAuthors().Return(42, nil)
@@ -303,6 +361,25 @@ func TestDeleteAuthor_HasBooks(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, w.Code)
 }
 
+func TestDeleteAuthor_Cascade(t *testing.T) {
+	h, d := newHandler(t)
+	five, seven := 5, 7
+	d.store.EXPECT().GetBooksByAuthorID(5).Return([]database.Book{{ID: "b1", AuthorID: &five}}, nil)
+	d.store.EXPECT().GetBookAuthors("b1").Return([]database.BookAuthor{
+		{BookID: "b1", AuthorID: 5, Position: 0},
+		{BookID: "b1", AuthorID: 7, Position: 1},
+	}, nil)
+	d.store.EXPECT().SetBookAuthors("b1", []database.BookAuthor{{BookID: "b1", AuthorID: 7, Position: 1}}).Return(nil)
+	d.store.EXPECT().UpdateBook("b1", mock.MatchedBy(func(b *database.Book) bool {
+		return b.AuthorID != nil && *b.AuthorID == seven
+	})).Return(&database.Book{ID: "b1"}, nil)
+	d.store.EXPECT().DeleteAuthor(5).Return(nil)
+	c, w := newCtx(http.MethodDelete, "/authors/5?cascade=true", "", idParam("5"))
+	h.DeleteAuthor(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"unlinked_books":1`)
+}
+
 func TestBulkDeleteAuthors(t *testing.T) {
 	h, d := newHandler(t)
 	d.store.EXPECT().GetBooksByAuthorID(1).Return([]database.Book{}, nil)
@@ -466,6 +543,19 @@ func TestDeleteEmptySeries(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestDeleteEmptySeries_Cascade(t *testing.T) {
+	h, d := newHandler(t)
+	five, pos := 5, 2
+	d.store.EXPECT().GetBooksBySeriesID(5).Return([]database.Book{{ID: "b1", SeriesID: &five, SeriesSequence: database.NewSeriesSeq(pos)}}, nil)
+	d.store.EXPECT().UpdateBook("b1", mock.MatchedBy(func(b *database.Book) bool {
+		return b.SeriesID == nil && b.SeriesSequence == nil
+	})).Return(&database.Book{ID: "b1"}, nil)
+	d.store.EXPECT().DeleteSeries(5).Return(nil)
+	c, w := newCtx(http.MethodDelete, "/series/5?cascade=true", "", idParam("5"))
+	h.DeleteEmptySeries(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestDeleteEmptySeries_HasBooks(t *testing.T) {
 	h, d := newHandler(t)
 	d.store.EXPECT().GetBooksBySeriesID(5).Return([]database.Book{{ID: "b"}}, nil)
@@ -540,6 +630,66 @@ func TestSetAudiobookNarrators_BadJSON(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestSetAudiobookNarrators_UnknownRole(t *testing.T) {
+	h, _ := newHandler(t)
+	c, w := newCtx(http.MethodPut, "/audiobooks/b1/narrators", `[{"narrator_id":3,"role":"singer"}]`, idParam("b1"))
+	h.SetAudiobookNarrators(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// ── Book authors (contributors) ────────────────────────────────────────────
+
+func TestListAudiobookAuthors(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().GetBookAuthors("b1").Return(nil, nil)
+	c, w := newCtx(http.MethodGet, "/audiobooks/b1/authors", "", idParam("b1"))
+	h.ListAudiobookAuthors(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+}
+
+func TestSetAudiobookAuthors(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", AuthorID: intptr(7)}, nil)
+	d.store.EXPECT().GetAuthorByID(9).Return(&database.Author{ID: 9}, nil)
+	d.store.EXPECT().GetAuthorByID(7).Return(&database.Author{ID: 7}, nil)
+	d.store.EXPECT().SetBookAuthors("b1", []database.BookAuthor{
+		{BookID: "b1", AuthorID: 9, Role: "editor", Position: 0},
+		{BookID: "b1", AuthorID: 7, Role: "co-author", Position: 1},
+	}).Return(nil)
+	d.store.EXPECT().UpdateBook("b1", mock.MatchedBy(func(b *database.Book) bool {
+		return b.AuthorID != nil && *b.AuthorID == 9
+	})).Return(&database.Book{ID: "b1"}, nil)
+	c, w := newCtx(http.MethodPut, "/audiobooks/b1/authors",
+		`[{"author_id":7,"position":3},{"author_id":9,"role":"editor","position":1}]`, idParam("b1"))
+	h.SetAudiobookAuthors(c)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestSetAudiobookAuthors_Invalid(t *testing.T) {
+	for name, body := range map[string]string{
+		"bad json":     `not-json`,
+		"empty":        `[]`,
+		"unknown role": `[{"author_id":1,"role":"illustrator"}]`,
+		"no author id": `[{"role":"author"}]`,
+	} {
+		h, _ := newHandler(t)
+		c, w := newCtx(http.MethodPut, "/audiobooks/b1/authors", body, idParam("b1"))
+		h.SetAudiobookAuthors(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
+
+func TestSetAudiobookAuthors_UnknownAuthor(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1"}, nil)
+	d.store.EXPECT().GetAuthorByID(5).Return(nil, nil)
+	c, w := newCtx(http.MethodPut, "/audiobooks/b1/authors", `[{"author_id":5}]`, idParam("b1"))
+	h.SetAudiobookAuthors(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "author 5 not found")
+}
+
 // ── helpers ──────────────────────────────────────────────────────────────
 
 type errString string
@@ -547,3 +697,57 @@ type errString string
 func (e errString) Error() string { return string(e) }
 
 func intptr(i int) *int { return &i }
+
+// ── Work merge / split ─────────────────────────────────────────────────────
+
+func TestMergeWorks(t *testing.T) {
+	h, d := newHandler(t)
+	w2 := "w2"
+	d.workSvc.EXPECT().GetWork("w1").Return(&database.Work{ID: "w1", Title: "Keep"}, nil)
+	d.workSvc.EXPECT().GetWork("w2").Return(&database.Work{ID: "w2", Title: "Dup"}, nil)
+	d.store.EXPECT().GetBooksByWorkID("w2").Return([]database.Book{{ID: "b1", WorkID: &w2}}, nil)
+	d.store.EXPECT().UpdateBook("b1", mock.MatchedBy(func(b *database.Book) bool {
+		return b.WorkID != nil && *b.WorkID == "w1"
+	})).Return(&database.Book{ID: "b1"}, nil)
+	d.store.EXPECT().RecordMetadataChange(mock.MatchedBy(func(r *database.MetadataChangeRecord) bool {
+		return r.BookID == "b1" && r.Field == "work_id" && r.ChangeType == "work_merge" && *r.PreviousValue == "w2"
+	})).Return(nil)
+	d.workSvc.EXPECT().DeleteWork("w2").Return(nil)
+	c, w := newCtx(http.MethodPost, "/works/merge", `{"keep_id":"w1","merge_ids":["w2"]}`, nil)
+	h.MergeWorks(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"books_relinked":1`)
+}
+
+func TestMergeWorks_KeepInMergeIDs(t *testing.T) {
+	h, d := newHandler(t)
+	d.workSvc.EXPECT().GetWork("w1").Return(&database.Work{ID: "w1"}, nil)
+	c, w := newCtx(http.MethodPost, "/works/merge", `{"keep_id":"w1","merge_ids":["w1"]}`, nil)
+	h.MergeWorks(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSplitWork(t *testing.T) {
+	h, d := newHandler(t)
+	w1, other := "w1", "w9"
+	d.workSvc.EXPECT().GetWork("w1").Return(&database.Work{ID: "w1", Title: "Dune"}, nil)
+	d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1", WorkID: &w1}, nil)
+	d.store.EXPECT().GetBookByID("b2").Return(&database.Book{ID: "b2", WorkID: &other}, nil)
+	d.workSvc.EXPECT().CreateWork(mock.MatchedBy(func(w *database.Work) bool { return w.Title == "Dune (Split)" })).
+		Return(&database.Work{ID: "w3", Title: "Dune (Split)"}, nil)
+	d.store.EXPECT().UpdateBook("b1", mock.Anything).Return(&database.Book{ID: "b1"}, nil)
+	d.store.EXPECT().RecordMetadataChange(mock.Anything).Return(nil)
+	c, w := newCtx(http.MethodPost, "/works/w1/split", `{"book_ids":["b1","b2"]}`, idParam("w1"))
+	h.SplitWork(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"books_moved":1`)
+}
+
+func TestSplitWork_NoMatchingBooks(t *testing.T) {
+	h, d := newHandler(t)
+	d.workSvc.EXPECT().GetWork("w1").Return(&database.Work{ID: "w1"}, nil)
+	d.store.EXPECT().GetBookByID("b2").Return(&database.Book{ID: "b2"}, nil)
+	c, w := newCtx(http.MethodPost, "/works/w1/split", `{"book_ids":["b2"]}`, idParam("w1"))
+	h.SplitWork(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
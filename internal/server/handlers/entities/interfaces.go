@@ -1,7 +1,7 @@
 // file: internal/server/handlers/entities/interfaces.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: 43710377-fdb3-490c-872e-fd03309163be
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Narrow dependency interfaces for the entities domain handlers (authors,
 // series, narrators, works). Each interface lists only the methods the
@@ -41,6 +41,7 @@ type EntitiesStore interface {
 	SetBookAuthors(bookID string, authors []database.BookAuthor) error
 	GetBookNarrators(bookID string) ([]database.BookNarrator, error)
 	SetBookNarrators(bookID string, narrators []database.BookNarrator) error
+	GetBookFiles(bookID string) ([]database.BookFile, error)
 	GetBookByID(id string) (*database.Book, error)
 	UpdateBook(id string, book *database.Book) (*database.Book, error)
 
@@ -57,11 +58,17 @@ type EntitiesStore interface {
 	UpdateSeriesName(id int, name string) error
 	DeleteSeries(id int) error
 
+	// Library-scoped author/series counts
+	GetAllBooks(limit, offset int) ([]database.Book, error)
+
 	// Works
 	GetAllWorks() ([]database.Work, error)
 	GetAllWorkBookCounts() (map[string]int, error)
 	GetBooksByWorkID(workID string) ([]database.Book, error)
 
+	// Audit trail for work merge/split relinks.
+	RecordMetadataChange(record *database.MetadataChangeRecord) error
+
 	// Operations (legacy operation row creation for author-merge /
 	// resolve-production-author).
 	CreateOperation(id, opType string, folderPath *string) (*database.Operation, error)
@@ -0,0 +1,114 @@
+// file: internal/server/handlers/entities/library_scope.go
+// version: 1.0.0
+// guid: 6d0f3b7e-41a8-4c52-9e1d-8a27c5f09b34
+// last-edited: 2026-10-17
+
+package entities
+
+import (
+	"context"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/libraryscope"
+)
+
+// scopedCounts holds per-author and per-series book and file counts over
+// the books one library scope can see.
+type scopedCounts struct {
+	authorBooks map[int]int
+	authorFiles map[int]int
+	seriesBooks map[int]int
+	seriesFiles map[int]int
+}
+
+// countVisibleBooks tallies the primary, non-deleted books inside scope the
+// same way the store's library-wide counts do: a book counts for every
+// author in its book_authors rows, or for its AuthorID when it has none.
+func (h *Handler) countVisibleBooks(ctx context.Context, scope *libraryscope.Scope) (*scopedCounts, error) {
+	books, err := database.GetAllBooksContext(ctx, h.store, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	counts := &scopedCounts{
+		authorBooks: map[int]int{},
+		authorFiles: map[int]int{},
+		seriesBooks: map[int]int{},
+		seriesFiles: map[int]int{},
+	}
+	visible := scope.FilterBooks(books)
+	for i := range visible {
+		book := &visible[i]
+		if book.IsPrimaryVersion != nil && !*book.IsPrimaryVersion {
+			continue
+		}
+		if book.MarkedForDeletion != nil && *book.MarkedForDeletion {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		files, err := h.store.GetBookFiles(book.ID)
+		if err != nil {
+			return nil, err
+		}
+		authors, err := h.store.GetBookAuthors(book.ID)
+		if err != nil {
+			return nil, err
+		}
+		authorIDs := make([]int, 0, len(authors))
+		for _, a := range authors {
+			authorIDs = append(authorIDs, a.AuthorID)
+		}
+		if len(authorIDs) == 0 && book.AuthorID != nil {
+			authorIDs = append(authorIDs, *book.AuthorID)
+		}
+		for _, id := range authorIDs {
+			counts.authorBooks[id]++
+			counts.authorFiles[id] += len(files)
+		}
+		if book.SeriesID != nil {
+			counts.seriesBooks[*book.SeriesID]++
+			counts.seriesFiles[*book.SeriesID] += len(files)
+		}
+	}
+	return counts, nil
+}
+
+// scopeAuthors narrows resp to the authors with at least one book in
+// scope, with their counts recomputed over those books only.
+func (h *Handler) scopeAuthors(ctx context.Context, scope *libraryscope.Scope, resp *audiobooks.AuthorWithCountListResponse) (*audiobooks.AuthorWithCountListResponse, error) {
+	counts, err := h.countVisibleBooks(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]audiobooks.AuthorWithCount, 0, len(counts.authorBooks))
+	for _, a := range resp.Items {
+		if counts.authorBooks[a.ID] == 0 {
+			continue
+		}
+		a.BookCount = counts.authorBooks[a.ID]
+		a.FileCount = counts.authorFiles[a.ID]
+		items = append(items, a)
+	}
+	return &audiobooks.AuthorWithCountListResponse{Items: items, Count: len(items)}, nil
+}
+
+// scopeSeries narrows resp to the series with at least one book in scope,
+// with their counts recomputed over those books only.
+func (h *Handler) scopeSeries(ctx context.Context, scope *libraryscope.Scope, resp *audiobooks.SeriesWithCountsResponse) (*audiobooks.SeriesWithCountsResponse, error) {
+	counts, err := h.countVisibleBooks(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]audiobooks.SeriesWithCount, 0, len(counts.seriesBooks))
+	for _, s := range resp.Items {
+		if counts.seriesBooks[s.ID] == 0 {
+			continue
+		}
+		s.BookCount = counts.seriesBooks[s.ID]
+		s.FileCount = counts.seriesFiles[s.ID]
+		items = append(items, s)
+	}
+	return &audiobooks.SeriesWithCountsResponse{Items: items, Count: len(items)}, nil
+}
@@ -635,6 +635,74 @@ func (_c *MockEntitiesStore_DeleteSeries_Call) RunAndReturn(run func(id int) err
 	return _c
 }
 
+// GetAllBooks provides a mock function for the type MockEntitiesStore
+func (_mock *MockEntitiesStore) GetAllBooks(limit int, offset int) ([]database.Book, error) {
+	ret := _mock.Called(limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBooks")
+	}
+
+	var r0 []database.Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]database.Book, error)); ok {
+		return returnFunc(limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []database.Book); ok {
+		r0 = returnFunc(limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntitiesStore_GetAllBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBooks'
+type MockEntitiesStore_GetAllBooks_Call struct {
+	*mock.Call
+}
+
+// GetAllBooks is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+func (_e *MockEntitiesStore_Expecter) GetAllBooks(limit interface{}, offset interface{}) *MockEntitiesStore_GetAllBooks_Call {
+	return &MockEntitiesStore_GetAllBooks_Call{Call: _e.mock.On("GetAllBooks", limit, offset)}
+}
+
+func (_c *MockEntitiesStore_GetAllBooks_Call) Run(run func(limit int, offset int)) *MockEntitiesStore_GetAllBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntitiesStore_GetAllBooks_Call) Return(books []database.Book, err error) *MockEntitiesStore_GetAllBooks_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockEntitiesStore_GetAllBooks_Call) RunAndReturn(run func(limit int, offset int) ([]database.Book, error)) *MockEntitiesStore_GetAllBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllWorkBookCounts provides a mock function for the type MockEntitiesStore
 func (_mock *MockEntitiesStore) GetAllWorkBookCounts() (map[string]int, error) {
 	ret := _mock.Called()
@@ -1055,6 +1123,68 @@ func (_c *MockEntitiesStore_GetBookByID_Call) RunAndReturn(run func(id string) (
 	return _c
 }
 
+// GetBookFiles provides a mock function for the type MockEntitiesStore
+func (_mock *MockEntitiesStore) GetBookFiles(bookID string) ([]database.BookFile, error) {
+	ret := _mock.Called(bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookFiles")
+	}
+
+	var r0 []database.BookFile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]database.BookFile, error)); ok {
+		return returnFunc(bookID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []database.BookFile); ok {
+		r0 = returnFunc(bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.BookFile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntitiesStore_GetBookFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookFiles'
+type MockEntitiesStore_GetBookFiles_Call struct {
+	*mock.Call
+}
+
+// GetBookFiles is a helper method to define mock.On call
+//   - bookID string
+func (_e *MockEntitiesStore_Expecter) GetBookFiles(bookID interface{}) *MockEntitiesStore_GetBookFiles_Call {
+	return &MockEntitiesStore_GetBookFiles_Call{Call: _e.mock.On("GetBookFiles", bookID)}
+}
+
+func (_c *MockEntitiesStore_GetBookFiles_Call) Run(run func(bookID string)) *MockEntitiesStore_GetBookFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntitiesStore_GetBookFiles_Call) Return(bookFiles []database.BookFile, err error) *MockEntitiesStore_GetBookFiles_Call {
+	_c.Call.Return(bookFiles, err)
+	return _c
+}
+
+func (_c *MockEntitiesStore_GetBookFiles_Call) RunAndReturn(run func(bookID string) ([]database.BookFile, error)) *MockEntitiesStore_GetBookFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBookNarrators provides a mock function for the type MockEntitiesStore
 func (_mock *MockEntitiesStore) GetBookNarrators(bookID string) ([]database.BookNarrator, error) {
 	ret := _mock.Called(bookID)
@@ -1544,6 +1674,57 @@ func (_c *MockEntitiesStore_ListNarrators_Call) RunAndReturn(run func() ([]datab
 	return _c
 }
 
+// RecordMetadataChange provides a mock function for the type MockEntitiesStore
+func (_mock *MockEntitiesStore) RecordMetadataChange(record *database.MetadataChangeRecord) error {
+	ret := _mock.Called(record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordMetadataChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.MetadataChangeRecord) error); ok {
+		r0 = returnFunc(record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntitiesStore_RecordMetadataChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordMetadataChange'
+type MockEntitiesStore_RecordMetadataChange_Call struct {
+	*mock.Call
+}
+
+// RecordMetadataChange is a helper method to define mock.On call
+//   - record *database.MetadataChangeRecord
+func (_e *MockEntitiesStore_Expecter) RecordMetadataChange(record interface{}) *MockEntitiesStore_RecordMetadataChange_Call {
+	return &MockEntitiesStore_RecordMetadataChange_Call{Call: _e.mock.On("RecordMetadataChange", record)}
+}
+
+func (_c *MockEntitiesStore_RecordMetadataChange_Call) Run(run func(record *database.MetadataChangeRecord)) *MockEntitiesStore_RecordMetadataChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *database.MetadataChangeRecord
+		if args[0] != nil {
+			arg0 = args[0].(*database.MetadataChangeRecord)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntitiesStore_RecordMetadataChange_Call) Return(err error) *MockEntitiesStore_RecordMetadataChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntitiesStore_RecordMetadataChange_Call) RunAndReturn(run func(record *database.MetadataChangeRecord) error) *MockEntitiesStore_RecordMetadataChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetBookAuthors provides a mock function for the type MockEntitiesStore
 func (_mock *MockEntitiesStore) SetBookAuthors(bookID string, authors []database.BookAuthor) error {
 	ret := _mock.Called(bookID, authors)
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/filesystem.go
-// version: 1.0.0
+// version: 1.7.0
 // guid: c4d5e6f7-a8b9-0123-cdef-012345678901
-// last-edited: 2026-06-02
+// last-edited: 2026-10-17
 
 // Package handlers — FilesystemHandler covers home-directory, filesystem
 // browse, exclusion CRUD, import-path CRUD, and the on-demand single-file
@@ -13,6 +13,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -26,7 +27,9 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/importer"
 	"github.com/falkcorp/audiobook-organizer/internal/organizer"
 	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
 	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+	servermiddleware "github.com/falkcorp/audiobook-organizer/internal/server/middleware"
 	ulid "github.com/oklog/ulid/v2"
 )
 
@@ -218,21 +221,43 @@ func (h *FilesystemHandler) AddImportPath(c *gin.Context) {
 		Path    string `json:"path" binding:"required"`
 		Name    string `json:"name" binding:"required"`
 		Enabled *bool  `json:"enabled"`
+		// ScanProfile is the path's default scan profile; empty means standard.
+		ScanProfile string `json:"scan_profile"`
+		// Watch overrides auto_scan_enabled for this path.
+		Watch *bool `json:"watch"`
+		// Adopt takes the path's books in place, reading their fields
+		// from the folder layout (AdoptPattern, else folder_naming_pattern).
+		Adopt        bool   `json:"adopt"`
+		AdoptPattern string `json:"adopt_pattern"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		httputil.RespondWithBadRequest(c, err.Error())
 		return
 	}
+	if !scanner.ValidScanProfile(req.ScanProfile) {
+		httputil.RespondWithValidationError(c, "scan_profile", "must be one of: "+strings.Join(scanner.ScanProfiles, ", "))
+		return
+	}
+	if err := scanner.ValidAdoptPattern(req.AdoptPattern); err != nil {
+		httputil.RespondWithValidationError(c, "adopt_pattern", err.Error())
+		return
+	}
 	createdPath, err := h.pathCreator.CreateImportPath(req.Path, req.Name)
 	if err != nil {
 		httputil.RespondWithBadRequest(c, err.Error())
 		return
 	}
 	folder := createdPath
-	if req.Enabled != nil && !*req.Enabled {
-		folder.Enabled = false
+	if (req.Enabled != nil && !*req.Enabled) || req.ScanProfile != "" || req.Watch != nil || req.Adopt {
+		if req.Enabled != nil {
+			folder.Enabled = *req.Enabled
+		}
+		folder.ScanProfile = req.ScanProfile
+		folder.Watch = req.Watch
+		folder.Adopt = req.Adopt
+		folder.AdoptPattern = req.AdoptPattern
 		if err := h.store.UpdateImportPath(folder.ID, folder); err != nil {
-			httputil.RespondWithCreated(c, gin.H{"importPath": folder, "warning": "created but could not update enabled flag"})
+			httputil.RespondWithCreated(c, gin.H{"importPath": folder, "warning": "created but could not update enabled flag, scan profile, watch or adopt setting"})
 			return
 		}
 	}
@@ -258,7 +283,7 @@ func (h *FilesystemHandler) AddImportPath(c *gin.Context) {
 	// Fallback: synchronous scan when op registry is unavailable.
 	if folder.Enabled && h.opEnqueuer == nil {
 		if _, statErr := os.Stat(folder.Path); statErr == nil {
-			books, scanErr := scanner.ScanDirectory(folder.Path, nil)
+			books, scanErr := scanner.ScanDirectoryContext(c.Request.Context(), folder.Path, 1, nil)
 			if scanErr == nil {
 				if len(books) > 0 {
 					_ = scanner.ProcessBooks(books, nil)
@@ -316,6 +341,99 @@ func (h *FilesystemHandler) RemoveImportPath(c *gin.Context) {
 	httputil.RespondWithNoContent(c)
 }
 
+// UpdateImportPath handles PATCH /api/v1/import-paths/:id. Only the
+// fields present in the body change; the path itself is fixed.
+func (h *FilesystemHandler) UpdateImportPath(c *gin.Context) {
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httputil.RespondWithBadRequest(c, "invalid import path id")
+		return
+	}
+	var req struct {
+		Name         *string `json:"name"`
+		Enabled      *bool   `json:"enabled"`
+		ScanProfile  *string `json:"scan_profile"`
+		RequireMount *bool   `json:"require_mount"`
+		Watch        *bool   `json:"watch"`
+		// ScanMode "initial" has the next scan deep-scan the path again.
+		ScanMode     *string `json:"scan_mode"`
+		Adopt        *bool   `json:"adopt"`
+		AdoptPattern *string `json:"adopt_pattern"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if req.ScanProfile != nil && !scanner.ValidScanProfile(*req.ScanProfile) {
+		httputil.RespondWithValidationError(c, "scan_profile", "must be one of: "+strings.Join(scanner.ScanProfiles, ", "))
+		return
+	}
+	if req.ScanMode != nil && *req.ScanMode != database.ImportPathScanInitial && *req.ScanMode != database.ImportPathScanIncremental {
+		httputil.RespondWithValidationError(c, "scan_mode", "must be initial or incremental")
+		return
+	}
+	if req.AdoptPattern != nil {
+		if err := scanner.ValidAdoptPattern(*req.AdoptPattern); err != nil {
+			httputil.RespondWithValidationError(c, "adopt_pattern", err.Error())
+			return
+		}
+	}
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		httputil.RespondWithValidationError(c, "name", "must not be empty")
+		return
+	}
+
+	paths, err := h.store.GetAllImportPaths()
+	if err != nil {
+		httputil.InternalError(c, "failed to load import paths", err)
+		return
+	}
+	var folder *database.ImportPath
+	for i := range paths {
+		if paths[i].ID == id {
+			folder = &paths[i]
+			break
+		}
+	}
+	if folder == nil {
+		httputil.RespondWithNotFound(c, "import path", c.Param("id"))
+		return
+	}
+	if req.Name != nil {
+		folder.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Enabled != nil {
+		folder.Enabled = *req.Enabled
+	}
+	if req.ScanProfile != nil {
+		folder.ScanProfile = *req.ScanProfile
+	}
+	if req.RequireMount != nil {
+		folder.RequireMount = *req.RequireMount
+	}
+	if req.Watch != nil {
+		folder.Watch = req.Watch
+	}
+	if req.ScanMode != nil {
+		folder.ScanMode = *req.ScanMode
+	}
+	if req.Adopt != nil {
+		folder.Adopt = *req.Adopt
+	}
+	if req.AdoptPattern != nil {
+		folder.AdoptPattern = *req.AdoptPattern
+	}
+	if err := h.store.UpdateImportPath(id, folder); err != nil {
+		httputil.InternalError(c, "failed to update import path", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"importPath": folder})
+}
+
 // ImportFile handles POST /api/v1/import.
 func (h *FilesystemHandler) ImportFile(c *gin.Context) {
 	var req importer.ImportFileRequest
@@ -324,7 +442,15 @@ func (h *FilesystemHandler) ImportFile(c *gin.Context) {
 		return
 	}
 
+	if user, ok := servermiddleware.CurrentUser(c); ok {
+		req.UserID = user.ID
+	}
+
 	result, err := h.fileImporter.ImportFile(&req)
+	if errors.Is(err, quota.ErrExceeded) {
+		httputil.RespondWithError(c, http.StatusInsufficientStorage, err.Error(), "QUOTA_EXCEEDED")
+		return
+	}
 	if err != nil {
 		httputil.RespondWithBadRequest(c, err.Error())
 		return
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/itunes.go
-// version: 1.2.0
+// version: 1.4.0
 // guid: d4e5f6a7-b8c9-0123-defa-123456789012
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package handlers
 
@@ -694,7 +694,7 @@ func (h *ITunesHandler) ListBooks(c *gin.Context) {
 	if search != "" {
 		// Search path still needs to scan the search results then filter,
 		// since SearchBooks doesn't have an iTunes-PID filter.
-		allBooks, err := h.store.SearchBooks(search, 0, 0)
+		allBooks, err := database.SearchBooksContext(c.Request.Context(), h.store, search, 0, 0)
 		if err != nil {
 			httputil.InternalError(c, "failed to list books", err)
 			return
@@ -747,6 +747,7 @@ func (h *ITunesHandler) ListBooks(c *gin.Context) {
 	httputil.RespondWithOK(c, gin.H{
 		"items": items,
 		"count": total,
+		"total": total,
 	})
 }
 
@@ -0,0 +1,354 @@
+// file: internal/server/handlers/metadata/custom_fields.go
+// version: 1.0.0
+// guid: 60baefae-0c98-44ec-a9d3-b7fa813e7641
+// last-edited: 2026-10-16
+
+package metadatahandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// resolveCustomFieldStore returns the custom field store behind the live
+// store, writing a 500 and returning false when there is none.
+func (h *Handler) resolveCustomFieldStore(c *gin.Context) (database.CustomFieldStore, bool) {
+	store := h.resolveStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return nil, false
+	}
+	cfs, ok := database.AsCustomFieldStore(store)
+	if !ok {
+		httputil.RespondWithInternalError(c, "custom metadata fields not available")
+		return nil, false
+	}
+	return cfs, true
+}
+
+// ListCustomFields handles GET /api/v1/metadata/custom-fields.
+func (h *Handler) ListCustomFields(c *gin.Context) {
+	cfs, ok := h.resolveCustomFieldStore(c)
+	if !ok {
+		return
+	}
+	defs, err := cfs.ListCustomFields()
+	if err != nil {
+		httputil.InternalError(c, "failed to list custom fields", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"fields": defs, "count": len(defs)})
+}
+
+// CreateCustomField handles POST /api/v1/metadata/custom-fields. The key
+// is permanent: it names the field in search (custom.<key>:value) and
+// naming patterns ({custom_<key>}).
+func (h *Handler) CreateCustomField(c *gin.Context) {
+	var def database.CustomFieldDefinition
+	if err := c.ShouldBindJSON(&def); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if err := def.Normalize(); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	cfs, ok := h.resolveCustomFieldStore(c)
+	if !ok {
+		return
+	}
+	existing, err := cfs.GetCustomField(def.Key)
+	if err != nil {
+		httputil.InternalError(c, "failed to load custom field", err)
+		return
+	}
+	if existing != nil {
+		httputil.RespondWithConflict(c, fmt.Sprintf("custom field %q already exists", def.Key))
+		return
+	}
+	h.saveCustomField(c, cfs, def, http.StatusCreated)
+}
+
+// UpdateCustomField handles PUT /api/v1/metadata/custom-fields/:key.
+// Label, description and validation rules can change; the type cannot,
+// since stored values were validated against it.
+func (h *Handler) UpdateCustomField(c *gin.Context) {
+	var def database.CustomFieldDefinition
+	if err := c.ShouldBindJSON(&def); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	def.Key = c.Param("key")
+	cfs, ok := h.resolveCustomFieldStore(c)
+	if !ok {
+		return
+	}
+	existing, err := cfs.GetCustomField(def.Key)
+	if err != nil {
+		httputil.InternalError(c, "failed to load custom field", err)
+		return
+	}
+	if existing == nil {
+		httputil.RespondWithNotFound(c, "custom field", def.Key)
+		return
+	}
+	if def.Type == "" {
+		def.Type = existing.Type
+	}
+	if err := def.Normalize(); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if def.Type != existing.Type {
+		httputil.RespondWithBadRequest(c, "type cannot be changed; delete and recreate the field")
+		return
+	}
+	h.saveCustomField(c, cfs, def, http.StatusOK)
+}
+
+func (h *Handler) saveCustomField(c *gin.Context, cfs database.CustomFieldStore, def database.CustomFieldDefinition, status int) {
+	if err := cfs.SaveCustomField(def); err != nil {
+		httputil.InternalError(c, "failed to save custom field", err)
+		return
+	}
+	saved, err := cfs.GetCustomField(def.Key)
+	if err != nil || saved == nil {
+		httputil.InternalError(c, "failed to reload custom field", err)
+		return
+	}
+	httputil.RespondWithSuccess(c, status, saved)
+}
+
+// DeleteCustomField handles DELETE /api/v1/metadata/custom-fields/:key,
+// removing the definition and every book's value for it.
+func (h *Handler) DeleteCustomField(c *gin.Context) {
+	key := c.Param("key")
+	cfs, ok := h.resolveCustomFieldStore(c)
+	if !ok {
+		return
+	}
+	existing, err := cfs.GetCustomField(key)
+	if err != nil {
+		httputil.InternalError(c, "failed to load custom field", err)
+		return
+	}
+	if existing == nil {
+		httputil.RespondWithNotFound(c, "custom field", key)
+		return
+	}
+	removed, err := cfs.DeleteCustomField(key)
+	if err != nil {
+		httputil.InternalError(c, "failed to delete custom field", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"key": key, "values_removed": removed})
+}
+
+// GetBookCustomFields handles GET /api/v1/audiobooks/:id/custom-fields.
+// Values of fields that have since been deleted are left out.
+func (h *Handler) GetBookCustomFields(c *gin.Context) {
+	id := c.Param("id")
+	cfs, ok := h.resolveCustomFieldStore(c)
+	if !ok {
+		return
+	}
+	if !h.bookExists(c, id) {
+		return
+	}
+	defs, values, err := loadBookCustomFields(cfs, id)
+	if err != nil {
+		httputil.InternalError(c, "failed to load custom fields", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"book_id": id, "values": values, "fields": defs})
+}
+
+// updateBookCustomFieldsRequest carries the values to set. JSON strings,
+// numbers and booleans are accepted; null or "" clears the field. Fields
+// not mentioned are left alone.
+type updateBookCustomFieldsRequest struct {
+	Values map[string]json.RawMessage `json:"values" binding:"required"`
+}
+
+// UpdateBookCustomFields handles PUT /api/v1/audiobooks/:id/custom-fields.
+// Every value is validated before anything is written.
+func (h *Handler) UpdateBookCustomFields(c *gin.Context) {
+	id := c.Param("id")
+	var req updateBookCustomFieldsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	cfs, ok := h.resolveCustomFieldStore(c)
+	if !ok {
+		return
+	}
+	if !h.bookExists(c, id) {
+		return
+	}
+	defs, err := cfs.ListCustomFields()
+	if err != nil {
+		httputil.InternalError(c, "failed to list custom fields", err)
+		return
+	}
+	byKey := make(map[string]database.CustomFieldDefinition, len(defs))
+	for _, d := range defs {
+		byKey[d.Key] = d
+	}
+
+	keys := make([]string, 0, len(req.Values))
+	for k := range req.Values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	updates := make(map[string]string, len(keys))
+	for _, k := range keys {
+		def, known := byKey[k]
+		if !known {
+			httputil.RespondWithValidationError(c, k, "unknown custom field")
+			return
+		}
+		raw, err := customFieldRawValue(req.Values[k])
+		if err != nil {
+			httputil.RespondWithValidationError(c, k, err.Error())
+			return
+		}
+		v, err := def.NormalizeValue(raw)
+		if err != nil {
+			httputil.RespondWithBadRequest(c, err.Error())
+			return
+		}
+		updates[k] = v
+	}
+	if err := cfs.SetBookCustomFields(id, updates); err != nil {
+		httputil.InternalError(c, "failed to save custom fields", err)
+		return
+	}
+	_, values, err := loadBookCustomFields(cfs, id)
+	if err != nil {
+		httputil.InternalError(c, "failed to load custom fields", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"book_id": id, "values": values})
+}
+
+// bookExists writes a 404 (or 500) and returns false unless id is a book.
+func (h *Handler) bookExists(c *gin.Context, id string) bool {
+	book, err := h.resolveStore().GetBookByID(id)
+	if err != nil {
+		httputil.InternalError(c, "failed to load audiobook", err)
+		return false
+	}
+	if book == nil {
+		httputil.RespondWithNotFound(c, "audiobook", id)
+		return false
+	}
+	return true
+}
+
+// loadBookCustomFields returns the field definitions and the book's values
+// for the fields that are still defined.
+func loadBookCustomFields(cfs database.CustomFieldStore, bookID string) ([]database.CustomFieldDefinition, map[string]string, error) {
+	defs, err := cfs.ListCustomFields()
+	if err != nil {
+		return nil, nil, err
+	}
+	stored, err := cfs.GetBookCustomFields(bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make(map[string]string, len(stored))
+	for _, d := range defs {
+		if v, ok := stored[d.Key]; ok {
+			values[d.Key] = v
+		}
+	}
+	return defs, values, nil
+}
+
+// customFieldRawValue turns a JSON scalar into the string NormalizeValue
+// expects; null becomes "" (clear).
+func customFieldRawValue(raw json.RawMessage) (string, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", fmt.Errorf("invalid JSON value")
+	}
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("must be a string, number, boolean or null")
+	}
+}
+
+// addCustomFieldsToExport attaches each exported book's custom field values
+// (as "custom_fields") and the field definitions to a metadata export.
+func addCustomFieldsToExport(exportData map[string]interface{}, cfs database.CustomFieldStore) error {
+	defs, err := cfs.ListCustomFields()
+	if err != nil {
+		return err
+	}
+	exportData["custom_field_definitions"] = defs
+	if len(defs) == 0 {
+		return nil
+	}
+	all, err := cfs.GetAllBookCustomFields()
+	if err != nil {
+		return err
+	}
+	books, _ := exportData["books"].([]map[string]interface{})
+	for _, b := range books {
+		id, _ := b["id"].(string)
+		values := map[string]string{}
+		for _, d := range defs {
+			if v, ok := all[id][d.Key]; ok {
+				values[d.Key] = v
+			}
+		}
+		b["custom_fields"] = values
+	}
+	return nil
+}
+
+// customFieldDescriptors describes the defined custom fields in the shape
+// GET /metadata/fields uses for the built-in ones.
+func customFieldDescriptors(defs []database.CustomFieldDefinition) []map[string]any {
+	out := make([]map[string]any, 0, len(defs))
+	for _, d := range defs {
+		f := map[string]any{
+			"name":        d.Key,
+			"label":       d.Label,
+			"type":        d.Type,
+			"required":    false,
+			"custom":      true,
+			"description": d.Description,
+		}
+		if len(d.Options) > 0 {
+			f["options"] = d.Options
+		}
+		if d.Pattern != "" {
+			f["pattern"] = d.Pattern
+		}
+		if d.Min != nil {
+			f["min"] = *d.Min
+		}
+		if d.Max != nil {
+			f["max"] = *d.Max
+		}
+		out = append(out, f)
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+// file: internal/server/handlers/metadata/custom_fields_test.go
+// version: 1.0.0
+// guid: 65b9759c-6a1d-4397-8beb-61aa026cf687
+// last-edited: 2026-10-16
+
+package metadatahandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	metadatahandler "github.com/falkcorp/audiobook-organizer/internal/server/handlers/metadata"
+	metadatamocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/metadata/mocks"
+)
+
+// customFieldStore adds an in-memory database.CustomFieldStore to the
+// generated store mock.
+type customFieldStore struct {
+	*metadatamocks.MockMetadataStore
+	defs   map[string]database.CustomFieldDefinition
+	values map[string]map[string]string
+}
+
+func (s *customFieldStore) ListCustomFields() ([]database.CustomFieldDefinition, error) {
+	out := []database.CustomFieldDefinition{}
+	for _, d := range s.defs {
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (s *customFieldStore) GetCustomField(key string) (*database.CustomFieldDefinition, error) {
+	if d, ok := s.defs[key]; ok {
+		return &d, nil
+	}
+	return nil, nil
+}
+
+func (s *customFieldStore) SaveCustomField(def database.CustomFieldDefinition) error {
+	if err := def.Normalize(); err != nil {
+		return err
+	}
+	s.defs[def.Key] = def
+	return nil
+}
+
+func (s *customFieldStore) DeleteCustomField(key string) (int, error) {
+	delete(s.defs, key)
+	n := 0
+	for _, vals := range s.values {
+		if _, ok := vals[key]; ok {
+			delete(vals, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *customFieldStore) GetBookCustomFields(bookID string) (map[string]string, error) {
+	out := map[string]string{}
+	for k, v := range s.values[bookID] {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (s *customFieldStore) SetBookCustomFields(bookID string, values map[string]string) error {
+	if s.values[bookID] == nil {
+		s.values[bookID] = map[string]string{}
+	}
+	for k, v := range values {
+		if v == "" {
+			delete(s.values[bookID], k)
+		} else {
+			s.values[bookID][k] = v
+		}
+	}
+	return nil
+}
+
+func (s *customFieldStore) GetAllBookCustomFields() (map[string]map[string]string, error) {
+	return s.values, nil
+}
+
+func newCustomFieldHandler(t *testing.T) (*metadatahandler.Handler, *customFieldStore) {
+	t.Helper()
+	store := &customFieldStore{
+		MockMetadataStore: metadatamocks.NewMockMetadataStore(t),
+		defs:              map[string]database.CustomFieldDefinition{},
+		values:            map[string]map[string]string{},
+	}
+	h := metadatahandler.New(
+		func() metadatahandler.MetadataStore { return store },
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+	return h, store
+}
+
+func keyParam(key string) gin.Params { return gin.Params{{Key: "key", Value: key}} }
+
+func TestCustomFieldDefinitions(t *testing.T) {
+	h, store := newCustomFieldHandler(t)
+
+	w := doReq(h.CreateCustomField, http.MethodPost, "/metadata/custom-fields",
+		map[string]any{"key": "source", "label": "Source", "type": "select", "options": []string{"Audible", "CD"}}, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = doReq(h.CreateCustomField, http.MethodPost, "/metadata/custom-fields",
+		map[string]any{"key": "source"}, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = doReq(h.CreateCustomField, http.MethodPost, "/metadata/custom-fields",
+		map[string]any{"key": "Purchase Date", "type": "date"}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = doReq(h.UpdateCustomField, http.MethodPut, "/metadata/custom-fields/source",
+		map[string]any{"label": "Bought from", "options": []string{"Audible", "CD", "Libro.fm"}}, keyParam("source"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "Bought from", store.defs["source"].Label)
+	assert.Len(t, store.defs["source"].Options, 3)
+
+	w = doReq(h.UpdateCustomField, http.MethodPut, "/metadata/custom-fields/source",
+		map[string]any{"type": "text"}, keyParam("source"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = doReq(h.UpdateCustomField, http.MethodPut, "/metadata/custom-fields/nope",
+		map[string]any{"label": "x"}, keyParam("nope"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doReq(h.ListCustomFields, http.MethodGet, "/metadata/custom-fields", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data struct {
+			Fields []database.CustomFieldDefinition `json:"fields"`
+			Count  int                              `json:"count"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.Data.Count)
+
+	store.values["b1"] = map[string]string{"source": "CD"}
+	w = doReq(h.DeleteCustomField, http.MethodDelete, "/metadata/custom-fields/source", nil, keyParam("source"))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"values_removed":1`)
+	assert.Empty(t, store.defs)
+}
+
+func TestBookCustomFields(t *testing.T) {
+	h, store := newCustomFieldHandler(t)
+	store.defs["source"] = database.CustomFieldDefinition{Key: "source", Type: database.CustomFieldSelect, Options: []string{"Audible", "CD"}}
+	store.defs["drm_free"] = database.CustomFieldDefinition{Key: "drm_free", Type: database.CustomFieldBoolean}
+	store.defs["price"] = database.CustomFieldDefinition{Key: "price", Type: database.CustomFieldNumber}
+	store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1"}, nil)
+	store.EXPECT().GetBookByID("missing").Return(nil, nil)
+
+	w := doReq(h.UpdateBookCustomFields, http.MethodPut, "/audiobooks/b1/custom-fields",
+		map[string]any{"values": map[string]any{"source": "audible", "drm_free": true, "price": 12.5}}, idParam("b1"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, map[string]string{"source": "Audible", "drm_free": "true", "price": "12.5"}, store.values["b1"])
+
+	// Invalid values and unknown keys are rejected without writing anything.
+	for _, body := range []map[string]any{
+		{"source": "Tape", "price": 1},
+		{"shelf": "A3"},
+		{"price": []int{1}},
+	} {
+		w = doReq(h.UpdateBookCustomFields, http.MethodPut, "/audiobooks/b1/custom-fields",
+			map[string]any{"values": body}, idParam("b1"))
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%v", body)
+	}
+	assert.Equal(t, "12.5", store.values["b1"]["price"])
+
+	w = doReq(h.UpdateBookCustomFields, http.MethodPut, "/audiobooks/b1/custom-fields",
+		map[string]any{"values": map[string]any{"drm_free": nil}}, idParam("b1"))
+	require.Equal(t, http.StatusOK, w.Code)
+	_, stillSet := store.values["b1"]["drm_free"]
+	assert.False(t, stillSet)
+
+	w = doReq(h.GetBookCustomFields, http.MethodGet, "/audiobooks/b1/custom-fields", nil, idParam("b1"))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Values map[string]string `json:"values"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, map[string]string{"source": "Audible", "price": "12.5"}, resp.Data.Values)
+
+	w = doReq(h.GetBookCustomFields, http.MethodGet, "/audiobooks/missing/custom-fields", nil, idParam("missing"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestExportMetadata_IncludesCustomFields(t *testing.T) {
+	h, store := newCustomFieldHandler(t)
+	store.defs["source"] = database.CustomFieldDefinition{Key: "source", Type: database.CustomFieldText}
+	store.values["b1"] = map[string]string{"source": "Audible"}
+	store.EXPECT().GetAllBooks(0, 0).Return([]database.Book{{ID: "b1", Title: "T"}, {ID: "b2", Title: "U"}}, nil)
+
+	w := doReq(h.ExportMetadata, http.MethodGet, "/metadata/export", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Books []struct {
+				ID           string            `json:"id"`
+				CustomFields map[string]string `json:"custom_fields"`
+			} `json:"books"`
+			Definitions []database.CustomFieldDefinition `json:"custom_field_definitions"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Books, 2)
+	assert.Equal(t, map[string]string{"source": "Audible"}, resp.Data.Books[0].CustomFields)
+	assert.Empty(t, resp.Data.Books[1].CustomFields)
+	assert.Len(t, resp.Data.Definitions, 1)
+
+	w = doReq(h.GetMetadataFields, http.MethodGet, "/metadata/fields", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"custom":true`)
+}
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/metadata/handler.go
-// version: 1.0.0
+// version: 1.4.0
 // guid: 54bb4ad0-cab0-41fc-b9cb-557c96beee44
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Package metadatahandler hosts the metadata-domain HTTP handlers extracted
 // from the server package's metadata_handlers.go: batch-update / validate /
@@ -10,7 +10,8 @@
 // prune, write-back, bulk fetch + bulk write-back enqueue, batch write-back
 // enqueue, the field-enumeration endpoint, and the rating PATCH (19 handlers
 // total). Behavior is preserved byte-for-byte (status codes, JSON shapes, error
-// strings, cache keys, op-enqueue payloads).
+// strings, cache keys, op-enqueue payloads). The custom metadata field
+// endpoints (definitions and per-book values) live in custom_fields.go.
 //
 // The package is named metadatahandler (dir handlers/metadata) to avoid
 // clashing with the existing internal/metadata package (imported here as
@@ -299,7 +300,7 @@ func (h *Handler) exportMetadataImpl(c *gin.Context) {
 	}
 
 	// Get all books
-	books, err := store.GetAllBooks(0, 0) // No limit/offset
+	books, err := database.GetAllBooksContext(c.Request.Context(), store, 0, 0)
 	if err != nil {
 		httputil.InternalError(c, "failed to get audiobooks", err)
 		return
@@ -311,6 +312,12 @@ func (h *Handler) exportMetadataImpl(c *gin.Context) {
 		httputil.InternalError(c, "failed to export metadata", err)
 		return
 	}
+	if cfs, ok := database.AsCustomFieldStore(store); ok {
+		if err := addCustomFieldsToExport(exportData, cfs); err != nil {
+			httputil.InternalError(c, "failed to export custom fields", err)
+			return
+		}
+	}
 
 	httputil.RespondWithOK(c, exportData)
 }
@@ -416,11 +423,15 @@ func (h *Handler) fetchAudiobookMetadataImpl(c *gin.Context) {
 	if fresh, err := store.GetBookByID(id); err == nil && fresh != nil {
 		enrichedBook = fresh
 	}
-	httputil.RespondWithOK(c, gin.H{
+	body := gin.H{
 		"message": resp.Message,
 		"book":    h.enrichBook(enrichedBook),
 		"source":  resp.Source,
-	})
+	}
+	if resp.FieldSources != nil {
+		body["field_sources"] = resp.FieldSources
+	}
+	httputil.RespondWithOK(c, body)
 }
 
 // searchAudiobookMetadata handles POST /api/v1/audiobooks/:id/search-metadata.
@@ -1012,6 +1023,7 @@ func (h *Handler) bulkFetchMetadataImpl(c *gin.Context) {
 			if shouldApply("duration", hasBookValue("duration")) {
 				dur := meta.DurationSec
 				book.Duration = &dur
+				book.DurationEstimated = nil
 				appliedFields = append(appliedFields, "duration")
 				didUpdate = true
 			}
@@ -1321,6 +1333,15 @@ func (h *Handler) getMetadataFieldsImpl(c *gin.Context) {
 		},
 	}
 
+	// Admin-defined custom fields follow the built-in ones.
+	if store := h.resolveStore(); store != nil {
+		if cfs, ok := database.AsCustomFieldStore(store); ok {
+			if defs, err := cfs.ListCustomFields(); err == nil {
+				fields = append(fields, customFieldDescriptors(defs)...)
+			}
+		}
+	}
+
 	httputil.RespondWithOK(c, gin.H{
 		"fields": fields,
 	})
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/metadata/interfaces.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b1ab2e4a-1f73-42f2-955d-c4a30f0fbaac
-// last-edited: 2026-06-03
+// last-edited: 2026-10-16
 
 // Narrow dependency interfaces for the metadata-domain HTTP handlers (the 19
 // per-book + library metadata endpoints extracted from the server package's
@@ -97,6 +97,7 @@ type MetadataFetchService interface {
 	ApplyMetadataCandidate(id string, candidate metafetch.MetadataCandidate, fields []string) (*metafetch.FetchMetadataResponse, error)
 	ApplyMetadataFileIO(id string)
 	WriteBackMetadataForBook(id string, segmentFilter ...[]string) (int, error)
+	WriteTagsForBook(id string, dryRun bool) (*metafetch.TagWriteResult, error)
 	MarkNoMatch(id string) error
 	RunApplyPipelineRenameOnly(id string, book *database.Book) error
 	RecordChangeHistory(book *database.Book, meta metadata.BookMetadata, sourceName string)
@@ -811,3 +811,71 @@ func (_c *MockMetadataFetchService_WriteBackMetadataForBook_Call) RunAndReturn(r
 	_c.Call.Return(run)
 	return _c
 }
+
+// WriteTagsForBook provides a mock function for the type MockMetadataFetchService
+func (_mock *MockMetadataFetchService) WriteTagsForBook(id string, dryRun bool) (*metafetch.TagWriteResult, error) {
+	ret := _mock.Called(id, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteTagsForBook")
+	}
+
+	var r0 *metafetch.TagWriteResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, bool) (*metafetch.TagWriteResult, error)); ok {
+		return returnFunc(id, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, bool) *metafetch.TagWriteResult); ok {
+		r0 = returnFunc(id, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*metafetch.TagWriteResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = returnFunc(id, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMetadataFetchService_WriteTagsForBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteTagsForBook'
+type MockMetadataFetchService_WriteTagsForBook_Call struct {
+	*mock.Call
+}
+
+// WriteTagsForBook is a helper method to define mock.On call
+//   - id string
+//   - dryRun bool
+func (_e *MockMetadataFetchService_Expecter) WriteTagsForBook(id interface{}, dryRun interface{}) *MockMetadataFetchService_WriteTagsForBook_Call {
+	return &MockMetadataFetchService_WriteTagsForBook_Call{Call: _e.mock.On("WriteTagsForBook", id, dryRun)}
+}
+
+func (_c *MockMetadataFetchService_WriteTagsForBook_Call) Run(run func(id string, dryRun bool)) *MockMetadataFetchService_WriteTagsForBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetadataFetchService_WriteTagsForBook_Call) Return(tagWriteResult *metafetch.TagWriteResult, err error) *MockMetadataFetchService_WriteTagsForBook_Call {
+	_c.Call.Return(tagWriteResult, err)
+	return _c
+}
+
+func (_c *MockMetadataFetchService_WriteTagsForBook_Call) RunAndReturn(run func(id string, dryRun bool) (*metafetch.TagWriteResult, error)) *MockMetadataFetchService_WriteTagsForBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,107 @@
+// file: internal/server/handlers/metadata/write_tags.go
+// version: 1.0.0
+// guid: 1f7c3e95-a2d4-4b80-9e6c-58d0b4a7f213
+// last-edited: 2026-10-16
+
+package metadatahandler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/metafetch"
+)
+
+// maxBatchWriteTags bounds POST /audiobooks/batch-write-tags, which runs
+// inline; larger sets go through batch-write-back.
+const maxBatchWriteTags = 100
+
+// WriteTags handles POST /api/v1/audiobooks/:id/write-tags: write the
+// book's title, author, narrator and series into its files' tags.
+//
+// Body (optional):
+//   - dry_run: report the changes without writing.
+func (h *Handler) WriteTags(c *gin.Context) {
+	id := c.Param("id")
+	var body struct {
+		DryRun bool `json:"dry_run"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			httputil.RespondWithBadRequest(c, err.Error())
+			return
+		}
+	}
+	store := h.resolveStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	if book, err := store.GetBookByID(id); err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "audiobook", id)
+		return
+	}
+	result, err := h.metadataFetchService.WriteTagsForBook(id, body.DryRun)
+	if err != nil {
+		httputil.InternalError(c, "failed to write tags", err)
+		return
+	}
+	httputil.RespondWithOK(c, result)
+}
+
+// BatchWriteTags handles POST /api/v1/audiobooks/batch-write-tags: the
+// WriteTags of each of book_ids (at most 100), in order. A book that
+// can't be found or written gets an error entry; the rest still run.
+//
+// Body:
+//   - book_ids: books to write.
+//   - dry_run: report the changes without writing.
+func (h *Handler) BatchWriteTags(c *gin.Context) {
+	var body struct {
+		BookIDs []string `json:"book_ids"`
+		DryRun  bool     `json:"dry_run"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if len(body.BookIDs) == 0 {
+		httputil.RespondWithValidationError(c, "book_ids", "is required")
+		return
+	}
+	if len(body.BookIDs) > maxBatchWriteTags {
+		httputil.RespondWithValidationError(c, "book_ids", fmt.Sprintf("at most %d per request; use batch-write-back for more", maxBatchWriteTags))
+		return
+	}
+
+	type bookResult struct {
+		*metafetch.TagWriteResult
+		BookID string `json:"book_id"`
+		Error  string `json:"error,omitempty"`
+	}
+	results := make([]bookResult, 0, len(body.BookIDs))
+	written, failed := 0, 0
+	for _, id := range body.BookIDs {
+		r, err := h.metadataFetchService.WriteTagsForBook(id, body.DryRun)
+		if err != nil {
+			failed++
+			results = append(results, bookResult{BookID: id, Error: err.Error()})
+			continue
+		}
+		written += r.Written
+		for _, f := range r.Files {
+			if f.Status == metafetch.TagWriteFailed {
+				failed++
+				break
+			}
+		}
+		results = append(results, bookResult{TagWriteResult: r, BookID: id})
+	}
+	httputil.RespondWithOK(c, gin.H{
+		"dry_run": body.DryRun,
+		"results": results,
+		"written": written,
+		"failed":  failed,
+	})
+}
@@ -0,0 +1,80 @@
+// file: internal/server/handlers/metadata/write_tags_test.go
+// version: 1.0.0
+// guid: 7b2d9e40-c6f1-4a35-8d17-e93a0f5c4b26
+// last-edited: 2026-10-16
+
+package metadatahandler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/metafetch"
+)
+
+func TestWriteTags(t *testing.T) {
+	h, d := newHandler(t)
+	d.store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1"}, nil)
+	d.store.EXPECT().GetBookByID("missing").Return(nil, nil)
+	d.mfs.EXPECT().WriteTagsForBook("b1", true).Return(&metafetch.TagWriteResult{
+		BookID: "b1", DryRun: true, Written: 1,
+		Files: []metafetch.FileTagWrite{{Path: "/lib/b1.m4b", Status: metafetch.TagWriteWouldWrite}},
+	}, nil)
+
+	w := doReq(h.WriteTags, http.MethodPost, "/audiobooks/b1/write-tags", map[string]any{"dry_run": true}, idParam("b1"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data metafetch.TagWriteResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Data.DryRun)
+	assert.Equal(t, metafetch.TagWriteWouldWrite, resp.Data.Files[0].Status)
+
+	w = doReq(h.WriteTags, http.MethodPost, "/audiobooks/missing/write-tags", nil, idParam("missing"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBatchWriteTags(t *testing.T) {
+	h, d := newHandler(t)
+	d.mfs.EXPECT().WriteTagsForBook("b1", false).Return(&metafetch.TagWriteResult{
+		BookID: "b1", Written: 2,
+		Files: []metafetch.FileTagWrite{{Status: metafetch.TagWriteWritten}, {Status: metafetch.TagWriteWritten}},
+	}, nil)
+	d.mfs.EXPECT().WriteTagsForBook("b2", false).Return(&metafetch.TagWriteResult{
+		BookID: "b2",
+		Files:  []metafetch.FileTagWrite{{Status: metafetch.TagWriteFailed, Error: "disk full"}},
+	}, nil)
+	d.mfs.EXPECT().WriteTagsForBook("gone", false).Return(nil, errors.New("audiobook not found: gone"))
+
+	w := doReq(h.BatchWriteTags, http.MethodPost, "/audiobooks/batch-write-tags",
+		map[string]any{"book_ids": []string{"b1", "b2", "gone"}}, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data struct {
+			Written int `json:"written"`
+			Failed  int `json:"failed"`
+			Results []struct {
+				BookID string `json:"book_id"`
+				Error  string `json:"error"`
+			} `json:"results"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Data.Written)
+	assert.Equal(t, 2, resp.Data.Failed)
+	require.Len(t, resp.Data.Results, 3)
+	assert.Equal(t, "gone", resp.Data.Results[2].BookID)
+	assert.NotEmpty(t, resp.Data.Results[2].Error)
+
+	// Validation: no ids, too many ids.
+	w = doReq(h.BatchWriteTags, http.MethodPost, "/audiobooks/batch-write-tags", map[string]any{"book_ids": []string{}}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = doReq(h.BatchWriteTags, http.MethodPost, "/audiobooks/batch-write-tags", map[string]any{"book_ids": make([]string, 101)}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
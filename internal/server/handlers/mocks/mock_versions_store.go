@@ -200,6 +200,74 @@ func (_c *MockVersionsStore_DeleteRaw_Call) RunAndReturn(run func(key string) er
 	return _c
 }
 
+// GetAllBooks provides a mock function for the type MockVersionsStore
+func (_mock *MockVersionsStore) GetAllBooks(limit int, offset int) ([]database.Book, error) {
+	ret := _mock.Called(limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBooks")
+	}
+
+	var r0 []database.Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]database.Book, error)); ok {
+		return returnFunc(limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []database.Book); ok {
+		r0 = returnFunc(limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVersionsStore_GetAllBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBooks'
+type MockVersionsStore_GetAllBooks_Call struct {
+	*mock.Call
+}
+
+// GetAllBooks is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+func (_e *MockVersionsStore_Expecter) GetAllBooks(limit interface{}, offset interface{}) *MockVersionsStore_GetAllBooks_Call {
+	return &MockVersionsStore_GetAllBooks_Call{Call: _e.mock.On("GetAllBooks", limit, offset)}
+}
+
+func (_c *MockVersionsStore_GetAllBooks_Call) Run(run func(limit int, offset int)) *MockVersionsStore_GetAllBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVersionsStore_GetAllBooks_Call) Return(books []database.Book, err error) *MockVersionsStore_GetAllBooks_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockVersionsStore_GetAllBooks_Call) RunAndReturn(run func(limit int, offset int) ([]database.Book, error)) *MockVersionsStore_GetAllBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBookAuthors provides a mock function for the type MockVersionsStore
 func (_mock *MockVersionsStore) GetBookAuthors(bookID string) ([]database.BookAuthor, error) {
 	ret := _mock.Called(bookID)
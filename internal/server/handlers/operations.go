@@ -1,11 +1,14 @@
 // file: internal/server/handlers/operations.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: e5f6a7b8-c9d0-1234-efab-234567890123
-// last-edited: 2026-06-01
+// last-edited: 2026-10-17
 
 package handlers
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // MaintenanceWindowConfigReq is the JSON body for the maintenance window config endpoint.
 type MaintenanceWindowConfigReq struct {
@@ -38,6 +41,9 @@ type OperationV2Response struct {
 	ResumeCount     int        `json:"resume_count"`
 	TraceID         string     `json:"trace_id"`
 	SpanID          string     `json:"span_id"`
+	// Params are the parameters the operation was enqueued with, as
+	// validated against its def's params schema.
+	Params json.RawMessage `json:"params,omitempty"`
 }
 
 // OpLogV2Response is the JSON shape for a single operation log line.
@@ -60,4 +66,8 @@ type OpDefResponse struct {
 	ResumePolicy string   `json:"resume_policy"`
 	Triggers     []string `json:"triggers"`
 	DependsOn    []string `json:"depends_on"`
+	Pool         string   `json:"pool,omitempty"` // worker pool, if any
+	// ParamsSchema is the JSON Schema params must satisfy; omitted for
+	// defs that accept anything.
+	ParamsSchema json.RawMessage `json:"params_schema,omitempty"`
 }
@@ -0,0 +1,67 @@
+// file: internal/server/handlers/operations/archive.go
+// version: 1.0.0
+// guid: c6877c92-46f4-44b8-a6d5-c1b19b187c63
+// last-edited: 2026-10-16
+
+package operations
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// archiveStore resolves the store's operation archive, looking through the
+// indexed wrapper. Returns nil when the store has none.
+func (h *Handler) archiveStore() database.OperationArchiveStore {
+	if archive, ok := h.store.(database.OperationArchiveStore); ok {
+		return archive
+	}
+	if uw, ok := h.store.(interface{ Unwrap() database.Store }); ok {
+		if archive, ok := uw.Unwrap().(database.OperationArchiveStore); ok {
+			return archive
+		}
+	}
+	return nil
+}
+
+// ListArchivedOperations implements GET /operations/archive: archived
+// operations newest first, paged by limit/offset and optionally filtered
+// by ?type=.
+func (h *Handler) ListArchivedOperations(c *gin.Context) {
+	params := httputil.ParsePaginationParams(c)
+	archive := h.archiveStore()
+	if archive == nil {
+		httputil.RespondWithOK(c, gin.H{"items": []database.ArchivedOperation{}, "total": 0, "limit": params.Limit, "offset": params.Offset})
+		return
+	}
+	items, total, err := archive.ListArchivedOperations(strings.TrimSpace(c.Query("type")), params.Limit, params.Offset)
+	if err != nil {
+		httputil.InternalError(c, "failed to list archived operations", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"items": items, "total": total, "limit": params.Limit, "offset": params.Offset})
+}
+
+// GetArchivedOperation implements GET /operations/archive/:id, returning
+// the archived record with its log lines.
+func (h *Handler) GetArchivedOperation(c *gin.Context) {
+	id := c.Param("id")
+	archive := h.archiveStore()
+	if archive == nil {
+		httputil.RespondWithNotFound(c, "archived operation", id)
+		return
+	}
+	rec, err := archive.GetArchivedOperation(id)
+	if err != nil {
+		httputil.InternalError(c, "failed to get archived operation", err)
+		return
+	}
+	if rec == nil {
+		httputil.RespondWithNotFound(c, "archived operation", id)
+		return
+	}
+	httputil.RespondWithOK(c, rec)
+}
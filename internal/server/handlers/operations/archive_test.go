@@ -0,0 +1,122 @@
+// file: internal/server/handlers/operations/archive_test.go
+// version: 1.1.0
+// guid: ffe27419-477b-4c28-92d8-c66ec4e0aa17
+// last-edited: 2026-10-16
+
+package operations_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/operations"
+	operationsmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/operations/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveOpsStore adds an operation archive to the mocked store.
+type archiveOpsStore struct {
+	*operationsmocks.MockOperationsStore
+	archived []database.ArchivedOperation
+}
+
+func (s *archiveOpsStore) ArchiveOperationsBefore(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func (s *archiveOpsStore) ListArchivedOperations(opType string, limit, offset int) ([]database.ArchivedOperation, int, error) {
+	var out []database.ArchivedOperation
+	for _, rec := range s.archived {
+		if opType == "" || rec.Type == opType {
+			rec.Logs = nil
+			out = append(out, rec)
+		}
+	}
+	total := len(out)
+	out = out[min(offset, total):min(offset+limit, total)]
+	return out, total, nil
+}
+
+func (s *archiveOpsStore) GetArchivedOperation(id string) (*database.ArchivedOperation, error) {
+	for i := range s.archived {
+		if s.archived[i].ID == id {
+			return &s.archived[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *archiveOpsStore) PruneArchivedOperations(time.Time) (int, error) { return 0, nil }
+
+func newArchiveHandler(t *testing.T) *operations.Handler {
+	t.Helper()
+	store := &archiveOpsStore{
+		MockOperationsStore: operationsmocks.NewMockOperationsStore(t),
+		archived: []database.ArchivedOperation{
+			{Operation: database.Operation{ID: "a2", Type: "scan", Status: "completed"}, LogCount: 1,
+				Logs: []database.OperationLog{{OperationID: "a2", Level: "info", Message: "done"}}},
+			{Operation: database.Operation{ID: "a1", Type: "organize", Status: "failed"}},
+		},
+	}
+	return operations.New(store, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+}
+
+func TestListArchivedOperations(t *testing.T) {
+	h := newArchiveHandler(t)
+	get := func(target string) map[string]any {
+		w := run(http.MethodGet, "/operations/archive", target, nil, func(r *gin.Engine) {
+			r.GET("/operations/archive", h.ListArchivedOperations)
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp["data"].(map[string]any)
+	}
+
+	data := get("/operations/archive")
+	assert.EqualValues(t, 2, data["total"])
+	items := data["items"].([]any)
+	require.Len(t, items, 2)
+	assert.Equal(t, "a2", items[0].(map[string]any)["id"])
+	assert.Nil(t, items[0].(map[string]any)["logs"])
+
+	data = get("/operations/archive?type=organize")
+	assert.EqualValues(t, 1, data["total"])
+}
+
+func TestListArchivedOperations_NoArchive(t *testing.T) {
+	h, _, _, _, _, _ := newTestHandler(t)
+	w := run(http.MethodGet, "/operations/archive", "/operations/archive", nil, func(r *gin.Engine) {
+		r.GET("/operations/archive", h.ListArchivedOperations)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":0`)
+}
+
+func TestGetArchivedOperation(t *testing.T) {
+	h := newArchiveHandler(t)
+	get := func(id string) *http.Response {
+		w := run(http.MethodGet, "/operations/archive/:id", "/operations/archive/"+id, nil, func(r *gin.Engine) {
+			r.GET("/operations/archive/:id", h.GetArchivedOperation)
+		})
+		return w.Result()
+	}
+
+	resp := get("a2")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var body struct {
+		Data database.ArchivedOperation `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "scan", body.Data.Type)
+	require.Len(t, body.Data.Logs, 1)
+	assert.Equal(t, "done", body.Data.Logs[0].Message)
+
+	assert.Equal(t, http.StatusNotFound, get("missing").StatusCode)
+}
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/operations/handler.go
-// version: 1.0.0
+// version: 1.11.0
 // guid: 1b7fbd86-cdda-4921-b2d0-786f5cadb438
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Package operations hosts the background-operation HTTP handlers extracted
 // from the server package: the long-running scan / organize / optimize /
@@ -24,8 +24,10 @@ package operations
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +36,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
 	"github.com/falkcorp/audiobook-organizer/internal/scheduler"
 	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
 	"github.com/falkcorp/audiobook-organizer/internal/sweep"
@@ -68,6 +71,13 @@ type Handler struct {
 	// revert wraps audiobooks.NewRevertService(s.Store()).RevertOperation(id).
 	// Same opaque-store rationale as preflightUndo.
 	revert func(id string) error
+
+	// appVersion returns the runtime app version for log exports. May be nil.
+	appVersion func() string
+
+	// predictScan forecasts a scan request's duration from the import
+	// paths' scan history for the StartScan response. May be nil.
+	predictScan func(folderPath *string, forceUpdate bool, profile string) *scanner.ScanPrediction
 }
 
 // New constructs an operations Handler from its dependencies. getScheduler is a
@@ -82,6 +92,8 @@ func New(
 	collectStale func(timeout time.Duration) ([]database.Operation, error),
 	preflightUndo func(id string) (*undo.UndoConflictReport, error),
 	revert func(id string) error,
+	appVersion func() string,
+	predictScan func(folderPath *string, forceUpdate bool, profile string) *scanner.ScanPrediction,
 ) *Handler {
 	return &Handler{
 		store:         store,
@@ -92,6 +104,8 @@ func New(
 		collectStale:  collectStale,
 		preflightUndo: preflightUndo,
 		revert:        revert,
+		appVersion:    appVersion,
+		predictScan:   predictScan,
 	}
 }
 
@@ -106,35 +120,59 @@ func (h *Handler) resolveScheduler() Scheduler {
 
 // --- Operation starters ---
 
-// StartScan implements POST /operations/scan.
+// StartScan implements POST /operations/scan. The body is library.scan's
+// params; EnqueueOp checks it against the op's params schema, so unknown
+// or invalid fields get a 400 listing each one, and the validated params
+// are stored on the operation record. The 202 response carries an
+// "estimate" forecast from earlier scans of the same folders when one can
+// be made.
 func (h *Handler) StartScan(c *gin.Context) {
 	if h.registry == nil {
 		httputil.RespondWithInternalError(c, "operations registry not initialized")
 		return
 	}
-	body, _ := c.GetRawData()
-	if len(body) == 0 {
-		body = []byte("{}")
+	body, ok := operationParamsBody(c)
+	if !ok {
+		return
 	}
 	opID, err := h.registry.EnqueueOp(c.Request.Context(), "library.scan", body)
+	if handlers.RespondWithParamsError(c, err) {
+		return
+	}
 	if err != nil {
 		httputil.InternalError(c, "enqueue failed", err)
 		return
 	}
-	c.JSON(202, gin.H{"op_id": opID, "id": opID})
+	resp := gin.H{"op_id": opID, "id": opID}
+	if h.predictScan != nil {
+		var req struct {
+			FolderPath  *string `json:"folder_path"`
+			ForceUpdate bool    `json:"force_update"`
+			Profile     string  `json:"profile"`
+		}
+		_ = json.Unmarshal(body, &req)
+		if pred := h.predictScan(req.FolderPath, req.ForceUpdate, req.Profile); pred != nil {
+			resp["estimate"] = pred
+		}
+	}
+	c.JSON(202, resp)
 }
 
-// StartOrganize implements POST /operations/organize.
+// StartOrganize implements POST /operations/organize. Like StartScan, the
+// body is validated against library.organize's params schema at enqueue.
 func (h *Handler) StartOrganize(c *gin.Context) {
 	if h.registry == nil {
 		httputil.RespondWithInternalError(c, "operations registry not initialized")
 		return
 	}
-	body, _ := c.GetRawData()
-	if len(body) == 0 {
-		body = []byte("{}")
+	body, ok := operationParamsBody(c)
+	if !ok {
+		return
 	}
 	opID, err := h.registry.EnqueueOp(c.Request.Context(), "library.organize", body)
+	if handlers.RespondWithParamsError(c, err) {
+		return
+	}
 	if err != nil {
 		httputil.InternalError(c, "enqueue failed", err)
 		return
@@ -142,6 +180,20 @@ func (h *Handler) StartOrganize(c *gin.Context) {
 	c.JSON(202, gin.H{"op_id": opID, "id": opID})
 }
 
+// operationParamsBody reads the request body as operation params. An
+// empty body means no params; anything else must be a JSON object.
+func operationParamsBody(c *gin.Context) (json.RawMessage, bool) {
+	body, _ := c.GetRawData()
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return json.RawMessage("{}"), true
+	}
+	if !json.Valid(body) {
+		httputil.RespondWithBadRequest(c, "invalid request body")
+		return nil, false
+	}
+	return json.RawMessage(body), true
+}
+
 // StartOptimize implements POST /operations/optimize.
 func (h *Handler) StartOptimize(c *gin.Context) {
 	if h.registry == nil {
@@ -156,7 +208,24 @@ func (h *Handler) StartOptimize(c *gin.Context) {
 	c.JSON(202, gin.H{"op_id": opID, "id": opID})
 }
 
-// StartTranscode implements POST /operations/transcode.
+// StartRecalculateSizes implements POST /system/recalculate-sizes. It
+// queues library.size-refresh, which drops the cached library sizes and
+// stats and recomputes them in the background.
+func (h *Handler) StartRecalculateSizes(c *gin.Context) {
+	if h.registry == nil {
+		httputil.RespondWithInternalError(c, "operations registry not initialized")
+		return
+	}
+	opID, err := h.registry.EnqueueOp(c.Request.Context(), "library.size-refresh", nil)
+	if err != nil {
+		httputil.InternalError(c, "enqueue failed", err)
+		return
+	}
+	c.JSON(202, gin.H{"op_id": opID, "id": opID})
+}
+
+// StartTranscode implements POST /operations/transcode. Like StartScan,
+// the body is checked against library.transcode's params schema.
 func (h *Handler) StartTranscode(c *gin.Context) {
 	if h.registry == nil {
 		httputil.RespondWithInternalError(c, "operations registry not initialized")
@@ -170,7 +239,10 @@ func (h *Handler) StartTranscode(c *gin.Context) {
 		httputil.RespondWithBadRequest(c, "book_id is required")
 		return
 	}
-	opID, err := h.registry.EnqueueOp(c.Request.Context(), "library.transcode", body)
+	opID, err := h.registry.EnqueueOp(c.Request.Context(), "library.transcode", json.RawMessage(body))
+	if handlers.RespondWithParamsError(c, err) {
+		return
+	}
 	if err != nil {
 		httputil.InternalError(c, "enqueue failed", err)
 		return
@@ -284,9 +356,22 @@ func (h *Handler) ClearStaleOperations(c *gin.Context) {
 	httputil.RespondWithOK(c, gin.H{"cleared": cleared})
 }
 
-// DeleteOperationHistory deletes operations matching the given status(es).
-// Query param: ?status=completed or ?status=failed or ?status=completed,failed
+// allOperations is a ListOperations limit that returns every operation.
+const allOperations = 1<<31 - 1
+
+// terminalOperationStatuses are the statuses DeleteOperationHistory may
+// delete.
+var terminalOperationStatuses = []string{"completed", "failed", "canceled"}
+
+// DeleteOperationHistory deletes finished operations.
+// Query: status (completed, failed, canceled or a comma-separated list) and
+// older_than (an age such as 30d or 12h). At least one is required.
 // Implements DELETE /operations/history.
+//
+// With status alone every matching operation is deleted. With older_than,
+// only operations created before that age are, together with their log
+// lines and the registry operations that finished before it; status then
+// defaults to all three.
 func (h *Handler) DeleteOperationHistory(c *gin.Context) {
 	if h.store == nil {
 		httputil.RespondWithInternalError(c, "database not initialized")
@@ -294,28 +379,117 @@ func (h *Handler) DeleteOperationHistory(c *gin.Context) {
 	}
 
 	statusParam := c.Query("status")
-	if statusParam == "" {
-		httputil.RespondWithBadRequest(c, "status parameter required")
+	olderThan := c.Query("older_than")
+	if statusParam == "" && olderThan == "" {
+		httputil.RespondWithBadRequest(c, "status or older_than parameter required")
 		return
 	}
 
-	statuses := strings.Split(statusParam, ",")
+	statuses := terminalOperationStatuses
+	if statusParam != "" {
+		statuses = strings.Split(statusParam, ",")
+	}
 	// Only allow deleting terminal statuses
-	allowed := map[string]bool{"completed": true, "failed": true, "canceled": true}
 	for _, st := range statuses {
-		if !allowed[st] {
+		if !slices.Contains(terminalOperationStatuses, st) {
 			httputil.RespondWithBadRequest(c, fmt.Sprintf("cannot delete operations with status %q", st))
 			return
 		}
 	}
 
-	deleted, err := h.store.DeleteOperationsByStatus(statuses)
+	if olderThan == "" {
+		deleted, err := h.store.DeleteOperationsByStatus(statuses)
+		if err != nil {
+			httputil.InternalError(c, "failed to delete operations", err)
+			return
+		}
+		httputil.RespondWithOK(c, gin.H{"deleted": deleted})
+		return
+	}
+
+	age, err := parseAge(olderThan)
+	if err != nil {
+		httputil.RespondWithValidationError(c, "older_than", err.Error())
+		return
+	}
+	cutoff := time.Now().Add(-age)
+	deleted, err := h.deleteOperationsBefore(c.Request.Context(), cutoff, statuses)
 	if err != nil {
 		httputil.InternalError(c, "failed to delete operations", err)
 		return
 	}
+	registryDeleted := 0
+	if pruner := h.operationV2Pruner(); pruner != nil {
+		registryDeleted, err = pruner.PruneOperationsV2(c.Request.Context(), cutoff, statuses)
+		if err != nil {
+			httputil.InternalError(c, "failed to delete registry operations", err)
+			return
+		}
+	}
 
-	httputil.RespondWithOK(c, gin.H{"deleted": deleted})
+	httputil.RespondWithOK(c, gin.H{
+		"deleted":          deleted + registryDeleted,
+		"operations":       deleted,
+		"registry_deleted": registryDeleted,
+		"cutoff":           cutoff.UTC(),
+	})
+}
+
+// deleteOperationsBefore deletes operations in statuses created before
+// cutoff, with their log lines.
+func (h *Handler) deleteOperationsBefore(ctx context.Context, cutoff time.Time, statuses []string) (int, error) {
+	ops, _, err := database.ListOperationsContext(ctx, h.store, allOperations, 0)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, op := range ops {
+		if !slices.Contains(statuses, op.Status) || !op.CreatedAt.Before(cutoff) {
+			continue
+		}
+		if err := h.store.DeleteOperationWithLogs(op.ID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// operationV2Pruner returns the store's registry-operation pruner, or nil
+// when it has none.
+func (h *Handler) operationV2Pruner() database.OperationV2Pruner {
+	if p, ok := h.store.(database.OperationV2Pruner); ok {
+		return p
+	}
+	if uw, ok := h.store.(interface{ Unwrap() database.Store }); ok {
+		if p, ok := uw.Unwrap().(database.OperationV2Pruner); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// parseAge parses an age such as "30d" or "12h". Days are not a Go
+// duration unit, so they are handled here.
+func parseAge(raw string) (time.Duration, error) {
+	var age time.Duration
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errors.New("must be a number of days such as 30d, or a duration such as 12h")
+		}
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return 0, errors.New("must be a number of days such as 30d, or a duration such as 12h")
+		}
+		age = d
+	}
+	if age <= 0 {
+		return 0, errors.New("must be positive")
+	}
+	return age, nil
 }
 
 // --- Maintenance chores ---
@@ -473,14 +647,31 @@ func (h *Handler) AuditFileConsistency(c *gin.Context) {
 // --- Operation listing / logs / result / changes ---
 
 // ListOperations returns a snapshot of currently queued/running operations with
-// basic progress. Implements GET /operations.
+// basic progress. Implements GET /operations. Passing cursor (or
+// pagination=cursor for the first page) switches from offset paging to
+// cursor paging, newest first, with next_cursor in place of total/offset.
+// status (one status or a comma-separated list) narrows offset paging to
+// operations in those statuses; total then counts only those.
 func (h *Handler) ListOperations(c *gin.Context) {
 	params := httputil.ParsePaginationParams(c)
 	if h.store == nil {
 		httputil.RespondWithOK(c, gin.H{"items": []database.Operation{}, "total": 0, "limit": params.Limit, "offset": params.Offset})
 		return
 	}
-	ops, total, err := h.store.ListOperations(params.Limit, params.Offset)
+	cursorMode := c.Query("cursor") != "" || c.Query("pagination") == "cursor"
+	if status := c.Query("status"); status != "" {
+		if cursorMode {
+			httputil.RespondWithBadRequest(c, "status is not supported with cursor pagination")
+			return
+		}
+		h.listOperationsByStatus(c, strings.Split(status, ","), params.Limit, params.Offset)
+		return
+	}
+	if cursorMode {
+		h.listOperationsByCursor(c, params.Limit)
+		return
+	}
+	ops, total, err := database.ListOperationsContext(c.Request.Context(), h.store, params.Limit, params.Offset)
 	if err != nil {
 		httputil.InternalError(c, "failed to list operations", err)
 		return
@@ -491,6 +682,68 @@ func (h *Handler) ListOperations(c *gin.Context) {
 	httputil.RespondWithOK(c, gin.H{"items": ops, "total": total, "limit": params.Limit, "offset": params.Offset})
 }
 
+func (h *Handler) listOperationsByStatus(c *gin.Context, statuses []string, limit, offset int) {
+	all, _, err := database.ListOperationsContext(c.Request.Context(), h.store, allOperations, 0)
+	if err != nil {
+		httputil.InternalError(c, "failed to list operations", err)
+		return
+	}
+	ops := []database.Operation{}
+	total := 0
+	for _, op := range all {
+		if !slices.Contains(statuses, op.Status) {
+			continue
+		}
+		if total >= offset && len(ops) < limit {
+			ops = append(ops, op)
+		}
+		total++
+	}
+	httputil.RespondWithOK(c, gin.H{"items": ops, "total": total, "limit": limit, "offset": offset, "status": statuses})
+}
+
+func (h *Handler) listOperationsByCursor(c *gin.Context, limit int) {
+	if _, ok := c.GetQuery("offset"); ok {
+		httputil.RespondWithBadRequest(c, "offset is not supported with cursor pagination")
+		return
+	}
+	var after *database.PageCursor
+	if raw := c.Query("cursor"); raw != "" {
+		cur, err := database.DecodePageCursor(raw)
+		if err != nil {
+			httputil.RespondWithValidationError(c, "cursor", "malformed cursor")
+			return
+		}
+		after = cur
+	}
+	pager, ok := h.store.(database.CursorPageStore)
+	if !ok {
+		if uw, isWrapped := h.store.(interface{ Unwrap() database.Store }); isWrapped {
+			pager, ok = uw.Unwrap().(database.CursorPageStore)
+		}
+	}
+	if !ok {
+		httputil.RespondWithBadRequest(c, "cursor pagination is not supported by this store")
+		return
+	}
+	// One extra row says whether another page follows.
+	ops, err := pager.ListOperationsAfter(after, limit+1)
+	if errors.Is(err, database.ErrInvalidCursor) {
+		httputil.RespondWithValidationError(c, "cursor", "malformed cursor")
+		return
+	}
+	if err != nil {
+		httputil.InternalError(c, "failed to list operations", err)
+		return
+	}
+	var nextCursor any
+	if len(ops) > limit {
+		ops = ops[:limit]
+		nextCursor = database.OperationPageCursor(&ops[len(ops)-1]).Encode()
+	}
+	httputil.RespondWithOK(c, gin.H{"items": ops, "count": len(ops), "limit": limit, "next_cursor": nextCursor})
+}
+
 // ListStaleOperations implements GET /operations/stale.
 func (h *Handler) ListStaleOperations(c *gin.Context) {
 	timeoutMinutes := config.AppConfig.OperationTimeoutMinutes
@@ -826,18 +1079,19 @@ func (h *Handler) GetMaintenanceWindowStatus(c *gin.Context) {
 		"last_run_date":     sched.GetLastMaintenanceRunDate(),
 		"next_run_estimate": calculateNextWindowRun(cfg.MaintenanceWindowStart),
 		"currently_running": sched.IsMaintenanceRunning(),
+		"timezone":          config.Location().String(),
 	})
 }
 
-// calculateNextWindowRun returns the next RFC3339 timestamp when startHour
-// occurs locally.
+// calculateNextWindowRun returns, as a UTC RFC3339 timestamp, the next time
+// startHour occurs in the configured time zone.
 func calculateNextWindowRun(startHour int) string {
-	now := time.Now()
+	now := config.Now()
 	next := time.Date(now.Year(), now.Month(), now.Day(), startHour, 0, 0, 0, now.Location())
 	if !next.After(now) {
-		next = next.Add(24 * time.Hour)
+		next = next.AddDate(0, 0, 1)
 	}
-	return next.Format(time.RFC3339)
+	return next.UTC().Format(time.RFC3339)
 }
 
 // UpdateMaintenanceWindowConfig persists maintenance window schedule settings.
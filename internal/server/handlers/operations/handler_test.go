@@ -1,7 +1,7 @@
 // file: internal/server/handlers/operations/handler_test.go
-// version: 1.0.0
+// version: 1.8.0
 // guid: 36cf7fbb-8b23-4edb-ad4b-079ab2bd6cf1
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Unit tests for the operations-domain HTTP handlers. Each public method has at
 // least one test; happy paths plus key branches (cancel not-found fallback,
@@ -16,6 +16,7 @@ package operations_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -25,6 +26,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
 	"github.com/falkcorp/audiobook-organizer/internal/scheduler"
 	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/operations"
 	operationsmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/operations/mocks"
@@ -58,6 +62,10 @@ func newTestHandler(t *testing.T) (*operations.Handler, *operationsmocks.MockOpe
 			return &undo.UndoConflictReport{TotalChanges: 1}, nil
 		},
 		func(id string) error { return nil },
+		func() string { return "1.2.3" },
+		func(folderPath *string, forceUpdate bool, profile string) *scanner.ScanPrediction {
+			return &scanner.ScanPrediction{EstimatedSeconds: 90, Files: 300, Basis: scanner.PredictionBasisFolder}
+		},
 	)
 	return h, store, reg, sched, pipe, scans
 }
@@ -91,11 +99,50 @@ func TestStartScan_Enqueues(t *testing.T) {
 		r.POST("/operations/scan", h.StartScan)
 	})
 	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp struct {
+		OpID     string                  `json:"op_id"`
+		Estimate *scanner.ScanPrediction `json:"estimate"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "op-1", resp.OpID)
+	require.NotNil(t, resp.Estimate)
+	assert.Equal(t, 90, resp.Estimate.EstimatedSeconds)
+}
+
+func TestStartScan_InvalidParams(t *testing.T) {
+	h, _, reg, _, _, _ := newTestHandler(t)
+	reg.EXPECT().EnqueueOp(mock.Anything, "library.scan", json.RawMessage(`{"profile":"thorough","folder":"/x"}`)).
+		Return("", &opsregistry.ParamsError{DefID: "library.scan", Fields: []opsregistry.FieldError{
+			{Field: "folder", Message: "unknown parameter"},
+			{Field: "profile", Message: `must be one of "", "quick", "standard", "deep"`},
+		}})
+
+	w := run(http.MethodPost, "/operations/scan", "/operations/scan", []byte(`{"profile":"thorough","folder":"/x"}`), func(r *gin.Engine) {
+		r.POST("/operations/scan", h.StartScan)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp struct {
+		Code   string                `json:"code"`
+		Fields []httputil.FieldError `json:"fields"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "VALIDATION_ERROR", resp.Code)
+	require.Len(t, resp.Fields, 2)
+	assert.Equal(t, "folder", resp.Fields[0].Field)
+}
+
+func TestStartScan_MalformedBody(t *testing.T) {
+	h, _, _, _, _, _ := newTestHandler(t)
+
+	w := run(http.MethodPost, "/operations/scan", "/operations/scan", []byte(`{"profile":`), func(r *gin.Engine) {
+		r.POST("/operations/scan", h.StartScan)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestStartScan_NilRegistry(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	h := operations.New(operationsmocks.NewMockOperationsStore(t), nil, nil, nil, nil, nil, nil, nil)
+	h := operations.New(operationsmocks.NewMockOperationsStore(t), nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	w := run(http.MethodPost, "/operations/scan", "/operations/scan", []byte(`{}`), func(r *gin.Engine) {
 		r.POST("/operations/scan", h.StartScan)
 	})
@@ -122,6 +169,16 @@ func TestStartOptimize_Enqueues(t *testing.T) {
 	assert.Equal(t, http.StatusAccepted, w.Code)
 }
 
+func TestStartRecalculateSizes_Enqueues(t *testing.T) {
+	h, _, reg, _, _, _ := newTestHandler(t)
+	reg.EXPECT().EnqueueOp(mock.Anything, "library.size-refresh", mock.Anything).Return("op-5", nil)
+
+	w := run(http.MethodPost, "/system/recalculate-sizes", "/system/recalculate-sizes", nil, func(r *gin.Engine) {
+		r.POST("/system/recalculate-sizes", h.StartRecalculateSizes)
+	})
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
 func TestStartTranscode_RequiresBookID(t *testing.T) {
 	h, _, _, _, _, _ := newTestHandler(t)
 	w := run(http.MethodPost, "/operations/transcode", "/operations/transcode", []byte(`{}`), func(r *gin.Engine) {
@@ -262,6 +319,51 @@ func TestDeleteOperationHistory_Deletes(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+// prunerOpsStore adds registry-operation pruning to the mocked store.
+type prunerOpsStore struct {
+	*operationsmocks.MockOperationsStore
+	cutoff   time.Time
+	statuses []string
+}
+
+func (s *prunerOpsStore) PruneOperationsV2(_ context.Context, cutoff time.Time, statuses []string) (int, error) {
+	s.cutoff, s.statuses = cutoff, statuses
+	return 3, nil
+}
+
+func TestDeleteOperationHistory_OlderThan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &prunerOpsStore{MockOperationsStore: operationsmocks.NewMockOperationsStore(t)}
+	now := time.Now()
+	store.EXPECT().ListOperations(mock.AnythingOfType("int"), 0).Return([]database.Operation{
+		{ID: "old-done", Status: "completed", CreatedAt: now.Add(-40 * 24 * time.Hour)},
+		{ID: "old-failed", Status: "failed", CreatedAt: now.Add(-40 * 24 * time.Hour)},
+		{ID: "old-running", Status: "running", CreatedAt: now.Add(-40 * 24 * time.Hour)},
+		{ID: "new-done", Status: "completed", CreatedAt: now.Add(-time.Hour)},
+	}, 4, nil)
+	store.EXPECT().DeleteOperationWithLogs("old-done").Return(nil)
+	h := operations.New(store, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	w := run(http.MethodDelete, "/operations/history", "/operations/history?older_than=30d&status=completed", nil, func(r *gin.Engine) {
+		r.DELETE("/operations/history", h.DeleteOperationHistory)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]any)
+	assert.Equal(t, float64(4), data["deleted"])
+	assert.Equal(t, float64(3), data["registry_deleted"])
+	assert.Equal(t, []string{"completed"}, store.statuses)
+	assert.WithinDuration(t, now.Add(-30*24*time.Hour), store.cutoff, time.Minute)
+
+	for _, bad := range []string{"30x", "-1d", "0h"} {
+		w = run(http.MethodDelete, "/operations/history", "/operations/history?older_than="+bad, nil, func(r *gin.Engine) {
+			r.DELETE("/operations/history", h.DeleteOperationHistory)
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, bad)
+	}
+}
+
 // --- OptimizeDatabase ---
 
 func TestOptimizeDatabase_NoBooks(t *testing.T) {
@@ -338,6 +440,96 @@ func TestListOperations_StoreError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestListOperations_StatusFilter(t *testing.T) {
+	h, store, _, _, _, _ := newTestHandler(t)
+	store.EXPECT().ListOperations(mock.AnythingOfType("int"), 0).Return([]database.Operation{
+		{ID: "o4", Status: "completed"}, {ID: "o3", Status: "running"}, {ID: "o2", Status: "completed"}, {ID: "o1", Status: "completed"},
+	}, 4, nil)
+	w := run(http.MethodGet, "/operations", "/operations?status=completed&limit=1&offset=1", nil, func(r *gin.Engine) {
+		r.GET("/operations", h.ListOperations)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]any)
+	assert.Equal(t, float64(3), data["total"])
+	items := data["items"].([]any)
+	require.Len(t, items, 1)
+	assert.Equal(t, "o2", items[0].(map[string]any)["id"])
+
+	w = run(http.MethodGet, "/operations", "/operations?status=completed&pagination=cursor", nil, func(r *gin.Engine) {
+		r.GET("/operations", h.ListOperations)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// cursorOpsStore adds cursor paging to the mocked store.
+type cursorOpsStore struct {
+	*operationsmocks.MockOperationsStore
+	ops []database.Operation
+}
+
+func (s *cursorOpsStore) ListBooksAfter(*database.PageCursor, string, bool, int) ([]database.Book, error) {
+	return nil, nil
+}
+
+func (s *cursorOpsStore) ListOperationsAfter(after *database.PageCursor, limit int) ([]database.Operation, error) {
+	start := 0
+	if after != nil {
+		for i := range s.ops {
+			if s.ops[i].ID == after.ID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+limit, len(s.ops))
+	return s.ops[start:end], nil
+}
+
+func TestListOperations_Cursor(t *testing.T) {
+	now := time.Now()
+	store := &cursorOpsStore{
+		MockOperationsStore: operationsmocks.NewMockOperationsStore(t),
+		ops: []database.Operation{
+			{ID: "o3", CreatedAt: now}, {ID: "o2", CreatedAt: now.Add(-time.Minute)}, {ID: "o1", CreatedAt: now.Add(-2 * time.Minute)},
+		},
+	}
+	h := operations.New(store, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	get := func(target string) (int, map[string]any) {
+		w := run(http.MethodGet, "/operations", target, nil, func(r *gin.Engine) {
+			r.GET("/operations", h.ListOperations)
+		})
+		var resp map[string]any
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		data, _ := resp["data"].(map[string]any)
+		return w.Code, data
+	}
+
+	code, data := get("/operations?pagination=cursor&limit=2")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, data["items"], 2)
+	next, _ := data["next_cursor"].(string)
+	require.NotEmpty(t, next)
+
+	code, data = get("/operations?limit=2&cursor=" + next)
+	require.Equal(t, http.StatusOK, code)
+	items := data["items"].([]any)
+	require.Len(t, items, 1)
+	assert.Equal(t, "o1", items[0].(map[string]any)["id"])
+	assert.Nil(t, data["next_cursor"])
+
+	// A page that ends exactly at the last operation has no next cursor.
+	code, data = get("/operations?pagination=cursor&limit=3")
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, data["items"], 3)
+	assert.Nil(t, data["next_cursor"])
+
+	code, _ = get("/operations?cursor=garbage")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = get("/operations?cursor=" + next + "&offset=5")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
 // --- ListStaleOperations ---
 
 func TestListStaleOperations_UsesInjectedCollector(t *testing.T) {
@@ -431,6 +623,7 @@ func TestUndoPreflightHandler_Error(t *testing.T) {
 	h := operations.New(store, nil, nil, nil, nil, nil,
 		func(id string) (*undo.UndoConflictReport, error) { return nil, errors.New("boom") },
 		func(id string) error { return nil },
+		nil, nil,
 	)
 	w := run(http.MethodGet, "/operations/:id/undo/preflight", "/operations/op-1/undo/preflight", nil, func(r *gin.Engine) {
 		r.GET("/operations/:id/undo/preflight", h.UndoPreflightHandler)
@@ -454,6 +647,7 @@ func TestRevertOperation_Error(t *testing.T) {
 	h := operations.New(store, nil, nil, nil, nil, nil,
 		func(id string) (*undo.UndoConflictReport, error) { return nil, nil },
 		func(id string) error { return errors.New("revert failed") },
+		nil, nil,
 	)
 	w := run(http.MethodPost, "/operations/:id/revert", "/operations/op-1/revert", nil, func(r *gin.Engine) {
 		r.POST("/operations/:id/revert", h.RevertOperation)
@@ -474,7 +668,7 @@ func TestListTasks_Success(t *testing.T) {
 
 func TestListTasks_NilScheduler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	h := operations.New(operationsmocks.NewMockOperationsStore(t), nil, nil, nil, nil, nil, nil, nil)
+	h := operations.New(operationsmocks.NewMockOperationsStore(t), nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	w := run(http.MethodGet, "/tasks", "/tasks", nil, func(r *gin.Engine) {
 		r.GET("/tasks", h.ListTasks)
 	})
@@ -0,0 +1,326 @@
+// file: internal/server/handlers/operations/log_export.go
+// version: 1.2.0
+// guid: 25993b5f-d734-4e55-9b12-694c1e18d294
+// last-edited: 2026-10-17
+
+package operations
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// LogExportOperation is the operation header record of a log export.
+type LogExportOperation struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Status      string          `json:"status"`
+	Params      json.RawMessage `json:"params,omitempty"`
+	Progress    int             `json:"progress"`
+	Total       int             `json:"total"`
+	Message     string          `json:"message,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	QueuedAt    time.Time       `json:"queued_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// LogExportPhase is the span of log lines tagged with one phase.
+type LogExportPhase struct {
+	Name       string    `json:"name"`
+	FirstAt    time.Time `json:"first_at"`
+	LastAt     time.Time `json:"last_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Lines      int       `json:"lines"`
+}
+
+// LogExportTiming breaks an operation's wall time down. Durations that
+// cannot be computed yet (an op still queued or running) are omitted.
+type LogExportTiming struct {
+	QueueWaitMs *int64           `json:"queue_wait_ms,omitempty"`
+	RunMs       *int64           `json:"run_ms,omitempty"`
+	TotalMs     *int64           `json:"total_ms,omitempty"`
+	Phases      []LogExportPhase `json:"phases"`
+}
+
+// LogExportEnvironment snapshots the server an export came from.
+type LogExportEnvironment struct {
+	Version      string    `json:"version"`
+	GoVersion    string    `json:"go_version"`
+	OS           string    `json:"os"`
+	Arch         string    `json:"arch"`
+	DatabaseType string    `json:"database_type"`
+	ConfigHash   string    `json:"config_hash"`
+	ExportedAt   time.Time `json:"exported_at"`
+	// Timezone is the server's schedule zone; timestamps are UTC.
+	Timezone string `json:"timezone"`
+}
+
+// logExportLine is one log record in an export.
+type logExportLine struct {
+	Level     string          `json:"level"`
+	Message   string          `json:"message"`
+	Attrs     json.RawMessage `json:"attrs,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ExportOperationLogs implements GET /operations/:id/logs/export. It
+// streams every log line of an operation, preceded by the operation
+// (with its parameters), a timing breakdown and an environment snapshot,
+// as a downloadable file for bug reports.
+//
+// Query params:
+//   - format: "ndjson" (default) — one JSON object per line, each with a
+//     "record" discriminator (operation, timing, environment, log) — or
+//     "text" for a plain-text rendering.
+func (h *Handler) ExportOperationLogs(c *gin.Context) {
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	format := c.DefaultQuery("format", "ndjson")
+	if format != "ndjson" && format != "text" {
+		httputil.RespondWithBadRequest(c, "format must be one of: ndjson, text")
+		return
+	}
+	id := c.Param("id")
+
+	op, ok := h.exportOperation(id)
+	if !ok {
+		httputil.RespondWithNotFound(c, "operation", id)
+		return
+	}
+	lines, err := h.exportLogLines(id)
+	if err != nil {
+		httputil.InternalError(c, "failed to get operation logs", err)
+		return
+	}
+	timing := exportTiming(op, lines)
+	env := h.exportEnvironment()
+
+	ext, contentType := "ndjson", "application/x-ndjson"
+	if format == "text" {
+		ext, contentType = "txt", "text/plain; charset=utf-8"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="operation-%s-logs.%s"`, id, ext))
+	c.Status(http.StatusOK)
+
+	w := bufio.NewWriter(c.Writer)
+	defer w.Flush()
+	if format == "text" {
+		writeTextExport(w, op, timing, env, lines)
+		return
+	}
+	enc := json.NewEncoder(w)
+	_ = enc.Encode(struct {
+		Record string `json:"record"`
+		LogExportOperation
+	}{"operation", op})
+	_ = enc.Encode(struct {
+		Record string `json:"record"`
+		LogExportTiming
+	}{"timing", timing})
+	_ = enc.Encode(struct {
+		Record string `json:"record"`
+		LogExportEnvironment
+	}{"environment", env})
+	for _, l := range lines {
+		_ = enc.Encode(struct {
+			Record string `json:"record"`
+			logExportLine
+		}{"log", l})
+	}
+}
+
+// exportOperation loads the operation from the v2 registry table, falling
+// back to the legacy table like GetOperationStatus.
+func (h *Handler) exportOperation(id string) (LogExportOperation, bool) {
+	if v2, err := h.store.GetOperationV2(id); err == nil && v2 != nil {
+		op := LogExportOperation{
+			ID: v2.ID, Type: v2.DefID, Status: v2.Status,
+			Progress: v2.ProgressCurrent, Total: v2.ProgressTotal, Message: v2.ProgressMessage,
+			QueuedAt: v2.QueuedAt, StartedAt: v2.StartedAt, CompletedAt: v2.CompletedAt,
+		}
+		if v2.Params != "" && json.Valid([]byte(v2.Params)) {
+			op.Params = json.RawMessage(v2.Params)
+		}
+		if v2.ErrorMessage != nil {
+			op.Error = *v2.ErrorMessage
+		}
+		return op, true
+	}
+	legacy, err := h.store.GetOperationByID(id)
+	if err != nil || legacy == nil {
+		return LogExportOperation{}, false
+	}
+	op := LogExportOperation{
+		ID: legacy.ID, Type: legacy.Type, Status: legacy.Status,
+		Progress: legacy.Progress, Total: legacy.Total, Message: legacy.Message,
+		QueuedAt: legacy.CreatedAt, StartedAt: legacy.StartedAt, CompletedAt: legacy.CompletedAt,
+	}
+	if legacy.ErrorMessage != nil {
+		op.Error = *legacy.ErrorMessage
+	}
+	return op, true
+}
+
+// exportLogLines returns every log line, v2 first with a v1 fallback as in
+// GetOperationLogs.
+func (h *Handler) exportLogLines(id string) ([]logExportLine, error) {
+	v2Logs, err := h.store.GetOpLogsV2(id, 0)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]logExportLine, 0, len(v2Logs))
+	for _, l := range v2Logs {
+		line := logExportLine{Level: l.Level, Message: l.Message, CreatedAt: l.CreatedAt}
+		if l.Attrs != "" && json.Valid([]byte(l.Attrs)) {
+			line.Attrs = json.RawMessage(l.Attrs)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		if v1Logs, err := h.store.GetOperationLogs(id); err == nil {
+			for _, l := range v1Logs {
+				lines = append(lines, logExportLine{Level: l.Level, Message: l.Message, CreatedAt: l.CreatedAt})
+			}
+		}
+	}
+	return lines, nil
+}
+
+// exportTiming derives queue wait and run time from the operation's
+// timestamps and per-phase spans from the "phase" attribute the registry
+// tags log lines with. The canonical start/progress/end markers are not
+// phases and are left out.
+func exportTiming(op LogExportOperation, lines []logExportLine) LogExportTiming {
+	ms := func(from, to time.Time) *int64 {
+		d := to.Sub(from).Milliseconds()
+		return &d
+	}
+	var t LogExportTiming
+	if op.StartedAt != nil && !op.QueuedAt.IsZero() {
+		t.QueueWaitMs = ms(op.QueuedAt, *op.StartedAt)
+	}
+	if op.CompletedAt != nil {
+		if op.StartedAt != nil {
+			t.RunMs = ms(*op.StartedAt, *op.CompletedAt)
+		}
+		if !op.QueuedAt.IsZero() {
+			t.TotalMs = ms(op.QueuedAt, *op.CompletedAt)
+		}
+	}
+
+	phases := map[string]*LogExportPhase{}
+	for _, l := range lines {
+		if len(l.Attrs) == 0 {
+			continue
+		}
+		var attrs struct {
+			Phase string `json:"phase"`
+		}
+		if json.Unmarshal(l.Attrs, &attrs) != nil {
+			continue
+		}
+		switch attrs.Phase {
+		case "", "start", "progress", "end":
+			continue
+		}
+		p, ok := phases[attrs.Phase]
+		if !ok {
+			p = &LogExportPhase{Name: attrs.Phase, FirstAt: l.CreatedAt, LastAt: l.CreatedAt}
+			phases[attrs.Phase] = p
+		}
+		if l.CreatedAt.Before(p.FirstAt) {
+			p.FirstAt = l.CreatedAt
+		}
+		if l.CreatedAt.After(p.LastAt) {
+			p.LastAt = l.CreatedAt
+		}
+		p.Lines++
+	}
+	t.Phases = make([]LogExportPhase, 0, len(phases))
+	for _, p := range phases {
+		p.DurationMs = p.LastAt.Sub(p.FirstAt).Milliseconds()
+		t.Phases = append(t.Phases, *p)
+	}
+	sort.Slice(t.Phases, func(i, j int) bool { return t.Phases[i].FirstAt.Before(t.Phases[j].FirstAt) })
+	return t
+}
+
+// exportEnvironment snapshots the running server. The config is reduced
+// to a hash so exports can be compared without leaking settings.
+func (h *Handler) exportEnvironment() LogExportEnvironment {
+	env := LogExportEnvironment{
+		Version:      "unknown",
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		DatabaseType: config.AppConfig.DatabaseType,
+		ExportedAt:   time.Now().UTC(),
+		Timezone:     config.Location().String(),
+	}
+	if h.appVersion != nil {
+		env.Version = h.appVersion()
+	}
+	if raw, err := json.Marshal(config.AppConfig); err == nil {
+		sum := sha256.Sum256(raw)
+		env.ConfigHash = hex.EncodeToString(sum[:])[:16]
+	}
+	return env
+}
+
+func writeTextExport(w *bufio.Writer, op LogExportOperation, timing LogExportTiming, env LogExportEnvironment, lines []logExportLine) {
+	fmtTime := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	fmtMs := func(d *int64) string {
+		if d == nil {
+			return "-"
+		}
+		return (time.Duration(*d) * time.Millisecond).String()
+	}
+	fmt.Fprintf(w, "# Operation %s (%s)\n", op.ID, op.Type)
+	fmt.Fprintf(w, "status:       %s\n", op.Status)
+	fmt.Fprintf(w, "progress:     %d/%d %s\n", op.Progress, op.Total, op.Message)
+	if op.Error != "" {
+		fmt.Fprintf(w, "error:        %s\n", op.Error)
+	}
+	if len(op.Params) > 0 {
+		fmt.Fprintf(w, "params:       %s\n", op.Params)
+	}
+	fmt.Fprintf(w, "queued:       %s\n", fmtTime(&op.QueuedAt))
+	fmt.Fprintf(w, "started:      %s\n", fmtTime(op.StartedAt))
+	fmt.Fprintf(w, "completed:    %s\n", fmtTime(op.CompletedAt))
+	fmt.Fprintf(w, "\n# Timing\nqueue wait:   %s\nrun:          %s\ntotal:        %s\n",
+		fmtMs(timing.QueueWaitMs), fmtMs(timing.RunMs), fmtMs(timing.TotalMs))
+	for _, p := range timing.Phases {
+		fmt.Fprintf(w, "phase %-20s %s (%d lines)\n", p.Name, fmtMs(&p.DurationMs), p.Lines)
+	}
+	fmt.Fprintf(w, "\n# Environment\nversion:      %s\ngo:           %s %s/%s\ndatabase:     %s\nconfig hash:  %s\nexported:     %s\ntimezone:     %s\n",
+		env.Version, env.GoVersion, env.OS, env.Arch, env.DatabaseType, env.ConfigHash, fmtTime(&env.ExportedAt), env.Timezone)
+	fmt.Fprintf(w, "\n# Logs (%d lines)\n", len(lines))
+	for _, l := range lines {
+		line := fmt.Sprintf("%s %-5s %s", l.CreatedAt.UTC().Format(time.RFC3339Nano), strings.ToUpper(l.Level), l.Message)
+		if len(l.Attrs) > 0 && string(l.Attrs) != "{}" {
+			line += " " + string(l.Attrs)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
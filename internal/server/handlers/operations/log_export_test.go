@@ -0,0 +1,114 @@
+// file: internal/server/handlers/operations/log_export_test.go
+// version: 1.0.0
+// guid: 30d1a09c-f9a0-4f6f-a1b8-fde909aaa736
+// last-edited: 2026-10-16
+
+package operations_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportFixture() (*database.OperationV2Row, []database.OpLogV2Row) {
+	queued := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	started := queued.Add(2 * time.Second)
+	completed := started.Add(10 * time.Second)
+	errMsg := "disk full"
+	row := &database.OperationV2Row{
+		ID: "op-9", DefID: "library.scan", Status: "failed", Params: `{"path":"/books"}`,
+		ErrorMessage: &errMsg, QueuedAt: queued, StartedAt: &started, CompletedAt: &completed,
+	}
+	logs := []database.OpLogV2Row{
+		{Level: "info", Message: "operation started", Attrs: `{"phase":"start"}`, CreatedAt: started},
+		{Level: "info", Message: "walking", Attrs: `{"phase":"discover"}`, CreatedAt: started.Add(time.Second)},
+		{Level: "info", Message: "walked", Attrs: `{"phase":"discover"}`, CreatedAt: started.Add(4 * time.Second)},
+		{Level: "error", Message: "write failed", Attrs: `{"phase":"save"}`, CreatedAt: started.Add(9 * time.Second)},
+	}
+	return row, logs
+}
+
+func TestExportOperationLogs_NDJSON(t *testing.T) {
+	h, store, _, _, _, _ := newTestHandler(t)
+	row, logs := exportFixture()
+	store.EXPECT().GetOperationV2("op-9").Return(row, nil)
+	store.EXPECT().GetOpLogsV2("op-9", 0).Return(logs, nil)
+
+	w := run(http.MethodGet, "/operations/:id/logs/export", "/operations/op-9/logs/export", nil, func(r *gin.Engine) {
+		r.GET("/operations/:id/logs/export", h.ExportOperationLogs)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `filename="operation-op-9-logs.ndjson"`)
+
+	var records []map[string]any
+	sc := bufio.NewScanner(bytes.NewReader(w.Body.Bytes()))
+	for sc.Scan() {
+		var rec map[string]any
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &rec))
+		records = append(records, rec)
+	}
+	require.Len(t, records, 3+len(logs))
+	assert.Equal(t, "operation", records[0]["record"])
+	assert.Equal(t, map[string]any{"path": "/books"}, records[0]["params"])
+	assert.Equal(t, "disk full", records[0]["error"])
+
+	timing := records[1]
+	assert.Equal(t, "timing", timing["record"])
+	assert.Equal(t, float64(2000), timing["queue_wait_ms"])
+	assert.Equal(t, float64(10000), timing["run_ms"])
+	phases := timing["phases"].([]any)
+	require.Len(t, phases, 2)
+	assert.Equal(t, "discover", phases[0].(map[string]any)["name"])
+	assert.Equal(t, float64(3000), phases[0].(map[string]any)["duration_ms"])
+
+	env := records[2]
+	assert.Equal(t, "environment", env["record"])
+	assert.Equal(t, "1.2.3", env["version"])
+	assert.NotEmpty(t, env["config_hash"])
+
+	assert.Equal(t, "log", records[3]["record"])
+	assert.Equal(t, "operation started", records[3]["message"])
+}
+
+func TestExportOperationLogs_Text(t *testing.T) {
+	h, store, _, _, _, _ := newTestHandler(t)
+	row, logs := exportFixture()
+	store.EXPECT().GetOperationV2("op-9").Return(row, nil)
+	store.EXPECT().GetOpLogsV2("op-9", 0).Return(logs, nil)
+
+	w := run(http.MethodGet, "/operations/:id/logs/export", "/operations/op-9/logs/export?format=text", nil, func(r *gin.Engine) {
+		r.GET("/operations/:id/logs/export", h.ExportOperationLogs)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, "# Operation op-9 (library.scan)\n"))
+	assert.Contains(t, body, `params:       {"path":"/books"}`)
+	assert.Contains(t, body, "run:          10s")
+	assert.Contains(t, body, "ERROR write failed")
+}
+
+func TestExportOperationLogs_Errors(t *testing.T) {
+	h, store, _, _, _, _ := newTestHandler(t)
+	w := run(http.MethodGet, "/operations/:id/logs/export", "/operations/op-9/logs/export?format=xml", nil, func(r *gin.Engine) {
+		r.GET("/operations/:id/logs/export", h.ExportOperationLogs)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	store.EXPECT().GetOperationV2("nope").Return(nil, nil)
+	store.EXPECT().GetOperationByID("nope").Return(nil, nil)
+	w = run(http.MethodGet, "/operations/:id/logs/export", "/operations/nope/logs/export", nil, func(r *gin.Engine) {
+		r.GET("/operations/:id/logs/export", h.ExportOperationLogs)
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
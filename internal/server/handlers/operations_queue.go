@@ -0,0 +1,154 @@
+// file: internal/server/handlers/operations_queue.go
+// version: 1.0.0
+// guid: 5e0d9c3b-7f2a-4b81-9c6e-2a4d8f1b3e57
+// last-edited: 2026-10-17
+
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+// OperationsQueue is the queue control surface of the operations registry.
+// It is optional: a registry without it answers the queue endpoints with
+// 503.
+type OperationsQueue interface {
+	QueueStatus() (opsregistry.QueueStatus, error)
+	PauseQueue()
+	ResumeQueue()
+	MoveQueued(opID string, pos opsregistry.QueuePosition) error
+}
+
+// QueuePoolResponse is one worker pool in GET /operations/queue.
+type QueuePoolResponse struct {
+	Name          string `json:"name"`
+	MaxConcurrent int    `json:"max_concurrent"`
+	Running       int    `json:"running"`
+	Queued        int    `json:"queued"`
+}
+
+// QueueResponse is the JSON shape of GET /operations/queue.
+type QueueResponse struct {
+	Paused        bool                  `json:"paused"`
+	Workers       int                   `json:"workers"`
+	MaxConcurrent int                   `json:"max_concurrent"`
+	Running       int                   `json:"running"`
+	Pools         []QueuePoolResponse   `json:"pools"`
+	Queued        []OperationV2Response `json:"queued"`
+}
+
+func (h *OperationsV2Handler) queue(c *gin.Context) (OperationsQueue, bool) {
+	q, ok := h.registry.(OperationsQueue)
+	if !ok {
+		httputil.RespondWithServiceUnavailable(c, "operation queue is not available")
+	}
+	return q, ok
+}
+
+// GetOperationQueue implements GET /api/v1/operations/queue.
+// Returns the queue limits, per-pool counts and the waiting operations in
+// dispatch order.
+func (h *OperationsV2Handler) GetOperationQueue(c *gin.Context) {
+	q, ok := h.queue(c)
+	if !ok {
+		return
+	}
+	h.respondQueue(c, q)
+}
+
+// PauseOperationQueue implements POST /api/v1/operations/queue/pause.
+// Queued operations stay queued until resumed; running ones finish.
+func (h *OperationsV2Handler) PauseOperationQueue(c *gin.Context) {
+	q, ok := h.queue(c)
+	if !ok {
+		return
+	}
+	q.PauseQueue()
+	h.respondQueue(c, q)
+}
+
+// ResumeOperationQueue implements POST /api/v1/operations/queue/resume.
+func (h *OperationsV2Handler) ResumeOperationQueue(c *gin.Context) {
+	q, ok := h.queue(c)
+	if !ok {
+		return
+	}
+	q.ResumeQueue()
+	h.respondQueue(c, q)
+}
+
+// ReorderOperationQueue implements POST /api/v1/operations/queue/reorder.
+// Body: { "op_id": "...", "before": "<op_id>" | "after": "<op_id>" | "priority": 0-2 }
+func (h *OperationsV2Handler) ReorderOperationQueue(c *gin.Context) {
+	q, ok := h.queue(c)
+	if !ok {
+		return
+	}
+	var body struct {
+		OpID     string `json:"op_id"`
+		Before   string `json:"before"`
+		After    string `json:"after"`
+		Priority *int   `json:"priority"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.OpID == "" {
+		httputil.RespondWithBadRequest(c, "body must include op_id")
+		return
+	}
+	set := 0
+	for _, given := range []bool{body.Before != "", body.After != "", body.Priority != nil} {
+		if given {
+			set++
+		}
+	}
+	if set != 1 {
+		httputil.RespondWithValidationError(c, "before", "give exactly one of before, after or priority")
+		return
+	}
+	pos := opsregistry.QueuePosition{Before: body.Before, After: body.After}
+	if body.Priority != nil {
+		p := opsregistry.Priority(*body.Priority)
+		if p < opsregistry.PriorityLow || p > opsregistry.PriorityHigh {
+			httputil.RespondWithValidationError(c, "priority", "must be 0 (low), 1 (normal) or 2 (high)")
+			return
+		}
+		pos.Priority = &p
+	}
+
+	err := q.MoveQueued(body.OpID, pos)
+	if errors.Is(err, opsregistry.ErrNotQueued) {
+		httputil.RespondWithConflict(c, err.Error())
+		return
+	}
+	if err != nil {
+		httputil.InternalError(c, "reorder failed", err)
+		return
+	}
+	h.respondQueue(c, q)
+}
+
+func (h *OperationsV2Handler) respondQueue(c *gin.Context, q OperationsQueue) {
+	st, err := q.QueueStatus()
+	if err != nil {
+		httputil.InternalError(c, "failed to read operation queue", err)
+		return
+	}
+	resp := QueueResponse{
+		Paused:        st.Paused,
+		Workers:       st.Workers,
+		MaxConcurrent: st.MaxConcurrent,
+		Running:       st.Running,
+		Pools:         make([]QueuePoolResponse, 0, len(st.Pools)),
+		Queued:        make([]OperationV2Response, 0, len(st.Queued)),
+	}
+	for _, p := range st.Pools {
+		resp.Pools = append(resp.Pools, QueuePoolResponse(p))
+	}
+	for _, r := range st.Queued {
+		resp.Queued = append(resp.Queued, rowToResponse(r, h.displayNameFor(r.DefID), h.notifyLevelFor(r.DefID)))
+	}
+	httputil.RespondWithOK(c, resp)
+}
@@ -0,0 +1,105 @@
+// file: internal/server/handlers/operations_queue_test.go
+// version: 1.0.0
+// guid: 3d7a9f2e-6b14-4c58-a0e3-9f5b1c8d2e64
+
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
+	handlersmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queueRegistry is an OperationsRegistry that also controls a queue.
+type queueRegistry struct {
+	*handlersmocks.MockOperationsRegistry
+	paused bool
+	moved  []opsregistry.QueuePosition
+	err    error
+}
+
+func (q *queueRegistry) QueueStatus() (opsregistry.QueueStatus, error) {
+	return opsregistry.QueueStatus{
+		Paused:        q.paused,
+		Workers:       8,
+		MaxConcurrent: 4,
+		Pools:         []opsregistry.PoolStatus{{Name: "scan", MaxConcurrent: 1, Queued: 1}},
+		Queued:        []database.OperationV2Row{{ID: "op1", DefID: "library.scan", Status: "queued"}},
+	}, nil
+}
+
+func (q *queueRegistry) PauseQueue()  { q.paused = true }
+func (q *queueRegistry) ResumeQueue() { q.paused = false }
+
+func (q *queueRegistry) MoveQueued(_ string, pos opsregistry.QueuePosition) error {
+	q.moved = append(q.moved, pos)
+	return q.err
+}
+
+func newQueueRegistry(t *testing.T) *queueRegistry {
+	m := handlersmocks.NewMockOperationsRegistry(t)
+	m.EXPECT().ActiveDefs().Return([]opsregistry.OperationDef{{ID: "library.scan", DisplayName: "Library Scan"}}).Maybe()
+	return &queueRegistry{MockOperationsRegistry: m}
+}
+
+func TestOperationsV2Handler_Queue_Unavailable(t *testing.T) {
+	h := handlers.NewOperationsV2Handler(nil, handlersmocks.NewMockOperationsRegistry(t), nil)
+	c, w := newOpsV2Ctx(http.MethodGet, "/operations/queue", "", nil)
+	h.GetOperationQueue(c)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestOperationsV2Handler_Queue_PauseResume(t *testing.T) {
+	reg := newQueueRegistry(t)
+	h := handlers.NewOperationsV2Handler(nil, reg, nil)
+
+	c, w := newOpsV2Ctx(http.MethodPost, "/operations/queue/pause", "", nil)
+	h.PauseOperationQueue(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"paused":true`)
+	assert.Contains(t, w.Body.String(), `"display_name":"Library Scan"`)
+	assert.Contains(t, w.Body.String(), `"name":"scan"`)
+
+	c, w = newOpsV2Ctx(http.MethodPost, "/operations/queue/resume", "", nil)
+	h.ResumeOperationQueue(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"paused":false`)
+}
+
+func TestOperationsV2Handler_Queue_Reorder(t *testing.T) {
+	reg := newQueueRegistry(t)
+	h := handlers.NewOperationsV2Handler(nil, reg, nil)
+
+	c, w := newOpsV2Ctx(http.MethodPost, "/operations/queue/reorder", `{"op_id":"op2","before":"op1"}`, nil)
+	h.ReorderOperationQueue(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	c, w = newOpsV2Ctx(http.MethodPost, "/operations/queue/reorder", `{"op_id":"op2","priority":2}`, nil)
+	h.ReorderOperationQueue(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, reg.moved, 2)
+	assert.Equal(t, "op1", reg.moved[0].Before)
+	require.NotNil(t, reg.moved[1].Priority)
+	assert.Equal(t, opsregistry.PriorityHigh, *reg.moved[1].Priority)
+
+	for _, body := range []string{
+		`{"before":"op1"}`,
+		`{"op_id":"op2"}`,
+		`{"op_id":"op2","before":"op1","after":"op3"}`,
+		`{"op_id":"op2","priority":7}`,
+	} {
+		c, w = newOpsV2Ctx(http.MethodPost, "/operations/queue/reorder", body, nil)
+		h.ReorderOperationQueue(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	reg.err = opsregistry.ErrNotQueued
+	c, w = newOpsV2Ctx(http.MethodPost, "/operations/queue/reorder", `{"op_id":"op2","after":"op1"}`, nil)
+	h.ReorderOperationQueue(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
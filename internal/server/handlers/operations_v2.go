@@ -1,18 +1,20 @@
 // file: internal/server/handlers/operations_v2.go
-// version: 1.0.0
+// version: 1.3.0
 // guid: a1b2c3d4-e5f6-7a8b-9c0d-1e2f3a4b5c6d
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // UOS-06: SSE event hub, /operations/timeline, single-op introspection,
-// cancel, trigger-op, and /op-defs endpoints.
+// live log streaming, cancel, trigger-op, and /op-defs endpoints.
 
 package handlers
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -156,6 +158,9 @@ func (h *OperationsV2Handler) TriggerOperationV2(c *gin.Context) {
 	}
 
 	opID, err := h.registry.EnqueueOp(c.Request.Context(), body.DefID, body.Params)
+	if RespondWithParamsError(c, err) {
+		return
+	}
 	if err != nil {
 		httputil.InternalError(c, "enqueue failed", err)
 		return
@@ -238,6 +243,148 @@ func (h *OperationsV2Handler) OperationsSSE(c *gin.Context) {
 	}
 }
 
+// opLogStreamPoll is how often OperationLogsSSE checks whether the
+// operation has finished; tests shorten it.
+var opLogStreamPoll = 2 * time.Second
+
+// OperationLogsSSE implements GET /api/v1/operations/:id/logs/stream.
+// Query: tail (stored lines replayed first, default 1000).
+//
+// Replays the operation's stored log lines as "log" events, then streams
+// new lines live as the operation logs them, and sends one "end" event
+// carrying the final status once the operation is no longer queued or
+// running. A finished operation gets its history and the end event
+// straight away.
+func (h *OperationsV2Handler) OperationLogsSSE(c *gin.Context) {
+	id := c.Param("id")
+	if h.opsStore == nil {
+		httputil.RespondWithNotFound(c, "operation", id)
+		return
+	}
+	row, err := h.opsStore.GetOperationV2(id)
+	if err != nil || row == nil {
+		httputil.RespondWithNotFound(c, "operation", id)
+		return
+	}
+	tail := 1000
+	if raw := c.Query("tail"); raw != "" {
+		n, convErr := strconv.Atoi(raw)
+		if convErr != nil || n < 1 {
+			httputil.RespondWithValidationError(c, "tail", "must be a positive integer")
+			return
+		}
+		tail = n
+	}
+
+	// Subscribe before reading the history so no line falls in between;
+	// live lines already in the history are skipped by timestamp below.
+	var live <-chan opsregistry.Event
+	if h.hub != nil && !opFinished(row.Status) {
+		ch, unsubscribe := h.hub.Subscribe()
+		defer unsubscribe()
+		live = ch
+	}
+	history, err := h.opsStore.GetOpLogsV2(id, tail)
+	if err != nil {
+		httputil.InternalError(c, "failed to get operation logs", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	var last time.Time
+	for _, l := range history {
+		writeSSE(c, "log", logRowToResponse(l))
+		last = l.CreatedAt
+	}
+	if live == nil {
+		writeSSE(c, "end", gin.H{"op_id": id, "status": row.Status})
+		c.Writer.Flush()
+		return
+	}
+	fmt.Fprintf(c.Writer, ": heartbeat\n\n")
+	c.Writer.Flush()
+
+	send := func(ev opsregistry.Event) {
+		if line, ok := opLogLine(ev, id); ok && line.CreatedAt.After(last) {
+			writeSSE(c, "log", line)
+			last = line.CreatedAt
+		}
+	}
+	ticker := time.NewTicker(opLogStreamPoll)
+	defer ticker.Stop()
+	notify := c.Request.Context().Done()
+	for {
+		select {
+		case <-notify:
+			return
+		case ev, ok := <-live:
+			if !ok {
+				return
+			}
+			send(ev)
+			c.Writer.Flush()
+		case <-ticker.C:
+			row, err := h.opsStore.GetOperationV2(id)
+			if err != nil || row == nil || !opFinished(row.Status) {
+				fmt.Fprintf(c.Writer, ": heartbeat\n\n")
+				c.Writer.Flush()
+				continue
+			}
+			// Lines logged just before the status changed may still be
+			// queued; send them before ending.
+			for drained := false; !drained; {
+				select {
+				case ev, ok := <-live:
+					if !ok {
+						drained = true
+						break
+					}
+					send(ev)
+				default:
+					drained = true
+				}
+			}
+			writeSSE(c, "end", gin.H{"op_id": id, "status": row.Status})
+			c.Writer.Flush()
+			return
+		}
+	}
+}
+
+// opFinished reports whether an operation with this status will log no
+// more lines.
+func opFinished(status string) bool {
+	return status != "queued" && status != "running"
+}
+
+// opLogLine converts an "op.log" hub event for opID into a log line.
+func opLogLine(ev opsregistry.Event, opID string) (OpLogV2Response, bool) {
+	p, ok := ev.Payload.(map[string]any)
+	if ev.Name != "op.log" || !ok || p["op_id"] != opID {
+		return OpLogV2Response{}, false
+	}
+	line := OpLogV2Response{OperationID: opID, Attrs: map[string]any{}}
+	line.Level, _ = p["level"].(string)
+	line.Message, _ = p["message"].(string)
+	if ts, _ := p["created_at"].(string); ts != "" {
+		line.CreatedAt, _ = time.Parse(time.RFC3339Nano, ts)
+	}
+	return line, true
+}
+
+// writeSSE writes one SSE event with a JSON payload.
+func writeSSE(c *gin.Context, event string, payload any) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, b)
+}
+
 // --- helpers ---
 
 // displayNameFor looks up the human-readable display name for a def ID.
@@ -297,6 +444,9 @@ func rowToResponse(r database.OperationV2Row, displayName string, notifyLevel in
 	if r.ProgressMessage != "" {
 		resp.ProgressMessage = &r.ProgressMessage
 	}
+	if r.Params != "" && r.Params != "{}" && json.Valid([]byte(r.Params)) {
+		resp.Params = json.RawMessage(r.Params)
+	}
 	return resp
 }
 
@@ -343,9 +493,10 @@ func defToResponse(d opsregistry.OperationDef) OpDefResponse {
 		rp = "ask"
 	}
 
-	return OpDefResponse{
+	resp := OpDefResponse{
 		ID:           d.ID,
 		Plugin:       d.Plugin,
+		Pool:         d.Pool,
 		DisplayName:  d.DisplayName,
 		Description:  d.Description,
 		Cancellable:  d.Cancellable,
@@ -354,6 +505,25 @@ func defToResponse(d opsregistry.OperationDef) OpDefResponse {
 		Triggers:     triggers,
 		DependsOn:    depends,
 	}
+	if d.ParamsSchema != nil {
+		resp.ParamsSchema = *d.ParamsSchema
+	}
+	return resp
+}
+
+// RespondWithParamsError answers a 400 listing each invalid field when err
+// is a *opsregistry.ParamsError from EnqueueOp, and reports whether it did.
+func RespondWithParamsError(c *gin.Context, err error) bool {
+	var perr *opsregistry.ParamsError
+	if !errors.As(err, &perr) {
+		return false
+	}
+	fields := make([]httputil.FieldError, 0, len(perr.Fields))
+	for _, f := range perr.Fields {
+		fields = append(fields, httputil.FieldError{Field: f.Field, Message: f.Message})
+	}
+	httputil.RespondWithFieldErrors(c, "invalid parameters for "+perr.DefID, fields)
+	return true
 }
 
 // parseSinceDuration parses strings like "15m", "1h", "30s", "2h30m".
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/operations_v2_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b2c3d4e5-f6a7-8b9c-0d1e-2f3a4b5c6d7e
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 package handlers_test
 
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
@@ -263,3 +264,68 @@ func TestOperationsV2Handler_OperationsSSE_StreamThenDisconnect(t *testing.T) {
 	assert.Contains(t, body, ": heartbeat")
 	assert.Contains(t, body, "event: op.created")
 }
+
+// ── OperationLogsSSE ──────────────────────────────────────────────────────
+
+func TestOperationsV2Handler_OperationLogsSSE_Finished(t *testing.T) {
+	store := databasemocks.NewMockOpsV2Store(t)
+	store.EXPECT().GetOperationV2("op1").Return(&database.OperationV2Row{ID: "op1", Status: "completed"}, nil)
+	store.EXPECT().GetOpLogsV2("op1", 5).Return([]database.OpLogV2Row{
+		{OperationID: "op1", Level: "info", Message: "scanned 3 files"},
+	}, nil)
+
+	h := handlers.NewOperationsV2Handler(store, nil, nil)
+	c, w := newOpsV2Ctx(http.MethodGet, "/operations/op1/logs/stream?tail=5", "", gin.Params{{Key: "id", Value: "op1"}})
+	h.OperationLogsSSE(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, "event: log\ndata: {\"operation_id\":\"op1\",\"level\":\"info\",\"message\":\"scanned 3 files\"")
+	assert.True(t, strings.HasSuffix(body, "event: end\ndata: {\"op_id\":\"op1\",\"status\":\"completed\"}\n\n"), body)
+}
+
+func TestOperationsV2Handler_OperationLogsSSE_Live(t *testing.T) {
+	historic := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	store := databasemocks.NewMockOpsV2Store(t)
+	store.EXPECT().GetOperationV2("op1").Return(&database.OperationV2Row{ID: "op1", Status: "running"}, nil)
+	store.EXPECT().GetOpLogsV2("op1", 1000).Return([]database.OpLogV2Row{
+		{OperationID: "op1", Level: "info", Message: "history", CreatedAt: historic},
+	}, nil)
+
+	logEvent := func(opID, message string, at time.Time) opsregistry.Event {
+		return opsregistry.Event{Name: "op.log", Payload: map[string]any{
+			"op_id": opID, "level": "info", "message": message, "created_at": at.Format(time.RFC3339Nano),
+		}}
+	}
+	ch := make(chan opsregistry.Event, 4)
+	ch <- logEvent("op1", "history", historic)
+	ch <- logEvent("op2", "other op", historic.Add(time.Second))
+	ch <- opsregistry.Event{Name: "op.updated", Payload: map[string]any{"op_id": "op1"}}
+	ch <- logEvent("op1", "live", historic.Add(time.Second))
+	close(ch)
+	var roChan <-chan opsregistry.Event = ch
+	hub := handlersmocks.NewMockOperationsEventHub(t)
+	hub.EXPECT().Subscribe().Return(roChan, func() {})
+
+	h := handlers.NewOperationsV2Handler(store, nil, hub)
+	// The closed channel ends the stream once the queued events are sent.
+	c, w := newOpsV2Ctx(http.MethodGet, "/operations/op1/logs/stream", "", gin.Params{{Key: "id", Value: "op1"}})
+	h.OperationLogsSSE(c)
+
+	body := w.Body.String()
+	assert.Equal(t, 1, strings.Count(body, `"message":"history"`), "replayed lines are not repeated live")
+	assert.Contains(t, body, `"message":"live"`)
+	assert.NotContains(t, body, "other op")
+	assert.Less(t, strings.Index(body, "history"), strings.Index(body, "live"))
+}
+
+func TestOperationsV2Handler_OperationLogsSSE_NotFound(t *testing.T) {
+	store := databasemocks.NewMockOpsV2Store(t)
+	store.EXPECT().GetOperationV2("nope").Return(nil, nil)
+
+	h := handlers.NewOperationsV2Handler(store, nil, nil)
+	c, w := newOpsV2Ctx(http.MethodGet, "/operations/nope/logs/stream", "", gin.Params{{Key: "id", Value: "nope"}})
+	h.OperationLogsSSE(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -1,10 +1,11 @@
 // file: internal/server/handlers/organize.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b3c4d5e6-f7a8-9012-bcde-f01234567890
-// last-edited: 2026-06-02
+// last-edited: 2026-10-17
 
 // Package handlers — OrganizeHandler covers the rename-preview, rename-apply,
-// organize-preview, and single-book organize HTTP endpoints.
+// organize-preview, naming-pattern preview, and single-book organize HTTP
+// endpoints.
 //
 // The concrete service types (organizer.RenameService, organizer.Service,
 // organizer.PreviewService) live in internal/organizer, which is not
@@ -194,6 +195,68 @@ func (h *OrganizeHandler) PreviewOrganize(c *gin.Context) {
 	httputil.RespondWithOK(c, preview)
 }
 
+// maxPatternPreviewBooks caps book_ids on a naming-pattern preview.
+const maxPatternPreviewBooks = 50
+
+// patternPreviewRequest is the body of POST /api/v1/organize/preview-pattern.
+// Empty patterns fall back to the configured ones; without book_ids the
+// patterns render against organizer.SamplePatternBooks.
+type patternPreviewRequest struct {
+	FolderPattern string   `json:"folder_pattern"`
+	FilePattern   string   `json:"file_pattern"`
+	BookIDs       []string `json:"book_ids"`
+}
+
+// PreviewPattern handles POST /api/v1/organize/preview-pattern.
+// Renders naming patterns against sample books or the given library books
+// so a pattern can be tried before it is saved. Nothing is moved.
+func (h *OrganizeHandler) PreviewPattern(c *gin.Context) {
+	var req patternPreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, "invalid request body")
+		return
+	}
+	if len(req.BookIDs) > maxPatternPreviewBooks {
+		httputil.RespondWithBadRequest(c, fmt.Sprintf("at most %d book_ids per preview", maxPatternPreviewBooks))
+		return
+	}
+
+	cfg := config.Snapshot()
+	if strings.TrimSpace(req.FolderPattern) == "" {
+		req.FolderPattern = cfg.FolderNamingPattern
+	}
+	if strings.TrimSpace(req.FilePattern) == "" {
+		req.FilePattern = cfg.FileNamingPattern
+	}
+
+	books := organizer.SamplePatternBooks()
+	if len(req.BookIDs) > 0 {
+		books = books[:0]
+		for _, id := range req.BookIDs {
+			book, err := h.store.GetBookByID(id)
+			if err != nil || book == nil {
+				httputil.RespondWithNotFound(c, "book", id)
+				return
+			}
+			books = append(books, book)
+		}
+	}
+
+	org := organizer.NewOrganizer(&cfg)
+	org.SetStore(h.store)
+	previews, err := org.PreviewPatterns(req.FolderPattern, req.FilePattern, books)
+	if err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+
+	httputil.RespondWithOK(c, gin.H{
+		"folder_pattern": req.FolderPattern,
+		"file_pattern":   req.FilePattern,
+		"previews":       previews,
+	})
+}
+
 // OrganizeBook handles POST /api/v1/audiobooks/:id/organize.
 // Executes the full organize pipeline for a single book, mirroring the batch
 // organize logic: re-organize-in-place for books already under rootDir,
@@ -0,0 +1,180 @@
+// file: internal/server/handlers/orphans.go
+// version: 1.0.0
+// guid: 33bb134a-131f-4d43-a9af-ef3a5c11de56
+// last-edited: 2026-10-16
+
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/importer"
+	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+	"github.com/falkcorp/audiobook-organizer/internal/reconcile"
+	servermiddleware "github.com/falkcorp/audiobook-organizer/internal/server/middleware"
+)
+
+// maxOrphanActionPaths caps the paths accepted by one bulk orphan action.
+const maxOrphanActionPaths = 500
+
+// OrphanActionRequest is the body of the bulk orphan actions.
+type OrphanActionRequest struct {
+	Paths []string `json:"paths" binding:"required"`
+	// Unignore removes the paths from the ignore list (ignore action only).
+	Unignore bool `json:"unignore"`
+}
+
+// OrphanActionResult reports one path's outcome in a bulk action.
+type OrphanActionResult struct {
+	Path   string `json:"path"`
+	OK     bool   `json:"ok"`
+	BookID string `json:"book_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (h *FilesystemHandler) orphanStore() (reconcile.OrphanStore, bool) {
+	if h.store == nil {
+		return nil, false
+	}
+	store, ok := h.store.(reconcile.OrphanStore)
+	return store, ok
+}
+
+// ListOrphanFiles handles GET /api/v1/library/orphans.
+//
+// Lists audio files under root_dir that no book record references:
+// leftovers from manual copying or failed moves. Ignored files are
+// hidden unless include_ignored=true.
+func (h *FilesystemHandler) ListOrphanFiles(c *gin.Context) {
+	store, ok := h.orphanStore()
+	if !ok {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	if h.rootDir == "" {
+		httputil.RespondWithBadRequest(c, "root_dir is not configured")
+		return
+	}
+	includeIgnored, _ := strconv.ParseBool(c.Query("include_ignored"))
+	report, err := reconcile.FindOrphanFiles(store, h.rootDir, config.AppConfig.SupportedExtensions, includeIgnored)
+	if err != nil {
+		httputil.InternalError(c, "failed to build orphan file report", err)
+		return
+	}
+	httputil.RespondWithOK(c, report)
+}
+
+// bindOrphanAction parses and validates a bulk action body, responding
+// with 400 on failure.
+func bindOrphanAction(c *gin.Context) (*OrphanActionRequest, bool) {
+	var req OrphanActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return nil, false
+	}
+	if len(req.Paths) == 0 || len(req.Paths) > maxOrphanActionPaths {
+		httputil.RespondWithValidationError(c, "paths", "must contain 1-"+strconv.Itoa(maxOrphanActionPaths)+" entries")
+		return nil, false
+	}
+	return &req, true
+}
+
+// runOrphanAction checks each path is still an orphan, applies fn and
+// responds with the per-path results.
+func (h *FilesystemHandler) runOrphanAction(c *gin.Context, req *OrphanActionRequest, fn func(path string) (bookID string, err error)) {
+	store, ok := h.orphanStore()
+	if !ok {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	checker, err := reconcile.NewOrphanChecker(store, h.rootDir)
+	if err != nil {
+		httputil.InternalError(c, "failed to load library index", err)
+		return
+	}
+	results := make([]OrphanActionResult, 0, len(req.Paths))
+	succeeded := 0
+	for _, p := range req.Paths {
+		res := OrphanActionResult{Path: p}
+		path, cerr := checker.Check(p)
+		if cerr == nil {
+			res.BookID, cerr = fn(path)
+		}
+		if cerr != nil {
+			res.Error = cerr.Error()
+		} else {
+			res.OK = true
+			succeeded++
+		}
+		results = append(results, res)
+	}
+	httputil.RespondWithOK(c, gin.H{"results": results, "succeeded": succeeded, "failed": len(results) - succeeded})
+}
+
+// ImportOrphanFiles handles POST /api/v1/library/orphans/import, importing
+// each orphan in place as a new book.
+func (h *FilesystemHandler) ImportOrphanFiles(c *gin.Context) {
+	req, ok := bindOrphanAction(c)
+	if !ok {
+		return
+	}
+	if h.fileImporter == nil {
+		httputil.RespondWithInternalError(c, "import service not initialized")
+		return
+	}
+	userID := ""
+	if user, ok := servermiddleware.CurrentUser(c); ok {
+		userID = user.ID
+	}
+	h.runOrphanAction(c, req, func(path string) (string, error) {
+		result, err := h.fileImporter.ImportFile(&importer.ImportFileRequest{FilePath: path, UserID: userID})
+		if err != nil {
+			return "", err
+		}
+		if h.publisher != nil {
+			h.publisher.Publish(c.Request.Context(), plugin.NewEvent(plugin.EventBookImported, result.ID, map[string]any{
+				"file_path": result.FilePath,
+				"source":    "orphan",
+			}))
+		}
+		return result.ID, nil
+	})
+}
+
+// IgnoreOrphanFiles handles POST /api/v1/library/orphans/ignore. Ignored
+// orphans drop out of the report until unignored.
+func (h *FilesystemHandler) IgnoreOrphanFiles(c *gin.Context) {
+	req, ok := bindOrphanAction(c)
+	if !ok {
+		return
+	}
+	store, ok := h.orphanStore()
+	if !ok {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	h.runOrphanAction(c, req, func(path string) (string, error) {
+		return "", reconcile.SetOrphanIgnored(store, path, !req.Unignore)
+	})
+}
+
+// DeleteOrphanFiles handles POST /api/v1/library/orphans/delete. Each
+// path is re-checked against the library before removal, so a file that
+// was imported since the report was built is refused.
+func (h *FilesystemHandler) DeleteOrphanFiles(c *gin.Context) {
+	req, ok := bindOrphanAction(c)
+	if !ok {
+		return
+	}
+	store, ok := h.orphanStore()
+	if !ok {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	h.runOrphanAction(c, req, func(path string) (string, error) {
+		return "", reconcile.RemoveOrphanFile(store, path)
+	})
+}
@@ -0,0 +1,87 @@
+// file: internal/server/handlers/orphans_test.go
+// version: 1.1.0
+// guid: 11641649-9f11-4670-a762-e1e3db67e451
+// last-edited: 2026-10-17
+
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphanFileHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	prev := config.AppConfig.SupportedExtensions
+	t.Cleanup(func() { config.AppConfig.SupportedExtensions = prev })
+	config.AppConfig.SupportedExtensions = []string{".m4b"}
+
+	store := storetest.New(t)
+
+	root := t.TempDir()
+	tracked := filepath.Join(root, "tracked.m4b")
+	orphan := filepath.Join(root, "stray.m4b")
+	for _, p := range []string{tracked, orphan} {
+		require.NoError(t, os.WriteFile(p, []byte("x"), 0o644))
+	}
+	storetest.Book(t, store, database.Book{Title: "Tracked", FilePath: tracked})
+
+	h := handlers.NewFilesystemHandler(store, nil, nil, nil, nil, nil, root, false)
+	r := gin.New()
+	r.GET("/library/orphans", h.ListOrphanFiles)
+	r.POST("/library/orphans/delete", h.DeleteOrphanFiles)
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "/library/orphans", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list struct {
+		Data struct {
+			Files []struct {
+				Path string `json:"path"`
+			} `json:"files"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Data.Files, 1)
+	assert.Equal(t, orphan, list.Data.Files[0].Path)
+
+	w = do(http.MethodPost, "/library/orphans/delete", gin.H{"paths": []string{tracked, orphan}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var res struct {
+		Data struct {
+			Results   []handlers.OrphanActionResult `json:"results"`
+			Succeeded int                           `json:"succeeded"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 1, res.Data.Succeeded)
+	assert.False(t, res.Data.Results[0].OK, "referenced file must not be deleted")
+	assert.FileExists(t, tracked)
+	assert.NoFileExists(t, orphan)
+
+	w = do(http.MethodPost, "/library/orphans/delete", gin.H{"paths": []string{}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
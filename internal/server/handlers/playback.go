@@ -0,0 +1,264 @@
+// file: internal/server/handlers/playback.go
+// version: 1.0.0
+// guid: 5a1c7e93-4b2d-4f60-8e15-d9b3a6c0f247
+// last-edited: 2026-10-17
+
+package handlers
+
+import (
+	"sort"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// Playback event types accepted by PUT /api/v1/audiobooks/:id/progress.
+const (
+	PlaybackEventProgress = "progress"
+	PlaybackEventStart    = "start"
+	PlaybackEventPause    = "pause"
+	PlaybackEventComplete = "complete"
+)
+
+// ProgressUpdateReq is the payload for PUT /api/v1/audiobooks/:id/progress.
+// PercentComplete (0-100) is derived from the book's duration when
+// omitted. ListenedSeconds is the time actually listened since the
+// player's previous report and feeds the listening stats.
+type ProgressUpdateReq struct {
+	SegmentID       string   `json:"segment_id,omitempty"`
+	PositionSeconds *int     `json:"position_seconds" binding:"required"`
+	PercentComplete *float64 `json:"percent_complete,omitempty"`
+	EventType       string   `json:"event_type,omitempty"`
+	PlaySpeed       float64  `json:"play_speed,omitempty"`
+	ListenedSeconds int      `json:"listened_seconds,omitempty"`
+}
+
+// ProgressEntry is one book in GET /api/v1/users/me/progress.
+type ProgressEntry struct {
+	database.PlaybackProgress
+	Title string `json:"title,omitempty"`
+}
+
+// ListeningBookStats is one book in GET /api/v1/stats/listening. PlayCount
+// and ListenSeconds count every listener of the book.
+type ListeningBookStats struct {
+	AudiobookID     string  `json:"audiobook_id"`
+	Title           string  `json:"title,omitempty"`
+	PercentComplete float64 `json:"percent_complete"`
+	PlayCount       int     `json:"play_count"`
+	ListenSeconds   int     `json:"listen_seconds"`
+}
+
+// ListeningStats is the response of GET /api/v1/stats/listening.
+type ListeningStats struct {
+	UserID          string               `json:"user_id"`
+	ListenSeconds   int                  `json:"listen_seconds"`
+	BooksInProgress int                  `json:"books_in_progress"`
+	BooksFinished   int                  `json:"books_finished"`
+	Books           []ListeningBookStats `json:"books"`
+}
+
+// PlaybackStore is the narrow database interface PlaybackHandler requires.
+type PlaybackStore interface {
+	database.PlaybackStore
+	GetBookByID(id string) (*database.Book, error)
+}
+
+// PlaybackEventSender is the *realtime.EventHub method PlaybackHandler
+// uses to broadcast playback.progress.
+type PlaybackEventSender interface {
+	SendPlaybackProgress(userID string, data map[string]any)
+}
+
+// PlaybackHandler serves playback progress and listening stats.
+type PlaybackHandler struct {
+	store PlaybackStore
+	// getEvents resolves the event hub at request time; nil or returning
+	// nil means no events are sent.
+	getEvents func() PlaybackEventSender
+}
+
+// NewPlaybackHandler constructs a PlaybackHandler.
+func NewPlaybackHandler(store PlaybackStore, getEvents func() PlaybackEventSender) *PlaybackHandler {
+	return &PlaybackHandler{store: store, getEvents: getEvents}
+}
+
+// UpdateProgress records the calling user's position in a book, logs the
+// playback event and adds to the listening stats. A "start" event counts
+// a play.
+// PUT /api/v1/audiobooks/:id/progress
+func (h *PlaybackHandler) UpdateProgress(c *gin.Context) {
+	var req ProgressUpdateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if req.EventType == "" {
+		req.EventType = PlaybackEventProgress
+	}
+	switch {
+	case *req.PositionSeconds < 0:
+		httputil.RespondWithValidationError(c, "position_seconds", "must not be negative")
+		return
+	case req.ListenedSeconds < 0:
+		httputil.RespondWithValidationError(c, "listened_seconds", "must not be negative")
+		return
+	case req.PercentComplete != nil && (*req.PercentComplete < 0 || *req.PercentComplete > 100):
+		httputil.RespondWithValidationError(c, "percent_complete", "must be between 0 and 100")
+		return
+	}
+	switch req.EventType {
+	case PlaybackEventProgress, PlaybackEventStart, PlaybackEventPause, PlaybackEventComplete:
+	default:
+		httputil.RespondWithValidationError(c, "event_type", "must be progress, start, pause or complete")
+		return
+	}
+
+	book, err := h.store.GetBookByID(c.Param("id"))
+	if err != nil {
+		httputil.InternalError(c, "failed to load audiobook", err)
+		return
+	}
+	if book == nil {
+		httputil.RespondWithNotFound(c, "audiobook", c.Param("id"))
+		return
+	}
+
+	userID := CallingUserID(c)
+	numID := database.BookNumericID(book.ID)
+	progress := &database.PlaybackProgress{
+		UserID:      userID,
+		BookID:      numID,
+		AudiobookID: book.ID,
+		SegmentID:   req.SegmentID,
+		PositionSec: *req.PositionSeconds,
+		Percent:     progressPercent(req, book),
+	}
+	if err := h.store.UpdatePlaybackProgress(progress); err != nil {
+		httputil.InternalError(c, "failed to save progress", err)
+		return
+	}
+	if err := h.store.AddPlaybackEvent(&database.PlaybackEvent{
+		UserID:      userID,
+		BookID:      numID,
+		AudiobookID: book.ID,
+		SegmentID:   req.SegmentID,
+		PositionSec: *req.PositionSeconds,
+		EventType:   req.EventType,
+		PlaySpeed:   req.PlaySpeed,
+	}); err != nil {
+		httputil.InternalError(c, "failed to record playback event", err)
+		return
+	}
+	if req.EventType == PlaybackEventStart {
+		err = h.store.IncrementBookPlayStats(numID, req.ListenedSeconds)
+	} else if req.ListenedSeconds > 0 {
+		err = database.AddBookListenSeconds(h.store, numID, req.ListenedSeconds)
+	}
+	if err == nil && req.ListenedSeconds > 0 {
+		err = h.store.IncrementUserListenStats(userID, req.ListenedSeconds)
+	}
+	if err != nil {
+		httputil.InternalError(c, "failed to update listening stats", err)
+		return
+	}
+
+	if h.getEvents != nil {
+		if events := h.getEvents(); events != nil {
+			events.SendPlaybackProgress(userID, map[string]any{
+				"audiobook_id":     book.ID,
+				"segment_id":       progress.SegmentID,
+				"position_seconds": progress.PositionSec,
+				"percent_complete": progress.Percent,
+				"event_type":       req.EventType,
+				"updated_at":       progress.UpdatedAt,
+			})
+		}
+	}
+	httputil.RespondWithOK(c, progress)
+}
+
+// progressPercent is the request's percent_complete, or the position as a
+// share of the book's duration; a "complete" event is always 100.
+func progressPercent(req ProgressUpdateReq, book *database.Book) float64 {
+	switch {
+	case req.EventType == PlaybackEventComplete:
+		return 100
+	case req.PercentComplete != nil:
+		return *req.PercentComplete
+	case book.Duration != nil && *book.Duration > 0:
+		return min(100, float64(*req.PositionSeconds)*100/float64(*book.Duration))
+	}
+	return 0
+}
+
+// ListMyProgress returns the calling user's progress on every book,
+// most recently played first.
+// GET /api/v1/users/me/progress
+func (h *PlaybackHandler) ListMyProgress(c *gin.Context) {
+	list, err := database.ListPlaybackProgress(h.store, CallingUserID(c))
+	if err != nil {
+		httputil.InternalError(c, "failed to list progress", err)
+		return
+	}
+	p := httputil.ParsePaginationParams(c)
+	total := len(list)
+	start := min(p.Offset, total)
+	end := min(start+p.Limit, total)
+	entries := make([]ProgressEntry, 0, end-start)
+	for _, pr := range list[start:end] {
+		entries = append(entries, ProgressEntry{PlaybackProgress: pr, Title: h.bookTitle(pr.AudiobookID)})
+	}
+	httputil.RespondWithOK(c, gin.H{"progress": entries, "count": len(entries), "total": total, "limit": p.Limit, "offset": p.Offset})
+}
+
+// GetListeningStats returns the calling user's listening totals and the
+// books they have progress on, most listened first.
+// GET /api/v1/stats/listening
+func (h *PlaybackHandler) GetListeningStats(c *gin.Context) {
+	userID := CallingUserID(c)
+	list, err := database.ListPlaybackProgress(h.store, userID)
+	if err != nil {
+		httputil.InternalError(c, "failed to list progress", err)
+		return
+	}
+	stats := ListeningStats{UserID: userID, Books: make([]ListeningBookStats, 0, len(list))}
+	if us, err := h.store.GetUserStats(userID); err != nil {
+		httputil.InternalError(c, "failed to load listening stats", err)
+		return
+	} else if us != nil {
+		stats.ListenSeconds = us.ListenSeconds
+	}
+	for _, pr := range list {
+		if pr.Percent >= database.FinishedThreshold*100 {
+			stats.BooksFinished++
+		} else {
+			stats.BooksInProgress++
+		}
+		entry := ListeningBookStats{
+			AudiobookID:     pr.AudiobookID,
+			Title:           h.bookTitle(pr.AudiobookID),
+			PercentComplete: pr.Percent,
+		}
+		if bs, err := h.store.GetBookStats(pr.BookID); err == nil && bs != nil {
+			entry.PlayCount, entry.ListenSeconds = bs.PlayCount, bs.ListenSeconds
+		}
+		stats.Books = append(stats.Books, entry)
+	}
+	sort.SliceStable(stats.Books, func(i, j int) bool {
+		return stats.Books[i].ListenSeconds > stats.Books[j].ListenSeconds
+	})
+	httputil.RespondWithOK(c, stats)
+}
+
+// bookTitle returns the title of a book, or "" once it is gone.
+func (h *PlaybackHandler) bookTitle(id string) string {
+	if id == "" {
+		return ""
+	}
+	if b, err := h.store.GetBookByID(id); err == nil && b != nil {
+		return b.Title
+	}
+	return ""
+}
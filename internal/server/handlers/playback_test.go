@@ -0,0 +1,113 @@
+// file: internal/server/handlers/playback_test.go
+// version: 1.0.0
+// guid: 0d6f2b84-9c1e-4a37-b5d8-3e7a1c9f6b52
+
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type playbackEvents struct {
+	sent []map[string]any
+}
+
+func (e *playbackEvents) SendPlaybackProgress(userID string, data map[string]any) {
+	data["user_id"] = userID
+	e.sent = append(e.sent, data)
+}
+
+func TestPlaybackHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := storetest.New(t)
+	duration := 1000
+	mort := storetest.Book(t, store, database.Book{Title: "Mort", FilePath: "/lib/mort.m4b", Duration: &duration})
+	dune := storetest.Book(t, store, database.Book{Title: "Dune", FilePath: "/lib/dune.m4b"})
+
+	events := &playbackEvents{}
+	h := handlers.NewPlaybackHandler(store, func() handlers.PlaybackEventSender { return events })
+	user := "userA"
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("auth_user", &database.User{ID: user}) })
+	r.PUT("/audiobooks/:id/progress", h.UpdateProgress)
+	r.GET("/users/me/progress", h.ListMyProgress)
+	r.GET("/stats/listening", h.GetListeningStats)
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPut, "/audiobooks/"+mort.ID+"/progress", gin.H{"position_seconds": 0, "event_type": "start"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = do(http.MethodPut, "/audiobooks/"+mort.ID+"/progress", gin.H{"position_seconds": 250, "listened_seconds": 250})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var saved struct {
+		Data database.PlaybackProgress `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &saved))
+	assert.Equal(t, 25.0, saved.Data.Percent, "percent derived from the book's duration")
+	assert.Equal(t, mort.ID, saved.Data.AudiobookID)
+	require.Len(t, events.sent, 2)
+	assert.Equal(t, user, events.sent[1]["user_id"])
+	assert.Equal(t, 250, events.sent[1]["position_seconds"])
+
+	w = do(http.MethodPut, "/audiobooks/"+dune.ID+"/progress", gin.H{"position_seconds": 90, "event_type": "complete", "listened_seconds": 90})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	for _, bad := range []gin.H{{}, {"position_seconds": -1}, {"position_seconds": 1, "event_type": "rewind"}, {"position_seconds": 1, "percent_complete": 101}} {
+		w = do(http.MethodPut, "/audiobooks/"+mort.ID+"/progress", bad)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%v: %s", bad, w.Body.String())
+	}
+	w = do(http.MethodPut, "/audiobooks/missing/progress", gin.H{"position_seconds": 1})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = do(http.MethodGet, "/users/me/progress", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list struct {
+		Data struct {
+			Progress []handlers.ProgressEntry `json:"progress"`
+			Total    int                      `json:"total"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 2, list.Data.Total)
+	assert.Equal(t, "Dune", list.Data.Progress[0].Title, "most recent first")
+	assert.Equal(t, 100.0, list.Data.Progress[0].Percent)
+
+	w = do(http.MethodGet, "/stats/listening", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var stats struct {
+		Data handlers.ListeningStats `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, 340, stats.Data.ListenSeconds)
+	assert.Equal(t, 1, stats.Data.BooksInProgress)
+	assert.Equal(t, 1, stats.Data.BooksFinished)
+	require.Len(t, stats.Data.Books, 2)
+	assert.Equal(t, handlers.ListeningBookStats{AudiobookID: mort.ID, Title: "Mort", PercentComplete: 25, PlayCount: 1, ListenSeconds: 250}, stats.Data.Books[0])
+	assert.Equal(t, 0, stats.Data.Books[1].PlayCount, "no start event, no play")
+
+	// Another user sees none of it.
+	user = "userB"
+	w = do(http.MethodGet, "/users/me/progress", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Zero(t, list.Data.Total)
+}
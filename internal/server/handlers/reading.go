@@ -1,7 +1,7 @@
 // file: internal/server/handlers/reading.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: b8c9d0e1-f2a3-4567-bcde-567890123456
-// last-edited: 2026-06-02
+// last-edited: 2026-10-17
 
 package handlers
 
@@ -35,6 +35,12 @@ type ReadingStore interface {
 	ListUserBookStatesByStatus(userID, status string, limit, offset int) ([]database.UserBookState, error)
 }
 
+// userBookStateCounter counts a user's books in one read status for the
+// list-by-status total. Kept out of ReadingStore so its mock doesn't need it.
+type userBookStateCounter interface {
+	CountUserBookStatesByStatus(userID, status string) (int, error)
+}
+
 // ReadingHandler handles per-user read/progress tracking endpoints.
 type ReadingHandler struct {
 	store ReadingStore
@@ -183,5 +189,17 @@ func (h *ReadingHandler) ListByStatus(c *gin.Context) {
 		httputil.InternalError(c, "failed to list states", err)
 		return
 	}
-	httputil.RespondWithOK(c, gin.H{"states": list, "count": len(list), "limit": p.Limit, "offset": p.Offset})
+	total := len(list)
+	counter, ok := h.store.(userBookStateCounter)
+	if !ok {
+		if uw, isWrapped := h.store.(interface{ Unwrap() database.Store }); isWrapped {
+			counter, ok = uw.Unwrap().(userBookStateCounter)
+		}
+	}
+	if ok {
+		if n, err := counter.CountUserBookStatesByStatus(CallingUserID(c), status); err == nil {
+			total = n
+		}
+	}
+	httputil.RespondWithOK(c, gin.H{"states": list, "count": len(list), "total": total, "limit": p.Limit, "offset": p.Offset})
 }
@@ -0,0 +1,182 @@
+// file: internal/server/handlers/system/app_logs.go
+// version: 1.1.0
+// guid: f0a7c16a-eabd-47f7-a1a3-ae87a57ce208
+// last-edited: 2026-10-17
+
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/applog"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// appLogHeartbeat is how often StreamSystemLogs writes a keep-alive
+// comment.
+const appLogHeartbeat = 30 * time.Second
+
+// appLogSource returns the application log, or nil when none is kept.
+func (h *Handler) appLogSource() AppLogSource {
+	src, _ := h.systemSvc.(AppLogSource)
+	return src
+}
+
+// appLogFilter reads the filter query parameters shared by the list and
+// stream endpoints. It responds and returns false on a bad value.
+func appLogFilter(c *gin.Context) (database.AppLogFilter, bool) {
+	f := database.AppLogFilter{
+		MinLevel: c.Query("level"),
+		Module:   c.Query("module"),
+		Search:   c.Query("search"),
+		Before:   c.Query("before"),
+	}
+	if f.MinLevel != "" && !slices.Contains(database.AppLogLevels, f.MinLevel) {
+		httputil.RespondWithValidationError(c, "level", "must be debug, info, warn or error")
+		return f, false
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"since", &f.Since}, {"until", &f.Until}} {
+		raw := c.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httputil.RespondWithValidationError(c, p.name, "must be an RFC 3339 timestamp")
+			return f, false
+		}
+		*p.dst = t
+	}
+	return f, true
+}
+
+// getAppLogs serves GET /system/logs from the application log. It reports
+// false, having written nothing, when no application log is kept.
+func (h *Handler) getAppLogs(c *gin.Context, src AppLogSource) bool {
+	f, ok := appLogFilter(c)
+	if !ok {
+		return true
+	}
+	f.Limit = httputil.ParsePaginationParams(c).Limit
+	logs, err := src.QueryAppLogs(f)
+	if errors.Is(err, applog.ErrUnavailable) {
+		return false
+	}
+	if err != nil {
+		httputil.InternalError(c, "failed to get system logs", err)
+		return true
+	}
+	if logs == nil {
+		logs = []database.AppLogEntry{}
+	}
+	resp := gin.H{"source": "application", "logs": logs, "limit": f.Limit}
+	if len(logs) == f.Limit {
+		resp["next_before"] = logs[len(logs)-1].ID
+	}
+	httputil.RespondWithOK(c, resp)
+	return true
+}
+
+// StreamSystemLogs implements GET /system/logs/stream.
+// Query: tail (stored lines replayed first, default 100, max 1000) and the
+// level, module and search filters of GET /system/logs.
+//
+// Replays the newest stored lines oldest first as "log" events, then
+// streams matching lines live as the server logs them. Each event's id is
+// the line ID, so a reconnecting EventSource, which sends Last-Event-ID,
+// only gets the lines it missed.
+func (h *Handler) StreamSystemLogs(c *gin.Context) {
+	src := h.appLogSource()
+	if src == nil {
+		httputil.RespondWithServiceUnavailable(c, applog.ErrUnavailable.Error())
+		return
+	}
+	f, ok := appLogFilter(c)
+	if !ok {
+		return
+	}
+	f.Before, f.Since, f.Until = "", time.Time{}, time.Time{}
+	f.Limit = 100
+	if raw := c.Query("tail"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			httputil.RespondWithValidationError(c, "tail", "must be a non-negative integer")
+			return
+		}
+		f.Limit = min(n, 1000)
+	}
+
+	// App log IDs are time-ordered (database.NewAppLogID), so subscribing
+	// first and then dropping live entries at or below the last replayed
+	// ID, or the client's Last-Event-ID on reconnect, loses and repeats
+	// nothing.
+	live, unsubscribe, err := src.SubscribeAppLogs()
+	if err != nil {
+		httputil.RespondWithServiceUnavailable(c, err.Error())
+		return
+	}
+	defer unsubscribe()
+	var history []database.AppLogEntry
+	if f.Limit > 0 {
+		if history, err = src.QueryAppLogs(f); err != nil {
+			httputil.InternalError(c, "failed to get system logs", err)
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	last := c.GetHeader("Last-Event-ID")
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ID <= last {
+			continue
+		}
+		writeAppLogEvent(c, history[i])
+		last = history[i].ID
+	}
+	fmt.Fprintf(c.Writer, ": heartbeat\n\n")
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(appLogHeartbeat)
+	defer heartbeat.Stop()
+	notify := c.Request.Context().Done()
+	for {
+		select {
+		case <-notify:
+			return
+		case <-heartbeat.C:
+			fmt.Fprintf(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case e, ok := <-live:
+			if !ok {
+				return
+			}
+			if e.ID <= last || !f.Matches(e) {
+				continue
+			}
+			writeAppLogEvent(c, e)
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeAppLogEvent(c *gin.Context, e database.AppLogEntry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.Writer, "id: %s\nevent: log\ndata: %s\n\n", e.ID, b)
+}
@@ -0,0 +1,125 @@
+// file: internal/server/handlers/system/app_logs_test.go
+// version: 1.0.0
+// guid: 3abe1c92-868c-485e-8279-8e8bed881482
+
+package system_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/dedup"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/system"
+	systemmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appLogService is a SystemService that also keeps an application log.
+type appLogService struct {
+	*systemmocks.MockSystemService
+	stored  []database.AppLogEntry
+	filters []database.AppLogFilter
+	live    chan database.AppLogEntry
+}
+
+func (s *appLogService) QueryAppLogs(f database.AppLogFilter) ([]database.AppLogEntry, error) {
+	s.filters = append(s.filters, f)
+	return s.stored, nil
+}
+
+func (s *appLogService) SubscribeAppLogs() (<-chan database.AppLogEntry, func(), error) {
+	return s.live, func() {}, nil
+}
+
+func newAppLogHandler(t *testing.T, svc *appLogService) *system.Handler {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	svc.MockSystemService = systemmocks.NewMockSystemService(t)
+	return system.New(
+		func() system.SystemStore { return systemmocks.NewMockSystemStore(t) },
+		svc,
+		systemmocks.NewMockConfigUpdateService(t),
+		systemmocks.NewMockPluginHealthChecker(t),
+		func() system.EventStreamer { return systemmocks.NewMockEventStreamer(t) },
+		systemmocks.NewMockOperationLogsProvider(t),
+		nil,
+		func(path string) (uint64, uint64, error) { return 0, 0, nil },
+		func() {},
+		func() string { return "test-version" },
+		func(g []dedup.AuthorDedupGroup) []dedup.AuthorDedupGroup { return g },
+		nil,
+	)
+}
+
+func TestGetSystemLogs_ApplicationLog(t *testing.T) {
+	svc := &appLogService{stored: []database.AppLogEntry{{ID: "2", Message: "new"}, {ID: "1", Message: "old"}}}
+	h := newAppLogHandler(t, svc)
+	route := func(r *gin.Engine) { r.GET("/system/logs", h.GetSystemLogs) }
+
+	w := run(http.MethodGet, "/system/logs",
+		"/system/logs?level=warn&module=scanner&search=x&since=2026-10-17T00:00:00Z&before=9&limit=2", nil, route)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Source     string                 `json:"source"`
+			Logs       []database.AppLogEntry `json:"logs"`
+			NextBefore string                 `json:"next_before"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "application", resp.Data.Source)
+	assert.Len(t, resp.Data.Logs, 2)
+	assert.Equal(t, "1", resp.Data.NextBefore, "a full page carries a cursor")
+	require.Len(t, svc.filters, 1)
+	assert.Equal(t, database.AppLogFilter{
+		MinLevel: "warn", Module: "scanner", Search: "x", Before: "9", Limit: 2,
+		Since: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+	}, svc.filters[0])
+
+	w = run(http.MethodGet, "/system/logs", "/system/logs?level=loud", nil, route)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = run(http.MethodGet, "/system/logs", "/system/logs?until=yesterday", nil, route)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestStreamSystemLogs(t *testing.T) {
+	svc := &appLogService{
+		stored: []database.AppLogEntry{{ID: "2", Level: "warn", Message: "second"}, {ID: "1", Level: "error", Message: "first"}},
+		live:   make(chan database.AppLogEntry),
+	}
+	h := newAppLogHandler(t, svc)
+	r := gin.New()
+	r.GET("/system/logs/stream", h.StreamSystemLogs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/system/logs/stream?level=warn&tail=5", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.ServeHTTP(w, req)
+	}()
+	svc.live <- database.AppLogEntry{ID: "2", Level: "warn", Message: "replayed already"}
+	svc.live <- database.AppLogEntry{ID: "3", Level: "info", Message: "below level"}
+	svc.live <- database.AppLogEntry{ID: "4", Level: "error", Message: "live"}
+	cancel()
+	<-done
+
+	body := w.Body.String()
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Contains(t, body, `"first"`)
+	assert.Less(t, strings.Index(body, `"first"`), strings.Index(body, `"second"`), "history is replayed oldest first")
+	assert.Contains(t, body, "id: 4\nevent: log\n")
+	assert.NotContains(t, body, "replayed already")
+	assert.NotContains(t, body, "below level")
+	require.Len(t, svc.filters, 1)
+	assert.Equal(t, 5, svc.filters[0].Limit)
+}
@@ -0,0 +1,36 @@
+// file: internal/server/handlers/system/blob_storage.go
+// version: 1.0.0
+// guid: 7d4b1e86-0c93-4a2f-b5e8-94f2a6c0d371
+// last-edited: 2026-10-16
+
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
+)
+
+// applyBlobStorageChange switches covers to the blob store described by
+// after when an update changed a blob_storage_* setting. A store that
+// cannot be built (missing credentials, a bad endpoint) reverts the whole
+// update, like an unbindable listen address.
+func (h *Handler) applyBlobStorageChange(before, after config.Config) error {
+	changed := false
+	for _, k := range config.ChangedKeys(before, after) {
+		if strings.HasPrefix(k, "blob_storage_") {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := covers.ConfigureBlobStore(after); err != nil {
+		h.revertConfig(before, after)
+		return fmt.Errorf("blob storage not changed: %w", err)
+	}
+	return nil
+}
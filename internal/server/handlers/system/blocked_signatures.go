@@ -0,0 +1,118 @@
+// file: internal/server/handlers/system/blocked_signatures.go
+// version: 1.0.0
+// guid: 6a0c41d2-93b7-4f5e-8d1a-2c7e94b05f36
+// last-edited: 2026-10-17
+
+package system
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// signatureBookStore is what resolving a blocked hash to its book needs.
+type signatureBookStore interface {
+	GetBookByID(id string) (*database.Book, error)
+	GetBookByFileHash(hash string) (*database.Book, error)
+	GetBookByOriginalHash(hash string) (*database.Book, error)
+	GetAuthorByID(id int) (*database.Author, error)
+}
+
+var errNoSignature = errors.New("no book with a title and duration matches this hash")
+
+// signatureForHash builds the metadata signature blocked alongside hash,
+// from bookID when given and otherwise from the book carrying the hash.
+func signatureForHash(store SystemStore, hash, bookID, reason string) (database.BlockedSignature, error) {
+	bs, ok := optionalStore[signatureBookStore](store)
+	if !ok {
+		return database.BlockedSignature{}, errNoSignature
+	}
+	var book *database.Book
+	if bookID != "" {
+		book, _ = bs.GetBookByID(bookID)
+	} else if book, _ = bs.GetBookByFileHash(hash); book == nil {
+		book, _ = bs.GetBookByOriginalHash(hash)
+	}
+	if book == nil || book.Duration == nil {
+		return database.BlockedSignature{}, errNoSignature
+	}
+	author := ""
+	if book.Author != nil {
+		author = book.Author.Name
+	} else if book.AuthorID != nil {
+		if a, err := bs.GetAuthorByID(*book.AuthorID); err == nil && a != nil {
+			author = a.Name
+		}
+	}
+	sig, ok := database.NewBlockedSignature(book.Title, author, *book.Duration, hash, reason)
+	if !ok {
+		return database.BlockedSignature{}, errNoSignature
+	}
+	return sig, nil
+}
+
+// ListBlockedSignatures returns every blocked metadata signature, newest
+// first. Implements GET /blocked-signatures.
+func (h *Handler) ListBlockedSignatures(c *gin.Context) {
+	kv, ok := h.rawKV(c)
+	if !ok {
+		return
+	}
+	sigs, err := database.ListBlockedSignatures(kv)
+	if err != nil {
+		httputil.InternalError(c, "failed to get blocked signatures", err)
+		return
+	}
+	sort.Slice(sigs, func(i, j int) bool { return sigs[i].CreatedAt.After(sigs[j].CreatedAt) })
+	httputil.RespondWithOK(c, gin.H{
+		"items": sigs,
+		"total": len(sigs),
+	})
+}
+
+// RemoveBlockedSignature unblocks one metadata signature. Implements
+// DELETE /blocked-signatures/:id.
+func (h *Handler) RemoveBlockedSignature(c *gin.Context) {
+	kv, ok := h.rawKV(c)
+	if !ok {
+		return
+	}
+	id := c.Param("id")
+	removed, err := database.RemoveBlockedSignature(kv, id)
+	if errors.Is(err, database.ErrInvalidSignatureID) {
+		httputil.RespondWithValidationError(c, "id", err.Error())
+		return
+	}
+	if err != nil {
+		httputil.InternalError(c, "failed to remove blocked signature", err)
+		return
+	}
+	if !removed {
+		httputil.RespondWithNotFound(c, "blocked signature", id)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{
+		"message": "signature unblocked successfully",
+		"id":      id,
+	})
+}
+
+// rawKV resolves the store's raw key space, responding with an error when
+// it is unavailable.
+func (h *Handler) rawKV(c *gin.Context) (database.RawKVStore, bool) {
+	store := h.resolveStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return nil, false
+	}
+	kv, ok := optionalStore[database.RawKVStore](store)
+	if !ok {
+		httputil.RespondWithInternalError(c, "signature blocklist not available")
+		return nil, false
+	}
+	return kv, true
+}
@@ -0,0 +1,130 @@
+// file: internal/server/handlers/system/blocked_signatures_test.go
+// version: 1.1.0
+// guid: b8e2d5f1-7c34-4a09-9e6b-51d0a3c7f284
+// last-edited: 2026-10-17
+
+package system_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	systemmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signatureStore adds an in-memory raw key space and a book lookup by
+// hash to the mock store.
+type signatureStore struct {
+	*systemmocks.MockSystemStore
+	kv    map[string][]byte
+	books map[string]*database.Book
+}
+
+func (s *signatureStore) SetRaw(key string, value []byte) error {
+	s.kv[key] = value
+	return nil
+}
+
+func (s *signatureStore) GetRaw(key string) ([]byte, error) { return s.kv[key], nil }
+
+func (s *signatureStore) DeleteRaw(key string) error {
+	delete(s.kv, key)
+	return nil
+}
+
+func (s *signatureStore) ScanPrefix(prefix string) ([]database.KVPair, error) {
+	var out []database.KVPair
+	for k, v := range s.kv {
+		if strings.HasPrefix(k, prefix) {
+			out = append(out, database.KVPair{Key: k, Value: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+func (s *signatureStore) CountPrefix(prefix string) (int64, error) {
+	pairs, _ := s.ScanPrefix(prefix)
+	return int64(len(pairs)), nil
+}
+
+func (s *signatureStore) GetBookByID(id string) (*database.Book, error) { return s.books[id], nil }
+
+func (s *signatureStore) GetBookByFileHash(hash string) (*database.Book, error) {
+	for _, b := range s.books {
+		if b.FileHash != nil && *b.FileHash == hash {
+			return b, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *signatureStore) GetBookByOriginalHash(string) (*database.Book, error) { return nil, nil }
+
+func (s *signatureStore) GetAuthorByID(id int) (*database.Author, error) {
+	return &database.Author{ID: id, Name: "Frank Herbert"}, nil
+}
+
+func TestBlockedSignatures(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	duration, authorID := 21*3600, 7
+	store := &signatureStore{
+		MockSystemStore: systemmocks.NewMockSystemStore(t),
+		kv:              map[string][]byte{},
+		books: map[string]*database.Book{
+			"b1": {ID: "b1", Title: "Dune", AuthorID: &authorID, Duration: &duration, FileHash: &hash},
+			"b2": {ID: "b2", Title: "Untimed"},
+		},
+	}
+	h := newStoreHandler(t, store)
+	register := func(r *gin.Engine) {
+		r.POST("/blocked-hashes", h.AddBlockedHash)
+		r.DELETE("/blocked-hashes/:hash", h.RemoveBlockedHash)
+		r.GET("/blocked-signatures", h.ListBlockedSignatures)
+		r.DELETE("/blocked-signatures/:id", h.RemoveBlockedSignature)
+	}
+	list := func() []database.BlockedSignature {
+		w := run(http.MethodGet, "", "/blocked-signatures", nil, register)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data struct {
+				Items []database.BlockedSignature `json:"items"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data.Items
+	}
+
+	w := run(http.MethodPost, "", "/blocked-hashes",
+		[]byte(`{"hash":"`+hash+`","reason":"bad rip","block_signature":true,"book_id":"b2"}`), register)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "a book without a duration has no signature")
+
+	store.EXPECT().AddBlockedHashWithAlgorithm(hash, "", "bad rip").Return(nil)
+	w = run(http.MethodPost, "", "/blocked-hashes",
+		[]byte(`{"hash":"`+hash+`","reason":"bad rip","block_signature":true}`), register)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	sigs := list()
+	require.Len(t, sigs, 1)
+	assert.Equal(t, "dune", sigs[0].Title)
+	assert.Equal(t, "frank herbert", sigs[0].Author)
+	assert.Equal(t, hash, sigs[0].Hash)
+
+	store.EXPECT().RemoveBlockedHash(hash).Return(nil)
+	w = run(http.MethodDelete, "", "/blocked-hashes/"+hash, nil, register)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"signatures_removed":1`)
+	assert.Empty(t, list())
+
+	sig, _ := database.NewBlockedSignature("Dune", "Frank Herbert", duration, "", "manual")
+	require.NoError(t, database.AddBlockedSignature(store, sig))
+	assert.Equal(t, http.StatusOK, run(http.MethodDelete, "", "/blocked-signatures/"+sig.ID, nil, register).Code)
+	assert.Equal(t, http.StatusNotFound, run(http.MethodDelete, "", "/blocked-signatures/"+sig.ID, nil, register).Code)
+	assert.Equal(t, http.StatusBadRequest, run(http.MethodDelete, "", "/blocked-signatures/nope", nil, register).Code)
+}
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/system/handler.go
-// version: 1.0.1
+// version: 1.11.0
 // guid: 8475f406-df31-4286-95b0-30787397603e
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Package system hosts the system-level HTTP handlers extracted from the server
 // package: health, status, announcements, storage, logs, activity-log,
@@ -23,12 +23,15 @@ package system
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -39,6 +42,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
 	"github.com/falkcorp/audiobook-organizer/internal/metafetch"
 	"github.com/falkcorp/audiobook-organizer/internal/policy"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
 	"github.com/falkcorp/audiobook-organizer/internal/security/pathvalidation"
 )
 
@@ -92,6 +96,14 @@ type Handler struct {
 	// the duplicates domain), which stays in package server. The controller
 	// passes s.filterReviewedAuthorGroups.
 	filterReviewedAuthorGroups func([]dedup.AuthorDedupGroup) []dedup.AuthorDedupGroup
+
+	// listener moves or restarts the HTTP listener; nil in tests that run
+	// without a server, in which case RestartServer answers 503.
+	listener ListenerController
+
+	// configMu serializes UpdateConfig so the If-Match check and the write
+	// it guards cannot interleave with another update.
+	configMu sync.Mutex
 }
 
 // New constructs a system Handler from its dependencies.
@@ -107,6 +119,7 @@ func New(
 	resetLibrarySizeCache func(),
 	appVersion func() string,
 	filterReviewedAuthorGroups func([]dedup.AuthorDedupGroup) []dedup.AuthorDedupGroup,
+	listener ListenerController,
 ) *Handler {
 	return &Handler{
 		getStore:                   getStore,
@@ -120,6 +133,7 @@ func New(
 		resetLibrarySizeCache:      resetLibrarySizeCache,
 		appVersion:                 appVersion,
 		filterReviewedAuthorGroups: filterReviewedAuthorGroups,
+		listener:                   listener,
 	}
 }
 
@@ -150,7 +164,7 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 	var dbErr error
 	var brokenFileCount int
 	if store != nil {
-		if bc, err := store.CountBooks(); err == nil {
+		if bc, err := database.CountBooksContext(c.Request.Context(), store); err == nil {
 			bookCount = bc
 		} else {
 			dbErr = err
@@ -192,7 +206,7 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 	}
 	resp := gin.H{
 		"status":        "ok",
-		"timestamp":     time.Now().Unix(),
+		"timestamp":     time.Now().UTC().Format(time.RFC3339),
 		"version":       version,
 		"database_type": config.AppConfig.DatabaseType,
 		"metrics": gin.H{
@@ -327,12 +341,21 @@ func (h *Handler) GetSystemStorage(c *gin.Context) {
 }
 
 // GetSystemLogs implements GET /system/logs.
+// Query: level (minimum), module, search, since, until (RFC 3339), before
+// (the next_before cursor of the previous page) and limit.
+//
+// Pages through the persistent application log, newest first. Without
+// one it falls back to the logs of recent operations, where level is an
+// exact match and paging is by offset.
 func (h *Handler) GetSystemLogs(c *gin.Context) {
 	// For operation-specific logs, redirect to the operations handler.
 	if id := c.Query("operation_id"); id != "" {
 		h.opLogs.GetOperationLogs(c)
 		return
 	}
+	if src := h.appLogSource(); src != nil && h.getAppLogs(c, src) {
+		return
+	}
 
 	level := c.Query("level")
 	params := httputil.ParsePaginationParams(c)
@@ -344,6 +367,7 @@ func (h *Handler) GetSystemLogs(c *gin.Context) {
 	}
 
 	httputil.RespondWithOK(c, gin.H{
+		"source": "operations",
 		"logs":   logs,
 		"limit":  params.Limit,
 		"offset": params.Offset,
@@ -456,17 +480,27 @@ func (h *Handler) FactoryReset(c *gin.Context) {
 	httputil.RespondWithOK(c, gin.H{"message": "factory reset complete"})
 }
 
-// GetConfig implements GET /config.
+// GetConfig implements GET /config. The response carries the config
+// revision as "version" and as the ETag header; send it back in If-Match on
+// PUT /config to avoid overwriting someone else's changes.
 func (h *Handler) GetConfig(c *gin.Context) {
 	// Create a copy of config with masked secrets
-	maskedConfig := config.AppConfig
+	current := config.Snapshot()
+	maskedConfig := current
 	if maskedConfig.OpenAIAPIKey != "" {
 		maskedConfig.OpenAIAPIKey = database.MaskSecret(maskedConfig.OpenAIAPIKey)
 	}
-	httputil.RespondWithOK(c, gin.H{"config": maskedConfig})
+	version := config.Revision(current)
+	c.Header("ETag", `"`+version+`"`)
+	httputil.RespondWithOK(c, gin.H{"config": maskedConfig, "version": version})
 }
 
 // UpdateConfig implements PUT /config.
+//
+// An If-Match header holding a stale config version is rejected with 412,
+// so a client editing an old copy cannot silently undo another client's
+// changes. On success the changed key names are broadcast as a
+// config.updated event.
 func (h *Handler) UpdateConfig(c *gin.Context) {
 	var payload map[string]any
 	if err := c.ShouldBindJSON(&payload); err != nil {
@@ -474,10 +508,18 @@ func (h *Handler) UpdateConfig(c *gin.Context) {
 		return
 	}
 
+	h.configMu.Lock()
+	defer h.configMu.Unlock()
+
 	// WHY Snapshot/Mutate: saving the previous config and rolling it back on
 	// error are writes to the global AppConfig; use the accessors so concurrent
 	// HTTP requests or background goroutines see a consistent value.
 	previousConfig := config.Snapshot()
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" && !etagMatches(ifMatch, config.Revision(previousConfig)) {
+		httputil.RespondWithError(c, http.StatusPreconditionFailed,
+			"configuration was changed by another client; reload and try again", "CONFIG_CONFLICT")
+		return
+	}
 	status, resp := h.configUpdate.UpdateConfig(payload)
 	if status >= 400 {
 		// Roll back to previous config under the write lock.
@@ -495,19 +537,68 @@ func (h *Handler) UpdateConfig(c *gin.Context) {
 		return
 	}
 
-	maskedConfig := h.configUpdate.MaskSecrets(config.Snapshot())
+	current := config.Snapshot()
+	listenAddr, err := h.applyListenChange(previousConfig, current)
+	if err != nil {
+		httputil.RespondWithError(c, http.StatusConflict, err.Error(), "LISTEN_FAILED")
+		return
+	}
+	if err := h.applyBlobStorageChange(previousConfig, current); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	version := config.Revision(current)
+	maskedConfig := h.configUpdate.MaskSecrets(current)
 	response := gin.H{"config": maskedConfig}
+	var flat map[string]any
 	if raw, err := json.Marshal(maskedConfig); err == nil {
-		var flat map[string]any
 		if err := json.Unmarshal(raw, &flat); err == nil {
 			for k, v := range flat {
 				response[k] = v
 			}
 		}
 	}
+	response["version"] = version
+	if listenAddr != "" {
+		response["listen_address"] = listenAddr
+	}
+	h.broadcastConfigChange(previousConfig, current, version)
+	c.Header("ETag", `"`+version+`"`)
 	httputil.RespondWithOK(c, response)
 }
 
+// broadcastConfigChange sends config.updated with the names of the keys
+// that differ between before and after. The event reaches every events
+// subscriber, so values are left out; clients allowed to read the config
+// refetch it.
+func (h *Handler) broadcastConfigChange(before, after config.Config, version string) {
+	keys := config.ChangedKeys(before, after)
+	if len(keys) == 0 {
+		return
+	}
+	sender, ok := h.resolveHub().(ConfigEventSender)
+	if !ok {
+		return
+	}
+	sender.SendConfigUpdated(version, keys)
+}
+
+// etagMatches reports whether an If-Match header value names version. It
+// accepts "*", weak validators and comma-separated lists.
+func etagMatches(header, version string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		tag = strings.TrimPrefix(tag, "W/")
+		if strings.Trim(tag, `"`) == version {
+			return true
+		}
+	}
+	return false
+}
+
 // HandleEvents handles Server-Sent Events (SSE) for real-time updates.
 // Implements GET /api/events.
 func (h *Handler) HandleEvents(c *gin.Context) {
@@ -519,6 +610,17 @@ func (h *Handler) HandleEvents(c *gin.Context) {
 	hub.HandleSSE(c)
 }
 
+// HandleWebSocket streams the same events as HandleEvents over a WebSocket.
+// Implements GET /ws.
+func (h *Handler) HandleWebSocket(c *gin.Context) {
+	hub, ok := h.resolveHub().(WebSocketStreamer)
+	if !ok {
+		httputil.RespondWithError(c, 503, "event hub not initialized", "SERVICE_UNAVAILABLE")
+		return
+	}
+	hub.HandleWebSocket(c)
+}
+
 // CreateBackup creates a database backup. Implements POST /backup/create.
 func (h *Handler) CreateBackup(c *gin.Context) {
 	var req struct {
@@ -574,24 +676,31 @@ func (h *Handler) ListBackups(c *gin.Context) {
 }
 
 // RestoreBackup restores from a backup file. Implements POST /backup/restore.
+// verify is a mode ("none", "checksum", "full"); the legacy boolean true
+// means checksum. Unless safety_backup is false, the database being
+// overwritten is backed up first. dry_run only reports what would change.
 func (h *Handler) RestoreBackup(c *gin.Context) {
 	var req struct {
-		BackupFilename string `json:"backup_filename" binding:"required"`
-		TargetPath     string `json:"target_path"`
-		Verify         bool   `json:"verify"`
+		BackupFilename string          `json:"backup_filename" binding:"required"`
+		TargetPath     string          `json:"target_path"`
+		Verify         json.RawMessage `json:"verify"`
+		DryRun         bool            `json:"dry_run"`
+		SafetyBackup   *bool           `json:"safety_backup"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		httputil.RespondWithBadRequest(c, err.Error())
 		return
 	}
-
-	backupConfig := backup.DefaultBackupConfig()
-	if dbPath := config.AppConfig.DatabasePath; dbPath != "" && !filepath.IsAbs(backupConfig.BackupDir) {
-		backupConfig.BackupDir = filepath.Join(filepath.Dir(dbPath), backupConfig.BackupDir)
+	mode, err := parseRestoreVerify(req.Verify)
+	if err != nil {
+		httputil.RespondWithValidationError(c, "verify", err.Error())
+		return
 	}
+
+	backupDir := resolveBackupDir()
 	safeFilename := pathvalidation.SanitizeFilename(req.BackupFilename)
-	backupPath := filepath.Join(backupConfig.BackupDir, safeFilename)
+	backupPath := filepath.Join(backupDir, safeFilename)
 
 	// Use current database path as target if not specified
 	var targetPath string
@@ -606,17 +715,101 @@ func (h *Handler) RestoreBackup(c *gin.Context) {
 		targetPath = filepath.Dir(config.AppConfig.DatabasePath)
 	}
 
-	if err := backup.RestoreBackup(backupPath, targetPath, req.Verify); err != nil {
+	opts := backup.RestoreOptions{
+		Verify:       mode,
+		DryRun:       req.DryRun,
+		DatabaseType: config.AppConfig.DatabaseType,
+	}
+	if req.SafetyBackup == nil || *req.SafetyBackup {
+		opts.SafetyBackupDir = backupDir
+	}
+	result, err := backup.Restore(backupPath, targetPath, opts)
+	if errors.Is(err, backup.ErrVerificationFailed) {
+		httputil.RespondWithError(c, http.StatusUnprocessableEntity, err.Error(), "VERIFICATION_FAILED")
+		return
+	}
+	if err != nil {
 		httputil.InternalError(c, "failed to restore backup", err)
 		return
 	}
 
+	message := "backup restored successfully"
+	if req.DryRun {
+		message = "dry run: nothing was restored"
+	}
 	httputil.RespondWithOK(c, gin.H{
-		"message": "backup restored successfully",
-		"target":  targetPath,
+		"message":       message,
+		"target":        targetPath,
+		"dry_run":       result.DryRun,
+		"verification":  result.Verification,
+		"changes":       result.Changes,
+		"safety_backup": result.SafetyBackup,
 	})
 }
 
+// VerifyBackup checks a stored backup against its manifest without
+// restoring it. Implements POST /backup/verify.
+func (h *Handler) VerifyBackup(c *gin.Context) {
+	var req struct {
+		BackupFilename string `json:"backup_filename" binding:"required"`
+		Mode           string `json:"mode"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = string(backup.VerifyFull)
+	}
+	mode, err := backup.ParseVerifyMode(req.Mode)
+	if err != nil {
+		httputil.RespondWithValidationError(c, "mode", err.Error())
+		return
+	}
+	filename := pathvalidation.SanitizeFilename(req.BackupFilename)
+	backupPath := filepath.Join(resolveBackupDir(), filename)
+	if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+		httputil.RespondWithNotFound(c, "backup", filename)
+		return
+	}
+	report, err := backup.VerifyBackup(backupPath, mode)
+	if err != nil {
+		httputil.InternalError(c, "failed to verify backup", err)
+		return
+	}
+	httputil.RespondWithOK(c, report)
+}
+
+// parseRestoreVerify accepts the restore verify field as a mode string or
+// the legacy boolean.
+func parseRestoreVerify(raw json.RawMessage) (backup.VerifyMode, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return backup.VerifyNone, nil
+	}
+	var legacy bool
+	if json.Unmarshal(raw, &legacy) == nil {
+		if legacy {
+			return backup.VerifyChecksum, nil
+		}
+		return backup.VerifyNone, nil
+	}
+	var mode string
+	if err := json.Unmarshal(raw, &mode); err != nil {
+		return "", fmt.Errorf("must be a boolean or one of none, checksum, full")
+	}
+	return backup.ParseVerifyMode(mode)
+}
+
+// resolveBackupDir returns the backup directory, resolved against the
+// database directory when relative.
+func resolveBackupDir() string {
+	dir := backup.DefaultBackupConfig().BackupDir
+	if dbPath := config.AppConfig.DatabasePath; dbPath != "" && !filepath.IsAbs(dir) {
+		dir = filepath.Join(filepath.Dir(dbPath), dir)
+	}
+	return dir
+}
+
 // DeleteBackup deletes a backup file. Implements DELETE /backup/:filename.
 func (h *Handler) DeleteBackup(c *gin.Context) {
 	filename := c.Param("filename")
@@ -663,33 +856,88 @@ func (h *Handler) GetDashboard(c *gin.Context) {
 
 	// Try to read broken file count from underlying store (PebbleStore)
 	brokenFileCount := 0
-	if store != nil {
-		if gf, ok := store.(interface{ GetBrokenFileCount() (int, error) }); ok {
-			if cnt, err := gf.GetBrokenFileCount(); err == nil {
-				brokenFileCount = cnt
-			}
-		} else if uw, ok := store.(interface{ Unwrap() database.Store }); ok {
-			if inner, ok2 := uw.Unwrap().(interface{ GetBrokenFileCount() (int, error) }); ok2 {
-				if cnt, err := inner.GetBrokenFileCount(); err == nil {
-					brokenFileCount = cnt
-				}
-			}
+	if gf, ok := optionalStore[interface{ GetBrokenFileCount() (int, error) }](store); ok {
+		if cnt, err := gf.GetBrokenFileCount(); err == nil {
+			brokenFileCount = cnt
 		}
 	}
 
 	httputil.RespondWithOK(c, gin.H{
-		"formatDistribution": stats.FormatDistribution,
-		"stateDistribution":  stats.StateDistribution,
-		"recentOperations":   recentOps,
-		"totalSize":          stats.TotalSize,
-		"totalBooks":         stats.TotalBooks,
-		"totalDuration":      stats.TotalDuration,
-		"organizedBooks":     stats.OrganizedBooks,
-		"unorganizedBooks":   stats.UnorganizedBooks,
-		"broken_file_count":  brokenFileCount,
+		"formatDistribution":   stats.FormatDistribution,
+		"stateDistribution":    stats.StateDistribution,
+		"sizeDistribution":     nonNilCounts(stats.SizeDistribution),
+		"durationDistribution": nonNilCounts(stats.DurationDistribution),
+		"qualityDistribution":  nonNilCounts(stats.QualityDistribution),
+		"recentOperations":     recentOps,
+		"totalSize":            stats.TotalSize,
+		"totalBooks":           stats.TotalBooks,
+		"totalDuration":        stats.TotalDuration,
+		"organizedBooks":       stats.OrganizedBooks,
+		"unorganizedBooks":     stats.UnorganizedBooks,
+		"broken_file_count":    brokenFileCount,
+		"trends":               dashboardTrends(store, stats),
 	})
 }
 
+// dashboardTrendDays is how far back the dashboard trend deltas look.
+const dashboardTrendDays = 30
+
+// DashboardTrends holds the change in headline totals since the baseline
+// snapshot (the latest one at least dashboardTrendDays old).
+type DashboardTrends struct {
+	BaselineDate   string `json:"baselineDate"`
+	TotalBooks     int    `json:"totalBooks"`
+	TotalSize      int64  `json:"totalSize"`
+	TotalDuration  int64  `json:"totalDuration"`
+	OrganizedBooks int    `json:"organizedBooks"`
+}
+
+// dashboardTrends returns the deltas versus dashboardTrendDays ago, or nil
+// when the store keeps no history or none reaches back that far.
+func dashboardTrends(store SystemStore, stats *database.DashboardStats) *DashboardTrends {
+	hs, ok := optionalStore[interface {
+		GetLibraryStatsSnapshot(asOf time.Time) (*database.LibraryStatsSnapshot, error)
+	}](store)
+	if !ok {
+		return nil
+	}
+	base, err := hs.GetLibraryStatsSnapshot(time.Now().AddDate(0, 0, -dashboardTrendDays))
+	if err != nil || base == nil {
+		return nil
+	}
+	return &DashboardTrends{
+		BaselineDate:   base.Date,
+		TotalBooks:     stats.TotalBooks - base.TotalBooks,
+		TotalSize:      stats.TotalSize - base.TotalSize,
+		TotalDuration:  stats.TotalDuration - base.TotalDuration,
+		OrganizedBooks: stats.OrganizedBooks - base.OrganizedBooks,
+	}
+}
+
+// optionalStore returns store (or the store it wraps) as T when it
+// implements that optional capability.
+func optionalStore[T any](store SystemStore) (T, bool) {
+	if v, ok := store.(T); ok {
+		return v, true
+	}
+	if uw, ok := store.(interface{ Unwrap() database.Store }); ok {
+		if v, ok := uw.Unwrap().(T); ok {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// nonNilCounts keeps distributions missing from stats cached before they
+// existed rendering as {} rather than null.
+func nonNilCounts(m map[string]int) map[string]int {
+	if m == nil {
+		return map[string]int{}
+	}
+	return m
+}
+
 // ListBlockedHashes returns all blocked hashes. Implements GET /blocked-hashes.
 func (h *Handler) ListBlockedHashes(c *gin.Context) {
 	store := h.resolveStore()
@@ -710,7 +958,8 @@ func (h *Handler) ListBlockedHashes(c *gin.Context) {
 	})
 }
 
-// AddBlockedHash adds a hash to the blocklist. Implements POST /blocked-hashes.
+// AddBlockedHash adds a hash to the blocklist and, with block_signature,
+// the metadata signature of its book. Implements POST /blocked-hashes.
 func (h *Handler) AddBlockedHash(c *gin.Context) {
 	store := h.resolveStore()
 	if store == nil {
@@ -721,6 +970,14 @@ func (h *Handler) AddBlockedHash(c *gin.Context) {
 	var req struct {
 		Hash   string `json:"hash" binding:"required"`
 		Reason string `json:"reason" binding:"required"`
+		// Algorithm names how Hash was computed, as listed next to a
+		// book's file_hash. Omitted, the hash is taken to be from the
+		// default partial mode used before algorithms were recorded.
+		Algorithm string `json:"algorithm"`
+		// BlockSignature also blocks the metadata signature of the book
+		// with this hash (or of BookID), catching re-encodes of it.
+		BlockSignature bool   `json:"block_signature"`
+		BookID         string `json:"book_id"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -728,27 +985,61 @@ func (h *Handler) AddBlockedHash(c *gin.Context) {
 		return
 	}
 
-	// Validate hash format (should be 64 character hex string for SHA256)
-	if len(req.Hash) != 64 {
+	// Validate hash format: 16 hex characters for xxhash64, 64 for SHA256.
+	if req.Algorithm != "" {
+		if _, _, ok := scanner.ParseFileHashAlgorithm(req.Algorithm); !ok {
+			httputil.RespondWithValidationError(c, "algorithm", "unknown file hash algorithm")
+			return
+		}
+	}
+	if req.Algorithm == scanner.FileHashXXHash64 {
+		if len(req.Hash) != 16 {
+			httputil.RespondWithBadRequest(c, "hash must be 16 characters (xxhash64)")
+			return
+		}
+	} else if len(req.Hash) != 64 {
 		httputil.RespondWithBadRequest(c, "hash must be 64 characters (SHA256)")
 		return
 	}
 
-	err := store.AddBlockedHash(req.Hash, req.Reason)
+	var sig *database.BlockedSignature
+	if req.BlockSignature {
+		s, err := signatureForHash(store, req.Hash, req.BookID, req.Reason)
+		if err != nil {
+			httputil.RespondWithValidationError(c, "block_signature", err.Error())
+			return
+		}
+		sig = &s
+	}
+
+	err := store.AddBlockedHashWithAlgorithm(req.Hash, req.Algorithm, req.Reason)
 	if err != nil {
 		httputil.InternalError(c, "failed to add blocked hash", err)
 		return
 	}
+	if sig != nil {
+		kv, ok := optionalStore[database.RawKVStore](store)
+		if !ok {
+			httputil.RespondWithInternalError(c, "signature blocklist not available")
+			return
+		}
+		if err := database.AddBlockedSignature(kv, *sig); err != nil {
+			httputil.InternalError(c, "failed to add blocked signature", err)
+			return
+		}
+	}
 
 	httputil.RespondWithCreated(c, gin.H{
-		"message": "hash blocked successfully",
-		"hash":    req.Hash,
-		"reason":  req.Reason,
+		"message":   "hash blocked successfully",
+		"hash":      req.Hash,
+		"algorithm": req.Algorithm,
+		"reason":    req.Reason,
+		"signature": sig,
 	})
 }
 
-// RemoveBlockedHash removes a hash from the blocklist. Implements DELETE
-// /blocked-hashes/:hash.
+// RemoveBlockedHash removes a hash, and any signature blocked with it,
+// from the blocklist. Implements DELETE /blocked-hashes/:hash.
 func (h *Handler) RemoveBlockedHash(c *gin.Context) {
 	store := h.resolveStore()
 	if store == nil {
@@ -767,10 +1058,19 @@ func (h *Handler) RemoveBlockedHash(c *gin.Context) {
 		httputil.InternalError(c, "failed to remove blocked hash", err)
 		return
 	}
+	// Signatures blocked together with the hash go with it.
+	signatures := 0
+	if kv, ok := optionalStore[database.RawKVStore](store); ok {
+		if signatures, err = database.RemoveBlockedSignaturesForHash(kv, hash); err != nil {
+			httputil.InternalError(c, "failed to remove blocked signatures", err)
+			return
+		}
+	}
 
 	httputil.RespondWithOK(c, gin.H{
-		"message": "hash unblocked successfully",
-		"hash":    hash,
+		"message":            "hash unblocked successfully",
+		"hash":               hash,
+		"signatures_removed": signatures,
 	})
 }
 
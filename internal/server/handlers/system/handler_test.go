@@ -1,7 +1,7 @@
 // file: internal/server/handlers/system/handler_test.go
-// version: 1.0.0
+// version: 1.7.0
 // guid: af6670e5-d640-4339-b0b2-3b0cf1596ce7
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Unit tests for the system-domain HTTP handlers. Each public method has at
 // least one test; happy paths plus key branches (config mask-secrets path,
@@ -77,6 +77,7 @@ func newTestHandler(t *testing.T) (*system.Handler, deps) {
 		func() {},
 		func() string { return "test-version" },
 		func(g []dedup.AuthorDedupGroup) []dedup.AuthorDedupGroup { return g },
+		nil,
 	)
 	return h, d
 }
@@ -331,6 +332,9 @@ func TestGetConfig_OK(t *testing.T) {
 	var resp map[string]any
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
 	assert.NotNil(t, resp["data"].(map[string]any)["config"])
+	version := resp["data"].(map[string]any)["version"].(string)
+	assert.Equal(t, config.Revision(config.Snapshot()), version)
+	assert.Equal(t, `"`+version+`"`, w.Header().Get("ETag"))
 }
 
 // --- UpdateConfig ---
@@ -356,6 +360,68 @@ func TestUpdateConfig_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestUpdateConfig_StaleIfMatch(t *testing.T) {
+	h, _ := newTestHandler(t)
+
+	r := gin.New()
+	r.PUT("/config", h.UpdateConfig)
+	req := httptest.NewRequest(http.MethodPut, "/config", bytes.NewReader([]byte(`{"root_dir":"/x"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"0000000000000000"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+}
+
+// configHub records config.updated broadcasts.
+type configHub struct {
+	version string
+	keys    []string
+}
+
+func (f *configHub) HandleSSE(c *gin.Context) {}
+
+func (f *configHub) SendConfigUpdated(version string, keys []string) {
+	f.version, f.keys = version, keys
+}
+
+func TestUpdateConfig_BroadcastsChangedKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	orig := config.Snapshot()
+	t.Cleanup(func() { config.Mutate(func(c *config.Config) { *c = orig }) })
+	config.Mutate(func(c *config.Config) {
+		c.DatabaseType = "pebble"
+		c.OpenAIAPIKey = ""
+	})
+
+	cfgUpd := systemmocks.NewMockConfigUpdateService(t)
+	cfgUpd.EXPECT().UpdateConfig(mock.Anything).RunAndReturn(func(map[string]any) (int, map[string]any) {
+		config.Mutate(func(c *config.Config) { c.OpenAIAPIKey = "sk-abcdefghijklmnop" })
+		return http.StatusOK, map[string]any{}
+	})
+	cfgUpd.EXPECT().MaskSecrets(mock.Anything).RunAndReturn(config.NewUpdateService(nil).MaskSecrets)
+	hub := &configHub{}
+	h := system.New(
+		func() system.SystemStore { return nil },
+		nil, cfgUpd, nil,
+		func() system.EventStreamer { return hub },
+		nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	r := gin.New()
+	r.PUT("/config", h.UpdateConfig)
+	req := httptest.NewRequest(http.MethodPut, "/config", bytes.NewReader([]byte(`{"openai_api_key":"sk-abcdefghijklmnop"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"`+config.Revision(config.Snapshot())+`"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"openai_api_key"}, hub.keys)
+	assert.Equal(t, config.Revision(config.Snapshot()), hub.version)
+	assert.Equal(t, `"`+hub.version+`"`, w.Header().Get("ETag"))
+}
+
 // --- HandleEvents ---
 
 func TestHandleEvents_NilHub503(t *testing.T) {
@@ -365,7 +431,7 @@ func TestHandleEvents_NilHub503(t *testing.T) {
 		func() system.SystemStore { return store },
 		nil, nil, nil,
 		nil, // nil getHub provider -> resolveHub() returns nil -> 503
-		nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil,
 	)
 	w := run(http.MethodGet, "/api/events", "/api/events", nil, func(r *gin.Engine) {
 		r.GET("/api/events", h.HandleEvents)
@@ -385,6 +451,14 @@ func TestHandleEvents_DelegatesToHub(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestHandleWebSocket_HubWithoutWebSocket503(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := run(http.MethodGet, "/ws", "/ws", nil, func(r *gin.Engine) {
+		r.GET("/ws", h.HandleWebSocket)
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
 // --- CreateBackup ---
 
 func TestCreateBackup_Error(t *testing.T) {
@@ -439,6 +513,36 @@ func TestRestoreBackup_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestRestoreBackup_RejectsUnknownVerifyMode(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := run(http.MethodPost, "/backup/restore", "/backup/restore", []byte(`{"backup_filename":"x.tar.gz","verify":"paranoid"}`), func(r *gin.Engine) {
+		r.POST("/backup/restore", h.RestoreBackup)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRestoreBackup_RejectsNonModeVerify(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := run(http.MethodPost, "/backup/restore", "/backup/restore", []byte(`{"backup_filename":"x.tar.gz","verify":42}`), func(r *gin.Engine) {
+		r.POST("/backup/restore", h.RestoreBackup)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// --- VerifyBackup ---
+
+func TestVerifyBackup_NotFound(t *testing.T) {
+	h, _ := newTestHandler(t)
+	prev := config.AppConfig.DatabasePath
+	config.AppConfig.DatabasePath = t.TempDir() + "/audiobooks.db"
+	defer func() { config.AppConfig.DatabasePath = prev }()
+
+	w := run(http.MethodPost, "/backup/verify", "/backup/verify", []byte(`{"backup_filename":"missing.tar.gz"}`), func(r *gin.Engine) {
+		r.POST("/backup/verify", h.VerifyBackup)
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 // --- DeleteBackup ---
 
 func TestDeleteBackup_RequiresFilename(t *testing.T) {
@@ -469,7 +573,10 @@ func TestGetDashboard_OK(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	var resp map[string]any
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Equal(t, float64(3), resp["data"].(map[string]any)["totalBooks"])
+	data := resp["data"].(map[string]any)
+	assert.Equal(t, float64(3), data["totalBooks"])
+	assert.Equal(t, map[string]any{}, data["sizeDistribution"])
+	assert.Nil(t, data["trends"], "mock store keeps no history")
 }
 
 func TestGetDashboard_StatsError(t *testing.T) {
@@ -510,7 +617,7 @@ func TestAddBlockedHash_RejectsBadLength(t *testing.T) {
 func TestAddBlockedHash_OK(t *testing.T) {
 	h, d := newTestHandler(t)
 	hash := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
-	d.store.EXPECT().AddBlockedHash(hash, "spam").Return(nil)
+	d.store.EXPECT().AddBlockedHashWithAlgorithm(hash, "", "spam").Return(nil)
 
 	w := run(http.MethodPost, "/blocked-hashes", "/blocked-hashes", []byte(`{"hash":"`+hash+`","reason":"spam"}`), func(r *gin.Engine) {
 		r.POST("/blocked-hashes", h.AddBlockedHash)
@@ -518,6 +625,25 @@ func TestAddBlockedHash_OK(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, w.Code)
 }
 
+func TestAddBlockedHash_XXHash64(t *testing.T) {
+	h, d := newTestHandler(t)
+	d.store.EXPECT().AddBlockedHashWithAlgorithm("0123456789abcdef", "xxhash64", "spam").Return(nil)
+
+	w := run(http.MethodPost, "/blocked-hashes", "/blocked-hashes", []byte(`{"hash":"0123456789abcdef","algorithm":"xxhash64","reason":"spam"}`), func(r *gin.Engine) {
+		r.POST("/blocked-hashes", h.AddBlockedHash)
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestAddBlockedHash_RejectsUnknownAlgorithm(t *testing.T) {
+	h, _ := newTestHandler(t)
+	hash := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	w := run(http.MethodPost, "/blocked-hashes", "/blocked-hashes", []byte(`{"hash":"`+hash+`","algorithm":"md5"}`), func(r *gin.Engine) {
+		r.POST("/blocked-hashes", h.AddBlockedHash)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // --- RemoveBlockedHash ---
 
 func TestRemoveBlockedHash_OK(t *testing.T) {
@@ -0,0 +1,72 @@
+// file: internal/server/handlers/system/import_decisions.go
+// version: 1.2.0
+// guid: 9db29e41-c31a-40fd-a5c8-5bf19dd52ada
+// last-edited: 2026-10-17
+
+package system
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+const (
+	defaultImportDecisionLimit = 200
+	maxImportDecisionLimit     = 1000
+)
+
+// GetImportDecisions implements GET /import-decisions.
+//
+// Query params:
+//   - path (required): a file, or a directory to list the files below it.
+//   - limit: max decisions returned (default 200, max 1000).
+//
+// Answers "why wasn't this file imported?" from the scanner's decision
+// log, which keeps the latest verdict per file: imported, unchanged,
+// unsupported_extension, excluded, blocked_hash, blocked_signature,
+// duplicate, suspicious, quota_exceeded or error, or quarantined when the
+// post-scan size check moved the book out of the library. An empty list means the scanner
+// never saw the path, usually because it is outside every import path.
+func (h *Handler) GetImportDecisions(c *gin.Context) {
+	path := strings.TrimSpace(c.Query("path"))
+	if path == "" {
+		httputil.RespondWithValidationError(c, "path", "is required")
+		return
+	}
+	path = filepath.Clean(path)
+
+	limit := defaultImportDecisionLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			httputil.RespondWithValidationError(c, "limit", "must be a positive integer")
+			return
+		}
+		limit = min(n, maxImportDecisionLimit)
+	}
+
+	store := h.getStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	ds, ok := optionalStore[database.ImportDecisionStore](store)
+	if !ok {
+		httputil.RespondWithInternalError(c, "import decision log not available")
+		return
+	}
+	decisions, err := ds.GetImportDecisions(path, limit)
+	if err != nil {
+		httputil.InternalError(c, "failed to load import decisions", err)
+		return
+	}
+	if decisions == nil {
+		decisions = []database.ImportDecision{}
+	}
+	httputil.RespondWithOK(c, gin.H{"path": path, "decisions": decisions, "count": len(decisions)})
+}
@@ -0,0 +1,102 @@
+// file: internal/server/handlers/system/import_decisions_test.go
+// version: 1.2.0
+// guid: ec94a061-ed52-4e3b-91f0-d41deb3724d1
+// last-edited: 2026-10-16
+
+package system_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/dedup"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/system"
+	systemmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decisionStore adds an in-memory import decision log to the mock store.
+type decisionStore struct {
+	*systemmocks.MockSystemStore
+	decisions []database.ImportDecision
+}
+
+func (s *decisionStore) RecordImportDecision(d database.ImportDecision) error {
+	s.decisions = append(s.decisions, d)
+	return nil
+}
+
+func (s *decisionStore) GetImportDecisions(path string, limit int) ([]database.ImportDecision, error) {
+	var out []database.ImportDecision
+	for _, d := range s.decisions {
+		if (d.Path == path || strings.HasPrefix(d.Path, path+"/")) && len(out) < limit {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+// newStoreHandler builds a handler over store, a mock extended with
+// optional store capabilities.
+func newStoreHandler(t *testing.T, store system.SystemStore) *system.Handler {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	return system.New(
+		func() system.SystemStore { return store },
+		nil, nil, nil, nil, nil, nil,
+		func(path string) (uint64, uint64, error) { return 0, 0, nil },
+		func() {},
+		func() string { return "test-version" },
+		func(g []dedup.AuthorDedupGroup) []dedup.AuthorDedupGroup { return g },
+		nil,
+	)
+}
+
+func TestGetImportDecisions(t *testing.T) {
+	store := &decisionStore{MockSystemStore: systemmocks.NewMockSystemStore(t)}
+	store.decisions = []database.ImportDecision{
+		{Path: "/lib/a/book.m4b", Decision: database.ImportDecisionImported, BookID: "b1"},
+		{Path: "/lib/a/notes.txt", Decision: database.ImportDecisionUnsupportedExtension},
+		{Path: "/lib/b/other.mp3", Decision: database.ImportDecisionBlockedHash},
+	}
+	h := newStoreHandler(t, store)
+	register := func(r *gin.Engine) { r.GET("/import-decisions", h.GetImportDecisions) }
+
+	w := run(http.MethodGet, "/import-decisions", "/import-decisions?path=/lib/a/", nil, register)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data struct {
+			Path      string                    `json:"path"`
+			Decisions []database.ImportDecision `json:"decisions"`
+			Count     int                       `json:"count"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "/lib/a", resp.Data.Path)
+	assert.Equal(t, 2, resp.Data.Count)
+	assert.Equal(t, database.ImportDecisionUnsupportedExtension, resp.Data.Decisions[1].Decision)
+
+	w = run(http.MethodGet, "/import-decisions", "/import-decisions?path=/nowhere", nil, register)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.NotNil(t, resp.Data.Decisions)
+	assert.Zero(t, resp.Data.Count)
+
+	for _, q := range []string{"", "?path=/lib&limit=0"} {
+		w = run(http.MethodGet, "/import-decisions", "/import-decisions"+q, nil, register)
+		assert.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+}
+
+func TestGetImportDecisions_NotAvailable(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := run(http.MethodGet, "/import-decisions", "/import-decisions?path=/lib", nil, func(r *gin.Engine) {
+		r.GET("/import-decisions", h.GetImportDecisions)
+	})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
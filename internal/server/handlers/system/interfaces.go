@@ -1,7 +1,7 @@
 // file: internal/server/handlers/system/interfaces.go
-// version: 1.0.0
+// version: 1.6.0
 // guid: 7a91ad40-5c96-4423-ad24-715acb791cf8
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 // Narrow dependency interfaces for the system domain handlers (health, status,
 // announcements, storage, logs, activity-log, reset/factory-reset, config
@@ -14,10 +14,12 @@
 package system
 
 import (
-	"github.com/gin-gonic/gin"
+	"time"
+
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/sysinfo"
+	"github.com/gin-gonic/gin"
 )
 
 // SystemStore is the narrow database.Store subset the system handlers require.
@@ -45,25 +47,28 @@ type SystemStore interface {
 	CountSeries() (int, error)  // StatsStore
 
 	// announcements
-	GetAllAuthors() ([]database.Author, error)                   // AuthorStore
+	GetAllAuthors() ([]database.Author, error)                        // AuthorStore
 	GetBooksByAuthorIDWithRole(authorID int) ([]database.Book, error) // AuthorStore
-	GetAllBooks(limit, offset int) ([]database.Book, error)      // BookStore
+	GetAllBooks(limit, offset int) ([]database.Book, error)           // BookStore
+
+	// import quota usage
+	ListSoftDeletedBooks(limit, offset int, olderThan *time.Time) ([]database.Book, error) // BookReader
 
 	// activity log
 	GetSystemActivityLogs(source string, limit int) ([]database.SystemActivityLog, error) // SystemActivityStore
 
 	// reset / factory-reset
-	Reset() error             // LifecycleStore
-	InvalidateLibraryStats()  // StatsStore
+	Reset() error            // LifecycleStore
+	InvalidateLibraryStats() // StatsStore
 
 	// dashboard
 	GetDashboardStats() (*database.DashboardStats, error)        // StatsStore
 	GetRecentOperations(limit int) ([]database.Operation, error) // OperationStore
 
 	// blocked hashes
-	GetAllBlockedHashes() ([]database.DoNotImport, error) // HashBlocklistStore
-	AddBlockedHash(hash, reason string) error             // HashBlocklistStore
-	RemoveBlockedHash(hash string) error                  // HashBlocklistStore
+	GetAllBlockedHashes() ([]database.DoNotImport, error)             // HashBlocklistStore
+	AddBlockedHashWithAlgorithm(hash, algorithm, reason string) error // HashBlocklistStore
+	RemoveBlockedHash(hash string) error                              // HashBlocklistStore
 
 	// user preferences
 	GetUserPreference(key string) (*database.UserPreference, error) // UserPreferenceStore
@@ -77,6 +82,15 @@ type SystemService interface {
 	CollectSystemLogs(level, search string, limit, offset int) ([]sysinfo.SystemLogEntry, int, error)
 }
 
+// AppLogSource is the optional *sysinfo.SystemService surface for the
+// persistent application log. It is type-asserted on SystemService, as
+// ConfigEventSender is on EventStreamer, so the SystemService mock keeps
+// working; without it GET /system/logs serves operation logs.
+type AppLogSource interface {
+	QueryAppLogs(f database.AppLogFilter) ([]database.AppLogEntry, error)
+	SubscribeAppLogs() (<-chan database.AppLogEntry, func(), error)
+}
+
 // ConfigUpdateService is the narrow *config.UpdateService subset used by
 // updateConfig.
 type ConfigUpdateService interface {
@@ -84,6 +98,29 @@ type ConfigUpdateService interface {
 	UpdateConfig(payload map[string]any) (int, map[string]any)
 }
 
+// ConfigEventSender is the optional *realtime.EventHub method updateConfig
+// uses to broadcast config.updated. It is type-asserted on the resolved
+// EventStreamer rather than added to it, so hubs that only stream (and the
+// EventStreamer mock) keep working.
+type ConfigEventSender interface {
+	SendConfigUpdated(version string, keys []string)
+}
+
+// WebSocketStreamer is the optional *realtime.EventHub method behind GET
+// /ws. Like ConfigEventSender it is type-asserted on the resolved
+// EventStreamer so the EventStreamer mock keeps working.
+type WebSocketStreamer interface {
+	HandleWebSocket(c *gin.Context)
+}
+
+// ListenerController is the narrow *server.Server subset that owns the
+// HTTP listener, used by RestartServer and by UpdateConfig when
+// listen_host or listen_port change.
+type ListenerController interface {
+	ListenAddr() string
+	RestartListener() (string, error)
+}
+
 // PluginHealthChecker is the narrow *plugin.Registry subset used by
 // getSystemStatus to attach plugin health to the status response.
 type PluginHealthChecker interface {
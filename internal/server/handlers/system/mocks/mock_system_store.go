@@ -5,6 +5,8 @@
 package systemmocks
 
 import (
+	"time"
+
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -36,36 +38,40 @@ func (_m *MockSystemStore) EXPECT() *MockSystemStore_Expecter {
 	return &MockSystemStore_Expecter{mock: &_m.Mock}
 }
 
-// AddBlockedHash provides a mock function for the type MockSystemStore
-func (_mock *MockSystemStore) AddBlockedHash(hash string, reason string) error {
-	ret := _mock.Called(hash, reason)
+// AddBlockedHashWithAlgorithm provides a mock function for the type MockSystemStore
+func (_mock *MockSystemStore) AddBlockedHashWithAlgorithm(hash string, algorithm string, reason string) error {
+	ret := _mock.Called(hash, algorithm, reason)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddBlockedHash")
+		panic("no return value specified for AddBlockedHashWithAlgorithm")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = returnFunc(hash, reason)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		return returnFunc(hash, algorithm, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(hash, algorithm, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSystemStore_AddBlockedHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBlockedHash'
-type MockSystemStore_AddBlockedHash_Call struct {
+// MockSystemStore_AddBlockedHashWithAlgorithm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBlockedHashWithAlgorithm'
+type MockSystemStore_AddBlockedHashWithAlgorithm_Call struct {
 	*mock.Call
 }
 
-// AddBlockedHash is a helper method to define mock.On call
+// AddBlockedHashWithAlgorithm is a helper method to define mock.On call
 //   - hash string
+//   - algorithm string
 //   - reason string
-func (_e *MockSystemStore_Expecter) AddBlockedHash(hash interface{}, reason interface{}) *MockSystemStore_AddBlockedHash_Call {
-	return &MockSystemStore_AddBlockedHash_Call{Call: _e.mock.On("AddBlockedHash", hash, reason)}
+func (_e *MockSystemStore_Expecter) AddBlockedHashWithAlgorithm(hash interface{}, algorithm interface{}, reason interface{}) *MockSystemStore_AddBlockedHashWithAlgorithm_Call {
+	return &MockSystemStore_AddBlockedHashWithAlgorithm_Call{Call: _e.mock.On("AddBlockedHashWithAlgorithm", hash, algorithm, reason)}
 }
 
-func (_c *MockSystemStore_AddBlockedHash_Call) Run(run func(hash string, reason string)) *MockSystemStore_AddBlockedHash_Call {
+func (_c *MockSystemStore_AddBlockedHashWithAlgorithm_Call) Run(run func(hash string, algorithm string, reason string)) *MockSystemStore_AddBlockedHashWithAlgorithm_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -75,20 +81,25 @@ func (_c *MockSystemStore_AddBlockedHash_Call) Run(run func(hash string, reason
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSystemStore_AddBlockedHash_Call) Return(err error) *MockSystemStore_AddBlockedHash_Call {
+func (_c *MockSystemStore_AddBlockedHashWithAlgorithm_Call) Return(err error) *MockSystemStore_AddBlockedHashWithAlgorithm_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSystemStore_AddBlockedHash_Call) RunAndReturn(run func(hash string, reason string) error) *MockSystemStore_AddBlockedHash_Call {
+func (_c *MockSystemStore_AddBlockedHashWithAlgorithm_Call) RunAndReturn(run func(hash string, algorithm string, reason string) error) *MockSystemStore_AddBlockedHashWithAlgorithm_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -940,6 +951,80 @@ func (_c *MockSystemStore_InvalidateLibraryStats_Call) RunAndReturn(run func())
 	return _c
 }
 
+// ListSoftDeletedBooks provides a mock function for the type MockSystemStore
+func (_mock *MockSystemStore) ListSoftDeletedBooks(limit int, offset int, olderThan *time.Time) ([]database.Book, error) {
+	ret := _mock.Called(limit, offset, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSoftDeletedBooks")
+	}
+
+	var r0 []database.Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, *time.Time) ([]database.Book, error)); ok {
+		return returnFunc(limit, offset, olderThan)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, *time.Time) []database.Book); ok {
+		r0 = returnFunc(limit, offset, olderThan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, *time.Time) error); ok {
+		r1 = returnFunc(limit, offset, olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSystemStore_ListSoftDeletedBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSoftDeletedBooks'
+type MockSystemStore_ListSoftDeletedBooks_Call struct {
+	*mock.Call
+}
+
+// ListSoftDeletedBooks is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - olderThan *time.Time
+func (_e *MockSystemStore_Expecter) ListSoftDeletedBooks(limit interface{}, offset interface{}, olderThan interface{}) *MockSystemStore_ListSoftDeletedBooks_Call {
+	return &MockSystemStore_ListSoftDeletedBooks_Call{Call: _e.mock.On("ListSoftDeletedBooks", limit, offset, olderThan)}
+}
+
+func (_c *MockSystemStore_ListSoftDeletedBooks_Call) Run(run func(limit int, offset int, olderThan *time.Time)) *MockSystemStore_ListSoftDeletedBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSystemStore_ListSoftDeletedBooks_Call) Return(books []database.Book, err error) *MockSystemStore_ListSoftDeletedBooks_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockSystemStore_ListSoftDeletedBooks_Call) RunAndReturn(run func(limit int, offset int, olderThan *time.Time) ([]database.Book, error)) *MockSystemStore_ListSoftDeletedBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveBlockedHash provides a mock function for the type MockSystemStore
 func (_mock *MockSystemStore) RemoveBlockedHash(hash string) error {
 	ret := _mock.Called(hash)
@@ -0,0 +1,248 @@
+// file: internal/server/handlers/system/quality.go
+// version: 1.1.0
+// guid: aed5b755-2b5d-4564-ac75-7b7b7a882852
+// last-edited: 2026-10-17
+
+package system
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// QualityGroup summarizes the audio quality of one author's or series'
+// books.
+type QualityGroup struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Books int    `json:"books"`
+	// AvgBitrate and MinBitrate (kbps) cover only books with a known
+	// bitrate; BooksWithBitrate says how many that was.
+	BooksWithBitrate int            `json:"books_with_bitrate"`
+	AvgBitrate       float64        `json:"avg_bitrate_kbps"`
+	MinBitrate       int            `json:"min_bitrate_kbps"`
+	Codecs           map[string]int `json:"codecs"`
+
+	bitrateSum int
+}
+
+// QualityItem is one of the lowest-quality books in the report.
+type QualityItem struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name,omitempty"`
+	SeriesName   string `json:"series_name,omitempty"`
+	Codec        string `json:"codec"`
+	Bitrate      int    `json:"bitrate_kbps"`
+	QualityScore *int   `json:"quality_score,omitempty"`
+}
+
+// QualityReport is the response of GET /stats/quality.
+type QualityReport struct {
+	BooksConsidered     int            `json:"books_considered"`
+	BooksWithoutBitrate int            `json:"books_without_bitrate"`
+	Codecs              map[string]int `json:"codecs"`
+	Authors             []QualityGroup `json:"authors"`
+	Series              []QualityGroup `json:"series"`
+	Lowest              []QualityItem  `json:"lowest"`
+}
+
+// QualityReportOptions bounds the report.
+type QualityReportOptions struct {
+	// GroupLimit caps the authors and series lists (worst first).
+	GroupLimit int
+	// LowestLimit caps the lowest-quality book list.
+	LowestLimit int
+	// IncludeNonPrimary counts every version instead of only the primary
+	// one of each version group.
+	IncludeNonPrimary bool
+}
+
+// BuildQualityReport groups books by author and series and ranks them by
+// average bitrate, lowest first. Books marked for deletion are skipped, as
+// are non-primary versions unless opts.IncludeNonPrimary is set. The
+// lowest list orders books by bitrate, then quality score.
+func BuildQualityReport(books []database.Book, authorNames, seriesNames map[int]string, opts QualityReportOptions) QualityReport {
+	report := QualityReport{Codecs: map[string]int{}}
+	authors := map[int]*QualityGroup{}
+	series := map[int]*QualityGroup{}
+	group := func(groups map[int]*QualityGroup, id int, names map[int]string) *QualityGroup {
+		g, ok := groups[id]
+		if !ok {
+			g = &QualityGroup{ID: id, Name: names[id], Codecs: map[string]int{}}
+			groups[id] = g
+		}
+		return g
+	}
+
+	var items []QualityItem
+	for _, b := range books {
+		if b.MarkedForDeletion != nil && *b.MarkedForDeletion {
+			continue
+		}
+		if !opts.IncludeNonPrimary && b.VersionGroupID != nil && *b.VersionGroupID != "" &&
+			b.IsPrimaryVersion != nil && !*b.IsPrimaryVersion {
+			continue
+		}
+		report.BooksConsidered++
+		codec := bookCodec(b)
+		report.Codecs[codec]++
+		bitrate := derefInt(b.Bitrate)
+		if bitrate <= 0 {
+			report.BooksWithoutBitrate++
+		}
+
+		var targets []*QualityGroup
+		if b.AuthorID != nil {
+			targets = append(targets, group(authors, *b.AuthorID, authorNames))
+		}
+		if b.SeriesID != nil {
+			targets = append(targets, group(series, *b.SeriesID, seriesNames))
+		}
+		for _, g := range targets {
+			g.Books++
+			g.Codecs[codec]++
+			if bitrate > 0 {
+				g.BooksWithBitrate++
+				g.bitrateSum += bitrate
+				if g.MinBitrate == 0 || bitrate < g.MinBitrate {
+					g.MinBitrate = bitrate
+				}
+			}
+		}
+
+		if bitrate > 0 {
+			item := QualityItem{
+				ID: b.ID, Title: b.Title, Codec: codec, Bitrate: bitrate, QualityScore: b.QualityScore,
+			}
+			if b.AuthorID != nil {
+				item.AuthorName = authorNames[*b.AuthorID]
+			}
+			if b.SeriesID != nil {
+				item.SeriesName = seriesNames[*b.SeriesID]
+			}
+			items = append(items, item)
+		}
+	}
+
+	report.Authors = rankQualityGroups(authors, opts.GroupLimit)
+	report.Series = rankQualityGroups(series, opts.GroupLimit)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Bitrate != items[j].Bitrate {
+			return items[i].Bitrate < items[j].Bitrate
+		}
+		return derefInt(items[i].QualityScore) < derefInt(items[j].QualityScore)
+	})
+	if opts.LowestLimit > 0 && len(items) > opts.LowestLimit {
+		items = items[:opts.LowestLimit]
+	}
+	if items == nil {
+		items = []QualityItem{}
+	}
+	report.Lowest = items
+	return report
+}
+
+// rankQualityGroups computes averages and orders groups worst first.
+// Groups with no known bitrate sort last.
+func rankQualityGroups(groups map[int]*QualityGroup, limit int) []QualityGroup {
+	out := make([]QualityGroup, 0, len(groups))
+	for _, g := range groups {
+		if g.BooksWithBitrate > 0 {
+			g.AvgBitrate = float64(g.bitrateSum) / float64(g.BooksWithBitrate)
+		}
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if (a.BooksWithBitrate == 0) != (b.BooksWithBitrate == 0) {
+			return b.BooksWithBitrate == 0
+		}
+		if a.AvgBitrate != b.AvgBitrate {
+			return a.AvgBitrate < b.AvgBitrate
+		}
+		return a.ID < b.ID
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// bookCodec is the lower-cased codec, falling back to the file format.
+func bookCodec(b database.Book) string {
+	if b.Codec != nil && *b.Codec != "" {
+		return strings.ToLower(*b.Codec)
+	}
+	if f := strings.ToLower(strings.TrimPrefix(b.Format, ".")); f != "" {
+		return f
+	}
+	return "unknown"
+}
+
+// GetQualityReport implements GET /stats/quality.
+//
+// Query params:
+//   - limit: max authors and series returned, worst first (default 50).
+//   - lowest: max lowest-quality books returned (default 20).
+//   - include_non_primary: "true" to count every version of a book.
+//
+// Series names are resolved when the store can list series; otherwise
+// series are reported by ID only.
+func (h *Handler) GetQualityReport(c *gin.Context) {
+	opts := QualityReportOptions{
+		GroupLimit:        50,
+		LowestLimit:       20,
+		IncludeNonPrimary: c.Query("include_non_primary") == "true",
+	}
+	for param, dst := range map[string]*int{"limit": &opts.GroupLimit, "lowest": &opts.LowestLimit} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 1000 {
+			httputil.RespondWithValidationError(c, param, "must be between 1 and 1000")
+			return
+		}
+		*dst = n
+	}
+
+	store := h.getStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	books, err := database.GetAllBooksContext(c.Request.Context(), store, 0, 0)
+	if err != nil {
+		httputil.InternalError(c, "failed to list audiobooks", err)
+		return
+	}
+	authors, err := store.GetAllAuthors()
+	if err != nil {
+		httputil.InternalError(c, "failed to list authors", err)
+		return
+	}
+	authorNames := make(map[int]string, len(authors))
+	for _, a := range authors {
+		authorNames[a.ID] = a.Name
+	}
+	seriesNames := map[int]string{}
+	if sl, ok := store.(interface {
+		GetAllSeries() ([]database.Series, error)
+	}); ok {
+		if all, err := sl.GetAllSeries(); err == nil {
+			for _, s := range all {
+				seriesNames[s.ID] = s.Name
+			}
+		}
+	}
+
+	httputil.RespondWithOK(c, BuildQualityReport(books, authorNames, seriesNames, opts))
+}
@@ -0,0 +1,103 @@
+// file: internal/server/handlers/system/quality_test.go
+// version: 1.1.0
+// guid: f603c717-6e62-4193-aed0-5eafcbfa8cd7
+// last-edited: 2026-10-16
+
+package system_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/system"
+	systemmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func qualityBooks() []database.Book {
+	ip := func(v int) *int { return &v }
+	sp := func(v string) *string { return &v }
+	bp := func(v bool) *bool { return &v }
+	return []database.Book{
+		{ID: "a1", Title: "Low", AuthorID: ip(1), SeriesID: ip(10), Codec: sp("MP3"), Bitrate: ip(64), QualityScore: ip(30)},
+		{ID: "a2", Title: "Mid", AuthorID: ip(1), SeriesID: ip(10), Codec: sp("MP3"), Bitrate: ip(128)},
+		{ID: "b1", Title: "High", AuthorID: ip(2), Codec: sp("AAC"), Bitrate: ip(256)},
+		{ID: "b2", Title: "Unknown", AuthorID: ip(2), Format: ".m4b"},
+		// Non-primary and deleted books are left out by default.
+		{ID: "b3", Title: "Alt", AuthorID: ip(2), Bitrate: ip(32), VersionGroupID: sp("g"), IsPrimaryVersion: bp(false)},
+		{ID: "b4", Title: "Gone", AuthorID: ip(2), Bitrate: ip(16), MarkedForDeletion: bp(true)},
+	}
+}
+
+func TestBuildQualityReport(t *testing.T) {
+	r := system.BuildQualityReport(qualityBooks(),
+		map[int]string{1: "Ann", 2: "Bob"}, map[int]string{10: "Saga"},
+		system.QualityReportOptions{LowestLimit: 2})
+
+	assert.Equal(t, 4, r.BooksConsidered)
+	assert.Equal(t, 1, r.BooksWithoutBitrate)
+	assert.Equal(t, map[string]int{"mp3": 2, "aac": 1, "m4b": 1}, r.Codecs)
+
+	require.Len(t, r.Authors, 2)
+	assert.Equal(t, "Ann", r.Authors[0].Name, "worst average first")
+	assert.InDelta(t, 96.0, r.Authors[0].AvgBitrate, 0.01)
+	assert.Equal(t, 64, r.Authors[0].MinBitrate)
+	assert.Equal(t, 2, r.Authors[1].Books)
+	assert.Equal(t, 1, r.Authors[1].BooksWithBitrate)
+
+	require.Len(t, r.Series, 1)
+	assert.Equal(t, "Saga", r.Series[0].Name)
+
+	require.Len(t, r.Lowest, 2)
+	assert.Equal(t, "a1", r.Lowest[0].ID)
+	assert.Equal(t, "Ann", r.Lowest[0].AuthorName)
+	assert.Equal(t, "a2", r.Lowest[1].ID)
+
+	all := system.BuildQualityReport(qualityBooks(), nil, nil, system.QualityReportOptions{IncludeNonPrimary: true})
+	assert.Equal(t, 5, all.BooksConsidered)
+	assert.Equal(t, "b3", all.Lowest[0].ID)
+}
+
+// seriesStore adds GetAllSeries to the mocked store.
+type seriesStore struct {
+	*systemmocks.MockSystemStore
+}
+
+func (seriesStore) GetAllSeries() ([]database.Series, error) {
+	return []database.Series{{ID: 10, Name: "Saga"}}, nil
+}
+
+func TestGetQualityReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := systemmocks.NewMockSystemStore(t)
+	store.EXPECT().GetAllBooks(0, 0).Return(qualityBooks(), nil)
+	store.EXPECT().GetAllAuthors().Return([]database.Author{{ID: 1, Name: "Ann"}, {ID: 2, Name: "Bob"}}, nil)
+	h := system.New(
+		func() system.SystemStore { return seriesStore{store} },
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	w := run(http.MethodGet, "/stats/quality", "/stats/quality?lowest=1", nil, func(r *gin.Engine) {
+		r.GET("/stats/quality", h.GetQualityReport)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data system.QualityReport `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Series, 1)
+	assert.Equal(t, "Saga", resp.Data.Series[0].Name)
+	assert.Len(t, resp.Data.Lowest, 1)
+}
+
+func TestGetQualityReport_BadLimit(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := run(http.MethodGet, "/stats/quality", "/stats/quality?limit=0", nil, func(r *gin.Engine) {
+		r.GET("/stats/quality", h.GetQualityReport)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
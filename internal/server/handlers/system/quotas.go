@@ -0,0 +1,30 @@
+// file: internal/server/handlers/system/quotas.go
+// version: 1.0.0
+// guid: f166249a-87b4-44c5-a656-15db58f7c3e3
+// last-edited: 2026-10-16
+
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
+)
+
+// GetImportQuotaUsage implements GET /system/import-quotas. It lists every
+// configured import quota (plus users covered only by the default user
+// quota) with current usage and whether the next import would be refused.
+func (h *Handler) GetImportQuotaUsage(c *gin.Context) {
+	store := h.getStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	statuses, err := quota.NewEnforcer(&config.AppConfig, store).Statuses()
+	if err != nil {
+		httputil.InternalError(c, "failed to compute import quota usage", err)
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{"quotas": statuses})
+}
@@ -0,0 +1,50 @@
+// file: internal/server/handlers/system/quotas_test.go
+// version: 1.1.0
+// guid: a4131107-ecef-4c27-83a3-4db757527386
+// last-edited: 2026-10-17
+
+package system_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/quota"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetImportQuotaUsage(t *testing.T) {
+	prev := config.AppConfig.ImportQuotas
+	config.AppConfig.ImportQuotas = []config.ImportQuota{{Path: "/watch", MaxBooks: 2}}
+	defer func() { config.AppConfig.ImportQuotas = prev }()
+
+	size := int64(100)
+	h, d := newTestHandler(t)
+	d.store.EXPECT().GetAllBooks(0, 0).Return([]database.Book{
+		{ID: "a", FilePath: "/watch/a.m4b", FileSize: &size},
+		{ID: "b", FilePath: "/watch/b.m4b", FileSize: &size},
+		{ID: "c", FilePath: "/watchers/c.m4b", FileSize: &size},
+	}, nil)
+	d.store.EXPECT().ListSoftDeletedBooks(0, 0, (*time.Time)(nil)).Return(nil, nil)
+
+	w := run(http.MethodGet, "/system/import-quotas", "/system/import-quotas", nil, func(r *gin.Engine) {
+		r.GET("/system/import-quotas", h.GetImportQuotaUsage)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Quotas []quota.Status `json:"quotas"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Quotas, 1)
+	assert.Equal(t, 2, resp.Data.Quotas[0].Usage.Books)
+	assert.Equal(t, int64(200), resp.Data.Quotas[0].Usage.Bytes)
+	assert.True(t, resp.Data.Quotas[0].Exceeded)
+}
@@ -0,0 +1,82 @@
+// file: internal/server/handlers/system/restart.go
+// version: 1.1.0
+// guid: c7a04e92-5d1b-4f38-9e26-b8f3d10a6c75
+// last-edited: 2026-10-17
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// RestartServer implements POST /system/restart.
+//
+// Restarts the HTTP server in process: a new server is started on the
+// configured address (listen_host / listen_port, or the startup address)
+// and the old one finishes its in-flight requests, this one included.
+// Background work, operations and the database are not touched.
+func (h *Handler) RestartServer(c *gin.Context) {
+	if h.listener == nil {
+		httputil.RespondWithError(c, http.StatusServiceUnavailable, "HTTP listener not available", "SERVICE_UNAVAILABLE")
+		return
+	}
+	// Both addresses are the resolved form of the bound socket, so a
+	// restart in place compares equal whatever host name was configured.
+	previous := h.listener.ListenAddr()
+	addr, err := h.listener.RestartListener()
+	if err != nil {
+		httputil.RespondWithError(c, http.StatusConflict, err.Error(), "LISTEN_FAILED")
+		return
+	}
+	httputil.RespondWithOK(c, gin.H{
+		"address":          addr,
+		"previous_address": previous,
+		"moved":            addr != previous,
+	})
+}
+
+// applyListenChange moves the HTTP listener when an update changed
+// listen_host or listen_port, returning the new address ("" when the
+// listener was left alone). If the new address cannot be bound the whole
+// update is reverted and saved again, so the stored config matches the
+// address actually served.
+func (h *Handler) applyListenChange(before, after config.Config) (string, error) {
+	if h.listener == nil || (before.ListenHost == after.ListenHost && before.ListenPort == after.ListenPort) {
+		return "", nil
+	}
+	addr, err := h.listener.RestartListener()
+	if err == nil {
+		return addr, nil
+	}
+	h.revertConfig(before, after)
+	return "", fmt.Errorf("listen address not changed: %w", err)
+}
+
+// revertConfig restores before and persists it by resubmitting the keys
+// that differ from after.
+func (h *Handler) revertConfig(before, after config.Config) {
+	config.Mutate(func(cfg *config.Config) { *cfg = before })
+	var all map[string]any
+	if raw, err := json.Marshal(before); err == nil {
+		_ = json.Unmarshal(raw, &all)
+	}
+	payload := map[string]any{}
+	for _, k := range config.ChangedKeys(before, after) {
+		if v, ok := all[k]; ok {
+			payload[k] = v
+		}
+	}
+	if len(payload) == 0 {
+		return
+	}
+	if status, resp := h.configUpdate.UpdateConfig(payload); status >= 400 {
+		slog.Error("failed to save reverted config", "status", status, "error", resp["error"])
+	}
+}
@@ -0,0 +1,112 @@
+// file: internal/server/handlers/system/restart_test.go
+// version: 1.1.0
+// guid: 1b6e3d90-84fa-4c27-b15e-9d20c7a4f3e8
+// last-edited: 2026-10-17
+
+package system_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/system"
+	systemmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/system/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeListener stands in for the server's HTTP listener.
+type fakeListener struct {
+	addr     string
+	err      error
+	restarts int
+}
+
+func (f *fakeListener) ListenAddr() string { return f.addr }
+
+func (f *fakeListener) RestartListener() (string, error) {
+	f.restarts++
+	if f.err != nil {
+		return "", f.err
+	}
+	if p := config.Snapshot().ListenPort; p > 0 {
+		f.addr = "0.0.0.0:9999"
+	}
+	return f.addr, nil
+}
+
+func TestRestartServer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ln := &fakeListener{addr: "127.0.0.1:8484"}
+	h := system.New(func() system.SystemStore { return nil },
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, ln)
+	register := func(r *gin.Engine) { r.POST("/system/restart", h.RestartServer) }
+
+	w := run(http.MethodPost, "/system/restart", "/system/restart", nil, register)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"address":"127.0.0.1:8484"`)
+	assert.Contains(t, w.Body.String(), `"moved":false`)
+	assert.Equal(t, 1, ln.restarts)
+
+	ln.err = errors.New("address already in use")
+	w = run(http.MethodPost, "/system/restart", "/system/restart", nil, register)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	h, _ = newTestHandler(t)
+	w = run(http.MethodPost, "/system/restart", "/system/restart", nil, func(r *gin.Engine) {
+		r.POST("/system/restart", h.RestartServer)
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestUpdateConfig_ListenPort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	orig := config.Snapshot()
+	t.Cleanup(func() { config.Mutate(func(c *config.Config) { *c = orig }) })
+	config.Mutate(func(c *config.Config) {
+		c.DatabaseType = "pebble"
+		c.ListenPort = 0
+	})
+
+	var saved []map[string]any
+	cfgUpd := systemmocks.NewMockConfigUpdateService(t)
+	cfgUpd.EXPECT().UpdateConfig(mock.Anything).RunAndReturn(func(p map[string]any) (int, map[string]any) {
+		saved = append(saved, p)
+		if port, ok := p["listen_port"].(float64); ok {
+			config.Mutate(func(c *config.Config) { c.ListenPort = int(port) })
+		}
+		return http.StatusOK, map[string]any{}
+	})
+	cfgUpd.EXPECT().MaskSecrets(mock.Anything).RunAndReturn(config.NewUpdateService(nil).MaskSecrets).Maybe()
+	ln := &fakeListener{addr: "127.0.0.1:8484"}
+	h := system.New(func() system.SystemStore { return nil },
+		nil, cfgUpd, nil, nil, nil, nil, nil, nil, nil, nil, ln)
+	update := func(body string) *httptest.ResponseRecorder {
+		return run(http.MethodPut, "/config", "/config", []byte(body), func(r *gin.Engine) {
+			r.PUT("/config", h.UpdateConfig)
+		})
+	}
+
+	w := update(`{"listen_port":9999}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"listen_address":"0.0.0.0:9999"`)
+	assert.Equal(t, 1, ln.restarts)
+
+	// Unrelated changes leave the listener alone.
+	w = update(`{"listen_port":9999}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, ln.restarts)
+
+	// A port that cannot be bound reverts and re-saves the old value.
+	ln.err = errors.New("address already in use")
+	w = update(`{"listen_port":80}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 9999, config.Snapshot().ListenPort)
+	require.Len(t, saved, 4)
+	assert.Equal(t, map[string]any{"listen_port": float64(9999)}, saved[3])
+}
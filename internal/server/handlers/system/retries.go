@@ -0,0 +1,120 @@
+// file: internal/server/handlers/system/retries.go
+// version: 1.0.0
+// guid: a5d81e2c-6f39-4b07-8c14-e2b97f03d6a1
+// last-edited: 2026-10-16
+
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/retryqueue"
+)
+
+// retryQueue resolves the store's retry queue, answering the request
+// itself when there is none.
+func (h *Handler) retryQueue(c *gin.Context) (database.RetryQueueStore, bool) {
+	store := h.getStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return nil, false
+	}
+	rq, ok := optionalStore[database.RetryQueueStore](store)
+	if !ok {
+		httputil.RespondWithInternalError(c, "retry queue not available")
+		return nil, false
+	}
+	return rq, true
+}
+
+// ListRetries implements GET /retries.
+//
+// Query params:
+//   - status: pending or exhausted (default both).
+//   - kind: scan or organize (default both).
+//
+// Lists files whose scan or organize step failed for a transient reason,
+// soonest next attempt first, with exhausted entries last.
+func (h *Handler) ListRetries(c *gin.Context) {
+	status, kind := c.Query("status"), c.Query("kind")
+	if status != "" && status != database.RetryStatusPending && status != database.RetryStatusExhausted {
+		httputil.RespondWithValidationError(c, "status", "must be pending or exhausted")
+		return
+	}
+	if kind != "" && kind != database.RetryKindScan && kind != database.RetryKindOrganize {
+		httputil.RespondWithValidationError(c, "kind", "must be scan or organize")
+		return
+	}
+	rq, ok := h.retryQueue(c)
+	if !ok {
+		return
+	}
+	all, err := rq.ListRetryEntries()
+	if err != nil {
+		httputil.InternalError(c, "failed to load retry queue", err)
+		return
+	}
+	entries := []database.RetryEntry{}
+	pending := 0
+	for _, e := range all {
+		if (status != "" && e.Status != status) || (kind != "" && e.Kind != kind) {
+			continue
+		}
+		if e.Status == database.RetryStatusPending {
+			pending++
+		}
+		entries = append(entries, e)
+	}
+	httputil.RespondWithOK(c, gin.H{
+		"retries":   entries,
+		"count":     len(entries),
+		"pending":   pending,
+		"exhausted": len(entries) - pending,
+	})
+}
+
+// RetryNow implements POST /retries/:id/retry. The entry becomes due at
+// once (an exhausted one with a fresh attempt budget) and is picked up by
+// the next retry_failed_files run, within a minute.
+func (h *Handler) RetryNow(c *gin.Context) {
+	rq, ok := h.retryQueue(c)
+	if !ok {
+		return
+	}
+	id := c.Param("id")
+	e, err := retryqueue.Requeue(rq, id)
+	if err != nil {
+		httputil.InternalError(c, "failed to requeue retry", err)
+		return
+	}
+	if e == nil {
+		httputil.RespondWithNotFound(c, "retry", id)
+		return
+	}
+	httputil.RespondWithOK(c, e)
+}
+
+// DismissRetry implements DELETE /retries/:id, dropping the entry without
+// retrying it.
+func (h *Handler) DismissRetry(c *gin.Context) {
+	rq, ok := h.retryQueue(c)
+	if !ok {
+		return
+	}
+	id := c.Param("id")
+	e, err := rq.GetRetryEntry(id)
+	if err != nil {
+		httputil.InternalError(c, "failed to load retry", err)
+		return
+	}
+	if e == nil {
+		httputil.RespondWithNotFound(c, "retry", id)
+		return
+	}
+	if err := rq.DeleteRetryEntry(id); err != nil {
+		httputil.InternalError(c, "failed to dismiss retry", err)
+		return
+	}
+	httputil.RespondWithNoContent(c)
+}
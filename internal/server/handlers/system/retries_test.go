@@ -0,0 +1,112 @@
+// file: internal/server/handlers/system/retries_test.go
+// version: 1.0.0
+// guid: 3f8e0b27-d15a-4c69-a7e2-6b94c1d08f53
+// last-edited: 2026-10-16
+
+package system_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	systemmocks "github.com/falkcorp/audiobook-organizer/internal/server/handlers/system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// retryStore adds an in-memory retry queue to the mock store.
+type retryStore struct {
+	*systemmocks.MockSystemStore
+	entries map[string]database.RetryEntry
+}
+
+func (s *retryStore) PutRetryEntry(e database.RetryEntry) error {
+	s.entries[e.ID] = e
+	return nil
+}
+
+func (s *retryStore) GetRetryEntry(id string) (*database.RetryEntry, error) {
+	e, ok := s.entries[id]
+	if !ok {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (s *retryStore) ListRetryEntries() ([]database.RetryEntry, error) {
+	var out []database.RetryEntry
+	for _, id := range []string{"scan-1", "organize-1"} {
+		if e, ok := s.entries[id]; ok {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (s *retryStore) DeleteRetryEntry(id string) error {
+	delete(s.entries, id)
+	return nil
+}
+
+func TestRetries(t *testing.T) {
+	store := &retryStore{
+		MockSystemStore: systemmocks.NewMockSystemStore(t),
+		entries: map[string]database.RetryEntry{
+			"scan-1":     {ID: "scan-1", Kind: database.RetryKindScan, Path: "/nas/a.m4b", Status: database.RetryStatusPending, Attempts: 1},
+			"organize-1": {ID: "organize-1", Kind: database.RetryKindOrganize, BookID: "b1", Status: database.RetryStatusExhausted, Attempts: 5},
+		},
+	}
+	h := newStoreHandler(t, store)
+	register := func(r *gin.Engine) {
+		r.GET("/retries", h.ListRetries)
+		r.POST("/retries/:id/retry", h.RetryNow)
+		r.DELETE("/retries/:id", h.DismissRetry)
+	}
+
+	var resp struct {
+		Data struct {
+			Retries   []database.RetryEntry `json:"retries"`
+			Count     int                   `json:"count"`
+			Pending   int                   `json:"pending"`
+			Exhausted int                   `json:"exhausted"`
+		} `json:"data"`
+	}
+	w := run(http.MethodGet, "/retries", "/retries", nil, register)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Data.Count)
+	assert.Equal(t, 1, resp.Data.Exhausted)
+
+	w = run(http.MethodGet, "/retries", "/retries?status=exhausted", nil, register)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Data.Count)
+	assert.Equal(t, "b1", resp.Data.Retries[0].BookID)
+
+	w = run(http.MethodGet, "/retries", "/retries?kind=bogus", nil, register)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = run(http.MethodPost, "/retries/:id/retry", "/retries/organize-1/retry", nil, register)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, database.RetryStatusPending, store.entries["organize-1"].Status)
+	assert.Zero(t, store.entries["organize-1"].Attempts)
+
+	w = run(http.MethodPost, "/retries/:id/retry", "/retries/nope/retry", nil, register)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = run(http.MethodDelete, "/retries/:id", "/retries/scan-1", nil, register)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.NotContains(t, store.entries, "scan-1")
+	w = run(http.MethodDelete, "/retries/:id", "/retries/scan-1", nil, register)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRetries_NotAvailable(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := run(http.MethodGet, "/retries", "/retries", nil, func(r *gin.Engine) {
+		r.GET("/retries", h.ListRetries)
+	})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,54 @@
+// file: internal/server/handlers/system/slow_queries.go
+// version: 1.0.0
+// guid: 0d4a7e92-3c61-4b58-8f2e-b15c9a6d7e04
+// last-edited: 2026-10-16
+
+package system
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// GetSlowQueries implements GET /system/slow-queries: per-method database
+// timings since startup, slowest in total first, and the most recent
+// calls over slow_query_threshold_ms.
+//
+// Query params:
+//   - limit: methods listed (default 50, max 1000).
+//
+// When the slow-query log is disabled, enabled is false and both lists
+// are empty.
+func (h *Handler) GetSlowQueries(c *gin.Context) {
+	limit := 50
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 1000 {
+			httputil.RespondWithValidationError(c, "limit", "must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+	timed, ok := database.AsTimedStore(h.getStore())
+	if !ok {
+		httputil.RespondWithOK(c, gin.H{
+			"enabled": false,
+			"methods": []database.SlowQueryMethodStats{},
+			"recent":  []database.SlowQuery{},
+		})
+		return
+	}
+	report := timed.SlowQueryReport()
+	if len(report.Methods) > limit {
+		report.Methods = report.Methods[:limit]
+	}
+	httputil.RespondWithOK(c, gin.H{
+		"enabled":      true,
+		"threshold_ms": report.ThresholdMs,
+		"methods":      report.Methods,
+		"recent":       report.Recent,
+	})
+}
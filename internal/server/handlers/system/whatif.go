@@ -0,0 +1,236 @@
+// file: internal/server/handlers/system/whatif.go
+// version: 1.1.0
+// guid: 1553274c-473d-444a-b8fb-7a1e82148dd7
+// last-edited: 2026-10-17
+
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// whatIfTopN caps the per-book breakdown returned with each estimate.
+const whatIfTopN = 20
+
+// WhatIfConvertOptions selects the books a hypothetical transcode would
+// touch and the encoding they would end up in.
+type WhatIfConvertOptions struct {
+	// Codec limits the estimate to books in this codec (case-insensitive;
+	// falls back to the file format when no codec is recorded). Empty
+	// matches every codec.
+	Codec string
+	// MinBitrate selects books strictly above this bitrate (kbps).
+	MinBitrate int
+	// TargetCodec is reported back as-is; the estimate only depends on
+	// TargetBitrate.
+	TargetCodec   string
+	TargetBitrate int
+}
+
+// WhatIfBook is one book's contribution to an estimate.
+type WhatIfBook struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	CurrentBytes   int64  `json:"current_bytes"`
+	EstimatedBytes int64  `json:"estimated_bytes"`
+}
+
+// WhatIfEstimate is the projected effect of one action on library size.
+type WhatIfEstimate struct {
+	Action        string `json:"action"`
+	Description   string `json:"description"`
+	BooksAffected int    `json:"books_affected"`
+	// BooksSkipped counts matching books with too little media info (no
+	// size, or no bitrate/duration) to estimate.
+	BooksSkipped   int          `json:"books_skipped"`
+	CurrentBytes   int64        `json:"current_bytes"`
+	EstimatedBytes int64        `json:"estimated_bytes"`
+	SavingsBytes   int64        `json:"savings_bytes"`
+	TopBooks       []WhatIfBook `json:"top_books"`
+}
+
+// EstimateConvert projects the size change from transcoding every book
+// matching opts to opts.TargetBitrate. New sizes come from duration ×
+// target bitrate when the duration is known, otherwise from scaling the
+// current size by target/current bitrate. Books already at or below the
+// target bitrate are left out.
+func EstimateConvert(books []database.Book, opts WhatIfConvertOptions) WhatIfEstimate {
+	est := WhatIfEstimate{
+		Action: "convert",
+		Description: fmt.Sprintf("Convert %s books above %dkbps to %dkbps %s",
+			firstNonEmptyStr(opts.Codec, "all"), opts.MinBitrate, opts.TargetBitrate, firstNonEmptyStr(opts.TargetCodec, "opus")),
+	}
+	for _, b := range books {
+		if !codecMatches(b, opts.Codec) {
+			continue
+		}
+		bitrate := derefInt(b.Bitrate)
+		if b.Bitrate != nil && (bitrate <= opts.MinBitrate || bitrate <= opts.TargetBitrate) {
+			continue
+		}
+		current := derefInt64(b.FileSize)
+		if bitrate <= 0 || current <= 0 {
+			est.BooksSkipped++
+			continue
+		}
+		var estimated int64
+		if d := derefInt(b.Duration); d > 0 {
+			estimated = int64(d) * int64(opts.TargetBitrate) * 1000 / 8
+		} else {
+			estimated = current * int64(opts.TargetBitrate) / int64(bitrate)
+		}
+		if estimated > current {
+			estimated = current
+		}
+		est.add(b, current, estimated)
+	}
+	est.finish()
+	return est
+}
+
+// EstimateDeleteNonPrimary projects the space freed by deleting every
+// version-grouped book that is not its group's primary.
+func EstimateDeleteNonPrimary(books []database.Book) WhatIfEstimate {
+	est := WhatIfEstimate{
+		Action:      "delete-non-primary",
+		Description: "Delete non-primary versions",
+	}
+	for _, b := range books {
+		if b.VersionGroupID == nil || *b.VersionGroupID == "" {
+			continue
+		}
+		if b.IsPrimaryVersion != nil && *b.IsPrimaryVersion {
+			continue
+		}
+		current := derefInt64(b.FileSize)
+		if current <= 0 {
+			est.BooksSkipped++
+			continue
+		}
+		est.add(b, current, 0)
+	}
+	est.finish()
+	return est
+}
+
+func (e *WhatIfEstimate) add(b database.Book, current, estimated int64) {
+	e.BooksAffected++
+	e.CurrentBytes += current
+	e.EstimatedBytes += estimated
+	e.TopBooks = append(e.TopBooks, WhatIfBook{ID: b.ID, Title: b.Title, CurrentBytes: current, EstimatedBytes: estimated})
+}
+
+// finish computes the savings and trims TopBooks to the largest savers.
+func (e *WhatIfEstimate) finish() {
+	e.SavingsBytes = e.CurrentBytes - e.EstimatedBytes
+	sort.SliceStable(e.TopBooks, func(i, j int) bool {
+		return e.TopBooks[i].CurrentBytes-e.TopBooks[i].EstimatedBytes >
+			e.TopBooks[j].CurrentBytes-e.TopBooks[j].EstimatedBytes
+	})
+	if len(e.TopBooks) > whatIfTopN {
+		e.TopBooks = e.TopBooks[:whatIfTopN]
+	}
+	if e.TopBooks == nil {
+		e.TopBooks = []WhatIfBook{}
+	}
+}
+
+func codecMatches(b database.Book, codec string) bool {
+	if codec == "" {
+		return true
+	}
+	if b.Codec != nil && *b.Codec != "" {
+		return strings.EqualFold(*b.Codec, codec)
+	}
+	return strings.EqualFold(strings.TrimPrefix(b.Format, "."), codec)
+}
+
+func derefInt(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func derefInt64(p *int64) int64 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func firstNonEmptyStr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// GetStorageWhatIf implements GET /stats/what-if.
+//
+// Query params:
+//   - action: "convert" or "delete-non-primary"; omitted returns both
+//     with defaults.
+//   - codec, min_bitrate, target_codec, target_bitrate: convert options
+//     (defaults: any codec, above 128kbps, to 64kbps opus).
+//
+// Nothing is modified; the estimates come from the stored media info.
+func (h *Handler) GetStorageWhatIf(c *gin.Context) {
+	action := c.Query("action")
+	switch action {
+	case "", "convert", "delete-non-primary":
+	default:
+		httputil.RespondWithBadRequest(c, "action must be one of: convert, delete-non-primary")
+		return
+	}
+
+	opts := WhatIfConvertOptions{
+		Codec:         strings.TrimSpace(c.Query("codec")),
+		MinBitrate:    128,
+		TargetCodec:   firstNonEmptyStr(strings.TrimSpace(c.Query("target_codec")), "opus"),
+		TargetBitrate: 64,
+	}
+	for param, dst := range map[string]*int{"min_bitrate": &opts.MinBitrate, "target_bitrate": &opts.TargetBitrate} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			httputil.RespondWithValidationError(c, param, "must be a non-negative integer")
+			return
+		}
+		*dst = n
+	}
+	if opts.TargetBitrate <= 0 {
+		httputil.RespondWithValidationError(c, "target_bitrate", "must be greater than 0")
+		return
+	}
+
+	store := h.getStore()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	books, err := database.GetAllBooksContext(c.Request.Context(), store, 0, 0)
+	if err != nil {
+		httputil.InternalError(c, "failed to list audiobooks", err)
+		return
+	}
+
+	estimates := []WhatIfEstimate{}
+	if action == "" || action == "convert" {
+		estimates = append(estimates, EstimateConvert(books, opts))
+	}
+	if action == "" || action == "delete-non-primary" {
+		estimates = append(estimates, EstimateDeleteNonPrimary(books))
+	}
+	httputil.RespondWithOK(c, gin.H{"estimates": estimates})
+}
@@ -0,0 +1,85 @@
+// file: internal/server/handlers/system/whatif_test.go
+// version: 1.0.0
+// guid: dc359e55-8ac3-4f2b-b95a-2da8d929e717
+// last-edited: 2026-10-16
+
+package system_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/server/handlers/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func whatIfBooks() []database.Book {
+	ip := func(v int) *int { return &v }
+	i64 := func(v int64) *int64 { return &v }
+	sp := func(v string) *string { return &v }
+	bp := func(v bool) *bool { return &v }
+	return []database.Book{
+		// 10h at 192kbps MP3 → 10h at 64kbps = 288MB.
+		{ID: "mp3-hi", Codec: sp("MP3"), Bitrate: ip(192), Duration: ip(36000), FileSize: i64(864_000_000)},
+		// No duration: scaled by bitrate, 256 → 64 = quarter size.
+		{ID: "mp3-nodur", Codec: sp("MP3"), Bitrate: ip(256), FileSize: i64(400_000_000)},
+		// At the threshold: excluded.
+		{ID: "mp3-128", Codec: sp("MP3"), Bitrate: ip(128), FileSize: i64(100_000_000)},
+		// Wrong codec when filtering on mp3.
+		{ID: "aac", Codec: sp("AAC"), Bitrate: ip(256), FileSize: i64(500_000_000),
+			VersionGroupID: sp("g1"), IsPrimaryVersion: bp(false)},
+		// Matching codec but no bitrate: skipped.
+		{ID: "mp3-unknown", Format: "mp3", FileSize: i64(50_000_000),
+			VersionGroupID: sp("g1"), IsPrimaryVersion: bp(true)},
+	}
+}
+
+func TestEstimateConvert(t *testing.T) {
+	est := system.EstimateConvert(whatIfBooks(), system.WhatIfConvertOptions{
+		Codec: "mp3", MinBitrate: 128, TargetCodec: "opus", TargetBitrate: 64,
+	})
+	assert.Equal(t, 2, est.BooksAffected)
+	assert.Equal(t, 1, est.BooksSkipped)
+	assert.Equal(t, int64(1_264_000_000), est.CurrentBytes)
+	assert.Equal(t, int64(288_000_000+100_000_000), est.EstimatedBytes)
+	assert.Equal(t, int64(876_000_000), est.SavingsBytes)
+	require.Len(t, est.TopBooks, 2)
+	assert.Equal(t, "mp3-hi", est.TopBooks[0].ID)
+}
+
+func TestEstimateDeleteNonPrimary(t *testing.T) {
+	est := system.EstimateDeleteNonPrimary(whatIfBooks())
+	assert.Equal(t, 1, est.BooksAffected)
+	assert.Equal(t, int64(500_000_000), est.SavingsBytes)
+}
+
+func TestGetStorageWhatIf(t *testing.T) {
+	h, d := newTestHandler(t)
+	d.store.EXPECT().GetAllBooks(0, 0).Return(whatIfBooks(), nil)
+
+	w := run(http.MethodGet, "/stats/what-if", "/stats/what-if", nil, func(r *gin.Engine) {
+		r.GET("/stats/what-if", h.GetStorageWhatIf)
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data struct {
+			Estimates []system.WhatIfEstimate `json:"estimates"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Estimates, 2)
+	assert.Equal(t, "convert", resp.Data.Estimates[0].Action)
+	assert.Equal(t, "delete-non-primary", resp.Data.Estimates[1].Action)
+}
+
+func TestGetStorageWhatIf_BadAction(t *testing.T) {
+	h, _ := newTestHandler(t)
+	w := run(http.MethodGet, "/stats/what-if", "/stats/what-if?action=shrink", nil, func(r *gin.Engine) {
+		r.GET("/stats/what-if", h.GetStorageWhatIf)
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
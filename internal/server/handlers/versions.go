@@ -1,7 +1,7 @@
 // file: internal/server/handlers/versions.go
-// version: 1.0.0
+// version: 1.2.0
 // guid: 7e3c1a92-4b8d-4f60-9a2e-1c0d5f8b6a47
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 
 package handlers
 
@@ -23,6 +23,7 @@ import (
 // actually call, including the external-ID methods used by
 // reassignExternalIDsForFiles.
 type VersionsStore interface {
+	GetAllBooks(limit, offset int) ([]database.Book, error)
 	GetBookByID(id string) (*database.Book, error)
 	GetBooksByVersionGroup(groupID string) ([]database.Book, error)
 	CreateBook(book *database.Book) (*database.Book, error)
@@ -146,33 +147,145 @@ func (h *VersionsHandler) SetAudiobookPrimary(c *gin.Context) {
 		return
 	}
 
+	if err := h.setPrimary(book); err != nil {
+		httputil.RespondWithInternalError(c, err.Error())
+		return
+	}
+
+	httputil.RespondWithOK(c, gin.H{"message": "audiobook set as primary"})
+}
+
+// setPrimary makes book the primary of its version group (or marks it
+// primary when ungrouped) and clears any upgrade-available flags in the
+// group, since they were relative to the old primary.
+func (h *VersionsHandler) setPrimary(book *database.Book) error {
 	if book.VersionGroupID == nil {
 		primaryFlag := true
 		book.IsPrimaryVersion = &primaryFlag
-		if _, err := h.store.UpdateBook(id, book); err != nil {
-			httputil.RespondWithInternalError(c, "failed to update audiobook")
-			return
+		book.UpgradeAvailable = nil
+		book.UpgradeCandidateID = nil
+		if _, err := h.store.UpdateBook(book.ID, book); err != nil {
+			return fmt.Errorf("failed to update audiobook")
 		}
-		httputil.RespondWithOK(c, gin.H{"message": "audiobook set as primary"})
-		return
+		return nil
 	}
 
 	books, err := h.store.GetBooksByVersionGroup(*book.VersionGroupID)
 	if err != nil {
-		httputil.RespondWithInternalError(c, "failed to fetch versions")
-		return
+		return fmt.Errorf("failed to fetch versions")
 	}
 
 	for i := range books {
-		primaryFlag := books[i].ID == id
+		primaryFlag := books[i].ID == book.ID
 		books[i].IsPrimaryVersion = &primaryFlag
+		books[i].UpgradeAvailable = nil
+		books[i].UpgradeCandidateID = nil
 		if _, err := h.store.UpdateBook(books[i].ID, &books[i]); err != nil {
-			httputil.RespondWithInternalError(c, "failed to update version")
+			return fmt.Errorf("failed to update version")
+		}
+	}
+	return nil
+}
+
+// ListUpgrades lists primary versions flagged "upgrade available" because
+// a version imported later scores higher on quality.
+func (h *VersionsHandler) ListUpgrades(c *gin.Context) {
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+
+	books, err := database.GetAllBooksContext(c.Request.Context(), h.store, 0, 0)
+	if err != nil {
+		httputil.RespondWithInternalError(c, "failed to list audiobooks")
+		return
+	}
+
+	type upgrade struct {
+		Book      database.Book  `json:"book"`
+		Candidate *database.Book `json:"candidate,omitempty"`
+	}
+	upgrades := []upgrade{}
+	for _, b := range books {
+		if b.UpgradeAvailable == nil || !*b.UpgradeAvailable {
+			continue
+		}
+		u := upgrade{Book: b}
+		if b.UpgradeCandidateID != nil {
+			if cand, err := h.store.GetBookByID(*b.UpgradeCandidateID); err == nil {
+				u.Candidate = cand
+			}
+		}
+		upgrades = append(upgrades, u)
+	}
+
+	httputil.RespondWithOK(c, gin.H{"upgrades": upgrades, "count": len(upgrades)})
+}
+
+// AcceptUpgrades promotes each flagged book's upgrade candidate to primary.
+// Body: {"book_ids": [...]} naming flagged primaries; an empty or missing
+// list accepts every flagged upgrade. Books that are not flagged, or whose
+// candidate no longer exists, are reported as skipped.
+func (h *VersionsHandler) AcceptUpgrades(c *gin.Context) {
+	var req struct {
+		BookIDs []string `json:"book_ids"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httputil.RespondWithBadRequest(c, err.Error())
 			return
 		}
 	}
 
-	httputil.RespondWithOK(c, gin.H{"message": "audiobook set as primary"})
+	if h.store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+
+	var flagged []database.Book
+	if len(req.BookIDs) == 0 {
+		books, err := database.GetAllBooksContext(c.Request.Context(), h.store, 0, 0)
+		if err != nil {
+			httputil.RespondWithInternalError(c, "failed to list audiobooks")
+			return
+		}
+		for _, b := range books {
+			if b.UpgradeAvailable != nil && *b.UpgradeAvailable {
+				flagged = append(flagged, b)
+			}
+		}
+	} else {
+		for _, id := range req.BookIDs {
+			b, err := h.store.GetBookByID(id)
+			if err != nil || b == nil {
+				flagged = append(flagged, database.Book{ID: id})
+				continue
+			}
+			flagged = append(flagged, *b)
+		}
+	}
+
+	accepted := []gin.H{}
+	skipped := []gin.H{}
+	for _, b := range flagged {
+		if b.UpgradeAvailable == nil || !*b.UpgradeAvailable || b.UpgradeCandidateID == nil {
+			skipped = append(skipped, gin.H{"book_id": b.ID, "reason": "no upgrade available"})
+			continue
+		}
+		cand, err := h.store.GetBookByID(*b.UpgradeCandidateID)
+		if err != nil || cand == nil {
+			skipped = append(skipped, gin.H{"book_id": b.ID, "reason": "upgrade candidate not found"})
+			continue
+		}
+		if err := h.setPrimary(cand); err != nil {
+			slog.Warn("accept upgrade failed", "book", b.ID, "candidate", cand.ID, "err", err)
+			skipped = append(skipped, gin.H{"book_id": b.ID, "reason": err.Error()})
+			continue
+		}
+		accepted = append(accepted, gin.H{"book_id": b.ID, "new_primary_id": cand.ID})
+	}
+
+	httputil.RespondWithOK(c, gin.H{"accepted": accepted, "skipped": skipped})
 }
 
 // GetVersionGroup gets all audiobooks in a version group
@@ -1,7 +1,7 @@
 // file: internal/server/handlers/versions_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 3a9f6d21-7c84-4e0b-bd35-9f12a7c6e840
-// last-edited: 2026-06-03
+// last-edited: 2026-10-16
 
 package handlers_test
 
@@ -130,6 +130,47 @@ func TestVersionsHandler_SetAudiobookPrimary_WithGroup(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+// ── AcceptUpgrades ────────────────────────────────────────────────────────
+
+func TestVersionsHandler_AcceptUpgrades_All(t *testing.T) {
+	flag := true
+	store := handlersmocks.NewMockVersionsStore(t)
+	store.EXPECT().GetAllBooks(0, 0).Return([]database.Book{
+		{ID: "old", VersionGroupID: strptr("g1"), UpgradeAvailable: &flag, UpgradeCandidateID: strptr("new")},
+		{ID: "other"},
+	}, nil)
+	store.EXPECT().GetBookByID("new").Return(&database.Book{ID: "new", VersionGroupID: strptr("g1")}, nil)
+	store.EXPECT().GetBooksByVersionGroup("g1").Return([]database.Book{
+		{ID: "old", UpgradeAvailable: &flag, UpgradeCandidateID: strptr("new")},
+		{ID: "new"},
+	}, nil)
+	store.EXPECT().UpdateBook("old", mock.MatchedBy(func(b *database.Book) bool {
+		return !*b.IsPrimaryVersion && b.UpgradeAvailable == nil && b.UpgradeCandidateID == nil
+	})).Return(&database.Book{ID: "old"}, nil)
+	store.EXPECT().UpdateBook("new", mock.MatchedBy(func(b *database.Book) bool {
+		return *b.IsPrimaryVersion
+	})).Return(&database.Book{ID: "new"}, nil)
+
+	h := handlers.NewVersionsHandler(store)
+	c, w := newVersionsCtx(http.MethodPost, "/audiobooks/upgrades/accept", "", nil)
+	h.AcceptUpgrades(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"new_primary_id":"new"`)
+}
+
+func TestVersionsHandler_AcceptUpgrades_SkipsUnflagged(t *testing.T) {
+	store := handlersmocks.NewMockVersionsStore(t)
+	store.EXPECT().GetBookByID("b1").Return(&database.Book{ID: "b1"}, nil)
+
+	h := handlers.NewVersionsHandler(store)
+	c, w := newVersionsCtx(http.MethodPost, "/audiobooks/upgrades/accept", `{"book_ids":["b1"]}`, nil)
+	h.AcceptUpgrades(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "no upgrade available")
+}
+
 // ── GetVersionGroup ───────────────────────────────────────────────────────
 
 func TestVersionsHandler_GetVersionGroup_Success(t *testing.T) {
@@ -1,7 +1,7 @@
 // file: internal/server/handlers_integration_test.go
-// version: 1.6.0
+// version: 1.9.0
 // guid: 3f4a5b6c-7d8e-9f0a-1b2c-3d4e5f6a7b8c
-// last-edited: 2026-06-10
+// last-edited: 2026-10-16
 
 package server
 
@@ -49,6 +49,8 @@ func newOperationsHandler(s *Server) *operations.Handler {
 		func(id string) error {
 			return NewRevertService(s.Store()).RevertOperation(id)
 		},
+		func() string { return appVersion },
+		nil, // predictScan
 	)
 }
 
@@ -117,6 +119,7 @@ func newSystemHandler(s *Server) *system.Handler {
 		resetLibrarySizeCache,
 		func() string { return appVersion },
 		s.filterReviewedAuthorGroups,
+		s,
 	)
 }
 
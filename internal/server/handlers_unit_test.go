@@ -1,5 +1,5 @@
 // file: internal/server/handlers_unit_test.go
-// version: 1.8.0
+// version: 1.11.0
 // guid: f8a2d1c3-4b5e-6789-abcd-ef0123456789
 //
 // Unit tests for HTTP handlers using MockStore + httptest.
@@ -53,7 +53,7 @@ func setupHandlerTest(t *testing.T) (*Server, *mocks.MockStore, *gin.Engine) {
 func TestHandler_HealthCheck_Success(t *testing.T) {
 	srv, mockStore, router := setupHandlerTest(t)
 
-	mockStore.EXPECT().CountBooks().Return(42, nil)
+	mockStore.EXPECT().CountBooksContext(mock.Anything).Return(42, nil)
 	mockStore.EXPECT().CountAuthors().Return(1, nil)
 	mockStore.EXPECT().CountSeries().Return(1, nil)
 
@@ -78,7 +78,7 @@ func TestHandler_HealthCheck_Success(t *testing.T) {
 func TestHandler_HealthCheck_DBError(t *testing.T) {
 	srv, mockStore, router := setupHandlerTest(t)
 
-	mockStore.EXPECT().CountBooks().Return(0, errors.New("db down"))
+	mockStore.EXPECT().CountBooksContext(mock.Anything).Return(0, errors.New("db down"))
 	mockStore.EXPECT().CountAuthors().Return(0, errors.New("db down"))
 	mockStore.EXPECT().CountSeries().Return(0, errors.New("db down"))
 
@@ -171,7 +171,7 @@ func TestHandler_ListOperations_Success(t *testing.T) {
 		{ID: "op-1", Type: "scan", Status: "completed"},
 		{ID: "op-2", Type: "organize", Status: "running"},
 	}
-	mockStore.EXPECT().ListOperations(mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(ops, 2, nil)
+	mockStore.EXPECT().ListOperationsContext(mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(ops, 2, nil)
 
 	router.GET("/operations", newOperationsHandler(srv).ListOperations)
 
@@ -191,7 +191,7 @@ func TestHandler_ListOperations_Success(t *testing.T) {
 func TestHandler_ListOperations_StoreError(t *testing.T) {
 	srv, mockStore, router := setupHandlerTest(t)
 
-	mockStore.EXPECT().ListOperations(mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(nil, 0, errors.New("db error"))
+	mockStore.EXPECT().ListOperationsContext(mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(nil, 0, errors.New("db error"))
 
 	router.GET("/operations", newOperationsHandler(srv).ListOperations)
 
@@ -347,7 +347,7 @@ func TestHandler_AddBlockedHash_Success(t *testing.T) {
 	srv, mockStore, router := setupHandlerTest(t)
 
 	hash := "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"
-	mockStore.EXPECT().AddBlockedHash(hash, "test reason").Return(nil)
+	mockStore.EXPECT().AddBlockedHashWithAlgorithm(hash, "", "test reason").Return(nil)
 
 	router.POST("/blocked-hashes", newSystemHandler(srv).AddBlockedHash)
 
@@ -397,6 +397,7 @@ func TestHandler_RemoveBlockedHash_Success(t *testing.T) {
 	srv, mockStore, router := setupHandlerTest(t)
 
 	mockStore.EXPECT().RemoveBlockedHash("somehash").Return(nil)
+	mockStore.EXPECT().ScanPrefix("blocked_signature:").Return(nil, nil)
 
 	router.DELETE("/blocked-hashes/:hash", newSystemHandler(srv).RemoveBlockedHash)
 
@@ -0,0 +1,124 @@
+// file: internal/server/import_watch.go
+// version: 1.0.0
+// guid: 7e3b9d52-a4c1-4f86-b2d0-5c9e8a1f6b47
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/logger"
+	"github.com/falkcorp/audiobook-organizer/internal/realtime"
+	"github.com/falkcorp/audiobook-organizer/internal/watcher"
+)
+
+// importWatchSyncInterval is how often the set of watched import paths
+// is reconciled with the database, so adding, removing, enabling or
+// un-watching a path takes effect without a restart.
+const importWatchSyncInterval = time.Minute
+
+// maxWatchScanDirs caps the per-directory scans one settled batch of
+// changes enqueues; a wider change scans the whole import path.
+const maxWatchScanDirs = 10
+
+// watchedImportPaths returns the paths the file watcher should monitor.
+func watchedImportPaths(paths []database.ImportPath, autoScan bool) []string {
+	var out []string
+	for _, ip := range paths {
+		if ip.Watched(autoScan) {
+			out = append(out, ip.Path)
+		}
+	}
+	return out
+}
+
+// watchScanFolders is the folders to scan for one settled batch of
+// changes under root: the changed directories, or root itself when there
+// are none or too many.
+func watchScanFolders(root string, dirs []string) []string {
+	if len(dirs) == 0 || len(dirs) > maxWatchScanDirs {
+		return []string{root}
+	}
+	return dirs
+}
+
+// startImportWatchers watches each watched import path for new audio
+// files and enqueues a library.scan of the directories that changed once
+// they settle. The watched set is re-synced every
+// importWatchSyncInterval; all watchers stop at shutdown.
+func (s *Server) startImportWatchers(shutdown <-chan struct{}, wg *sync.WaitGroup) {
+	store := s.Store()
+	if store == nil {
+		return
+	}
+	watchLog := logger.NewWithActivityLog("auto-scan", store)
+
+	debounce := watcher.DefaultDebounce
+	if config.AppConfig.AutoScanDebounceSeconds > 0 {
+		debounce = time.Duration(config.AppConfig.AutoScanDebounceSeconds) * time.Second
+	}
+	// Each watcher invokes the shared callback with its own root, so the
+	// scan target is correct per event.
+	cb := func(root string, dirs []string) {
+		folders := watchScanFolders(root, dirs)
+		watchLog.Info("Auto-scan triggered for %s: %s", root, strings.Join(folders, ", "))
+		if s.hub != nil {
+			s.hub.Broadcast(&realtime.Event{
+				Type: "scan.auto_triggered",
+				Data: map[string]any{"path": root, "folders": folders},
+			})
+		}
+		if s.scanService == nil || s.opRegistry == nil {
+			return
+		}
+		for _, folder := range folders {
+			scanPath := folder
+			if _, enqErr := s.opRegistry.EnqueueOp(context.Background(), "library.scan", libraryScanParams{FolderPath: &scanPath}); enqErr != nil {
+				watchLog.Error("Auto-scan: failed to enqueue scan of %s: %v", scanPath, enqErr)
+			}
+		}
+	}
+	mgr := watcher.NewManager(cb, debounce)
+
+	resync := func() {
+		importPaths, err := store.GetAllImportPaths()
+		if err != nil {
+			watchLog.Warn("Auto-scan: failed to list import paths: %v", err)
+			return
+		}
+		started, stopped, err := mgr.Sync(watchedImportPaths(importPaths, config.AppConfig.AutoScanEnabled))
+		for _, p := range started {
+			watchLog.Info("Auto-scan file watcher started for %s", p)
+		}
+		for _, p := range stopped {
+			watchLog.Info("Auto-scan file watcher stopped for %s", p)
+		}
+		if err != nil {
+			watchLog.Warn("Auto-scan: %v", err)
+		}
+	}
+	resync()
+
+	ticker := time.NewTicker(importWatchSyncInterval)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				resync()
+			case <-shutdown:
+				mgr.Stop()
+				watchLog.Info("File watchers stopped")
+				return
+			}
+		}
+	}()
+}
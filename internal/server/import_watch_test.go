@@ -0,0 +1,39 @@
+// file: internal/server/import_watch_test.go
+// version: 1.1.0
+// guid: b5d2f8a4-3c7e-4a19-9f60-e8a4c1d7b392
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestWatchedImportPaths(t *testing.T) {
+	on, off := true, false
+	paths := []database.ImportPath{
+		{Path: "/in/default", Enabled: true},
+		{Path: "/in/opt-in", Enabled: true, Watch: &on},
+		{Path: "/in/opt-out", Enabled: true, Watch: &off},
+		{Path: "/in/disabled", Enabled: false, Watch: &on},
+		{Path: "/in/new", Enabled: true, Watch: &on, ScanMode: database.ImportPathScanInitial},
+	}
+	assert.Equal(t, []string{"/in/default", "/in/opt-in"}, watchedImportPaths(paths, true))
+	assert.Equal(t, []string{"/in/opt-in"}, watchedImportPaths(paths, false))
+}
+
+func TestWatchScanFolders(t *testing.T) {
+	assert.Equal(t, []string{"/in"}, watchScanFolders("/in", nil))
+	assert.Equal(t, []string{"/in/a", "/in/b"}, watchScanFolders("/in", []string{"/in/a", "/in/b"}))
+
+	many := make([]string, maxWatchScanDirs+1)
+	for i := range many {
+		many[i] = fmt.Sprintf("/in/%d", i)
+	}
+	assert.Equal(t, []string{"/in"}, watchScanFolders("/in", many))
+}
@@ -1,10 +1,11 @@
 // file: internal/server/indexed_store.go
-// version: 1.2.0
+// version: 1.4.0
 // guid: 5d2e4f3a-7b5a-4a70-b8c5-3d7e0f1b9a79
 //
 // indexedStore decorates a database.Store so that every successful
-// book mutation (create / update / delete) schedules an async
-// Bleve index update. This keeps the search index in sync without
+// book mutation (create / update / delete, alternate-title and
+// contributor edits)
+// schedules an async Bleve index update. This keeps the search index in sync without
 // threading explicit index calls through every handler and service
 // that touches books.
 //
@@ -51,6 +52,42 @@ func (s *indexedStore) UpdateBook(id string, b *database.Book) (*database.Book,
 	return updated, err
 }
 
+// AddBookAlternativeTitle reindexes so the new title is searchable.
+func (s *indexedStore) AddBookAlternativeTitle(bookID, title, source, language string) error {
+	if err := s.Store.AddBookAlternativeTitle(bookID, title, source, language); err != nil {
+		return err
+	}
+	s.server.enqueueIndex(bookID, false)
+	return nil
+}
+
+// RemoveBookAlternativeTitle reindexes so the removed title stops matching.
+func (s *indexedStore) RemoveBookAlternativeTitle(bookID, title string) error {
+	if err := s.Store.RemoveBookAlternativeTitle(bookID, title); err != nil {
+		return err
+	}
+	s.server.enqueueIndex(bookID, false)
+	return nil
+}
+
+// SetBookAlternativeTitles reindexes after a bulk replace.
+func (s *indexedStore) SetBookAlternativeTitles(bookID string, titles []database.BookAlternativeTitle) error {
+	if err := s.Store.SetBookAlternativeTitles(bookID, titles); err != nil {
+		return err
+	}
+	s.server.enqueueIndex(bookID, false)
+	return nil
+}
+
+// SetBookAuthors reindexes so co-authors and editors are searchable.
+func (s *indexedStore) SetBookAuthors(bookID string, authors []database.BookAuthor) error {
+	if err := s.Store.SetBookAuthors(bookID, authors); err != nil {
+		return err
+	}
+	s.server.enqueueIndex(bookID, false)
+	return nil
+}
+
 // Unwrap returns the inner store so decorator-aware helpers (e.g.
 // unwrapAIJobsStore) can peel layers and reach concrete sub-interfaces.
 func (s *indexedStore) Unwrap() database.Store {
@@ -1,5 +1,5 @@
 // file: internal/server/indexed_store_test.go
-// version: 1.1.0
+// version: 1.3.0
 // guid: 6e3f5a2b-8c5a-4a70-b8c5-3d7e0f1b9a89
 
 package server
@@ -216,3 +216,100 @@ func TestIndexedStore_UpdateReindexes(t *testing.T) {
 	srv.closeIndexQueue()
 	<-done
 }
+
+func TestIndexedStore_AltTitleReindexes(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("pebble: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	idx, err := search.Open(filepath.Join(t.TempDir(), "bleve"))
+	if err != nil {
+		t.Fatalf("bleve: %v", err)
+	}
+	t.Cleanup(func() { _ = idx.Close() })
+
+	srv := NewServer(store)
+	srv.setSearchIndex(idx)
+	srv.indexQueue = make(chan indexRequest, 32)
+	done := make(chan struct{})
+	go func() {
+		srv.runIndexWorker()
+		close(done)
+	}()
+
+	wrapped := &indexedStore{Store: store, server: srv}
+
+	_, _ = wrapped.CreateBook(&database.Book{
+		ID: "b1", Title: "Foundation", FilePath: "/tmp/b1", Format: "m4b",
+	})
+	if err := wrapped.AddBookAlternativeTitle("b1", "Fondation", "user", "fr"); err != nil {
+		t.Fatalf("add alt title: %v", err)
+	}
+	drainQueue(t, srv)
+
+	hits, _, _ := idx.Search("alt_titles:fondation", 0, 10)
+	if len(hits) != 1 || hits[0].BookID != "b1" {
+		t.Errorf("after add, alt title hits = %v, want [b1]", hits)
+	}
+
+	if err := wrapped.RemoveBookAlternativeTitle("b1", "Fondation"); err != nil {
+		t.Fatalf("remove alt title: %v", err)
+	}
+	drainQueue(t, srv)
+
+	hits, _, _ = idx.Search("alt_titles:fondation", 0, 10)
+	if len(hits) != 0 {
+		t.Errorf("after remove, alt title still matches: %v", hits)
+	}
+
+	srv.closeIndexQueue()
+	<-done
+}
+
+func TestIndexedStore_BookAuthorsReindex(t *testing.T) {
+	store, err := database.NewPebbleStore(filepath.Join(t.TempDir(), "db"))
+	if err != nil {
+		t.Fatalf("pebble: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	idx, err := search.Open(filepath.Join(t.TempDir(), "bleve"))
+	if err != nil {
+		t.Fatalf("bleve: %v", err)
+	}
+	t.Cleanup(func() { _ = idx.Close() })
+
+	srv := NewServer(store)
+	srv.setSearchIndex(idx)
+	srv.indexQueue = make(chan indexRequest, 32)
+	done := make(chan struct{})
+	go func() {
+		srv.runIndexWorker()
+		close(done)
+	}()
+
+	wrapped := &indexedStore{Store: store, server: srv}
+
+	gaiman, _ := store.CreateAuthor("Neil Gaiman")
+	pratchett, _ := store.CreateAuthor("Terry Pratchett")
+	_, _ = wrapped.CreateBook(&database.Book{
+		ID: "b1", Title: "Good Omens", FilePath: "/tmp/b1", Format: "m4b", AuthorID: &gaiman.ID,
+	})
+	if err := wrapped.SetBookAuthors("b1", []database.BookAuthor{
+		{BookID: "b1", AuthorID: gaiman.ID, Role: "author", Position: 0},
+		{BookID: "b1", AuthorID: pratchett.ID, Role: "co-author", Position: 1},
+	}); err != nil {
+		t.Fatalf("set authors: %v", err)
+	}
+	drainQueue(t, srv)
+
+	hits, _, _ := idx.Search("contributors:pratchett", 0, 10)
+	if len(hits) != 1 || hits[0].BookID != "b1" {
+		t.Errorf("co-author hits = %v, want [b1]", hits)
+	}
+
+	srv.closeIndexQueue()
+	<-done
+}
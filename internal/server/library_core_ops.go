@@ -1,5 +1,5 @@
 // file: internal/server/library_core_ops.go
-// version: 1.2.0
+// version: 1.13.0
 // guid: 3c4d5e6f-7a8b-9c0d-1e2f-3a4b5c6d7e8f
 
 // library_core_ops registers the scan, organize, and transcode OperationDefs
@@ -11,6 +11,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/falkcorp/audiobook-organizer/internal/auth"
@@ -27,20 +28,103 @@ import (
 type libraryScanParams struct {
 	FolderPath  *string `json:"folder_path,omitempty"`
 	ForceUpdate *bool   `json:"force_update,omitempty"`
+	Profile     string  `json:"profile,omitempty"`
+	// Adopt takes the scanned folders' books in place, reading their
+	// fields from the folder layout (AdoptPattern, else
+	// folder_naming_pattern).
+	Adopt        bool   `json:"adopt,omitempty"`
+	AdoptPattern string `json:"adopt_pattern,omitempty"`
+	// Background scans (scheduler-started) pause while user-started
+	// operations run.
+	Background bool `json:"background,omitempty"`
 }
 
 type libraryOrganizeParams struct {
-	FolderPath         *string  `json:"folder_path,omitempty"`
+	FolderPath *string `json:"folder_path,omitempty"`
+	// BookIDs limits the run to these books (e.g. a UI multi-selection);
+	// omitted, the whole library is organized. The schema rejects an
+	// empty list so an empty selection cannot mean "everything".
 	BookIDs            []string `json:"book_ids,omitempty"`
 	FetchMetadataFirst bool     `json:"fetch_metadata_first"`
 	SyncITunesFirst    bool     `json:"sync_itunes_first"`
+	// Scope filters; see organizer.Request. PlaylistID restricts the run
+	// to a saved playlist's books (smart playlists are evaluated as their
+	// owner).
+	AuthorID     *int   `json:"author_id,omitempty"`
+	SeriesID     *int   `json:"series_id,omitempty"`
+	WorkID       string `json:"work_id,omitempty"`
+	LibraryState string `json:"library_state,omitempty"`
+	PlaylistID   string `json:"playlist_id,omitempty"`
+}
+
+// libraryScanParamsSchema and libraryOrganizeParamsSchema are the
+// ParamsSchema of library.scan and library.organize. They list exactly the
+// fields of libraryScanParams and libraryOrganizeParams, so a misspelled
+// or unsupported parameter is rejected at enqueue rather than ignored.
+// The one exception is "priority", which the legacy operations API took
+// and clients still send; it is accepted and has no effect.
+var libraryScanParamsSchema = opsregistry.MustParamsSchema(`{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"folder_path":   {"type": ["string", "null"], "minLength": 1},
+		"force_update":  {"type": ["boolean", "null"]},
+		"profile":       {"type": "string", "enum": ` + jsonStringList(append([]string{""}, scanner.ScanProfiles...)) + `},
+		"adopt":         {"type": "boolean"},
+		"adopt_pattern": {"type": "string"},
+		"background":    {"type": "boolean"},
+		"priority":      {"type": ["integer", "null"]}
+	}
+}`)
+
+var libraryOrganizeParamsSchema = opsregistry.MustParamsSchema(`{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"folder_path":          {"type": ["string", "null"], "minLength": 1},
+		"book_ids":             {"type": ["array", "null"], "items": {"type": "string", "minLength": 1}, "minItems": 1},
+		"fetch_metadata_first": {"type": "boolean"},
+		"sync_itunes_first":    {"type": "boolean"},
+		"author_id":            {"type": ["integer", "null"], "minimum": 1},
+		"series_id":            {"type": ["integer", "null"], "minimum": 1},
+		"work_id":              {"type": "string"},
+		"library_state":        {"type": "string"},
+		"playlist_id":          {"type": "string"},
+		"priority":             {"type": ["integer", "null"]}
+	}
+}`)
+
+// libraryTranscodeParamsSchema is the ParamsSchema of library.transcode,
+// shared by POST /operations/transcode and POST /audiobooks/:id/convert.
+var libraryTranscodeParamsSchema = opsregistry.MustParamsSchema(`{
+	"type": "object",
+	"additionalProperties": false,
+	"required": ["book_id"],
+	"properties": {
+		"book_id":       {"type": "string", "minLength": 1},
+		"output_format": {"type": "string", "enum": ` + jsonStringList(append([]string{""}, transcode.OutputFormats...)) + `},
+		"bitrate":       {"type": "integer", "minimum": ` + fmt.Sprint(minTranscodeBitrate) + `, "maximum": ` + fmt.Sprint(maxTranscodeBitrate) + `},
+		"keep_original": {"type": "boolean"},
+		"priority":      {"type": ["integer", "null"]}
+	}
+}`)
+
+// AAC bitrates (kbps) library.transcode accepts; 0 or unset means 128.
+const (
+	minTranscodeBitrate = 32
+	maxTranscodeBitrate = 320
+)
+
+func jsonStringList(values []string) string {
+	b, _ := json.Marshal(values)
+	return string(b)
 }
 
 type libraryTranscodeParams struct {
 	BookID       string `json:"book_id"`
-	OutputFormat string `json:"output_format"`
-	Bitrate      int    `json:"bitrate"`
-	KeepOriginal bool   `json:"keep_original"`
+	OutputFormat string `json:"output_format,omitempty"`
+	Bitrate      int    `json:"bitrate,omitempty"`
+	KeepOriginal bool   `json:"keep_original,omitempty"`
 }
 
 // RegisterLibraryScanOp registers the "library.scan" v2 OperationDef.
@@ -56,6 +140,9 @@ func (s *Server) RegisterLibraryScanOp(reg *opsregistry.Registry) error {
 		Timeout:         4 * time.Hour,
 		ResumePolicy:    opsregistry.ResumeDrop,
 		ConcurrencyKey:  "library.scan",
+		Pool:            "scan",
+		PathLeases:      s.libraryScanLeases,
+		ParamsSchema:    libraryScanParamsSchema,
 		Permissions:     []auth.Permission{auth.PermScanTrigger},
 		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite},
 		Run: func(ctx context.Context, rawParams json.RawMessage, reporter opsregistry.Reporter) error {
@@ -76,11 +163,17 @@ func (s *Server) RegisterLibraryScanOp(reg *opsregistry.Registry) error {
 			if p.FolderPath != nil {
 				folderPath = *p.FolderPath
 			}
-			logging.Info(ctx, "library scan starting", "folder_path", folderPath)
+			logging.Info(ctx, "library scan starting", "folder_path", folderPath, "profile", p.Profile, "adopt", p.Adopt)
 
 			scanReq := &scanner.ScanRequest{
-				FolderPath:  p.FolderPath,
-				ForceUpdate: p.ForceUpdate,
+				FolderPath:   p.FolderPath,
+				ForceUpdate:  p.ForceUpdate,
+				Profile:      p.Profile,
+				Adopt:        p.Adopt,
+				AdoptPattern: p.AdoptPattern,
+			}
+			if p.Background {
+				scanReq.Yield = s.yieldToInteractiveOps
 			}
 			progress := registryProgressAdapter{r: reporter}
 			err := s.scanService.PerformScan(ctx, scanReq, operations.LoggerFromReporter(progress))
@@ -109,6 +202,10 @@ func (s *Server) RegisterLibraryOrganizeOp(reg *opsregistry.Registry) error {
 		Timeout:         4 * time.Hour,
 		ResumePolicy:    opsregistry.ResumeDrop,
 		ConcurrencyKey:  "library.organize",
+		Pool:            "organize",
+		PathLeases:      s.libraryOrganizeLeases,
+		ParamsSchema:    libraryOrganizeParamsSchema,
+		Destructive:     true,
 		Permissions:     []auth.Permission{auth.PermScanTrigger},
 		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite, opsregistry.CapFilesWrite},
 		Run: func(ctx context.Context, rawParams json.RawMessage, reporter opsregistry.Reporter) error {
@@ -137,13 +234,33 @@ func (s *Server) RegisterLibraryOrganizeOp(reg *opsregistry.Registry) error {
 				"fetch_metadata_first", p.FetchMetadataFirst,
 				"sync_itunes_first", p.SyncITunesFirst)
 
+			bookIDs := p.BookIDs
+			if p.PlaylistID != "" {
+				playlistIDs, err := s.playlistBookIDs(p.PlaylistID)
+				if err != nil {
+					op.SetStatus("failed")
+					logging.Error(ctx, "library organize failed", "err", err)
+					return err
+				}
+				bookIDs = intersectBookIDs(bookIDs, playlistIDs)
+				if len(bookIDs) == 0 {
+					logging.Info(ctx, "library organize: playlist scope matched no books", "playlist_id", p.PlaylistID)
+					op.SetStatus("success")
+					return nil
+				}
+			}
+
 			progress := registryProgressAdapter{r: reporter}
 			organizeReq := &OrganizeRequest{
 				FolderPath:         p.FolderPath,
-				BookIDs:            p.BookIDs,
+				BookIDs:            bookIDs,
 				FetchMetadataFirst: p.FetchMetadataFirst,
 				SyncITunesFirst:    p.SyncITunesFirst,
 				OperationID:        opID,
+				AuthorID:           p.AuthorID,
+				SeriesID:           p.SeriesID,
+				WorkID:             p.WorkID,
+				LibraryState:       p.LibraryState,
 			}
 			err := s.organizeService.PerformOrganize(ctx, organizeReq, operations.LoggerFromReporter(progress))
 			if err != nil {
@@ -151,6 +268,7 @@ func (s *Server) RegisterLibraryOrganizeOp(reg *opsregistry.Registry) error {
 				logging.Error(ctx, "library organize failed", "err", err)
 				return err
 			}
+			s.invalidateSizeCaches()
 			op.SetStatus("success")
 			logging.Info(ctx, "library organize complete", "book_count", len(p.BookIDs))
 			return nil
@@ -164,13 +282,16 @@ func (s *Server) RegisterLibraryTranscodeOp(reg *opsregistry.Registry) error {
 		ID:              "library.transcode",
 		Plugin:          "library",
 		DisplayName:     "Transcode to M4B",
-		Description:     "Transcode an audiobook file to M4B format and register it as a new version.",
+		Description:     "Transcode an audiobook to a single chaptered M4B (or M4A) file and register it as a new version.",
 		DefaultPriority: opsregistry.PriorityNormal,
 		Cancellable:     true,
 		Isolate:         false,
 		Timeout:         6 * time.Hour,
 		ResumePolicy:    opsregistry.ResumeDrop,
 		ConcurrencyKey:  "", // transcodes can run in parallel
+		Pool:            "convert",
+		Destructive:     true,
+		ParamsSchema:    libraryTranscodeParamsSchema,
 		Permissions:     []auth.Permission{auth.PermLibraryOrganize},
 		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite, opsregistry.CapFilesWrite},
 		Run: func(ctx context.Context, rawParams json.RawMessage, reporter opsregistry.Reporter) error {
@@ -237,14 +358,17 @@ func (s *Server) RegisterLibraryTranscodeOp(reg *opsregistry.Registry) error {
 				progress.Log("warn", fmt.Sprintf("Failed to update original book version info: %v", err), nil)
 			}
 
-			m4bFormat := "m4b"
+			m4bFormat := opts.OutputFormat
+			if m4bFormat == "" {
+				m4bFormat = "m4b"
+			}
 			aacCodec := "aac"
 			bitrateVal := opts.Bitrate
 			if bitrateVal <= 0 {
 				bitrateVal = 128
 			}
 			isPrimary := true
-			m4bNotes := "Transcoded to M4B"
+			m4bNotes := "Transcoded to " + strings.ToUpper(m4bFormat)
 
 			newBook := &database.Book{
 				ID:                   ulid.Make().String(),
@@ -273,7 +397,7 @@ func (s *Server) RegisterLibraryTranscodeOp(reg *opsregistry.Registry) error {
 			if _, err := s.Store().CreateBook(newBook); err != nil {
 				progress.Log("warn", fmt.Sprintf("Failed to create M4B version record, updating original: %v", err), nil)
 				isPrim := true
-				fallbackNotes := fmt.Sprintf("Transcoded to M4B (in-place, original was at %s)", originalBook.FilePath)
+				fallbackNotes := fmt.Sprintf("Transcoded to %s (in-place, original was at %s)", strings.ToUpper(m4bFormat), originalBook.FilePath)
 				originalBook.FilePath = outputPath
 				originalBook.Format = m4bFormat
 				originalBook.Codec = &aacCodec
@@ -284,8 +408,10 @@ func (s *Server) RegisterLibraryTranscodeOp(reg *opsregistry.Registry) error {
 				if _, updateErr := s.Store().UpdateBook(p.BookID, originalBook); updateErr != nil {
 					return updateErr
 				}
+				s.recordTranscodeChapters(ctx, p.BookID, outputPath, progress)
 				return nil
 			}
+			s.recordTranscodeChapters(ctx, newBook.ID, outputPath, progress)
 
 			op.AddEntity("books", newBook.ID)
 			progress.Log("info", fmt.Sprintf("Created M4B version %s (group %s), original %s demoted to non-primary", newBook.ID, groupID, p.BookID), nil)
@@ -313,6 +439,19 @@ func (s *Server) RegisterLibraryTranscodeOp(reg *opsregistry.Registry) error {
 	})
 }
 
+// recordTranscodeChapters stores the chapter markers of a transcode's
+// output for bookID, so the chapter editor and chapter_count reflect them
+// without waiting for the next scan.
+func (s *Server) recordTranscodeChapters(ctx context.Context, bookID, path string, progress operations.ProgressReporter) {
+	chapters, _, ok := scanner.ProbeChapters(ctx, path)
+	if !ok || len(chapters) == 0 {
+		return
+	}
+	if err := database.PutBookChapters(s.Store(), bookID, chapters); err != nil {
+		progress.Log("warn", fmt.Sprintf("Failed to record chapters: %v", err), nil)
+	}
+}
+
 func init() {
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterLibraryScanOp(reg) })
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterLibraryOrganizeOp(reg) })
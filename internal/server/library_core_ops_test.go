@@ -0,0 +1,117 @@
+// file: internal/server/library_core_ops_test.go
+// version: 1.2.0
+// guid: 5a1e8c3d-f7b2-4d69-9e04-b6c2a7d3f815
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The params schemas must list exactly the fields the typed params decode,
+// plus the legacy "priority" field.
+func TestLibraryParamsSchemasMatchStructs(t *testing.T) {
+	for _, tt := range []struct {
+		schema *json.RawMessage
+		params any
+	}{
+		{libraryScanParamsSchema, libraryScanParams{}},
+		{libraryOrganizeParamsSchema, libraryOrganizeParams{}},
+		{libraryTranscodeParamsSchema, libraryTranscodeParams{}},
+	} {
+		var schema struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(*tt.schema, &schema))
+		var inSchema, inStruct []string
+		for name := range schema.Properties {
+			if name != "priority" {
+				inSchema = append(inSchema, name)
+			}
+		}
+		typ := reflect.TypeOf(tt.params)
+		for i := 0; i < typ.NumField(); i++ {
+			inStruct = append(inStruct, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+		}
+		sort.Strings(inSchema)
+		sort.Strings(inStruct)
+		assert.Equal(t, inStruct, inSchema, typ.Name())
+	}
+}
+
+func TestStartScanAndOrganize_ValidateParams(t *testing.T) {
+	srv := setupMaintenanceTestServer(t)
+	// NewServer only runs the op registrars when RootDir is configured.
+	require.NoError(t, srv.RegisterLibraryScanOp(srv.opRegistry))
+	require.NoError(t, srv.RegisterLibraryOrganizeOp(srv.opRegistry))
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		return w
+	}
+	type fieldErrors struct {
+		Fields []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"fields"`
+	}
+
+	w := post("/api/v1/operations/scan", `{"folderPath":"/books","profile":"thorough"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	var errs fieldErrors
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errs))
+	require.Len(t, errs.Fields, 2)
+	assert.Equal(t, "folderPath", errs.Fields[0].Field)
+	assert.Equal(t, "unknown parameter", errs.Fields[0].Message)
+	assert.Equal(t, "profile", errs.Fields[1].Field)
+
+	w = post("/api/v1/operations/organize", `{"book_ids":["b1",""],"author_id":"7"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	errs = fieldErrors{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errs))
+	require.Len(t, errs.Fields, 2)
+	assert.Equal(t, "author_id", errs.Fields[0].Field)
+	assert.Equal(t, "book_ids[1]", errs.Fields[1].Field)
+
+	// An empty selection must not fall through to the whole library.
+	w = post("/api/v1/operations/organize", `{"book_ids":[]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = post("/api/v1/operations/organize", `{"book_ids": ["b1"], "fetch_metadata_first": true}`)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var started struct {
+		OpID string `json:"op_id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	row, err := srv.Store().GetOperationV2(started.OpID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"book_ids":["b1"],"fetch_metadata_first":true}`, row.Params)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/operations/v2/"+started.OpID, nil)
+	w = httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var got struct {
+		Data struct {
+			Operation struct {
+				Params json.RawMessage `json:"params"`
+			} `json:"operation"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.JSONEq(t, `{"book_ids":["b1"],"fetch_metadata_first":true}`, string(got.Data.Operation.Params))
+}
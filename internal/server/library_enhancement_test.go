@@ -1,7 +1,7 @@
 // file: internal/server/library_enhancement_test.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: 0336376b-882f-41df-b8ab-7e27526cdb1d
-// last-edited: 2026-05-01
+// last-edited: 2026-10-17
 
 package server
 
@@ -561,6 +561,59 @@ func TestServerSideSorting(t *testing.T) {
 	})
 }
 
+func TestServerSideAttributeFilters(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	store := database.GetGlobalStore()
+	lang := func(s string) *string { return &s }
+	year := func(n int) *int { return &n }
+	for _, b := range []struct {
+		title, format string
+		language      *string
+		year          *int
+	}{
+		{"Old English", "m4b", lang("en"), year(1990)},
+		{"New English", "mp3", lang("en"), year(2022)},
+		{"New German", "m4b", lang("de"), year(2021)},
+	} {
+		book := createTestBook(t, b.title)
+		book.Format, book.Language, book.AudiobookReleaseYear = b.format, b.language, b.year
+		_, err := store.UpdateBook(book.ID, book)
+		require.NoError(t, err)
+	}
+
+	list := func(t *testing.T, query string) (titles []string, count int) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/audiobooks?"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		data := parseJSONResponse(t, w)["data"].(map[string]any)
+		for _, item := range data["items"].([]any) {
+			titles = append(titles, item.(map[string]any)["title"].(string))
+		}
+		return titles, int(data["count"].(float64))
+	}
+
+	titles, count := list(t, "language=en&year_min=2000&sort_by=title")
+	assert.Equal(t, []string{"New English"}, titles)
+	assert.Equal(t, 1, count, "count is the filtered total")
+
+	titles, count = list(t, "format=M4B&sort_by=added_at&sort_order=desc&limit=1")
+	assert.Equal(t, []string{"New German"}, titles)
+	assert.Equal(t, 2, count)
+
+	titles, count = list(t, "language=fr")
+	assert.Empty(t, titles, "a filter matching nothing must not fall back to the whole library")
+	assert.Zero(t, count)
+
+	for _, query := range []string{"year_min=soon", "year_min=2020&year_max=2010", "missing_metadata=vibes"} {
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/audiobooks?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
 // ── 6. Server-Side Field Filtering ──────────────────────────────────────────
 
 func TestServerSideFieldFiltering(t *testing.T) {
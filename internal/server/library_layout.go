@@ -0,0 +1,121 @@
+// file: internal/server/library_layout.go
+// version: 1.1.0
+// guid: 6c1e8b47-f3a2-4d90-9b5e-d7a04c2f8e13
+// last-edited: 2026-10-17
+
+// Library layout export/apply: GET /library/layout downloads the
+// organized library's author/series/book structure as a portable JSON or
+// YAML file; POST /library/layout/apply re-creates such a layout under a
+// target directory from files found in a source directory.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/layout"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// handleExportLayout handles GET /api/v1/library/layout.
+//
+// Query:
+//   - format: json (default) or yaml.
+//
+// The skipped count (books outside root_dir, not yet organized) is
+// reported in the X-Layout-Skipped header.
+func (s *Server) handleExportLayout(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "yaml" {
+		httputil.RespondWithValidationError(c, "format", "must be json or yaml")
+		return
+	}
+	store := s.Store()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	if config.AppConfig.RootDir == "" {
+		httputil.RespondWithBadRequest(c, "root_dir is not configured")
+		return
+	}
+	l, skipped, err := layout.Export(store, config.AppConfig.RootDir)
+	if err != nil {
+		httputil.InternalError(c, "failed to export library layout", err)
+		return
+	}
+
+	var body []byte
+	contentType := "application/json"
+	if format == "yaml" {
+		body, err = yaml.Marshal(l)
+		contentType = "application/yaml"
+	} else {
+		body, err = json.MarshalIndent(l, "", "  ")
+	}
+	if err != nil {
+		httputil.InternalError(c, "failed to encode library layout", err)
+		return
+	}
+	name := fmt.Sprintf("library-layout-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	c.Header("X-Layout-Skipped", fmt.Sprint(skipped))
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// handleApplyLayout handles POST /api/v1/library/layout/apply.
+//
+// Body:
+//   - layout: the structure file, as JSON or YAML text.
+//   - source_dir: where the files are now.
+//   - target_root: where to re-create the layout; defaults to root_dir.
+//   - mode: move (default) or symlink.
+//   - dry_run: report what would happen without touching anything.
+//
+// Run a library scan of target_root afterwards to import the result.
+func (s *Server) handleApplyLayout(c *gin.Context) {
+	var req struct {
+		Layout     string `json:"layout" binding:"required"`
+		SourceDir  string `json:"source_dir" binding:"required"`
+		TargetRoot string `json:"target_root"`
+		Mode       string `json:"mode"`
+		DryRun     bool   `json:"dry_run"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	if req.TargetRoot == "" {
+		req.TargetRoot = config.AppConfig.RootDir
+	}
+	if req.TargetRoot == "" {
+		httputil.RespondWithValidationError(c, "target_root", "is required when root_dir is not configured")
+		return
+	}
+	if req.Mode != "" && req.Mode != layout.ModeMove && req.Mode != layout.ModeSymlink {
+		httputil.RespondWithValidationError(c, "mode", "must be move or symlink")
+		return
+	}
+	l, err := layout.Parse([]byte(req.Layout))
+	if err != nil {
+		httputil.RespondWithValidationError(c, "layout", err.Error())
+		return
+	}
+	result, err := layout.Apply(c.Request.Context(), l, layout.ApplyOptions{
+		SourceDir:  req.SourceDir,
+		TargetRoot: req.TargetRoot,
+		Mode:       req.Mode,
+		DryRun:     req.DryRun,
+	})
+	if err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	httputil.RespondWithOK(c, result)
+}
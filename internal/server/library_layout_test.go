@@ -0,0 +1,69 @@
+// file: internal/server/library_layout_test.go
+// version: 1.0.0
+// guid: e2a7c4b9-81d3-4f56-a0e8-3b9d5f1c7a64
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/layout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryLayoutExportAndApply(t *testing.T) {
+	srv, cleanup := setupTestServer(t)
+	defer cleanup()
+	store := srv.Store()
+	root := config.AppConfig.RootDir
+
+	author, err := store.CreateAuthor("Jane Doe")
+	require.NoError(t, err)
+	path := filepath.Join(root, "Jane Doe", "Book", "book.m4b")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("audio"), 0o644))
+	size := int64(len("audio"))
+	_, err = store.CreateBook(&database.Book{ID: "b1", Title: "Book", AuthorID: &author.ID, FilePath: path, FileSize: &size})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/library/layout?format=yaml", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".yaml")
+	assert.Contains(t, w.Body.String(), "path: Jane Doe/Book/book.m4b")
+	exported := w.Body.String()
+
+	// Re-create it from a flat copy of the files somewhere else.
+	src, dst := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "Jane Doe - Book.m4b"), []byte("audio"), 0o644))
+	body, _ := json.Marshal(map[string]any{"layout": exported, "source_dir": src, "target_root": dst})
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/library/layout/apply", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data layout.ApplyResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Data.Counts[layout.StatusPlaced])
+	assert.FileExists(t, filepath.Join(dst, "Jane Doe", "Book", "book.m4b"))
+
+	body, _ = json.Marshal(map[string]any{"layout": "version: 7", "source_dir": src})
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/library/layout/apply", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
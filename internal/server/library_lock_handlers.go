@@ -0,0 +1,24 @@
+// file: internal/server/library_lock_handlers.go
+// version: 1.0.0
+// guid: 2c7e9a41-d6b3-4f05-8a1e-5b3f0c8d7e26
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// handleLibraryLockTakeover handles POST /api/v1/system/library-lock/takeover.
+// It claims the library lock for this instance even though another one
+// still looks alive, e.g. after that instance's host went away without
+// releasing it. The other instance stops running destructive operations
+// on its next check.
+func (s *Server) handleLibraryLockTakeover(c *gin.Context) {
+	if s.libraryLock == nil {
+		httputil.RespondWithServiceUnavailable(c, "library lock not available")
+		return
+	}
+	httputil.RespondWithOK(c, s.libraryLock.ForceTakeover())
+}
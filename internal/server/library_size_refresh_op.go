@@ -1,10 +1,12 @@
 // file: internal/server/library_size_refresh_op.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 9f1c2d3e-4b5a-6c7d-8e9f-0a1b2c3d4e5f
 
 // library.size-refresh: walks the library root + import-path trees to
-// recompute physical on-disk sizes. Runs nightly via the maintenance
-// window and can be triggered manually from /scheduler.
+// recompute physical on-disk sizes and recomputes the store's cached
+// LibraryStats (the sizes /system/status reports). Runs nightly via the
+// maintenance window and can be triggered manually from /scheduler or
+// POST /system/recalculate-sizes.
 
 package server
 
@@ -22,9 +24,10 @@ import (
 )
 
 // RegisterLibrarySizeRefreshOp registers the "library.size-refresh"
-// OperationDef. The op invalidates the package-level size cache and then
-// calls calculateLibrarySizes to repopulate it from a fresh filesystem
-// walk. Used by both the manual /scheduler trigger and the nightly
+// OperationDef. The op invalidates both size caches, calls
+// calculateLibrarySizes to repopulate the walk cache from a fresh
+// filesystem walk, then recomputes LibraryStats. Used by the manual
+// /scheduler and /system/recalculate-sizes triggers and the nightly
 // maintenance.window run.
 func (s *Server) RegisterLibrarySizeRefreshOp(reg *opsregistry.Registry) error {
 	return reg.RegisterOp(opsregistry.OperationDef{
@@ -55,11 +58,15 @@ func (s *Server) RegisterLibrarySizeRefreshOp(reg *opsregistry.Registry) error {
 			_ = progress.Log("info", fmt.Sprintf("library size refresh starting (root=%s, import_folders=%d)", rootDir, len(folders)), nil)
 			_ = progress.UpdateProgress(0, 1, "walking filesystem")
 
-			// Invalidate the cache so calculateLibrarySizes does a real walk
-			// instead of returning the existing cached value.
-			resetLibrarySizeCache()
+			// Invalidate the caches so calculateLibrarySizes does a real walk
+			// instead of returning the existing cached value, and the stats
+			// read below recomputes rather than serving the stale entry.
+			s.invalidateSizeCaches()
 			started := time.Now()
 			lib, imp := calculateLibrarySizes(rootDir, folders)
+			if _, err := store.GetDashboardStats(); err != nil {
+				return fmt.Errorf("library.size-refresh: recompute library stats: %w", err)
+			}
 			elapsed := time.Since(started)
 
 			slog.Info("library size refresh complete",
@@ -76,6 +83,16 @@ func (s *Server) RegisterLibrarySizeRefreshOp(reg *opsregistry.Registry) error {
 	})
 }
 
+// invalidateSizeCaches drops both size caches: the filesystem-walk cache
+// and the store's LibraryStats, which the /system/status sizes come from.
+// Called after operations that move or delete files in bulk.
+func (s *Server) invalidateSizeCaches() {
+	resetLibrarySizeCache()
+	if store := s.Store(); store != nil {
+		store.InvalidateLibraryStats()
+	}
+}
+
 func init() {
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterLibrarySizeRefreshOp(reg) })
 }
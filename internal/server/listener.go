@@ -0,0 +1,215 @@
+// file: internal/server/listener.go
+// version: 1.1.0
+// guid: 8d3f6a21-c94e-4b07-a5d2-7e1b0c39f846
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"golang.org/x/net/http2"
+)
+
+// listenerDrainTimeout bounds how long a replaced HTTP server may keep
+// serving its in-flight requests.
+const listenerDrainTimeout = 30 * time.Second
+
+// httpListener is the main HTTP(S) server and the socket it serves. It is
+// replaced as a unit when the listen address changes or the server is
+// restarted, so requests keep being answered throughout.
+type httpListener struct {
+	mu      sync.Mutex
+	cfg     ServerConfig
+	handler http.Handler
+	server  *http.Server
+	ln      net.Listener
+}
+
+// config returns the configuration the listener is currently serving.
+func (l *httpListener) config() ServerConfig {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cfg
+}
+
+// newHTTPServer builds the main server for cfg; with TLS configured it
+// speaks HTTP/2 and advertises HTTP/3 when cfg.HTTP3Port is set.
+func newHTTPServer(cfg ServerConfig, handler http.Handler) (*http.Server, error) {
+	srv := &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadTimeout, // Only limit header read, not body (allows large uploads)
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    1 << 20, // 1MB
+	}
+	if !cfg.tlsEnabled() {
+		return srv, nil
+	}
+	nextProtos := []string{"h2", "http/1.1"}
+	if cfg.HTTP3Port != "" {
+		// Add h3 to advertised protocols
+		nextProtos = append([]string{"h3"}, nextProtos...)
+	}
+	srv.TLSConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+	}
+	// Explicitly configure HTTP/2
+	if err := http2.ConfigureServer(srv, &http2.Server{}); err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP/2: %w", err)
+	}
+	return srv, nil
+}
+
+func (cfg ServerConfig) tlsEnabled() bool {
+	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
+}
+
+// start binds cfg's address and serves it in the background.
+func (l *httpListener) start(cfg ServerConfig, handler http.Handler) error {
+	srv, err := newHTTPServer(cfg, handler)
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.cfg, l.handler, l.server, l.ln = cfg, handler, srv, ln
+	l.mu.Unlock()
+	go serveHTTP(srv, ln, cfg)
+	return nil
+}
+
+// serveHTTP runs srv on ln until it is shut down or ln is handed over.
+func serveHTTP(srv *http.Server, ln net.Listener, cfg ServerConfig) {
+	var err error
+	if cfg.tlsEnabled() {
+		err = srv.ServeTLS(ln, cfg.TLSCertFile, cfg.TLSKeyFile)
+	} else {
+		err = srv.Serve(ln)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, net.ErrClosed) {
+		slog.Error("HTTP server stopped", "addr", srv.Addr, "err", err)
+	}
+}
+
+// rebind moves the listener to host:port and returns the address it is
+// bound to, resolved like ListenAddr. A new address is bound before
+// the old socket is closed, so a bad address leaves the server where it
+// was; restarting on the same address closes the old socket first and
+// is unreachable only for that instant. The old server drains its
+// in-flight requests in the background.
+func (l *httpListener) rebind(host, port string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.server == nil {
+		return "", errors.New("HTTP server is not running")
+	}
+	cfg := l.cfg
+	cfg.Host, cfg.Port = host, port
+	srv, err := newHTTPServer(cfg, l.handler)
+	if err != nil {
+		return "", err
+	}
+	if cfg.tlsEnabled() {
+		// Fail before touching the old listener if the certificate can
+		// no longer be loaded.
+		if _, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
+			return "", fmt.Errorf("load TLS certificate: %w", err)
+		}
+	}
+
+	oldSrv, oldLn := l.server, l.ln
+	var ln net.Listener
+	if host == l.cfg.Host && port == l.cfg.Port {
+		_ = oldLn.Close()
+		ln, err = net.Listen("tcp", srv.Addr)
+		if err != nil {
+			// The old socket is gone; try to take it back.
+			if back, backErr := net.Listen("tcp", oldSrv.Addr); backErr == nil {
+				l.ln = back
+				go serveHTTP(oldSrv, back, l.cfg)
+			} else {
+				slog.Error("HTTP server is not listening after a failed restart", "addr", oldSrv.Addr, "err", backErr)
+			}
+			return "", fmt.Errorf("listen on %s: %w", srv.Addr, err)
+		}
+	} else {
+		ln, err = net.Listen("tcp", srv.Addr)
+		if err != nil {
+			return "", fmt.Errorf("listen on %s: %w", srv.Addr, err)
+		}
+	}
+
+	l.cfg, l.server, l.ln = cfg, srv, ln
+	go serveHTTP(srv, ln, cfg)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), listenerDrainTimeout)
+		defer cancel()
+		if err := oldSrv.Shutdown(ctx); err != nil && !errors.Is(err, net.ErrClosed) {
+			slog.Warn("previous HTTP server did not drain cleanly", "addr", oldSrv.Addr, "err", err)
+		}
+	}()
+	addr := ln.Addr().String()
+	slog.Info("HTTP server listening on new address", "addr", addr, "previous", oldLn.Addr().String())
+	return addr, nil
+}
+
+// shutdown stops the current server, waiting for in-flight requests
+// until ctx expires.
+func (l *httpListener) shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	srv := l.server
+	l.mu.Unlock()
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
+
+// ListenAddr returns the address the HTTP server is listening on, or ""
+// before it has started.
+func (s *Server) ListenAddr() string {
+	s.listener.mu.Lock()
+	defer s.listener.mu.Unlock()
+	if s.listener.ln == nil {
+		return ""
+	}
+	return s.listener.ln.Addr().String()
+}
+
+// RestartListener restarts the HTTP server on the configured address:
+// listen_host and listen_port when set, otherwise the address it was
+// started with. Returns the new address in ListenAddr form.
+func (s *Server) RestartListener() (string, error) {
+	cur := s.listener.config()
+	host, port := listenTarget(config.Snapshot(), cur)
+	return s.listener.rebind(host, port)
+}
+
+// listenTarget resolves the address to listen on: the listen_host and
+// listen_port settings override the --host and --port flags in cur.
+func listenTarget(cfg config.Config, cur ServerConfig) (host, port string) {
+	host, port = cur.Host, cur.Port
+	if cfg.ListenHost != "" {
+		host = cfg.ListenHost
+	}
+	if cfg.ListenPort > 0 {
+		port = strconv.Itoa(cfg.ListenPort)
+	}
+	return host, port
+}
@@ -0,0 +1,100 @@
+// file: internal/server/listener_test.go
+// version: 1.1.0
+// guid: e5c29b07-1a83-4d6f-b7e4-30f8a6d1c952
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getBody(t *testing.T, addr string) string {
+	t.Helper()
+	client := &http.Client{Timeout: 2 * time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err := client.Get("http://" + addr + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func freePort(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+}
+
+func TestHTTPListener_Rebind(t *testing.T) {
+	var l httpListener
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, "ok") })
+	first := freePort(t)
+	require.NoError(t, l.start(ServerConfig{Host: "127.0.0.1", Port: first}, handler))
+	t.Cleanup(func() { _ = l.shutdown(t.Context()) })
+	assert.Equal(t, "ok", getBody(t, "127.0.0.1:"+first))
+
+	// Moving to a new port serves there and lets the old one go.
+	second := freePort(t)
+	addr, err := l.rebind("127.0.0.1", second)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:"+second, addr)
+	assert.Equal(t, "ok", getBody(t, addr))
+	assert.Eventually(t, func() bool {
+		c, err := net.DialTimeout("tcp", "127.0.0.1:"+first, 200*time.Millisecond)
+		if err == nil {
+			c.Close()
+		}
+		return err != nil
+	}, 2*time.Second, 20*time.Millisecond)
+
+	// Restarting in place keeps the address.
+	addr, err = l.rebind("127.0.0.1", second)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", getBody(t, addr))
+
+	// An address that cannot be bound leaves the listener where it was.
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer busy.Close()
+	_, err = l.rebind("127.0.0.1", strconv.Itoa(busy.Addr().(*net.TCPAddr).Port))
+	require.Error(t, err)
+	assert.Equal(t, second, l.config().Port)
+	assert.Equal(t, "ok", getBody(t, "127.0.0.1:"+second))
+}
+
+// The returned address is the resolved one ListenAddr reports, so
+// restarting on an unresolved host is not mistaken for a move.
+func TestHTTPListener_RebindReturnsResolvedAddr(t *testing.T) {
+	var l httpListener
+	port := freePort(t)
+	require.NoError(t, l.start(ServerConfig{Host: "localhost", Port: port}, http.NotFoundHandler()))
+	t.Cleanup(func() { _ = l.shutdown(t.Context()) })
+	before := l.ln.Addr().String()
+
+	addr, err := l.rebind("localhost", port)
+	require.NoError(t, err)
+	assert.Equal(t, before, addr)
+	assert.Equal(t, l.ln.Addr().String(), addr)
+}
+
+func TestListenTarget(t *testing.T) {
+	cur := ServerConfig{Host: "localhost", Port: "8484"}
+	host, port := listenTarget(config.Config{}, cur)
+	assert.Equal(t, "localhost", host)
+	assert.Equal(t, "8484", port)
+
+	host, port = listenTarget(config.Config{ListenHost: "0.0.0.0", ListenPort: 9000}, cur)
+	assert.Equal(t, "0.0.0.0", host)
+	assert.Equal(t, "9000", port)
+}
@@ -1,5 +1,5 @@
 // file: internal/server/maintenance_fixups.go
-// version: 2.5.0
+// version: 2.5.1
 // guid: a1b2c3d4-e5f6-7a8b-9c0d-1e2f3a4b5c6d
 // last-edited: 2026-06-10
 
@@ -243,7 +243,7 @@ func wipeBookFiles(store maintenanceStore, dryRun bool) (int64, error) {
 		n, err := store.CountFiles()
 		return int64(n), err
 	}
-	if s, ok := store.(*database.PebbleStore); ok {
+	if s, ok := database.AsPebbleStore(store); ok {
 		n, err := s.WipeByPrefixes([]string{"book_file:"})
 		return int64(n), err
 	}
@@ -271,7 +271,7 @@ func wipeBookFiles(store maintenanceStore, dryRun bool) (int64, error) {
 
 // wipeSegments deletes all book_segment rows using the appropriate store backend.
 func wipeSegments(store maintenanceStore, dryRun bool) (int64, error) {
-	if s, ok := store.(*database.PebbleStore); ok {
+	if s, ok := database.AsPebbleStore(store); ok {
 		// Pebble segments use "bf:" (primary) and "bfs:" (secondary) prefixes.
 		if dryRun {
 			n, err := s.CountByPrefix("bf:")
@@ -289,7 +289,7 @@ func wipeBooks(store maintenanceStore, dryRun bool) (int64, error) {
 		n, err := store.CountBooks()
 		return int64(n), err
 	}
-	if s, ok := store.(*database.PebbleStore); ok {
+	if s, ok := database.AsPebbleStore(store); ok {
 		// Book keys: "book:" prefix. Include secondary indexes.
 		n, err := s.WipeByPrefixes([]string{"book:"})
 		return int64(n), err
@@ -303,7 +303,7 @@ func wipeAuthors(store maintenanceStore, dryRun bool) (int64, error) {
 		n, err := store.CountAuthors()
 		return int64(n), err
 	}
-	if s, ok := store.(*database.PebbleStore); ok {
+	if s, ok := database.AsPebbleStore(store); ok {
 		n, err := s.WipeByPrefixes([]string{"author:"})
 		return int64(n), err
 	}
@@ -316,7 +316,7 @@ func wipeSeries(store maintenanceStore, dryRun bool) (int64, error) {
 		n, err := store.CountSeries()
 		return int64(n), err
 	}
-	if s, ok := store.(*database.PebbleStore); ok {
+	if s, ok := database.AsPebbleStore(store); ok {
 		n, err := s.WipeByPrefixes([]string{"series:"})
 		return int64(n), err
 	}
@@ -325,7 +325,7 @@ func wipeSeries(store maintenanceStore, dryRun bool) (int64, error) {
 
 // wipeExternalIDs deletes all external_id_map rows using the appropriate store backend.
 func wipeExternalIDs(store maintenanceStore, dryRun bool) (int64, error) {
-	if s, ok := store.(*database.PebbleStore); ok {
+	if s, ok := database.AsPebbleStore(store); ok {
 		if dryRun {
 			n, err := s.CountByPrefix("ext_id:")
 			return int64(n), err
@@ -1,7 +1,7 @@
 // file: internal/server/maintenance_window_handlers_test.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: d5e6f7a8-b9c0-1234-efab-456789012345
-// last-edited: 2026-05-11
+// last-edited: 2026-10-17
 
 package server
 
@@ -10,12 +10,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"path/filepath"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
-	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
 	"github.com/falkcorp/audiobook-organizer/internal/scheduler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,16 +26,7 @@ func setupMaintenanceTestServer(t *testing.T) *Server {
 	t.Helper()
 	gin.SetMode(gin.TestMode)
 
-	pebblePath := filepath.Join(t.TempDir(), "pebble")
-	store, err := database.NewPebbleStore(pebblePath)
-	require.NoError(t, err, "open pebble store")
-
-	origStore := database.GetGlobalStore()
-	database.SetGlobalStore(store)
-	t.Cleanup(func() {
-		database.SetGlobalStore(origStore)
-		store.Close()
-	})
+	store := storetest.NewGlobal(t)
 
 	srv := NewServer(store)
 	srv.scheduler = scheduler.NewTaskScheduler(scheduler.SchedulerDeps{
@@ -0,0 +1,106 @@
+// file: internal/server/mediaserver_export_op.go
+// version: 1.0.0
+// guid: b2e8d51c-7f46-4a93-8c0e-5d1a9f3b6e27
+// last-edited: 2026-10-17
+
+// mediaserver_export_op registers "mediaserver.export-plex", which
+// writes the organized library as a Plex-named tree of links under
+// integrations.plex_export_dir. Point a Plex music library at that
+// directory; the media-server notifier rescans it when the export ends.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/layout"
+	"github.com/falkcorp/audiobook-organizer/internal/mediaserver"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+type plexExportParams struct {
+	// Dir overrides integrations.plex_export_dir.
+	Dir string `json:"dir"`
+	// Mode is symlink (default) or hardlink.
+	Mode   string `json:"mode"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// RegisterPlexExportOp registers the "mediaserver.export-plex" OperationDef.
+func (s *Server) RegisterPlexExportOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              mediaserver.ExportOpID,
+		Plugin:          "mediaserver",
+		DisplayName:     "Export Library for Plex",
+		Description:     "Write the organized library as a tree of links named the way Plex reads audiobooks.",
+		DefaultPriority: opsregistry.PriorityNormal,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         2 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeRestart,
+		ConcurrencyKey:  mediaserver.ExportOpID,
+		Permissions:     []auth.Permission{auth.PermIntegrationsManage},
+		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead, opsregistry.CapFilesRead, opsregistry.CapFilesWrite},
+		Run: func(ctx context.Context, raw json.RawMessage, reporter opsregistry.Reporter) error {
+			var p plexExportParams
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &p); err != nil {
+					return fmt.Errorf("%s: decode params: %w", mediaserver.ExportOpID, err)
+				}
+			}
+			return s.runPlexExport(ctx, p, reporter)
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterPlexExportOp(reg) })
+}
+
+func (s *Server) runPlexExport(ctx context.Context, p plexExportParams, reporter opsregistry.Reporter) error {
+	store := s.Store()
+	if store == nil {
+		return errors.New("mediaserver.export-plex: database not initialized")
+	}
+	cfg := config.Snapshot()
+	if p.Dir == "" {
+		p.Dir = cfg.Integrations.PlexExportDir
+	}
+	if p.Dir == "" {
+		return errors.New("mediaserver.export-plex: set integrations.plex_export_dir or pass dir")
+	}
+	if cfg.RootDir == "" {
+		return errors.New("mediaserver.export-plex: root_dir is not configured")
+	}
+
+	_ = reporter.UpdateProgress(0, 2, "Reading library layout")
+	l, skipped, err := layout.Export(store, cfg.RootDir)
+	if err != nil {
+		return fmt.Errorf("mediaserver.export-plex: %w", err)
+	}
+	if skipped > 0 {
+		_ = reporter.Log(slog.LevelInfo, fmt.Sprintf("Skipping %d books not yet organized under %s", skipped, cfg.RootDir))
+	}
+	_ = reporter.UpdateProgress(1, 2, "Writing links under "+p.Dir)
+	res, err := mediaserver.Export(ctx, l, mediaserver.ExportOptions{Root: cfg.RootDir, Dir: p.Dir, Mode: p.Mode, DryRun: p.DryRun})
+	if err != nil {
+		return fmt.Errorf("mediaserver.export-plex: %w", err)
+	}
+	for _, f := range res.Files {
+		if f.Status == layout.StatusConflict || f.Status == layout.StatusFailed {
+			_ = reporter.Log(slog.LevelWarn, fmt.Sprintf("%s: %s", f.Path, f.Error))
+		}
+	}
+	_ = reporter.UpdateProgress(2, 2, "Export finished")
+	_ = reporter.Log(slog.LevelInfo, fmt.Sprintf("Plex export to %s: %d placed, %d already present, %d would be placed, %d missing, %d conflicts, %d failed; %d stale links removed",
+		p.Dir, res.Counts[layout.StatusPlaced], res.Counts[layout.StatusPresent], res.Counts[layout.StatusWouldPlace],
+		res.Counts[layout.StatusMissing], res.Counts[layout.StatusConflict], res.Counts[layout.StatusFailed], res.Removed))
+	return nil
+}
@@ -1,7 +1,7 @@
 // file: internal/server/metadata_candidate_op.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: 3f7e2c91-b4a0-4d8e-9c5f-1a6b7d8e0f23
-// last-edited: 2026-05-11
+// last-edited: 2026-10-16
 //
 // Registers the metadata.candidate-fetch v2 OperationDef. Pure params
 // type moved to internal/metabatch.FetchOpParams.
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/metabatch"
 	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
@@ -37,7 +38,7 @@ func (s *Server) RegisterMetadataCandidateFetchOp(reg *opsregistry.Registry) err
 		ID:              "metadata.candidate-fetch",
 		Plugin:          "metadata",
 		DisplayName:     "Fetch Metadata Candidates",
-		Description:     "Fetch and cache metadata candidates for a set of audiobooks (rate-limited, parallel). Results are stored in v1 OperationResult rows for review.",
+		Description:     "Fetch and cache metadata candidates for a set of audiobooks (rate-limited, metadata_fetch_concurrency workers). Results are stored in v1 OperationResult rows for review.",
 		DefaultPriority: opsregistry.PriorityNormal,
 		Cancellable:     true,
 		Isolate:         false,
@@ -84,7 +85,7 @@ func (s *Server) RegisterMetadataCandidateFetchOp(reg *opsregistry.Registry) err
 
 			var completed int64 = int64(p.AlreadyDone)
 			var wg sync.WaitGroup
-			numWorkers := 8
+			numWorkers := max(config.AppConfig.MetadataFetchConcurrency, 1)
 			if numWorkers > len(p.BookIDs) {
 				numWorkers = len(p.BookIDs)
 			}
@@ -1,5 +1,5 @@
 // file: internal/server/metadata_fetch_service_test.go
-// version: 4.4.0
+// version: 4.5.0
 // guid: f6a7b8c9-d0e1-f2a3-b4c5-d6e7f8a9b0c1
 
 package server
@@ -809,7 +809,7 @@ func TestApplySeriesPositionFilter_RejectsWrongPosition(t *testing.T) {
 	results := []metadata.BookMetadata{
 		{Title: "The Long Cosmos", SeriesPosition: "3"}, // wrong — book is #5
 	}
-	got := metafetch.ApplySeriesPositionFilter(results, 5)
+	got := metafetch.ApplySeriesPositionFilter(results, "5")
 	if got != nil {
 		t.Errorf("expected nil (wrong position), got %v", got)
 	}
@@ -819,7 +819,7 @@ func TestApplySeriesPositionFilter_AcceptsCorrectPosition(t *testing.T) {
 	results := []metadata.BookMetadata{
 		{Title: "The Long Cosmos", SeriesPosition: "5"},
 	}
-	got := metafetch.ApplySeriesPositionFilter(results, 5)
+	got := metafetch.ApplySeriesPositionFilter(results, "5")
 	if got == nil {
 		t.Fatal("expected result, got nil")
 	}
@@ -833,7 +833,7 @@ func TestApplySeriesPositionFilter_NoKnownPosition(t *testing.T) {
 		{Title: "Some Book", SeriesPosition: "3"},
 	}
 	// knownPosition == 0 means "we don't know" — pass through unchanged
-	got := metafetch.ApplySeriesPositionFilter(results, 0)
+	got := metafetch.ApplySeriesPositionFilter(results, "")
 	if len(got) != 1 {
 		t.Errorf("expected 1 result, got %d", len(got))
 	}
@@ -844,7 +844,7 @@ func TestApplySeriesPositionFilter_NoPositionInResult(t *testing.T) {
 	results := []metadata.BookMetadata{
 		{Title: "The Long Cosmos"},
 	}
-	got := metafetch.ApplySeriesPositionFilter(results, 5)
+	got := metafetch.ApplySeriesPositionFilter(results, "5")
 	if len(got) != 1 {
 		t.Errorf("expected 1 result (no position to reject), got %d", len(got))
 	}
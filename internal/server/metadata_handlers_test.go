@@ -1,7 +1,7 @@
 // file: internal/server/metadata_handlers_test.go
-// version: 1.0.2
+// version: 1.1.0
 // guid: 7a3e2f1b-9c4d-4e8a-b6f0-1d5c2a0e3b7f
-// last-edited: 2026-05-03
+// last-edited: 2026-10-17
 
 package server
 
@@ -14,21 +14,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
 )
 
 func setupRatingTestServer(t *testing.T) *Server {
 	t.Helper()
-	pebblePath := t.TempDir() + "/pebble"
-	store, err := database.NewPebbleStore(pebblePath)
-	if err != nil {
-		t.Fatalf("open pebble: %v", err)
-	}
-	origStore := database.GetGlobalStore()
-	database.SetGlobalStore(store)
-	t.Cleanup(func() {
-		database.SetGlobalStore(origStore)
-		store.Close()
-	})
+	store := storetest.NewGlobal(t)
 	srv := NewServer(store)
 	return srv
 }
@@ -1,7 +1,7 @@
 // file: internal/server/metadata_ops.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: fba55738-5898-4950-8e79-3ee008ad0c70
-// last-edited: 2026-06-03
+// last-edited: 2026-10-17
 //
 // Async-operation machinery for the metadata domain, relocated verbatim from
 // metadata_handlers.go (ADR-003 Phase 4) when the 19 metadata HTTP handlers
@@ -70,7 +70,7 @@ func (s *Server) runBulkMetadataFetchAll(
 	// Total unknown until books load; use placeholder (0/1) to avoid 0/0.
 	_ = progress.UpdateProgress(0, 1, "loading books (0/1 0.00%)")
 
-	allBooks, err := store.GetAllBooks(0, 0)
+	allBooks, err := database.GetAllBooksContext(ctx, store, 0, 0)
 	if err != nil {
 		op.SetStatus("failed")
 		logging.Error(ctx, "failed to load all books", "err", err)
@@ -0,0 +1,245 @@
+// file: internal/server/middleware/api_version.go
+// version: 1.1.0
+// guid: db7115a2-17ec-4bad-b675-2d81ced6e5b1
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Unversioned /api/* paths were deprecated on LegacyAPIDeprecatedAt and
+// stop being served after LegacyAPISunset.
+var (
+	LegacyAPIDeprecatedAt = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	LegacyAPISunset       = time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+)
+
+// unversionedAPIExempt lists /api/* paths that are registered directly
+// and are not aliases of /api/v1.
+var unversionedAPIExempt = []string{"/api/health", "/api/events", "/api/metrics"}
+
+// APIVersions serves every API version off the single /api/v1 route
+// tree. It must be installed with engine.Use before the /api/v1 group is
+// registered so unmatched /api/* paths reach it through NoRoute.
+//
+//   - /api/v1/* passes through untouched.
+//   - /api/v2/* runs the matching v1 route and rewrites its JSON body into
+//     the v2 envelope (see V2Envelope).
+//   - Other /api/* paths run the matching v1 route in-process, so methods
+//     and bodies survive (a 301 turned POSTs into GETs), and carry
+//     Deprecation, Sunset and successor-version Link headers.
+func APIVersions(engine *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		switch {
+		case !strings.HasPrefix(path, "/api/"), strings.HasPrefix(path, "/api/v1/"):
+			c.Next()
+		case strings.HasPrefix(path, "/api/v2/"):
+			ew := &v2EnvelopeWriter{w: c.Writer}
+			serveAsV1(engine, ew, c.Request, strings.TrimPrefix(path, "/api/v2"))
+			ew.finish()
+			c.Abort()
+		default:
+			for _, p := range unversionedAPIExempt {
+				if strings.HasPrefix(path, p) {
+					c.Next()
+					return
+				}
+			}
+			rest := strings.TrimPrefix(path, "/api")
+			h := c.Writer.Header()
+			h.Set("Deprecation", fmt.Sprintf("@%d", LegacyAPIDeprecatedAt.Unix()))
+			h.Set("Sunset", LegacyAPISunset.Format(http.TimeFormat))
+			h.Add("Link", fmt.Sprintf("</api/v1%s>; rel=\"successor-version\"", rest))
+			serveAsV1(engine, c.Writer, c.Request, rest)
+			c.Abort()
+		}
+	}
+}
+
+// serveAsV1 dispatches a copy of req re-pathed to /api/v1+rest through
+// engine, writing the response to w.
+func serveAsV1(engine *gin.Engine, w http.ResponseWriter, req *http.Request, rest string) {
+	r := req.Clone(req.Context())
+	r.URL.Path = "/api/v1" + rest
+	r.URL.RawPath = ""
+	r.RequestURI = r.URL.RequestURI()
+	// The outer chain has already wrapped w for compression; a second
+	// pass in the inner chain would double-encode the body.
+	r.Header.Del("Accept-Encoding")
+	engine.ServeHTTP(w, r)
+}
+
+// v2EnvelopeWriter buffers JSON responses so finish can re-wrap them;
+// anything else (files, streams, empty bodies) is passed straight through.
+type v2EnvelopeWriter struct {
+	w       http.ResponseWriter
+	status  int
+	decided bool
+	buffer  bool
+	buf     bytes.Buffer
+}
+
+func (e *v2EnvelopeWriter) Header() http.Header { return e.w.Header() }
+
+func (e *v2EnvelopeWriter) WriteHeader(code int) {
+	if e.decided {
+		return
+	}
+	e.decided = true
+	e.status = code
+	e.buffer = code != http.StatusNoContent && code != http.StatusNotModified &&
+		strings.HasPrefix(e.w.Header().Get("Content-Type"), "application/json")
+	if !e.buffer {
+		e.w.WriteHeader(code)
+	}
+}
+
+func (e *v2EnvelopeWriter) Write(p []byte) (int, error) {
+	if !e.decided {
+		e.WriteHeader(http.StatusOK)
+	}
+	if e.buffer {
+		return e.buf.Write(p)
+	}
+	return e.w.Write(p)
+}
+
+func (e *v2EnvelopeWriter) Flush() {
+	if e.buffer {
+		return
+	}
+	if f, ok := e.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (e *v2EnvelopeWriter) finish() {
+	if !e.buffer {
+		return
+	}
+	body := V2Envelope(e.status, e.buf.Bytes())
+	e.w.Header().Del("Content-Length")
+	e.w.WriteHeader(e.status)
+	_, _ = e.w.Write(body)
+}
+
+// V2Meta is the meta block on every v2 JSON response.
+type V2Meta struct {
+	APIVersion string `json:"api_version"`
+	Count      *int   `json:"count,omitempty"`
+	Limit      *int   `json:"limit,omitempty"`
+	Offset     *int   `json:"offset,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+}
+
+// V2Error is the error object of a failed v2 response.
+type V2Error struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Status  int            `json:"status"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// V2Response is the v2 envelope: exactly one of Data and Error is set.
+type V2Response struct {
+	Data  any      `json:"data,omitempty"`
+	Error *V2Error `json:"error,omitempty"`
+	Meta  V2Meta   `json:"meta"`
+}
+
+// v1PaginationKeys may sit next to "data" in a v1 success body.
+var v1PaginationKeys = map[string]bool{"count": true, "limit": true, "offset": true, "total": true}
+
+// V2Envelope converts a v1 JSON body with the given status into the v2
+// envelope. Success bodies of the form {"data": ..., count/limit/offset/
+// total} are unwrapped with the pagination fields moved to meta; any other
+// success body becomes data as-is. Error bodies ({"error": msg, "code",
+// ...} or {"message": msg}) become a structured error, with leftover
+// fields kept as details and a code derived from the status when the
+// handler set none. Bodies that aren't JSON are returned unchanged.
+func V2Envelope(status int, body []byte) []byte {
+	var raw any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return body
+	}
+	resp := V2Response{Meta: V2Meta{APIVersion: "v2"}}
+	obj, isObj := raw.(map[string]any)
+
+	if status >= http.StatusBadRequest {
+		v2err := &V2Error{Status: status, Code: statusCode(status), Message: http.StatusText(status)}
+		if isObj {
+			if msg, ok := obj["error"].(string); ok && msg != "" {
+				v2err.Message = msg
+			} else if msg, ok := obj["message"].(string); ok && msg != "" {
+				v2err.Message = msg
+			}
+			if code, ok := obj["code"].(string); ok && code != "" {
+				v2err.Code = code
+			}
+			for k, v := range obj {
+				switch k {
+				case "error", "message", "code", "status":
+					continue
+				}
+				if v2err.Details == nil {
+					v2err.Details = map[string]any{}
+				}
+				v2err.Details[k] = v
+			}
+		}
+		resp.Error = v2err
+	} else {
+		resp.Data = raw
+		if data, ok := obj["data"]; isObj && ok && onlyPagination(obj) {
+			resp.Data = data
+			resp.Meta.Count = intField(obj, "count")
+			resp.Meta.Limit = intField(obj, "limit")
+			resp.Meta.Offset = intField(obj, "offset")
+			resp.Meta.Total = intField(obj, "total")
+		}
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func onlyPagination(obj map[string]any) bool {
+	for k := range obj {
+		if k != "data" && !v1PaginationKeys[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func intField(obj map[string]any, key string) *int {
+	f, ok := obj[key].(float64)
+	if !ok {
+		return nil
+	}
+	n := int(f)
+	return &n
+}
+
+// statusCode turns an HTTP status into an error code, e.g. 404 →
+// "NOT_FOUND".
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "ERROR"
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
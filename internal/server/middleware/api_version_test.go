@@ -0,0 +1,110 @@
+// file: internal/server/middleware/api_version_test.go
+// version: 1.0.0
+// guid: 4a495941-4b75-4116-9bc7-a41cae71cb0d
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVersionedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/health", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	r.Use(APIVersions(r))
+	r.POST("/api/v1/echo", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusOK, gin.H{"data": gin.H{"method": c.Request.Method, "body": string(body)}})
+	})
+	r.GET("/api/v1/items", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": []int{1, 2}, "count": 2, "limit": 10})
+	})
+	r.GET("/api/v1/fail", func(c *gin.Context) {
+		c.JSON(http.StatusConflict, gin.H{"error": "already running", "code": "CONFLICT", "status": 409, "operation_id": "op-1"})
+	})
+	r.GET("/api/v1/file", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/plain", []byte("raw"))
+	})
+	r.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "endpoint not found"})
+	})
+	return r
+}
+
+func TestAPIVersions_LegacyPathServedInProcess(t *testing.T) {
+	r := newVersionedRouter()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/echo", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"method":"POST","body":"{\"a\":1}"}}`, w.Body.String())
+	assert.Equal(t, "@1792108800", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1/echo>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestAPIVersions_V1AndExemptUntouched(t *testing.T) {
+	r := newVersionedRouter()
+	for _, path := range []string{"/api/v1/items", "/api/health"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Empty(t, w.Header().Get("Deprecation"), path)
+	}
+}
+
+func TestAPIVersions_V2Envelope(t *testing.T) {
+	r := newVersionedRouter()
+	get := func(path string) (*httptest.ResponseRecorder, V2Response) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var resp V2Response
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), path)
+		}
+		return w, resp
+	}
+
+	w, resp := get("/api/v2/items")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []any{float64(1), float64(2)}, resp.Data)
+	assert.Equal(t, "v2", resp.Meta.APIVersion)
+	require.NotNil(t, resp.Meta.Count)
+	assert.Equal(t, 2, *resp.Meta.Count)
+	assert.Nil(t, resp.Meta.Offset)
+
+	w, resp = get("/api/v2/fail")
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, V2Error{Code: "CONFLICT", Message: "already running", Status: 409,
+		Details: map[string]any{"operation_id": "op-1"}}, *resp.Error)
+
+	w, resp = get("/api/v2/missing")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "NOT_FOUND", resp.Error.Code)
+	assert.Equal(t, "endpoint not found", resp.Error.Message)
+
+	w, _ = get("/api/v2/file")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "raw", w.Body.String())
+}
+
+func TestV2Envelope_NonPaginationSiblingsKeptInData(t *testing.T) {
+	out := V2Envelope(http.StatusOK, []byte(`{"data":{"id":1},"warnings":["x"]}`))
+	assert.JSONEq(t, `{"data":{"data":{"id":1},"warnings":["x"]},"meta":{"api_version":"v2"}}`, string(out))
+	assert.Equal(t, "not json", string(V2Envelope(http.StatusOK, []byte("not json"))))
+}
@@ -1,7 +1,7 @@
 // file: internal/server/middleware/auth.go
-// version: 1.5.0
+// version: 1.6.0
 // guid: 83c42ecb-1df2-4baf-9890-3f91ab4db6fe
-// last-edited: 2026-06-04
+// last-edited: 2026-10-16
 
 package middleware
 
@@ -15,8 +15,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/libraryscope"
 )
 
 // rbacUnsupportedWarnOnce ensures the "RBAC unsupported on this backend" warning
@@ -88,6 +90,13 @@ func CurrentAPIKey(c *gin.Context) (*database.APIKey, bool) {
 	return key, ok && key != nil
 }
 
+// LibraryScope returns the library isolation scope of the authenticated
+// user, or nil when the caller may see every library.
+func LibraryScope(c *gin.Context) *libraryscope.Scope {
+	user, _ := CurrentUser(c)
+	return libraryscope.ForUser(&config.AppConfig, user)
+}
+
 // RequireAuth enforces session-based auth when at least one user exists.
 // Tokens prefixed with "abk_" are routed through API key validation;
 // all other tokens fall through to session validation.
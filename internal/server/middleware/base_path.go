@@ -0,0 +1,47 @@
+// file: internal/server/middleware/base_path.go
+// version: 1.0.0
+// guid: 3b766188-45ff-4f4a-a071-4a0cf9c167cb
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// StripBasePath lets the app be hosted under basePath (e.g. "/audiobooks")
+// behind a reverse proxy. Requests under the prefix reach next with it
+// removed; requests without it pass through unchanged, so both proxies that
+// forward the full path and ones that strip it work. The bare prefix
+// redirects to prefix + "/" so relative URLs in the SPA resolve. An empty
+// basePath returns next as-is.
+//
+// It wraps the router rather than running as gin middleware because gin
+// picks the route before any middleware sees the request.
+func StripBasePath(basePath string, next http.Handler) http.Handler {
+	basePath = strings.TrimRight(basePath, "/")
+	if basePath == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case path == basePath:
+			target := basePath + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+		case strings.HasPrefix(path, basePath+"/"):
+			r2 := r.Clone(r.Context())
+			u := *r.URL
+			u.Path = strings.TrimPrefix(path, basePath)
+			u.RawPath = strings.TrimPrefix(r.URL.RawPath, basePath)
+			r2.URL = &u
+			next.ServeHTTP(w, r2)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
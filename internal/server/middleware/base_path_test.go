@@ -0,0 +1,40 @@
+// file: internal/server/middleware/base_path_test.go
+// version: 1.0.0
+// guid: cfe5961c-785e-495d-a722-00a1241c8c98
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripBasePath(t *testing.T) {
+	var seen string
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.URL.Path
+	})
+	h := StripBasePath("/audiobooks", echo)
+
+	for in, want := range map[string]string{
+		"/audiobooks/api/v1/health": "/api/v1/health",
+		"/audiobooks/":              "/",
+		"/api/v1/health":            "/api/v1/health", // proxy already stripped it
+		"/audiobooksx/index.html":   "/audiobooksx/index.html",
+	} {
+		seen = ""
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, in, nil))
+		assert.Equal(t, want, seen, in)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/audiobooks?x=1", nil))
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/audiobooks/?x=1", w.Header().Get("Location"))
+
+	assert.NotNil(t, StripBasePath("", echo))
+}
@@ -0,0 +1,193 @@
+// file: internal/server/middleware/compress.go
+// version: 1.0.0
+// guid: 1f701e1e-9065-4a2e-9f4b-49128066d538
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingZstd = "zstd"
+	encodingGzip = "gzip"
+)
+
+// zstdWindowSize keeps encoder memory low and stays well under the 8 MiB
+// window browsers accept for zstd content-encoding.
+const zstdWindowSize = 1 << 20
+
+var (
+	gzipPool = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	zstdPool = sync.Pool{New: func() any {
+		w, _ := zstd.NewWriter(io.Discard,
+			zstd.WithEncoderLevel(zstd.SpeedDefault),
+			zstd.WithEncoderConcurrency(1),
+			zstd.WithWindowSize(zstdWindowSize))
+		return w
+	}}
+)
+
+// Compress compresses text-like responses with zstd or gzip, whichever the
+// client's Accept-Encoding prefers (zstd on a tie). Paths under any of
+// excludedPrefixes are never compressed. The decision is made when the
+// handler starts writing, so responses that are already encoded, partial
+// (206), empty (204/304) or binary (audio, images, archives) and event
+// streams pass through untouched.
+func Compress(excludedPrefixes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || strings.EqualFold(c.GetHeader("Connection"), "upgrade") {
+			c.Next()
+			return
+		}
+		for _, p := range excludedPrefixes {
+			if strings.HasPrefix(c.Request.URL.Path, p) {
+				c.Next()
+				return
+			}
+		}
+
+		cw := &compressWriter{ResponseWriter: c.Writer, encoding: encoding}
+		c.Writer = cw
+		defer cw.close()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks zstd or gzip from an Accept-Encoding header, or
+// "" when neither is acceptable.
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name != encodingZstd && name != encodingGzip {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(f), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && name == encodingZstd) {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// compressibleType reports whether a Content-Type is worth compressing.
+func compressibleType(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mt == "text/event-stream":
+		return false
+	case strings.HasPrefix(mt, "text/"),
+		strings.HasSuffix(mt, "+json"), strings.HasSuffix(mt, "+xml"):
+		return true
+	}
+	switch mt {
+	case "application/json", "application/javascript", "application/xml",
+		"application/x-ndjson", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// encoder is the common surface of the pooled gzip and zstd writers.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+}
+
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	decided  bool
+	enc      encoder
+}
+
+// decide runs on the first write, once status and headers are final.
+func (w *compressWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	h := w.Header()
+	switch status := w.Status(); {
+	case status < 200, status == 204, status == 206, status == 304:
+		return
+	}
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" || !compressibleType(h.Get("Content-Type")) {
+		return
+	}
+	h.Set("Content-Encoding", w.encoding)
+	h.Add("Vary", "Accept-Encoding")
+	h.Del("Content-Length")
+	if w.encoding == encodingZstd {
+		z := zstdPool.Get().(*zstd.Encoder)
+		z.Reset(w.ResponseWriter)
+		w.enc = z
+		return
+	}
+	g := gzipPool.Get().(*gzip.Writer)
+	g.Reset(w.ResponseWriter)
+	w.enc = g
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	w.decide()
+	if w.enc != nil {
+		return w.enc.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Flush() {
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) close() {
+	if w.enc == nil {
+		return
+	}
+	_ = w.enc.Close()
+	switch e := w.enc.(type) {
+	case *zstd.Encoder:
+		e.Reset(io.Discard)
+		zstdPool.Put(e)
+	case *gzip.Writer:
+		e.Reset(io.Discard)
+		gzipPool.Put(e)
+	}
+	w.enc = nil
+}
@@ -0,0 +1,76 @@
+// file: internal/server/middleware/compress_test.go
+// version: 1.0.0
+// guid: 5e3f7305-8ac5-487b-91d7-12dd05a3980d
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "zstd", negotiateEncoding("gzip, deflate, br, zstd"))
+	assert.Equal(t, "gzip", negotiateEncoding("gzip"))
+	assert.Equal(t, "gzip", negotiateEncoding("zstd;q=0.5, gzip"))
+	assert.Equal(t, "gzip", negotiateEncoding("zstd;q=0, gzip;q=0.1"))
+	assert.Equal(t, "", negotiateEncoding("br, identity"))
+	assert.Equal(t, "", negotiateEncoding(""))
+}
+
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	payload := strings.Repeat(`{"title":"Dune"},`, 200)
+	r := gin.New()
+	r.Use(Compress("/api/events"))
+	r.GET("/json", func(c *gin.Context) { c.Data(http.StatusOK, "application/json", []byte(payload)) })
+	r.GET("/audio", func(c *gin.Context) { c.Data(http.StatusOK, "audio/mpeg", []byte(payload)) })
+	r.GET("/api/events", func(c *gin.Context) { c.Data(http.StatusOK, "application/json", []byte(payload)) })
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", accept)
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/json", "gzip, zstd")
+	require.Equal(t, "zstd", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Less(t, w.Body.Len(), len(payload))
+	zr, err := zstd.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	zr.Close()
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(body))
+
+	w = get("/json", "gzip")
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	gr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err = io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(body))
+
+	for _, path := range []string{"/audio", "/api/events"} {
+		w = get(path, "gzip, zstd")
+		assert.Empty(t, w.Header().Get("Content-Encoding"), path)
+		assert.Equal(t, payload, w.Body.String(), path)
+	}
+	w = get("/json", "identity")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
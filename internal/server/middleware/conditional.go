@@ -0,0 +1,111 @@
+// file: internal/server/middleware/conditional.go
+// version: 1.0.0
+// guid: 14a6941c-cbf0-4673-b67d-9f98c3f11943
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConditionalGET adds an ETag to successful JSON GET responses and answers
+// 304 Not Modified when the request's If-None-Match already names it. The
+// ETag is a weak hash of the body, so it stays valid whichever
+// Content-Encoding the response is sent with. Responses without their own
+// Cache-Control get "private, no-cache" so browsers store them but always
+// revalidate. Handlers that set their own ETag, and non-200 or non-JSON
+// responses, pass through unchanged.
+func ConditionalGET() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+		ew := &etagWriter{ResponseWriter: c.Writer}
+		c.Writer = ew
+		c.Next()
+		c.Writer = ew.ResponseWriter
+		ew.finish(c.GetHeader("If-None-Match"))
+	}
+}
+
+type etagWriter struct {
+	gin.ResponseWriter
+	decided bool
+	buffer  bool
+	buf     bytes.Buffer
+}
+
+func (w *etagWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	h := w.Header()
+	w.buffer = w.Status() == http.StatusOK && h.Get("ETag") == "" &&
+		strings.HasPrefix(h.Get("Content-Type"), "application/json")
+}
+
+func (w *etagWriter) Write(p []byte) (int, error) {
+	w.decide()
+	if w.buffer {
+		return w.buf.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush is a no-op while buffering: the body is released in finish.
+func (w *etagWriter) Flush() {
+	if w.buffer {
+		return
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *etagWriter) finish(ifNoneMatch string) {
+	if !w.buffer {
+		return
+	}
+	sum := sha256.Sum256(w.buf.Bytes())
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	h := w.Header()
+	h.Set("ETag", etag)
+	if h.Get("Cache-Control") == "" {
+		h.Set("Cache-Control", "private, no-cache")
+	}
+	if etagMatches(ifNoneMatch, etag) {
+		h.Del("Content-Type")
+		h.Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+}
+
+// etagMatches applies If-None-Match's weak comparison: "*" or any listed
+// tag equal to etag once W/ prefixes are ignored.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+// file: internal/server/middleware/conditional_test.go
+// version: 1.0.0
+// guid: 44f4a7dd-e21a-4b00-b819-d9b8a0ae1eaa
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalGET(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	title := "Dune"
+	r := gin.New()
+	r.Use(ConditionalGET())
+	r.GET("/books/1", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"title": title}) })
+	r.GET("/missing", func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"error": "nope"}) })
+	r.GET("/cached", func(c *gin.Context) {
+		c.Header("Cache-Control", "max-age=60")
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/books/1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"title":"Dune"}`, w.Body.String())
+
+	w = get("/books/1", `"other", `+etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	title = "Dune Messiah"
+	w = get("/books/1", etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	w = get("/missing", "*")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+
+	w = get("/cached", "")
+	assert.Equal(t, "max-age=60", w.Header().Get("Cache-Control"))
+}
+
+func TestETagMatches(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, etagMatches(`"abc"`, `W/"abc"`))
+	assert.True(t, etagMatches(`*`, `W/"abc"`))
+	assert.True(t, etagMatches(`W/"x", W/"abc"`, `W/"abc"`))
+	assert.False(t, etagMatches(`"abd"`, `W/"abc"`))
+	assert.False(t, etagMatches(``, `W/"abc"`))
+}
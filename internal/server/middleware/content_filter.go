@@ -0,0 +1,52 @@
+// file: internal/server/middleware/content_filter.go
+// version: 1.0.0
+// guid: ffb4e09d-1278-478e-8465-a8151a25a09b
+// last-edited: 2026-10-16
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/contentfilter"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+const contextContentFilterKey = "content_filter"
+
+// ResolveContentFilter loads the authenticated user's content filter
+// profile and stores it on the context for CurrentContentFilter. Must run
+// after RequireAuth. A profile that cannot be read blocks the request
+// rather than silently showing unfiltered content.
+func ResolveContentFilter(store contentfilter.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if store == nil || !ok {
+			c.Next()
+			return
+		}
+		profile, err := contentfilter.Load(store, user.ID)
+		if err != nil {
+			httputil.InternalError(c, "failed to load content filter", err)
+			c.Abort()
+			return
+		}
+		if f := contentfilter.New(profile, store); f != nil {
+			c.Set(contextContentFilterKey, f)
+		}
+		c.Next()
+	}
+}
+
+// CurrentContentFilter returns the caller's content filter, or nil when
+// the caller sees everything.
+func CurrentContentFilter(c *gin.Context) *contentfilter.Filter {
+	if c == nil {
+		return nil
+	}
+	value, ok := c.Get(contextContentFilterKey)
+	if !ok {
+		return nil
+	}
+	f, _ := value.(*contentfilter.Filter)
+	return f
+}
@@ -0,0 +1,76 @@
+// file: internal/server/middleware/timeout.go
+// version: 1.0.0
+// guid: 6b1e4d93-2a7c-4f58-8d05-c3e9a1f7b264
+// last-edited: 2026-10-17
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// untimedSegments are path segments of routes that stream their response
+// or move large bodies, and so run for as long as the client stays
+// connected.
+var untimedSegments = map[string]bool{
+	"events":   true,
+	"stream":   true,
+	"download": true,
+	"export":   true,
+	"upload":   true,
+	"restore":  true,
+	"sample":   true,
+}
+
+// selectTimeout returns the deadline for a request by route class: read
+// for GET and HEAD, write for everything else, and none for streaming and
+// transfer routes.
+func selectTimeout(method, path string, read, write time.Duration) time.Duration {
+	if strings.Contains(path, "/import/") || strings.Contains(path, "/backup/") {
+		return 0
+	}
+	for _, seg := range strings.Split(path, "/") {
+		if untimedSegments[seg] {
+			return 0
+		}
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return read
+	case http.MethodOptions:
+		return 0
+	default:
+		return write
+	}
+}
+
+// RequestTimeout gives each request a context deadline by route class. A
+// zero or negative duration leaves that class untimed. Handlers that pass
+// c.Request.Context() to the store or scanner stop when the deadline
+// passes or the client disconnects; a handler that returns without
+// responding after the deadline passed is answered 504.
+func RequestTimeout(read, write time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d := selectTimeout(c.Request.Method, c.Request.URL.Path, read, write)
+		if d <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if !c.Writer.Written() && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			httputil.RespondWithTimeout(c)
+			c.Abort()
+		}
+	}
+}
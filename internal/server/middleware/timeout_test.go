@@ -0,0 +1,72 @@
+// file: internal/server/middleware/timeout_test.go
+// version: 1.0.0
+// guid: 0d7a3c58-e1f4-4b92-a6c8-9f2b5e4d1a07
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectTimeout(t *testing.T) {
+	t.Parallel()
+
+	read, write := time.Minute, 5*time.Minute
+	assert.Equal(t, read, selectTimeout(http.MethodGet, "/api/v1/audiobooks", read, write))
+	assert.Equal(t, write, selectTimeout(http.MethodPost, "/api/v1/audiobooks/batch", read, write))
+	assert.Equal(t, write, selectTimeout(http.MethodDelete, "/api/v1/audiobooks/x", read, write))
+	assert.Zero(t, selectTimeout(http.MethodGet, "/api/v1/operations/x/logs/stream", read, write))
+	assert.Zero(t, selectTimeout(http.MethodGet, "/api/v1/events", read, write))
+	assert.Zero(t, selectTimeout(http.MethodGet, "/api/v1/metadata/export", read, write))
+	assert.Zero(t, selectTimeout(http.MethodPost, "/api/v1/import/file", read, write))
+	assert.Zero(t, selectTimeout(http.MethodPost, "/api/v1/library/restore", read, write))
+	assert.Equal(t, read, selectTimeout(http.MethodGet, "/api/v1/exports-list", read, write))
+}
+
+func TestRequestTimeout_Middleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestTimeout(20*time.Millisecond, -1))
+	wait := func(c *gin.Context) error {
+		<-c.Request.Context().Done()
+		return c.Request.Context().Err()
+	}
+	router.GET("/slow", func(c *gin.Context) {
+		httputil.InternalError(c, "failed to list audiobooks", wait(c))
+	})
+	router.GET("/silent", func(c *gin.Context) { _ = wait(c) })
+	router.GET("/fast", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/events", func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		assert.False(t, hasDeadline)
+		c.Status(http.StatusOK)
+	})
+	router.POST("/write", func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		assert.False(t, hasDeadline, "a negative duration leaves the class untimed")
+		c.Status(http.StatusOK)
+	})
+
+	for path, want := range map[string]int{
+		"/slow":   http.StatusGatewayTimeout,
+		"/silent": http.StatusGatewayTimeout,
+		"/fast":   http.StatusOK,
+		"/events": http.StatusOK,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, want, w.Code, path)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/write", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
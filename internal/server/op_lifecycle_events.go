@@ -0,0 +1,84 @@
+// file: internal/server/op_lifecycle_events.go
+// version: 1.0.0
+// guid: 7e63d492-8f95-4c62-a235-d4d82140d909
+// last-edited: 2026-10-16
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+)
+
+// opLifecycleEvents republishes operation lifecycle transitions on the
+// plugin event bus as operation.* events, where the webhook plugin (and
+// any other subscriber) can pick them up.
+type opLifecycleEvents struct {
+	events plugin.EventPublisher
+}
+
+var opLifecycleEventTypes = map[string]plugin.EventType{
+	opsregistry.LifecycleCreated:   plugin.EventOperationCreated,
+	opsregistry.LifecycleStarted:   plugin.EventOperationStarted,
+	opsregistry.LifecycleProgress:  plugin.EventOperationProgress,
+	opsregistry.LifecycleCompleted: plugin.EventOperationCompleted,
+	opsregistry.LifecycleFailed:    plugin.EventOperationFailed,
+	opsregistry.LifecycleCanceled:  plugin.EventOperationCanceled,
+}
+
+// OpLifecycle implements opsregistry.LifecycleObserver. EventBus.Publish
+// dispatches asynchronously, so this never blocks the worker.
+func (o opLifecycleEvents) OpLifecycle(ev opsregistry.LifecycleEvent) {
+	eventType, ok := opLifecycleEventTypes[ev.Type]
+	if !ok {
+		return
+	}
+	data := map[string]any{
+		"op_id":            ev.OpID,
+		"def_id":           ev.DefID,
+		"plugin":           ev.Plugin,
+		"status":           ev.Status,
+		"progress_current": ev.ProgressCurrent,
+		"progress_total":   ev.ProgressTotal,
+	}
+	if len(ev.Params) > 0 {
+		data["params"] = ev.Params
+	}
+	if ev.ProgressMessage != "" {
+		data["progress_message"] = ev.ProgressMessage
+	}
+	if ev.Percent > 0 {
+		data["percent"] = ev.Percent
+	}
+	if ev.Error != "" {
+		data["error"] = ev.Error
+	}
+	if len(ev.Result) > 0 {
+		data["result"] = ev.Result
+	}
+	if ev.Resumed {
+		data["resumed"] = true
+	}
+	if ev.DurationMs > 0 {
+		data["duration_ms"] = ev.DurationMs
+	}
+
+	event := plugin.NewEvent(eventType, opParamsBookID(ev.Params), data)
+	event.Timestamp = ev.Timestamp
+	o.events.Publish(context.Background(), event)
+}
+
+// opParamsBookID returns params.book_id for single-book operations.
+func opParamsBookID(params json.RawMessage) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var p struct {
+		BookID string `json:"book_id"`
+	}
+	_ = json.Unmarshal(params, &p)
+	return p.BookID
+}
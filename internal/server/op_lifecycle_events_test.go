@@ -0,0 +1,43 @@
+// file: internal/server/op_lifecycle_events_test.go
+// version: 1.0.0
+// guid: 79a06878-39a6-442d-8445-1bbcc39f7a16
+// last-edited: 2026-10-16
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturePublisher struct{ events []plugin.Event }
+
+func (p *capturePublisher) Publish(_ context.Context, e plugin.Event) { p.events = append(p.events, e) }
+
+func TestOpLifecycleEvents_Publishes(t *testing.T) {
+	pub := &capturePublisher{}
+	obs := opLifecycleEvents{events: pub}
+
+	obs.OpLifecycle(opsregistry.LifecycleEvent{
+		Type: opsregistry.LifecycleFailed, OpID: "op-1", DefID: "library.organize", Status: "failed",
+		Params: json.RawMessage(`{"book_id":"b-1"}`), Error: "boom", DurationMs: 42,
+	})
+	obs.OpLifecycle(opsregistry.LifecycleEvent{Type: "unknown"})
+
+	require.Len(t, pub.events, 1)
+	e := pub.events[0]
+	assert.Equal(t, plugin.EventOperationFailed, e.Type)
+	assert.Equal(t, "b-1", e.BookID)
+	assert.Equal(t, "library.organize", e.Data["def_id"])
+	assert.Equal(t, "boom", e.Data["error"])
+
+	body, err := json.Marshal(e)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"params":{"book_id":"b-1"}`)
+}
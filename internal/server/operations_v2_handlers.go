@@ -1,7 +1,7 @@
 // file: internal/server/operations_v2_handlers.go
-// version: 1.2.1
+// version: 1.3.0
 // guid: e5f6a7b8-c9d0-1e2f-3a4b-5c6d7e8f9a0b
-// last-edited: 2026-05-08
+// last-edited: 2026-10-16
 
 // UOS-06: SSE event hub, /operations/timeline, single-op introspection,
 // cancel, trigger-op, and /op-defs endpoints.
@@ -66,6 +66,7 @@ type opDefResponse struct {
 	ResumePolicy string   `json:"resume_policy"`
 	Triggers     []string `json:"triggers"`
 	DependsOn    []string `json:"depends_on"`
+	Destructive  bool     `json:"destructive"`
 }
 
 // handleGetOperationTimeline implements GET /api/v1/operations/timeline?since=15m.
@@ -386,6 +387,7 @@ func defToResponse(d opsregistry.OperationDef) opDefResponse {
 		ResumePolicy: rp,
 		Triggers:     triggers,
 		DependsOn:    depends,
+		Destructive:  d.Destructive,
 	}
 }
 
@@ -0,0 +1,65 @@
+// file: internal/server/organize_pattern_preview_test.go
+// version: 1.0.0
+// guid: 7c3e91a4-2b5d-4f08-a6e1-d94b0c27f3a6
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewNamingPattern(t *testing.T) {
+	srv := setupMaintenanceTestServer(t)
+	book, err := srv.Store().CreateBook(&database.Book{
+		Title:    "The Fifth Season",
+		FilePath: "/library/in/fifth-season.m4b",
+		Author:   &database.Author{Name: "N. K. Jemisin"},
+	})
+	require.NoError(t, err)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/organize/preview-pattern", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		return w
+	}
+	type previews struct {
+		Data struct {
+			Previews []struct {
+				BookID string `json:"book_id"`
+				Path   string `json:"path"`
+				Error  string `json:"error"`
+			} `json:"previews"`
+		} `json:"data"`
+	}
+
+	w := post(`{"folder_pattern":"{author}/<{series}/>","file_pattern":"<{series_num:02d} - >{title}"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got previews
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.NotEmpty(t, got.Data.Previews)
+	assert.Equal(t, "Brandon Sanderson/The Stormlight Archive/01 - The Way of Kings.m4b", got.Data.Previews[0].Path)
+
+	w = post(`{"folder_pattern":"{author}","file_pattern":"{title}","book_ids":["` + book.ID + `"]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	got = previews{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Len(t, got.Data.Previews, 1)
+	assert.Equal(t, book.ID, got.Data.Previews[0].BookID)
+	assert.Equal(t, "N. K. Jemisin/The Fifth Season.m4b", got.Data.Previews[0].Path)
+
+	w = post(`{"folder_pattern":"{author}/<{series}","file_pattern":"{title}"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = post(`{"book_ids":["missing"]}`)
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+}
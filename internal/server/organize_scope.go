@@ -0,0 +1,58 @@
+// file: internal/server/organize_scope.go
+// version: 1.0.0
+// guid: e4769e01-6245-4d0c-be3f-3b2136c98913
+// last-edited: 2026-10-16
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/playlist"
+)
+
+// playlistBookIDs resolves a saved playlist to the book IDs an organize
+// run should be scoped to. Smart playlists are evaluated against the
+// search index as the user who created them, so per-user filters match
+// what that user sees.
+func (s *Server) playlistBookIDs(playlistID string) ([]string, error) {
+	store := s.Store()
+	if store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	pl, err := store.GetUserPlaylist(playlistID)
+	if err != nil {
+		return nil, fmt.Errorf("load playlist %s: %w", playlistID, err)
+	}
+	if pl == nil {
+		return nil, fmt.Errorf("playlist %s not found", playlistID)
+	}
+	if pl.Type != database.UserPlaylistTypeSmart {
+		return pl.BookIDs, nil
+	}
+	ids, err := playlist.EvaluateSmartPlaylist(store, s.SearchIndex(), pl.Query, pl.SortJSON, pl.Limit, pl.CreatedByUserID)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate playlist %s: %w", playlistID, err)
+	}
+	return ids, nil
+}
+
+// intersectBookIDs returns the IDs in scope that are also in explicit,
+// preserving scope order. An empty explicit list means no restriction.
+func intersectBookIDs(explicit, scope []string) []string {
+	if len(explicit) == 0 {
+		return scope
+	}
+	want := make(map[string]bool, len(explicit))
+	for _, id := range explicit {
+		want[id] = true
+	}
+	out := make([]string, 0, len(scope))
+	for _, id := range scope {
+		if want[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+// file: internal/server/organize_scope_test.go
+// version: 1.0.0
+// guid: 72af7e9d-e082-40f0-9cf9-9a50e3be1411
+// last-edited: 2026-10-16
+
+package server
+
+import (
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistBookIDs(t *testing.T) {
+	store := &database.MockStore{
+		GetUserPlaylistFunc: func(id string) (*database.UserPlaylist, error) {
+			switch id {
+			case "static":
+				return &database.UserPlaylist{ID: id, Type: database.UserPlaylistTypeStatic, BookIDs: []string{"a", "b", "c"}}, nil
+			case "smart":
+				return &database.UserPlaylist{ID: id, Type: database.UserPlaylistTypeSmart, Query: "author:x"}, nil
+			}
+			return nil, nil
+		},
+	}
+	srv := &Server{store: store}
+
+	ids, err := srv.playlistBookIDs("static")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+
+	_, err = srv.playlistBookIDs("missing")
+	assert.ErrorContains(t, err, "not found")
+
+	// No search index wired: smart playlists can't be evaluated.
+	_, err = srv.playlistBookIDs("smart")
+	assert.Error(t, err)
+}
+
+func TestIntersectBookIDs(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, intersectBookIDs(nil, []string{"a", "b"}))
+	assert.Equal(t, []string{"c", "a"}, intersectBookIDs([]string{"a", "c", "z"}, []string{"c", "b", "a"}))
+}
@@ -0,0 +1,74 @@
+// file: internal/server/path_leases.go
+// version: 1.0.0
+// guid: 9c1f4e73-2b8a-4d05-b6e9-7a3d0c58f1b2
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+// Path leases keep scans and organizes of overlapping folders from
+// running at once: a scan that walks a folder while organize moves its
+// files records the books at their old paths and re-imports them. Scans
+// take read leases, so scans of the same folder still run side by side;
+// anything that moves files takes write leases. The registry queues a
+// conflicting run until the lease holder finishes.
+
+// libraryScanLeases covers the folders a library.scan run visits: the
+// requested folder, or else the library root and every import path.
+func (s *Server) libraryScanLeases(raw json.RawMessage) []opsregistry.PathLease {
+	var p libraryScanParams
+	_ = json.Unmarshal(raw, &p)
+	if p.FolderPath != nil && *p.FolderPath != "" {
+		return scanLeases(*p.FolderPath)
+	}
+	return scanLeases(s.libraryLeasePaths()...)
+}
+
+// libraryOrganizeLeases covers the folders a library.organize run moves
+// files out of and into. A run scoped to a folder moves from that folder
+// into the library root; any other scope can touch every import path.
+func (s *Server) libraryOrganizeLeases(raw json.RawMessage) []opsregistry.PathLease {
+	var p libraryOrganizeParams
+	_ = json.Unmarshal(raw, &p)
+	if p.FolderPath != nil && *p.FolderPath != "" {
+		return opsregistry.WriteLeases(*p.FolderPath, config.AppConfig.RootDir)
+	}
+	return opsregistry.WriteLeases(s.libraryLeasePaths()...)
+}
+
+// folderAutoScanLeases covers the new import path.
+func (s *Server) folderAutoScanLeases(raw json.RawMessage) []opsregistry.PathLease {
+	var p folderAutoScanOpParams
+	_ = json.Unmarshal(raw, &p)
+	return scanLeases(p.FolderPath)
+}
+
+// scanLeases leases the folders a scan visits. Scans only read them
+// unless auto-organize is on, in which case the scan also moves what it
+// finds into the library root.
+func scanLeases(folders ...string) []opsregistry.PathLease {
+	if config.AppConfig.AutoOrganize && config.AppConfig.RootDir != "" {
+		return opsregistry.WriteLeases(append(folders, config.AppConfig.RootDir)...)
+	}
+	return opsregistry.ReadLeases(folders...)
+}
+
+// libraryLeasePaths is the library root plus every import path, enabled
+// or not (a disabled path can still hold books organize moves).
+func (s *Server) libraryLeasePaths() []string {
+	paths := []string{config.AppConfig.RootDir}
+	if store := s.Store(); store != nil {
+		if importPaths, err := store.GetAllImportPaths(); err == nil {
+			for _, ip := range importPaths {
+				paths = append(paths, ip.Path)
+			}
+		}
+	}
+	return paths
+}
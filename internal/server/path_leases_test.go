@@ -0,0 +1,42 @@
+// file: internal/server/path_leases_test.go
+// version: 1.0.0
+// guid: 6f0a3d82-c9b7-4e15-9a46-d21e8b7c5f03
+
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathLeases(t *testing.T) {
+	oldRoot, oldAuto := config.AppConfig.RootDir, config.AppConfig.AutoOrganize
+	defer func() { config.AppConfig.RootDir, config.AppConfig.AutoOrganize = oldRoot, oldAuto }()
+	config.AppConfig.RootDir = "/library"
+	config.AppConfig.AutoOrganize = false
+
+	s := &Server{}
+	raw := func(v any) json.RawMessage {
+		b, _ := json.Marshal(v)
+		return b
+	}
+	folder := "/imports/new"
+
+	assert.Equal(t, opsregistry.ReadLeases(folder),
+		s.libraryScanLeases(raw(libraryScanParams{FolderPath: &folder})))
+	assert.Equal(t, opsregistry.ReadLeases("/library"),
+		s.libraryScanLeases(raw(libraryScanParams{})), "a full scan without a store covers the library root")
+	assert.Equal(t, opsregistry.WriteLeases(folder, "/library"),
+		s.libraryOrganizeLeases(raw(libraryOrganizeParams{FolderPath: &folder})))
+	assert.Equal(t, opsregistry.WriteLeases("/library"),
+		s.libraryOrganizeLeases(raw(libraryOrganizeParams{BookIDs: []string{"b1"}})))
+
+	// With auto-organize on, a scan moves what it finds into the root.
+	config.AppConfig.AutoOrganize = true
+	assert.Equal(t, opsregistry.WriteLeases(folder, "/library"),
+		s.folderAutoScanLeases(raw(folderAutoScanOpParams{FolderPath: folder})))
+}
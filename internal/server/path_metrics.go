@@ -0,0 +1,137 @@
+// file: internal/server/path_metrics.go
+// version: 1.0.0
+// guid: 4c9e2a71-b5d8-4f36-8e1a-d7f3b06c2e94
+// last-edited: 2026-10-17
+
+// Per import path and per library root Prometheus gauges, refreshed on a
+// slow ticker so dashboards can alert when one source stops being scanned
+// or grows abnormally.
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/metrics"
+)
+
+// defaultLibraryLabel is the {library} label for the main library root
+// when it isn't part of a configured library.
+const defaultLibraryLabel = "default"
+
+// libraryRoot is one directory reported under a library's label.
+type libraryRoot struct {
+	library string
+	root    string
+}
+
+// libraryRoots lists the roots of cfg's libraries: the main root dir,
+// then each configured library's root dir and import paths.
+func libraryRoots(cfg *config.Config) []libraryRoot {
+	var roots []libraryRoot
+	seen := map[string]bool{}
+	add := func(library, root string) {
+		if root = strings.TrimSpace(root); root == "" {
+			return
+		}
+		root = filepath.Clean(root)
+		if seen[root] {
+			return
+		}
+		seen[root] = true
+		roots = append(roots, libraryRoot{library: library, root: root})
+	}
+	for _, lib := range cfg.Libraries {
+		add(lib.ID, lib.RootDir)
+		for _, p := range lib.ImportPaths {
+			add(lib.ID, p)
+		}
+	}
+	add(defaultLibraryLabel, cfg.RootDir)
+	return roots
+}
+
+// collectPathMetrics totals books per import path and per library root.
+// A book counts toward every root that contains it.
+func collectPathMetrics(books []database.Book, paths []database.ImportPath, cfg *config.Config) ([]metrics.ImportPathStat, []metrics.LibraryStat) {
+	pathStats := make([]metrics.ImportPathStat, len(paths))
+	for i, ip := range paths {
+		pathStats[i] = metrics.ImportPathStat{Path: ip.Path, Healthy: ip.Health != database.ImportPathUnhealthy}
+		if ip.LastScan != nil {
+			pathStats[i].LastScan = *ip.LastScan
+		}
+	}
+	roots := libraryRoots(cfg)
+	libStats := make([]metrics.LibraryStat, len(roots))
+	for i, r := range roots {
+		libStats[i] = metrics.LibraryStat{Library: r.library, Root: r.root}
+	}
+	for _, b := range books {
+		for i := range pathStats {
+			if _, ok := pathWithin(pathStats[i].Path, b.FilePath); ok {
+				pathStats[i].Books++
+			}
+		}
+		for i := range libStats {
+			if _, ok := pathWithin(libStats[i].Root, b.FilePath); ok {
+				libStats[i].Books++
+				if b.FileSize != nil {
+					libStats[i].Bytes += *b.FileSize
+				}
+			}
+		}
+	}
+	return pathStats, libStats
+}
+
+// refreshPathMetrics recomputes the per-path and per-library gauges from
+// the store.
+func (s *Server) refreshPathMetrics(ctx context.Context, now time.Time) error {
+	store := s.Store()
+	if store == nil {
+		return nil
+	}
+	paths, err := store.GetAllImportPaths()
+	if err != nil {
+		return err
+	}
+	books, err := database.GetAllBooksContext(ctx, store, 0, 0)
+	if err != nil {
+		return err
+	}
+	pathStats, libStats := collectPathMetrics(books, paths, &config.AppConfig)
+	metrics.SetImportPathStats(pathStats, now)
+	metrics.SetLibraryStats(libStats)
+	return nil
+}
+
+// runPathMetricsUpdater refreshes the per-path and per-library gauges
+// until shutdown. It lists every book, so it runs far less often than the
+// system status ticker.
+func (s *Server) runPathMetricsUpdater(shutdown <-chan struct{}) {
+	const interval = 5 * time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-shutdown
+		cancel()
+	}()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.refreshPathMetrics(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			slog.Warn("path metrics refresh failed", "err", err)
+		}
+		select {
+		case <-shutdown:
+			return
+		case <-ticker.C:
+		}
+	}
+}
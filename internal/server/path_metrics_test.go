@@ -0,0 +1,46 @@
+// file: internal/server/path_metrics_test.go
+// version: 1.0.0
+// guid: a1e7c3f9-2d64-4b85-9c0e-6f8b3d2a5e17
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectPathMetrics(t *testing.T) {
+	size := func(n int64) *int64 { return &n }
+	scanned := time.Now().Add(-time.Hour)
+	books := []database.Book{
+		{FilePath: "/library/A/a.m4b", FileSize: size(100)},
+		{FilePath: "/library/B/b.m4b", FileSize: size(50)},
+		{FilePath: "/imports/new/c.mp3", FileSize: size(10)},
+		{FilePath: "/kids/d.m4b"},
+		{FilePath: "/imports/newer/e.mp3"},
+	}
+	paths := []database.ImportPath{
+		{Path: "/imports/new", LastScan: &scanned, Health: database.ImportPathHealthy},
+		{Path: "/imports/down", Health: database.ImportPathUnhealthy},
+	}
+	cfg := &config.Config{
+		RootDir:   "/library",
+		Libraries: []config.LibraryDefinition{{ID: "kids", RootDir: "/kids/", ImportPaths: []string{"/imports/kids"}}},
+	}
+
+	pathStats, libStats := collectPathMetrics(books, paths, cfg)
+	assert.Equal(t, []metrics.ImportPathStat{
+		{Path: "/imports/new", Books: 1, Healthy: true, LastScan: scanned},
+		{Path: "/imports/down"},
+	}, pathStats, "matching is on whole path components")
+	assert.Equal(t, []metrics.LibraryStat{
+		{Library: "kids", Root: "/kids", Books: 1},
+		{Library: "kids", Root: "/imports/kids"},
+		{Library: "default", Root: "/library", Books: 2, Bytes: 150},
+	}, libStats)
+}
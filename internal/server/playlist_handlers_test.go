@@ -1,5 +1,5 @@
 // file: internal/server/playlist_handlers_test.go
-// version: 1.0.1
+// version: 1.1.0
 // guid: 8b4d6f3e-9c4a-4a70-b8c5-3d7e0f1b9a89
 
 package server
@@ -14,6 +14,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
 	"github.com/falkcorp/audiobook-organizer/internal/search"
 )
 
@@ -23,17 +24,7 @@ import (
 func setupPlaylistTestServer(t *testing.T) *Server {
 	t.Helper()
 
-	pebblePath := filepath.Join(t.TempDir(), "pebble")
-	store, err := database.NewPebbleStore(pebblePath)
-	if err != nil {
-		t.Fatalf("open pebble: %v", err)
-	}
-	origStore := database.GetGlobalStore()
-	database.SetGlobalStore(store)
-	t.Cleanup(func() {
-		database.SetGlobalStore(origStore)
-		store.Close()
-	})
+	store := storetest.NewGlobal(t)
 
 	idx, err := search.Open(filepath.Join(t.TempDir(), "bleve"))
 	if err != nil {
@@ -1,7 +1,7 @@
 // file: internal/server/plugins_init.go
-// version: 1.1.2
+// version: 1.3.0
 // guid: a2b3c4d5-e6f7-8a9b-0c1d-2e3f4a5b6c7d
-// last-edited: 2026-05-19
+// last-edited: 2026-10-17
 
 package server
 
@@ -35,9 +35,10 @@ func (s *Server) initPlugins(ctx context.Context) {
 	}
 
 	baseDeps := plugin.Deps{
-		Store:  s.Store(),
-		Events: s.eventBus,
-		Logger: logger.New("plugin"),
+		Store:   s.Store(),
+		Events:  s.eventBus,
+		Logger:  logger.New("plugin"),
+		BaseURL: config.AppConfig.BaseURL,
 	}
 
 	pluginGroup := s.router.Group("/api/v1/plugins")
@@ -1,13 +1,16 @@
 // file: internal/server/quarantine_handlers.go
-// version: 2.2.0
+// version: 2.3.0
 // guid: c3d4e5f6-a7b8-9c0d-1e2f-3a4b5c6d7e8f
 
 package server
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/quarantine"
 )
 
 // quarantineBook handles POST /api/v1/audiobooks/:id/quarantine
@@ -57,6 +60,34 @@ func (s *Server) unquarantineBook(c *gin.Context) {
 	}{Status: "unquarantined", BookID: id})
 }
 
+// overrideSizeAnomaly handles POST /api/v1/audiobooks/:id/quarantine/override.
+// It accepts a book flagged by the size anomaly safeguard: the book is
+// restored and later scans no longer flag it.
+func (s *Server) overrideSizeAnomaly(c *gin.Context) {
+	id := c.Param("id")
+	if s.Store() == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	book, err := s.Store().GetBookByID(id)
+	if err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "book", id)
+		return
+	}
+	if err := s.quarantineSvc.OverrideSizeAnomaly(id); err != nil {
+		if errors.Is(err, quarantine.ErrNotSizeAnomaly) {
+			httputil.RespondWithConflict(c, err.Error())
+			return
+		}
+		httputil.InternalError(c, "override failed", err)
+		return
+	}
+	httputil.RespondWithOK(c, struct {
+		Status string `json:"status"`
+		BookID string `json:"book_id"`
+	}{Status: "overridden", BookID: id})
+}
+
 // listQuarantinedBooks handles GET /api/v1/audiobooks/quarantined
 func (s *Server) listQuarantinedBooks(c *gin.Context) {
 	if s.Store() == nil {
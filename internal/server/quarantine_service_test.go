@@ -1,16 +1,21 @@
 // file: internal/server/quarantine_service_test.go
-// version: 1.1.0
+// version: 1.2.0
 
 package server
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/quarantine"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -118,3 +123,83 @@ func TestUnquarantineBook_MovesFileBack(t *testing.T) {
 	require.Nil(t, updated.QuarantineReason)
 	require.Nil(t, updated.QuarantinedAt)
 }
+
+func TestSizeAnomaly(t *testing.T) {
+	size := func(n int64) *int64 { return &n }
+	dur := func(n int) *int { return &n }
+	estimated := true
+
+	for name, tt := range map[string]struct {
+		book    database.Book
+		anomaly bool
+	}{
+		"2 KB novel":         {database.Book{FileSize: size(2048), Duration: dur(10 * 3600)}, true},
+		"5 GB short story":   {database.Book{FileSize: size(5 << 30), Duration: dur(20 * 60)}, true},
+		"64 kbps mp3":        {database.Book{FileSize: size(64 * 1000 / 8 * 3600), Duration: dur(3600)}, false},
+		"unknown duration":   {database.Book{FileSize: size(2048)}, false},
+		"estimated duration": {database.Book{FileSize: size(2048), Duration: dur(36000), DurationEstimated: &estimated}, false},
+		"unknown size":       {database.Book{Duration: dur(36000)}, false},
+		"lossless 1411 kbps": {database.Book{FileSize: size(1411 * 1000 / 8 * 600), Duration: dur(600)}, false},
+	} {
+		reason := quarantine.SizeAnomaly(&tt.book, 8, 2500)
+		assert.Equal(t, tt.anomaly, reason != "", "%s: %q", name, reason)
+	}
+	assert.Empty(t, quarantine.SizeAnomaly(&database.Book{FileSize: size(2048), Duration: dur(36000)}, -1, 2500),
+		"a disabled bound isn't checked")
+}
+
+func TestAutoQuarantineSizeAnomalies_Override(t *testing.T) {
+	srv, cleanup := setupTestServer(t)
+	defer cleanup()
+	prevMin, prevMax := config.AppConfig.SizeAnomalyMinKbps, config.AppConfig.SizeAnomalyMaxKbps
+	config.AppConfig.SizeAnomalyMinKbps, config.AppConfig.SizeAnomalyMaxKbps = 8, 2500
+	t.Cleanup(func() { config.AppConfig.SizeAnomalyMinKbps, config.AppConfig.SizeAnomalyMaxKbps = prevMin, prevMax })
+
+	store := srv.Store()
+	root := config.AppConfig.RootDir
+	create := func(name, state string) *database.Book {
+		path := filepath.Join(root, "Import", name+".mp3")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("tiny"), 0644))
+		size, duration := int64(2048), 10*3600
+		book, err := store.CreateBook(&database.Book{
+			Title: name, FilePath: path, Format: "mp3",
+			FileSize: &size, Duration: &duration, LibraryState: &state,
+		})
+		require.NoError(t, err)
+		return book
+	}
+	suspect := create("Suspect", "imported")
+	organized := create("Organized", "organized")
+
+	srv.quarantineSvc.AutoQuarantineSizeAnomalies()
+
+	got, err := store.GetBookByID(suspect.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.QuarantinedAt)
+	assert.True(t, strings.HasPrefix(*got.QuarantineReason, quarantine.SizeAnomalyReasonPrefix), *got.QuarantineReason)
+	got, err = store.GetBookByID(organized.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.QuarantinedAt, "organized books are not rechecked")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/audiobooks/"+suspect.ID+"/quarantine/override", nil)
+	srv.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	got, err = store.GetBookByID(suspect.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.QuarantinedAt)
+	assert.Equal(t, suspect.FilePath, got.FilePath)
+
+	srv.quarantineSvc.AutoQuarantineSizeAnomalies()
+	got, err = store.GetBookByID(suspect.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.QuarantinedAt, "an overridden book stays in the library")
+
+	require.NoError(t, srv.quarantineSvc.QuarantineBook(organized.ID, "taglib failed"))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/audiobooks/"+organized.ID+"/quarantine/override", nil)
+	srv.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code, "other quarantines are not overridden")
+}
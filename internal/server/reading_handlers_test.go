@@ -1,5 +1,5 @@
 // file: internal/server/reading_handlers_test.go
-// version: 1.1.1
+// version: 1.2.0
 // guid: 4f9a2c1d-5b8e-4f70-a7d6-2e8c0f1b9a57
 
 package server
@@ -13,35 +13,23 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
 )
 
 func setupReadingTestServer(t *testing.T) *Server {
 	t.Helper()
 	// Spec 3.6 features live on PebbleDB (SQLite has no-op stubs),
 	// so override the default SQLite test setup with a PebbleStore.
-	pebblePath := t.TempDir() + "/pebble"
-	store, err := database.NewPebbleStore(pebblePath)
-	if err != nil {
-		t.Fatalf("open pebble: %v", err)
-	}
-	origStore := database.GetGlobalStore()
-	database.SetGlobalStore(store)
-	t.Cleanup(func() {
-		database.SetGlobalStore(origStore)
-		store.Close()
-	})
+	store := storetest.NewGlobal(t)
 
 	srv := NewServer(store)
 
-	_, err = store.CreateBook(&database.Book{
+	book := storetest.Book(t, store, database.Book{
 		ID: "b1", Title: "Test Book", FilePath: "/tmp/b1", Format: "m4b",
 	})
-	if err != nil {
-		t.Fatalf("create book: %v", err)
-	}
 	for i, segID := range []string{"s1", "s2", "s3"} {
-		_ = store.CreateBookFile(&database.BookFile{
-			ID: segID, BookID: "b1", FilePath: "/tmp/" + segID,
+		storetest.BookFile(t, store, book, database.BookFile{
+			ID: segID, FilePath: "/tmp/" + segID,
 			TrackNumber: i + 1, Duration: 600,
 		})
 	}
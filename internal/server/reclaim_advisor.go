@@ -0,0 +1,329 @@
+// file: internal/server/reclaim_advisor.go
+// version: 1.1.0
+// guid: 3e8a5c21-7d94-4b06-a1f3-c9b2e4d70a58
+// last-edited: 2026-10-17
+
+// Disk-space reclamation advisor: GET /stats/reclaim ranks the space that
+// could be freed by deleting copies the library doesn't need, measured
+// from the files on disk, and pairs every suggestion with the
+// library.reclaim-space operation that frees it.
+
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/merge"
+	"github.com/gin-gonic/gin"
+)
+
+// Reclaim kinds. A book that qualifies for several is listed under the
+// first one here.
+const (
+	reclaimSoftDeleted = "soft-deleted"
+	reclaimLowQuality  = "low-quality"
+	reclaimNonPrimary  = "non-primary-version"
+	reclaimDuplicate   = "duplicate"
+)
+
+// reclaimTarget is one book to delete and the copy that makes it
+// redundant (empty for soft-deleted books).
+type reclaimTarget struct {
+	BookID string `json:"book_id"`
+	KeepID string `json:"keep_id,omitempty"`
+}
+
+// reclaimParams are the library.reclaim-space parameters.
+type reclaimParams struct {
+	Kind  string          `json:"kind"`
+	Books []reclaimTarget `json:"books"`
+}
+
+// reclaimAction is a ready-to-post POST /operations/v2 body.
+type reclaimAction struct {
+	DefID  string        `json:"def_id"`
+	Params reclaimParams `json:"params"`
+}
+
+// reclaimItem is one book whose files could be deleted.
+type reclaimItem struct {
+	BookID   string        `json:"book_id"`
+	Title    string        `json:"title"`
+	FilePath string        `json:"file_path"`
+	Bytes    int64         `json:"bytes"`
+	KeepID   string        `json:"keep_id,omitempty"`
+	Reason   string        `json:"reason"`
+	Action   reclaimAction `json:"action"`
+}
+
+// reclaimCategory totals one kind of reclaimable space. Action covers
+// every item, including those past the listing limit.
+type reclaimCategory struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Books       int    `json:"books"`
+	Bytes       int64  `json:"bytes"`
+	// Skipped counts qualifying books with nothing to reclaim: files
+	// missing, or only under import or iTunes paths, which are never
+	// deleted.
+	Skipped int           `json:"skipped"`
+	Items   []reclaimItem `json:"items"`
+	Action  reclaimAction `json:"action"`
+}
+
+// reclaimCandidate is a book the advisor would delete, before sizing.
+type reclaimCandidate struct {
+	book   database.Book
+	keepID string
+	reason string
+}
+
+// importPathCache answers GetAllImportPaths from one read, so protected
+// path checks over many books don't each hit the store.
+type importPathCache []database.ImportPath
+
+func (c importPathCache) GetAllImportPaths() ([]database.ImportPath, error) { return c, nil }
+
+// softDeletedReclaimCandidates lists soft-deleted books past the purge
+// retention window (all of them when no retention is configured).
+func softDeletedReclaimCandidates(store database.Store, retentionDays int, now time.Time) ([]reclaimCandidate, error) {
+	var cutoff *time.Time
+	if retentionDays > 0 {
+		ts := now.AddDate(0, 0, -retentionDays)
+		cutoff = &ts
+	}
+	books, err := store.ListSoftDeletedBooks(1_000_000, 0, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]reclaimCandidate, 0, len(books))
+	for _, b := range books {
+		reason := "soft-deleted"
+		if b.MarkedForDeletionAt != nil {
+			reason = "soft-deleted " + b.MarkedForDeletionAt.UTC().Format("2006-01-02")
+		}
+		out = append(out, reclaimCandidate{book: b, reason: reason})
+	}
+	return out, nil
+}
+
+// classifyReclaimCandidates sorts live books into the low-quality,
+// non-primary-version and duplicate kinds:
+//   - low-quality: primaries flagged with a better-scoring version
+//     (deleted after the upgrade is promoted);
+//   - non-primary-version: other versions whose group has a primary,
+//     except pending upgrade candidates;
+//   - duplicate: books sharing a file hash with a better copy.
+func classifyReclaimCandidates(books []database.Book) map[string][]reclaimCandidate {
+	byID := make(map[string]*database.Book, len(books))
+	primaries := map[string]*database.Book{}
+	for i := range books {
+		b := &books[i]
+		byID[b.ID] = b
+		if isVersionGrouped(b) && b.IsPrimaryVersion != nil && *b.IsPrimaryVersion {
+			primaries[*b.VersionGroupID] = b
+		}
+	}
+
+	out := map[string][]reclaimCandidate{}
+	claimed := map[string]bool{}
+	upgrades := map[string]bool{}
+	for i := range books {
+		b := &books[i]
+		if b.UpgradeAvailable == nil || !*b.UpgradeAvailable || b.UpgradeCandidateID == nil {
+			continue
+		}
+		cand, ok := byID[*b.UpgradeCandidateID]
+		if !ok {
+			continue
+		}
+		upgrades[cand.ID] = true
+		claimed[b.ID] = true
+		out[reclaimLowQuality] = append(out[reclaimLowQuality], reclaimCandidate{
+			book: *b, keepID: cand.ID,
+			reason: fmt.Sprintf("%s is a higher-quality version", cand.Title),
+		})
+	}
+
+	for i := range books {
+		b := &books[i]
+		if !isVersionGrouped(b) || claimed[b.ID] || upgrades[b.ID] {
+			continue
+		}
+		if b.IsPrimaryVersion != nil && *b.IsPrimaryVersion {
+			continue
+		}
+		primary, ok := primaries[*b.VersionGroupID]
+		if !ok || claimed[primary.ID] {
+			continue
+		}
+		claimed[b.ID] = true
+		out[reclaimNonPrimary] = append(out[reclaimNonPrimary], reclaimCandidate{
+			book: *b, keepID: primary.ID, reason: "not the primary version",
+		})
+	}
+
+	byHash := map[string][]*database.Book{}
+	for i := range books {
+		b := &books[i]
+		if claimed[b.ID] || upgrades[b.ID] || b.FileHash == nil || *b.FileHash == "" {
+			continue
+		}
+		byHash[*b.FileHash] = append(byHash[*b.FileHash], b)
+	}
+	for _, group := range byHash {
+		if len(group) < 2 {
+			continue
+		}
+		keep := group[0]
+		for _, b := range group[1:] {
+			if merge.BookIsBetter(b, keep) {
+				keep = b
+			}
+		}
+		for _, b := range group {
+			if b == keep {
+				continue
+			}
+			out[reclaimDuplicate] = append(out[reclaimDuplicate], reclaimCandidate{
+				book: *b, keepID: keep.ID, reason: "identical file kept at " + keep.FilePath,
+			})
+		}
+	}
+	return out
+}
+
+func isVersionGrouped(b *database.Book) bool {
+	return b.VersionGroupID != nil && *b.VersionGroupID != ""
+}
+
+// reclaimableBytes measures what purging book with file deletion would
+// free: its file, or for a directory its book files, skipping anything
+// under protected paths exactly as the purge does.
+func reclaimableBytes(store database.Store, paths importPathCache, book database.Book) int64 {
+	if book.FilePath == "" || audiobooks.IsProtectedPath(paths, book.FilePath) {
+		return 0
+	}
+	info, err := os.Stat(book.FilePath)
+	if err != nil {
+		return 0
+	}
+	if !info.IsDir() {
+		return info.Size()
+	}
+	files, err := store.GetBookFiles(book.ID)
+	if err != nil {
+		return 0
+	}
+	var total int64
+	for _, f := range files {
+		if f.FilePath == "" || audiobooks.IsProtectedPath(paths, f.FilePath) {
+			continue
+		}
+		if fi, err := os.Stat(f.FilePath); err == nil && !fi.IsDir() {
+			total += fi.Size()
+		}
+	}
+	return total
+}
+
+// buildReclaimCategory sizes candidates and lists the largest limit of
+// them.
+func buildReclaimCategory(kind, description string, candidates []reclaimCandidate, size func(database.Book) int64, limit int) reclaimCategory {
+	cat := reclaimCategory{
+		Kind:        kind,
+		Description: description,
+		Items:       []reclaimItem{},
+		Action:      reclaimAction{DefID: reclaimOpID, Params: reclaimParams{Kind: kind, Books: []reclaimTarget{}}},
+	}
+	for _, cand := range candidates {
+		n := size(cand.book)
+		if n <= 0 {
+			cat.Skipped++
+			continue
+		}
+		target := reclaimTarget{BookID: cand.book.ID, KeepID: cand.keepID}
+		cat.Books++
+		cat.Bytes += n
+		cat.Action.Params.Books = append(cat.Action.Params.Books, target)
+		cat.Items = append(cat.Items, reclaimItem{
+			BookID:   cand.book.ID,
+			Title:    cand.book.Title,
+			FilePath: cand.book.FilePath,
+			Bytes:    n,
+			KeepID:   cand.keepID,
+			Reason:   cand.reason,
+			Action:   reclaimAction{DefID: reclaimOpID, Params: reclaimParams{Kind: kind, Books: []reclaimTarget{target}}},
+		})
+	}
+	slices.SortStableFunc(cat.Items, func(a, b reclaimItem) int { return cmp.Compare(b.Bytes, a.Bytes) })
+	if len(cat.Items) > limit {
+		cat.Items = cat.Items[:limit]
+	}
+	return cat
+}
+
+// handleReclaimAdvisor implements GET /stats/reclaim.
+//
+// Query params:
+//   - limit: items listed per category (default 50, max 1000).
+//
+// Categories come back largest first. Each item's action, and each
+// category's action for all of its items, is a POST /operations/v2 body.
+// Nothing is modified.
+func (s *Server) handleReclaimAdvisor(c *gin.Context) {
+	limit := 50
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 1000 {
+			httputil.RespondWithValidationError(c, "limit", "must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+	store := s.Store()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	books, err := database.GetAllBooksContext(c.Request.Context(), store, 0, 0)
+	if err != nil {
+		httputil.InternalError(c, "failed to list audiobooks", err)
+		return
+	}
+	deleted, err := softDeletedReclaimCandidates(store, config.AppConfig.PurgeSoftDeletedAfterDays, time.Now())
+	if err != nil {
+		httputil.InternalError(c, "failed to list soft-deleted audiobooks", err)
+		return
+	}
+	paths, err := store.GetAllImportPaths()
+	if err != nil {
+		httputil.InternalError(c, "failed to list import paths", err)
+		return
+	}
+	size := func(b database.Book) int64 { return reclaimableBytes(store, importPathCache(paths), b) }
+
+	live := classifyReclaimCandidates(books)
+	categories := []reclaimCategory{
+		buildReclaimCategory(reclaimSoftDeleted, "Soft-deleted books past the purge retention period", deleted, size, limit),
+		buildReclaimCategory(reclaimLowQuality, "Primary versions with a higher-quality version available", live[reclaimLowQuality], size, limit),
+		buildReclaimCategory(reclaimNonPrimary, "Non-primary versions", live[reclaimNonPrimary], size, limit),
+		buildReclaimCategory(reclaimDuplicate, "Identical copies of another book's file", live[reclaimDuplicate], size, limit),
+	}
+	slices.SortStableFunc(categories, func(a, b reclaimCategory) int { return cmp.Compare(b.Bytes, a.Bytes) })
+
+	var total int64
+	for _, cat := range categories {
+		total += cat.Bytes
+	}
+	httputil.RespondWithOK(c, gin.H{"categories": categories, "total_bytes": total})
+}
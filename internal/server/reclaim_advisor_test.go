@@ -0,0 +1,112 @@
+// file: internal/server/reclaim_advisor_test.go
+// version: 1.0.0
+// guid: a4d7e0b3-5c19-4f82-9b6e-08f3c1d2a795
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyReclaimCandidates(t *testing.T) {
+	sp := func(v string) *string { return &v }
+	bp := func(v bool) *bool { return &v }
+	ip := func(v int) *int { return &v }
+	books := []database.Book{
+		// g1: flagged primary with a better pending upgrade and one more version.
+		{ID: "g1-primary", VersionGroupID: sp("g1"), IsPrimaryVersion: bp(true),
+			UpgradeAvailable: bp(true), UpgradeCandidateID: sp("g1-upgrade")},
+		{ID: "g1-upgrade", VersionGroupID: sp("g1"), IsPrimaryVersion: bp(false)},
+		{ID: "g1-other", VersionGroupID: sp("g1"), IsPrimaryVersion: bp(false)},
+		// g2: plain non-primary version.
+		{ID: "g2-primary", VersionGroupID: sp("g2"), IsPrimaryVersion: bp(true)},
+		{ID: "g2-old", VersionGroupID: sp("g2"), IsPrimaryVersion: bp(false)},
+		// g3 has no primary: nothing to keep.
+		{ID: "g3-a", VersionGroupID: sp("g3"), IsPrimaryVersion: bp(false)},
+		// Identical files: the higher-bitrate record is kept.
+		{ID: "dup-a", FileHash: sp("h1"), Format: "m4b", Bitrate: ip(64)},
+		{ID: "dup-b", FileHash: sp("h1"), Format: "m4b", Bitrate: ip(128)},
+		{ID: "lonely", FileHash: sp("h2")},
+	}
+
+	got := classifyReclaimCandidates(books)
+	ids := func(kind string) map[string]string {
+		out := map[string]string{}
+		for _, c := range got[kind] {
+			out[c.book.ID] = c.keepID
+		}
+		return out
+	}
+	assert.Equal(t, map[string]string{"g1-primary": "g1-upgrade"}, ids(reclaimLowQuality))
+	assert.Equal(t, map[string]string{"g2-old": "g2-primary"}, ids(reclaimNonPrimary))
+	assert.Equal(t, map[string]string{"dup-a": "dup-b"}, ids(reclaimDuplicate))
+}
+
+func TestReclaimAdvisorAndCheck(t *testing.T) {
+	srv, cleanup := setupTestServer(t)
+	defer cleanup()
+	store := srv.Store()
+	root := config.AppConfig.RootDir
+
+	write := func(name string, size int) string {
+		p := filepath.Join(root, name)
+		require.NoError(t, os.WriteFile(p, make([]byte, size), 0o644))
+		return p
+	}
+	sp := func(v string) *string { return &v }
+	bp := func(v bool) *bool { return &v }
+	for _, b := range []database.Book{
+		{ID: "keep", Title: "Keep", FilePath: write("keep.m4b", 300), VersionGroupID: sp("g"), IsPrimaryVersion: bp(true)},
+		{ID: "old", Title: "Old", FilePath: write("old.mp3", 200), VersionGroupID: sp("g"), IsPrimaryVersion: bp(false)},
+		{ID: "gone", Title: "Gone", FilePath: filepath.Join(root, "missing.mp3"), VersionGroupID: sp("g"), IsPrimaryVersion: bp(false)},
+	} {
+		b := b
+		_, err := store.CreateBook(&b)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/reclaim", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Data struct {
+			TotalBytes int64             `json:"total_bytes"`
+			Categories []reclaimCategory `json:"categories"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int64(200), resp.Data.TotalBytes)
+	require.NotEmpty(t, resp.Data.Categories)
+	top := resp.Data.Categories[0]
+	assert.Equal(t, reclaimNonPrimary, top.Kind)
+	assert.Equal(t, 1, top.Books)
+	assert.Equal(t, 1, top.Skipped)
+	require.Len(t, top.Items, 1)
+	assert.Equal(t, "old", top.Items[0].BookID)
+	assert.Equal(t, reclaimOpID, top.Action.DefID)
+	assert.Equal(t, []reclaimTarget{{BookID: "old", KeepID: "keep"}}, top.Action.Params.Books)
+
+	old, _ := store.GetBookByID("old")
+	require.NoError(t, srv.checkReclaimTarget(store, reclaimNonPrimary, old, "keep"))
+	assert.Error(t, srv.checkReclaimTarget(store, reclaimDuplicate, old, "keep"))
+	assert.Error(t, srv.checkReclaimTarget(store, reclaimNonPrimary, old, "old"))
+
+	result, err := srv.audiobookService.PurgeBooks(context.Background(), []string{"old"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Purged)
+	assert.NoFileExists(t, old.FilePath)
+	assert.FileExists(t, filepath.Join(root, "keep.m4b"))
+}
@@ -0,0 +1,187 @@
+// file: internal/server/reclaim_op.go
+// version: 1.1.0
+// guid: 6b1f9d37-2a58-4c0e-8e74-d5a3c7b9f210
+// last-edited: 2026-10-17
+
+// library.reclaim-space deletes books suggested by the reclamation
+// advisor (GET /stats/reclaim), files included. Each book is checked
+// again before it goes: advice can be stale by the time it is acted on,
+// and the copy that made a book redundant must still be there.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+const reclaimOpID = "library.reclaim-space"
+
+// RegisterReclaimSpaceOp registers the "library.reclaim-space"
+// OperationDef.
+func (s *Server) RegisterReclaimSpaceOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              reclaimOpID,
+		Plugin:          "library",
+		DisplayName:     "Reclaim Disk Space",
+		Description:     "Delete redundant books and their files as suggested by the reclamation advisor.",
+		DefaultPriority: opsregistry.PriorityNormal,
+		Cancellable:     true,
+		Isolate:         false,
+		Destructive:     true,
+		Timeout:         2 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeDrop,
+		ConcurrencyKey:  reclaimOpID,
+		Permissions:     []auth.Permission{auth.PermLibraryDelete},
+		Capabilities: []opsregistry.Capability{
+			opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite, opsregistry.CapFilesWrite,
+		},
+		Run: func(ctx context.Context, raw json.RawMessage, reporter opsregistry.Reporter) error {
+			var p reclaimParams
+			if err := json.Unmarshal(raw, &p); err != nil {
+				return fmt.Errorf("%s: decode params: %w", reclaimOpID, err)
+			}
+			return s.runReclaimSpace(ctx, p, reporter)
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterReclaimSpaceOp(reg) })
+}
+
+func (s *Server) runReclaimSpace(ctx context.Context, p reclaimParams, reporter opsregistry.Reporter) error {
+	switch p.Kind {
+	case reclaimSoftDeleted, reclaimLowQuality, reclaimNonPrimary, reclaimDuplicate:
+	default:
+		return fmt.Errorf("%s: unknown kind %q", reclaimOpID, p.Kind)
+	}
+	store := s.Store()
+	if store == nil || s.audiobookService == nil {
+		return fmt.Errorf("%s: database not initialized", reclaimOpID)
+	}
+	logf := func(level slog.Level, format string, args ...any) {
+		_ = reporter.Log(level, fmt.Sprintf(format, args...))
+	}
+
+	total := len(p.Books)
+	purged, trashed, skipped, filesDeleted := 0, 0, 0, 0
+	for i, t := range p.Books {
+		if reporter.IsCanceled() {
+			return ctx.Err()
+		}
+		_ = reporter.UpdateProgress(i, total, fmt.Sprintf("Reclaiming %d/%d", i+1, total))
+		book, err := store.GetBookByID(t.BookID)
+		if err != nil || book == nil {
+			skipped++
+			logf(slog.LevelWarn, "Skipping %s: book not found", t.BookID)
+			continue
+		}
+		reporter.SetCurrentItem(book.Title)
+		if err := s.checkReclaimTarget(store, p.Kind, book, t.KeepID); err != nil {
+			skipped++
+			logf(slog.LevelWarn, "Skipping %s: %v", book.Title, err)
+			continue
+		}
+		result, err := s.audiobookService.PurgeBooks(ctx, []string{book.ID}, true)
+		if err != nil {
+			return fmt.Errorf("%s: purge %s: %w", reclaimOpID, book.ID, err)
+		}
+		purged += result.Purged
+		trashed += result.PendingPurge
+		filesDeleted += result.FilesDeleted
+		for _, e := range result.Errors {
+			logf(slog.LevelError, "%s", e)
+		}
+		if result.Purged > 0 {
+			logf(slog.LevelInfo, "Deleted %s (%s)", book.Title, book.FilePath)
+		}
+		if result.PendingPurge > 0 {
+			logf(slog.LevelInfo, "Moved %s to trash (%s)", book.Title, book.FilePath)
+		}
+	}
+	if purged > 0 {
+		s.invalidateSizeCaches()
+	}
+	msg := fmt.Sprintf("Deleted %d books (%d files), moved %d to trash, skipped %d", purged, filesDeleted, trashed, skipped)
+	_ = reporter.UpdateProgress(total, total, msg)
+	logf(slog.LevelInfo, "%s", msg)
+	return nil
+}
+
+// checkReclaimTarget confirms book still qualifies for kind, with keepID
+// still a live copy where one is required. For low-quality books the
+// upgrade is promoted to primary first, so the group keeps a primary.
+func (s *Server) checkReclaimTarget(store database.Store, kind string, book *database.Book, keepID string) error {
+	if kind == reclaimSoftDeleted {
+		if book.MarkedForDeletion == nil || !*book.MarkedForDeletion {
+			return errors.New("no longer soft-deleted")
+		}
+		return nil
+	}
+	if book.MarkedForDeletion != nil && *book.MarkedForDeletion {
+		return errors.New("soft-deleted since the advice was given")
+	}
+	if keepID == "" || keepID == book.ID {
+		return errors.New("no copy to keep")
+	}
+	keep, err := store.GetBookByID(keepID)
+	if err != nil || keep == nil || (keep.MarkedForDeletion != nil && *keep.MarkedForDeletion) {
+		return fmt.Errorf("kept copy %s is gone", keepID)
+	}
+	sameGroup := isVersionGrouped(book) && isVersionGrouped(keep) && *book.VersionGroupID == *keep.VersionGroupID
+
+	switch kind {
+	case reclaimLowQuality:
+		if book.UpgradeAvailable == nil || !*book.UpgradeAvailable || book.UpgradeCandidateID == nil || *book.UpgradeCandidateID != keepID {
+			return errors.New("upgrade no longer pending")
+		}
+		if !sameGroup {
+			return errors.New("upgrade is no longer in the same version group")
+		}
+		return promotePrimaryVersion(store, keep)
+	case reclaimNonPrimary:
+		if !sameGroup || (book.IsPrimaryVersion != nil && *book.IsPrimaryVersion) {
+			return errors.New("no longer a non-primary version")
+		}
+		if keep.IsPrimaryVersion == nil || !*keep.IsPrimaryVersion {
+			return fmt.Errorf("%s is no longer the primary version", keep.Title)
+		}
+	case reclaimDuplicate:
+		if book.FileHash == nil || keep.FileHash == nil || *book.FileHash == "" || *book.FileHash != *keep.FileHash {
+			return errors.New("files no longer identical")
+		}
+		if keep.FilePath == book.FilePath {
+			return errors.New("both records point at the same file")
+		}
+	}
+	return nil
+}
+
+// promotePrimaryVersion makes book the primary of its version group and
+// clears the group's upgrade flags, which were relative to the old
+// primary.
+func promotePrimaryVersion(store database.Store, book *database.Book) error {
+	group, err := store.GetBooksByVersionGroup(*book.VersionGroupID)
+	if err != nil {
+		return fmt.Errorf("load version group: %w", err)
+	}
+	for i := range group {
+		isPrimary := group[i].ID == book.ID
+		group[i].IsPrimaryVersion = &isPrimary
+		group[i].UpgradeAvailable = nil
+		group[i].UpgradeCandidateID = nil
+		if _, err := store.UpdateBook(group[i].ID, &group[i]); err != nil {
+			return fmt.Errorf("update version %s: %w", group[i].ID, err)
+		}
+	}
+	return nil
+}
@@ -1,5 +1,5 @@
 // file: internal/server/reconcile_ops.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: 5c2d8f41-a3e7-4b19-8d60-9f1e2c3a4b5d
 
 // reconcile_ops registers the v2 OperationDefs for the reconcile scan and
@@ -47,6 +47,7 @@ func (s *Server) RegisterReconcileScanOpV2(reg *opsregistry.Registry) error {
 		Timeout:         2 * time.Hour,
 		ResumePolicy:    opsregistry.ResumeDrop,
 		ConcurrencyKey:  "reconcile.scan",
+		Pool:            "scan",
 		Permissions:     []auth.Permission{auth.PermSettingsManage},
 		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead},
 		Run: func(ctx context.Context, rawParams json.RawMessage, reporter opsregistry.Reporter) error {
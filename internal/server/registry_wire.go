@@ -1,5 +1,5 @@
 // file: internal/server/registry_wire.go
-// version: 1.9.0
+// version: 1.13.0
 
 package server
 
@@ -10,6 +10,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/activity"
 	"github.com/falkcorp/audiobook-organizer/internal/ai"
 	"github.com/falkcorp/audiobook-organizer/internal/aiscan"
+	"github.com/falkcorp/audiobook-organizer/internal/applog"
 	audiobookspkg "github.com/falkcorp/audiobook-organizer/internal/audiobooks"
 	"github.com/falkcorp/audiobook-organizer/internal/batch"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
@@ -18,6 +19,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/fileops"
 	"github.com/falkcorp/audiobook-organizer/internal/importer"
 	itunesservice "github.com/falkcorp/audiobook-organizer/internal/itunes/service"
+	"github.com/falkcorp/audiobook-organizer/internal/librarylock"
 	"github.com/falkcorp/audiobook-organizer/internal/merge"
 	"github.com/falkcorp/audiobook-organizer/internal/metafetch"
 	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
@@ -60,7 +62,7 @@ func init() {
 		Groups: []string{"ai"},
 		Build: func(c *serviceregistry.Container) (any, error) {
 			store := serviceregistry.Get[database.Store](c, "store")
-			ps, ok := store.(*database.PebbleStore)
+			ps, ok := database.AsPebbleStore(store)
 			if !ok {
 				return (*database.EmbeddingStore)(nil), nil
 			}
@@ -166,7 +168,7 @@ func init() {
 		Groups: []string{"ai"},
 		Build: func(c *serviceregistry.Container) (any, error) {
 			store := serviceregistry.Get[database.Store](c, "store")
-			ps, ok := store.(*database.PebbleStore)
+			ps, ok := database.AsPebbleStore(store)
 			if !ok {
 				return (*database.AIScanStore)(nil), nil
 			}
@@ -302,6 +304,22 @@ func wireServerFromContainer(s *Server, c *serviceregistry.Container) {
 		if s.activityService != nil {
 			s.opRegistry.SetActivityRecorder(s.activityService)
 		}
+		if s.eventBus != nil {
+			s.opRegistry.SetLifecycleObserver(opLifecycleEvents{events: s.eventBus})
+		}
+		s.opRegistry.SetFreezer(libraryFreezer{s: s})
+	}
+	if lock, ok := serviceregistry.TryGet[*librarylock.Lock](c, "librarylock"); ok && lock != nil {
+		s.libraryLock = lock
+		if s.opRegistry != nil {
+			s.opRegistry.SetRunGuard(lock)
+		}
+		if s.systemService != nil {
+			s.systemService.SetLibraryLock(lock)
+		}
+	}
+	if logs, ok := serviceregistry.TryGet[*applog.Service](c, "applog"); ok && logs != nil && s.systemService != nil {
+		s.systemService.SetAppLogs(logs)
 	}
 	if hub, ok := serviceregistry.TryGet[*opsregistry.EventHub](c, "ophub"); ok {
 		s.opHub = hub
@@ -1,31 +1,33 @@
 // file: internal/server/response_types.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: 7f8a9b0c-1d2e-3f4a-5b6c-7d8e9f0a1b2c
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package server
 
+import "github.com/falkcorp/audiobook-organizer/internal/database"
+
 // AudiobookResponse provides a consistent format for audiobook responses.
 type AudiobookResponse struct {
-	ID                  string   `json:"id"`
-	Title               string   `json:"title"`
-	Author              string   `json:"author,omitempty"`
-	Series              string   `json:"series,omitempty"`
-	SeriesSequence      *int     `json:"series_sequence,omitempty"`
-	FilePath            string   `json:"file_path,omitempty"`
-	Format              string   `json:"format,omitempty"`
-	Duration            int64    `json:"duration,omitempty"`
-	ReleaseYear         *int     `json:"release_year,omitempty"`
-	Genre               string   `json:"genre,omitempty"`
-	Narrators           string   `json:"narrators,omitempty"`
-	Publisher           string   `json:"publisher,omitempty"`
-	Language            string   `json:"language,omitempty"`
-	CoverArtPath        string   `json:"cover_art_path,omitempty"`
-	Description         string   `json:"description,omitempty"`
-	Rating              *float64 `json:"rating,omitempty"`
-	TagList             []string `json:"tags,omitempty"`
-	IsMarkedForDeletion bool     `json:"is_marked_for_deletion,omitempty"`
-	IsAudiobook         bool     `json:"is_audiobook,omitempty"`
+	ID                  string              `json:"id"`
+	Title               string              `json:"title"`
+	Author              string              `json:"author,omitempty"`
+	Series              string              `json:"series,omitempty"`
+	SeriesSequence      *database.SeriesSeq `json:"series_sequence,omitempty"`
+	FilePath            string              `json:"file_path,omitempty"`
+	Format              string              `json:"format,omitempty"`
+	Duration            int64               `json:"duration,omitempty"`
+	ReleaseYear         *int                `json:"release_year,omitempty"`
+	Genre               string              `json:"genre,omitempty"`
+	Narrators           string              `json:"narrators,omitempty"`
+	Publisher           string              `json:"publisher,omitempty"`
+	Language            string              `json:"language,omitempty"`
+	CoverArtPath        string              `json:"cover_art_path,omitempty"`
+	Description         string              `json:"description,omitempty"`
+	Rating              *float64            `json:"rating,omitempty"`
+	TagList             []string            `json:"tags,omitempty"`
+	IsMarkedForDeletion bool                `json:"is_marked_for_deletion,omitempty"`
+	IsAudiobook         bool                `json:"is_audiobook,omitempty"`
 }
 
 // WorkResponse provides a consistent format for work responses.
@@ -0,0 +1,92 @@
+// file: internal/server/retry_failed_op.go
+// version: 1.0.0
+// guid: 4e7b1c93-a2d8-4f65-9c0e-b83f5d16a2e7
+// last-edited: 2026-10-16
+
+// retry_failed_op registers "library.retry-failed", which re-runs the
+// entries of the failed-file retry queue whose backoff has elapsed. The
+// retry_failed_files scheduler task enqueues it every minute while
+// entries are due.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/operations"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+	"github.com/falkcorp/audiobook-organizer/internal/retryqueue"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+	ulid "github.com/oklog/ulid/v2"
+)
+
+// RegisterRetryFailedOp registers the "library.retry-failed" OperationDef.
+func (s *Server) RegisterRetryFailedOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              "library.retry-failed",
+		Plugin:          "library",
+		DisplayName:     "Retry Failed Files",
+		Description:     "Retry files whose scan or organize step failed for a transient reason, once their backoff has elapsed.",
+		DefaultPriority: opsregistry.PriorityLow,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         time.Hour,
+		ResumePolicy:    opsregistry.ResumeDrop,
+		ConcurrencyKey:  "library.retry-failed",
+		Permissions:     []auth.Permission{auth.PermScanTrigger},
+		Capabilities:    []opsregistry.Capability{opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite, opsregistry.CapFilesWrite},
+		Run: func(ctx context.Context, _ json.RawMessage, reporter opsregistry.Reporter) error {
+			rq := retryqueue.Store(s.Store())
+			if rq == nil {
+				return fmt.Errorf("library.retry-failed: retry queue not available")
+			}
+			due, err := retryqueue.Due(rq, time.Now())
+			if err != nil {
+				return fmt.Errorf("library.retry-failed: list retries: %w", err)
+			}
+			progress := registryProgressAdapter{r: reporter}
+			log := operations.LoggerFromReporter(progress)
+			log.Info("Retrying %d failed files", len(due))
+
+			opID := ulid.Make().String()
+			organized := false
+			for i, e := range due {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				_ = progress.UpdateProgress(i, len(due), fmt.Sprintf("Retrying %s", e.Path))
+				var runErr error
+				switch e.Kind {
+				case database.RetryKindScan:
+					runErr = scanner.RetryScan(ctx, e, log)
+				case database.RetryKindOrganize:
+					if s.organizeService == nil {
+						continue
+					}
+					runErr = s.organizeService.RetryOrganizeBook(ctx, e.BookID, opID, log)
+					organized = true
+				default:
+					log.Warn("Dropping retry entry %s of unknown kind %q", e.ID, e.Kind)
+					_ = rq.DeleteRetryEntry(e.ID)
+				}
+				if runErr != nil {
+					log.Warn("Retry of %s failed: %v", e.Path, runErr)
+				}
+			}
+			if organized {
+				s.invalidateSizeCaches()
+			}
+			_ = progress.UpdateProgress(len(due), len(due), "done")
+			return nil
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterRetryFailedOp(reg) })
+}
@@ -0,0 +1,278 @@
+// file: internal/server/routes.go
+// version: 1.0.0
+// guid: 3f8a1c6e-b2d7-4e95-a0c4-7d9e2b5f1a68
+// last-edited: 2026-10-17
+
+// Route registry. Every API route is declared through a routeGroup, which
+// records method, path, handler, auth level, required permission and an
+// optional description, applies the permission check itself, and registers
+// the route with gin. The recorded table is served at GET /api/v1/routes,
+// as JSON or as generated OpenAPI paths.
+
+package server
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// RouteAuth is who may reach a route before any permission check.
+type RouteAuth string
+
+const (
+	RouteAuthPublic        RouteAuth = "public"
+	RouteAuthAuthenticated RouteAuth = "authenticated"
+	RouteAuthAdmin         RouteAuth = "admin"
+)
+
+// noPermission declares a route that needs nothing beyond its group's
+// auth level.
+const noPermission auth.Permission = ""
+
+// apiBasePath is the prefix of the versioned API; generated OpenAPI paths
+// are relative to it, like docs/openapi.yaml.
+const apiBasePath = "/api/v1"
+
+// RouteInfo describes one registered route.
+type RouteInfo struct {
+	Method      string          `json:"method"`
+	Path        string          `json:"path"`
+	Auth        RouteAuth       `json:"auth"`
+	Permission  auth.Permission `json:"permission,omitempty"`
+	Handler     string          `json:"handler"`
+	Description string          `json:"description,omitempty"`
+}
+
+// Describe sets the route's description and returns the route.
+func (r *RouteInfo) Describe(description string) *RouteInfo {
+	r.Description = description
+	return r
+}
+
+// routeRegistry collects the routes declared during setupRoutes.
+type routeRegistry struct {
+	perm   func(auth.Permission) gin.HandlerFunc
+	routes []*RouteInfo
+}
+
+func newRouteRegistry(perm func(auth.Permission) gin.HandlerFunc) *routeRegistry {
+	return &routeRegistry{perm: perm}
+}
+
+// root returns a public group over rg.
+func (reg *routeRegistry) root(rg *gin.RouterGroup) *routeGroup {
+	return &routeGroup{rg: rg, reg: reg, level: RouteAuthPublic}
+}
+
+// Routes returns the registered routes ordered by path, then method.
+func (reg *routeRegistry) Routes() []RouteInfo {
+	out := make([]RouteInfo, 0, len(reg.routes))
+	for _, r := range reg.routes {
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+// routeGroup wraps a gin.RouterGroup so every route registered on it is
+// recorded in the registry with its auth level and permission.
+type routeGroup struct {
+	rg    *gin.RouterGroup
+	reg   *routeRegistry
+	level RouteAuth
+}
+
+// Group returns a sub-group that inherits the auth level.
+func (g *routeGroup) Group(relativePath string) *routeGroup {
+	return &routeGroup{rg: g.rg.Group(relativePath), reg: g.reg, level: g.level}
+}
+
+// Use adds middleware that does not change who may reach the routes
+// (rate limits, body limits, caching).
+func (g *routeGroup) Use(mw ...gin.HandlerFunc) *routeGroup {
+	g.rg.Use(mw...)
+	return g
+}
+
+// Require puts the group's routes behind mw, which must enforce level.
+func (g *routeGroup) Require(level RouteAuth, mw ...gin.HandlerFunc) *routeGroup {
+	g.rg.Use(mw...)
+	g.level = level
+	return g
+}
+
+func (g *routeGroup) GET(relativePath string, perm auth.Permission, handlers ...gin.HandlerFunc) *RouteInfo {
+	return g.handle(http.MethodGet, relativePath, perm, handlers)
+}
+
+func (g *routeGroup) POST(relativePath string, perm auth.Permission, handlers ...gin.HandlerFunc) *RouteInfo {
+	return g.handle(http.MethodPost, relativePath, perm, handlers)
+}
+
+func (g *routeGroup) PUT(relativePath string, perm auth.Permission, handlers ...gin.HandlerFunc) *RouteInfo {
+	return g.handle(http.MethodPut, relativePath, perm, handlers)
+}
+
+func (g *routeGroup) PATCH(relativePath string, perm auth.Permission, handlers ...gin.HandlerFunc) *RouteInfo {
+	return g.handle(http.MethodPatch, relativePath, perm, handlers)
+}
+
+func (g *routeGroup) DELETE(relativePath string, perm auth.Permission, handlers ...gin.HandlerFunc) *RouteInfo {
+	return g.handle(http.MethodDelete, relativePath, perm, handlers)
+}
+
+// handle records the route and registers it with gin, prefixed by the
+// permission check when perm is set. A permission on a public route is a
+// declaration bug (there is no user to check), so it panics at startup.
+func (g *routeGroup) handle(method, relativePath string, perm auth.Permission, handlers []gin.HandlerFunc) *RouteInfo {
+	full := joinRoutePath(g.rg.BasePath(), relativePath)
+	if len(handlers) == 0 {
+		panic("route " + method + " " + full + " has no handler")
+	}
+	if perm != noPermission && g.level == RouteAuthPublic {
+		panic("route " + method + " " + full + " requires permission " + string(perm) + " but is public")
+	}
+	chain := handlers
+	if perm != noPermission {
+		chain = append([]gin.HandlerFunc{g.reg.perm(perm)}, handlers...)
+	}
+	g.rg.Handle(method, relativePath, chain...)
+	info := &RouteInfo{
+		Method:     method,
+		Path:       full,
+		Auth:       g.level,
+		Permission: perm,
+		Handler:    handlerName(handlers[len(handlers)-1]),
+	}
+	g.reg.routes = append(g.reg.routes, info)
+	return info
+}
+
+// joinRoutePath joins like gin does: a trailing slash on rel is kept.
+func joinRoutePath(base, rel string) string {
+	if rel == "" {
+		return base
+	}
+	p := path.Join(base, rel)
+	if strings.HasSuffix(rel, "/") && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return p
+}
+
+// handlerName is the handler's function name without the module path,
+// e.g. "server.(*Server).handleListSchedules".
+func handlerName(h gin.HandlerFunc) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(h).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := strings.TrimSuffix(fn.Name(), "-fm")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// openAPIPaths renders the API routes as an OpenAPI paths object relative
+// to apiBasePath. Gin's :param and *param segments become {param} with a
+// path parameter declared for each.
+func (reg *routeRegistry) openAPIPaths() map[string]map[string]any {
+	paths := map[string]map[string]any{}
+	for _, r := range reg.Routes() {
+		if !strings.HasPrefix(r.Path, apiBasePath+"/") {
+			continue
+		}
+		p, params := openAPIPath(strings.TrimPrefix(r.Path, apiBasePath))
+		item := paths[p]
+		if item == nil {
+			item = map[string]any{}
+			paths[p] = item
+		}
+		op := map[string]any{
+			"x-handler": r.Handler,
+			"summary":   r.Description,
+			"responses": map[string]any{"default": map[string]any{"description": "See docs/openapi.yaml"}},
+		}
+		if r.Description == "" {
+			op["summary"] = r.Handler
+		}
+		if r.Auth != RouteAuthPublic {
+			op["security"] = []map[string][]string{{"bearerAuth": {}}}
+		}
+		if r.Auth == RouteAuthAdmin {
+			op["x-admin-only"] = true
+		}
+		if r.Permission != noPermission {
+			op["x-required-permission"] = r.Permission
+		}
+		if len(params) > 0 {
+			ps := make([]map[string]any, 0, len(params))
+			for _, name := range params {
+				ps = append(ps, map[string]any{
+					"name": name, "in": "path", "required": true,
+					"schema": map[string]string{"type": "string"},
+				})
+			}
+			op["parameters"] = ps
+		}
+		item[strings.ToLower(r.Method)] = op
+	}
+	return paths
+}
+
+func openAPIPath(ginPath string) (string, []string) {
+	segs := strings.Split(ginPath, "/")
+	var params []string
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			params = append(params, seg[1:])
+			segs[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/"), params
+}
+
+// handleListRoutes handles GET /api/v1/routes. ?format=openapi returns an
+// OpenAPI 3 document with the generated paths instead of the route list.
+func (s *Server) handleListRoutes(c *gin.Context) {
+	if s.routes == nil {
+		httputil.RespondWithServiceUnavailable(c, "routes not registered")
+		return
+	}
+	switch c.Query("format") {
+	case "", "json":
+		httputil.RespondWithOK(c, gin.H{
+			"auth_enabled": config.AppConfig.EnableAuth,
+			"routes":       s.routes.Routes(),
+		})
+	case "openapi":
+		// A bare document, not the {"data": ...} envelope, so it can be fed
+		// to OpenAPI tooling as is.
+		c.JSON(http.StatusOK, gin.H{
+			"openapi": "3.0.3",
+			"info":    gin.H{"title": "Audiobook Organizer API (generated routes)", "version": "v1"},
+			"servers": []gin.H{{"url": apiBasePath}},
+			"paths":   s.routes.openAPIPaths(),
+			"components": gin.H{"securitySchemes": gin.H{
+				"bearerAuth": gin.H{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			}},
+		})
+	default:
+		httputil.RespondWithBadRequest(c, "format must be json or openapi")
+	}
+}
@@ -0,0 +1,122 @@
+// file: internal/server/routes_test.go
+// version: 1.0.0
+// guid: 7b2e9d41-c5a8-4f36-8e1d-a3c6f0b4d952
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteRegistry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	var checked []auth.Permission
+	reg := newRouteRegistry(func(p auth.Permission) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			checked = append(checked, p)
+			if c.GetHeader("X-Deny") != "" {
+				c.AbortWithStatus(http.StatusForbidden)
+			}
+		}
+	})
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+
+	root := reg.root(&engine.RouterGroup)
+	api := root.Group("/api/v1")
+	api.GET("/public", noPermission, ok)
+	protected := api.Group("").Require(RouteAuthAuthenticated, func(c *gin.Context) { c.Next() })
+	protected.POST("/things/:id", auth.PermLibraryEditMetadata, ok).Describe("Update a thing")
+	protected.GET("/files/*path", noPermission, ok)
+
+	assert.Panics(t, func() { api.GET("/oops", auth.PermLibraryView, ok) })
+
+	serve := func(method, target string, deny bool) int {
+		req := httptest.NewRequest(method, target, nil)
+		if deny {
+			req.Header.Set("X-Deny", "1")
+		}
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/public", true))
+	assert.Empty(t, checked)
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPost, "/api/v1/things/1", false))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/things/1", true))
+	assert.Equal(t, []auth.Permission{auth.PermLibraryEditMetadata, auth.PermLibraryEditMetadata}, checked)
+
+	routes := reg.Routes()
+	require.Len(t, routes, 3)
+	assert.Equal(t, RouteInfo{Method: http.MethodGet, Path: "/api/v1/files/*path", Auth: RouteAuthAuthenticated, Handler: routes[0].Handler}, routes[0])
+	assert.Equal(t, "/api/v1/public", routes[1].Path)
+	assert.Equal(t, RouteAuthPublic, routes[1].Auth)
+	assert.Equal(t, auth.PermLibraryEditMetadata, routes[2].Permission)
+	assert.Equal(t, "Update a thing", routes[2].Description)
+	assert.Contains(t, routes[2].Handler, "TestRouteRegistry")
+
+	paths := reg.openAPIPaths()
+	require.Contains(t, paths, "/things/{id}")
+	require.Contains(t, paths, "/files/{path}")
+	post := paths["/things/{id}"]["post"].(map[string]any)
+	assert.Equal(t, "Update a thing", post["summary"])
+	assert.Equal(t, auth.PermLibraryEditMetadata, post["x-required-permission"])
+	assert.NotNil(t, post["security"])
+	assert.Nil(t, paths["/public"]["get"].(map[string]any)["security"])
+}
+
+func TestSetupRoutes_EveryAPIRouteInRegistry(t *testing.T) {
+	srv := setupMaintenanceTestServer(t)
+
+	registered := map[string]RouteInfo{}
+	for _, r := range srv.routes.Routes() {
+		registered[r.Method+" "+r.Path] = r
+	}
+	for _, r := range srv.router.Routes() {
+		if r.Path == "/" {
+			continue // static placeholder, not part of the API
+		}
+		assert.Contains(t, registered, r.Method+" "+r.Path, "route bypasses the registry")
+	}
+
+	sched := registered["GET /api/v1/schedules"]
+	assert.Equal(t, RouteAuthAuthenticated, sched.Auth)
+	assert.Equal(t, auth.PermSettingsManage, sched.Permission)
+	assert.Equal(t, RouteAuthPublic, registered["POST /api/v1/auth/login"].Auth)
+	assert.Equal(t, RouteAuthAdmin, registered["POST /api/v1/maintenance/wipe"].Auth)
+
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/routes", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data struct {
+			Routes []RouteInfo `json:"routes"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data.Routes, len(registered))
+
+	w = httptest.NewRecorder()
+	srv.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/routes?format=openapi", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths["/schedules/{id}"], "put")
+
+	w = httptest.NewRecorder()
+	srv.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/routes?format=xml", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,67 @@
+// file: internal/server/scan_profiles.go
+// version: 1.1.0
+// guid: 3ff31f59-ed7e-41e2-8bf2-d072661c7552
+// last-edited: 2026-10-17
+//
+// Scan-profile endpoints: a pre-scan time estimate for each profile and
+// the chapter markers recorded by scans.
+
+package server
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+)
+
+// handleScanEstimate projects how long a scan would take.
+// GET /api/v1/operations/scan/estimate?folder_path=&profile=&force_update=
+//
+// The response lists the folders the scan would visit with the profile
+// each would run under, plus an estimate for every profile so the UI can
+// offer the choice before starting. Only directory entries are read.
+func (s *Server) handleScanEstimate(c *gin.Context) {
+	if s.scanService == nil {
+		httputil.RespondWithInternalError(c, "scan service not initialized")
+		return
+	}
+	profile := c.Query("profile")
+	if !scanner.ValidScanProfile(profile) {
+		httputil.RespondWithValidationError(c, "profile", "must be one of: "+strings.Join(scanner.ScanProfiles, ", "))
+		return
+	}
+	var folderPath *string
+	if fp := strings.TrimSpace(c.Query("folder_path")); fp != "" {
+		folderPath = &fp
+	}
+	plan, err := s.scanService.EstimateScan(folderPath, c.Query("force_update") == "true", profile)
+	if err != nil {
+		httputil.InternalError(c, "failed to estimate scan", err)
+		return
+	}
+	httputil.RespondWithOK(c, plan)
+}
+
+// handleBookChapters returns the chapters recorded for a book: embedded
+// or cue sheet markers read by scans, plus proposed or edited ones.
+// GET /api/v1/audiobooks/:id/chapters
+func (s *Server) handleBookChapters(c *gin.Context) {
+	bookID := c.Param("id")
+	book, err := s.Store().GetBookByID(bookID)
+	if err != nil || book == nil {
+		httputil.RespondWithNotFound(c, "book", bookID)
+		return
+	}
+	chapters, err := database.GetBookChapters(s.Store(), bookID)
+	if err != nil {
+		httputil.InternalError(c, "failed to load chapters", err)
+		return
+	}
+	if chapters == nil {
+		chapters = []database.BookChapter{}
+	}
+	httputil.RespondWithOK(c, gin.H{"chapters": chapters, "count": len(chapters)})
+}
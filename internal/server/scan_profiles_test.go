@@ -0,0 +1,80 @@
+// file: internal/server/scan_profiles_test.go
+// version: 1.2.0
+// guid: 91e8f718-6fc9-4a76-8cc1-bb476472f05d
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanProfiles_ImportPathDefaultAndEstimate(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	importDir := t.TempDir()
+	config.AppConfig.SupportedExtensions = []string{".m4b"}
+	config.AppConfig.ConcurrentScans = 2
+	require.NoError(t, os.WriteFile(filepath.Join(importDir, "book.m4b"), make([]byte, 1024), 0o644))
+
+	ip, err := database.GetGlobalStore().CreateImportPath(importDir, "Import")
+	require.NoError(t, err)
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/import-paths/%d", ip.ID), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusBadRequest, patch(`{"scan_profile":"thorough"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, patch(`{"scan_mode":"sometimes"}`).Code)
+	require.Equal(t, http.StatusOK, patch(`{"scan_profile":"quick"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, patch(`{"adopt":true,"adopt_pattern":"{author}/{series}"}`).Code, "adopt pattern needs {title}")
+	require.Equal(t, http.StatusOK, patch(`{"adopt":true,"adopt_pattern":"{author}/{title}"}`).Code)
+	adopted, err := database.GetGlobalStore().GetImportPathByID(ip.ID)
+	require.NoError(t, err)
+	assert.True(t, adopted.Adopt)
+	assert.Equal(t, "{author}/{title}", adopted.AdoptPattern)
+
+	estimate := func() scanner.ScanPlan {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/operations/scan/estimate", nil)
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Data scanner.ScanPlan `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data.Folders, 1)
+		return resp.Data
+	}
+	// A new path awaits its initial deep scan whatever its default.
+	assert.Equal(t, scanner.ScanProfileDeep, estimate().Folders[0].Profile)
+
+	require.Equal(t, http.StatusOK, patch(`{"scan_mode":"incremental"}`).Code)
+	plan := estimate()
+	assert.Equal(t, scanner.ScanProfileQuick, plan.Folders[0].Profile)
+	assert.Equal(t, 1, plan.Folders[0].Files)
+	require.Len(t, plan.Estimates, len(scanner.ScanProfiles))
+	assert.Equal(t, int64(1024), plan.Estimates[0].Bytes)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/operations/scan/estimate?profile=nope", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,8 +1,8 @@
 // file: internal/server/scheduler_extra_ops.go
-// version: 2.0.0
+// version: 2.1.0
 // guid: f1e2d3c4-b5a6-7890-fedc-ba9876543210
 
-// scheduler_extra_ops is a thin shim that wires the 13 ExtraOpsRegistrar
+// scheduler_extra_ops is a thin shim that wires the ExtraOpsRegistrar
 // methods (now living in internal/scheduler/extra_ops.go) into the server
 // package's addOpRegistrar mechanism.
 //
@@ -45,6 +45,9 @@ func init() {
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error {
 		return s.extraOpsRegistrar.RegisterCleanupOldBackupsOp(reg)
 	})
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error {
+		return s.extraOpsRegistrar.RegisterDatabaseBackupOp(reg)
+	})
 	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error {
 		return s.extraOpsRegistrar.RegisterISBNEnrichmentOp(reg)
 	})
@@ -1,7 +1,7 @@
 // file: internal/server/scheduler_maintenance_window_op.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: 2a4b6c8d-0e1f-2a3b-4c5d-6e7f8a9b0c1d
-// last-edited: 2026-05-11
+// last-edited: 2026-10-16
 
 package server
 
@@ -61,7 +61,7 @@ func (s *Server) RegisterMaintenanceWindowOp(reg *opsregistry.Registry) error {
 			// Step 1: Auto-update (if enabled and not already completed post-restart)
 			if config.AppConfig.AutoUpdateEnabled {
 				updateDone, _ := store.GetSetting("maintenance_window_update_completed")
-				today := time.Now().Format("2006-01-02")
+				today := config.Now().Format("2006-01-02")
 				if updateDone == nil || updateDone.Value != today {
 					_ = progress.Log("info", "Running auto-update (step 1)", nil)
 					// Auto-update is a single bounded step (0/1 → done).
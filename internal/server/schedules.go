@@ -0,0 +1,166 @@
+// file: internal/server/schedules.go
+// version: 1.1.0
+// guid: 9d5b3e17-c4a8-4f62-b1e0-7a2c8d6f4b39
+// last-edited: 2026-10-17
+
+// Cron schedules: /schedules CRUD over scheduler.Schedule. Each schedule
+// runs one of the tasks listed at GET /tasks on a cron expression; runs
+// are enqueued as scheduled operations and show up in the operations
+// queue. Every run of a task, however triggered, is also kept in its
+// history at /tasks/:name/history.
+
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/scheduler"
+	"github.com/gin-gonic/gin"
+)
+
+// scheduleRequest is the create body. Enabled defaults to true.
+type scheduleRequest struct {
+	Name    string `json:"name"`
+	Task    string `json:"task" binding:"required"`
+	Cron    string `json:"cron" binding:"required"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// respondScheduleError maps scheduler errors to HTTP statuses.
+func respondScheduleError(c *gin.Context, id string, err error) {
+	switch {
+	case errors.Is(err, scheduler.ErrScheduleNotFound):
+		httputil.RespondWithNotFound(c, "schedule", id)
+	case errors.Is(err, scheduler.ErrInvalidSchedule):
+		httputil.RespondWithBadRequest(c, err.Error())
+	default:
+		httputil.InternalError(c, "schedule request failed", err)
+	}
+}
+
+// requireScheduler returns the task scheduler, or responds and returns
+// nil when it has not been started yet.
+func (s *Server) requireScheduler(c *gin.Context) *scheduler.TaskScheduler {
+	if s.scheduler == nil {
+		httputil.RespondWithServiceUnavailable(c, "scheduler not initialized")
+		return nil
+	}
+	return s.scheduler
+}
+
+// handleListSchedules handles GET /api/v1/schedules.
+func (s *Server) handleListSchedules(c *gin.Context) {
+	sched := s.requireScheduler(c)
+	if sched == nil {
+		return
+	}
+	schedules, err := sched.ListSchedules()
+	if err != nil {
+		respondScheduleError(c, "", err)
+		return
+	}
+	httputil.RespondWithOK(c, schedules)
+}
+
+// handleGetSchedule handles GET /api/v1/schedules/:id.
+func (s *Server) handleGetSchedule(c *gin.Context) {
+	sched := s.requireScheduler(c)
+	if sched == nil {
+		return
+	}
+	id := c.Param("id")
+	schedule, err := sched.GetSchedule(id)
+	if err != nil {
+		respondScheduleError(c, id, err)
+		return
+	}
+	httputil.RespondWithOK(c, schedule)
+}
+
+// handleCreateSchedule handles POST /api/v1/schedules.
+func (s *Server) handleCreateSchedule(c *gin.Context) {
+	sched := s.requireScheduler(c)
+	if sched == nil {
+		return
+	}
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	enabled := req.Enabled == nil || *req.Enabled
+	schedule, err := sched.CreateSchedule(req.Name, req.Task, req.Cron, enabled)
+	if err != nil {
+		respondScheduleError(c, "", err)
+		return
+	}
+	httputil.RespondWithCreated(c, schedule)
+}
+
+// handleUpdateSchedule handles PUT /api/v1/schedules/:id. Fields left
+// out of the body are unchanged.
+func (s *Server) handleUpdateSchedule(c *gin.Context) {
+	sched := s.requireScheduler(c)
+	if sched == nil {
+		return
+	}
+	var req scheduler.ScheduleUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondWithBadRequest(c, err.Error())
+		return
+	}
+	id := c.Param("id")
+	schedule, err := sched.UpdateSchedule(id, req)
+	if err != nil {
+		respondScheduleError(c, id, err)
+		return
+	}
+	httputil.RespondWithOK(c, schedule)
+}
+
+// handleDeleteSchedule handles DELETE /api/v1/schedules/:id.
+func (s *Server) handleDeleteSchedule(c *gin.Context) {
+	sched := s.requireScheduler(c)
+	if sched == nil {
+		return
+	}
+	id := c.Param("id")
+	if err := sched.DeleteSchedule(id); err != nil {
+		respondScheduleError(c, id, err)
+		return
+	}
+	httputil.RespondWithNoContent(c)
+}
+
+// handleTaskHistory handles GET /api/v1/tasks/:name/history: the task's
+// most recent runs, newest first, whatever triggered them.
+//
+// Query params:
+//   - limit: runs returned (default 20, max 50).
+func (s *Server) handleTaskHistory(c *gin.Context) {
+	sched := s.requireScheduler(c)
+	if sched == nil {
+		return
+	}
+	limit := 20
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 50 {
+			httputil.RespondWithValidationError(c, "limit", "must be between 1 and 50")
+			return
+		}
+		limit = n
+	}
+	name := c.Param("name")
+	runs, err := sched.TaskHistory(name, limit)
+	switch {
+	case errors.Is(err, scheduler.ErrUnknownTask):
+		httputil.RespondWithNotFound(c, "task", name)
+	case err != nil:
+		httputil.InternalError(c, "failed to read task history", err)
+	default:
+		httputil.RespondWithOK(c, runs)
+	}
+}
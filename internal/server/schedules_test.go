@@ -0,0 +1,100 @@
+// file: internal/server/schedules_test.go
+// version: 1.1.0
+// guid: 4b8e2d96-f1a3-4c57-9e0b-c6d3a7f15e28
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulesCRUD(t *testing.T) {
+	srv := setupMaintenanceTestServer(t)
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var r *http.Request
+		if body != nil {
+			data, _ := json.Marshal(body)
+			r = httptest.NewRequest(method, path, bytes.NewReader(data))
+			r.Header.Set("Content-Type", "application/json")
+		} else {
+			r = httptest.NewRequest(method, path, nil)
+		}
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/schedules", map[string]any{"task": "library_scan", "cron": "0 3 * * *", "name": "Nightly scan"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data scheduler.Schedule `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "Nightly scan", created.Data.Name)
+	assert.True(t, created.Data.Enabled)
+	assert.NotNil(t, created.Data.NextRunAt)
+	id := created.Data.ID
+
+	w = do(http.MethodPost, "/api/v1/schedules", map[string]any{"task": "nope", "cron": "@daily"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(http.MethodPost, "/api/v1/schedules", map[string]any{"task": "database_backup", "cron": "0 25 * * *"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodPut, "/api/v1/schedules/"+id, map[string]any{"enabled": false})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated struct {
+		Data scheduler.Schedule `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.False(t, updated.Data.Enabled)
+	assert.Nil(t, updated.Data.NextRunAt)
+	assert.Equal(t, "0 3 * * *", updated.Data.Cron)
+
+	w = do(http.MethodGet, "/api/v1/schedules", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data []scheduler.Schedule `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Data, 1)
+
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/api/v1/schedules/"+id, nil).Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/api/v1/schedules/"+id, nil).Code)
+}
+
+func TestTaskHistoryEndpoint(t *testing.T) {
+	srv := setupMaintenanceTestServer(t)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	// The test server registers no operations, so the run fails to start
+	// and the failure is what history records.
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/tasks/library_size_refresh/run", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = get("/api/v1/tasks/library_size_refresh/history")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data []scheduler.TaskRun `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "manual", resp.Data[0].Source)
+	assert.Contains(t, resp.Data[0].Error, "library.size-refresh")
+
+	assert.Equal(t, http.StatusNotFound, get("/api/v1/tasks/no_such_task/history").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/api/v1/tasks/library_size_refresh/history?limit=0").Code)
+}
@@ -1,7 +1,7 @@
 // file: internal/server/server.go
-// version: 2.28.0
+// version: 2.40.0
 // guid: 4c5d6e7f-8a9b-0c1d-2e3f-4a5b6c7d8e9f
-// last-edited: 2026-06-14
+// last-edited: 2026-10-17
 
 package server
 
@@ -10,7 +10,6 @@ import (
 
 	"log"
 	"log/slog"
-	"net/http"
 	"os"
 	"strings"
 	"sync"
@@ -23,6 +22,7 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/batch"
 	"github.com/falkcorp/audiobook-organizer/internal/cache"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/covers"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	"github.com/falkcorp/audiobook-organizer/internal/dedup"
 	"github.com/falkcorp/audiobook-organizer/internal/deluge"
@@ -40,7 +40,6 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/scheduler"
 	operationshandlers "github.com/falkcorp/audiobook-organizer/internal/server/handlers/operations"
 	systemhandlers "github.com/falkcorp/audiobook-organizer/internal/server/handlers/system"
-	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
@@ -48,6 +47,7 @@ import (
 	// the plugins register themselves with the serviceregistry. The
 	// container's PostInit calls Plugin.Register(opRegistry) for each.
 	"github.com/falkcorp/audiobook-organizer/internal/fileops"
+	"github.com/falkcorp/audiobook-organizer/internal/librarylock"
 	"github.com/falkcorp/audiobook-organizer/internal/organizer"
 	"github.com/falkcorp/audiobook-organizer/internal/plugin"
 	_ "github.com/falkcorp/audiobook-organizer/internal/plugins/acoustid"
@@ -85,6 +85,13 @@ const librarySizeCacheTTL = 24 * time.Hour
 // appVersion is set at startup via SetVersion(), injected from main.version
 var appVersion = "dev"
 
+// appCommit and appBuildDate are set at startup via SetBuildInfo(), injected
+// from main.commit / main.date.
+var (
+	appCommit    = "none"
+	appBuildDate = "unknown"
+)
+
 // SetVersion sets the application version string.
 
 // resetLibrarySizeCache resets the library size cache (for testing)
@@ -114,6 +121,8 @@ type enrichedBookResponse struct {
 	Narrators                        []narratorEntry `json:"narrators,omitempty"`
 	FileExists                       *bool           `json:"file_exists,omitempty"`
 	MetadataSourceHashDuplicateCount *int            `json:"metadata_source_hash_duplicate_count,omitempty"`
+	// ChapterCount is only filled in for single-book responses.
+	ChapterCount *int `json:"chapter_count,omitempty"`
 }
 
 type authorEntry struct {
@@ -157,7 +166,7 @@ type narratorEntry struct {
 // Server represents the HTTP server
 type Server struct {
 	store                  database.Store
-	httpServer             *http.Server
+	listener               httpListener
 	router                 *gin.Engine
 	audiobookService       *audiobookspkg.AudiobookService
 	audiobookUpdateService *AudiobookUpdateService
@@ -194,7 +203,7 @@ type Server struct {
 	operationsHandler *operationshandlers.Handler
 	// systemHandler is the migrated system-domain handler (instantiated in
 	// wireHandlers). The public /health and /api/events routes are registered in
-	// setupRoutes (before the /api/* redirect middleware, so their pre-middleware
+	// setupRoutes (before the /api/* versioning middleware, so their pre-middleware
 	// ordering is preserved) via closures that delegate to this handler; the
 	// remaining protected system routes are registered directly in wireHandlers.
 	systemHandler      *systemhandlers.Handler
@@ -227,6 +236,9 @@ type Server struct {
 	// without relying on timed sleeps.
 	indexWorkerBusy int32
 	http3Server     *http3.Server
+	// routes records every route declared in setupRoutes (routes.go) and
+	// backs GET /api/v1/routes.
+	routes *routeRegistry
 
 	hub              *realtime.EventHub
 	writeBackBatcher *itunesservice.WriteBackBatcher
@@ -236,10 +248,18 @@ type Server struct {
 	// No plugins are registered until their own bot-tasks wire them in.
 	opRegistry *opsregistry.Registry
 
+	// apiIdle tracks API traffic so the startup scan can wait for a quiet
+	// period (see startup_idle.go).
+	apiIdle apiIdleTracker
+
 	// opHub is the UOS-06 SSE event bus for operations events.
 	// Created in NewServer, wired to opRegistry via SetBus before Start().
 	opHub *opsregistry.EventHub
 
+	// libraryLock keeps a second instance pointed at the same library from
+	// running destructive operations; see internal/librarylock.
+	libraryLock *librarylock.Lock
+
 	// protectedPathCache holds the union of Deluge save_paths and
 	// config.ProtectedPaths. Consulted before any in-place tag write.
 	// Nil when Deluge is not configured (extra paths only, or no Deluge URL).
@@ -336,7 +356,7 @@ func NewServer(store database.Store) *Server {
 	router.Use(gin.Recovery())
 	router.Use(corsMiddleware())
 	router.Use(servermiddleware.BasicAuth())
-	router.Use(gzip.Gzip(gzip.DefaultCompression, gzip.WithExcludedPaths([]string{"/api/events"})))
+	router.Use(servermiddleware.Compress("/api/events"))
 	// OpenTelemetry instrumentation: create per-handler spans and record metrics
 	router.Use(otelgin.Middleware("audiobook-organizer"))
 
@@ -353,6 +373,11 @@ func NewServer(store database.Store) *Server {
 	// inline DB calls) no longer reach for database.GetGlobalStore
 	// (SERVER-GLOBAL-STORE-AUDIT phase 7).
 	scanner.SetStore(resolvedStore)
+	// Mirror covers to the configured blob store; with a bad setting they
+	// stay on local disk only.
+	if err := covers.ConfigureBlobStore(config.Snapshot()); err != nil {
+		slog.Warn("blob storage not available, keeping covers on local disk only", "err", err)
+	}
 	bgCtx, bgCancel := context.WithCancel(context.Background())
 	server := &Server{
 		store:                  store,
@@ -648,8 +673,43 @@ func NewServer(store database.Store) *Server {
 		slog.Info("Activity log service initialized and recording")
 	}
 
-	// Wire post-scan auto-quarantine hook.
-	server.scanService.PostScanFn = server.quarantineSvc.AutoQuarantineFailedScans
+	// Wire post-scan auto-quarantine hook: unreadable files and imports
+	// whose size doesn't fit their duration.
+	server.scanService.PostScanFn = func() {
+		server.quarantineSvc.AutoQuarantineFailedScans()
+		server.quarantineSvc.AutoQuarantineSizeAnomalies()
+	}
+
+	// Deep scans finish by fingerprinting whatever they imported; the
+	// backfill skips files that already have fingerprints.
+	server.scanService.FingerprintFn = func(context.Context) {
+		server.bgWG.Add("acoustid-backfill")
+		go func() {
+			defer server.bgWG.Done("acoustid-backfill")
+			server.backfillAcoustIDs(server.bgCtx)
+		}()
+	}
+
+	// Import paths that go unreachable (or come back) alert through the
+	// event bus so notifier plugins can page someone.
+	server.scanService.ImportPathHealthFn = func(ip database.ImportPath) {
+		evType := plugin.EventImportPathRecovered
+		if ip.Health == database.ImportPathUnhealthy {
+			evType = plugin.EventImportPathUnhealthy
+		}
+		server.publishEvent(context.Background(), plugin.NewEvent(evType, "", map[string]any{
+			"import_path_id": ip.ID,
+			"path":           ip.Path,
+			"name":           ip.Name,
+			"reason":         ip.HealthReason,
+		}))
+	}
+
+	// A newer release found by the update checker (scheduled or manual)
+	// is announced once per version.
+	if server.updater != nil {
+		server.updater.SetNotifier(server.notifyUpdateAvailable)
+	}
 
 	// Wire post-folder auto-organize hook (breaks scanner→organizer import cycle).
 	server.scanService.AutoOrganizeFn = func(ctx context.Context, books []scanner.Book, l logger.Logger) {
@@ -801,8 +861,9 @@ type serverOrganizeHooks struct {
 
 // perm returns a Gin middleware that checks the calling user has the
 // given permission. It's a thin wrapper around RequirePermission from
-// the middleware package, curried with the server's Store. Used inline
-// in route registration: `protected.GET("/path", s.perm(P), s.handler)`.
+// the middleware package, curried with the server's Store. The route
+// registry applies it for every route declared with a permission:
+// `protected.GET("/path", auth.PermX, s.handler)`.
 
 // itunesSvcGuard returns a gin handler that checks s.itunesSvc is non-nil
 // and enabled before delegating to fn. Any route that directly dereferences
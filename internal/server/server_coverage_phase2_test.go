@@ -1,7 +1,7 @@
 // file: internal/server/server_coverage_phase2_test.go
-// version: 1.2.0
+// version: 1.4.0
 // guid: d5e6f7a8-b9c0-1d2e-3f4a-5b6c7d8e9f0a
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package server
 
@@ -137,7 +137,7 @@ func TestAddBlockedHashDatabaseError(t *testing.T) {
 	validHash := "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
 	mockStore.EXPECT().SetRootDir(mock.Anything).Return()
 	mockStore.EXPECT().
-		AddBlockedHash(validHash, "duplicate file").
+		AddBlockedHashWithAlgorithm(validHash, "", "duplicate file").
 		Return(errors.New("database error: constraint violation")).
 		Once()
 
@@ -170,6 +170,7 @@ func TestDeleteWorkErrors(t *testing.T) {
 			workID: "01HQWKV1234567890ABCDEFGHJK",
 			mockSetup: func(m *mocks.MockStore) {
 				m.EXPECT().SetRootDir(mock.Anything).Return()
+				m.EXPECT().GetBooksByWorkID("01HQWKV1234567890ABCDEFGHJK").Return(nil, nil).Once()
 				// WorkService.DeleteWork checks existence first, then deletes.
 				m.EXPECT().GetWorkByID("01HQWKV1234567890ABCDEFGHJK").Return(&database.Work{ID: "01HQWKV1234567890ABCDEFGHJK", Title: "Work"}, nil).Once()
 				m.EXPECT().DeleteWork("01HQWKV1234567890ABCDEFGHJK").Return(errors.New("database connection error")).Once()
@@ -181,6 +182,7 @@ func TestDeleteWorkErrors(t *testing.T) {
 			workID: "01HQWKV9999999999999999999",
 			mockSetup: func(m *mocks.MockStore) {
 				m.EXPECT().SetRootDir(mock.Anything).Return()
+				m.EXPECT().GetBooksByWorkID("01HQWKV9999999999999999999").Return(nil, nil).Once()
 				// WorkService.DeleteWork checks existence; nil work → returns "work not found" without calling DeleteWork.
 				m.EXPECT().GetWorkByID("01HQWKV9999999999999999999").Return(nil, nil).Once()
 			},
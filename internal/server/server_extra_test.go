@@ -1,7 +1,7 @@
 // file: internal/server/server_extra_test.go
-// version: 1.4.0
+// version: 1.5.0
 // guid: 61a2d3c4-80ab-4f6f-8c39-15a2ac5b7f0c
-// last-edited: 2026-05-08
+// last-edited: 2026-10-16
 
 package server
 
@@ -193,9 +193,15 @@ func TestWorkEndpoints(t *testing.T) {
 	server.router.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 
+	// A referenced work is protected unless the caller opts into cascade.
 	req = httptest.NewRequest(http.MethodDelete, "/api/v1/works/"+created.ID, nil)
 	w = httptest.NewRecorder()
 	server.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/works/"+created.ID+"?cascade=true", nil)
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
 	require.Equal(t, http.StatusNoContent, w.Code)
 }
 
@@ -1,7 +1,7 @@
 // file: internal/server/server_helpers.go
-// version: 1.3.0
+// version: 1.5.0
 // guid: 8a40b808-2bf2-4a35-893c-ad5e3351dbae
-// last-edited: 2026-05-25
+// last-edited: 2026-10-16
 
 package server
 
@@ -21,6 +21,13 @@ func SetVersion(v string) {
 	appVersion = v
 }
 
+// SetBuildInfo records the commit and build date baked in via ldflags,
+// reported by GET /system/version.
+func SetBuildInfo(commit, date string) {
+	appCommit = commit
+	appBuildDate = date
+}
+
 // validateAbsolutePath rejects non-absolute paths and paths containing traversal
 // sequences. Delegates to pathvalidation.CleanAbsolutePath; kept for callers
 // that only need an error signal and don't use the cleaned return value.
@@ -72,6 +79,13 @@ func intVal(p *int) any {
 	return *p
 }
 
+func seriesSeqVal(p *database.SeriesSeq) any {
+	if p == nil {
+		return nil
+	}
+	return p.JSONValue()
+}
+
 func nonEmpty(s string) any {
 	if s == "" {
 		return nil
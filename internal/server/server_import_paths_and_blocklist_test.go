@@ -1,7 +1,7 @@
 // file: internal/server/server_import_paths_and_blocklist_test.go
-// version: 1.3.0
+// version: 1.4.0
 // guid: 2f4a6b8c-0d1e-2f3a-4b5c-6d7e8f9a0b1c
-// last-edited: 2026-04-30
+// last-edited: 2026-10-17
 
 package server
 
@@ -153,6 +153,8 @@ func TestBlockedHashes_CRUD(t *testing.T) {
 	hashes := []database.DoNotImport{{Hash: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", Reason: "test"}}
 	store.EXPECT().GetAllBlockedHashes().Return(hashes, nil)
 	store.EXPECT().RemoveBlockedHash(hashes[0].Hash).Return(nil)
+	// Unblocking also drops signatures blocked with the hash.
+	store.EXPECT().ScanPrefix("blocked_signature:").Return(nil, nil)
 
 	req = httptest.NewRequest(http.MethodGet, "/api/v1/blocked-hashes", nil)
 	w = httptest.NewRecorder()
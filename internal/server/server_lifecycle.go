@@ -1,7 +1,7 @@
 // file: internal/server/server_lifecycle.go
-// version: 1.33.0
+// version: 1.49.0
 // guid: 2f98675b-61e1-45a0-94e9-e7fdeb8f273e
-// last-edited: 2026-06-09
+// last-edited: 2026-10-17
 
 package server
 
@@ -10,6 +10,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"net"
 
 	"net/http"
 	"os"
@@ -37,10 +38,8 @@ import (
 	servermiddleware "github.com/falkcorp/audiobook-organizer/internal/server/middleware"
 	"github.com/falkcorp/audiobook-organizer/internal/serviceregistry"
 	"github.com/falkcorp/audiobook-organizer/internal/transcode"
-	"github.com/falkcorp/audiobook-organizer/internal/watcher"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/quic-go/quic-go/http3"
-	"golang.org/x/net/http2"
 )
 
 func (s *Server) resumeInterruptedOperations() {
@@ -265,14 +264,9 @@ func (s *Server) Start(cfg ServerConfig) error {
 	go s.warmAuthorsCache()
 	go s.warmSeriesCache()
 
-	s.httpServer = &http.Server{
-		Addr:              fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
-		Handler:           s.router,
-		ReadHeaderTimeout: cfg.ReadTimeout, // Only limit header read, not body (allows large uploads)
-		WriteTimeout:      cfg.WriteTimeout,
-		IdleTimeout:       cfg.IdleTimeout,
-		MaxHeaderBytes:    1 << 20, // 1MB
-	}
+	// Hosting under a sub-path (base_path) strips the prefix before gin
+	// routes the request; see servermiddleware.StripBasePath.
+	handler := servermiddleware.StripBasePath(config.AppConfig.BasePath, s.router)
 
 	if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
 		if _, err := os.Stat(cfg.TLSCertFile); err != nil {
@@ -289,24 +283,7 @@ func (s *Server) Start(cfg ServerConfig) error {
 	}
 
 	// Enable HTTP/2 if TLS is configured
-	if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
-		// Configure TLS with HTTP/2 (and optionally HTTP/3)
-		nextProtos := []string{"h2", "http/1.1"}
-		if cfg.HTTP3Port != "" {
-			// Add h3 to advertised protocols
-			nextProtos = append([]string{"h3"}, nextProtos...)
-		}
-		tlsConfig := &tls.Config{
-			MinVersion: tls.VersionTLS12,
-			NextProtos: nextProtos,
-		}
-		s.httpServer.TLSConfig = tlsConfig
-
-		// Explicitly configure HTTP/2
-		if err := http2.ConfigureServer(s.httpServer, &http2.Server{}); err != nil {
-			return fmt.Errorf("failed to configure HTTP/2: %w", err)
-		}
-
+	if cfg.tlsEnabled() {
 		// Add Alt-Svc header to advertise HTTP/3 if enabled
 		if cfg.HTTP3Port != "" {
 			s.router.Use(func(c *gin.Context) {
@@ -315,23 +292,27 @@ func (s *Server) Start(cfg ServerConfig) error {
 			})
 		}
 
-		// Start HTTPS server with HTTP/2
-		go func() {
-			protocols := "HTTPS/HTTP2"
-			if cfg.HTTP3Port != "" {
-				protocols = "HTTPS/HTTP2 (HTTP/3 on UDP port " + cfg.HTTP3Port + ")"
-			}
-			slog.Info("Starting server on", "protocols", protocols, "addr", s.httpServer.Addr)
-			if err := s.httpServer.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile); err != nil && err != http.ErrServerClosed {
-				slog.Error("Failed to start HTTPS server", "err", err)
-			}
-		}()
+		// Start HTTPS server with HTTP/2. The main listener can later move
+		// to another address (listen_host / listen_port, POST
+		// /system/restart); see listener.go.
+		protocols := "HTTPS/HTTP2"
+		if cfg.HTTP3Port != "" {
+			protocols = "HTTPS/HTTP2 (HTTP/3 on UDP port " + cfg.HTTP3Port + ")"
+		}
+		slog.Info("Starting server on", "protocols", protocols, "addr", net.JoinHostPort(cfg.Host, cfg.Port))
+		if err := s.listener.start(cfg, handler); err != nil {
+			slog.Error("Failed to start HTTPS server", "err", err)
+		}
 
 		// Start HTTP/3 server if configured
 		if cfg.HTTP3Port != "" {
+			tlsConfig := &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				NextProtos: []string{"h3", "h2", "http/1.1"},
+			}
 			s.http3Server = &http3.Server{
 				Addr:      fmt.Sprintf("%s:%s", cfg.Host, cfg.HTTP3Port),
-				Handler:   s.router,
+				Handler:   handler,
 				TLSConfig: tlsConfig,
 			}
 			go func() {
@@ -345,17 +326,19 @@ func (s *Server) Start(cfg ServerConfig) error {
 		// Start HTTP to HTTPS redirect server on port 80
 		go func() {
 			redirectAddr := fmt.Sprintf("%s:80", cfg.Host)
-			httpsPort := cfg.Port
-			if httpsPort == "80" {
-				httpsPort = "443" // Don't redirect 80->80
-			}
 
 			redirectHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Redirect to wherever the HTTPS listener is now.
+				current := s.listener.config()
+				httpsPort := current.Port
+				if httpsPort == "80" {
+					httpsPort = "443" // Don't redirect 80->80
+				}
 				// Build HTTPS URL
 				target := "https://" + r.Host
 				// Add port if not default HTTPS port
 				if httpsPort != "443" {
-					target = fmt.Sprintf("https://%s:%s", cfg.Host, httpsPort)
+					target = fmt.Sprintf("https://%s:%s", current.Host, httpsPort)
 				}
 				target += r.URL.RequestURI()
 
@@ -363,7 +346,7 @@ func (s *Server) Start(cfg ServerConfig) error {
 				http.Redirect(w, r, target, http.StatusMovedPermanently)
 			})
 
-			slog.Info("Starting HTTP->HTTPS redirect server on (redirects to )", "redirectAddr", redirectAddr, "httpsPort", httpsPort)
+			slog.Info("Starting HTTP->HTTPS redirect server on (redirects to )", "redirectAddr", redirectAddr, "httpsPort", cfg.Port)
 			httpRedirectServer := &http.Server{
 				Addr:    redirectAddr,
 				Handler: redirectHandler,
@@ -375,12 +358,10 @@ func (s *Server) Start(cfg ServerConfig) error {
 		}()
 	} else {
 		// Start HTTP/1.1 server without TLS
-		go func() {
-			slog.Info("Starting HTTP/1.1 server on (use --tls-cert and --tls-key for HTTP/2, add --http3-port for HTTP/3)", "addr", s.httpServer.Addr)
-			if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-				slog.Error("Failed to start server", "err", err)
-			}
-		}()
+		slog.Info("Starting HTTP/1.1 server on (use --tls-cert and --tls-key for HTTP/2, add --http3-port for HTTP/3)", "addr", net.JoinHostPort(cfg.Host, cfg.Port))
+		if err := s.listener.start(cfg, handler); err != nil {
+			slog.Error("Failed to start server", "err", err)
+		}
 	}
 
 	// Seed / refresh the multi-user roles (spec 3.7). Idempotent: if
@@ -532,6 +513,7 @@ func (s *Server) Start(cfg ServerConfig) error {
 			}
 			return s.batchPoller.Poll(ctx)
 		},
+		WaitForIdle: s.waitForStartupIdle,
 	})
 	s.scheduler.Start(shutdown, &backgroundWG)
 
@@ -569,7 +551,7 @@ func (s *Server) Start(cfg ServerConfig) error {
 						"folders":      folderCount,
 						"memory_alloc": alloc.Alloc,
 						"goroutines":   runtime.NumGoroutine(),
-						"timestamp":    time.Now().Unix(),
+						"timestamp":    time.Now().UTC().Format(time.RFC3339),
 					})
 				}
 			case <-shutdown:
@@ -587,58 +569,16 @@ func (s *Server) Start(cfg ServerConfig) error {
 		s.runCacheStatsSnapshotter(shutdown)
 	}()
 
-	// Start auto-scan file watchers if enabled. ONE watcher per enabled
-	// import path — previously only the first enabled path was watched,
-	// so users with multiple import locations had silent blind spots on
-	// every path after the first.
-	var fileWatchers []*watcher.Watcher
-	if config.AppConfig.AutoScanEnabled && s.Store() != nil {
-		importPaths, err := s.Store().GetAllImportPaths()
-		if err == nil && len(importPaths) > 0 {
-			var watchPaths []string
-			for _, ip := range importPaths {
-				if ip.Enabled {
-					watchPaths = append(watchPaths, ip.Path)
-				}
-			}
-			if len(watchPaths) > 0 {
-				debounce := 5 * time.Second
-				if config.AppConfig.AutoScanDebounceSeconds > 0 {
-					debounce = time.Duration(config.AppConfig.AutoScanDebounceSeconds) * time.Second
-				}
-				watchLog := logger.NewWithActivityLog("auto-scan", s.Store())
-				// The same callback is reused across watchers because
-				// each watcher invokes it with its own root path, so
-				// the scan target is correct per event.
-				cb := func(path string) {
-					watchLog.Info("Auto-scan triggered for: %s", path)
-					if s.hub != nil {
-						s.hub.Broadcast(&realtime.Event{
-							Type: "scan.auto_triggered",
-							Data: map[string]any{"path": path},
-						})
-					}
-					if s.scanService != nil && s.opRegistry != nil {
-						go func() {
-							scanPath := path
-							if _, enqErr := s.opRegistry.EnqueueOp(context.Background(), "library.scan", libraryScanParams{FolderPath: &scanPath}); enqErr != nil {
-								watchLog.Error("Auto-scan: failed to enqueue: %v", enqErr)
-							}
-						}()
-					}
-				}
-				for _, wp := range watchPaths {
-					fw := watcher.New(cb, debounce)
-					if startErr := fw.Start(wp); startErr != nil {
-						watchLog.Warn("Failed to start file watcher for %s: %v", wp, startErr)
-						continue
-					}
-					fileWatchers = append(fileWatchers, fw)
-					watchLog.Info("Auto-scan file watcher started for %s", wp)
-				}
-			}
-		}
-	}
+	// Per import path and per library Prometheus gauges.
+	backgroundWG.Add(1)
+	go func() {
+		defer backgroundWG.Done()
+		s.runPathMetricsUpdater(shutdown)
+	}()
+
+	// Watch import paths for new audio files (auto_scan_enabled, or the
+	// path's own watch flag) and scan what changed.
+	s.startImportWatchers(shutdown, &backgroundWG)
 
 	// Periodic cleanup of expired/revoked auth sessions.
 	if s.Store() != nil {
@@ -711,7 +651,7 @@ func (s *Server) Start(cfg ServerConfig) error {
 			slog.Warn("HTTP/3 server close error", "err", err)
 		}
 	}
-	if err := s.httpServer.Shutdown(ctx); err != nil {
+	if err := s.listener.shutdown(ctx); err != nil {
 		slog.Warn("HTTP server forced shutdown", "err", err)
 	}
 
@@ -807,14 +747,6 @@ func (s *Server) Start(cfg ServerConfig) error {
 		}
 	}
 
-	// Stop every file watcher (one per import path).
-	for _, fw := range fileWatchers {
-		fw.Stop()
-	}
-	if len(fileWatchers) > 0 {
-		slog.Info("File watchers stopped ()", "fileWatchers_count", len(fileWatchers))
-	}
-
 	// Close embedding store
 	if s.embeddingStore != nil {
 		if err := s.embeddingStore.Close(); err != nil {
@@ -866,15 +798,17 @@ func (s *Server) setupRoutes() {
 	// This matches common practice (e.g. internal metrics endpoints). If this
 	// server is ever exposed to an untrusted network, restrict /metrics at the
 	// network layer (firewall / separate internal port) rather than app auth.
-	s.router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	s.routes = newRouteRegistry(s.perm)
+	root := s.routes.root(&s.router.RouterGroup)
+	root.GET("/metrics", noPermission, gin.WrapH(promhttp.Handler()))
 
 	// Health check endpoint (both paths for compatibility). Registered here on
-	// s.router BEFORE the /api/* redirect middleware (below) so they keep
+	// s.router BEFORE the /api/* versioning middleware (below) so they keep
 	// bypassing it; they delegate to the migrated system handler, which is wired
 	// later in wireHandlers (always before any request is served).
-	s.router.GET("/health", func(c *gin.Context) { s.systemHandler.HealthCheck(c) })
-	s.router.GET("/api/health", func(c *gin.Context) { s.systemHandler.HealthCheck(c) })
-	s.router.GET("/api/v1/health", func(c *gin.Context) { s.systemHandler.HealthCheck(c) })
+	root.GET("/health", noPermission, func(c *gin.Context) { s.systemHandler.HealthCheck(c) })
+	root.GET("/api/health", noPermission, func(c *gin.Context) { s.systemHandler.HealthCheck(c) })
+	root.GET("/api/v1/health", noPermission, func(c *gin.Context) { s.systemHandler.HealthCheck(c) })
 
 	// Real-time events (SSE). Same pre-middleware-ordering rationale as /health.
 	// Gated behind auth (pen-test finding MED-2): the stream carries library
@@ -889,30 +823,18 @@ func (s *Server) setupRoutes() {
 	if config.AppConfig.EnableAuth {
 		eventsAuth = servermiddleware.RequireAuth(s.Store())
 	}
-	s.router.GET("/api/events", eventsAuth, func(c *gin.Context) { s.systemHandler.HandleEvents(c) })
+	root.Group("").Require(RouteAuthAuthenticated, eventsAuth).
+		GET("/api/events", noPermission, func(c *gin.Context) { s.systemHandler.HandleEvents(c) })
 
 	// Public temp-login consumer at the root so URLs are short and
 	// browser-friendly. Validates the token, deletes it (single-use),
 	// creates a 24h session, sets the cookie, redirects to the SPA.
-	s.router.GET("/auth/temp-login", s.consumeTempLoginToken)
-
-	// Redirect /api/* to /api/v1/* for v1 compatibility
-	s.router.Use(func(c *gin.Context) {
-		path := c.Request.URL.Path
-		// If path starts with /api/ but not /api/v1/ and not /api/health and not /api/events
-		if strings.HasPrefix(path, "/api/") &&
-			!strings.HasPrefix(path, "/api/v1/") &&
-			!strings.HasPrefix(path, "/api/health") &&
-			!strings.HasPrefix(path, "/api/events") &&
-			!strings.HasPrefix(path, "/api/metrics") {
-			// Redirect to /api/v1/
-			newPath := strings.Replace(path, "/api/", "/api/v1/", 1)
-			c.Redirect(http.StatusMovedPermanently, newPath)
-			c.Abort()
-			return
-		}
-		c.Next()
-	})
+	root.GET("/auth/temp-login", noPermission, s.consumeTempLoginToken)
+
+	// Unversioned /api/* and /api/v2/* are served off the /api/v1 routes
+	// in-process (see servermiddleware.APIVersions); /api/health, /api/events
+	// and /api/metrics are registered above and pass through.
+	s.router.Use(servermiddleware.APIVersions(s.router))
 
 	jsonLimitBytes := int64(config.AppConfig.JSONBodyLimitMB) * 1024 * 1024
 	uploadLimitBytes := int64(config.AppConfig.UploadBodyLimitMB) * 1024 * 1024
@@ -927,11 +849,17 @@ func (s *Server) setupRoutes() {
 		apiRateLimiter = servermiddleware.NewIPRateLimiter(rpm, burst).Middleware()
 	}
 	bodyLimitMiddleware := servermiddleware.MaxRequestBodySize(jsonLimitBytes, uploadLimitBytes)
+	requestTimeoutMiddleware := servermiddleware.RequestTimeout(
+		time.Duration(config.AppConfig.RequestTimeoutReadSeconds)*time.Second,
+		time.Duration(config.AppConfig.RequestTimeoutWriteSeconds)*time.Second,
+	)
 	authMiddleware := gin.HandlerFunc(func(c *gin.Context) {
 		c.Next()
 	})
+	contentFilterMiddleware := authMiddleware
 	if config.AppConfig.EnableAuth {
 		authMiddleware = servermiddleware.RequireAuth(s.Store())
+		contentFilterMiddleware = servermiddleware.ResolveContentFilter(s.Store())
 	} else {
 		slog.Warn("authentication is disabled (enable_authfalse) — do not expose this server to untrusted networks")
 	}
@@ -939,12 +867,12 @@ func (s *Server) setupRoutes() {
 		slog.Warn("rate limiting is disabled (enable_rate_limitfalse) — the API is vulnerable to abuse. Set enable_rate_limit true in config.yaml for production deployments")
 	}
 
-	// API routes (auth + rate limits + request-size limits)
-	api := s.router.Group("/api/v1")
-	api.Use(apiRateLimiter, bodyLimitMiddleware)
+	// API routes (auth + rate limits + request-size limits + per-class
+	// request timeouts + ETag/304 on JSON GETs)
+	api := root.Group(apiBasePath)
+	api.Use(s.apiIdle.middleware(), apiRateLimiter, bodyLimitMiddleware, requestTimeoutMiddleware, servermiddleware.ConditionalGET())
 	{
-		protected := api.Group("")
-		protected.Use(authMiddleware)
+		protected := api.Group("").Require(RouteAuthAuthenticated, authMiddleware, contentFilterMiddleware)
 
 		s.wireHandlers(api, authMiddleware, protected)
 		{
@@ -958,10 +886,12 @@ func (s *Server) setupRoutes() {
 			// handlers/audiobooks sub-package (wire_handlers.go). The sibling
 			// /audiobooks/:id/* routes below stay here because they belong to
 			// OTHER domains (quarantine, rating, sample, write-back).
-			protected.GET("/audiobooks/quarantined", s.perm(auth.PermLibraryView), s.listQuarantinedBooks)
-			protected.POST("/audiobooks/:id/quarantine", s.perm(auth.PermSettingsManage), s.quarantineBook)
-			protected.DELETE("/audiobooks/:id/quarantine", s.perm(auth.PermSettingsManage), s.unquarantineBook)
-			protected.GET("/audiobooks/:id/sample", s.perm(auth.PermLibraryView), s.handleAudioSample)
+			protected.GET("/audiobooks/quarantined", auth.PermLibraryView, s.listQuarantinedBooks)
+			protected.GET("/audiobooks/pending-purge", auth.PermLibraryView, s.listPendingPurges)
+			protected.POST("/audiobooks/:id/quarantine", auth.PermSettingsManage, s.quarantineBook)
+			protected.DELETE("/audiobooks/:id/quarantine", auth.PermSettingsManage, s.unquarantineBook)
+			protected.POST("/audiobooks/:id/quarantine/override", auth.PermSettingsManage, s.overrideSizeAnomaly)
+			protected.GET("/audiobooks/:id/sample", auth.PermLibraryView, s.handleAudioSample)
 
 			// Author, narrator, and series routes.
 			// NOTE: /authors, /authors/count, /authors/merge,
@@ -992,7 +922,7 @@ func (s *Server) setupRoutes() {
 			// wireHandlers (wire_handlers.go). The survivor below stays here
 			// because it lives in fingerprint_rescan.go, not the migrated
 			// dedup_handlers.go.
-			protected.POST("/dedup/fingerprint-rescan", s.perm(auth.PermScanTrigger), s.triggerFingerprintRescan)
+			protected.POST("/dedup/fingerprint-rescan", auth.PermScanTrigger, s.triggerFingerprintRescan)
 
 			// Operation routes
 			//
@@ -1010,31 +940,31 @@ func (s *Server) setupRoutes() {
 			//
 			// UOS-14: /operations/active and /operations/recent are removed — return 410 Gone.
 			// Use GET /operations/timeline instead.
-			protected.GET("/operations/active", s.perm(auth.PermLibraryView), func(c *gin.Context) {
+			protected.GET("/operations/active", auth.PermLibraryView, func(c *gin.Context) {
 				c.JSON(http.StatusGone, gin.H{"error": "gone", "message": "this endpoint has been removed; use GET /api/v1/operations/timeline instead"})
 			})
-			protected.GET("/operations/recent", s.perm(auth.PermLibraryView), func(c *gin.Context) {
+			protected.GET("/operations/recent", auth.PermLibraryView, func(c *gin.Context) {
 				c.JSON(http.StatusGone, gin.H{"error": "gone", "message": "this endpoint has been removed; use GET /api/v1/operations/timeline instead"})
 			})
 
 			// UOS-06 operations v2 routes (timeline, events, v2/:id, op-defs)
 			// are registered in wireHandlers via OperationsV2Handler.
 
-			protected.GET("/file-ops/pending", s.perm(auth.PermLibraryView), s.handleListPendingFileOps)
-			protected.GET("/operations/:id/results", s.perm(auth.PermLibraryView), s.handleGetOperationResults)
-			protected.GET("/operations/reconcile/preview", s.perm(auth.PermLibraryView), s.reconcilePreview)
-			protected.POST("/operations/reconcile", s.perm(auth.PermScanTrigger), s.startReconcile)
-			protected.POST("/operations/reconcile/scan", s.perm(auth.PermScanTrigger), s.startReconcileScan)
-			protected.GET("/operations/reconcile/scan/latest", s.perm(auth.PermLibraryView), s.latestReconcileScan)
-			protected.POST("/operations/itunes-path-reconcile", s.perm(auth.PermScanTrigger), s.itunesSvcGuard(s.handleITunesPathReconcile))
-			protected.POST("/operations/itunes-path-repair", s.perm(auth.PermScanTrigger), s.itunesSvcGuard(s.handleITunesPathRepair))
-			protected.POST("/operations/cleanup-version-groups", s.perm(auth.PermSettingsManage), s.cleanupDuplicateVersionGroupsHandler)
-			protected.POST("/operations/mark-broken-segments", s.perm(auth.PermSettingsManage), s.markBrokenSegmentBooksHandler)
-			protected.POST("/operations/merge-novg-duplicates", s.perm(auth.PermSettingsManage), s.mergeNoVGDuplicatesHandler)
-			protected.POST("/operations/assign-orphan-vgs", s.perm(auth.PermSettingsManage), s.assignOrphanVGsHandler)
+			protected.GET("/file-ops/pending", auth.PermLibraryView, s.handleListPendingFileOps)
+			protected.GET("/operations/:id/results", auth.PermLibraryView, s.handleGetOperationResults)
+			protected.GET("/operations/reconcile/preview", auth.PermLibraryView, s.reconcilePreview)
+			protected.POST("/operations/reconcile", auth.PermScanTrigger, s.startReconcile)
+			protected.POST("/operations/reconcile/scan", auth.PermScanTrigger, s.startReconcileScan)
+			protected.GET("/operations/reconcile/scan/latest", auth.PermLibraryView, s.latestReconcileScan)
+			protected.POST("/operations/itunes-path-reconcile", auth.PermScanTrigger, s.itunesSvcGuard(s.handleITunesPathReconcile))
+			protected.POST("/operations/itunes-path-repair", auth.PermScanTrigger, s.itunesSvcGuard(s.handleITunesPathRepair))
+			protected.POST("/operations/cleanup-version-groups", auth.PermSettingsManage, s.cleanupDuplicateVersionGroupsHandler)
+			protected.POST("/operations/mark-broken-segments", auth.PermSettingsManage, s.markBrokenSegmentBooksHandler)
+			protected.POST("/operations/merge-novg-duplicates", auth.PermSettingsManage, s.mergeNoVGDuplicatesHandler)
+			protected.POST("/operations/assign-orphan-vgs", auth.PermSettingsManage, s.assignOrphanVGsHandler)
 
 			// Import routes
-			protected.POST("/import/collision-preview", s.perm(auth.PermLibraryView), s.handleImportCollisionPreview)
+			protected.POST("/import/collision-preview", auth.PermLibraryView, s.handleImportCollisionPreview)
 
 			// iTunes import routes
 			itunesGroup := protected.Group("/itunes")
@@ -1060,24 +990,24 @@ func (s *Server) setupRoutes() {
 				// applies all adds/removes/updates in one atomic
 				// safeWriteITL call. Supports dry_run=true to
 				// preview without applying. Backlog 7.9.
-				itunesGroup.POST("/rebuild", s.perm(auth.PermLibraryEditMetadata), s.rebuildITLHandler)
+				itunesGroup.POST("/rebuild", auth.PermLibraryEditMetadata, s.rebuildITLHandler)
 				// Full rebuild: strip all tracks, re-insert all DB books (7.9 nuclear path).
-				itunesGroup.POST("/rebuild-full", s.perm(auth.PermLibraryEditMetadata), s.rebuildITLFullHandler)
+				itunesGroup.POST("/rebuild-full", auth.PermLibraryEditMetadata, s.rebuildITLFullHandler)
 				// Partial export: build ITL containing only specified book IDs (6.4 partial).
-				itunesGroup.POST("/export-partial", s.perm(auth.PermIntegrationsManage), s.exportITLPartialHandler)
+				itunesGroup.POST("/export-partial", auth.PermIntegrationsManage, s.exportITLPartialHandler)
 
 				// ITL file transfer (6.4)
-				itunesGroup.GET("/library/download", s.perm(auth.PermIntegrationsManage), s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleDownload(c) }))
-				itunesGroup.POST("/library/upload", s.perm(auth.PermIntegrationsManage), s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleUpload(c) }))
-				itunesGroup.GET("/library/backups", s.perm(auth.PermIntegrationsManage), s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleBackupList(c) }))
-				itunesGroup.POST("/library/restore", s.perm(auth.PermIntegrationsManage), s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleRestore(c) }))
+				itunesGroup.GET("/library/download", auth.PermIntegrationsManage, s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleDownload(c) }))
+				itunesGroup.POST("/library/upload", auth.PermIntegrationsManage, s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleUpload(c) }))
+				itunesGroup.GET("/library/backups", auth.PermIntegrationsManage, s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleBackupList(c) }))
+				itunesGroup.POST("/library/restore", auth.PermIntegrationsManage, s.itunesSvcGuard(func(c *gin.Context) { s.itunesSvc.Transfer.HandleRestore(c) }))
 			}
 
 			// Cover art
-			protected.GET("/covers/proxy", s.perm(auth.PermLibraryView), s.handleCoverProxy)
-			protected.GET("/covers/local/:filename", s.perm(auth.PermLibraryView), s.handleLocalCover)
-			protected.GET("/audiobooks/:id/cover-history", s.perm(auth.PermLibraryView), s.handleListCoverHistory)
-			protected.POST("/audiobooks/:id/cover-history/restore", s.perm(auth.PermLibraryEditMetadata), s.handleRestoreCover)
+			protected.GET("/covers/proxy", auth.PermLibraryView, s.handleCoverProxy)
+			protected.GET("/covers/local/:filename", auth.PermLibraryView, s.handleLocalCover)
+			protected.GET("/audiobooks/:id/cover-history", auth.PermLibraryView, s.handleListCoverHistory)
+			protected.POST("/audiobooks/:id/cover-history/restore", auth.PermLibraryEditMetadata, s.handleRestoreCover)
 
 			// Unified task/scheduler routes and the maintenance-window routes
 			// (GET /tasks, POST /tasks/:name/run, PUT /tasks/:name, POST
@@ -1086,25 +1016,25 @@ func (s *Server) setupRoutes() {
 			// and are now registered in wireHandlers (wire_handlers.go).
 
 			// Result-getter GETs (not job triggers — these poll async results)
-			protected.GET("/maintenance/scan-composer-tags/:id", s.perm(auth.PermSettingsManage), s.handleGetComposerScanResults)
-			protected.GET("/maintenance/repair-missing-files/:id", s.perm(auth.PermSettingsManage), s.handleGetMissingFileRepairResults)
+			protected.GET("/maintenance/scan-composer-tags/:id", auth.PermSettingsManage, s.handleGetComposerScanResults)
+			protected.GET("/maintenance/repair-missing-files/:id", auth.PermSettingsManage, s.handleGetMissingFileRepairResults)
 			// Hash stats endpoints
-			protected.GET("/maintenance/book-file-hash-stats", s.perm(auth.PermSettingsManage), s.handleGetBookFileHashStats)
-			protected.GET("/maintenance/book-metadata-hash-stats", s.perm(auth.PermSettingsManage), s.handleGetBookMetadataHashStats)
-			protected.GET("/maintenance/acoustid-stats", s.perm(auth.PermSettingsManage), s.handleGetAcoustIDStats)
+			protected.GET("/maintenance/book-file-hash-stats", auth.PermSettingsManage, s.handleGetBookFileHashStats)
+			protected.GET("/maintenance/book-metadata-hash-stats", auth.PermSettingsManage, s.handleGetBookMetadataHashStats)
+			protected.GET("/maintenance/acoustid-stats", auth.PermSettingsManage, s.handleGetAcoustIDStats)
 			// Unified maintenance job dispatcher
-			protected.GET("/maintenance/jobs", s.perm(auth.PermSettingsManage), s.listMaintenanceJobs)
+			protected.GET("/maintenance/jobs", auth.PermSettingsManage, s.listMaintenanceJobs)
 			// Route-level permission guard (pen-test finding MED-3). The handler
 			// also checks permissions per-job, but only when EnableAuth is true;
 			// gating at the route is defense-in-depth and protects against a
 			// future job that forgets to implement PermissionAware.
-			protected.POST("/maintenance/jobs/:job_id", s.perm(auth.PermSettingsManage), s.runMaintenanceJob)
+			protected.POST("/maintenance/jobs/:job_id", auth.PermSettingsManage, s.runMaintenanceJob)
 
 			// Admin-only destructive endpoints
-			adminOnly := protected.Group("")
-			adminOnly.Use(servermiddleware.RequireAdmin())
+			adminOnly := protected.Group("").Require(RouteAuthAdmin, servermiddleware.RequireAdmin())
 			{
-				adminOnly.POST("/maintenance/wipe", s.handleWipe)
+				adminOnly.POST("/maintenance/wipe", noPermission, s.handleWipe)
+				adminOnly.POST("/system/library-lock/takeover", noPermission, s.handleLibraryLockTakeover)
 			}
 
 			// Policy, system, config, dashboard, and backup routes migrated to the
@@ -1113,31 +1043,31 @@ func (s *Server) setupRoutes() {
 			// Enhanced metadata routes
 			// batch-update / validate / export / import / search / fields /
 			// bulk-fetch migrated to the handlers/metadata sub-package (wireHandlers).
-			protected.POST("/metadata/batch-fetch-candidates", s.perm(auth.PermLibraryEditMetadata), s.handleBatchFetchCandidates)
-			protected.GET("/metadata/recent-fetches", s.perm(auth.PermLibraryView), s.handleGetLatestMetadataFetch)
+			protected.POST("/metadata/batch-fetch-candidates", auth.PermLibraryEditMetadata, s.handleBatchFetchCandidates)
+			protected.GET("/metadata/recent-fetches", auth.PermLibraryView, s.handleGetLatestMetadataFetch)
 			// Unified metadata-results listing — preferred over /metadata/pending-review.
 			// Returns books with their latest fetch status + by_status counts; supports
 			// repeatable ?status= filtering for the Library page toggles + Resume Review.
-			protected.GET("/library/metadata-results", s.perm(auth.PermLibraryView), s.handleListMetadataResults)
+			protected.GET("/library/metadata-results", auth.PermLibraryView, s.handleListMetadataResults)
 			// /library/quick-queries migrated to the handlers/system sub-package
 			// (wireHandlers).
-			protected.POST("/metadata/batch-apply-candidates", s.perm(auth.PermLibraryEditMetadata), s.handleBatchApplyCandidates)
-			protected.POST("/metadata/batch-reject-candidates", s.perm(auth.PermLibraryEditMetadata), s.handleRejectCandidates)
-			protected.POST("/metadata/batch-unreject-candidates", s.perm(auth.PermLibraryEditMetadata), s.handleUnrejectCandidates)
+			protected.POST("/metadata/batch-apply-candidates", auth.PermLibraryEditMetadata, s.handleBatchApplyCandidates)
+			protected.POST("/metadata/batch-reject-candidates", auth.PermLibraryEditMetadata, s.handleRejectCandidates)
+			protected.POST("/metadata/batch-unreject-candidates", auth.PermLibraryEditMetadata, s.handleUnrejectCandidates)
 			// fetch-metadata / search-metadata / apply-metadata / mark-no-match /
 			// revert-metadata / metadata-rejections / cow-versions(+prune) /
 			// write-back migrated to the handlers/metadata sub-package (wireHandlers).
-			protected.GET("/audiobooks/:id/similar", s.perm(auth.PermLibraryView), s.handleSimilarBooks)
+			protected.GET("/audiobooks/:id/similar", auth.PermLibraryView, s.handleSimilarBooks)
 
 			// AI parsing, scan-pipeline, metadata-source-test, and parse-with-ai
 			// routes migrated to AIHandler (wire_handlers.go).
 
 			// Open Library dump routes
-			protected.GET("/openlibrary/status", s.perm(auth.PermIntegrationsManage), s.getOLStatus)
-			protected.POST("/openlibrary/download", s.perm(auth.PermIntegrationsManage), s.startOLDownload)
-			protected.POST("/openlibrary/import", s.perm(auth.PermIntegrationsManage), s.startOLImport)
-			protected.POST("/openlibrary/upload", s.perm(auth.PermIntegrationsManage), s.uploadOLDump)
-			protected.DELETE("/openlibrary/data", s.perm(auth.PermIntegrationsManage), s.deleteOLData)
+			protected.GET("/openlibrary/status", auth.PermIntegrationsManage, s.getOLStatus)
+			protected.POST("/openlibrary/download", auth.PermIntegrationsManage, s.startOLDownload)
+			protected.POST("/openlibrary/import", auth.PermIntegrationsManage, s.startOLImport)
+			protected.POST("/openlibrary/upload", auth.PermIntegrationsManage, s.uploadOLDump)
+			protected.DELETE("/openlibrary/data", auth.PermIntegrationsManage, s.deleteOLData)
 
 			// Work routes (logical title-level grouping) and the singular /work
 			// compatibility routes migrated to the handlers/entities sub-package
@@ -1147,9 +1077,12 @@ func (s *Server) setupRoutes() {
 			// (VersionsHandler).
 
 			// Update routes
-			protected.GET("/update/status", s.perm(auth.PermSettingsManage), s.getUpdateStatus)
-			protected.POST("/update/check", s.perm(auth.PermSettingsManage), s.checkForUpdate)
-			protected.POST("/update/apply", s.perm(auth.PermSettingsManage), s.applyUpdate)
+			protected.GET("/update/status", auth.PermSettingsManage, s.getUpdateStatus)
+			protected.POST("/update/check", auth.PermSettingsManage, s.checkForUpdate)
+			protected.POST("/update/apply", auth.PermSettingsManage, s.applyUpdate)
+			protected.GET("/system/version", noPermission, s.getVersion)
+			protected.GET("/routes", noPermission, s.handleListRoutes).
+				Describe("List API routes with auth level and required permission (?format=openapi for generated paths)")
 
 			// Blocked hashes management routes migrated to the handlers/system
 			// sub-package (wireHandlers).
@@ -1157,7 +1090,7 @@ func (s *Server) setupRoutes() {
 			// Diagnostics routes (db-health/export/submit-ai/ai-results/
 			// apply-suggestions migrated to DiagnosticsHandler in
 			// wire_handlers.go; fingerprint-failures stays here for now).
-			protected.GET("/diagnostics/fingerprint-failures", s.perm(auth.PermSettingsManage), s.getFingerprintFailures)
+			protected.GET("/diagnostics/fingerprint-failures", auth.PermSettingsManage, s.getFingerprintFailures)
 
 			// AI Jobs observability route migrated to AIHandler (wire_handlers.go)
 
@@ -1166,6 +1099,7 @@ func (s *Server) setupRoutes() {
 			s.registerVersionLifecycleRoutes(protected)
 			s.registerEntityTagRoutes(protected)
 			s.registerDelugeRoutes(protected)
+			s.registerEmailRoutes(protected)
 			s.setupBenchRoutes(protected)
 		}
 	}
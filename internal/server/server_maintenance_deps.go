@@ -1,7 +1,7 @@
 // file: internal/server/server_maintenance_deps.go
-// version: 1.1.0
+// version: 1.2.0
 // guid: b4c5d6e7-f8a9-0123-7890-345678901234
-// last-edited: 2026-05-19
+// last-edited: 2026-10-16
 
 // This file implements the maintenance.ServerDeps interface on *Server, giving
 // the maintenance plugin access to server internals without creating an import
@@ -42,6 +42,7 @@ func (s *Server) RunBulkWriteBack(ctx context.Context, opID string, bookIDs []st
 
 func (s *Server) RunAutoPurgeSoftDeleted(opID string) {
 	s.runAutoPurgeSoftDeleted(opID)
+	s.invalidateSizeCaches()
 }
 
 func (s *Server) ExecuteSeriesPrune(ctx context.Context, store database.Store, progress operations.ProgressReporter, opID string) error {
@@ -77,11 +78,19 @@ func (s *Server) CleanupOrphanedTempFiles(rootDir string, opID string) int {
 }
 
 func (s *Server) CleanupTrashedVersions() int {
-	return CleanupTrashedVersions(s.Store())
+	n := CleanupTrashedVersions(s.Store())
+	if n > 0 {
+		s.invalidateSizeCaches()
+	}
+	return n
 }
 
 func (s *Server) SweepArchivedBooks() int {
-	return sweep.SweepArchivedBooks(s.Store())
+	n := sweep.SweepArchivedBooks(s.Store())
+	if n > 0 {
+		s.invalidateSizeCaches()
+	}
+	return n
 }
 
 // ---- optional component accessors ----
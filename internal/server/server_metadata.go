@@ -1,7 +1,7 @@
 // file: internal/server/server_metadata.go
-// version: 1.2.0
+// version: 1.4.0
 // guid: 588350bc-83db-47ed-9590-2b6513aadcda
-// last-edited: 2026-05-13
+// last-edited: 2026-10-17
 
 package server
 
@@ -268,7 +268,14 @@ func (s *Server) batchFetchBookAuthorsAndNarrators(bookIDs []string) (map[string
 // author and narrator data. Convenience wrapper for single-book endpoints.
 func (s *Server) enrichBookForResponseSingle(book *database.Book) enrichedBookResponse {
 	bookAuthorsMap, authorsByID, bookNarratorsMap, narratorsByID := s.batchFetchBookAuthorsAndNarrators([]string{book.ID})
-	return s.enrichBookForResponse(book, bookAuthorsMap, authorsByID, bookNarratorsMap, narratorsByID)
+	resp := s.enrichBookForResponse(book, bookAuthorsMap, authorsByID, bookNarratorsMap, narratorsByID)
+	if store := s.Store(); store != nil {
+		if chapters, err := database.GetBookChapters(store, book.ID); err == nil {
+			n := len(chapters)
+			resp.ChapterCount = &n
+		}
+	}
+	return resp
 }
 
 // enrichBookForResponse resolves author, series, and narrator names from join
@@ -420,7 +427,7 @@ func buildMetadataProvenance(book *database.Book, state map[string]metafetch.Met
 	if meta.SeriesIndex > 0 {
 		seriesIdx = meta.SeriesIndex
 	}
-	addEntry("series_index", seriesIdx, intVal(book.SeriesSequence))
+	addEntry("series_index", seriesIdx, seriesSeqVal(book.SeriesSequence))
 	addEntry("print_year", nonEmpty(meta.PrintYear), intVal(book.PrintYear))
 	addEntry("edition", nonEmpty(meta.Edition), stringVal(book.Edition))
 	addEntry("description", nonEmpty(meta.Comments), stringVal(book.Description))
@@ -1,7 +1,7 @@
 // file: internal/server/server_middleware.go
-// version: 1.2.1
+// version: 1.3.0
 // guid: 6a093405-441a-4c14-a9c5-46326ea767c1
-// last-edited: 2026-05-19
+// last-edited: 2026-10-16
 
 package server
 
@@ -10,6 +10,7 @@ import (
 	"log/slog"
 
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 
@@ -37,6 +38,13 @@ func corsMiddleware() gin.HandlerFunc {
 					allowedOrigin = origin
 				}
 			}
+
+			// The public origin from base_url, for proxies that rewrite Host.
+			if u, err := url.Parse(config.AppConfig.BaseURL); err == nil && u.Host != "" {
+				if origin == u.Scheme+"://"+u.Host {
+					allowedOrigin = origin
+				}
+			}
 		}
 
 		if allowedOrigin != "" {
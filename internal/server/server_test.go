@@ -1,7 +1,7 @@
 // file: internal/server/server_test.go
-// version: 2.0.0
+// version: 2.2.0
 // guid: b2c3d4e5-f6a7-8901-bcde-234567890abc
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 // NOTE(fable5 T022): setupTestServer ported from NewSQLiteStore to NewPebbleStore.
 
@@ -152,6 +152,34 @@ func TestHealthCheck(t *testing.T) {
 	}
 }
 
+func TestGetVersion(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	origVersion, origCommit, origDate := appVersion, appCommit, appBuildDate
+	t.Cleanup(func() {
+		SetVersion(origVersion)
+		SetBuildInfo(origCommit, origDate)
+	})
+	SetVersion("1.4.2")
+	SetBuildInfo("abc1234", "2026-10-01T12:00:00Z")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/system/version", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var wrapper struct {
+		Data map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &wrapper))
+	assert.Equal(t, "1.4.2", wrapper.Data["version"])
+	assert.Equal(t, "abc1234", wrapper.Data["commit"])
+	assert.Equal(t, "2026-10-01T12:00:00Z", wrapper.Data["build_date"])
+	assert.NotEmpty(t, wrapper.Data["go_version"])
+	assert.NotContains(t, wrapper.Data, "update", "no check has run yet")
+}
+
 // TestListAudiobooks tests the list audiobooks endpoint
 func TestListAudiobooks(t *testing.T) {
 	server, cleanup := setupTestServer(t)
@@ -1560,6 +1588,35 @@ func TestSoftDeleteAudiobook(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "soft deleted")
 }
 
+// TestDeleteAudiobookBlocksSignature checks block_signature=true blocks
+// the book's title, author and duration for later scans.
+func TestDeleteAudiobookBlocksSignature(t *testing.T) {
+	server, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	store := database.GetGlobalStore()
+	duration := 9 * 3600
+	book, err := store.CreateBook(&database.Book{
+		Title:    "The Blocked Book",
+		FilePath: filepath.Join(t.TempDir(), "blocked.m4b"),
+		Duration: &duration,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodDelete,
+		fmt.Sprintf("/api/v1/audiobooks/%s?soft_delete=true&block_signature=true", book.ID), nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"signature_blocked":true`)
+
+	kv, ok := store.(database.RawKVStore)
+	require.True(t, ok)
+	match, err := database.MatchBlockedSignature(kv, "Blocked Book", "", duration+60)
+	require.NoError(t, err)
+	assert.NotNil(t, match)
+}
+
 // TestGetOperationLogs tests the operation logs endpoint
 func TestGetOperationLogs(t *testing.T) {
 	server, cleanup := setupTestServer(t)
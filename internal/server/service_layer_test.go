@@ -1,7 +1,7 @@
 // file: internal/server/service_layer_test.go
-// version: 1.9.0
+// version: 1.10.0
 // guid: 8b9c0d1e-2f3a-4b5c-6d7e-8f9a0b1c2d3e
-// last-edited: 2026-05-15
+// last-edited: 2026-10-17
 
 package server
 
@@ -1320,7 +1320,7 @@ func TestAudiobookService_CountAudiobooks_Error(t *testing.T) {
 	mockStore := mocks.NewMockStore(t)
 	svc := NewAudiobookService(mockStore)
 
-	mockStore.EXPECT().CountBooks().Return(0, errors.New("database error"))
+	mockStore.EXPECT().CountBooksContext(mock.Anything).Return(0, errors.New("database error"))
 
 	count, err := svc.CountAudiobooks(context.Background())
 	if err == nil {
@@ -0,0 +1,173 @@
+// file: internal/server/startup_idle.go
+// version: 1.0.0
+// guid: 291dc890-19ab-40ca-a819-7f74bf348d2c
+// last-edited: 2026-10-16
+//
+// Idle detection for the startup scan. The scan waits until the API has
+// been quiet and the disks mostly idle for startup_scan_idle_seconds, and
+// once running pauses between books while an operation a user started
+// through the API is running.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+const (
+	// idlePollInterval is how often the idle wait samples API and disk
+	// activity, and how often a yielding scan rechecks.
+	idlePollInterval = 5 * time.Second
+	// diskBusyThreshold is the busiest device's utilisation (fraction of
+	// wall time with IO in flight) above which the disks count as busy.
+	diskBusyThreshold = 0.25
+)
+
+// diskStatsPath is read for per-device IO time; absent off Linux, in which
+// case only API traffic decides idleness.
+var diskStatsPath = "/proc/diskstats"
+
+// apiIdleTracker records when the API last served a request.
+type apiIdleTracker struct {
+	last atomic.Int64 // unix nanos
+}
+
+// middleware stamps each API request and marks its context interactive,
+// so operations it enqueues count as user-started.
+func (t *apiIdleTracker) middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t.last.Store(time.Now().UnixNano())
+		c.Request = c.Request.WithContext(opsregistry.WithInteractive(c.Request.Context()))
+		c.Next()
+	}
+}
+
+func (t *apiIdleTracker) lastRequest() time.Time {
+	n := t.last.Load()
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+// waitForStartupIdle blocks until the API has been quiet and the disks
+// idle for startup_scan_idle_seconds, or startup_scan_max_delay_minutes
+// has passed. It returns false if shutdown closes first.
+func (s *Server) waitForStartupIdle(shutdown <-chan struct{}) bool {
+	quietPeriod := time.Duration(config.AppConfig.StartupScanIdleSeconds) * time.Second
+	if quietPeriod <= 0 {
+		return true
+	}
+	var deadline time.Time
+	if m := config.AppConfig.StartupScanMaxDelayMinutes; m > 0 {
+		deadline = time.Now().Add(time.Duration(m) * time.Minute)
+	}
+	slog.Info("startup scan waiting for an idle period", "quiet_period", quietPeriod)
+
+	quietSince := time.Now()
+	prevTicks, prevOK := readDiskIOTicks()
+	prevAt := time.Now()
+	ticker := time.NewTicker(idlePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-shutdown:
+			return false
+		case now := <-ticker.C:
+			if last := s.apiIdle.lastRequest(); last.After(quietSince) {
+				quietSince = last
+			}
+			ticks, ok := readDiskIOTicks()
+			if ok && prevOK && diskBusyFraction(prevTicks, ticks, now.Sub(prevAt)) > diskBusyThreshold {
+				quietSince = now
+			}
+			prevTicks, prevOK, prevAt = ticks, ok, now
+
+			if now.Sub(quietSince) >= quietPeriod {
+				slog.Info("startup scan: server idle, starting")
+				return true
+			}
+			if !deadline.IsZero() && now.After(deadline) {
+				slog.Info("startup scan: no idle period within the maximum delay, starting anyway")
+				return true
+			}
+		}
+	}
+}
+
+// yieldToInteractiveOps blocks while an operation started through the API
+// is running. Installed as the Yield hook of background scans.
+func (s *Server) yieldToInteractiveOps(ctx context.Context) {
+	for s.opRegistry != nil && s.opRegistry.RunningInteractive() > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(idlePollInterval):
+		}
+	}
+}
+
+// readDiskIOTicks returns each block device's cumulative milliseconds
+// spent doing IO.
+func readDiskIOTicks() (map[string]uint64, bool) {
+	f, err := os.Open(diskStatsPath)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+	return parseDiskStats(f), true
+}
+
+// parseDiskStats reads /proc/diskstats lines ("major minor name" followed
+// by the IO counters; the tenth counter is io_ticks). Loop and RAM
+// devices are skipped.
+func parseDiskStats(r io.Reader) map[string]uint64 {
+	out := make(map[string]uint64)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 13 {
+			continue
+		}
+		name := fields[2]
+		if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
+			continue
+		}
+		if ticks, err := strconv.ParseUint(fields[12], 10, 64); err == nil {
+			out[name] = ticks
+		}
+	}
+	return out
+}
+
+// diskBusyFraction is the busiest device's share of elapsed spent with IO
+// in flight between two samples.
+func diskBusyFraction(prev, cur map[string]uint64, elapsed time.Duration) float64 {
+	ms := float64(elapsed.Milliseconds())
+	if ms <= 0 {
+		return 0
+	}
+	busiest := 0.0
+	for name, ticks := range cur {
+		before, ok := prev[name]
+		if !ok || ticks < before {
+			continue
+		}
+		if f := float64(ticks-before) / ms; f > busiest {
+			busiest = f
+		}
+	}
+	return busiest
+}
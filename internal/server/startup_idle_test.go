@@ -0,0 +1,53 @@
+// file: internal/server/startup_idle_test.go
+// version: 1.0.0
+// guid: 173b153a-d2ff-4eb1-a6dd-b76ad11c06a1
+// last-edited: 2026-10-16
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiskStats(t *testing.T) {
+	const sample = `   7       0 loop0 100 0 200 10 0 0 0 0 0 999 10 0 0 0 0
+   8       0 sda 5000 10 80000 3000 2000 5 40000 1000 0 4200 4000 0 0 0 0
+   8       1 sda1 4900 10 79000 2900 1900 5 39000 900 0 4100 3800 0 0 0 0
+ 259       0 nvme0n1 10 0 80 1 0 0 0 0 0
+short line`
+	got := parseDiskStats(strings.NewReader(sample))
+	assert.Equal(t, map[string]uint64{"sda": 4200, "sda1": 4100}, got)
+}
+
+func TestDiskBusyFraction(t *testing.T) {
+	prev := map[string]uint64{"sda": 1000, "sdb": 500}
+	cur := map[string]uint64{"sda": 1500, "sdb": 600, "sdc": 9000}
+	// sda was busy 500ms of 1s; sdc has no previous sample.
+	assert.InDelta(t, 0.5, diskBusyFraction(prev, cur, time.Second), 1e-9)
+	assert.Zero(t, diskBusyFraction(prev, cur, 0))
+}
+
+func TestWaitForStartupIdle_Disabled(t *testing.T) {
+	orig := config.AppConfig.StartupScanIdleSeconds
+	defer func() { config.AppConfig.StartupScanIdleSeconds = orig }()
+	config.AppConfig.StartupScanIdleSeconds = 0
+
+	s := &Server{}
+	assert.True(t, s.waitForStartupIdle(make(chan struct{})))
+}
+
+func TestWaitForStartupIdle_Shutdown(t *testing.T) {
+	orig := config.AppConfig.StartupScanIdleSeconds
+	defer func() { config.AppConfig.StartupScanIdleSeconds = orig }()
+	config.AppConfig.StartupScanIdleSeconds = 3600
+
+	shutdown := make(chan struct{})
+	close(shutdown)
+	s := &Server{}
+	assert.False(t, s.waitForStartupIdle(shutdown))
+}
@@ -1,21 +1,21 @@
 //go:build embed_frontend
 
 // file: internal/server/static_embed.go
-// version: 1.4.0
+// version: 1.5.0
 // guid: 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package server
 
 import (
 	"embed"
-	"io"
 	"io/fs"
-	"mime"
+	"log/slog"
 	"net/http"
-	"path/filepath"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
 )
 
@@ -27,70 +27,28 @@ func SetEmbeddedFS(fs embed.FS) {
 	webFS = fs
 }
 
-// setupStaticFiles serves the embedded React frontend
+// setupStaticFiles serves the embedded React frontend through spaHandler,
+// or the placeholder page when web/dist was not embedded.
 func (s *Server) setupStaticFiles() {
-	// Get the web/dist subdirectory from the embedded filesystem
 	webDist, err := fs.Sub(webFS, "web/dist")
-	if err != nil {
-		// If Sub fails, log and continue - we'll fall back to placeholder
-		webDist = nil
-	}
-
-	// NoRoute handler to serve static files or SPA index.html
-	s.router.NoRoute(func(c *gin.Context) {
-		// Return 404 for unknown API routes
-		if len(c.Request.URL.Path) >= 4 && c.Request.URL.Path[:4] == "/api" {
-			httputil.RespondWithNotFound(c, "endpoint", "")
-			return
-		}
-
-		// If webDist is nil, show placeholder
-		if webDist == nil {
-			s.setupPlaceholder()
-			c.Request.URL.Path = "/"
-			s.router.HandleContext(c)
-			return
+	if err == nil {
+		if _, statErr := fs.Stat(webDist, "index.html"); statErr != nil {
+			err = statErr
 		}
-
-		// Try to serve the file from the embedded filesystem
-		path := c.Request.URL.Path
-		if path == "/" {
-			path = "/index.html"
-		}
-
-		// Remove leading slash for fs.Open
-		filePath := path[1:]
-
-		file, err := webDist.Open(filePath)
-		if err == nil {
-			defer file.Close()
-
-			// Get file info for size
-			stat, err := file.Stat()
-			if err == nil && !stat.IsDir() {
-				// Detect MIME type from extension
-				contentType := mime.TypeByExtension(filepath.Ext(filePath))
-				if contentType == "" {
-					contentType = "application/octet-stream"
-				}
-
-				// Read file content
-				content, err := io.ReadAll(file)
-				if err == nil {
-					c.Data(http.StatusOK, contentType, content)
-					return
-				}
+	}
+	if err != nil {
+		slog.Warn("embedded frontend unavailable, serving placeholder", "err", err)
+		s.setupPlaceholder()
+		s.router.NoRoute(func(c *gin.Context) {
+			if strings.HasPrefix(c.Request.URL.Path, "/api") {
+				httputil.RespondWithNotFound(c, "endpoint", "")
+				return
 			}
-		}
-
-		// File not found or error, serve index.html for SPA routing
-		indexData, err := fs.ReadFile(webDist, "index.html")
-		if err != nil {
-			c.String(http.StatusInternalServerError, "Failed to load frontend")
-			return
-		}
-		c.Data(http.StatusOK, "text/html; charset=utf-8", indexData)
-	})
+			c.Redirect(http.StatusFound, config.AppConfig.BasePath+"/")
+		})
+		return
+	}
+	s.router.NoRoute(spaHandler(webDist, config.AppConfig.BasePath))
 }
 
 // setupPlaceholder serves the API documentation placeholder page (fallback)
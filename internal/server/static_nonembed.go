@@ -1,17 +1,21 @@
 //go:build !embed_frontend
 
 // file: internal/server/static_nonembed.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: 2b3c4d5e-6f7a-8b9c-0d1e-2f3a4b5c6d7e
-// last-edited: 2026-05-01
+// last-edited: 2026-10-16
 
 package server
 
 import (
 	"embed"
+	"log/slog"
 	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
 )
 
@@ -20,8 +24,16 @@ func SetEmbeddedFS(fs embed.FS) {
 	// No-op: not using embedded frontend
 }
 
-// setupStaticFiles serves a placeholder HTML page (no embedded frontend)
+// setupStaticFiles serves the built frontend from config web_dir when set,
+// otherwise a placeholder HTML page (no embedded frontend).
 func (s *Server) setupStaticFiles() {
+	if dir := config.AppConfig.WebDir; dir != "" {
+		if _, err := os.Stat(filepath.Join(dir, "index.html")); err == nil {
+			s.router.NoRoute(spaHandler(os.DirFS(dir), config.AppConfig.BasePath))
+			return
+		}
+		slog.Warn("web_dir has no index.html, serving placeholder", "web_dir", dir)
+	}
 	s.setupPlaceholder()
 }
 
@@ -106,6 +118,6 @@ func (s *Server) setupPlaceholder() {
 			return
 		}
 		// For non-API routes, redirect to home
-		c.Redirect(http.StatusFound, "/")
+		c.Redirect(http.StatusFound, config.AppConfig.BasePath+"/")
 	})
 }
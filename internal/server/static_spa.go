@@ -0,0 +1,107 @@
+// file: internal/server/static_spa.go
+// version: 1.0.0
+// guid: 7e3cc4c8-d4c9-4cb0-886a-2162f9bc66b2
+// last-edited: 2026-10-16
+
+package server
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// Cache policies for the built frontend. Vite fingerprints everything under
+// assets/ with a content hash, so those files never change under a given
+// name; index.html must always be revalidated so a deploy is picked up.
+const (
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	staticCacheControl    = "public, max-age=3600"
+	indexCacheControl     = "no-cache"
+)
+
+// hashedAssetPattern matches Vite's "name-<hash>.ext" output names.
+var hashedAssetPattern = regexp.MustCompile(`-[A-Za-z0-9_-]{8,}\.[A-Za-z0-9]+$`)
+
+// assetURLPattern matches root-relative src/href attributes in index.html,
+// skipping protocol-relative ("//host") URLs.
+var assetURLPattern = regexp.MustCompile(`(\s(?:src|href)=")/([^/"])`)
+
+// fileExtPattern is what a static file's extension looks like; route
+// segments with dots in them ("/authors/J.R.R. Tolkien") don't match.
+var fileExtPattern = regexp.MustCompile(`^\.[A-Za-z0-9]{1,8}$`)
+
+func isHashedAsset(name string) bool {
+	return strings.HasPrefix(name, "assets/") && hashedAssetPattern.MatchString(name)
+}
+
+// rewriteIndexHTML points index.html's root-relative asset URLs at basePath
+// and publishes it to the SPA as window.__BASE_PATH__ for the router and
+// API client. With no base path the document is returned untouched.
+func rewriteIndexHTML(html []byte, basePath string) []byte {
+	if basePath == "" {
+		return html
+	}
+	out := assetURLPattern.ReplaceAll(html, []byte("${1}"+basePath+"/${2}"))
+	script := []byte("<head><script>window.__BASE_PATH__=" + strconv.Quote(basePath) + ";</script>")
+	return bytes.Replace(out, []byte("<head>"), script, 1)
+}
+
+// spaHandler serves the built frontend in dist. Existing files are served
+// with a cache policy matching their name; unknown paths that look like
+// files (a file extension, or under assets/) get a 404 instead of index.html so
+// a stale chunk reference fails loudly rather than parsing HTML as
+// JavaScript; every other path is a client-side route and gets index.html
+// (history-mode fallback). Unknown /api paths keep the JSON 404.
+func spaHandler(dist fs.FS, basePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqPath := c.Request.URL.Path
+		if reqPath == "/api" || strings.HasPrefix(reqPath, "/api/") {
+			httputil.RespondWithNotFound(c, "endpoint", "")
+			return
+		}
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+reqPath), "/")
+		if name != "" && name != "index.html" {
+			if f, err := dist.Open(name); err == nil {
+				defer f.Close()
+				if stat, err := f.Stat(); err == nil && !stat.IsDir() {
+					if rs, ok := f.(io.ReadSeeker); ok {
+						if isHashedAsset(name) {
+							c.Header("Cache-Control", immutableCacheControl)
+						} else {
+							c.Header("Cache-Control", staticCacheControl)
+						}
+						http.ServeContent(c.Writer, c.Request, name, stat.ModTime(), rs)
+						return
+					}
+				}
+			}
+			if strings.HasPrefix(name, "assets/") || fileExtPattern.MatchString(path.Ext(name)) {
+				c.Status(http.StatusNotFound)
+				return
+			}
+		}
+
+		index, err := fs.ReadFile(dist, "index.html")
+		if err != nil {
+			c.String(http.StatusInternalServerError, "Failed to load frontend")
+			return
+		}
+		c.Header("Cache-Control", indexCacheControl)
+		http.ServeContent(c.Writer, c.Request, "index.html", time.Time{}, bytes.NewReader(rewriteIndexHTML(index, basePath)))
+	}
+}
@@ -0,0 +1,88 @@
+// file: internal/server/static_spa_test.go
+// version: 1.0.0
+// guid: 62a176a2-19b9-4fba-91b9-279d1f331272
+// last-edited: 2026-10-16
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIndexHTML = `<!doctype html><html><head>` +
+	`<link rel="icon" href="/favicon.svg" />` +
+	`<script type="module" crossorigin src="/assets/index-AbCd1234.js"></script>` +
+	`<link rel="preconnect" href="//fonts.example.com" />` +
+	`</head><body><div id="root"></div></body></html>`
+
+func newSPARouter(basePath string) *gin.Engine {
+	dist := fstest.MapFS{
+		"index.html":                 {Data: []byte(testIndexHTML)},
+		"favicon.svg":                {Data: []byte("<svg/>")},
+		"assets/index-AbCd1234.js":   {Data: []byte("console.log(1)")},
+		"assets/vendor-Zz_9-xY8.css": {Data: []byte("body{}")},
+	}
+	r := gin.New()
+	r.NoRoute(spaHandler(dist, basePath))
+	return r
+}
+
+func serveSPA(r *gin.Engine, method, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestSPAHandler_CacheHeaders(t *testing.T) {
+	r := newSPARouter("")
+
+	w := serveSPA(r, http.MethodGet, "/assets/index-AbCd1234.js")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, immutableCacheControl, w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+
+	w = serveSPA(r, http.MethodGet, "/favicon.svg")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, staticCacheControl, w.Header().Get("Cache-Control"))
+
+	w = serveSPA(r, http.MethodGet, "/")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, indexCacheControl, w.Header().Get("Cache-Control"))
+	assert.Equal(t, testIndexHTML, w.Body.String())
+}
+
+func TestSPAHandler_Fallback(t *testing.T) {
+	r := newSPARouter("")
+
+	// Deep links, including ones with dots, get the app shell.
+	for _, target := range []string{"/library/01HX/edit", "/authors/J.R.R.%20Tolkien", "/index.html"} {
+		w := serveSPA(r, http.MethodGet, target)
+		assert.Equal(t, http.StatusOK, w.Code, target)
+		assert.Contains(t, w.Body.String(), `<div id="root">`, target)
+	}
+
+	// Missing files 404 instead of returning HTML.
+	for _, target := range []string{"/assets/index-OldHash1.js", "/robots.txt", "/assets/chunk"} {
+		assert.Equal(t, http.StatusNotFound, serveSPA(r, http.MethodGet, target).Code, target)
+	}
+	assert.Equal(t, http.StatusNotFound, serveSPA(r, http.MethodGet, "/api/v1/nope").Code)
+	assert.Equal(t, http.StatusNotFound, serveSPA(r, http.MethodPost, "/library").Code)
+}
+
+func TestSPAHandler_BasePath(t *testing.T) {
+	r := newSPARouter("/audiobooks")
+	w := serveSPA(r, http.MethodGet, "/library")
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `<script>window.__BASE_PATH__="/audiobooks";</script>`)
+	assert.Contains(t, body, `src="/audiobooks/assets/index-AbCd1234.js"`)
+	assert.Contains(t, body, `href="/audiobooks/favicon.svg"`)
+	assert.Contains(t, body, `href="//fonts.example.com"`)
+}
@@ -0,0 +1,295 @@
+// file: internal/server/storage_tiers.go
+// version: 1.1.0
+// guid: 5d2c8e71-94b3-4f06-a8e2-1c7b3f9d6a40
+// last-edited: 2026-10-17
+
+// Storage tiers: library sub-paths configured as hot (fast, small) or
+// cold (slow, large). GET /stats/tiers reports what each tier holds and
+// which books the tier policy would move to cold storage, paired with the
+// library.tier-move operation that moves them.
+
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// storageTierFor returns the index of the tier holding path, the
+// configured tier with the longest path containing it, or -1.
+func storageTierFor(tiers []config.StorageTier, path string) int {
+	best := -1
+	for i, t := range tiers {
+		if _, ok := pathWithin(t.Path, path); ok && (best < 0 || len(t.Path) > len(tiers[best].Path)) {
+			best = i
+		}
+	}
+	return best
+}
+
+// pathWithin returns path relative to dir, and whether path lies
+// strictly inside dir.
+func pathWithin(dir, path string) (string, bool) {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// coldTierTarget returns where a book at path on a hot tier goes: the
+// same relative path under the first cold tier. ok is false when path is
+// not on a hot tier or no cold tier is configured.
+func coldTierTarget(tiers []config.StorageTier, path string) (string, bool) {
+	i := storageTierFor(tiers, path)
+	if i < 0 || tiers[i].Tier != config.StorageTierHot {
+		return "", false
+	}
+	rel, _ := pathWithin(tiers[i].Path, path)
+	for _, t := range tiers {
+		if t.Tier == config.StorageTierCold {
+			return filepath.Join(t.Path, rel), true
+		}
+	}
+	return "", false
+}
+
+// tierMoveParams are the library.tier-move parameters. With no books the
+// operation applies the whole policy.
+type tierMoveParams struct {
+	Books []string `json:"books"`
+}
+
+// tierMoveAction is a ready-to-post POST /operations/v2 body.
+type tierMoveAction struct {
+	DefID  string         `json:"def_id"`
+	Params tierMoveParams `json:"params"`
+}
+
+// tierMove is one book the tier policy would move to cold storage.
+type tierMove struct {
+	BookID     string `json:"book_id"`
+	Title      string `json:"title"`
+	FilePath   string `json:"file_path"`
+	TargetPath string `json:"target_path"`
+	Bytes      int64  `json:"bytes"`
+	Reason     string `json:"reason"`
+}
+
+// tierPolicy decides which books leave the hot tier: non-primary
+// versions, and books neither listened to nor added within
+// TierColdAfterDays.
+type tierPolicy struct {
+	tiers     []config.StorageTier
+	primaries map[string]bool
+	// cutoff is zero when the listening rule is disabled.
+	cutoff time.Time
+	recent map[string]bool
+}
+
+// newTierPolicy loads what the policy needs: the version groups that
+// have a primary and the books listened to since the cutoff.
+func newTierPolicy(store database.Store, books []database.Book, cfg *config.Config, now time.Time) (*tierPolicy, error) {
+	p := &tierPolicy{tiers: cfg.StorageTiers, primaries: map[string]bool{}}
+	for i := range books {
+		b := &books[i]
+		if isVersionGrouped(b) && b.IsPrimaryVersion != nil && *b.IsPrimaryVersion {
+			p.primaries[*b.VersionGroupID] = true
+		}
+	}
+	if cfg.TierColdAfterDays > 0 {
+		p.cutoff = now.AddDate(0, 0, -cfg.TierColdAfterDays)
+		recent, err := booksListenedSince(store, p.cutoff)
+		if err != nil {
+			return nil, err
+		}
+		p.recent = recent
+	}
+	return p, nil
+}
+
+// booksListenedSince returns the IDs of books any user has a position
+// in updated after since. Positions recorded without a signed-in user
+// are kept under "_local".
+func booksListenedSince(store database.Store, since time.Time) (map[string]bool, error) {
+	users, err := store.ListUsers()
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	ids := []string{"_local"}
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	recent := map[string]bool{}
+	for _, id := range ids {
+		positions, err := store.ListUserPositionsSince(id, since)
+		if err != nil {
+			return nil, fmt.Errorf("list positions: %w", err)
+		}
+		for _, pos := range positions {
+			recent[pos.BookID] = true
+		}
+	}
+	return recent, nil
+}
+
+// move returns where book should go and why, or ok=false when it stays.
+func (p *tierPolicy) move(book *database.Book) (target, reason string, ok bool) {
+	if book.FilePath == "" || book.QuarantinedAt != nil || (book.MarkedForDeletion != nil && *book.MarkedForDeletion) {
+		return "", "", false
+	}
+	target, ok = coldTierTarget(p.tiers, book.FilePath)
+	if !ok {
+		return "", "", false
+	}
+	if isVersionGrouped(book) && (book.IsPrimaryVersion == nil || !*book.IsPrimaryVersion) && p.primaries[*book.VersionGroupID] {
+		return target, "not the primary version", true
+	}
+	if p.cutoff.IsZero() || p.recent[book.ID] {
+		return "", "", false
+	}
+	if book.ITunesLastPlayed != nil && book.ITunesLastPlayed.After(p.cutoff) {
+		return "", "", false
+	}
+	if book.CreatedAt != nil && book.CreatedAt.After(p.cutoff) {
+		return "", "", false
+	}
+	return target, "not listened to since " + p.cutoff.UTC().Format("2006-01-02"), true
+}
+
+// planTierMoves lists the books the policy would move, skipping those
+// under protected paths, which are never moved.
+func planTierMoves(store database.Store, books []database.Book, policy *tierPolicy) ([]tierMove, error) {
+	paths, err := store.GetAllImportPaths()
+	if err != nil {
+		return nil, fmt.Errorf("list import paths: %w", err)
+	}
+	var moves []tierMove
+	for i := range books {
+		b := &books[i]
+		target, reason, ok := policy.move(b)
+		if !ok || audiobooks.IsProtectedPath(importPathCache(paths), b.FilePath) {
+			continue
+		}
+		m := tierMove{BookID: b.ID, Title: b.Title, FilePath: b.FilePath, TargetPath: target, Reason: reason}
+		if b.FileSize != nil {
+			m.Bytes = *b.FileSize
+		}
+		moves = append(moves, m)
+	}
+	return moves, nil
+}
+
+// tierUsage totals the books on one tier, or on no tier.
+type tierUsage struct {
+	Path  string `json:"path,omitempty"`
+	Tier  string `json:"tier,omitempty"`
+	Books int    `json:"books"`
+	Bytes int64  `json:"bytes"`
+	// DiskTotalBytes and DiskFreeBytes describe the filesystem holding
+	// the tier; zero when it can't be read.
+	DiskTotalBytes uint64 `json:"disk_total_bytes,omitempty"`
+	DiskFreeBytes  uint64 `json:"disk_free_bytes,omitempty"`
+}
+
+// handleStorageTiers implements GET /stats/tiers.
+//
+// Query params:
+//   - limit: planned moves listed (default 50, max 1000).
+//
+// Usage counts live books by their recorded size. Moves come back
+// largest first; action covers every planned move, including those past
+// the listing limit. Nothing is modified.
+func (s *Server) handleStorageTiers(c *gin.Context) {
+	limit := 50
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 1000 {
+			httputil.RespondWithValidationError(c, "limit", "must be between 1 and 1000")
+			return
+		}
+		limit = n
+	}
+	store := s.Store()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	books, err := database.GetAllBooksContext(c.Request.Context(), store, 0, 0)
+	if err != nil {
+		httputil.InternalError(c, "failed to list audiobooks", err)
+		return
+	}
+	cfg := config.Snapshot()
+
+	tiers := make([]tierUsage, len(cfg.StorageTiers))
+	for i, t := range cfg.StorageTiers {
+		tiers[i] = tierUsage{Path: t.Path, Tier: t.Tier}
+		if total, free, err := getDiskStats(t.Path); err == nil {
+			tiers[i].DiskTotalBytes, tiers[i].DiskFreeBytes = total, free
+		}
+	}
+	var untiered tierUsage
+	for i := range books {
+		b := &books[i]
+		if b.MarkedForDeletion != nil && *b.MarkedForDeletion {
+			continue
+		}
+		usage := &untiered
+		if i := storageTierFor(cfg.StorageTiers, b.FilePath); i >= 0 {
+			usage = &tiers[i]
+		}
+		usage.Books++
+		if b.FileSize != nil {
+			usage.Bytes += *b.FileSize
+		}
+	}
+
+	policy, err := newTierPolicy(store, books, &cfg, time.Now())
+	if err != nil {
+		httputil.InternalError(c, "failed to load listening history", err)
+		return
+	}
+	moves, err := planTierMoves(store, books, policy)
+	if err != nil {
+		httputil.InternalError(c, "failed to plan tier moves", err)
+		return
+	}
+	action := tierMoveAction{DefID: tierMoveOpID, Params: tierMoveParams{Books: make([]string, 0, len(moves))}}
+	var moveBytes int64
+	for _, m := range moves {
+		action.Params.Books = append(action.Params.Books, m.BookID)
+		moveBytes += m.Bytes
+	}
+	slices.SortStableFunc(moves, func(a, b tierMove) int { return cmp.Compare(b.Bytes, a.Bytes) })
+	items := moves
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	if items == nil {
+		items = []tierMove{}
+	}
+
+	httputil.RespondWithOK(c, gin.H{
+		"tiers":                tiers,
+		"untiered":             untiered,
+		"tier_cold_after_days": cfg.TierColdAfterDays,
+		"moves": gin.H{
+			"books":  len(moves),
+			"bytes":  moveBytes,
+			"items":  items,
+			"action": action,
+		},
+	})
+}
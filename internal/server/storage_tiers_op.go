@@ -0,0 +1,241 @@
+// file: internal/server/storage_tiers_op.go
+// version: 1.1.0
+// guid: 9a4e1f6c-3b27-4d85-b0c9-7e2d5a8f1c63
+// last-edited: 2026-10-17
+
+// library.tier-move moves books from hot storage tiers to the cold tier,
+// as planned by GET /stats/tiers. Each book is checked against the policy
+// again before it moves, since it may have been listened to or promoted
+// since the plan was made.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/audiobooks"
+	"github.com/falkcorp/audiobook-organizer/internal/auth"
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/fileops"
+	opsregistry "github.com/falkcorp/audiobook-organizer/internal/operations/registry"
+)
+
+const tierMoveOpID = "library.tier-move"
+
+// RegisterTierMoveOp registers the "library.tier-move" OperationDef.
+func (s *Server) RegisterTierMoveOp(reg *opsregistry.Registry) error {
+	return reg.RegisterOp(opsregistry.OperationDef{
+		ID:              tierMoveOpID,
+		Plugin:          "library",
+		DisplayName:     "Move to Cold Storage",
+		Description:     "Move rarely-listened books and non-primary versions from hot storage tiers to the cold tier.",
+		DefaultPriority: opsregistry.PriorityLow,
+		Cancellable:     true,
+		Isolate:         false,
+		Timeout:         12 * time.Hour,
+		ResumePolicy:    opsregistry.ResumeDrop,
+		ConcurrencyKey:  tierMoveOpID,
+		Permissions:     []auth.Permission{auth.PermLibraryOrganize},
+		Capabilities: []opsregistry.Capability{
+			opsregistry.CapLibraryRead, opsregistry.CapLibraryWrite, opsregistry.CapFilesWrite,
+		},
+		Run: func(ctx context.Context, raw json.RawMessage, reporter opsregistry.Reporter) error {
+			var p tierMoveParams
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &p); err != nil {
+					return fmt.Errorf("%s: decode params: %w", tierMoveOpID, err)
+				}
+			}
+			return s.runTierMove(ctx, p, reporter)
+		},
+	})
+}
+
+func init() {
+	addOpRegistrar(func(s *Server, reg *opsregistry.Registry) error { return s.RegisterTierMoveOp(reg) })
+}
+
+func (s *Server) runTierMove(ctx context.Context, p tierMoveParams, reporter opsregistry.Reporter) error {
+	store := s.Store()
+	if store == nil {
+		return fmt.Errorf("%s: database not initialized", tierMoveOpID)
+	}
+	logf := func(level slog.Level, format string, args ...any) {
+		_ = reporter.Log(level, fmt.Sprintf(format, args...))
+	}
+	cfg := config.Snapshot()
+	books, err := database.GetAllBooksContext(ctx, store, 0, 0)
+	if err != nil {
+		return fmt.Errorf("%s: list books: %w", tierMoveOpID, err)
+	}
+	policy, err := newTierPolicy(store, books, &cfg, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", tierMoveOpID, err)
+	}
+	paths, err := store.GetAllImportPaths()
+	if err != nil {
+		return fmt.Errorf("%s: list import paths: %w", tierMoveOpID, err)
+	}
+
+	ids := p.Books
+	if len(ids) == 0 {
+		moves, err := planTierMoves(store, books, policy)
+		if err != nil {
+			return fmt.Errorf("%s: %w", tierMoveOpID, err)
+		}
+		for _, m := range moves {
+			ids = append(ids, m.BookID)
+		}
+	}
+
+	total := len(ids)
+	moved, skipped := 0, 0
+	for i, id := range ids {
+		if reporter.IsCanceled() {
+			return ctx.Err()
+		}
+		_ = reporter.UpdateProgress(i, total, fmt.Sprintf("Moving %d/%d", i+1, total))
+		book, err := store.GetBookByID(id)
+		if err != nil || book == nil {
+			skipped++
+			logf(slog.LevelWarn, "Skipping %s: book not found", id)
+			continue
+		}
+		reporter.SetCurrentItem(book.Title)
+		target, reason, ok := policy.move(book)
+		if !ok {
+			skipped++
+			logf(slog.LevelInfo, "Skipping %s: no longer due for cold storage", book.Title)
+			continue
+		}
+		if audiobooks.IsProtectedPath(importPathCache(paths), book.FilePath) {
+			skipped++
+			logf(slog.LevelWarn, "Skipping %s: under a protected path", book.Title)
+			continue
+		}
+		from := book.FilePath
+		if err := moveBookToTier(store, book, target); err != nil {
+			skipped++
+			logf(slog.LevelError, "Failed to move %s: %v", book.Title, err)
+			continue
+		}
+		moved++
+		logf(slog.LevelInfo, "Moved %s (%s) from %s to %s", book.Title, reason, from, target)
+	}
+	if moved > 0 {
+		s.invalidateSizeCaches()
+	}
+	msg := fmt.Sprintf("Moved %d books to cold storage, skipped %d", moved, skipped)
+	_ = reporter.UpdateProgress(total, total, msg)
+	logf(slog.LevelInfo, "%s", msg)
+	return nil
+}
+
+// moveBookToTier moves book's file, or its directory with everything in
+// it, to target and repoints the book and its book files. A failed move
+// puts back the files already moved.
+func moveBookToTier(store database.Store, book *database.Book, target string) error {
+	if _, err := os.Lstat(target); err == nil {
+		return fmt.Errorf("%s already exists", target)
+	}
+	src := book.FilePath
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	type pair struct{ from, to string }
+	var moved []pair
+	move := func(from, to string) error {
+		if err := moveTierFile(from, to); err != nil {
+			for _, m := range slices.Backward(moved) {
+				if rerr := moveTierFile(m.to, m.from); rerr != nil {
+					slog.Warn("tier move rollback failed", "path", m.to, "err", rerr)
+				}
+			}
+			return err
+		}
+		moved = append(moved, pair{from, to})
+		return nil
+	}
+
+	if !info.IsDir() {
+		if err := move(src, target); err != nil {
+			return err
+		}
+	} else {
+		var files, dirs []string
+		err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			switch {
+			case err != nil:
+				return err
+			case d.IsDir():
+				dirs = append(dirs, path)
+			case d.Type().IsRegular():
+				files = append(files, path)
+			default:
+				return fmt.Errorf("%s is not a regular file", path)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			rel, _ := filepath.Rel(src, f)
+			if err := move(f, filepath.Join(target, rel)); err != nil {
+				return err
+			}
+		}
+		for _, d := range slices.Backward(dirs) {
+			_ = os.Remove(d)
+		}
+	}
+
+	files, err := store.GetBookFiles(book.ID)
+	if err != nil {
+		return fmt.Errorf("files moved but book files not updated: %w", err)
+	}
+	var errs []error
+	for _, f := range files {
+		rel, within := pathWithin(src, f.FilePath)
+		switch {
+		case f.FilePath == src:
+			f.FilePath = target
+		case within:
+			f.FilePath = filepath.Join(target, rel)
+		default:
+			continue
+		}
+		if err := store.UpdateBookFile(f.ID, &f); err != nil {
+			errs = append(errs, fmt.Errorf("update book file %s: %w", f.ID, err))
+		}
+	}
+	book.FilePath = target
+	if _, err := store.UpdateBook(book.ID, book); err != nil {
+		errs = append(errs, fmt.Errorf("update book: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// moveTierFile moves one file, copying it when src and dst are on
+// different filesystems, as tiers usually are.
+func moveTierFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o775); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	return fileops.SafeMove(src, dst, fileops.OperationConfig{VerifyChecksums: true})
+}
@@ -0,0 +1,141 @@
+// file: internal/server/storage_tiers_test.go
+// version: 1.0.0
+// guid: e7b3a9d2-6c14-4f58-9a0e-2d8c5f1b7e34
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/falkcorp/audiobook-organizer/internal/config"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/database/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTierPolicy(t *testing.T) {
+	tiers := []config.StorageTier{
+		{Path: "/ssd/books", Tier: config.StorageTierHot},
+		{Path: "/ssd/books/archive", Tier: config.StorageTierCold},
+		{Path: "/hdd/books", Tier: config.StorageTierCold},
+	}
+	assert.Equal(t, 1, storageTierFor(tiers, "/ssd/books/archive/a.m4b"), "the longest tier path wins")
+	assert.Equal(t, 0, storageTierFor(tiers, "/ssd/books/a.m4b"))
+	assert.Equal(t, -1, storageTierFor(tiers, "/ssd/booksellers/a.m4b"))
+	assert.Equal(t, -1, storageTierFor(tiers, "/ssd/books"))
+	target, ok := coldTierTarget(tiers, "/ssd/books/Author/Title.m4b")
+	assert.True(t, ok)
+	assert.Equal(t, "/ssd/books/archive/Author/Title.m4b", target)
+
+	now := time.Now()
+	old, recent := now.AddDate(-1, 0, 0), now.AddDate(0, 0, -1)
+	p := &tierPolicy{
+		tiers:     []config.StorageTier{tiers[0], tiers[2]},
+		primaries: map[string]bool{"g": true},
+		cutoff:    now.AddDate(0, 0, -90),
+		recent:    map[string]bool{"listened": true, "old-version": true},
+	}
+	sp := func(v string) *string { return &v }
+	bp := func(v bool) *bool { return &v }
+	for _, tc := range []struct {
+		book database.Book
+		move bool
+	}{
+		{database.Book{ID: "stale", FilePath: "/ssd/books/a.m4b", CreatedAt: &old}, true},
+		{database.Book{ID: "listened", FilePath: "/ssd/books/b.m4b", CreatedAt: &old}, false},
+		{database.Book{ID: "itunes", FilePath: "/ssd/books/c.m4b", CreatedAt: &old, ITunesLastPlayed: &recent}, false},
+		{database.Book{ID: "new", FilePath: "/ssd/books/d.m4b", CreatedAt: &recent}, false},
+		{database.Book{ID: "old-version", FilePath: "/ssd/books/e.mp3", CreatedAt: &recent, VersionGroupID: sp("g"), IsPrimaryVersion: bp(false)}, true},
+		{database.Book{ID: "orphan-version", FilePath: "/ssd/books/f.mp3", CreatedAt: &recent, VersionGroupID: sp("x"), IsPrimaryVersion: bp(false)}, false},
+		{database.Book{ID: "cold", FilePath: "/hdd/books/g.m4b", CreatedAt: &old}, false},
+		{database.Book{ID: "untiered", FilePath: "/media/h.m4b", CreatedAt: &old}, false},
+		{database.Book{ID: "deleted", FilePath: "/ssd/books/i.m4b", CreatedAt: &old, MarkedForDeletion: bp(true)}, false},
+	} {
+		_, _, moves := p.move(&tc.book)
+		assert.Equal(t, tc.move, moves, tc.book.ID)
+	}
+}
+
+func TestStorageTiersAndMove(t *testing.T) {
+	srv, cleanup := setupTestServer(t)
+	defer cleanup()
+	store := srv.Store()
+	root := config.AppConfig.RootDir
+	hot, cold := filepath.Join(root, "hot"), filepath.Join(root, "cold")
+	config.AppConfig.StorageTiers = []config.StorageTier{
+		{Path: hot, Tier: config.StorageTierHot},
+		{Path: cold, Tier: config.StorageTierCold},
+	}
+	config.AppConfig.TierColdAfterDays = -1
+
+	write := func(rel string, size int) string {
+		p := filepath.Join(hot, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, make([]byte, size), 0o644))
+		return p
+	}
+	sp := func(v string) *string { return &v }
+	bp := func(v bool) *bool { return &v }
+	ip := func(v int64) *int64 { return &v }
+	storetest.Book(t, store, database.Book{ID: "primary", FilePath: write("Author/New.m4b", 300), FileSize: ip(300),
+		VersionGroupID: sp("g"), IsPrimaryVersion: bp(true)})
+	part1, part2 := write("Author/Old/01.mp3", 100), write("Author/Old/02.mp3", 100)
+	write("Author/Old/cover.jpg", 10)
+	old := storetest.Book(t, store, database.Book{ID: "old", FilePath: filepath.Dir(part1), FileSize: ip(200),
+		VersionGroupID: sp("g"), IsPrimaryVersion: bp(false)})
+	storetest.BookFile(t, store, old, database.BookFile{FilePath: part1, TrackNumber: 1})
+	storetest.BookFile(t, store, old, database.BookFile{FilePath: part2, TrackNumber: 2})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/tiers", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data struct {
+			Tiers []tierUsage `json:"tiers"`
+			Moves struct {
+				Books  int            `json:"books"`
+				Bytes  int64          `json:"bytes"`
+				Items  []tierMove     `json:"items"`
+				Action tierMoveAction `json:"action"`
+			} `json:"moves"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Tiers, 2)
+	assert.Equal(t, 2, resp.Data.Tiers[0].Books)
+	assert.Equal(t, int64(500), resp.Data.Tiers[0].Bytes)
+	assert.Equal(t, 0, resp.Data.Tiers[1].Books)
+	assert.Equal(t, 1, resp.Data.Moves.Books)
+	require.Len(t, resp.Data.Moves.Items, 1)
+	target := filepath.Join(cold, "Author", "Old")
+	assert.Equal(t, target, resp.Data.Moves.Items[0].TargetPath)
+	assert.Equal(t, tierMoveAction{DefID: tierMoveOpID, Params: tierMoveParams{Books: []string{"old"}}}, resp.Data.Moves.Action)
+
+	require.NoError(t, moveBookToTier(store, old, target))
+	assert.NoDirExists(t, filepath.Dir(part1))
+	assert.FileExists(t, filepath.Join(target, "02.mp3"))
+	assert.FileExists(t, filepath.Join(target, "cover.jpg"))
+	moved, err := store.GetBookByID("old")
+	require.NoError(t, err)
+	assert.Equal(t, target, moved.FilePath)
+	files, err := store.GetBookFiles("old")
+	require.NoError(t, err)
+	for _, f := range files {
+		assert.Equal(t, target, filepath.Dir(f.FilePath))
+	}
+
+	primary, err := store.GetBookByID("primary")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(cold, "Author"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cold, "Author", "New.m4b"), nil, 0o644))
+	assert.Error(t, moveBookToTier(store, primary, filepath.Join(cold, "Author", "New.m4b")), "an existing target is never overwritten")
+	assert.FileExists(t, primary.FilePath)
+}
@@ -1,5 +1,5 @@
 // file: internal/server/tag_roundtrip_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: b1c2d3e4-f5a6-7b8c-9d0e-1f2a3b4c5d6e
 
 package server
@@ -27,7 +27,7 @@ func TestBuildFullTagMap_AllFields(t *testing.T) {
 		ID:             "BOOK123",
 		Title:          "Return of the Archon",
 		SeriesID:       intPtrHelper(42),
-		SeriesSequence: intPtrHelper(5),
+		SeriesSequence: database.NewSeriesSeq(5),
 		Language:       stringPtr("english"),
 		Publisher:      stringPtr("Audible Studios"),
 		Description:    stringPtr("A great book"),
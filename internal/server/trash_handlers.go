@@ -0,0 +1,37 @@
+// file: internal/server/trash_handlers.go
+// version: 1.0.0
+// guid: 9f2b7d46-1e8c-4a35-b6d0-5c3e8a1f7b92
+// last-edited: 2026-10-17
+
+package server
+
+import (
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+)
+
+// listPendingPurges handles GET /api/v1/audiobooks/pending-purge: books
+// whose files are in the trash, soonest purge first. Restoring one is
+// POST /audiobooks/:id/restore.
+func (s *Server) listPendingPurges(c *gin.Context) {
+	store := s.Store()
+	if store == nil {
+		httputil.RespondWithInternalError(c, "database not initialized")
+		return
+	}
+	pending, err := database.ListPendingPurges(store)
+	if err != nil {
+		httputil.InternalError(c, "list pending purges failed", err)
+		return
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].PurgeAfter.Before(pending[j].PurgeAfter)
+	})
+	httputil.RespondWithOK(c, struct {
+		Items []database.PendingPurge `json:"items"`
+		Total int                     `json:"total"`
+	}{Items: pending, Total: len(pending)})
+}
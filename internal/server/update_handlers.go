@@ -1,15 +1,67 @@
 // file: internal/server/update_handlers.go
-// version: 2.1.0
+// version: 2.2.0
 // guid: 4c5d6e7f-8a9b-0c1d-2e3f-4a5b6c7d8e9f
 
 package server
 
 import (
+	"context"
+	"fmt"
+	"runtime"
+
 	"github.com/gin-gonic/gin"
+	"github.com/falkcorp/audiobook-organizer/internal/activity"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/httputil"
+	"github.com/falkcorp/audiobook-organizer/internal/plugin"
+	"github.com/falkcorp/audiobook-organizer/internal/updater"
 )
 
+// versionResponse is the body of GET /system/version.
+type versionResponse struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+	// UpdateCheckEnabled reports whether releases are polled in the
+	// background (update_check_enabled or auto_update_enabled).
+	UpdateCheckEnabled bool `json:"update_check_enabled"`
+	// Update is the most recent update check result; omitted until a
+	// check has run.
+	Update *updater.UpdateInfo `json:"update,omitempty"`
+}
+
+// getVersion returns the build's version, commit and build date plus the
+// last update check result, if any.
+func (s *Server) getVersion(c *gin.Context) {
+	resp := versionResponse{
+		Version:            appVersion,
+		Commit:             appCommit,
+		BuildDate:          appBuildDate,
+		GoVersion:          runtime.Version(),
+		Platform:           runtime.GOOS + "/" + runtime.GOARCH,
+		UpdateCheckEnabled: config.AppConfig.UpdateCheckEnabled || config.AppConfig.AutoUpdateEnabled,
+	}
+	if s.updater != nil {
+		resp.Update = s.updater.LastCheck()
+	}
+	httputil.RespondWithOK(c, resp)
+}
+
+// notifyUpdateAvailable is the updater's notifier: it publishes
+// update.available for notifier plugins and records it in the activity log.
+func (s *Server) notifyUpdateAvailable(info updater.UpdateInfo) {
+	s.publishEvent(context.Background(), plugin.NewEvent(plugin.EventUpdateAvailable, "", map[string]any{
+		"current_version": info.CurrentVersion,
+		"latest_version":  info.LatestVersion,
+		"channel":         info.Channel,
+		"release_url":     info.ReleaseURL,
+	}))
+	activity.EmitInfo(s.activityWriter, "", "update.available", "updater",
+		fmt.Sprintf("Update available: %s (running %s)", info.LatestVersion, info.CurrentVersion))
+}
+
 // getUpdateStatus returns the last update check info.
 func (s *Server) getUpdateStatus(c *gin.Context) {
 	info := s.updater.LastCheck()
@@ -1,7 +1,7 @@
 // file: internal/server/user_tags.go
-// version: 2.3.0
+// version: 2.4.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef0123456789
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package server
 
@@ -20,10 +20,10 @@ func normalizeTag(t string) string {
 }
 
 // setupUserTagRoutes registers the user tag API routes on the given router group.
-func (s *Server) setupUserTagRoutes(protected *gin.RouterGroup) {
-	protected.PUT("/audiobooks/:id/user-tags", s.setBookUserTags)
-	protected.POST("/audiobooks/:id/user-tags", s.addBookUserTag)
-	protected.DELETE("/audiobooks/:id/user-tags/:tag", s.removeBookUserTag)
+func (s *Server) setupUserTagRoutes(protected *routeGroup) {
+	protected.PUT("/audiobooks/:id/user-tags", noPermission, s.setBookUserTags)
+	protected.POST("/audiobooks/:id/user-tags", noPermission, s.addBookUserTag)
+	protected.DELETE("/audiobooks/:id/user-tags/:tag", noPermission, s.removeBookUserTag)
 }
 
 // setBookUserTags replaces all user-defined tags on a book.
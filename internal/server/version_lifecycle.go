@@ -1,7 +1,7 @@
 // file: internal/server/version_lifecycle.go
-// version: 1.2.0
+// version: 1.3.0
 // guid: 5a3b4c0d-6e7f-4a70-b8c5-3d7e0f1b9a99
-// last-edited: 2026-05-01
+// last-edited: 2026-10-17
 //
 // Version lifecycle HTTP handlers. Core logic lives in internal/versions.
 
@@ -140,9 +140,9 @@ func CleanupTrashedVersions(store database.Store) (purged int) {
 }
 
 // registerVersionLifecycleRoutes wires the version lifecycle endpoints.
-func (s *Server) registerVersionLifecycleRoutes(protected *gin.RouterGroup) {
-	protected.DELETE("/books/:id/versions/:vid", s.perm(auth.PermLibraryDelete), s.handleTrashVersion)
-	protected.POST("/books/:id/versions/:vid/restore", s.perm(auth.PermLibraryOrganize), s.handleRestoreVersion)
-	protected.POST("/books/:id/versions/:vid/purge-now", s.perm(auth.PermLibraryDelete), s.handlePurgeVersion)
-	protected.DELETE("/purged-versions/:vid", s.perm(auth.PermLibraryDelete), s.handleHardDeleteVersion)
+func (s *Server) registerVersionLifecycleRoutes(protected *routeGroup) {
+	protected.DELETE("/books/:id/versions/:vid", auth.PermLibraryDelete, s.handleTrashVersion)
+	protected.POST("/books/:id/versions/:vid/restore", auth.PermLibraryOrganize, s.handleRestoreVersion)
+	protected.POST("/books/:id/versions/:vid/purge-now", auth.PermLibraryDelete, s.handlePurgeVersion)
+	protected.DELETE("/purged-versions/:vid", auth.PermLibraryDelete, s.handleHardDeleteVersion)
 }
@@ -1,7 +1,7 @@
 // file: internal/server/wire_handlers.go
-// version: 2.8.0
+// version: 2.53.0
 // guid: f7a8b9c0-d1e2-3456-7890-abcdef012345
-// last-edited: 2026-06-10
+// last-edited: 2026-10-17
 
 package server
 
@@ -12,7 +12,9 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 	dedupengine "github.com/falkcorp/audiobook-organizer/internal/dedup"
+	"github.com/falkcorp/audiobook-organizer/internal/doctor"
 	"github.com/falkcorp/audiobook-organizer/internal/merge"
+	"github.com/falkcorp/audiobook-organizer/internal/scanner"
 	"github.com/falkcorp/audiobook-organizer/internal/server/handlers"
 	audiobookshandler "github.com/falkcorp/audiobook-organizer/internal/server/handlers/audiobooks"
 	deduphandler "github.com/falkcorp/audiobook-organizer/internal/server/handlers/dedup"
@@ -27,35 +29,34 @@ import (
 
 // wireHandlers instantiates handler structs and registers their routes.
 // Called from Start() after the protected group is created.
-func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFunc, protected *gin.RouterGroup) {
+func (s *Server) wireHandlers(api *routeGroup, authMiddleware gin.HandlerFunc, protected *routeGroup) {
 	authH := handlers.NewAuthHandler(s.Store(), config.AppConfig.EnableAuth)
 	apiKeyH := handlers.NewAPIKeyHandler(s.Store())
 
 	authGroup := api.Group("/auth")
 	{
-		authGroup.GET("/status", authH.GetStatus)
-		authGroup.POST("/setup", authH.SetupInitialAdmin)
-		authGroup.POST("/login", authH.Login)
-		authGroup.POST("/accept-invite", s.handleAcceptInvite)
-		authGroup.POST("/bootstrap", s.handleBootstrap)
+		authGroup.GET("/status", noPermission, authH.GetStatus)
+		authGroup.POST("/setup", noPermission, authH.SetupInitialAdmin)
+		authGroup.POST("/login", noPermission, authH.Login)
+		authGroup.POST("/accept-invite", noPermission, s.handleAcceptInvite)
+		authGroup.POST("/bootstrap", noPermission, s.handleBootstrap)
 	}
 
-	authProtected := authGroup.Group("")
-	authProtected.Use(authMiddleware)
+	authProtected := authGroup.Group("").Require(RouteAuthAuthenticated, authMiddleware)
 	{
-		authProtected.GET("/me", authH.Me)
-		authProtected.PATCH("/me", authH.UpdateMe)
-		authProtected.POST("/logout", authH.Logout)
-		authProtected.GET("/sessions", authH.ListMySessions)
-		authProtected.DELETE("/sessions/:id", authH.RevokeMySession)
-		authProtected.PUT("/me/password", authH.ChangePassword)
-		authProtected.POST("/temp-tokens", s.perm(permTempLoginMint()), s.createTempLoginToken)
+		authProtected.GET("/me", noPermission, authH.Me)
+		authProtected.PATCH("/me", noPermission, authH.UpdateMe)
+		authProtected.POST("/logout", noPermission, authH.Logout)
+		authProtected.GET("/sessions", noPermission, authH.ListMySessions)
+		authProtected.DELETE("/sessions/:id", noPermission, authH.RevokeMySession)
+		authProtected.PUT("/me/password", noPermission, authH.ChangePassword)
+		authProtected.POST("/temp-tokens", permTempLoginMint(), s.createTempLoginToken)
 
-		authProtected.POST("/api-keys", apiKeyH.Create)
-		authProtected.GET("/api-keys", apiKeyH.List)
-		authProtected.GET("/api-keys/:id", apiKeyH.Get)
-		authProtected.PATCH("/api-keys/:id", apiKeyH.UpdateStatus)
-		authProtected.DELETE("/api-keys/:id", apiKeyH.Revoke)
+		authProtected.POST("/api-keys", noPermission, apiKeyH.Create)
+		authProtected.GET("/api-keys", noPermission, apiKeyH.List)
+		authProtected.GET("/api-keys/:id", noPermission, apiKeyH.Get)
+		authProtected.PATCH("/api-keys/:id", noPermission, apiKeyH.UpdateStatus)
+		authProtected.DELETE("/api-keys/:id", noPermission, apiKeyH.Revoke)
 	}
 
 	// ── Build split-book candidate store ─────────────────────────────────────
@@ -70,7 +71,15 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 	cacheH := handlers.NewCacheHandler(s.metricsStore, s.Store())
 	activityH := handlers.NewActivityHandler(s.activityService, s.Store())
 	readingH := handlers.NewReadingHandler(s.Store())
+	// Lazy hub provider with a typed-nil guard, as for the system handler.
+	playbackH := handlers.NewPlaybackHandler(s.Store(), func() handlers.PlaybackEventSender {
+		if s.hub == nil {
+			return nil
+		}
+		return s.hub
+	})
 	userH := handlers.NewUserHandler(s.Store())
+	contentFilterH := handlers.NewContentFilterHandler(s.Store())
 	splitBookH := handlers.NewSplitBookHandler(s.opRegistry, splitBookCands, s.Store())
 	metaCacheH := handlers.NewMetadataCacheHandler(s.Store(), s.metadataFetchService, s.writeBackBatcher)
 	organizeH := handlers.NewOrganizeHandler(
@@ -94,8 +103,14 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 		config.AppConfig.AutoOrganize,
 	)
 	playlistH := handlers.NewPlaylistHandlerWithGetter(s.Store(), s.SearchIndex)
+	collectionH := handlers.NewCollectionHandler(s.Store())
 	pluginsH := handlers.NewPluginsHandler(s.pluginRegistry, config.AppConfig.Plugins)
 	versionsH := handlers.NewVersionsHandler(s.Store())
+	var doctorStore doctor.Store
+	if st := s.Store(); st != nil {
+		doctorStore = st
+	}
+	doctorH := handlers.NewDoctorHandler(doctorStore)
 
 	// Entities domain handler (authors/series/narrators/works). Guard typed-nil
 	// boxing for each interface-typed dep so the handler's nil checks (and the
@@ -217,6 +232,14 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 		func(id string) error {
 			return NewRevertService(s.Store()).RevertOperation(id)
 		},
+		func() string { return appVersion },
+		func(folderPath *string, forceUpdate bool, profile string) *scanner.ScanPrediction {
+			paths, err := s.Store().GetAllImportPaths()
+			if err != nil {
+				return nil
+			}
+			return scanner.PredictScan(paths, folderPath, forceUpdate, profile)
+		},
 	)
 	// getSystemLogs (system handler) delegates its operation_id branch to
 	// operationsH.GetOperationLogs; stash it on the Server for that call.
@@ -284,6 +307,7 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 		resetLibrarySizeCache,
 		func() string { return appVersion },
 		s.filterReviewedAuthorGroups,
+		s,
 	)
 	s.systemHandler = systemH
 
@@ -455,6 +479,7 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 		s.aiScanStore,
 		opReg,
 		diagParser,
+		func() string { return appVersion },
 	)
 
 	// Audiobooks domain handler (main library list / CRUD: list, count, facets,
@@ -596,180 +621,223 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 	)
 
 	// ── Public cache routes (no auth) ────────────────────────────────────────
-	api.GET("/cache/stats", cacheH.HandleCacheStats)
-	api.GET("/cache/stats/history", cacheH.HandleCacheStatsHistory)
+	api.GET("/cache/stats", noPermission, cacheH.HandleCacheStats)
+	api.GET("/cache/stats/history", noPermission, cacheH.HandleCacheStatsHistory)
 
 	// ── Protected routes ─────────────────────────────────────────────────────
 
 	// Activity log
-	protected.GET("/activity", s.perm(auth.PermLibraryView), activityH.ListActivity)
-	protected.GET("/activity/sources", s.perm(auth.PermLibraryView), activityH.ListActivitySources)
-	protected.POST("/activity/compact", s.perm(auth.PermSettingsManage), activityH.CompactActivity)
-	protected.GET("/operations/:id/activity", s.perm(auth.PermLibraryView), activityH.ListOperationActivity)
+	protected.GET("/activity", auth.PermLibraryView, activityH.ListActivity)
+	protected.GET("/activity/sources", auth.PermLibraryView, activityH.ListActivitySources)
+	protected.POST("/activity/compact", auth.PermSettingsManage, activityH.CompactActivity)
+	protected.GET("/operations/:id/activity", auth.PermLibraryView, activityH.ListOperationActivity)
+
+	// File event journal for external sync tools
+	protected.GET("/journal", auth.PermLibraryView, s.handleFileJournal)
 
 	// Split-book dedup
-	protected.POST("/dedup/split-book-scan", s.perm(auth.PermScanTrigger), splitBookH.TriggerSplitBookScan)
-	protected.GET("/dedup/split-book-candidates", s.perm(auth.PermLibraryView), splitBookH.ListSplitBookCandidates)
-	protected.POST("/dedup/split-book-candidates/:id/merge", s.perm(auth.PermLibraryEditMetadata), splitBookH.MergeSplitBookCandidate)
+	protected.POST("/dedup/split-book-scan", auth.PermScanTrigger, splitBookH.TriggerSplitBookScan)
+	protected.GET("/dedup/split-book-candidates", auth.PermLibraryView, splitBookH.ListSplitBookCandidates)
+	protected.POST("/dedup/split-book-candidates/:id/merge", auth.PermLibraryEditMetadata, splitBookH.MergeSplitBookCandidate)
 
 	// Filesystem + import paths
-	protected.GET("/filesystem/home", s.perm(auth.PermSettingsManage), filesystemH.GetHomeDirectory)
-	protected.GET("/filesystem/browse", s.perm(auth.PermSettingsManage), filesystemH.BrowseFilesystem)
-	protected.POST("/filesystem/exclude", s.perm(auth.PermSettingsManage), filesystemH.CreateExclusion)
-	protected.DELETE("/filesystem/exclude", s.perm(auth.PermSettingsManage), filesystemH.RemoveExclusion)
-	protected.GET("/import-paths", s.perm(auth.PermSettingsManage), filesystemH.ListImportPaths)
-	protected.POST("/import-paths", s.perm(auth.PermSettingsManage), filesystemH.AddImportPath)
-	protected.DELETE("/import-paths/:id", s.perm(auth.PermSettingsManage), filesystemH.RemoveImportPath)
-	protected.POST("/import/file", s.perm(auth.PermScanTrigger), filesystemH.ImportFile)
+	protected.GET("/filesystem/home", auth.PermSettingsManage, filesystemH.GetHomeDirectory)
+	protected.GET("/filesystem/browse", auth.PermSettingsManage, filesystemH.BrowseFilesystem)
+	protected.POST("/filesystem/exclude", auth.PermSettingsManage, filesystemH.CreateExclusion)
+	protected.DELETE("/filesystem/exclude", auth.PermSettingsManage, filesystemH.RemoveExclusion)
+	protected.GET("/import-paths", auth.PermSettingsManage, filesystemH.ListImportPaths)
+	protected.POST("/import-paths", auth.PermSettingsManage, filesystemH.AddImportPath)
+	protected.DELETE("/import-paths/:id", auth.PermSettingsManage, filesystemH.RemoveImportPath)
+	protected.PATCH("/import-paths/:id", auth.PermSettingsManage, filesystemH.UpdateImportPath)
+	protected.POST("/import/file", auth.PermScanTrigger, filesystemH.ImportFile)
+	protected.GET("/library/orphans", auth.PermLibraryView, filesystemH.ListOrphanFiles)
+	protected.POST("/library/orphans/import", auth.PermScanTrigger, filesystemH.ImportOrphanFiles)
+	protected.POST("/library/orphans/ignore", auth.PermLibraryEditMetadata, filesystemH.IgnoreOrphanFiles)
+	protected.POST("/library/orphans/delete", auth.PermLibraryDelete, filesystemH.DeleteOrphanFiles)
 
 	// Organize + rename
-	protected.POST("/audiobooks/:id/rename/preview", s.perm(auth.PermLibraryOrganize), organizeH.PreviewRename)
-	protected.POST("/audiobooks/:id/rename/apply", s.perm(auth.PermLibraryOrganize), organizeH.ApplyRename)
-	protected.GET("/audiobooks/:id/preview-organize", s.perm(auth.PermLibraryOrganize), organizeH.PreviewOrganize)
-	protected.POST("/audiobooks/:id/organize", s.perm(auth.PermLibraryOrganize), organizeH.OrganizeBook)
+	protected.POST("/audiobooks/:id/rename/preview", auth.PermLibraryOrganize, organizeH.PreviewRename)
+	protected.POST("/audiobooks/:id/rename/apply", auth.PermLibraryOrganize, organizeH.ApplyRename)
+	protected.GET("/audiobooks/:id/preview-organize", auth.PermLibraryOrganize, organizeH.PreviewOrganize)
+	protected.POST("/audiobooks/:id/organize", auth.PermLibraryOrganize, organizeH.OrganizeBook)
+	protected.POST("/organize/preview-pattern", auth.PermLibraryOrganize, organizeH.PreviewPattern)
 
 	// Metadata cache
-	protected.GET("/audiobooks/metadata/cached", s.perm(auth.PermLibraryView), metaCacheH.ListCachedCandidates)
-	protected.GET("/audiobooks/metadata/cache/review", s.perm(auth.PermLibraryView), metaCacheH.GetCacheReviewResults)
-	protected.POST("/audiobooks/metadata/batch-apply-cached", s.perm(auth.PermLibraryEditMetadata), metaCacheH.BatchApplyFromCache)
-	protected.POST("/audiobooks/:id/clear-no-match", s.perm(auth.PermLibraryEditMetadata), metaCacheH.ClearMetadataNoMatch)
+	protected.GET("/audiobooks/metadata/cached", auth.PermLibraryView, metaCacheH.ListCachedCandidates)
+	protected.GET("/audiobooks/metadata/cache/review", auth.PermLibraryView, metaCacheH.GetCacheReviewResults)
+	protected.POST("/audiobooks/metadata/batch-apply-cached", auth.PermLibraryEditMetadata, metaCacheH.BatchApplyFromCache)
+	protected.POST("/audiobooks/:id/clear-no-match", auth.PermLibraryEditMetadata, metaCacheH.ClearMetadataNoMatch)
 
 	// Reading progress
-	protected.POST("/books/:id/position", readingH.SetPosition)
-	protected.GET("/books/:id/position", readingH.GetPosition)
-	protected.GET("/books/:id/state", readingH.GetBookState)
-	protected.PATCH("/books/:id/status", readingH.SetBookStatus)
-	protected.DELETE("/books/:id/status", readingH.ClearBookStatus)
-	protected.GET("/me/:status", readingH.ListByStatus)
+	protected.POST("/books/:id/position", noPermission, readingH.SetPosition)
+	protected.GET("/books/:id/position", noPermission, readingH.GetPosition)
+	protected.GET("/books/:id/state", noPermission, readingH.GetBookState)
+	protected.PATCH("/books/:id/status", noPermission, readingH.SetBookStatus)
+	protected.DELETE("/books/:id/status", noPermission, readingH.ClearBookStatus)
+	protected.GET("/me/:status", noPermission, readingH.ListByStatus)
+
+	// Playback progress and listening stats
+	protected.PUT("/audiobooks/:id/progress", noPermission, playbackH.UpdateProgress)
+	protected.GET("/users/me/progress", noPermission, playbackH.ListMyProgress)
+	protected.GET("/stats/listening", noPermission, playbackH.GetListeningStats)
 
 	// Playlists
-	protected.GET("/playlists", s.perm(auth.PermLibraryView), playlistH.ListPlaylists)
-	protected.POST("/playlists", playlistH.CreatePlaylist)
-	protected.GET("/playlists/:id", playlistH.GetPlaylist)
-	protected.PUT("/playlists/:id", playlistH.UpdatePlaylist)
-	protected.DELETE("/playlists/:id", playlistH.DeletePlaylist)
-	protected.POST("/playlists/:id/books", playlistH.AddBooksToPlaylist)
-	protected.DELETE("/playlists/:id/books/:bookID", playlistH.RemoveBookFromPlaylist)
-	protected.POST("/playlists/:id/reorder", playlistH.ReorderPlaylist)
-	protected.POST("/playlists/:id/materialize", playlistH.MaterializePlaylist)
+	protected.GET("/playlists", auth.PermLibraryView, playlistH.ListPlaylists)
+	protected.POST("/playlists", noPermission, playlistH.CreatePlaylist)
+	protected.GET("/playlists/:id", noPermission, playlistH.GetPlaylist)
+	protected.PUT("/playlists/:id", noPermission, playlistH.UpdatePlaylist)
+	protected.DELETE("/playlists/:id", noPermission, playlistH.DeletePlaylist)
+	protected.POST("/playlists/:id/books", noPermission, playlistH.AddBooksToPlaylist)
+	protected.DELETE("/playlists/:id/books/:bookID", noPermission, playlistH.RemoveBookFromPlaylist)
+	protected.POST("/playlists/:id/reorder", noPermission, playlistH.ReorderPlaylist)
+	protected.POST("/playlists/:id/materialize", noPermission, playlistH.MaterializePlaylist)
+
+	// Collections (user-curated shelves)
+	protected.GET("/collections", auth.PermLibraryView, collectionH.ListCollections)
+	protected.POST("/collections", noPermission, collectionH.CreateCollection)
+	protected.GET("/collections/:id", noPermission, collectionH.GetCollection)
+	protected.PUT("/collections/:id", noPermission, collectionH.UpdateCollection)
+	protected.DELETE("/collections/:id", noPermission, collectionH.DeleteCollection)
+	protected.POST("/collections/:id/books", noPermission, collectionH.AddBooksToCollection)
+	protected.DELETE("/collections/:id/books/:bookID", noPermission, collectionH.RemoveBookFromCollection)
+	protected.POST("/collections/:id/reorder", noPermission, collectionH.ReorderCollection)
 
 	// User management
 	users := protected.Group("/users")
 	{
-		users.GET("", s.perm("users.manage"), userH.ListUsers)
-		users.POST("/invite", s.perm("users.manage"), userH.CreateInvite)
-		users.GET("/invites", s.perm("users.manage"), userH.ListInvites)
-		users.DELETE("/invites/:token", s.perm("users.manage"), userH.DeleteInvite)
-		users.POST("/:id/deactivate", s.perm("users.manage"), userH.DeactivateUser)
-		users.POST("/:id/reactivate", s.perm("users.manage"), userH.ReactivateUser)
-		users.POST("/:id/reset-password", s.perm("users.manage"), userH.ResetPassword)
+		users.GET("", "users.manage", userH.ListUsers)
+		users.POST("/invite", "users.manage", userH.CreateInvite)
+		users.GET("/invites", "users.manage", userH.ListInvites)
+		users.DELETE("/invites/:token", "users.manage", userH.DeleteInvite)
+		users.POST("/:id/deactivate", "users.manage", userH.DeactivateUser)
+		users.POST("/:id/reactivate", "users.manage", userH.ReactivateUser)
+		users.POST("/:id/reset-password", "users.manage", userH.ResetPassword)
+		users.GET("/:id/content-filter", "users.manage", contentFilterH.GetContentFilter)
+		users.PUT("/:id/content-filter", "users.manage", contentFilterH.UpdateContentFilter)
 	}
 
 	// Version groups
-	protected.GET("/audiobooks/:id/versions", s.perm(auth.PermLibraryView), versionsH.ListAudiobookVersions)
-	protected.POST("/audiobooks/:id/versions", s.perm(auth.PermLibraryEditMetadata), versionsH.LinkAudiobookVersion)
-	protected.PUT("/audiobooks/:id/set-primary", s.perm(auth.PermLibraryEditMetadata), versionsH.SetAudiobookPrimary)
-	protected.POST("/audiobooks/:id/split-version", s.perm(auth.PermLibraryEditMetadata), versionsH.SplitVersion)
-	protected.POST("/audiobooks/:id/split-to-books", s.perm(auth.PermLibraryEditMetadata), versionsH.SplitSegmentsToBooks)
-	protected.POST("/audiobooks/:id/move-segments", s.perm(auth.PermLibraryEditMetadata), versionsH.MoveSegments)
-	protected.GET("/version-groups/:id", s.perm(auth.PermLibraryView), versionsH.GetVersionGroup)
+	protected.GET("/audiobooks/:id/versions", auth.PermLibraryView, versionsH.ListAudiobookVersions)
+	protected.POST("/audiobooks/:id/versions", auth.PermLibraryEditMetadata, versionsH.LinkAudiobookVersion)
+	protected.PUT("/audiobooks/:id/set-primary", auth.PermLibraryEditMetadata, versionsH.SetAudiobookPrimary)
+	protected.POST("/audiobooks/:id/split-version", auth.PermLibraryEditMetadata, versionsH.SplitVersion)
+	protected.POST("/audiobooks/:id/split-to-books", auth.PermLibraryEditMetadata, versionsH.SplitSegmentsToBooks)
+	protected.POST("/audiobooks/:id/move-segments", auth.PermLibraryEditMetadata, versionsH.MoveSegments)
+	protected.GET("/version-groups/:id", auth.PermLibraryView, versionsH.GetVersionGroup)
+	protected.GET("/audiobooks/upgrades", auth.PermLibraryView, versionsH.ListUpgrades)
+	protected.POST("/audiobooks/upgrades/accept", auth.PermLibraryEditMetadata, versionsH.AcceptUpgrades)
 
 	// iTunes (12 migrated routes; survivors stay in server_lifecycle.go).
 	// Two protected.Group("/itunes") blocks (here + survivors) is fine in Gin
 	// since there is no duplicate method+path.
 	itunesG := protected.Group("/itunes")
 	{
-		itunesG.POST("/validate", s.perm(auth.PermLibraryEditMetadata), itunesH.Validate)
-		itunesG.POST("/test-mapping", s.perm(auth.PermLibraryEditMetadata), itunesH.TestMapping)
-		itunesG.POST("/import", s.perm(auth.PermLibraryEditMetadata), itunesH.Import)
-		itunesG.POST("/write-back", s.perm(auth.PermLibraryEditMetadata), itunesH.WriteBack)
-		itunesG.POST("/write-back-all", s.perm(auth.PermLibraryEditMetadata), itunesH.WriteBackAll)
-		itunesG.GET("/library-stats", s.perm(auth.PermLibraryView), itunesH.LibraryStats)
-		itunesG.POST("/write-back/preview", s.perm(auth.PermLibraryEditMetadata), itunesH.WriteBackPreview)
-		itunesG.GET("/books", s.perm(auth.PermLibraryView), itunesH.ListBooks)
-		itunesG.GET("/import-status/:id", s.perm(auth.PermLibraryView), itunesH.ImportStatus)
-		itunesG.POST("/import-status/bulk", s.perm(auth.PermLibraryEditMetadata), itunesH.ImportStatusBulk)
-		itunesG.GET("/library-status", s.perm(auth.PermLibraryView), itunesH.LibraryStatus)
-		itunesG.POST("/sync", s.perm(auth.PermLibraryEditMetadata), itunesH.Sync)
+		itunesG.POST("/validate", auth.PermLibraryEditMetadata, itunesH.Validate)
+		itunesG.POST("/test-mapping", auth.PermLibraryEditMetadata, itunesH.TestMapping)
+		itunesG.POST("/import", auth.PermLibraryEditMetadata, itunesH.Import)
+		itunesG.POST("/write-back", auth.PermLibraryEditMetadata, itunesH.WriteBack)
+		itunesG.POST("/write-back-all", auth.PermLibraryEditMetadata, itunesH.WriteBackAll)
+		itunesG.GET("/library-stats", auth.PermLibraryView, itunesH.LibraryStats)
+		itunesG.POST("/write-back/preview", auth.PermLibraryEditMetadata, itunesH.WriteBackPreview)
+		itunesG.GET("/books", auth.PermLibraryView, itunesH.ListBooks)
+		itunesG.GET("/import-status/:id", auth.PermLibraryView, itunesH.ImportStatus)
+		itunesG.POST("/import-status/bulk", auth.PermLibraryEditMetadata, itunesH.ImportStatusBulk)
+		itunesG.GET("/library-status", auth.PermLibraryView, itunesH.LibraryStatus)
+		itunesG.POST("/sync", auth.PermLibraryEditMetadata, itunesH.Sync)
 	}
 
 	// AI domain (migrated from server_lifecycle.go).
-	protected.POST("/authors/duplicates/ai-review", s.perm(auth.PermLibraryEditMetadata), aiH.ReviewDuplicateAuthors)
-	protected.POST("/authors/duplicates/ai-review/apply", s.perm(auth.PermLibraryEditMetadata), aiH.ApplyAuthorReview)
-	protected.POST("/ai/parse-filename", s.perm(auth.PermLibraryEditMetadata), aiH.ParseFilename)
-	protected.POST("/ai/test-connection", s.perm(auth.PermLibraryEditMetadata), aiH.TestConnection)
+	protected.POST("/authors/duplicates/ai-review", auth.PermLibraryEditMetadata, aiH.ReviewDuplicateAuthors)
+	protected.POST("/authors/duplicates/ai-review/apply", auth.PermLibraryEditMetadata, aiH.ApplyAuthorReview)
+	protected.POST("/ai/parse-filename", auth.PermLibraryEditMetadata, aiH.ParseFilename)
+	protected.POST("/ai/test-connection", auth.PermLibraryEditMetadata, aiH.TestConnection)
 	aiScans := protected.Group("/ai/scans")
 	{
-		aiScans.POST("", s.perm(auth.PermLibraryEditMetadata), aiH.StartScan)
-		aiScans.GET("", s.perm(auth.PermLibraryView), aiH.ListScans)
-		aiScans.GET("/compare", aiH.CompareScans) // Must be before /:id to avoid conflict
-		aiScans.GET("/:id", s.perm(auth.PermLibraryView), aiH.GetScan)
-		aiScans.GET("/:id/results", s.perm(auth.PermLibraryView), aiH.GetScanResults)
-		aiScans.POST("/:id/apply", s.perm(auth.PermLibraryEditMetadata), aiH.ApplyScanResults)
-		aiScans.POST("/:id/cancel", s.perm(auth.PermLibraryEditMetadata), aiH.CancelScan)
-		aiScans.DELETE("/:id", s.perm(auth.PermLibraryDelete), aiH.DeleteScan)
-	}
-	protected.POST("/metadata-sources/test", s.perm(auth.PermSettingsManage), aiH.TestMetadataSource)
-	protected.POST("/audiobooks/:id/parse-with-ai", s.perm(auth.PermLibraryEditMetadata), aiH.ParseAudiobook)
-	protected.GET("/ai-jobs", s.perm(auth.PermSettingsManage), aiH.ListAIJobs)
+		aiScans.POST("", auth.PermLibraryEditMetadata, aiH.StartScan)
+		aiScans.GET("", auth.PermLibraryView, aiH.ListScans)
+		aiScans.GET("/compare", noPermission, aiH.CompareScans) // Must be before /:id to avoid conflict
+		aiScans.GET("/:id", auth.PermLibraryView, aiH.GetScan)
+		aiScans.GET("/:id/results", auth.PermLibraryView, aiH.GetScanResults)
+		aiScans.POST("/:id/apply", auth.PermLibraryEditMetadata, aiH.ApplyScanResults)
+		aiScans.POST("/:id/cancel", auth.PermLibraryEditMetadata, aiH.CancelScan)
+		aiScans.DELETE("/:id", auth.PermLibraryDelete, aiH.DeleteScan)
+	}
+	protected.POST("/metadata-sources/test", auth.PermSettingsManage, aiH.TestMetadataSource)
+	protected.POST("/audiobooks/:id/parse-with-ai", auth.PermLibraryEditMetadata, aiH.ParseAudiobook)
+	protected.GET("/ai-jobs", auth.PermSettingsManage, aiH.ListAIJobs)
 
 	// Entities domain (migrated from server_lifecycle.go): authors, narrators,
 	// series, and works. Paths + permission guards copied verbatim. Sibling
 	// /authors/duplicates*, /series/duplicates*, /authors/duplicates/ai-review*
 	// (now aiH.*) and the entity-tag routes stay on *Server / their own handlers.
-	protected.GET("/authors", s.perm(auth.PermLibraryView), entitiesH.ListAuthors)
-	protected.GET("/authors/count", s.perm(auth.PermLibraryView), entitiesH.CountAuthors)
-	protected.POST("/authors/merge", s.perm(auth.PermLibraryEditMetadata), entitiesH.MergeAuthors)
-	protected.POST("/authors/:id/reclassify-as-narrator", s.perm(auth.PermLibraryEditMetadata), entitiesH.ReclassifyAuthorAsNarrator)
-	protected.PUT("/authors/:id/name", s.perm(auth.PermLibraryEditMetadata), entitiesH.RenameAuthor)
-	protected.POST("/authors/:id/split", s.perm(auth.PermLibraryEditMetadata), entitiesH.SplitCompositeAuthor)
-	protected.POST("/authors/:id/resolve-production", s.perm(auth.PermLibraryEditMetadata), entitiesH.ResolveProductionAuthor)
-	protected.GET("/authors/:id/aliases", s.perm(auth.PermLibraryView), entitiesH.GetAuthorAliases)
-	protected.POST("/authors/:id/aliases", s.perm(auth.PermLibraryEditMetadata), entitiesH.CreateAuthorAlias)
-	protected.DELETE("/authors/:id/aliases/:aliasId", s.perm(auth.PermLibraryDelete), entitiesH.DeleteAuthorAlias)
-	protected.GET("/authors/:id/books", s.perm(auth.PermLibraryView), entitiesH.GetAuthorBooks)
-	protected.DELETE("/authors/:id", s.perm(auth.PermLibraryDelete), entitiesH.DeleteAuthor)
-	protected.POST("/authors/bulk-delete", s.perm(auth.PermLibraryDelete), entitiesH.BulkDeleteAuthors)
-
-	protected.GET("/narrators", s.perm(auth.PermLibraryView), entitiesH.ListNarrators)
-	protected.GET("/narrators/count", s.perm(auth.PermLibraryView), entitiesH.CountNarrators)
-	protected.GET("/audiobooks/:id/narrators", s.perm(auth.PermLibraryView), entitiesH.ListAudiobookNarrators)
-	protected.PUT("/audiobooks/:id/narrators", s.perm(auth.PermLibraryEditMetadata), entitiesH.SetAudiobookNarrators)
-
-	protected.GET("/series", s.perm(auth.PermLibraryView), entitiesH.ListSeries)
-	protected.GET("/series/count", s.perm(auth.PermLibraryView), entitiesH.CountSeries)
-	protected.PATCH("/series/:id", s.perm(auth.PermLibraryEditMetadata), entitiesH.UpdateSeriesName)
-	protected.GET("/series/:id/books", s.perm(auth.PermLibraryView), entitiesH.GetSeriesBooks)
-	protected.PUT("/series/:id/name", s.perm(auth.PermLibraryEditMetadata), entitiesH.RenameSeries)
-	protected.POST("/series/:id/split", s.perm(auth.PermLibraryEditMetadata), entitiesH.SplitSeries)
-	protected.DELETE("/series/:id", s.perm(auth.PermLibraryDelete), entitiesH.DeleteEmptySeries)
-	protected.POST("/series/bulk-delete", s.perm(auth.PermLibraryDelete), entitiesH.BulkDeleteSeries)
-
-	protected.GET("/works", s.perm(auth.PermLibraryView), entitiesH.ListWorks)
-	protected.POST("/works", s.perm(auth.PermLibraryEditMetadata), entitiesH.CreateWork)
-	protected.GET("/works/:id", s.perm(auth.PermLibraryView), entitiesH.GetWork)
-	protected.PUT("/works/:id", s.perm(auth.PermLibraryEditMetadata), entitiesH.UpdateWork)
-	protected.DELETE("/works/:id", s.perm(auth.PermLibraryDelete), entitiesH.DeleteWork)
-	protected.GET("/works/:id/books", s.perm(auth.PermLibraryView), entitiesH.ListWorkBooks)
-	protected.GET("/work", s.perm(auth.PermLibraryView), entitiesH.ListWork)
-	protected.GET("/work/stats", s.perm(auth.PermLibraryView), entitiesH.GetWorkStats)
+	protected.GET("/authors", auth.PermLibraryView, entitiesH.ListAuthors)
+	protected.GET("/authors/count", auth.PermLibraryView, entitiesH.CountAuthors)
+	protected.POST("/authors/merge", auth.PermLibraryEditMetadata, entitiesH.MergeAuthors)
+	protected.POST("/authors/:id/reclassify-as-narrator", auth.PermLibraryEditMetadata, entitiesH.ReclassifyAuthorAsNarrator)
+	protected.PUT("/authors/:id/name", auth.PermLibraryEditMetadata, entitiesH.RenameAuthor)
+	protected.POST("/authors/:id/split", auth.PermLibraryEditMetadata, entitiesH.SplitCompositeAuthor)
+	protected.POST("/authors/:id/resolve-production", auth.PermLibraryEditMetadata, entitiesH.ResolveProductionAuthor)
+	protected.GET("/authors/:id/aliases", auth.PermLibraryView, entitiesH.GetAuthorAliases)
+	protected.POST("/authors/:id/aliases", auth.PermLibraryEditMetadata, entitiesH.CreateAuthorAlias)
+	protected.DELETE("/authors/:id/aliases/:aliasId", auth.PermLibraryDelete, entitiesH.DeleteAuthorAlias)
+	protected.GET("/authors/:id/books", auth.PermLibraryView, entitiesH.GetAuthorBooks)
+	protected.DELETE("/authors/:id", auth.PermLibraryDelete, entitiesH.DeleteAuthor)
+	protected.POST("/authors/bulk-delete", auth.PermLibraryDelete, entitiesH.BulkDeleteAuthors)
+	protected.PUT("/authors/:id/authority", auth.PermLibraryEditMetadata, entitiesH.SetAuthorAuthority)
+	protected.POST("/authors/:id/namesakes", auth.PermLibraryEditMetadata, entitiesH.CreateAuthorNamesake)
+	protected.GET("/authors/resolutions", auth.PermLibraryView, entitiesH.ListAuthorResolutions)
+	protected.POST("/authors/resolutions/:id/resolve", auth.PermLibraryEditMetadata, entitiesH.ResolveAuthorResolution)
+	protected.GET("/entities/auto-merges", auth.PermLibraryView, entitiesH.ListAutoMerges)
+
+	protected.GET("/narrators", auth.PermLibraryView, entitiesH.ListNarrators)
+	protected.GET("/narrators/count", auth.PermLibraryView, entitiesH.CountNarrators)
+	protected.GET("/audiobooks/:id/authors", auth.PermLibraryView, entitiesH.ListAudiobookAuthors)
+	protected.PUT("/audiobooks/:id/authors", auth.PermLibraryEditMetadata, entitiesH.SetAudiobookAuthors)
+	protected.GET("/audiobooks/:id/narrators", auth.PermLibraryView, entitiesH.ListAudiobookNarrators)
+	protected.PUT("/audiobooks/:id/narrators", auth.PermLibraryEditMetadata, entitiesH.SetAudiobookNarrators)
+
+	protected.GET("/series", auth.PermLibraryView, entitiesH.ListSeries)
+	protected.GET("/series/count", auth.PermLibraryView, entitiesH.CountSeries)
+	protected.PATCH("/series/:id", auth.PermLibraryEditMetadata, entitiesH.UpdateSeriesName)
+	protected.GET("/series/:id/books", auth.PermLibraryView, entitiesH.GetSeriesBooks)
+	protected.PUT("/series/:id/name", auth.PermLibraryEditMetadata, entitiesH.RenameSeries)
+	protected.POST("/series/:id/split", auth.PermLibraryEditMetadata, entitiesH.SplitSeries)
+	protected.DELETE("/series/:id", auth.PermLibraryDelete, entitiesH.DeleteEmptySeries)
+	protected.POST("/series/bulk-delete", auth.PermLibraryDelete, entitiesH.BulkDeleteSeries)
+
+	protected.GET("/works", auth.PermLibraryView, entitiesH.ListWorks)
+	protected.POST("/works", auth.PermLibraryEditMetadata, entitiesH.CreateWork)
+	protected.POST("/works/merge", auth.PermLibraryEditMetadata, entitiesH.MergeWorks)
+	protected.GET("/works/:id", auth.PermLibraryView, entitiesH.GetWork)
+	protected.PUT("/works/:id", auth.PermLibraryEditMetadata, entitiesH.UpdateWork)
+	protected.DELETE("/works/:id", auth.PermLibraryDelete, entitiesH.DeleteWork)
+	protected.GET("/works/:id/books", auth.PermLibraryView, entitiesH.ListWorkBooks)
+	protected.POST("/works/:id/split", auth.PermLibraryEditMetadata, entitiesH.SplitWork)
+	protected.GET("/work", auth.PermLibraryView, entitiesH.ListWork)
+	protected.GET("/work/stats", auth.PermLibraryView, entitiesH.GetWorkStats)
 
 	// Diagnostics (migrated from server_lifecycle.go).
-	protected.GET("/diagnostics/db-health", s.perm(auth.PermSettingsManage), diagH.GetDBHealth)
-	protected.POST("/diagnostics/export", s.perm(auth.PermSettingsManage), diagH.StartExport)
-	protected.GET("/diagnostics/export/:operationId/download", s.perm(auth.PermSettingsManage), diagH.DownloadExport)
-	protected.POST("/diagnostics/submit-ai", s.perm(auth.PermSettingsManage), diagH.SubmitAI)
-	protected.GET("/diagnostics/ai-results/:operationId", s.perm(auth.PermSettingsManage), diagH.GetAIResults)
-	protected.POST("/diagnostics/apply-suggestions", s.perm(auth.PermSettingsManage), diagH.ApplySuggestions)
+	protected.GET("/diagnostics/db-health", auth.PermSettingsManage, diagH.GetDBHealth)
+	protected.POST("/diagnostics/export", auth.PermSettingsManage, diagH.StartExport)
+	protected.GET("/diagnostics/export/:operationId/download", auth.PermSettingsManage, diagH.DownloadExport)
+	protected.GET("/diagnostics/issue-bundle", auth.PermSettingsManage, diagH.DownloadIssueBundle)
+	protected.POST("/diagnostics/submit-ai", auth.PermSettingsManage, diagH.SubmitAI)
+	protected.GET("/diagnostics/ai-results/:operationId", auth.PermSettingsManage, diagH.GetAIResults)
+	protected.POST("/diagnostics/apply-suggestions", auth.PermSettingsManage, diagH.ApplySuggestions)
 
 	// Operations v2 (UOS-06)
-	protected.GET("/operations/timeline", s.perm(auth.PermLibraryView), opsV2H.GetOperationTimeline)
-	protected.GET("/operations/events", s.perm(auth.PermLibraryView), opsV2H.OperationsSSE)
-	protected.GET("/operations/v2/:id", s.perm(auth.PermLibraryView), opsV2H.GetOperationV2)
-	protected.DELETE("/operations/v2/:id", s.perm(auth.PermSettingsManage), opsV2H.CancelOperationV2)
-	protected.POST("/operations/v2", s.perm(auth.PermScanTrigger), opsV2H.TriggerOperationV2)
-	protected.GET("/op-defs", s.perm(auth.PermLibraryView), opsV2H.ListOpDefs)
-	protected.GET("/op-defs/:id", s.perm(auth.PermLibraryView), opsV2H.GetOpDef)
+	protected.GET("/operations/timeline", auth.PermLibraryView, opsV2H.GetOperationTimeline)
+	protected.GET("/operations/events", auth.PermLibraryView, opsV2H.OperationsSSE)
+	protected.GET("/operations/:id/logs/stream", auth.PermLibraryView, opsV2H.OperationLogsSSE)
+	protected.GET("/operations/v2/:id", auth.PermLibraryView, opsV2H.GetOperationV2)
+	protected.DELETE("/operations/v2/:id", auth.PermSettingsManage, opsV2H.CancelOperationV2)
+	protected.POST("/operations/v2", auth.PermScanTrigger, opsV2H.TriggerOperationV2)
+	protected.GET("/operations/queue", auth.PermLibraryView, opsV2H.GetOperationQueue)
+	protected.POST("/operations/queue/pause", auth.PermSettingsManage, opsV2H.PauseOperationQueue)
+	protected.POST("/operations/queue/resume", auth.PermSettingsManage, opsV2H.ResumeOperationQueue)
+	protected.POST("/operations/queue/reorder", auth.PermSettingsManage, opsV2H.ReorderOperationQueue)
+	protected.GET("/op-defs", auth.PermLibraryView, opsV2H.ListOpDefs)
+	protected.GET("/op-defs/:id", auth.PermLibraryView, opsV2H.GetOpDef)
 
 	// Operations domain (migrated from server_lifecycle.go). Paths + permission
 	// guards copied verbatim. These share the /operations path prefix with the
@@ -777,90 +845,124 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 	// that stay in server_lifecycle.go (active/recent/reconcile/itunes-path-*/
 	// cleanup-version-groups/results/file-ops) — all distinct method+path pairs,
 	// all using the identical `:id` param name, so Gin registers them cleanly.
-	protected.GET("/operations", s.perm(auth.PermLibraryView), operationsH.ListOperations)
-	protected.GET("/operations/stale", s.perm(auth.PermLibraryView), operationsH.ListStaleOperations)
-	protected.POST("/operations/scan", s.perm(auth.PermScanTrigger), operationsH.StartScan)
-	protected.POST("/operations/organize", s.perm(auth.PermScanTrigger), operationsH.StartOrganize)
-	protected.POST("/operations/transcode", s.perm(auth.PermScanTrigger), operationsH.StartTranscode)
-	protected.POST("/operations/optimize", s.perm(auth.PermScanTrigger), operationsH.StartOptimize)
-	protected.GET("/operations/:id/status", s.perm(auth.PermLibraryView), operationsH.GetOperationStatus)
-	protected.GET("/operations/:id/logs", s.perm(auth.PermLibraryView), operationsH.GetOperationLogs)
-	protected.GET("/operations/:id/result", s.perm(auth.PermLibraryView), operationsH.GetOperationResult)
-	protected.DELETE("/operations/:id", s.perm(auth.PermSettingsManage), operationsH.CancelOperation)
-	protected.POST("/operations/clear-stale", s.perm(auth.PermSettingsManage), operationsH.ClearStaleOperations)
-	protected.DELETE("/operations/history", s.perm(auth.PermSettingsManage), operationsH.DeleteOperationHistory)
-	protected.POST("/operations/optimize-database", s.perm(auth.PermSettingsManage), operationsH.OptimizeDatabase)
-	protected.POST("/operations/sweep-tombstones", s.perm(auth.PermSettingsManage), operationsH.SweepTombstones)
-	protected.POST("/operations/set-internal-flag", s.perm(auth.PermSettingsManage), operationsH.SetInternalFlag)
-	protected.GET("/operations/audit-files", s.perm(auth.PermSettingsManage), operationsH.AuditFileConsistency)
-	protected.GET("/operations/:id/changes", s.perm(auth.PermLibraryView), operationsH.GetOperationChanges)
-	protected.GET("/operations/:id/undo/preflight", s.perm(auth.PermLibraryView), operationsH.UndoPreflightHandler)
-	protected.POST("/operations/:id/revert", s.perm(auth.PermLibraryOrganize), operationsH.RevertOperation)
-	protected.GET("/tasks", s.perm(auth.PermSettingsManage), operationsH.ListTasks)
-	protected.POST("/tasks/:name/run", s.perm(auth.PermSettingsManage), operationsH.RunTask)
-	protected.PUT("/tasks/:name", s.perm(auth.PermSettingsManage), operationsH.UpdateTaskConfig)
-	protected.POST("/maintenance-window/run", s.perm(auth.PermSettingsManage), operationsH.RunMaintenanceWindowNow)
-	protected.GET("/maintenance-window/status", s.perm(auth.PermSettingsManage), operationsH.GetMaintenanceWindowStatus)
-	protected.PUT("/maintenance-window/config", s.perm(auth.PermSettingsManage), operationsH.UpdateMaintenanceWindowConfig)
+	protected.GET("/operations", auth.PermLibraryView, operationsH.ListOperations)
+	protected.GET("/operations/stale", auth.PermLibraryView, operationsH.ListStaleOperations)
+	protected.GET("/operations/archive", auth.PermLibraryView, operationsH.ListArchivedOperations)
+	protected.GET("/operations/archive/:id", auth.PermLibraryView, operationsH.GetArchivedOperation)
+	protected.POST("/operations/scan", auth.PermScanTrigger, operationsH.StartScan)
+	protected.GET("/operations/scan/estimate", auth.PermScanTrigger, s.handleScanEstimate)
+	protected.POST("/operations/organize", auth.PermScanTrigger, operationsH.StartOrganize)
+	protected.POST("/operations/transcode", auth.PermScanTrigger, operationsH.StartTranscode)
+	protected.POST("/operations/optimize", auth.PermScanTrigger, operationsH.StartOptimize)
+	protected.GET("/operations/:id/status", auth.PermLibraryView, operationsH.GetOperationStatus)
+	protected.GET("/operations/:id/logs", auth.PermLibraryView, operationsH.GetOperationLogs)
+	protected.GET("/operations/:id/logs/export", auth.PermLibraryView, operationsH.ExportOperationLogs)
+	protected.GET("/operations/:id/result", auth.PermLibraryView, operationsH.GetOperationResult)
+	protected.DELETE("/operations/:id", auth.PermSettingsManage, operationsH.CancelOperation)
+	protected.POST("/operations/clear-stale", auth.PermSettingsManage, operationsH.ClearStaleOperations)
+	protected.DELETE("/operations/history", auth.PermSettingsManage, operationsH.DeleteOperationHistory)
+	protected.POST("/operations/optimize-database", auth.PermSettingsManage, operationsH.OptimizeDatabase)
+	protected.POST("/operations/sweep-tombstones", auth.PermSettingsManage, operationsH.SweepTombstones)
+	protected.POST("/operations/set-internal-flag", auth.PermSettingsManage, operationsH.SetInternalFlag)
+	protected.GET("/operations/audit-files", auth.PermSettingsManage, operationsH.AuditFileConsistency)
+	protected.GET("/operations/:id/changes", auth.PermLibraryView, operationsH.GetOperationChanges)
+	protected.GET("/operations/:id/undo/preflight", auth.PermLibraryView, operationsH.UndoPreflightHandler)
+	protected.POST("/operations/:id/revert", auth.PermLibraryOrganize, operationsH.RevertOperation)
+	protected.GET("/snapshots", auth.PermLibraryView, s.handleListSnapshots)
+	protected.GET("/operations/:id/snapshot", auth.PermLibraryView, s.handleGetOperationSnapshot)
+	protected.POST("/operations/:id/snapshot/restore", auth.PermSettingsManage, s.handleRestoreOperationSnapshot)
+	protected.DELETE("/operations/:id/snapshot", auth.PermSettingsManage, s.handleDeleteOperationSnapshot)
+	protected.GET("/tasks", auth.PermSettingsManage, operationsH.ListTasks)
+	protected.POST("/tasks/:name/run", auth.PermSettingsManage, operationsH.RunTask)
+	protected.GET("/tasks/:name/history", auth.PermSettingsManage, s.handleTaskHistory)
+	protected.PUT("/tasks/:name", auth.PermSettingsManage, operationsH.UpdateTaskConfig)
+	protected.GET("/schedules", auth.PermSettingsManage, s.handleListSchedules)
+	protected.POST("/schedules", auth.PermSettingsManage, s.handleCreateSchedule)
+	protected.GET("/schedules/:id", auth.PermSettingsManage, s.handleGetSchedule)
+	protected.PUT("/schedules/:id", auth.PermSettingsManage, s.handleUpdateSchedule)
+	protected.DELETE("/schedules/:id", auth.PermSettingsManage, s.handleDeleteSchedule)
+	protected.POST("/maintenance-window/run", auth.PermSettingsManage, operationsH.RunMaintenanceWindowNow)
+	protected.GET("/maintenance-window/status", auth.PermSettingsManage, operationsH.GetMaintenanceWindowStatus)
+	protected.PUT("/maintenance-window/config", auth.PermSettingsManage, operationsH.UpdateMaintenanceWindowConfig)
 
 	// System domain (migrated from server_lifecycle.go). Paths + permission
 	// guards copied verbatim. The public /health (x3) and /api/events routes stay
-	// in setupRoutes — they are registered on s.router BEFORE the /api/* redirect
+	// in setupRoutes — they are registered on s.router BEFORE the /api/* versioning
 	// middleware, so re-registering them here would change their middleware
 	// ordering; they delegate to systemH via closures instead.
-	protected.GET("/policy/tags", s.perm(auth.PermLibraryView), systemH.HandlePolicyTags)
-	protected.GET("/system/status", s.perm(auth.PermSettingsManage), systemH.GetSystemStatus)
-	protected.GET("/system/announcements", s.perm(auth.PermSettingsManage), systemH.GetSystemAnnouncements)
-	protected.GET("/system/storage", s.perm(auth.PermSettingsManage), systemH.GetSystemStorage)
-	protected.GET("/system/logs", s.perm(auth.PermSettingsManage), systemH.GetSystemLogs)
-	protected.GET("/system/activity-log", s.perm(auth.PermSettingsManage), systemH.GetSystemActivityLog)
-	protected.POST("/system/reset", s.perm(auth.PermSettingsManage), systemH.ResetSystem)
-	protected.POST("/system/factory-reset", s.perm(auth.PermSettingsManage), systemH.FactoryReset)
-	protected.GET("/config", s.perm(auth.PermSettingsManage), systemH.GetConfig)
-	protected.PUT("/config", s.perm(auth.PermSettingsManage), systemH.UpdateConfig)
-	protected.GET("/dashboard", s.perm(auth.PermLibraryView), systemH.GetDashboard)
-	protected.POST("/backup/create", s.perm(auth.PermSettingsManage), systemH.CreateBackup)
-	protected.GET("/backup/list", s.perm(auth.PermSettingsManage), systemH.ListBackups)
-	protected.POST("/backup/restore", s.perm(auth.PermSettingsManage), systemH.RestoreBackup)
-	protected.DELETE("/backup/:filename", s.perm(auth.PermSettingsManage), systemH.DeleteBackup)
-	protected.GET("/library/quick-queries", s.perm(auth.PermLibraryView), systemH.GetQuickQueries)
-	protected.GET("/blocked-hashes", s.perm(auth.PermLibraryView), systemH.ListBlockedHashes)
-	protected.POST("/blocked-hashes", s.perm(auth.PermLibraryEditMetadata), systemH.AddBlockedHash)
-	protected.DELETE("/blocked-hashes/:hash", s.perm(auth.PermLibraryDelete), systemH.RemoveBlockedHash)
-	protected.GET("/preferences/:key", s.perm(auth.PermLibraryView), systemH.GetUserPreference)
-	protected.PUT("/preferences/:key", s.perm(auth.PermLibraryEditMetadata), systemH.SetUserPreference)
-	protected.DELETE("/preferences/:key", s.perm(auth.PermLibraryDelete), systemH.DeleteUserPreference)
+	protected.GET("/policy/tags", auth.PermLibraryView, systemH.HandlePolicyTags)
+	protected.GET("/ws", noPermission, systemH.HandleWebSocket)
+	protected.GET("/system/status", auth.PermSettingsManage, systemH.GetSystemStatus)
+	protected.GET("/system/announcements", auth.PermSettingsManage, systemH.GetSystemAnnouncements)
+	protected.GET("/system/storage", auth.PermSettingsManage, systemH.GetSystemStorage)
+	protected.GET("/system/import-quotas", auth.PermSettingsManage, systemH.GetImportQuotaUsage)
+	protected.POST("/system/recalculate-sizes", auth.PermSettingsManage, operationsH.StartRecalculateSizes)
+	protected.GET("/stats/what-if", auth.PermLibraryView, systemH.GetStorageWhatIf)
+	protected.GET("/stats/quality", auth.PermLibraryView, systemH.GetQualityReport)
+	protected.GET("/stats/reclaim", auth.PermLibraryView, s.handleReclaimAdvisor)
+	protected.GET("/stats/tiers", auth.PermLibraryView, s.handleStorageTiers)
+	protected.GET("/library/layout", auth.PermLibraryView, s.handleExportLayout)
+	protected.POST("/library/layout/apply", auth.PermLibraryOrganize, s.handleApplyLayout)
+	protected.GET("/system/logs", auth.PermSettingsManage, systemH.GetSystemLogs)
+	protected.GET("/system/logs/stream", auth.PermSettingsManage, systemH.StreamSystemLogs)
+	protected.GET("/system/activity-log", auth.PermSettingsManage, systemH.GetSystemActivityLog)
+	protected.POST("/system/reset", auth.PermSettingsManage, systemH.ResetSystem)
+	protected.POST("/system/factory-reset", auth.PermSettingsManage, systemH.FactoryReset)
+	protected.POST("/system/restart", auth.PermSettingsManage, systemH.RestartServer)
+	protected.GET("/system/doctor", auth.PermSettingsManage, doctorH.RunDoctor)
+	protected.GET("/system/slow-queries", auth.PermSettingsManage, systemH.GetSlowQueries)
+	protected.GET("/config", auth.PermSettingsManage, systemH.GetConfig)
+	protected.PUT("/config", auth.PermSettingsManage, systemH.UpdateConfig)
+	protected.GET("/dashboard", auth.PermLibraryView, systemH.GetDashboard)
+	protected.POST("/backup/create", auth.PermSettingsManage, systemH.CreateBackup)
+	protected.GET("/backup/list", auth.PermSettingsManage, systemH.ListBackups)
+	protected.POST("/backup/restore", auth.PermSettingsManage, systemH.RestoreBackup)
+	protected.POST("/backup/verify", auth.PermSettingsManage, systemH.VerifyBackup)
+	protected.DELETE("/backup/:filename", auth.PermSettingsManage, systemH.DeleteBackup)
+	protected.GET("/library/quick-queries", auth.PermLibraryView, systemH.GetQuickQueries)
+	protected.GET("/import-decisions", auth.PermLibraryView, systemH.GetImportDecisions)
+	protected.GET("/retries", auth.PermLibraryView, systemH.ListRetries)
+	protected.POST("/retries/:id/retry", auth.PermScanTrigger, systemH.RetryNow)
+	protected.DELETE("/retries/:id", auth.PermScanTrigger, systemH.DismissRetry)
+	protected.GET("/blocked-hashes", auth.PermLibraryView, systemH.ListBlockedHashes)
+	protected.POST("/blocked-hashes", auth.PermLibraryEditMetadata, systemH.AddBlockedHash)
+	protected.DELETE("/blocked-hashes/:hash", auth.PermLibraryDelete, systemH.RemoveBlockedHash)
+	protected.GET("/blocked-signatures", auth.PermLibraryView, systemH.ListBlockedSignatures)
+	protected.DELETE("/blocked-signatures/:id", auth.PermLibraryDelete, systemH.RemoveBlockedSignature)
+	protected.GET("/preferences/:key", auth.PermLibraryView, systemH.GetUserPreference)
+	protected.PUT("/preferences/:key", auth.PermLibraryEditMetadata, systemH.SetUserPreference)
+	protected.DELETE("/preferences/:key", auth.PermLibraryDelete, systemH.DeleteUserPreference)
 
 	// Embedding-based dedup domain routes (migrated from server_lifecycle.go).
 	// The split-book /dedup/* routes (registered above) and the
 	// /dedup/fingerprint-rescan + /dedup/validate survivors stay where they are.
-	protected.GET("/dedup/candidates", s.perm(auth.PermLibraryView), dedupH.ListDedupCandidates)
-	protected.GET("/dedup/candidates/export", s.perm(auth.PermLibraryView), dedupH.ExportDedupCandidates)
-	protected.GET("/dedup/stats", s.perm(auth.PermLibraryView), dedupH.GetDedupStats)
+	protected.GET("/dedup/candidates", auth.PermLibraryView, dedupH.ListDedupCandidates)
+	protected.GET("/dedup/candidates/export", auth.PermLibraryView, dedupH.ExportDedupCandidates)
+	protected.GET("/dedup/stats", auth.PermLibraryView, dedupH.GetDedupStats)
 	// T016: breakdown and rescore endpoints (frozen API contract for T017).
-	protected.GET("/dedup/candidates/:id/breakdown", s.perm(auth.PermLibraryView), dedupH.GetDedupCandidateBreakdown)
-	protected.POST("/dedup/rescore", s.perm(auth.PermScanTrigger), dedupH.RescoreDedupCandidates)
-	protected.POST("/dedup/candidates/:id/merge", s.perm(auth.PermLibraryEditMetadata), dedupH.MergeDedupCandidate)
-	protected.POST("/dedup/candidates/:id/dismiss", s.perm(auth.PermLibraryEditMetadata), dedupH.DismissDedupCandidate)
-	protected.POST("/dedup/candidates/bulk-merge", s.perm(auth.PermLibraryEditMetadata), dedupH.BulkMergeDedupCandidates)
-	protected.POST("/dedup/candidates/merge-cluster", s.perm(auth.PermLibraryEditMetadata), dedupH.MergeDedupCluster)
-	protected.POST("/dedup/candidates/dismiss-cluster", s.perm(auth.PermLibraryEditMetadata), dedupH.DismissDedupCluster)
-	protected.POST("/dedup/candidates/remove-from-cluster", s.perm(auth.PermLibraryEditMetadata), dedupH.RemoveFromDedupCluster)
-	protected.GET("/dedup/candidates/series-summary", s.perm(auth.PermLibraryView), dedupH.ListDedupCandidateSeries)
-	protected.POST("/dedup/candidates/merge-series", s.perm(auth.PermLibraryEditMetadata), dedupH.MergeDedupCandidateSeries)
-	protected.POST("/dedup/scan", s.perm(auth.PermScanTrigger), dedupH.TriggerDedupScan)
-	protected.POST("/dedup/scan-llm", s.perm(auth.PermScanTrigger), dedupH.TriggerDedupLLM)
-	protected.POST("/dedup/scan-acoustid", s.perm(auth.PermScanTrigger), dedupH.TriggerDedupAcoustID)
-	protected.POST("/audiobooks/:id/compare-acoustid", s.perm(auth.PermLibraryView), dedupH.HandleCompareAcoustID)
-	protected.POST("/dedup/scan-book-signature", s.perm(auth.PermScanTrigger), dedupH.TriggerBookSignatureScan)
-	protected.POST("/dedup/refresh", s.perm(auth.PermScanTrigger), dedupH.TriggerDedupRefresh)
-	protected.POST("/dedup/purge-stale", s.perm(auth.PermScanTrigger), dedupH.PurgeStaleCandidates)
-	protected.POST("/dedup/purge-legacy-fp", s.perm(auth.PermScanTrigger), dedupH.PurgeLegacyFPCandidates)
-	protected.POST("/dedup/reset-acoustid", s.perm(auth.PermScanTrigger), dedupH.ResetAcoustIDFingerprints)
-	protected.POST("/dedup/embed", s.perm(auth.PermScanTrigger), dedupH.TriggerEmbedScan)
-	protected.POST("/dedup/embed-async", s.perm(auth.PermScanTrigger), dedupH.TriggerEmbedAsync)
-	protected.POST("/dedup/lsh-index", s.perm(auth.PermScanTrigger), dedupH.TriggerLSHIndexBuild)
-	protected.POST("/dedup/emb-reencode", s.perm(auth.PermScanTrigger), dedupH.EmbReeencode) // T021: float16+zstd re-encode op
+	protected.GET("/dedup/candidates/:id/breakdown", auth.PermLibraryView, dedupH.GetDedupCandidateBreakdown)
+	protected.POST("/dedup/rescore", auth.PermScanTrigger, dedupH.RescoreDedupCandidates)
+	protected.POST("/dedup/candidates/:id/merge", auth.PermLibraryEditMetadata, dedupH.MergeDedupCandidate)
+	protected.POST("/dedup/candidates/:id/dismiss", auth.PermLibraryEditMetadata, dedupH.DismissDedupCandidate)
+	protected.POST("/dedup/candidates/bulk-merge", auth.PermLibraryEditMetadata, dedupH.BulkMergeDedupCandidates)
+	protected.POST("/dedup/candidates/merge-cluster", auth.PermLibraryEditMetadata, dedupH.MergeDedupCluster)
+	protected.POST("/dedup/candidates/dismiss-cluster", auth.PermLibraryEditMetadata, dedupH.DismissDedupCluster)
+	protected.POST("/dedup/candidates/remove-from-cluster", auth.PermLibraryEditMetadata, dedupH.RemoveFromDedupCluster)
+	protected.GET("/dedup/candidates/series-summary", auth.PermLibraryView, dedupH.ListDedupCandidateSeries)
+	protected.POST("/dedup/candidates/merge-series", auth.PermLibraryEditMetadata, dedupH.MergeDedupCandidateSeries)
+	protected.POST("/dedup/scan", auth.PermScanTrigger, dedupH.TriggerDedupScan)
+	protected.POST("/dedup/scan-llm", auth.PermScanTrigger, dedupH.TriggerDedupLLM)
+	protected.POST("/dedup/scan-acoustid", auth.PermScanTrigger, dedupH.TriggerDedupAcoustID)
+	protected.POST("/audiobooks/:id/compare-acoustid", auth.PermLibraryView, dedupH.HandleCompareAcoustID)
+	protected.POST("/dedup/scan-book-signature", auth.PermScanTrigger, dedupH.TriggerBookSignatureScan)
+	protected.POST("/dedup/refresh", auth.PermScanTrigger, dedupH.TriggerDedupRefresh)
+	protected.POST("/dedup/purge-stale", auth.PermScanTrigger, dedupH.PurgeStaleCandidates)
+	protected.POST("/dedup/purge-legacy-fp", auth.PermScanTrigger, dedupH.PurgeLegacyFPCandidates)
+	protected.POST("/dedup/reset-acoustid", auth.PermScanTrigger, dedupH.ResetAcoustIDFingerprints)
+	protected.POST("/dedup/embed", auth.PermScanTrigger, dedupH.TriggerEmbedScan)
+	protected.POST("/dedup/embed-async", auth.PermScanTrigger, dedupH.TriggerEmbedAsync)
+	protected.POST("/dedup/lsh-index", auth.PermScanTrigger, dedupH.TriggerLSHIndexBuild)
+	protected.POST("/dedup/emb-reencode", auth.PermScanTrigger, dedupH.EmbReeencode) // T021: float16+zstd re-encode op
 
 	// Duplicates domain (SQL-backed dup detection, series prune/normalize,
 	// dedup-entry validation; migrated from server_lifecycle.go). Paths + permission
@@ -868,104 +970,119 @@ func (s *Server) wireHandlers(api *gin.RouterGroup, authMiddleware gin.HandlerFu
 	// sibling routes were intentionally left here by the entities phase and are now
 	// owned by this handler; /dedup/validate is the dedup-entry validator (distinct
 	// from the embedding-based /dedup/* routes above and the split-book /dedup/* routes).
-	protected.GET("/audiobooks/duplicates", s.perm(auth.PermLibraryView), duplicatesH.ListDuplicateAudiobooks)
-	protected.GET("/audiobooks/duplicates/scan-results", s.perm(auth.PermLibraryView), duplicatesH.ListBookDuplicateScanResults)
-	protected.POST("/audiobooks/duplicates/scan", s.perm(auth.PermLibraryEditMetadata), duplicatesH.ScanBookDuplicates)
-	protected.POST("/audiobooks/duplicates/merge", s.perm(auth.PermLibraryEditMetadata), duplicatesH.MergeBookDuplicatesAsVersions)
-	protected.POST("/audiobooks/duplicates/dismiss", s.perm(auth.PermLibraryEditMetadata), duplicatesH.DismissBookDuplicateGroup)
-	protected.GET("/authors/duplicates", s.perm(auth.PermLibraryView), duplicatesH.ListDuplicateAuthors)
-	protected.POST("/authors/duplicates/refresh", s.perm(auth.PermLibraryEditMetadata), duplicatesH.RefreshDuplicateAuthors)
-	protected.POST("/audiobooks/merge", s.perm(auth.PermLibraryEditMetadata), duplicatesH.MergeBooks)
-	protected.GET("/series/duplicates", s.perm(auth.PermLibraryView), duplicatesH.ListSeriesDuplicates)
-	protected.POST("/series/duplicates/refresh", s.perm(auth.PermLibraryEditMetadata), duplicatesH.RefreshSeriesDuplicates)
-	protected.POST("/series/deduplicate", s.perm(auth.PermLibraryEditMetadata), duplicatesH.DeduplicateSeriesHandler)
-	protected.POST("/series/merge", s.perm(auth.PermLibraryEditMetadata), duplicatesH.MergeSeriesGroup)
-	protected.GET("/series/prune/preview", s.perm(auth.PermLibraryView), duplicatesH.SeriesPrunePreview)
-	protected.POST("/series/prune", s.perm(auth.PermLibraryEditMetadata), duplicatesH.SeriesPrune)
-	protected.GET("/series/normalize/preview", s.perm(auth.PermLibraryView), duplicatesH.SeriesNormalizePreview)
-	protected.POST("/series/normalize", s.perm(auth.PermLibraryEditMetadata), duplicatesH.SeriesNormalize)
-	protected.POST("/dedup/validate", s.perm(auth.PermLibraryEditMetadata), duplicatesH.ValidateDedupEntry)
+	protected.GET("/audiobooks/duplicates", auth.PermLibraryView, duplicatesH.ListDuplicateAudiobooks)
+	protected.GET("/audiobooks/duplicates/scan-results", auth.PermLibraryView, duplicatesH.ListBookDuplicateScanResults)
+	protected.POST("/audiobooks/duplicates/scan", auth.PermLibraryEditMetadata, duplicatesH.ScanBookDuplicates)
+	protected.POST("/audiobooks/duplicates/merge", auth.PermLibraryEditMetadata, duplicatesH.MergeBookDuplicatesAsVersions)
+	protected.POST("/audiobooks/duplicates/dismiss", auth.PermLibraryEditMetadata, duplicatesH.DismissBookDuplicateGroup)
+	protected.POST("/audiobooks/duplicates/resolve", auth.PermLibraryEditMetadata, duplicatesH.ResolveBookDuplicates)
+	protected.GET("/authors/duplicates", auth.PermLibraryView, duplicatesH.ListDuplicateAuthors)
+	protected.POST("/authors/duplicates/refresh", auth.PermLibraryEditMetadata, duplicatesH.RefreshDuplicateAuthors)
+	protected.POST("/audiobooks/merge", auth.PermLibraryEditMetadata, duplicatesH.MergeBooks)
+	protected.GET("/series/duplicates", auth.PermLibraryView, duplicatesH.ListSeriesDuplicates)
+	protected.POST("/series/duplicates/refresh", auth.PermLibraryEditMetadata, duplicatesH.RefreshSeriesDuplicates)
+	protected.POST("/series/deduplicate", auth.PermLibraryEditMetadata, duplicatesH.DeduplicateSeriesHandler)
+	protected.POST("/series/merge", auth.PermLibraryEditMetadata, duplicatesH.MergeSeriesGroup)
+	protected.GET("/series/prune/preview", auth.PermLibraryView, duplicatesH.SeriesPrunePreview)
+	protected.POST("/series/prune", auth.PermLibraryEditMetadata, duplicatesH.SeriesPrune)
+	protected.GET("/series/normalize/preview", auth.PermLibraryView, duplicatesH.SeriesNormalizePreview)
+	protected.POST("/series/normalize", auth.PermLibraryEditMetadata, duplicatesH.SeriesNormalize)
+	protected.POST("/dedup/validate", auth.PermLibraryEditMetadata, duplicatesH.ValidateDedupEntry)
 
 	// Audiobooks domain (main library list / CRUD; migrated from
 	// server_lifecycle.go). Paths + permission guards copied verbatim. Sibling
 	// /audiobooks/:id/* routes owned by OTHER domains (quarantine, rating,
 	// sample, organize/rename, versions, metadata, itunes, parse-with-ai, the
 	// batch-write-back/bulk-write-back endpoints) stay in server_lifecycle.go.
-	protected.GET("/audiobooks", s.perm(auth.PermLibraryView), audiobooksH.ListAudiobooks)
-	protected.GET("/audiobooks/count", s.perm(auth.PermLibraryView), audiobooksH.CountAudiobooks)
-	protected.GET("/audiobooks/facets", s.perm(auth.PermLibraryView), audiobooksH.AudiobookFacets)
-	protected.GET("/audiobooks/soft-deleted", s.perm(auth.PermLibraryView), audiobooksH.ListSoftDeletedAudiobooks)
-	protected.DELETE("/audiobooks/purge-soft-deleted", s.perm(auth.PermLibraryDelete), audiobooksH.PurgeSoftDeletedAudiobooks)
-	protected.POST("/audiobooks/:id/restore", s.perm(auth.PermLibraryOrganize), audiobooksH.RestoreAudiobook)
-	protected.POST("/audiobooks/:id/rescan", s.perm(auth.PermLibraryEditMetadata), audiobooksH.RescanAudiobook)
-	protected.GET("/audiobooks/:id", s.perm(auth.PermLibraryView), audiobooksH.GetAudiobook)
-	protected.GET("/audiobooks/:id/tags", s.perm(auth.PermLibraryView), audiobooksH.GetAudiobookTags)
-	protected.PUT("/audiobooks/:id", s.perm(auth.PermLibraryEditMetadata), audiobooksH.UpdateAudiobook)
-	protected.DELETE("/audiobooks/:id", s.perm(auth.PermLibraryDelete), audiobooksH.DeleteAudiobook)
-	protected.GET("/audiobooks/:id/cover", s.perm(auth.PermLibraryView), audiobooksH.ServeAudiobookCover)
-	protected.GET("/audiobooks/:id/segments", s.perm(auth.PermLibraryView), audiobooksH.ListAudiobookSegments)
-	protected.GET("/audiobooks/:id/segments/:segmentId/tags", s.perm(auth.PermLibraryView), audiobooksH.GetSegmentTags)
-	protected.GET("/audiobooks/:id/files", s.perm(auth.PermLibraryView), audiobooksH.ListBookFiles)
-	protected.PATCH("/audiobooks/:id/files/:file_id", s.perm(auth.PermLibraryEditMetadata), audiobooksH.PatchBookFile)
-	protected.GET("/audiobooks/:id/changelog", s.perm(auth.PermLibraryView), audiobooksH.GetBookChangelog)
-	protected.GET("/audiobooks/:id/path-history", s.perm(auth.PermLibraryView), audiobooksH.GetBookPathHistory)
-	protected.GET("/audiobooks/:id/external-ids", s.perm(auth.PermLibraryView), audiobooksH.GetAudiobookExternalIDs)
-	protected.POST("/audiobooks/:id/extract-track-info", s.perm(auth.PermLibraryEditMetadata), audiobooksH.ExtractTrackInfo)
-	protected.POST("/audiobooks/:id/relocate", s.perm(auth.PermLibraryOrganize), audiobooksH.RelocateBookFiles)
-	protected.POST("/audiobooks/batch", s.perm(auth.PermLibraryEditMetadata), audiobooksH.BatchUpdateAudiobooks)
-	protected.POST("/audiobooks/batch-operations", s.perm(auth.PermLibraryEditMetadata), audiobooksH.BatchOperations)
-	protected.GET("/tags", s.perm(auth.PermLibraryView), audiobooksH.ListAllUserTags)
-	protected.GET("/audiobooks/:id/user-tags", s.perm(auth.PermLibraryView), audiobooksH.GetBookUserTags)
-	protected.GET("/audiobooks/:id/tags-detailed", s.perm(auth.PermLibraryView), audiobooksH.GetBookTagsDetailed)
-	protected.POST("/audiobooks/batch-tags", s.perm(auth.PermLibraryEditMetadata), audiobooksH.BatchUpdateTags)
-	protected.GET("/audiobooks/:id/alternative-titles", s.perm(auth.PermLibraryView), audiobooksH.GetBookAlternativeTitles)
-	protected.POST("/audiobooks/:id/alternative-titles", s.perm(auth.PermLibraryEditMetadata), audiobooksH.AddBookAlternativeTitle)
-	protected.DELETE("/audiobooks/:id/alternative-titles", s.perm(auth.PermLibraryDelete), audiobooksH.RemoveBookAlternativeTitle)
-	protected.GET("/audiobooks/:id/metadata-history", s.perm(auth.PermLibraryView), audiobooksH.GetBookMetadataHistory)
-	protected.GET("/audiobooks/:id/metadata-history/:field", s.perm(auth.PermLibraryView), audiobooksH.GetFieldMetadataHistory)
-	protected.POST("/audiobooks/:id/metadata-history/:field/undo", s.perm(auth.PermLibraryEditMetadata), audiobooksH.UndoMetadataChange)
-	protected.POST("/audiobooks/:id/undo-last-apply", s.perm(auth.PermLibraryEditMetadata), audiobooksH.UndoLastApply)
-	protected.GET("/audiobooks/:id/field-states", s.perm(auth.PermLibraryView), audiobooksH.GetAudiobookFieldStates)
-	protected.GET("/audiobooks/:id/changes", s.perm(auth.PermLibraryView), audiobooksH.GetBookChanges)
+	protected.GET("/audiobooks", auth.PermLibraryView, audiobooksH.ListAudiobooks)
+	protected.GET("/audiobooks/count", auth.PermLibraryView, audiobooksH.CountAudiobooks)
+	protected.GET("/audiobooks/facets", auth.PermLibraryView, audiobooksH.AudiobookFacets)
+	protected.GET("/audiobooks/soft-deleted", auth.PermLibraryView, audiobooksH.ListSoftDeletedAudiobooks)
+	protected.DELETE("/audiobooks/purge-soft-deleted", auth.PermLibraryDelete, audiobooksH.PurgeSoftDeletedAudiobooks)
+	protected.POST("/audiobooks/:id/restore", auth.PermLibraryOrganize, audiobooksH.RestoreAudiobook)
+	protected.DELETE("/audiobooks/:id/purge", auth.PermLibraryDelete, audiobooksH.PurgeAudiobook)
+	protected.POST("/audiobooks/:id/rescan", auth.PermLibraryEditMetadata, audiobooksH.RescanAudiobook)
+	protected.GET("/audiobooks/:id", auth.PermLibraryView, audiobooksH.GetAudiobook)
+	protected.GET("/audiobooks/:id/tags", auth.PermLibraryView, audiobooksH.GetAudiobookTags)
+	protected.PUT("/audiobooks/:id", auth.PermLibraryEditMetadata, audiobooksH.UpdateAudiobook)
+	protected.PATCH("/audiobooks/:id", auth.PermLibraryEditMetadata, audiobooksH.PatchAudiobook)
+	protected.DELETE("/audiobooks/:id", auth.PermLibraryDelete, audiobooksH.DeleteAudiobook)
+	protected.GET("/audiobooks/:id/cover", auth.PermLibraryView, audiobooksH.ServeAudiobookCover)
+	protected.GET("/audiobooks/:id/segments", auth.PermLibraryView, audiobooksH.ListAudiobookSegments)
+	protected.GET("/audiobooks/:id/segments/:segmentId/tags", auth.PermLibraryView, audiobooksH.GetSegmentTags)
+	protected.GET("/audiobooks/:id/files", auth.PermLibraryView, audiobooksH.ListBookFiles)
+	protected.GET("/audiobooks/:id/chapters", auth.PermLibraryView, s.handleBookChapters)
+	protected.PUT("/audiobooks/:id/chapters", auth.PermLibraryEditMetadata, s.handleUpdateBookChapters)
+	protected.POST("/audiobooks/:id/chapters/write", auth.PermLibraryEditMetadata, s.handleWriteBookChapters)
+	protected.POST("/audiobooks/:id/convert", auth.PermLibraryOrganize, s.handleConvertBook)
+	protected.PATCH("/audiobooks/:id/files/:file_id", auth.PermLibraryEditMetadata, audiobooksH.PatchBookFile)
+	protected.GET("/audiobooks/:id/changelog", auth.PermLibraryView, audiobooksH.GetBookChangelog)
+	protected.GET("/audiobooks/:id/path-history", auth.PermLibraryView, audiobooksH.GetBookPathHistory)
+	protected.GET("/audiobooks/:id/external-ids", auth.PermLibraryView, audiobooksH.GetAudiobookExternalIDs)
+	protected.POST("/audiobooks/:id/extract-track-info", auth.PermLibraryEditMetadata, audiobooksH.ExtractTrackInfo)
+	protected.POST("/audiobooks/:id/relocate", auth.PermLibraryOrganize, audiobooksH.RelocateBookFiles)
+	protected.POST("/audiobooks/batch", auth.PermLibraryEditMetadata, audiobooksH.BatchUpdateAudiobooks)
+	protected.POST("/audiobooks/batch-operations", auth.PermLibraryEditMetadata, audiobooksH.BatchOperations)
+	protected.POST("/audiobooks/batch-delete", auth.PermLibraryDelete, audiobooksH.BatchDeleteAudiobooks)
+	protected.GET("/tags", auth.PermLibraryView, audiobooksH.ListAllUserTags)
+	protected.GET("/audiobooks/:id/user-tags", auth.PermLibraryView, audiobooksH.GetBookUserTags)
+	protected.GET("/audiobooks/:id/tags-detailed", auth.PermLibraryView, audiobooksH.GetBookTagsDetailed)
+	protected.POST("/audiobooks/batch-tags", auth.PermLibraryEditMetadata, audiobooksH.BatchUpdateTags)
+	protected.GET("/audiobooks/:id/alternative-titles", auth.PermLibraryView, audiobooksH.GetBookAlternativeTitles)
+	protected.POST("/audiobooks/:id/alternative-titles", auth.PermLibraryEditMetadata, audiobooksH.AddBookAlternativeTitle)
+	protected.DELETE("/audiobooks/:id/alternative-titles", auth.PermLibraryDelete, audiobooksH.RemoveBookAlternativeTitle)
+	protected.GET("/audiobooks/:id/metadata-history", auth.PermLibraryView, audiobooksH.GetBookMetadataHistory)
+	protected.GET("/audiobooks/:id/metadata-history/:field", auth.PermLibraryView, audiobooksH.GetFieldMetadataHistory)
+	protected.POST("/audiobooks/:id/metadata-history/:field/undo", auth.PermLibraryEditMetadata, audiobooksH.UndoMetadataChange)
+	protected.POST("/audiobooks/:id/undo-last-apply", auth.PermLibraryEditMetadata, audiobooksH.UndoLastApply)
+	protected.GET("/audiobooks/:id/field-states", auth.PermLibraryView, audiobooksH.GetAudiobookFieldStates)
+	protected.GET("/audiobooks/:id/changes", auth.PermLibraryView, audiobooksH.GetBookChanges)
 
 	// Metadata domain (handlers/metadata) — 19 routes relocated from
 	// server_lifecycle.go. EXACT paths + perm guards preserved.
-	protected.POST("/metadata/batch-update", s.perm(auth.PermLibraryEditMetadata), metadataH.BatchUpdateMetadata)
-	protected.POST("/metadata/validate", s.perm(auth.PermLibraryEditMetadata), metadataH.ValidateMetadata)
-	protected.GET("/metadata/export", s.perm(auth.PermLibraryView), metadataH.ExportMetadata)
-	protected.POST("/metadata/import", s.perm(auth.PermLibraryEditMetadata), metadataH.ImportMetadata)
-	protected.GET("/metadata/search", s.perm(auth.PermLibraryView), metadataH.SearchMetadata)
-	protected.GET("/metadata/fields", s.perm(auth.PermLibraryView), metadataH.GetMetadataFields)
-	protected.POST("/metadata/bulk-fetch", s.perm(auth.PermLibraryEditMetadata), metadataH.BulkFetchMetadata)
-	protected.POST("/audiobooks/:id/fetch-metadata", s.perm(auth.PermLibraryEditMetadata), metadataH.FetchAudiobookMetadata)
-	protected.POST("/audiobooks/:id/search-metadata", s.perm(auth.PermLibraryEditMetadata), metadataH.SearchAudiobookMetadata)
-	protected.POST("/audiobooks/:id/apply-metadata", s.perm(auth.PermLibraryEditMetadata), metadataH.ApplyAudiobookMetadata)
-	protected.POST("/audiobooks/:id/mark-no-match", s.perm(auth.PermLibraryEditMetadata), metadataH.MarkAudiobookNoMatch)
-	protected.POST("/audiobooks/:id/revert-metadata", s.perm(auth.PermLibraryEditMetadata), metadataH.RevertAudiobookMetadata)
-	protected.GET("/audiobooks/:id/metadata-rejections", s.perm(auth.PermLibraryView), metadataH.HandleGetMetadataRejections)
-	protected.GET("/audiobooks/:id/cow-versions", s.perm(auth.PermLibraryView), metadataH.ListBookCOWVersions)
-	protected.POST("/audiobooks/:id/cow-versions/prune", s.perm(auth.PermLibraryEditMetadata), metadataH.PruneBookCOWVersions)
-	protected.POST("/audiobooks/:id/write-back", s.perm(auth.PermLibraryEditMetadata), metadataH.WriteBackAudiobookMetadata)
-	protected.PATCH("/audiobooks/:id/rating", s.perm(auth.PermLibraryEditMetadata), metadataH.HandleUpdateBookRating)
-	protected.POST("/audiobooks/batch-write-back", s.perm(auth.PermLibraryEditMetadata), metadataH.BatchWriteBackAudiobooks)
-	protected.POST("/audiobooks/bulk-write-back", s.perm(auth.PermLibraryEditMetadata), metadataH.HandleBulkWriteBack)
+	protected.POST("/metadata/batch-update", auth.PermLibraryEditMetadata, metadataH.BatchUpdateMetadata)
+	protected.POST("/metadata/validate", auth.PermLibraryEditMetadata, metadataH.ValidateMetadata)
+	protected.GET("/metadata/export", auth.PermLibraryView, metadataH.ExportMetadata)
+	protected.POST("/metadata/import", auth.PermLibraryEditMetadata, metadataH.ImportMetadata)
+	protected.GET("/metadata/search", auth.PermLibraryView, metadataH.SearchMetadata)
+	protected.GET("/metadata/fields", auth.PermLibraryView, metadataH.GetMetadataFields)
+	protected.GET("/metadata/custom-fields", auth.PermLibraryView, metadataH.ListCustomFields)
+	protected.POST("/metadata/custom-fields", auth.PermSettingsManage, metadataH.CreateCustomField)
+	protected.PUT("/metadata/custom-fields/:key", auth.PermSettingsManage, metadataH.UpdateCustomField)
+	protected.DELETE("/metadata/custom-fields/:key", auth.PermSettingsManage, metadataH.DeleteCustomField)
+	protected.POST("/metadata/bulk-fetch", auth.PermLibraryEditMetadata, metadataH.BulkFetchMetadata)
+	protected.POST("/audiobooks/:id/fetch-metadata", auth.PermLibraryEditMetadata, metadataH.FetchAudiobookMetadata)
+	protected.POST("/audiobooks/:id/search-metadata", auth.PermLibraryEditMetadata, metadataH.SearchAudiobookMetadata)
+	protected.POST("/audiobooks/:id/apply-metadata", auth.PermLibraryEditMetadata, metadataH.ApplyAudiobookMetadata)
+	protected.POST("/audiobooks/:id/mark-no-match", auth.PermLibraryEditMetadata, metadataH.MarkAudiobookNoMatch)
+	protected.POST("/audiobooks/:id/revert-metadata", auth.PermLibraryEditMetadata, metadataH.RevertAudiobookMetadata)
+	protected.GET("/audiobooks/:id/metadata-rejections", auth.PermLibraryView, metadataH.HandleGetMetadataRejections)
+	protected.GET("/audiobooks/:id/cow-versions", auth.PermLibraryView, metadataH.ListBookCOWVersions)
+	protected.POST("/audiobooks/:id/cow-versions/prune", auth.PermLibraryEditMetadata, metadataH.PruneBookCOWVersions)
+	protected.POST("/audiobooks/:id/write-back", auth.PermLibraryEditMetadata, metadataH.WriteBackAudiobookMetadata)
+	protected.POST("/audiobooks/:id/write-tags", auth.PermLibraryEditMetadata, metadataH.WriteTags)
+	protected.PATCH("/audiobooks/:id/rating", auth.PermLibraryEditMetadata, metadataH.HandleUpdateBookRating)
+	protected.GET("/audiobooks/:id/custom-fields", auth.PermLibraryView, metadataH.GetBookCustomFields)
+	protected.PUT("/audiobooks/:id/custom-fields", auth.PermLibraryEditMetadata, metadataH.UpdateBookCustomFields)
+	protected.POST("/audiobooks/batch-write-back", auth.PermLibraryEditMetadata, metadataH.BatchWriteBackAudiobooks)
+	protected.POST("/audiobooks/batch-write-tags", auth.PermLibraryEditMetadata, metadataH.BatchWriteTags)
+	protected.POST("/audiobooks/bulk-write-back", auth.PermLibraryEditMetadata, metadataH.HandleBulkWriteBack)
 
 	// Plugins
 	plugins := protected.Group("/plugins")
 	{
-		plugins.GET("", s.perm(auth.PermSettingsManage), pluginsH.ListPlugins)
-		plugins.GET("/:id", s.perm(auth.PermSettingsManage), pluginsH.GetPlugin)
-		plugins.POST("/:id/enable", s.perm(auth.PermSettingsManage), pluginsH.EnablePlugin)
-		plugins.POST("/:id/disable", s.perm(auth.PermSettingsManage), pluginsH.DisablePlugin)
-		plugins.GET("/:id/health", s.perm(auth.PermSettingsManage), pluginsH.PluginHealth)
-		plugins.PUT("/:id/settings", s.perm(auth.PermSettingsManage), pluginsH.UpdatePluginSettings)
+		plugins.GET("", auth.PermSettingsManage, pluginsH.ListPlugins)
+		plugins.GET("/:id", auth.PermSettingsManage, pluginsH.GetPlugin)
+		plugins.POST("/:id/enable", auth.PermSettingsManage, pluginsH.EnablePlugin)
+		plugins.POST("/:id/disable", auth.PermSettingsManage, pluginsH.DisablePlugin)
+		plugins.GET("/:id/health", auth.PermSettingsManage, pluginsH.PluginHealth)
+		plugins.PUT("/:id/settings", auth.PermSettingsManage, pluginsH.UpdatePluginSettings)
 	}
 
 	// Admin-only Phase 2 routes
-	adminOnly := protected.Group("")
-	adminOnly.Use(servermiddleware.RequireAdmin())
+	adminOnly := protected.Group("").Require(RouteAuthAdmin, servermiddleware.RequireAdmin())
 	{
-		adminOnly.GET("/cache/stats/keys", cacheH.HandleCacheKeysIntrospection)
-		adminOnly.POST("/admin/recompact-digests", activityH.RecompactDigests)
+		adminOnly.GET("/cache/stats/keys", noPermission, cacheH.HandleCacheKeysIntrospection)
+		adminOnly.POST("/admin/recompact-digests", noPermission, activityH.RecompactDigests)
 	}
 }
@@ -1,18 +1,21 @@
 // file: internal/sysinfo/service.go
-// version: 1.1.0
+// version: 1.4.0
 // guid: h8i9j0k1-l2m3-n4o5-p6q7-r8s9t0u1v2w3
-// last-edited: 2026-05-20
+// last-edited: 2026-10-17
 
 package sysinfo
 
 import (
 	"fmt"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/falkcorp/audiobook-organizer/internal/applog"
 	"github.com/falkcorp/audiobook-organizer/internal/config"
 	"github.com/falkcorp/audiobook-organizer/internal/database"
+	"github.com/falkcorp/audiobook-organizer/internal/librarylock"
 )
 
 // SystemServiceStore is the narrow slice of database.Store this service uses.
@@ -30,6 +33,8 @@ type SystemService struct {
 	version    string
 	libSizesFn LibrarySizesFn
 	startTime  time.Time
+	lock       *librarylock.Lock
+	appLogs    *applog.Service
 }
 
 // NewSystemService constructs a SystemService.
@@ -48,6 +53,28 @@ func NewSystemService(db SystemServiceStore, version string, libSizesFn LibraryS
 	}
 }
 
+// SetLibraryLock adds the multi-instance library lock to the status.
+func (ss *SystemService) SetLibraryLock(l *librarylock.Lock) {
+	ss.lock = l
+}
+
+// SetAppLogs makes the persistent application log available to
+// QueryAppLogs and SubscribeAppLogs.
+func (ss *SystemService) SetAppLogs(l *applog.Service) {
+	ss.appLogs = l
+}
+
+// QueryAppLogs pages through the application log, newest first. It
+// returns applog.ErrUnavailable when no application log is kept.
+func (ss *SystemService) QueryAppLogs(f database.AppLogFilter) ([]database.AppLogEntry, error) {
+	return ss.appLogs.Query(f)
+}
+
+// SubscribeAppLogs tails the application log.
+func (ss *SystemService) SubscribeAppLogs() (<-chan database.AppLogEntry, func(), error) {
+	return ss.appLogs.Subscribe()
+}
+
 type SystemStatus struct {
 	Status              string               `json:"status"`
 	Version             string               `json:"version"`
@@ -70,6 +97,14 @@ type SystemStatus struct {
 	AppUptimeSeconds    float64              `json:"app_uptime_seconds"`
 	SystemUptimeSeconds float64              `json:"system_uptime_seconds"`
 	BrokenFileCount     *int                 `json:"broken_file_count,omitempty"`
+	// SizesComputedAt is when the cached library stats the sizes and
+	// counts above come from were computed; SizeCacheAgeSeconds is its
+	// age. POST /system/recalculate-sizes forces a recompute.
+	SizesComputedAt     *time.Time `json:"sizes_computed_at,omitempty"`
+	SizeCacheAgeSeconds float64    `json:"size_cache_age_seconds"`
+	// LibraryLock is whether this instance holds the library lock and, if
+	// not, which instance does.
+	LibraryLock *librarylock.Status `json:"library_lock,omitempty"`
 }
 
 type SystemLibraryStatus struct {
@@ -202,6 +237,15 @@ func (ss *SystemService) CollectSystemStatus() (*SystemStatus, error) {
 		AppUptimeSeconds:    time.Since(ss.startTime).Seconds(),
 		SystemUptimeSeconds: GetSystemUptimeSeconds(),
 	}
+	if !dbStats.ComputedAt.IsZero() {
+		computedAt := dbStats.ComputedAt
+		status.SizesComputedAt = &computedAt
+		status.SizeCacheAgeSeconds = time.Since(computedAt).Seconds()
+	}
+	if ss.lock != nil {
+		lockStatus := ss.lock.Status()
+		status.LibraryLock = &lockStatus
+	}
 
 	return status, nil
 }
@@ -268,7 +312,9 @@ func (ss *SystemService) GetFormattedUptime(startTime time.Time) string {
 	return time.Since(startTime).String()
 }
 
-// CollectSystemLogs gathers logs for recent operations with filtering and pagination.
+// CollectSystemLogs gathers logs for recent operations with filtering and
+// pagination. It is the fallback for GET /system/logs when no application
+// log is kept.
 func (ss *SystemService) CollectSystemLogs(level, search string, limit, offset int) ([]SystemLogEntry, int, error) {
 	if ss.db == nil {
 		return nil, 0, fmt.Errorf("database not initialized")
@@ -320,13 +366,9 @@ func (ss *SystemService) CollectSystemLogs(level, search string, limit, offset i
 		}
 	}
 
-	for i := 0; i < len(allLogs)-1; i++ {
-		for j := i + 1; j < len(allLogs); j++ {
-			if allLogs[j].Timestamp.After(allLogs[i].Timestamp) {
-				allLogs[i], allLogs[j] = allLogs[j], allLogs[i]
-			}
-		}
-	}
+	sort.SliceStable(allLogs, func(i, j int) bool {
+		return allLogs[i].Timestamp.After(allLogs[j].Timestamp)
+	})
 
 	total := len(allLogs)
 	start := offset
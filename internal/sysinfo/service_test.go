@@ -1,11 +1,12 @@
 // file: internal/sysinfo/service_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: g7h8i9j0-k1l2-m3n4-o5p6-q7r8s9t0u1v2
 
 package sysinfo
 
 import (
 	"testing"
+	"time"
 
 	"github.com/falkcorp/audiobook-organizer/internal/database"
 )
@@ -28,6 +29,25 @@ func TestSystemService_CollectSystemStatus_Success(t *testing.T) {
 	}
 }
 
+func TestSystemService_CollectSystemStatus_SizeCacheAge(t *testing.T) {
+	computed := time.Now().Add(-90 * time.Second)
+	mockDB := &database.MockStore{
+		GetDashboardStatsFunc: func() (*database.DashboardStats, error) {
+			return &database.DashboardStats{OrganizedSize: 100, ComputedAt: computed}, nil
+		},
+	}
+	status, err := NewSystemService(mockDB, "test", nil).CollectSystemStatus()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status.SizesComputedAt == nil || !status.SizesComputedAt.Equal(computed) {
+		t.Errorf("SizesComputedAt = %v, want %v", status.SizesComputedAt, computed)
+	}
+	if status.SizeCacheAgeSeconds < 90 {
+		t.Errorf("SizeCacheAgeSeconds = %v, want >= 90", status.SizeCacheAgeSeconds)
+	}
+}
+
 func TestSystemService_FilterLogsBySearch_Match(t *testing.T) {
 	service := NewSystemService(&database.MockStore{}, "test", nil)
 
@@ -0,0 +1,103 @@
+// file: internal/tagger/chapters.go
+// version: 1.0.0
+// guid: 8e1a4f63-0d7b-4c92-b5a8-3f6c1e9d2b70
+// last-edited: 2026-10-16
+//
+// WriteChapters embeds chapter markers into an audio file. TagLib has no
+// chapter support, so this goes through ffmpeg: the audio and cover are
+// stream-copied and the chapters come from a generated FFMETADATA file.
+
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+// ChapterFormats are the extensions WriteChapters supports: MP4 chapter
+// tracks for m4b/m4a, ID3v2 CHAP frames for mp3.
+var ChapterFormats = []string{".m4b", ".m4a", ".mp3"}
+
+// ChapterMetadata renders chapters as an FFMETADATA1 document with
+// millisecond timestamps.
+func ChapterMetadata(chapters []database.BookChapter) string {
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+	for _, ch := range chapters {
+		fmt.Fprintf(&b, "\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
+			int64(ch.StartSec*1000+0.5), int64(ch.EndSec*1000+0.5), escapeFFMetadata(ch.Title))
+	}
+	return b.String()
+}
+
+// escapeFFMetadata backslash-escapes the characters FFMETADATA treats as
+// syntax.
+func escapeFFMetadata(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '=', ';', '#', '\\', '\n':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// WriteChapters replaces the chapter markers embedded in path with
+// chapters and returns the path actually written, which differs from path
+// when the file was protected and imported first (see ResolvePathForWrite).
+// The result is written next to the file and renamed over it, so a
+// failed run leaves the original untouched.
+func WriteChapters(ctx context.Context, ffmpegPath, path string, chapters []database.BookChapter, deps SafeWriteDeps) (string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !slices.Contains(ChapterFormats, ext) {
+		return "", fmt.Errorf("write chapters: unsupported format %q", ext)
+	}
+	target, err := resolvePath(ctx, path, deps)
+	if err != nil {
+		return "", err
+	}
+
+	meta, err := os.CreateTemp(filepath.Dir(target), ".chapters-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("write chapters: %w", err)
+	}
+	defer os.Remove(meta.Name())
+	if _, err := meta.WriteString(ChapterMetadata(chapters)); err != nil {
+		meta.Close()
+		return "", fmt.Errorf("write chapters: %w", err)
+	}
+	if err := meta.Close(); err != nil {
+		return "", fmt.Errorf("write chapters: %w", err)
+	}
+
+	tmp := strings.TrimSuffix(target, filepath.Ext(target)) + ".chapters.tmp" + filepath.Ext(target)
+	defer os.Remove(tmp)
+	args := []string{"-nostdin", "-loglevel", "error", "-y",
+		"-i", target, "-f", "ffmetadata", "-i", meta.Name(),
+		// Audio plus any cover; other streams (m4b bin_data tracks, the
+		// old chapter text track) are dropped so ffmpeg can rebuild them.
+		"-map", "0:a", "-map", "0:v?", "-c", "copy",
+		"-map_metadata", "0", "-map_chapters", "1"}
+	if ext == ".mp3" {
+		args = append(args, "-id3v2_version", "3")
+	} else {
+		args = append(args, "-disposition:v", "attached_pic")
+	}
+	args = append(args, tmp)
+	if out, err := exec.CommandContext(ctx, ffmpegPath, args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("write chapters: ffmpeg: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		return "", fmt.Errorf("write chapters: %w", err)
+	}
+	return target, nil
+}
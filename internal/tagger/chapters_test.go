@@ -0,0 +1,34 @@
+// file: internal/tagger/chapters_test.go
+// version: 1.0.0
+// guid: 5a0c3e97-2d84-4f1b-9c6a-b7e2d4f81c05
+
+package tagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/falkcorp/audiobook-organizer/internal/database"
+)
+
+func TestChapterMetadata(t *testing.T) {
+	t.Parallel()
+	got := ChapterMetadata([]database.BookChapter{
+		{Index: 1, Title: "Prologue", StartSec: 0, EndSec: 61.5},
+		{Index: 2, Title: "Part 1; A=B", StartSec: 61.5, EndSec: 3600.0004},
+	})
+	want := ";FFMETADATA1\n" +
+		"\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=0\nEND=61500\ntitle=Prologue\n" +
+		"\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=61500\nEND=3600000\ntitle=Part 1\\; A\\=B\n"
+	if got != want {
+		t.Errorf("ChapterMetadata =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestWriteChapters_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+	_, err := WriteChapters(context.Background(), "ffmpeg", "/books/x.flac", nil, SafeWriteDeps{})
+	if err == nil {
+		t.Error("expected error for flac")
+	}
+}
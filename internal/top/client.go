@@ -0,0 +1,218 @@
+// file: internal/top/client.go
+// version: 1.0.0
+// guid: 2a9d41e7-c8b3-4f60-95d2-7e1b0c46f3a8
+// last-edited: 2026-10-16
+
+package top
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client reads operation and system state from a running server's API.
+type Client struct {
+	base  *url.URL
+	token string
+	http  *http.Client
+	// stream has no timeout; SSE connections stay open indefinitely.
+	stream *http.Client
+}
+
+// NewClient returns a client for the server at baseURL (e.g.
+// "http://nas:8484"). token, when set, is sent as a bearer token; API
+// keys ("abk_...") and session tokens both work. insecure skips TLS
+// certificate verification for self-signed installs.
+func NewClient(baseURL, token string, insecure bool) (*Client, error) {
+	raw := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid server URL %q", baseURL)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		// #nosec G402 -- opt-in for installs with self-signed certificates
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &Client{
+		base:   u,
+		token:  token,
+		http:   &http.Client{Transport: transport, Timeout: 15 * time.Second},
+		stream: &http.Client{Transport: transport},
+	}, nil
+}
+
+// Operation is one row of the operations timeline.
+type Operation struct {
+	ID              string     `json:"id"`
+	DefID           string     `json:"def_id"`
+	DisplayName     string     `json:"display_name"`
+	Status          string     `json:"status"`
+	Priority        int        `json:"priority"`
+	ProgressCurrent *int       `json:"progress_current"`
+	ProgressTotal   *int       `json:"progress_total"`
+	ProgressMessage *string    `json:"progress_message"`
+	CurrentItem     *string    `json:"current_item"`
+	QueuedAt        time.Time  `json:"queued_at"`
+	StartedAt       *time.Time `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	ErrorMessage    *string    `json:"error_message"`
+}
+
+// SystemStatus is the subset of GET /system/status the dashboard shows.
+type SystemStatus struct {
+	Version          string  `json:"version"`
+	TotalBookCount   int     `json:"total_book_count"`
+	TotalFileCount   int     `json:"total_file_count"`
+	AuthorCount      int     `json:"author_count"`
+	SeriesCount      int     `json:"series_count"`
+	TotalSizeBytes   int64   `json:"total_size_bytes"`
+	AppUptimeSeconds float64 `json:"app_uptime_seconds"`
+	Memory           struct {
+		HeapAlloc   uint64 `json:"heap_alloc"`
+		SysBytes    uint64 `json:"sys_bytes"`
+		SystemTotal uint64 `json:"system_total"`
+		NumGC       uint32 `json:"num_gc"`
+	} `json:"memory"`
+	Runtime struct {
+		NumGoroutine int `json:"num_goroutine"`
+		NumCPU       int `json:"num_cpu"`
+	} `json:"runtime"`
+}
+
+// Event is one server-sent operations event.
+type Event struct {
+	Name string
+	Data map[string]any
+	At   time.Time
+}
+
+// Timeline returns operations queued within since, newest first.
+func (c *Client) Timeline(ctx context.Context, since time.Duration) ([]Operation, error) {
+	var out struct {
+		Operations []Operation `json:"operations"`
+	}
+	err := c.get(ctx, "/api/v1/operations/timeline?since="+url.QueryEscape(since.String()), &out)
+	return out.Operations, err
+}
+
+// Status returns the server's system status. It needs the settings
+// permission; callers treat an error as "stats unavailable".
+func (c *Client) Status(ctx context.Context) (*SystemStatus, error) {
+	var out SystemStatus
+	if err := c.get(ctx, "/api/v1/system/status", &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out any) error {
+	req, err := c.request(ctx, path)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp)
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// Events streams GET /operations/events to fn until ctx is done or the
+// connection drops.
+func (c *Client) Events(ctx context.Context, fn func(Event)) error {
+	req, err := c.request(ctx, "/api/v1/operations/events")
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.stream.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp)
+	}
+	return readSSE(resp.Body, fn)
+}
+
+func (c *Client) request(ctx context.Context, path string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base.String()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+func statusError(resp *http.Response) error {
+	var e struct {
+		Error string `json:"error"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&e)
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("%s: pass an API key with --token", resp.Status)
+	case e.Error != "":
+		return fmt.Errorf("%s: %s", resp.Status, e.Error)
+	}
+	return fmt.Errorf("%s", resp.Status)
+}
+
+// readSSE parses a text/event-stream body, calling fn once per event.
+// Comment lines (heartbeats) are skipped.
+func readSSE(r io.Reader, fn func(Event)) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	var name string
+	var data strings.Builder
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				ev := Event{Name: name, At: time.Now()}
+				_ = json.Unmarshal([]byte(data.String()), &ev.Data)
+				fn(ev)
+			}
+			name = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
@@ -0,0 +1,367 @@
+// file: internal/top/dashboard.go
+// version: 1.0.0
+// guid: c3f8a6d2-19e4-4b7c-a05f-6d2e8b93c174
+// last-edited: 2026-10-16
+
+package top
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxEvents is how many recent events the dashboard keeps.
+const maxEvents = 200
+
+// Dashboard is the state one frame is rendered from. It is safe for
+// concurrent use: the poller and the event stream update it while the
+// render loop reads it.
+type Dashboard struct {
+	mu        sync.Mutex
+	server    string
+	ops       []Operation
+	status    *SystemStatus
+	statusErr string
+	events    []Event
+	connected bool
+	lastErr   string
+	updated   time.Time
+}
+
+// NewDashboard returns an empty dashboard for server.
+func NewDashboard(server string) *Dashboard {
+	return &Dashboard{server: server}
+}
+
+// SetOperations replaces the operation list from a timeline poll.
+func (d *Dashboard) SetOperations(ops []Operation, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.lastErr = err.Error()
+		return
+	}
+	d.ops, d.lastErr, d.updated = ops, "", time.Now()
+}
+
+// SetStatus records the latest system status.
+func (d *Dashboard) SetStatus(s *SystemStatus, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.statusErr = err.Error()
+		return
+	}
+	d.status, d.statusErr = s, ""
+}
+
+// SetConnected records whether the event stream is up.
+func (d *Dashboard) SetConnected(ok bool) {
+	d.mu.Lock()
+	d.connected = ok
+	d.mu.Unlock()
+}
+
+// AddEvent applies an event: progress updates patch the matching
+// operation in place so bars move between polls, and everything except
+// high-frequency progress ticks lands in the recent events list.
+func (d *Dashboard) AddEvent(ev Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	opID, _ := ev.Data["op_id"].(string)
+	switch ev.Name {
+	case "op.updated":
+		for i := range d.ops {
+			if d.ops[i].ID != opID {
+				continue
+			}
+			if v, ok := number(ev.Data["progress_current"]); ok {
+				d.ops[i].ProgressCurrent = &v
+			}
+			if v, ok := number(ev.Data["progress_total"]); ok {
+				d.ops[i].ProgressTotal = &v
+			}
+			if s, ok := ev.Data["status"].(string); ok && s != "" {
+				d.ops[i].Status = s
+			}
+		}
+		return
+	case "op.current_item":
+		for i := range d.ops {
+			if d.ops[i].ID == opID {
+				label, _ := ev.Data["label"].(string)
+				d.ops[i].CurrentItem = &label
+			}
+		}
+		return
+	}
+	d.events = append(d.events, ev)
+	if len(d.events) > maxEvents {
+		d.events = d.events[len(d.events)-maxEvents:]
+	}
+}
+
+func number(v any) (int, bool) {
+	f, ok := v.(float64)
+	return int(f), ok
+}
+
+// Render draws one frame of at most width×height cells, with ANSI bold
+// and color for headings and errors.
+func (d *Dashboard) Render(width, height int, now time.Time) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if width < 40 {
+		width = 40
+	}
+	if height < 10 {
+		height = 10
+	}
+
+	var running, queued, failed []Operation
+	for _, op := range d.ops {
+		switch op.Status {
+		case "running":
+			running = append(running, op)
+		case "queued", "pending", "waiting_deps":
+			queued = append(queued, op)
+		case "failed":
+			if op.CompletedAt != nil && now.Sub(*op.CompletedAt) < time.Hour {
+				failed = append(failed, op)
+			}
+		}
+	}
+	sort.Slice(running, func(i, j int) bool { return startOf(running[i]).Before(startOf(running[j])) })
+
+	var lines []string
+	add := func(format string, args ...any) {
+		lines = append(lines, fit(fmt.Sprintf(format, args...), width))
+	}
+
+	stream := "live"
+	if !d.connected {
+		stream = "reconnecting"
+	}
+	add("%s", bold(fmt.Sprintf("audiobook-organizer top — %s", d.server)))
+	add("events: %s   updated: %s   %s", stream, ago(d.updated, now), now.Format("15:04:05"))
+	if d.lastErr != "" {
+		add("%s", red("error: "+d.lastErr))
+	}
+	if s := d.status; s != nil {
+		add("version %s   up %s   books %d   files %d   authors %d   series %d   library %s",
+			s.Version, duration(time.Duration(s.AppUptimeSeconds)*time.Second),
+			s.TotalBookCount, s.TotalFileCount, s.AuthorCount, s.SeriesCount, humanBytes(s.TotalSizeBytes))
+		add("heap %s   sys %s   host mem %s   goroutines %d   cpus %d   gc %d",
+			humanBytes(int64(s.Memory.HeapAlloc)), humanBytes(int64(s.Memory.SysBytes)), humanBytes(int64(s.Memory.SystemTotal)),
+			s.Runtime.NumGoroutine, s.Runtime.NumCPU, s.Memory.NumGC)
+	} else if d.statusErr != "" {
+		add("system stats unavailable: %s", d.statusErr)
+	}
+	add("")
+	add("%s", bold(fmt.Sprintf("RUNNING %d   QUEUED %d   FAILED (1h) %d", len(running), len(queued), len(failed))))
+
+	// Split the remaining rows between operations and events, giving
+	// operations what they need up to two thirds.
+	rest := height - len(lines) - 2
+	opRows := min(len(running)*2+len(queued), rest*2/3)
+	eventRows := rest - opRows
+
+	var opLines []string
+	for _, op := range running {
+		opLines = append(opLines, fit(fmt.Sprintf("▶ %-28s %s %s", name(op), bar(op, 20), elapsed(op, now)), width))
+		detail := ""
+		if op.CurrentItem != nil && *op.CurrentItem != "" {
+			detail = *op.CurrentItem
+		} else if op.ProgressMessage != nil {
+			detail = *op.ProgressMessage
+		}
+		opLines = append(opLines, fit("    "+detail, width))
+	}
+	for _, op := range queued {
+		opLines = append(opLines, fit(fmt.Sprintf("… %-28s %-12s queued %s", name(op), op.Status, ago(op.QueuedAt, now)), width))
+	}
+	if len(opLines) > opRows {
+		opLines = append(opLines[:max(opRows-1, 0)], fit(fmt.Sprintf("  … %d more", len(running)+len(queued)-countShown(opLines[:max(opRows-1, 0)])), width))
+	}
+	lines = append(lines, opLines...)
+
+	lines = append(lines, "", bold("RECENT EVENTS"))
+	start := max(len(d.events)-eventRows, 0)
+	for i := len(d.events) - 1; i >= start; i-- {
+		lines = append(lines, fit(formatEvent(d.events[i]), width))
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// countShown counts operation entries (not detail lines) in lines.
+func countShown(lines []string) int {
+	n := 0
+	for _, l := range lines {
+		if strings.HasPrefix(l, "▶") || strings.HasPrefix(l, "…") {
+			n++
+		}
+	}
+	return n
+}
+
+func formatEvent(ev Event) string {
+	id, _ := ev.Data["op_id"].(string)
+	if len(id) > 8 {
+		id = id[len(id)-8:]
+	}
+	var detail string
+	switch ev.Name {
+	case "op.created":
+		def, _ := ev.Data["def_id"].(string)
+		detail = "created " + def
+	case "op.log":
+		level, _ := ev.Data["level"].(string)
+		msg, _ := ev.Data["message"].(string)
+		detail = strings.ToUpper(level) + " " + msg
+		if level == "error" {
+			detail = red(detail)
+		}
+	default:
+		if s, ok := ev.Data["status"].(string); ok {
+			detail = s
+		}
+	}
+	return fmt.Sprintf("%s %-14s %-8s %s", ev.At.Format("15:04:05"), ev.Name, id, detail)
+}
+
+func name(op Operation) string {
+	if op.DisplayName != "" {
+		return op.DisplayName
+	}
+	return op.DefID
+}
+
+func startOf(op Operation) time.Time {
+	if op.StartedAt != nil {
+		return *op.StartedAt
+	}
+	return op.QueuedAt
+}
+
+// bar draws a progress bar, or an indeterminate marker when the total
+// is unknown.
+func bar(op Operation, width int) string {
+	if op.ProgressTotal == nil || *op.ProgressTotal <= 0 || op.ProgressCurrent == nil {
+		return "[" + strings.Repeat("·", width) + "]      "
+	}
+	cur, total := *op.ProgressCurrent, *op.ProgressTotal
+	frac := min(float64(cur)/float64(total), 1)
+	filled := int(frac * float64(width))
+	return fmt.Sprintf("[%s%s] %3.0f%% %d/%d", strings.Repeat("█", filled), strings.Repeat(" ", width-filled), frac*100, cur, total)
+}
+
+func elapsed(op Operation, now time.Time) string {
+	return duration(now.Sub(startOf(op)))
+}
+
+func ago(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return duration(now.Sub(t)) + " ago"
+}
+
+func duration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// fit truncates s to width visible runes, ignoring ANSI escapes.
+func fit(s string, width int) string {
+	if visibleLen(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			j := strings.IndexByte(s[i:], 'm')
+			if j < 0 {
+				break
+			}
+			b.WriteString(s[i : i+j+1])
+			i += j + 1
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if n == width-1 {
+			b.WriteRune('…')
+			break
+		}
+		b.WriteRune(r)
+		n++
+		i += size
+	}
+	if strings.Contains(s, "\x1b[") {
+		b.WriteString(reset)
+	}
+	return b.String()
+}
+
+func visibleLen(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			if j := strings.IndexByte(s[i:], 'm'); j >= 0 {
+				i += j + 1
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		n++
+		i += size
+	}
+	return n
+}
+
+const reset = "\x1b[0m"
+
+func bold(s string) string { return "\x1b[1m" + s + reset }
+func red(s string) string  { return "\x1b[31m" + s + reset }
+
+// envSize reads COLUMNS and LINES, defaulting to 100×30.
+func envSize() (int, int) {
+	w, h := 100, 30
+	if v, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && v > 0 {
+		w = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LINES")); err == nil && v > 0 {
+		h = v
+	}
+	return w, h
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package top
+
+// TerminalSize returns the COLUMNS/LINES environment, or a default.
+func TerminalSize() (int, int) {
+	return envSize()
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+package top
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// TerminalSize returns stdout's columns and rows, falling back to the
+// COLUMNS/LINES environment when stdout is not a terminal.
+func TerminalSize() (int, int) {
+	var ws struct{ Row, Col, X, Y uint16 }
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws))) // #nosec G103 -- TIOCGWINSZ fills ws
+	if errno != 0 || ws.Col == 0 || ws.Row == 0 {
+		return envSize()
+	}
+	return int(ws.Col), int(ws.Row)
+}
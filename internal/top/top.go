@@ -0,0 +1,139 @@
+// file: internal/top/top.go
+// version: 1.0.0
+// guid: 6e0b7d35-a2f9-4c18-9d4e-3b85f1c07a62
+// last-edited: 2026-10-16
+//
+// Package top implements `audiobook-organizer top`, a terminal dashboard
+// for headless installs. It talks to a running server over the HTTP API:
+// the operations timeline and system status are polled, and operation
+// events arrive over the SSE stream between polls.
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Options controls Run.
+type Options struct {
+	// Interval between timeline polls. Defaults to 2s.
+	Interval time.Duration
+	// Since is the timeline window. Defaults to 24h.
+	Since time.Duration
+	// Once renders a single frame without the alternate screen and
+	// returns, for scripts and `watch`.
+	Once bool
+	// Size returns the terminal's columns and rows. Defaults to
+	// TerminalSize.
+	Size func() (int, int)
+}
+
+// statusEvery is how many timeline polls pass between status polls;
+// system stats change slowly and the status endpoint walks the library.
+const statusEvery = 5
+
+// Run draws the dashboard to out until ctx is canceled.
+func Run(ctx context.Context, c *Client, server string, opts Options, out io.Writer) error {
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+	if opts.Since <= 0 {
+		opts.Since = 24 * time.Hour
+	}
+	if opts.Size == nil {
+		opts.Size = TerminalSize
+	}
+	d := NewDashboard(server)
+
+	if opts.Once {
+		ops, err := c.Timeline(ctx, opts.Since)
+		if err != nil {
+			return err
+		}
+		d.SetOperations(ops, nil)
+		d.SetStatus(c.Status(ctx))
+		d.SetConnected(true)
+		w, h := opts.Size()
+		_, err = fmt.Fprintln(out, d.Render(w, h, time.Now()))
+		return err
+	}
+
+	// Alternate screen, hidden cursor; both restored on the way out.
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	redraw := make(chan struct{}, 1)
+	poke := func() {
+		select {
+		case redraw <- struct{}{}:
+		default:
+		}
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		streamEvents(ctx, c, d, poke)
+	}()
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for tick := 0; ; tick++ {
+		d.SetOperations(c.Timeline(ctx, opts.Since))
+		if tick%statusEvery == 0 {
+			d.SetStatus(c.Status(ctx))
+		}
+		draw(out, d, opts.Size)
+		for waiting := true; waiting; {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				waiting = false
+			case <-redraw:
+				draw(out, d, opts.Size)
+			}
+		}
+	}
+}
+
+func draw(out io.Writer, d *Dashboard, size func() (int, int)) {
+	w, h := size()
+	// Home the cursor and clear below instead of clearing the whole
+	// screen first, which flickers on slow terminals.
+	fmt.Fprint(out, "\x1b[H"+d.Render(w, h, time.Now())+"\x1b[J")
+}
+
+// streamEvents keeps the SSE stream connected, backing off between
+// reconnects up to 30s.
+func streamEvents(ctx context.Context, c *Client, d *Dashboard, changed func()) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		connectedAt := time.Now()
+		d.SetConnected(true)
+		changed()
+		_ = c.Events(ctx, func(ev Event) {
+			d.AddEvent(ev)
+			changed()
+		})
+		d.SetConnected(false)
+		changed()
+		if time.Since(connectedAt) > time.Minute {
+			backoff = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
@@ -0,0 +1,93 @@
+// file: internal/top/top_test.go
+// version: 1.0.0
+// guid: 7f2c9e14-5a8b-4d36-b0e7-e16d4a93c825
+// last-edited: 2026-10-16
+
+package top
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSSE(t *testing.T) {
+	body := ": heartbeat\n\n" +
+		"event: op.created\ndata: {\"op_id\":\"op1\",\"def_id\":\"library.scan\"}\n\n" +
+		"event: op.updated\ndata: {\"op_id\":\"op1\",\"progress_current\":3,\"progress_total\":10}\n\n"
+	var got []Event
+	err := readSSE(strings.NewReader(body), func(ev Event) { got = append(got, ev) })
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Len(t, got, 2)
+	assert.Equal(t, "op.created", got[0].Name)
+	assert.Equal(t, "library.scan", got[0].Data["def_id"])
+	assert.Equal(t, float64(10), got[1].Data["progress_total"])
+}
+
+func TestDashboard(t *testing.T) {
+	now := time.Now()
+	started := now.Add(-90 * time.Second)
+	d := NewDashboard("http://nas:8484")
+	d.SetOperations([]Operation{
+		{ID: "op1", DefID: "library.scan", DisplayName: "Library scan", Status: "running", QueuedAt: started, StartedAt: &started},
+		{ID: "op2", DefID: "library.organize", Status: "queued", QueuedAt: now},
+		{ID: "op3", DefID: "library.transcode", Status: "completed", QueuedAt: started},
+	}, nil)
+	d.SetConnected(true)
+
+	d.AddEvent(Event{Name: "op.updated", Data: map[string]any{"op_id": "op1", "progress_current": float64(5), "progress_total": float64(10)}})
+	d.AddEvent(Event{Name: "op.current_item", Data: map[string]any{"op_id": "op1", "label": "Dune.m4b"}})
+	d.AddEvent(Event{Name: "op.log", At: now, Data: map[string]any{"op_id": "op1", "level": "warn", "message": "slow disk"}})
+
+	frame := d.Render(120, 30, now)
+	assert.Contains(t, frame, "RUNNING 1   QUEUED 1")
+	assert.Contains(t, frame, "50% 5/10")
+	assert.Contains(t, frame, "Dune.m4b")
+	assert.Contains(t, frame, "library.organize")
+	assert.Contains(t, frame, "WARN slow disk")
+	assert.NotContains(t, frame, "library.transcode")
+	assert.LessOrEqual(t, strings.Count(frame, "\n")+1, 30)
+	for _, line := range strings.Split(frame, "\n") {
+		assert.LessOrEqual(t, visibleLen(line), 120)
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/operations/timeline":
+			assert.Equal(t, "1h0m0s", r.URL.Query().Get("since"))
+			_, _ = w.Write([]byte(`{"data":{"operations":[{"id":"op1","def_id":"library.scan","status":"running","progress_current":1,"progress_total":4}]}}`))
+		case "/api/v1/system/status":
+			_, _ = w.Write([]byte(`{"data":{"version":"1.2.3","total_book_count":42}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, "abk_test", false)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	opts := Options{Once: true, Since: time.Hour, Size: func() (int, int) { return 100, 20 }}
+	require.NoError(t, Run(context.Background(), c, srv.URL, opts, &out))
+	assert.Contains(t, out.String(), "25% 1/4")
+	assert.Contains(t, out.String(), "books 42")
+
+	anon, err := NewClient(strings.TrimPrefix(srv.URL, "http://"), "", false)
+	require.NoError(t, err)
+	err = Run(context.Background(), anon, srv.URL, opts, &out)
+	assert.ErrorContains(t, err, "--token")
+}
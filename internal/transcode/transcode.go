@@ -1,5 +1,5 @@
 // file: internal/transcode/transcode.go
-// version: 1.5.1
+// version: 1.6.0
 // guid: f8a1b2c3-d4e5-6789-abcd-ef0123456789
 
 package transcode
@@ -13,6 +13,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,10 +23,14 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/operations"
 )
 
+// OutputFormats lists the container formats Transcode can produce. Both
+// hold AAC audio with chapters; they differ only in extension.
+var OutputFormats = []string{"m4b", "m4a"}
+
 // TranscodeOpts configures a transcode operation.
 type TranscodeOpts struct {
 	BookID       string
-	OutputFormat string // "m4b" default
+	OutputFormat string // one of OutputFormats, "m4b" default
 	Bitrate      int    // kbps, default 128
 	KeepOriginal bool   // default true
 }
@@ -137,11 +142,15 @@ func probeDuration(ffprobePath, filePath string) (float64, error) {
 // BuildChapterMetadata probes each input file and generates an FFMetadata chapter file.
 // Returns the path to the temp metadata file (caller must clean up).
 func BuildChapterMetadata(inputFiles []string) (string, error) {
+	return buildChapterMetadata(inputFiles, nil)
+}
+
+func buildChapterMetadata(inputFiles, titles []string) (string, error) {
 	ffprobePath, err := FindFFprobe()
 	if err != nil {
 		return "", err
 	}
-	return BuildChapterMetadataWithProber(inputFiles, func(path string) (float64, error) {
+	return buildChapterMetadataWithProber(inputFiles, titles, func(path string) (float64, error) {
 		return probeDuration(ffprobePath, path)
 	})
 }
@@ -149,6 +158,31 @@ func BuildChapterMetadata(inputFiles []string) (string, error) {
 // BuildChapterMetadataWithProber generates chapter metadata using a custom duration prober.
 // This is useful for testing.
 func BuildChapterMetadataWithProber(inputFiles []string, prober func(string) (float64, error)) (string, error) {
+	return buildChapterMetadataWithProber(inputFiles, nil, prober)
+}
+
+// ChapterTitles returns a chapter title per input file, taken from the
+// files' title tags, or nil when any file lacks a title or two files share
+// one; callers then fall back to "Chapter N".
+func ChapterTitles(inputFiles []string, files []database.BookFile) []string {
+	byPath := make(map[string]string, len(files))
+	for _, f := range files {
+		byPath[f.FilePath] = strings.TrimSpace(f.Title)
+	}
+	titles := make([]string, len(inputFiles))
+	seen := make(map[string]bool, len(inputFiles))
+	for i, path := range inputFiles {
+		title := byPath[path]
+		if title == "" || seen[title] {
+			return nil
+		}
+		seen[title] = true
+		titles[i] = title
+	}
+	return titles
+}
+
+func buildChapterMetadataWithProber(inputFiles, titles []string, prober func(string) (float64, error)) (string, error) {
 	f, err := os.CreateTemp("", "audiobook-chapters-*.txt")
 	if err != nil {
 		return "", fmt.Errorf("failed to create chapter file: %w", err)
@@ -170,6 +204,9 @@ func BuildChapterMetadataWithProber(inputFiles []string, prober func(string) (fl
 		}
 		durationMs := int64(dur * 1000)
 		title := fmt.Sprintf("Chapter %d", i+1)
+		if i < len(titles) && titles[i] != "" {
+			title = escapeFFMetadata(titles[i])
+		}
 
 		if _, err := fmt.Fprintf(f, "\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
 			offsetMs, offsetMs+durationMs, title); err != nil {
@@ -187,7 +224,15 @@ func BuildChapterMetadataWithProber(inputFiles []string, prober func(string) (fl
 	return f.Name(), nil
 }
 
-// Transcode converts audio files for a book into a single M4B.
+// escapeFFMetadata escapes the characters FFMetadata files treat as
+// syntax in values.
+func escapeFFMetadata(v string) string {
+	return strings.NewReplacer(`\`, `\\`, "=", `\=`, ";", `\;`, "#", `\#`, "\n", `\`+"\n").Replace(v)
+}
+
+// Transcode converts audio files for a book into a single AAC file in
+// opts.OutputFormat. Multi-file books are concatenated and get one
+// chapter per input file.
 func Transcode(ctx context.Context, opts TranscodeOpts, store interface {
 	database.BookReader
 	database.BookFileStore
@@ -200,6 +245,9 @@ func Transcode(ctx context.Context, opts TranscodeOpts, store interface {
 	if opts.OutputFormat == "" {
 		opts.OutputFormat = "m4b"
 	}
+	if !slices.Contains(OutputFormats, opts.OutputFormat) {
+		return "", fmt.Errorf("unsupported output format %q", opts.OutputFormat)
+	}
 	if opts.Bitrate <= 0 {
 		opts.Bitrate = 128
 	}
@@ -227,19 +275,20 @@ func Transcode(ctx context.Context, opts TranscodeOpts, store interface {
 	}
 
 	multiFile := len(inputFiles) > 1
-	progress.Log("info", fmt.Sprintf("Transcoding %d input file(s) to M4B", len(inputFiles)), nil)
+	progress.Log("info", fmt.Sprintf("Transcoding %d input file(s) to %s", len(inputFiles), strings.ToUpper(opts.OutputFormat)), nil)
 	for i, f := range inputFiles {
 		progress.Log("info", fmt.Sprintf("  Input %d: %s", i+1, f), nil)
 	}
 
-	// Determine output path: same directory, .m4b extension
+	// Determine output path: same directory, extension from the format
 	baseDir := filepath.Dir(inputFiles[0])
 	baseName := strings.TrimSuffix(filepath.Base(book.FilePath), filepath.Ext(book.FilePath))
 	if baseName == "" {
 		baseName = book.Title
 	}
-	outputPath := filepath.Join(baseDir, baseName+".m4b")
-	tmpOutput := filepath.Join(baseDir, baseName+"-transcode.tmp.m4b")
+	ext := "." + opts.OutputFormat
+	outputPath := filepath.Join(baseDir, baseName+ext)
+	tmpOutput := filepath.Join(baseDir, baseName+"-transcode.tmp"+ext)
 
 	// Track all temp files for cleanup on failure
 	tempFiles := []string{tmpOutput}
@@ -360,13 +409,13 @@ func Transcode(ctx context.Context, opts TranscodeOpts, store interface {
 	if multiFile {
 		progress.UpdateProgress(3, 5, "Adding chapter markers")
 
-		chapterFile, err := BuildChapterMetadata(inputFiles)
+		chapterFile, err := buildChapterMetadata(inputFiles, ChapterTitles(inputFiles, bookFiles))
 		if err != nil {
 			slog.Warn("transcode failed to build chapter metadata, skipping", "err", err)
 		} else {
 			defer os.Remove(chapterFile)
 
-			chapteredOutput := outputPath + ".ch.m4b"
+			chapteredOutput := outputPath + ".ch" + ext
 			tempFiles = append(tempFiles, chapteredOutput)
 			chapterArgs := []string{
 				"-y",
@@ -394,7 +443,7 @@ func Transcode(ctx context.Context, opts TranscodeOpts, store interface {
 	if book.CoverURL != nil && *book.CoverURL != "" {
 		coverPath := *book.CoverURL
 		if _, err := os.Stat(coverPath); err == nil {
-			coverOutput := tmpOutput + ".cover.m4b"
+			coverOutput := tmpOutput + ".cover" + ext
 			tempFiles = append(tempFiles, coverOutput)
 			coverArgs := []string{
 				"-y",
@@ -460,7 +509,7 @@ func CleanupStaleTempFiles(rootDir string, maxAge time.Duration) int {
 			return nil
 		}
 		name := info.Name()
-		if strings.Contains(name, "-transcode.tmp") || strings.HasSuffix(name, ".ch.m4b") {
+		if strings.Contains(name, "-transcode.tmp") || strings.HasSuffix(name, ".ch.m4b") || strings.HasSuffix(name, ".ch.m4a") {
 			if time.Since(info.ModTime()) > maxAge {
 				if err := os.Remove(path); err == nil {
 					slog.Info("transcode cleaned up stale temp file (age )", "value0", "path", "path", path, "value1", time.Since(info.ModTime()).Round(time.Minute))
@@ -1,5 +1,5 @@
 // file: internal/transcode/transcode_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: a9b8c7d6-e5f4-3210-fedc-ba9876543210
 
 package transcode
@@ -105,6 +105,48 @@ func TestBuildChapterMetadataWithProber(t *testing.T) {
 	}
 }
 
+func TestChapterTitles(t *testing.T) {
+	inputs := []string{"/b/01.mp3", "/b/02.mp3"}
+	files := []database.BookFile{
+		{FilePath: "/b/02.mp3", Title: "The Storm"},
+		{FilePath: "/b/01.mp3", Title: " Prologue "},
+	}
+	titles := ChapterTitles(inputs, files)
+	if len(titles) != 2 || titles[0] != "Prologue" || titles[1] != "The Storm" {
+		t.Errorf("unexpected titles %q", titles)
+	}
+
+	files[0].Title = "Prologue"
+	if titles := ChapterTitles(inputs, files); titles != nil {
+		t.Errorf("duplicate titles should fall back, got %q", titles)
+	}
+	files[0].Title = ""
+	if titles := ChapterTitles(inputs, files); titles != nil {
+		t.Errorf("a missing title should fall back, got %q", titles)
+	}
+}
+
+func TestBuildChapterMetadata_Titles(t *testing.T) {
+	prober := func(string) (float64, error) { return 60, nil }
+	metaPath, err := buildChapterMetadataWithProber([]string{"a.mp3", "b.mp3"}, []string{"Part 1; Dawn", "Part 2"}, prober)
+	if err != nil {
+		t.Fatalf("buildChapterMetadataWithProber failed: %v", err)
+	}
+	defer os.Remove(metaPath)
+
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatalf("failed to read metadata file: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, `title=Part 1\; Dawn`) {
+		t.Errorf("first title not escaped:\n%s", content)
+	}
+	if !strings.Contains(content, "title=Part 2") || strings.Contains(content, "title=Chapter") {
+		t.Errorf("file titles should replace Chapter N:\n%s", content)
+	}
+}
+
 func TestCollectInputFiles_SingleFile(t *testing.T) {
 	// Create a temp file to use as the book file
 	tmp, err := os.CreateTemp("", "test-audio-*.mp3")
@@ -1,5 +1,5 @@
 // file: internal/updater/register.go
-// version: 2.0.0
+// version: 2.2.0
 // guid: 8c9d0a1b-2c3d-4e5f-6a7b-8c9d0a1b2c3d
 //
 // Service registry registrations for the auto-updater + its scheduler.
@@ -80,11 +80,13 @@ func init() {
 			upd := serviceregistry.Get[*Updater](c, "updater")
 			scheduler := NewScheduler(upd, func() SchedulerConfig {
 				return SchedulerConfig{
-					Enabled:     config.AppConfig.AutoUpdateEnabled,
+					Enabled:     config.AppConfig.AutoUpdateEnabled || config.AppConfig.UpdateCheckEnabled,
+					NotifyOnly:  !config.AppConfig.AutoUpdateEnabled,
 					Channel:     config.AppConfig.AutoUpdateChannel,
 					CheckMins:   config.AppConfig.AutoUpdateCheckMinutes,
 					WindowStart: config.AppConfig.AutoUpdateWindowStart,
 					WindowEnd:   config.AppConfig.AutoUpdateWindowEnd,
+					Location:    config.Location(),
 				}
 			})
 			return &SchedulerStarterAdapter{scheduler: scheduler}, nil
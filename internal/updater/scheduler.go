@@ -1,5 +1,5 @@
 // file: internal/updater/scheduler.go
-// version: 1.0.1
+// version: 1.2.0
 // guid: 3b4c5d6e-7f8a-9b0c-1d2e-3f4a5b6c7d8e
 
 package updater
@@ -16,6 +16,12 @@ type SchedulerConfig struct {
 	CheckMins   int
 	WindowStart int // hour 0-23
 	WindowEnd   int // hour 0-23
+	// NotifyOnly checks for releases (firing the updater's notifier) but
+	// never downloads or applies them.
+	NotifyOnly bool
+	// Location is the zone the window hours are read in; nil means the
+	// process's local zone.
+	Location *time.Location
 }
 
 // Scheduler periodically checks for updates and applies them within a window.
@@ -92,9 +98,16 @@ func (s *Scheduler) tick() {
 	}
 
 	slog.Info("Update available", "currentVersion", info.CurrentVersion, "latestVersion", info.LatestVersion, "channel", info.Channel)
+	if cfg.NotifyOnly {
+		return
+	}
 
 	// Check if current hour is within the update window
-	hour := time.Now().Hour()
+	now := time.Now()
+	if cfg.Location != nil {
+		now = now.In(cfg.Location)
+	}
+	hour := now.Hour()
 	if !inWindow(hour, cfg.WindowStart, cfg.WindowEnd) {
 		slog.Info("Update available but outside update window (%02d:00-%02d:00, current hour %02d)")
 		return
@@ -1,5 +1,5 @@
 // file: internal/updater/updater.go
-// version: 1.0.1
+// version: 1.1.0
 // guid: 2a3b4c5d-6e7f-8a9b-0c1d-2e3f4a5b6c7d
 
 package updater
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -36,6 +37,13 @@ type Updater struct {
 	mu             sync.Mutex
 	lastCheck      *UpdateInfo
 	httpClient     *http.Client
+	apiBase        string // GitHub API root; overridden in tests
+
+	// notify is called once per newly seen release that is newer than the
+	// running version; notifiedVersion remembers the last one announced so
+	// repeated checks don't re-alert.
+	notify          func(UpdateInfo)
+	notifiedVersion string
 }
 
 // githubRelease is the subset of GitHub's release API response we use.
@@ -68,6 +76,7 @@ func NewUpdater(currentVersion string) *Updater {
 	return &Updater{
 		currentVersion: currentVersion,
 		repo:           "falkcorp/audiobook-organizer",
+		apiBase:        "https://api.github.com",
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -81,6 +90,14 @@ func (u *Updater) LastCheck() *UpdateInfo {
 	return u.lastCheck
 }
 
+// SetNotifier registers a callback fired when a check first finds a given
+// newer release. The callback runs on the checking goroutine.
+func (u *Updater) SetNotifier(fn func(UpdateInfo)) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.notify = fn
+}
+
 // CheckForUpdate queries GitHub for the latest version on the given channel.
 func (u *Updater) CheckForUpdate(channel string) (*UpdateInfo, error) {
 	var info *UpdateInfo
@@ -99,13 +116,21 @@ func (u *Updater) CheckForUpdate(channel string) (*UpdateInfo, error) {
 
 	u.mu.Lock()
 	u.lastCheck = info
+	var notify func(UpdateInfo)
+	if info.UpdateAvailable && info.LatestVersion != u.notifiedVersion {
+		u.notifiedVersion = info.LatestVersion
+		notify = u.notify
+	}
 	u.mu.Unlock()
 
+	if notify != nil {
+		notify(*info)
+	}
 	return info, nil
 }
 
 func (u *Updater) checkStable() (*UpdateInfo, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", u.repo)
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", u.apiBase, u.repo)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -149,7 +174,7 @@ func (u *Updater) checkStable() (*UpdateInfo, error) {
 		CurrentVersion:  u.currentVersion,
 		LatestVersion:   latestVersion,
 		Channel:         "stable",
-		UpdateAvailable: latestVersion != u.currentVersion && u.currentVersion != "dev",
+		UpdateAvailable: isNewerVersion(latestVersion, u.currentVersion),
 		ReleaseURL:      release.HTMLURL,
 		ReleaseNotes:    release.Body,
 		PublishedAt:     publishedAt,
@@ -158,7 +183,7 @@ func (u *Updater) checkStable() (*UpdateInfo, error) {
 }
 
 func (u *Updater) checkDevelop() (*UpdateInfo, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/commits/main", u.repo)
+	url := fmt.Sprintf("%s/repos/%s/commits/main", u.apiBase, u.repo)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -291,7 +316,7 @@ func (u *Updater) findAssetURL(info *UpdateInfo) (string, error) {
 	}
 
 	// Fetch the release to get asset URLs
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", u.repo)
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", u.apiBase, u.repo)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
@@ -332,3 +357,47 @@ func (u *Updater) RestartSelf() error {
 	os.Exit(0)
 	return nil // unreachable
 }
+
+// isNewerVersion reports whether latest is a newer release than current.
+// Versions are compared numerically on their dotted major.minor.patch core
+// (a leading "v" and any "-suffix" such as git-describe's "-3-gabc123-dirty"
+// are ignored); when either side doesn't parse, any difference counts as
+// newer. Dev builds never report an update.
+func isNewerVersion(latest, current string) bool {
+	if current == "dev" || latest == "" {
+		return false
+	}
+	l, lok := parseVersionCore(latest)
+	c, cok := parseVersionCore(current)
+	if !lok || !cok {
+		return latest != current
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+// parseVersionCore extracts the numeric major.minor.patch of v. Missing
+// minor/patch parts read as zero.
+func parseVersionCore(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(v, "v")
+	if idx := strings.IndexAny(v, "-+"); idx != -1 {
+		v = v[:idx]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
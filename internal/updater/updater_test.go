@@ -1,5 +1,5 @@
 // file: internal/updater/updater_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: 4c5d6e7f-8a9b-0c1d-2e3f-4a5b6c7d8e9f
 
 package updater
@@ -165,3 +165,91 @@ func TestSchedulerConfig(t *testing.T) {
 		t.Error("ticker should be nil when disabled")
 	}
 }
+
+func TestIsNewerVersion(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"2.0.0", "1.9.9", true},
+		{"1.10.0", "1.9.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"1.2.3", "1.3.0", false},
+		{"1.2.3", "v1.2.3-4-gabc1234-dirty", false},
+		{"1.2.4", "v1.2.3-4-gabc1234-dirty", true},
+		{"1.2", "1.1.9", true},
+		{"2.0.0", "dev", false},
+		{"abc1234", "def5678", true},
+		{"", "1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := isNewerVersion(tt.latest, tt.current); got != tt.want {
+			t.Errorf("isNewerVersion(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestCheckForUpdate_NotifiesOncePerRelease(t *testing.T) {
+	tag := "v2.0.0"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/test/repo/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		json.MarshalWrite(w, githubRelease{TagName: tag, HTMLURL: "https://example.com/" + tag})
+	}))
+	defer srv.Close()
+
+	u := NewUpdater("1.0.0")
+	u.repo = "test/repo"
+	u.apiBase = srv.URL
+	var notified []string
+	u.SetNotifier(func(info UpdateInfo) { notified = append(notified, info.LatestVersion) })
+
+	for range 2 {
+		info, err := u.CheckForUpdate("stable")
+		if err != nil {
+			t.Fatalf("CheckForUpdate: %v", err)
+		}
+		if !info.UpdateAvailable || info.LatestVersion != "2.0.0" {
+			t.Fatalf("unexpected info: %+v", info)
+		}
+	}
+	tag = "v2.1.0"
+	if _, err := u.CheckForUpdate("stable"); err != nil {
+		t.Fatalf("CheckForUpdate: %v", err)
+	}
+
+	if len(notified) != 2 || notified[0] != "2.0.0" || notified[1] != "2.1.0" {
+		t.Errorf("notified = %v, want [2.0.0 2.1.0]", notified)
+	}
+}
+
+func TestSchedulerTick_NotifyOnlyDoesNotApply(t *testing.T) {
+	var assetRequests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assetRequests++
+		json.MarshalWrite(w, githubRelease{TagName: "v2.0.0"})
+	}))
+	defer srv.Close()
+
+	u := NewUpdater("1.0.0")
+	u.repo = "test/repo"
+	u.apiBase = srv.URL
+	notified := false
+	u.SetNotifier(func(UpdateInfo) { notified = true })
+
+	s := NewScheduler(u, func() SchedulerConfig {
+		// A window covering every hour would apply the update if
+		// NotifyOnly were ignored.
+		return SchedulerConfig{Enabled: true, Channel: "stable", WindowStart: 0, WindowEnd: 24, NotifyOnly: true}
+	})
+	s.tick()
+
+	if !notified {
+		t.Error("expected the notifier to fire")
+	}
+	if assetRequests != 1 {
+		t.Errorf("expected only the release check request, got %d requests", assetRequests)
+	}
+}
@@ -1,5 +1,5 @@
 // file: internal/versions/ingest.go
-// version: 1.0.1
+// version: 1.1.0
 // guid: 3e1f2a9b-4c5d-4a70-b8c5-3d7e0f1b9a99
 //
 // Version creation on ingest (spec 3.1 task 5).
@@ -82,6 +82,7 @@ func CreateIngestVersion(store database.Store, params IngestVersionParams) (*dat
 		for _, f := range files {
 			if f.FilePath == params.FilePath {
 				f.FileHash = hash
+				f.FileHashAlgorithm = "sha256" // HashFile always reads the whole file
 				f.VersionID = ver.ID
 				if updateErr := store.UpdateBookFile(f.ID, &f); updateErr != nil {
 					slog.Warn("update file hash", "f", f.ID, "updateErr", updateErr)
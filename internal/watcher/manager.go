@@ -0,0 +1,94 @@
+// file: internal/watcher/manager.go
+// version: 1.0.0
+// guid: 4f8a2c61-9d3e-4b75-a0c8-e16b5d7f3a92
+// last-edited: 2026-10-17
+
+package watcher
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Manager keeps one Watcher per directory in a set that changes over
+// time, such as the enabled import paths. Every watcher shares the same
+// callback and debounce.
+type Manager struct {
+	callback Callback
+	debounce time.Duration
+
+	mu       sync.Mutex
+	watchers map[string]*Watcher
+}
+
+// NewManager creates a Manager. Pass 0 for debounce to use DefaultDebounce.
+func NewManager(callback Callback, debounce time.Duration) *Manager {
+	return &Manager{
+		callback: callback,
+		debounce: debounce,
+		watchers: make(map[string]*Watcher),
+	}
+}
+
+// Sync starts watchers for paths not yet watched and stops watchers for
+// paths no longer listed. It returns the paths started and stopped. A
+// path that fails to start is reported in the error and retried on the
+// next Sync.
+func (m *Manager) Sync(paths []string) (started, stopped []string, err error) {
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[p] = true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for p, w := range m.watchers {
+		if !want[p] {
+			w.Stop()
+			delete(m.watchers, p)
+			stopped = append(stopped, p)
+		}
+	}
+	var errs []error
+	for p := range want {
+		if _, ok := m.watchers[p]; ok {
+			continue
+		}
+		w := New(m.callback, m.debounce)
+		if startErr := w.Start(p); startErr != nil {
+			errs = append(errs, fmt.Errorf("watch %s: %w", p, startErr))
+			continue
+		}
+		m.watchers[p] = w
+		started = append(started, p)
+	}
+	sort.Strings(started)
+	sort.Strings(stopped)
+	return started, stopped, errors.Join(errs...)
+}
+
+// Paths returns the directories currently watched, sorted.
+func (m *Manager) Paths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]string, 0, len(m.watchers))
+	for p := range m.watchers {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Stop stops every watcher.
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for p, w := range m.watchers {
+		w.Stop()
+		delete(m.watchers, p)
+	}
+}
@@ -0,0 +1,51 @@
+// file: internal/watcher/manager_test.go
+// version: 1.0.0
+// guid: 93c5e7a1-2b4d-4f68-8e0a-d7f1c3b59e24
+// last-edited: 2026-10-17
+
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManagerSync(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	m := NewManager(func(string, []string) {}, 50*time.Millisecond)
+	defer m.Stop()
+
+	started, stopped, err := m.Sync([]string{a, b})
+	if err != nil || len(started) != 2 || len(stopped) != 0 {
+		t.Fatalf("first sync: started=%v stopped=%v err=%v", started, stopped, err)
+	}
+
+	started, stopped, err = m.Sync([]string{b})
+	if err != nil || len(started) != 0 || len(stopped) != 1 || stopped[0] != a {
+		t.Fatalf("second sync: started=%v stopped=%v err=%v", started, stopped, err)
+	}
+	if got := m.Paths(); len(got) != 1 || got[0] != b {
+		t.Errorf("Paths = %v, want [%s]", got, b)
+	}
+
+	// A path that can't be watched is reported and retried next time.
+	missing := filepath.Join(a, "later")
+	_, _, err = m.Sync([]string{b, missing})
+	if err == nil {
+		t.Error("expected an error for a missing path")
+	}
+	if err := os.Mkdir(missing, 0755); err != nil {
+		t.Fatal(err)
+	}
+	started, _, err = m.Sync([]string{b, missing})
+	if err != nil || len(started) != 1 || started[0] != missing {
+		t.Errorf("retry: started=%v err=%v", started, err)
+	}
+
+	m.Stop()
+	if got := m.Paths(); len(got) != 0 {
+		t.Errorf("Paths after Stop = %v", got)
+	}
+}
@@ -1,6 +1,7 @@
 // file: internal/watcher/watcher.go
-// version: 2.1.1
+// version: 3.0.0
 // guid: b2c3d4e5-f6a7-8901-bcde-f23456789012
+// last-edited: 2026-10-17
 
 package watcher
 
@@ -8,6 +9,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -30,8 +32,17 @@ var audioExtensions = map[string]bool{
 // DefaultDebounce is the default debounce period.
 const DefaultDebounce = 5 * time.Second
 
-// Callback is invoked after the debounce period with the root directory.
-type Callback func(rootDir string)
+// Callback is invoked once changes settle, with the root directory and
+// the directories under it whose audio files changed (sorted, nested
+// directories folded into their changed ancestors).
+type Callback func(rootDir string, changedDirs []string)
+
+// fileState is what a pending file looked like at its last event.
+type fileState struct {
+	size    int64
+	modTime time.Time
+	exists  bool
+}
 
 // Watcher monitors a directory tree for audio file changes and invokes a
 // callback after a debounce period.
@@ -46,6 +57,8 @@ type Watcher struct {
 	timer     *time.Timer
 	scanGen   uint64
 	running   bool
+	// pending holds the audio files changed since the last callback.
+	pending map[string]fileState
 }
 
 // New creates a Watcher. The callback is called with rootDir after events
@@ -59,6 +72,7 @@ func New(callback Callback, debounce time.Duration) *Watcher {
 		callback: callback,
 		stop:     make(chan struct{}),
 		stopped:  make(chan struct{}),
+		pending:  make(map[string]fileState),
 	}
 }
 
@@ -69,13 +83,20 @@ func (w *Watcher) Start(rootDir string) error {
 		w.mu.Unlock()
 		return nil
 	}
-	w.running = true
 	w.mu.Unlock()
 
+	// A missing root (an unmounted share) is an error so the caller can
+	// retry later, rather than a watcher that never sees anything.
+	if _, err := os.Stat(rootDir); err != nil {
+		return err
+	}
 	fsw, err := fsnotify.NewWatcher()
 	if err != nil {
 		return err
 	}
+	w.mu.Lock()
+	w.running = true
+	w.mu.Unlock()
 	w.fsWatcher = fsw
 	w.rootDir = rootDir
 
@@ -164,9 +185,20 @@ func (w *Watcher) handleEvent(event fsnotify.Event) {
 		return
 	}
 
+	w.mu.Lock()
+	w.pending[event.Name] = statFile(event.Name)
+	w.mu.Unlock()
 	w.scheduleScan()
 }
 
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{size: info.Size(), modTime: info.ModTime(), exists: true}
+}
+
 func (w *Watcher) scheduleScan() {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -181,21 +213,84 @@ func (w *Watcher) scheduleScan() {
 
 	w.timer = time.AfterFunc(w.debounce, func() {
 		w.mu.Lock()
-		if w.scanGen != gen {
-			// A newer event superseded this timer; do nothing.
+		if w.scanGen != gen || !w.running {
+			// A newer event superseded this timer, or we were stopped.
 			w.mu.Unlock()
 			return
 		}
 		w.timer = nil
+		// A file still being copied may not raise events often enough to
+		// hold the debounce open (network copies, some download
+		// clients). Wait another round until every pending file's size
+		// and mtime are unchanged since last seen.
+		if !w.settleLocked() {
+			w.mu.Unlock()
+			slog.Debug("watcher waiting for files to settle", "rootDir", w.rootDir)
+			w.scheduleScan()
+			return
+		}
+		dirs := changedDirs(w.rootDir, w.pending)
+		w.pending = make(map[string]fileState)
 		w.mu.Unlock()
 
-		slog.Info("watcher triggering callback", "rootDir", w.rootDir)
+		slog.Info("watcher triggering callback", "rootDir", w.rootDir, "changedDirs", len(dirs))
 		if w.callback != nil {
-			w.callback(w.rootDir)
+			w.callback(w.rootDir, dirs)
 		}
 	})
 }
 
+// settleLocked re-stats the pending files and reports whether all of
+// them are unchanged since they were last seen. Callers hold w.mu.
+func (w *Watcher) settleLocked() bool {
+	settled := true
+	for path, prev := range w.pending {
+		cur := statFile(path)
+		if cur != prev {
+			w.pending[path] = cur
+			settled = false
+		}
+	}
+	return settled
+}
+
+// changedDirs returns the directories holding the pending files, with
+// any directory under another changed directory dropped. A directory
+// that no longer exists is replaced by its nearest existing ancestor
+// inside root.
+func changedDirs(root string, pending map[string]fileState) []string {
+	set := make(map[string]bool)
+	for path := range pending {
+		dir := filepath.Dir(path)
+		for dir != root && strings.HasPrefix(dir, root) {
+			if _, err := os.Stat(dir); err == nil {
+				break
+			}
+			dir = filepath.Dir(dir)
+		}
+		set[dir] = true
+	}
+	dirs := make([]string, 0, len(set))
+	for d := range set {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+	var out []string
+	for _, d := range dirs {
+		nested := false
+		for _, kept := range out {
+			if strings.HasPrefix(d, kept+string(filepath.Separator)) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
 // IsAudioFile reports whether name has a recognized audio extension.
 func IsAudioFile(name string) bool {
 	ext := strings.ToLower(filepath.Ext(name))
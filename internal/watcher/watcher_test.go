@@ -1,6 +1,7 @@
 // file: internal/watcher/watcher_test.go
-// version: 1.0.0
+// version: 1.1.0
 // guid: a1b2c3d4-e5f6-7890-abcd-ef1234567890
+// last-edited: 2026-10-17
 
 package watcher
 
@@ -43,7 +44,7 @@ func TestDebounceSingleEvent(t *testing.T) {
 	dir := t.TempDir()
 
 	var calls atomic.Int32
-	w := New(func(rootDir string) {
+	w := New(func(string, []string) {
 		calls.Add(1)
 	}, 100*time.Millisecond)
 
@@ -70,7 +71,7 @@ func TestDebounceMultipleEvents(t *testing.T) {
 	dir := t.TempDir()
 
 	var calls atomic.Int32
-	w := New(func(rootDir string) {
+	w := New(func(string, []string) {
 		calls.Add(1)
 	}, 200*time.Millisecond)
 
@@ -98,7 +99,7 @@ func TestNonAudioFilesIgnored(t *testing.T) {
 	dir := t.TempDir()
 
 	var calls atomic.Int32
-	w := New(func(rootDir string) {
+	w := New(func(string, []string) {
 		calls.Add(1)
 	}, 100*time.Millisecond)
 
@@ -126,7 +127,7 @@ func TestRecursiveWatching(t *testing.T) {
 	}
 
 	var calls atomic.Int32
-	w := New(func(rootDir string) {
+	w := New(func(string, []string) {
 		calls.Add(1)
 	}, 100*time.Millisecond)
 
@@ -147,7 +148,7 @@ func TestRecursiveWatching(t *testing.T) {
 
 func TestStopIsIdempotent(t *testing.T) {
 	dir := t.TempDir()
-	w := New(func(string) {}, 100*time.Millisecond)
+	w := New(func(string, []string) {}, 100*time.Millisecond)
 	if err := w.Start(dir); err != nil {
 		t.Fatal(err)
 	}
@@ -157,7 +158,7 @@ func TestStopIsIdempotent(t *testing.T) {
 
 func TestStartIsIdempotent(t *testing.T) {
 	dir := t.TempDir()
-	w := New(func(string) {}, 100*time.Millisecond)
+	w := New(func(string, []string) {}, 100*time.Millisecond)
 	if err := w.Start(dir); err != nil {
 		t.Fatal(err)
 	}
@@ -175,7 +176,7 @@ func TestDeleteTriggers(t *testing.T) {
 
 	var mu sync.Mutex
 	var called bool
-	w := New(func(string) {
+	w := New(func(string, []string) {
 		mu.Lock()
 		called = true
 		mu.Unlock()
@@ -198,3 +199,98 @@ func TestDeleteTriggers(t *testing.T) {
 		t.Error("expected callback on file deletion")
 	}
 }
+
+func TestCallbackReportsChangedDirs(t *testing.T) {
+	dir := t.TempDir()
+	book := filepath.Join(dir, "author", "book")
+	other := filepath.Join(dir, "other")
+	for _, d := range []string{book, other} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := make(chan []string, 1)
+	w := New(func(_ string, dirs []string) { got <- dirs }, 100*time.Millisecond)
+	if err := w.Start(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	_ = os.WriteFile(filepath.Join(book, "01.mp3"), []byte("a"), 0644)
+	_ = os.WriteFile(filepath.Join(book, "02.mp3"), []byte("b"), 0644)
+	_ = os.WriteFile(filepath.Join(other, "x.m4b"), []byte("c"), 0644)
+
+	select {
+	case dirs := <-got:
+		want := []string{book, other}
+		if len(dirs) != 2 || dirs[0] != want[0] || dirs[1] != want[1] {
+			t.Errorf("changedDirs = %v, want %v", dirs, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback not invoked")
+	}
+}
+
+func TestChangedDirs(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a")
+	ab := filepath.Join(a, "b")
+	ac := filepath.Join(root, "a c")
+	for _, d := range []string{ab, ac} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pending := map[string]fileState{
+		filepath.Join(ab, "1.mp3"):                {},
+		filepath.Join(a, "2.mp3"):                 {},
+		filepath.Join(ac, "3.mp3"):                {},
+		filepath.Join(root, "gone", "x", "4.mp3"): {}, // deleted folder: nearest existing ancestor
+	}
+	got := changedDirs(root, pending)
+	want := []string{root}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("changedDirs = %v, want %v", got, want)
+	}
+
+	delete(pending, filepath.Join(root, "gone", "x", "4.mp3"))
+	got = changedDirs(root, pending)
+	if len(got) != 2 || got[0] != a || got[1] != ac {
+		t.Errorf("changedDirs = %v, want [%s %s]", got, a, ac)
+	}
+}
+
+// A file whose size keeps changing between checks — a copy in progress —
+// holds the callback back until it stops growing.
+func TestWaitsForGrowingFile(t *testing.T) {
+	dir := t.TempDir()
+	f := filepath.Join(dir, "book.m4b")
+
+	var calls atomic.Int32
+	w := New(func(string, []string) { calls.Add(1) }, 100*time.Millisecond)
+	if err := w.Start(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	if err := os.WriteFile(f, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Grow the file behind the watcher's back: record a stale state so the
+	// settle check sees a change, as it would for a copy that raises no
+	// further events.
+	time.Sleep(20 * time.Millisecond)
+	w.mu.Lock()
+	w.pending[f] = fileState{size: 0, exists: true}
+	w.mu.Unlock()
+
+	time.Sleep(150 * time.Millisecond)
+	if c := calls.Load(); c != 0 {
+		t.Fatalf("callback fired before file settled (%d calls)", c)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if c := calls.Load(); c != 1 {
+		t.Errorf("expected 1 callback once settled, got %d", c)
+	}
+}
@@ -1,5 +1,5 @@
 // file: main.go
-// version: 1.6.0
+// version: 1.7.0
 // guid: 5f6a7b8c-9d0e-1f2a-3b4c-5d6e7f8a9b0c
 
 package main
@@ -13,8 +13,13 @@ import (
 	"github.com/falkcorp/audiobook-organizer/internal/server"
 )
 
-// version is set at build time via -ldflags "-X main.version=..."
-var version = "dev"
+// version, commit and date are set at build time via
+// -ldflags "-X main.version=... -X main.commit=... -X main.date=..."
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
 
 var executeCmd = cmd.Execute
 
@@ -26,6 +31,7 @@ func run() int {
 	// Set version everywhere
 	cmd.SetVersion(version)
 	server.SetVersion(version)
+	server.SetBuildInfo(commit, date)
 	server.SetEmbeddedFS(WebFS)
 
 	// MAYDEPLOY-A: operation-runner child mode must be detected BEFORE
@@ -0,0 +1,62 @@
+#!/usr/bin/env python3
+"""Compare two `go test -bench` outputs and fail on ns/op regressions.
+
+Usage: bench_guard.py BASELINE CURRENT [--threshold 0.15]
+
+Each benchmark's median ns/op across -count runs is compared. Any
+benchmark that got slower by more than the threshold (default 15%)
+is reported and the script exits 1. Benchmarks present in only one
+file are listed but never fail the guard, so adding a benchmark does
+not require regenerating the baseline first.
+"""
+from __future__ import annotations
+
+import argparse
+import re
+import statistics
+import sys
+
+LINE = re.compile(r"^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op")
+
+
+def parse(path: str) -> dict[str, float]:
+    runs: dict[str, list[float]] = {}
+    with open(path, encoding="utf-8") as fh:
+        for line in fh:
+            m = LINE.match(line)
+            if m:
+                runs.setdefault(m.group(1), []).append(float(m.group(2)))
+    return {name: statistics.median(vals) for name, vals in runs.items()}
+
+
+def main() -> int:
+    ap = argparse.ArgumentParser()
+    ap.add_argument("baseline")
+    ap.add_argument("current")
+    ap.add_argument("--threshold", type=float, default=0.15)
+    args = ap.parse_args()
+
+    base = parse(args.baseline)
+    cur = parse(args.current)
+    regressions = []
+    for name in sorted(cur):
+        if name not in base:
+            print(f"  new       {name}: {cur[name]:.0f} ns/op")
+            continue
+        delta = (cur[name] - base[name]) / base[name]
+        marker = "REGRESSED" if delta > args.threshold else "ok"
+        print(f"  {marker:<9} {name}: {base[name]:.0f} -> {cur[name]:.0f} ns/op ({delta:+.1%})")
+        if delta > args.threshold:
+            regressions.append(name)
+    for name in sorted(set(base) - set(cur)):
+        print(f"  missing   {name}")
+
+    if regressions:
+        print(f"\n{len(regressions)} benchmark(s) regressed more than {args.threshold:.0%}")
+        return 1
+    print("\nNo regressions beyond threshold")
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
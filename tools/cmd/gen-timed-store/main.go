@@ -0,0 +1,307 @@
+// file: tools/cmd/gen-timed-store/main.go
+// version: 1.0.0
+// guid: 7c2e9a14-5b3d-4f80-a6e1-d94f0b2c8e37
+// last-edited: 2026-10-16
+
+// Package main implements gen-timed-store, which writes
+// internal/database/timed_store_gen.go: one TimedStore method per
+// exported *PebbleStore method, each timing the call and reporting it to
+// the slow-query log.
+//
+// The wrapper mirrors the concrete store's whole method set rather than
+// the Store interface, so optional-interface assertions made on the store
+// (LSH index, cursor paging, operation archive, ...) still succeed when
+// it is wrapped.
+//
+// Usage (from internal/database, via go generate):
+//
+//	go run ../../tools/cmd/gen-timed-store
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const outFile = "timed_store_gen.go"
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+	imports map[string]string // package name -> import path
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+func main() {
+	dir := "."
+	if len(os.Args) > 1 {
+		dir = os.Args[1]
+	}
+	methods, err := collect(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gen-timed-store: %v\n", err)
+		os.Exit(1)
+	}
+	src, err := render(methods)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gen-timed-store: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(dir, outFile), src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "gen-timed-store: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// collect parses the package's non-test files and returns its exported
+// *PebbleStore methods sorted by name.
+func collect(dir string) ([]method, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var out []method
+	for _, path := range paths {
+		base := filepath.Base(path)
+		if strings.HasSuffix(base, "_test.go") || base == outFile {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		imports := fileImports(f)
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() || !isPebbleStoreRecv(fn.Recv) {
+				continue
+			}
+			m, err := buildMethod(fset, fn, imports)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", base, fn.Name.Name, err)
+			}
+			out = append(out, m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out, nil
+}
+
+func isPebbleStoreRecv(recv *ast.FieldList) bool {
+	if len(recv.List) != 1 {
+		return false
+	}
+	star, ok := recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	id, ok := star.X.(*ast.Ident)
+	return ok && id.Name == "PebbleStore"
+}
+
+func fileImports(f *ast.File) map[string]string {
+	out := map[string]string{}
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := defaultPackageName(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		out[name] = path
+	}
+	return out
+}
+
+// reserved are names the generated method bodies use; parameters with
+// these names are renamed.
+var reserved = map[string]bool{"_": true, "t": true, "start": true, "d": true, "slow": true, "time": true}
+
+func buildMethod(fset *token.FileSet, fn *ast.FuncDecl, fileImps map[string]string) (method, error) {
+	m := method{name: fn.Name.Name, imports: map[string]string{}}
+	typeString := func(expr ast.Expr) (string, error) {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					if path, ok := fileImps[id.Name]; ok {
+						m.imports[id.Name] = path
+					}
+				}
+			}
+			return true
+		})
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, fset, expr); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	n := 0
+	for _, field := range fn.Type.Params.List {
+		typ := field.Type
+		variadic := false
+		if ell, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = ell.Elt, true
+		}
+		ts, err := typeString(typ)
+		if err != nil {
+			return m, err
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		for _, id := range names {
+			name := id.Name
+			if reserved[name] {
+				name = fmt.Sprintf("a%d", n)
+			}
+			m.params = append(m.params, param{name: name, typ: ts, variadic: variadic})
+			n++
+		}
+	}
+	if fn.Type.Results != nil {
+		for _, field := range fn.Type.Results.List {
+			ts, err := typeString(field.Type)
+			if err != nil {
+				return m, err
+			}
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				m.results = append(m.results, ts)
+			}
+		}
+	}
+	return m, nil
+}
+
+func render(methods []method) ([]byte, error) {
+	imports := map[string]string{"time": "time"}
+	for _, m := range methods {
+		for name, path := range m.imports {
+			imports[name] = path
+		}
+	}
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("// Code generated by tools/cmd/gen-timed-store; DO NOT EDIT.\n\n")
+	b.WriteString("package database\n\nimport (\n")
+	// Standard library first, then everything else.
+	for _, std := range []bool{true, false} {
+		if !std {
+			b.WriteString("\n")
+		}
+		for _, name := range names {
+			path := imports[name]
+			if isStd(path) != std {
+				continue
+			}
+			if defaultPackageName(path) == name {
+				fmt.Fprintf(&b, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&b, "\t%s %q\n", name, path)
+			}
+		}
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// timedStoreMethods names the wrapped methods; TimedStore keeps its\n")
+	b.WriteString("// per-method statistics at the same index.\n")
+	b.WriteString("var timedStoreMethods = [...]string{\n")
+	for _, m := range methods {
+		fmt.Fprintf(&b, "\t%q,\n", m.name)
+	}
+	b.WriteString("}\n")
+
+	for i, m := range methods {
+		renderMethod(&b, i, m)
+	}
+	return format.Source([]byte(b.String()))
+}
+
+// defaultPackageName guesses a package's name from its import path,
+// skipping a major-version suffix (".../pebble/v2" is pebble).
+func defaultPackageName(path string) string {
+	parts := strings.Split(path, "/")
+	last := parts[len(parts)-1]
+	if len(parts) > 1 && len(last) > 1 && last[0] == 'v' && strings.Trim(last[1:], "0123456789") == "" {
+		last = parts[len(parts)-2]
+	}
+	return last
+}
+
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+func renderMethod(b *strings.Builder, idx int, m method) {
+	var sig, call, slowArgs []string
+	for _, p := range m.params {
+		if p.variadic {
+			sig = append(sig, p.name+" ..."+p.typ)
+			call = append(call, p.name+"...")
+		} else {
+			sig = append(sig, p.name+" "+p.typ)
+			call = append(call, p.name)
+		}
+		slowArgs = append(slowArgs, strconv.Quote(p.name), p.name)
+	}
+	results := ""
+	switch len(m.results) {
+	case 0:
+	case 1:
+		results = " " + m.results[0]
+	default:
+		results = " (" + strings.Join(m.results, ", ") + ")"
+	}
+	rets := make([]string, len(m.results))
+	for i := range rets {
+		rets[i] = fmt.Sprintf("ret%d", i)
+	}
+
+	fmt.Fprintf(b, "\nfunc (t *TimedStore) %s(%s)%s {\n", m.name, strings.Join(sig, ", "), results)
+	b.WriteString("\tstart := time.Now()\n")
+	invoke := fmt.Sprintf("t.inner.%s(%s)", m.name, strings.Join(call, ", "))
+	if len(rets) == 0 {
+		b.WriteString("\t" + invoke + "\n")
+	} else {
+		b.WriteString("\t" + strings.Join(rets, ", ") + " := " + invoke + "\n")
+	}
+	fmt.Fprintf(b, "\tif d, slow := t.record(%d, start); slow {\n", idx)
+	if len(slowArgs) == 0 {
+		fmt.Fprintf(b, "\t\tt.slow(%d, start, d)\n", idx)
+	} else {
+		fmt.Fprintf(b, "\t\tt.slow(%d, start, d, %s)\n", idx, strings.Join(slowArgs, ", "))
+	}
+	b.WriteString("\t}\n")
+	if len(rets) > 0 {
+		b.WriteString("\treturn " + strings.Join(rets, ", ") + "\n")
+	}
+	b.WriteString("}\n")
+}
@@ -0,0 +1,54 @@
+// file: web/src/basePath.ts
+// version: 1.0.0
+// guid: 5bd44dac-e8ca-4ce1-8a7f-f21b7cea29cd
+
+declare global {
+  interface Window {
+    __BASE_PATH__?: string;
+  }
+}
+
+/**
+ * Sub-path the app is served under (e.g. "/audiobooks"), injected into
+ * index.html by the server when `base_path` is configured; "" at the root.
+ */
+export const basePath: string =
+  (typeof window !== 'undefined' && window.__BASE_PATH__) || '';
+
+/** Prefixes a root-relative URL with basePath. */
+export function withBasePath(url: string): string {
+  if (
+    !basePath ||
+    !url.startsWith('/') ||
+    url.startsWith('//') ||
+    url === basePath ||
+    url.startsWith(`${basePath}/`)
+  ) {
+    return url;
+  }
+  return basePath + url;
+}
+
+/**
+ * Routes root-relative fetch and EventSource URLs through basePath, so the
+ * `/api/v1/...` call sites across the services keep working when the app is
+ * hosted under a sub-path. A no-op at the root.
+ */
+export function installBasePathShim(): void {
+  if (!basePath) {
+    return;
+  }
+  const originalFetch = window.fetch.bind(window);
+  window.fetch = (input: RequestInfo | URL, init?: RequestInit) =>
+    originalFetch(
+      typeof input === 'string' ? withBasePath(input) : input,
+      init
+    );
+
+  const OriginalEventSource = window.EventSource;
+  window.EventSource = class extends OriginalEventSource {
+    constructor(url: string | URL, init?: EventSourceInit) {
+      super(typeof url === 'string' ? withBasePath(url) : url, init);
+    }
+  };
+}
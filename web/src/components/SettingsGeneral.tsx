@@ -1,7 +1,7 @@
 // file: web/src/components/SettingsGeneral.tsx
-// version: 1.0.2
+// version: 1.3.0
 // guid: 72ebd6f3-7436-4f24-8233-205c50dd05fb
-// last-edited: 2026-05-01
+// last-edited: 2026-10-17
 
 import { Dispatch, SetStateAction } from 'react';
 import {
@@ -36,6 +36,8 @@ import * as api from '../services/api';
 interface SettingsState {
   libraryPath: string;
   organizationStrategy: string;
+  organizationLayout: string;
+  writeSeriesMetadata: boolean;
   scanOnStartup: boolean;
   autoOrganize: boolean;
   folderNamingPattern: string;
@@ -173,6 +175,8 @@ export function SettingsGeneral(props: SettingsGeneralProps) {
     const replacements: Record<string, string> = {
       '{title}': exampleData.title,
       '{author}': exampleData.author,
+      '{authors}': exampleData.author,
+      '{first_author}': exampleData.author,
       '{narrator}': exampleData.narrator,
       '{series}': exampleData.series || '',
       '{series_number}': exampleData.series_number || '',
@@ -289,6 +293,27 @@ export function SettingsGeneral(props: SettingsGeneralProps) {
         </Typography>
       </Grid>
 
+      <Grid item xs={12}>
+        <TextField
+          fullWidth
+          select
+          label="Library Layout"
+          value={props.settings.organizationLayout}
+          onChange={(e) =>
+            props.handleChange('organizationLayout', e.target.value)
+          }
+          helperText="Folder structure organized books are placed in"
+        >
+          <MenuItem value="pattern">Folder naming pattern (below)</MenuItem>
+          <MenuItem value="author_series">Author / Series / Title</MenuItem>
+          <MenuItem value="flat">Flat (Author - Title)</MenuItem>
+          <MenuItem value="genre">Genre / Author / Series / Title</MenuItem>
+          <MenuItem value="first_letter">
+            First letter (A–Z) / Author / Series / Title
+          </MenuItem>
+        </TextField>
+      </Grid>
+
       <Grid item xs={12}>
         <FormControlLabel
           control={
@@ -317,6 +342,29 @@ export function SettingsGeneral(props: SettingsGeneralProps) {
         />
       </Grid>
 
+      <Grid item xs={12}>
+        <FormControlLabel
+          control={
+            <Switch
+              checked={props.settings.writeSeriesMetadata}
+              onChange={(e) =>
+                props.handleChange('writeSeriesMetadata', e.target.checked)
+              }
+            />
+          }
+          label="Write series.json to series folders"
+        />
+        <Typography
+          variant="caption"
+          color="text.secondary"
+          sx={{ display: 'block' }}
+        >
+          Lists each series' books in order, with any missing numbers, for
+          other tools to read. Kept up to date as books are organized or
+          deleted.
+        </Typography>
+      </Grid>
+
       <Grid item xs={12}>
         <Typography variant="h6" gutterBottom sx={{ mt: 2 }}>
           Scan Settings
@@ -401,7 +449,8 @@ export function SettingsGeneral(props: SettingsGeneralProps) {
             props.handleChange('folderNamingPattern', e.target.value)
           }
           helperText={
-            'Available: {title}, {author}, {series}, {series_number}, ' +
+            'Available: {title}, {author}, {authors}, {first_author}, ' +
+            '{series}, {series_number}, ' +
             '{print_year}, {audiobook_release_year}, {year}, ' +
             '{publisher}, {edition}, {narrator}, {language}, ' +
             '{isbn10}, {isbn13}, {track_number}, {total_tracks}.'
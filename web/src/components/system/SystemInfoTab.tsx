@@ -1,9 +1,10 @@
 // file: web/src/components/system/SystemInfoTab.tsx
-// version: 1.6.0
+// version: 1.7.0
 // guid: 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d
 
 import { useState, useEffect } from 'react';
 import {
+  Alert,
   Box,
   Typography,
   Grid,
@@ -61,6 +62,8 @@ export function SystemInfoTab() {
   const [info, setInfo] = useState<SystemInfo | null>(null);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
+  const [lock, setLock] = useState<api.LibraryLockStatus | null>(null);
+  const [takingOver, setTakingOver] = useState(false);
 
   useEffect(() => {
     fetchSystemInfo();
@@ -76,6 +79,7 @@ export function SystemInfoTab() {
       const libraryBooks =
         status.library_book_count ?? status.library.book_count;
       const folderCount = status.import_paths?.folder_count ?? 0;
+      setLock(status.library_lock ?? null);
 
       // Map API SystemStatus to SystemInfo format
       setInfo({
@@ -119,6 +123,19 @@ export function SystemInfoTab() {
     }
   };
 
+  const handleTakeover = async () => {
+    setTakingOver(true);
+    try {
+      setLock(await api.takeoverLibraryLock());
+    } catch (err) {
+      setError(
+        err instanceof Error ? err.message : 'Failed to take over library lock'
+      );
+    } finally {
+      setTakingOver(false);
+    }
+  };
+
   const formatBytes = (bytes: number): string => {
     const sizes = ['Bytes', 'KB', 'MB', 'GB', 'TB'];
     if (bytes === 0) return '0 Bytes';
@@ -206,6 +223,28 @@ export function SystemInfoTab() {
         </Button>
       </Stack>
 
+      {lock && !lock.held && lock.holder && (
+        <Alert
+          severity="warning"
+          sx={{ mb: 2 }}
+          action={
+            <Button
+              color="inherit"
+              size="small"
+              onClick={handleTakeover}
+              disabled={takingOver}
+            >
+              Take over
+            </Button>
+          }
+        >
+          Another instance ({lock.holder.hostname}, pid {lock.holder.pid})
+          holds the library lock, last seen{' '}
+          {new Date(lock.holder.heartbeat_at).toLocaleString()}. Destructive
+          operations are refused on this instance until it lets go.
+        </Alert>
+      )}
+
       <Grid container spacing={3}>
         {/* Operating System */}
         <Grid item xs={12} md={6}>
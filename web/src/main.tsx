@@ -1,5 +1,5 @@
 // file: web/src/main.tsx
-// version: 1.4.0
+// version: 1.5.0
 // guid: 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d
 
 import React, { useMemo } from 'react';
@@ -12,6 +12,9 @@ import { ErrorBoundary } from './components/ErrorBoundary';
 import { ToastProvider } from './components/toast/ToastProvider';
 import { useAppStore } from './stores/useAppStore';
 import { AuthProvider } from './contexts/AuthContext';
+import { basePath, installBasePathShim } from './basePath';
+
+installBasePathShim();
 
 // eslint-disable-next-line react-refresh/only-export-components
 function AppRoot() {
@@ -21,6 +24,7 @@ function AppRoot() {
   const app = (
     <ErrorBoundary>
       <BrowserRouter
+        basename={basePath || undefined}
         future={{ v7_startTransition: true, v7_relativeSplatPath: true }}
       >
         <ThemeProvider theme={theme}>
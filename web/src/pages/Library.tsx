@@ -1,7 +1,7 @@
 // file: web/src/pages/Library.tsx
-// version: 1.68.0
+// version: 1.70.0
 // guid: 3f4a5b6c-7d8e-9f0a-1b2c-3d4e5f6a7b8c
-// last-edited: 2026-05-20
+// last-edited: 2026-10-17
 
 import { useState, useEffect, useCallback, useRef } from 'react';
 import { useNavigate, useSearchParams } from 'react-router-dom';
@@ -684,7 +684,7 @@ export const Library = ({ defaultPreset = 'standard' }: LibraryProps) => {
       ]);
 
       const items = page_.items;
-      const serverCount = page_.count;
+      const serverCount = page_.total ?? page_.count;
 
       let convertedBooks: Audiobook[] = items.map(convertApiBook);
 
@@ -939,14 +939,14 @@ export const Library = ({ defaultPreset = 'standard' }: LibraryProps) => {
     try {
       const activeBooks = selectedAudiobooks.filter((book) => !book.marked_for_deletion);
       const idsToDelete = activeBooks.map((b) => b.id);
-      const results = await Promise.all(
-        idsToDelete.map((id) => api.deleteBook(id, { softDelete: true, blockHash: true }))
-      );
-      const blockedFailures = results.filter((result) => result.blocked !== true).length;
-      const baseMessage = `Soft deleted ${activeBooks.length} selected audiobooks.`;
-      if (blockedFailures > 0) {
+      const result = await api.batchDeleteBooks(idsToDelete, {
+        softDelete: true,
+        blockHash: true,
+      });
+      const baseMessage = `Soft deleted ${result.success} selected audiobooks.`;
+      if (result.failed > 0) {
         toast(
-          `${baseMessage} ${blockedFailures} hash${blockedFailures === 1 ? '' : 'es'} could not be blocked.`,
+          `${baseMessage} ${result.failed} could not be deleted.`,
           'warning'
         );
       } else {
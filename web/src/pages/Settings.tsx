@@ -1,7 +1,7 @@
 // file: web/src/pages/Settings.tsx
-// version: 1.45.5
+// version: 1.50.0
 // guid: 7a8b9c0d-1e2f-3a4b-5c6d-7e8f9a0b1c2d
-// last-edited: 2026-05-20
+// last-edited: 2026-10-17
 
 import { useState, useEffect, useMemo, useRef, ChangeEvent } from 'react';
 import { useNavigate, useLocation } from 'react-router-dom';
@@ -38,6 +38,7 @@ import {
   Stack,
 } from '@mui/material';
 import * as api from '../services/api';
+import { eventSourceManager } from '../services/eventSourceManager';
 import { ServerFileBrowser } from '../components/common/ServerFileBrowser';
 import { SettingsGeneral } from '../components/SettingsGeneral';
 import BlockedHashesTab from '../components/settings/BlockedHashesTab';
@@ -524,6 +525,7 @@ function tabFromHash(hash: string): number {
 interface UpdatesSectionProps {
   settings: {
     autoUpdateEnabled: boolean;
+    updateCheckEnabled: boolean;
     autoUpdateChannel: string;
     autoUpdateCheckMinutes: number;
     autoUpdateWindowStart: number;
@@ -610,6 +612,24 @@ function UpdatesSection({ settings, setSettings }: UpdatesSectionProps) {
           />
         </Grid>
 
+        <Grid item xs={12} sm={6}>
+          <FormControlLabel
+            control={
+              <Switch
+                checked={settings.updateCheckEnabled || settings.autoUpdateEnabled}
+                disabled={settings.autoUpdateEnabled}
+                onChange={(e) =>
+                  setSettings((prev) => ({
+                    ...prev,
+                    updateCheckEnabled: e.target.checked,
+                  }))
+                }
+              />
+            }
+            label="Notify when a new release is available"
+          />
+        </Grid>
+
         <Grid item xs={12} sm={6}>
           <TextField
             select
@@ -836,6 +856,8 @@ interface UiMetadataSource {
 interface SettingsState {
   libraryPath: string;
   organizationStrategy: string;
+  organizationLayout: string;
+  writeSeriesMetadata: boolean;
   scanOnStartup: boolean;
   autoOrganize: boolean;
   folderNamingPattern: string;
@@ -865,7 +887,9 @@ interface SettingsState {
   enableJsonLogging: boolean;
   purgeSoftDeletedAfterDays: number;
   purgeSoftDeletedDeleteFiles: boolean;
+  trashRetentionDays: number;
   autoUpdateEnabled: boolean;
+  updateCheckEnabled: boolean;
   autoUpdateChannel: string;
   autoUpdateCheckMinutes: number;
   autoUpdateWindowStart: number;
@@ -954,6 +978,9 @@ export function Settings() {
     libraryPath: '/path/to/audiobooks/library',
     // 'auto', 'copy', 'hardlink', 'reflink', 'symlink'
     organizationStrategy: 'auto',
+    // 'pattern', 'author_series', 'flat', 'genre', 'first_letter'
+    organizationLayout: 'pattern',
+    writeSeriesMetadata: false,
     scanOnStartup: false,
     autoOrganize: true,
     folderNamingPattern: '{author}/{series}/{title} ({print_year})',
@@ -1032,6 +1059,7 @@ export function Settings() {
     // Lifecycle / retention
     purgeSoftDeletedAfterDays: 30,
     purgeSoftDeletedDeleteFiles: false,
+    trashRetentionDays: 7,
 
     // Logging
     logLevel: 'info',
@@ -1040,6 +1068,7 @@ export function Settings() {
 
     // Auto-update
     autoUpdateEnabled: false,
+    updateCheckEnabled: false,
     autoUpdateChannel: 'stable',
     autoUpdateCheckMinutes: 60,
     autoUpdateWindowStart: 1,
@@ -1063,6 +1092,10 @@ export function Settings() {
 
   const [settings, setSettings] = useState<SettingsState>(initialSettings);
   const [saved, setSaved] = useState(false);
+  // Set when another client changed the config while this form has edits.
+  const [remoteChangeKeys, setRemoteChangeKeys] = useState<string[] | null>(
+    null
+  );
   const [expandedSource, setExpandedSource] = useState<string | null>(null);
   const [sourceTestStatus, setSourceTestStatus] = useState<Record<string, { testing: boolean; result?: { success: boolean; message?: string; error?: string } }>>({});
   const [savedApiKeyMask, setSavedApiKeyMask] = useState<string>('');
@@ -1094,6 +1127,25 @@ export function Settings() {
   // eslint-disable-next-line react-hooks/exhaustive-deps
   }, []);
 
+  // Follow config changes made by other clients: reload when the form is
+  // clean, otherwise warn so the user can reload before saving.
+  const hasUnsavedChangesRef = useRef(hasUnsavedChanges);
+  hasUnsavedChangesRef.current = hasUnsavedChanges;
+  useEffect(() => {
+    const unsubscribe = eventSourceManager.subscribe((event) => {
+      if (event.type !== 'config.updated' || !event.data) return;
+      const update = event.data as unknown as api.ConfigUpdatedEvent;
+      if (update.version === api.getConfigVersion()) return;
+      if (hasUnsavedChangesRef.current) {
+        setRemoteChangeKeys(update.keys ?? []);
+      } else {
+        loadConfig();
+      }
+    });
+    return unsubscribe;
+  // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, []);
+
   useEffect(() => {
     if (!hasUnsavedChanges) return;
     const handleBeforeUnload = (event: BeforeUnloadEvent) => {
@@ -1132,6 +1184,8 @@ export function Settings() {
         // Library settings
         libraryPath: config.root_dir || '',
         organizationStrategy: config.organization_strategy || 'auto',
+        organizationLayout: config.organization_layout || 'pattern',
+        writeSeriesMetadata: config.write_series_metadata ?? false,
         scanOnStartup: config.scan_on_startup ?? false,
         autoOrganize: config.auto_organize ?? true,
         folderNamingPattern:
@@ -1233,6 +1287,7 @@ export function Settings() {
         purgeSoftDeletedAfterDays: config.purge_soft_deleted_after_days ?? 30,
         purgeSoftDeletedDeleteFiles:
           config.purge_soft_deleted_delete_files ?? false,
+        trashRetentionDays: config.trash_retention_days ?? 7,
 
         // Logging
         logLevel: config.log_level || 'info',
@@ -1241,6 +1296,7 @@ export function Settings() {
 
         // Auto-update
         autoUpdateEnabled: config.auto_update_enabled ?? false,
+        updateCheckEnabled: config.update_check_enabled ?? false,
         autoUpdateChannel: config.auto_update_channel || 'stable',
         autoUpdateCheckMinutes: config.auto_update_check_minutes || 60,
         autoUpdateWindowStart: config.auto_update_window_start ?? 1,
@@ -1707,6 +1763,8 @@ export function Settings() {
 
         // Library organization
         organization_strategy: settings.organizationStrategy,
+        organization_layout: settings.organizationLayout,
+        write_series_metadata: settings.writeSeriesMetadata,
         scan_on_startup: settings.scanOnStartup,
         auto_organize: settings.autoOrganize,
         folder_naming_pattern: settings.folderNamingPattern,
@@ -1753,6 +1811,7 @@ export function Settings() {
         // Lifecycle / retention
         purge_soft_deleted_after_days: settings.purgeSoftDeletedAfterDays,
         purge_soft_deleted_delete_files: settings.purgeSoftDeletedDeleteFiles,
+        trash_retention_days: settings.trashRetentionDays,
 
         // Logging
         log_level: settings.logLevel,
@@ -1761,6 +1820,7 @@ export function Settings() {
 
         // Auto-update
         auto_update_enabled: settings.autoUpdateEnabled,
+        update_check_enabled: settings.updateCheckEnabled,
         auto_update_channel: settings.autoUpdateChannel,
         auto_update_check_minutes: settings.autoUpdateCheckMinutes,
         auto_update_window_start: settings.autoUpdateWindowStart,
@@ -1783,7 +1843,10 @@ export function Settings() {
           .filter(Boolean),
       };
 
-      const response = await api.updateConfig(updates);
+      const response = await api.updateConfig(updates, {
+        ifMatch: api.getConfigVersion(),
+      });
+      setRemoteChangeKeys(null);
 
       let nextSettings = settings;
       if (settings.openaiApiKey && response.openai_api_key) {
@@ -1809,6 +1872,10 @@ export function Settings() {
         navigate('/login');
         return false;
       }
+      if (error instanceof api.ApiError && error.status === 412) {
+        setRemoteChangeKeys((keys) => keys ?? []);
+        return false;
+      }
       console.error('Failed to save settings:', error);
       alert('Failed to save settings. Please try again.');
       return false;
@@ -1930,15 +1997,15 @@ export function Settings() {
   ): Partial<api.Config> => {
     const allowed = new Set([
       'root_dir', 'playlist_dir', 'organization_strategy', 'scan_on_startup', 'auto_organize',
-      'folder_naming_pattern', 'file_naming_pattern', 'create_backups', 'supported_extensions',
+      'folder_naming_pattern', 'file_naming_pattern', 'organization_layout', 'write_series_metadata', 'create_backups', 'supported_extensions',
       'exclude_patterns', 'enable_disk_quota', 'disk_quota_percent', 'enable_user_quotas',
       'default_user_quota_gb', 'auto_fetch_metadata', 'enable_ai_parsing',
       'metadata_llm_scoring_enabled', 'openai_api_key', 'metadata_sources', 'language',
       'concurrent_scans','memory_limit_type','cache_size','cache_invalidate_on_book_update',
       'metadata_fetch_cache_ttl_days','memory_limit_percent','memory_limit_mb',
-      'purge_soft_deleted_after_days','purge_soft_deleted_delete_files','log_level','log_format',
+      'purge_soft_deleted_after_days','purge_soft_deleted_delete_files','trash_retention_days','log_level','log_format',
       'enable_json_logging','auto_update_enabled','auto_update_channel','auto_update_check_minutes',
-      'auto_update_window_start','auto_update_window_end','maintenance_window_enabled',
+      'auto_update_window_start','auto_update_window_end','update_check_enabled','maintenance_window_enabled',
       'maintenance_window_start','maintenance_window_end','path_format','segment_title_format',
       'auto_rename_on_apply','auto_write_tags_on_apply','verify_after_write','protected_paths'
     ]);
@@ -1956,6 +2023,7 @@ export function Settings() {
         case 'organization_strategy':
         case 'folder_naming_pattern':
         case 'file_naming_pattern':
+        case 'organization_layout':
         case 'language':
         case 'memory_limit_type':
         case 'log_level':
@@ -2003,6 +2071,7 @@ export function Settings() {
         // boolean flags
         case 'scan_on_startup':
         case 'auto_organize':
+        case 'write_series_metadata':
         case 'create_backups':
         case 'enable_disk_quota':
         case 'enable_user_quotas':
@@ -2013,6 +2082,7 @@ export function Settings() {
         case 'purge_soft_deleted_delete_files':
         case 'enable_json_logging':
         case 'auto_update_enabled':
+        case 'update_check_enabled':
         case 'maintenance_window_enabled':
         case 'auto_rename_on_apply':
         case 'auto_write_tags_on_apply':
@@ -2029,6 +2099,7 @@ export function Settings() {
         case 'memory_limit_percent':
         case 'memory_limit_mb':
         case 'purge_soft_deleted_after_days':
+        case 'trash_retention_days':
         case 'auto_update_check_minutes':
         case 'auto_update_window_start':
         case 'auto_update_window_end':
@@ -2153,6 +2224,29 @@ export function Settings() {
           Settings saved successfully!
         </Alert>
       )}
+      {remoteChangeKeys && (
+        <Alert
+          severity="warning"
+          sx={{ mb: 2, flexShrink: 0 }}
+          action={
+            <Button
+              color="inherit"
+              size="small"
+              onClick={() => {
+                setRemoteChangeKeys(null);
+                loadConfig();
+              }}
+            >
+              Reload
+            </Button>
+          }
+        >
+          Settings were changed elsewhere
+          {remoteChangeKeys.length > 0 &&
+            ` (${remoteChangeKeys.join(', ')})`}
+          . Reload to pick up the latest values before saving.
+        </Alert>
+      )}
       {importNotice && (
         <Alert severity="info" sx={{ mb: 2, flexShrink: 0 }}>
           {importNotice}
@@ -2467,6 +2561,19 @@ export function Settings() {
                 Disable to keep files on disk while clearing database records.
               </Typography>
             </Grid>
+            <Grid item xs={12} sm={6}>
+              <TextField
+                fullWidth
+                type="number"
+                label="Keep Deleted Files in Trash (days)"
+                value={settings.trashRetentionDays}
+                onChange={(e) =>
+                  handleChange('trashRetentionDays', parseInt(e.target.value) || 0)
+                }
+                inputProps={{ min: 0, max: 365 }}
+                helperText="Files deleted with a book wait in .trash under the library root and can be restored until then. Set to 0 to delete immediately"
+              />
+            </Grid>
 
             <Grid item xs={12}>
               <Divider sx={{ my: 2 }} />
@@ -1,7 +1,7 @@
 // file: web/src/services/api.ts
-// version: 2.39.0
+// version: 2.100.0
 // guid: a0b1c2d3-e4f5-6789-abcd-ef0123456789
-// last-edited: 2026-06-12
+// last-edited: 2026-10-17
 
 // API service layer for audiobook-organizer backend
 // Provides typed functions for all backend endpoints
@@ -41,7 +41,9 @@ export class ApiError extends Error {
 export interface DeleteBookResponse {
   message: string;
   blocked?: boolean;
+  signature_blocked?: boolean;
   soft_delete?: boolean;
+  moved_to_trash?: boolean;
 }
 
 const buildApiError = async (response: Response, fallbackMessage: string) => {
@@ -79,6 +81,7 @@ export interface Book {
   file_path: string;
   format?: string;
   duration?: number;
+  duration_estimated?: boolean;
   narrator?: string;
   authors?: BookAuthorEntry[];
   narrators?: BookNarratorEntry[];
@@ -97,6 +100,8 @@ export interface Book {
   work_id?: string;
   edition?: string;
   genre?: string;
+  explicit?: boolean;
+  imported_by_user_id?: string;
   print_year?: number;
   audiobook_release_year?: number;
   original_filename?: string;
@@ -111,6 +116,8 @@ export interface Book {
   version_notes?: string;
   file_hash?: string;
   file_size?: number;
+  // How file_hash was computed: sha256, xxhash64 or sha256-partial-<N>mb
+  file_hash_algorithm?: string;
   original_file_hash?: string;
   organized_file_hash?: string;
   itunes_persistent_id?: string;
@@ -136,6 +143,8 @@ export interface Book {
   metadata_review_status?: string;
   last_written_at?: string;
   file_exists?: boolean;
+  // Set on single-book responses (GET /audiobooks/:id)
+  chapter_count?: number;
   // User ratings (RATE-1/RATE-2)
   user_rating_overall?: number | null;
   user_rating_story?: number | null;
@@ -160,6 +169,9 @@ export interface Book {
 export interface Author {
   id: number;
   name: string;
+  openlibrary_id?: string;
+  goodreads_id?: string;
+  birth_year?: number;
   created_at: string;
 }
 
@@ -259,6 +271,7 @@ export interface BookFile {
   channels?: number;
   bit_depth?: number;
   file_hash?: string;
+  file_hash_algorithm?: string;
   missing: boolean;
   file_exists?: boolean;
   // Deluge import fields (DELUGE-1, PR #540)
@@ -361,6 +374,78 @@ export interface ImportPath {
   created_at: string;
   last_scan?: string;
   book_count: number;
+  scan_profile?: ScanProfile;
+  require_mount?: boolean;
+  // Overrides auto_scan_enabled for this path; omitted follows it.
+  watch?: boolean;
+  health?: 'healthy' | 'unhealthy';
+  health_reason?: string;
+  health_checked_at?: string;
+  scan_backend?: StorageBackend;
+  last_scan_files?: number;
+  last_scan_seconds?: number;
+  last_scan_profile?: ScanProfile;
+  scan_files_per_second?: number;
+  // 'initial' until the first deep scan completes; omitted counts as
+  // 'incremental'.
+  scan_mode?: ImportPathScanMode;
+  // Adopt takes the path's books in place, reading author, series, title
+  // and sequence from their folders (adopt_pattern, else the folder
+  // naming pattern) instead of moving them.
+  adopt?: boolean;
+  adopt_pattern?: string;
+}
+
+export type ImportPathScanMode = 'initial' | 'incremental';
+
+export type StorageBackend = 'local' | 'nfs' | 'smb' | 'fuse';
+
+export type ScanProfile = 'quick' | 'standard' | 'deep';
+
+export interface ScanEstimate {
+  profile: ScanProfile;
+  files: number;
+  bytes: number;
+  estimated_seconds: number;
+}
+
+export interface ScanPlan {
+  folders: Array<{
+    path: string;
+    profile: ScanProfile;
+    files: number;
+    bytes: number;
+    estimated_seconds: number;
+  }>;
+  estimated_seconds: number;
+  estimates: ScanEstimate[];
+  predicted?: ScanPrediction;
+}
+
+export type PredictionBasis = 'folder' | 'backend' | 'default' | 'mixed';
+
+export interface ScanPrediction {
+  estimated_seconds: number;
+  files: number;
+  basis: PredictionBasis;
+  folders: Array<{
+    path: string;
+    profile: ScanProfile;
+    backend?: StorageBackend;
+    files: number;
+    files_per_second?: number;
+    estimated_seconds: number;
+    basis: Exclude<PredictionBasis, 'mixed'>;
+  }>;
+}
+
+export interface BookChapter {
+  index: number;
+  title: string;
+  start_sec: number;
+  end_sec: number;
+  /** Set while the marker is stored but not yet written into the file. */
+  source?: 'silence' | 'manual';
 }
 
 export interface Operation {
@@ -421,6 +506,14 @@ export interface OperationLog {
   created_at: string;
 }
 
+// ArchivedOperation is a finished operation moved to archive storage;
+// logs are only included when fetching a single one.
+export interface ArchivedOperation extends Operation {
+  archived_at: string;
+  log_count: number;
+  logs?: OperationLog[];
+}
+
 // Operations V2 (UOS-05: new timeline endpoint)
 export interface OperationV2 {
   id: string;
@@ -469,6 +562,55 @@ export async function getOperationTimeline(sinceMinutes = 15): Promise<Operation
   }
 }
 
+// OperationQueue is GET /operations/queue: the running caps, each worker
+// pool's load, and the queued operations in the order they will start.
+export interface OperationQueue {
+  paused: boolean;
+  workers: number;
+  max_concurrent: number;
+  running: number;
+  pools: { name: string; max_concurrent: number; running: number; queued: number }[];
+  queued: OperationV2[];
+}
+
+export async function getOperationQueue(): Promise<OperationQueue> {
+  const response = await fetch(`${API_BASE}/operations/queue`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch operation queue');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function setOperationQueuePaused(paused: boolean): Promise<OperationQueue> {
+  const response = await fetch(`${API_BASE}/operations/queue/${paused ? 'pause' : 'resume'}`, {
+    method: 'POST',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, paused ? 'Failed to pause queue' : 'Failed to resume queue');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+// reorderOperationQueue moves a queued operation before or after another,
+// or to a priority (0 low, 1 normal, 2 high). Give exactly one.
+export async function reorderOperationQueue(
+  opId: string,
+  position: { before: string } | { after: string } | { priority: 0 | 1 | 2 }
+): Promise<OperationQueue> {
+  const response = await fetch(`${API_BASE}/operations/queue/reorder`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ op_id: opId, ...position }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to reorder queue');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 // SSE event types emitted by the operations EventHub (UOS-06).
 export type OperationSSEEventName =
   | 'op.created'
@@ -519,6 +661,70 @@ export function openOperationsSSE(handler: OperationSSEHandler): EventSource {
   return es;
 }
 
+export interface OperationLogLine {
+  operation_id: string;
+  level: string;
+  message: string;
+  attrs: Record<string, unknown>;
+  created_at: string;
+}
+
+/**
+ * openOperationLogStream tails one operation's log over SSE: stored lines
+ * first, then live lines. onEnd fires with the final status once the
+ * operation finishes, and the stream is closed so the browser does not
+ * reconnect and replay the history.
+ */
+export function openOperationLogStream(
+  opId: string,
+  handler: {
+    onLine: (line: OperationLogLine) => void;
+    onEnd?: (status: string) => void;
+    onError?: (err: Event) => void;
+  },
+  tail?: number
+): EventSource {
+  const query = tail ? `?tail=${tail}` : '';
+  const es = new EventSource(`${API_BASE}/operations/${encodeURIComponent(opId)}/logs/stream${query}`);
+  es.addEventListener('log', (e: MessageEvent) => {
+    handler.onLine(JSON.parse(e.data) as OperationLogLine);
+  });
+  es.addEventListener('end', (e: MessageEvent) => {
+    es.close();
+    handler.onEnd?.(JSON.parse(e.data).status);
+  });
+  if (handler.onError) {
+    es.onerror = handler.onError;
+  }
+  return es;
+}
+
+/**
+ * openSystemLogStream tails the application log over SSE: the newest
+ * `tail` stored lines first, then live lines matching the filters.
+ */
+export function openSystemLogStream(
+  handler: {
+    onLine: (line: AppLogEntry) => void;
+    onError?: (err: Event) => void;
+  },
+  params?: { level?: string; module?: string; search?: string; tail?: number }
+): EventSource {
+  const query = new URLSearchParams();
+  if (params?.level) query.append('level', params.level);
+  if (params?.module) query.append('module', params.module);
+  if (params?.search) query.append('search', params.search);
+  if (params?.tail !== undefined) query.append('tail', params.tail.toString());
+  const es = new EventSource(`${API_BASE}/system/logs/stream?${query}`);
+  es.addEventListener('log', (e: MessageEvent) => {
+    handler.onLine(JSON.parse(e.data) as AppLogEntry);
+  });
+  if (handler.onError) {
+    es.onerror = handler.onError;
+  }
+  return es;
+}
+
 export interface SystemStatus {
   status: string;
   version?: string;
@@ -566,6 +772,23 @@ export interface SystemStatus {
   };
   app_uptime_seconds?: number;
   system_uptime_seconds?: number;
+  library_lock?: LibraryLockStatus;
+}
+
+// LibraryLockStatus is the multi-instance library lock as this instance
+// sees it; holder names the other live instance when held is false.
+export interface LibraryLockStatus {
+  instance_id: string;
+  held: boolean;
+  holder?: {
+    instance_id: string;
+    hostname: string;
+    pid: number;
+    started_at: string;
+    heartbeat_at: string;
+  };
+  lock_file?: string;
+  checked_at: string;
 }
 
 export interface SystemStorage {
@@ -579,19 +802,41 @@ export interface SystemStorage {
   user_quotas_enabled: boolean;
 }
 
-export interface SystemLogs {
-  logs: Array<{
-    operation_id: string;
-    timestamp: string;
-    level: string;
-    message: string;
-    details?: string;
-  }>;
-  total: number;
-  limit: number;
-  offset: number;
+export interface AppLogEntry {
+  id: string;
+  time: string;
+  level: 'debug' | 'info' | 'warn' | 'error';
+  module?: string;
+  message: string;
+  attrs?: Record<string, unknown>;
 }
 
+/**
+ * SystemLogs is a page of the application log (source "application"),
+ * paged with next_before, or on servers that keep none the logs of recent
+ * operations (source "operations"), paged by offset.
+ */
+export type SystemLogs =
+  | {
+      source: 'application';
+      logs: AppLogEntry[];
+      limit: number;
+      next_before?: string;
+    }
+  | {
+      source: 'operations';
+      logs: Array<{
+        operation_id: string;
+        timestamp: string;
+        level: string;
+        message: string;
+        details?: string;
+      }>;
+      total: number;
+      limit: number;
+      offset: number;
+    };
+
 export interface MetadataSource {
   id: string;
   name: string;
@@ -603,6 +848,26 @@ export interface MetadataSource {
   };
 }
 
+export interface MediaServerConfig {
+  enabled: boolean;
+  url: string;
+  library_id?: string;
+  path_mappings?: { local: string; remote: string }[];
+}
+
+export interface EmailConfig {
+  enabled: boolean;
+  smtp_host: string;
+  smtp_port: number;
+  smtp_username?: string;
+  smtp_security?: 'starttls' | 'tls' | 'none';
+  from: string;
+  recipients: string[];
+  notify_on?: ('completed' | 'failed')[];
+  min_duration_minutes: number;
+  operations?: string[];
+}
+
 export interface Config {
   // Core paths
   root_dir: string;
@@ -618,6 +883,11 @@ export interface Config {
   auto_organize: boolean;
   folder_naming_pattern: string;
   file_naming_pattern: string;
+  organization_layout?: string;
+  write_series_metadata?: boolean;
+  disambiguation_rules?: string[];
+  naming_replace_char?: string;
+  naming_windows_safe?: boolean;
   create_backups: boolean;
   supported_extensions: string[];
   exclude_patterns?: string[];
@@ -631,6 +901,10 @@ export interface Config {
   // Metadata
   auto_fetch_metadata: boolean;
   metadata_sources: MetadataSource[];
+  // 'first' applies the first source's match; 'merge' combines every
+  // source field by field, ordered per field by metadata_field_priority.
+  metadata_merge_strategy?: 'first' | 'merge';
+  metadata_field_priority?: Record<string, string[]>;
   language: string;
 
   // AI parsing
@@ -642,8 +916,56 @@ export interface Config {
   // to change it. Empty value falls through to the ACOUSTID_API_KEY env.
   acoustid_api_key?: string;
 
+  // Listener (overrides --host / --port; changes rebind immediately)
+  listen_host?: string;
+  listen_port?: number;
+
+  // Blob storage for covers (local disk or S3-compatible object storage).
+  // The secret access key is masked when read back.
+  blob_storage_backend?: 'local' | 's3';
+  blob_storage_local_dir?: string;
+  blob_storage_s3_endpoint?: string;
+  blob_storage_s3_region?: string;
+  blob_storage_s3_bucket?: string;
+  blob_storage_s3_prefix?: string;
+  blob_storage_s3_access_key_id?: string;
+  blob_storage_s3_secret_access_key?: string;
+  blob_storage_s3_path_style?: boolean;
+  audible_activation_bytes?: string;
+  audiobookshelf_url?: string;
+  audiobookshelf_api_token?: string;
+
+  // Media servers rescanned after organizing, and the Plex-named export
+  integrations?: {
+    plex?: MediaServerConfig;
+    jellyfin?: MediaServerConfig;
+    plex_export_dir?: string;
+  };
+  plex_token?: string;
+  jellyfin_api_key?: string;
+
+  // Email notifications for finished operations
+  email?: EmailConfig;
+  smtp_password?: string;
+
+  // Freeze snapshots taken before destructive operations
+  freeze_snapshots_enabled?: boolean;
+  freeze_snapshot_retention?: number;
+
+  // Slow-query log of database calls
+  slow_query_log_enabled?: boolean;
+  slow_query_threshold_ms?: number;
+
   // Performance
   concurrent_scans: number;
+  // File hashing: partial, sha256 or xxhash64; partial chunk size; parallel files per book
+  file_hash_algorithm?: string;
+  file_hash_partial_mb?: number;
+  file_hash_workers?: number;
+  // Operation queue caps, overall and per worker pool (scan, organize, convert)
+  operation_queue?: { max_concurrent: number; pools?: Record<string, number> };
+  metadata_fetch_concurrency?: number;
+  metadata_fetch_rate_per_second?: number;
 
   // Memory management
   memory_limit_type: string;
@@ -656,8 +978,15 @@ export interface Config {
   // Lifecycle / retention
   purge_soft_deleted_after_days?: number;
   purge_soft_deleted_delete_files?: boolean;
+  trash_retention_days?: number;
+  soft_delete_to_trash?: boolean;
 
   // Logging
+  app_log_level?: 'debug' | 'info' | 'warn' | 'error' | 'off';
+  app_log_retention_days?: number;
+  app_log_debug_retention_days?: number;
+  log_retention_days?: number;
+  operation_log_retention_days?: number;
   log_level: string;
   log_format: string;
   enable_json_logging: boolean;
@@ -668,6 +997,7 @@ export interface Config {
   auto_update_check_minutes?: number;
   auto_update_window_start?: number;
   auto_update_window_end?: number;
+  update_check_enabled?: boolean;
 
   // Maintenance window
   maintenance_window_enabled?: boolean;
@@ -728,6 +1058,8 @@ export interface AuthSession {
 export interface BooksPage {
   items: Book[];
   count: number;
+  /** Every book the query matches, across all pages. */
+  total: number;
 }
 
 export async function getBooks(
@@ -745,6 +1077,18 @@ export async function getBooks(
     fingerprintStatus?: 'complete' | 'partial' | 'none';
     coveragePercentMin?: number;
     coveragePercentMax?: number;
+    formats?: string[];
+    codecs?: string[];
+    qualities?: string[];
+    languages?: string[];
+    narrator?: string;
+    yearMin?: number;
+    yearMax?: number;
+    hasSeries?: boolean;
+    /** Field names, or ['any'] for books missing any tracked field. */
+    missingMetadata?: string[];
+    /** Sparse fieldset; items then carry only these fields (plus id). */
+    fields?: string[];
   }
 ): Promise<BooksPage> {
   const params = new URLSearchParams();
@@ -768,6 +1112,18 @@ export async function getBooks(
     params.set('coverage_percent_min', String(options.coveragePercentMin));
   if (options?.coveragePercentMax !== undefined)
     params.set('coverage_percent_max', String(options.coveragePercentMax));
+  if (options?.formats?.length) params.set('format', options.formats.join(','));
+  if (options?.codecs?.length) params.set('codec', options.codecs.join(','));
+  if (options?.qualities?.length) params.set('quality', options.qualities.join(','));
+  if (options?.languages?.length) params.set('language', options.languages.join(','));
+  if (options?.narrator) params.set('narrator', options.narrator);
+  if (options?.yearMin !== undefined) params.set('year_min', String(options.yearMin));
+  if (options?.yearMax !== undefined) params.set('year_max', String(options.yearMax));
+  if (options?.hasSeries !== undefined) params.set('has_series', String(options.hasSeries));
+  if (options?.missingMetadata?.length)
+    params.set('missing_metadata', options.missingMetadata.join(','));
+  if (options?.fields && options.fields.length > 0)
+    params.set('fields', options.fields.join(','));
   params.set('is_primary_version', 'true');
 
   const response = await fetch(`${API_BASE}/audiobooks?${params}`);
@@ -776,7 +1132,7 @@ export async function getBooks(
   }
   const body = await response.json();
   const data = body.data ?? body;
-  return { items: data.items ?? [], count: data.count ?? 0 };
+  return { items: data.items ?? [], count: data.count ?? 0, total: data.total ?? data.count ?? 0 };
 }
 
 export interface BookFacets {
@@ -838,7 +1194,7 @@ export async function searchBooksPage(
   }
   const body = await response.json();
   const data = body.data ?? body;
-  return { items: data.items ?? [], count: data.count ?? 0 };
+  return { items: data.items ?? [], count: data.count ?? 0, total: data.total ?? data.count ?? 0 };
 }
 
 export async function countBooks(): Promise<number> {
@@ -892,6 +1248,7 @@ export async function purgeSoftDeletedBooks(
   attempted: number;
   purged: number;
   files_deleted: number;
+  pending_purge: number;
   errors: string[];
 }> {
   const params = new URLSearchParams({
@@ -910,6 +1267,26 @@ export async function purgeSoftDeletedBooks(
   return body.data;
 }
 
+// A book whose files are in the trash, purged for good after purge_after
+// unless restored with restoreSoftDeletedBook.
+export interface PendingPurge {
+  book_id: string;
+  title: string;
+  trash_dir: string;
+  files: { from: string; to: string }[];
+  trashed_at: string;
+  purge_after: string;
+}
+
+export async function getPendingPurges(): Promise<{ items: PendingPurge[]; total: number }> {
+  const response = await fetch(`${API_BASE}/audiobooks/pending-purge`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch books pending purge');
+  }
+  const body = await response.json();
+  return { items: body.data?.items || [], total: body.data?.total ?? 0 };
+}
+
 export async function quarantineBook(bookId: string, reason?: string): Promise<void> {
   const response = await fetch(`${API_BASE}/audiobooks/${bookId}/quarantine`, {
     method: 'POST',
@@ -930,6 +1307,17 @@ export async function unquarantineBook(bookId: string): Promise<void> {
   }
 }
 
+// Accept a book flagged for a size/duration mismatch: restores it and
+// keeps later scans from quarantining it again.
+export async function overrideSizeAnomaly(bookId: string): Promise<void> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/quarantine/override`, {
+    method: 'POST',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to override size anomaly');
+  }
+}
+
 export async function restoreSoftDeletedBook(bookId: string): Promise<void> {
   const response = await fetch(`${API_BASE}/audiobooks/${bookId}/restore`, {
     method: 'POST',
@@ -939,6 +1327,28 @@ export async function restoreSoftDeletedBook(bookId: string): Promise<void> {
   }
 }
 
+// Purges one soft-deleted book now; files already in the trash go with it.
+export async function purgeDeletedBook(
+  bookId: string,
+  deleteFiles = false
+): Promise<{
+  attempted: number;
+  purged: number;
+  files_deleted: number;
+  pending_purge: number;
+  errors: string[];
+}> {
+  const params = new URLSearchParams({ delete_files: String(deleteFiles) });
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/purge?${params.toString()}`, {
+    method: 'DELETE',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to purge audiobook');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export interface RescanBookResult {
   book_id: string;
   old_total: number;
@@ -966,11 +1376,19 @@ export async function rescanBookFiles(bookId: string): Promise<RescanBookResult>
 
 export async function deleteBook(
   bookId: string,
-  options: { softDelete?: boolean; blockHash?: boolean } = {}
+  options: {
+    softDelete?: boolean;
+    moveToTrash?: boolean;
+    blockHash?: boolean;
+    blockSignature?: boolean;
+  } = {}
 ): Promise<DeleteBookResponse> {
   const params = new URLSearchParams();
   if (options.softDelete) params.set('soft_delete', 'true');
+  // Unset follows the soft_delete_to_trash setting.
+  if (options.moveToTrash !== undefined) params.set('move_to_trash', String(options.moveToTrash));
   if (options.blockHash) params.set('block_hash', 'true');
+  if (options.blockSignature) params.set('block_signature', 'true');
   const query = params.toString();
   const url =
     query.length > 0
@@ -985,6 +1403,42 @@ export async function deleteBook(
   return response.json();
 }
 
+export interface BatchDeleteResult {
+  results: { id: string; success?: boolean; error?: string }[];
+  success: number;
+  failed: number;
+  total: number;
+}
+
+// Deletes a multi-selection in one request; options mean what they do
+// for deleteBook.
+export async function batchDeleteBooks(
+  bookIds: string[],
+  options: {
+    softDelete?: boolean;
+    moveToTrash?: boolean;
+    blockHash?: boolean;
+    blockSignature?: boolean;
+  } = {}
+): Promise<BatchDeleteResult> {
+  const response = await fetch(`${API_BASE}/audiobooks/batch-delete`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({
+      ids: bookIds,
+      soft_delete: options.softDelete,
+      move_to_trash: options.moveToTrash,
+      block_hash: options.blockHash,
+      block_signature: options.blockSignature,
+    }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to delete audiobooks');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export type OverridePayload = {
   value?: unknown;
   locked?: boolean;
@@ -1012,6 +1466,30 @@ export async function updateBook(
   return body.data;
 }
 
+// JsonPatchOperation is one RFC 6902 operation for PATCH /api/v1/audiobooks/:id.
+export interface JsonPatchOperation {
+  op: 'add' | 'remove' | 'replace' | 'move' | 'copy' | 'test';
+  path: string;
+  from?: string;
+  value?: unknown;
+}
+
+export async function patchBook(
+  bookId: string,
+  patch: JsonPatchOperation[]
+): Promise<Book> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}`, {
+    method: 'PATCH',
+    headers: { 'Content-Type': 'application/json-patch+json' },
+    body: JSON.stringify(patch),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to patch audiobook');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 // RatingPatchBody is the partial-update payload for PATCH /api/v1/audiobooks/:id/rating.
 // Omit a field to leave it unchanged. Pass null to clear it.
 export interface RatingPatchBody {
@@ -1100,6 +1578,36 @@ export async function getBookSegments(bookId: string): Promise<BookSegment[]> {
   return body.data;
 }
 
+export async function getBookChapters(bookId: string): Promise<BookChapter[]> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/chapters`);
+  if (!response.ok) throw await buildApiError(response, 'Failed to fetch chapters');
+  const body = await response.json();
+  return body.data?.chapters || [];
+}
+
+export async function updateBookChapters(
+  bookId: string,
+  chapters: BookChapter[]
+): Promise<BookChapter[]> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/chapters`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ chapters }),
+  });
+  if (!response.ok) throw await buildApiError(response, 'Failed to update chapters');
+  const body = await response.json();
+  return body.data?.chapters || [];
+}
+
+export async function writeBookChapters(bookId: string): Promise<BookChapter[]> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/chapters/write`, {
+    method: 'POST',
+  });
+  if (!response.ok) throw await buildApiError(response, 'Failed to write chapters');
+  const body = await response.json();
+  return body.data?.chapters || [];
+}
+
 export async function getBookFiles(
   bookId: string,
   options?: { limit?: number; offset?: number; signal?: AbortSignal }
@@ -1273,6 +1781,85 @@ export async function deleteAuthorAlias(authorId: number, aliasId: number): Prom
   }
 }
 
+export interface AuthorAuthority {
+  openlibrary_id?: string;
+  goodreads_id?: string;
+  birth_year?: number;
+}
+
+export interface AuthorResolution {
+  id: string;
+  book_id: string;
+  book_title?: string;
+  name: string;
+  candidate_ids: number[];
+  candidates?: Author[];
+  assigned_id: number;
+  status: 'pending' | 'resolved';
+  resolved_id?: number;
+  created_at: string;
+  resolved_at?: string;
+}
+
+export async function setAuthorAuthority(
+  authorId: number,
+  authority: AuthorAuthority
+): Promise<Author> {
+  const response = await fetch(`${API_BASE}/authors/${authorId}/authority`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(authority),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to set author authority');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function createAuthorNamesake(
+  authorId: number,
+  authority: AuthorAuthority
+): Promise<Author> {
+  const response = await fetch(`${API_BASE}/authors/${authorId}/namesakes`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(authority),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to create namesake author');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function getAuthorResolutions(
+  status: 'pending' | 'resolved' | 'all' = 'pending'
+): Promise<AuthorResolution[]> {
+  const response = await fetch(`${API_BASE}/authors/resolutions?status=${status}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch author resolutions');
+  }
+  const body = await response.json();
+  return body.data?.items ?? [];
+}
+
+export async function resolveAuthorResolution(
+  resolutionId: string,
+  authorId: number
+): Promise<AuthorResolution> {
+  const response = await fetch(`${API_BASE}/authors/resolutions/${resolutionId}/resolve`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ author_id: authorId }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to resolve author');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export async function resolveProductionAuthor(authorId: number): Promise<Operation> {
   const response = await fetch(`${API_BASE}/authors/${authorId}/resolve-production`, {
     method: 'POST',
@@ -1380,6 +1967,28 @@ export async function dismissBookDuplicateGroup(groupKey: string): Promise<{ mes
   return body.data;
 }
 
+export interface BookDuplicateDecision {
+  book_ids: string[];
+  keep: 'best_quality' | 'primary' | 'book';
+  keep_id?: string;
+  delete_others?: boolean;
+  merge_metadata?: boolean;
+  block_hash?: boolean;
+}
+
+export async function resolveBookDuplicates(groups: BookDuplicateDecision[]): Promise<Operation> {
+  const response = await fetch(`${API_BASE}/audiobooks/duplicates/resolve`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ groups }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to resolve duplicates');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 // Series
 export async function getSeries(): Promise<SeriesWithCount[]> {
   const response = await fetch(`${API_BASE}/series`);
@@ -1575,6 +2184,31 @@ export async function addImportPathDetailed(
 
 // Operation status polling
 
+export async function updateImportPath(
+  id: number,
+  updates: {
+    name?: string;
+    enabled?: boolean;
+    scan_profile?: ScanProfile | '';
+    require_mount?: boolean;
+    watch?: boolean;
+    scan_mode?: ImportPathScanMode;
+    adopt?: boolean;
+    adopt_pattern?: string;
+  }
+): Promise<ImportPath> {
+  const response = await fetch(`${API_BASE}/import-paths/${id}`, {
+    method: 'PATCH',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(updates),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to update import path');
+  }
+  const body = await response.json();
+  return body.data.importPath as ImportPath;
+}
+
 export async function removeImportPath(id: number): Promise<void> {
   const response = await fetch(`${API_BASE}/import-paths/${id}`, {
     method: 'DELETE',
@@ -1626,8 +2260,9 @@ export async function startBulkMetadataFetch(
 export async function startScan(
   folderPath?: string,
   priority?: number,
-  forceUpdate?: boolean
-): Promise<Operation> {
+  forceUpdate?: boolean,
+  profile?: ScanProfile
+): Promise<Operation & { estimate?: ScanPrediction }> {
   return wrapTrigger('library.scan', async () => {
     const response = await fetch(`${API_BASE}/operations/scan`, {
       method: 'POST',
@@ -1636,6 +2271,7 @@ export async function startScan(
         folder_path: folderPath,
         priority,
         force_update: forceUpdate,
+        profile,
       }),
     });
     if (!response.ok) throw await buildApiError(response, 'Failed to start scan');
@@ -1643,6 +2279,21 @@ export async function startScan(
   });
 }
 
+export async function getScanEstimate(options?: {
+  folderPath?: string;
+  profile?: ScanProfile;
+  forceUpdate?: boolean;
+}): Promise<ScanPlan> {
+  const params = new URLSearchParams();
+  if (options?.folderPath) params.append('folder_path', options.folderPath);
+  if (options?.profile) params.append('profile', options.profile);
+  if (options?.forceUpdate) params.append('force_update', 'true');
+  const qs = params.toString();
+  const response = await fetch(`${API_BASE}/operations/scan/estimate${qs ? '?' + qs : ''}`);
+  if (!response.ok) throw await buildApiError(response, 'Failed to estimate scan');
+  return (await response.json()).data;
+}
+
 export async function startTranscode(
   bookId: string,
   opts?: { output_format?: string; bitrate?: number; keep_original?: boolean }
@@ -1663,6 +2314,22 @@ export async function startTranscode(
   });
 }
 
+// Queue a merge of the book's files into one chaptered m4b (or m4a).
+export async function convertAudiobook(
+  bookId: string,
+  opts?: { format?: 'm4b' | 'm4a'; bitrate?: number }
+): Promise<{ op_id: string; id: string; format: string }> {
+  return wrapTrigger('library.transcode', async () => {
+    const response = await fetch(`${API_BASE}/audiobooks/${bookId}/convert`, {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ format: opts?.format, bitrate: opts?.bitrate }),
+    });
+    if (!response.ok) throw await buildApiError(response, 'Failed to start conversion');
+    return (await response.json()).data;
+  });
+}
+
 export async function getOperationStatus(id: string): Promise<Operation> {
   const response = await fetch(`${API_BASE}/operations/${id}/status`);
   if (!response.ok) {
@@ -1715,6 +2382,12 @@ export async function getOperationLogs(id: string): Promise<OperationLog[]> {
   return data.items || data.logs || [];
 }
 
+// operationLogsExportUrl is the download link for an operation's logs,
+// with parameters, timing and an environment snapshot, for bug reports.
+export function operationLogsExportUrl(id: string, format: 'ndjson' | 'text' = 'ndjson'): string {
+  return `${API_BASE}/operations/${id}/logs/export?format=${format}`;
+}
+
 export async function getOperationLogsTail(id: string, tail: number): Promise<OperationLog[]> {
   const response = await fetch(`${API_BASE}/operations/${id}/logs?tail=${tail}`);
   if (!response.ok) {
@@ -1724,7 +2397,32 @@ export async function getOperationLogsTail(id: string, tail: number): Promise<Op
   return data.items || data.logs || [];
 }
 
-export async function cancelOperation(id: string): Promise<void> {
+export async function getArchivedOperations(
+  params: { type?: string; limit?: number; offset?: number } = {}
+): Promise<{ items: ArchivedOperation[]; total: number }> {
+  const query = new URLSearchParams();
+  if (params.type) query.set('type', params.type);
+  if (params.limit !== undefined) query.set('limit', String(params.limit));
+  if (params.offset !== undefined) query.set('offset', String(params.offset));
+  const response = await fetch(`${API_BASE}/operations/archive?${query}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch archived operations');
+  }
+  const body = await response.json();
+  const data = body.data ?? body;
+  return { items: data.items || [], total: data.total ?? 0 };
+}
+
+export async function getArchivedOperation(id: string): Promise<ArchivedOperation> {
+  const response = await fetch(`${API_BASE}/operations/archive/${encodeURIComponent(id)}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch archived operation');
+  }
+  const body = await response.json();
+  return body.data ?? body;
+}
+
+export async function cancelOperation(id: string): Promise<void> {
   const response = await fetch(`${API_BASE}/operations/${id}`, {
     method: 'DELETE',
   });
@@ -1746,9 +2444,12 @@ export async function clearStaleOperations(): Promise<{ cleared: number }> {
 
 export async function listOperations(
   limit = 50,
-  offset = 0
+  offset = 0,
+  status?: string
 ): Promise<{ items: Operation[]; total: number; limit: number; offset: number }> {
-  const response = await fetch(`${API_BASE}/operations?limit=${limit}&offset=${offset}`);
+  const params = new URLSearchParams({ limit: String(limit), offset: String(offset) });
+  if (status) params.set('status', status);
+  const response = await fetch(`${API_BASE}/operations?${params.toString()}`);
   if (!response.ok) {
     throw await buildApiError(response, 'Failed to fetch operations');
   }
@@ -1756,11 +2457,19 @@ export async function listOperations(
   return body.data;
 }
 
-export async function deleteOperationHistory(status: string): Promise<{ deleted: number }> {
-  const response = await fetch(
-    `${API_BASE}/operations/history?status=${encodeURIComponent(status)}`,
-    { method: 'DELETE' }
-  );
+// deleteOperationHistory deletes finished operations. With olderThan (e.g.
+// "30d") only those older than that go, registry operations included, and
+// status may be left empty to mean completed, failed and canceled.
+export async function deleteOperationHistory(
+  status: string,
+  olderThan?: string
+): Promise<{ deleted: number; operations?: number; registry_deleted?: number; cutoff?: string }> {
+  const params = new URLSearchParams();
+  if (status) params.set('status', status);
+  if (olderThan) params.set('older_than', olderThan);
+  const response = await fetch(`${API_BASE}/operations/history?${params.toString()}`, {
+    method: 'DELETE',
+  });
   if (!response.ok) {
     throw await buildApiError(response, 'Failed to delete operation history');
   }
@@ -1817,6 +2526,19 @@ export async function getSystemStatus(): Promise<SystemStatus> {
   return body.data;
 }
 
+// takeoverLibraryLock claims the library lock from another instance
+// (admin only).
+export async function takeoverLibraryLock(): Promise<LibraryLockStatus> {
+  const response = await fetch(`${API_BASE}/system/library-lock/takeover`, {
+    method: 'POST',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to take over library lock');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export async function getSystemStorage(): Promise<SystemStorage> {
   const response = await fetch(`${API_BASE}/system/storage`);
   if (!response.ok) {
@@ -1826,6 +2548,272 @@ export async function getSystemStorage(): Promise<SystemStorage> {
   return body.data;
 }
 
+export interface ImportQuotaStatus {
+  path?: string;
+  user_id?: string;
+  max_books: number;
+  max_bytes: number;
+  default?: boolean;
+  usage: { books: number; bytes: number };
+  exceeded: boolean;
+}
+
+export async function getImportQuotaUsage(): Promise<ImportQuotaStatus[]> {
+  const response = await fetch(`${API_BASE}/system/import-quotas`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch import quota usage');
+  }
+  const body = await response.json();
+  return body.data?.quotas || [];
+}
+
+export type ImportDecisionKind =
+  | 'imported'
+  | 'unchanged'
+  | 'unsupported_extension'
+  | 'excluded'
+  | 'blocked_hash'
+  | 'blocked_signature'
+  | 'duplicate'
+  | 'suspicious'
+  | 'quota_exceeded'
+  | 'error'
+  | 'quarantined';
+
+export interface ImportDecision {
+  path: string;
+  decision: ImportDecisionKind;
+  detail?: string;
+  book_id?: string;
+  decided_at: string;
+}
+
+export async function getImportDecisions(path: string, limit?: number): Promise<ImportDecision[]> {
+  const params = new URLSearchParams({ path });
+  if (limit) params.set('limit', String(limit));
+  const response = await fetch(`${API_BASE}/import-decisions?${params}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch import decisions');
+  }
+  const body = await response.json();
+  return body.data?.decisions || [];
+}
+
+export interface RetryEntry {
+  id: string;
+  kind: 'scan' | 'organize';
+  path: string;
+  book_id?: string;
+  import_path?: string;
+  status: 'pending' | 'exhausted';
+  attempts: number;
+  max_attempts: number;
+  last_error: string;
+  first_failed_at: string;
+  last_failed_at: string;
+  next_attempt_at?: string;
+}
+
+export async function getRetries(filter?: {
+  status?: RetryEntry['status'];
+  kind?: RetryEntry['kind'];
+}): Promise<RetryEntry[]> {
+  const params = new URLSearchParams();
+  if (filter?.status) params.set('status', filter.status);
+  if (filter?.kind) params.set('kind', filter.kind);
+  const response = await fetch(`${API_BASE}/retries?${params}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch retry queue');
+  }
+  const body = await response.json();
+  return body.data?.retries || [];
+}
+
+export async function retryNow(id: string): Promise<RetryEntry> {
+  const response = await fetch(`${API_BASE}/retries/${encodeURIComponent(id)}/retry`, {
+    method: 'POST',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to retry file');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function dismissRetry(id: string): Promise<void> {
+  const response = await fetch(`${API_BASE}/retries/${encodeURIComponent(id)}`, {
+    method: 'DELETE',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to dismiss retry');
+  }
+}
+
+export type CustomFieldType = 'text' | 'number' | 'boolean' | 'date' | 'select';
+
+export interface CustomFieldDefinition {
+  key: string;
+  label: string;
+  type: CustomFieldType;
+  description?: string;
+  options?: string[];
+  pattern?: string;
+  min?: number;
+  max?: number;
+  created_at?: string;
+  updated_at?: string;
+}
+
+export async function getCustomFields(): Promise<CustomFieldDefinition[]> {
+  const response = await fetch(`${API_BASE}/metadata/custom-fields`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch custom fields');
+  }
+  const body = await response.json();
+  return body.data?.fields || [];
+}
+
+export async function saveCustomField(
+  field: Partial<CustomFieldDefinition> & { key: string },
+  create = false
+): Promise<CustomFieldDefinition> {
+  const url = create
+    ? `${API_BASE}/metadata/custom-fields`
+    : `${API_BASE}/metadata/custom-fields/${encodeURIComponent(field.key)}`;
+  const response = await fetch(url, {
+    method: create ? 'POST' : 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(field),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to save custom field');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function deleteCustomField(key: string): Promise<{ key: string; values_removed: number }> {
+  const response = await fetch(`${API_BASE}/metadata/custom-fields/${encodeURIComponent(key)}`, {
+    method: 'DELETE',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to delete custom field');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function getBookCustomFields(
+  bookId: string
+): Promise<{ values: Record<string, string>; fields: CustomFieldDefinition[] }> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/custom-fields`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch custom field values');
+  }
+  const body = await response.json();
+  return { values: body.data?.values || {}, fields: body.data?.fields || [] };
+}
+
+export async function updateBookCustomFields(
+  bookId: string,
+  values: Record<string, string | number | boolean | null>
+): Promise<Record<string, string>> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/custom-fields`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ values }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to save custom field values');
+  }
+  const body = await response.json();
+  return body.data?.values || {};
+}
+
+export interface EntityAutoMerge {
+  kind: 'author' | 'series';
+  input: string;
+  matched_id: number;
+  matched_name: string;
+  strategy: 'normalized' | 'fuzzy';
+  score: number;
+  count: number;
+  first_seen: string;
+  last_seen: string;
+}
+
+export async function getEntityAutoMerges(kind?: 'author' | 'series'): Promise<EntityAutoMerge[]> {
+  const query = kind ? `?kind=${kind}` : '';
+  const response = await fetch(`${API_BASE}/entities/auto-merges${query}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch auto-merged names');
+  }
+  const body = await response.json();
+  return body.data?.merges || [];
+}
+
+export interface OrphanFile {
+  path: string;
+  size: number;
+  mod_time: string;
+  ignored: boolean;
+}
+
+export interface OrphanReport {
+  root_dir: string;
+  files: OrphanFile[];
+  total_bytes: number;
+  ignored_count: number;
+}
+
+export interface OrphanActionResult {
+  path: string;
+  ok: boolean;
+  book_id?: string;
+  error?: string;
+}
+
+export async function getOrphanFiles(includeIgnored = false): Promise<OrphanReport> {
+  const response = await fetch(`${API_BASE}/library/orphans?include_ignored=${includeIgnored}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch orphan files');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function orphanFileAction(
+  action: 'import' | 'ignore' | 'delete',
+  paths: string[],
+  unignore = false
+): Promise<{ results: OrphanActionResult[]; succeeded: number; failed: number }> {
+  const response = await fetch(`${API_BASE}/library/orphans/${action}`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ paths, unignore }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, `Failed to ${action} orphan files`);
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export interface RestartServerResult {
+  address: string;
+  previous_address: string;
+  moved: boolean;
+}
+
+export async function restartServer(): Promise<RestartServerResult> {
+  const response = await fetch(`${API_BASE}/system/restart`, { method: 'POST' });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to restart server');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export async function factoryReset(confirm: string): Promise<{ message: string }> {
   const response = await fetch(`${API_BASE}/system/factory-reset`, {
     method: 'POST',
@@ -1844,7 +2832,15 @@ export async function startOrganize(
   folderPath?: string,
   priority?: number,
   bookIds?: string[],
-  options?: { fetchMetadataFirst?: boolean; syncITunesFirst?: boolean }
+  options?: {
+    fetchMetadataFirst?: boolean;
+    syncITunesFirst?: boolean;
+    authorId?: number;
+    seriesId?: number;
+    workId?: string;
+    libraryState?: string;
+    playlistId?: string;
+  }
 ): Promise<Operation> {
   return wrapTrigger('library.organize', async () => {
     const response = await fetch(`${API_BASE}/operations/organize`, {
@@ -1856,6 +2852,11 @@ export async function startOrganize(
         book_ids: bookIds,
         fetch_metadata_first: options?.fetchMetadataFirst,
         sync_itunes_first: options?.syncITunesFirst,
+        author_id: options?.authorId,
+        series_id: options?.seriesId,
+        work_id: options?.workId,
+        library_state: options?.libraryState,
+        playlist_id: options?.playlistId,
       }),
     });
     if (!response.ok) throw await buildApiError(response, 'Failed to start organize');
@@ -1865,13 +2866,21 @@ export async function startOrganize(
 
 export async function getSystemLogs(params?: {
   level?: string;
+  module?: string;
   search?: string;
+  since?: string;
+  until?: string;
+  before?: string;
   limit?: number;
   offset?: number;
 }): Promise<SystemLogs> {
   const query = new URLSearchParams();
   if (params?.level) query.append('level', params.level);
+  if (params?.module) query.append('module', params.module);
   if (params?.search) query.append('search', params.search);
+  if (params?.since) query.append('since', params.since);
+  if (params?.until) query.append('until', params.until);
+  if (params?.before) query.append('before', params.before);
   if (params?.limit) query.append('limit', params.limit.toString());
   if (params?.offset) query.append('offset', params.offset.toString());
 
@@ -1884,25 +2893,49 @@ export async function getSystemLogs(params?: {
 }
 
 // Config
+// Version of the config last read or written by this client. Sent as
+// If-Match by callers that opt in, so a save from a stale form is
+// rejected (412) instead of overwriting another client's changes.
+let configVersion: string | undefined;
+
+export function getConfigVersion(): string | undefined {
+  return configVersion;
+}
+
+/** Payload of the `config.updated` realtime event. */
+export interface ConfigUpdatedEvent {
+  version: string;
+  keys: string[];
+}
+
 export async function getConfig(): Promise<Config> {
   const response = await fetch(`${API_BASE}/config`);
   if (!response.ok) {
     throw await buildApiError(response, 'Failed to fetch config');
   }
   const data = await response.json();
+  configVersion = data.data?.version ?? configVersion;
   return data.data?.config ?? data.config;
 }
 
-export async function updateConfig(updates: Partial<Config>): Promise<Config> {
+export async function updateConfig(
+  updates: Partial<Config>,
+  options: { ifMatch?: string } = {}
+): Promise<Config> {
+  const headers: Record<string, string> = { 'Content-Type': 'application/json' };
+  if (options.ifMatch) {
+    headers['If-Match'] = `"${options.ifMatch}"`;
+  }
   const response = await fetch(`${API_BASE}/config`, {
     method: 'PUT',
-    headers: { 'Content-Type': 'application/json' },
+    headers,
     body: JSON.stringify(updates),
   });
   if (!response.ok) {
     throw await buildApiError(response, 'Failed to update config');
   }
   const data = await response.json();
+  configVersion = data.data?.version ?? configVersion;
   return data.data?.config ?? data.config;
 }
 
@@ -1994,6 +3027,40 @@ export async function listAdminUsers(): Promise<AdminUserSummary[]> {
   return data.users ?? data.items ?? data ?? [];
 }
 
+export interface ContentFilterProfile {
+  allowed_genres: string[];
+  allowed_tags: string[];
+  block_explicit: boolean;
+}
+
+export async function getUserContentFilter(userId: string): Promise<ContentFilterProfile> {
+  const response = await fetch(`${API_BASE}/users/${encodeURIComponent(userId)}/content-filter`, {
+    credentials: 'include',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to load content filter');
+  }
+  const body = await response.json();
+  return (body.data ?? body).content_filter;
+}
+
+export async function updateUserContentFilter(
+  userId: string,
+  profile: ContentFilterProfile
+): Promise<ContentFilterProfile> {
+  const response = await fetch(`${API_BASE}/users/${encodeURIComponent(userId)}/content-filter`, {
+    method: 'PUT',
+    credentials: 'include',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(profile),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to save content filter');
+  }
+  const body = await response.json();
+  return (body.data ?? body).content_filter;
+}
+
 export async function getMe(): Promise<AuthUser> {
   const response = await fetch(`${API_BASE}/auth/me`, {
     credentials: 'include',
@@ -2578,9 +3645,19 @@ export async function searchMetadata(
   return body.data;
 }
 
+export interface MetadataFieldSource {
+  provider: string;
+  confidence: number;
+}
+
 export async function fetchBookMetadata(
   bookId: string
-): Promise<{ message: string; book: Book; source: string }> {
+): Promise<{
+  message: string;
+  book: Book;
+  source: string;
+  field_sources?: Record<string, MetadataFieldSource>;
+}> {
   const response = await fetch(`${API_BASE}/audiobooks/${bookId}/fetch-metadata`, {
     method: 'POST',
   });
@@ -2719,6 +3796,106 @@ export async function batchWriteBackMetadata(
   return { ...body.data, operation_id: body.data?.operation_id ?? body.operation_id };
 }
 
+export interface TagChange {
+  field: string;
+  old: string;
+  new: string;
+}
+
+export interface FileTagWrite {
+  path: string;
+  status: 'unchanged' | 'written' | 'would_write' | 'skipped' | 'error';
+  changes?: TagChange[];
+  error?: string;
+}
+
+export interface TagWriteResult {
+  book_id: string;
+  dry_run: boolean;
+  written: number;
+  tags?: {
+    album: string;
+    title: string;
+    author: string;
+    narrator: string;
+    series: string;
+    series_index: string;
+  };
+  files?: FileTagWrite[];
+  error?: string;
+}
+
+export async function writeTags(bookId: string, dryRun = false): Promise<TagWriteResult> {
+  const response = await fetch(`${API_BASE}/audiobooks/${bookId}/write-tags`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ dry_run: dryRun }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to write tags');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function batchWriteTags(
+  bookIds: string[],
+  dryRun = false
+): Promise<{ dry_run: boolean; written: number; failed: number; results: TagWriteResult[] }> {
+  const response = await fetch(`${API_BASE}/audiobooks/batch-write-tags`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ book_ids: bookIds, dry_run: dryRun }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to write tags');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+// Library layout export/apply
+export interface LayoutApplyFile {
+  path: string;
+  source?: string;
+  status: 'present' | 'placed' | 'would_place' | 'missing' | 'conflict' | 'error';
+  error?: string;
+}
+
+export interface LayoutApplyResult {
+  mode: 'move' | 'symlink';
+  dry_run: boolean;
+  counts: Record<string, number>;
+  files: LayoutApplyFile[];
+}
+
+export async function exportLibraryLayout(format: 'json' | 'yaml' = 'json'): Promise<Blob> {
+  const response = await fetch(`${API_BASE}/library/layout?format=${format}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to export library layout');
+  }
+  return response.blob();
+}
+
+export async function applyLibraryLayout(req: {
+  layout: string;
+  source_dir: string;
+  target_root?: string;
+  mode?: 'move' | 'symlink';
+  dry_run?: boolean;
+}): Promise<LayoutApplyResult> {
+  const response = await fetch(`${API_BASE}/library/layout/apply`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(req),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to apply library layout');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 // Bulk write-back (async operation for all/filtered books)
 export interface BulkWriteBackFilter {
   library_state?: string;
@@ -2890,6 +4067,23 @@ export async function testAIConnection(
   return body.data;
 }
 
+export async function sendTestEmail(
+  to?: string[]
+): Promise<{ sent_to: string[] }> {
+  const response = await fetch(`${API_BASE}/notifications/email/test`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: to && to.length > 0 ? JSON.stringify({ to }) : undefined,
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to send test email');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export async function parseAudiobookWithAI(
   bookId: string
 ): Promise<{ message: string; book: Book; confidence: string }> {
@@ -2990,53 +4184,186 @@ export interface BackupListResponse {
   count: number;
 }
 
-export async function createBackup(maxBackups?: number): Promise<BackupInfo> {
-  const response = await fetch(`${API_BASE}/backup/create`, {
-    method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
-    body: JSON.stringify(typeof maxBackups === 'number' ? { max_backups: maxBackups } : {}),
-  });
+export async function createBackup(maxBackups?: number): Promise<BackupInfo> {
+  const response = await fetch(`${API_BASE}/backup/create`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(typeof maxBackups === 'number' ? { max_backups: maxBackups } : {}),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to create backup');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function listBackups(): Promise<BackupListResponse> {
+  const response = await fetch(`${API_BASE}/backup/list`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to list backups');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export type BackupVerifyMode = 'none' | 'checksum' | 'full';
+
+export interface BackupVerifyReport {
+  mode: BackupVerifyMode;
+  ok: boolean;
+  checksum?: string;
+  schema_version?: number;
+  row_counts?: Record<string, number>;
+  problems?: string[];
+}
+
+export interface RestoreDiff {
+  database: string;
+  exists: boolean;
+  files_added?: string[];
+  files_changed?: string[];
+  files_unchanged: number;
+  files_extra?: string[];
+  current_schema_version?: number;
+  backup_schema_version?: number;
+  row_changes?: Record<string, { current: number; backup: number }>;
+}
+
+export interface RestoreBackupResult {
+  message: string;
+  target: string;
+  dry_run: boolean;
+  verification?: BackupVerifyReport;
+  changes?: RestoreDiff;
+  safety_backup?: BackupInfo;
+}
+
+export async function restoreBackup(
+  filename: string,
+  verify: boolean | BackupVerifyMode = true,
+  options: { dryRun?: boolean; safetyBackup?: boolean } = {}
+): Promise<RestoreBackupResult> {
+  const response = await fetch(`${API_BASE}/backup/restore`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({
+      backup_filename: filename,
+      verify,
+      dry_run: options.dryRun ?? false,
+      safety_backup: options.safetyBackup ?? true,
+    }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to restore backup');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function verifyBackup(
+  filename: string,
+  mode: BackupVerifyMode = 'full'
+): Promise<BackupVerifyReport> {
+  const response = await fetch(`${API_BASE}/backup/verify`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ backup_filename: filename, mode }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to verify backup');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function deleteBackup(filename: string): Promise<void> {
+  const response = await fetch(`${API_BASE}/backup/${filename}`, {
+    method: 'DELETE',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to delete backup');
+  }
+}
+
+// Freeze snapshots taken before destructive operations
+export interface FreezeSnapshot {
+  operation_id: string;
+  def_id: string;
+  params?: Record<string, unknown>;
+  created_at: string;
+  backup: BackupInfo;
+  files: number;
+  books: number;
+  scoped: boolean;
+}
+
+export interface SnapshotRestoreResult {
+  operation_id: string;
+  dry_run: boolean;
+  files_unchanged: number;
+  files_moved?: { file_id: string; from: string; to: string; error?: string }[];
+  files_missing?: string[];
+  database?: Omit<RestoreBackupResult, 'message'>;
+  restart_required: boolean;
+}
+
+export async function listSnapshots(): Promise<FreezeSnapshot[]> {
+  const response = await fetch(`${API_BASE}/snapshots`);
   if (!response.ok) {
-    throw await buildApiError(response, 'Failed to create backup');
+    throw await buildApiError(response, 'Failed to list snapshots');
   }
   const body = await response.json();
-  return body.data;
+  return body.data?.snapshots || [];
 }
 
-export async function listBackups(): Promise<BackupListResponse> {
-  const response = await fetch(`${API_BASE}/backup/list`);
+export async function getOperationSnapshot(
+  operationId: string
+): Promise<FreezeSnapshot> {
+  const response = await fetch(
+    `${API_BASE}/operations/${encodeURIComponent(operationId)}/snapshot`
+  );
   if (!response.ok) {
-    throw await buildApiError(response, 'Failed to list backups');
+    throw await buildApiError(response, 'Failed to get snapshot');
   }
   const body = await response.json();
   return body.data;
 }
 
-export async function restoreBackup(filename: string, verify = true): Promise<{ message: string }> {
-  const response = await fetch(`${API_BASE}/backup/restore`, {
-    method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
-    body: JSON.stringify({ backup_filename: filename, verify }),
-  });
+export async function restoreOperationSnapshot(
+  operationId: string,
+  dryRun = false
+): Promise<SnapshotRestoreResult> {
+  const response = await fetch(
+    `${API_BASE}/operations/${encodeURIComponent(operationId)}/snapshot/restore`,
+    {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ dry_run: dryRun }),
+    }
+  );
   if (!response.ok) {
-    throw await buildApiError(response, 'Failed to restore backup');
+    throw await buildApiError(response, 'Failed to restore snapshot');
   }
   const body = await response.json();
   return body.data;
 }
 
-export async function deleteBackup(filename: string): Promise<void> {
-  const response = await fetch(`${API_BASE}/backup/${filename}`, {
-    method: 'DELETE',
-  });
+export async function deleteOperationSnapshot(
+  operationId: string
+): Promise<void> {
+  const response = await fetch(
+    `${API_BASE}/operations/${encodeURIComponent(operationId)}/snapshot`,
+    { method: 'DELETE' }
+  );
   if (!response.ok) {
-    throw await buildApiError(response, 'Failed to delete backup');
+    throw await buildApiError(response, 'Failed to delete snapshot');
   }
 }
 
 // Blocked Hashes Management
 export interface BlockedHash {
   hash: string;
+  algorithm?: string;
   reason: string;
   created_at: string;
 }
@@ -3055,14 +4382,30 @@ export async function getBlockedHashes(): Promise<BlockedHashesResponse> {
   return body.data;
 }
 
+export interface BlockedSignature {
+  id: string;
+  title: string;
+  author: string;
+  duration_sec: number;
+  hash?: string;
+  reason: string;
+  created_at: string;
+}
+
 export async function addBlockedHash(
   hash: string,
-  reason: string
-): Promise<{ message: string; hash: string; reason: string }> {
+  reason: string,
+  options: { blockSignature?: boolean; bookId?: string } = {}
+): Promise<{ message: string; hash: string; reason: string; signature: BlockedSignature | null }> {
   const response = await fetch(`${API_BASE}/blocked-hashes`, {
     method: 'POST',
     headers: { 'Content-Type': 'application/json' },
-    body: JSON.stringify({ hash, reason }),
+    body: JSON.stringify({
+      hash,
+      reason,
+      block_signature: options.blockSignature ?? false,
+      book_id: options.bookId,
+    }),
   });
   if (!response.ok) {
     throw await buildApiError(response, 'Failed to add blocked hash');
@@ -3071,7 +4414,9 @@ export async function addBlockedHash(
   return body.data;
 }
 
-export async function removeBlockedHash(hash: string): Promise<{ message: string; hash: string }> {
+export async function removeBlockedHash(
+  hash: string
+): Promise<{ message: string; hash: string; signatures_removed: number }> {
   const response = await fetch(`${API_BASE}/blocked-hashes/${hash}`, {
     method: 'DELETE',
   });
@@ -3082,6 +4427,26 @@ export async function removeBlockedHash(hash: string): Promise<{ message: string
   return body.data;
 }
 
+export async function getBlockedSignatures(): Promise<{ items: BlockedSignature[]; total: number }> {
+  const response = await fetch(`${API_BASE}/blocked-signatures`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch blocked signatures');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function removeBlockedSignature(id: string): Promise<{ message: string; id: string }> {
+  const response = await fetch(`${API_BASE}/blocked-signatures/${encodeURIComponent(id)}`, {
+    method: 'DELETE',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to remove blocked signature');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 // Metadata History
 export interface MetadataChangeRecord {
   id: number;
@@ -3631,6 +4996,25 @@ export interface UpdateInfo {
   last_checked: string;
 }
 
+export interface SystemVersion {
+  version: string;
+  commit: string;
+  build_date: string;
+  go_version: string;
+  platform: string;
+  update_check_enabled: boolean;
+  update?: UpdateInfo;
+}
+
+export async function getSystemVersion(): Promise<SystemVersion> {
+  const response = await fetch(`${API_BASE}/system/version`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to get version');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export async function getUpdateStatus(): Promise<UpdateInfo> {
   const response = await fetch(`${API_BASE}/update/status`);
   if (!response.ok) {
@@ -3712,9 +5096,21 @@ export interface TaskInfo {
   run_on_startup: boolean;
   run_in_maintenance_window: boolean;
   last_run?: string;
+  last_operation_id?: string;
+  last_error?: string;
   is_running: boolean;
 }
 
+export interface TaskRun {
+  id: string;
+  task: string;
+  source: string;
+  started_at: string;
+  operation_id?: string;
+  error?: string;
+  status?: string;
+}
+
 export async function getRegisteredTasks(): Promise<TaskInfo[]> {
   const response = await fetch(`${API_BASE}/tasks`);
   if (!response.ok) {
@@ -3732,6 +5128,15 @@ export async function runTask(name: string): Promise<Operation | { message: stri
   return response.json();
 }
 
+export async function getTaskHistory(name: string, limit = 20): Promise<TaskRun[]> {
+  const response = await fetch(`${API_BASE}/tasks/${name}/history?limit=${limit}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch task history');
+  }
+  const body = await response.json();
+  return body.data ?? [];
+}
+
 export async function runMaintenanceWindow(): Promise<void> {
   const response = await fetch(`${API_BASE}/maintenance-window/run`, { method: 'POST' });
   if (!response.ok) {
@@ -3758,6 +5163,70 @@ export async function updateTaskConfig(
   }
 }
 
+export interface TaskSchedule {
+  id: string;
+  name: string;
+  task: string;
+  cron: string;
+  enabled: boolean;
+  created_at: string;
+  updated_at: string;
+  last_run_at?: string;
+  last_operation_id?: string;
+  last_error?: string;
+  next_run_at?: string;
+}
+
+export async function getSchedules(): Promise<TaskSchedule[]> {
+  const response = await fetch(`${API_BASE}/schedules`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch schedules');
+  }
+  const body = await response.json();
+  return body?.data ?? [];
+}
+
+export async function createSchedule(schedule: {
+  name?: string;
+  task: string;
+  cron: string;
+  enabled?: boolean;
+}): Promise<TaskSchedule> {
+  const response = await fetch(`${API_BASE}/schedules`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(schedule),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to create schedule');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function updateSchedule(
+  id: string,
+  updates: { name?: string; task?: string; cron?: string; enabled?: boolean }
+): Promise<TaskSchedule> {
+  const response = await fetch(`${API_BASE}/schedules/${id}`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(updates),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to update schedule');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
+export async function deleteSchedule(id: string): Promise<void> {
+  const response = await fetch(`${API_BASE}/schedules/${id}`, { method: 'DELETE' });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to delete schedule');
+  }
+}
+
 export interface MaintenanceWindowStatus {
   enabled: boolean;
   window_start: number;
@@ -4053,6 +5522,44 @@ export async function previewOrganize(bookId: string): Promise<OrganizePreviewRe
   return body.data;
 }
 
+export interface NamingPatternPreview {
+  book_id?: string;
+  title: string;
+  folder?: string;
+  file?: string;
+  path?: string;
+  error?: string;
+}
+
+export interface NamingPatternPreviewResponse {
+  folder_pattern: string;
+  file_pattern: string;
+  previews: NamingPatternPreview[];
+}
+
+// Renders naming patterns against sample books (or the given books)
+// without moving anything. Empty patterns fall back to the saved ones.
+export async function previewNamingPattern(
+  folderPattern: string,
+  filePattern: string,
+  bookIds?: string[]
+): Promise<NamingPatternPreviewResponse> {
+  const response = await fetch(`${API_BASE}/organize/preview-pattern`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({
+      folder_pattern: folderPattern,
+      file_pattern: filePattern,
+      book_ids: bookIds,
+    }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to preview naming pattern');
+  }
+  const body = await response.json();
+  return body.data;
+}
+
 export async function organizeBook(bookId: string): Promise<OrganizeResult> {
   const response = await fetch(`${API_BASE}/audiobooks/${bookId}/organize`, { method: 'POST' });
   if (!response.ok) {
@@ -4174,6 +5681,12 @@ export async function downloadDiagnosticsExport(operationId: string): Promise<Bl
   return response.blob();
 }
 
+// Anonymized diagnostics ZIP for attaching to a bug report; served as an
+// attachment, so link to it rather than fetching.
+export function diagnosticsIssueBundleUrl(): string {
+  return `${API_BASE}/diagnostics/issue-bundle`;
+}
+
 export async function submitDiagnosticsAI(
   category: string,
   description: string
@@ -5443,3 +6956,194 @@ export async function rescoreDedupCandidates(apply = false): Promise<DedupRescor
   const responseData = await response.json();
   return responseData.data;
 }
+
+// ── Collections ───────────────────────────────────────────────────────────────
+
+// A user-curated, ordered shelf of books ("Favorites", "To Listen").
+export interface Collection {
+  id: string;
+  user_id: string;
+  name: string;
+  description?: string;
+  book_ids: string[];
+  created_at: string;
+  updated_at: string;
+}
+
+export async function listCollections(): Promise<Collection[]> {
+  const response = await fetch(`${API_BASE}/collections?limit=500`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to list collections');
+  }
+  const data = await response.json();
+  return data.items || [];
+}
+
+export async function getCollection(id: string): Promise<Collection> {
+  const response = await fetch(`${API_BASE}/collections/${encodeURIComponent(id)}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to load collection');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+export async function createCollection(
+  name: string,
+  description?: string,
+  bookIds?: string[]
+): Promise<Collection> {
+  const response = await fetch(`${API_BASE}/collections`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ name, description, book_ids: bookIds }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to create collection');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+export async function updateCollection(
+  id: string,
+  updates: { name?: string; description?: string }
+): Promise<Collection> {
+  const response = await fetch(`${API_BASE}/collections/${encodeURIComponent(id)}`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(updates),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to update collection');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+export async function deleteCollection(id: string): Promise<void> {
+  const response = await fetch(`${API_BASE}/collections/${encodeURIComponent(id)}`, {
+    method: 'DELETE',
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to delete collection');
+  }
+}
+
+// Adds books at position (0-based), or at the end when position is omitted.
+export async function addBooksToCollection(
+  id: string,
+  bookIds: string[],
+  position?: number
+): Promise<Collection> {
+  const response = await fetch(`${API_BASE}/collections/${encodeURIComponent(id)}/books`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ book_ids: bookIds, position }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to add books to collection');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+export async function removeBookFromCollection(id: string, bookId: string): Promise<Collection> {
+  const response = await fetch(
+    `${API_BASE}/collections/${encodeURIComponent(id)}/books/${encodeURIComponent(bookId)}`,
+    { method: 'DELETE' }
+  );
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to remove book from collection');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+// bookIds must be the collection's current books in their new order.
+export async function reorderCollection(id: string, bookIds: string[]): Promise<Collection> {
+  const response = await fetch(`${API_BASE}/collections/${encodeURIComponent(id)}/reorder`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ book_ids: bookIds }),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to reorder collection');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+// PlaybackProgress is a user's latest position in a book; book_id is the
+// legacy numeric ID, audiobook_id the book's ULID.
+export interface PlaybackProgress {
+  user_id: string;
+  book_id: number;
+  audiobook_id?: string;
+  segment_id?: string;
+  position_seconds: number;
+  percent_complete: number;
+  updated_at: string;
+  title?: string;
+}
+
+export interface PlaybackProgressUpdate {
+  position_seconds: number;
+  segment_id?: string;
+  percent_complete?: number;
+  event_type?: 'progress' | 'start' | 'pause' | 'complete';
+  play_speed?: number;
+  // Time actually listened since the previous report.
+  listened_seconds?: number;
+}
+
+export interface ListeningStats {
+  user_id: string;
+  listen_seconds: number;
+  books_in_progress: number;
+  books_finished: number;
+  books: Array<{
+    audiobook_id: string;
+    title?: string;
+    percent_complete: number;
+    play_count: number;
+    listen_seconds: number;
+  }>;
+}
+
+export async function updatePlaybackProgress(
+  bookId: string,
+  update: PlaybackProgressUpdate
+): Promise<PlaybackProgress> {
+  const response = await fetch(`${API_BASE}/audiobooks/${encodeURIComponent(bookId)}/progress`, {
+    method: 'PUT',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(update),
+  });
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to save playback progress');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+export async function getMyPlaybackProgress(
+  limit = 50,
+  offset = 0
+): Promise<{ progress: PlaybackProgress[]; count: number; total: number }> {
+  const response = await fetch(`${API_BASE}/users/me/progress?limit=${limit}&offset=${offset}`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch playback progress');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
+
+export async function getListeningStats(): Promise<ListeningStats> {
+  const response = await fetch(`${API_BASE}/stats/listening`);
+  if (!response.ok) {
+    throw await buildApiError(response, 'Failed to fetch listening stats');
+  }
+  const responseData = await response.json();
+  return responseData.data;
+}
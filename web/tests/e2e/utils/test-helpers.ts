@@ -1,7 +1,7 @@
 // file: web/tests/e2e/utils/test-helpers.ts
-// version: 2.7.0
+// version: 2.8.0
 // guid: a1b2c3d4-e5f6-7890-abcd-e1f2a3b4c5d6
-// last-edited: 2026-02-15
+// last-edited: 2026-10-17
 
 import { Page } from '@playwright/test';
 
@@ -1183,6 +1183,21 @@ export async function setupMockApiRoutes(
       return route.fulfill(jsonResponse({ error: 'Not found' }, 404));
     }
 
+    if (pathname === '/api/v1/audiobooks/batch-delete' && method === 'POST') {
+      const body = (request.postDataJSON() as { ids?: string[] }) || {};
+      const ids = body.ids || [];
+      return route.fulfill(
+        jsonResponse({
+          data: {
+            results: ids.map((id) => ({ id, success: true })),
+            success: ids.length,
+            failed: 0,
+            total: ids.length,
+          },
+        })
+      );
+    }
+
     if (pathname.startsWith('/api/v1/audiobooks/') && method === 'DELETE') {
       return route.fulfill(jsonResponse({ message: 'Deleted' }));
     }